@@ -1,7 +1,9 @@
 package workload
 
 import (
+	"context"
 	"crypto/x509"
+	"errors"
 	"io/ioutil"
 	"net"
 	"os"
@@ -151,6 +153,22 @@ func (m *mockHandler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload
 	return nil
 }
 
+func (m *mockHandler) FetchX509Bundles(*workload.X509BundlesRequest, workload.SpiffeWorkloadAPI_FetchX509BundlesServer) error {
+	return errors.New("unimplemented")
+}
+
+func (m *mockHandler) FetchJWTSVID(context.Context, *workload.JWTSVIDRequest) (*workload.JWTSVIDResponse, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (m *mockHandler) FetchJWTBundles(*workload.JWTBundlesRequest, workload.SpiffeWorkloadAPI_FetchJWTBundlesServer) error {
+	return errors.New("unimplemented")
+}
+
+func (m *mockHandler) ValidateJWTSVID(context.Context, *workload.ValidateJWTSVIDRequest) (*workload.ValidateJWTSVIDResponse, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (m *mockHandler) resp1() *workload.X509SVIDResponse {
 	svid, key, err := util.LoadSVIDFixture()
 	if err != nil {
@@ -141,6 +141,7 @@ build_protobuf() {
 		# only build the plugin interfaces for plugin protos
 		if [[ ${_n} == "proto/agent/"* ]] ||
 			[[ ${_n} == "proto/server/"* ]] ||
+			[[ ${_n} == "proto/common/metricsink/"* ]] ||
 			[[ ${_n} == "proto/test/"* ]]; then
 			_log_info "creating plugin interface \"${_n%.proto}.go\""
 			protoc --proto_path=${_dir} --proto_path=${GOPATH}/src \
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/k8sregistrar"
+)
+
+const (
+	defaultConfigPath = "conf/k8s-workload-registrar/registrar.conf"
+)
+
+func main() {
+	configPath := flag.String("config", defaultConfigPath, "Path to the registrar config file")
+	flag.Parse()
+
+	if err := run(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("unable to read config: %v", err)
+	}
+	config, err := k8sregistrar.ParseConfig(string(data))
+	if err != nil {
+		return fmt.Errorf("unable to parse config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	logger, err := log.NewLogger(config.LogLevel, config.LogPath)
+	if err != nil {
+		return fmt.Errorf("unable to create logger: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	util.SignalListener(ctx, cancel)
+
+	controller, err := k8sregistrar.New(ctx, config, logger)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Registering the pods with parent ID %s", config.ParentID)
+	return controller.Run(ctx)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/oidcdiscovery"
+)
+
+const (
+	defaultConfigPath = "conf/oidc-discovery-provider/provider.conf"
+)
+
+func main() {
+	configPath := flag.String("config", defaultConfigPath, "Path to the provider config file")
+	flag.Parse()
+
+	if err := run(*configPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string) error {
+	data, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("unable to read config: %v", err)
+	}
+	config, err := oidcdiscovery.ParseConfig(string(data))
+	if err != nil {
+		return fmt.Errorf("unable to parse config: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	logger, err := log.NewLogger(config.LogLevel, config.LogPath)
+	if err != nil {
+		return fmt.Errorf("unable to create logger: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	util.SignalListener(ctx, cancel)
+
+	provider, err := oidcdiscovery.New(ctx, config, logger)
+	if err != nil {
+		return err
+	}
+	return provider.ListenAndServe(ctx)
+}
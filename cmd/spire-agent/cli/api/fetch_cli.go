@@ -12,6 +12,8 @@ import (
 	"path"
 	"time"
 
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/proto/api/workload"
 
 	"google.golang.org/grpc"
@@ -23,6 +25,7 @@ type FetchConfig struct {
 	socketPath string
 	timeout    int
 	writePath  string
+	output     string
 }
 
 type FetchCLI struct {
@@ -38,6 +41,14 @@ func (f FetchCLI) Help() string {
 	return err.Error()
 }
 
+func (f *FetchCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(f.flagSet(&FetchConfig{}))
+}
+
+func (*FetchCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (f *FetchCLI) Run(args []string) int {
 	err := f.parseConfig(args)
 	if err != nil {
@@ -60,7 +71,7 @@ func (f *FetchCLI) Run(args []string) int {
 	}
 
 	if !f.config.silent {
-		printX509SVIDResponse(resp, respTime)
+		printX509SVIDResponse(resp, respTime, f.config.output)
 	}
 
 	if f.config.writePath != "" {
@@ -75,15 +86,22 @@ func (f *FetchCLI) Run(args []string) int {
 }
 
 func (f *FetchCLI) parseConfig(args []string) error {
-	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
 	c := &FetchConfig{}
+	fs := f.flagSet(c)
+
+	f.config = c
+	return fs.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*FetchCLI) flagSet(c *FetchConfig) *flag.FlagSet {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
 	fs.BoolVar(&c.silent, "silent", false, "Suppress stdout")
 	fs.IntVar(&c.timeout, "timeout", 1, "Number of seconds to wait for a response")
 	fs.StringVar(&c.socketPath, "socketPath", "/tmp/agent.sock", "Path to the Workload API socket")
 	fs.StringVar(&c.writePath, "write", "", "Write SVID data to the specified path (optional)")
-
-	f.config = c
-	return fs.Parse(args)
+	cliprinter.AppendFlag(fs, &c.output)
+	return fs
 }
 
 func (f *FetchCLI) fetchX509SVID(c workload.SpiffeWorkloadAPIClient) (*workload.X509SVIDResponse, error) {
@@ -3,12 +3,41 @@ package api
 import (
 	"crypto/x509"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/proto/api/workload"
 )
 
-func printX509SVIDResponse(resp *workload.X509SVIDResponse, respTime time.Duration) {
+// x509SVIDResponseInfo is the response of the Workload API, as printed in
+// JSON. The private keys are left out.
+type x509SVIDResponseInfo struct {
+	ResponseTime string         `json:"response_time"`
+	SVIDs        []x509SVIDInfo `json:"svids"`
+}
+
+type x509SVIDInfo struct {
+	SpiffeID      string     `json:"spiffe_id"`
+	ValidAfter    time.Time  `json:"valid_after"`
+	ValidUntil    time.Time  `json:"valid_until"`
+	Intermediates []certInfo `json:"intermediates"`
+	Bundle        []certInfo `json:"bundle"`
+	Error         string     `json:"error,omitempty"`
+}
+
+type certInfo struct {
+	ValidAfter time.Time `json:"valid_after"`
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+func printX509SVIDResponse(resp *workload.X509SVIDResponse, respTime time.Duration, output string) {
+	if output == cliprinter.JSON {
+		printX509SVIDResponseJSON(resp, respTime)
+		return
+	}
+
 	lenMsg := fmt.Sprintf("Received %v bundle", len(resp.Svids))
 	if len(resp.Svids) != 1 {
 		lenMsg = lenMsg + "s"
@@ -31,7 +60,7 @@ func printX509SVID(msg *workload.X509SVID) {
 
 	// Parse SVID and CA bundle. If we encounter an error,
 	// simply print it and return so we can go to the next bundle
-	svid, err := x509.ParseCertificate(msg.X509Svid)
+	svid, intermediates, err := x509svid.ParseChain(msg.X509Svid)
 	if err != nil {
 		fmt.Printf("ERROR: Could not parse SVID: %s\n", err)
 		return
@@ -45,9 +74,58 @@ func printX509SVID(msg *workload.X509SVID) {
 
 	fmt.Printf("SVID Valid After:\t%v\n", svid.NotBefore)
 	fmt.Printf("SVID Valid Until:\t%v\n", svid.NotAfter)
+	for i, intermediate := range intermediates {
+		num := i + 1
+		fmt.Printf("Intermediate #%v Valid After:\t%v\n", num, intermediate.NotBefore)
+		fmt.Printf("Intermediate #%v Valid Until:\t%v\n", num, intermediate.NotAfter)
+	}
 	for i, ca := range svidBundle {
 		num := i + 1
 		fmt.Printf("CA #%v Valid After:\t%v\n", num, ca.NotBefore)
 		fmt.Printf("CA #%v Valid Until:\t%v\n", num, ca.NotAfter)
 	}
 }
+
+func printX509SVIDResponseJSON(resp *workload.X509SVIDResponse, respTime time.Duration) {
+	info := &x509SVIDResponseInfo{
+		ResponseTime: respTime.String(),
+		SVIDs:        []x509SVIDInfo{},
+	}
+	for _, s := range resp.Svids {
+		info.SVIDs = append(info.SVIDs, newX509SVIDInfo(s))
+	}
+
+	if err := cliprinter.PrintJSON(os.Stdout, info); err != nil {
+		fmt.Printf("ERROR: Could not print response: %s\n", err)
+	}
+}
+
+func newX509SVIDInfo(msg *workload.X509SVID) x509SVIDInfo {
+	info := x509SVIDInfo{
+		SpiffeID:      msg.SpiffeId,
+		Intermediates: []certInfo{},
+		Bundle:        []certInfo{},
+	}
+
+	svid, intermediates, err := x509svid.ParseChain(msg.X509Svid)
+	if err != nil {
+		info.Error = fmt.Sprintf("could not parse SVID: %s", err)
+		return info
+	}
+
+	svidBundle, err := x509.ParseCertificates(msg.Bundle)
+	if err != nil {
+		info.Error = fmt.Sprintf("could not parse CA certificates: %s", err)
+		return info
+	}
+
+	info.ValidAfter = svid.NotBefore
+	info.ValidUntil = svid.NotAfter
+	for _, intermediate := range intermediates {
+		info.Intermediates = append(info.Intermediates, certInfo{ValidAfter: intermediate.NotBefore, ValidUntil: intermediate.NotAfter})
+	}
+	for _, ca := range svidBundle {
+		info.Bundle = append(info.Bundle, certInfo{ValidAfter: ca.NotBefore, ValidUntil: ca.NotAfter})
+	}
+	return info
+}
@@ -10,11 +10,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/posener/complete"
 	"github.com/spiffe/spire/api/workload"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
 )
 
 type WatchConfig struct {
 	socketPath string
+	output     string
 }
 
 type WatchCLI struct {
@@ -32,6 +35,14 @@ func (w WatchCLI) Help() string {
 	return err.Error()
 }
 
+func (w *WatchCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(w.flagSet(&WatchConfig{}))
+}
+
+func (*WatchCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (w *WatchCLI) Run(args []string) int {
 	err := w.parseConfig(args)
 	if err != nil {
@@ -53,21 +64,28 @@ func (w *WatchCLI) Run(args []string) int {
 			fmt.Println(err)
 			return 1
 		case u := <-client.UpdateChan():
-			printX509SVIDResponse(u, time.Since(updateTime))
+			printX509SVIDResponse(u, time.Since(updateTime), w.config.output)
 			updateTime = time.Now()
 		}
 	}
 }
 
 func (w *WatchCLI) parseConfig(args []string) error {
-	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
 	c := &WatchConfig{}
-	fs.StringVar(&c.socketPath, "socketPath", "/tmp/agent.sock", "Path to the Workload API socket")
+	fs := w.flagSet(c)
 
 	w.config = c
 	return fs.Parse(args)
 }
 
+// flagSet defines the flags of the command, storing their values in c
+func (*WatchCLI) flagSet(c *WatchConfig) *flag.FlagSet {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.StringVar(&c.socketPath, "socketPath", "/tmp/agent.sock", "Path to the Workload API socket")
+	cliprinter.AppendFlag(fs, &c.output)
+	return fs
+}
+
 func (w *WatchCLI) startClient() (workload.X509Client, chan error) {
 	addr := &net.UnixAddr{
 		Net:  "unix",
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/pkg/agent/debug"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+)
+
+type ListCLI struct{}
+
+type listConfig struct {
+	// Location of the debug API socket of the agent
+	SocketPath string
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+
+	// How long to wait for the agent to answer
+	Timeout time.Duration
+}
+
+func (ListCLI) Synopsis() string {
+	return "Lists the registration entries cached by the agent"
+}
+
+func (l ListCLI) Help() string {
+	_, err := l.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (l ListCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(l.flagSet(&listConfig{}))
+}
+
+func (ListCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (l ListCLI) Run(args []string) int {
+	config, err := l.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := l.fetch(config)
+	if err != nil {
+		fmt.Printf("Could not describe the agent cache: %v\n", err)
+		return 1
+	}
+
+	if config.Output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(os.Stdout, resp); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	printCache(os.Stdout, resp)
+	return 0
+}
+
+// fetch asks the debug API of the agent for its cache
+func (ListCLI) fetch(config *listConfig) (*debug.CacheResponse, error) {
+	client := &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", config.SocketPath)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://agent" + debug.CachePath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(body)))
+	}
+
+	cache := new(debug.CacheResponse)
+	if err := json.NewDecoder(resp.Body).Decode(cache); err != nil {
+		return nil, fmt.Errorf("decode response: %v", err)
+	}
+	return cache, nil
+}
+
+func (l ListCLI) newConfig(args []string) (*listConfig, error) {
+	c := &listConfig{}
+	f := l.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (ListCLI) flagSet(c *listConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("cache list", flag.ContinueOnError)
+	f.StringVar(&c.SocketPath, "debugSocketPath", debug.DefaultSocketPath, "Location of the debug API socket of the agent")
+	cliprinter.AppendFlag(f, &c.Output)
+	f.DurationVar(&c.Timeout, "timeout", 5*time.Second, "How long to wait for the agent to answer")
+	return f
+}
+
+func printCache(w io.Writer, resp *debug.CacheResponse) {
+	if resp.LastSync.IsZero() {
+		fmt.Fprintln(w, "Last sync:\t\tnever")
+	} else {
+		fmt.Fprintf(w, "Last sync:\t\t%v\n", resp.LastSync)
+	}
+	if resp.AgentSVID != nil {
+		fmt.Fprintf(w, "Agent SPIFFE ID:\t%s\n", resp.AgentSVID.SPIFFEID)
+		fmt.Fprintf(w, "Agent SVID Valid Until:\t%v\n", resp.AgentSVID.ExpiresAt)
+	}
+
+	msg := fmt.Sprintf("Found %v cached entr", len(resp.Entries))
+	if len(resp.Entries) == 1 {
+		msg += "y"
+	} else {
+		msg += "ies"
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, msg)
+
+	for _, e := range resp.Entries {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Entry ID:\t\t%s\n", e.EntryID)
+		fmt.Fprintf(w, "SPIFFE ID:\t\t%s\n", e.SPIFFEID)
+		fmt.Fprintf(w, "Parent ID:\t\t%s\n", e.ParentID)
+		for _, s := range e.Selectors {
+			fmt.Fprintf(w, "Selector:\t\t%s\n", s)
+		}
+		for _, td := range e.FederatesWith {
+			fmt.Fprintf(w, "Federates With:\t\t%s\n", td)
+		}
+		if e.SVIDExpiresAt.IsZero() {
+			fmt.Fprintln(w, "SVID Valid Until:\tnot issued")
+		} else {
+			fmt.Fprintf(w, "SVID Valid Until:\t%v\n", e.SVIDExpiresAt)
+		}
+	}
+}
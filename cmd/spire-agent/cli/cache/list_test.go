@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/debug"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache-list-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := path.Join(dir, "debug.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer l.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(debug.CachePath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&debug.CacheResponse{
+			Entries: []debug.Entry{{EntryID: "1"}},
+		})
+	})
+	go http.Serve(l, mux)
+
+	resp, err := ListCLI{}.fetch(&listConfig{SocketPath: socketPath, Timeout: time.Second})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "1", resp.Entries[0].EntryID)
+
+	_, err = ListCLI{}.fetch(&listConfig{SocketPath: path.Join(dir, "missing.sock"), Timeout: time.Second})
+	assert.Error(t, err)
+}
+
+func TestPrintCache(t *testing.T) {
+	expiresAt := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	buf := new(bytes.Buffer)
+	printCache(buf, &debug.CacheResponse{
+		Entries: []debug.Entry{
+			{
+				EntryID:   "1",
+				SPIFFEID:  "spiffe://example.org/foo",
+				ParentID:  "spiffe://example.org/spire/agent/test",
+				Selectors: []string{"unix:uid:1000"},
+			},
+			{
+				EntryID:       "2",
+				SPIFFEID:      "spiffe://example.org/bar",
+				ParentID:      "spiffe://example.org/spire/agent/test",
+				Selectors:     []string{"unix:uid:1001"},
+				SVIDExpiresAt: expiresAt,
+			},
+		},
+	})
+
+	assert.Equal(t, `Last sync:		never
+
+Found 2 cached entries
+
+Entry ID:		1
+SPIFFE ID:		spiffe://example.org/foo
+Parent ID:		spiffe://example.org/spire/agent/test
+Selector:		unix:uid:1000
+SVID Valid Until:	not issued
+
+Entry ID:		2
+SPIFFE ID:		spiffe://example.org/bar
+Parent ID:		spiffe://example.org/spire/agent/test
+Selector:		unix:uid:1001
+SVID Valid Until:	2018-07-01 00:00:00 +0000 UTC
+`, buf.String())
+}
+
+func TestNewConfig(t *testing.T) {
+	c, err := ListCLI{}.newConfig([]string{"-debugSocketPath", "/tmp/debug.sock"})
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/debug.sock", c.SocketPath)
+	assert.Equal(t, cliprinter.Pretty, c.Output)
+	assert.Equal(t, 5*time.Second, c.Timeout)
+
+	c, err = ListCLI{}.newConfig([]string{"-output", "json"})
+	require.NoError(t, err)
+	assert.Equal(t, cliprinter.JSON, c.Output)
+}
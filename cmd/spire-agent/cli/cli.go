@@ -5,7 +5,10 @@ import (
 
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/api"
+	"github.com/spiffe/spire/cmd/spire-agent/cli/cache"
+	"github.com/spiffe/spire/cmd/spire-agent/cli/healthcheck"
 	"github.com/spiffe/spire/cmd/spire-agent/cli/run"
+	"github.com/spiffe/spire/cmd/spire-agent/cli/svid"
 	"github.com/spiffe/spire/pkg/common/version"
 )
 
@@ -19,9 +22,18 @@ func Run(args []string) int {
 		"api watch": func() (cli.Command, error) {
 			return &api.WatchCLI{}, nil
 		},
+		"cache list": func() (cli.Command, error) {
+			return &cache.ListCLI{}, nil
+		},
+		"healthcheck": func() (cli.Command, error) {
+			return healthcheck.NewHealthCheckCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return &run.RunCLI{}, nil
 		},
+		"svid rotate": func() (cli.Command, error) {
+			return &svid.RotateCLI{}, nil
+		},
 	}
 
 	exitStatus, err := c.Run()
@@ -0,0 +1,18 @@
+package healthcheck
+
+import (
+	"github.com/mitchellh/cli"
+	agent_health "github.com/spiffe/spire/pkg/agent/health"
+	"github.com/spiffe/spire/pkg/common/health"
+)
+
+// NewHealthCheckCommand returns the command checking the health of a running
+// agent, through its health check endpoint or its debug API socket
+func NewHealthCheckCommand() cli.Command {
+	return &health.CLI{
+		Name:           "agent",
+		DefaultAddress: agent_health.DefaultBindAddress,
+		SocketFlag:     "debugSocketPath",
+		SocketAPI:      "debug API",
+	}
+}
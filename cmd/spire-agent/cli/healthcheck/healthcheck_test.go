@@ -0,0 +1,14 @@
+package healthcheck
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHealthCheckCommand(t *testing.T) {
+	cmd := NewHealthCheckCommand()
+	assert.Equal(t, "Determines agent health status", cmd.Synopsis())
+	assert.Contains(t, cmd.(*health.CLI).AutocompleteFlags(), "-debugSocketPath")
+}
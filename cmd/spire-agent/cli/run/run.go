@@ -2,7 +2,9 @@ package run
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"flag"
@@ -13,10 +15,16 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl"
+	"github.com/posener/complete"
 	"github.com/spiffe/spire/pkg/agent"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/common/config"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/util"
 )
@@ -30,6 +38,17 @@ const (
 	defaultDataDir  = "."
 	defaultLogLevel = "INFO"
 	defaultUmask    = 0077
+
+	// Bounds of the sync interval and rotation threshold, which keep the
+	// load on the server and the renewal of SVIDs reasonable
+	minSyncInterval      = time.Second
+	maxSyncInterval      = time.Hour
+	minRotationThreshold = 10
+	maxRotationThreshold = 90
+
+	// SVID minting policies
+	svidMintingEager = "eager"
+	svidMintingLazy  = "lazy"
 )
 
 // RunConfig represents the available configurables for file
@@ -40,24 +59,66 @@ type runConfig struct {
 }
 
 type agentConfig struct {
-	ServerAddress   string `hcl:"server_address"`
-	ServerPort      int    `hcl:"server_port"`
-	TrustDomain     string `hcl:"trust_domain"`
-	TrustBundlePath string `hcl:"trust_bundle_path"`
-	JoinToken       string `hcl:"join_token"`
-
-	SocketPath string `hcl:"socket_path"`
-	DataDir    string `hcl:"data_dir"`
-	LogFile    string `hcl:"log_file"`
-	LogLevel   string `hcl:"log_level"`
+	ServerAddress     string `hcl:"server_address"`
+	ServerPort        int    `hcl:"server_port"`
+	TrustDomain       string `hcl:"trust_domain"`
+	TrustBundlePath   string `hcl:"trust_bundle_path"`
+	TrustBundleURL    string `hcl:"trust_bundle_url"`
+	TrustBundleSHA256 string `hcl:"trust_bundle_sha256"`
+	JoinToken         string `hcl:"join_token"`
+
+	SocketPath            string   `hcl:"socket_path"`
+	AdditionalSocketPaths []string `hcl:"additional_socket_paths"`
+	TCPAddress            string   `hcl:"workload_api_tcp_address"`
+	DataDir               string   `hcl:"data_dir"`
+	EntryCacheKeyPath     string   `hcl:"entry_cache_key_path"`
+	LogFile               string   `hcl:"log_file"`
+	LogLevel              string   `hcl:"log_level"`
+	LogFormat             string   `hcl:"log_format"`
+
+	SubsystemLogLevels map[string]string `hcl:"subsystem_log_levels"`
 
 	ConfigPath string
 	Umask      string `hcl:"umask"`
 
+	SyncInterval         int `hcl:"sync_interval"`
+	RotationThreshold    int `hcl:"rotation_threshold"`
+	ShutdownDrainTimeout int `hcl:"shutdown_drain_timeout"`
+	MaxSVIDStaleness     int `hcl:"max_svid_staleness"`
+
+	SVIDMintingPolicy string `hcl:"svid_minting_policy"`
+	WatchUpdates      bool   `hcl:"watch_updates"`
+
+	RequirePluginChecksum bool `hcl:"require_plugin_checksum"`
+
+	PrometheusBindAddress string   `hcl:"prometheus_bind_address"`
+	StatsdAddress         string   `hcl:"statsd_address"`
+	StatsdPrefix          string   `hcl:"statsd_prefix"`
+	StatsdDogStatsD       bool     `hcl:"statsd_dogstatsd"`
+	StatsdAllowedLabels   []string `hcl:"statsd_allowed_labels"`
+	ExpiringSVIDThreshold int      `hcl:"expiring_svid_threshold"`
+	DebugSocketPath       string   `hcl:"debug_socket_path"`
+
+	DelegatedIdentitySocketPath string   `hcl:"delegated_identity_socket_path"`
+	AuthorizedDelegates         []string `hcl:"authorized_delegates"`
+
 	ProfilingEnabled bool     `hcl:"profiling_enabled"`
 	ProfilingPort    int      `hcl:"profiling_port"`
 	ProfilingFreq    int      `hcl:"profiling_freq"`
 	ProfilingNames   []string `hcl:"profiling_names"`
+
+	SDSDefaultSVIDName             string `hcl:"sds_default_svid_name"`
+	SDSDefaultBundleName           string `hcl:"sds_default_bundle_name"`
+	SDSDisableSPIFFECertValidation bool   `hcl:"sds_disable_spiffe_cert_validation"`
+
+	HealthCheckEnabled        bool   `hcl:"health_check_enabled"`
+	HealthCheckBindAddress    string `hcl:"health_check_bind_address"`
+	HealthCheckMaxMissedSyncs int    `hcl:"health_check_max_missed_syncs"`
+
+	WorkloadAPIMaxStreamsPerPID int `hcl:"workload_api_max_streams_per_pid"`
+	WorkloadAPIMaxStreamsPerUID int `hcl:"workload_api_max_streams_per_uid"`
+	WorkloadAPIAttestationRate  int `hcl:"workload_api_attestation_rate"`
+	WorkloadAPIAttestationBurst int `hcl:"workload_api_attestation_burst"`
 }
 
 type RunCLI struct {
@@ -68,6 +129,14 @@ func (*RunCLI) Help() string {
 	return err.Error()
 }
 
+func (*RunCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(flagSet(&runConfig{}))
+}
+
+func (*RunCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (*RunCLI) Run(args []string) int {
 	cliConfig, err := parseFlags(args)
 	if err != nil {
@@ -94,6 +163,7 @@ func (*RunCLI) Run(args []string) int {
 	err = validateConfig(c)
 	if err != nil {
 		fmt.Println(err.Error())
+		return 1
 	}
 
 	agt := agent.New(c)
@@ -101,6 +171,11 @@ func (*RunCLI) Run(args []string) int {
 	defer cancel()
 	util.SignalListener(ctx, cancel)
 
+	// Let the log file be rotated
+	if logger, ok := c.Log.(*log.Logger); ok {
+		logger.ReopenOnSignal(ctx)
+	}
+
 	err = agt.Run(ctx)
 	if err != nil {
 		c.Log.Errorf("agent crashed: %v", err)
@@ -137,6 +212,9 @@ func parseFile(filePath string) (*runConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := config.Expand(hclTree); err != nil {
+		return nil, fmt.Errorf("unable to expand config: %v", err)
+	}
 	if err := hcl.DecodeObject(&c, hclTree); err != nil {
 		return nil, err
 	}
@@ -145,28 +223,36 @@ func parseFile(filePath string) (*runConfig, error) {
 }
 
 func parseFlags(args []string) (*runConfig, error) {
-	flags := flag.NewFlagSet("run", flag.ContinueOnError)
 	c := &runConfig{}
+	flags := flagSet(c)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
 
+// flagSet defines the flags of the run command, storing their values in c
+func flagSet(c *runConfig) *flag.FlagSet {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
 	flags.StringVar(&c.AgentConfig.ServerAddress, "serverAddress", "", "IP address or DNS name of the SPIRE server")
 	flags.IntVar(&c.AgentConfig.ServerPort, "serverPort", 0, "Port number of the SPIRE server")
 	flags.StringVar(&c.AgentConfig.TrustDomain, "trustDomain", "", "The trust domain that this agent belongs to")
 	flags.StringVar(&c.AgentConfig.TrustBundlePath, "trustBundle", "", "Path to the SPIRE server CA bundle")
+	flags.StringVar(&c.AgentConfig.TrustBundleURL, "trustBundleURL", "", "HTTPS URL to download the SPIRE server CA bundle from")
 	flags.StringVar(&c.AgentConfig.JoinToken, "joinToken", "", "An optional token which has been generated by the SPIRE server")
 	flags.StringVar(&c.AgentConfig.SocketPath, "socketPath", "", "Location to bind the workload API socket")
+	flags.StringVar(&c.AgentConfig.TCPAddress, "workloadAPITCPAddress", "", "Loopback TCP address to bind the workload API to instead of the socket (Windows)")
 	flags.StringVar(&c.AgentConfig.DataDir, "dataDir", "", "A directory the agent can use for its runtime data")
 	flags.StringVar(&c.AgentConfig.LogFile, "logFile", "", "File to write logs to")
 	flags.StringVar(&c.AgentConfig.LogLevel, "logLevel", "", "DEBUG, INFO, WARN or ERROR")
+	flags.StringVar(&c.AgentConfig.LogFormat, "logFormat", "", "text or json")
 
 	flags.StringVar(&c.AgentConfig.ConfigPath, "config", defaultConfigPath, "Path to a SPIRE config file")
 	flags.StringVar(&c.AgentConfig.Umask, "umask", "", "Umask value to use for new files")
-
-	err := flags.Parse(args)
-	if err != nil {
-		return nil, err
-	}
-
-	return c, nil
+	return flags
 }
 
 func mergeConfigs(c *agent.Config, fileConfig, cliConfig *runConfig) error {
@@ -218,6 +304,14 @@ func mergeConfig(orig *agent.Config, cmd *runConfig) error {
 		orig.TrustBundle = bundle
 	}
 
+	if cmd.AgentConfig.TrustBundleURL != "" {
+		orig.TrustBundleURL = cmd.AgentConfig.TrustBundleURL
+	}
+
+	if cmd.AgentConfig.TrustBundleSHA256 != "" {
+		orig.TrustBundleSHA256 = cmd.AgentConfig.TrustBundleSHA256
+	}
+
 	if cmd.AgentConfig.JoinToken != "" {
 		orig.JoinToken = cmd.AgentConfig.JoinToken
 	}
@@ -226,23 +320,46 @@ func mergeConfig(orig *agent.Config, cmd *runConfig) error {
 		orig.BindAddress.Name = cmd.AgentConfig.SocketPath
 	}
 
+	for _, socketPath := range cmd.AgentConfig.AdditionalSocketPaths {
+		orig.AdditionalBindAddresses = append(orig.AdditionalBindAddresses, &net.UnixAddr{Name: socketPath, Net: "unix"})
+	}
+
+	if cmd.AgentConfig.TCPAddress != "" {
+		addr, err := net.ResolveTCPAddr("tcp", cmd.AgentConfig.TCPAddress)
+		if err != nil {
+			return fmt.Errorf("Could not resolve workload API TCP address %s: %s", cmd.AgentConfig.TCPAddress, err)
+		}
+		orig.TCPBindAddress = addr
+	}
+
 	if cmd.AgentConfig.DataDir != "" {
 		orig.DataDir = cmd.AgentConfig.DataDir
 	}
 
-	// Handle log file and level
-	if cmd.AgentConfig.LogFile != "" || cmd.AgentConfig.LogLevel != "" {
+	if cmd.AgentConfig.EntryCacheKeyPath != "" {
+		orig.EntryCacheKeyPath = cmd.AgentConfig.EntryCacheKeyPath
+	}
+
+	// Handle log file, level and format
+	if cmd.AgentConfig.LogFile != "" || cmd.AgentConfig.LogLevel != "" ||
+		cmd.AgentConfig.LogFormat != "" || cmd.AgentConfig.SubsystemLogLevels != nil {
 		logLevel := defaultLogLevel
 		if cmd.AgentConfig.LogLevel != "" {
 			logLevel = cmd.AgentConfig.LogLevel
 		}
 
-		logger, err := log.NewLogger(logLevel, cmd.AgentConfig.LogFile)
+		logger, err := log.New(log.Config{
+			Level:           logLevel,
+			SubsystemLevels: cmd.AgentConfig.SubsystemLogLevels,
+			Format:          cmd.AgentConfig.LogFormat,
+			File:            cmd.AgentConfig.LogFile,
+		})
 		if err != nil {
-			return fmt.Errorf("Could not open log file %s: %s", cmd.AgentConfig.LogFile, err)
+			return fmt.Errorf("Could not set up logging: %s", err)
 		}
 
 		orig.Log = logger
+		orig.LogLevels = logger.Levels
 	}
 
 	if cmd.AgentConfig.Umask != "" {
@@ -253,6 +370,116 @@ func mergeConfig(orig *agent.Config, cmd *runConfig) error {
 		orig.Umask = int(umask)
 	}
 
+	if cmd.AgentConfig.SyncInterval != 0 {
+		orig.SyncInterval = time.Duration(cmd.AgentConfig.SyncInterval) * time.Second
+	}
+
+	if cmd.AgentConfig.RotationThreshold != 0 {
+		orig.RotationThreshold = cmd.AgentConfig.RotationThreshold
+	}
+
+	if cmd.AgentConfig.ShutdownDrainTimeout != 0 {
+		orig.ShutdownDrainTimeout = time.Duration(cmd.AgentConfig.ShutdownDrainTimeout) * time.Second
+	}
+
+	if cmd.AgentConfig.MaxSVIDStaleness != 0 {
+		orig.MaxSVIDStaleness = time.Duration(cmd.AgentConfig.MaxSVIDStaleness) * time.Second
+	}
+
+	if cmd.AgentConfig.ExpiringSVIDThreshold != 0 {
+		orig.ExpiringSVIDThreshold = time.Duration(cmd.AgentConfig.ExpiringSVIDThreshold) * time.Second
+	}
+
+	switch cmd.AgentConfig.SVIDMintingPolicy {
+	case "":
+	case svidMintingEager:
+		orig.LazySVIDMinting = false
+	case svidMintingLazy:
+		orig.LazySVIDMinting = true
+	default:
+		return fmt.Errorf("Unknown SVID minting policy %q, must be %q or %q", cmd.AgentConfig.SVIDMintingPolicy, svidMintingEager, svidMintingLazy)
+	}
+
+	if cmd.AgentConfig.WatchUpdates {
+		orig.WatchUpdates = cmd.AgentConfig.WatchUpdates
+	}
+
+	if cmd.AgentConfig.RequirePluginChecksum {
+		orig.RequirePluginChecksum = cmd.AgentConfig.RequirePluginChecksum
+	}
+
+	if cmd.AgentConfig.SDSDefaultSVIDName != "" {
+		orig.SDS.DefaultSVIDName = cmd.AgentConfig.SDSDefaultSVIDName
+	}
+
+	if cmd.AgentConfig.SDSDefaultBundleName != "" {
+		orig.SDS.DefaultBundleName = cmd.AgentConfig.SDSDefaultBundleName
+	}
+
+	if cmd.AgentConfig.SDSDisableSPIFFECertValidation {
+		orig.SDS.DisableSPIFFECertValidation = cmd.AgentConfig.SDSDisableSPIFFECertValidation
+	}
+
+	if cmd.AgentConfig.HealthCheckEnabled {
+		orig.HealthCheck.Enabled = cmd.AgentConfig.HealthCheckEnabled
+	}
+
+	if cmd.AgentConfig.HealthCheckBindAddress != "" {
+		orig.HealthCheck.BindAddress = cmd.AgentConfig.HealthCheckBindAddress
+	}
+
+	if cmd.AgentConfig.HealthCheckMaxMissedSyncs > 0 {
+		orig.HealthCheck.MaxMissedSyncs = cmd.AgentConfig.HealthCheckMaxMissedSyncs
+	}
+
+	if cmd.AgentConfig.WorkloadAPIMaxStreamsPerPID != 0 {
+		orig.WorkloadAPILimits.MaxStreamsPerPID = cmd.AgentConfig.WorkloadAPIMaxStreamsPerPID
+	}
+
+	if cmd.AgentConfig.WorkloadAPIMaxStreamsPerUID != 0 {
+		orig.WorkloadAPILimits.MaxStreamsPerUID = cmd.AgentConfig.WorkloadAPIMaxStreamsPerUID
+	}
+
+	if cmd.AgentConfig.WorkloadAPIAttestationRate != 0 {
+		orig.WorkloadAPILimits.AttestationRate = cmd.AgentConfig.WorkloadAPIAttestationRate
+	}
+
+	if cmd.AgentConfig.WorkloadAPIAttestationBurst != 0 {
+		orig.WorkloadAPILimits.AttestationBurst = cmd.AgentConfig.WorkloadAPIAttestationBurst
+	}
+
+	if cmd.AgentConfig.PrometheusBindAddress != "" {
+		orig.PrometheusBindAddress = cmd.AgentConfig.PrometheusBindAddress
+	}
+
+	if cmd.AgentConfig.StatsdAddress != "" {
+		orig.Statsd.Address = cmd.AgentConfig.StatsdAddress
+	}
+
+	if cmd.AgentConfig.StatsdPrefix != "" {
+		orig.Statsd.Prefix = cmd.AgentConfig.StatsdPrefix
+	}
+
+	if cmd.AgentConfig.StatsdDogStatsD {
+		orig.Statsd.DogStatsD = cmd.AgentConfig.StatsdDogStatsD
+	}
+
+	if cmd.AgentConfig.StatsdAllowedLabels != nil {
+		orig.Statsd.AllowedLabels = cmd.AgentConfig.StatsdAllowedLabels
+	}
+
+	if cmd.AgentConfig.DebugSocketPath != "" {
+		orig.DebugSocketPath = cmd.AgentConfig.DebugSocketPath
+	}
+
+	if cmd.AgentConfig.DelegatedIdentitySocketPath != "" {
+		orig.DelegatedIdentity.SocketPath = cmd.AgentConfig.DelegatedIdentitySocketPath
+	}
+
+	if len(cmd.AgentConfig.AuthorizedDelegates) > 0 {
+		orig.DelegatedIdentity.AuthorizedDelegates = cmd.AgentConfig.AuthorizedDelegates
+	}
+
 	if cmd.AgentConfig.ProfilingEnabled {
 		orig.ProfilingEnabled = cmd.AgentConfig.ProfilingEnabled
 	}
@@ -282,8 +509,92 @@ func validateConfig(c *agent.Config) error {
 		return errors.New("TrustDomain is required")
 	}
 
-	if c.TrustBundle == nil {
-		return errors.New("TrustBundle is required")
+	if c.TrustBundle == nil && c.TrustBundleURL == "" {
+		return errors.New("TrustBundle or TrustBundleURL is required")
+	}
+
+	if c.TrustBundle != nil && c.TrustBundleURL != "" {
+		return errors.New("TrustBundle and TrustBundleURL are mutually exclusive")
+	}
+
+	if c.TrustBundleURL != "" {
+		u, err := url.Parse(c.TrustBundleURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("TrustBundleURL must be an HTTPS URL")
+		}
+	}
+
+	if c.TrustBundleSHA256 != "" {
+		if c.TrustBundleURL == "" {
+			return errors.New("TrustBundleSHA256 requires TrustBundleURL")
+		}
+		if hash, err := hex.DecodeString(c.TrustBundleSHA256); err != nil || len(hash) != sha256.Size {
+			return errors.New("TrustBundleSHA256 must be a hex encoded SHA-256 hash")
+		}
+	}
+
+	if c.EntryCacheKeyPath != "" && isUnderDir(c.EntryCacheKeyPath, c.DataDir) {
+		return errors.New("Entry cache key path must not be under the data dir, next to the cache it encrypts")
+	}
+
+	socketPaths := map[string]bool{c.BindAddress.Name: true}
+	for _, addr := range c.AdditionalBindAddresses {
+		if socketPaths[addr.Name] {
+			return fmt.Errorf("Socket path %s is configured more than once", addr.Name)
+		}
+		socketPaths[addr.Name] = true
+	}
+
+	if c.DebugSocketPath != "" && socketPaths[c.DebugSocketPath] {
+		return errors.New("Debug socket path must differ from the workload API socket paths")
+	}
+
+	if c.DelegatedIdentity.SocketPath != "" {
+		if socketPaths[c.DelegatedIdentity.SocketPath] || c.DelegatedIdentity.SocketPath == c.DebugSocketPath {
+			return errors.New("Delegated identity socket path must differ from the workload and debug API socket paths")
+		}
+		if len(c.DelegatedIdentity.AuthorizedDelegates) == 0 {
+			return errors.New("AuthorizedDelegates is required to serve the delegated identity API")
+		}
+	}
+
+	for _, spiffeID := range c.DelegatedIdentity.AuthorizedDelegates {
+		if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload()); err != nil {
+			return fmt.Errorf("Invalid authorized delegate %q: %v", spiffeID, err)
+		}
+	}
+
+	if c.TCPBindAddress != nil && !c.TCPBindAddress.IP.IsLoopback() {
+		return errors.New("Workload API TCP address must be a loopback address")
+	}
+
+	if c.SyncInterval != 0 && (c.SyncInterval < minSyncInterval || c.SyncInterval > maxSyncInterval) {
+		return fmt.Errorf("SyncInterval must be between %v and %v", minSyncInterval, maxSyncInterval)
+	}
+
+	if c.RotationThreshold != 0 && (c.RotationThreshold < minRotationThreshold || c.RotationThreshold > maxRotationThreshold) {
+		return fmt.Errorf("RotationThreshold must be between %d and %d percent", minRotationThreshold, maxRotationThreshold)
+	}
+
+	if c.ShutdownDrainTimeout < 0 {
+		return errors.New("ShutdownDrainTimeout cannot be negative")
+	}
+
+	if c.MaxSVIDStaleness < 0 {
+		return errors.New("MaxSVIDStaleness cannot be negative")
+	}
+
+	if c.ExpiringSVIDThreshold < 0 {
+		return errors.New("ExpiringSVIDThreshold cannot be negative")
+	}
+
+	limits := c.WorkloadAPILimits
+	if limits.MaxStreamsPerPID < 0 || limits.MaxStreamsPerUID < 0 || limits.AttestationRate < 0 || limits.AttestationBurst < 0 {
+		return errors.New("Workload API limits cannot be negative")
+	}
+
+	if limits.AttestationBurst != 0 && limits.AttestationRate == 0 {
+		return errors.New("Workload API attestation burst requires an attestation rate")
 	}
 
 	return nil
@@ -300,11 +611,26 @@ func newDefaultConfig() *agent.Config {
 		BindAddress:   bindAddr,
 		DataDir:       defaultDataDir,
 		Log:           logger,
+		LogLevels:     logger.Levels,
 		ServerAddress: serverAddress,
 		Umask:         defaultUmask,
 	}
 }
 
+// isUnderDir returns true if the given path is within the given directory
+func isUnderDir(path, dir string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 func parseTrustBundle(path string) ([]*x509.Certificate, error) {
 	pemData, err := ioutil.ReadFile(path)
 	if err != nil {
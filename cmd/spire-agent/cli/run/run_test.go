@@ -2,9 +2,16 @@ package run
 
 import (
 	"bytes"
+	"crypto/x509"
+	"net"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +38,8 @@ func TestParseConfigGood(t *testing.T) {
 	assert.Equal(t, c.PluginConfigs["plugin_type_agent"]["plugin_name_agent"].Enabled, true)
 	assert.Equal(t, c.PluginConfigs["plugin_type_agent"]["plugin_name_agent"].PluginChecksum, "pluginAgentChecksum")
 	assert.Equal(t, c.PluginConfigs["plugin_type_agent"]["plugin_name_agent"].PluginCmd, "./pluginAgentCmd")
+	assert.Equal(t, []string{"-verbose"}, c.PluginConfigs["plugin_type_agent"]["plugin_name_agent"].PluginArgs)
+	assert.Equal(t, map[string]string{"PLUGIN_AGENT_TOKEN": "NOT-A-SECRET"}, c.PluginConfigs["plugin_type_agent"]["plugin_name_agent"].PluginEnv)
 	assert.Equal(t, expectedData, data.String())
 }
 
@@ -81,3 +90,388 @@ func TestMergeConfigGood(t *testing.T) {
 	assert.Equal(t, orig.DataDir, ".")
 	assert.Equal(t, orig.Umask, 0077)
 }
+
+func TestMergeConfigWatchUpdates(t *testing.T) {
+	orig := newDefaultConfig()
+	require.NoError(t, mergeConfig(orig, &runConfig{}))
+	assert.False(t, orig.WatchUpdates)
+
+	require.NoError(t, mergeConfig(orig, &runConfig{AgentConfig: agentConfig{WatchUpdates: true}}))
+	assert.True(t, orig.WatchUpdates)
+}
+
+func TestMergeConfigRequirePluginChecksum(t *testing.T) {
+	orig := newDefaultConfig()
+	require.NoError(t, mergeConfig(orig, &runConfig{}))
+	assert.False(t, orig.RequirePluginChecksum)
+
+	require.NoError(t, mergeConfig(orig, &runConfig{AgentConfig: agentConfig{RequirePluginChecksum: true}}))
+	assert.True(t, orig.RequirePluginChecksum)
+}
+
+func TestMergeConfigSDS(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			SDSDefaultSVIDName:             "svid",
+			SDSDefaultBundleName:           "bundle",
+			SDSDisableSPIFFECertValidation: true,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "svid", orig.SDS.DefaultSVIDName)
+	assert.Equal(t, "bundle", orig.SDS.DefaultBundleName)
+	assert.True(t, orig.SDS.DisableSPIFFECertValidation)
+}
+
+func TestMergeConfigSyncAndRotation(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			SyncInterval:      30,
+			RotationThreshold: 25,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, orig.SyncInterval)
+	assert.Equal(t, 25, orig.RotationThreshold)
+}
+
+func TestValidateConfigSyncAndRotationBounds(t *testing.T) {
+	newConfig := func() *agent.Config {
+		c := newDefaultConfig()
+		c.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+		c.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+		c.TrustBundle = []*x509.Certificate{}
+		return c
+	}
+
+	c := newConfig()
+	c.SyncInterval = time.Minute
+	c.RotationThreshold = 30
+	require.NoError(t, validateConfig(c))
+
+	c = newConfig()
+	c.SyncInterval = time.Millisecond
+	require.EqualError(t, validateConfig(c), "SyncInterval must be between 1s and 1h0m0s")
+
+	c = newConfig()
+	c.RotationThreshold = 95
+	require.EqualError(t, validateConfig(c), "RotationThreshold must be between 10 and 90 percent")
+}
+
+func TestMergeConfigWorkloadAPITCPAddress(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			TCPAddress: "127.0.0.1:8082",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8082", orig.TCPBindAddress.String())
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.TCPBindAddress = &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 8082}
+	require.EqualError(t, validateConfig(orig), "Workload API TCP address must be a loopback address")
+}
+
+func TestMergeConfigHealthCheck(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			HealthCheckEnabled:        true,
+			HealthCheckBindAddress:    "localhost:9090",
+			HealthCheckMaxMissedSyncs: 5,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.HealthCheck.Enabled)
+	assert.Equal(t, "localhost:9090", orig.HealthCheck.BindAddress)
+	assert.Equal(t, 5, orig.HealthCheck.MaxMissedSyncs)
+}
+
+func TestMergeConfigAdditionalSocketPaths(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			SocketPath:            "/tmp/agent.sock",
+			AdditionalSocketPaths: []string{"/run/spire/sockets/agent.sock"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	require.Len(t, orig.AdditionalBindAddresses, 1)
+	assert.Equal(t, "/run/spire/sockets/agent.sock", orig.AdditionalBindAddresses[0].Name)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.AdditionalBindAddresses = append(orig.AdditionalBindAddresses, &net.UnixAddr{Name: "/tmp/agent.sock", Net: "unix"})
+	require.EqualError(t, validateConfig(orig), "Socket path /tmp/agent.sock is configured more than once")
+}
+
+func TestMergeConfigWorkloadAPILimits(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			WorkloadAPIMaxStreamsPerPID: 10,
+			WorkloadAPIMaxStreamsPerUID: 50,
+			WorkloadAPIAttestationRate:  5,
+			WorkloadAPIAttestationBurst: 20,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 10, orig.WorkloadAPILimits.MaxStreamsPerPID)
+	assert.Equal(t, 50, orig.WorkloadAPILimits.MaxStreamsPerUID)
+	assert.Equal(t, 5, orig.WorkloadAPILimits.AttestationRate)
+	assert.Equal(t, 20, orig.WorkloadAPILimits.AttestationBurst)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.WorkloadAPILimits.AttestationRate = 0
+	require.EqualError(t, validateConfig(orig), "Workload API attestation burst requires an attestation rate")
+
+	orig.WorkloadAPILimits.MaxStreamsPerPID = -1
+	require.EqualError(t, validateConfig(orig), "Workload API limits cannot be negative")
+}
+
+func TestMergeConfigPrometheus(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			PrometheusBindAddress: "localhost:9988",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:9988", orig.PrometheusBindAddress)
+}
+
+func TestMergeConfigDebugSocketPath(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			SocketPath:      "/tmp/agent.sock",
+			DebugSocketPath: "/tmp/debug.sock",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/debug.sock", orig.DebugSocketPath)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.DebugSocketPath = "/tmp/agent.sock"
+	require.EqualError(t, validateConfig(orig), "Debug socket path must differ from the workload API socket paths")
+}
+
+func TestMergeConfigEntryCacheKeyPath(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			DataDir:           "/var/lib/spire/agent",
+			EntryCacheKeyPath: "/run/secrets/entry_cache.key",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "/run/secrets/entry_cache.key", orig.EntryCacheKeyPath)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.EntryCacheKeyPath = "/var/lib/spire/agent/keys/entry_cache.key"
+	require.EqualError(t, validateConfig(orig), "Entry cache key path must not be under the data dir, next to the cache it encrypts")
+}
+
+func TestMergeConfigShutdownDrainTimeout(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			ShutdownDrainTimeout: 20,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, orig.ShutdownDrainTimeout)
+}
+
+func TestMergeConfigMaxSVIDStaleness(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			MaxSVIDStaleness: 600,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, orig.MaxSVIDStaleness)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.MaxSVIDStaleness = -time.Second
+	require.EqualError(t, validateConfig(orig), "MaxSVIDStaleness cannot be negative")
+}
+
+func TestMergeConfigExpiringSVIDThreshold(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			ExpiringSVIDThreshold: 300,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, orig.ExpiringSVIDThreshold)
+}
+
+func TestMergeConfigDelegatedIdentity(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			SocketPath:                  "/tmp/agent.sock",
+			DelegatedIdentitySocketPath: "/tmp/delegated.sock",
+			AuthorizedDelegates:         []string{"spiffe://example.org/proxy"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/delegated.sock", orig.DelegatedIdentity.SocketPath)
+	assert.Equal(t, []string{"spiffe://example.org/proxy"}, orig.DelegatedIdentity.AuthorizedDelegates)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	orig.TrustBundle = []*x509.Certificate{}
+	require.NoError(t, validateConfig(orig))
+
+	orig.DelegatedIdentity.AuthorizedDelegates = []string{"spiffe://example.org/spire/agent/foo"}
+	require.Error(t, validateConfig(orig))
+
+	orig.DelegatedIdentity.AuthorizedDelegates = nil
+	require.EqualError(t, validateConfig(orig), "AuthorizedDelegates is required to serve the delegated identity API")
+
+	orig.DelegatedIdentity.SocketPath = "/tmp/agent.sock"
+	require.EqualError(t, validateConfig(orig), "Delegated identity socket path must differ from the workload and debug API socket paths")
+}
+
+func TestValidateConfigTrustBundleURL(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			TrustBundleURL:    "https://example.org/bundle.pem",
+			TrustBundleSHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.org/bundle.pem", orig.TrustBundleURL)
+
+	orig.ServerAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	require.NoError(t, validateConfig(orig))
+
+	orig.TrustBundleSHA256 = "abcd"
+	require.EqualError(t, validateConfig(orig), "TrustBundleSHA256 must be a hex encoded SHA-256 hash")
+
+	orig.TrustBundleSHA256 = ""
+	orig.TrustBundleURL = "http://example.org/bundle.pem"
+	require.EqualError(t, validateConfig(orig), "TrustBundleURL must be an HTTPS URL")
+
+	orig.TrustBundle = []*x509.Certificate{}
+	require.EqualError(t, validateConfig(orig), "TrustBundle and TrustBundleURL are mutually exclusive")
+
+	orig.TrustBundle = nil
+	orig.TrustBundleURL = ""
+	require.EqualError(t, validateConfig(orig), "TrustBundle or TrustBundleURL is required")
+}
+
+func TestMergeConfigSVIDMintingPolicy(t *testing.T) {
+	orig := newDefaultConfig()
+	require.NoError(t, mergeConfig(orig, &runConfig{}))
+	assert.False(t, orig.LazySVIDMinting)
+
+	require.NoError(t, mergeConfig(orig, &runConfig{AgentConfig: agentConfig{SVIDMintingPolicy: "lazy"}}))
+	assert.True(t, orig.LazySVIDMinting)
+
+	require.NoError(t, mergeConfig(orig, &runConfig{AgentConfig: agentConfig{SVIDMintingPolicy: "eager"}}))
+	assert.False(t, orig.LazySVIDMinting)
+
+	err := mergeConfig(orig, &runConfig{AgentConfig: agentConfig{SVIDMintingPolicy: "sometimes"}})
+	require.EqualError(t, err, `Unknown SVID minting policy "sometimes", must be "eager" or "lazy"`)
+}
+
+func TestMergeConfigStatsd(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			StatsdAddress:       "localhost:8125",
+			StatsdPrefix:        "prod",
+			StatsdDogStatsD:     true,
+			StatsdAllowedLabels: []string{"attestor_name"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, telemetry.StatsdConfig{
+		Address:       "localhost:8125",
+		Prefix:        "prod",
+		DogStatsD:     true,
+		AllowedLabels: []string{"attestor_name"},
+	}, orig.Statsd)
+}
+
+func TestMergeConfigLogging(t *testing.T) {
+	c := &runConfig{
+		AgentConfig: agentConfig{
+			LogFormat:          "json",
+			SubsystemLogLevels: map[string]string{"catalog": "DEBUG"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, logrus.InfoLevel, orig.LogLevels.Level())
+	assert.Equal(t, logrus.DebugLevel, orig.LogLevels.SubsystemLevel("catalog"))
+
+	c.AgentConfig.LogFormat = "xml"
+	err = mergeConfig(orig, c)
+	require.EqualError(t, err, `Could not set up logging: unknown log format "xml", must be "text" or "json"`)
+}
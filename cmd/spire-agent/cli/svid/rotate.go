@@ -0,0 +1,133 @@
+package svid
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/pkg/agent/debug"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+)
+
+type RotateCLI struct{}
+
+type rotateConfig struct {
+	// Location of the debug API socket of the agent
+	SocketPath string
+
+	// SPIFFE ID of the SVIDs to rotate, all of them if empty
+	SpiffeID string
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+
+	// How long to wait for the agent to answer
+	Timeout time.Duration
+}
+
+func (RotateCLI) Synopsis() string {
+	return "Forces the agent to rotate its workload SVIDs and keys"
+}
+
+func (r RotateCLI) Help() string {
+	_, err := r.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (r RotateCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(r.flagSet(&rotateConfig{}))
+}
+
+func (RotateCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (r RotateCLI) Run(args []string) int {
+	config, err := r.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := r.rotate(config)
+	if err != nil {
+		fmt.Printf("Could not rotate the SVIDs: %v\n", err)
+		return 1
+	}
+
+	if config.Output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(os.Stdout, resp); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	msg := fmt.Sprintf("Rotated %v SVID", resp.Rotated)
+	if resp.Rotated != 1 {
+		msg += "s"
+	}
+	fmt.Println(msg)
+	return 0
+}
+
+// rotate asks the debug API of the agent to rotate the SVIDs
+func (RotateCLI) rotate(config *rotateConfig) (*debug.RotateResponse, error) {
+	client := &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", config.SocketPath)
+			},
+		},
+	}
+
+	u := "http://agent" + debug.RotatePath
+	if config.SpiffeID != "" {
+		u += "?" + url.Values{"spiffe_id": {config.SpiffeID}}.Encode()
+	}
+
+	resp, err := client.Post(u, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(body)))
+	}
+
+	rotated := new(debug.RotateResponse)
+	if err := json.NewDecoder(resp.Body).Decode(rotated); err != nil {
+		return nil, fmt.Errorf("decode response: %v", err)
+	}
+	return rotated, nil
+}
+
+func (r RotateCLI) newConfig(args []string) (*rotateConfig, error) {
+	c := &rotateConfig{}
+	f := r.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (RotateCLI) flagSet(c *rotateConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("svid rotate", flag.ContinueOnError)
+	f.StringVar(&c.SocketPath, "debugSocketPath", debug.DefaultSocketPath, "Location of the debug API socket of the agent")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "SPIFFE ID of the SVIDs to rotate, all of them if not set")
+	cliprinter.AppendFlag(f, &c.Output)
+	f.DurationVar(&c.Timeout, "timeout", 30*time.Second, "How long to wait for the agent to answer")
+	return f
+}
@@ -0,0 +1,51 @@
+package svid
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/debug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "svid-rotate-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := path.Join(dir, "debug.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer l.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(debug.RotatePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("spiffe_id") != "spiffe://example.org/foo" {
+			http.Error(w, "unexpected SPIFFE ID", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(&debug.RotateResponse{Rotated: 1})
+	})
+	go http.Serve(l, mux)
+
+	resp, err := RotateCLI{}.rotate(&rotateConfig{
+		SocketPath: socketPath,
+		SpiffeID:   "spiffe://example.org/foo",
+		Timeout:    time.Second,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Rotated)
+
+	_, err = RotateCLI{}.rotate(&rotateConfig{SocketPath: socketPath, Timeout: time.Second})
+	assert.EqualError(t, err, "unexpected SPIFFE ID")
+
+	_, err = RotateCLI{}.rotate(&rotateConfig{SocketPath: path.Join(dir, "missing.sock"), Timeout: time.Second})
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+)
+
+type banCLI struct {
+	newAgentClient func(ctx context.Context, addr string) (agent_pb.AgentClient, error)
+	writer         io.Writer
+}
+
+type banConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// SPIFFE ID of the agent to ban
+	spiffeID string
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// NewBanCommand creates a new "ban" subcommand for "agent" command.
+func NewBanCommand() cli.Command {
+	return &banCLI{
+		writer:         os.Stdout,
+		newAgentClient: util.NewAgentClient,
+	}
+}
+
+func (*banCLI) Synopsis() string {
+	return "Bans an attested agent, preventing it from attesting again until it is evicted"
+}
+
+func (c *banCLI) Help() string {
+	_, err := c.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (b *banCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(b.flagSet(&banConfig{}))
+}
+
+func (*banCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *banCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	client, err := c.newAgentClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := client.BanAgent(ctx, &agent_pb.BanAgentRequest{SpiffeId: config.spiffeID})
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if config.output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(c.writer, resp); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(c.writer, "Agent %s banned\n", config.spiffeID)
+	return 0
+}
+
+func (b *banCLI) newConfig(args []string) (*banConfig, error) {
+	c := &banConfig{}
+	f := b.flagSet(c)
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+	if c.spiffeID == "" {
+		return nil, errors.New("a SPIFFE ID is required")
+	}
+	return c, nil
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*banCLI) flagSet(c *banConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("agent ban", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to ban")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
@@ -0,0 +1,36 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/agent"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBan(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	client := mock_agent.NewMockAgentClient(mockCtrl)
+	out := &bytes.Buffer{}
+	cli := &banCLI{
+		newAgentClient: func(ctx context.Context, addr string) (agent.AgentClient, error) {
+			return client, nil
+		},
+		writer: out,
+	}
+
+	client.EXPECT().BanAgent(gomock.Any(), &agent.BanAgentRequest{SpiffeId: agentID}).
+		Return(&agent.BanAgentResponse{}, nil)
+	require.Equal(t, 0, cli.Run([]string{"-spiffeID", agentID}))
+	require.Equal(t, "Agent "+agentID+" banned\n", out.String())
+
+	client.EXPECT().BanAgent(gomock.Any(), gomock.Any()).Return(nil, errors.New("no such agent"))
+	require.Equal(t, 1, cli.Run([]string{"-spiffeID", agentID}))
+	require.Equal(t, 1, cli.Run([]string{}))
+}
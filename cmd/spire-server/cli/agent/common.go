@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+// printAgent prints the SPIFFE ID of the agent, along with how it attested
+// and when its SVID expires
+func printAgent(w io.Writer, a *agent_pb.AttestedAgent) {
+	fmt.Fprintf(w, "SPIFFE ID:\t\t%s\n", a.SpiffeId)
+	fmt.Fprintf(w, "Attestation type:\t%s\n", a.AttestationType)
+	if a.Banned {
+		fmt.Fprintf(w, "Banned:\t\t\t%t\n", a.Banned)
+	} else {
+		fmt.Fprintf(w, "SVID serial:\t\t%s\n", a.CertSerialNumber)
+	}
+	if a.CertExpirationDate != "" {
+		fmt.Fprintf(w, "SVID expires at:\t%s\n", formatExpiry(a.CertExpirationDate))
+	}
+}
+
+// formatExpiry formats the expiration date of an agent SVID like the other
+// times printed by the CLI, leaving it as is if it can't be parsed
+func formatExpiry(date string) string {
+	t, err := time.Parse(datastore.TimeFormat, date)
+	if err != nil {
+		return date
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+)
+
+type evictCLI struct {
+	newAgentClient func(ctx context.Context, addr string) (agent_pb.AgentClient, error)
+	writer         io.Writer
+}
+
+type evictConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// SPIFFE ID of the agent to evict
+	spiffeID string
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// NewEvictCommand creates a new "evict" subcommand for "agent" command.
+func NewEvictCommand() cli.Command {
+	return &evictCLI{
+		writer:         os.Stdout,
+		newAgentClient: util.NewAgentClient,
+	}
+}
+
+func (*evictCLI) Synopsis() string {
+	return "Evicts an attested agent, forcing it to attest again"
+}
+
+func (c *evictCLI) Help() string {
+	_, err := c.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (e *evictCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(e.flagSet(&evictConfig{}))
+}
+
+func (*evictCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *evictCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	client, err := c.newAgentClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := client.DeleteAgent(ctx, &agent_pb.DeleteAgentRequest{SpiffeId: config.spiffeID})
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if config.output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(c.writer, resp); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(c.writer, "Agent %s evicted\n", config.spiffeID)
+	return 0
+}
+
+func (e *evictCLI) newConfig(args []string) (*evictConfig, error) {
+	c := &evictConfig{}
+	f := e.flagSet(c)
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+	if c.spiffeID == "" {
+		return nil, errors.New("a SPIFFE ID is required")
+	}
+	return c, nil
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*evictCLI) flagSet(c *evictConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("agent evict", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to evict")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
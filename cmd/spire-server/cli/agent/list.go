@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+)
+
+type listCLI struct {
+	newAgentClient func(ctx context.Context, addr string) (agent_pb.AgentClient, error)
+	writer         io.Writer
+}
+
+type listConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// Format to print the agents in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// NewListCommand creates a new "list" subcommand for "agent" command.
+func NewListCommand() cli.Command {
+	return &listCLI{
+		writer:         os.Stdout,
+		newAgentClient: util.NewAgentClient,
+	}
+}
+
+func (*listCLI) Synopsis() string {
+	return "Lists the attested agents"
+}
+
+func (l *listCLI) Help() string {
+	_, err := l.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (l *listCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(l.flagSet(&listConfig{}))
+}
+
+func (*listCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (l *listCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := l.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	c, err := l.newAgentClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := c.ListAgents(ctx, &agent_pb.ListAgentsRequest{})
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if config.output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(l.writer, resp); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	msg := fmt.Sprintf("Found %v attested ", len(resp.Agents))
+	fmt.Fprintln(l.writer, util.Pluralizer(msg, "agent", "agents", len(resp.Agents)))
+	for _, a := range resp.Agents {
+		fmt.Fprintln(l.writer)
+		printAgent(l.writer, a)
+	}
+	return 0
+}
+
+func (l *listCLI) newConfig(args []string) (*listConfig, error) {
+	c := &listConfig{}
+	f := l.flagSet(c)
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*listCLI) flagSet(c *listConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("agent list", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
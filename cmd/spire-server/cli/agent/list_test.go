@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/agent"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListTestSuite struct {
+	suite.Suite
+	mockCtrl   *gomock.Controller
+	mockClient *mock_agent.MockAgentClient
+	cli        *listCLI
+}
+
+func TestListTestSuite(t *testing.T) {
+	suite.Run(t, new(ListTestSuite))
+}
+
+func (s *ListTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_agent.NewMockAgentClient(s.mockCtrl)
+	s.cli = &listCLI{
+		newAgentClient: func(ctx context.Context, addr string) (agent.AgentClient, error) {
+			return s.mockClient, nil
+		},
+		writer: &bytes.Buffer{},
+	}
+}
+
+func (s *ListTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ListTestSuite) TestRun() {
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), &agent.ListAgentsRequest{}).
+		Return(&agent.ListAgentsResponse{
+			Agents: []*agent.AttestedAgent{
+				{
+					SpiffeId:           "spiffe://example.org/spire/agent/join_token/abcd",
+					AttestationType:    "join_token",
+					CertSerialNumber:   "1234",
+					CertExpirationDate: "Mon, 01 Jan 2018 00:00:00 +0000",
+				},
+				{
+					SpiffeId:           "spiffe://example.org/spire/agent/aws_iid/i-0123",
+					AttestationType:    "aws_iid",
+					CertExpirationDate: "Tue, 02 Jan 2018 00:00:00 +0000",
+					Banned:             true,
+				},
+			},
+		}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Require().Equal("Found 2 attested agents\n\n"+
+		"SPIFFE ID:\t\tspiffe://example.org/spire/agent/join_token/abcd\n"+
+		"Attestation type:\tjoin_token\n"+
+		"SVID serial:\t\t1234\n"+
+		"SVID expires at:\t2018-01-01T00:00:00Z\n\n"+
+		"SPIFFE ID:\t\tspiffe://example.org/spire/agent/aws_iid/i-0123\n"+
+		"Attestation type:\taws_iid\n"+
+		"Banned:\t\t\ttrue\n"+
+		"SVID expires at:\t2018-01-02T00:00:00Z\n", s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *ListTestSuite) TestRunWithJSONOutput() {
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), &agent.ListAgentsRequest{}).
+		Return(&agent.ListAgentsResponse{
+			Agents: []*agent.AttestedAgent{
+				{
+					SpiffeId:           "spiffe://example.org/spire/agent/aws_iid/i-0123",
+					AttestationType:    "aws_iid",
+					CertExpirationDate: "Tue, 02 Jan 2018 00:00:00 +0000",
+					Banned:             true,
+				},
+			},
+		}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-output", "json"}))
+	s.Require().JSONEq(`{
+		"agents": [{
+			"spiffe_id": "spiffe://example.org/spire/agent/aws_iid/i-0123",
+			"attestation_type": "aws_iid",
+			"cert_expiration_date": "Tue, 02 Jan 2018 00:00:00 +0000",
+			"banned": true
+		}]
+	}`, s.cli.writer.(*bytes.Buffer).String())
+}
@@ -0,0 +1,190 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/proto/common"
+
+	common_util "github.com/spiffe/spire/pkg/common/util"
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
+)
+
+type showCLI struct {
+	newAgentClient func(ctx context.Context, addr string) (agent_pb.AgentClient, error)
+	newEntryClient func(ctx context.Context, addr string) (entry_pb.EntryClient, error)
+	writer         io.Writer
+}
+
+type showConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// SPIFFE ID of the agent to show
+	spiffeID string
+
+	// Format to print the agent in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// showOutput is the agent along with the registration entries it is
+// authorized for, as printed in JSON
+type showOutput struct {
+	Agent   *agent_pb.AttestedAgent     `json:"agent"`
+	Entries []*common.RegistrationEntry `json:"entries"`
+}
+
+// NewShowCommand creates a new "show" subcommand for "agent" command.
+func NewShowCommand() cli.Command {
+	return &showCLI{
+		writer:         os.Stdout,
+		newAgentClient: util.NewAgentClient,
+		newEntryClient: util.NewEntryClient,
+	}
+}
+
+func (*showCLI) Synopsis() string {
+	return "Shows an attested agent, along with its selectors and the registration entries it is authorized for"
+}
+
+func (s *showCLI) Help() string {
+	_, err := s.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (s *showCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(s.flagSet(&showConfig{}))
+}
+
+func (*showCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (s *showCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := s.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	ac, err := s.newAgentClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := ac.GetAgent(ctx, &agent_pb.GetAgentRequest{SpiffeId: config.spiffeID})
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	ec, err := s.newEntryClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	entriesResp, err := ec.ListEntries(ctx, &entry_pb.ListEntriesRequest{})
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	entries := agentEntries(resp.Agent, entriesResp.Entries)
+	if config.output == cliprinter.JSON {
+		out := &showOutput{Agent: resp.Agent, Entries: entries}
+		if err := cliprinter.PrintJSON(s.writer, out); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	printAgent(s.writer, resp.Agent)
+	for _, sel := range resp.Agent.Selectors {
+		fmt.Fprintf(s.writer, "Selector:\t\t%s:%s\n", sel.Type, sel.Value)
+	}
+
+	fmt.Fprintln(s.writer)
+	msg := fmt.Sprintf("Found %v registration ", len(entries))
+	fmt.Fprintln(s.writer, util.Pluralizer(msg, "entry", "entries", len(entries)))
+	for _, e := range entries {
+		fmt.Fprintln(s.writer)
+		fmt.Fprintf(s.writer, "Entry ID:\t%s\n", e.EntryId)
+		fmt.Fprintf(s.writer, "SPIFFE ID:\t%s\n", e.SpiffeId)
+		fmt.Fprintf(s.writer, "Parent ID:\t%s\n", e.ParentId)
+		for _, sel := range e.Selectors {
+			fmt.Fprintf(s.writer, "Selector:\t%s:%s\n", sel.Type, sel.Value)
+		}
+	}
+	return 0
+}
+
+// agentEntries returns the registration entries the agent is authorized
+// for, the same way the server resolves them: the entries the agent is the
+// parent of, the node entries its selectors map it to, and the descendants
+// of both.
+func agentEntries(a *agent_pb.AttestedAgent, entries []*common.RegistrationEntry) []*common.RegistrationEntry {
+	agentSelectors := selector.NewSetFromRaw(a.Selectors)
+
+	found := []*common.RegistrationEntry{}
+	seen := make(map[string]bool)
+	visited := map[string]bool{a.SpiffeId: true}
+	ids := []string{a.SpiffeId}
+	for len(ids) > 0 {
+		id := ids[0]
+		ids = ids[1:]
+
+		for _, e := range entries {
+			mapped := id == a.SpiffeId && len(e.Selectors) > 0 &&
+				agentSelectors.IncludesSet(selector.NewSetFromRaw(e.Selectors))
+			if (e.ParentId != id && !mapped) || seen[e.EntryId] {
+				continue
+			}
+			seen[e.EntryId] = true
+			found = append(found, e)
+
+			if !visited[e.SpiffeId] {
+				visited[e.SpiffeId] = true
+				ids = append(ids, e.SpiffeId)
+			}
+		}
+	}
+
+	common_util.SortRegistrationEntries(found)
+	return found
+}
+
+func (s *showCLI) newConfig(args []string) (*showConfig, error) {
+	c := &showConfig{}
+	f := s.flagSet(c)
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+	if c.spiffeID == "" {
+		return nil, errors.New("a SPIFFE ID is required")
+	}
+	return c, nil
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*showCLI) flagSet(c *showConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("agent show", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.spiffeID, "spiffeID", "", "The SPIFFE ID of the agent to show")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/agent"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
+	"github.com/stretchr/testify/suite"
+)
+
+const agentID = "spiffe://example.org/spire/agent/join_token/abcd"
+
+type ShowTestSuite struct {
+	suite.Suite
+	mockCtrl        *gomock.Controller
+	mockAgentClient *mock_agent.MockAgentClient
+	mockEntryClient *mock_entry.MockEntryClient
+	cli             *showCLI
+}
+
+func TestShowTestSuite(t *testing.T) {
+	suite.Run(t, new(ShowTestSuite))
+}
+
+func (s *ShowTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockAgentClient = mock_agent.NewMockAgentClient(s.mockCtrl)
+	s.mockEntryClient = mock_entry.NewMockEntryClient(s.mockCtrl)
+	s.cli = &showCLI{
+		newAgentClient: func(ctx context.Context, addr string) (agent.AgentClient, error) {
+			return s.mockAgentClient, nil
+		},
+		newEntryClient: func(ctx context.Context, addr string) (entry.EntryClient, error) {
+			return s.mockEntryClient, nil
+		},
+		writer: &bytes.Buffer{},
+	}
+}
+
+func (s *ShowTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ShowTestSuite) TestRun() {
+	s.mockAgentClient.EXPECT().GetAgent(gomock.Any(), &agent.GetAgentRequest{SpiffeId: agentID}).
+		Return(&agent.GetAgentResponse{
+			Agent: &agent.AttestedAgent{
+				SpiffeId:           agentID,
+				AttestationType:    "join_token",
+				CertSerialNumber:   "1234",
+				CertExpirationDate: "Mon, 01 Jan 2018 00:00:00 +0000",
+				Selectors:          []*common.Selector{{Type: "type", Value: "value"}},
+			},
+		}, nil)
+	s.mockEntryClient.EXPECT().ListEntries(gomock.Any(), &entry.ListEntriesRequest{}).
+		Return(&entry.ListEntriesResponse{
+			Entries: []*common.RegistrationEntry{
+				{
+					EntryId:  "node",
+					SpiffeId: "spiffe://example.org/node",
+					ParentId: "spiffe://example.org/spire/server",
+					Selectors: []*common.Selector{
+						{Type: "type", Value: "value"},
+					},
+				},
+				{
+					EntryId:   "workload",
+					SpiffeId:  "spiffe://example.org/workload",
+					ParentId:  "spiffe://example.org/node",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+				{
+					EntryId:   "other",
+					SpiffeId:  "spiffe://example.org/other",
+					ParentId:  "spiffe://example.org/spire/agent/join_token/efgh",
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+			},
+		}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-spiffeID", agentID}))
+	s.Require().Equal("SPIFFE ID:\t\t"+agentID+"\n"+
+		"Attestation type:\tjoin_token\n"+
+		"SVID serial:\t\t1234\n"+
+		"SVID expires at:\t2018-01-01T00:00:00Z\n"+
+		"Selector:\t\ttype:value\n\n"+
+		"Found 2 registration entries\n\n"+
+		"Entry ID:\tnode\n"+
+		"SPIFFE ID:\tspiffe://example.org/node\n"+
+		"Parent ID:\tspiffe://example.org/spire/server\n"+
+		"Selector:\ttype:value\n\n"+
+		"Entry ID:\tworkload\n"+
+		"SPIFFE ID:\tspiffe://example.org/workload\n"+
+		"Parent ID:\tspiffe://example.org/node\n"+
+		"Selector:\tunix:uid:1000\n", s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *ShowTestSuite) TestRunWithJSONOutput() {
+	s.mockAgentClient.EXPECT().GetAgent(gomock.Any(), &agent.GetAgentRequest{SpiffeId: agentID}).
+		Return(&agent.GetAgentResponse{
+			Agent: &agent.AttestedAgent{SpiffeId: agentID, AttestationType: "join_token"},
+		}, nil)
+	s.mockEntryClient.EXPECT().ListEntries(gomock.Any(), &entry.ListEntriesRequest{}).
+		Return(&entry.ListEntriesResponse{}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-spiffeID", agentID, "-output", "json"}))
+	s.Require().JSONEq(`{
+		"agent": {"spiffe_id": "`+agentID+`", "attestation_type": "join_token"},
+		"entries": []
+	}`, s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *ShowTestSuite) TestRunRequiresSpiffeID() {
+	s.Require().Equal(1, s.cli.Run([]string{}))
+}
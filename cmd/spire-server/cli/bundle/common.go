@@ -0,0 +1,96 @@
+package bundle
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/server/datastore"
+
+	bundle_pb "github.com/spiffe/spire/proto/api/v1/bundle"
+)
+
+const (
+	formatPEM    = "pem"
+	formatSPIFFE = "spiffe"
+)
+
+func validateFormat(format string) error {
+	switch format {
+	case formatPEM, formatSPIFFE:
+		return nil
+	default:
+		return fmt.Errorf("invalid format %q", format)
+	}
+}
+
+// printBundle prints the bundle as PEM encoded CA certificates, or in the
+// SPIFFE bundle format, which also holds the JWT signing keys
+func printBundle(w io.Writer, bundle *bundle_pb.TrustBundle, format string) error {
+	b, err := bundleutil.FromDatastore(&datastore.Bundle{
+		CaCerts:        bundle.CaCerts,
+		JwtSigningKeys: bundle.JwtSigningKeys,
+		SequenceNumber: bundle.SequenceNumber,
+		RefreshHint:    bundle.RefreshHint,
+	})
+	if err != nil {
+		return fmt.Errorf("FAILED to parse bundle: %v", err)
+	}
+
+	if format == formatPEM {
+		for _, cert := range b.RootCAs {
+			if err := pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	data, err := bundleutil.Marshal(b)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// parseBundle parses a bundle made of PEM encoded CA certificates, or in the
+// SPIFFE bundle format
+func parseBundle(data []byte, format string) (*bundle_pb.TrustBundle, error) {
+	if format == formatPEM {
+		bundle := &bundle_pb.TrustBundle{}
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+				return nil, fmt.Errorf("unable to parse CA certificate: %v", err)
+			}
+			bundle.CaCerts = append(bundle.CaCerts, block.Bytes...)
+		}
+		if len(bundle.CaCerts) == 0 {
+			return nil, errors.New("no PEM encoded CA certificate found")
+		}
+		return bundle, nil
+	}
+
+	b, err := bundleutil.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.RootCAs) == 0 {
+		return nil, errors.New("bundle has no X509-SVID authorities")
+	}
+	stored, err := bundleutil.ToDatastore("", b)
+	if err != nil {
+		return nil, err
+	}
+	return &bundle_pb.TrustBundle{
+		CaCerts:        stored.CaCerts,
+		JwtSigningKeys: stored.JwtSigningKeys,
+		RefreshHint:    stored.RefreshHint,
+	}, nil
+}
@@ -0,0 +1,219 @@
+package bundle
+
+import (
+	"context"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	bundle_pb "github.com/spiffe/spire/proto/api/v1/bundle"
+)
+
+type listCLI struct {
+	newBundleClient func(ctx context.Context, addr string) (bundle_pb.BundleClient, error)
+	writer          io.Writer
+}
+
+type listConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// If set, only the bundle of this federated trust domain is listed
+	id string
+
+	// Format to print the bundles in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// NewListCommand creates a new "list" subcommand for "bundle" command.
+func NewListCommand() cli.Command {
+	return &listCLI{
+		writer:          os.Stdout,
+		newBundleClient: util.NewBundleClient,
+	}
+}
+
+func (*listCLI) Synopsis() string {
+	return "Lists the bundles of the federated trust domains"
+}
+
+func (l *listCLI) Help() string {
+	_, err := l.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (l *listCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(l.flagSet(&listConfig{}))
+}
+
+func (*listCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (l *listCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := l.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	c, err := l.newBundleClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	var bundles []*bundle_pb.TrustBundle
+	if config.id != "" {
+		resp, err := c.GetFederatedBundle(ctx, &bundle_pb.GetFederatedBundleRequest{TrustDomain: config.id})
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		bundles = append(bundles, resp.Bundle)
+	} else {
+		resp, err := c.ListFederatedBundles(ctx, &bundle_pb.ListFederatedBundlesRequest{})
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		bundles = resp.Bundles
+	}
+
+	infos := []*bundleInfo{}
+	for _, bundle := range bundles {
+		info, err := newBundleInfo(bundle)
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		infos = append(infos, info)
+	}
+
+	if config.output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(l.writer, map[string]interface{}{"bundles": infos}); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	msg := fmt.Sprintf("Found %v federated ", len(infos))
+	fmt.Fprintln(l.writer, util.Pluralizer(msg, "bundle", "bundles", len(infos)))
+	for _, info := range infos {
+		printBundleInfo(l.writer, info)
+	}
+	return 0
+}
+
+// bundleInfo is the trust domain of a bundle, along with the expiry of its CA
+// certificates and JWT signing keys, as printed in JSON
+type bundleInfo struct {
+	TrustDomain string `json:"trust_domain"`
+
+	// The bundle expires with the last of its CA certificates
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CAs     []caInfo     `json:"cas"`
+	JWTKeys []jwtKeyInfo `json:"jwt_keys"`
+
+	// Refresh hint in seconds
+	RefreshHint int64 `json:"refresh_hint,omitempty"`
+}
+
+type caInfo struct {
+	Subject   string    `json:"subject"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type jwtKeyInfo struct {
+	KeyID     string     `json:"key_id"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// newBundleInfo gathers the expiry of the CA certificates and JWT signing
+// keys of the bundle
+func newBundleInfo(bundle *bundle_pb.TrustBundle) (*bundleInfo, error) {
+	certs, err := x509.ParseCertificates(bundle.CaCerts)
+	if err != nil {
+		return nil, fmt.Errorf("FAILED to parse the CA certificates of %s: %v", bundle.TrustDomain, err)
+	}
+
+	info := &bundleInfo{
+		TrustDomain: bundle.TrustDomain,
+		CAs:         []caInfo{},
+		JWTKeys:     []jwtKeyInfo{},
+		RefreshHint: bundle.RefreshHint,
+	}
+	for _, cert := range certs {
+		if info.ExpiresAt == nil || cert.NotAfter.After(*info.ExpiresAt) {
+			expiresAt := cert.NotAfter.UTC()
+			info.ExpiresAt = &expiresAt
+		}
+		info.CAs = append(info.CAs, caInfo{
+			Subject:   cert.Subject.String(),
+			ExpiresAt: cert.NotAfter.UTC(),
+		})
+	}
+	for _, key := range bundle.JwtSigningKeys {
+		keyInfo := jwtKeyInfo{KeyID: key.Kid}
+		if key.NotAfter != 0 {
+			expiresAt := time.Unix(key.NotAfter, 0).UTC()
+			keyInfo.ExpiresAt = &expiresAt
+		}
+		info.JWTKeys = append(info.JWTKeys, keyInfo)
+	}
+	return info, nil
+}
+
+// printBundleInfo prints the trust domain of the bundle, along with the
+// expiry of its CA certificates and JWT signing keys
+func printBundleInfo(w io.Writer, info *bundleInfo) {
+	fmt.Fprintf(w, "Trust domain:\t%s\n", info.TrustDomain)
+	if info.ExpiresAt != nil {
+		fmt.Fprintf(w, "Expires at:\t%s\n", formatTime(*info.ExpiresAt))
+	}
+	for _, ca := range info.CAs {
+		fmt.Fprintf(w, "CA:\t\t%s, expires at %s\n", ca.Subject, formatTime(ca.ExpiresAt))
+	}
+	for _, key := range info.JWTKeys {
+		if key.ExpiresAt != nil {
+			fmt.Fprintf(w, "JWT key:\t%s, expires at %s\n", key.KeyID, formatTime(*key.ExpiresAt))
+		} else {
+			fmt.Fprintf(w, "JWT key:\t%s\n", key.KeyID)
+		}
+	}
+	if info.RefreshHint != 0 {
+		fmt.Fprintf(w, "Refresh hint:\t%v\n", time.Duration(info.RefreshHint)*time.Second)
+	}
+	fmt.Fprintln(w)
+}
+
+func (l *listCLI) newConfig(args []string) (*listConfig, error) {
+	c := &listConfig{}
+	f := l.flagSet(c)
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*listCLI) flagSet(c *listConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("bundle list", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.id, "id", "", "SPIFFE ID of a federated trust domain. If set, only its bundle is listed")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,100 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/bundle"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/bundle"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListTestSuite struct {
+	suite.Suite
+	mockCtrl   *gomock.Controller
+	mockClient *mock_bundle.MockBundleClient
+	cli        *listCLI
+}
+
+func TestListTestSuite(t *testing.T) {
+	suite.Run(t, new(ListTestSuite))
+}
+
+func (s *ListTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_bundle.NewMockBundleClient(s.mockCtrl)
+	s.cli = &listCLI{
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
+			return s.mockClient, nil
+		},
+		writer: &bytes.Buffer{},
+	}
+}
+
+func (s *ListTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *ListTestSuite) TestRun() {
+	ca, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	keyExpiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s.mockClient.EXPECT().ListFederatedBundles(gomock.Any(), &bundle.ListFederatedBundlesRequest{}).
+		Return(&bundle.ListFederatedBundlesResponse{
+			Bundles: []*bundle.TrustBundle{
+				{
+					TrustDomain:    "spiffe://partner.org",
+					CaCerts:        ca.Raw,
+					JwtSigningKeys: []*common.PublicKey{{Kid: "KID", NotAfter: keyExpiry.Unix()}},
+					RefreshHint:    300,
+				},
+			},
+		}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	caExpiry := ca.NotAfter.UTC().Format(time.RFC3339)
+	s.Require().Equal("Found 1 federated bundle\n"+
+		"Trust domain:\tspiffe://partner.org\n"+
+		"Expires at:\t"+caExpiry+"\n"+
+		"CA:\t\t"+ca.Subject.String()+", expires at "+caExpiry+"\n"+
+		"JWT key:\tKID, expires at 2030-01-01T00:00:00Z\n"+
+		"Refresh hint:\t5m0s\n\n", s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *ListTestSuite) TestRunWithJSONOutput() {
+	keyExpiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mockClient.EXPECT().ListFederatedBundles(gomock.Any(), &bundle.ListFederatedBundlesRequest{}).
+		Return(&bundle.ListFederatedBundlesResponse{
+			Bundles: []*bundle.TrustBundle{
+				{
+					TrustDomain:    "spiffe://partner.org",
+					JwtSigningKeys: []*common.PublicKey{{Kid: "KID", NotAfter: keyExpiry.Unix()}},
+					RefreshHint:    300,
+				},
+			},
+		}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-output", "json"}))
+	s.Require().JSONEq(`{
+		"bundles": [{
+			"trust_domain": "spiffe://partner.org",
+			"cas": [],
+			"jwt_keys": [{"key_id": "KID", "expires_at": "2030-01-01T00:00:00Z"}],
+			"refresh_hint": 300
+		}]
+	}`, s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *ListTestSuite) TestRunWithID() {
+	s.mockClient.EXPECT().GetFederatedBundle(gomock.Any(), &bundle.GetFederatedBundleRequest{TrustDomain: "spiffe://partner.org"}).
+		Return(&bundle.GetFederatedBundleResponse{Bundle: &bundle.TrustBundle{TrustDomain: "spiffe://partner.org"}}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-id", "spiffe://partner.org"}))
+	s.Require().Equal("Found 1 federated bundle\nTrust domain:\tspiffe://partner.org\n\n", s.cli.writer.(*bytes.Buffer).String())
+}
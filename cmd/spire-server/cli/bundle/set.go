@@ -0,0 +1,151 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	bundle_pb "github.com/spiffe/spire/proto/api/v1/bundle"
+)
+
+type setCLI struct {
+	newBundleClient func(ctx context.Context, addr string) (bundle_pb.BundleClient, error)
+	stdin           io.Reader
+	writer          io.Writer
+}
+
+type setConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// SPIFFE ID of the federated trust domain the bundle is for
+	id string
+
+	// Path to the bundle. The bundle is read from standard input if empty.
+	path string
+
+	// Format the bundle is in, formatPEM or formatSPIFFE
+	format string
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// NewSetCommand creates a new "set" subcommand for "bundle" command.
+func NewSetCommand() cli.Command {
+	return &setCLI{
+		stdin:           os.Stdin,
+		writer:          os.Stdout,
+		newBundleClient: util.NewBundleClient,
+	}
+}
+
+func (*setCLI) Synopsis() string {
+	return "Creates or replaces the bundle of a federated trust domain"
+}
+
+func (s *setCLI) Help() string {
+	_, err := s.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (s *setCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(s.flagSet(&setConfig{}))
+}
+
+func (*setCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (s *setCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := s.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	bundle, err := s.readBundle(config)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+	bundle.TrustDomain = config.id
+
+	c, err := s.newBundleClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if _, err := c.SetFederatedBundle(ctx, &bundle_pb.SetFederatedBundleRequest{Bundle: bundle}); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if config.output == cliprinter.JSON {
+		info, err := newBundleInfo(bundle)
+		if err == nil {
+			err = cliprinter.PrintJSON(s.writer, info)
+		}
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(s.writer, "Bundle of %s set.\n", config.id)
+	return 0
+}
+
+func (s *setCLI) readBundle(config *setConfig) (*bundle_pb.TrustBundle, error) {
+	var data []byte
+	var err error
+	if config.path == "" {
+		data, err = ioutil.ReadAll(s.stdin)
+	} else {
+		data, err = ioutil.ReadFile(config.path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseBundle(data, config.format)
+}
+
+func (s *setCLI) newConfig(args []string) (*setConfig, error) {
+	c := &setConfig{}
+	f := s.flagSet(c)
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if c.id == "" {
+		return nil, errors.New("the SPIFFE ID of the trust domain is required")
+	}
+	if err := validateFormat(c.format); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*setCLI) flagSet(c *setConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("bundle set", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.id, "id", "", "SPIFFE ID of the federated trust domain the bundle is for")
+	f.StringVar(&c.path, "path", "", "Path to the bundle. Read from standard input if not set")
+	f.StringVar(&c.format, "format", formatPEM, "Format the bundle is in: pem, or spiffe for the SPIFFE bundle format")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
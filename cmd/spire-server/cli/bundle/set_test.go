@@ -0,0 +1,97 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/bundle"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/bundle"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type SetTestSuite struct {
+	suite.Suite
+	mockCtrl   *gomock.Controller
+	mockClient *mock_bundle.MockBundleClient
+	cli        *setCLI
+}
+
+func TestSetTestSuite(t *testing.T) {
+	suite.Run(t, new(SetTestSuite))
+}
+
+func (s *SetTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_bundle.NewMockBundleClient(s.mockCtrl)
+	s.cli = &setCLI{
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
+			return s.mockClient, nil
+		},
+		writer: &bytes.Buffer{},
+	}
+}
+
+func (s *SetTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *SetTestSuite) TestRunWithPEMFromStdin() {
+	ca, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	s.cli.stdin = bytes.NewReader(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}))
+
+	s.mockClient.EXPECT().SetFederatedBundle(gomock.Any(), &bundle.SetFederatedBundleRequest{
+		Bundle: &bundle.TrustBundle{TrustDomain: "spiffe://partner.org", CaCerts: ca.Raw},
+	}).Return(&bundle.SetFederatedBundleResponse{}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-id", "spiffe://partner.org"}))
+	s.Require().Equal("Bundle of spiffe://partner.org set.\n", s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *SetTestSuite) TestRunWithSPIFFEFile() {
+	dir, err := ioutil.TempDir("", "bundle-set-test")
+	s.Require().NoError(err)
+	defer os.RemoveAll(dir)
+
+	ca, key, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	pkixBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	s.Require().NoError(err)
+	expected := &bundle.TrustBundle{
+		TrustDomain:    "spiffe://partner.org",
+		CaCerts:        ca.Raw,
+		JwtSigningKeys: []*common.PublicKey{{PkixBytes: pkixBytes, Kid: "KID"}},
+		RefreshHint:    300,
+	}
+
+	// The bundle is read in the format printed by bundle show
+	var doc bytes.Buffer
+	s.Require().NoError(printBundle(&doc, expected, formatSPIFFE))
+	path := filepath.Join(dir, "bundle.json")
+	s.Require().NoError(ioutil.WriteFile(path, doc.Bytes(), 0600))
+
+	s.mockClient.EXPECT().SetFederatedBundle(gomock.Any(), &bundle.SetFederatedBundleRequest{Bundle: expected}).
+		Return(&bundle.SetFederatedBundleResponse{}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-id", "spiffe://partner.org", "-path", path, "-format", "spiffe"}))
+}
+
+func (s *SetTestSuite) TestRunWithInvalidArgs() {
+	// The trust domain is required
+	s.Require().Equal(1, s.cli.Run([]string{}))
+
+	// Files without certificates are rejected before reaching the server
+	s.cli.stdin = bytes.NewReader([]byte("not a bundle"))
+	s.Require().Equal(1, s.cli.Run([]string{"-id", "spiffe://partner.org"}))
+
+	s.Require().Equal(1, s.cli.Run([]string{"-id", "spiffe://partner.org", "-format", "der"}))
+}
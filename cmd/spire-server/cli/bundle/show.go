@@ -2,34 +2,41 @@ package bundle
 
 import (
 	"context"
-	"crypto/x509"
-	"encoding/pem"
 	"flag"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
 	"github.com/spiffe/spire/cmd/spire-server/util"
-	"github.com/spiffe/spire/proto/api/registration"
-	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	bundle_pb "github.com/spiffe/spire/proto/api/v1/bundle"
 )
 
 type showCLI struct {
-	newRegistrationClient func(ctx context.Context, addr string) (registration.RegistrationClient, error)
-	writer                io.Writer
+	newBundleClient func(ctx context.Context, addr string) (bundle_pb.BundleClient, error)
+	writer          io.Writer
 }
 
 type showConfig struct {
 	// Address of SPIRE server
 	addr string
+
+	// Format to print the bundle in, formatPEM or formatSPIFFE
+	format string
+
+	// If cliprinter.JSON, the bundle is printed in the SPIFFE bundle format,
+	// whatever the format
+	output string
 }
 
 // NewShowCommand creates a new "show" subcommand for "bundle" command.
 func NewShowCommand() cli.Command {
 	return &showCLI{
-		writer:                os.Stdout,
-		newRegistrationClient: util.NewRegistrationClient,
+		writer:          os.Stdout,
+		newBundleClient: util.NewBundleClient,
 	}
 }
 
@@ -42,6 +49,14 @@ func (s *showCLI) Help() string {
 	return err.Error()
 }
 
+func (s *showCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(s.flagSet(&showConfig{}))
+}
+
+func (*showCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (s *showCLI) Run(args []string) int {
 	ctx := context.Background()
 
@@ -51,20 +66,23 @@ func (s *showCLI) Run(args []string) int {
 		return 1
 	}
 
-	c, err := s.newRegistrationClient(ctx, config.addr)
+	c, err := s.newBundleClient(ctx, config.addr)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	bundle, err := c.FetchBundle(ctx, &common.Empty{})
+	resp, err := c.GetBundle(ctx, &bundle_pb.GetBundleRequest{})
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	err = s.printBundleAsPEM(bundle)
-	if err != nil {
+	format := config.format
+	if config.output == cliprinter.JSON {
+		format = formatSPIFFE
+	}
+	if err := printBundle(s.writer, resp.Bundle, format); err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
@@ -72,24 +90,24 @@ func (s *showCLI) Run(args []string) int {
 	return 0
 }
 
-func (*showCLI) newConfig(args []string) (*showConfig, error) {
-	f := flag.NewFlagSet("bundle show", flag.ContinueOnError)
+func (s *showCLI) newConfig(args []string) (*showConfig, error) {
 	c := &showConfig{}
-	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
-	return c, f.Parse(args)
-}
-
-func (s *showCLI) printBundleAsPEM(bundle *registration.Bundle) error {
-	certs, err := x509.ParseCertificates(bundle.CaCerts)
-	if err != nil {
-		return fmt.Errorf("FAILED to parse bundle's ASN.1 DER data: %v", err)
+	f := s.flagSet(c)
+	if err := f.Parse(args); err != nil {
+		return nil, err
 	}
 
-	for _, cert := range certs {
-		err := pem.Encode(s.writer, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
-		if err != nil {
-			return err
-		}
+	if err := validateFormat(c.format); err != nil {
+		return nil, err
 	}
-	return nil
+	return c, nil
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*showCLI) flagSet(c *showConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("bundle show", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.format, "format", formatPEM, "Format to print the bundle in: pem, or spiffe for the SPIFFE bundle format")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
 }
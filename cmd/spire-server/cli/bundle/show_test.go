@@ -3,6 +3,7 @@ package bundle
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -10,16 +11,16 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
-	"github.com/spiffe/spire/proto/api/registration"
-	"github.com/spiffe/spire/proto/common"
-	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/api/v1/bundle"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/bundle"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/suite"
 )
 
 type ShowTestSuite struct {
 	suite.Suite
-	mockClient *mock_registration.MockRegistrationClient
+	mockClient *mock_bundle.MockBundleClient
 }
 
 func TestShowTestSuite(t *testing.T) {
@@ -30,7 +31,7 @@ func (s *ShowTestSuite) SetupTest() {
 	mockCtrl := gomock.NewController(s.T())
 	defer mockCtrl.Finish()
 
-	s.mockClient = mock_registration.NewMockRegistrationClient(mockCtrl)
+	s.mockClient = mock_bundle.NewMockBundleClient(mockCtrl)
 }
 
 func (s *ShowTestSuite) TestSynopsisAndHelp() {
@@ -43,7 +44,7 @@ func (s *ShowTestSuite) TestSynopsisAndHelp() {
 
 func (s *ShowTestSuite) TestRunWithDefaultArgs() {
 	cli := &showCLI{
-		newRegistrationClient: func(ctx context.Context, addr string) (registration.RegistrationClient, error) {
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
 			return s.mockClient, nil
 		},
 		writer: &bytes.Buffer{},
@@ -52,8 +53,8 @@ func (s *ShowTestSuite) TestRunWithDefaultArgs() {
 	ca, _, err := util.LoadCAFixture()
 	s.Require().Nil(err)
 
-	resp := &registration.Bundle{CaCerts: ca.Raw}
-	s.mockClient.EXPECT().FetchBundle(gomock.Any(), &common.Empty{}).Return(resp, nil)
+	resp := &bundle.GetBundleResponse{Bundle: &bundle.TrustBundle{CaCerts: ca.Raw}}
+	s.mockClient.EXPECT().GetBundle(gomock.Any(), &bundle.GetBundleRequest{}).Return(resp, nil)
 
 	args := []string{}
 	s.Require().Equal(0, cli.Run(args))
@@ -63,11 +64,44 @@ func (s *ShowTestSuite) TestRunWithDefaultArgs() {
 	s.Assert().Equal(ca.Raw, bundleASN1)
 }
 
-func (s *ShowTestSuite) TestRunWithDefaultArgsAndFailedNewRegClient() {
+func (s *ShowTestSuite) TestRunWithSPIFFEFormat() {
+	cli := &showCLI{
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
+			return s.mockClient, nil
+		},
+		writer: &bytes.Buffer{},
+	}
+
+	ca, _, err := util.LoadCAFixture()
+	s.Require().Nil(err)
+
+	resp := &bundle.GetBundleResponse{Bundle: &bundle.TrustBundle{CaCerts: ca.Raw}}
+	s.mockClient.EXPECT().GetBundle(gomock.Any(), &bundle.GetBundleRequest{}).Return(resp, nil)
+
+	args := []string{"-format", "spiffe"}
+	s.Require().Equal(0, cli.Run(args))
+
+	b, err := bundleutil.Unmarshal(cli.writer.(*bytes.Buffer).Bytes())
+	s.Require().NoError(err)
+	s.Assert().Equal([]*x509.Certificate{ca}, b.RootCAs)
+
+	// The bundle is printed in the SPIFFE bundle format with -output json
+	cli.writer = &bytes.Buffer{}
+	s.mockClient.EXPECT().GetBundle(gomock.Any(), &bundle.GetBundleRequest{}).Return(resp, nil)
+	s.Require().Equal(0, cli.Run([]string{"-output", "json"}))
+	b, err = bundleutil.Unmarshal(cli.writer.(*bytes.Buffer).Bytes())
+	s.Require().NoError(err)
+	s.Assert().Equal([]*x509.Certificate{ca}, b.RootCAs)
+
+	// Unknown formats are rejected before reaching the server
+	s.Require().Equal(1, cli.Run([]string{"-format", "der"}))
+}
+
+func (s *ShowTestSuite) TestRunWithDefaultArgsAndFailedNewBundleClient() {
 	expecterError := errors.New("error creating client")
 
 	cli := &showCLI{
-		newRegistrationClient: func(ctx context.Context, addr string) (registration.RegistrationClient, error) {
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
 			return nil, expecterError
 		},
 	}
@@ -85,16 +119,16 @@ func (s *ShowTestSuite) TestRunWithDefaultArgsAndFailedNewRegClient() {
 	s.Assert().Equal(output, fmt.Sprintln(expecterError.Error()))
 }
 
-func (s *ShowTestSuite) TestRunWithDefaultArgsAndFailedFetchBundle() {
+func (s *ShowTestSuite) TestRunWithDefaultArgsAndFailedGetBundle() {
 	expecterError := errors.New("error fetching bundle")
 
 	cli := &showCLI{
-		newRegistrationClient: func(ctx context.Context, addr string) (registration.RegistrationClient, error) {
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
 			return s.mockClient, nil
 		},
 	}
 
-	s.mockClient.EXPECT().FetchBundle(gomock.Any(), &common.Empty{}).Return(nil, expecterError)
+	s.mockClient.EXPECT().GetBundle(gomock.Any(), &bundle.GetBundleRequest{}).Return(nil, expecterError)
 
 	stdOutRedir := &util.OutputRedirection{}
 	err := stdOutRedir.Start(os.Stdout)
@@ -113,14 +147,14 @@ func (s *ShowTestSuite) TestRunWithArgs() {
 	expecterAddr := "localhost:8080"
 
 	cli := &showCLI{
-		newRegistrationClient: func(ctx context.Context, addr string) (registration.RegistrationClient, error) {
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
 			s.Assert().Equal(expecterAddr, addr)
 			return s.mockClient, nil
 		},
 	}
 
-	resp := &registration.Bundle{}
-	s.mockClient.EXPECT().FetchBundle(gomock.Any(), &common.Empty{}).Return(resp, nil)
+	resp := &bundle.GetBundleResponse{Bundle: &bundle.TrustBundle{}}
+	s.mockClient.EXPECT().GetBundle(gomock.Any(), &bundle.GetBundleRequest{}).Return(resp, nil)
 
 	args := []string{"-serverAddr", expecterAddr}
 	s.Require().Equal(0, cli.Run(args))
@@ -128,13 +162,13 @@ func (s *ShowTestSuite) TestRunWithArgs() {
 
 func (s *ShowTestSuite) TestRunWithWrongArgs() {
 	cli := &showCLI{
-		newRegistrationClient: func(ctx context.Context, addr string) (registration.RegistrationClient, error) {
+		newBundleClient: func(ctx context.Context, addr string) (bundle.BundleClient, error) {
 			return s.mockClient, nil
 		},
 	}
 
-	resp := &registration.Bundle{}
-	s.mockClient.EXPECT().FetchBundle(gomock.Any(), &common.Empty{}).Return(resp, nil)
+	resp := &bundle.GetBundleResponse{Bundle: &bundle.TrustBundle{}}
+	s.mockClient.EXPECT().GetBundle(gomock.Any(), &bundle.GetBundleRequest{}).Return(resp, nil)
 
 	stdOutRedir := util.OutputRedirection{}
 	stdErrRedir := util.OutputRedirection{}
@@ -155,6 +189,10 @@ func (s *ShowTestSuite) TestRunWithWrongArgs() {
 
 	expectedErrOutput := "flag provided but not defined: -someArg\n" +
 		"Usage of bundle show:\n" +
+		"  -format string\n" +
+		"    \tFormat to print the bundle in: pem, or spiffe for the SPIFFE bundle format (default \"pem\")\n" +
+		"  -output format\n" +
+		"    \tThe format to print the outcome in: pretty, or json (default pretty)\n" +
 		"  -serverAddr string\n" +
 		"    \tAddress of the SPIRE server (default \"localhost:8081\")\n"
 
@@ -4,9 +4,14 @@ import (
 	"log"
 
 	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
+	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
+	"github.com/spiffe/spire/cmd/spire-server/cli/localauthority"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/cmd/spire-server/cli/stats"
+	"github.com/spiffe/spire/cmd/spire-server/cli/svid"
 	"github.com/spiffe/spire/cmd/spire-server/cli/token"
 	"github.com/spiffe/spire/pkg/common/version"
 )
@@ -15,24 +20,75 @@ func Run(args []string) int {
 	c := cli.NewCLI("spire-server", version.Version())
 	c.Args = args
 	c.Commands = map[string]cli.CommandFactory{
+		"agent ban": func() (cli.Command, error) {
+			return agent.NewBanCommand(), nil
+		},
+		"agent evict": func() (cli.Command, error) {
+			return agent.NewEvictCommand(), nil
+		},
+		"agent list": func() (cli.Command, error) {
+			return agent.NewListCommand(), nil
+		},
+		"agent show": func() (cli.Command, error) {
+			return agent.NewShowCommand(), nil
+		},
+		"bundle list": func() (cli.Command, error) {
+			return bundle.NewListCommand(), nil
+		},
+		"bundle set": func() (cli.Command, error) {
+			return bundle.NewSetCommand(), nil
+		},
 		"bundle show": func() (cli.Command, error) {
 			return bundle.NewShowCommand(), nil
 		},
+		"entry apply": func() (cli.Command, error) {
+			return &entry.ApplyCLI{}, nil
+		},
+		"entry count": func() (cli.Command, error) {
+			return &entry.CountCLI{}, nil
+		},
 		"entry create": func() (cli.Command, error) {
 			return &entry.CreateCLI{}, nil
 		},
 		"entry delete": func() (cli.Command, error) {
 			return &entry.DeleteCLI{}, nil
 		},
+		"entry rotate": func() (cli.Command, error) {
+			return &entry.RotateCLI{}, nil
+		},
 		"entry show": func() (cli.Command, error) {
 			return &entry.ShowCLI{}, nil
 		},
+		"healthcheck": func() (cli.Command, error) {
+			return healthcheck.NewHealthCheckCommand(), nil
+		},
+		"localauthority activate": func() (cli.Command, error) {
+			return &localauthority.ActivateCLI{}, nil
+		},
+		"localauthority prepare": func() (cli.Command, error) {
+			return &localauthority.PrepareCLI{}, nil
+		},
+		"localauthority show": func() (cli.Command, error) {
+			return &localauthority.ShowCLI{}, nil
+		},
+		"localauthority taint": func() (cli.Command, error) {
+			return &localauthority.TaintCLI{}, nil
+		},
 		"run": func() (cli.Command, error) {
 			return &run.RunCLI{}, nil
 		},
+		"stats": func() (cli.Command, error) {
+			return stats.NewStatsCommand(), nil
+		},
+		"svid revoke": func() (cli.Command, error) {
+			return &svid.RevokeCLI{}, nil
+		},
 		"token generate": func() (cli.Command, error) {
 			return &token.GenerateCLI{}, nil
 		},
+		"validate": func() (cli.Command, error) {
+			return &run.ValidateCLI{}, nil
+		},
 	}
 
 	exitStatus, err := c.Run()
@@ -0,0 +1,350 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/common"
+
+	"golang.org/x/net/context"
+
+	common_util "github.com/spiffe/spire/pkg/common/util"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
+)
+
+// ApplyConfig is a configuration struct for the
+// `spire-server entry apply` CLI command
+type ApplyConfig struct {
+	// Address of SPIRE server
+	Addr string
+
+	// Path to the file declaring the registration entries, in JSON or YAML
+	Path string
+
+	// If set, the changes needed are printed but not made
+	DryRun bool
+
+	// If set, the registered entries which are not declared are deleted,
+	// if their parent ID is one the file owns
+	Prune bool
+
+	// Format to print the changes in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+}
+
+// Validate ensures that the values in ApplyConfig are valid
+func (ac *ApplyConfig) Validate() error {
+	if ac.Addr == "" {
+		return errors.New("a server address is required")
+	}
+
+	if ac.Path == "" {
+		return errors.New("a file of registration entries is required")
+	}
+
+	return nil
+}
+
+// ApplyCLI is a struct which represents an invocation of the
+// `spire-server entry apply` CLI command
+type ApplyCLI struct {
+	Client entry_pb.EntryClient
+	Config *ApplyConfig
+}
+
+// applyFile is the file declaring the registration entries. It is in the
+// JSON format of the data file taken by `spire-server entry create`, or in
+// the same format written in YAML.
+type applyFile struct {
+	// Parent IDs of the entries managed through the file. When pruning,
+	// only the undeclared entries with one of these parent IDs are deleted,
+	// leaving alone the entries managed otherwise.
+	OwnedParentIDs []string `json:"owned_parent_ids"`
+
+	Entries []*common.RegistrationEntry `json:"entries"`
+}
+
+// changes are the changes needed for the registered entries to match the
+// declared ones
+type changes struct {
+	create    []*common.RegistrationEntry
+	update    []*common.RegistrationEntry
+	delete    []*common.RegistrationEntry
+	unchanged int
+}
+
+// applyOutput is the outcome of the command, as printed in JSON
+type applyOutput struct {
+	DryRun    bool                        `json:"dry_run"`
+	Created   []*common.RegistrationEntry `json:"created"`
+	Updated   []*common.RegistrationEntry `json:"updated"`
+	Deleted   []*common.RegistrationEntry `json:"deleted"`
+	Unchanged int                         `json:"unchanged"`
+}
+
+// Synopsis prints a description of the ApplyCLI command
+func (ApplyCLI) Synopsis() string {
+	return "Makes the registration entries match a file"
+}
+
+// Help prints a help message for the ApplyCLI command
+func (a ApplyCLI) Help() string {
+	err := a.loadConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (a *ApplyCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(a.flagSet(&ApplyConfig{}))
+}
+
+func (*ApplyCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run executes all logic associated with a single invocation of the
+// `spire-server entry apply` CLI command
+func (a *ApplyCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := a.loadConfig(args); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if err := a.Config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	file, err := a.parseFile(a.Config.Path)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	var owned []string
+	if a.Config.Prune {
+		if len(file.OwnedParentIDs) == 0 {
+			fmt.Printf("%s must list the parent IDs it owns in owned_parent_ids for entries to be pruned\n", a.Config.Path)
+			return 1
+		}
+		owned = file.OwnedParentIDs
+	}
+
+	if a.Client == nil {
+		a.Client, err = util.NewEntryClient(ctx, a.Config.Addr)
+		if err != nil {
+			fmt.Printf("Error creating new entry client: %v\n", err)
+			return 1
+		}
+	}
+
+	resp, err := a.Client.ListEntries(ctx, &entry_pb.ListEntriesRequest{})
+	if err != nil {
+		fmt.Printf("Error fetching entries: %s\n", err)
+		return 1
+	}
+
+	c := diffEntries(file.Entries, resp.Entries, owned)
+	if err := a.applyChanges(ctx, c); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// parseFile reads the file declaring the registration entries, which must be
+// unique. JSON being a subset of YAML, both are parsed alike.
+func (ApplyCLI) parseFile(path string) (*applyFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &applyFile{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", path, err)
+	}
+
+	keys := make(map[string]bool)
+	for _, e := range file.Entries {
+		key := entryKey(e)
+		if keys[key] {
+			return nil, fmt.Errorf("entry for %s with parent ID %s and the same selectors is declared more than once", e.SpiffeId, e.ParentId)
+		}
+		keys[key] = true
+	}
+	return file, nil
+}
+
+// applyChanges creates and updates the entries before deleting the ones no
+// longer declared, so that workloads moving to a new entry are not left
+// without one in between
+func (a *ApplyCLI) applyChanges(ctx context.Context, c *changes) error {
+	verb := func(done, planned string) string {
+		if a.Config.DryRun {
+			return planned
+		}
+		return done
+	}
+	printChange := func(e *common.RegistrationEntry, done, planned string) {
+		if a.Config.Output == cliprinter.JSON {
+			return
+		}
+		fmt.Printf("%s the following entry:\n\n", verb(done, planned))
+		printEntry(e)
+	}
+
+	out := &applyOutput{
+		DryRun:    a.Config.DryRun,
+		Created:   []*common.RegistrationEntry{},
+		Updated:   []*common.RegistrationEntry{},
+		Deleted:   []*common.RegistrationEntry{},
+		Unchanged: c.unchanged,
+	}
+
+	for _, e := range c.create {
+		if !a.Config.DryRun {
+			resp, err := a.Client.CreateEntry(ctx, &entry_pb.CreateEntryRequest{Entry: e})
+			if err != nil {
+				return fmt.Errorf("unable to create entry for %s: %v", e.SpiffeId, err)
+			}
+			e = resp.Entry
+		}
+		out.Created = append(out.Created, e)
+		printChange(e, "Created", "Would create")
+	}
+
+	for _, e := range c.update {
+		if !a.Config.DryRun {
+			resp, err := a.Client.UpdateEntry(ctx, &entry_pb.UpdateEntryRequest{Entry: e})
+			if err != nil {
+				return fmt.Errorf("unable to update entry %s: %v", e.EntryId, err)
+			}
+			e = resp.Entry
+		}
+		out.Updated = append(out.Updated, e)
+		printChange(e, "Updated", "Would update")
+	}
+
+	for _, e := range c.delete {
+		if !a.Config.DryRun {
+			if _, err := a.Client.DeleteEntry(ctx, &entry_pb.DeleteEntryRequest{Id: e.EntryId}); err != nil {
+				return fmt.Errorf("unable to delete entry %s: %v", e.EntryId, err)
+			}
+		}
+		out.Deleted = append(out.Deleted, e)
+		printChange(e, "Deleted", "Would delete")
+	}
+
+	if a.Config.Output == cliprinter.JSON {
+		return cliprinter.PrintJSON(os.Stdout, out)
+	}
+
+	fmt.Printf("%d %s, %d %s, %d %s, %d unchanged\n",
+		len(c.create), verb("created", "to create"),
+		len(c.update), verb("updated", "to update"),
+		len(c.delete), verb("deleted", "to delete"),
+		c.unchanged)
+	return nil
+}
+
+// diffEntries finds the changes needed for the registered entries to match
+// the declared ones. Entries are told apart by their SPIFFE ID, parent ID and
+// selectors; a registered entry declared with other attributes is updated.
+// Registered entries which are not declared are only deleted if their parent
+// ID is one of the owned ones.
+func diffEntries(declared, registered []*common.RegistrationEntry, owned []string) *changes {
+	byKey := make(map[string]*common.RegistrationEntry)
+	for _, e := range registered {
+		byKey[entryKey(e)] = e
+	}
+
+	c := new(changes)
+	for _, e := range declared {
+		key := entryKey(e)
+		existing, ok := byKey[key]
+		switch {
+		case !ok:
+			e.EntryId = ""
+			c.create = append(c.create, e)
+		case !sameAttributes(e, existing):
+			e.EntryId = existing.EntryId
+			e.RotatedAt = existing.RotatedAt
+			c.update = append(c.update, e)
+		default:
+			c.unchanged++
+		}
+		delete(byKey, key)
+	}
+
+	isOwned := make(map[string]bool)
+	for _, id := range owned {
+		isOwned[id] = true
+	}
+	for _, e := range byKey {
+		if isOwned[e.ParentId] {
+			c.delete = append(c.delete, e)
+		}
+	}
+	common_util.SortRegistrationEntries(c.delete)
+	return c
+}
+
+// entryKey identifies an entry by its SPIFFE ID, parent ID and selectors
+func entryKey(e *common.RegistrationEntry) string {
+	var selectors []string
+	for _, s := range e.Selectors {
+		selectors = append(selectors, s.Type+":"+s.Value)
+	}
+	sort.Strings(selectors)
+	return strings.Join(append([]string{e.SpiffeId, e.ParentId}, selectors...), "\n")
+}
+
+// sameAttributes returns true if the entries have the same attributes, apart
+// from the ones identifying them
+func sameAttributes(a, b *common.RegistrationEntry) bool {
+	if a.Ttl != b.Ttl || a.JwtSvidTtl != b.JwtSvidTtl || a.Admin != b.Admin {
+		return false
+	}
+
+	federatesWith := func(e *common.RegistrationEntry) string {
+		ids := append([]string(nil), e.FederatesWith...)
+		sort.Strings(ids)
+		return strings.Join(ids, "\n")
+	}
+	return federatesWith(a) == federatesWith(b)
+}
+
+func (a *ApplyCLI) loadConfig(args []string) error {
+	c := &ApplyConfig{}
+	f := a.flagSet(c)
+
+	err := f.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	a.Config = c
+	return nil
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*ApplyCLI) flagSet(c *ApplyConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("entry apply", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.Path, "f", "", "Path to a file containing registration data in JSON or YAML, declaring the registration entries")
+	f.BoolVar(&c.DryRun, "dryRun", false, "If set, the changes needed are printed but not made")
+	f.BoolVar(&c.Prune, "prune", false, "If set, the registered entries which are not declared are deleted, if the file owns their parent ID")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
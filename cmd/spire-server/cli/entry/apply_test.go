@@ -0,0 +1,202 @@
+package entry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
+	"github.com/stretchr/testify/suite"
+)
+
+const testParentID = "spiffe://example.org/spire/agent/test"
+
+type ApplyTestSuite struct {
+	suite.Suite
+
+	dir        string
+	mockCtrl   *gomock.Controller
+	mockClient *mock_entry.MockEntryClient
+	cli        *ApplyCLI
+}
+
+func TestApplyTestSuite(t *testing.T) {
+	suite.Run(t, new(ApplyTestSuite))
+}
+
+func (s *ApplyTestSuite) SetupTest() {
+	var err error
+	s.dir, err = ioutil.TempDir("", "entry-apply-test")
+	s.Require().NoError(err)
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_entry.NewMockEntryClient(s.mockCtrl)
+	s.cli = &ApplyCLI{Client: s.mockClient}
+}
+
+func (s *ApplyTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	os.RemoveAll(s.dir)
+}
+
+func (s *ApplyTestSuite) TestRun() {
+	unchanged := newEntry("1", "spiffe://example.org/foo", "unix:uid:1000")
+	changed := newEntry("2", "spiffe://example.org/bar", "unix:uid:1001")
+	removed := newEntry("3", "spiffe://example.org/baz", "unix:uid:1002")
+	notOwned := newEntry("5", "spiffe://example.org/quux", "unix:uid:1004")
+	notOwned.ParentId = "spiffe://example.org/other"
+	s.expectList(unchanged, changed, removed, notOwned)
+
+	declaredChanged := newEntry("", "spiffe://example.org/bar", "unix:uid:1001")
+	declaredChanged.Ttl = 60
+	added := newEntry("", "spiffe://example.org/qux", "unix:uid:1003")
+	path := s.writeFile([]string{testParentID}, newEntry("", "spiffe://example.org/foo", "unix:uid:1000"), declaredChanged, added)
+
+	created := newEntry("4", "spiffe://example.org/qux", "unix:uid:1003")
+	s.mockClient.EXPECT().CreateEntry(gomock.Any(), &entry.CreateEntryRequest{Entry: added}).
+		Return(&entry.CreateEntryResponse{Entry: created}, nil)
+
+	updated := newEntry("2", "spiffe://example.org/bar", "unix:uid:1001")
+	updated.Ttl = 60
+	s.mockClient.EXPECT().UpdateEntry(gomock.Any(), &entry.UpdateEntryRequest{Entry: updated}).
+		Return(&entry.UpdateEntryResponse{Entry: updated}, nil)
+
+	s.mockClient.EXPECT().DeleteEntry(gomock.Any(), &entry.DeleteEntryRequest{Id: "3"}).
+		Return(&entry.DeleteEntryResponse{Entry: removed}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-f", path, "-prune"}))
+}
+
+func (s *ApplyTestSuite) TestRunWithoutPrune() {
+	s.expectList(newEntry("1", "spiffe://example.org/foo", "unix:uid:1000"))
+	path := s.writeFile([]string{testParentID}, newEntry("1", "spiffe://example.org/foo", "unix:uid:1000"))
+	s.Require().Equal(0, s.cli.Run([]string{"-f", path}))
+
+	// Undeclared entries are only deleted when pruning
+	s.expectList(newEntry("1", "spiffe://example.org/foo", "unix:uid:1000"))
+	path = s.writeFile([]string{testParentID})
+	s.Require().Equal(0, s.cli.Run([]string{"-f", path}))
+}
+
+func (s *ApplyTestSuite) TestRunPruneRequiresOwnedParentIDs() {
+	path := s.writeFile(nil, newEntry("", "spiffe://example.org/foo", "unix:uid:1000"))
+
+	// No entry is listed, nor changed
+	s.Require().Equal(1, s.cli.Run([]string{"-f", path, "-prune"}))
+}
+
+func (s *ApplyTestSuite) TestRunWithYAML() {
+	s.expectList(newEntry("1", "spiffe://example.org/foo", "unix:uid:1000"))
+	path := filepath.Join(s.dir, "entries.yaml")
+	s.Require().NoError(ioutil.WriteFile(path, []byte(`
+owned_parent_ids:
+- spiffe://example.org/spire/agent/test
+entries:
+- spiffe_id: spiffe://example.org/foo
+  parent_id: spiffe://example.org/spire/agent/test
+  selectors:
+  - type: unix
+    value: uid:1000
+  ttl: 3600
+`), 0600))
+
+	file, err := s.cli.parseFile(path)
+	s.Require().NoError(err)
+	s.Require().Equal(&applyFile{
+		OwnedParentIDs: []string{testParentID},
+		Entries:        []*common.RegistrationEntry{newEntry("", "spiffe://example.org/foo", "unix:uid:1000")},
+	}, file)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-f", path, "-prune"}))
+}
+
+func (s *ApplyTestSuite) TestRunDryRun() {
+	s.expectList(newEntry("1", "spiffe://example.org/foo", "unix:uid:1000"))
+	path := s.writeFile([]string{testParentID}, newEntry("", "spiffe://example.org/bar", "unix:uid:1001"))
+
+	// No changes are made, so no other call is expected
+	s.Require().Equal(0, s.cli.Run([]string{"-f", path, "-dryRun", "-prune"}))
+}
+
+func (s *ApplyTestSuite) TestRunWithJSONOutput() {
+	s.expectList(newEntry("1", "spiffe://example.org/foo", "unix:uid:1000"))
+	path := s.writeFile(nil, newEntry("", "spiffe://example.org/foo", "unix:uid:1000"))
+
+	s.Require().Equal(0, s.cli.Run([]string{"-f", path, "-output", "json"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-f", path, "-output", "yaml"}))
+}
+
+func (s *ApplyTestSuite) TestRunWithDuplicateEntries() {
+	e := newEntry("", "spiffe://example.org/foo", "unix:uid:1000")
+	path := s.writeFile(nil, e, e)
+
+	s.Require().Equal(1, s.cli.Run([]string{"-f", path}))
+}
+
+func (s *ApplyTestSuite) TestRunWithoutFile() {
+	s.Require().Equal(1, s.cli.Run([]string{}))
+}
+
+func (s *ApplyTestSuite) TestDiffEntries() {
+	registered := newEntry("1", "spiffe://example.org/foo", "unix:uid:1000", "unix:gid:1000")
+	registered.FederatesWith = []string{"spiffe://a.org", "spiffe://b.org"}
+	registered.RotatedAt = 1
+
+	// Selectors and trust domains are compared whatever their order
+	declared := newEntry("", "spiffe://example.org/foo", "unix:gid:1000", "unix:uid:1000")
+	declared.FederatesWith = []string{"spiffe://b.org", "spiffe://a.org"}
+	c := diffEntries([]*common.RegistrationEntry{declared}, []*common.RegistrationEntry{registered}, []string{testParentID})
+	s.Require().Equal(&changes{unchanged: 1}, c)
+
+	// Updates keep the ID and rotation time of the registered entry
+	declared.Admin = true
+	c = diffEntries([]*common.RegistrationEntry{declared}, []*common.RegistrationEntry{registered}, []string{testParentID})
+	s.Require().Len(c.update, 1)
+	s.Require().Equal("1", c.update[0].EntryId)
+	s.Require().Equal(int64(1), c.update[0].RotatedAt)
+
+	// Entries with other selectors are different entries
+	declared = newEntry("", "spiffe://example.org/foo", "unix:uid:1000")
+	c = diffEntries([]*common.RegistrationEntry{declared}, []*common.RegistrationEntry{registered}, []string{testParentID})
+	s.Require().Equal([]*common.RegistrationEntry{declared}, c.create)
+	s.Require().Equal([]*common.RegistrationEntry{registered}, c.delete)
+
+	// Undeclared entries are only deleted if their parent ID is owned
+	c = diffEntries([]*common.RegistrationEntry{declared}, []*common.RegistrationEntry{registered}, []string{"spiffe://example.org/other"})
+	s.Require().Equal([]*common.RegistrationEntry{declared}, c.create)
+	s.Require().Empty(c.delete)
+	c = diffEntries([]*common.RegistrationEntry{declared}, []*common.RegistrationEntry{registered}, nil)
+	s.Require().Empty(c.delete)
+}
+
+func (s *ApplyTestSuite) expectList(entries ...*common.RegistrationEntry) {
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), &entry.ListEntriesRequest{}).
+		Return(&entry.ListEntriesResponse{Entries: entries}, nil)
+}
+
+func (s *ApplyTestSuite) writeFile(owned []string, entries ...*common.RegistrationEntry) string {
+	data, err := json.Marshal(&applyFile{OwnedParentIDs: owned, Entries: entries})
+	s.Require().NoError(err)
+	path := filepath.Join(s.dir, "entries.json")
+	s.Require().NoError(ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func newEntry(id, spiffeID string, selectors ...string) *common.RegistrationEntry {
+	e := &common.RegistrationEntry{
+		EntryId:  id,
+		SpiffeId: spiffeID,
+		ParentId: testParentID,
+		Ttl:      3600,
+	}
+	for _, sel := range selectors {
+		selector, _ := parseSelector(sel)
+		e.Selectors = append(e.Selectors, selector)
+	}
+	return e
+}
@@ -0,0 +1,168 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
+
+	"golang.org/x/net/context"
+)
+
+// CountConfig is a configuration struct for the
+// `spire-server entry count` CLI command
+type CountConfig struct {
+	// Address of SPIRE server
+	Addr string
+
+	// Type and value are delimited by a colon (:)
+	// ex. "unix:uid:1000" or "spiffe_id:spiffe://example.org/foo"
+	Selectors SelectorFlag
+
+	ParentID string
+	SpiffeID string
+
+	// SPIFFE IDs of trust domains the entries must federate with
+	FederatesWith StringsFlag
+
+	// Format to print the count in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+}
+
+// Validate ensures that the values in CountConfig are valid
+func (cc *CountConfig) Validate() error {
+	if cc.Addr == "" {
+		return errors.New("a server address is required")
+	}
+
+	return nil
+}
+
+// CountCLI is a struct which represents an invocation of the
+// `spire-server entry count` CLI command
+type CountCLI struct {
+	Client entry_pb.EntryClient
+	Config *CountConfig
+
+	Count int32
+}
+
+// Synopsis prints a description of the CountCLI command
+func (CountCLI) Synopsis() string {
+	return "Counts registration entries"
+}
+
+// Help prints a help message for the CountCLI command
+func (c CountCLI) Help() string {
+	err := c.loadConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (c *CountCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(c.flagSet(&CountConfig{}))
+}
+
+func (*CountCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run executes all logic associated with a single invocation of the
+// `spire-server entry count` CLI command
+func (c *CountCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	if err := c.loadConfig(args); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if err := c.Config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	var err error
+	if c.Client == nil {
+		c.Client, err = util.NewEntryClient(ctx, c.Config.Addr)
+		if err != nil {
+			fmt.Printf("Error creating new entry client: %v\n", err)
+			return 1
+		}
+	}
+
+	if err := c.countEntries(ctx); err != nil {
+		fmt.Printf("Error counting entries: %s\n", err)
+		return 1
+	}
+
+	if c.Config.Output == cliprinter.JSON {
+		// The count is printed even when zero, which the API response omits
+		out := struct {
+			Count int32 `json:"count"`
+		}{c.Count}
+		if err := cliprinter.PrintJSON(os.Stdout, out); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	msg := fmt.Sprintf("Found %v ", c.Count)
+	fmt.Println(util.Pluralizer(msg, "entry", "entries", int(c.Count)))
+	return 0
+}
+
+// countEntries counts the registration entries matching all the configured
+// filters. The entries are filtered by the server, as for `entry show`.
+func (c *CountCLI) countEntries(ctx context.Context) error {
+	req := &entry_pb.CountEntriesRequest{
+		ByParentId:      c.Config.ParentID,
+		BySpiffeId:      c.Config.SpiffeID,
+		ByFederatesWith: c.Config.FederatesWith,
+	}
+	for _, sel := range c.Config.Selectors {
+		selector, err := parseSelector(sel)
+		if err != nil {
+			return err
+		}
+		req.WithSelectors = append(req.WithSelectors, selector)
+	}
+
+	resp, err := c.Client.CountEntries(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	c.Count = resp.Count
+	return nil
+}
+
+func (c *CountCLI) loadConfig(args []string) error {
+	config := &CountConfig{}
+	f := c.flagSet(config)
+
+	err := f.Parse(args)
+	if err != nil {
+		return err
+	}
+	c.Config = config
+	return nil
+}
+
+// flagSet defines the flags of the command, storing their values in config
+func (*CountCLI) flagSet(config *CountConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("entry count", flag.ContinueOnError)
+	f.StringVar(&config.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&config.ParentID, "parentID", "", "The Parent ID of the records to count")
+	f.StringVar(&config.SpiffeID, "spiffeID", "", "The SPIFFE ID of the records to count")
+	cliprinter.AppendFlag(f, &config.Output)
+
+	f.Var(&config.Selectors, "selector", "A colon-delimeted type:value selector the records to count must have. Can be used more than once")
+	f.Var(&config.FederatesWith, "federatesWith", "SPIFFE ID of a trust domain the records to count must federate with. Can be used more than once")
+	return f
+}
@@ -0,0 +1,75 @@
+package entry
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
+	"github.com/stretchr/testify/suite"
+)
+
+type CountTestSuite struct {
+	suite.Suite
+
+	mockCtrl   *gomock.Controller
+	mockClient *mock_entry.MockEntryClient
+	cli        *CountCLI
+}
+
+func TestCountTestSuite(t *testing.T) {
+	suite.Run(t, new(CountTestSuite))
+}
+
+func (s *CountTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_entry.NewMockEntryClient(s.mockCtrl)
+	s.cli = &CountCLI{Client: s.mockClient}
+}
+
+func (s *CountTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *CountTestSuite) TestRunWithoutFilters() {
+	s.mockClient.EXPECT().CountEntries(gomock.Any(), &entry.CountEntriesRequest{}).
+		Return(&entry.CountEntriesResponse{Count: 4}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Assert().Equal(int32(4), s.cli.Count)
+}
+
+func (s *CountTestSuite) TestRunWithFilters() {
+	args := []string{
+		"-parentID", "spiffe://example.org/father",
+		"-spiffeID", "spiffe://example.org/son",
+		"-selector", "unix:uid:1000",
+		"-federatesWith", "spiffe://partner.org",
+		"-output", "json",
+	}
+
+	req := &entry.CountEntriesRequest{
+		ByParentId:      "spiffe://example.org/father",
+		BySpiffeId:      "spiffe://example.org/son",
+		WithSelectors:   []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		ByFederatesWith: []string{"spiffe://partner.org"},
+	}
+	s.mockClient.EXPECT().CountEntries(gomock.Any(), req).
+		Return(&entry.CountEntriesResponse{}, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(int32(0), s.cli.Count)
+}
+
+func (s *CountTestSuite) TestRunWithInvalidSelector() {
+	s.Require().Equal(1, s.cli.Run([]string{"-selector", "unix"}))
+}
+
+func (s *CountTestSuite) TestRunWithError() {
+	s.mockClient.EXPECT().CountEntries(gomock.Any(), &entry.CountEntriesRequest{}).
+		Return(nil, errors.New("datastore unavailable"))
+
+	s.Require().Equal(1, s.cli.Run([]string{}))
+}
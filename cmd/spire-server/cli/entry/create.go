@@ -6,12 +6,16 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"os"
 
+	"github.com/posener/complete"
 	"github.com/spiffe/spire/cmd/spire-server/util"
-	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/proto/common"
 
 	"golang.org/x/net/context"
+
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
 )
 
 type CreateConfig struct {
@@ -29,6 +33,28 @@ type CreateConfig struct {
 	ParentID string
 	SpiffeID string
 	Ttl      int
+
+	// TTL, in seconds, of the JWT-SVIDs issued for the entry. Zero means
+	// the server default is used.
+	JWTSVIDTtl int
+
+	// List of SPIFFE IDs of trust domains the entry federates with
+	FederatesWith StringsFlag
+
+	// Whether or not the entry is for an admin workload
+	Admin bool
+
+	// If set, entries are validated by the server but not created
+	DryRun bool
+
+	// Format to print the entries in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+}
+
+// validation is the outcome of validating an entry, as printed in JSON
+type validation struct {
+	Entry *common.RegistrationEntry `json:"entry"`
+	*entry_pb.ValidateEntryResponse
 }
 
 // Perform basic validation, even on fields that we
@@ -59,6 +85,10 @@ func (rc *CreateConfig) Validate() error {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTtl < 0 {
+		return errors.New("the JWT-SVID TTL cannot be negative")
+	}
+
 	return nil
 }
 
@@ -73,6 +103,14 @@ func (c CreateCLI) Help() string {
 	return err.Error()
 }
 
+func (c CreateCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(c.flagSet(&CreateConfig{}))
+}
+
+func (CreateCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (c CreateCLI) Run(args []string) int {
 	ctx := context.Background()
 
@@ -98,13 +136,26 @@ func (c CreateCLI) Run(args []string) int {
 		return 1
 	}
 
-	cl, err := util.NewRegistrationClient(ctx, config.Addr)
+	// Entries are validated the same way whether they are created or not
+	ec, err := util.NewEntryClient(ctx, config.Addr)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	err = c.registerEntries(ctx, cl, entries)
+	if config.DryRun {
+		valid, err := c.validateEntries(ctx, ec, entries, config.Output)
+		if err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		if !valid {
+			return 1
+		}
+		return 0
+	}
+
+	err = c.registerEntries(ctx, ec, entries, config.Output)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
@@ -116,9 +167,11 @@ func (c CreateCLI) Run(args []string) int {
 // parseConfig builds a registration entry from the given config
 func (c CreateCLI) parseConfig(config *CreateConfig) ([]*common.RegistrationEntry, error) {
 	e := &common.RegistrationEntry{
-		ParentId: config.ParentID,
-		SpiffeId: config.SpiffeID,
-		Ttl:      int32(config.Ttl),
+		ParentId:   config.ParentID,
+		SpiffeId:   config.SpiffeID,
+		Ttl:        int32(config.Ttl),
+		JwtSvidTtl: int32(config.JWTSVIDTtl),
+		Admin:      config.Admin,
 	}
 
 	selectors := []*common.Selector{}
@@ -132,6 +185,7 @@ func (c CreateCLI) parseConfig(config *CreateConfig) ([]*common.RegistrationEntr
 	}
 
 	e.Selectors = selectors
+	e.FederatesWith = config.FederatesWith
 	return []*common.RegistrationEntry{e}, nil
 }
 
@@ -147,34 +201,87 @@ func (CreateCLI) parseFile(path string) ([]*common.RegistrationEntry, error) {
 	return entries.Entries, nil
 }
 
-func (CreateCLI) registerEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry) error {
-	for _, e := range entries {
-		id, err := c.CreateEntry(ctx, e)
+func (CreateCLI) registerEntries(ctx context.Context, c entry_pb.EntryClient, entries []*common.RegistrationEntry, output string) error {
+	for i, e := range entries {
+		resp, err := c.CreateEntry(ctx, &entry_pb.CreateEntryRequest{Entry: e})
 		if err != nil {
 			fmt.Println("FAILED to create the following entry:")
 			printEntry(e)
 			return err
 		}
 
-		e.EntryId = id.Id
-		printEntry(e)
+		entries[i] = resp.Entry
+		if output != cliprinter.JSON {
+			printEntry(resp.Entry)
+		}
 	}
 
+	if output == cliprinter.JSON {
+		// Same format as the data file, with the IDs of the entries created
+		return cliprinter.PrintJSON(os.Stdout, &common.RegistrationEntries{Entries: entries})
+	}
 	return nil
 }
 
-func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
-	f := flag.NewFlagSet("entry create", flag.ContinueOnError)
-	c := &CreateConfig{}
+// validateEntries asks the server to validate the entries without creating
+// them, and prints the outcome. It returns false if any entry is invalid.
+func (CreateCLI) validateEntries(ctx context.Context, c entry_pb.EntryClient, entries []*common.RegistrationEntry, output string) (bool, error) {
+	valid := true
+	validations := []validation{}
+	for _, e := range entries {
+		resp, err := c.ValidateEntry(ctx, &entry_pb.ValidateEntryRequest{Entry: e})
+		if err != nil {
+			return false, err
+		}
+
+		if output == cliprinter.JSON {
+			valid = valid && len(resp.Problems) == 0
+			validations = append(validations, validation{Entry: e, ValidateEntryResponse: resp})
+			continue
+		}
+
+		if len(resp.Problems) > 0 {
+			valid = false
+			fmt.Println("INVALID entry:")
+		} else {
+			fmt.Println("Valid entry:")
+		}
+		printEntry(e)
+		printValidation(resp)
+	}
 
+	if output == cliprinter.JSON {
+		out := map[string]interface{}{"valid": valid, "validations": validations}
+		if err := cliprinter.PrintJSON(os.Stdout, out); err != nil {
+			return false, err
+		}
+	}
+	return valid, nil
+}
+
+func (c CreateCLI) newConfig(args []string) (*CreateConfig, error) {
+	config := &CreateConfig{}
+	f := c.flagSet(config)
+
+	return config, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (CreateCLI) flagSet(c *CreateConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("entry create", flag.ContinueOnError)
 	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
 	f.StringVar(&c.ParentID, "parentID", "", "The SPIFFE ID of this record's parent")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID that this record represents")
-	f.IntVar(&c.Ttl, "ttl", 3600, "A TTL, in seconds, for any SVID issued as a result of this record")
+	f.IntVar(&c.Ttl, "ttl", 3600, "A TTL, in seconds, for any X509-SVID issued as a result of this record")
+	f.IntVar(&c.JWTSVIDTtl, "jwtSVIDTTL", 0, "A TTL, in seconds, for any JWT-SVID issued as a result of this record. Defaults to the server default")
+
+	f.BoolVar(&c.Admin, "admin", false, "If set, the SPIFFE ID in this entry will be granted access to the Registration API")
 
 	f.StringVar(&c.Path, "data", "", "Path to a file containing registration JSON (optional)")
+	f.BoolVar(&c.DryRun, "dryRun", false, "If set, entries are validated by the server and the agents they would match are reported, but they are not created")
+	cliprinter.AppendFlag(f, &c.Output)
 
 	f.Var(&c.Selectors, "selector", "A colon-delimeted type:value selector. Can be used more than once")
-
-	return c, f.Parse(args)
+	f.Var(&c.FederatesWith, "federatesWith", "SPIFFE ID of a trust domain to federate with. Can be used more than once")
+	return f
 }
@@ -1,38 +1,50 @@
 package entry
 
 import (
+	"errors"
 	"path"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
 
 	cmdutil "github.com/spiffe/spire/cmd/spire-server/util"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
 )
 
 // TODO: Test additional scenarios
 func TestCreateParseConfig(t *testing.T) {
 	c := &CreateConfig{
-		Addr:      cmdutil.DefaultServerAddr,
-		ParentID:  "spiffe://example.org/foo",
-		SpiffeID:  "spiffe://example.org/bar",
-		Ttl:       60,
-		Selectors: SelectorFlag{"unix:uid:1000", "unix:gid:1000"},
+		Addr:          cmdutil.DefaultServerAddr,
+		ParentID:      "spiffe://example.org/foo",
+		SpiffeID:      "spiffe://example.org/bar",
+		Ttl:           60,
+		JWTSVIDTtl:    30,
+		Selectors:     SelectorFlag{"unix:uid:1000", "unix:gid:1000"},
+		FederatesWith: StringsFlag{"spiffe://otherdomain.org"},
+		Admin:         true,
 	}
 
 	entries, err := CreateCLI{}.parseConfig(c)
 	require.NoError(t, err)
 
 	expectedEntry := &common.RegistrationEntry{
-		ParentId: "spiffe://example.org/foo",
-		SpiffeId: "spiffe://example.org/bar",
-		Ttl:      60,
+		ParentId:   "spiffe://example.org/foo",
+		SpiffeId:   "spiffe://example.org/bar",
+		Ttl:        60,
+		JwtSvidTtl: 30,
 		Selectors: []*common.Selector{
 			{Type: "unix", Value: "uid:1000"},
 			{Type: "unix", Value: "gid:1000"},
 		},
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+		Admin:         true,
 	}
 
 	expectedEntries := []*common.RegistrationEntry{expectedEntry}
@@ -85,3 +97,49 @@ func TestRegisterParseSelector(t *testing.T) {
 	_, err = parseSelector(str)
 	assert.NotNil(t, err)
 }
+
+func TestCreateRegisterEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_entry.NewMockEntryClient(ctrl)
+	entries := util.GetRegistrationEntries("good.json")[:1]
+
+	created := *entries[0]
+	created.EntryId = "00000000-0000-0000-0000-000000000000"
+	client.EXPECT().CreateEntry(gomock.Any(), &entry_pb.CreateEntryRequest{Entry: entries[0]}).
+		Return(&entry_pb.CreateEntryResponse{Entry: &created}, nil)
+	err := CreateCLI{}.registerEntries(context.Background(), client, entries, cliprinter.Pretty)
+	require.NoError(t, err)
+	assert.Equal(t, created.EntryId, entries[0].EntryId)
+
+	client.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	err = CreateCLI{}.registerEntries(context.Background(), client, entries, cliprinter.Pretty)
+	require.EqualError(t, err, "oh no")
+}
+
+func TestCreateValidateEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_entry.NewMockEntryClient(ctrl)
+	entries := util.GetRegistrationEntries("good.json")
+
+	client.EXPECT().ValidateEntry(gomock.Any(), &entry_pb.ValidateEntryRequest{Entry: entries[0]}).
+		Return(&entry_pb.ValidateEntryResponse{MatchingAgents: []string{entries[0].ParentId}}, nil)
+	client.EXPECT().ValidateEntry(gomock.Any(), &entry_pb.ValidateEntryRequest{Entry: entries[1]}).
+		Return(&entry_pb.ValidateEntryResponse{}, nil)
+	valid, err := CreateCLI{}.validateEntries(context.Background(), client, entries, cliprinter.Pretty)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	client.EXPECT().ValidateEntry(gomock.Any(), gomock.Any()).
+		Return(&entry_pb.ValidateEntryResponse{Problems: []string{"entry already exists"}}, nil)
+	valid, err = CreateCLI{}.validateEntries(context.Background(), client, entries[:1], cliprinter.JSON)
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	client.EXPECT().ValidateEntry(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	_, err = CreateCLI{}.validateEntries(context.Background(), client, entries[:1], cliprinter.Pretty)
+	require.EqualError(t, err, "oh no")
+}
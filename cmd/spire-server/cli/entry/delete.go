@@ -4,9 +4,13 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
 
+	"github.com/posener/complete"
 	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
 
 	"golang.org/x/net/context"
 )
@@ -17,6 +21,9 @@ type DeleteConfig struct {
 
 	// ID of the record to delete
 	EntryID string
+
+	// Format to print the entry in, cliprinter.Pretty or cliprinter.JSON
+	Output string
 }
 
 // Perform basic validation
@@ -43,6 +50,14 @@ func (d DeleteCLI) Help() string {
 	return err.Error()
 }
 
+func (d DeleteCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(d.flagSet(&DeleteConfig{}))
+}
+
+func (DeleteCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (d DeleteCLI) Run(args []string) int {
 	ctx := context.Background()
 
@@ -68,19 +83,33 @@ func (d DeleteCLI) Run(args []string) int {
 		return d.printErr(err)
 	}
 
+	if config.Output == cliprinter.JSON {
+		// Same format as the data file taken by `spire-server entry create`
+		if err := cliprinter.PrintJSON(os.Stdout, &common.RegistrationEntries{Entries: []*common.RegistrationEntry{e}}); err != nil {
+			return d.printErr(err)
+		}
+		return 0
+	}
+
 	fmt.Printf("Deleted the following entry:\n\n")
 	printEntry(e)
 	return 0
 }
 
-func (DeleteCLI) newConfig(args []string) (*DeleteConfig, error) {
-	f := flag.NewFlagSet("entry delete", flag.ContinueOnError)
+func (d DeleteCLI) newConfig(args []string) (*DeleteConfig, error) {
 	c := &DeleteConfig{}
+	f := d.flagSet(c)
+
+	return c, f.Parse(args)
+}
 
+// flagSet defines the flags of the command, storing their values in c
+func (DeleteCLI) flagSet(c *DeleteConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("entry delete", flag.ContinueOnError)
 	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
 	f.StringVar(&c.EntryID, "entryID", "", "The Registration Entry ID of the record to delete")
-
-	return c, f.Parse(args)
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
 }
 
 func (DeleteCLI) printErr(err error) int {
@@ -0,0 +1,118 @@
+package entry
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/common"
+
+	"golang.org/x/net/context"
+)
+
+type RotateConfig struct {
+	// Address of SPIRE server
+	Addr string
+
+	// ID of the record to rotate the SVIDs of
+	EntryID string
+
+	// Format to print the entry in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+}
+
+// Perform basic validation
+func (rc *RotateConfig) Validate() error {
+	if rc.Addr == "" {
+		return errors.New("a server address is required")
+	}
+
+	if rc.EntryID == "" {
+		return errors.New("an entry ID is required")
+	}
+
+	return nil
+}
+
+type RotateCLI struct{}
+
+func (RotateCLI) Synopsis() string {
+	return "Forces the rotation of the SVIDs of a registration entry"
+}
+
+func (r RotateCLI) Help() string {
+	_, err := r.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (r RotateCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(r.flagSet(&RotateConfig{}))
+}
+
+func (RotateCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (r RotateCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := r.newConfig(args)
+	if err != nil {
+		return r.printErr(err)
+	}
+
+	if err = config.Validate(); err != nil {
+		return r.printErr(err)
+	}
+
+	cl, err := util.NewRegistrationClient(ctx, config.Addr)
+	if err != nil {
+		return r.printErr(err)
+	}
+
+	req := &registration.RegistrationEntryID{
+		Id: config.EntryID,
+	}
+	e, err := cl.RotateEntrySVIDs(ctx, req)
+	if err != nil {
+		return r.printErr(err)
+	}
+
+	if config.Output == cliprinter.JSON {
+		// Same format as the data file taken by `spire-server entry create`
+		if err := cliprinter.PrintJSON(os.Stdout, &common.RegistrationEntries{Entries: []*common.RegistrationEntry{e}}); err != nil {
+			return r.printErr(err)
+		}
+		return 0
+	}
+
+	fmt.Printf("Forced the rotation of the SVIDs of the following entry:\n\n")
+	printEntry(e)
+	return 0
+}
+
+func (r RotateCLI) newConfig(args []string) (*RotateConfig, error) {
+	c := &RotateConfig{}
+	f := r.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (RotateCLI) flagSet(c *RotateConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("entry rotate", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.EntryID, "entryID", "", "The Registration Entry ID of the record to rotate the SVIDs of")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
+
+func (RotateCLI) printErr(err error) int {
+	fmt.Println(err.Error())
+	return 1
+}
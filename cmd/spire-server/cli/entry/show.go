@@ -4,9 +4,12 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"os"
 
+	"github.com/posener/complete"
 	"github.com/spiffe/spire/cmd/spire-server/util"
-	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
 	"github.com/spiffe/spire/proto/common"
 
 	"golang.org/x/net/context"
@@ -25,13 +28,19 @@ type ShowConfig struct {
 	EntryID  string
 	ParentID string
 	SpiffeID string
+
+	// SPIFFE IDs of trust domains the entries must federate with
+	FederatesWith StringsFlag
+
+	// Format to print the entries in, cliprinter.Pretty or cliprinter.JSON
+	Output string
 }
 
 // Validate ensures that the values in ShowConfig are valid
 func (sc *ShowConfig) Validate() error {
 	// If entryID is given, it should be the only constraint
 	if sc.EntryID != "" {
-		if sc.ParentID != "" || sc.SpiffeID != "" || len(sc.Selectors) > 0 {
+		if sc.ParentID != "" || sc.SpiffeID != "" || len(sc.Selectors) > 0 || len(sc.FederatesWith) > 0 {
 			return errors.New("The -entryID flag can't be combined with others")
 		}
 	}
@@ -42,7 +51,7 @@ func (sc *ShowConfig) Validate() error {
 // ShowCLI is a struct which represents an invocation of the
 // `spire-server entry show` CLI command
 type ShowCLI struct {
-	Client registration.RegistrationClient
+	Client entry_pb.EntryClient
 	Config *ShowConfig
 
 	Entries []*common.RegistrationEntry
@@ -59,6 +68,14 @@ func (s ShowCLI) Help() string {
 	return err.Error()
 }
 
+func (s *ShowCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(s.flagSet(&ShowConfig{}))
+}
+
+func (*ShowCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 // Run executes all logic associated with a single invocation of the
 // `spire-server entry show` CLI command
 func (s *ShowCLI) Run(args []string) int {
@@ -70,171 +87,83 @@ func (s *ShowCLI) Run(args []string) int {
 		return 1
 	}
 
+	if err = s.Config.Validate(); err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
 	if s.Client == nil {
-		s.Client, err = util.NewRegistrationClient(ctx, s.Config.Addr)
+		s.Client, err = util.NewEntryClient(ctx, s.Config.Addr)
 		if err != nil {
-			fmt.Printf("Error creating new registration client: %v", err)
+			fmt.Printf("Error creating new entry client: %v", err)
 			return 1
 		}
 	}
 
-	// If an Entry ID was specified, look it up directly then exit
+	// If an Entry ID was specified, look it up directly
 	if s.Config.EntryID != "" {
 		err = s.fetchByEntryID(ctx, s.Config.EntryID)
 		if err != nil {
 			fmt.Printf("Error fetching entry ID %s: %s\n", s.Config.EntryID, err)
 			return 1
 		}
-
-		s.printEntries()
-		return 0
-	}
-
-	// If we didn't get any args, fetch everything then exit
-	if s.Config.ParentID == "" && s.Config.SpiffeID == "" && len(s.Config.Selectors) == 0 {
-		err = s.fetchAllEntries(ctx)
+	} else {
+		err = s.listEntries(ctx)
 		if err != nil {
 			fmt.Printf("Error fetching entries: %s\n", err)
 			return 1
 		}
-
-		s.printEntries()
-		return 0
-	}
-
-	// Fetch all records matching each constraint, then find and
-	// print the intersection at the end.
-	err = s.fetchByParentID(ctx)
-	if err != nil {
-		fmt.Printf("Error fetching by parent ID: %s", err)
-		return 1
-	}
-
-	err = s.fetchBySpiffeID(ctx)
-	if err != nil {
-		fmt.Printf("Error fetching by SPIFFE ID: %s", err)
-		return 1
 	}
 
-	err = s.fetchBySelectors(ctx)
-	if err != nil {
-		fmt.Printf("Error fetching by selectors: %s", err)
+	if err = s.printEntries(); err != nil {
+		fmt.Println(err.Error())
 		return 1
 	}
-
-	s.filterEntries()
-	s.printEntries()
 	return 0
 }
 
-func (s *ShowCLI) fetchAllEntries(ctx context.Context) error {
-	var err error
-	entries, err := s.Client.FetchEntries(ctx, &common.Empty{})
-	if err != nil {
-		return err
-	}
-
-	s.Entries = entries.Entries
-	return nil
-}
-
 // fetchByEntryID uses the configured EntryID to fetch the appropriate registration entry
 func (s *ShowCLI) fetchByEntryID(ctx context.Context, id string) error {
-	regID := &registration.RegistrationEntryID{Id: id}
-	entry, err := s.Client.FetchEntry(ctx, regID)
+	resp, err := s.Client.GetEntry(ctx, &entry_pb.GetEntryRequest{Id: id})
 	if err != nil {
 		return err
 	}
 
-	s.Entries = []*common.RegistrationEntry{entry}
+	s.Entries = []*common.RegistrationEntry{resp.Entry}
 	return nil
 }
 
-// fetchByParentID appends registration entries which match the configured
-// Parent ID to `entries`
-func (s *ShowCLI) fetchByParentID(ctx context.Context) error {
-	if s.Config.ParentID != "" {
-		parentID := &registration.ParentID{Id: s.Config.ParentID}
-		entries, err := s.Client.ListByParentID(ctx, parentID)
-		if err != nil {
-			return err
-		}
-
-		s.Entries = append(s.Entries, entries.Entries...)
-	}
-
-	return nil
-}
-
-// fetchBySpiffeID appends registration entries which match the configured
-// SPIFFE ID to `entries`
-func (s *ShowCLI) fetchBySpiffeID(ctx context.Context) error {
-	if s.Config.SpiffeID != "" {
-		spiffeID := &registration.SpiffeID{Id: s.Config.SpiffeID}
-		entries, err := s.Client.ListBySpiffeID(ctx, spiffeID)
-		if err != nil {
-			return err
-		}
-
-		s.Entries = append(s.Entries, entries.Entries...)
+// listEntries fetches the registration entries matching all the configured
+// filters. The filtering and sorting of the entries is done by the server.
+func (s *ShowCLI) listEntries(ctx context.Context) error {
+	req := &entry_pb.ListEntriesRequest{
+		ByParentId:      s.Config.ParentID,
+		BySpiffeId:      s.Config.SpiffeID,
+		ByFederatesWith: s.Config.FederatesWith,
 	}
-
-	return nil
-}
-
-// fetchBySelectors fetches all registration entries containing the full
-// set of configured selectors, appending them to `entries`
-func (s *ShowCLI) fetchBySelectors(ctx context.Context) error {
 	for _, sel := range s.Config.Selectors {
 		selector, err := parseSelector(sel)
 		if err != nil {
 			return err
 		}
+		req.WithSelectors = append(req.WithSelectors, selector)
+	}
 
-		entries, err := s.Client.ListBySelector(ctx, selector)
-		if err != nil {
-			return err
-		}
-
-		s.Entries = append(s.Entries, entries.Entries...)
+	resp, err := s.Client.ListEntries(ctx, req)
+	if err != nil {
+		return err
 	}
 
+	s.Entries = resp.Entries
 	return nil
 }
 
-// filterEntries evicts any entries from the stored slice which
-// do not match every selector specified by the user
-func (s *ShowCLI) filterEntries() {
-	newSlice := []*common.RegistrationEntry{}
-	// Map used to skip duplicated entries.
-	matchingEntries := map[string]*common.RegistrationEntry{}
-	for _, e := range s.Entries {
-		match, _ := hasSelectors(e, s.Config.Selectors)
-		if !match {
-			continue
-		}
-
-		// If SpiffeID was specified, discard entries that don't match.
-		if s.Config.SpiffeID != "" && e.SpiffeId != s.Config.SpiffeID {
-			continue
-		}
-
-		// If ParentID was specified, discard entries that don't match.
-		if s.Config.ParentID != "" && e.ParentId != s.Config.ParentID {
-			continue
-		}
-
-		// If this entry wasn't matched before, save it.
-		if _, ok := matchingEntries[e.EntryId]; !ok {
-			matchingEntries[e.EntryId] = e
-			newSlice = append(newSlice, e)
-		}
+func (s *ShowCLI) printEntries() error {
+	if s.Config.Output == cliprinter.JSON {
+		// Same format as the data file taken by `spire-server entry create`
+		return cliprinter.PrintJSON(os.Stdout, &common.RegistrationEntries{Entries: s.Entries})
 	}
 
-	s.Entries = newSlice
-}
-
-func (s *ShowCLI) printEntries() {
 	msg := fmt.Sprintf("Found %v ", len(s.Entries))
 	msg = util.Pluralizer(msg, "entry", "entries", len(s.Entries))
 
@@ -242,18 +171,12 @@ func (s *ShowCLI) printEntries() {
 	for _, e := range s.Entries {
 		printEntry(e)
 	}
+	return nil
 }
 
 func (s *ShowCLI) loadConfig(args []string) error {
-	f := flag.NewFlagSet("entry show", flag.ContinueOnError)
 	c := &ShowConfig{}
-
-	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
-	f.StringVar(&c.EntryID, "entryID", "", "The Entry ID of the records to show")
-	f.StringVar(&c.ParentID, "parentID", "", "The Parent ID of the records to show")
-	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the records to show")
-
-	f.Var(&c.Selectors, "selector", "A colon-delimeted type:value selector. Can be used more than once")
+	f := s.flagSet(c)
 
 	err := f.Parse(args)
 	if err != nil {
@@ -262,3 +185,17 @@ func (s *ShowCLI) loadConfig(args []string) error {
 	s.Config = c
 	return nil
 }
+
+// flagSet defines the flags of the command, storing their values in c
+func (*ShowCLI) flagSet(c *ShowConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("entry show", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.EntryID, "entryID", "", "The Entry ID of the records to show")
+	f.StringVar(&c.ParentID, "parentID", "", "The Parent ID of the records to show")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the records to show")
+	cliprinter.AppendFlag(f, &c.Output)
+
+	f.Var(&c.Selectors, "selector", "A colon-delimeted type:value selector the records to show must have. Can be used more than once")
+	f.Var(&c.FederatesWith, "federatesWith", "SPIFFE ID of a trust domain the records to show must federate with. Can be used more than once")
+	return f
+}
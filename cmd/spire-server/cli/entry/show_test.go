@@ -4,9 +4,9 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
-	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/api/v1/entry"
 	"github.com/spiffe/spire/proto/common"
-	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -14,14 +14,14 @@ type ShowTestSuite struct {
 	suite.Suite
 
 	cli        *ShowCLI
-	mockClient *mock_registration.MockRegistrationClient
+	mockClient *mock_entry.MockEntryClient
 }
 
 func (suite *ShowTestSuite) SetupTest() {
 	mockCtrl := gomock.NewController(suite.T())
 	defer mockCtrl.Finish()
 
-	suite.mockClient = mock_registration.NewMockRegistrationClient(mockCtrl)
+	suite.mockClient = mock_entry.NewMockEntryClient(mockCtrl)
 
 	cli := &ShowCLI{
 		Config:  new(ShowConfig),
@@ -43,14 +43,36 @@ func (s *ShowTestSuite) TestRunWithEntryID() {
 		entryID,
 	}
 
-	req := &registration.RegistrationEntryID{Id: entryID}
-	resp := s.registrationEntries(1)[0]
-	s.mockClient.EXPECT().FetchEntry(gomock.Any(), req).Return(resp, nil)
+	req := &entry.GetEntryRequest{Id: entryID}
+	resp := &entry.GetEntryResponse{Entry: s.registrationEntries(1)[0]}
+	s.mockClient.EXPECT().GetEntry(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 	s.Assert().Equal(s.registrationEntries(1), s.cli.Entries)
 }
 
+func (s *ShowTestSuite) TestRunWithEntryIDAndFilters() {
+	args := []string{
+		"-entryID",
+		"123456",
+		"-federatesWith",
+		"spiffe://partner.org",
+	}
+
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *ShowTestSuite) TestRunWithoutFilters() {
+	entries := s.registrationEntries(4)
+
+	req := &entry.ListEntriesRequest{}
+	resp := &entry.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Assert().Equal(entries, s.cli.Entries)
+}
+
 func (s *ShowTestSuite) TestRunWithParentID() {
 	entries := s.registrationEntries(2)
 
@@ -59,9 +81,9 @@ func (s *ShowTestSuite) TestRunWithParentID() {
 		entries[0].ParentId,
 	}
 
-	req := &registration.ParentID{Id: entries[0].ParentId}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListByParentID(gomock.Any(), req).Return(resp, nil)
+	req := &entry.ListEntriesRequest{ByParentId: entries[0].ParentId}
+	resp := &entry.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 	s.Assert().Equal(entries, s.cli.Entries)
@@ -69,79 +91,84 @@ func (s *ShowTestSuite) TestRunWithParentID() {
 
 func (s *ShowTestSuite) TestRunWithSpiffeID() {
 	entries := s.registrationEntries(1)
-	entry := entries[0]
+	e := entries[0]
 
 	args := []string{
 		"-spiffeID",
-		entry.SpiffeId,
+		e.SpiffeId,
 	}
 
-	req := &registration.SpiffeID{Id: entry.SpiffeId}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListBySpiffeID(gomock.Any(), req).Return(resp, nil)
+	req := &entry.ListEntriesRequest{BySpiffeId: e.SpiffeId}
+	resp := &entry.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 	s.Assert().Equal(entries, s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithSelector() {
-	entries := s.registrationEntries(2)
+func (s *ShowTestSuite) TestRunWithSelectors() {
+	entries := s.registrationEntries(2)[1:2]
 
 	args := []string{
 		"-selector",
 		"foo:bar",
+		"-selector",
+		"bar:baz",
 	}
 
-	req := &common.Selector{Type: "foo", Value: "bar"}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
+	req := &entry.ListEntriesRequest{
+		WithSelectors: []*common.Selector{
+			{Type: "foo", Value: "bar"},
+			{Type: "bar", Value: "baz"},
+		},
+	}
+	resp := &entry.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 	s.Assert().Equal(entries, s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithSelectors() {
-	entries := s.registrationEntries(2)
+func (s *ShowTestSuite) TestRunWithAllFilters() {
+	entries := s.registrationEntries(3)[2:3]
 
 	args := []string{
-		"-selector",
-		"foo:bar",
+		"-parentID",
+		entries[0].ParentId,
+		"-spiffeID",
+		entries[0].SpiffeId,
 		"-selector",
 		"bar:baz",
+		"-federatesWith",
+		"spiffe://partner.org",
 	}
 
-	req := &common.Selector{Type: "foo", Value: "bar"}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
-
-	req = &common.Selector{Type: "bar", Value: "baz"}
-	resp.Entries = entries[1:2]
-	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
+	req := &entry.ListEntriesRequest{
+		ByParentId:      entries[0].ParentId,
+		BySpiffeId:      entries[0].SpiffeId,
+		WithSelectors:   []*common.Selector{{Type: "bar", Value: "baz"}},
+		ByFederatesWith: []string{"spiffe://partner.org"},
+	}
+	resp := &entry.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
-	s.Assert().Equal(entries[1:2], s.cli.Entries)
+	s.Assert().Equal(entries, s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithParentIDAndSelectors() {
-	entries := s.registrationEntries(4)[2:4]
-
-	args := []string{
-		"-parentID",
-		entries[0].ParentId,
-		"-selector",
-		"bar:baz",
-	}
+func (s *ShowTestSuite) TestRunWithJSONOutput() {
+	entries := s.registrationEntries(2)
 
-	req1 := &registration.ParentID{Id: entries[0].ParentId}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListByParentID(gomock.Any(), req1).Return(resp, nil)
+	req := &entry.ListEntriesRequest{}
+	resp := &entry.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
-	req2 := &common.Selector{Type: "bar", Value: "baz"}
-	resp = &common.RegistrationEntries{Entries: entries[0:1]}
-	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req2).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{"-output", "json"}))
+	s.Assert().Equal(entries, s.cli.Entries)
+}
 
-	s.Require().Equal(0, s.cli.Run(args))
-	s.Assert().Equal(entries[0:1], s.cli.Entries)
+func (s *ShowTestSuite) TestRunWithInvalidOutput() {
+	s.Require().Equal(1, s.cli.Run([]string{"-output", "yaml"}))
 }
 
 // registrationEntries returns `count` registration entry records. At most 4.
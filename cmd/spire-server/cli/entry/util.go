@@ -3,8 +3,12 @@ package entry
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/proto/common"
+
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
 )
 
 // hasSelectors takes a registration entry and a selector flag set. It returns
@@ -61,11 +65,42 @@ func printEntry(e *common.RegistrationEntry) {
 	fmt.Printf("SPIFFE ID:\t%s\n", e.SpiffeId)
 	fmt.Printf("Parent ID:\t%s\n", e.ParentId)
 	fmt.Printf("TTL:\t\t%v\n", e.Ttl)
+	if e.JwtSvidTtl != 0 {
+		fmt.Printf("JWT-SVID TTL:\t%v\n", e.JwtSvidTtl)
+	}
+	if e.Admin {
+		fmt.Printf("Admin:\t\t%t\n", e.Admin)
+	}
+	if e.RotatedAt != 0 {
+		fmt.Printf("Rotated At:\t%s\n", time.Unix(e.RotatedAt, 0).UTC().Format(time.RFC3339))
+	}
 
 	for _, s := range e.Selectors {
 		fmt.Printf("Selector:\t%s:%s\n", s.Type, s.Value)
 	}
 
+	for _, id := range e.FederatesWith {
+		fmt.Printf("FederatesWith:\t%s\n", id)
+	}
+
+	fmt.Println()
+}
+
+// printValidation prints the outcome of validating an entry
+func printValidation(resp *entry_pb.ValidateEntryResponse) {
+	for _, problem := range resp.Problems {
+		fmt.Printf("Problem:\t%s\n", problem)
+	}
+
+	for _, warning := range resp.Warnings {
+		fmt.Printf("Warning:\t%s\n", warning)
+	}
+
+	fmt.Println(util.Pluralizer(fmt.Sprintf("Matches %d ", len(resp.MatchingAgents)), "agent", "agents", len(resp.MatchingAgents)))
+	for _, agentID := range resp.MatchingAgents {
+		fmt.Printf("Agent:\t\t%s\n", agentID)
+	}
+
 	fmt.Println()
 }
 
@@ -81,3 +116,16 @@ func (s *SelectorFlag) Set(val string) error {
 	*s = append(*s, val)
 	return nil
 }
+
+// Define a custom type for string lists. Doing
+// this allows us to support repeatable flags
+type StringsFlag []string
+
+func (s *StringsFlag) String() string {
+	return fmt.Sprint(*s)
+}
+
+func (s *StringsFlag) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
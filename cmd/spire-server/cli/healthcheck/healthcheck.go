@@ -0,0 +1,18 @@
+package healthcheck
+
+import (
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/pkg/common/health"
+	server_health "github.com/spiffe/spire/pkg/server/health"
+)
+
+// NewHealthCheckCommand returns the command checking the health of a running
+// server, through its health check endpoint or its admin API socket
+func NewHealthCheckCommand() cli.Command {
+	return &health.CLI{
+		Name:           "server",
+		DefaultAddress: server_health.DefaultBindAddress,
+		SocketFlag:     "adminSocketPath",
+		SocketAPI:      "admin API",
+	}
+}
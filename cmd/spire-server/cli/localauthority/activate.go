@@ -0,0 +1,79 @@
+package localauthority
+
+import (
+	"flag"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+
+	"golang.org/x/net/context"
+)
+
+type ActivateCLI struct{}
+
+func (ActivateCLI) Synopsis() string {
+	return "Activates the prepared CA, which signs SVIDs from then on"
+}
+
+func (a ActivateCLI) Help() string {
+	_, err := a.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (a ActivateCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(a.flagSet(&Config{}))
+}
+
+func (ActivateCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (a ActivateCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := a.newConfig(args)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if err = config.Validate(true); err != nil {
+		return printErr(err)
+	}
+
+	cl, err := util.NewLocalAuthorityClient(ctx, config.Addr)
+	if err != nil {
+		return printErr(err)
+	}
+
+	resp, err := cl.ActivateX509Authority(ctx, &localauthority.ActivateX509AuthorityRequest{
+		SerialNumber: config.SerialNumber,
+	})
+	if err != nil {
+		return printErr(err)
+	}
+
+	if config.Output == cliprinter.JSON {
+		return printJSON(resp)
+	}
+
+	printAuthority("Activated CA", resp.ActivatedAuthority)
+	return 0
+}
+
+func (a ActivateCLI) newConfig(args []string) (*Config, error) {
+	c := &Config{}
+	f := a.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (ActivateCLI) flagSet(c *Config) *flag.FlagSet {
+	f := flag.NewFlagSet("localauthority activate", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.SerialNumber, "serialNumber", "", "The serial number, in decimal, of the prepared CA certificate")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
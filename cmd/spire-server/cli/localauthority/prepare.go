@@ -0,0 +1,78 @@
+package localauthority
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+
+	"golang.org/x/net/context"
+)
+
+type PrepareCLI struct{}
+
+func (PrepareCLI) Synopsis() string {
+	return "Prepares a new CA, published in the trust bundle ahead of its activation"
+}
+
+func (p PrepareCLI) Help() string {
+	_, err := p.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (p PrepareCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(p.flagSet(&Config{}))
+}
+
+func (PrepareCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (p PrepareCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := p.newConfig(args)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if err = config.Validate(false); err != nil {
+		return printErr(err)
+	}
+
+	cl, err := util.NewLocalAuthorityClient(ctx, config.Addr)
+	if err != nil {
+		return printErr(err)
+	}
+
+	resp, err := cl.PrepareX509Authority(ctx, &localauthority.PrepareX509AuthorityRequest{})
+	if err != nil {
+		return printErr(err)
+	}
+
+	if config.Output == cliprinter.JSON {
+		return printJSON(resp)
+	}
+
+	printAuthority("Prepared CA", resp.PreparedAuthority)
+	fmt.Println("Activate it once the trust bundle has reached the agents and federated trust domains.")
+	return 0
+}
+
+func (p PrepareCLI) newConfig(args []string) (*Config, error) {
+	c := &Config{}
+	f := p.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (PrepareCLI) flagSet(c *Config) *flag.FlagSet {
+	f := flag.NewFlagSet("localauthority prepare", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
@@ -0,0 +1,78 @@
+package localauthority
+
+import (
+	"flag"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+
+	"golang.org/x/net/context"
+)
+
+type ShowCLI struct{}
+
+func (ShowCLI) Synopsis() string {
+	return "Shows the active, prepared and old CAs of the server"
+}
+
+func (s ShowCLI) Help() string {
+	_, err := s.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (s ShowCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(s.flagSet(&Config{}))
+}
+
+func (ShowCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (s ShowCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := s.newConfig(args)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if err = config.Validate(false); err != nil {
+		return printErr(err)
+	}
+
+	cl, err := util.NewLocalAuthorityClient(ctx, config.Addr)
+	if err != nil {
+		return printErr(err)
+	}
+
+	resp, err := cl.GetX509AuthorityState(ctx, &localauthority.GetX509AuthorityStateRequest{})
+	if err != nil {
+		return printErr(err)
+	}
+
+	if config.Output == cliprinter.JSON {
+		return printJSON(resp)
+	}
+
+	printAuthority("Active CA", resp.Active)
+	printAuthority("Prepared CA", resp.Prepared)
+	printAuthority("Old CA", resp.Old)
+	return 0
+}
+
+func (s ShowCLI) newConfig(args []string) (*Config, error) {
+	c := &Config{}
+	f := s.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (ShowCLI) flagSet(c *Config) *flag.FlagSet {
+	f := flag.NewFlagSet("localauthority show", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
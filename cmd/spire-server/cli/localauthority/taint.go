@@ -0,0 +1,81 @@
+package localauthority
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+
+	"golang.org/x/net/context"
+)
+
+type TaintCLI struct{}
+
+func (TaintCLI) Synopsis() string {
+	return "Taints a CA that is no longer active, forcing the rotation of the SVIDs it signed"
+}
+
+func (t TaintCLI) Help() string {
+	_, err := t.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (t TaintCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(t.flagSet(&Config{}))
+}
+
+func (TaintCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (t TaintCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := t.newConfig(args)
+	if err != nil {
+		return printErr(err)
+	}
+
+	if err = config.Validate(true); err != nil {
+		return printErr(err)
+	}
+
+	cl, err := util.NewLocalAuthorityClient(ctx, config.Addr)
+	if err != nil {
+		return printErr(err)
+	}
+
+	resp, err := cl.TaintX509Authority(ctx, &localauthority.TaintX509AuthorityRequest{
+		SerialNumber: config.SerialNumber,
+	})
+	if err != nil {
+		return printErr(err)
+	}
+
+	if config.Output == cliprinter.JSON {
+		return printJSON(resp)
+	}
+
+	printAuthority("Tainted CA", resp.TaintedAuthority)
+	fmt.Printf("Forced the rotation of the SVIDs of %d registration entries\n", resp.RotatedEntries)
+	return 0
+}
+
+func (t TaintCLI) newConfig(args []string) (*Config, error) {
+	c := &Config{}
+	f := t.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (TaintCLI) flagSet(c *Config) *flag.FlagSet {
+	f := flag.NewFlagSet("localauthority taint", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.SerialNumber, "serialNumber", "", "The serial number, in decimal, of the CA certificate to taint")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
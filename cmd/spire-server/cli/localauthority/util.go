@@ -0,0 +1,58 @@
+package localauthority
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+)
+
+// Config is the configuration shared by the localauthority commands
+type Config struct {
+	// Address of SPIRE server
+	Addr string
+
+	// Serial number, in decimal, of the CA certificate
+	SerialNumber string
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+}
+
+// Perform basic validation
+func (c *Config) Validate(serialNumberRequired bool) error {
+	if c.Addr == "" {
+		return errors.New("a server address is required")
+	}
+
+	if serialNumberRequired && c.SerialNumber == "" {
+		return errors.New("a serial number is required")
+	}
+
+	return nil
+}
+
+func printAuthority(title string, a *localauthority.AuthorityState) {
+	fmt.Printf("%s:\n", title)
+	if a == nil {
+		fmt.Printf("  None\n")
+		return
+	}
+	fmt.Printf("  Serial number : %s\n", a.SerialNumber)
+	fmt.Printf("  Expires at    : %s\n", time.Unix(a.ExpiresAt, 0).UTC().Format(time.RFC3339))
+}
+
+func printJSON(v interface{}) int {
+	if err := cliprinter.PrintJSON(os.Stdout, v); err != nil {
+		return printErr(err)
+	}
+	return 0
+}
+
+func printErr(err error) int {
+	fmt.Println(err.Error())
+	return 1
+}
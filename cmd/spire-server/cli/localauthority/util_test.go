@@ -0,0 +1,37 @@
+package localauthority
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	c := &Config{Addr: "localhost:8081"}
+	assert.NoError(t, c.Validate(false))
+	assert.EqualError(t, c.Validate(true), "a serial number is required")
+
+	c = &Config{Addr: "localhost:8081", SerialNumber: "1"}
+	assert.NoError(t, c.Validate(true))
+
+	c = &Config{SerialNumber: "1"}
+	assert.EqualError(t, c.Validate(true), "a server address is required")
+}
+
+func TestNewConfig(t *testing.T) {
+	c, err := TaintCLI{}.newConfig([]string{"-serialNumber", "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Addr: "localhost:8081", SerialNumber: "1", Output: cliprinter.Pretty}, c)
+
+	c, err = ShowCLI{}.newConfig([]string{"-serverAddr", "localhost:9000"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Addr: "localhost:9000", Output: cliprinter.Pretty}, c)
+
+	c, err = ShowCLI{}.newConfig([]string{"-output", "json"})
+	assert.NoError(t, err)
+	assert.Equal(t, cliprinter.JSON, c.Output)
+
+	_, err = ShowCLI{}.newConfig([]string{"-serialNumber", "1"})
+	assert.Error(t, err)
+}
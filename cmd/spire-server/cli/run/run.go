@@ -11,12 +11,20 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/hcl"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/pkg/common/acmeutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/common/config"
+	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/pkg/server/bundle"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
 )
 
 const (
@@ -31,37 +39,94 @@ type runConfig struct {
 	PluginConfigs catalog.PluginConfigMap `hcl:"plugins"`
 }
 
+type federatesWithConfig struct {
+	BundleEndpointURL string `hcl:"bundle_endpoint_url"`
+}
+
 type serverConfig struct {
-	BindAddress      string `hcl:"bind_address"`
-	BindPort         int    `hcl:"bind_port"`
-	BindHTTPPort     int    `hcl:"bind_http_port"`
-	TrustDomain      string `hcl:"trust_domain"`
-	LogFile          string `hcl:"log_file"`
-	LogLevel         string `hcl:"log_level"`
-	BaseSVIDTtl      int    `hcl:"base_svid_ttl"`
-	ServerSVIDTtl    int    `hcl:"server_svid_ttl"`
-	ConfigPath       string
-	Umask            string   `hcl:"umask"`
-	UpstreamBundle   bool     `hcl:"upstream_bundle"`
-	ProfilingEnabled bool     `hcl:"profiling_enabled"`
-	ProfilingPort    int      `hcl:"profiling_port"`
-	ProfilingFreq    int      `hcl:"profiling_freq"`
-	ProfilingNames   []string `hcl:"profiling_names"`
+	BindAddress        string `hcl:"bind_address"`
+	BindPort           int    `hcl:"bind_port"`
+	BindHTTPPort       int    `hcl:"bind_http_port"`
+	TrustDomain        string `hcl:"trust_domain"`
+	LogFile            string `hcl:"log_file"`
+	LogLevel           string `hcl:"log_level"`
+	LogFormat          string `hcl:"log_format"`
+	BaseSVIDTtl        int    `hcl:"base_svid_ttl"`
+	ServerSVIDTtl      int    `hcl:"server_svid_ttl"`
+	ConfigPath         string
+	Umask              string   `hcl:"umask"`
+	UpstreamBundle     bool     `hcl:"upstream_bundle"`
+	HealthCheckEnabled bool     `hcl:"health_check_enabled"`
+	ReflectionEnabled  bool     `hcl:"reflection_enabled"`
+	CRLEnabled         bool     `hcl:"crl_enabled"`
+	CSRAllowedKeyTypes []string `hcl:"csr_allowed_key_types"`
+	SVIDMaxTTL         int      `hcl:"svid_max_ttl"`
+	SVIDTTLPolicy      string   `hcl:"svid_ttl_policy"`
+	CSRSignConcurrency int      `hcl:"csr_sign_concurrency"`
+	CSRSignMaxQueued   int      `hcl:"csr_sign_max_queued"`
+	ProfilingEnabled   bool     `hcl:"profiling_enabled"`
+	ProfilingPort      int      `hcl:"profiling_port"`
+	ProfilingFreq      int      `hcl:"profiling_freq"`
+	ProfilingNames     []string `hcl:"profiling_names"`
+
+	OCSPBindAddress string `hcl:"ocsp_bind_address"`
+	CAJournalPath   string `hcl:"ca_journal_path"`
+	JWTIssuer       string `hcl:"jwt_issuer"`
+
+	RequirePluginChecksum bool `hcl:"require_plugin_checksum"`
+
+	EntryCacheEnabled        bool `hcl:"entry_cache_enabled"`
+	EntryCachePollInterval   int  `hcl:"entry_cache_poll_interval"`
+	EntryCacheResyncInterval int  `hcl:"entry_cache_resync_interval"`
+
+	BundleEndpointBindAddress     string `hcl:"bundle_endpoint_bind_address"`
+	BundleEndpointCertPath        string `hcl:"bundle_endpoint_cert_path"`
+	BundleEndpointKeyPath         string `hcl:"bundle_endpoint_key_path"`
+	BundleEndpointACMEDomainName  string `hcl:"bundle_endpoint_acme_domain_name"`
+	BundleEndpointACMEDirectory   string `hcl:"bundle_endpoint_acme_directory_url"`
+	BundleEndpointACMEEmail       string `hcl:"bundle_endpoint_acme_email"`
+	BundleEndpointACMECacheDir    string `hcl:"bundle_endpoint_acme_cache_dir"`
+	BundleEndpointACMEToSAccepted bool   `hcl:"bundle_endpoint_acme_tos_accepted"`
+
+	FederatesWith map[string]federatesWithConfig `hcl:"federates_with"`
+
+	PrometheusBindAddress string   `hcl:"prometheus_bind_address"`
+	StatsdAddress         string   `hcl:"statsd_address"`
+	StatsdPrefix          string   `hcl:"statsd_prefix"`
+	StatsdDogStatsD       bool     `hcl:"statsd_dogstatsd"`
+	StatsdAllowedLabels   []string `hcl:"statsd_allowed_labels"`
+	ExpiringSVIDThreshold int      `hcl:"expiring_svid_threshold"`
+	SVIDMetricLabels      bool     `hcl:"svid_metric_labels"`
+
+	OTLPTracesEndpoint string `hcl:"otlp_traces_endpoint"`
+
+	HealthCheckBindAddress string `hcl:"health_check_bind_address"`
+
+	SubsystemLogLevels map[string]string `hcl:"subsystem_log_levels"`
+	AdminSocketPath    string            `hcl:"admin_socket_path"`
 }
 
 // Run CLI struct
 type RunCLI struct {
 }
 
-//Help prints the server cmd usage
+// Help prints the server cmd usage
 func (*RunCLI) Help() string {
-	_, err := parseFlags([]string{"-h"})
+	_, err := parseFlags("run", []string{"-h"}, nil)
 	return err.Error()
 }
 
-//Run the SPIFFE Server
+func (*RunCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(flagSet("run", &runConfig{}, nil))
+}
+
+func (*RunCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run the SPIFFE Server
 func (*RunCLI) Run(args []string) int {
-	cliConfig, err := parseFlags(args)
+	cliConfig, err := parseFlags("run", args, nil)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
@@ -96,6 +161,11 @@ func (*RunCLI) Run(args []string) int {
 	defer cancel()
 	util.SignalListener(ctx, cancel)
 
+	// Let the log file be rotated
+	if logger, ok := c.Log.(*log.Logger); ok {
+		logger.ReopenOnSignal(ctx)
+	}
+
 	err = s.Run(ctx)
 	if err != nil {
 		c.Log.Error(err.Error())
@@ -105,7 +175,7 @@ func (*RunCLI) Run(args []string) int {
 	return 0
 }
 
-//Synopsis of the command
+// Synopsis of the command
 func (*RunCLI) Synopsis() string {
 	return "Runs the server"
 }
@@ -131,6 +201,9 @@ func parseFile(filePath string) (*runConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := config.Expand(hclTree); err != nil {
+		return nil, fmt.Errorf("unable to expand config: %v", err)
+	}
 
 	if err := hcl.DecodeObject(&c, hclTree); err != nil {
 		return nil, err
@@ -139,26 +212,38 @@ func parseFile(filePath string) (*runConfig, error) {
 	return c, nil
 }
 
-func parseFlags(args []string) (*runConfig, error) {
-	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+// parseFlags parses the flags shared by the run and validate commands. The
+// -output flag is only registered if output is set.
+func parseFlags(name string, args []string, output *string) (*runConfig, error) {
 	c := &runConfig{}
+	flags := flagSet(name, c, output)
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
 
+	return c, nil
+}
+
+// flagSet defines the flags shared by the run and validate commands, storing
+// their values in c
+func flagSet(name string, c *runConfig, output *string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
 	flags.StringVar(&c.Server.BindAddress, "bindAddress", "", "IP address or DNS name of the SPIRE server")
 	flags.IntVar(&c.Server.BindPort, "serverPort", 0, "Port number of the SPIRE server")
 	flags.IntVar(&c.Server.BindHTTPPort, "bindHTTPPort", 0, "HTTP Port number of the SPIRE server")
 	flags.StringVar(&c.Server.TrustDomain, "trustDomain", "", "The trust domain that this server belongs to")
 	flags.StringVar(&c.Server.LogFile, "logFile", "", "File to write logs to")
 	flags.StringVar(&c.Server.LogLevel, "logLevel", "", "DEBUG, INFO, WARN or ERROR")
+	flags.StringVar(&c.Server.LogFormat, "logFormat", "", "text or json")
 	flags.StringVar(&c.Server.ConfigPath, "config", defaultConfigPath, "Path to a SPIRE config file")
 	flags.StringVar(&c.Server.Umask, "umask", "", "Umask value to use for new files")
 	flags.BoolVar(&c.Server.UpstreamBundle, "upstreamBundle", false, "Include upstream CA certificates in the bundle")
-
-	err := flags.Parse(args)
-	if err != nil {
-		return nil, err
+	if output != nil {
+		cliprinter.AppendFlag(flags, output)
 	}
-
-	return c, nil
+	return flags
 }
 
 func mergeConfigs(c *server.Config, fileConfig, cliConfig *runConfig) error {
@@ -199,19 +284,26 @@ func mergeConfig(orig *server.Config, cmd *runConfig) error {
 		orig.TrustDomain = trustDomain
 	}
 
-	// Handle log file and level
-	if cmd.Server.LogFile != "" || cmd.Server.LogLevel != "" {
+	// Handle log file, level and format
+	if cmd.Server.LogFile != "" || cmd.Server.LogLevel != "" ||
+		cmd.Server.LogFormat != "" || cmd.Server.SubsystemLogLevels != nil {
 		logLevel := defaultLogLevel
 		if cmd.Server.LogLevel != "" {
 			logLevel = cmd.Server.LogLevel
 		}
 
-		logger, err := log.NewLogger(logLevel, cmd.Server.LogFile)
+		logger, err := log.New(log.Config{
+			Level:           logLevel,
+			SubsystemLevels: cmd.Server.SubsystemLogLevels,
+			Format:          cmd.Server.LogFormat,
+			File:            cmd.Server.LogFile,
+		})
 		if err != nil {
-			return fmt.Errorf("Could not open log file %s: %s", cmd.Server.LogFile, err)
+			return fmt.Errorf("Could not set up logging: %s", err)
 		}
 
 		orig.Log = logger
+		orig.LogLevels = logger.Levels
 	}
 
 	if cmd.Server.Umask != "" {
@@ -227,6 +319,137 @@ func mergeConfig(orig *server.Config, cmd *runConfig) error {
 		orig.UpstreamBundle = cmd.Server.UpstreamBundle
 	}
 
+	if cmd.Server.HealthCheckEnabled {
+		orig.HealthCheck.Enabled = cmd.Server.HealthCheckEnabled
+	}
+
+	if cmd.Server.HealthCheckBindAddress != "" {
+		orig.HealthCheck.BindAddress = cmd.Server.HealthCheckBindAddress
+	}
+
+	if cmd.Server.ReflectionEnabled {
+		orig.ReflectionEnabled = cmd.Server.ReflectionEnabled
+	}
+
+	if cmd.Server.CRLEnabled {
+		orig.CRLEnabled = cmd.Server.CRLEnabled
+	}
+
+	if cmd.Server.RequirePluginChecksum {
+		orig.RequirePluginChecksum = cmd.Server.RequirePluginChecksum
+	}
+
+	if cmd.Server.OCSPBindAddress != "" {
+		orig.OCSPBindAddress = cmd.Server.OCSPBindAddress
+	}
+
+	if cmd.Server.CAJournalPath != "" {
+		orig.CAJournalPath = cmd.Server.CAJournalPath
+	}
+
+	if cmd.Server.JWTIssuer != "" {
+		orig.JWTIssuer = cmd.Server.JWTIssuer
+	}
+
+	if cmd.Server.EntryCacheEnabled {
+		orig.EntryCache.Enabled = cmd.Server.EntryCacheEnabled
+	}
+
+	if cmd.Server.EntryCachePollInterval != 0 {
+		orig.EntryCache.PollInterval = time.Duration(cmd.Server.EntryCachePollInterval) * time.Second
+	}
+
+	if cmd.Server.EntryCacheResyncInterval != 0 {
+		orig.EntryCache.ResyncInterval = time.Duration(cmd.Server.EntryCacheResyncInterval) * time.Second
+	}
+
+	if cmd.Server.BundleEndpointBindAddress != "" {
+		orig.BundleEndpoint.BindAddress = cmd.Server.BundleEndpointBindAddress
+	}
+
+	if cmd.Server.BundleEndpointCertPath != "" {
+		orig.BundleEndpoint.CertPath = cmd.Server.BundleEndpointCertPath
+	}
+
+	if cmd.Server.BundleEndpointKeyPath != "" {
+		orig.BundleEndpoint.KeyPath = cmd.Server.BundleEndpointKeyPath
+	}
+
+	if cmd.Server.BundleEndpointACMEDomainName != "" {
+		orig.BundleEndpoint.ACME = &acmeutil.Config{
+			DomainName:   cmd.Server.BundleEndpointACMEDomainName,
+			DirectoryURL: cmd.Server.BundleEndpointACMEDirectory,
+			Email:        cmd.Server.BundleEndpointACMEEmail,
+			CacheDir:     cmd.Server.BundleEndpointACMECacheDir,
+			ToSAccepted:  cmd.Server.BundleEndpointACMEToSAccepted,
+		}
+	}
+
+	for trustDomain, config := range cmd.Server.FederatesWith {
+		if orig.FederatedTrustDomains == nil {
+			orig.FederatedTrustDomains = make(map[string]bundle.FederatedTrustDomain)
+		}
+		orig.FederatedTrustDomains[trustDomain] = bundle.FederatedTrustDomain{
+			BundleEndpointURL: config.BundleEndpointURL,
+		}
+	}
+
+	if len(cmd.Server.CSRAllowedKeyTypes) > 0 {
+		orig.CSRPolicy.AllowedKeyTypes = cmd.Server.CSRAllowedKeyTypes
+	}
+
+	if cmd.Server.SVIDMaxTTL > 0 {
+		orig.CSRPolicy.MaxTTL = time.Duration(cmd.Server.SVIDMaxTTL) * time.Second
+	}
+
+	if cmd.Server.SVIDTTLPolicy != "" {
+		orig.CSRPolicy.TTLPolicy = cmd.Server.SVIDTTLPolicy
+	}
+
+	if cmd.Server.CSRSignConcurrency != 0 {
+		orig.SignPool.Concurrency = cmd.Server.CSRSignConcurrency
+	}
+
+	if cmd.Server.CSRSignMaxQueued != 0 {
+		orig.SignPool.MaxQueued = cmd.Server.CSRSignMaxQueued
+	}
+
+	if cmd.Server.PrometheusBindAddress != "" {
+		orig.PrometheusBindAddress = cmd.Server.PrometheusBindAddress
+	}
+
+	if cmd.Server.StatsdAddress != "" {
+		orig.Statsd.Address = cmd.Server.StatsdAddress
+	}
+
+	if cmd.Server.StatsdPrefix != "" {
+		orig.Statsd.Prefix = cmd.Server.StatsdPrefix
+	}
+
+	if cmd.Server.StatsdDogStatsD {
+		orig.Statsd.DogStatsD = cmd.Server.StatsdDogStatsD
+	}
+
+	if cmd.Server.StatsdAllowedLabels != nil {
+		orig.Statsd.AllowedLabels = cmd.Server.StatsdAllowedLabels
+	}
+
+	if cmd.Server.ExpiringSVIDThreshold != 0 {
+		orig.ExpiringSVIDThreshold = time.Duration(cmd.Server.ExpiringSVIDThreshold) * time.Second
+	}
+
+	if cmd.Server.SVIDMetricLabels {
+		orig.SVIDMetricLabels = cmd.Server.SVIDMetricLabels
+	}
+
+	if cmd.Server.OTLPTracesEndpoint != "" {
+		orig.OTLPTracesEndpoint = cmd.Server.OTLPTracesEndpoint
+	}
+
+	if cmd.Server.AdminSocketPath != "" {
+		orig.AdminSocketPath = cmd.Server.AdminSocketPath
+	}
+
 	if cmd.Server.ProfilingEnabled {
 		orig.ProfilingEnabled = cmd.Server.ProfilingEnabled
 	}
@@ -260,6 +483,66 @@ func validateConfig(c *server.Config) error {
 	if c.TrustDomain.String() == "" {
 		return errors.New("TrustDomain is required")
 	}
+	if err := idutil.ValidateSpiffeID(c.TrustDomain.String(), idutil.AllowAnyTrustDomain()); err != nil {
+		return fmt.Errorf("invalid TrustDomain: %v", err)
+	}
+
+	// The revocation status of the SVIDs is only known with CRLs enabled
+	if c.OCSPBindAddress != "" && !c.CRLEnabled {
+		return errors.New("OCSPBindAddress requires CRLEnabled")
+	}
+
+	// Validators discovering the JWT signing keys through OIDC discovery
+	// fetch them from the issuer URL
+	if c.JWTIssuer != "" {
+		u, err := url.Parse(c.JWTIssuer)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return errors.New("JWTIssuer must be an https URL")
+		}
+	}
+
+	// The bundle endpoint is served with either a certificate obtained
+	// through ACME or one provided
+	if c.BundleEndpoint.BindAddress != "" {
+		switch {
+		case c.BundleEndpoint.ACME != nil:
+			if !c.BundleEndpoint.ACME.ToSAccepted {
+				return errors.New("BundleEndpointACMEDomainName requires BundleEndpointACMEToSAccepted")
+			}
+		case c.BundleEndpoint.CertPath == "" || c.BundleEndpoint.KeyPath == "":
+			return errors.New("BundleEndpointBindAddress requires either BundleEndpointACMEDomainName, or BundleEndpointCertPath and BundleEndpointKeyPath")
+		}
+	}
+
+	// Federated bundles are fetched from endpoints authenticated with Web PKI
+	for trustDomain, config := range c.FederatedTrustDomains {
+		if err := idutil.ValidateSpiffeID(trustDomain, idutil.AllowAnyTrustDomain()); err != nil {
+			return fmt.Errorf("invalid federated trust domain: %v", err)
+		}
+		if trustDomain == c.TrustDomain.String() {
+			return fmt.Errorf("cannot federate with the local trust domain %q", trustDomain)
+		}
+		u, err := url.Parse(config.BundleEndpointURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("bundle endpoint URL of %q must be an https URL", trustDomain)
+		}
+	}
+
+	for _, keyType := range c.CSRPolicy.AllowedKeyTypes {
+		if !csrpolicy.IsValidKeyType(keyType) {
+			return fmt.Errorf("invalid CSR key type %q", keyType)
+		}
+	}
+
+	switch c.CSRPolicy.TTLPolicy {
+	case "", csrpolicy.TTLPolicyClamp, csrpolicy.TTLPolicyReject:
+	default:
+		return fmt.Errorf("invalid SVID TTL policy %q", c.CSRPolicy.TTLPolicy)
+	}
+
+	if c.SignPool.Concurrency < 0 || c.SignPool.MaxQueued < 0 {
+		return errors.New("CSR signing concurrency and queue size cannot be negative")
+	}
 
 	return nil
 }
@@ -272,6 +555,7 @@ func newDefaultConfig() *server.Config {
 
 	return &server.Config{
 		Log:             logger,
+		LogLevels:       logger.Levels,
 		BindAddress:     bindAddress,
 		BindHTTPAddress: serverHTTPAddress,
 		Umask:           defaultUmask,
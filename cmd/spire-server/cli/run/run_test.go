@@ -2,9 +2,18 @@ package run
 
 import (
 	"bytes"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/bundle"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +29,9 @@ func TestParseConfigGood(t *testing.T) {
 	assert.Equal(t, c.Server.TrustDomain, "example.org")
 	assert.Equal(t, c.Server.LogLevel, "INFO")
 	assert.Equal(t, c.Server.Umask, "")
+	assert.Equal(t, map[string]federatesWithConfig{
+		"spiffe://partner.org": {BundleEndpointURL: "https://partner.org/bundle"},
+	}, c.Server.FederatesWith)
 
 	// Check for plugins configurations
 	expectedData := "join_token = \"PLUGIN-SERVER-NOT-A-SECRET\"\n\ntrust_domain = \"example.org\""
@@ -31,17 +43,55 @@ func TestParseConfigGood(t *testing.T) {
 	assert.Equal(t, c.PluginConfigs["plugin_type_server"]["plugin_name_server"].Enabled, true)
 	assert.Equal(t, c.PluginConfigs["plugin_type_server"]["plugin_name_server"].PluginChecksum, "pluginServerChecksum")
 	assert.Equal(t, c.PluginConfigs["plugin_type_server"]["plugin_name_server"].PluginCmd, "./pluginServerCmd")
+	assert.Equal(t, []string{"-verbose"}, c.PluginConfigs["plugin_type_server"]["plugin_name_server"].PluginArgs)
+	assert.Equal(t, map[string]string{"PLUGIN_SERVER_TOKEN": "NOT-A-SECRET"}, c.PluginConfigs["plugin_type_server"]["plugin_name_server"].PluginEnv)
 	assert.Equal(t, expectedData, data.String())
 }
 
+func TestParseConfigExpand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "run-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte("PLUGIN-SERVER-SECRET\n"), 0600))
+	os.Setenv("RUN_TEST_TRUST_DOMAIN", "example.org")
+	defer os.Unsetenv("RUN_TEST_TRUST_DOMAIN")
+
+	configPath := filepath.Join(dir, "server.conf")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`
+server {
+	trust_domain = "${RUN_TEST_TRUST_DOMAIN}"
+}
+plugins {
+	plugin_type_server "plugin_name_server" {
+		plugin_data {
+			join_token = "${file:`+tokenPath+`}"
+		}
+	}
+}`), 0600))
+
+	c, err := parseFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "example.org", c.Server.TrustDomain)
+
+	var data bytes.Buffer
+	require.NoError(t, printer.DefaultConfig.Fprint(&data, c.PluginConfigs["plugin_type_server"]["plugin_name_server"].PluginData))
+	assert.Equal(t, `join_token = "PLUGIN-SERVER-SECRET"`, data.String())
+
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`server { trust_domain = "${RUN_TEST_UNSET}" }`), 0600))
+	_, err = parseFile(configPath)
+	assert.EqualError(t, err, "unable to expand config: line 1: environment variable RUN_TEST_UNSET is not set (write $${RUN_TEST_UNSET} for a literal ${RUN_TEST_UNSET})")
+}
+
 func TestParseFlagsGood(t *testing.T) {
-	c, err := parseFlags([]string{
+	c, err := parseFlags("run", []string{
 		"-bindAddress=127.0.0.1",
 		"-bindHTTPPort=8080",
 		"-trustDomain=example.org",
 		"-logLevel=INFO",
 		"-umask=",
-	})
+	}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, c.Server.BindAddress, "127.0.0.1")
 	assert.Equal(t, c.Server.BindHTTPPort, 8080)
@@ -50,6 +100,17 @@ func TestParseFlagsGood(t *testing.T) {
 	assert.Equal(t, c.Server.Umask, "")
 }
 
+func TestAutocompleteFlags(t *testing.T) {
+	// Only validate prints an outcome
+	runFlags := (&RunCLI{}).AutocompleteFlags()
+	assert.Contains(t, runFlags, "-config")
+	assert.NotContains(t, runFlags, "-output")
+
+	validateFlags := (&ValidateCLI{}).AutocompleteFlags()
+	assert.Contains(t, validateFlags, "-config")
+	assert.Contains(t, validateFlags, "-output")
+}
+
 func TestMergeConfigGood(t *testing.T) {
 	sc := &serverConfig{
 		BindAddress:  "127.0.0.1",
@@ -74,4 +135,325 @@ func TestMergeConfigGood(t *testing.T) {
 	assert.Equal(t, orig.TrustDomain.Scheme, "spiffe")
 	assert.Equal(t, orig.TrustDomain.Host, "example.org")
 	assert.Equal(t, orig.Umask, 0077)
+	assert.False(t, orig.HealthCheck.Enabled)
+	assert.False(t, orig.ReflectionEnabled)
+	assert.False(t, orig.CRLEnabled)
+}
+
+func TestMergeConfigHealthCheckAndReflection(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			HealthCheckEnabled:     true,
+			HealthCheckBindAddress: "localhost:9090",
+			ReflectionEnabled:      true,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.HealthCheck.Enabled)
+	assert.Equal(t, "localhost:9090", orig.HealthCheck.BindAddress)
+	assert.True(t, orig.ReflectionEnabled)
+}
+
+func TestMergeConfigCRL(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			CRLEnabled: true,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.CRLEnabled)
+}
+
+func TestMergeConfigRequirePluginChecksum(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			RequirePluginChecksum: true,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.RequirePluginChecksum)
+}
+
+func TestMergeConfigOCSP(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			OCSPBindAddress: "localhost:8888",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:8888", orig.OCSPBindAddress)
+
+	// OCSP requires CRLs, which record the SVIDs issued
+	orig.BindAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.BindHTTPAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8080}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	assert.EqualError(t, validateConfig(orig), "OCSPBindAddress requires CRLEnabled")
+	orig.CRLEnabled = true
+	assert.NoError(t, validateConfig(orig))
+}
+
+func TestMergeConfigBundleEndpoint(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			BundleEndpointBindAddress:    "0.0.0.0:443",
+			BundleEndpointACMEDomainName: "bundle.example.org",
+			BundleEndpointACMEEmail:      "admin@example.org",
+			BundleEndpointACMECacheDir:   "/var/lib/spire/acme",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:443", orig.BundleEndpoint.BindAddress)
+	require.NotNil(t, orig.BundleEndpoint.ACME)
+	assert.Equal(t, "bundle.example.org", orig.BundleEndpoint.ACME.DomainName)
+	assert.Equal(t, "admin@example.org", orig.BundleEndpoint.ACME.Email)
+	assert.Equal(t, "/var/lib/spire/acme", orig.BundleEndpoint.ACME.CacheDir)
+
+	// The terms of service of the ACME CA have to be accepted
+	orig.BindAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.BindHTTPAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8080}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	assert.EqualError(t, validateConfig(orig), "BundleEndpointACMEDomainName requires BundleEndpointACMEToSAccepted")
+	orig.BundleEndpoint.ACME.ToSAccepted = true
+	assert.NoError(t, validateConfig(orig))
+
+	// Without ACME, a certificate has to be provided
+	orig.BundleEndpoint.ACME = nil
+	assert.EqualError(t, validateConfig(orig), "BundleEndpointBindAddress requires either BundleEndpointACMEDomainName, or BundleEndpointCertPath and BundleEndpointKeyPath")
+	orig.BundleEndpoint.CertPath = "cert.pem"
+	orig.BundleEndpoint.KeyPath = "key.pem"
+	assert.NoError(t, validateConfig(orig))
+}
+
+func TestMergeConfigFederatesWith(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			FederatesWith: map[string]federatesWithConfig{
+				"spiffe://partner.org": {BundleEndpointURL: "http://partner.org/bundle"},
+			},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "http://partner.org/bundle", orig.FederatedTrustDomains["spiffe://partner.org"].BundleEndpointURL)
+
+	// Bundle endpoints are authenticated with Web PKI
+	orig.BindAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.BindHTTPAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8080}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	assert.EqualError(t, validateConfig(orig), `bundle endpoint URL of "spiffe://partner.org" must be an https URL`)
+	orig.FederatedTrustDomains["spiffe://partner.org"] = bundle.FederatedTrustDomain{BundleEndpointURL: "https://partner.org/bundle"}
+	assert.NoError(t, validateConfig(orig))
+
+	orig.FederatedTrustDomains["spiffe://example.org"] = bundle.FederatedTrustDomain{BundleEndpointURL: "https://example.org/bundle"}
+	assert.EqualError(t, validateConfig(orig), `cannot federate with the local trust domain "spiffe://example.org"`)
+}
+
+func TestMergeConfigCAJournalPath(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			CAJournalPath: "/var/lib/spire/ca-journal.json",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "/var/lib/spire/ca-journal.json", orig.CAJournalPath)
+}
+
+func TestMergeConfigJWTIssuer(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			JWTIssuer: "http://oidc.example.org",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "http://oidc.example.org", orig.JWTIssuer)
+
+	// Validators fetch the JWT signing keys from the issuer over HTTPS
+	orig.BindAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.BindHTTPAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8080}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	assert.EqualError(t, validateConfig(orig), "JWTIssuer must be an https URL")
+	orig.JWTIssuer = "https://oidc.example.org"
+	assert.NoError(t, validateConfig(orig))
+}
+
+func TestMergeConfigCSRPolicy(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			CSRAllowedKeyTypes: []string{"ec-p256", "rsa-2048"},
+			SVIDMaxTTL:         3600,
+			SVIDTTLPolicy:      "reject",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ec-p256", "rsa-2048"}, orig.CSRPolicy.AllowedKeyTypes)
+	assert.Equal(t, time.Hour, orig.CSRPolicy.MaxTTL)
+	assert.Equal(t, "reject", orig.CSRPolicy.TTLPolicy)
+
+	orig.BindAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.BindHTTPAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8080}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	assert.NoError(t, validateConfig(orig))
+	orig.CSRPolicy.TTLPolicy = "ignore"
+	assert.EqualError(t, validateConfig(orig), `invalid SVID TTL policy "ignore"`)
+}
+
+func TestMergeConfigSignPool(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			CSRSignConcurrency: 8,
+			CSRSignMaxQueued:   1000,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 8, orig.SignPool.Concurrency)
+	assert.Equal(t, 1000, orig.SignPool.MaxQueued)
+
+	orig.BindAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+	orig.BindHTTPAddress = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8080}
+	orig.TrustDomain = url.URL{Scheme: "spiffe", Host: "example.org"}
+	assert.NoError(t, validateConfig(orig))
+	orig.SignPool.MaxQueued = -1
+	assert.EqualError(t, validateConfig(orig), "CSR signing concurrency and queue size cannot be negative")
+}
+
+func TestMergeConfigPrometheus(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			PrometheusBindAddress: "localhost:9988",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:9988", orig.PrometheusBindAddress)
+}
+
+func TestMergeConfigStatsd(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			StatsdAddress:       "localhost:8125",
+			StatsdPrefix:        "prod",
+			StatsdDogStatsD:     true,
+			StatsdAllowedLabels: []string{"attestor"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, telemetry.StatsdConfig{
+		Address:       "localhost:8125",
+		Prefix:        "prod",
+		DogStatsD:     true,
+		AllowedLabels: []string{"attestor"},
+	}, orig.Statsd)
+}
+
+func TestMergeConfigEntryCache(t *testing.T) {
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, &runConfig{})
+	require.NoError(t, err)
+	assert.False(t, orig.EntryCache.Enabled)
+
+	c := &runConfig{
+		Server: serverConfig{
+			EntryCacheEnabled:        true,
+			EntryCachePollInterval:   5,
+			EntryCacheResyncInterval: 300,
+		},
+	}
+
+	err = mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.EntryCache.Enabled)
+	assert.Equal(t, 5*time.Second, orig.EntryCache.PollInterval)
+	assert.Equal(t, 5*time.Minute, orig.EntryCache.ResyncInterval)
+}
+
+func TestMergeConfigExpiringSVIDThreshold(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			ExpiringSVIDThreshold: 300,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, orig.ExpiringSVIDThreshold)
+}
+
+func TestMergeConfigSVIDMetricLabels(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			SVIDMetricLabels: true,
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.True(t, orig.SVIDMetricLabels)
+}
+
+func TestMergeConfigOTLPTracesEndpoint(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			OTLPTracesEndpoint: "http://localhost:4318/v1/traces",
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4318/v1/traces", orig.OTLPTracesEndpoint)
+}
+
+func TestMergeConfigLogging(t *testing.T) {
+	c := &runConfig{
+		Server: serverConfig{
+			LogFormat:          "json",
+			SubsystemLogLevels: map[string]string{"catalog": "DEBUG"},
+		},
+	}
+
+	orig := newDefaultConfig()
+	err := mergeConfig(orig, c)
+	require.NoError(t, err)
+	assert.Equal(t, logrus.InfoLevel, orig.LogLevels.Level())
+	assert.Equal(t, logrus.DebugLevel, orig.LogLevels.SubsystemLevel("catalog"))
+
+	c.Server.LogFormat = "xml"
+	err = mergeConfig(orig, c)
+	require.EqualError(t, err, `Could not set up logging: unknown log format "xml", must be "text" or "json"`)
 }
@@ -0,0 +1,206 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/pkg/server"
+	"golang.org/x/sys/unix"
+
+	server_catalog "github.com/spiffe/spire/pkg/server/catalog"
+)
+
+// ValidateCLI validates the configuration of the server without running it,
+// e.g. to check configuration changes before rolling them out
+type ValidateCLI struct {
+}
+
+// validateOutput is the outcome of the command, as printed in JSON
+type validateOutput struct {
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+}
+
+// Help prints the validate cmd usage
+func (*ValidateCLI) Help() string {
+	_, err := parseFlags("validate", []string{"-h"}, new(string))
+	return err.Error()
+}
+
+// Run validates the configuration, printing the problems found
+func (*ValidateCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(flagSet("validate", &runConfig{}, new(string)))
+}
+
+func (*ValidateCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (*ValidateCLI) Run(args []string) int {
+	var output string
+	problems := validate(args, &output)
+	if output == cliprinter.JSON {
+		out := &validateOutput{Valid: len(problems) == 0, Problems: append([]string{}, problems...)}
+		if err := cliprinter.PrintJSON(os.Stdout, out); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		if !out.Valid {
+			return 1
+		}
+		return 0
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+		return 1
+	}
+
+	fmt.Println("Configuration is valid.")
+	return 0
+}
+
+// Synopsis of the command
+func (*ValidateCLI) Synopsis() string {
+	return "Validates the server configuration"
+}
+
+// validate parses the configuration given by the flags the same way the run
+// command does, then checks the paths it refers to, starts the plugins and
+// configures the built-in ones in dry-run mode. It returns the problems
+// found, and sets output to the format they should be printed in.
+func validate(args []string, output *string) []string {
+	cliConfig, err := parseFlags("validate", args, output)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	fileConfig, err := parseFile(cliConfig.Server.ConfigPath)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	// The log file is opened while merging the configurations, so it is
+	// checked and left out instead
+	var problems []string
+	logFile := fileConfig.Server.LogFile
+	if cliConfig.Server.LogFile != "" {
+		logFile = cliConfig.Server.LogFile
+	}
+	if logFile != "" {
+		problems = appendProblem(problems, "log_file", checkWritableDir(filepath.Dir(logFile)))
+	}
+	fileConfig.Server.LogFile = ""
+	cliConfig.Server.LogFile = ""
+
+	c := newDefaultConfig()
+	c.PluginConfigs = fileConfig.PluginConfigs
+
+	if err := mergeConfigs(c, fileConfig, cliConfig); err != nil {
+		return append(problems, err.Error())
+	}
+	if err := validateConfig(c); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	problems = append(problems, checkPaths(c)...)
+
+	// The plugins are only started once the rest of the configuration is
+	// known to be valid
+	if len(problems) > 0 {
+		return problems
+	}
+	if err := configurePlugins(c); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return problems
+}
+
+// checkPaths checks the files and directories the server reads from and
+// writes to, returning the problems found
+func checkPaths(c *server.Config) []string {
+	var problems []string
+	if c.CAJournalPath != "" {
+		problems = appendProblem(problems, "ca_journal_path", checkWritableDir(filepath.Dir(c.CAJournalPath)))
+	}
+	if c.AdminSocketPath != "" {
+		problems = appendProblem(problems, "admin_socket_path", checkWritableDir(filepath.Dir(c.AdminSocketPath)))
+	}
+	if c.BundleEndpoint.CertPath != "" {
+		problems = appendProblem(problems, "bundle_endpoint_cert_path", checkAccess(c.BundleEndpoint.CertPath, unix.R_OK))
+	}
+	if c.BundleEndpoint.KeyPath != "" {
+		problems = appendProblem(problems, "bundle_endpoint_key_path", checkAccess(c.BundleEndpoint.KeyPath, unix.R_OK))
+	}
+	if c.BundleEndpoint.ACME != nil && c.BundleEndpoint.ACME.CacheDir != "" {
+		// The cache directory is created if it does not exist
+		dir := c.BundleEndpoint.ACME.CacheDir
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			dir = filepath.Dir(dir)
+		}
+		problems = appendProblem(problems, "bundle_endpoint_acme_cache_dir", checkWritableDir(dir))
+	}
+
+	for pluginType, plugins := range c.PluginConfigs {
+		for pluginName, config := range plugins {
+			if !config.Enabled || config.PluginCmd == "" {
+				continue
+			}
+			name := fmt.Sprintf("%s(%s): plugin_cmd", pluginType, pluginName)
+			problems = appendProblem(problems, name, checkAccess(config.PluginCmd, unix.X_OK))
+		}
+	}
+
+	return problems
+}
+
+// configurePlugins starts the plugins and configures the built-in ones in
+// dry-run mode
+func configurePlugins(c *server.Config) error {
+	cat := server_catalog.New(&server_catalog.Config{
+		PluginConfigs:         c.PluginConfigs,
+		Log:                   c.Log.WithField("subsystem_name", "catalog"),
+		DryRun:                true,
+		RequirePluginChecksum: c.RequirePluginChecksum,
+	})
+	defer cat.Stop()
+
+	return cat.Run(context.Background())
+}
+
+// checkWritableDir returns an error unless the path is a directory the server
+// can create files in
+func checkWritableDir(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+	return checkAccess(path, unix.W_OK)
+}
+
+// checkAccess returns an error unless the path can be accessed in the given
+// mode, one of unix.R_OK, unix.W_OK or unix.X_OK. The access is checked for
+// the real user and group of the process, which is only the one of the
+// server if the command is run as the same user.
+func checkAccess(path string, mode uint32) error {
+	if err := unix.Access(path, mode); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+func appendProblem(problems []string, name string, err error) []string {
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+	}
+	return problems
+}
@@ -0,0 +1,106 @@
+package run
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/stretchr/testify/require"
+)
+
+const validateConfigTemplate = `
+server {
+    bind_address = "127.0.0.1"
+    bind_port = "8081"
+    bind_http_port = "8080"
+    trust_domain = %q
+    ca_journal_path = %q
+}
+
+plugins {
+    ServerCA "memory" {
+        enabled = true
+        plugin_data {
+            trust_domain = "example.org"
+        }
+    }
+
+    DataStore "sql" {
+        enabled = true
+        plugin_data {
+            database_type = %q
+            connection_string = %q
+        }
+    }
+
+    NodeAttestor "join_token" {
+        enabled = true
+        plugin_data {
+            trust_domain = "example.org"
+        }
+    }
+
+    NodeResolver "noop" {
+        enabled = true
+        plugin_data {}
+    }
+
+    UpstreamCA "disk" {
+        enabled = true
+        plugin_data {
+            trust_domain = "example.org"
+            ttl = "1h"
+            key_file_path = "../../../../conf/server/dummy_upstream_ca.key"
+            cert_file_path = "../../../../conf/server/dummy_upstream_ca.crt"
+        }
+    }
+}
+`
+
+func TestValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-validate-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "server.conf")
+	dbPath := filepath.Join(dir, "datastore.sqlite3")
+	writeConfig := func(trustDomain, journalPath, databaseType string) {
+		config := fmt.Sprintf(validateConfigTemplate, trustDomain, journalPath, databaseType, dbPath)
+		require.NoError(t, ioutil.WriteFile(configPath, []byte(config), 0600))
+	}
+
+	// A valid configuration, with the plugins configured in dry-run mode
+	writeConfig("example.org", filepath.Join(dir, "journal"), "sqlite3")
+	require.Empty(t, validate([]string{"-config", configPath}, new(string)))
+	_, err = os.Stat(dbPath)
+	require.True(t, os.IsNotExist(err), "the database should not be created")
+
+	// Problems with the server configuration are all reported
+	missingDir := filepath.Join(dir, "missing")
+	writeConfig("spiffe://example.org", filepath.Join(missingDir, "journal"), "sqlite3")
+	problems := validate([]string{"-config", configPath, "-logFile", filepath.Join(missingDir, "server.log")}, new(string))
+	require.Len(t, problems, 3)
+	require.Contains(t, problems[0], "log_file: stat "+missingDir)
+	require.Contains(t, problems[1], "invalid TrustDomain")
+	require.Contains(t, problems[2], "ca_journal_path: stat "+missingDir)
+
+	// Problems with the plugin configurations are reported
+	writeConfig("example.org", filepath.Join(dir, "journal"), "mysql")
+	problems = validate([]string{"-config", configPath}, new(string))
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0], "unsupported database_type: mysql")
+
+	// A missing configuration file is reported
+	problems = validate([]string{"-config", filepath.Join(missingDir, "server.conf")}, new(string))
+	require.Len(t, problems, 1)
+	require.Contains(t, problems[0], "could not find config file")
+
+	// The format to print the problems in is parsed along
+	var output string
+	problems = validate([]string{"-config", configPath, "-output", "json"}, &output)
+	require.Len(t, problems, 1)
+	require.Equal(t, cliprinter.JSON, output)
+}
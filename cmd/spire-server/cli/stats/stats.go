@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+
+	stats_pb "github.com/spiffe/spire/proto/api/v1/stats"
+)
+
+type statsCLI struct {
+	newStatsClient func(ctx context.Context, addr string) (stats_pb.StatsClient, error)
+	writer         io.Writer
+}
+
+type statsConfig struct {
+	// Address of SPIRE server
+	addr string
+
+	// Format to print the stats in, cliprinter.Pretty or cliprinter.JSON
+	output string
+}
+
+// statsOutput is the stats of the server, as printed in JSON. Unlike in the
+// API response, zero values are printed.
+type statsOutput struct {
+	EntryCount        int32  `json:"entry_count"`
+	AgentCount        int32  `json:"agent_count"`
+	ActiveCAExpiresAt int64  `json:"active_ca_expires_at"`
+	DatastoreType     string `json:"datastore_type"`
+}
+
+// NewStatsCommand creates a new "stats" command.
+func NewStatsCommand() cli.Command {
+	return &statsCLI{
+		writer:         os.Stdout,
+		newStatsClient: util.NewStatsClient,
+	}
+}
+
+func (*statsCLI) Synopsis() string {
+	return "Reports the number of entries and agents, the expiry of the CA and the datastore of the server"
+}
+
+func (s *statsCLI) Help() string {
+	_, err := s.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (s *statsCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(s.flagSet(&statsConfig{}))
+}
+
+func (*statsCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (s *statsCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := s.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	c, err := s.newStatsClient(ctx, config.addr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	resp, err := c.GetStats(ctx, &stats_pb.GetStatsRequest{})
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if config.output == cliprinter.JSON {
+		out := &statsOutput{
+			EntryCount:        resp.EntryCount,
+			AgentCount:        resp.AgentCount,
+			ActiveCAExpiresAt: resp.ActiveCaExpiresAt,
+			DatastoreType:     resp.DatastoreType,
+		}
+		if err := cliprinter.PrintJSON(s.writer, out); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	expiry := "none"
+	if resp.ActiveCaExpiresAt != 0 {
+		expiry = time.Unix(resp.ActiveCaExpiresAt, 0).UTC().Format(time.RFC3339)
+	}
+	fmt.Fprintf(s.writer, "Registration entries:\t%d\n", resp.EntryCount)
+	fmt.Fprintf(s.writer, "Attested agents:\t%d\n", resp.AgentCount)
+	fmt.Fprintf(s.writer, "Active CA expires at:\t%s\n", expiry)
+	fmt.Fprintf(s.writer, "Datastore:\t\t%s\n", resp.DatastoreType)
+	return 0
+}
+
+func (s *statsCLI) newConfig(args []string) (*statsConfig, error) {
+	c := &statsConfig{}
+	f := s.flagSet(c)
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (*statsCLI) flagSet(c *statsConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("stats", flag.ContinueOnError)
+	f.StringVar(&c.addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	cliprinter.AppendFlag(f, &c.output)
+	return f
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/v1/stats"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/stats"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+	mockCtrl   *gomock.Controller
+	mockClient *mock_stats.MockStatsClient
+	cli        *statsCLI
+}
+
+func TestStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
+
+func (s *StatsTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_stats.NewMockStatsClient(s.mockCtrl)
+	s.cli = &statsCLI{
+		newStatsClient: func(ctx context.Context, addr string) (stats.StatsClient, error) {
+			return s.mockClient, nil
+		},
+		writer: &bytes.Buffer{},
+	}
+}
+
+func (s *StatsTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func (s *StatsTestSuite) TestRun() {
+	expiresAt := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	s.mockClient.EXPECT().GetStats(gomock.Any(), &stats.GetStatsRequest{}).
+		Return(&stats.GetStatsResponse{
+			EntryCount:        12,
+			AgentCount:        3,
+			ActiveCaExpiresAt: expiresAt.Unix(),
+			DatastoreType:     "sql",
+		}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Require().Equal("Registration entries:\t12\n"+
+		"Attested agents:\t3\n"+
+		"Active CA expires at:\t2018-07-01T00:00:00Z\n"+
+		"Datastore:\t\tsql\n", s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *StatsTestSuite) TestRunWithoutActiveCA() {
+	s.mockClient.EXPECT().GetStats(gomock.Any(), &stats.GetStatsRequest{}).
+		Return(&stats.GetStatsResponse{DatastoreType: "sql"}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Require().Contains(s.cli.writer.(*bytes.Buffer).String(), "Active CA expires at:\tnone\n")
+}
+
+func (s *StatsTestSuite) TestRunWithJSONOutput() {
+	s.mockClient.EXPECT().GetStats(gomock.Any(), &stats.GetStatsRequest{}).
+		Return(&stats.GetStatsResponse{AgentCount: 3, DatastoreType: "sql"}, nil)
+
+	s.Require().Equal(0, s.cli.Run([]string{"-output", "json"}))
+	s.Require().JSONEq(`{
+		"entry_count": 0,
+		"agent_count": 3,
+		"active_ca_expires_at": 0,
+		"datastore_type": "sql"
+	}`, s.cli.writer.(*bytes.Buffer).String())
+}
+
+func (s *StatsTestSuite) TestRunWithError() {
+	s.mockClient.EXPECT().GetStats(gomock.Any(), &stats.GetStatsRequest{}).
+		Return(nil, errors.New("unavailable"))
+
+	s.Require().Equal(1, s.cli.Run([]string{}))
+}
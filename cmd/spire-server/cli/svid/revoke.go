@@ -0,0 +1,137 @@
+package svid
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+type RevokeConfig struct {
+	// Address of SPIRE server
+	Addr string
+
+	// Serial number, in decimal, of the SVID to revoke
+	SerialNumber string
+
+	// ID of the registration entry to revoke all the SVIDs of
+	EntryID string
+
+	// Format to print the revoked SVIDs in, cliprinter.Pretty or
+	// cliprinter.JSON
+	Output string
+}
+
+// Perform basic validation
+func (rc *RevokeConfig) Validate() error {
+	if rc.Addr == "" {
+		return errors.New("a server address is required")
+	}
+
+	if rc.SerialNumber == "" && rc.EntryID == "" {
+		return errors.New("a serial number or an entry ID is required")
+	}
+
+	if rc.SerialNumber != "" && rc.EntryID != "" {
+		return errors.New("either a serial number or an entry ID is required, not both")
+	}
+
+	return nil
+}
+
+type RevokeCLI struct{}
+
+func (RevokeCLI) Synopsis() string {
+	return "Revokes an SVID, or all the SVIDs of a registration entry"
+}
+
+func (r RevokeCLI) Help() string {
+	_, err := r.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (r RevokeCLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(r.flagSet(&RevokeConfig{}))
+}
+
+func (RevokeCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (r RevokeCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := r.newConfig(args)
+	if err != nil {
+		return r.printErr(err)
+	}
+
+	if err = config.Validate(); err != nil {
+		return r.printErr(err)
+	}
+
+	cl, err := util.NewRegistrationClient(ctx, config.Addr)
+	if err != nil {
+		return r.printErr(err)
+	}
+
+	serialNumbers, err := r.revoke(ctx, cl, config)
+	if err != nil {
+		return r.printErr(err)
+	}
+
+	if config.Output == cliprinter.JSON {
+		out := &registration.RevokeSVIDsReply{SerialNumbers: serialNumbers}
+		if err := cliprinter.PrintJSON(os.Stdout, out); err != nil {
+			return r.printErr(err)
+		}
+		return 0
+	}
+
+	fmt.Printf("Revoked %d SVIDs\n", len(serialNumbers))
+	for _, serialNumber := range serialNumbers {
+		fmt.Printf("Serial number : %s\n", serialNumber)
+	}
+	return 0
+}
+
+func (RevokeCLI) revoke(ctx context.Context, cl registration.RegistrationClient, config *RevokeConfig) ([]string, error) {
+	req := &registration.RevokeSVIDsRequest{
+		SerialNumber: config.SerialNumber,
+		EntryId:      config.EntryID,
+	}
+	resp, err := cl.RevokeSVIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.SerialNumbers, nil
+}
+
+func (r RevokeCLI) newConfig(args []string) (*RevokeConfig, error) {
+	c := &RevokeConfig{}
+	f := r.flagSet(c)
+
+	return c, f.Parse(args)
+}
+
+// flagSet defines the flags of the command, storing their values in c
+func (RevokeCLI) flagSet(c *RevokeConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("svid revoke", flag.ContinueOnError)
+	f.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	f.StringVar(&c.SerialNumber, "serialNumber", "", "The serial number, in decimal, of the SVID to revoke")
+	f.StringVar(&c.EntryID, "entryID", "", "The Registration Entry ID of the record to revoke all the SVIDs of")
+	cliprinter.AppendFlag(f, &c.Output)
+	return f
+}
+
+func (RevokeCLI) printErr(err error) int {
+	fmt.Println(err.Error())
+	return 1
+}
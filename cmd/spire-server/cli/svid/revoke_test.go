@@ -0,0 +1,47 @@
+package svid
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestRevoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_registration.NewMockRegistrationClient(ctrl)
+	req := &registration.RevokeSVIDsRequest{EntryId: "abcdefgh"}
+	resp := &registration.RevokeSVIDsReply{SerialNumbers: []string{"1", "2"}}
+
+	c.EXPECT().RevokeSVIDs(gomock.Any(), req).Return(resp, nil)
+	serialNumbers, err := RevokeCLI{}.revoke(ctx, c, &RevokeConfig{EntryID: "abcdefgh"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, serialNumbers)
+}
+
+func TestRevokeConfigValidate(t *testing.T) {
+	c := &RevokeConfig{Addr: "localhost:8081", SerialNumber: "1"}
+	assert.NoError(t, c.Validate())
+
+	c = &RevokeConfig{Addr: "localhost:8081", EntryID: "abcdefgh"}
+	assert.NoError(t, c.Validate())
+
+	c = &RevokeConfig{Addr: "localhost:8081"}
+	assert.EqualError(t, c.Validate(), "a serial number or an entry ID is required")
+
+	c = &RevokeConfig{Addr: "localhost:8081", SerialNumber: "1", EntryID: "abcdefgh"}
+	assert.EqualError(t, c.Validate(), "either a serial number or an entry ID is required, not both")
+
+	c = &RevokeConfig{SerialNumber: "1"}
+	assert.EqualError(t, c.Validate(), "a server address is required")
+}
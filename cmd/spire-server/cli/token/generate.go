@@ -1,16 +1,25 @@
 package token
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"net/url"
+	"os"
 	"path"
+	"time"
 
+	"github.com/posener/complete"
+	"github.com/satori/go.uuid"
 	"github.com/spiffe/spire/cmd/spire-server/util"
-	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
 	"github.com/spiffe/spire/proto/common"
 
 	"golang.org/x/net/context"
+
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
 )
 
 type GenerateCLI struct{}
@@ -24,6 +33,29 @@ type GenerateConfig struct {
 
 	// Token TTL in seconds
 	TTL int
+
+	// Format to print the outcome in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+
+	// If set, the token is written to this file, only readable by the
+	// user, instead of being printed
+	OutFile string
+}
+
+// generateOutput is the outcome of the command, as printed in JSON
+type generateOutput struct {
+	// The token, unless written to a file
+	Token     string `json:"token,omitempty"`
+	TokenFile string `json:"token_file,omitempty"`
+
+	// SPIFFE ID the agent attesting with the token gets
+	AgentSPIFFEID string `json:"agent_spiffe_id"`
+
+	// Time the token expires at
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Additional SPIFFE ID assigned to the agent, if any
+	SPIFFEID string `json:"spiffe_id,omitempty"`
 }
 
 func (GenerateCLI) Synopsis() string {
@@ -35,6 +67,15 @@ func (g GenerateCLI) Help() string {
 	return err.Error()
 }
 
+func (g GenerateCLI) AutocompleteFlags() complete.Flags {
+	c := GenerateConfig{}
+	return cliprinter.AutocompleteFlags(g.flagSet(&c))
+}
+
+func (GenerateCLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
 func (g GenerateCLI) Run(args []string) int {
 	ctx := context.Background()
 
@@ -44,54 +85,108 @@ func (g GenerateCLI) Run(args []string) int {
 		return 1
 	}
 
-	c, err := util.NewRegistrationClient(ctx, config.Addr)
+	ac, err := util.NewAgentClient(ctx, config.Addr)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
 
-	token, err := g.createToken(ctx, c, config.TTL)
+	ec, err := util.NewEntryClient(ctx, config.Addr)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
 	}
-	fmt.Printf("Token: %s\n", token)
 
-	if config.SpiffeID != "" {
-		err = g.createVanityRecord(ctx, c, token, config.SpiffeID)
-		if err != nil {
-			fmt.Printf("Error assigning SPIFFE ID: %s\n", err.Error())
-			return 1
-		}
+	if err := g.generate(ctx, ac, ec, config, os.Stdout); err != nil {
+		fmt.Println(err.Error())
+		return 1
 	}
 
 	return 0
 }
 
-// createToken calls the registration API and creates a new token
-// with the given TTL. It returns the raw token and an error, if any
-func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl int) (string, error) {
-	req := &registration.JoinToken{Ttl: int32(ttl)}
-	resp, err := c.CreateJoinToken(ctx, req)
+// generate creates the token, along with the registration entry giving the
+// agent the additional SPIFFE ID if any, and prints the outcome. The entry is
+// created first, for a token generated here, so that a token is never handed
+// out without the SPIFFE ID it was asked for. It is deleted again if the
+// token cannot be created.
+func (g GenerateCLI) generate(ctx context.Context, ac agent_pb.AgentClient, ec entry_pb.EntryClient, config GenerateConfig, w io.Writer) error {
+	token := ""
+	entryID := ""
+	if config.SpiffeID != "" {
+		u, err := uuid.NewV4()
+		if err != nil {
+			return fmt.Errorf("unable to generate token: %v", err)
+		}
+		token = u.String()
+
+		entryID, err = g.createVanityRecord(ctx, ec, token, config.SpiffeID)
+		if err != nil {
+			return fmt.Errorf("Error assigning SPIFFE ID: %s", err.Error())
+		}
+	}
+
+	resp, err := g.createToken(ctx, ac, token, config.TTL)
 	if err != nil {
-		return "", err
+		if entryID != "" {
+			if _, delErr := ec.DeleteEntry(ctx, &entry_pb.DeleteEntryRequest{Id: entryID}); delErr != nil {
+				return fmt.Errorf("%v; the registration entry %s created for it could not be deleted: %v", err, entryID, delErr)
+			}
+		}
+		return err
+	}
+	token = resp.Token
+
+	out := &generateOutput{
+		AgentSPIFFEID: resp.AgentSpiffeId,
+		ExpiresAt:     time.Unix(resp.ExpiresAt, 0).UTC(),
+		SPIFFEID:      config.SpiffeID,
 	}
+	if config.OutFile != "" {
+		if err := writeToken(config.OutFile, token); err != nil {
+			return fmt.Errorf("unable to write token: %v", err)
+		}
+		out.TokenFile = config.OutFile
+	} else {
+		out.Token = token
+	}
+
+	if config.Output == cliprinter.JSON {
+		return cliprinter.PrintJSON(w, out)
+	}
+
+	if out.Token != "" {
+		fmt.Fprintf(w, "Token: %s\n", out.Token)
+	} else {
+		fmt.Fprintf(w, "Token written to %s\n", out.TokenFile)
+	}
+	fmt.Fprintf(w, "Agent SPIFFE ID: %s\n", out.AgentSPIFFEID)
+	fmt.Fprintf(w, "Expires at: %s\n", out.ExpiresAt.Format(time.RFC3339))
+	if out.SPIFFEID != "" {
+		fmt.Fprintf(w, "SPIFFE ID: %s\n", out.SPIFFEID)
+	}
+	return nil
+}
 
-	return resp.Token, nil
+// createToken calls the agent API and creates a new token with the given
+// TTL. The server generates the token if none is given. It tells the SPIFFE
+// ID of the agent attesting with it, and when it expires.
+func (GenerateCLI) createToken(ctx context.Context, c agent_pb.AgentClient, token string, ttl int) (*agent_pb.CreateJoinTokenResponse, error) {
+	return c.CreateJoinToken(ctx, &agent_pb.CreateJoinTokenRequest{Token: token, Ttl: int32(ttl)})
 }
 
 // createVanityRecord inserts a registration entry with parent ID set to the SPIFFE ID
 // belonging to a token. The purpose is to allow folks to easily create vanity names
-// backed by token IDs.
-func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.RegistrationClient, token, spiffeID string) error {
+// backed by token IDs. It returns the ID of the entry.
+func (GenerateCLI) createVanityRecord(ctx context.Context, c entry_pb.EntryClient, token, spiffeID string) (string, error) {
 	id, err := url.Parse(spiffeID)
 	if err != nil {
-		return fmt.Errorf("could not parse SPIFFE ID: %s", err.Error())
+		return "", fmt.Errorf("could not parse SPIFFE ID: %s", err.Error())
 	}
 
 	// Basic sanity check before calling the server
 	if id.Scheme != "spiffe" || id.Host == "" || id.Path == "" {
-		return fmt.Errorf("\"%s\" is not a valid SPIFFE ID", id.String())
+		return "", fmt.Errorf("\"%s\" is not a valid SPIFFE ID", id.String())
 	}
 
 	parentID := &url.URL{
@@ -99,34 +194,63 @@ func (GenerateCLI) createVanityRecord(ctx context.Context, c registration.Regist
 		Host:   id.Host,
 		Path:   path.Join("spire", "agent", "join_token", token),
 	}
-	req := &common.RegistrationEntry{
-		ParentId: parentID.String(),
-		SpiffeId: id.String(),
-		Selectors: []*common.Selector{
-			{Type: "spiffe_id", Value: parentID.String()},
+	req := &entry_pb.CreateEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId: parentID.String(),
+			SpiffeId: id.String(),
+			Selectors: []*common.Selector{
+				{Type: "spiffe_id", Value: parentID.String()},
+			},
 		},
 	}
 
-	_, err = c.CreateEntry(ctx, req)
+	resp, err := c.CreateEntry(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Entry.EntryId, nil
+}
+
+// writeToken writes the token to the file, making sure only the user can
+// read it even if the file already exists
+func writeToken(path, token string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
-	return nil
+	if err := f.Chmod(0600); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(f, token)
+	return err
 }
 
-func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
-	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+func (g GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 	c := GenerateConfig{}
-
-	flags.IntVar(&c.TTL, "ttl", 600, "Token TTL in seconds")
-	flags.StringVar(&c.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
-	flags.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	flags := g.flagSet(&c)
 
 	err := flags.Parse(args)
 	if err != nil {
 		return c, err
 	}
 
+	if c.TTL <= 0 {
+		return c, errors.New("the token TTL must be positive")
+	}
+
 	return c, nil
 }
+
+// flagSet defines the flags of the command, storing their values in c
+func (GenerateCLI) flagSet(c *GenerateConfig) *flag.FlagSet {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.IntVar(&c.TTL, "ttl", 600, "Token TTL in seconds")
+	flags.StringVar(&c.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
+	flags.StringVar(&c.Addr, "serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	cliprinter.AppendFlag(flags, &c.Output)
+	flags.StringVar(&c.OutFile, "outFile", "", "Path to write the token to, only readable by the user, instead of printing it")
+	return flags
+}
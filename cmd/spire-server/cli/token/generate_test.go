@@ -1,12 +1,23 @@
 package token
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
-	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/api/v1/entry"
 	"github.com/spiffe/spire/proto/common"
-	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/agent"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
@@ -20,40 +31,170 @@ func TestCreateToken(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	c := mock_registration.NewMockRegistrationClient(ctrl)
-	req := &registration.JoinToken{Ttl: 60}
-	resp := &registration.JoinToken{Token: "foobar", Ttl: 60}
+	c := mock_agent.NewMockAgentClient(ctrl)
+	req := &agent.CreateJoinTokenRequest{Token: "foobar", Ttl: 60}
+	resp := &agent.CreateJoinTokenResponse{Token: "foobar", ExpiresAt: 1000}
 
 	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
-	token, err := GenerateCLI{}.createToken(ctx, c, 60)
+	token, err := GenerateCLI{}.createToken(ctx, c, "foobar", 60)
 	require.NoError(t, err)
-	assert.Equal(t, "foobar", token)
+	assert.Equal(t, resp, token)
 }
 
 func TestCreateVanityRecord(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	c := mock_registration.NewMockRegistrationClient(ctrl)
+	c := mock_entry.NewMockEntryClient(ctrl)
 	token := "foobar"
 	spiffeID := "spiffe://example.org/VanityID"
 	tokenID := "spiffe://example.org/spire/agent/join_token/foobar"
 
-	req := &common.RegistrationEntry{
-		ParentId: tokenID,
-		SpiffeId: spiffeID,
-		Selectors: []*common.Selector{
-			{Type: "spiffe_id", Value: tokenID},
+	req := &entry.CreateEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId: tokenID,
+			SpiffeId: spiffeID,
+			Selectors: []*common.Selector{
+				{Type: "spiffe_id", Value: tokenID},
+			},
 		},
 	}
 
-	c.EXPECT().CreateEntry(gomock.Any(), req)
-	err := GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
+	c.EXPECT().CreateEntry(gomock.Any(), req).
+		Return(&entry.CreateEntryResponse{Entry: &common.RegistrationEntry{EntryId: "entry1"}}, nil)
+	entryID, err := GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
 	assert.NoError(t, err)
+	assert.Equal(t, "entry1", entryID)
 
 	// Test a bad spiffe id
 	spiffeID = "badID/foo/bar"
 	c.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).MaxTimes(0)
-	err = GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
+	_, err = GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
 	assert.Error(t, err)
 }
+
+func TestGenerate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ac := mock_agent.NewMockAgentClient(ctrl)
+	ec := mock_entry.NewMockEntryClient(ctrl)
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectToken := func() {
+		ac.EXPECT().CreateJoinToken(gomock.Any(), &agent.CreateJoinTokenRequest{Ttl: 600}).
+			Return(&agent.CreateJoinTokenResponse{
+				Token:         "foobar",
+				ExpiresAt:     expiresAt.Unix(),
+				AgentSpiffeId: "spiffe://example.org/spire/agent/join_token/foobar",
+			}, nil)
+	}
+
+	expectToken()
+	var out bytes.Buffer
+	require.NoError(t, GenerateCLI{}.generate(ctx, ac, ec, GenerateConfig{TTL: 600, Output: cliprinter.Pretty}, &out))
+	assert.Equal(t, "Token: foobar\n"+
+		"Agent SPIFFE ID: spiffe://example.org/spire/agent/join_token/foobar\n"+
+		"Expires at: 2030-01-01T00:00:00Z\n", out.String())
+
+	// The token is written to a file only the user can read instead of being
+	// printed
+	dir, err := ioutil.TempDir("", "token-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte("old"), 0644))
+
+	// The vanity entry is created before the token, which the server is
+	// given
+	var token string
+	tokenResp := &agent.CreateJoinTokenResponse{ExpiresAt: expiresAt.Unix()}
+	gomock.InOrder(
+		ec.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, req *entry.CreateEntryRequest) {
+				token = strings.TrimPrefix(req.Entry.ParentId, "spiffe://example.org/spire/agent/join_token/")
+			}).
+			Return(&entry.CreateEntryResponse{Entry: &common.RegistrationEntry{EntryId: "entry1"}}, nil),
+		ac.EXPECT().CreateJoinToken(gomock.Any(), gomock.Any()).
+			Do(func(ctx context.Context, req *agent.CreateJoinTokenRequest) {
+				assert.NotEmpty(t, token)
+				assert.Equal(t, &agent.CreateJoinTokenRequest{Token: token, Ttl: 600}, req)
+				tokenResp.Token = token
+				tokenResp.AgentSpiffeId = "spiffe://example.org/spire/agent/join_token/" + token
+			}).
+			Return(tokenResp, nil),
+	)
+	out.Reset()
+	config := GenerateConfig{
+		TTL:      600,
+		SpiffeID: "spiffe://example.org/VanityID",
+		Output:   cliprinter.JSON,
+		OutFile:  tokenPath,
+	}
+	require.NoError(t, GenerateCLI{}.generate(ctx, ac, ec, config, &out))
+
+	var output generateOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &output))
+	assert.Equal(t, generateOutput{
+		TokenFile:     tokenPath,
+		AgentSPIFFEID: "spiffe://example.org/spire/agent/join_token/" + token,
+		ExpiresAt:     expiresAt,
+		SPIFFEID:      "spiffe://example.org/VanityID",
+	}, output)
+
+	data, err := ioutil.ReadFile(tokenPath)
+	require.NoError(t, err)
+	assert.Equal(t, token+"\n", string(data))
+	info, err := os.Stat(tokenPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestGenerateDeletesVanityRecordOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ac := mock_agent.NewMockAgentClient(ctrl)
+	ec := mock_entry.NewMockEntryClient(ctrl)
+	config := GenerateConfig{
+		TTL:      600,
+		SpiffeID: "spiffe://example.org/VanityID",
+		Output:   cliprinter.Pretty,
+	}
+
+	// No token is created if the entry cannot be
+	ec.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).Return(nil, errors.New("create failed"))
+	var out bytes.Buffer
+	err := GenerateCLI{}.generate(ctx, ac, ec, config, &out)
+	assert.EqualError(t, err, "Error assigning SPIFFE ID: create failed")
+
+	// The entry is deleted if the token cannot be created
+	ec.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+		Return(&entry.CreateEntryResponse{Entry: &common.RegistrationEntry{EntryId: "entry1"}}, nil)
+	ac.EXPECT().CreateJoinToken(gomock.Any(), gomock.Any()).Return(nil, errors.New("token failed"))
+	ec.EXPECT().DeleteEntry(gomock.Any(), &entry.DeleteEntryRequest{Id: "entry1"}).Return(&entry.DeleteEntryResponse{}, nil)
+	err = GenerateCLI{}.generate(ctx, ac, ec, config, &out)
+	assert.EqualError(t, err, "token failed")
+
+	// A leftover entry is reported
+	ec.EXPECT().CreateEntry(gomock.Any(), gomock.Any()).
+		Return(&entry.CreateEntryResponse{Entry: &common.RegistrationEntry{EntryId: "entry1"}}, nil)
+	ac.EXPECT().CreateJoinToken(gomock.Any(), gomock.Any()).Return(nil, errors.New("token failed"))
+	ec.EXPECT().DeleteEntry(gomock.Any(), gomock.Any()).Return(nil, errors.New("delete failed"))
+	err = GenerateCLI{}.generate(ctx, ac, ec, config, &out)
+	assert.EqualError(t, err, "token failed; the registration entry entry1 created for it could not be deleted: delete failed")
+	assert.Empty(t, out.String())
+}
+
+func TestNewConfig(t *testing.T) {
+	c, err := GenerateCLI{}.newConfig([]string{"-ttl", "60", "-output", "json", "-outFile", "/tmp/token"})
+	require.NoError(t, err)
+	assert.Equal(t, 60, c.TTL)
+	assert.Equal(t, cliprinter.JSON, c.Output)
+	assert.Equal(t, "/tmp/token", c.OutFile)
+
+	_, err = GenerateCLI{}.newConfig([]string{"-ttl", "0"})
+	assert.EqualError(t, err, "the token TTL must be positive")
+
+	_, err = GenerateCLI{}.newConfig([]string{"-output", "yaml"})
+	assert.EqualError(t, err, `invalid value "yaml" for flag -output: invalid output "yaml"`)
+}
@@ -9,7 +9,13 @@ import (
 
 	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/proto/api/registration"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/api/v1/bundle"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+	"github.com/spiffe/spire/proto/api/v1/stats"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -18,6 +24,60 @@ const (
 )
 
 func NewRegistrationClient(ctx context.Context, address string) (registration.RegistrationClient, error) {
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return registration.NewRegistrationClient(conn), err
+}
+
+// NewAgentClient returns a client for the v1 Agent API of the server
+func NewAgentClient(ctx context.Context, address string) (agent.AgentClient, error) {
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return agent.NewAgentClient(conn), nil
+}
+
+// NewBundleClient returns a client for the v1 Bundle API of the server
+func NewBundleClient(ctx context.Context, address string) (bundle.BundleClient, error) {
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return bundle.NewBundleClient(conn), nil
+}
+
+// NewEntryClient returns a client for the v1 Entry API of the server
+func NewEntryClient(ctx context.Context, address string) (entry.EntryClient, error) {
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return entry.NewEntryClient(conn), nil
+}
+
+// NewLocalAuthorityClient returns a client for the v1 LocalAuthority API of
+// the server
+func NewLocalAuthorityClient(ctx context.Context, address string) (localauthority.LocalAuthorityClient, error) {
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return localauthority.NewLocalAuthorityClient(conn), nil
+}
+
+// NewStatsClient returns a client for the v1 Stats API of the server
+func NewStatsClient(ctx context.Context, address string) (stats.StatsClient, error) {
+	conn, err := dial(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return stats.NewStatsClient(conn), nil
+}
+
+func dial(ctx context.Context, address string) (*grpc.ClientConn, error) {
 	// TODO: Pass a bundle in here
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: true,
@@ -35,11 +95,7 @@ func NewRegistrationClient(ctx context.Context, address string) (registration.Re
 		return nil, err
 	}
 
-	conn, err := dialer.Dial(ctx, addr)
-	if err != nil {
-		return nil, err
-	}
-	return registration.NewRegistrationClient(conn), err
+	return dialer.Dial(ctx, addr)
 }
 
 // Pluralizer concatenates `singular` to `msg` when `val` is one, and
@@ -3,20 +3,25 @@ package agent
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/pprof"
 	"path"
 	"runtime"
 	"sync"
-	"syscall"
 
 	"github.com/spiffe/spire/pkg/agent/attestor/node"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/debug"
 	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/endpoints/delegated"
+	"github.com/spiffe/spire/pkg/agent/health"
 	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/profiling"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/common/metricsink"
 	_ "golang.org/x/net/trace"
 	"google.golang.org/grpc"
 )
@@ -29,7 +34,7 @@ type Agent struct {
 // This method initializes the agent, including its plugins,
 // and then blocks on the main event loop.
 func (a *Agent) Run(ctx context.Context) error {
-	syscall.Umask(a.c.Umask)
+	setUmask(a.c.Umask)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -39,15 +44,41 @@ func (a *Agent) Run(ctx context.Context) error {
 		defer stopProfiling()
 	}
 
+	if err := a.bootstrapTrustBundle(); err != nil {
+		return err
+	}
+
+	var prometheus *telemetry.PrometheusSink
+	if a.c.PrometheusBindAddress != "" {
+		prometheus = telemetry.NewPrometheusSink()
+	}
+
+	var statsd *telemetry.StatsdSink
+	if a.c.Statsd.Address != "" {
+		var err error
+		statsd, err = telemetry.NewStatsdSink(a.c.Statsd)
+		if err != nil {
+			return err
+		}
+		defer statsd.Close()
+	}
+
+	// The plugins are only known once the catalog has been started
+	pluginSink := telemetry.NewPluginSink(a.c.Log.WithField("subsystem_name", "telemetry"))
+
 	tel := telemetry.NewSink(&telemetry.SinkConfig{
 		Logger:      a.c.Log.WithField("subsystem_name", "telemetry").Writer(),
 		ServiceName: "spire_agent",
 		StopChan:    ctx.Done(),
+		Prometheus:  prometheus,
+		Statsd:      statsd,
+		Plugins:     pluginSink,
 	})
 
 	cat := catalog.New(&catalog.Config{
-		PluginConfigs: a.c.PluginConfigs,
-		Log:           a.c.Log.WithField("subsystem_name", "catalog"),
+		PluginConfigs:         a.c.PluginConfigs,
+		Log:                   a.c.Log.WithField("subsystem_name", "catalog"),
+		RequirePluginChecksum: a.c.RequirePluginChecksum,
 	})
 	defer cat.Stop()
 
@@ -55,28 +86,103 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
-	as, err := a.attest(ctx, cat)
+	as, err := a.newAttestor(cat).Attest(ctx)
 	if err != nil {
 		return err
 	}
 
-	manager, err := a.newManager(ctx, tel, as)
+	manager, err := a.newManager(ctx, cat, tel, as)
 	if err != nil {
 		return err
 	}
 
 	endpoints := a.newEndpoints(ctx, cat, tel, manager)
 
-	err = util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		manager.Run,
 		endpoints.ListenAndServe,
-	)
+		a.sendMetricsToPlugins(cat, pluginSink),
+	}
+	if a.c.HealthCheck.Enabled {
+		tasks = append(tasks, a.newHealthChecker(manager).ListenAndServe)
+	}
+	if prometheus != nil {
+		tasks = append(tasks, a.servePrometheus(prometheus))
+	}
+	if a.c.DebugSocketPath != "" {
+		tasks = append(tasks, a.newDebugServer(manager).ListenAndServe)
+	}
+	if a.c.DelegatedIdentity.SocketPath != "" {
+		tasks = append(tasks, a.newDelegatedIdentityServer(cat, tel, manager).ListenAndServe)
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
 	return err
 }
 
+func (a *Agent) newDebugServer(mgr manager.Manager) *debug.Server {
+	return &debug.Server{
+		SocketPath: a.c.DebugSocketPath,
+		Manager:    mgr,
+		LogLevels:  a.c.LogLevels,
+		Health:     a.newHealthChecker(mgr),
+		Log:        a.c.Log.WithField("subsystem_name", "debug"),
+	}
+}
+
+func (a *Agent) newDelegatedIdentityServer(cat catalog.Catalog, tel telemetry.Sink, mgr manager.Manager) *delegated.Server {
+	return &delegated.Server{
+		Config:  a.c.DelegatedIdentity,
+		Manager: mgr,
+		Catalog: cat,
+		Log:     a.c.Log.WithField("subsystem_name", "delegated_identity_api"),
+		Tel:     tel,
+	}
+}
+
+// sendMetricsToPlugins returns a task sending the metrics to the MetricSink
+// plugins until the context is cancelled
+func (a *Agent) sendMetricsToPlugins(cat catalog.Catalog, sink *telemetry.PluginSink) func(context.Context) error {
+	var sinks []metricsink.MetricSink
+	for _, p := range cat.MetricSinks() {
+		sinks = append(sinks, p)
+	}
+	return func(ctx context.Context) error {
+		return sink.Run(ctx, sinks)
+	}
+}
+
+// servePrometheus returns a task serving the metrics for Prometheus to
+// scrape until the context is cancelled
+func (a *Agent) servePrometheus(sink *telemetry.PrometheusSink) func(context.Context) error {
+	return func(ctx context.Context) error {
+		l, err := net.Listen("tcp", a.c.PrometheusBindAddress)
+		if err != nil {
+			return fmt.Errorf("create prometheus listener: %v", err)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", sink)
+		server := &http.Server{Handler: mux}
+
+		a.c.Log.Infof("Serving Prometheus metrics on %s", l.Addr())
+		errChan := make(chan error)
+		go func() { errChan <- server.Serve(l) }()
+
+		select {
+		case err := <-errChan:
+			return err
+		case <-ctx.Done():
+			server.Close()
+			<-errChan
+			return nil
+		}
+	}
+}
+
 func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	var wg sync.WaitGroup
 	ctx, cancel := context.WithCancel(ctx)
@@ -131,7 +237,9 @@ func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
 	}
 }
 
-func (a *Agent) attest(ctx context.Context, cat catalog.Catalog) (*attestor.AttestationResult, error) {
+// newAttestor returns a node attestor. A new attestor is used for every
+// attestation since it keeps the node client it dials.
+func (a *Agent) newAttestor(cat catalog.Catalog) attestor.Attestor {
 	config := attestor.Config{
 		Catalog:         cat,
 		JoinToken:       a.c.JoinToken,
@@ -142,20 +250,36 @@ func (a *Agent) attest(ctx context.Context, cat catalog.Catalog) (*attestor.Atte
 		Log:             a.c.Log.WithField("subsystem_name", "attestor"),
 		ServerAddress:   a.c.ServerAddress,
 	}
-	return attestor.New(&config).Attest(ctx)
+	return attestor.New(&config)
 }
 
-func (a *Agent) newManager(ctx context.Context, tel telemetry.Sink, as *attestor.AttestationResult) (manager.Manager, error) {
+func (a *Agent) newManager(ctx context.Context, cat catalog.Catalog, tel telemetry.Sink, as *attestor.AttestationResult) (manager.Manager, error) {
 	config := &manager.Config{
-		SVID:            as.SVID,
-		SVIDKey:         as.Key,
-		Bundle:          as.Bundle,
-		TrustDomain:     a.c.TrustDomain,
-		ServerAddr:      a.c.ServerAddress,
-		Log:             a.c.Log.WithField("subsystem_name", "manager"),
-		Tel:             tel,
-		BundleCachePath: a.bundleCachePath(),
-		SVIDCachePath:   a.agentSVIDPath(),
+		SVID:                  as.SVID,
+		SVIDKey:               as.Key,
+		SVIDIntermediates:     as.Intermediates,
+		Bundle:                as.Bundle,
+		TrustDomain:           a.c.TrustDomain,
+		ServerAddr:            a.c.ServerAddress,
+		Log:                   a.c.Log.WithField("subsystem_name", "manager"),
+		Tel:                   tel,
+		BundleCachePath:       a.bundleCachePath(),
+		SVIDCachePath:         a.agentSVIDPath(),
+		EntryCachePath:        a.entryCachePath(),
+		EntryCacheKeyPath:     a.c.EntryCacheKeyPath,
+		SyncInterval:          a.c.SyncInterval,
+		RotationThreshold:     a.c.RotationThreshold,
+		LazySVIDMinting:       a.c.LazySVIDMinting,
+		WatchUpdates:          a.c.WatchUpdates,
+		MaxSVIDStaleness:      a.c.MaxSVIDStaleness,
+		ExpiringSVIDThreshold: a.c.ExpiringSVIDThreshold,
+		Reattest: func(ctx context.Context) (svid.State, error) {
+			as, err := a.newAttestor(cat).Reattest(ctx)
+			if err != nil {
+				return svid.State{}, err
+			}
+			return svid.State{SVID: as.SVID, Key: as.Key, Intermediates: as.Intermediates}, nil
+		},
 	}
 
 	mgr, err := manager.New(config)
@@ -171,17 +295,45 @@ func (a *Agent) newManager(ctx context.Context, tel telemetry.Sink, as *attestor
 }
 
 func (a *Agent) newEndpoints(ctx context.Context, cat catalog.Catalog, tel telemetry.Sink, mgr manager.Manager) endpoints.Server {
+	bindAddrs := []net.Addr{a.workloadAPIAddr()}
+	for _, addr := range a.c.AdditionalBindAddresses {
+		bindAddrs = append(bindAddrs, addr)
+	}
+
 	config := &endpoints.Config{
-		BindAddr: a.c.BindAddress,
-		Catalog:  cat,
-		Manager:  mgr,
-		Log:      a.c.Log.WithField("subsystem_name", "endpoints"),
-		Tel:      tel,
+		BindAddrs:    bindAddrs,
+		Catalog:      cat,
+		Manager:      mgr,
+		TrustDomain:  a.c.TrustDomain,
+		SDS:          a.c.SDS,
+		Limits:       a.c.WorkloadAPILimits,
+		DrainTimeout: a.c.ShutdownDrainTimeout,
+		Log:          a.c.Log.WithField("subsystem_name", "endpoints"),
+		Tel:          tel,
 	}
 
 	return endpoints.New(config)
 }
 
+func (a *Agent) newHealthChecker(mgr manager.Manager) *health.Checker {
+	return &health.Checker{
+		Config:       a.c.HealthCheck,
+		Manager:      mgr,
+		SyncInterval: a.c.SyncInterval,
+		Log:          a.c.Log.WithField("subsystem_name", "health"),
+
+		WorkloadAPIAddr: a.workloadAPIAddr(),
+	}
+}
+
+// workloadAPIAddr returns the main address the Workload API is served on
+func (a *Agent) workloadAPIAddr() net.Addr {
+	if a.c.TCPBindAddress != nil {
+		return a.c.TCPBindAddress
+	}
+	return a.c.BindAddress
+}
+
 func (a *Agent) bundleCachePath() string {
 	return path.Join(a.c.DataDir, "bundle.der")
 }
@@ -189,3 +341,12 @@ func (a *Agent) bundleCachePath() string {
 func (a *Agent) agentSVIDPath() string {
 	return path.Join(a.c.DataDir, "agent_svid.der")
 }
+
+// entryCachePath returns the path of the encrypted entry cache, or an empty
+// path if no key is configured to encrypt it
+func (a *Agent) entryCachePath() string {
+	if a.c.EntryCacheKeyPath == "" {
+		return ""
+	}
+	return path.Join(a.c.DataDir, "entry_cache.bin")
+}
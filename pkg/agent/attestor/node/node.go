@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	spiffe_tls "github.com/spiffe/go-spiffe/tls"
@@ -18,6 +19,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/grpcutil"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
 	"github.com/spiffe/spire/proto/api/node"
@@ -30,10 +32,21 @@ type AttestationResult struct {
 	SVID   *x509.Certificate
 	Key    *ecdsa.PrivateKey
 	Bundle []*x509.Certificate
+
+	// Intermediates between the SVID and the bundle, when the CA of the
+	// server is an intermediate of the upstream CA
+	Intermediates []*x509.Certificate
 }
 
 type Attestor interface {
+	// Attest returns the agent SVID cached on disk, performing node
+	// attestation if there is none or it has expired.
 	Attest(ctx context.Context) (*AttestationResult, error)
+
+	// Reattest performs node attestation with a new key, regardless of
+	// the SVID cached on disk. The server decides whether the attestation
+	// data may be used again, e.g. join tokens are single-use.
+	Reattest(ctx context.Context) (*AttestationResult, error)
 }
 
 type Config struct {
@@ -61,33 +74,57 @@ func (a *attestor) Attest(ctx context.Context) (*AttestationResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	svid, key, err := a.loadSVID(ctx)
+	svid, intermediates, key, err := a.loadSVID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if svid != nil && time.Now().After(svid.NotAfter) {
+		a.c.Log.Warn("Cached agent SVID has expired. Will perform node attestation")
+		svid = nil
+	}
+
 	if svid == nil {
-		svid, bundle, err = a.newSVID(ctx, key, bundle)
-		if err != nil {
-			return nil, err
-		}
+		return a.newSVID(ctx, key, bundle)
 	}
-	return &AttestationResult{Bundle: bundle, SVID: svid, Key: key}, nil
+	return &AttestationResult{Bundle: bundle, SVID: svid, Intermediates: intermediates, Key: key}, nil
 }
 
-func (a *attestor) loadSVID(ctx context.Context) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+func (a *attestor) Reattest(ctx context.Context) (*AttestationResult, error) {
+	bundle, err := a.loadBundle()
+	if err != nil {
+		return nil, err
+	}
+
 	mgrs := a.c.Catalog.KeyManagers()
 	if len(mgrs) > 1 {
-		return nil, nil, errors.New("more than one key manager configured")
+		return nil, errors.New("more than one key manager configured")
+	}
+	gResp, err := mgrs[0].GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("generate key pair: %s", err)
+	}
+	key, err := x509.ParseECPrivateKey(gResp.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parse key from keymanager: %v", err)
+	}
+
+	return a.newSVID(ctx, key, bundle)
+}
+
+func (a *attestor) loadSVID(ctx context.Context) (*x509.Certificate, []*x509.Certificate, *ecdsa.PrivateKey, error) {
+	mgrs := a.c.Catalog.KeyManagers()
+	if len(mgrs) > 1 {
+		return nil, nil, nil, errors.New("more than one key manager configured")
 	}
 
 	mgr := mgrs[0]
 	fResp, err := mgr.FetchPrivateKey(ctx, &keymanager.FetchPrivateKeyRequest{})
 	if err != nil {
-		return nil, nil, fmt.Errorf("load private key: %v", err)
+		return nil, nil, nil, fmt.Errorf("load private key: %v", err)
 	}
 
-	svid := a.readSVIDFromDisk()
+	svid, intermediates := a.readSVIDFromDisk()
 	if len(fResp.PrivateKey) > 0 && svid == nil {
 		a.c.Log.Warn("Private key recovered, but no SVID found")
 	}
@@ -98,19 +135,19 @@ func (a *attestor) loadSVID(ctx context.Context) (*x509.Certificate, *ecdsa.Priv
 	} else {
 		gResp, err := mgr.GenerateKeyPair(ctx, &keymanager.GenerateKeyPairRequest{})
 		if err != nil {
-			return nil, nil, fmt.Errorf("generate key pair: %s", err)
+			return nil, nil, nil, fmt.Errorf("generate key pair: %s", err)
 		}
 
-		svid = nil
+		svid, intermediates = nil, nil
 		keyData = gResp.PrivateKey
 	}
 
 	key, err := x509.ParseECPrivateKey(keyData)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
+		return nil, nil, nil, fmt.Errorf("parse key from keymanager: %v", key)
 	}
 
-	return svid, key, nil
+	return svid, intermediates, key, nil
 }
 
 func (a *attestor) loadBundle() ([]*x509.Certificate, error) {
@@ -175,20 +212,21 @@ func (a *attestor) fetchAttestationData(
 
 // Read agent SVID from data dir. If an error is encountered, it will be logged and `nil`
 // will be returned.
-func (a *attestor) readSVIDFromDisk() *x509.Certificate {
-	cert, err := manager.ReadSVID(a.c.SVIDCachePath)
+func (a *attestor) readSVIDFromDisk() (*x509.Certificate, []*x509.Certificate) {
+	cert, intermediates, err := manager.ReadSVID(a.c.SVIDCachePath)
 	if err == manager.ErrNotCached {
 		a.c.Log.Debug("No pre-existing agent SVID found. Will perform node attestation")
-		return nil
+		return nil, nil
 	} else if err != nil {
 		a.c.Log.Warnf("Could not get agent SVID from %s: %s", a.c.SVIDCachePath, err)
 	}
-	return cert
+	return cert, intermediates
 }
 
 // newSVID obtains an agent svid for the given private key by performing node attesatation. The bundle is
-// necessary in order to validate the SPIRE server we are attesting to. Returns the SVID and an updated bundle.
-func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []*x509.Certificate) (*x509.Certificate, []*x509.Certificate, error) {
+// necessary in order to validate the SPIRE server we are attesting to. Returns the SVID, its intermediates
+// and an updated bundle.
+func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []*x509.Certificate) (*AttestationResult, error) {
 	// make sure all of the streams are cancelled if something goes awry
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -197,19 +235,19 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []
 	if a.c.JoinToken == "" {
 		plugins := a.c.Catalog.NodeAttestors()
 		if len(plugins) > 1 {
-			return nil, nil, errors.New("more than one node attestor configured")
+			return nil, errors.New("more than one node attestor configured")
 		}
 		attestor := plugins[0]
 		var err error
 		fetchStream, err = attestor.FetchAttestationData(ctx)
 		if err != nil {
-			return nil, nil, fmt.Errorf("opening stream for fetching attestation: %v", err)
+			return nil, fmt.Errorf("opening stream for fetching attestation: %v", err)
 		}
 	}
 
 	conn, err := a.serverConn(ctx, bundle)
 	if err != nil {
-		return nil, nil, fmt.Errorf("create attestation client: %v", err)
+		return nil, fmt.Errorf("create attestation client: %v", err)
 	}
 	defer conn.Close()
 	if a.c.NodeClient == nil {
@@ -218,7 +256,7 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []
 
 	attestStream, err := a.c.NodeClient.Attest(ctx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("opening stream for attestation: %v", err)
+		return nil, fmt.Errorf("opening stream for attestation: %v", err)
 	}
 
 	var spiffeID string
@@ -227,14 +265,14 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []
 	for {
 		data, err := a.fetchAttestationData(fetchStream, attestResp.Challenge)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
 		// (re)generate the SVID if the spiffeid changes.
 		if spiffeID != data.SpiffeId {
 			csr, err = util.MakeCSR(key, data.SpiffeId)
 			if err != nil {
-				return nil, nil, fmt.Errorf("generate CSR for agent SVID: %v", err)
+				return nil, fmt.Errorf("generate CSR for agent SVID: %v", err)
 			}
 			spiffeID = data.SpiffeId
 		}
@@ -246,12 +284,12 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []
 		}
 
 		if err := attestStream.Send(attestReq); err != nil {
-			return nil, nil, fmt.Errorf("sending attestation request to SPIRE server: %v", err)
+			return nil, fmt.Errorf("sending attestation request to SPIRE server: %v", err)
 		}
 
 		attestResp, err = attestStream.Recv()
 		if err != nil {
-			return nil, nil, fmt.Errorf("attesting to SPIRE server: %v", err)
+			return nil, fmt.Errorf("attesting to SPIRE server: %v", err)
 		}
 
 		// if the response has no additional data then break out and parse
@@ -272,12 +310,13 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle []
 		a.c.Log.Warnf("received unexpected result on trailing recv: %v", err)
 	}
 
-	svid, bundle, err := a.parseAttestationResponse(spiffeID, attestResp)
+	result, err := a.parseAttestationResponse(spiffeID, attestResp)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parse attestation response: %v", err)
+		return nil, fmt.Errorf("parse attestation response: %v", err)
 	}
+	result.Key = key
 
-	return svid, bundle, nil
+	return result, nil
 }
 
 func (a *attestor) serverConn(ctx context.Context, bundle []*x509.Certificate) (*grpc.ClientConn, error) {
@@ -307,27 +346,27 @@ func (a *attestor) serverCredFunc(bundle []*x509.Certificate) func() (credential
 	return credFunc
 }
 
-func (a *attestor) parseAttestationResponse(id string, r *node.AttestResponse) (*x509.Certificate, []*x509.Certificate, error) {
+func (a *attestor) parseAttestationResponse(id string, r *node.AttestResponse) (*AttestationResult, error) {
 	if len(r.SvidUpdate.Svids) < 1 {
-		return nil, nil, errors.New("no svid received")
+		return nil, errors.New("no svid received")
 	}
 
 	svidMsg, ok := r.SvidUpdate.Svids[id]
 	if !ok {
-		return nil, nil, fmt.Errorf("incorrect svid: %s", id)
+		return nil, fmt.Errorf("incorrect svid: %s", id)
 	}
 
-	svid, err := x509.ParseCertificate(svidMsg.SvidCert)
+	svid, intermediates, err := x509svid.ParseChain(svidMsg.SvidCert)
 	if err != nil {
-		return nil, nil, fmt.Errorf("invalid svid: %v", err)
+		return nil, fmt.Errorf("invalid svid: %v", err)
 	}
 
 	bundle, err := x509.ParseCertificates(r.SvidUpdate.Bundle)
 	if err != nil {
-		return nil, nil, fmt.Errorf("invalid bundle: %v", bundle)
+		return nil, fmt.Errorf("invalid bundle: %v", bundle)
 	}
 
-	return svid, bundle, nil
+	return &AttestationResult{SVID: svid, Intermediates: intermediates, Bundle: bundle}, nil
 }
 
 func (a *attestor) serverID() *url.URL {
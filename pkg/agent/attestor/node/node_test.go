@@ -9,9 +9,11 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
 	"github.com/spiffe/spire/proto/api/node"
@@ -101,6 +103,51 @@ func (s *NodeAttestorTestSuite) TestAttestLoadFromDisk() {
 	s.Assert().Equal(as.Bundle, bundle)
 }
 
+func (s *NodeAttestorTestSuite) TestAttestExpiredSVIDFromDisk() {
+	s.linkBundle()
+
+	temp, err := util.NewSVIDTemplate("spiffe://example.com/spire/agent/join_token/foobar")
+	s.Require().NoError(err)
+	temp.NotBefore = time.Now().Add(-2 * time.Hour)
+	temp.NotAfter = time.Now().Add(-1 * time.Hour)
+	expired, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.Require().NoError(manager.StoreSVID(s.config.SVIDCachePath, expired, nil))
+
+	// The key is kept, the SVID is obtained by attesting again
+	s.setCatalog(true)
+	s.setFetchPrivateKeyResponse()
+	s.setFetchAttestationDataResponse(nil)
+	s.setAttestResponse(nil)
+	as, err := s.attestor.Attest(ctx)
+	s.Require().NoError(err)
+
+	svid, key, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+
+	s.Assert().Equal(as.Key, key)
+	s.Assert().Equal(as.SVID, svid)
+}
+
+func (s *NodeAttestorTestSuite) TestReattest() {
+	s.linkBundle()
+	s.linkAgentSVIDPath()
+
+	// The SVID on disk is ignored
+	s.setCatalog(true)
+	s.setGenerateKeyPairResponse()
+	s.setFetchAttestationDataResponse(nil)
+	s.setAttestResponse(nil)
+	as, err := s.attestor.Reattest(ctx)
+	s.Require().NoError(err)
+
+	svid, key, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+
+	s.Assert().Equal(as.Key, key)
+	s.Assert().Equal(as.SVID, svid)
+}
+
 func (s *NodeAttestorTestSuite) TestAttestNode() {
 	s.linkBundle()
 	s.setCatalog(true)
@@ -1,8 +1,8 @@
 /*
 The auth package handles GRPC transport "security" for the workload API. It
 does so by implementing the GRPC credential interface, the function of which
-is dependent on the underlying transport method. Currently, only UNIX domain
-sockets are supported.
+is dependent on the underlying transport method. UNIX domain sockets are
+supported on Linux and BSD variants, and loopback TCP connections on Windows.
 
 In the context of the Workload API, we are looking to retrieve the PID of the
 caller. To do this, two steps are required: 1) use one of the types provided
@@ -35,6 +35,11 @@ type CallerInfo struct {
 	Addr net.Addr
 	PID  int32
 
+	// UID of the caller. It is only resolved for unix domain socket
+	// connections on Linux, HasUID is false otherwise.
+	UID    uint32
+	HasUID bool
+
 	// Bailing out during gRPC transport negotiation can lead to
 	// "weird" behavior, and it may also be unclear as to why the
 	// connection failed to establish. Instead, allow the connection
@@ -26,6 +26,8 @@ func (c *grpcCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.
 	switch conn.RemoteAddr().Network() {
 	case "unix":
 		info = FromUDSConn(conn)
+	case "tcp":
+		info = FromTCPConn(conn)
 	default:
 		info = CallerInfo{Err: ErrUnsupportedTransport}
 	}
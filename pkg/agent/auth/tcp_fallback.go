@@ -0,0 +1,13 @@
+// +build !windows
+
+package auth
+
+import "net"
+
+// FromTCPConn is only supported on Windows, where unix domain socket peer
+// credentials are not available
+func FromTCPConn(conn net.Conn) CallerInfo {
+	var info CallerInfo
+	info.Err = ErrUnsupportedPlatform
+	return info
+}
@@ -0,0 +1,122 @@
+// +build windows
+
+package auth
+
+import (
+	"encoding/binary"
+	"net"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// Address family and table class requesting the IPv4 TCP connections
+	// along with the PID of the process owning them
+	afINET                      = 2
+	tcpTableOwnerPIDConnections = 4
+
+	errorInsufficientBuffer = 122
+)
+
+var (
+	iphlpapi                = windows.NewLazySystemDLL("iphlpapi.dll")
+	procGetExtendedTCPTable = iphlpapi.NewProc("GetExtendedTcpTable")
+)
+
+// tcpRowOwnerPID mirrors MIB_TCPROW_OWNER_PID. Addresses and ports are in
+// network byte order.
+type tcpRowOwnerPID struct {
+	State      uint32
+	LocalAddr  uint32
+	LocalPort  uint32
+	RemoteAddr uint32
+	RemotePort uint32
+	OwningPID  uint32
+}
+
+// FromTCPConn resolves the PID of the caller of a loopback TCP connection
+// by looking up the process owning the other end of the connection in the
+// TCP connection table. Only IPv4 loopback connections are supported.
+func FromTCPConn(conn net.Conn) CallerInfo {
+	info := CallerInfo{Addr: conn.RemoteAddr()}
+
+	local, ok := conn.LocalAddr().(*net.TCPAddr)
+	if !ok {
+		info.Err = ErrInvalidConnection
+		return info
+	}
+	remote, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok || !remote.IP.IsLoopback() || remote.IP.To4() == nil {
+		info.Err = ErrInvalidConnection
+		return info
+	}
+
+	rows, err := tcpConnections()
+	if err != nil {
+		info.Err = err
+		return info
+	}
+
+	// The caller's end of the connection has our remote address as its
+	// local address and the other way around
+	for _, row := range rows {
+		if sameAddr(row.LocalAddr, row.LocalPort, remote) && sameAddr(row.RemoteAddr, row.RemotePort, local) {
+			info.PID = int32(row.OwningPID)
+			return info
+		}
+	}
+
+	info.Err = ErrInvalidConnection
+	return info
+}
+
+// tcpConnections returns the IPv4 TCP connection table
+func tcpConnections() ([]tcpRowOwnerPID, error) {
+	buf := make([]byte, 1)
+	size := uint32(len(buf))
+	for {
+		r, _, _ := procGetExtendedTCPTable.Call(
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(unsafe.Pointer(&size)),
+			0,
+			afINET,
+			tcpTableOwnerPIDConnections,
+			0)
+		switch syscall.Errno(r) {
+		case 0:
+			return parseTCPTable(buf), nil
+		case errorInsufficientBuffer:
+			// The table changed size, try again with the size reported
+			buf = make([]byte, size)
+		default:
+			return nil, syscall.Errno(r)
+		}
+	}
+}
+
+func parseTCPTable(buf []byte) []tcpRowOwnerPID {
+	rowSize := int(unsafe.Sizeof(tcpRowOwnerPID{}))
+	count := int(binary.LittleEndian.Uint32(buf))
+
+	var rows []tcpRowOwnerPID
+	for i := 0; i < count; i++ {
+		offset := 4 + i*rowSize
+		if offset+rowSize > len(buf) {
+			break
+		}
+		rows = append(rows, *(*tcpRowOwnerPID)(unsafe.Pointer(&buf[offset])))
+	}
+	return rows
+}
+
+func sameAddr(addr, port uint32, tcpAddr *net.TCPAddr) bool {
+	ip := make(net.IP, 4)
+	binary.LittleEndian.PutUint32(ip, addr)
+
+	// Only the low 16 bits hold the port, in network byte order
+	p := int(port&0xff)<<8 | int(port>>8&0xff)
+
+	return ip.Equal(tcpAddr.IP) && p == tcpAddr.Port
+}
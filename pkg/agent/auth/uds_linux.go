@@ -31,5 +31,7 @@ func FromUDSConn(conn net.Conn) CallerInfo {
 
 	info.Addr = uconn.RemoteAddr()
 	info.PID = int32(ucred.Pid)
+	info.UID = ucred.Uid
+	info.HasUID = true
 	return info
 }
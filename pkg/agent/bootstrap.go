@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/common/util"
+)
+
+const (
+	// How long the download of the bootstrap trust bundle may take
+	trustBundleFetchTimeout = 30 * time.Second
+
+	// Bootstrap trust bundles larger than this are rejected
+	maxTrustBundleSize = 1 << 20
+)
+
+// bootstrapTrustBundle downloads the trust bundle from the configured URL,
+// unless a bundle was configured or is cached from a previous run. The
+// cached bundle is kept up to date by the server and takes precedence.
+func (a *Agent) bootstrapTrustBundle() error {
+	if a.c.TrustBundle != nil || a.c.TrustBundleURL == "" {
+		return nil
+	}
+
+	if _, err := manager.ReadBundle(a.bundleCachePath()); err != manager.ErrNotCached {
+		return err
+	}
+
+	a.c.Log.Infof("Fetching the bootstrap trust bundle from %s", a.c.TrustBundleURL)
+	bundle, err := fetchTrustBundle(a.c.TrustBundleURL, a.c.TrustBundleSHA256, nil)
+	if err != nil {
+		return fmt.Errorf("bootstrap trust bundle: %v", err)
+	}
+
+	a.c.TrustBundle = bundle
+	return nil
+}
+
+// fetchTrustBundle downloads the PEM encoded trust bundle over HTTPS. The
+// server certificate is validated against roots, or the system roots if nil,
+// unless the SHA-256 hash of the bundle is pinned, in which case the bundle
+// is checked against the hash instead.
+func fetchTrustBundle(bundleURL, sha256Hex string, roots *x509.CertPool) ([]*x509.Certificate, error) {
+	if !strings.HasPrefix(bundleURL, "https://") {
+		return nil, errors.New("the trust bundle URL must use HTTPS")
+	}
+
+	tlsConfig := &tls.Config{RootCAs: roots}
+	if sha256Hex != "" {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	client := &http.Client{
+		Timeout:   trustBundleFetchTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+
+	resp, err := client.Get(bundleURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxTrustBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxTrustBundleSize {
+		return nil, fmt.Errorf("trust bundle is larger than %d bytes", maxTrustBundleSize)
+	}
+
+	if sha256Hex != "" {
+		expected, err := hex.DecodeString(sha256Hex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trust bundle hash: %v", err)
+		}
+		actual := sha256.Sum256(data)
+		if !bytes.Equal(actual[:], expected) {
+			return nil, fmt.Errorf("trust bundle hash %x does not match the pinned hash", actual)
+		}
+	}
+
+	bundle, err := util.ParseCertificates(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle) == 0 {
+		return nil, errors.New("no certificates found in trust bundle")
+	}
+	return bundle, nil
+}
@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/util"
+	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchTrustBundle(t *testing.T) {
+	ca, _, err := testutil.LoadCAFixture()
+	require.NoError(t, err)
+	bundlePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	hash := sha256.Sum256(bundlePEM)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bundle.pem" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(bundlePEM)
+	}))
+	defer server.Close()
+	roots := util.NewCertPool(server.Certificate())
+
+	// Server validated against the roots
+	bundle, err := fetchTrustBundle(server.URL+"/bundle.pem", "", roots)
+	require.NoError(t, err)
+	require.Len(t, bundle, 1)
+	require.True(t, bundle[0].Equal(ca))
+
+	// Server not trusted
+	_, err = fetchTrustBundle(server.URL+"/bundle.pem", "", nil)
+	require.Error(t, err)
+
+	// Pinned hash, the server certificate is not validated
+	bundle, err = fetchTrustBundle(server.URL+"/bundle.pem", hex.EncodeToString(hash[:]), nil)
+	require.NoError(t, err)
+	require.Len(t, bundle, 1)
+
+	// Pinned hash mismatch
+	otherHash := sha256.Sum256([]byte("other"))
+	_, err = fetchTrustBundle(server.URL+"/bundle.pem", hex.EncodeToString(otherHash[:]), nil)
+	require.Contains(t, err.Error(), "does not match the pinned hash")
+
+	// Not found
+	_, err = fetchTrustBundle(server.URL+"/missing", "", roots)
+	require.EqualError(t, err, "unexpected status 404 Not Found")
+
+	// Plain HTTP
+	_, err = fetchTrustBundle("http://example.org/bundle.pem", "", nil)
+	require.EqualError(t, err, "the trust bundle URL must use HTTPS")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/common/metricsink"
 
 	goplugin "github.com/hashicorp/go-plugin"
 	common "github.com/spiffe/spire/pkg/common/catalog"
@@ -24,12 +25,14 @@ import (
 
 const (
 	KeyManagerType       = "KeyManager"
+	MetricSinkType       = "MetricSink"
 	NodeAttestorType     = "NodeAttestor"
 	WorkloadAttestorType = "WorkloadAttestor"
 )
 
 type Catalog interface {
 	KeyManagers() []*ManagedKeyManager
+	MetricSinks() []*ManagedMetricSink
 	NodeAttestors() []*ManagedNodeAttestor
 	WorkloadAttestors() []*ManagedWorkloadAttestor
 }
@@ -37,6 +40,7 @@ type Catalog interface {
 var (
 	supportedPlugins = map[string]goplugin.Plugin{
 		KeyManagerType:       &keymanager.GRPCPlugin{},
+		MetricSinkType:       &metricsink.GRPCPlugin{},
 		NodeAttestorType:     &nodeattestor.GRPCPlugin{},
 		WorkloadAttestorType: &workloadattestor.GRPCPlugin{},
 	}
@@ -62,6 +66,9 @@ var (
 type Config struct {
 	PluginConfigs common.PluginConfigMap
 	Log           logrus.FieldLogger
+
+	// If set, external plugins are only run if their checksum is configured
+	RequirePluginChecksum bool
 }
 
 type AgentCatalog struct {
@@ -70,6 +77,7 @@ type AgentCatalog struct {
 	log logrus.FieldLogger
 
 	keyManagerPlugins       []*ManagedKeyManager
+	metricSinkPlugins       []*ManagedMetricSink
 	nodeAttestorPlugins     []*ManagedNodeAttestor
 	workloadAttestorPlugins []*ManagedWorkloadAttestor
 }
@@ -80,6 +88,8 @@ func New(c *Config) *AgentCatalog {
 		SupportedPlugins: supportedPlugins,
 		BuiltinPlugins:   builtinPlugins,
 		Log:              c.Log,
+
+		RequirePluginChecksum: c.RequirePluginChecksum,
 	}
 
 	return &AgentCatalog{
@@ -130,6 +140,13 @@ func (c *AgentCatalog) KeyManagers() []*ManagedKeyManager {
 	return append([]*ManagedKeyManager(nil), c.keyManagerPlugins...)
 }
 
+func (c *AgentCatalog) MetricSinks() []*ManagedMetricSink {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	return append([]*ManagedMetricSink(nil), c.metricSinkPlugins...)
+}
+
 func (c *AgentCatalog) NodeAttestors() []*ManagedNodeAttestor {
 	c.m.RLock()
 	defer c.m.RUnlock()
@@ -165,6 +182,12 @@ func (c *AgentCatalog) categorize() error {
 				return fmt.Errorf(errMsg, p.Config.PluginName, KeyManagerType)
 			}
 			c.keyManagerPlugins = append(c.keyManagerPlugins, NewManagedKeyManager(pl, p.Config))
+		case MetricSinkType:
+			pl, ok := p.Plugin.(metricsink.MetricSink)
+			if !ok {
+				return fmt.Errorf(errMsg, p.Config.PluginName, MetricSinkType)
+			}
+			c.metricSinkPlugins = append(c.metricSinkPlugins, NewManagedMetricSink(pl, p.Config))
 		case NodeAttestorType:
 			pl, ok := p.Plugin.(nodeattestor.NodeAttestor)
 			if !ok {
@@ -182,7 +205,8 @@ func (c *AgentCatalog) categorize() error {
 		}
 	}
 
-	// Guarantee we have at least one of each type
+	// Guarantee we have at least one of each type. Metric sinks are
+	// optional and not counted.
 	pluginCount := map[string]int{}
 	pluginCount[KeyManagerType] = len(c.keyManagerPlugins)
 	pluginCount[NodeAttestorType] = len(c.nodeAttestorPlugins)
@@ -198,6 +222,7 @@ func (c *AgentCatalog) categorize() error {
 
 func (c *AgentCatalog) reset() {
 	c.keyManagerPlugins = nil
+	c.metricSinkPlugins = nil
 	c.nodeAttestorPlugins = nil
 	c.workloadAttestorPlugins = nil
 }
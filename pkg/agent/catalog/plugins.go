@@ -5,6 +5,7 @@ import (
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/common/metricsink"
 )
 
 type ManagedKeyManager struct {
@@ -23,6 +24,22 @@ func (p *ManagedKeyManager) Config() common.PluginConfig {
 	return p.config
 }
 
+type ManagedMetricSink struct {
+	config common.PluginConfig
+	metricsink.MetricSink
+}
+
+func NewManagedMetricSink(p metricsink.MetricSink, config common.PluginConfig) *ManagedMetricSink {
+	return &ManagedMetricSink{
+		config:     config,
+		MetricSink: p,
+	}
+}
+
+func (p *ManagedMetricSink) Config() common.PluginConfig {
+	return p.config
+}
+
 type ManagedNodeAttestor struct {
 	config common.PluginConfig
 	nodeattestor.NodeAttestor
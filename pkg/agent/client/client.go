@@ -26,10 +26,16 @@ import (
 
 var (
 	ErrUnableToGetStream = errors.New("unable to get a stream")
+	ErrAgentEvicted      = errors.New("agent has been evicted")
 )
 
 type Client interface {
-	FetchUpdates(req *node.FetchX509SVIDRequest) (*Update, error)
+	FetchUpdates(ctx context.Context, req *node.FetchX509SVIDRequest) (*Update, error)
+	FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, error)
+
+	// WatchUpdates calls notify every time the server notifies of updates,
+	// until the stream ends or the context is done.
+	WatchUpdates(ctx context.Context, notify func()) error
 
 	// Release releases any resources that were held by this Client, if any.
 	Release()
@@ -41,8 +47,9 @@ type Config struct {
 	Log         logrus.FieldLogger
 	TrustDomain url.URL
 	// KeysAndBundle is a callback that must return the keys and bundle used by the client
-	// to connect via mTLS to Addr.
-	KeysAndBundle func() (*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate)
+	// to connect via mTLS to Addr. The SVID comes first in the chain, followed by its
+	// intermediates.
+	KeysAndBundle func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate)
 }
 
 type client struct {
@@ -64,12 +71,16 @@ func (c *client) credsFunc() (credentials.TransportCredentials, error) {
 	var tlsCert []tls.Certificate
 	var tlsConfig *tls.Config
 
-	svid, key, bundle := c.c.KeysAndBundle()
+	chain, key, bundle := c.c.KeysAndBundle()
 	spiffePeer := &spiffe_tls.TLSPeer{
 		SpiffeIDs:  []string{"spiffe://" + c.c.TrustDomain.Host + "/spire/server"},
 		TrustRoots: util.NewCertPool(bundle...),
 	}
-	tlsCert = append(tlsCert, tls.Certificate{Certificate: [][]byte{svid.Raw}, PrivateKey: key})
+	var certs [][]byte
+	for _, cert := range chain {
+		certs = append(certs, cert.Raw)
+	}
+	tlsCert = append(tlsCert, tls.Certificate{Certificate: certs, PrivateKey: key})
 	tlsConfig = spiffePeer.NewTLSConfig(tlsCert)
 	return credentials.NewTLS(tlsConfig), nil
 }
@@ -90,13 +101,13 @@ func (c *client) dial() (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
-func (c *client) FetchUpdates(req *node.FetchX509SVIDRequest) (*Update, error) {
+func (c *client) FetchUpdates(ctx context.Context, req *node.FetchX509SVIDRequest) (*Update, error) {
 	nodeClient, err := c.newNodeClient()
 	if err != nil {
 		return nil, err
 	}
 
-	stream, err := nodeClient.FetchX509SVID(context.Background())
+	stream, err := nodeClient.FetchX509SVID(ctx)
 	// We weren't able to get a stream...close the client and return the error.
 	if err != nil {
 		c.Release()
@@ -115,7 +126,12 @@ func (c *client) FetchUpdates(req *node.FetchX509SVIDRequest) (*Update, error) {
 
 	regEntries := map[string]*common.RegistrationEntry{}
 	svids := map[string]*node.Svid{}
+	federatedBundles := map[string][]byte{}
 	var lastBundle []byte
+	var lastJWTSigningKeys []*common.PublicKey
+	var lastCRL []byte
+	var lastBundleSequenceNumber uint64
+	var lastTaintedCACerts []byte
 	// Read all the server responses from the stream.
 	for {
 		resp, err := stream.Recv()
@@ -124,7 +140,10 @@ func (c *client) FetchUpdates(req *node.FetchX509SVIDRequest) (*Update, error) {
 		}
 		if err != nil {
 			// There was an error receiving a response, exit loop to return what we have.
-			return &Update{regEntries, svids, lastBundle}, err
+			return &Update{regEntries, svids, lastBundle, federatedBundles, lastJWTSigningKeys, lastCRL, lastBundleSequenceNumber, lastTaintedCACerts}, err
+		}
+		if resp.AgentStatus == node.AgentStatus_EVICTED {
+			return nil, ErrAgentEvicted
 		}
 
 		for _, re := range resp.SvidUpdate.RegistrationEntries {
@@ -133,15 +152,68 @@ func (c *client) FetchUpdates(req *node.FetchX509SVIDRequest) (*Update, error) {
 		for spiffeid, svid := range resp.SvidUpdate.Svids {
 			svids[spiffeid] = svid
 		}
+		for trustDomain, bundle := range resp.SvidUpdate.FederatedBundles {
+			federatedBundles[trustDomain] = bundle
+		}
 		lastBundle = resp.SvidUpdate.Bundle
+		lastJWTSigningKeys = resp.SvidUpdate.JwtSigningKeys
+		lastCRL = resp.SvidUpdate.Crl
+		lastBundleSequenceNumber = resp.SvidUpdate.BundleSequenceNumber
+		lastTaintedCACerts = resp.SvidUpdate.TaintedCaCerts
 	}
 	return &Update{
-		Entries: regEntries,
-		SVIDs:   svids,
-		Bundle:  lastBundle,
+		Entries:              regEntries,
+		SVIDs:                svids,
+		Bundle:               lastBundle,
+		FederatedBundles:     federatedBundles,
+		JWTSigningKeys:       lastJWTSigningKeys,
+		CRL:                  lastCRL,
+		BundleSequenceNumber: lastBundleSequenceNumber,
+		TaintedCACerts:       lastTaintedCACerts,
+	}, nil
+}
+
+func (c *client) FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, error) {
+	nodeClient, err := c.newNodeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := nodeClient.FetchJWTSVID(ctx, &node.FetchJWTSVIDRequest{
+		Jsr: jsr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Svid == nil {
+		return nil, errors.New("JWTSVID response missing SVID")
+	}
+
+	return &JWTSVID{
+		Token:     resp.Svid.Token,
+		IssuedAt:  time.Unix(resp.Svid.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(resp.Svid.ExpiresAt, 0).UTC(),
 	}, nil
 }
 
+func (c *client) WatchUpdates(ctx context.Context, notify func()) error {
+	nodeClient, err := c.newNodeClient()
+	if err != nil {
+		return err
+	}
+
+	stream, err := nodeClient.WatchUpdates(ctx, &node.WatchUpdatesRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+		notify()
+	}
+}
+
 func (c *client) Release() {
 	c.m.Lock()
 	defer c.m.Unlock()
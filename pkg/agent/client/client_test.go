@@ -1,8 +1,11 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus/hooks/test"
@@ -44,6 +47,11 @@ func TestFetchUpdates(t *testing.T) {
 					SvidCert: []byte{11, 22, 33},
 				},
 			},
+			FederatedBundles: map[string][]byte{
+				"spiffe://otherdomain.org": {50, 60, 70},
+			},
+			BundleSequenceNumber: 3,
+			TaintedCaCerts:       []byte{80, 90},
 		},
 	}
 
@@ -53,13 +61,117 @@ func TestFetchUpdates(t *testing.T) {
 	nodeFsc.EXPECT().Recv().Return(res, nil)
 	nodeFsc.EXPECT().Recv().Return(nil, io.EOF)
 
-	update, err := client.FetchUpdates(req)
+	update, err := client.FetchUpdates(context.Background(), req)
 	require.Nil(t, err)
 
 	assert.Equal(t, res.SvidUpdate.Bundle, update.Bundle)
 	assert.Equal(t, res.SvidUpdate.Svids, update.SVIDs)
+	assert.Equal(t, res.SvidUpdate.FederatedBundles, update.FederatedBundles)
+	assert.Equal(t, res.SvidUpdate.BundleSequenceNumber, update.BundleSequenceNumber)
+	assert.Equal(t, res.SvidUpdate.TaintedCaCerts, update.TaintedCACerts)
 	for _, entry := range res.SvidUpdate.RegistrationEntries {
 		assert.Equal(t, entry, update.Entries[entry.EntryId])
 	}
 	client.Release()
 }
+
+func TestFetchUpdatesAgentEvicted(t *testing.T) {
+	cfg := &Config{
+		Log: log,
+	}
+
+	ctrl := gomock.NewController(t)
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeFsc := mock_node.NewMockNode_FetchX509SVIDClient(ctrl)
+
+	client := New(cfg)
+	client.newNodeClientCallback = func() (node.NodeClient, error) {
+		return nodeClient, nil
+	}
+	req := &node.FetchX509SVIDRequest{}
+	res := &node.FetchX509SVIDResponse{
+		AgentStatus: node.AgentStatus_EVICTED,
+	}
+
+	nodeClient.EXPECT().FetchX509SVID(gomock.Any()).Return(nodeFsc, nil)
+	nodeFsc.EXPECT().Send(req)
+	nodeFsc.EXPECT().CloseSend()
+	nodeFsc.EXPECT().Recv().Return(res, nil)
+
+	update, err := client.FetchUpdates(context.Background(), req)
+	require.Equal(t, ErrAgentEvicted, err)
+	require.Nil(t, update)
+	client.Release()
+}
+
+func TestFetchJWTSVID(t *testing.T) {
+	cfg := &Config{
+		Log: log,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+
+	client := New(cfg)
+	client.newNodeClientCallback = func() (node.NodeClient, error) {
+		return nodeClient, nil
+	}
+	jsr := &node.JSR{
+		SpiffeId: "spiffe://example.org/foo",
+		Audience: []string{"audience"},
+	}
+
+	nodeClient.EXPECT().FetchJWTSVID(gomock.Any(), &node.FetchJWTSVIDRequest{Jsr: jsr}).Return(&node.FetchJWTSVIDResponse{
+		Svid: &node.JWTSVID{
+			Token:     "token",
+			IssuedAt:  1,
+			ExpiresAt: 2,
+		},
+	}, nil)
+	svid, err := client.FetchJWTSVID(context.Background(), jsr)
+	require.NoError(t, err)
+	assert.Equal(t, &JWTSVID{
+		Token:     "token",
+		IssuedAt:  time.Unix(1, 0).UTC(),
+		ExpiresAt: time.Unix(2, 0).UTC(),
+	}, svid)
+
+	nodeClient.EXPECT().FetchJWTSVID(gomock.Any(), &node.FetchJWTSVIDRequest{Jsr: jsr}).Return(&node.FetchJWTSVIDResponse{}, nil)
+	_, err = client.FetchJWTSVID(context.Background(), jsr)
+	require.EqualError(t, err, "JWTSVID response missing SVID")
+
+	nodeClient.EXPECT().FetchJWTSVID(gomock.Any(), &node.FetchJWTSVIDRequest{Jsr: jsr}).Return(nil, errors.New("oh no"))
+	_, err = client.FetchJWTSVID(context.Background(), jsr)
+	require.EqualError(t, err, "oh no")
+}
+
+func TestWatchUpdates(t *testing.T) {
+	cfg := &Config{
+		Log: log,
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeWuc := mock_node.NewMockNode_WatchUpdatesClient(ctrl)
+
+	client := New(cfg)
+	client.newNodeClientCallback = func() (node.NodeClient, error) {
+		return nodeClient, nil
+	}
+
+	nodeClient.EXPECT().WatchUpdates(gomock.Any(), &node.WatchUpdatesRequest{}).Return(nodeWuc, nil)
+	gomock.InOrder(
+		nodeWuc.EXPECT().Recv().Return(&node.WatchUpdatesResponse{}, nil).Times(2),
+		nodeWuc.EXPECT().Recv().Return(nil, io.EOF),
+	)
+
+	notified := 0
+	err := client.WatchUpdates(context.Background(), func() {
+		notified++
+	})
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 2, notified)
+	client.Release()
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
@@ -12,6 +13,33 @@ type Update struct {
 	Entries map[string]*common.RegistrationEntry
 	SVIDs   map[string]*node.Svid
 	Bundle  []byte
+
+	// FederatedBundles holds the CA bundles of the foreign trust domains the
+	// entries federate with, keyed by trust domain SPIFFE ID.
+	FederatedBundles map[string][]byte
+
+	// JWTSigningKeys holds the JWT signing keys published in the bundle of
+	// the agent's trust domain.
+	JWTSigningKeys []*common.PublicKey
+
+	// CRL holds the CRL of the server CA, ASN.1 DER encoded, if CRLs are
+	// enabled on the server.
+	CRL []byte
+
+	// BundleSequenceNumber is the sequence number of the bundle of the
+	// agent's trust domain. Zero if the server does not keep one.
+	BundleSequenceNumber uint64
+
+	// TaintedCACerts holds the tainted CA certificates of the server, ASN.1
+	// DER encoded. SVIDs signed by them have to be rotated.
+	TaintedCACerts []byte
+}
+
+// JWTSVID is a signed JWT-SVID along with its issue and expiry times
+type JWTSVID struct {
+	Token     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
 }
 
 func (u *Update) String() string {
@@ -4,8 +4,15 @@ import (
 	"crypto/x509"
 	"net"
 	"net/url"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/endpoints"
+	"github.com/spiffe/spire/pkg/agent/endpoints/delegated"
+	"github.com/spiffe/spire/pkg/agent/endpoints/sds"
+	"github.com/spiffe/spire/pkg/agent/health"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 
 	common_catalog "github.com/spiffe/spire/pkg/common/catalog"
 )
@@ -14,14 +21,33 @@ type Config struct {
 	// Address to bind the workload api to
 	BindAddress *net.UnixAddr
 
+	// Additional addresses to bind the workload api to, e.g. socket paths
+	// mounted by containers
+	AdditionalBindAddresses []*net.UnixAddr
+
+	// Loopback TCP address to bind the workload api to instead of
+	// BindAddress, for platforms without unix domain sockets
+	TCPBindAddress *net.TCPAddr
+
 	// Directory to store runtime data
 	DataDir string
 
+	// Path to the key encrypting the workload SVIDs and keys cached under
+	// DataDir. They are not cached on disk if empty.
+	EntryCacheKeyPath string
+
 	// Configurations for agent plugins
 	PluginConfigs common_catalog.PluginConfigMap
 
+	// If set, external plugins are only run if their checksum is configured
+	RequirePluginChecksum bool
+
 	Log logrus.FieldLogger
 
+	// Levels of the logger, which can be changed at runtime through the
+	// debug API if set
+	LogLevels *log.Levels
+
 	// Address of SPIRE server
 	ServerAddress *net.TCPAddr
 
@@ -29,12 +55,73 @@ type Config struct {
 	TrustDomain url.URL
 	TrustBundle []*x509.Certificate
 
+	// HTTPS URL to download the trust bundle from when TrustBundle is not
+	// set and no bundle is cached yet. The server certificate is validated
+	// by the system roots, unless the hex encoded SHA-256 hash of the
+	// bundle is pinned.
+	TrustBundleURL    string
+	TrustBundleSHA256 string
+
 	// Join token to use for attestation, if needed
 	JoinToken string
 
 	// Umask value to use
 	Umask int
 
+	// How often the agent synchronizes with the server. Defaults to 5
+	// seconds.
+	SyncInterval time.Duration
+
+	// Percentage of the lifetime remaining at which SVIDs are renewed.
+	// Defaults to 50.
+	RotationThreshold int
+
+	// How long SVIDs that could not be renewed, e.g. while the server is
+	// unreachable, keep being served past their rotation time. They are
+	// never served past their expiry. Zero serves them until they expire.
+	MaxSVIDStaleness time.Duration
+
+	// How close to their expiry workload SVIDs are reported as expiring
+	// soon. Defaults to 10 minutes.
+	ExpiringSVIDThreshold time.Duration
+
+	// Only request the SVIDs of the entries of workloads that have
+	// connected, instead of the SVIDs of every entry assigned to the agent
+	LazySVIDMinting bool
+
+	// Have the server push a notification whenever the entries of the agent
+	// or the bundle change, to synchronize right away
+	WatchUpdates bool
+
+	// Configuration of the secrets served to Envoy over SDS
+	SDS sds.Config
+
+	// Per-caller limits on the use of the workload api
+	WorkloadAPILimits endpoints.Limits
+
+	// How long the workload api calls in flight are waited for on
+	// shutdown. Defaults to 5 seconds.
+	ShutdownDrainTimeout time.Duration
+
+	// Configuration of the health check endpoint
+	HealthCheck health.Config
+
+	// Location of the socket to serve the debug API on, which describes the
+	// agent cache. The debug API is not served if empty.
+	DebugSocketPath string
+
+	// Configuration of the Delegated Identity API, which lets authorized
+	// workloads fetch SVIDs on behalf of others
+	DelegatedIdentity delegated.Config
+
+	// Address to serve the metrics on for Prometheus to scrape, on the
+	// /metrics path. Metrics are not served if empty.
+	PrometheusBindAddress string
+
+	// Configuration of the StatsD server to send the metrics to. Metrics are
+	// not sent if it has no address.
+	Statsd telemetry.StatsdConfig
+
 	// If true enables profiling.
 	ProfilingEnabled bool
 
@@ -0,0 +1,187 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/health"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	common_health "github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/log"
+)
+
+const (
+	// DefaultSocketPath is the default location of the debug API socket
+	DefaultSocketPath = "./spire_debug_api"
+
+	// CachePath is the path the cache is described on
+	CachePath = "/cache"
+
+	// RotatePath is the path SVIDs are rotated on. The SVIDs of a single
+	// SPIFFE ID are rotated if given in the spiffe_id query parameter.
+	RotatePath = "/svid/rotate"
+)
+
+// CacheResponse describes the state of the agent cache
+type CacheResponse struct {
+	// Time of the last successful synchronization with the server, zero
+	// if there has been none
+	LastSync time.Time `json:"last_sync"`
+
+	AgentSVID *SVID   `json:"agent_svid,omitempty"`
+	Entries   []Entry `json:"entries"`
+}
+
+// RotateResponse describes the outcome of a forced rotation
+type RotateResponse struct {
+	// Number of SVIDs rotated
+	Rotated int `json:"rotated"`
+}
+
+// SVID describes an X509-SVID held by the agent
+type SVID struct {
+	SPIFFEID  string    `json:"spiffe_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Entry describes a cached registration entry and its SVID
+type Entry struct {
+	EntryID       string    `json:"entry_id"`
+	SPIFFEID      string    `json:"spiffe_id"`
+	ParentID      string    `json:"parent_id"`
+	Selectors     []string  `json:"selectors"`
+	FederatesWith []string  `json:"federates_with,omitempty"`
+	SVIDExpiresAt time.Time `json:"svid_expires_at"`
+}
+
+// Server serves the debug API over a unix domain socket only accessible
+// to the user running the agent
+type Server struct {
+	SocketPath string
+	Manager    manager.Manager
+	Log        logrus.FieldLogger
+
+	// If set, the log levels are served and can be changed on
+	// log.LevelsPath
+	LogLevels *log.Levels
+
+	// If set, the health report of the agent is served on
+	// common_health.ReportPath
+	Health *health.Checker
+}
+
+// ListenAndServe serves the debug API until the context is cancelled
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	os.Remove(s.SocketPath)
+	l, err := net.Listen("unix", s.SocketPath)
+	if err != nil {
+		return fmt.Errorf("create debug API listener: %v", err)
+	}
+	if err := os.Chmod(s.SocketPath, 0600); err != nil {
+		l.Close()
+		return fmt.Errorf("restrict debug API socket: %v", err)
+	}
+
+	server := &http.Server{Handler: s.Handler()}
+
+	s.Log.Infof("Serving debug API on %s", s.SocketPath)
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		<-errChan
+		return nil
+	}
+}
+
+// Handler returns the HTTP handler serving the debug API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(CachePath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.Cache())
+	})
+	mux.HandleFunc(RotatePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp, err := s.Rotate(r.URL.Query().Get("spiffe_id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+	if s.LogLevels != nil {
+		mux.Handle(log.LevelsPath, log.LevelsHandler(s.LogLevels))
+	}
+	if s.Health != nil {
+		mux.Handle(common_health.ReportPath, s.Health.ReportHandler())
+	}
+	return mux
+}
+
+// Cache describes the agent SVID and the cached entries, sorted by SPIFFE ID
+func (s *Server) Cache() *CacheResponse {
+	resp := &CacheResponse{
+		LastSync: s.Manager.LastSync(),
+		Entries:  []Entry{},
+	}
+
+	if state, ok := s.Manager.SubscribeToSVIDChanges().Value().(svid.State); ok && state.SVID != nil {
+		resp.AgentSVID = &SVID{ExpiresAt: state.SVID.NotAfter}
+		if len(state.SVID.URIs) > 0 {
+			resp.AgentSVID.SPIFFEID = state.SVID.URIs[0].String()
+		}
+	}
+
+	for _, e := range s.Manager.CachedEntries() {
+		entry := Entry{
+			EntryID:       e.RegistrationEntry.EntryId,
+			SPIFFEID:      e.RegistrationEntry.SpiffeId,
+			ParentID:      e.RegistrationEntry.ParentId,
+			FederatesWith: e.RegistrationEntry.FederatesWith,
+		}
+		for _, sel := range e.RegistrationEntry.Selectors {
+			entry.Selectors = append(entry.Selectors, sel.Type+":"+sel.Value)
+		}
+		if e.SVID != nil {
+			entry.SVIDExpiresAt = e.SVID.NotAfter
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+	sort.Slice(resp.Entries, func(i, j int) bool {
+		if resp.Entries[i].SPIFFEID != resp.Entries[j].SPIFFEID {
+			return resp.Entries[i].SPIFFEID < resp.Entries[j].SPIFFEID
+		}
+		return resp.Entries[i].EntryID < resp.Entries[j].EntryID
+	})
+
+	return resp
+}
+
+// Rotate immediately rotates the SVIDs of the SPIFFE ID, or all of them if
+// empty, along with their private keys
+func (s *Server) Rotate(spiffeID string) (*RotateResponse, error) {
+	rotated, err := s.Manager.RotateSVIDs(spiffeID)
+	if err != nil {
+		s.Log.Errorf("Could not rotate SVIDs: %v", err)
+		return nil, fmt.Errorf("could not rotate SVIDs: %v", err)
+	}
+	return &RotateResponse{Rotated: rotated}, nil
+}
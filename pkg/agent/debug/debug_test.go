@@ -0,0 +1,236 @@
+package debug
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/imkira/go-observer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/health"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	common_health "github.com/spiffe/spire/pkg/common/health"
+	common_log "github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/agent/manager"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr := mock_manager.NewMockManager(ctrl)
+	s := &Server{Manager: mgr}
+
+	lastSync := time.Now().Add(-time.Minute)
+	agentSVID := newSVID(t, "spiffe://example.org/spire/agent/test")
+	workloadSVID := newSVID(t, "spiffe://example.org/foo")
+	expectCache(mgr, lastSync, agentSVID, []*cache.Entry{
+		{
+			RegistrationEntry: &common.RegistrationEntry{
+				EntryId:   "2",
+				SpiffeId:  "spiffe://example.org/foo",
+				ParentId:  "spiffe://example.org/spire/agent/test",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+			SVID: workloadSVID,
+		},
+		{
+			RegistrationEntry: &common.RegistrationEntry{
+				EntryId:       "1",
+				SpiffeId:      "spiffe://example.org/bar",
+				ParentId:      "spiffe://example.org/spire/agent/test",
+				Selectors:     []*common.Selector{{Type: "unix", Value: "uid:1001"}, {Type: "unix", Value: "gid:1001"}},
+				FederatesWith: []string{"spiffe://otherdomain.org"},
+			},
+		},
+	})
+
+	require.Equal(t, &CacheResponse{
+		LastSync: lastSync,
+		AgentSVID: &SVID{
+			SPIFFEID:  "spiffe://example.org/spire/agent/test",
+			ExpiresAt: agentSVID.NotAfter,
+		},
+		Entries: []Entry{
+			{
+				EntryID:       "1",
+				SPIFFEID:      "spiffe://example.org/bar",
+				ParentID:      "spiffe://example.org/spire/agent/test",
+				Selectors:     []string{"unix:uid:1001", "unix:gid:1001"},
+				FederatesWith: []string{"spiffe://otherdomain.org"},
+			},
+			{
+				EntryID:       "2",
+				SPIFFEID:      "spiffe://example.org/foo",
+				ParentID:      "spiffe://example.org/spire/agent/test",
+				Selectors:     []string{"unix:uid:1000"},
+				SVIDExpiresAt: workloadSVID.NotAfter,
+			},
+		},
+	}, s.Cache())
+}
+
+func TestRotate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr := mock_manager.NewMockManager(ctrl)
+	log, _ := test.NewNullLogger()
+	server := httptest.NewServer((&Server{Manager: mgr, Log: log}).Handler())
+	defer server.Close()
+
+	// Only POST requests rotate SVIDs
+	resp, err := http.Get(server.URL + RotatePath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	mgr.EXPECT().RotateSVIDs("spiffe://example.org/foo").Return(2, nil)
+	resp, err = http.Post(server.URL+RotatePath+"?spiffe_id=spiffe://example.org/foo", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	rotateResp := new(RotateResponse)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(rotateResp))
+	require.Equal(t, &RotateResponse{Rotated: 2}, rotateResp)
+
+	mgr.EXPECT().RotateSVIDs("").Return(0, errors.New("server unreachable"))
+	_, err = (&Server{Manager: mgr, Log: log}).Rotate("")
+	require.EqualError(t, err, "could not rotate SVIDs: server unreachable")
+}
+
+func TestLogLevels(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	// Not served unless the levels are given
+	server := httptest.NewServer((&Server{Log: log}).Handler())
+	resp, err := http.Get(server.URL + common_log.LevelsPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	server.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	logger, err := common_log.New(common_log.Config{Level: "INFO"})
+	require.NoError(t, err)
+	server = httptest.NewServer((&Server{Log: log, LogLevels: logger.Levels}).Handler())
+	defer server.Close()
+
+	resp, err = http.Post(server.URL+common_log.LevelsPath+"?subsystem=cache_manager&level=debug", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "debug", logger.Levels.SubsystemLevel("cache_manager").String())
+}
+
+func TestHealthReport(t *testing.T) {
+	log, _ := test.NewNullLogger()
+
+	// Not served unless the health checker is given
+	server := httptest.NewServer((&Server{Log: log}).Handler())
+	resp, err := http.Get(server.URL + common_health.ReportPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	server.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	server = httptest.NewServer((&Server{Log: log, Health: &health.Checker{Log: log}}).Handler())
+	defer server.Close()
+
+	resp, err = http.Get(server.URL + common_health.ReportPath + "?check=" + common_health.CheckShallow)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	report := new(common_health.Report)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(report))
+	require.Equal(t, &common_health.Report{Healthy: true}, report)
+}
+
+func TestListenAndServe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "debug-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mgr := mock_manager.NewMockManager(ctrl)
+	log, _ := test.NewNullLogger()
+	s := &Server{
+		SocketPath: path.Join(dir, "debug.sock"),
+		Manager:    mgr,
+		Log:        log,
+	}
+	expectCache(mgr, time.Time{}, newSVID(t, "spiffe://example.org/spire/agent/test"), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() { errChan <- s.ListenAndServe(ctx) }()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", s.SocketPath)
+			},
+		},
+	}
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = client.Get("http://agent" + CachePath)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	cacheResp := new(CacheResponse)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(cacheResp))
+	require.Empty(t, cacheResp.Entries)
+	require.True(t, cacheResp.LastSync.IsZero())
+
+	// Only the user running the agent may use the socket
+	info, err := os.Stat(s.SocketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cancel()
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("debug API did not stop")
+	}
+}
+
+func expectCache(mgr *mock_manager.MockManager, lastSync time.Time, agentSVID *x509.Certificate, entries []*cache.Entry) {
+	mgr.EXPECT().LastSync().Return(lastSync)
+	mgr.EXPECT().SubscribeToSVIDChanges().Return(observer.NewProperty(svid.State{SVID: agentSVID}).Observe())
+	mgr.EXPECT().CachedEntries().Return(entries)
+}
+
+func newSVID(t *testing.T, spiffeID string) *x509.Certificate {
+	template, err := util.NewSVIDTemplate(spiffeID)
+	require.NoError(t, err)
+	cert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+	return cert
+}
@@ -2,9 +2,12 @@ package endpoints
 
 import (
 	"net"
+	"net/url"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/endpoints/sds"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 
@@ -12,13 +15,28 @@ import (
 )
 
 type Config struct {
-	BindAddr *net.UnixAddr
+	// BindAddrs are the unix domain socket or loopback TCP addresses the
+	// Workload API is served on
+	BindAddrs []net.Addr
 
 	GRPCHook func(*grpc.Server) error
 
 	Catalog catalog.Catalog
 	Manager manager.Manager
 
+	// Trust domain of the agent, used to name bundles served over SDS
+	TrustDomain url.URL
+
+	// Configuration of the secrets served over SDS
+	SDS sds.Config
+
+	// Per-caller limits on the use of the Workload API
+	Limits Limits
+
+	// How long the calls in flight are waited for on shutdown. Defaults to
+	// DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
 	Log logrus.FieldLogger
 	Tel telemetry.Sink
 }
@@ -0,0 +1,201 @@
+package delegated
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/api/delegated"
+	"github.com/spiffe/spire/proto/common"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler implements the Delegated Identity API. Callers are attested like
+// workloads, and are only served if one of the registration entries they
+// match has an authorized delegate SPIFFE ID.
+type Handler struct {
+	Manager             manager.Manager
+	Catalog             catalog.Catalog
+	AuthorizedDelegates []string
+	L                   logrus.FieldLogger
+	T                   telemetry.Sink
+}
+
+// FetchX509SVIDs streams the X509-SVIDs of the workload identified in the
+// request, sending a new response as they change.
+func (h *Handler) FetchX509SVIDs(req *delegated.FetchX509SVIDsRequest, stream delegated.DelegatedIdentity_FetchX509SVIDsServer) error {
+	ctx := stream.Context()
+
+	if err := h.authorize(ctx); err != nil {
+		return err
+	}
+
+	selectors, err := h.workloadSelectors(ctx, req.Workload)
+	if err != nil {
+		return err
+	}
+
+	subscriber := h.Manager.SubscribeToCacheChanges(selectors)
+	defer subscriber.Finish()
+
+	for {
+		select {
+		case update := <-subscriber.Updates():
+			resp, err := composeX509SVIDsResponse(update)
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "Could not serialize response: %v", err)
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// FetchJWTSVIDs returns JWT-SVIDs for the requested audience, for all of the
+// identities the workload identified in the request is entitled to.
+func (h *Handler) FetchJWTSVIDs(ctx context.Context, req *delegated.FetchJWTSVIDsRequest) (*delegated.FetchJWTSVIDsResponse, error) {
+	if len(req.Audience) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "audience must be specified")
+	}
+
+	if err := h.authorize(ctx); err != nil {
+		return nil, err
+	}
+
+	selectors, err := h.workloadSelectors(ctx, req.Workload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(delegated.FetchJWTSVIDsResponse)
+	for _, entry := range h.Manager.MatchingEntries(selectors) {
+		spiffeID := entry.RegistrationEntry.SpiffeId
+		svid, err := h.Manager.FetchJWTSVID(ctx, spiffeID, req.Audience)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "could not fetch %q JWT-SVID: %v", spiffeID, err)
+		}
+		resp.Svids = append(resp.Svids, &delegated.JWTSVID{
+			SpiffeId: spiffeID,
+			Svid:     svid.Token,
+		})
+	}
+
+	return resp, nil
+}
+
+// authorize attests the caller, returning an error unless it is entitled to
+// an authorized delegate SPIFFE ID
+func (h *Handler) authorize(ctx context.Context) error {
+	pid, err := callerPID(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Is this a supported system? Please report this bug: %v", err)
+	}
+
+	authorized := make(map[string]bool)
+	for _, spiffeID := range h.AuthorizedDelegates {
+		authorized[spiffeID] = true
+	}
+
+	for _, entry := range h.Manager.MatchingEntries(h.attest(ctx, pid)) {
+		if authorized[entry.RegistrationEntry.SpiffeId] {
+			return nil
+		}
+	}
+
+	h.L.Warnf("Process with PID %d is not an authorized delegate", pid)
+	return status.Errorf(codes.PermissionDenied, "caller is not an authorized delegate")
+}
+
+// workloadSelectors returns the selectors of the workload, attesting it if
+// it is identified by its PID
+func (h *Handler) workloadSelectors(ctx context.Context, w *delegated.Workload) ([]*common.Selector, error) {
+	switch {
+	case w == nil || (w.Pid == 0 && len(w.Selectors) == 0):
+		return nil, status.Errorf(codes.InvalidArgument, "workload selectors or PID must be specified")
+	case w.Pid != 0 && len(w.Selectors) > 0:
+		return nil, status.Errorf(codes.InvalidArgument, "workload selectors and PID are mutually exclusive")
+	case w.Pid < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid workload PID %d", w.Pid)
+	case w.Pid != 0:
+		return h.attest(ctx, w.Pid), nil
+	default:
+		return w.Selectors, nil
+	}
+}
+
+// attest attests the process, returning its selectors
+func (h *Handler) attest(ctx context.Context, pid int32) []*common.Selector {
+	config := attestor.Config{
+		Catalog: h.Catalog,
+		L:       h.L,
+		T:       h.T,
+	}
+
+	return attestor.New(&config).Attest(ctx, pid)
+}
+
+func composeX509SVIDsResponse(update *cache.WorkloadUpdate) (*delegated.FetchX509SVIDsResponse, error) {
+	resp := &delegated.FetchX509SVIDsResponse{
+		Svids: []*delegated.X509SVID{},
+	}
+
+	for _, c := range update.Bundle {
+		resp.Bundle = append(resp.Bundle, c.Raw...)
+	}
+
+	for _, e := range update.Entries {
+		id := e.RegistrationEntry.SpiffeId
+
+		keyData, err := x509.MarshalPKCS8PrivateKey(e.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("marshal key for %v: %v", id, err)
+		}
+
+		resp.Svids = append(resp.Svids, &delegated.X509SVID{
+			SpiffeId:    id,
+			X509Svid:    e.SVIDChain(),
+			X509SvidKey: keyData,
+		})
+
+		for trustDomain, federatedBundle := range e.Bundles {
+			if resp.FederatedBundles == nil {
+				resp.FederatedBundles = make(map[string][]byte)
+			}
+			resp.FederatedBundles[trustDomain] = federatedBundle
+		}
+	}
+
+	return resp, nil
+}
+
+// callerPID returns the PID of the process which has issued the request.
+// See the auth package for more information.
+func callerPID(ctx context.Context) (int32, error) {
+	info, ok := auth.CallerFromContext(ctx)
+	if !ok {
+		return 0, errors.New("Unable to fetch credentials from context")
+	}
+
+	if info.Err != nil {
+		return 0, fmt.Errorf("Unable to resolve caller PID: %s", info.Err)
+	}
+
+	if info.PID == 0 {
+		return 0, errors.New("Unable to resolve caller PID")
+	}
+
+	return info.PID, nil
+}
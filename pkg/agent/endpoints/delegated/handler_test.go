@@ -0,0 +1,204 @@
+package delegated
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/api/delegated"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/mock/agent/manager"
+	"github.com/spiffe/spire/test/mock/agent/manager/cache"
+	"github.com/spiffe/spire/test/mock/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/test/mock/proto/api/delegated"
+	"github.com/spiffe/spire/test/util"
+
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	delegateSelectors = []*common.Selector{{Type: "unix", Value: "uid:0"}}
+	workloadSelectors = []*common.Selector{{Type: "unix", Value: "uid:1000"}}
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+
+	h    *Handler
+	ctrl *gomock.Controller
+
+	attestor *mock_workloadattestor.MockWorkloadAttestor
+	manager  *mock_manager.MockManager
+	stream   *mock_delegated.MockDelegatedIdentity_FetchX509SVIDsServer
+}
+
+func (s *HandlerTestSuite) SetupTest() {
+	mockCtrl := gomock.NewController(s.T())
+	log, _ := test.NewNullLogger()
+
+	s.attestor = mock_workloadattestor.NewMockWorkloadAttestor(mockCtrl)
+	s.manager = mock_manager.NewMockManager(mockCtrl)
+	s.stream = mock_delegated.NewMockDelegatedIdentity_FetchX509SVIDsServer(mockCtrl)
+
+	catalog := fakeagentcatalog.New()
+	catalog.SetWorkloadAttestors(s.attestor)
+
+	s.h = &Handler{
+		Manager:             s.manager,
+		Catalog:             catalog,
+		AuthorizedDelegates: []string{"spiffe://example.org/proxy"},
+		L:                   log,
+		T:                   telemetry.Blackhole{},
+	}
+	s.ctrl = mockCtrl
+}
+
+func TestDelegatedIdentityServer(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *HandlerTestSuite) TestFetchX509SVIDs() {
+	ctx, cancel := context.WithCancel(s.callerContext())
+	defer cancel()
+
+	s.stream.EXPECT().Context().Return(ctx).AnyTimes()
+	s.expectAuthorization("spiffe://example.org/proxy")
+	s.attestor.EXPECT().Attest(gomock.Any(), &workloadattestor.AttestRequest{Pid: int32(2)}).Return(&workloadattestor.AttestResponse{Selectors: workloadSelectors}, nil)
+
+	updates := make(chan *cache.WorkloadUpdate, 1)
+	updates <- s.workloadUpdate()
+	subscriber := mock_cache.NewMockSubscriber(s.ctrl)
+	subscriber.EXPECT().Updates().Return(updates).AnyTimes()
+	subscriber.EXPECT().Finish()
+	s.manager.EXPECT().SubscribeToCacheChanges(cache.Selectors(workloadSelectors)).Return(subscriber)
+
+	sent := make(chan *delegated.FetchX509SVIDsResponse, 1)
+	s.stream.EXPECT().Send(gomock.Any()).Do(func(resp *delegated.FetchX509SVIDsResponse) {
+		sent <- resp
+	}).Return(nil)
+
+	result := make(chan error)
+	req := &delegated.FetchX509SVIDsRequest{Workload: &delegated.Workload{Pid: 2}}
+	go func() { result <- s.h.FetchX509SVIDs(req, s.stream) }()
+
+	select {
+	case resp := <-sent:
+		s.Require().Len(resp.Svids, 1)
+		s.Equal("spiffe://example.org/foo", resp.Svids[0].SpiffeId)
+		s.Equal(map[string][]byte{"spiffe://otherdomain.org": {1, 2, 3}}, resp.FederatedBundles)
+	case <-time.After(time.Second):
+		s.FailNow("timed out waiting for the response")
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		s.NoError(err)
+	case <-time.After(time.Second):
+		s.Fail("handler hung after the context was cancelled")
+	}
+}
+
+func (s *HandlerTestSuite) TestFetchX509SVIDsUnauthorized() {
+	s.stream.EXPECT().Context().Return(s.callerContext())
+	s.expectAuthorization("spiffe://example.org/workload")
+
+	req := &delegated.FetchX509SVIDsRequest{Workload: &delegated.Workload{Selectors: workloadSelectors}}
+	err := s.h.FetchX509SVIDs(req, s.stream)
+	s.EqualError(err, "rpc error: code = PermissionDenied desc = caller is not an authorized delegate")
+}
+
+func (s *HandlerTestSuite) TestFetchJWTSVIDs() {
+	audience := []string{"foo"}
+
+	// Without audience
+	_, err := s.h.FetchJWTSVIDs(s.callerContext(), &delegated.FetchJWTSVIDsRequest{})
+	s.EqualError(err, "rpc error: code = InvalidArgument desc = audience must be specified")
+
+	// Without workload
+	s.expectAuthorization("spiffe://example.org/proxy")
+	_, err = s.h.FetchJWTSVIDs(s.callerContext(), &delegated.FetchJWTSVIDsRequest{Audience: audience})
+	s.EqualError(err, "rpc error: code = InvalidArgument desc = workload selectors or PID must be specified")
+
+	// Workload identified by selectors
+	s.expectAuthorization("spiffe://example.org/proxy")
+	s.manager.EXPECT().MatchingEntries(workloadSelectors).Return([]*cache.Entry{
+		{RegistrationEntry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/foo"}},
+	})
+	s.manager.EXPECT().FetchJWTSVID(gomock.Any(), "spiffe://example.org/foo", audience).Return(&client.JWTSVID{Token: "token"}, nil)
+
+	resp, err := s.h.FetchJWTSVIDs(s.callerContext(), &delegated.FetchJWTSVIDsRequest{
+		Workload: &delegated.Workload{Selectors: workloadSelectors},
+		Audience: audience,
+	})
+	s.Require().NoError(err)
+	s.Equal(&delegated.FetchJWTSVIDsResponse{
+		Svids: []*delegated.JWTSVID{{SpiffeId: "spiffe://example.org/foo", Svid: "token"}},
+	}, resp)
+}
+
+func (s *HandlerTestSuite) TestWorkloadSelectors() {
+	ctx := context.Background()
+
+	_, err := s.h.workloadSelectors(ctx, &delegated.Workload{Pid: 2, Selectors: workloadSelectors})
+	s.EqualError(err, "rpc error: code = InvalidArgument desc = workload selectors and PID are mutually exclusive")
+
+	_, err = s.h.workloadSelectors(ctx, &delegated.Workload{Pid: -1})
+	s.EqualError(err, "rpc error: code = InvalidArgument desc = invalid workload PID -1")
+
+	selectors, err := s.h.workloadSelectors(ctx, &delegated.Workload{Selectors: workloadSelectors})
+	s.NoError(err)
+	s.Equal(workloadSelectors, selectors)
+}
+
+func (s *HandlerTestSuite) callerContext() context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.CallerInfo{PID: 1},
+	})
+}
+
+// expectAuthorization expects the caller to be attested and entitled to
+// the given SPIFFE ID
+func (s *HandlerTestSuite) expectAuthorization(spiffeID string) {
+	s.attestor.EXPECT().Attest(gomock.Any(), &workloadattestor.AttestRequest{Pid: int32(1)}).Return(&workloadattestor.AttestResponse{Selectors: delegateSelectors}, nil)
+	s.manager.EXPECT().MatchingEntries(delegateSelectors).Return([]*cache.Entry{
+		{RegistrationEntry: &common.RegistrationEntry{SpiffeId: spiffeID}},
+	})
+}
+
+func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
+	svid, key, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+	ca, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+
+	return &cache.WorkloadUpdate{
+		Entries: []*cache.Entry{{
+			SVID:       svid,
+			PrivateKey: key,
+			RegistrationEntry: &common.RegistrationEntry{
+				SpiffeId:      "spiffe://example.org/foo",
+				FederatesWith: []string{"spiffe://otherdomain.org"},
+			},
+			Bundles: map[string][]byte{
+				"spiffe://otherdomain.org": {1, 2, 3},
+			},
+		}},
+		Bundle: []*x509.Certificate{ca},
+	}
+}
@@ -0,0 +1,75 @@
+package delegated
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+
+	"google.golang.org/grpc"
+
+	delegated_pb "github.com/spiffe/spire/proto/api/delegated"
+)
+
+type Config struct {
+	// Location of the socket to serve the Delegated Identity API on. The
+	// API is not served if empty.
+	SocketPath string
+
+	// SPIFFE IDs of the workloads allowed to fetch SVIDs on behalf of
+	// other workloads
+	AuthorizedDelegates []string
+}
+
+// Server serves the Delegated Identity API over a unix domain socket. The
+// socket is open to every user, callers are authorized by attesting them.
+type Server struct {
+	Config  Config
+	Manager manager.Manager
+	Catalog catalog.Catalog
+	Log     logrus.FieldLogger
+	Tel     telemetry.Sink
+}
+
+// ListenAndServe serves the Delegated Identity API until the context is
+// cancelled
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	os.Remove(s.Config.SocketPath)
+	l, err := net.Listen("unix", s.Config.SocketPath)
+	if err != nil {
+		return fmt.Errorf("create delegated identity API listener: %v", err)
+	}
+	defer l.Close()
+	os.Chmod(s.Config.SocketPath, os.ModePerm)
+
+	server := grpc.NewServer(
+		grpc.Creds(auth.NewCredentials()),
+		grpc.UnaryInterceptor(telemetry.UnaryServerInterceptor(s.Tel)),
+		grpc.StreamInterceptor(telemetry.StreamServerInterceptor(s.Tel)))
+	delegated_pb.RegisterDelegatedIdentityServer(server, &Handler{
+		Manager:             s.Manager,
+		Catalog:             s.Catalog,
+		AuthorizedDelegates: s.Config.AuthorizedDelegates,
+		L:                   s.Log,
+		T:                   s.Tel,
+	})
+
+	s.Log.Infof("Serving delegated identity API on %s", s.Config.SocketPath)
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Stop()
+		<-errChan
+		return nil
+	}
+}
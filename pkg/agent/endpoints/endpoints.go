@@ -5,15 +5,24 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/endpoints/sds"
 	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 
 	"google.golang.org/grpc"
 
+	sds_pb "github.com/spiffe/spire/proto/api/sds"
 	workload_pb "github.com/spiffe/spire/proto/api/workload"
 )
 
+// DefaultDrainTimeout is how long the calls in flight are waited for on
+// shutdown, unless configured otherwise
+const DefaultDrainTimeout = 5 * time.Second
+
 type Server interface {
 	ListenAndServe(ctx context.Context) error
 }
@@ -23,58 +32,187 @@ type endpoints struct {
 }
 
 func (e *endpoints) ListenAndServe(ctx context.Context) error {
-	server := grpc.NewServer(grpc.Creds(auth.NewCredentials()))
+	drainCtx, drain := context.WithCancel(context.Background())
+	defer drain()
+
+	limiter := newLimiter(e.c.Limits, e.c.Tel)
+	server := grpc.NewServer(
+		grpc.Creds(auth.NewCredentials()),
+		grpc.UnaryInterceptor(grpcutil.ChainUnaryInterceptors(
+			telemetry.UnaryServerInterceptor(e.c.Tel),
+			limiter.UnaryInterceptor,
+		)),
+		grpc.StreamInterceptor(grpcutil.ChainStreamInterceptors(
+			telemetry.StreamServerInterceptor(e.c.Tel),
+			drainInterceptor(drainCtx, limiter.StreamInterceptor),
+		)))
 
 	e.registerWorkloadAPI(server)
+	e.registerSDSAPI(server)
 
-	l, err := e.createUDSListener()
-	if err != nil {
-		return err
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+	for _, addr := range e.c.BindAddrs {
+		l, err := e.createListener(addr)
+		if err != nil {
+			return err
+		}
+		listeners = append(listeners, l)
 	}
-	defer l.Close()
 
 	if e.c.GRPCHook != nil {
-		err = e.c.GRPCHook(server)
+		err := e.c.GRPCHook(server)
 		if err != nil {
 			return fmt.Errorf("call grpc hook: %v", err)
 		}
 	}
 
 	e.c.Log.Info("Starting workload API")
-	errChan := make(chan error)
-	go func() { errChan <- server.Serve(l) }()
+	errChan := make(chan error, len(listeners))
+	for _, l := range listeners {
+		go func(l net.Listener) { errChan <- server.Serve(l) }(l)
+	}
 
+	// Stopping the server makes it return from every listener
+	var err error
 	select {
 	case err = <-errChan:
-		return err
+		server.Stop()
 	case <-ctx.Done():
 		e.c.Log.Info("Stopping workload API")
-		server.Stop()
-		l.Close()
+		drain()
+		e.gracefulStop(server)
 		<-errChan
-		return nil
 	}
+	for i := 1; i < len(listeners); i++ {
+		<-errChan
+	}
+	return err
+}
+
+// gracefulStop stops accepting connections and waits for the calls in
+// flight to finish, up to the drain timeout. Streams have been notified
+// through their context by then.
+func (e *endpoints) gracefulStop(server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(e.drainTimeout())
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+	case <-timer.C:
+		e.c.Log.Warn("Workload API calls did not finish within the drain timeout")
+		server.Stop()
+		<-stopped
+	}
+}
+
+func (e *endpoints) drainTimeout() time.Duration {
+	if e.c.DrainTimeout > 0 {
+		return e.c.DrainTimeout
+	}
+	return DefaultDrainTimeout
 }
 
 func (e *endpoints) registerWorkloadAPI(server *grpc.Server) {
 	w := &workload.Handler{
-		Manager: e.c.Manager,
-		Catalog: e.c.Catalog,
-		L:       e.c.Log.WithField("subsystem_name", "workload_api"),
-		T:       e.c.Tel,
+		Manager:     e.c.Manager,
+		Catalog:     e.c.Catalog,
+		TrustDomain: e.c.TrustDomain,
+		L:           e.c.Log.WithField("subsystem_name", "workload_api"),
+		T:           e.c.Tel,
 	}
 
 	workload_pb.RegisterSpiffeWorkloadAPIServer(server, w)
 }
 
-func (e *endpoints) createUDSListener() (net.Listener, error) {
-	os.Remove(e.c.BindAddr.String())
+// registerSDSAPI serves the Envoy Secret Discovery Service on the same
+// socket as the Workload API
+func (e *endpoints) registerSDSAPI(server *grpc.Server) {
+	s := &sds.Handler{
+		Manager:     e.c.Manager,
+		Catalog:     e.c.Catalog,
+		TrustDomain: e.c.TrustDomain,
+		Config:      e.c.SDS,
+		L:           e.c.Log.WithField("subsystem_name", "sds_api"),
+		T:           e.c.Tel,
+	}
 
-	l, err := net.Listen(e.c.BindAddr.Network(), e.c.BindAddr.String())
+	sds_pb.RegisterSecretDiscoveryServiceServer(server, s)
+}
+
+func (e *endpoints) createListener(bindAddr net.Addr) (net.Listener, error) {
+	switch addr := bindAddr.(type) {
+	case *net.UnixAddr:
+		return e.createUDSListener(addr)
+	case *net.TCPAddr:
+		return e.createTCPListener(addr)
+	default:
+		return nil, fmt.Errorf("unsupported bind address %v", bindAddr)
+	}
+}
+
+func (e *endpoints) createUDSListener(addr *net.UnixAddr) (net.Listener, error) {
+	os.Remove(addr.String())
+
+	l, err := net.Listen(addr.Network(), addr.String())
 	if err != nil {
 		return nil, fmt.Errorf("create UDS listener: %s", err)
 	}
 
-	os.Chmod(e.c.BindAddr.String(), os.ModePerm)
+	os.Chmod(addr.String(), os.ModePerm)
+	return l, nil
+}
+
+// createTCPListener listens on a loopback address, where the callers can be
+// identified by the process owning their end of the connection
+func (e *endpoints) createTCPListener(addr *net.TCPAddr) (net.Listener, error) {
+	if !addr.IP.IsLoopback() {
+		return nil, fmt.Errorf("create TCP listener: %v is not a loopback address", addr)
+	}
+
+	l, err := net.ListenTCP(addr.Network(), addr)
+	if err != nil {
+		return nil, fmt.Errorf("create TCP listener: %s", err)
+	}
 	return l, nil
 }
+
+// drainInterceptor gives streams a context which is also cancelled when
+// ctx is, so the handlers return on shutdown and the callers reconnect
+func drainInterceptor(ctx context.Context, next grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		streamCtx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-ctx.Done():
+				cancel()
+			case <-done:
+			}
+		}()
+
+		return next(srv, drainingStream{ServerStream: ss, ctx: streamCtx}, info, handler)
+	}
+}
+
+type drainingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s drainingStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,96 @@
+package endpoints
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+)
+
+func TestListenAndServeOnMultipleSockets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "endpoints-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	addrs := []net.Addr{
+		&net.UnixAddr{Name: path.Join(dir, "host.sock"), Net: "unix"},
+		&net.UnixAddr{Name: path.Join(dir, "container.sock"), Net: "unix"},
+	}
+
+	log, _ := test.NewNullLogger()
+	e := New(&Config{
+		BindAddrs: addrs,
+		Log:       log,
+		Tel:       telemetry.Blackhole{},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() { errChan <- e.ListenAndServe(ctx) }()
+
+	for _, addr := range addrs {
+		var conn net.Conn
+		for i := 0; i < 50; i++ {
+			conn, err = net.Dial(addr.Network(), addr.String())
+			if err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		require.NoError(t, err, "unable to connect to %v", addr)
+		conn.Close()
+	}
+
+	cancel()
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("endpoints did not stop")
+	}
+}
+
+func TestDrainInterceptor(t *testing.T) {
+	drainCtx, drain := context.WithCancel(context.Background())
+	interceptor := drainInterceptor(drainCtx, func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, ss)
+	})
+
+	started := make(chan struct{})
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- interceptor(nil, fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+			close(started)
+			<-ss.Context().Done()
+			return nil
+		})
+	}()
+
+	<-started
+	drain()
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("stream was not notified of the shutdown")
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,213 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// How often buckets of callers that went quiet are dropped
+const limiterSweepInterval = time.Minute
+
+// Limits caps the use a single caller makes of the Workload API, so one
+// misbehaving workload, e.g. one reconnecting in a loop, cannot starve the
+// attestation of the others. Zero values mean no limit.
+type Limits struct {
+	// Maximum number of concurrent streams opened by a process, and by
+	// all of the processes of a user
+	MaxStreamsPerPID int
+	MaxStreamsPerUID int
+
+	// Number of calls per second a process, and a user, may make. Every
+	// call attests the caller. AttestationBurst is how many calls may be
+	// made at once, and defaults to AttestationRate.
+	AttestationRate  int
+	AttestationBurst int
+}
+
+// limiter enforces Limits on the calls made to the gRPC server. Users are
+// only limited when the platform resolves the UID of the caller.
+type limiter struct {
+	limits Limits
+	t      telemetry.Sink
+
+	mtx       sync.Mutex
+	pids      *callerLimits
+	uids      *callerLimits
+	lastSweep time.Time
+
+	// Overridden by tests
+	now func() time.Time
+}
+
+// callerLimits tracks the streams and call rate of the callers, keyed by
+// PID or UID
+type callerLimits struct {
+	maxStreams int
+	streams    map[int64]int
+	buckets    map[int64]*bucket
+}
+
+// bucket is a token bucket, refilled at the attestation rate
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(limits Limits, t telemetry.Sink) *limiter {
+	if limits.AttestationBurst == 0 {
+		limits.AttestationBurst = limits.AttestationRate
+	}
+
+	return &limiter{
+		limits: limits,
+		t:      t,
+		pids:   newCallerLimits(limits.MaxStreamsPerPID),
+		uids:   newCallerLimits(limits.MaxStreamsPerUID),
+		now:    time.Now,
+	}
+}
+
+func newCallerLimits(maxStreams int) *callerLimits {
+	return &callerLimits{
+		maxStreams: maxStreams,
+		streams:    make(map[int64]int),
+		buckets:    make(map[int64]*bucket),
+	}
+}
+
+func (l *limiter) UnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	release, err := l.acquire(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return handler(ctx, req)
+}
+
+func (l *limiter) StreamInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	release, err := l.acquire(ss.Context(), true)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return handler(srv, ss)
+}
+
+// acquire takes a token from the buckets of the caller and, for streams, a
+// stream slot. The returned function releases the stream slot. Callers
+// that could not be identified are left to the handlers, which reject them.
+func (l *limiter) acquire(ctx context.Context, stream bool) (func(), error) {
+	info, ok := auth.CallerFromContext(ctx)
+	if !ok || info.Err != nil || info.PID == 0 {
+		return func() {}, nil
+	}
+
+	limits := []*callerLimits{l.pids}
+	keys := []int64{int64(info.PID)}
+	if info.HasUID {
+		limits = append(limits, l.uids)
+		keys = append(keys, int64(info.UID))
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	if stream {
+		for i, cl := range limits {
+			if cl.maxStreams > 0 && cl.streams[keys[i]] >= cl.maxStreams {
+				return nil, l.exceeded(info, "too many concurrent streams")
+			}
+		}
+	}
+
+	if l.limits.AttestationRate > 0 {
+		for i, cl := range limits {
+			if l.refill(cl, keys[i], now).tokens < 1 {
+				return nil, l.exceeded(info, "attestation rate exceeded")
+			}
+		}
+		for i, cl := range limits {
+			cl.buckets[keys[i]].tokens--
+		}
+	}
+
+	if !stream {
+		return func() {}, nil
+	}
+
+	for i, cl := range limits {
+		cl.streams[keys[i]]++
+	}
+	return func() {
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+		for i, cl := range limits {
+			cl.streams[keys[i]]--
+			if cl.streams[keys[i]] <= 0 {
+				delete(cl.streams, keys[i])
+			}
+		}
+	}, nil
+}
+
+// refill returns the bucket of the caller, topped up with the tokens
+// accrued since it was last used. New buckets start full.
+func (l *limiter) refill(cl *callerLimits, key int64, now time.Time) *bucket {
+	burst := float64(l.limits.AttestationBurst)
+
+	b, ok := cl.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		cl.buckets[key] = b
+		return b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * float64(l.limits.AttestationRate)
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+	return b
+}
+
+// sweep drops the buckets that have been refilled since they were last
+// used, which are no different from new ones
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < limiterSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	if l.limits.AttestationRate == 0 {
+		return
+	}
+	full := time.Duration(l.limits.AttestationBurst) * time.Second / time.Duration(l.limits.AttestationRate)
+	for _, cl := range []*callerLimits{l.pids, l.uids} {
+		for key, b := range cl.buckets {
+			if now.Sub(b.last) >= full {
+				delete(cl.buckets, key)
+			}
+		}
+	}
+}
+
+func (l *limiter) exceeded(info auth.CallerInfo, reason string) error {
+	tLabels := []telemetry.Label{{Name: "workload_pid", Value: fmt.Sprint(info.PID)}}
+	l.t.IncrCounterWithLabels([]string{"workload_api", "limit_exceeded"}, 1, tLabels)
+
+	return status.Errorf(codes.ResourceExhausted, "%s for caller with PID %d", reason, info.PID)
+}
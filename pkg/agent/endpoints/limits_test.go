@@ -0,0 +1,100 @@
+package endpoints
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimiterStreamsPerPID(t *testing.T) {
+	l := newLimiter(Limits{MaxStreamsPerPID: 2}, telemetry.Blackhole{})
+
+	release1, err := l.acquire(callerContext(1, 1000), true)
+	require.NoError(t, err)
+	_, err = l.acquire(callerContext(1, 1000), true)
+	require.NoError(t, err)
+	_, err = l.acquire(callerContext(1, 1000), true)
+	requireResourceExhausted(t, err)
+
+	// Other processes and unary calls are not affected
+	_, err = l.acquire(callerContext(2, 1000), true)
+	require.NoError(t, err)
+	_, err = l.acquire(callerContext(1, 1000), false)
+	require.NoError(t, err)
+
+	release1()
+	_, err = l.acquire(callerContext(1, 1000), true)
+	require.NoError(t, err)
+}
+
+func TestLimiterStreamsPerUID(t *testing.T) {
+	l := newLimiter(Limits{MaxStreamsPerUID: 2}, telemetry.Blackhole{})
+
+	_, err := l.acquire(callerContext(1, 1000), true)
+	require.NoError(t, err)
+	_, err = l.acquire(callerContext(2, 1000), true)
+	require.NoError(t, err)
+	_, err = l.acquire(callerContext(3, 1000), true)
+	requireResourceExhausted(t, err)
+
+	_, err = l.acquire(callerContext(3, 1001), true)
+	require.NoError(t, err)
+}
+
+func TestLimiterAttestationRate(t *testing.T) {
+	now := time.Now()
+	l := newLimiter(Limits{AttestationRate: 2, AttestationBurst: 3}, telemetry.Blackhole{})
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := l.acquire(callerContext(1, 1000), false)
+		require.NoError(t, err)
+	}
+	_, err := l.acquire(callerContext(1, 1000), true)
+	requireResourceExhausted(t, err)
+
+	// Other callers are not affected
+	_, err = l.acquire(callerContext(2, 0), false)
+	require.NoError(t, err)
+
+	// Tokens are replenished at the attestation rate
+	now = now.Add(500 * time.Millisecond)
+	_, err = l.acquire(callerContext(1, 1000), false)
+	require.NoError(t, err)
+	_, err = l.acquire(callerContext(1, 1000), false)
+	requireResourceExhausted(t, err)
+
+	// Idle callers are forgotten
+	now = now.Add(limiterSweepInterval)
+	_, err = l.acquire(callerContext(2, 0), false)
+	require.NoError(t, err)
+	require.Len(t, l.pids.buckets, 1)
+}
+
+func TestLimiterUnidentifiedCaller(t *testing.T) {
+	l := newLimiter(Limits{MaxStreamsPerPID: 1, AttestationRate: 1}, telemetry.Blackhole{})
+
+	for i := 0; i < 3; i++ {
+		_, err := l.acquire(context.Background(), true)
+		require.NoError(t, err)
+	}
+}
+
+func callerContext(pid int32, uid uint32) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.CallerInfo{PID: pid, UID: uid, HasUID: true},
+	})
+}
+
+func requireResourceExhausted(t *testing.T, err error) {
+	require.Error(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,392 @@
+package sds
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/attestor/workload"
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/catalog"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/api/sds"
+	"github.com/spiffe/spire/proto/api/sds/secret"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// SecretTypeURL is the type of the resources served over SDS
+	SecretTypeURL = "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret"
+
+	// DefaultSVIDName is the default name of the secret holding the first
+	// X509-SVID issued to the caller
+	DefaultSVIDName = "default"
+
+	// DefaultBundleName is the default name of the secret holding the
+	// validation context for the agent's trust domain
+	DefaultBundleName = "ROOTCA"
+
+	spiffeCertValidatorName = "envoy.tls.cert_validator.spiffe"
+
+	sdsAPI = "sds_api"
+)
+
+// Config configures the secrets served over SDS
+type Config struct {
+	// DefaultSVIDName is the name of the secret holding the first
+	// X509-SVID issued to the caller. Defaults to DefaultSVIDName.
+	DefaultSVIDName string
+
+	// DefaultBundleName is the name of the secret holding the validation
+	// context for the agent's trust domain. Defaults to DefaultBundleName.
+	DefaultBundleName string
+
+	// DisableSPIFFECertValidation makes validation contexts carry a plain
+	// trusted CA instead of a SPIFFE certificate validator config.
+	DisableSPIFFECertValidation bool
+}
+
+// Handler implements the Envoy v3 Secret Discovery Service
+type Handler struct {
+	Manager     manager.Manager
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+	Config      Config
+	L           logrus.FieldLogger
+	T           telemetry.Sink
+}
+
+// StreamSecrets streams the requested secrets to Envoy, sending a new
+// response whenever the requested names or the caller's SVIDs change
+func (h *Handler) StreamSecrets(stream sds.SecretDiscoveryService_StreamSecretsServer) error {
+	ctx := stream.Context()
+
+	subscriber, err := h.subscribe(ctx)
+	if err != nil {
+		return err
+	}
+	defer subscriber.Finish()
+
+	requests := make(chan *sds.DiscoveryRequest)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var update *cache.WorkloadUpdate
+	var names []string
+	var lastNonce string
+	var version int
+	received := false
+	for {
+		select {
+		case req := <-requests:
+			// Responses to requests acknowledging an older nonce have been
+			// superseded, so the request can be dropped
+			if req.ResponseNonce != lastNonce {
+				continue
+			}
+			if lastNonce != "" {
+				if req.VersionInfo != strconv.Itoa(version) {
+					h.L.Warnf("Envoy node %q rejected secrets version %d", req.Node.GetId(), version)
+					continue
+				}
+				if sameNames(names, req.ResourceNames) {
+					// Plain ACK of the last response
+					continue
+				}
+			}
+			names = req.ResourceNames
+			received = true
+		case update = <-subscriber.Updates():
+		case err := <-errs:
+			// Envoy closed the stream
+			if err == io.EOF || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return nil
+		}
+
+		if !received || update == nil {
+			continue
+		}
+
+		version++
+		resp, err := h.buildResponse(update, names, strconv.Itoa(version))
+		if err != nil {
+			return err
+		}
+		lastNonce = resp.Nonce
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		h.T.IncrCounter([]string{sdsAPI, "update"}, 1)
+	}
+}
+
+// FetchSecrets returns the requested secrets as they currently are
+func (h *Handler) FetchSecrets(ctx context.Context, req *sds.DiscoveryRequest) (*sds.DiscoveryResponse, error) {
+	subscriber, err := h.subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer subscriber.Finish()
+
+	select {
+	case update := <-subscriber.Updates():
+		return h.buildResponse(update, req.ResourceNames, "")
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// subscribe attests the caller and subscribes to its cache entries
+func (h *Handler) subscribe(ctx context.Context) (cache.Subscriber, error) {
+	info, ok := auth.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "unable to fetch credentials from context")
+	}
+	if info.Err != nil || info.PID == 0 {
+		return nil, status.Errorf(codes.Internal, "unable to resolve caller PID: %v", info.Err)
+	}
+
+	config := attestor.Config{
+		Catalog: h.Catalog,
+		L:       h.L,
+		T:       h.T,
+	}
+	selectors := attestor.New(&config).Attest(ctx, info.PID)
+
+	return h.Manager.SubscribeToCacheChanges(selectors), nil
+}
+
+// buildResponse builds a response carrying the named secrets. All secrets
+// available to the caller are returned if no names are given. Names that
+// do not match a secret are left out, Envoy keeps waiting for them.
+func (h *Handler) buildResponse(update *cache.WorkloadUpdate, names []string, version string) (*sds.DiscoveryResponse, error) {
+	nonce, err := uuid.NewV4()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to generate nonce: %v", err)
+	}
+
+	secrets, err := h.secrets(update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to build secrets: %v", err)
+	}
+
+	resp := &sds.DiscoveryResponse{
+		VersionInfo: version,
+		TypeUrl:     SecretTypeURL,
+		Nonce:       nonce.String(),
+	}
+
+	if len(names) == 0 {
+		for name := range secrets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	for _, name := range names {
+		s, ok := secrets[name]
+		if !ok {
+			continue
+		}
+		// The type URL is derived from the registered message name, which
+		// matches the Envoy definitions
+		resource, err := ptypes.MarshalAny(s)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to marshal secret %q: %v", name, err)
+		}
+		resp.Resources = append(resp.Resources, resource)
+	}
+
+	return resp, nil
+}
+
+// secrets returns the secrets available to the caller, keyed by name. SVIDs
+// are named after their SPIFFE ID and bundles after their trust domain ID.
+// The default names refer to the first SVID and the agent's bundle.
+func (h *Handler) secrets(update *cache.WorkloadUpdate) (map[string]*secret.Secret, error) {
+	secrets := make(map[string]*secret.Secret)
+
+	for i, e := range update.Entries {
+		keyData, err := x509.MarshalPKCS8PrivateKey(e.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("marshal key for %v: %v", e.RegistrationEntry.SpiffeId, err)
+		}
+		certChain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: e.SVID.Raw})
+		for _, intermediate := range e.Intermediates {
+			certChain = append(certChain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw})...)
+		}
+		tlsCertificate := &secret.Secret_TlsCertificate{
+			TlsCertificate: &secret.TlsCertificate{
+				CertificateChain: &secret.DataSource{
+					InlineBytes: certChain,
+				},
+				PrivateKey: &secret.DataSource{
+					InlineBytes: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyData}),
+				},
+			},
+		}
+
+		secrets[e.RegistrationEntry.SpiffeId] = &secret.Secret{
+			Name: e.RegistrationEntry.SpiffeId,
+			Type: tlsCertificate,
+		}
+		if i == 0 {
+			secrets[h.defaultSVIDName()] = &secret.Secret{
+				Name: h.defaultSVIDName(),
+				Type: tlsCertificate,
+			}
+		}
+	}
+
+	// Gather the bundles of every trust domain the caller may talk to
+	bundles := map[string][]*x509.Certificate{
+		h.TrustDomain.String(): update.Bundle,
+	}
+	for _, e := range update.Entries {
+		for trustDomain, data := range e.Bundles {
+			certs, err := x509.ParseCertificates(data)
+			if err != nil {
+				return nil, fmt.Errorf("parse bundle for %v: %v", trustDomain, err)
+			}
+			bundles[trustDomain] = certs
+		}
+	}
+
+	for trustDomain, certs := range bundles {
+		validationContext, err := h.validationContext(map[string][]*x509.Certificate{trustDomain: certs})
+		if err != nil {
+			return nil, err
+		}
+		secrets[trustDomain] = &secret.Secret{
+			Name: trustDomain,
+			Type: validationContext,
+		}
+	}
+
+	// The default bundle validates peers of any of the trust domains when
+	// the SPIFFE validator is in use
+	defaultBundles := bundles
+	if h.Config.DisableSPIFFECertValidation {
+		defaultBundles = map[string][]*x509.Certificate{h.TrustDomain.String(): update.Bundle}
+	}
+	validationContext, err := h.validationContext(defaultBundles)
+	if err != nil {
+		return nil, err
+	}
+	secrets[h.defaultBundleName()] = &secret.Secret{
+		Name: h.defaultBundleName(),
+		Type: validationContext,
+	}
+
+	return secrets, nil
+}
+
+// validationContext builds a validation context trusting the given bundles,
+// keyed by trust domain ID
+func (h *Handler) validationContext(bundles map[string][]*x509.Certificate) (*secret.Secret_ValidationContext, error) {
+	if h.Config.DisableSPIFFECertValidation {
+		var data []byte
+		for _, certs := range bundles {
+			data = append(data, pemBundle(certs)...)
+		}
+		return &secret.Secret_ValidationContext{
+			ValidationContext: &secret.CertificateValidationContext{
+				TrustedCa: &secret.DataSource{InlineBytes: data},
+			},
+		}, nil
+	}
+
+	config := &secret.SPIFFECertValidatorConfig{}
+	for trustDomainID, certs := range bundles {
+		u, err := url.Parse(trustDomainID)
+		if err != nil {
+			return nil, fmt.Errorf("parse trust domain %q: %v", trustDomainID, err)
+		}
+		config.TrustDomains = append(config.TrustDomains, &secret.SPIFFECertValidatorConfig_TrustDomain{
+			Name:        u.Host,
+			TrustBundle: &secret.DataSource{InlineBytes: pemBundle(certs)},
+		})
+	}
+	sort.Slice(config.TrustDomains, func(i, j int) bool {
+		return config.TrustDomains[i].Name < config.TrustDomains[j].Name
+	})
+
+	typedConfig, err := ptypes.MarshalAny(config)
+	if err != nil {
+		return nil, err
+	}
+	return &secret.Secret_ValidationContext{
+		ValidationContext: &secret.CertificateValidationContext{
+			CustomValidatorConfig: &secret.TypedExtensionConfig{
+				Name:        spiffeCertValidatorName,
+				TypedConfig: typedConfig,
+			},
+		},
+	}, nil
+}
+
+func (h *Handler) defaultSVIDName() string {
+	if h.Config.DefaultSVIDName != "" {
+		return h.Config.DefaultSVIDName
+	}
+	return DefaultSVIDName
+}
+
+func (h *Handler) defaultBundleName() string {
+	if h.Config.DefaultBundleName != "" {
+		return h.Config.DefaultBundleName
+	}
+	return DefaultBundleName
+}
+
+func pemBundle(certs []*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+func sameNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
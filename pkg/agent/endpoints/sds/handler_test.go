@@ -0,0 +1,305 @@
+package sds
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/api/sds"
+	"github.com/spiffe/spire/proto/api/sds/secret"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/fakes/fakeagentcatalog"
+	"github.com/spiffe/spire/test/mock/agent/manager"
+	"github.com/spiffe/spire/test/mock/agent/manager/cache"
+	"github.com/spiffe/spire/test/mock/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/test/util"
+
+	"google.golang.org/grpc/peer"
+)
+
+type HandlerTestSuite struct {
+	suite.Suite
+
+	h    *Handler
+	ctrl *gomock.Controller
+
+	attestor *mock_workloadattestor.MockWorkloadAttestor
+	manager  *mock_manager.MockManager
+
+	svid     *x509.Certificate
+	ca       *x509.Certificate
+	federate *x509.Certificate
+}
+
+func TestHandler(t *testing.T) {
+	suite.Run(t, new(HandlerTestSuite))
+}
+
+func (s *HandlerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	log, _ := test.NewNullLogger()
+
+	s.attestor = mock_workloadattestor.NewMockWorkloadAttestor(s.ctrl)
+	s.manager = mock_manager.NewMockManager(s.ctrl)
+
+	catalog := fakeagentcatalog.New()
+	catalog.SetWorkloadAttestors(s.attestor)
+
+	s.h = &Handler{
+		Manager:     s.manager,
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+		L:           log,
+		T:           telemetry.Blackhole{},
+	}
+
+	var err error
+	s.svid, _, err = util.LoadSVIDFixture()
+	s.Require().NoError(err)
+	s.ca, _, err = util.LoadCAFixture()
+	s.Require().NoError(err)
+	template, err := util.NewCATemplate("otherdomain.org")
+	s.Require().NoError(err)
+	s.federate, _, err = util.SelfSign(template)
+	s.Require().NoError(err)
+}
+
+func (s *HandlerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *HandlerTestSuite) TestStreamSecrets() {
+	ctx, cancel := context.WithCancel(s.callerContext())
+	defer cancel()
+
+	updates := s.expectSubscription()
+
+	requests := make(chan *sds.DiscoveryRequest)
+	responses := make(chan *sds.DiscoveryResponse)
+	stream := &fakeStream{
+		ctx:       ctx,
+		requests:  requests,
+		responses: responses,
+	}
+
+	result := make(chan error)
+	go func() { result <- s.h.StreamSecrets(stream) }()
+
+	// Nothing is sent until the first request is received
+	updates <- s.workloadUpdate()
+	requests <- &sds.DiscoveryRequest{
+		TypeUrl:       SecretTypeURL,
+		ResourceNames: []string{"default"},
+	}
+	resp := s.receive(responses)
+	s.Require().Equal("1", resp.VersionInfo)
+	s.Require().Equal(SecretTypeURL, resp.TypeUrl)
+	s.Require().Equal([]string{"default"}, s.secretNames(resp))
+
+	// A plain ACK does not trigger a response, a change of names does
+	requests <- &sds.DiscoveryRequest{
+		VersionInfo:   "1",
+		ResponseNonce: resp.Nonce,
+		ResourceNames: []string{"default"},
+	}
+	requests <- &sds.DiscoveryRequest{
+		VersionInfo:   "1",
+		ResponseNonce: resp.Nonce,
+		ResourceNames: []string{"default", "ROOTCA"},
+	}
+	resp = s.receive(responses)
+	s.Require().Equal("2", resp.VersionInfo)
+	s.Require().Equal([]string{"default", "ROOTCA"}, s.secretNames(resp))
+
+	// Requests carrying a stale nonce are ignored, cache updates are pushed
+	requests <- &sds.DiscoveryRequest{
+		VersionInfo:   "1",
+		ResponseNonce: "stale",
+		ResourceNames: []string{"spiffe://example.org/foo"},
+	}
+	updates <- s.workloadUpdate()
+	resp = s.receive(responses)
+	s.Require().Equal("3", resp.VersionInfo)
+	s.Require().Equal([]string{"default", "ROOTCA"}, s.secretNames(resp))
+
+	cancel()
+	select {
+	case err := <-result:
+		s.Require().NoError(err)
+	case <-time.After(time.Second):
+		s.FailNow("handler hung, shutdown timer exceeded")
+	}
+}
+
+func (s *HandlerTestSuite) TestStreamSecretsWithoutCaller() {
+	s.Require().Error(s.h.StreamSecrets(&fakeStream{ctx: context.Background()}))
+}
+
+func (s *HandlerTestSuite) TestFetchSecrets() {
+	updates := s.expectSubscription()
+	go func() { updates <- s.workloadUpdate() }()
+
+	resp, err := s.h.FetchSecrets(s.callerContext(), &sds.DiscoveryRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{
+		"ROOTCA",
+		"default",
+		"spiffe://example.org",
+		"spiffe://example.org/foo",
+		"spiffe://otherdomain.org",
+	}, s.secretNames(resp))
+}
+
+func (s *HandlerTestSuite) TestSecrets() {
+	secrets, err := s.h.secrets(s.workloadUpdate())
+	s.Require().NoError(err)
+
+	// SVIDs are served under their SPIFFE ID and the default name
+	for _, name := range []string{"default", "spiffe://example.org/foo"} {
+		tlsCertificate := secrets[name].GetTlsCertificate()
+		s.Require().NotNil(tlsCertificate, name)
+		s.Require().Equal(s.pem(s.svid), tlsCertificate.CertificateChain.InlineBytes)
+		block, _ := pem.Decode(tlsCertificate.PrivateKey.InlineBytes)
+		s.Require().Equal("PRIVATE KEY", block.Type)
+	}
+
+	// The default bundle validates peers of every trust domain
+	s.Require().Equal([]*secret.SPIFFECertValidatorConfig_TrustDomain{
+		{Name: "example.org", TrustBundle: &secret.DataSource{InlineBytes: s.pem(s.ca)}},
+		{Name: "otherdomain.org", TrustBundle: &secret.DataSource{InlineBytes: s.pem(s.federate)}},
+	}, s.validatorConfig(secrets["ROOTCA"]).TrustDomains)
+
+	s.Require().Equal([]*secret.SPIFFECertValidatorConfig_TrustDomain{
+		{Name: "otherdomain.org", TrustBundle: &secret.DataSource{InlineBytes: s.pem(s.federate)}},
+	}, s.validatorConfig(secrets["spiffe://otherdomain.org"]).TrustDomains)
+}
+
+func (s *HandlerTestSuite) TestSecretsWithConfig() {
+	s.h.Config = Config{
+		DefaultSVIDName:             "svid",
+		DefaultBundleName:           "bundle",
+		DisableSPIFFECertValidation: true,
+	}
+
+	secrets, err := s.h.secrets(s.workloadUpdate())
+	s.Require().NoError(err)
+	s.Require().NotNil(secrets["svid"].GetTlsCertificate())
+	s.Require().Nil(secrets["default"])
+
+	validationContext := secrets["bundle"].GetValidationContext()
+	s.Require().NotNil(validationContext)
+	s.Require().Nil(validationContext.CustomValidatorConfig)
+	s.Require().Equal(s.pem(s.ca), validationContext.TrustedCa.InlineBytes)
+}
+
+func (s *HandlerTestSuite) expectSubscription() chan *cache.WorkloadUpdate {
+	selectors := []*common.Selector{{Type: "foo", Value: "bar"}}
+	s.attestor.EXPECT().Attest(gomock.Any(), &workloadattestor.AttestRequest{Pid: int32(1)}).Return(&workloadattestor.AttestResponse{Selectors: selectors}, nil)
+
+	updates := make(chan *cache.WorkloadUpdate)
+	subscriber := mock_cache.NewMockSubscriber(s.ctrl)
+	subscriber.EXPECT().Updates().Return(updates).AnyTimes()
+	subscriber.EXPECT().Finish()
+	s.manager.EXPECT().SubscribeToCacheChanges(cache.Selectors{selectors[0]}).Return(subscriber)
+	return updates
+}
+
+func (s *HandlerTestSuite) callerContext() context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.CallerInfo{PID: 1},
+	})
+}
+
+func (s *HandlerTestSuite) receive(responses chan *sds.DiscoveryResponse) *sds.DiscoveryResponse {
+	select {
+	case resp := <-responses:
+		return resp
+	case <-time.After(time.Second):
+		s.FailNow("timed out waiting for a response")
+		return nil
+	}
+}
+
+func (s *HandlerTestSuite) secretNames(resp *sds.DiscoveryResponse) []string {
+	var names []string
+	for _, resource := range resp.Resources {
+		sec := new(secret.Secret)
+		s.Require().NoError(ptypes.UnmarshalAny(resource, sec))
+		names = append(names, sec.Name)
+	}
+	return names
+}
+
+func (s *HandlerTestSuite) validatorConfig(sec *secret.Secret) *secret.SPIFFECertValidatorConfig {
+	validator := sec.GetValidationContext().CustomValidatorConfig
+	s.Require().Equal("envoy.tls.cert_validator.spiffe", validator.Name)
+	config := new(secret.SPIFFECertValidatorConfig)
+	s.Require().NoError(ptypes.UnmarshalAny(validator.TypedConfig, config))
+	return config
+}
+
+func (s *HandlerTestSuite) pem(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+}
+
+func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
+	_, key, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+
+	return &cache.WorkloadUpdate{
+		Entries: []*cache.Entry{
+			{
+				SVID:       s.svid,
+				PrivateKey: key,
+				RegistrationEntry: &common.RegistrationEntry{
+					SpiffeId:      "spiffe://example.org/foo",
+					FederatesWith: []string{"spiffe://otherdomain.org"},
+				},
+				Bundles: map[string][]byte{
+					"spiffe://otherdomain.org": s.federate.Raw,
+				},
+			},
+		},
+		Bundle: []*x509.Certificate{s.ca},
+	}
+}
+
+// fakeStream feeds requests to the handler and hands its responses back
+type fakeStream struct {
+	sds.SecretDiscoveryService_StreamSecretsServer
+
+	ctx       context.Context
+	requests  chan *sds.DiscoveryRequest
+	responses chan *sds.DiscoveryResponse
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) Recv() (*sds.DiscoveryRequest, error) {
+	select {
+	case req := <-f.requests:
+		return req, nil
+	case <-f.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func (f *fakeStream) Send(resp *sds.DiscoveryResponse) error {
+	f.responses <- resp
+	return nil
+}
@@ -2,19 +2,29 @@ package workload
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"sync"
 	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/agent/attestor/workload"
 	"github.com/spiffe/spire/pkg/agent/auth"
 	"github.com/spiffe/spire/pkg/agent/catalog"
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/api/workload"
+	"github.com/spiffe/spire/proto/common"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -23,10 +33,15 @@ import (
 
 // Handler implements the Workload API interface
 type Handler struct {
-	Manager manager.Manager
-	Catalog catalog.Catalog
-	L       logrus.FieldLogger
-	T       telemetry.Sink
+	Manager     manager.Manager
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+	L           logrus.FieldLogger
+	T           telemetry.Sink
+
+	// Number of FetchX509SVID streams open, reported as a gauge
+	connMtx     sync.Mutex
+	connections int
 }
 
 const (
@@ -34,12 +49,57 @@ const (
 	workloadPid = "workload_pid"
 )
 
-func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+// FetchJWTSVID returns JWT-SVIDs for the requested audience, for all or one
+// of the identities the caller is entitled to.
+func (h *Handler) FetchJWTSVID(ctx context.Context, req *workload.JWTSVIDRequest) (*workload.JWTSVIDResponse, error) {
+	if len(req.Audience) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "audience must be specified")
+	}
+
+	if err := checkSecurityHeader(ctx); err != nil {
+		return nil, err
+	}
+
+	pid, err := h.callerPID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Is this a supported system? Please report this bug: %v", err)
+	}
+
+	selectors := h.attest(ctx, pid)
+
+	var spiffeIDs []string
+	for _, entry := range h.Manager.MatchingEntries(selectors) {
+		if req.SpiffeId != "" && entry.RegistrationEntry.SpiffeId != req.SpiffeId {
+			continue
+		}
+		spiffeIDs = append(spiffeIDs, entry.RegistrationEntry.SpiffeId)
+	}
+	if len(spiffeIDs) == 0 {
+		return nil, status.Errorf(codes.PermissionDenied, "no identity issued")
+	}
+
+	resp := new(workload.JWTSVIDResponse)
+	for _, spiffeID := range spiffeIDs {
+		svid, err := h.Manager.FetchJWTSVID(ctx, spiffeID, req.Audience)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "could not fetch %q JWT-SVID: %v", spiffeID, err)
+		}
+		resp.Svids = append(resp.Svids, &workload.JWTSVID{
+			SpiffeId: spiffeID,
+			Svid:     svid.Token,
+		})
+	}
+
+	return resp, nil
+}
+
+// FetchJWTBundles streams the JWT bundles of the trust domains the caller
+// may validate JWT-SVIDs of, sending a new response as they change.
+func (h *Handler) FetchJWTBundles(_ *workload.JWTBundlesRequest, stream workload.SpiffeWorkloadAPI_FetchJWTBundlesServer) error {
 	ctx := stream.Context()
 
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok || len(md["workload.spiffe.io"]) != 1 || md["workload.spiffe.io"][0] != "true" {
-		return status.Errorf(codes.InvalidArgument, "Security header missing from request")
+	if err := checkSecurityHeader(ctx); err != nil {
+		return err
 	}
 
 	pid, err := h.callerPID(ctx)
@@ -47,18 +107,134 @@ func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.Spi
 		return status.Errorf(codes.Internal, "Is this a supported system? Please report this bug: %v", err)
 	}
 
-	tLabels := []telemetry.Label{{workloadPid, string(pid)}}
-	h.T.IncrCounterWithLabels([]string{workloadApi, "connection"}, 1, tLabels)
-	h.T.IncrCounterWithLabels([]string{workloadApi, "connections"}, 1, tLabels)
-	defer h.T.IncrCounterWithLabels([]string{workloadApi, "connections"}, -1, tLabels)
+	subscriber := h.Manager.SubscribeToCacheChanges(h.attest(ctx, pid))
+	defer subscriber.Finish()
 
-	config := attestor.Config{
-		Catalog: h.Catalog,
-		L:       h.L,
-		T:       h.T,
+	for {
+		select {
+		case update := <-subscriber.Updates():
+			resp, err := h.composeJWTBundlesResponse(update)
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "Could not serialize response: %v", err)
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// FetchX509Bundles streams the X.509 bundles of the trust domains the caller
+// should trust, without its SVIDs and keys, sending a new response as the
+// bundles change.
+func (h *Handler) FetchX509Bundles(_ *workload.X509BundlesRequest, stream workload.SpiffeWorkloadAPI_FetchX509BundlesServer) error {
+	ctx := stream.Context()
+
+	if err := checkSecurityHeader(ctx); err != nil {
+		return err
+	}
+
+	pid, err := h.callerPID(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Is this a supported system? Please report this bug: %v", err)
+	}
+
+	subscriber := h.Manager.SubscribeToCacheChanges(h.attest(ctx, pid))
+	defer subscriber.Finish()
+
+	var previous *workload.X509BundlesResponse
+	for {
+		select {
+		case update := <-subscriber.Updates():
+			if len(update.Entries) == 0 {
+				return status.Errorf(codes.PermissionDenied, "no identity issued")
+			}
+
+			// Updates are also sent when SVIDs are rotated, only send the
+			// bundles when they change
+			resp := h.composeX509BundlesResponse(update)
+			if previous != nil && proto.Equal(resp, previous) {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			previous = resp
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// ValidateJWTSVID validates a JWT-SVID against the JWT bundles available to
+// the caller.
+func (h *Handler) ValidateJWTSVID(ctx context.Context, req *workload.ValidateJWTSVIDRequest) (*workload.ValidateJWTSVIDResponse, error) {
+	if req.Audience == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "audience must be specified")
+	}
+	if req.Svid == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "svid must be specified")
+	}
+
+	if err := checkSecurityHeader(ctx); err != nil {
+		return nil, err
+	}
+
+	pid, err := h.callerPID(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Is this a supported system? Please report this bug: %v", err)
+	}
+
+	subscriber := h.Manager.SubscribeToCacheChanges(h.attest(ctx, pid))
+	defer subscriber.Finish()
+
+	var update *cache.WorkloadUpdate
+	select {
+	case update = <-subscriber.Updates():
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	keys, err := h.jwtKeys(update)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not load JWT bundles: %v", err)
 	}
 
-	selectors := attestor.New(&config).Attest(ctx, pid)
+	spiffeID, claims, err := jwtsvid.ValidateToken(req.Svid, keys, []string{req.Audience})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s, err := claimsToStruct(claims)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not serialize claims: %v", err)
+	}
+
+	return &workload.ValidateJWTSVIDResponse{
+		SpiffeId: spiffeID,
+		Claims:   s,
+	}, nil
+}
+
+func (h *Handler) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+	ctx := stream.Context()
+
+	if err := checkSecurityHeader(ctx); err != nil {
+		return err
+	}
+
+	pid, err := h.callerPID(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Is this a supported system? Please report this bug: %v", err)
+	}
+
+	tLabels := []telemetry.Label{{workloadPid, string(pid)}}
+	h.trackConnection(1)
+	defer h.trackConnection(-1)
+
+	selectors := h.attest(ctx, pid)
 
 	subscriber := h.Manager.SubscribeToCacheChanges(selectors)
 	defer subscriber.Finish()
@@ -100,6 +276,9 @@ func (h *Handler) sendResponse(update *cache.WorkloadUpdate, stream workload.Spi
 func (h *Handler) composeResponse(update *cache.WorkloadUpdate) (*workload.X509SVIDResponse, error) {
 	resp := new(workload.X509SVIDResponse)
 	resp.Svids = []*workload.X509SVID{}
+	if update.CRL != nil {
+		resp.Crl = [][]byte{update.CRL}
+	}
 
 	bundle := []byte{}
 	for _, c := range update.Bundle {
@@ -116,17 +295,150 @@ func (h *Handler) composeResponse(update *cache.WorkloadUpdate) (*workload.X509S
 
 		svid := &workload.X509SVID{
 			SpiffeId:    id,
-			X509Svid:    e.SVID.Raw,
+			X509Svid:    e.SVIDChain(),
 			X509SvidKey: keyData,
 			Bundle:      bundle,
 		}
 
 		resp.Svids = append(resp.Svids, svid)
+
+		for trustDomain, federatedBundle := range e.Bundles {
+			if resp.FederatedBundles == nil {
+				resp.FederatedBundles = make(map[string][]byte)
+			}
+			resp.FederatedBundles[trustDomain] = federatedBundle
+		}
+	}
+
+	return resp, nil
+}
+
+func (h *Handler) composeX509BundlesResponse(update *cache.WorkloadUpdate) *workload.X509BundlesResponse {
+	bundle := []byte{}
+	for _, c := range update.Bundle {
+		bundle = append(bundle, c.Raw...)
+	}
+
+	resp := &workload.X509BundlesResponse{
+		Bundles: map[string][]byte{
+			h.TrustDomain.String(): bundle,
+		},
+	}
+	if update.CRL != nil {
+		resp.Crl = [][]byte{update.CRL}
+	}
+	for _, e := range update.Entries {
+		for trustDomain, federatedBundle := range e.Bundles {
+			resp.Bundles[trustDomain] = federatedBundle
+		}
+	}
+
+	return resp
+}
+
+func (h *Handler) composeJWTBundlesResponse(update *cache.WorkloadUpdate) (*workload.JWTBundlesResponse, error) {
+	keys, err := h.jwtKeys(update)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &workload.JWTBundlesResponse{
+		Bundles: make(map[string][]byte),
+	}
+	for trustDomainID, trustDomainKeys := range keys {
+		jwks, err := bundleutil.Marshal(&bundleutil.Bundle{
+			JWTSigningKeys: trustDomainKeys,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshal JWKS for %v: %v", trustDomainID, err)
+		}
+		resp.Bundles[trustDomainID] = jwks
 	}
 
 	return resp, nil
 }
 
+// jwtKeys returns the JWT signing keys of the agent's trust domain and the
+// trust domains the caller federates with
+func (h *Handler) jwtKeys(update *cache.WorkloadUpdate) (keyStore, error) {
+	keys := make(keyStore)
+
+	trustDomainKeys, err := jwtsvid.KeysFromCertificates(update.Bundle)
+	if err != nil {
+		return nil, err
+	}
+	for kid, key := range update.JWTSigningKeys {
+		trustDomainKeys[kid] = key
+	}
+	keys[h.TrustDomain.String()] = trustDomainKeys
+
+	for _, e := range update.Entries {
+		for trustDomainID, bundle := range e.Bundles {
+			certs, err := x509.ParseCertificates(bundle)
+			if err != nil {
+				return nil, fmt.Errorf("parse bundle for %v: %v", trustDomainID, err)
+			}
+			trustDomainKeys, err := jwtsvid.KeysFromCertificates(certs)
+			if err != nil {
+				return nil, err
+			}
+			keys[trustDomainID] = trustDomainKeys
+		}
+	}
+
+	return keys, nil
+}
+
+// attest attests the caller, returning its selectors
+func (h *Handler) attest(ctx context.Context, pid int32) []*common.Selector {
+	config := attestor.Config{
+		Catalog: h.Catalog,
+		L:       h.L,
+		T:       h.T,
+	}
+
+	return attestor.New(&config).Attest(ctx, pid)
+}
+
+// checkSecurityHeader makes sure the request carries the Workload API
+// security header, which keeps the API from being called by a browser
+func checkSecurityHeader(ctx context.Context) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md["workload.spiffe.io"]) != 1 || md["workload.spiffe.io"][0] != "true" {
+		return status.Errorf(codes.InvalidArgument, "Security header missing from request")
+	}
+	return nil
+}
+
+// claimsToStruct converts JWT claims into a protobuf Struct
+func claimsToStruct(claims map[string]interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(structpb.Struct)
+	if err := jsonpb.UnmarshalString(string(data), s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// keyStore holds JWT signing keys keyed by trust domain ID and key ID
+type keyStore map[string]map[string]crypto.PublicKey
+
+func (k keyStore) FindPublicKey(trustDomainID, keyID string) (crypto.PublicKey, error) {
+	keys, ok := k[trustDomainID]
+	if !ok {
+		return nil, fmt.Errorf("no keys found for trust domain %q", trustDomainID)
+	}
+	key, ok := keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("public key %q not found in trust domain %q", keyID, trustDomainID)
+	}
+	return key, nil
+}
+
 // callerPID takes a grpc context, and returns the PID of the caller which has issued
 // the request. Returns an error if the call was not made locally, if the necessary
 // syscalls aren't unsupported, or if the transport security was not properly configured.
@@ -148,3 +460,13 @@ func (h *Handler) callerPID(ctx context.Context) (pid int32, err error) {
 
 	return info.PID, nil
 }
+
+// trackConnection updates the number of open FetchX509SVID streams. It is
+// reported as a gauge rather than a counter, since it goes down as streams
+// end, and the gauge is set under the lock so that updates are not reordered.
+func (h *Handler) trackConnection(delta int) {
+	h.connMtx.Lock()
+	defer h.connMtx.Unlock()
+	h.connections += delta
+	h.T.SetGauge([]string{workloadApi, "connections"}, float32(h.connections))
+}
@@ -2,8 +2,14 @@ package workload
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +18,10 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/spiffe/spire/pkg/agent/auth"
+	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
 	"github.com/spiffe/spire/proto/api/workload"
@@ -53,10 +62,11 @@ func (s *HandlerTestSuite) SetupTest() {
 	catalog.SetWorkloadAttestors(s.attestor)
 
 	h := &Handler{
-		Manager: s.manager,
-		Catalog: catalog,
-		L:       log,
-		T:       telemetry.Blackhole{},
+		Manager:     s.manager,
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+		L:           log,
+		T:           telemetry.Blackhole{},
 	}
 
 	s.h = h
@@ -127,6 +137,219 @@ func (s *HandlerTestSuite) TestFetchX509SVID() {
 	}
 }
 
+func (s *HandlerTestSuite) TestTrackConnection() {
+	sink := &fakeGaugeSink{gauges: make(map[string]float32)}
+	s.h.T = sink
+
+	// The number of open streams goes up and down, so it is a gauge
+	s.h.trackConnection(1)
+	s.h.trackConnection(1)
+	s.Equal(float32(2), sink.gauges["workload_api.connections"])
+	s.h.trackConnection(-1)
+	s.Equal(float32(1), sink.gauges["workload_api.connections"])
+}
+
+func (s *HandlerTestSuite) TestFetchJWTSVID() {
+	audience := []string{"foo"}
+
+	// Without audience
+	_, err := s.h.FetchJWTSVID(s.callerContext(), &workload.JWTSVIDRequest{})
+	s.Require().EqualError(err, "rpc error: code = InvalidArgument desc = audience must be specified")
+
+	// Without the security header
+	_, err = s.h.FetchJWTSVID(context.Background(), &workload.JWTSVIDRequest{Audience: audience})
+	s.Require().EqualError(err, "rpc error: code = InvalidArgument desc = Security header missing from request")
+
+	// No identity issued
+	selectors := s.expectAttestation()
+	s.manager.EXPECT().MatchingEntries(selectors).Return(nil)
+	_, err = s.h.FetchJWTSVID(s.callerContext(), &workload.JWTSVIDRequest{Audience: audience})
+	s.Require().EqualError(err, "rpc error: code = PermissionDenied desc = no identity issued")
+
+	entries := []*cache.Entry{
+		{RegistrationEntry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/one"}},
+		{RegistrationEntry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/two"}},
+	}
+
+	// All identities
+	selectors = s.expectAttestation()
+	s.manager.EXPECT().MatchingEntries(selectors).Return(entries)
+	s.manager.EXPECT().FetchJWTSVID(gomock.Any(), "spiffe://example.org/one", audience).Return(&client.JWTSVID{Token: "one"}, nil)
+	s.manager.EXPECT().FetchJWTSVID(gomock.Any(), "spiffe://example.org/two", audience).Return(&client.JWTSVID{Token: "two"}, nil)
+	resp, err := s.h.FetchJWTSVID(s.callerContext(), &workload.JWTSVIDRequest{Audience: audience})
+	s.Require().NoError(err)
+	s.Require().Equal(&workload.JWTSVIDResponse{
+		Svids: []*workload.JWTSVID{
+			{SpiffeId: "spiffe://example.org/one", Svid: "one"},
+			{SpiffeId: "spiffe://example.org/two", Svid: "two"},
+		},
+	}, resp)
+
+	// A single identity
+	selectors = s.expectAttestation()
+	s.manager.EXPECT().MatchingEntries(selectors).Return(entries)
+	s.manager.EXPECT().FetchJWTSVID(gomock.Any(), "spiffe://example.org/two", audience).Return(&client.JWTSVID{Token: "two"}, nil)
+	resp, err = s.h.FetchJWTSVID(s.callerContext(), &workload.JWTSVIDRequest{
+		Audience: audience,
+		SpiffeId: "spiffe://example.org/two",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&workload.JWTSVIDResponse{
+		Svids: []*workload.JWTSVID{
+			{SpiffeId: "spiffe://example.org/two", Svid: "two"},
+		},
+	}, resp)
+
+	// The server cannot be reached
+	selectors = s.expectAttestation()
+	s.manager.EXPECT().MatchingEntries(selectors).Return(entries[:1])
+	s.manager.EXPECT().FetchJWTSVID(gomock.Any(), "spiffe://example.org/one", audience).Return(nil, errors.New("oh no"))
+	_, err = s.h.FetchJWTSVID(s.callerContext(), &workload.JWTSVIDRequest{Audience: audience})
+	s.Require().EqualError(err, `rpc error: code = Unavailable desc = could not fetch "spiffe://example.org/one" JWT-SVID: oh no`)
+}
+
+func (s *HandlerTestSuite) TestValidateJWTSVID() {
+	ca, caKey, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	keyID, err := jwtsvid.KeyID(ca.PublicKey)
+	s.Require().NoError(err)
+	token, err := jwtsvid.SignToken("spiffe://example.org/foo", []string{"audience"}, time.Now().Add(time.Minute), caKey, keyID)
+	s.Require().NoError(err)
+
+	// Without audience or token
+	_, err = s.h.ValidateJWTSVID(s.callerContext(), &workload.ValidateJWTSVIDRequest{Svid: token})
+	s.Require().EqualError(err, "rpc error: code = InvalidArgument desc = audience must be specified")
+	_, err = s.h.ValidateJWTSVID(s.callerContext(), &workload.ValidateJWTSVIDRequest{Audience: "audience"})
+	s.Require().EqualError(err, "rpc error: code = InvalidArgument desc = svid must be specified")
+
+	s.expectSubscription(&cache.WorkloadUpdate{Bundle: []*x509.Certificate{ca}})
+	resp, err := s.h.ValidateJWTSVID(s.callerContext(), &workload.ValidateJWTSVIDRequest{
+		Audience: "audience",
+		Svid:     token,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/foo", resp.SpiffeId)
+	s.Require().Equal("spiffe://example.org/foo", resp.Claims.Fields["sub"].GetStringValue())
+
+	// Wrong audience
+	s.expectSubscription(&cache.WorkloadUpdate{Bundle: []*x509.Certificate{ca}})
+	_, err = s.h.ValidateJWTSVID(s.callerContext(), &workload.ValidateJWTSVIDRequest{
+		Audience: "other",
+		Svid:     token,
+	})
+	s.Require().EqualError(err, `rpc error: code = InvalidArgument desc = expected audience "other"`)
+}
+
+func (s *HandlerTestSuite) TestComposeJWTBundlesResponse() {
+	ca, _, err := util.LoadCAFixture()
+	s.Require().NoError(err)
+	jwtSigningKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	template, err := util.NewCATemplate("otherdomain.org")
+	s.Require().NoError(err)
+	federated, _, err := util.SelfSign(template)
+	s.Require().NoError(err)
+
+	resp, err := s.h.composeJWTBundlesResponse(&cache.WorkloadUpdate{
+		Entries: []*cache.Entry{{
+			RegistrationEntry: &common.RegistrationEntry{SpiffeId: "spiffe://example.org/foo"},
+			Bundles:           map[string][]byte{"spiffe://otherdomain.org": federated.Raw},
+		}},
+		Bundle:         []*x509.Certificate{ca},
+		JWTSigningKeys: map[string]crypto.PublicKey{"kid": jwtSigningKey.Public()},
+	})
+	s.Require().NoError(err)
+
+	expected := make(map[string][]byte)
+	for trustDomainID, cert := range map[string]*x509.Certificate{
+		"spiffe://example.org":     ca,
+		"spiffe://otherdomain.org": federated,
+	} {
+		keys, err := jwtsvid.KeysFromCertificates([]*x509.Certificate{cert})
+		s.Require().NoError(err)
+		// The JWT signing keys published in the bundle are served along
+		// with the keys of the CA certificates of the trust domain
+		if trustDomainID == "spiffe://example.org" {
+			keys["kid"] = jwtSigningKey.Public()
+		}
+		expected[trustDomainID], err = bundleutil.Marshal(&bundleutil.Bundle{JWTSigningKeys: keys})
+		s.Require().NoError(err)
+	}
+	s.Require().Equal(expected, resp.Bundles)
+}
+
+func (s *HandlerTestSuite) TestFetchX509Bundles() {
+	stream := mock_workload.NewMockSpiffeWorkloadAPI_FetchX509BundlesServer(s.ctrl)
+
+	// Without the security header
+	stream.EXPECT().Context().Return(context.Background())
+	err := s.h.FetchX509Bundles(nil, stream)
+	s.Require().EqualError(err, "rpc error: code = InvalidArgument desc = Security header missing from request")
+
+	// Without any identity issued
+	stream.EXPECT().Context().Return(s.callerContext())
+	s.expectSubscription(new(cache.WorkloadUpdate))
+	err = s.h.FetchX509Bundles(nil, stream)
+	s.Require().EqualError(err, "rpc error: code = PermissionDenied desc = no identity issued")
+
+	// The bundles are only sent again when they change
+	ctx, cancel := context.WithCancel(s.callerContext())
+	defer cancel()
+	stream.EXPECT().Context().Return(ctx)
+	selectors := s.expectAttestation()
+	updates := make(chan *cache.WorkloadUpdate, 3)
+	updates <- s.workloadUpdate()
+	updates <- s.workloadUpdate()
+	changed := s.workloadUpdate()
+	changed.Entries[0].Bundles["spiffe://otherdomain.org"] = []byte{4, 5, 6}
+	updates <- changed
+	subscriber := mock_cache.NewMockSubscriber(s.ctrl)
+	subscriber.EXPECT().Updates().Return(updates).AnyTimes()
+	subscriber.EXPECT().Finish()
+	s.manager.EXPECT().SubscribeToCacheChanges(cache.Selectors(selectors)).Return(subscriber)
+
+	sent := make(chan *workload.X509BundlesResponse, 3)
+	stream.EXPECT().Send(gomock.Any()).Do(func(resp *workload.X509BundlesResponse) {
+		sent <- resp
+	}).Return(nil).Times(2)
+
+	result := make(chan error)
+	go func() { result <- s.h.FetchX509Bundles(nil, stream) }()
+
+	for _, expected := range []byte{1, 4} {
+		select {
+		case resp := <-sent:
+			s.Require().Equal(expected, resp.Bundles["spiffe://otherdomain.org"][0])
+		case <-time.After(time.Second):
+			s.FailNow("timed out waiting for the bundles")
+		}
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		s.NoError(err)
+	case <-time.After(time.Second):
+		s.Fail("workload handler hung, shutdown timer exceeded")
+	}
+}
+
+func (s *HandlerTestSuite) TestComposeX509BundlesResponse() {
+	update := s.workloadUpdate()
+
+	resp := s.h.composeX509BundlesResponse(update)
+	s.Equal(&workload.X509BundlesResponse{
+		Bundles: map[string][]byte{
+			"spiffe://example.org":     update.Bundle[0].Raw,
+			"spiffe://otherdomain.org": {1, 2, 3},
+		},
+	}, resp)
+
+	update.CRL = []byte("CRL")
+	resp = s.h.composeX509BundlesResponse(update)
+	s.Equal([][]byte{[]byte("CRL")}, resp.Crl)
+}
+
 func (s *HandlerTestSuite) TestSendResponse() {
 	emptyUpdate := new(cache.WorkloadUpdate)
 	s.stream.EXPECT().Send(gomock.Any()).Times(0)
@@ -153,11 +376,21 @@ func (s *HandlerTestSuite) TestComposeResponse() {
 	}
 	apiMsg := &workload.X509SVIDResponse{
 		Svids: []*workload.X509SVID{svidMsg},
+		FederatedBundles: map[string][]byte{
+			"spiffe://otherdomain.org": {1, 2, 3},
+		},
 	}
 
 	resp, err := s.h.composeResponse(s.workloadUpdate())
 	s.Assert().NoError(err)
 	s.Assert().Equal(apiMsg, resp)
+
+	// The CRL of the server CA is sent along with the SVIDs
+	update.CRL = []byte("CRL")
+	apiMsg.Crl = [][]byte{[]byte("CRL")}
+	resp, err = s.h.composeResponse(update)
+	s.Assert().NoError(err)
+	s.Assert().Equal(apiMsg, resp)
 }
 
 func (s *HandlerTestSuite) TestCallerPID() {
@@ -190,6 +423,29 @@ func (s *HandlerTestSuite) TestCallerPID() {
 	s.Assert().Error(err)
 }
 
+func (s *HandlerTestSuite) callerContext() context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.CallerInfo{PID: 1},
+	})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+}
+
+func (s *HandlerTestSuite) expectAttestation() []*common.Selector {
+	selectors := []*common.Selector{{Type: "foo", Value: "bar"}}
+	s.attestor.EXPECT().Attest(gomock.Any(), &workloadattestor.AttestRequest{Pid: int32(1)}).Return(&workloadattestor.AttestResponse{Selectors: selectors}, nil)
+	return selectors
+}
+
+func (s *HandlerTestSuite) expectSubscription(update *cache.WorkloadUpdate) {
+	selectors := s.expectAttestation()
+	updates := make(chan *cache.WorkloadUpdate, 1)
+	updates <- update
+	subscriber := mock_cache.NewMockSubscriber(s.ctrl)
+	subscriber.EXPECT().Updates().Return(updates).AnyTimes()
+	subscriber.EXPECT().Finish()
+	s.manager.EXPECT().SubscribeToCacheChanges(cache.Selectors(selectors)).Return(subscriber)
+}
+
 func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
 	svid, key, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
@@ -200,7 +456,11 @@ func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
 		SVID:       svid,
 		PrivateKey: key,
 		RegistrationEntry: &common.RegistrationEntry{
-			SpiffeId: "spiffe://example.org/foo",
+			SpiffeId:      "spiffe://example.org/foo",
+			FederatesWith: []string{"spiffe://otherdomain.org"},
+		},
+		Bundles: map[string][]byte{
+			"spiffe://otherdomain.org": {1, 2, 3},
 		},
 	}
 	update := &cache.WorkloadUpdate{
@@ -210,3 +470,12 @@ func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
 
 	return update
 }
+
+type fakeGaugeSink struct {
+	telemetry.Blackhole
+	gauges map[string]float32
+}
+
+func (s *fakeGaugeSink) SetGauge(key []string, val float32) {
+	s.gauges[strings.Join(key, ".")] = val
+}
@@ -0,0 +1,171 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/agent/manager"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	common "github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/proto/api/workload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultBindAddress is the default address the health checks are
+	// served on
+	DefaultBindAddress = "localhost:8080"
+
+	// DefaultMaxMissedSyncs is the default number of sync intervals the
+	// agent may go without reaching the server before it is not ready
+	DefaultMaxMissedSyncs = 3
+)
+
+// Config configures the health check endpoint
+type Config struct {
+	// If true, the health checks are served over HTTP
+	Enabled bool
+
+	// Address to serve the health checks on. Defaults to
+	// DefaultBindAddress.
+	BindAddress string
+
+	// Number of sync intervals the agent may go without reaching the server
+	// before it is not ready. Defaults to DefaultMaxMissedSyncs.
+	MaxMissedSyncs int
+}
+
+// Checker serves the liveness and readiness of the agent. The agent is live
+// while its SVID is valid, and ready while it is live, has recently reached
+// the server and its Workload API answers.
+type Checker struct {
+	Config       Config
+	Manager      manager.Manager
+	SyncInterval time.Duration
+	Log          logrus.FieldLogger
+
+	// Address the Workload API is served on. The Workload API is not
+	// checked if nil.
+	WorkloadAPIAddr net.Addr
+}
+
+// ListenAndServe serves the health checks until the context is cancelled
+func (c *Checker) ListenAndServe(ctx context.Context) error {
+	return common.ListenAndServe(ctx, c.bindAddress(), c.Handler(), c.Log)
+}
+
+// Handler returns the HTTP handler serving the health checks
+func (c *Checker) Handler() http.Handler {
+	return common.Handler(c, c.Log)
+}
+
+// ReportHandler returns the HTTP handler serving the health report as JSON
+func (c *Checker) ReportHandler() http.Handler {
+	return common.ReportHandler(c, c.Log)
+}
+
+// Report checks the health of the components of the agent the given check
+// depends on
+func (c *Checker) Report(ctx context.Context, check string) (*common.Report, error) {
+	return common.MakeReport(ctx, c, check)
+}
+
+// Components returns the components of the agent the given check depends on
+func (c *Checker) Components(check string) ([]common.Component, error) {
+	switch check {
+	case common.CheckShallow:
+		return nil, nil
+	case common.CheckLive:
+		return []common.Component{{Name: "svid", Check: c.checkSVID}}, nil
+	case common.CheckReady:
+		components := []common.Component{
+			{Name: "svid", Check: c.checkSVID},
+			{Name: "sync", Check: c.checkSync},
+		}
+		if c.WorkloadAPIAddr != nil {
+			components = append(components, common.Component{Name: "workload_api", Check: c.checkWorkloadAPI})
+		}
+		return components, nil
+	default:
+		return nil, fmt.Errorf("unknown check %q", check)
+	}
+}
+
+func (c *Checker) checkSVID(context.Context) error {
+	state, ok := c.Manager.SubscribeToSVIDChanges().Value().(svid.State)
+	if !ok || state.SVID == nil {
+		return errors.New("agent is not attested")
+	}
+	return checkSVIDCert(state.SVID, time.Now())
+}
+
+func (c *Checker) checkSync(context.Context) error {
+	lastSync := c.Manager.LastSync()
+	if lastSync.IsZero() {
+		return errors.New("agent has not synchronized with the server yet")
+	}
+	maxAge := c.syncInterval() * time.Duration(c.maxMissedSyncs())
+	if age := time.Since(lastSync); age > maxAge {
+		return fmt.Errorf("agent last synchronized with the server %v ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+// checkWorkloadAPI calls the Workload API, for a request the agent rejects
+// before attesting the caller. Getting that answer back shows the API is
+// served, rather than only that its socket accepts connections.
+func (c *Checker) checkWorkloadAPI(ctx context.Context) error {
+	addr := c.WorkloadAPIAddr
+	conn, err := grpc.DialContext(ctx, addr.String(), grpc.WithInsecure(), grpc.WithDialer(
+		func(_ string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout(addr.Network(), addr.String(), timeout)
+		}))
+	if err != nil {
+		return fmt.Errorf("workload API is unavailable: %v", err)
+	}
+	defer conn.Close()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "workload.spiffe.io", "true")
+	_, err = workload.NewSpiffeWorkloadAPIClient(conn).ValidateJWTSVID(ctx, &workload.ValidateJWTSVIDRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		return fmt.Errorf("workload API is unavailable: %v", err)
+	}
+	return nil
+}
+
+func (c *Checker) bindAddress() string {
+	if c.Config.BindAddress != "" {
+		return c.Config.BindAddress
+	}
+	return DefaultBindAddress
+}
+
+func (c *Checker) maxMissedSyncs() int {
+	if c.Config.MaxMissedSyncs > 0 {
+		return c.Config.MaxMissedSyncs
+	}
+	return DefaultMaxMissedSyncs
+}
+
+func (c *Checker) syncInterval() time.Duration {
+	if c.SyncInterval > 0 {
+		return c.SyncInterval
+	}
+	return manager.DefaultSyncInterval
+}
+
+func checkSVIDCert(cert *x509.Certificate, now time.Time) error {
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("agent SVID expired at %v", cert.NotAfter)
+	}
+	return nil
+}
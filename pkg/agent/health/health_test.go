@@ -0,0 +1,223 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/imkira/go-observer"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/endpoints/workload"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	common "github.com/spiffe/spire/pkg/common/health"
+	workload_pb "github.com/spiffe/spire/proto/api/workload"
+	"github.com/spiffe/spire/test/mock/agent/manager"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+)
+
+var ctx = context.Background()
+
+type CheckerTestSuite struct {
+	suite.Suite
+
+	ctrl    *gomock.Controller
+	manager *mock_manager.MockManager
+	c       *Checker
+}
+
+func TestChecker(t *testing.T) {
+	suite.Run(t, new(CheckerTestSuite))
+}
+
+func (s *CheckerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.manager = mock_manager.NewMockManager(s.ctrl)
+
+	log, _ := test.NewNullLogger()
+	s.c = &Checker{
+		Manager:      s.manager,
+		SyncInterval: time.Minute,
+		Log:          log,
+	}
+}
+
+func (s *CheckerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *CheckerTestSuite) TestLive() {
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.Require().NoError(s.check(common.CheckLive))
+
+	s.expectSVID(time.Now().Add(-time.Minute))
+	s.Require().Error(s.check(common.CheckLive))
+}
+
+func (s *CheckerTestSuite) TestReady() {
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Now().Add(-2 * time.Minute))
+	s.Require().NoError(s.check(common.CheckReady))
+
+	// the server has not been reached for more than three sync intervals
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Now().Add(-4 * time.Minute))
+	s.Require().Error(s.check(common.CheckReady))
+
+	s.c.Config.MaxMissedSyncs = 5
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Now().Add(-4 * time.Minute))
+	s.Require().NoError(s.check(common.CheckReady))
+
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Time{})
+	s.Require().EqualError(s.check(common.CheckReady), "agent has not synchronized with the server yet")
+}
+
+func (s *CheckerTestSuite) TestReadyChecksWorkloadAPI() {
+	addr, stop := s.serveWorkloadAPI(true)
+	s.c.WorkloadAPIAddr = addr
+
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Now())
+	s.Require().NoError(s.check(common.CheckReady))
+
+	// The socket accepts connections, but the Workload API is not served
+	// on it
+	otherAddr, stopOther := s.serveWorkloadAPI(false)
+	defer stopOther()
+	s.c.WorkloadAPIAddr = otherAddr
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Now())
+	s.Require().Error(s.check(common.CheckReady))
+
+	stop()
+	s.c.WorkloadAPIAddr = addr
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Now())
+	s.Require().Error(s.check(common.CheckReady))
+}
+
+func (s *CheckerTestSuite) TestReport() {
+	report, err := s.c.Report(ctx, common.CheckShallow)
+	s.Require().NoError(err)
+	s.Require().Equal(&common.Report{Healthy: true}, report)
+
+	s.expectSVID(time.Now().Add(time.Hour))
+	report, err = s.c.Report(ctx, common.CheckLive)
+	s.Require().NoError(err)
+	s.Require().Equal(&common.Report{
+		Healthy:    true,
+		Components: []common.ComponentHealth{{Name: "svid", Healthy: true}},
+	}, report)
+
+	// Every component is checked, even after one is found unhealthy
+	addr, stop := s.serveWorkloadAPI(true)
+	defer stop()
+	s.c.WorkloadAPIAddr = addr
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Time{})
+	report, err = s.c.Report(ctx, common.CheckReady)
+	s.Require().NoError(err)
+	s.Require().Equal(&common.Report{
+		Healthy: false,
+		Components: []common.ComponentHealth{
+			{Name: "svid", Healthy: true},
+			{Name: "sync", Error: "agent has not synchronized with the server yet"},
+			{Name: "workload_api", Healthy: true},
+		},
+	}, report)
+
+	_, err = s.c.Report(ctx, "deep")
+	s.Require().EqualError(err, `unknown check "deep"`)
+}
+
+func (s *CheckerTestSuite) TestReportHandler() {
+	server := httptest.NewServer(s.c.Handler())
+	defer server.Close()
+
+	s.expectSVID(time.Now().Add(-time.Minute))
+	resp, err := http.Get(server.URL + common.ReportPath)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	report := new(common.Report)
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(report))
+	s.Require().False(report.Healthy)
+	s.Require().Len(report.Components, 1)
+	s.Require().Contains(report.Components[0].Error, "agent SVID expired")
+
+	resp, err = http.Get(server.URL + common.ReportPath + "?check=shallow")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + common.ReportPath + "?check=deep")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (s *CheckerTestSuite) TestHandler() {
+	s.expectSVID(time.Now().Add(time.Hour))
+	s.manager.EXPECT().LastSync().Return(time.Time{})
+
+	server := httptest.NewServer(s.c.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + common.LivePath)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	s.expectSVID(time.Now().Add(time.Hour))
+	resp, err = http.Get(server.URL + common.ReadyPath)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func (s *CheckerTestSuite) expectSVID(notAfter time.Time) {
+	template, err := util.NewSVIDTemplate("spiffe://example.org/spire/agent/test")
+	s.Require().NoError(err)
+	template.NotBefore = notAfter.Add(-2 * time.Hour)
+	template.NotAfter = notAfter
+	cert, _, err := util.SelfSign(template)
+	s.Require().NoError(err)
+
+	state := observer.NewProperty(svid.State{SVID: cert})
+	s.manager.EXPECT().SubscribeToSVIDChanges().Return(state.Observe())
+}
+
+// check checks the components the given check depends on, returning the
+// error of the first unhealthy one as the liveness and readiness endpoints do
+func (s *CheckerTestSuite) check(check string) error {
+	components, err := s.c.Components(check)
+	s.Require().NoError(err)
+	for _, comp := range components {
+		if err := comp.Check(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serveWorkloadAPI serves a gRPC server, with the Workload API registered on
+// it or not, returning its address and a function stopping it
+func (s *CheckerTestSuite) serveWorkloadAPI(register bool) (net.Addr, func()) {
+	l, err := net.Listen("tcp", "localhost:0")
+	s.Require().NoError(err)
+
+	server := grpc.NewServer()
+	if register {
+		workload_pb.RegisterSpiffeWorkloadAPIServer(server, &workload.Handler{})
+	}
+	go server.Serve(l)
+	return l.Addr(), server.Stop
+}
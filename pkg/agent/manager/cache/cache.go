@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"sync"
@@ -19,12 +20,25 @@ type Entry struct {
 	SVID              *x509.Certificate
 	PrivateKey        *ecdsa.PrivateKey
 
+	// Intermediates chain the SVID up to the trust bundle. There are none
+	// unless the server CA is an intermediate of an upstream CA.
+	Intermediates []*x509.Certificate
+
 	// Bundles stores the ID => Bundle map for
 	// federated bundles. The registration entry
 	// only stores references to the keys here.
 	Bundles map[string][]byte
 }
 
+// SVIDChain returns the SVID followed by its intermediates, DER encoded
+func (e *Entry) SVIDChain() []byte {
+	chain := append([]byte(nil), e.SVID.Raw...)
+	for _, intermediate := range e.Intermediates {
+		chain = append(chain, intermediate.Raw...)
+	}
+	return chain
+}
+
 type Cache interface {
 	// Entry gets the cache entry for the specified RegistrationEntry.
 	Entry(regEntry *common.RegistrationEntry) *Entry
@@ -39,6 +53,9 @@ type Cache interface {
 	IsEmpty() bool
 	// Registers and returns a Subscriber, and then sends latest WorkloadUpdate on its channel
 	Subscribe(selectors Selectors) Subscriber
+	// HasSubscribers returns true if an active subscriber would be sent the
+	// entries registered for the selectors.
+	HasSubscribers(selectors Selectors) bool
 	// Set the bundle
 	SetBundle([]*x509.Certificate)
 	// Retrieve the bundle
@@ -46,6 +63,14 @@ type Cache interface {
 	// SubscribeToBundleChanges returns a new observer.Stream of []*x509.Certificate instances. Each
 	// time the bundle is updated, a new instance is streamed.
 	SubscribeToBundleChanges() observer.Stream
+	// Set the JWT signing keys published in the bundle, keyed by key ID
+	SetJWTSigningKeys(map[string]crypto.PublicKey)
+	// Retrieve the JWT signing keys
+	JWTSigningKeys() map[string]crypto.PublicKey
+	// Set the CRL of the server CA, ASN.1 DER encoded
+	SetCRL([]byte)
+	// Retrieve the CRL of the server CA
+	CRL() []byte
 }
 
 type cacheImpl struct {
@@ -56,6 +81,12 @@ type cacheImpl struct {
 	subscribers *subscribers
 	bundle      observer.Property
 	notifyMutex sync.Mutex
+
+	// JWT signing keys published in the bundle, guarded by m
+	jwtSigningKeys map[string]crypto.PublicKey
+
+	// CRL of the server CA, guarded by m
+	crl []byte
 }
 
 // New creates a new Cache.
@@ -82,6 +113,36 @@ func (c *cacheImpl) SubscribeToBundleChanges() observer.Stream {
 	return c.bundle.Observe()
 }
 
+func (c *cacheImpl) SetJWTSigningKeys(keys map[string]crypto.PublicKey) {
+	c.m.Lock()
+	c.jwtSigningKeys = keys
+	c.m.Unlock()
+
+	subs := c.subscribers.getAll()
+	c.notifySubscribers(subs)
+}
+
+func (c *cacheImpl) JWTSigningKeys() map[string]crypto.PublicKey {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.jwtSigningKeys
+}
+
+func (c *cacheImpl) SetCRL(crl []byte) {
+	c.m.Lock()
+	c.crl = crl
+	c.m.Unlock()
+
+	subs := c.subscribers.getAll()
+	c.notifySubscribers(subs)
+}
+
+func (c *cacheImpl) CRL() []byte {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.crl
+}
+
 func (c *cacheImpl) Entries() []*Entry {
 	c.m.Lock()
 	defer c.m.Unlock()
@@ -105,6 +166,19 @@ func (c *cacheImpl) Subscribe(selectors Selectors) Subscriber {
 	return sub
 }
 
+func (c *cacheImpl) HasSubscribers(selectors Selectors) bool {
+	regEntrySelectors := selector.NewSetFromRaw(selectors)
+	for _, sub := range c.subscribers.getAll() {
+		sub.m.Lock()
+		matches := sub.active && selector.NewSetFromRaw(sub.sel).IncludesSet(regEntrySelectors)
+		sub.m.Unlock()
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *cacheImpl) Entry(regEntry *common.RegistrationEntry) *Entry {
 	c.m.Lock()
 	defer c.m.Unlock()
@@ -133,6 +207,8 @@ func (c *cacheImpl) notifySubscribers(subs []*subscriber) {
 
 	entries := c.Entries()
 	bundle := c.Bundle()
+	jwtSigningKeys := c.JWTSigningKeys()
+	crl := c.CRL()
 	for _, sub := range subs {
 		sub.m.Lock()
 		// If subscriber is not active any more, remove it.
@@ -150,7 +226,7 @@ func (c *cacheImpl) notifySubscribers(subs []*subscriber) {
 		}
 
 		subEntries := subscriberEntries(sub, entries)
-		sub.c <- &WorkloadUpdate{Entries: subEntries, Bundle: bundle}
+		sub.c <- &WorkloadUpdate{Entries: subEntries, Bundle: bundle, JWTSigningKeys: jwtSigningKeys, CRL: crl}
 		sub.m.Unlock()
 	}
 }
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -332,3 +333,61 @@ func TestSubscriberFinish(t *testing.T) {
 		assert.Nil(t, wu)
 	})
 }
+
+func TestSetJWTSigningKeysNotifiesSubscribers(t *testing.T) {
+	cache := New(logger, nil)
+
+	sub := cache.Subscribe(Selectors{&common.Selector{Type: "unix", Value: "uid:1111"}})
+	defer sub.Finish()
+
+	// Comsume the update sent by Subscribe function.
+	wu := <-sub.Updates()
+	assert.Nil(t, wu.JWTSigningKeys)
+
+	keys := map[string]crypto.PublicKey{"kid": privateKey.Public()}
+	cache.SetJWTSigningKeys(keys)
+	assert.Equal(t, keys, cache.JWTSigningKeys())
+
+	util.RunWithTimeout(t, 5*time.Second, func() {
+		wu := <-sub.Updates()
+		assert.Equal(t, keys, wu.JWTSigningKeys)
+	})
+}
+
+func TestSetCRLNotifiesSubscribers(t *testing.T) {
+	cache := New(logger, nil)
+
+	sub := cache.Subscribe(Selectors{&common.Selector{Type: "unix", Value: "uid:1111"}})
+	defer sub.Finish()
+
+	// Comsume the update sent by Subscribe function.
+	wu := <-sub.Updates()
+	assert.Nil(t, wu.CRL)
+
+	cache.SetCRL([]byte("CRL"))
+	assert.Equal(t, []byte("CRL"), cache.CRL())
+
+	util.RunWithTimeout(t, 5*time.Second, func() {
+		wu := <-sub.Updates()
+		assert.Equal(t, []byte("CRL"), wu.CRL)
+	})
+}
+
+func TestHasSubscribers(t *testing.T) {
+	cache := New(logger, nil)
+
+	uid := Selectors{&common.Selector{Type: "unix", Value: "uid:1111"}}
+	uidAndGid := Selectors{
+		&common.Selector{Type: "unix", Value: "uid:1111"},
+		&common.Selector{Type: "unix", Value: "gid:2222"},
+	}
+
+	assert.False(t, cache.HasSubscribers(uid))
+
+	sub := cache.Subscribe(uid)
+	assert.True(t, cache.HasSubscribers(uid))
+	assert.False(t, cache.HasSubscribers(uidAndGid))
+
+	sub.Finish()
+	assert.False(t, cache.HasSubscribers(uid))
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spiffe/spire/pkg/agent/client"
+)
+
+// JWTSVIDEntry is a cached JWT-SVID along with the SPIFFE ID and audience it
+// was requested for
+type JWTSVIDEntry struct {
+	SpiffeID string
+	Audience []string
+	SVID     *client.JWTSVID
+}
+
+// JWTSVIDCache caches JWT-SVIDs keyed by SPIFFE ID and audience. The order of
+// the audience values does not matter.
+type JWTSVIDCache struct {
+	mtx   sync.Mutex
+	svids map[string]*JWTSVIDEntry
+}
+
+// NewJWTSVIDCache creates a new, empty JWTSVIDCache
+func NewJWTSVIDCache() *JWTSVIDCache {
+	return &JWTSVIDCache{
+		svids: make(map[string]*JWTSVIDEntry),
+	}
+}
+
+// GetJWTSVID returns the cached JWT-SVID for the SPIFFE ID and audience
+func (c *JWTSVIDCache) GetJWTSVID(spiffeID string, audience []string) (*client.JWTSVID, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	entry, ok := c.svids[jwtSVIDKey(spiffeID, audience)]
+	if !ok {
+		return nil, false
+	}
+	return entry.SVID, true
+}
+
+// SetJWTSVID caches the JWT-SVID for the SPIFFE ID and audience, replacing
+// any previous one
+func (c *JWTSVIDCache) SetJWTSVID(spiffeID string, audience []string, svid *client.JWTSVID) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.svids[jwtSVIDKey(spiffeID, audience)] = &JWTSVIDEntry{
+		SpiffeID: spiffeID,
+		Audience: append([]string(nil), audience...),
+		SVID:     svid,
+	}
+}
+
+// DeleteJWTSVID removes the cached JWT-SVID for the SPIFFE ID and audience
+func (c *JWTSVIDCache) DeleteJWTSVID(spiffeID string, audience []string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.svids, jwtSVIDKey(spiffeID, audience))
+}
+
+// Entries returns all the cached JWT-SVIDs
+func (c *JWTSVIDCache) Entries() []JWTSVIDEntry {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	entries := make([]JWTSVIDEntry, 0, len(c.svids))
+	for _, entry := range c.svids {
+		entries = append(entries, *entry)
+	}
+	return entries
+}
+
+func jwtSVIDKey(spiffeID string, audience []string) string {
+	audience = append([]string(nil), audience...)
+	sort.Strings(audience)
+	// NUL cannot appear in a SPIFFE ID or an audience value, so it makes
+	// for an unambiguous separator
+	return spiffeID + "\x00" + strings.Join(audience, "\x00")
+}
@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTSVIDCache(t *testing.T) {
+	c := NewJWTSVIDCache()
+
+	svid := &client.JWTSVID{Token: "token", ExpiresAt: time.Now().Add(time.Minute)}
+	c.SetJWTSVID("spiffe://example.org/foo", []string{"a", "b"}, svid)
+
+	// audience order does not matter
+	actual, ok := c.GetJWTSVID("spiffe://example.org/foo", []string{"b", "a"})
+	assert.True(t, ok)
+	assert.Equal(t, svid, actual)
+
+	_, ok = c.GetJWTSVID("spiffe://example.org/foo", []string{"a"})
+	assert.False(t, ok)
+	_, ok = c.GetJWTSVID("spiffe://example.org/bar", []string{"a", "b"})
+	assert.False(t, ok)
+
+	assert.Equal(t, []JWTSVIDEntry{{
+		SpiffeID: "spiffe://example.org/foo",
+		Audience: []string{"a", "b"},
+		SVID:     svid,
+	}}, c.Entries())
+
+	c.DeleteJWTSVID("spiffe://example.org/foo", []string{"a", "b"})
+	_, ok = c.GetJWTSVID("spiffe://example.org/foo", []string{"a", "b"})
+	assert.False(t, ok)
+	assert.Empty(t, c.Entries())
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"crypto"
 	"crypto/x509"
 	"sync"
 
@@ -16,6 +17,13 @@ type Subscriber interface {
 type WorkloadUpdate struct {
 	Entries []*Entry
 	Bundle  []*x509.Certificate
+
+	// JWTSigningKeys holds the JWT signing keys published in the bundle,
+	// keyed by key ID.
+	JWTSigningKeys map[string]crypto.PublicKey
+
+	// CRL holds the CRL of the server CA, ASN.1 DER encoded, if any.
+	CRL []byte
 }
 
 type subscriber struct {
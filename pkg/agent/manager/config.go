@@ -1,6 +1,7 @@
 package manager
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"fmt"
@@ -16,6 +17,14 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 )
 
+// DefaultSyncInterval is how often the manager synchronizes with the server
+// unless configured otherwise
+const DefaultSyncInterval = 5 * time.Second
+
+// DefaultExpiringSVIDThreshold is how close to their expiry workload SVIDs
+// are reported as expiring soon unless configured otherwise
+const DefaultExpiringSVIDThreshold = 10 * time.Minute
+
 // Config holds a cache manager configuration
 type Config struct {
 	// Agent SVID and key resulting from successful attestation.
@@ -31,6 +40,45 @@ type Config struct {
 	BundleCachePath  string
 	SyncInterval     time.Duration
 	RotationInterval time.Duration
+
+	// SVIDIntermediates are presented along with the agent SVID when the CA
+	// of the server is an intermediate of the upstream CA.
+	SVIDIntermediates []*x509.Certificate
+
+	// RotationThreshold is the percentage of the lifetime remaining at which
+	// workload SVIDs, JWT-SVIDs and the agent SVID are renewed. Defaults to
+	// svid.DefaultRotationThreshold.
+	RotationThreshold int
+
+	// EntryCachePath and EntryCacheKeyPath locate the encrypted cache of
+	// workload SVIDs and keys, and the key encrypting it, which is provisioned
+	// separately. Entries are not cached on disk if EntryCachePath is empty.
+	EntryCachePath    string
+	EntryCacheKeyPath string
+
+	// LazySVIDMinting only requests the SVIDs of the entries of workloads
+	// that have connected, instead of requesting the SVIDs of every entry
+	// assigned to the agent. SVIDs no workload is subscribed to are dropped
+	// instead of rotated.
+	LazySVIDMinting bool
+
+	// WatchUpdates has the server notify the manager of the changes of the
+	// entries of the agent and of the bundle, on which it synchronizes right
+	// away instead of on the next sync interval.
+	WatchUpdates bool
+
+	// MaxSVIDStaleness is how long workload SVIDs and JWT-SVIDs that could
+	// not be renewed keep being served past their rotation time. They are
+	// never served past their expiry. Zero serves them until they expire.
+	MaxSVIDStaleness time.Duration
+
+	// ExpiringSVIDThreshold is how close to their expiry workload SVIDs are
+	// reported as expiring soon. Defaults to DefaultExpiringSVIDThreshold.
+	ExpiringSVIDThreshold time.Duration
+
+	// Reattest performs node attestation again when the agent SVID could
+	// not be rotated before it expires. See svid.RotatorConfig.
+	Reattest func(ctx context.Context) (svid.State, error)
 }
 
 // New creates a cache manager based on c's configuration
@@ -41,24 +89,40 @@ func New(c *Config) (*manager, error) {
 	}
 
 	if c.SyncInterval == 0 {
-		c.SyncInterval = 5 * time.Second
+		c.SyncInterval = DefaultSyncInterval
 	}
 
 	if c.RotationInterval == 0 {
 		c.RotationInterval = 60 * time.Second
 	}
 
+	if c.RotationThreshold == 0 {
+		c.RotationThreshold = svid.DefaultRotationThreshold
+	}
+
+	if c.ExpiringSVIDThreshold == 0 {
+		c.ExpiringSVIDThreshold = DefaultExpiringSVIDThreshold
+	}
+
+	if c.Tel == nil {
+		c.Tel = telemetry.Blackhole{}
+	}
+
+	jwtSVIDs := cache.NewJWTSVIDCache()
 	cache := cache.New(c.Log, c.Bundle)
 
 	rotCfg := &svid.RotatorConfig{
-		Log:          c.Log,
-		SVID:         c.SVID,
-		SVIDKey:      c.SVIDKey,
-		SpiffeID:     spiffeID,
-		BundleStream: cache.SubscribeToBundleChanges(),
-		ServerAddr:   c.ServerAddr,
-		TrustDomain:  c.TrustDomain,
-		Interval:     c.RotationInterval,
+		Log:               c.Log,
+		SVID:              c.SVID,
+		SVIDKey:           c.SVIDKey,
+		SVIDIntermediates: c.SVIDIntermediates,
+		SpiffeID:          spiffeID,
+		BundleStream:      cache.SubscribeToBundleChanges(),
+		ServerAddr:        c.ServerAddr,
+		TrustDomain:       c.TrustDomain,
+		Interval:          c.RotationInterval,
+		RotationThreshold: c.RotationThreshold,
+		Reattest:          c.Reattest,
 	}
 	svidRotator, client := svid.NewRotator(rotCfg)
 
@@ -71,7 +135,12 @@ func New(c *Config) (*manager, error) {
 		serverAddr:      c.ServerAddr,
 		svidCachePath:   c.SVIDCachePath,
 		bundleCachePath: c.BundleCachePath,
+		entryCachePath:  c.EntryCachePath,
 		client:          client,
+		jwtSVIDs:        jwtSVIDs,
+		staleWarnings:   make(map[string]time.Time),
+		minting:         make(map[string]chan struct{}),
+		syncNow:         make(chan struct{}, 1),
 	}
 
 	return m, nil
@@ -14,7 +14,10 @@ import (
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Cache Manager errors
@@ -22,6 +25,10 @@ var (
 	ErrNotCached = errors.New("not cached")
 )
 
+// mintTimeout bounds the time a workload waits for its SVIDs to be minted
+// on demand.
+var mintTimeout = 10 * time.Second
+
 // Manager provides cache management functionalities for agents.
 type Manager interface {
 	// Initialize initializes the manager.
@@ -46,6 +53,23 @@ type Manager interface {
 	// in order to find matching cache entries. A cache entry is matched when its RegistrationEntry's
 	// selectors are included in the set of selectors passed as parameter.
 	MatchingEntries(selectors []*common.Selector) []*cache.Entry
+
+	// CachedEntries returns all of the entries in the cache, regardless of
+	// the selectors they are registered for.
+	CachedEntries() []*cache.Entry
+
+	// FetchJWTSVID returns a JWT-SVID for the SPIFFE ID and audience. Cached
+	// JWT-SVIDs are returned until they reach the rotation threshold.
+	FetchJWTSVID(ctx context.Context, spiffeID string, audience []string) (*client.JWTSVID, error)
+
+	// LastSync returns the time of the last successful synchronization with
+	// the server, or the zero time if none has succeeded yet.
+	LastSync() time.Time
+
+	// RotateSVIDs immediately rotates the cached X509-SVIDs, along with their
+	// private keys, of the given SPIFFE ID or all of them if empty. Returns
+	// the number of SVIDs rotated.
+	RotateSVIDs(spiffeID string) (int, error)
 }
 
 type manager struct {
@@ -57,41 +81,101 @@ type manager struct {
 	cache cache.Cache
 	svid  svid.Rotator
 
+	lastSync time.Time
+
 	spiffeID       string
 	serverSPIFFEID string
 	serverAddr     net.Addr
 
 	svidCachePath   string
 	bundleCachePath string
+	entryCachePath  string
+
+	// entryCacheKey encrypts the entries cached on disk. It is nil if the
+	// entries are not cached on disk.
+	entryCacheKey []byte
 
 	client client.Client
+
+	// jwtSVIDs caches the JWT-SVIDs fetched for workloads, which are
+	// refreshed while synchronizing.
+	jwtSVIDs *cache.JWTSVIDCache
+
+	// syncMtx serializes the synchronizations with the requests of SVIDs
+	// minted on demand, which share the fields below.
+	syncMtx sync.Mutex
+
+	// regEntries holds the latest registration entries received from the
+	// server, keyed by entry ID.
+	regEntries map[string]*common.RegistrationEntry
+
+	// federatedBundles holds the latest federated bundles received from
+	// the server, keyed by trust domain SPIFFE ID.
+	federatedBundles map[string][]byte
+
+	// bundleSequenceNumber is the sequence number of the last bundle
+	// received from the server, if it keeps one.
+	bundleSequenceNumber uint64
+
+	// staleWarnings holds the last time a warning was logged for each cache
+	// entry served with a stale SVID, keyed by entry ID.
+	staleWarnings map[string]time.Time
+
+	// mintMtx protects minting, which holds a channel closed once minted
+	// for each entry whose SVID is being minted on demand, keyed by entry ID.
+	mintMtx sync.Mutex
+	minting map[string]chan struct{}
+
+	// syncNow has the synchronizer run before the next sync interval
+	syncNow chan struct{}
 }
 
 func (m *manager) Initialize(ctx context.Context) error {
-	m.storeSVID(m.svid.State().SVID)
+	m.storeSVID(m.svid.State())
 	m.storeBundle(m.cache.Bundle())
 
-	return m.synchronize()
+	restored := m.restoreEntries()
+
+	err := m.synchronize()
+	if err != nil && err != client.ErrAgentEvicted && restored {
+		// Workloads are served the restored entries until the server can
+		// be reached again
+		m.c.Log.Warnf("synchronize failed, serving entries cached on disk: %v", err)
+		return nil
+	}
+	return err
 }
 
 func (m *manager) Run(ctx context.Context) error {
 	defer m.client.Release()
 
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		m.runSynchronizer,
 		m.runSVIDObserver,
 		m.runBundleObserver,
-		m.svid.Run)
+		m.svid.Run,
+	}
+	if m.c.WatchUpdates {
+		tasks = append(tasks, m.runUpdateWatcher)
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 	if err != nil && err != context.Canceled {
 		m.c.Log.Errorf("cache manager crashed: %v", err)
 		return err
 	}
 
+	// Flush the cache so a restarted agent serves the latest SVIDs
+	m.storeEntries()
+
 	m.c.Log.Info("cache manager stopped")
 	return nil
 }
 
 func (m *manager) SubscribeToCacheChanges(selectors cache.Selectors) cache.Subscriber {
+	if m.c.LazySVIDMinting {
+		m.mintEntries(selectors)
+	}
 	return m.cache.Subscribe(selectors)
 }
 
@@ -104,6 +188,10 @@ func (m *manager) SubscribeToBundleChanges() observer.Stream {
 }
 
 func (m *manager) MatchingEntries(selectors []*common.Selector) (entries []*cache.Entry) {
+	if m.c.LazySVIDMinting {
+		m.mintEntries(selectors)
+	}
+
 	for _, entry := range m.cache.Entries() {
 		regEntrySelectors := selector.NewSetFromRaw(entry.RegistrationEntry.Selectors)
 		if selector.NewSetFromRaw(selectors).IncludesSet(regEntrySelectors) {
@@ -113,6 +201,67 @@ func (m *manager) MatchingEntries(selectors []*common.Selector) (entries []*cach
 	return entries
 }
 
+func (m *manager) CachedEntries() []*cache.Entry {
+	return m.cache.Entries()
+}
+
+func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []string) (*client.JWTSVID, error) {
+	now := time.Now()
+
+	cachedSVID, ok := m.jwtSVIDs.GetJWTSVID(spiffeID, audience)
+	if ok && !m.jwtSVIDNeedsRefresh(cachedSVID, now) {
+		return cachedSVID, nil
+	}
+
+	newSVID, err := m.client.FetchJWTSVID(ctx, &node.JSR{
+		SpiffeId: spiffeID,
+		Audience: audience,
+	})
+	switch {
+	case err == nil:
+	case ok && m.servable(cachedSVID.IssuedAt, cachedSVID.ExpiresAt, now):
+		// The cached JWT-SVID may still be served until it can be renewed
+		m.c.Log.Warnf("unable to renew JWT-SVID for %s: %v", spiffeID, err)
+		return cachedSVID, nil
+	default:
+		return nil, err
+	}
+
+	m.jwtSVIDs.SetJWTSVID(spiffeID, audience, newSVID)
+	return newSVID, nil
+}
+
+func (m *manager) LastSync() time.Time {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.lastSync
+}
+
+func (m *manager) RotateSVIDs(spiffeID string) (int, error) {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
+	cEntryRequests := entryRequests{}
+	for _, entry := range m.cache.Entries() {
+		if spiffeID != "" && entry.RegistrationEntry.SpiffeId != spiffeID {
+			continue
+		}
+		if err := m.addEntryRequest(entry.RegistrationEntry, cEntryRequests); err != nil {
+			return 0, err
+		}
+	}
+	if len(cEntryRequests) == 0 {
+		return 0, nil
+	}
+
+	m.c.Log.Infof("Rotating %d SVIDs on request", len(cEntryRequests))
+	if err := m.processEntryRequests(cEntryRequests); err != nil {
+		return 0, err
+	}
+	m.storeEntries()
+	return len(cEntryRequests), nil
+}
+
 func (m *manager) runSynchronizer(ctx context.Context) error {
 	t := time.NewTicker(m.c.SyncInterval)
 	defer t.Stop()
@@ -120,17 +269,57 @@ func (m *manager) runSynchronizer(ctx context.Context) error {
 	for {
 		select {
 		case <-t.C:
-			err := m.synchronize()
-			if err != nil {
-				// Just log the error to keep waiting for next sinchronization...
-				m.c.Log.Errorf("synchronize failed: %v", err)
-			}
+		case <-m.syncNow:
+		case <-ctx.Done():
+			return nil
+		}
+
+		err := m.synchronize()
+		if err == client.ErrAgentEvicted {
+			return err
+		}
+		if err != nil {
+			// Just log the error to keep waiting for next sinchronization...
+			m.c.Log.Errorf("synchronize failed: %v", err)
+		}
+		m.checkStaleCacheEntries()
+	}
+}
+
+// runUpdateWatcher has the synchronizer run as soon as the server notifies of
+// updates. The stream is opened again when it ends, unless the server does
+// not push updates.
+func (m *manager) runUpdateWatcher(ctx context.Context) error {
+	for {
+		err := m.client.WatchUpdates(ctx, m.requestSync)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case status.Code(err) == codes.Unimplemented:
+			m.c.Log.Warn("The server does not push updates, changes are only received every sync interval")
+			return nil
+		default:
+			m.c.Log.Warnf("Watching for updates failed: %v", err)
+		}
+
+		// Changes made in the meantime are notified once watching again
+		select {
+		case <-time.After(m.c.SyncInterval):
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
+// requestSync has the synchronizer run right away, unless it is about to
+// already
+func (m *manager) requestSync() {
+	select {
+	case m.syncNow <- struct{}{}:
+	default:
+	}
+}
+
 func (m *manager) runSVIDObserver(ctx context.Context) error {
 	svidStream := m.SubscribeToSVIDChanges()
 	for {
@@ -139,7 +328,8 @@ func (m *manager) runSVIDObserver(ctx context.Context) error {
 			return nil
 		case <-svidStream.Changes():
 			s := svidStream.Next().(svid.State)
-			m.storeSVID(s.SVID)
+			m.c.Tel.IncrCounter([]string{"cache_manager", "agent_svid_rotations"}, 1)
+			m.storeSVID(s)
 		}
 	}
 }
@@ -161,13 +351,61 @@ func (m *manager) isAlreadyCached(regEntry *common.RegistrationEntry) bool {
 	return m.cache.Entry(regEntry) != nil
 }
 
-func (m *manager) storeSVID(svid *x509.Certificate) {
-	err := StoreSVID(m.svidCachePath, svid)
+func (m *manager) storeSVID(s svid.State) {
+	err := StoreSVID(m.svidCachePath, s.SVID, s.Intermediates)
 	if err != nil {
 		m.c.Log.Warnf("could not store SVID: %v", err)
 	}
 }
 
+// restoreEntries puts the entries cached on disk by a previous run back on
+// the cache, skipping those with an SVID which may no longer be served.
+// Returns true if any entry was restored.
+func (m *manager) restoreEntries() bool {
+	if m.entryCachePath == "" {
+		return false
+	}
+
+	key, err := ReadCacheKey(m.c.EntryCacheKeyPath)
+	if err != nil {
+		m.c.Log.Warnf("could not read entry cache key, entries will not be cached on disk: %v", err)
+		return false
+	}
+	m.entryCacheKey = key
+
+	entries, err := ReadEntries(m.entryCachePath, key)
+	if err == ErrNotCached {
+		return false
+	} else if err != nil {
+		m.c.Log.Warnf("could not read cached entries: %v", err)
+		return false
+	}
+
+	now := time.Now()
+	restored := 0
+	for _, entry := range entries {
+		if !m.servable(entry.SVID.NotBefore, entry.SVID.NotAfter, now) {
+			continue
+		}
+		m.cache.SetEntry(entry)
+		restored++
+	}
+	if restored > 0 {
+		m.c.Log.Infof("Restored %d cache entries from disk", restored)
+	}
+	return restored > 0
+}
+
+func (m *manager) storeEntries() {
+	if m.entryCacheKey == nil {
+		return
+	}
+	err := StoreEntries(m.entryCachePath, m.entryCacheKey, m.cache.Entries())
+	if err != nil {
+		m.c.Log.Errorf("could not store cache entries: %v", err)
+	}
+}
+
 func (m *manager) storeBundle(bundle []*x509.Certificate) {
 	err := StoreBundle(m.bundleCachePath, bundle)
 	if err != nil {
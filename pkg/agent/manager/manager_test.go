@@ -13,20 +13,29 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
+	"github.com/imkira/go-observer"
 	testlog "github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/agent/client"
 	"github.com/spiffe/spire/test/util"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -123,7 +132,7 @@ func TestStoreSVIDOnStartup(t *testing.T) {
 		BundleCachePath: path.Join(dir, "bundle.der"),
 	}
 
-	_, err := ReadSVID(c.SVIDCachePath)
+	_, _, err := ReadSVID(c.SVIDCachePath)
 	if err != ErrNotCached {
 		t.Fatalf("wanted: %v, got: %v", ErrNotCached, err)
 	}
@@ -140,7 +149,7 @@ func TestStoreSVIDOnStartup(t *testing.T) {
 
 	// Althought start failed, the SVID should have been saved, because it should be
 	// one of the first thing the manager does at initialization.
-	cert, err := ReadSVID(c.SVIDCachePath)
+	cert, _, err := ReadSVID(c.SVIDCachePath)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -475,6 +484,664 @@ func TestSynchronizationClearsStaleCacheEntries(t *testing.T) {
 		regEntriesFromCacheEntries(m.cache.Entries()))
 }
 
+func TestSynchronizationEvictedAgent(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponseForEvictedAgentTest,
+		svidTTL:           3,
+	})
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     url.URL{Host: trustDomain},
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Tel:             &telemetry.Blackhole{},
+	}
+
+	m := newManager(t, c)
+
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	compareRegistrationEntries(t,
+		regEntriesMap["resp1"],
+		regEntriesFromCacheEntries(m.cache.Entries()))
+
+	// the server now reports the agent as evicted
+	if err := m.synchronize(); err != client.ErrAgentEvicted {
+		t.Fatalf("wanted: %v, got: %v", client.ErrAgentEvicted, err)
+	}
+
+	if entries := m.cache.Entries(); len(entries) != 0 {
+		t.Fatalf("cache should be empty after eviction, got %d entries", len(entries))
+	}
+	if _, _, err := ReadSVID(c.SVIDCachePath); err != ErrNotCached {
+		t.Fatalf("wanted: %v, got: %v", ErrNotCached, err)
+	}
+}
+
+func TestRestoreEntriesFromDisk(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponseForStaleCacheTest,
+		svidTTL:           200,
+	})
+	apiHandler.start()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	keyPath := path.Join(dir, "entry_cache.key")
+	if err := ioutil.WriteFile(keyPath, bytes.Repeat([]byte{1}, cacheKeySize), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:              baseSVID,
+		SVIDKey:           baseSVIDKey,
+		Log:               testLogger,
+		TrustDomain:       url.URL{Host: trustDomain},
+		SVIDCachePath:     path.Join(dir, "svid.der"),
+		BundleCachePath:   path.Join(dir, "bundle.der"),
+		EntryCachePath:    path.Join(dir, "entry_cache.bin"),
+		EntryCacheKeyPath: keyPath,
+		Bundle:            apiHandler.bundle,
+		Tel:               &telemetry.Blackhole{},
+	}
+
+	m := newManager(t, c)
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	expected := append(regEntriesMap["resp1"], regEntriesMap["resp2"]...)
+	compareRegistrationEntries(t, expected, regEntriesFromCacheEntries(m.cache.Entries()))
+
+	// a new manager serves the entries cached on disk while the server is
+	// unreachable
+	apiHandler.stop()
+
+	m = newManager(t, c)
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	compareRegistrationEntries(t, expected, regEntriesFromCacheEntries(m.cache.Entries()))
+	for _, entry := range m.cache.Entries() {
+		if entry.SVID == nil || entry.PrivateKey == nil {
+			t.Fatalf("restored entry for %s is missing its SVID or key", entry.RegistrationEntry.SpiffeId)
+		}
+	}
+}
+
+func TestUpdateFederatedBundles(t *testing.T) {
+	m := &manager{
+		c:     &Config{Log: testLogger},
+		cache: cache.New(testLogger, nil),
+		federatedBundles: map[string][]byte{
+			"spiffe://otherdomain.org": []byte("bundle1"),
+		},
+	}
+
+	regEntry := &common.RegistrationEntry{
+		EntryId:       "entry1",
+		SpiffeId:      "spiffe://example.org/workload",
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+	}
+	m.cache.SetEntry(&cache.Entry{
+		RegistrationEntry: regEntry,
+		Bundles:           m.entryBundles(regEntry),
+	})
+	regEntries := map[string]*common.RegistrationEntry{"entry1": regEntry}
+
+	if m.updateFederatedBundles(regEntries) {
+		t.Fatal("expected no update while the bundles are unchanged")
+	}
+
+	// The federated bundle is rotated
+	m.federatedBundles["spiffe://otherdomain.org"] = []byte("bundle2")
+	if !m.updateFederatedBundles(regEntries) {
+		t.Fatal("expected an update after the bundle changed")
+	}
+	bundles := m.cache.Entry(regEntry).Bundles
+	if !bytes.Equal(bundles["spiffe://otherdomain.org"], []byte("bundle2")) {
+		t.Fatalf("unexpected bundles: %v", bundles)
+	}
+
+	// The entry federates with a new trust domain
+	m.federatedBundles["spiffe://thirddomain.org"] = []byte("bundle3")
+	regEntries["entry1"] = &common.RegistrationEntry{
+		EntryId:       "entry1",
+		SpiffeId:      "spiffe://example.org/workload",
+		FederatesWith: []string{"spiffe://otherdomain.org", "spiffe://thirddomain.org"},
+	}
+	if !m.updateFederatedBundles(regEntries) {
+		t.Fatal("expected an update after the entry changed")
+	}
+	entry := m.cache.Entry(regEntry)
+	if len(entry.Bundles) != 2 || len(entry.RegistrationEntry.FederatesWith) != 2 {
+		t.Fatalf("unexpected cache entry: %v", entry)
+	}
+}
+
+func TestLazySVIDMinting(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponse,
+		svidTTL:           200,
+	})
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     url.URL{Host: trustDomain},
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Tel:             &telemetry.Blackhole{},
+		LazySVIDMinting: true,
+	}
+
+	m := newManager(t, c)
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// No workload has connected yet
+	if len(m.cache.Entries()) != 0 {
+		t.Fatalf("expected no cached entries, got %d", len(m.cache.Entries()))
+	}
+
+	util.RunWithTimeout(t, 5*time.Second, func() {
+		sub := m.SubscribeToCacheChanges(cache.Selectors{&common.Selector{Type: "unix", Value: "uid:1111"}})
+		defer sub.Finish()
+		u := <-sub.Updates()
+
+		compareRegistrationEntries(t,
+			regEntriesMap["resp2"],
+			regEntriesFromCacheEntries(u.Entries))
+	})
+
+	// Only the entries of the connected workload are minted
+	compareRegistrationEntries(t, regEntriesMap["resp2"], regEntriesFromCacheEntries(m.cache.Entries()))
+}
+
+func TestMintEntriesCoalescesRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mock_client.NewMockClient(ctrl)
+
+	selectors := []*common.Selector{{Type: "unix", Value: "uid:1111"}}
+	m := &manager{
+		c:      &Config{Log: testLogger, Tel: &telemetry.Blackhole{}},
+		cache:  cache.New(testLogger, nil),
+		client: c,
+		regEntries: map[string]*common.RegistrationEntry{
+			"entry1": {EntryId: "entry1", SpiffeId: "spiffe://example.org/workload", Selectors: selectors},
+		},
+		minting: make(map[string]chan struct{}),
+	}
+
+	svid, _ := createCA(t, "example.org")
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	c.EXPECT().FetchUpdates(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, req *node.FetchX509SVIDRequest) {
+		if len(req.Csrs) != 1 {
+			t.Errorf("expected 1 CSR, got %d", len(req.Csrs))
+		}
+		close(fetching)
+		<-release
+	}).Return(&client.Update{
+		SVIDs: map[string]*node.Svid{
+			"spiffe://example.org/workload": {SvidCert: svid.Raw},
+		},
+	}, nil)
+
+	first := make(chan struct{})
+	go func() {
+		m.mintEntries(selectors)
+		close(first)
+	}()
+	<-fetching
+
+	// The second request waits on the first instead of calling the server
+	second := make(chan struct{})
+	go func() {
+		m.mintEntries(selectors)
+		close(second)
+	}()
+
+	close(release)
+	util.RunWithTimeout(t, 5*time.Second, func() {
+		<-first
+		<-second
+	})
+
+	if len(m.cache.Entries()) != 1 {
+		t.Fatalf("expected 1 cached entry, got %d", len(m.cache.Entries()))
+	}
+	if len(m.minting) != 0 {
+		t.Fatalf("expected no entries being minted, got %d", len(m.minting))
+	}
+}
+
+func TestFetchUpdatesSkipsUnchangedBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mock_client.NewMockClient(ctrl)
+
+	m := &manager{
+		c:      &Config{Log: testLogger},
+		cache:  cache.New(testLogger, nil),
+		client: c,
+	}
+
+	ca, _ := createCA(t, "example.org")
+	c.EXPECT().FetchUpdates(gomock.Any(), gomock.Any()).Return(&client.Update{
+		Bundle:               ca.Raw,
+		BundleSequenceNumber: 1,
+	}, nil)
+	if _, _, err := m.fetchUpdates(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !m.bundleAlreadyCached([]*x509.Certificate{ca}) {
+		t.Fatal("expected the bundle to be cached")
+	}
+
+	// The bundle is not parsed again while its sequence number is unchanged
+	c.EXPECT().FetchUpdates(gomock.Any(), gomock.Any()).Return(&client.Update{
+		Bundle:               []byte("garbage"),
+		BundleSequenceNumber: 1,
+	}, nil)
+	if _, _, err := m.fetchUpdates(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !m.bundleAlreadyCached([]*x509.Certificate{ca}) {
+		t.Fatal("expected the bundle to be left alone")
+	}
+
+	c.EXPECT().FetchUpdates(gomock.Any(), gomock.Any()).Return(&client.Update{
+		Bundle:               []byte("garbage"),
+		BundleSequenceNumber: 2,
+	}, nil)
+	if _, _, err := m.fetchUpdates(nil); err == nil {
+		t.Fatal("expected the changed bundle to be parsed")
+	}
+}
+
+func TestCheckForcedRotations(t *testing.T) {
+	m := &manager{
+		c:     &Config{Log: testLogger, Tel: &telemetry.Blackhole{}},
+		cache: cache.New(testLogger, nil),
+	}
+
+	regEntry := &common.RegistrationEntry{
+		EntryId:   "entry1",
+		SpiffeId:  "spiffe://example.org/workload",
+		RotatedAt: 1,
+	}
+	m.cache.SetEntry(&cache.Entry{RegistrationEntry: regEntry})
+	regEntries := map[string]*common.RegistrationEntry{"entry1": regEntry}
+
+	cEntryRequests := entryRequests{}
+	if err := m.checkForcedRotations(regEntries, cEntryRequests); err != nil {
+		t.Fatal(err)
+	}
+	if len(cEntryRequests) != 0 {
+		t.Fatalf("expected no request while the rotation is not forced, got %d", len(cEntryRequests))
+	}
+
+	// The rotation is forced on the server
+	regEntries["entry1"] = &common.RegistrationEntry{
+		EntryId:   "entry1",
+		SpiffeId:  "spiffe://example.org/workload",
+		RotatedAt: 2,
+	}
+	if err := m.checkForcedRotations(regEntries, cEntryRequests); err != nil {
+		t.Fatal(err)
+	}
+	request, ok := cEntryRequests["entry1"]
+	if !ok {
+		t.Fatal("expected a request after the rotation was forced")
+	}
+	if request.entry.RegistrationEntry.RotatedAt != 2 || request.entry.PrivateKey == nil {
+		t.Fatalf("unexpected cache entry: %v", request.entry)
+	}
+}
+
+func TestCheckTaintedSVID(t *testing.T) {
+	ca, caKey := createCA(t, "example.org")
+	otherCA, _ := createCA(t, "example.org")
+	agentSVID, _ := createSVID(t, ca, caKey, "spiffe://example.org/agent", time.Hour)
+
+	rotator := &fakeRotator{state: svid.State{SVID: agentSVID}}
+	m := &manager{
+		c:    &Config{Log: testLogger},
+		svid: rotator,
+	}
+
+	if err := m.checkTaintedSVID(otherCA.Raw); err != nil {
+		t.Fatal(err)
+	}
+	if rotator.forcedRotations != 0 {
+		t.Fatal("expected the rotation not to be forced while the CA is not tainted")
+	}
+
+	if err := m.checkTaintedSVID(append(append([]byte{}, otherCA.Raw...), ca.Raw...)); err != nil {
+		t.Fatal(err)
+	}
+	if rotator.forcedRotations != 1 {
+		t.Fatal("expected the rotation to be forced once the CA is tainted")
+	}
+
+	if err := m.checkTaintedSVID([]byte("garbage")); err == nil {
+		t.Fatal("expected the tainted CA certificates to be parsed")
+	}
+}
+
+func TestCheckStaleCacheEntries(t *testing.T) {
+	m := &manager{
+		c: &Config{
+			Log:               testLogger,
+			Tel:               &telemetry.Blackhole{},
+			RotationThreshold: 50,
+			MaxSVIDStaleness:  10 * time.Minute,
+		},
+		cache:         cache.New(testLogger, nil),
+		staleWarnings: make(map[string]time.Time),
+	}
+
+	now := time.Now()
+	setEntry := func(entryID string, notBefore, notAfter time.Time) {
+		m.cache.SetEntry(&cache.Entry{
+			RegistrationEntry: &common.RegistrationEntry{EntryId: entryID, SpiffeId: "spiffe://example.org/" + entryID},
+			SVID:              &x509.Certificate{NotBefore: notBefore, NotAfter: notAfter},
+		})
+	}
+	setEntry("fresh", now.Add(-10*time.Minute), now.Add(time.Hour))
+	// Past its rotation time for 5 minutes
+	setEntry("stale", now.Add(-45*time.Minute), now.Add(35*time.Minute))
+	// Past its rotation time for 55 minutes
+	setEntry("too-stale", now.Add(-2*time.Hour), now.Add(10*time.Minute))
+	setEntry("expired", now.Add(-2*time.Hour), now.Add(-time.Minute))
+
+	m.checkStaleCacheEntries()
+	entryIDs := func() (ids []string) {
+		for _, entry := range m.cache.Entries() {
+			ids = append(ids, entry.RegistrationEntry.EntryId)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	if ids := entryIDs(); !reflect.DeepEqual(ids, []string{"fresh", "stale"}) {
+		t.Fatalf("unexpected cache entries after the check: %v", ids)
+	}
+	if _, ok := m.staleWarnings["stale"]; !ok {
+		t.Fatal("expected a warning about the stale SVID")
+	}
+
+	// Without a maximum staleness, SVIDs are served until they expire
+	m.c.MaxSVIDStaleness = 0
+	setEntry("too-stale", now.Add(-2*time.Hour), now.Add(10*time.Minute))
+	setEntry("expired", now.Add(-2*time.Hour), now.Add(-time.Minute))
+
+	m.checkStaleCacheEntries()
+	if ids := entryIDs(); !reflect.DeepEqual(ids, []string{"fresh", "stale", "too-stale"}) {
+		t.Fatalf("unexpected cache entries after the check: %v", ids)
+	}
+}
+
+func TestRotateSVIDs(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponse,
+		svidTTL:           200,
+	})
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     url.URL{Host: trustDomain},
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Tel:             &telemetry.Blackhole{},
+	}
+
+	m := newManager(t, c)
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	keys := make(map[string]*ecdsa.PrivateKey)
+	for _, entry := range m.cache.Entries() {
+		keys[entry.RegistrationEntry.EntryId] = entry.PrivateKey
+	}
+
+	rotated, err := m.RotateSVIDs("spiffe://example.org/blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated != 1 {
+		t.Fatalf("expected 1 rotated SVID, got %d", rotated)
+	}
+
+	for _, entry := range m.cache.Entries() {
+		rekeyed := entry.PrivateKey != keys[entry.RegistrationEntry.EntryId]
+		if shouldRekey := entry.RegistrationEntry.SpiffeId == "spiffe://example.org/blog"; rekeyed != shouldRekey {
+			t.Fatalf("unexpected rotation of %v: rekeyed=%t", entry.RegistrationEntry.SpiffeId, rekeyed)
+		}
+	}
+
+	// All of the SVIDs are rotated without a SPIFFE ID
+	rotated, err = m.RotateSVIDs("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated != len(m.cache.Entries()) {
+		t.Fatalf("expected %d rotated SVIDs, got %d", len(m.cache.Entries()), rotated)
+	}
+}
+
+func TestFetchJWTSVID(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponseForTestHappyPathWithoutSyncNorRotation,
+		svidTTL:           200,
+	})
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     url.URL{Host: trustDomain},
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Tel:             &telemetry.Blackhole{},
+	}
+
+	m := newManager(t, c)
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	spiffeID := regEntriesMap["resp1"][0].SpiffeId
+	audience := []string{"foo"}
+	fetch := func() string {
+		svid, err := m.FetchJWTSVID(context.Background(), spiffeID, audience)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return svid.Token
+	}
+
+	// fresh JWT-SVIDs are served from the cache
+	if token := fetch(); token != "token-1" {
+		t.Fatalf("wanted token-1, got %s", token)
+	}
+	if token := fetch(); token != "token-1" {
+		t.Fatalf("wanted cached token-1, got %s", token)
+	}
+
+	// JWT-SVIDs past half of their lifetime are renewed
+	apiHandler.jwtSVIDAge = 45 * time.Second
+	m.jwtSVIDs.SetJWTSVID(spiffeID, audience, &client.JWTSVID{
+		Token:     "token-1",
+		IssuedAt:  time.Now().Add(-45 * time.Second),
+		ExpiresAt: time.Now().Add(15 * time.Second),
+	})
+	if token := fetch(); token != "token-2" {
+		t.Fatalf("wanted token-2, got %s", token)
+	}
+
+	// the cached JWT-SVID is served while it is valid if renewal fails
+	apiHandler.jwtSVIDErr = errors.New("oh no")
+	if token := fetch(); token != "token-2" {
+		t.Fatalf("wanted cached token-2, got %s", token)
+	}
+}
+
+func TestRefreshJWTSVIDs(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponseForTestHappyPathWithoutSyncNorRotation,
+		svidTTL:           200,
+	})
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     url.URL{Host: trustDomain},
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Tel:             &telemetry.Blackhole{},
+	}
+
+	m := newManager(t, c)
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	regEntry := regEntriesMap["resp1"][0]
+	fresh := &client.JWTSVID{
+		Token:     "fresh",
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	stale := &client.JWTSVID{
+		Token:     "stale",
+		IssuedAt:  time.Now().Add(-45 * time.Second),
+		ExpiresAt: time.Now().Add(15 * time.Second),
+	}
+	m.jwtSVIDs.SetJWTSVID(regEntry.SpiffeId, []string{"fresh"}, fresh)
+	m.jwtSVIDs.SetJWTSVID(regEntry.SpiffeId, []string{"stale"}, stale)
+	m.jwtSVIDs.SetJWTSVID("spiffe://example.org/gone", []string{"foo"}, fresh)
+
+	m.refreshJWTSVIDs(map[string]*common.RegistrationEntry{regEntry.EntryId: regEntry})
+
+	if svid, _ := m.jwtSVIDs.GetJWTSVID(regEntry.SpiffeId, []string{"fresh"}); svid != fresh {
+		t.Fatalf("fresh JWT-SVID should not be renewed, got %v", svid)
+	}
+	if svid, _ := m.jwtSVIDs.GetJWTSVID(regEntry.SpiffeId, []string{"stale"}); svid == nil || svid.Token != "token-1" {
+		t.Fatalf("stale JWT-SVID should be renewed, got %v", svid)
+	}
+	if _, ok := m.jwtSVIDs.GetJWTSVID("spiffe://example.org/gone", []string{"foo"}); ok {
+		t.Fatal("JWT-SVID for a SPIFFE ID without a registration entry should be dropped")
+	}
+}
+
 func TestSubscribersGetUpToDateBundle(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -531,6 +1198,69 @@ func TestSubscribersGetUpToDateBundle(t *testing.T) {
 	})
 }
 
+func TestWatchUpdatesSynchronizesRightAway(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	trustDomain := "example.org"
+
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:                 t,
+		trustDomain:       trustDomain,
+		dir:               dir,
+		fetchSVIDResponse: fetchSVIDResponseForTestWatchUpdatesSynchronizesRightAway,
+		watchUpdates:      make(chan struct{}),
+		svidTTL:           200,
+	})
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+
+	c := &Config{
+		ServerAddr: &net.UnixAddr{
+			Net:  "unix",
+			Name: apiHandler.sockPath,
+		},
+		SVID:             baseSVID,
+		SVIDKey:          baseSVIDKey,
+		Log:              testLogger,
+		TrustDomain:      url.URL{Host: trustDomain},
+		SVIDCachePath:    path.Join(dir, "svid.der"),
+		BundleCachePath:  path.Join(dir, "bundle.der"),
+		Bundle:           []*x509.Certificate{apiHandler.bundle[0]},
+		Tel:              &telemetry.Blackhole{},
+		RotationInterval: 1 * time.Hour,
+		SyncInterval:     1 * time.Hour,
+		WatchUpdates:     true,
+	}
+
+	m := newManager(t, c)
+
+	sub := m.SubscribeToCacheChanges(cache.Selectors{&common.Selector{Type: "unix", Value: "uid:1111"}})
+
+	defer initializeAndRunManager(t, m)()
+
+	util.RunWithTimeout(t, 1*time.Second, func() {
+		u := <-sub.Updates()
+		if len(u.Bundle) != 1 {
+			t.Fatalf("expected 1 bundle, got: %d", len(u.Bundle))
+		}
+	})
+
+	// The sync interval is not due, the server pushes an update instead
+	util.RunWithTimeout(t, 5*time.Second, func() {
+		apiHandler.c.watchUpdates <- struct{}{}
+		u := <-sub.Updates()
+		if len(u.Bundle) != 2 {
+			t.Fatalf("expected 2 bundles, got: %d", len(u.Bundle))
+		}
+		if !u.Bundle[1].Equal(apiHandler.bundle[1]) {
+			t.Fatal("new bundles were expected to be equals")
+		}
+	})
+}
+
 func TestSurvivesCARotation(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -670,6 +1400,21 @@ func fetchSVIDResponseForStaleCacheTest(h *mockNodeAPIHandler, req *node.FetchX5
 	return stream.Send(newFetchX509SVIDResponse(nil, nil, h.bundle))
 }
 
+func fetchSVIDResponseForEvictedAgentTest(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+	svids, err := h.makeSvids(req.Csrs)
+	if err != nil {
+		return err
+	}
+
+	switch h.reqCount {
+	case 1:
+		return stream.Send(newFetchX509SVIDResponse([]string{"resp1"}, nil, h.bundle))
+	case 2:
+		return stream.Send(newFetchX509SVIDResponse([]string{"resp1"}, svids, h.bundle))
+	}
+	return stream.Send(&node.FetchX509SVIDResponse{AgentStatus: node.AgentStatus_EVICTED})
+}
+
 func fetchSVIDResponseForTestSubscribersGetUpToDateBundle(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
 	switch h.reqCount {
 	case 2:
@@ -680,6 +1425,16 @@ func fetchSVIDResponseForTestSubscribersGetUpToDateBundle(h *mockNodeAPIHandler,
 	return fetchSVIDResponse(h, req, stream)
 }
 
+func fetchSVIDResponseForTestWatchUpdatesSynchronizesRightAway(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+	switch h.reqCount {
+	case 3:
+		ca, _ := createCA(h.c.t, h.c.trustDomain)
+		h.bundle = append(h.bundle, ca)
+	}
+
+	return fetchSVIDResponse(h, req, stream)
+}
+
 func fetchSVIDResponseForTestSurvivesCARotation(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
 	switch h.reqCount {
 	case 2:
@@ -770,6 +1525,9 @@ type mockNodeAPIHandlerConfig struct {
 	dir string
 	// Callback used to build the response according to the request and state of mockNodeAPIHandler.
 	fetchSVIDResponse func(*mockNodeAPIHandler, *node.FetchX509SVIDRequest, node.Node_FetchX509SVIDServer) error
+	// Updates pushed to agents watching for them. WatchUpdates is not
+	// implemented if nil.
+	watchUpdates chan struct{}
 
 	svidTTL int
 }
@@ -792,6 +1550,12 @@ type mockNodeAPIHandler struct {
 	// Counts the number of requests received from clients
 	reqCount int
 
+	// Counts the number of JWT-SVID requests, the JWT-SVIDs returned are
+	// issued jwtSVIDAge ago and live for a minute. jwtSVIDErr fails them.
+	jwtReqCount int
+	jwtSVIDAge  time.Duration
+	jwtSVIDErr  error
+
 	delay time.Duration
 }
 
@@ -851,11 +1615,43 @@ func (h *mockNodeAPIHandler) FetchX509SVID(stream node.Node_FetchX509SVIDServer)
 	return nil
 }
 
+func (h *mockNodeAPIHandler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+	if h.jwtSVIDErr != nil {
+		return nil, h.jwtSVIDErr
+	}
+	h.jwtReqCount++
+
+	issuedAt := time.Now().Add(-h.jwtSVIDAge)
+	return &node.FetchJWTSVIDResponse{
+		Svid: &node.JWTSVID{
+			Token:     fmt.Sprintf("token-%d", h.jwtReqCount),
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: issuedAt.Add(time.Minute).Unix(),
+		},
+	}, nil
+}
+
 func (h *mockNodeAPIHandler) FetchFederatedBundle(context.Context, *node.FetchFederatedBundleRequest) (*node.FetchFederatedBundleResponse, error) {
 	h.c.t.Fatalf("unexpected call to FetchFederatedBundle")
 	return nil, nil
 }
 
+func (h *mockNodeAPIHandler) WatchUpdates(req *node.WatchUpdatesRequest, stream node.Node_WatchUpdatesServer) error {
+	if h.c.watchUpdates == nil {
+		return status.Error(codes.Unimplemented, "not implemented")
+	}
+	for {
+		select {
+		case <-h.c.watchUpdates:
+			if err := stream.Send(&node.WatchUpdatesResponse{}); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
 func (h *mockNodeAPIHandler) start() {
 	s := grpc.NewServer(h.creds)
 	node.RegisterNodeServer(s, h)
@@ -1021,3 +1817,27 @@ func initializeAndRunManager(t *testing.T, m *manager) (closer func()) {
 		wg.Wait()
 	}
 }
+
+// fakeRotator is an svid.Rotator with a fixed state, counting the forced
+// rotations
+type fakeRotator struct {
+	state           svid.State
+	forcedRotations int
+}
+
+func (r *fakeRotator) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (r *fakeRotator) State() svid.State {
+	return r.state
+}
+
+func (r *fakeRotator) Subscribe() observer.Stream {
+	return observer.NewProperty(r.state).Observe()
+}
+
+func (r *fakeRotator) ForceRotation() {
+	r.forcedRotations++
+}
@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"time"
+)
+
+// How often a warning is logged for each stale SVID still being served
+const staleWarningInterval = time.Minute
+
+// staleness returns how long an SVID issued at notBefore and expiring at
+// notAfter is past its rotation time, or zero if it is not due for rotation.
+func (m *manager) staleness(notBefore, notAfter, now time.Time) time.Duration {
+	rotateAt := notAfter.Add(-notAfter.Sub(notBefore) * time.Duration(m.c.RotationThreshold) / 100)
+	if !now.After(rotateAt) {
+		return 0
+	}
+	return now.Sub(rotateAt)
+}
+
+// servable returns true if an SVID may still be served to workloads, i.e.
+// it has not expired and is not stale for longer than MaxSVIDStaleness.
+func (m *manager) servable(notBefore, notAfter, now time.Time) bool {
+	if !now.Before(notAfter) {
+		return false
+	}
+	return m.c.MaxSVIDStaleness == 0 || m.staleness(notBefore, notAfter, now) <= m.c.MaxSVIDStaleness
+}
+
+// checkStaleCacheEntries looks for the cached SVIDs that could not be
+// renewed in time, e.g. because the server is unreachable. They keep being
+// served, with warnings as their staleness grows, until they expire or are
+// stale for longer than MaxSVIDStaleness, at which point they are dropped.
+func (m *manager) checkStaleCacheEntries() {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
+	now := time.Now()
+	stale := 0
+	dropped := 0
+	var maxStaleness time.Duration
+	for _, entry := range m.cache.Entries() {
+		entryID := entry.RegistrationEntry.EntryId
+		staleness := m.staleness(entry.SVID.NotBefore, entry.SVID.NotAfter, now)
+		if staleness == 0 {
+			delete(m.staleWarnings, entryID)
+			continue
+		}
+
+		if !m.servable(entry.SVID.NotBefore, entry.SVID.NotAfter, now) {
+			m.c.Log.Errorf("Stopped serving the SVID for %v, it could not be renewed for %v", entry.RegistrationEntry.SpiffeId, staleness.Round(time.Second))
+			m.cache.DeleteEntry(entry.RegistrationEntry)
+			delete(m.staleWarnings, entryID)
+			dropped++
+			continue
+		}
+
+		stale++
+		if staleness > maxStaleness {
+			maxStaleness = staleness
+		}
+		if now.Sub(m.staleWarnings[entryID]) >= staleWarningInterval {
+			m.c.Log.Warnf("Serving a stale SVID for %v, it could not be renewed for %v and expires in %v", entry.RegistrationEntry.SpiffeId, staleness.Round(time.Second), entry.SVID.NotAfter.Sub(now).Round(time.Second))
+			m.staleWarnings[entryID] = now
+		}
+	}
+
+	m.c.Tel.SetGauge([]string{"cache_manager", "stale_svids"}, float32(stale))
+	m.c.Tel.SetGauge([]string{"cache_manager", "max_svid_staleness"}, float32(maxStaleness.Seconds()))
+	if dropped > 0 {
+		m.c.Tel.IncrCounter([]string{"cache_manager", "dropped_stale_svids"}, float32(dropped))
+		m.storeEntries()
+	}
+}
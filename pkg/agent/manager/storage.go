@@ -2,12 +2,24 @@ package manager
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/common/x509svid"
+	"github.com/spiffe/spire/proto/common"
 )
 
+// cacheKeySize is the size of the AES-256 key encrypting the entry cache
+const cacheKeySize = 32
+
 // ReadBundle returns the bundle located at bundleCachePath. Returns nil
 // if there was some reason by which the bundle couldn't be loaded along with
 // the error reason.
@@ -44,25 +56,176 @@ func StoreBundle(bundleCachePath string, bundle []*x509.Certificate) error {
 // ReadSVID returns the SVID located at svidCachePath. Returns nil
 // if there was some reason by which the SVID couldn't be loaded along
 // with the error reason.
-func ReadSVID(svidCachePath string) (*x509.Certificate, error) {
+func ReadSVID(svidCachePath string) (*x509.Certificate, []*x509.Certificate, error) {
 	if _, err := os.Stat(svidCachePath); os.IsNotExist(err) {
-		return nil, ErrNotCached
+		return nil, nil, ErrNotCached
 	}
 
 	data, err := ioutil.ReadFile(svidCachePath)
 	if err != nil {
-		return nil, fmt.Errorf("error reading SVID at %s: %s", svidCachePath, err)
+		return nil, nil, fmt.Errorf("error reading SVID at %s: %s", svidCachePath, err)
 	}
 
-	cert, err := x509.ParseCertificate(data)
+	cert, intermediates, err := x509svid.ParseChain(data)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing SVID at %s: %s", svidCachePath, err)
+		return nil, nil, fmt.Errorf("error parsing SVID at %s: %s", svidCachePath, err)
 	}
-	return cert, nil
+	return cert, intermediates, nil
 }
 
-// StoreSVID writes the specified svid to disk into svidCachePath. Returns nil if all went
-// fine, otherwise ir returns an error.
-func StoreSVID(svidCachePath string, svid *x509.Certificate) error {
-	return ioutil.WriteFile(svidCachePath, svid.Raw, 0600)
+// StoreSVID writes the specified svid, followed by its intermediates, to disk into
+// svidCachePath. Returns nil if all went fine, otherwise ir returns an error.
+func StoreSVID(svidCachePath string, svid *x509.Certificate, intermediates []*x509.Certificate) error {
+	data := append([]byte(nil), svid.Raw...)
+	for _, intermediate := range intermediates {
+		data = append(data, intermediate.Raw...)
+	}
+	return ioutil.WriteFile(svidCachePath, data, 0600)
+}
+
+// DeleteSVID removes the SVID stored at svidCachePath, if any.
+func DeleteSVID(svidCachePath string) error {
+	err := os.Remove(svidCachePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// cachedEntry is the on-disk representation of a cache entry
+type cachedEntry struct {
+	RegistrationEntry []byte            `json:"registration_entry"`
+	SVID              []byte            `json:"svid"` // followed by the intermediates
+	PrivateKey        []byte            `json:"private_key"`
+	Bundles           map[string][]byte `json:"bundles,omitempty"`
+}
+
+// ReadCacheKey returns the key used to encrypt the entry cache, located at
+// keyPath. The key is provisioned by the operator, apart from the cache, so
+// that the cache cannot be decrypted by whoever can read it.
+func ReadCacheKey(keyPath string) ([]byte, error) {
+	key, err := ioutil.ReadFile(keyPath)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("error reading cache key at %s: %s", keyPath, err)
+	case len(key) != cacheKeySize:
+		return nil, fmt.Errorf("invalid cache key at %s: expected %d bytes, got %d", keyPath, cacheKeySize, len(key))
+	}
+	return key, nil
+}
+
+// ReadEntries returns the cache entries stored at entryCachePath, decrypting
+// them with key. Returns ErrNotCached if no entries have been stored.
+func ReadEntries(entryCachePath string, key []byte) ([]*cache.Entry, error) {
+	if _, err := os.Stat(entryCachePath); os.IsNotExist(err) {
+		return nil, ErrNotCached
+	}
+
+	data, err := ioutil.ReadFile(entryCachePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading entries at %s: %s", entryCachePath, err)
+	}
+
+	aead, err := newCacheAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("error decrypting entries at %s: data too short", entryCachePath)
+	}
+	data, err = aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting entries at %s: %s", entryCachePath, err)
+	}
+
+	var cachedEntries []cachedEntry
+	if err := json.Unmarshal(data, &cachedEntries); err != nil {
+		return nil, fmt.Errorf("error parsing entries at %s: %s", entryCachePath, err)
+	}
+
+	var entries []*cache.Entry
+	for _, cachedEntry := range cachedEntries {
+		regEntry := new(common.RegistrationEntry)
+		if err := proto.Unmarshal(cachedEntry.RegistrationEntry, regEntry); err != nil {
+			return nil, fmt.Errorf("error parsing registration entry at %s: %s", entryCachePath, err)
+		}
+		svid, intermediates, err := x509svid.ParseChain(cachedEntry.SVID)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SVID for %s at %s: %s", regEntry.SpiffeId, entryCachePath, err)
+		}
+		privateKey, err := x509.ParseECPrivateKey(cachedEntry.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing private key for %s at %s: %s", regEntry.SpiffeId, entryCachePath, err)
+		}
+		entries = append(entries, &cache.Entry{
+			RegistrationEntry: regEntry,
+			SVID:              svid,
+			PrivateKey:        privateKey,
+			Bundles:           cachedEntry.Bundles,
+			Intermediates:     intermediates,
+		})
+	}
+	return entries, nil
+}
+
+// StoreEntries encrypts the cache entries with key and writes them to disk
+// into entryCachePath. Returns nil if all went fine, otherwise it returns an
+// error.
+func StoreEntries(entryCachePath string, key []byte, entries []*cache.Entry) error {
+	cachedEntries := []cachedEntry{}
+	for _, entry := range entries {
+		regEntry, err := proto.Marshal(entry.RegistrationEntry)
+		if err != nil {
+			return err
+		}
+		privateKey, err := x509.MarshalECPrivateKey(entry.PrivateKey)
+		if err != nil {
+			return err
+		}
+		cachedEntries = append(cachedEntries, cachedEntry{
+			RegistrationEntry: regEntry,
+			SVID:              entry.SVIDChain(),
+			PrivateKey:        privateKey,
+			Bundles:           entry.Bundles,
+		})
+	}
+
+	data, err := json.Marshal(cachedEntries)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newCacheAEAD(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated
+	// cache behind
+	tmpPath := entryCachePath + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, aead.Seal(nonce, nonce, data, nil), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, entryCachePath)
+}
+
+// DeleteEntries removes the cache entries stored at entryCachePath, if any.
+func DeleteEntries(entryCachePath string) error {
+	err := os.Remove(entryCachePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func newCacheAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache key: %s", err)
+	}
+	return cipher.NewGCM(block)
 }
@@ -1,9 +1,18 @@
 package manager
 
 import (
-	"github.com/spiffe/spire/test/util"
+	"bytes"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
 	"path"
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/util"
 )
 
 func TestReadBundle(t *testing.T) {
@@ -30,3 +39,108 @@ func TestReadBundle(t *testing.T) {
 		}
 	}
 }
+
+func TestStoreAndReadSVID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	svidCachePath := path.Join(dir, "svid.der")
+
+	ca, caKey := createCA(t, "example.org")
+	svid, _ := createSVID(t, ca, caKey, "spiffe://example.org/spire/agent/join_token/abcd", time.Hour)
+
+	// The intermediates are stored after the SVID
+	if err := StoreSVID(svidCachePath, svid, []*x509.Certificate{ca}); err != nil {
+		t.Fatal(err)
+	}
+	cert, intermediates, err := ReadSVID(svidCachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cert.Equal(svid) {
+		t.Fatal("SVID was not correctly stored")
+	}
+	if len(intermediates) != 1 || !intermediates[0].Equal(ca) {
+		t.Fatalf("intermediates were not correctly stored: %v", intermediates)
+	}
+}
+
+func TestStoreAndReadEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyPath := path.Join(dir, "entry_cache.key")
+	entryCachePath := path.Join(dir, "entry_cache.bin")
+
+	// the key is provisioned apart from the cache
+	if _, err := ReadCacheKey(keyPath); err == nil {
+		t.Fatal("expected an error reading a missing key")
+	}
+	if err := ioutil.WriteFile(keyPath, []byte("too short"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCacheKey(keyPath); err == nil {
+		t.Fatal("expected an error reading a key of the wrong size")
+	}
+	if err := ioutil.WriteFile(keyPath, bytes.Repeat([]byte{1}, cacheKeySize), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ReadCacheKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadEntries(entryCachePath, key); err != ErrNotCached {
+		t.Fatalf("wanted: %v, got: %v", ErrNotCached, err)
+	}
+
+	svid, privateKey, err := util.LoadSVIDFixture()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := &cache.Entry{
+		RegistrationEntry: &common.RegistrationEntry{
+			EntryId:  "00000000-0000-0000-0000-000000000000",
+			SpiffeId: "spiffe://example.org/foo",
+		},
+		SVID:       svid,
+		PrivateKey: privateKey,
+		Bundles:    map[string][]byte{"spiffe://otherdomain.org": {1, 2, 3}},
+	}
+	if err := StoreEntries(entryCachePath, key, []*cache.Entry{entry}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadEntries(entryCachePath, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("wanted 1 entry, got %d", len(entries))
+	}
+	if !proto.Equal(entry.RegistrationEntry, entries[0].RegistrationEntry) ||
+		!entry.SVID.Equal(entries[0].SVID) ||
+		entry.PrivateKey.D.Cmp(entries[0].PrivateKey.D) != 0 ||
+		!bytes.Equal(entry.Bundles["spiffe://otherdomain.org"], entries[0].Bundles["spiffe://otherdomain.org"]) {
+		t.Fatal("entry read back does not match the stored one")
+	}
+
+	// entries cannot be read with another key
+	otherKey := make([]byte, len(key))
+	if _, err := ReadEntries(entryCachePath, otherKey); err == nil {
+		t.Fatal("wanted an error reading entries with the wrong key")
+	}
+
+	if err := DeleteEntries(entryCachePath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadEntries(entryCachePath, key); err != ErrNotCached {
+		t.Fatalf("wanted: %v, got: %v", ErrNotCached, err)
+	}
+}
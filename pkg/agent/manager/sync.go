@@ -1,14 +1,22 @@
 package manager
 
 import (
+	"bytes"
+	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
+	"fmt"
 	"time"
 
+	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
+	"github.com/spiffe/spire/pkg/agent/svid"
+	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
 	proto "github.com/spiffe/spire/proto/common"
@@ -16,15 +24,48 @@ import (
 
 // synchronize hits the node api, checks for entries we haven't fetched yet, and fetches them.
 func (m *manager) synchronize() (err error) {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
+	defer m.c.Tel.MeasureSince([]string{"cache_manager", "sync_duration"}, time.Now())
+	defer func() {
+		if err != nil {
+			m.c.Tel.IncrCounter([]string{"cache_manager", "sync_errors"}, 1)
+		}
+	}()
+
 	var regEntries map[string]*proto.RegistrationEntry
 	var cEntryRequests = entryRequests{}
 
 	regEntries, _, err = m.fetchUpdates(nil)
+	if err == client.ErrAgentEvicted {
+		m.evict()
+		return err
+	}
 	if err != nil {
 		return err
 	}
 
-	m.clearStaleCacheEntries(regEntries)
+	// Record that the server was reached, for the health checks
+	m.mtx.Lock()
+	m.lastSync = time.Now()
+	m.mtx.Unlock()
+
+	m.regEntries = regEntries
+
+	cleared := m.clearStaleCacheEntries(regEntries)
+	if m.c.LazySVIDMinting && m.clearUnusedCacheEntries() {
+		cleared = true
+	}
+
+	// Forced rotations are checked before the cached registration entries
+	// are refreshed with the federated bundles
+	err = m.checkForcedRotations(regEntries, cEntryRequests)
+	if err != nil {
+		return err
+	}
+
+	updated := m.updateFederatedBundles(regEntries)
 
 	err = m.checkExpiredCacheEntries(cEntryRequests)
 	if err != nil {
@@ -41,6 +82,13 @@ func (m *manager) synchronize() (err error) {
 		return err
 	}
 
+	if cleared || updated || len(cEntryRequests) > 0 {
+		m.storeEntries()
+	}
+	m.c.Tel.SetGauge([]string{"cache_manager", "cached_entries"}, float32(len(m.cache.Entries())))
+
+	m.refreshJWTSVIDs(regEntries)
+
 	return nil
 }
 
@@ -54,15 +102,64 @@ func (m *manager) fetchUpdates(entryRequests map[string]*entryRequest) (map[stri
 		}
 	}
 
-	update, err := m.client.FetchUpdates(&node.FetchX509SVIDRequest{Csrs: csrs})
+	update, err := m.client.FetchUpdates(context.Background(), &node.FetchX509SVIDRequest{Csrs: csrs})
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// The bundle is left alone if its sequence number shows it has not
+	// changed since the last sync
+	if update.BundleSequenceNumber == 0 || update.BundleSequenceNumber != m.bundleSequenceNumber {
+		if err := m.updateBundle(update); err != nil {
+			return nil, nil, err
+		}
+		m.bundleSequenceNumber = update.BundleSequenceNumber
+	}
+
+	if !bytes.Equal(update.CRL, m.cache.CRL()) {
+		m.cache.SetCRL(update.CRL)
+	}
+
+	m.federatedBundles = update.FederatedBundles
+
+	if err := m.checkTaintedSVID(update.TaintedCACerts); err != nil {
+		return nil, nil, err
+	}
+
+	return update.Entries, update.SVIDs, nil
+}
+
+// checkTaintedSVID forces the rotation of the agent SVID if it was signed by
+// one of the tainted CAs of the server. The SVIDs of the cache entries are
+// rotated through their registration entries, which the server rotates when
+// a CA is tainted.
+func (m *manager) checkTaintedSVID(taintedCACerts []byte) error {
+	if len(taintedCACerts) == 0 {
+		return nil
+	}
+	cas, err := x509.ParseCertificates(taintedCACerts)
+	if err != nil {
+		return err
+	}
+
+	svid := m.svid.State().SVID
+	for _, ca := range cas {
+		if svid.CheckSignatureFrom(ca) == nil {
+			m.c.Log.Warnf("Agent SVID was signed by tainted CA certificate %v", ca.SerialNumber)
+			m.svid.ForceRotation()
+			return nil
+		}
+	}
+	return nil
+}
+
+// updateBundle caches the bundle and JWT signing keys received from the
+// server, unless they are cached already
+func (m *manager) updateBundle(update *client.Update) error {
 	if update.Bundle != nil {
 		bundle, err := x509.ParseCertificates(update.Bundle)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 
 		if !m.bundleAlreadyCached(bundle) {
@@ -70,7 +167,14 @@ func (m *manager) fetchUpdates(entryRequests map[string]*entryRequest) (map[stri
 		}
 	}
 
-	return update.Entries, update.SVIDs, nil
+	jwtSigningKeys, err := parseJWTSigningKeys(update.JWTSigningKeys)
+	if err != nil {
+		return err
+	}
+	if !m.jwtSigningKeysAlreadyCached(jwtSigningKeys) {
+		m.cache.SetJWTSigningKeys(jwtSigningKeys)
+	}
+	return nil
 }
 
 func (m *manager) processEntryRequests(entryRequests entryRequests) error {
@@ -95,78 +199,344 @@ func (m *manager) updateEntriesSVIDs(entryRequestsMap map[string]*entryRequest,
 		ce := entryRequest.entry
 		svid, ok := svids[ce.RegistrationEntry.SpiffeId]
 		if ok {
-			cert, err := x509.ParseCertificate(svid.SvidCert)
+			cert, intermediates, err := x509svid.ParseChain(svid.SvidCert)
 			if err != nil {
 				return err
 			}
 			// Complete the pre-built cache entry with the SVID and put it on the cache.
 			ce.SVID = cert
+			ce.Intermediates = intermediates
 			m.cache.SetEntry(ce)
+			m.c.Tel.IncrCounter([]string{"cache_manager", "workload_svid_updates"}, 1)
 		}
 	}
 	return nil
 }
 
-func (m *manager) clearStaleCacheEntries(regEntries map[string]*proto.RegistrationEntry) {
+// clearStaleCacheEntries removes the cache entries whose registration entry
+// is gone. Returns true if any entry was removed.
+func (m *manager) clearStaleCacheEntries(regEntries map[string]*proto.RegistrationEntry) (cleared bool) {
 	for _, entry := range m.cache.Entries() {
 		if _, ok := regEntries[entry.RegistrationEntry.EntryId]; !ok {
 			m.cache.DeleteEntry(entry.RegistrationEntry)
+			cleared = true
+		}
+	}
+	return cleared
+}
+
+// updateFederatedBundles updates the federated bundles of the cache entries
+// whose trust domains, or the bundles of those trust domains, have changed,
+// so subscribed workloads get the new bundles without waiting for their SVID
+// to be renewed. Returns true if any entry was updated.
+func (m *manager) updateFederatedBundles(regEntries map[string]*proto.RegistrationEntry) (updated bool) {
+	for _, entry := range m.cache.Entries() {
+		regEntry, ok := regEntries[entry.RegistrationEntry.EntryId]
+		if !ok {
+			continue
+		}
+
+		bundles := m.entryBundles(regEntry)
+		if stringsEqual(entry.RegistrationEntry.FederatesWith, regEntry.FederatesWith) && bundlesEqual(entry.Bundles, bundles) {
+			continue
 		}
+
+		m.c.Log.Debugf("Updating federated bundles for %v", regEntry.SpiffeId)
+		m.cache.SetEntry(&cache.Entry{
+			RegistrationEntry: regEntry,
+			SVID:              entry.SVID,
+			PrivateKey:        entry.PrivateKey,
+			Bundles:           bundles,
+			Intermediates:     entry.Intermediates,
+		})
+		updated = true
 	}
+	return updated
 }
 
 func (m *manager) checkExpiredCacheEntries(cEntryRequests entryRequests) error {
 	defer m.c.Tel.MeasureSince([]string{"cache_manager", "expiry_check_duration"}, time.Now())
 
+	now := time.Now()
+	expiring := 0
+	expiringSoon := 0
 	for _, entry := range m.cache.Entries() {
-		ttl := entry.SVID.NotAfter.Sub(time.Now())
-		lifetime := entry.SVID.NotAfter.Sub(entry.SVID.NotBefore)
-		// If the cached SVID has a remaining lifetime less than 50%, prepare a
-		// new entryRequest.
-		if ttl < lifetime/2 {
-			m.c.Log.Debugf("cache entry ttl for spiffeId %s is less than a half its lifetime", entry.RegistrationEntry.SpiffeId)
+		// Expired SVIDs are not expiring soon anymore
+		if !entry.SVID.NotAfter.Before(now) && entry.SVID.NotAfter.Before(now.Add(m.c.ExpiringSVIDThreshold)) {
+			expiringSoon++
+		}
+		if _, ok := cEntryRequests[entry.RegistrationEntry.EntryId]; ok {
+			continue
+		}
+		// If the cached SVID has a remaining lifetime less than the rotation
+		// threshold, prepare a new entryRequest.
+		if svid.ShouldRotate(entry.SVID.NotBefore, entry.SVID.NotAfter, now, m.c.RotationThreshold) {
+			m.c.Log.Debugf("cache entry ttl for spiffeId %s is less than %d%% of its lifetime", entry.RegistrationEntry.SpiffeId, m.c.RotationThreshold)
 			privateKey, csr, err := m.newCSR(entry.RegistrationEntry.SpiffeId)
 			if err != nil {
 				return err
 			}
 
-			bundles := make(map[string][]byte) //TODO: Populate Bundles
 			cacheEntry := &cache.Entry{
 				RegistrationEntry: entry.RegistrationEntry,
 				SVID:              nil,
 				PrivateKey:        privateKey,
-				Bundles:           bundles,
+				Bundles:           m.entryBundles(entry.RegistrationEntry),
 			}
 			cEntryRequests.add(&entryRequest{csr, cacheEntry})
+			expiring++
 		}
 	}
 
-	m.c.Tel.AddSample([]string{"cache_manager", "expiring_svids"}, float32(len(cEntryRequests)))
+	m.c.Tel.AddSample([]string{"cache_manager", "expiring_svids"}, float32(expiring))
+	m.c.Tel.SetGauge([]string{"cache_manager", "expiring_soon_svids"}, float32(expiringSoon))
+	return nil
+}
+
+// checkForcedRotations requests new SVIDs, with new keys, for the cache
+// entries whose rotation was forced on the server after they were issued.
+func (m *manager) checkForcedRotations(regEntries map[string]*proto.RegistrationEntry, cEntryRequests entryRequests) error {
+	for _, entry := range m.cache.Entries() {
+		regEntry, ok := regEntries[entry.RegistrationEntry.EntryId]
+		if !ok || regEntry.RotatedAt <= entry.RegistrationEntry.RotatedAt {
+			continue
+		}
+
+		m.c.Log.Infof("Rotation of the SVID for %v was forced", regEntry.SpiffeId)
+		if err := m.addEntryRequest(regEntry, cEntryRequests); err != nil {
+			return err
+		}
+		m.c.Tel.IncrCounter([]string{"cache_manager", "forced_rotations"}, 1)
+	}
 	return nil
 }
 
 func (m *manager) checkForNewCacheEntries(regEntries map[string]*proto.RegistrationEntry, cEntryRequests entryRequests) error {
 	for _, regEntry := range regEntries {
-		if !m.isAlreadyCached(regEntry) {
-			privateKey, csr, err := m.newCSR(regEntry.SpiffeId)
-			if err != nil {
-				return err
-			}
+		if m.isAlreadyCached(regEntry) {
+			continue
+		}
+		// Lazily minted SVIDs are only requested for connected workloads
+		if m.c.LazySVIDMinting && !m.cache.HasSubscribers(regEntry.Selectors) {
+			continue
+		}
 
-			bundles := make(map[string][]byte) //TODO: Populate Bundles
-			cacheEntry := &cache.Entry{
-				RegistrationEntry: regEntry,
-				SVID:              nil,
-				PrivateKey:        privateKey,
-				Bundles:           bundles,
-			}
-			cEntryRequests.add(&entryRequest{csr, cacheEntry})
+		if err := m.addEntryRequest(regEntry, cEntryRequests); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// mintEntries requests the SVIDs of the registration entries matching the
+// selectors that are not cached yet, so a workload connecting for the first
+// time is served right away when SVIDs are minted lazily. The server is not
+// called while holding syncMtx, and entries already being minted for another
+// workload are waited on rather than requested again. Failures are logged,
+// the SVIDs are requested again on the next synchronization.
+func (m *manager) mintEntries(selectors []*proto.Selector) {
+	ctx, cancel := context.WithTimeout(context.Background(), mintTimeout)
+	defer cancel()
+
+	cEntryRequests, pending := m.claimEntries(selectors)
+	if len(cEntryRequests) > 0 {
+		if err := m.mintEntryRequests(ctx, cEntryRequests); err != nil {
+			m.c.Log.Warnf("could not mint SVIDs on demand: %v", err)
+		}
+		m.releaseEntries(cEntryRequests)
+	}
+
+	for _, done := range pending {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// claimEntries returns the requests for the SVIDs of the registration
+// entries matching the selectors that are neither cached nor being minted,
+// and marks them as being minted. The channels of the entries being minted
+// already are returned so the caller can wait on them. Entries whose request
+// cannot be built are skipped.
+func (m *manager) claimEntries(selectors []*proto.Selector) (entryRequests, []chan struct{}) {
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+	m.mintMtx.Lock()
+	defer m.mintMtx.Unlock()
+
+	set := selector.NewSetFromRaw(selectors)
+	cEntryRequests := entryRequests{}
+	var pending []chan struct{}
+	for _, regEntry := range m.regEntries {
+		if m.isAlreadyCached(regEntry) || !set.IncludesSet(selector.NewSetFromRaw(regEntry.Selectors)) {
+			continue
+		}
+		if done, ok := m.minting[regEntry.EntryId]; ok {
+			pending = append(pending, done)
+			continue
+		}
+		if err := m.addEntryRequest(regEntry, cEntryRequests); err != nil {
+			m.c.Log.Warnf("could not request SVID for %v: %v", regEntry.SpiffeId, err)
+			continue
+		}
+		m.minting[regEntry.EntryId] = make(chan struct{})
+	}
+	return cEntryRequests, pending
+}
+
+// releaseEntries unmarks the entries claimed by claimEntries, waking up the
+// requests waiting on them.
+func (m *manager) releaseEntries(cEntryRequests entryRequests) {
+	m.mintMtx.Lock()
+	defer m.mintMtx.Unlock()
+
+	for entryID := range cEntryRequests {
+		if done, ok := m.minting[entryID]; ok {
+			close(done)
+			delete(m.minting, entryID)
+		}
+	}
+}
+
+// mintEntryRequests fetches the SVIDs of the entry requests and caches them.
+// Only the SVIDs are taken from the server response, the bundles and
+// entries are left to the synchronizer.
+func (m *manager) mintEntryRequests(ctx context.Context, cEntryRequests entryRequests) error {
+	csrs := [][]byte{}
+	for _, entryRequest := range cEntryRequests {
+		m.c.Log.Debugf("Requesting SVID for %v", entryRequest.entry.RegistrationEntry.SpiffeId)
+		csrs = append(csrs, entryRequest.CSR)
+	}
+
+	update, err := m.client.FetchUpdates(ctx, &node.FetchX509SVIDRequest{Csrs: csrs})
+	if err != nil {
+		return err
+	}
+
+	m.syncMtx.Lock()
+	defer m.syncMtx.Unlock()
+
+	// The entries removed by a synchronization in the meantime are not
+	// cached again
+	current := entryRequests{}
+	for entryID, entryRequest := range cEntryRequests {
+		if _, ok := m.regEntries[entryID]; ok {
+			current.add(entryRequest)
+		}
+	}
+	if err := m.updateEntriesSVIDs(current, update.SVIDs); err != nil {
+		return err
+	}
+	m.storeEntries()
+	return nil
+}
+
+// clearUnusedCacheEntries removes the cache entries no workload is
+// subscribed to once their SVID is due for rotation, rather than renewing
+// it. Returns true if any entry was removed.
+func (m *manager) clearUnusedCacheEntries() (cleared bool) {
+	now := time.Now()
+	for _, entry := range m.cache.Entries() {
+		if !svid.ShouldRotate(entry.SVID.NotBefore, entry.SVID.NotAfter, now, m.c.RotationThreshold) {
+			continue
+		}
+		if m.cache.HasSubscribers(entry.RegistrationEntry.Selectors) {
+			continue
+		}
+		m.c.Log.Debugf("Dropping unused SVID for %v", entry.RegistrationEntry.SpiffeId)
+		m.cache.DeleteEntry(entry.RegistrationEntry)
+		cleared = true
+	}
+	return cleared
+}
+
+// addEntryRequest adds a request for the SVID of the registration entry,
+// with a new private key.
+func (m *manager) addEntryRequest(regEntry *proto.RegistrationEntry, cEntryRequests entryRequests) error {
+	privateKey, csr, err := m.newCSR(regEntry.SpiffeId)
+	if err != nil {
+		return err
+	}
+
+	cacheEntry := &cache.Entry{
+		RegistrationEntry: regEntry,
+		SVID:              nil,
+		PrivateKey:        privateKey,
+		Bundles:           m.entryBundles(regEntry),
+	}
+	cEntryRequests.add(&entryRequest{csr, cacheEntry})
+	return nil
+}
+
+// refreshJWTSVIDs renews the cached JWT-SVIDs that reached the rotation
+// threshold, so workloads are not kept waiting on the server when they ask for
+// them. JWT-SVIDs of SPIFFE IDs the agent is no longer entitled to are
+// dropped.
+func (m *manager) refreshJWTSVIDs(regEntries map[string]*proto.RegistrationEntry) {
+	spiffeIDs := make(map[string]bool)
+	for _, regEntry := range regEntries {
+		spiffeIDs[regEntry.SpiffeId] = true
+	}
+
+	now := time.Now()
+	for _, entry := range m.jwtSVIDs.Entries() {
+		if !spiffeIDs[entry.SpiffeID] {
+			m.jwtSVIDs.DeleteJWTSVID(entry.SpiffeID, entry.Audience)
+			continue
+		}
+		if !m.jwtSVIDNeedsRefresh(entry.SVID, now) {
+			continue
+		}
+
+		m.c.Log.Debugf("Renewing JWT-SVID for %v", entry.SpiffeID)
+		newSVID, err := m.client.FetchJWTSVID(context.Background(), &node.JSR{
+			SpiffeId: entry.SpiffeID,
+			Audience: entry.Audience,
+		})
+		switch {
+		case err == nil:
+			m.jwtSVIDs.SetJWTSVID(entry.SpiffeID, entry.Audience, newSVID)
+		case !m.servable(entry.SVID.IssuedAt, entry.SVID.ExpiresAt, now):
+			m.c.Log.Warnf("unable to renew JWT-SVID for %s, it is no longer served: %v", entry.SpiffeID, err)
+			m.jwtSVIDs.DeleteJWTSVID(entry.SpiffeID, entry.Audience)
+		default:
+			m.c.Log.Warnf("unable to renew JWT-SVID for %s: %v", entry.SpiffeID, err)
+		}
+	}
+}
+
+// jwtSVIDNeedsRefresh returns true if the JWT-SVID has a remaining lifetime
+// of less than the rotation threshold
+func (m *manager) jwtSVIDNeedsRefresh(jwtSVID *client.JWTSVID, now time.Time) bool {
+	return svid.ShouldRotate(jwtSVID.IssuedAt, jwtSVID.ExpiresAt, now, m.c.RotationThreshold)
+}
+
+// evict discards the cached SVIDs and keys after the server has evicted the
+// agent, so workloads are no longer served. The cached agent SVID is removed
+// as well, forcing the agent to attest again on its next start.
+func (m *manager) evict() {
+	m.c.Log.Warn("Agent has been evicted by the server, discarding cached SVIDs and keys")
+
+	for _, entry := range m.cache.Entries() {
+		m.cache.DeleteEntry(entry.RegistrationEntry)
+	}
+	for _, entry := range m.jwtSVIDs.Entries() {
+		m.jwtSVIDs.DeleteJWTSVID(entry.SpiffeID, entry.Audience)
+	}
+
+	if err := DeleteSVID(m.svidCachePath); err != nil {
+		m.c.Log.Errorf("could not delete SVID: %v", err)
+	}
+	if m.entryCachePath != "" {
+		if err := DeleteEntries(m.entryCachePath); err != nil {
+			m.c.Log.Errorf("could not delete cached entries: %v", err)
+		}
+	}
+}
+
 func (m *manager) newCSR(spiffeID string) (pk *ecdsa.PrivateKey, csr []byte, err error) {
 	pk, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
@@ -179,6 +549,42 @@ func (m *manager) newCSR(spiffeID string) (pk *ecdsa.PrivateKey, csr []byte, err
 	return
 }
 
+// entryBundles returns the federated bundles for the trust domains the given
+// registration entry federates with.
+func (m *manager) entryBundles(regEntry *proto.RegistrationEntry) map[string][]byte {
+	bundles := make(map[string][]byte)
+	for _, trustDomain := range regEntry.FederatesWith {
+		if bundle, ok := m.federatedBundles[trustDomain]; ok {
+			bundles[trustDomain] = bundle
+		}
+	}
+	return bundles
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func bundlesEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for trustDomain, bundle := range a {
+		if other, ok := b[trustDomain]; !ok || !bytes.Equal(bundle, other) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *manager) bundleAlreadyCached(bundle []*x509.Certificate) bool {
 	currentBundle := m.cache.Bundle()
 
@@ -199,6 +605,38 @@ func (m *manager) bundleAlreadyCached(bundle []*x509.Certificate) bool {
 	return true
 }
 
+// jwtSigningKeysAlreadyCached returns true if the cache holds the same JWT
+// signing keys, by key ID.
+func (m *manager) jwtSigningKeysAlreadyCached(keys map[string]crypto.PublicKey) bool {
+	currentKeys := m.cache.JWTSigningKeys()
+	if len(keys) != len(currentKeys) {
+		return false
+	}
+	for kid := range keys {
+		if _, ok := currentKeys[kid]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseJWTSigningKeys parses the JWT signing keys published in the bundle,
+// keyed by key ID.
+func parseJWTSigningKeys(pbKeys []*common.PublicKey) (map[string]crypto.PublicKey, error) {
+	if len(pbKeys) == 0 {
+		return nil, nil
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, pbKey := range pbKeys {
+		key, err := x509.ParsePKIXPublicKey(pbKey.PkixBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse JWT signing key %q: %v", pbKey.Kid, err)
+		}
+		keys[pbKey.Kid] = key
+	}
+	return keys, nil
+}
+
 // entryRequest holds a CSR and a pre-built cache entry for the RegistrationEntry
 // contained in the entry field.
 type entryRequest struct {
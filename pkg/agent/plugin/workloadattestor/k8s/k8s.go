@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -47,9 +48,10 @@ type k8sPluginConfig struct {
 }
 
 type podInfo struct {
-	// We only care about namespace, serviceAccountName and containerID
+	// We only care about namespace, labels, serviceAccountName and containerID
 	Metadata struct {
-		Namespace string `json:"namespace"`
+		Namespace string            `json:"namespace"`
+		Labels    map[string]string `json:"labels"`
 	} `json:"metadata"`
 	Spec struct {
 		ServiceAccountName string `json:"serviceAccountName"`
@@ -242,10 +244,23 @@ func getCgroups(path string, fs fileSystem) (cgroups [][]string, err error) {
 }
 
 func getSelectorsFromPodInfo(info *podInfo) []*common.Selector {
-	return []*common.Selector{
+	selectors := []*common.Selector{
 		{Type: selectorType, Value: fmt.Sprintf("sa:%v", info.Spec.ServiceAccountName)},
 		{Type: selectorType, Value: fmt.Sprintf("ns:%v", info.Metadata.Namespace)},
 	}
+
+	var keys []string
+	for key := range info.Metadata.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("pod-label:%v:%v", key, info.Metadata.Labels[key]),
+		})
+	}
+	return selectors
 }
 
 func (p *k8sPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
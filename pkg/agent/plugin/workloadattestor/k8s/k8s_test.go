@@ -221,3 +221,17 @@ func TestK8s_GetPluginInfo(t *testing.T) {
 	assert.Equal(t, &spi.GetPluginInfoResponse{}, data)
 	assert.Equal(t, nil, e)
 }
+
+func TestK8s_GetSelectorsFromPodInfo(t *testing.T) {
+	info := &podInfo{}
+	info.Metadata.Namespace = "default"
+	info.Metadata.Labels = map[string]string{"tier": "web", "app": "blog"}
+	info.Spec.ServiceAccountName = "blog"
+
+	var values []string
+	for _, selector := range getSelectorsFromPodInfo(info) {
+		require.Equal(t, "k8s", selector.Type)
+		values = append(values, selector.Value)
+	}
+	require.Equal(t, []string{"sa:blog", "ns:default", "pod-label:app:blog", "pod-label:tier:web"}, values)
+}
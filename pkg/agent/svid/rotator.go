@@ -13,14 +13,23 @@ import (
 	"github.com/imkira/go-observer"
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/proto/api/node"
 )
 
+// DefaultRotationThreshold is the default percentage of the SVID lifetime
+// remaining at which SVIDs are rotated
+const DefaultRotationThreshold = 50
+
 type Rotator interface {
 	Run(ctx context.Context) error
 
 	State() State
 	Subscribe() observer.Stream
+
+	// ForceRotation makes the rotator rotate the SVID as soon as possible,
+	// e.g. because the CA which signed it is tainted
+	ForceRotation()
 }
 
 type rotator struct {
@@ -31,11 +40,19 @@ type rotator struct {
 
 	// Mutex used to protect access to c.BundleStream.
 	bsm *sync.RWMutex
+
+	// Receives a value when the rotation is forced
+	forceRotation chan struct{}
 }
 
 type State struct {
 	SVID *x509.Certificate
 	Key  *ecdsa.PrivateKey
+
+	// Intermediates are the CA certificates between the SVID and the
+	// bundle, presented along with the SVID. They are only set when the CA
+	// of the server is an intermediate of the upstream CA.
+	Intermediates []*x509.Certificate
 }
 
 // Run runs the rotator. It monitors the server SVID for expiration and rotates
@@ -52,10 +69,11 @@ func (r *rotator) Run(ctx context.Context) error {
 			return nil
 		case <-t.C:
 			if r.shouldRotate() {
-				if err := r.rotateSVID(); err != nil {
-					r.c.Log.Errorf("Could not rotate agent SVID: %v", err)
-				}
+				r.rotate(ctx)
 			}
+		case <-r.forceRotation:
+			r.c.Log.Info("Forcing the rotation of the agent SVID")
+			r.rotate(ctx)
 		case <-r.c.BundleStream.Changes():
 			r.bsm.Lock()
 			r.c.BundleStream.Next()
@@ -72,13 +90,67 @@ func (r *rotator) Subscribe() observer.Stream {
 	return r.state.Observe()
 }
 
+func (r *rotator) ForceRotation() {
+	// A rotation already pending covers this one
+	select {
+	case r.forceRotation <- struct{}{}:
+	default:
+	}
+}
+
+// rotate rotates the SVID, falling back on node attestation if it is about to
+// expire and cannot be rotated
+func (r *rotator) rotate(ctx context.Context) {
+	if err := r.rotateSVID(); err != nil {
+		r.c.Log.Errorf("Could not rotate agent SVID: %v", err)
+		if r.shouldReattest() {
+			if err := r.reattest(ctx); err != nil {
+				r.c.Log.Errorf("Could not re-attest agent: %v", err)
+			}
+		}
+	}
+}
+
 // shouldRotate returns a boolean informing the caller of whether or not the
 // SVID should be rotated.
 func (r *rotator) shouldRotate() bool {
 	s := r.state.Value().(State)
 
-	ttl := time.Until(s.SVID.NotAfter)
-	watermark := s.SVID.NotAfter.Sub(s.SVID.NotBefore) / 2
+	return ShouldRotate(s.SVID.NotBefore, s.SVID.NotAfter, time.Now(), r.c.RotationThreshold)
+}
+
+// shouldReattest returns true if the SVID has expired, or will before the
+// next expiry check, and the agent is able to re-attest.
+func (r *rotator) shouldReattest() bool {
+	if r.c.Reattest == nil {
+		return false
+	}
+	s := r.state.Value().(State)
+
+	return s.SVID.NotAfter.Sub(time.Now()) < r.c.Interval
+}
+
+// reattest obtains a new agent SVID by performing node attestation again,
+// which does not require a valid SVID, unlike rotation.
+func (r *rotator) reattest(ctx context.Context) error {
+	r.c.Log.Info("Agent SVID could not be rotated before it expires. Performing node attestation")
+
+	s, err := r.c.Reattest(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.client.Release()
+
+	r.state.Update(s)
+	return nil
+}
+
+// ShouldRotate returns true if, at the given time, less than threshold
+// percent of the lifetime between notBefore and notAfter remains.
+func ShouldRotate(notBefore, notAfter, now time.Time, threshold int) bool {
+	ttl := notAfter.Sub(now)
+	watermark := notAfter.Sub(notBefore) * time.Duration(threshold) / 100
 
 	return ttl < watermark
 }
@@ -97,7 +169,7 @@ func (r *rotator) rotateSVID() error {
 		return err
 	}
 
-	update, err := r.client.FetchUpdates(&node.FetchX509SVIDRequest{Csrs: [][]byte{csr}})
+	update, err := r.client.FetchUpdates(context.Background(), &node.FetchX509SVIDRequest{Csrs: [][]byte{csr}})
 	if err != nil {
 		return err
 	}
@@ -110,7 +182,7 @@ func (r *rotator) rotateSVID() error {
 	if !ok {
 		return errors.New("it was not possible to get agent SVID from FetchX509SVID response")
 	}
-	cert, err := x509.ParseCertificate(svid.SvidCert)
+	cert, intermediates, err := x509svid.ParseChain(svid.SvidCert)
 	if err != nil {
 		return err
 	}
@@ -121,8 +193,9 @@ func (r *rotator) rotateSVID() error {
 	r.client.Release()
 
 	s := State{
-		SVID: cert,
-		Key:  key,
+		SVID:          cert,
+		Key:           key,
+		Intermediates: intermediates,
 	}
 
 	r.state.Update(s)
@@ -1,6 +1,7 @@
 package svid
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"net"
@@ -18,9 +19,11 @@ type RotatorConfig struct {
 	Log         logrus.FieldLogger
 	TrustDomain url.URL
 	ServerAddr  net.Addr
-	// Initial SVID and key
-	SVID    *x509.Certificate
-	SVIDKey *ecdsa.PrivateKey
+	// Initial SVID and key, and the intermediates presented along with the
+	// SVID
+	SVID              *x509.Certificate
+	SVIDKey           *ecdsa.PrivateKey
+	SVIDIntermediates []*x509.Certificate
 
 	BundleStream observer.Stream
 
@@ -28,6 +31,15 @@ type RotatorConfig struct {
 
 	// How long to wait between expiry checks
 	Interval time.Duration
+
+	// Percentage of the SVID lifetime remaining at which the SVID is
+	// rotated. Defaults to DefaultRotationThreshold.
+	RotationThreshold int
+
+	// Reattest performs node attestation to obtain a new SVID and key. It
+	// is used when the SVID could not be rotated before it expires. The
+	// agent does not re-attest if nil.
+	Reattest func(ctx context.Context) (State, error)
 }
 
 func NewRotator(c *RotatorConfig) (*rotator, client.Client) {
@@ -35,9 +47,14 @@ func NewRotator(c *RotatorConfig) (*rotator, client.Client) {
 		c.Interval = 60 * time.Second
 	}
 
+	if c.RotationThreshold == 0 {
+		c.RotationThreshold = DefaultRotationThreshold
+	}
+
 	state := observer.NewProperty(State{
-		SVID: c.SVID,
-		Key:  c.SVIDKey,
+		SVID:          c.SVID,
+		Key:           c.SVIDKey,
+		Intermediates: c.SVIDIntermediates,
 	})
 
 	bsm := &sync.RWMutex{}
@@ -45,20 +62,21 @@ func NewRotator(c *RotatorConfig) (*rotator, client.Client) {
 		TrustDomain: c.TrustDomain,
 		Log:         c.Log,
 		Addr:        c.ServerAddr,
-		KeysAndBundle: func() (*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
+		KeysAndBundle: func() ([]*x509.Certificate, *ecdsa.PrivateKey, []*x509.Certificate) {
 			s := state.Value().(State)
 			bsm.RLock()
 			defer bsm.RUnlock()
 			bundle := c.BundleStream.Value().([]*x509.Certificate)
-			return s.SVID, s.Key, bundle
+			return append([]*x509.Certificate{s.SVID}, s.Intermediates...), s.Key, bundle
 		},
 	}
 	client := client.New(cfg)
 
 	return &rotator{
-		c:      c,
-		client: client,
-		state:  state,
-		bsm:    bsm,
+		c:             c,
+		client:        client,
+		state:         state,
+		bsm:           bsm,
+		forceRotation: make(chan struct{}, 1),
 	}, client
 }
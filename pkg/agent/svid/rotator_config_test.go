@@ -9,4 +9,7 @@ func TestNewRotator(t *testing.T) {
 	if r.c.Interval == 0 {
 		t.Error("svid rotator interval should not be 0")
 	}
+	if r.c.RotationThreshold != DefaultRotationThreshold {
+		t.Errorf("svid rotator threshold should default to %d, got %d", DefaultRotationThreshold, r.c.RotationThreshold)
+	}
 }
@@ -3,6 +3,7 @@ package svid
 import (
 	"context"
 	"crypto/x509"
+	"errors"
 	"net/url"
 	"testing"
 	"time"
@@ -162,6 +163,61 @@ func (s *RotatorTestSuite) TestShouldRotate() {
 	s.Assert().True(s.r.shouldRotate())
 }
 
+func (s *RotatorTestSuite) TestShouldRotateThreshold() {
+	notBefore := time.Now().Add(-30 * time.Minute)
+	notAfter := notBefore.Add(time.Hour)
+
+	// half of the lifetime remains
+	s.Assert().False(ShouldRotate(notBefore, notAfter, time.Now(), 40))
+	s.Assert().True(ShouldRotate(notBefore, notAfter, time.Now(), 60))
+
+	s.r.c.RotationThreshold = 60
+	temp, err := util.NewSVIDTemplate("spiffe://example.org/test")
+	s.Require().NoError(err)
+	temp.NotBefore = notBefore
+	temp.NotAfter = notAfter
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.r.state = observer.NewProperty(State{SVID: cert})
+	s.Assert().True(s.r.shouldRotate())
+}
+
+func (s *RotatorTestSuite) TestRunWithForcedRotation() {
+	// Cert that's valid for 1hr, and not due for rotation
+	temp, err := util.NewSVIDTemplate("spiffe://example.org/test")
+	s.Require().NoError(err)
+	oldCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	newCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{SVID: oldCert})
+	s.expectSVIDRotation(newCert)
+
+	stream := s.r.Subscribe()
+
+	// Forcing the rotation again before it happens is a no-op
+	s.r.ForceRotation()
+	s.r.ForceRotation()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := new(tomb.Tomb)
+	t.Go(func() error {
+		return s.r.Run(ctx)
+	})
+
+	select {
+	case <-time.NewTimer(5 * time.Second).C:
+		s.T().Error("SVID rotation timeout reached")
+	case <-stream.Changes():
+		state := stream.Next().(State)
+		s.Assert().Equal(newCert, state.SVID)
+	}
+
+	cancel()
+	s.Require().NoError(t.Wait())
+}
+
 func (s *RotatorTestSuite) TestRotateSVID() {
 	cert, _, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
@@ -176,11 +232,106 @@ func (s *RotatorTestSuite) TestRotateSVID() {
 	s.Assert().True(cert.Equal(state.SVID))
 }
 
+func (s *RotatorTestSuite) TestRotateSVIDKeepsIntermediates() {
+	caTemplate, err := util.NewCATemplate("example.org")
+	s.Require().NoError(err)
+	ca, caKey, err := util.SelfSign(caTemplate)
+	s.Require().NoError(err)
+	template, err := util.NewSVIDTemplate(s.r.c.SpiffeID)
+	s.Require().NoError(err)
+	cert, _, err := util.Sign(template, ca, caKey)
+	s.Require().NoError(err)
+
+	// The CA of the server is sent along with the SVID when it is an
+	// intermediate of the upstream CA
+	stream := s.r.Subscribe()
+	s.client.EXPECT().
+		FetchUpdates(gomock.Any(), gomock.Any()).
+		Return(&client.Update{
+			SVIDs: map[string]*node.Svid{
+				s.r.c.SpiffeID: {
+					SvidCert: append(append([]byte(nil), cert.Raw...), ca.Raw...),
+				},
+			},
+		}, nil)
+	s.client.EXPECT().Release()
+	s.Require().NoError(s.r.rotateSVID())
+	s.Require().True(stream.HasNext())
+
+	state := stream.Next().(State)
+	s.Assert().True(cert.Equal(state.SVID))
+	s.Require().Len(state.Intermediates, 1)
+	s.Assert().True(ca.Equal(state.Intermediates[0]))
+}
+
+func (s *RotatorTestSuite) TestRunReattestsWhenRotationFails() {
+	temp, err := util.NewSVIDTemplate("spiffe://example.org/test")
+	s.Require().NoError(err)
+	goodCert, goodKey, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	// Cert that has expired
+	temp.NotBefore = time.Now().Add(-2 * time.Hour)
+	temp.NotAfter = time.Now().Add(-1 * time.Hour)
+	badCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{SVID: badCert})
+	s.r.c.Interval = 10 * time.Millisecond
+	s.r.c.Reattest = func(ctx context.Context) (State, error) {
+		return State{SVID: goodCert, Key: goodKey}, nil
+	}
+
+	s.client.EXPECT().FetchUpdates(gomock.Any(), gomock.Any()).Return(nil, errors.New("expired certificate"))
+	s.client.EXPECT().Release().MaxTimes(2)
+
+	stream := s.r.Subscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := new(tomb.Tomb)
+	t.Go(func() error {
+		return s.r.Run(ctx)
+	})
+
+	select {
+	case <-time.NewTimer(5 * time.Second).C:
+		s.T().Error("re-attestation timeout reached")
+	case <-stream.Changes():
+		state := stream.Next().(State)
+		s.Assert().Equal(goodCert, state.SVID)
+		s.Assert().Equal(goodKey, state.Key)
+	}
+
+	cancel()
+	s.Require().NoError(t.Wait())
+}
+
+func (s *RotatorTestSuite) TestShouldReattest() {
+	temp, err := util.NewSVIDTemplate("spiffe://example.org/test")
+	s.Require().NoError(err)
+	cert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+	s.r.state = observer.NewProperty(State{SVID: cert})
+
+	// Re-attestation is not configured
+	s.r.c.Interval = 2 * time.Hour
+	s.Assert().False(s.r.shouldReattest())
+
+	s.r.c.Reattest = func(ctx context.Context) (State, error) {
+		return State{}, errors.New("not expected")
+	}
+	s.Assert().True(s.r.shouldReattest())
+
+	// There is still time to rotate
+	s.r.c.Interval = time.Minute
+	s.Assert().False(s.r.shouldReattest())
+}
+
 // expectSVIDRotation sets the appropriate expectations for an SVID rotation, and returns
 // the the provided certificate to the client.Client caller.
 func (s *RotatorTestSuite) expectSVIDRotation(cert *x509.Certificate) {
 	s.client.EXPECT().
-		FetchUpdates(gomock.Any()).
+		FetchUpdates(gomock.Any(), gomock.Any()).
 		Return(&client.Update{
 			SVIDs: map[string]*node.Svid{
 				s.r.c.SpiffeID: {
@@ -0,0 +1,9 @@
+// +build !windows
+
+package agent
+
+import "syscall"
+
+func setUmask(umask int) {
+	syscall.Umask(umask)
+}
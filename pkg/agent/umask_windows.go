@@ -0,0 +1,7 @@
+// +build windows
+
+package agent
+
+// setUmask is a no-op on Windows, which has no umask. Files created by the
+// agent inherit the ACLs of data_dir instead.
+func setUmask(umask int) {}
@@ -0,0 +1,63 @@
+package acmeutil
+
+import (
+	"crypto/tls"
+	"errors"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Config configures the acquisition of a Web PKI certificate from an ACME CA
+type Config struct {
+	// DNS name the certificate is requested for. The server has to be
+	// reachable on port 443 of that name for the CA to validate it.
+	DomainName string
+
+	// URL of the ACME directory. Defaults to Let's Encrypt.
+	DirectoryURL string
+
+	// Contact email address registered with the ACME CA, if any
+	Email string
+
+	// Directory to keep the account key and certificates in across restarts.
+	// They are only kept in memory if empty, which quickly runs into the
+	// rate limits of the CA.
+	CacheDir string
+
+	// Whether the terms of service of the ACME CA are accepted. Required.
+	ToSAccepted bool
+}
+
+// TLSConfig returns a TLS configuration obtaining the certificate from the
+// ACME CA on the first handshake, and renewing it before it expires. Domain
+// validation is answered on the same listener, through the tls-alpn-01
+// challenge.
+func TLSConfig(c *Config) (*tls.Config, error) {
+	if c.DomainName == "" {
+		return nil, errors.New("the domain name to obtain the certificate for is required")
+	}
+	if !c.ToSAccepted {
+		return nil, errors.New("the terms of service of the ACME CA must be accepted")
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(c.DomainName),
+		Email:      c.Email,
+		Client:     &acme.Client{DirectoryURL: c.DirectoryURL},
+	}
+	if c.CacheDir != "" {
+		manager.Cache = autocert.DirCache(c.CacheDir)
+	}
+
+	tlsConfig := manager.TLSConfig()
+	tlsConfig.GetCertificate = func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		// Clients connecting by IP address send no server name
+		if hello.ServerName == "" {
+			hello.ServerName = c.DomainName
+		}
+		return manager.GetCertificate(hello)
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,92 @@
+package acmeutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/spiffe/spire/test/fakes/fakeacme"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSConfig(t *testing.T) {
+	ca, err := fakeacme.New()
+	require.NoError(t, err)
+	defer ca.Close()
+
+	dir, err := ioutil.TempDir("", "acmeutil-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	tlsConfig, err := TLSConfig(&Config{
+		DomainName:   "bundle.example.org",
+		DirectoryURL: ca.URL,
+		CacheDir:     dir,
+		ToSAccepted:  true,
+	})
+	require.NoError(t, err)
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	require.NoError(t, err)
+	defer l.Close()
+	ca.Resolve("bundle.example.org", l.Addr().String())
+	go acceptAndHandshake(l)
+
+	// The certificate is obtained on the first handshake, the domain being
+	// validated over the same listener
+	cert := handshake(t, l.Addr().String(), "bundle.example.org", ca)
+	require.Equal(t, []string{"bundle.example.org"}, cert.DNSNames)
+	require.Equal(t, 1, ca.Issued())
+
+	// Clients connecting by IP address get the certificate of the domain,
+	// which is not requested again
+	cert = handshake(t, l.Addr().String(), "", ca)
+	require.Equal(t, []string{"bundle.example.org"}, cert.DNSNames)
+	require.Equal(t, 1, ca.Issued())
+
+	// Other domains are refused
+	_, err = tls.Dial("tcp", l.Addr().String(), &tls.Config{
+		ServerName: "other.example.org",
+		RootCAs:    ca.Roots,
+	})
+	require.Error(t, err)
+}
+
+func TestTLSConfigRequiresToS(t *testing.T) {
+	_, err := TLSConfig(&Config{DomainName: "bundle.example.org"})
+	require.EqualError(t, err, "the terms of service of the ACME CA must be accepted")
+
+	_, err = TLSConfig(&Config{ToSAccepted: true})
+	require.EqualError(t, err, "the domain name to obtain the certificate for is required")
+}
+
+func handshake(t *testing.T, addr, serverName string, ca *fakeacme.CA) *x509.Certificate {
+	config := &tls.Config{
+		ServerName: serverName,
+		RootCAs:    ca.Roots,
+	}
+	if serverName == "" {
+		// The certificate is for the domain, not the IP address
+		config.InsecureSkipVerify = true
+	}
+	conn, err := tls.Dial("tcp", addr, config)
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0]
+}
+
+func acceptAndHandshake(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}()
+	}
+}
@@ -0,0 +1,284 @@
+package bundleutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const (
+	// x509SVIDUse and jwtSVIDUse are the uses of the keys of X509-SVID and
+	// JWT-SVID authorities in SPIFFE bundles
+	x509SVIDUse = "x509-svid"
+	jwtSVIDUse  = "jwt-svid"
+
+	// signatureUse is the use of the keys of JWK sets served to OpenID
+	// Connect relying parties
+	signatureUse = "sig"
+)
+
+// Bundle is a trust bundle, as exchanged in the SPIFFE bundle format: a JWK
+// set holding the X509-SVID and JWT-SVID authorities of a trust domain
+type Bundle struct {
+	// CA certificates of the X509-SVID authorities
+	RootCAs []*x509.Certificate
+
+	// Public keys of the JWT-SVID authorities, keyed by key ID
+	JWTSigningKeys map[string]crypto.PublicKey
+
+	// How often the bundle should be fetched again. Zero if not advised.
+	RefreshHint time.Duration
+
+	// Sequence number of the bundle, increased every time it changes. Zero
+	// if not kept.
+	Sequence uint64
+}
+
+type document struct {
+	Keys        []key  `json:"keys"`
+	RefreshHint int64  `json:"spiffe_refresh_hint,omitempty"`
+	Sequence    uint64 `json:"spiffe_sequence,omitempty"`
+}
+
+type key struct {
+	Use     string `json:"use"`
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid,omitempty"`
+
+	// Algorithm the key signs with. Only set in JWK sets, as SPIFFE bundles
+	// leave it to the JWT-SVIDs.
+	Algorithm string `json:"alg,omitempty"`
+
+	// EC keys
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// The CA certificate of X509-SVID authorities, base64 encoded ASN.1 DER
+	X509Chain []string `json:"x5c,omitempty"`
+}
+
+// Marshal encodes the bundle in the SPIFFE bundle format. The JWT-SVID
+// authorities are sorted by key ID.
+func Marshal(bundle *Bundle) ([]byte, error) {
+	doc := document{
+		Keys:        []key{},
+		RefreshHint: int64(bundle.RefreshHint / time.Second),
+		Sequence:    bundle.Sequence,
+	}
+
+	for _, cert := range bundle.RootCAs {
+		k := key{
+			Use:       x509SVIDUse,
+			X509Chain: []string{base64.StdEncoding.EncodeToString(cert.Raw)},
+		}
+		if err := setPublicKey(&k, cert.PublicKey); err != nil {
+			return nil, err
+		}
+		doc.Keys = append(doc.Keys, k)
+	}
+
+	var keyIDs []string
+	for keyID := range bundle.JWTSigningKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		k := key{
+			Use:   jwtSVIDUse,
+			KeyID: keyID,
+		}
+		if err := setPublicKey(&k, bundle.JWTSigningKeys[keyID]); err != nil {
+			return nil, err
+		}
+		doc.Keys = append(doc.Keys, k)
+	}
+
+	return json.Marshal(doc)
+}
+
+// MarshalJWKS encodes the JWT signing keys as a plain JWK set, with the
+// "sig" use and the algorithm of each key set, as OpenID Connect relying
+// parties expect. The keys are sorted by key ID.
+func MarshalJWKS(jwtSigningKeys map[string]crypto.PublicKey) ([]byte, error) {
+	jwks := struct {
+		Keys []key `json:"keys"`
+	}{
+		Keys: []key{},
+	}
+
+	var keyIDs []string
+	for keyID := range jwtSigningKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		k := key{
+			Use:   signatureUse,
+			KeyID: keyID,
+		}
+		if err := setPublicKey(&k, jwtSigningKeys[keyID]); err != nil {
+			return nil, err
+		}
+		k.Algorithm = algorithm(k)
+		jwks.Keys = append(jwks.Keys, k)
+	}
+
+	return json.Marshal(jwks)
+}
+
+// Unmarshal decodes a bundle in the SPIFFE bundle format. Keys of other uses
+// are ignored.
+func Unmarshal(data []byte) (*Bundle, error) {
+	doc := new(document)
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("unable to parse bundle: %v", err)
+	}
+
+	bundle := &Bundle{
+		JWTSigningKeys: make(map[string]crypto.PublicKey),
+		RefreshHint:    time.Duration(doc.RefreshHint) * time.Second,
+		Sequence:       doc.Sequence,
+	}
+	for _, k := range doc.Keys {
+		switch k.Use {
+		case x509SVIDUse:
+			if len(k.X509Chain) != 1 {
+				return nil, errors.New("X509-SVID authorities must hold a single certificate")
+			}
+			der, err := base64.StdEncoding.DecodeString(k.X509Chain[0])
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode CA certificate: %v", err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse CA certificate: %v", err)
+			}
+			bundle.RootCAs = append(bundle.RootCAs, cert)
+		case jwtSVIDUse:
+			if k.KeyID == "" {
+				return nil, errors.New("JWT-SVID authorities must have a key ID")
+			}
+			publicKey, err := getPublicKey(k)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JWT signing key %q: %v", k.KeyID, err)
+			}
+			bundle.JWTSigningKeys[k.KeyID] = publicKey
+		}
+	}
+
+	return bundle, nil
+}
+
+// setPublicKey sets the key type and parameters of the JWK
+func setPublicKey(k *key, publicKey crypto.PublicKey) error {
+	switch publicKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		k.KeyType = "EC"
+		k.Curve = publicKey.Curve.Params().Name
+		k.X = encodeInt(publicKey.X, size)
+		k.Y = encodeInt(publicKey.Y, size)
+	case *rsa.PublicKey:
+		k.KeyType = "RSA"
+		k.N = encodeInt(publicKey.N, 0)
+		k.E = encodeInt(big.NewInt(int64(publicKey.E)), 0)
+	default:
+		return fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
+// algorithm returns the algorithm JWT-SVIDs are signed with by the key of
+// the JWK, as chosen by the jwtsvid package
+func algorithm(k key) string {
+	switch k.Curve {
+	case "P-256":
+		return "ES256"
+	case "P-384":
+		return "ES384"
+	case "P-521":
+		return "ES512"
+	}
+	if k.KeyType == "RSA" {
+		return "RS256"
+	}
+	return ""
+}
+
+// getPublicKey returns the public key of the JWK
+func getPublicKey(k key) (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+	}
+}
+
+// encodeInt base64url encodes the big-endian bytes of the integer, left
+// padded to size bytes
+func encodeInt(i *big.Int, size int) string {
+	b := i.Bytes()
+	if len(b) < size {
+		b = append(make([]byte, size-len(b)), b...)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeInt decodes a base64url encoded big-endian integer
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode key parameter: %v", err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
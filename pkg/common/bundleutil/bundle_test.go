@@ -0,0 +1,144 @@
+package bundleutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	template, err := util.NewCATemplate("example.org")
+	require.NoError(t, err)
+	caCert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	data, err := Marshal(&Bundle{
+		RootCAs:        []*x509.Certificate{caCert},
+		JWTSigningKeys: map[string]crypto.PublicKey{"kid": key.Public()},
+		RefreshHint:    5 * time.Minute,
+		Sequence:       42,
+	})
+	require.NoError(t, err)
+
+	var doc struct {
+		Keys        []map[string]interface{} `json:"keys"`
+		RefreshHint int64                    `json:"spiffe_refresh_hint"`
+		Sequence    uint64                   `json:"spiffe_sequence"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	require.Equal(t, int64(300), doc.RefreshHint)
+	require.Equal(t, uint64(42), doc.Sequence)
+	require.Len(t, doc.Keys, 2)
+
+	require.Equal(t, "x509-svid", doc.Keys[0]["use"])
+	require.Equal(t, "EC", doc.Keys[0]["kty"])
+	require.Equal(t, []interface{}{base64.StdEncoding.EncodeToString(caCert.Raw)}, doc.Keys[0]["x5c"])
+	require.NotContains(t, doc.Keys[0], "kid")
+
+	require.Equal(t, "jwt-svid", doc.Keys[1]["use"])
+	require.Equal(t, "EC", doc.Keys[1]["kty"])
+	require.Equal(t, "kid", doc.Keys[1]["kid"])
+	require.Equal(t, "P-256", doc.Keys[1]["crv"])
+	require.Len(t, doc.Keys[1]["x"], 43)
+	require.Len(t, doc.Keys[1]["y"], 43)
+	require.NotContains(t, doc.Keys[1], "x5c")
+
+	// Bundles without refresh hint or sequence number leave them out
+	data, err = Marshal(&Bundle{})
+	require.NoError(t, err)
+	require.Equal(t, `{"keys":[]}`, string(data))
+}
+
+func TestMarshalJWKS(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	data, err := MarshalJWKS(map[string]crypto.PublicKey{
+		"ec":  ecKey.Public(),
+		"rsa": rsaKey.Public(),
+	})
+	require.NoError(t, err)
+
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	require.NoError(t, json.Unmarshal(data, &jwks))
+	require.Len(t, jwks.Keys, 2)
+
+	require.Equal(t, "sig", jwks.Keys[0]["use"])
+	require.Equal(t, "ec", jwks.Keys[0]["kid"])
+	require.Equal(t, "EC", jwks.Keys[0]["kty"])
+	require.Equal(t, "ES384", jwks.Keys[0]["alg"])
+
+	require.Equal(t, "sig", jwks.Keys[1]["use"])
+	require.Equal(t, "rsa", jwks.Keys[1]["kid"])
+	require.Equal(t, "RSA", jwks.Keys[1]["kty"])
+	require.Equal(t, "RS256", jwks.Keys[1]["alg"])
+
+	data, err = MarshalJWKS(nil)
+	require.NoError(t, err)
+	require.Equal(t, `{"keys":[]}`, string(data))
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	template, err := util.NewCATemplate("example.org")
+	require.NoError(t, err)
+	caCert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	bundle := &Bundle{
+		RootCAs: []*x509.Certificate{caCert},
+		JWTSigningKeys: map[string]crypto.PublicKey{
+			"ec":  ecKey.Public(),
+			"rsa": rsaKey.Public(),
+		},
+		RefreshHint: time.Minute,
+		Sequence:    1,
+	}
+	data, err := Marshal(bundle)
+	require.NoError(t, err)
+	actual, err := Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, bundle, actual)
+}
+
+func TestUnmarshal(t *testing.T) {
+	// Keys of other uses are ignored
+	bundle, err := Unmarshal([]byte(`{"keys": [{"use": "enc", "kty": "oct"}]}`))
+	require.NoError(t, err)
+	require.Empty(t, bundle.RootCAs)
+	require.Empty(t, bundle.JWTSigningKeys)
+
+	_, err = Unmarshal([]byte(`{"keys": [{"use": "x509-svid", "kty": "EC"}]}`))
+	require.EqualError(t, err, "X509-SVID authorities must hold a single certificate")
+
+	_, err = Unmarshal([]byte(`{"keys": [{"use": "jwt-svid", "kty": "EC", "crv": "P-256"}]}`))
+	require.EqualError(t, err, "JWT-SVID authorities must have a key ID")
+
+	_, err = Unmarshal([]byte(`{"keys": [{"use": "jwt-svid", "kid": "kid", "kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`))
+	require.EqualError(t, err, `invalid JWT signing key "kid": point is not on the curve`)
+
+	_, err = Unmarshal([]byte(`{"keys": [{"use": "jwt-svid", "kid": "kid", "kty": "oct"}]}`))
+	require.EqualError(t, err, `invalid JWT signing key "kid": unsupported key type "oct"`)
+
+	_, err = Unmarshal([]byte(`{`))
+	require.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package bundleutil
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+// FromDatastore returns the bundle held by a bundle of the datastore, along
+// with its sequence number and refresh hint
+func FromDatastore(bundle *datastore.Bundle) (*Bundle, error) {
+	certs, err := x509.ParseCertificates(bundle.CaCerts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CA certificates: %v", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwtKey := range bundle.JwtSigningKeys {
+		publicKey, err := x509.ParsePKIXPublicKey(jwtKey.PkixBytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse JWT signing key %q: %v", jwtKey.Kid, err)
+		}
+		keys[jwtKey.Kid] = publicKey
+	}
+
+	return &Bundle{
+		RootCAs:        certs,
+		JWTSigningKeys: keys,
+		RefreshHint:    time.Duration(bundle.RefreshHint) * time.Second,
+		Sequence:       bundle.SequenceNumber,
+	}, nil
+}
+
+// ToDatastore returns the bundle of the trust domain to store in the
+// datastore, with the JWT signing keys sorted by key ID. The sequence number
+// is left to the datastore.
+func ToDatastore(trustDomain string, b *Bundle) (*datastore.Bundle, error) {
+	bundle := &datastore.Bundle{
+		TrustDomain: trustDomain,
+		RefreshHint: int64(b.RefreshHint / time.Second),
+	}
+	for _, cert := range b.RootCAs {
+		bundle.CaCerts = append(bundle.CaCerts, cert.Raw...)
+	}
+
+	var keyIDs []string
+	for keyID := range b.JWTSigningKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		pkixBytes, err := x509.MarshalPKIXPublicKey(b.JWTSigningKeys[keyID])
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT signing key %q: %v", keyID, err)
+		}
+		bundle.JwtSigningKeys = append(bundle.JwtSigningKeys, &common.PublicKey{
+			PkixBytes: pkixBytes,
+			Kid:       keyID,
+		})
+	}
+	return bundle, nil
+}
@@ -0,0 +1,59 @@
+package bundleutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatastore(t *testing.T) {
+	template, err := util.NewCATemplate("example.org")
+	require.NoError(t, err)
+	caCert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	bundle := &Bundle{
+		RootCAs: []*x509.Certificate{caCert},
+		JWTSigningKeys: map[string]crypto.PublicKey{
+			"key2": key2.Public(),
+			"key1": key1.Public(),
+		},
+		RefreshHint: time.Minute,
+	}
+	stored, err := ToDatastore("spiffe://example.org", bundle)
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org", stored.TrustDomain)
+	require.Equal(t, caCert.Raw, stored.CaCerts)
+	require.Equal(t, int64(60), stored.RefreshHint)
+	require.Len(t, stored.JwtSigningKeys, 2)
+	require.Equal(t, "key1", stored.JwtSigningKeys[0].Kid)
+	require.Equal(t, "key2", stored.JwtSigningKeys[1].Kid)
+
+	// The sequence number is read back from the datastore
+	stored.SequenceNumber = 3
+	bundle.Sequence = 3
+	actual, err := FromDatastore(stored)
+	require.NoError(t, err)
+	require.Equal(t, bundle, actual)
+
+	_, err = FromDatastore(&datastore.Bundle{CaCerts: []byte("junk")})
+	require.Error(t, err)
+
+	_, err = FromDatastore(&datastore.Bundle{
+		JwtSigningKeys: []*common.PublicKey{{Kid: "kid", PkixBytes: []byte("junk")}},
+	})
+	require.Error(t, err)
+}
@@ -1,12 +1,18 @@
 package catalog
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -43,6 +49,14 @@ type Config struct {
 	SupportedPlugins map[string]goplugin.Plugin
 	BuiltinPlugins   BuiltinPluginMap
 	Log              logrus.FieldLogger
+
+	// If set, the built-in plugins are configured in dry-run mode, only
+	// validating their configuration. External plugins are started but not
+	// configured.
+	DryRun bool
+
+	// If set, external plugins are only run if their checksum is configured
+	RequirePluginChecksum bool
 }
 
 type catalog struct {
@@ -50,6 +64,8 @@ type catalog struct {
 	plugins          []*ManagedPlugin
 	supportedPlugins map[string]goplugin.Plugin
 	builtinPlugins   BuiltinPluginMap
+	dryRun           bool
+	requireChecksum  bool
 
 	l logrus.FieldLogger
 	m *sync.RWMutex
@@ -68,6 +84,8 @@ func New(config *Config) Catalog {
 		pluginConfigs:    config.PluginConfigs,
 		supportedPlugins: config.SupportedPlugins,
 		builtinPlugins:   config.BuiltinPlugins,
+		dryRun:           config.DryRun,
+		requireChecksum:  config.RequirePluginChecksum,
 		l:                config.Log,
 		m:                new(sync.RWMutex),
 	}
@@ -203,13 +221,14 @@ func (c *catalog) startPlugins() error {
 			continue
 		}
 
-		config, err := c.newPluginConfig(p)
+		config, cleanup, err := c.newPluginConfig(p)
 		if err != nil {
 			return err
 		}
 
 		c.l.Debugf("%s(%s): starting plugin", pluginType, pluginName)
 		client, err := goplugin.NewClient(config).Client()
+		cleanup()
 		if err != nil {
 			return fmt.Errorf("%s(%s): unable to create plugin client: %v", pluginType, pluginName, err)
 		}
@@ -239,8 +258,17 @@ func (c *catalog) configurePlugins(ctx context.Context) error {
 			continue
 		}
 
+		// External plugins may predate dry-run mode, and act on their
+		// configuration regardless, so only the built-in ones, which are
+		// known to support it, are configured
+		if c.dryRun && c.builtins(pluginType, pluginName) == nil {
+			c.l.Debugf("%s(%s): external plugin is not configured in dry-run mode", pluginType, pluginName)
+			continue
+		}
+
 		req := &pb.ConfigureRequest{
 			Configuration: p.Config.PluginData,
+			DryRun:        c.dryRun,
 		}
 
 		c.l.Debugf("%s(%s): configuring plugin", pluginType, pluginName)
@@ -254,17 +282,23 @@ func (c *catalog) configurePlugins(ctx context.Context) error {
 }
 
 // newPluginConfig generates a go-plugin client config, given a ManagedPlugin
-// struct. Useful when starting a plugin
-func (c *catalog) newPluginConfig(p *ManagedPlugin) (*goplugin.ClientConfig, error) {
-	secureConfig, err := c.secureConfig(p)
+// struct. Useful when starting a plugin. The returned function removes the
+// verified copy of the plugin binary, if any, and is to be called once the
+// plugin is started.
+func (c *catalog) newPluginConfig(p *ManagedPlugin) (*goplugin.ClientConfig, func(), error) {
+	checksum, err := c.pluginChecksum(p)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	env, err := pluginEnv(p)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Build go-plugin client config struct
 	pluginType, ok := c.supportedPlugins[p.Config.PluginType]
 	if !ok {
-		return nil, fmt.Errorf("Plugin type %s is unsupported", p.Config.PluginType)
+		return nil, nil, fmt.Errorf("Plugin type %s is unsupported", p.Config.PluginType)
 	}
 	pluginMap := map[string]goplugin.Plugin{
 		p.Config.PluginName: pluginType,
@@ -272,6 +306,23 @@ func (c *catalog) newPluginConfig(p *ManagedPlugin) (*goplugin.ClientConfig, err
 	l := c.l.WithField("plugin_type", p.Config.PluginType)
 	l = l.WithField("plugin_name", p.Config.PluginName)
 
+	// go-plugin would verify the binary by its path before running it by
+	// its path, letting it be replaced in between, so the binary is copied
+	// and verified here instead, and the copy is run
+	cmdPath := p.Config.PluginCmd
+	cleanup := func() {}
+	if checksum != nil {
+		dir, err := verifiedCopy(p.Config.PluginCmd, checksum)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s(%s): %v", p.Config.PluginType, p.Config.PluginName, err)
+		}
+		cmdPath = filepath.Join(dir, filepath.Base(p.Config.PluginCmd))
+		cleanup = func() { os.RemoveAll(dir) }
+	}
+
+	cmd := exec.Command(cmdPath, p.Config.PluginArgs...)
+	cmd.Env = env
+
 	config := &goplugin.ClientConfig{
 		HandshakeConfig: goplugin.HandshakeConfig{
 			ProtocolVersion:  1,
@@ -279,18 +330,22 @@ func (c *catalog) newPluginConfig(p *ManagedPlugin) (*goplugin.ClientConfig, err
 			MagicCookieValue: p.Config.PluginType,
 		},
 		Plugins:          pluginMap,
-		Cmd:              exec.Command(p.Config.PluginCmd),
+		Cmd:              cmd,
 		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
 		Managed:          true,
-		SecureConfig:     secureConfig,
 		Logger:           &log.HCLogAdapter{Log: l, Name: "plugin"},
 	}
 
-	return config, nil
+	return config, cleanup, nil
 }
 
-func (c *catalog) secureConfig(p *ManagedPlugin) (*goplugin.SecureConfig, error) {
+// pluginChecksum returns the SHA-256 hash the plugin binary must have, or
+// nil if none is configured and none is required
+func (c *catalog) pluginChecksum(p *ManagedPlugin) ([]byte, error) {
 	if p.Config.PluginChecksum == "" {
+		if c.requireChecksum {
+			return nil, fmt.Errorf("%s plugin %s has no plugin_checksum, which is required", p.Config.PluginType, p.Config.PluginName)
+		}
 		c.l.Warnf("%s plugin %s not using secure config", p.Config.PluginType, p.Config.PluginName)
 		return nil, nil
 	}
@@ -299,13 +354,79 @@ func (c *catalog) secureConfig(p *ManagedPlugin) (*goplugin.SecureConfig, error)
 	if err != nil {
 		return nil, fmt.Errorf("decode plugin hash: %v", err)
 	}
+	// A hash of another length would only be found not to match once the
+	// plugin is started, which does not tell what is wrong
+	if len(sum) != sha256.Size {
+		return nil, fmt.Errorf("plugin hash of %s plugin %s is %d bytes long, rather than the %d bytes of a SHA-256 hash",
+			p.Config.PluginType, p.Config.PluginName, len(sum), sha256.Size)
+	}
+
+	return sum, nil
+}
+
+// verifiedCopy copies the plugin binary into a new private directory,
+// hashing what is copied, and returns the directory if the hash matches the
+// checksum
+func verifiedCopy(path string, checksum []byte) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	dir, err := ioutil.TempDir("", "spire-plugin-")
+	if err != nil {
+		return "", err
+	}
+
+	if err := copyAndVerify(src, filepath.Join(dir, filepath.Base(path)), checksum); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("plugin binary %s: %v", path, err)
+	}
+	return dir, nil
+}
+
+func copyAndVerify(src io.Reader, path string, checksum []byte) error {
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0500)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(dst, hash), src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash.Sum(nil), checksum) {
+		return errors.New("checksum does not match plugin_checksum")
+	}
+	return nil
+}
 
-	config := &goplugin.SecureConfig{
-		Checksum: sum,
-		Hash:     sha256.New(),
+// pluginEnv returns the environment variables configured for the plugin, as
+// sorted KEY=value pairs. go-plugin passes the environment of the process
+// along, which takes precedence, so variables it already sets are refused
+// rather than silently left with another value. Their values are not
+// reported, as they may be secrets.
+func pluginEnv(p *ManagedPlugin) ([]string, error) {
+	var keys []string
+	for key := range p.Config.PluginEnv {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	return config, nil
+	var env []string
+	for _, key := range keys {
+		if _, ok := os.LookupEnv(key); ok {
+			return nil, fmt.Errorf("%s plugin %s: environment variable %s is already set and cannot be overridden by plugin_env", p.Config.PluginType, p.Config.PluginName, key)
+		}
+		env = append(env, key+"="+p.Config.PluginEnv[key])
+	}
+	return env, nil
 }
 
 // builtins determines, given a configured plugin's name and type, if it is an
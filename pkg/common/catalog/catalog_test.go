@@ -1,8 +1,17 @@
 package catalog
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
 	"net/rpc"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -10,6 +19,7 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/log"
+	pb "github.com/spiffe/spire/proto/common/plugin"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -20,6 +30,20 @@ type testPlugin struct{}
 func (testPlugin) Server(_ *plugin.MuxBroker) (interface{}, error)                { return nil, nil }
 func (testPlugin) Client(_ *plugin.MuxBroker, _ *rpc.Client) (interface{}, error) { return nil, nil }
 
+// configuredPlugin records the requests it is configured with
+type configuredPlugin struct {
+	requests []*pb.ConfigureRequest
+}
+
+func (p *configuredPlugin) Configure(ctx context.Context, req *pb.ConfigureRequest) (*pb.ConfigureResponse, error) {
+	p.requests = append(p.requests, req)
+	return &pb.ConfigureResponse{}, nil
+}
+
+func (p *configuredPlugin) GetPluginInfo(context.Context, *pb.GetPluginInfoRequest) (*pb.GetPluginInfoResponse, error) {
+	return &pb.GetPluginInfoResponse{}, nil
+}
+
 type CatalogTestSuite struct {
 	suite.Suite
 
@@ -89,7 +113,6 @@ func (c *CatalogTestSuite) TestNewPluginConfig() {
 		Cmd:              exec.Command("./attestor"),
 		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
 		Managed:          true,
-		SecureConfig:     nil,
 		Logger: &log.HCLogAdapter{
 			Log:  c.catalog.l.WithField("plugin_type", "NodeAttestor").WithField("plugin_name", "join_token"),
 			Name: "plugin",
@@ -97,12 +120,132 @@ func (c *CatalogTestSuite) TestNewPluginConfig() {
 	}
 
 	_ = c.catalog.loadConfigs()
-	pluginConfig, err := c.catalog.newPluginConfig(c.catalog.plugins[0])
+	pluginConfig, cleanup, err := c.catalog.newPluginConfig(c.catalog.plugins[0])
 	if c.Assert().Nil(err) {
+		cleanup()
 		c.Assert().Equal(expectedConfig, pluginConfig)
 	}
 }
 
+func (c *CatalogTestSuite) TestNewPluginConfigArgsAndEnv() {
+	os.Setenv("CATALOG_TEST_SET", "process")
+	defer os.Unsetenv("CATALOG_TEST_SET")
+
+	p := &ManagedPlugin{Config: PluginConfig{
+		PluginName: "join_token",
+		PluginType: "NodeAttestor",
+		PluginCmd:  "./attestor",
+		PluginArgs: []string{"-mode", "strict"},
+		PluginEnv: map[string]string{
+			"TOKEN": "s3cr3t",
+		},
+	}}
+
+	pluginConfig, cleanup, err := c.catalog.newPluginConfig(p)
+	c.Require().NoError(err)
+	cleanup()
+	c.Assert().Equal([]string{"./attestor", "-mode", "strict"}, pluginConfig.Cmd.Args)
+	c.Assert().Equal([]string{"TOKEN=s3cr3t"}, pluginConfig.Cmd.Env)
+
+	// Variables set in the environment of the process would take precedence
+	p.Config.PluginEnv["CATALOG_TEST_SET"] = "plugin"
+	_, _, err = c.catalog.newPluginConfig(p)
+	c.Assert().EqualError(err, "NodeAttestor plugin join_token: environment variable CATALOG_TEST_SET is already set and cannot be overridden by plugin_env")
+}
+
+func (c *CatalogTestSuite) TestNewPluginConfigChecksum() {
+	dir, err := ioutil.TempDir("", "catalog-test")
+	c.Require().NoError(err)
+	defer os.RemoveAll(dir)
+
+	binary := []byte("#!/bin/sh\n")
+	sum := sha256.Sum256(binary)
+	path := filepath.Join(dir, "attestor")
+	c.Require().NoError(ioutil.WriteFile(path, binary, 0755))
+
+	p := &ManagedPlugin{Config: PluginConfig{
+		PluginName:     "join_token",
+		PluginType:     "NodeAttestor",
+		PluginCmd:      path,
+		PluginChecksum: hex.EncodeToString(sum[:]),
+	}}
+
+	// The verified copy of the binary is run, rather than the binary, which
+	// could be replaced once verified
+	pluginConfig, cleanup, err := c.catalog.newPluginConfig(p)
+	c.Require().NoError(err)
+	copyPath := pluginConfig.Cmd.Path
+	c.Assert().NotEqual(path, copyPath)
+	c.Assert().Equal("attestor", filepath.Base(copyPath))
+	copied, err := ioutil.ReadFile(copyPath)
+	c.Require().NoError(err)
+	c.Assert().Equal(binary, copied)
+
+	cleanup()
+	_, err = os.Stat(filepath.Dir(copyPath))
+	c.Assert().True(os.IsNotExist(err))
+
+	c.Require().NoError(ioutil.WriteFile(path, []byte("replaced"), 0755))
+	_, _, err = c.catalog.newPluginConfig(p)
+	c.Assert().EqualError(err, fmt.Sprintf("NodeAttestor(join_token): plugin binary %s: checksum does not match plugin_checksum", path))
+}
+
+func (c *CatalogTestSuite) TestPluginChecksum() {
+	p := &ManagedPlugin{Config: PluginConfig{
+		PluginName:     "join_token",
+		PluginType:     "NodeAttestor",
+		PluginChecksum: strings.Repeat("ab", sha256.Size),
+	}}
+	checksum, err := c.catalog.pluginChecksum(p)
+	c.Require().NoError(err)
+	c.Assert().Equal(bytes.Repeat([]byte{0xab}, sha256.Size), checksum)
+
+	p.Config.PluginChecksum = "not hex"
+	_, err = c.catalog.pluginChecksum(p)
+	c.Assert().Error(err)
+
+	p.Config.PluginChecksum = "abcd"
+	_, err = c.catalog.pluginChecksum(p)
+	c.Assert().EqualError(err, "plugin hash of NodeAttestor plugin join_token is 2 bytes long, rather than the 32 bytes of a SHA-256 hash")
+
+	// Without a checksum, the plugin is run unverified unless one is required
+	p.Config.PluginChecksum = ""
+	checksum, err = c.catalog.pluginChecksum(p)
+	c.Require().NoError(err)
+	c.Assert().Nil(checksum)
+
+	c.catalog.requireChecksum = true
+	_, err = c.catalog.pluginChecksum(p)
+	c.Assert().EqualError(err, "NodeAttestor plugin join_token has no plugin_checksum, which is required")
+}
+
+func (c *CatalogTestSuite) TestConfigurePluginsDryRun() {
+	builtin := &configuredPlugin{}
+	external := &configuredPlugin{}
+	c.catalog.builtinPlugins = BuiltinPluginMap{"NodeAttestor": {"join_token": builtin}}
+	c.catalog.plugins = []*ManagedPlugin{
+		{
+			Config: PluginConfig{PluginType: "NodeAttestor", PluginName: "join_token", Enabled: true, PluginData: "a = 1"},
+			Plugin: builtin,
+		},
+		{
+			Config: PluginConfig{PluginType: "NodeAttestor", PluginName: "external", Enabled: true, PluginData: "b = 2"},
+			Plugin: external,
+		},
+	}
+
+	// Only the built-in plugins, which support dry-run mode, are configured
+	c.catalog.dryRun = true
+	c.Require().NoError(c.catalog.configurePlugins(context.Background()))
+	c.Require().Equal([]*pb.ConfigureRequest{{Configuration: "a = 1", DryRun: true}}, builtin.requests)
+	c.Require().Empty(external.requests)
+
+	c.catalog.dryRun = false
+	c.Require().NoError(c.catalog.configurePlugins(context.Background()))
+	c.Require().Equal(&pb.ConfigureRequest{Configuration: "a = 1"}, builtin.requests[1])
+	c.Require().Equal([]*pb.ConfigureRequest{{Configuration: "b = 2"}}, external.requests)
+}
+
 func TestCatalog(t *testing.T) {
 	suite.Run(t, new(CatalogTestSuite))
 }
@@ -20,6 +20,11 @@ type PluginConfig struct {
 	PluginCmd      string `hcl:"plugin_cmd"`
 	PluginChecksum string `hcl:"plugin_checksum"`
 
+	// Arguments and environment variables the plugin binary is run with,
+	// on top of the environment of the process
+	PluginArgs []string          `hcl:"plugin_args"`
+	PluginEnv  map[string]string `hcl:"plugin_env"`
+
 	PluginData string `hcl:"plugin_data"`
 	PluginType string
 	Enabled    bool `hcl:"enabled"`
@@ -34,6 +39,9 @@ type HclPluginConfig struct {
 	PluginCmd      string `hcl:"plugin_cmd"`
 	PluginChecksum string `hcl:"plugin_checksum"`
 
+	PluginArgs []string          `hcl:"plugin_args"`
+	PluginEnv  map[string]string `hcl:"plugin_env"`
+
 	PluginData ast.Node `hcl:"plugin_data"`
 	PluginType string
 	Enabled    bool `hcl:"enabled"`
@@ -58,6 +66,8 @@ func parsePluginConfig(hclPluginConfig HclPluginConfig) (PluginConfig, error) {
 		PluginName:     hclPluginConfig.PluginName,
 		PluginCmd:      hclPluginConfig.PluginCmd,
 		PluginChecksum: hclPluginConfig.PluginChecksum,
+		PluginArgs:     hclPluginConfig.PluginArgs,
+		PluginEnv:      hclPluginConfig.PluginEnv,
 		PluginType:     hclPluginConfig.PluginType,
 		Enabled:        hclPluginConfig.Enabled,
 
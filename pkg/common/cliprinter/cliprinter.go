@@ -0,0 +1,95 @@
+// Package cliprinter implements the -output flag shared by the spire-server
+// and spire-agent commands, so that tooling can rely on one parsing contract:
+// with -output json, a command that succeeds prints a single JSON document to
+// standard out.
+package cliprinter
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+const (
+	// Pretty prints the outcome of a command for people to read
+	Pretty = "pretty"
+
+	// JSON prints the outcome of a command as a single JSON document
+	JSON = "json"
+)
+
+// AppendFlag registers the -output flag on the flag set, storing the format
+// chosen in output. The format defaults to Pretty.
+func AppendFlag(f *flag.FlagSet, output *string) {
+	*output = Pretty
+	f.Var(formatValue{output}, "output", "The `format` to print the outcome in: pretty, or json")
+}
+
+// AutocompleteFlags returns the completion of the flags defined on the flag
+// set of a command: the formats for -output, files for the flags taking a
+// path, and nothing for the boolean flags
+func AutocompleteFlags(f *flag.FlagSet) complete.Flags {
+	flags := complete.Flags{}
+	f.VisitAll(func(fl *flag.Flag) {
+		flags["-"+fl.Name] = predictFlag(fl)
+	})
+	return flags
+}
+
+func predictFlag(fl *flag.Flag) complete.Predictor {
+	if b, ok := fl.Value.(interface {
+		IsBoolFlag() bool
+	}); ok && b.IsBoolFlag() {
+		return complete.PredictNothing
+	}
+	if _, ok := fl.Value.(formatValue); ok {
+		return complete.PredictSet(Pretty, JSON)
+	}
+
+	name := strings.ToLower(fl.Name)
+	for _, suffix := range []string{"path", "file", "dir"} {
+		if strings.HasSuffix(name, suffix) {
+			return complete.PredictFiles("*")
+		}
+	}
+	switch name {
+	case "config", "data", "f", "trustbundle", "write":
+		return complete.PredictFiles("*")
+	}
+	return complete.PredictAnything
+}
+
+// PrintJSON prints v to w as an indented JSON document
+func PrintJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+type formatValue struct {
+	output *string
+}
+
+func (v formatValue) String() string {
+	if v.output == nil {
+		return ""
+	}
+	return *v.output
+}
+
+func (v formatValue) Set(s string) error {
+	switch s {
+	case Pretty, JSON:
+		*v.output = s
+	default:
+		return fmt.Errorf("invalid output %q", s)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cliprinter
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parse(args ...string) (string, error) {
+	f := flag.NewFlagSet("test", flag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	var output string
+	AppendFlag(f, &output)
+	err := f.Parse(args)
+	return output, err
+}
+
+func TestAppendFlag(t *testing.T) {
+	output, err := parse()
+	require.NoError(t, err)
+	assert.Equal(t, Pretty, output)
+
+	output, err = parse("-output", "json")
+	require.NoError(t, err)
+	assert.Equal(t, JSON, output)
+
+	output, err = parse("-output", "pretty")
+	require.NoError(t, err)
+	assert.Equal(t, Pretty, output)
+
+	_, err = parse("-output", "yaml")
+	assert.EqualError(t, err, `invalid value "yaml" for flag -output: invalid output "yaml"`)
+}
+
+func TestPrintJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, PrintJSON(buf, map[string]string{"spiffe_id": "spiffe://example.org/foo"}))
+	assert.Equal(t, "{\n  \"spiffe_id\": \"spiffe://example.org/foo\"\n}\n", buf.String())
+}
+
+func TestAutocompleteFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cliprinter-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "token"), nil, 0600))
+
+	f := flag.NewFlagSet("test", flag.ContinueOnError)
+	var output, outFile, addr string
+	var verbose bool
+	AppendFlag(f, &output)
+	f.StringVar(&outFile, "outFile", "", "")
+	f.StringVar(&addr, "serverAddr", "", "")
+	f.BoolVar(&verbose, "verbose", false, "")
+
+	flags := AutocompleteFlags(f)
+	assert.Len(t, flags, 4)
+	assert.Equal(t, []string{Pretty, JSON}, flags["-output"].Predict(complete.Args{}))
+	assert.Contains(t, flags["-outFile"].Predict(complete.Args{Last: dir + "/"}), filepath.Join(dir, "token"))
+	assert.Empty(t, flags["-serverAddr"].Predict(complete.Args{}))
+	assert.Nil(t, flags["-verbose"])
+}
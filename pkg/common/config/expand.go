@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/hcl/hcl/token"
+)
+
+// fileURLPrefix starts the values that are the URL of a file, e.g.
+// "file:///run/secrets/token"
+const fileURLPrefix = "file://"
+
+// refRE matches the escaped "${", "$${", and the references to files,
+// "${file:/path}", and to environment variables, "${NAME}"
+var refRE = regexp.MustCompile(`\$\$\{|\$\{(?:file:([^}]+)|([A-Za-z_][A-Za-z0-9_]*))\}`)
+
+// Expand expands the string values of the HCL tree in place, so that secrets
+// do not have to be written literally in the configuration. Values that are
+// the URL of a file, "file:///path", are replaced by the content of the file,
+// without its trailing newlines. Within other values, "${NAME}" references
+// are replaced by the value of the NAME environment variable, which must be
+// set, "${file:/path}" references by the content of the file, and "$${" by a
+// literal "${". Keys are left alone, and plugin_data blocks are expanded like
+// the rest of the tree.
+func Expand(node ast.Node) error {
+	var err error
+	ast.Walk(node, func(n ast.Node) (ast.Node, bool) {
+		if err != nil {
+			return n, false
+		}
+		lit, ok := n.(*ast.LiteralType)
+		if !ok || lit.Token.Type != token.STRING {
+			return n, true
+		}
+
+		value, ok := lit.Token.Value().(string)
+		if !ok {
+			return n, true
+		}
+		var expanded string
+		expanded, err = expandValue(value)
+		if err != nil {
+			err = fmt.Errorf("line %d: %v", lit.Token.Pos.Line, err)
+			return n, false
+		}
+		if expanded != value {
+			lit.Token.Text = strconv.Quote(expanded)
+			lit.Token.JSON = false
+		}
+		return n, true
+	})
+	return err
+}
+
+func expandValue(value string) (string, error) {
+	if strings.HasPrefix(value, fileURLPrefix+"/") {
+		return readFile(strings.TrimPrefix(value, fileURLPrefix))
+	}
+
+	var err error
+	expanded := refRE.ReplaceAllStringFunc(value, func(ref string) string {
+		match := refRE.FindStringSubmatch(ref)
+		switch {
+		case ref == "$${":
+			return "${"
+		case match[1] != "":
+			data, readErr := readFile(match[1])
+			if readErr != nil && err == nil {
+				err = readErr
+			}
+			return data
+		}
+
+		name := match[2]
+		v, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("environment variable %s is not set (write $${%s} for a literal ${%s})", name, name, name)
+		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
+
+// readFile returns the content of the file, without its trailing newlines
+func readFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read file %s: %v", path, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
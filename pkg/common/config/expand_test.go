@@ -0,0 +1,90 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "expand-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, ioutil.WriteFile(passwordPath, []byte("s3cr\"t\n"), 0600))
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte("t0ken\n"), 0600))
+	os.Setenv("EXPAND_TEST_HOST", "db.example.org")
+	defer os.Unsetenv("EXPAND_TEST_HOST")
+
+	tree, err := hcl.Parse(`
+server {
+	trust_domain = "example.org"
+}
+plugins {
+	DataStore "sql" {
+		plugin_data {
+			connection_string = "host=${EXPAND_TEST_HOST} dbname=spire"
+			password = "${file:` + passwordPath + `}"
+			token = "file://` + tokenPath + `"
+			template = "$${EXPAND_TEST_HOST}/$${file:/etc/passwd}/$$HOME/file:///etc/passwd"
+		}
+	}
+}`)
+	require.NoError(t, err)
+	require.NoError(t, Expand(tree))
+
+	var c struct {
+		Server struct {
+			TrustDomain string `hcl:"trust_domain"`
+		} `hcl:"server"`
+	}
+	require.NoError(t, hcl.DecodeObject(&c, tree))
+	require.Equal(t, "example.org", c.Server.TrustDomain)
+
+	// The plugin data is printed back to HCL before being given to the
+	// plugins, so the expanded values must survive the round trip
+	var data bytes.Buffer
+	require.NoError(t, printer.DefaultConfig.Fprint(&data, tree))
+	var plugin struct {
+		Plugins struct {
+			DataStore map[string]struct {
+				PluginData struct {
+					ConnectionString string `hcl:"connection_string"`
+					Password         string `hcl:"password"`
+					Token            string `hcl:"token"`
+					Template         string `hcl:"template"`
+				} `hcl:"plugin_data"`
+			} `hcl:"DataStore"`
+		} `hcl:"plugins"`
+	}
+	require.NoError(t, hcl.Decode(&plugin, data.String()))
+	pluginData := plugin.Plugins.DataStore["sql"].PluginData
+	require.Equal(t, "host=db.example.org dbname=spire", pluginData.ConnectionString)
+	require.Equal(t, `s3cr"t`, pluginData.Password)
+	require.Equal(t, "t0ken", pluginData.Token)
+	// Escaped references are left unexpanded, as are the URLs of files
+	// within values
+	require.Equal(t, "${EXPAND_TEST_HOST}/${file:/etc/passwd}/$$HOME/file:///etc/passwd", pluginData.Template)
+}
+
+func TestExpandErrors(t *testing.T) {
+	tree, err := hcl.Parse(`password = "${EXPAND_TEST_UNSET}"`)
+	require.NoError(t, err)
+	require.EqualError(t, Expand(tree), "line 1: environment variable EXPAND_TEST_UNSET is not set (write $${EXPAND_TEST_UNSET} for a literal ${EXPAND_TEST_UNSET})")
+
+	tree, err = hcl.Parse(`password = "${file:/does/not/exist}"`)
+	require.NoError(t, err)
+	require.EqualError(t, Expand(tree), "line 1: unable to read file /does/not/exist: open /does/not/exist: no such file or directory")
+
+	tree, err = hcl.Parse(`password = "file:///does/not/exist"`)
+	require.NoError(t, err)
+	require.EqualError(t, Expand(tree), "line 1: unable to read file /does/not/exist: open /does/not/exist: no such file or directory")
+}
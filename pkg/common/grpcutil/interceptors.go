@@ -0,0 +1,37 @@
+package grpcutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ChainUnaryInterceptors returns an interceptor calling the given
+// interceptors in order, then the handler, as the gRPC server only accepts a
+// single unary interceptor.
+func ChainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ChainStreamInterceptors returns an interceptor calling the given
+// interceptors in order, then the handler, as the gRPC server only accepts a
+// single stream interceptor.
+func ChainStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], handler
+			handler = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,52 @@
+package grpcutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestChainUnaryInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	resp, err := ChainUnaryInterceptors(interceptor("first"), interceptor("second"), interceptor("third"))(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "req", resp)
+	require.Equal(t, []string{"first", "second", "third", "handler"}, calls)
+
+	// Without interceptors, the handler is called directly
+	calls = nil
+	_, err = ChainUnaryInterceptors()(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	require.Equal(t, []string{"handler"}, calls)
+}
+
+func TestChainStreamInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, name)
+			return handler(srv, ss)
+		}
+	}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		calls = append(calls, "handler")
+		return nil
+	}
+
+	err := ChainStreamInterceptors(interceptor("first"), interceptor("second"), interceptor("third"))(nil, nil, &grpc.StreamServerInfo{}, handler)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", "third", "handler"}, calls)
+}
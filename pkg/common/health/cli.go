@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+)
+
+// CLI is the healthcheck command, checking the health of a running server or
+// agent through its health check endpoint or its local API socket
+type CLI struct {
+	// Name of the process checked, e.g. "server"
+	Name string
+
+	// Address the process serves its health checks on by default
+	DefaultAddress string
+
+	// Flag naming the path to the local API socket, and the name of that
+	// API, e.g. "adminSocketPath" and "admin API"
+	SocketFlag string
+	SocketAPI  string
+}
+
+type cliConfig struct {
+	// Address the process serves its health checks on
+	Addr string
+
+	// If set, the health of the process is checked through its local API
+	// socket instead of its health check endpoint
+	SocketPath string
+
+	// If true, the readiness of the process is checked instead of its
+	// liveness
+	Ready bool
+
+	// If true, only check that the process answers, without checking its
+	// components
+	Shallow bool
+
+	// If true, the health of each component checked is printed
+	Verbose bool
+
+	// Format to print the health in, cliprinter.Pretty or cliprinter.JSON
+	Output string
+
+	// How long to wait for the process to answer
+	Timeout time.Duration
+}
+
+func (c *CLI) Synopsis() string {
+	return fmt.Sprintf("Determines %s health status", c.Name)
+}
+
+func (c *CLI) Help() string {
+	_, err := c.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (c *CLI) AutocompleteFlags() complete.Flags {
+	return cliprinter.AutocompleteFlags(c.flagSet(&cliConfig{}))
+}
+
+func (*CLI) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *CLI) Run(args []string) int {
+	config, err := c.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	title := strings.Title(c.Name)
+	report, err := c.check(config)
+	if err != nil {
+		fmt.Printf("%s is unhealthy: %v\n", title, err)
+		return 1
+	}
+
+	if config.Output == cliprinter.JSON {
+		if err := cliprinter.PrintJSON(os.Stdout, report); err != nil {
+			fmt.Println(err.Error())
+			return 1
+		}
+		if !report.Healthy {
+			return 1
+		}
+		return 0
+	}
+
+	var problems []string
+	for _, component := range report.Components {
+		if config.Verbose {
+			status := "ok"
+			if !component.Healthy {
+				status = component.Error
+			}
+			fmt.Printf("%s: %s\n", component.Name, status)
+		}
+		if !component.Healthy {
+			problems = append(problems, component.Error)
+		}
+	}
+
+	if !report.Healthy {
+		if config.Verbose || len(problems) == 0 {
+			fmt.Printf("%s is unhealthy.\n", title)
+		} else {
+			fmt.Printf("%s is unhealthy: %s\n", title, strings.Join(problems, "; "))
+		}
+		return 1
+	}
+
+	fmt.Printf("%s is healthy.\n", title)
+	return 0
+}
+
+// check fetches the health report of the process, either from its health
+// check endpoint or through its local API socket
+func (c *CLI) check(config *cliConfig) (*Report, error) {
+	check := CheckLive
+	switch {
+	case config.Shallow:
+		check = CheckShallow
+	case config.Ready:
+		check = CheckReady
+	}
+
+	client := &http.Client{Timeout: config.Timeout}
+	base := "http://" + config.Addr
+	if config.SocketPath != "" {
+		client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", config.SocketPath)
+			},
+		}
+		base = "http://" + c.Name
+	}
+
+	resp, err := client.Get(base + ReportPath + "?check=" + check)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Unhealthy processes answer with a report too
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s", strings.TrimSpace(string(body)))
+	}
+
+	report := new(Report)
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("decode health report: %v", err)
+	}
+	return report, nil
+}
+
+func (c *CLI) newConfig(args []string) (*cliConfig, error) {
+	config := &cliConfig{}
+	f := c.flagSet(config)
+
+	if err := f.Parse(args); err != nil {
+		return nil, err
+	}
+	if config.Ready && config.Shallow {
+		return nil, errors.New("the -ready and -shallow flags can't be combined")
+	}
+	return config, nil
+}
+
+// flagSet defines the flags of the command, storing their values in config
+func (c *CLI) flagSet(config *cliConfig) *flag.FlagSet {
+	f := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	f.StringVar(&config.Addr, "address", c.DefaultAddress, fmt.Sprintf("Address the %s serves its health checks on", c.Name))
+	f.StringVar(&config.SocketPath, c.SocketFlag, "", fmt.Sprintf("Path to the %s socket of the %s. If set, the health is checked through it instead of the health check endpoint", c.SocketAPI, c.Name))
+	f.BoolVar(&config.Ready, "ready", false, fmt.Sprintf("Check the readiness of the %s instead of its liveness", c.Name))
+	f.BoolVar(&config.Shallow, "shallow", false, fmt.Sprintf("Only check that the %s answers, without checking its components", c.Name))
+	f.BoolVar(&config.Verbose, "verbose", false, "Print the health of each component checked")
+	cliprinter.AppendFlag(f, &config.Output)
+	f.DurationVar(&config.Timeout, "timeout", 5*time.Second, fmt.Sprintf("How long to wait for the %s to answer", c.Name))
+	return f
+}
@@ -0,0 +1,113 @@
+package health
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/cliprinter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCLI = &CLI{
+	Name:           "server",
+	DefaultAddress: "localhost:8080",
+	SocketFlag:     "adminSocketPath",
+	SocketAPI:      "admin API",
+}
+
+// reportHandler serves a healthy report for the liveness and shallow checks,
+// and an unhealthy one for the readiness check
+func reportHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ReportPath, func(w http.ResponseWriter, r *http.Request) {
+		report := &Report{Healthy: true}
+		switch r.URL.Query().Get("check") {
+		case CheckLive:
+			report.Components = []ComponentHealth{{Name: "ca", Healthy: true}}
+		case CheckReady:
+			report.Healthy = false
+			report.Components = []ComponentHealth{
+				{Name: "ca", Healthy: true},
+				{Name: "datastore", Error: "datastore is unavailable: connection refused"},
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(report)
+	})
+	return mux
+}
+
+func TestCheck(t *testing.T) {
+	server := httptest.NewServer(reportHandler())
+	defer server.Close()
+
+	addr := strings.TrimPrefix(server.URL, "http://")
+	cli := testCLI
+
+	report, err := cli.check(&cliConfig{Addr: addr, Timeout: time.Second})
+	require.NoError(t, err)
+	assert.True(t, report.Healthy)
+	assert.Len(t, report.Components, 1)
+
+	report, err = cli.check(&cliConfig{Addr: addr, Shallow: true, Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Healthy: true}, report)
+
+	report, err = cli.check(&cliConfig{Addr: addr, Ready: true, Timeout: time.Second})
+	require.NoError(t, err)
+	assert.False(t, report.Healthy)
+	assert.Equal(t, "datastore is unavailable: connection refused", report.Components[1].Error)
+}
+
+func TestCheckThroughSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "healthcheck-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "api.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	server := &http.Server{Handler: reportHandler()}
+	go server.Serve(l)
+	defer server.Close()
+
+	report, err := testCLI.check(&cliConfig{SocketPath: socketPath, Timeout: time.Second})
+	require.NoError(t, err)
+	assert.True(t, report.Healthy)
+}
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(reportHandler())
+	defer server.Close()
+
+	addr := strings.TrimPrefix(server.URL, "http://")
+	cli := testCLI
+
+	assert.Equal(t, 0, cli.Run([]string{"-address", addr, "-verbose"}))
+	assert.Equal(t, 0, cli.Run([]string{"-address", addr, "-output", "json"}))
+	assert.Equal(t, 1, cli.Run([]string{"-address", addr, "-ready"}))
+	assert.Equal(t, 1, cli.Run([]string{"-address", addr, "-ready", "-output", "json"}))
+	assert.Equal(t, 1, cli.Run([]string{"-address", addr, "-ready", "-shallow"}))
+}
+
+func TestNewConfig(t *testing.T) {
+	c, err := testCLI.newConfig([]string{"-ready", "-verbose", "-address", "localhost:9090"})
+	require.NoError(t, err)
+	assert.True(t, c.Ready)
+	assert.True(t, c.Verbose)
+	assert.Equal(t, "localhost:9090", c.Addr)
+	assert.Equal(t, cliprinter.Pretty, c.Output)
+	assert.Equal(t, 5*time.Second, c.Timeout)
+
+	_, err = testCLI.newConfig([]string{"-ready", "-shallow"})
+	assert.EqualError(t, err, "the -ready and -shallow flags can't be combined")
+}
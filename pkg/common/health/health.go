@@ -0,0 +1,187 @@
+// Package health holds what the health checks of the server and of the agent
+// have in common: the health report, the HTTP endpoints serving it, and the
+// healthcheck command fetching it.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LivePath and ReadyPath are the paths the liveness and readiness
+	// checks are served on
+	LivePath  = "/live"
+	ReadyPath = "/ready"
+
+	// ReportPath is the path the health report is served on. The check
+	// query parameter selects what is checked: CheckShallow, CheckLive (the
+	// default) or CheckReady.
+	ReportPath = "/health"
+
+	// checkTimeout bounds how long each component is waited for by a check
+	checkTimeout = 5 * time.Second
+)
+
+// Checks the health report can be made for
+const (
+	// CheckShallow only checks that the process answers, without checking
+	// its components
+	CheckShallow = "shallow"
+
+	// CheckLive checks the components the liveness of the process depends on
+	CheckLive = "live"
+
+	// CheckReady checks the components the readiness of the process depends
+	// on
+	CheckReady = "ready"
+)
+
+// Report is the health of the process and of each component checked
+type Report struct {
+	Healthy    bool              `json:"healthy"`
+	Components []ComponentHealth `json:"components,omitempty"`
+}
+
+// ComponentHealth is the health of a component of the process
+type ComponentHealth struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Component is a component of the process whose health is checked
+type Component struct {
+	Name string
+
+	// Check returns an error if the component is unhealthy
+	Check func(context.Context) error
+}
+
+// Checker tells which components each check depends on
+type Checker interface {
+	// Components returns the components the given check depends on, or an
+	// error if the check is unknown
+	Components(check string) ([]Component, error)
+}
+
+// MakeReport checks the health of the components the given check depends
+// on, each of them being checked even if another one is unhealthy
+func MakeReport(ctx context.Context, c Checker, check string) (*Report, error) {
+	components, err := c.Components(check)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Healthy: true}
+	for _, comp := range components {
+		status := ComponentHealth{Name: comp.Name, Healthy: true}
+		if err := withTimeout(ctx, comp.Check); err != nil {
+			status.Healthy = false
+			status.Error = err.Error()
+			report.Healthy = false
+		}
+		report.Components = append(report.Components, status)
+	}
+	return report, nil
+}
+
+// Handler returns the HTTP handler serving the liveness and readiness checks,
+// as plain text, and the health report
+func Handler(c Checker, log logrus.FieldLogger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivePath, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, checkError(r.Context(), c, CheckLive))
+	})
+	mux.HandleFunc(ReadyPath, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, checkError(r.Context(), c, CheckReady))
+	})
+	mux.Handle(ReportPath, ReportHandler(c, log))
+	return mux
+}
+
+// ReportHandler returns the HTTP handler serving the health report as JSON.
+// Unhealthy processes are reported with a 503 status.
+func ReportHandler(c Checker, log logrus.FieldLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		check := r.URL.Query().Get("check")
+		if check == "" {
+			check = CheckLive
+		}
+		report, err := MakeReport(r.Context(), c, check)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			log.Errorf("Failed to write health report: %v", err)
+		}
+	})
+}
+
+// ListenAndServe serves the handler on the address until the context is
+// cancelled
+func ListenAndServe(ctx context.Context, addr string, handler http.Handler, log logrus.FieldLogger) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("create health check listener: %v", err)
+	}
+
+	server := &http.Server{Handler: handler}
+
+	log.Infof("Serving health checks on %s", l.Addr())
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		<-errChan
+		return nil
+	}
+}
+
+// checkError returns the error of the first unhealthy component the check
+// depends on, if any
+func checkError(ctx context.Context, c Checker, check string) error {
+	components, err := c.Components(check)
+	if err != nil {
+		return err
+	}
+	for _, comp := range components {
+		if err := withTimeout(ctx, comp.Check); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withTimeout runs the check, bounding how long the component is waited for
+func withTimeout(ctx context.Context, check func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	return check(ctx)
+}
+
+func writeStatus(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "text/plain")
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, err)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
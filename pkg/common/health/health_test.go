@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChecker is live while its first component is healthy, and ready while
+// both its components are
+type fakeChecker struct {
+	errs    [2]error
+	checked []string
+}
+
+func (c *fakeChecker) Components(check string) ([]Component, error) {
+	first := Component{Name: "first", Check: c.checkFunc("first", 0)}
+	second := Component{Name: "second", Check: c.checkFunc("second", 1)}
+	switch check {
+	case CheckShallow:
+		return nil, nil
+	case CheckLive:
+		return []Component{first}, nil
+	case CheckReady:
+		return []Component{first, second}, nil
+	default:
+		return nil, fmt.Errorf("unknown check %q", check)
+	}
+}
+
+func (c *fakeChecker) checkFunc(name string, i int) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			return errors.New("no deadline")
+		}
+		c.checked = append(c.checked, name)
+		return c.errs[i]
+	}
+}
+
+func TestMakeReport(t *testing.T) {
+	ctx := context.Background()
+	c := &fakeChecker{}
+
+	report, err := MakeReport(ctx, c, CheckShallow)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Healthy: true}, report)
+
+	report, err = MakeReport(ctx, c, CheckReady)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		Healthy: true,
+		Components: []ComponentHealth{
+			{Name: "first", Healthy: true},
+			{Name: "second", Healthy: true},
+		},
+	}, report)
+
+	// Every component is checked, even after one is found unhealthy
+	c.errs[0] = errors.New("first failed")
+	c.checked = nil
+	report, err = MakeReport(ctx, c, CheckReady)
+	require.NoError(t, err)
+	assert.Equal(t, &Report{
+		Healthy: false,
+		Components: []ComponentHealth{
+			{Name: "first", Error: "first failed"},
+			{Name: "second", Healthy: true},
+		},
+	}, report)
+	assert.Equal(t, []string{"first", "second"}, c.checked)
+
+	_, err = MakeReport(ctx, c, "deep")
+	assert.EqualError(t, err, `unknown check "deep"`)
+}
+
+func TestHandler(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	c := &fakeChecker{}
+	c.errs[1] = errors.New("second failed")
+
+	server := httptest.NewServer(Handler(c, log))
+	defer server.Close()
+
+	status, body := get(t, server.URL+LivePath)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok\n", body)
+
+	// The first unhealthy component is reported, and the ones after it are
+	// not checked
+	c.errs[0] = errors.New("first failed")
+	c.checked = nil
+	status, body = get(t, server.URL+ReadyPath)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "first failed\n", body)
+	assert.Equal(t, []string{"first"}, c.checked)
+}
+
+func TestReportHandler(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	c := &fakeChecker{}
+	c.errs[1] = errors.New("second failed")
+
+	server := httptest.NewServer(ReportHandler(c, log))
+	defer server.Close()
+
+	// The liveness is checked by default
+	status, body := get(t, server.URL)
+	assert.Equal(t, http.StatusOK, status)
+	report := new(Report)
+	require.NoError(t, json.Unmarshal([]byte(body), report))
+	assert.Equal(t, &Report{
+		Healthy:    true,
+		Components: []ComponentHealth{{Name: "first", Healthy: true}},
+	}, report)
+
+	status, body = get(t, server.URL+"?check=ready")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	report = new(Report)
+	require.NoError(t, json.Unmarshal([]byte(body), report))
+	assert.False(t, report.Healthy)
+
+	status, body = get(t, server.URL+"?check=deep")
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "unknown check \"deep\"\n", body)
+}
+
+func get(t *testing.T, url string) (int, string) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
@@ -0,0 +1,33 @@
+package jwtsvid
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+
+	"github.com/spiffe/spire/pkg/common/x509util"
+)
+
+// KeyID returns the identifier of a JWT signing key, derived from the
+// subject key identifier of its public key
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	keyID, err := x509util.GetSubjectKeyId(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(keyID), nil
+}
+
+// KeysFromCertificates returns the public keys of the bundle certificates,
+// keyed by key ID
+func KeysFromCertificates(certs []*x509.Certificate) (map[string]crypto.PublicKey, error) {
+	keys := make(map[string]crypto.PublicKey)
+	for _, cert := range certs {
+		keyID, err := KeyID(cert.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[keyID] = cert.PublicKey
+	}
+	return keys, nil
+}
@@ -0,0 +1,24 @@
+package jwtsvid
+
+import (
+	"crypto"
+	"crypto/x509"
+	"testing"
+
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeysFromCertificates(t *testing.T) {
+	template, err := util.NewCATemplate("example.org")
+	require.NoError(t, err)
+	cert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+
+	keyID, err := KeyID(cert.PublicKey)
+	require.NoError(t, err)
+
+	keys, err := KeysFromCertificates([]*x509.Certificate{cert})
+	require.NoError(t, err)
+	require.Equal(t, map[string]crypto.PublicKey{keyID: cert.PublicKey}, keys)
+}
@@ -0,0 +1,194 @@
+package jwtsvid
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+// DefaultTTL is the TTL of JWT-SVIDs signed without an explicit TTL
+const DefaultTTL = 5 * time.Minute
+
+// KeyStore looks up the public keys used to validate JWT-SVIDs
+type KeyStore interface {
+	// FindPublicKey returns the public key with the given key ID from the
+	// bundle of the trust domain.
+	FindPublicKey(trustDomainID, keyID string) (crypto.PublicKey, error)
+}
+
+// SignToken signs a JWT-SVID for the SPIFFE ID, valid for the audience
+// until expiresAt. The key ID of the signing key is set in the header so
+// validators can pick the right key out of the bundle.
+func SignToken(spiffeID string, audience []string, expiresAt time.Time, signer crypto.PrivateKey, keyID string) (string, error) {
+	return SignTokenWithIssuer("", spiffeID, audience, expiresAt, signer, keyID)
+}
+
+// SignTokenWithIssuer signs a JWT-SVID as SignToken does, setting the issuer
+// claim if not empty, for validators which require it, such as those
+// discovering the keys through OpenID Connect discovery.
+func SignTokenWithIssuer(issuer, spiffeID string, audience []string, expiresAt time.Time, signer crypto.PrivateKey, keyID string) (string, error) {
+	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAny()); err != nil {
+		return "", err
+	}
+	if len(audience) == 0 {
+		return "", errors.New("audience is required")
+	}
+
+	method, err := signingMethod(signer)
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{
+		"sub": spiffeID,
+		"aud": audience,
+		"exp": expiresAt.Unix(),
+		"iat": time.Now().Unix(),
+	}
+	if issuer != "" {
+		claims["iss"] = issuer
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = keyID
+	return token.SignedString(signer)
+}
+
+// ValidateToken validates the signature and claims of a JWT-SVID. The
+// token must carry every one of the expected audience values. It returns
+// the SPIFFE ID of the token subject and all of its claims.
+func ValidateToken(token string, keyStore KeyStore, audience []string) (string, map[string]interface{}, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodECDSA, *jwt.SigningMethodRSA:
+		default:
+			return nil, fmt.Errorf("unsupported signing method %q", t.Method.Alg())
+		}
+
+		keyID, ok := t.Header["kid"].(string)
+		if !ok || keyID == "" {
+			return nil, errors.New("token header missing key id")
+		}
+
+		spiffeID, err := subject(claims)
+		if err != nil {
+			return nil, err
+		}
+		trustDomainID := "spiffe://" + spiffeID.Host
+
+		return keyStore.FindPublicKey(trustDomainID, keyID)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		return "", nil, errors.New("token missing exp claim")
+	}
+
+	tokenAudience, err := claimAudience(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, expected := range audience {
+		if !contains(tokenAudience, expected) {
+			return "", nil, fmt.Errorf("expected audience %q", expected)
+		}
+	}
+
+	spiffeID, err := subject(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return spiffeID.String(), claims, nil
+}
+
+// GetTokenExpiry returns the issue and expiry times of a JWT-SVID without
+// validating it. It must only be used on tokens from a trusted source.
+func GetTokenExpiry(token string) (issuedAt, expiresAt time.Time, err error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(token, claims); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return time.Time{}, time.Time{}, errors.New("token missing iat claim")
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}, time.Time{}, errors.New("token missing exp claim")
+	}
+	return time.Unix(int64(iat), 0), time.Unix(int64(exp), 0), nil
+}
+
+func subject(claims jwt.MapClaims) (*url.URL, error) {
+	sub, ok := claims["sub"].(string)
+	if !ok || sub == "" {
+		return nil, errors.New("token missing sub claim")
+	}
+	id, err := idutil.ParseSpiffeID(sub, idutil.AllowAny())
+	if err != nil {
+		return nil, fmt.Errorf("token sub claim is not a valid SPIFFE ID: %v", err)
+	}
+	return id, nil
+}
+
+// claimAudience returns the aud claim, which may either be a string or a list
+// of strings
+func claimAudience(claims jwt.MapClaims) ([]string, error) {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return []string{aud}, nil
+	case []interface{}:
+		var audience []string
+		for _, v := range aud {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("token aud claim must only contain strings")
+			}
+			audience = append(audience, s)
+		}
+		return audience, nil
+	case nil:
+		return nil, errors.New("token missing aud claim")
+	default:
+		return nil, errors.New("token aud claim must be a string or a list of strings")
+	}
+}
+
+func signingMethod(signer crypto.PrivateKey) (jwt.SigningMethod, error) {
+	switch key := signer.(type) {
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("unsupported curve %q", key.Curve.Params().Name)
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", signer)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
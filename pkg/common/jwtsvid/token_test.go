@@ -0,0 +1,128 @@
+package jwtsvid
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestToken(t *testing.T) {
+	suite.Run(t, new(TokenSuite))
+}
+
+type TokenSuite struct {
+	suite.Suite
+
+	key   *ecdsa.PrivateKey
+	keyID string
+}
+
+func (s *TokenSuite) SetupTest() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	s.key = key
+	s.keyID, err = KeyID(key.Public())
+	s.Require().NoError(err)
+}
+
+func (s *TokenSuite) TestSignAndValidate() {
+	token := s.sign("spiffe://example.org/foo", []string{"audience", "other"}, time.Now().Add(time.Minute))
+
+	spiffeID, claims, err := ValidateToken(token, s, []string{"audience"})
+	s.Require().NoError(err)
+	s.Require().Equal("spiffe://example.org/foo", spiffeID)
+	s.Require().Equal("spiffe://example.org/foo", claims["sub"])
+	s.Require().Equal([]interface{}{"audience", "other"}, claims["aud"])
+}
+
+func (s *TokenSuite) TestSignWithIssuer() {
+	token, err := SignTokenWithIssuer("https://oidc.example.org", "spiffe://example.org/foo", []string{"audience"}, time.Now().Add(time.Minute), s.key, s.keyID)
+	s.Require().NoError(err)
+
+	_, claims, err := ValidateToken(token, s, []string{"audience"})
+	s.Require().NoError(err)
+	s.Require().Equal("https://oidc.example.org", claims["iss"])
+
+	// No issuer claim is set by default
+	_, claims, err = ValidateToken(s.sign("spiffe://example.org/foo", []string{"audience"}, time.Now().Add(time.Minute)), s, []string{"audience"})
+	s.Require().NoError(err)
+	s.Require().NotContains(claims, "iss")
+}
+
+func (s *TokenSuite) TestGetTokenExpiry() {
+	expiresAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	token := s.sign("spiffe://example.org/foo", []string{"audience"}, expiresAt)
+
+	issuedAt, actual, err := GetTokenExpiry(token)
+	s.Require().NoError(err)
+	s.Require().Equal(expiresAt, actual)
+	s.Require().WithinDuration(time.Now(), issuedAt, time.Second*2)
+
+	_, _, err = GetTokenExpiry("foo")
+	s.Require().Error(err)
+}
+
+func (s *TokenSuite) TestSignRequiresAudience() {
+	_, err := SignToken("spiffe://example.org/foo", nil, time.Now().Add(time.Minute), s.key, s.keyID)
+	s.Require().EqualError(err, "audience is required")
+}
+
+func (s *TokenSuite) TestSignRequiresSpiffeID() {
+	_, err := SignToken("foo", []string{"audience"}, time.Now().Add(time.Minute), s.key, s.keyID)
+	s.Require().Error(err)
+}
+
+func (s *TokenSuite) TestValidateAudienceMismatch() {
+	token := s.sign("spiffe://example.org/foo", []string{"audience"}, time.Now().Add(time.Minute))
+
+	_, _, err := ValidateToken(token, s, []string{"other"})
+	s.Require().EqualError(err, `expected audience "other"`)
+}
+
+func (s *TokenSuite) TestValidateExpired() {
+	token := s.sign("spiffe://example.org/foo", []string{"audience"}, time.Now().Add(-time.Minute))
+
+	_, _, err := ValidateToken(token, s, []string{"audience"})
+	s.Require().Error(err)
+}
+
+func (s *TokenSuite) TestValidateUnknownTrustDomain() {
+	token := s.sign("spiffe://otherdomain.org/foo", []string{"audience"}, time.Now().Add(time.Minute))
+
+	_, _, err := ValidateToken(token, s, []string{"audience"})
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), `no keys for trust domain "spiffe://otherdomain.org"`)
+}
+
+func (s *TokenSuite) TestValidateWrongKey() {
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	token, err := SignToken("spiffe://example.org/foo", []string{"audience"}, time.Now().Add(time.Minute), otherKey, s.keyID)
+	s.Require().NoError(err)
+
+	_, _, err = ValidateToken(token, s, []string{"audience"})
+	s.Require().Error(err)
+}
+
+func (s *TokenSuite) sign(spiffeID string, audience []string, expiresAt time.Time) string {
+	token, err := SignToken(spiffeID, audience, expiresAt, s.key, s.keyID)
+	s.Require().NoError(err)
+	return token
+}
+
+// FindPublicKey implements KeyStore with the suite key
+func (s *TokenSuite) FindPublicKey(trustDomainID, keyID string) (crypto.PublicKey, error) {
+	if trustDomainID != "spiffe://example.org" {
+		return nil, errors.New(`no keys for trust domain "` + trustDomainID + `"`)
+	}
+	if keyID != s.keyID {
+		return nil, errors.New("unknown key")
+	}
+	return s.key.Public(), nil
+}
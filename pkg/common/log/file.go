@@ -0,0 +1,83 @@
+package log
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// reopenableFile is a log file which can be closed and opened again, so that
+// it can be rotated by tools such as logrotate
+type reopenableFile struct {
+	mtx  sync.Mutex
+	path string
+	f    *os.File
+}
+
+func openFile(path string) (*reopenableFile, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return &reopenableFile{
+		path: path,
+		f:    f,
+	}, nil
+}
+
+func (r *reopenableFile) Write(p []byte) (int, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.f.Write(p)
+}
+
+// Reopen opens the file again, creating it if it has been moved away, and
+// closes the previous one. The previous file is kept if it cannot be opened.
+func (r *reopenableFile) Reopen() error {
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.f.Close()
+	r.f = f
+	return nil
+}
+
+// Reopen opens the log file again, to be called once it has been rotated.
+// It does nothing when logging to stdout.
+func (l *Logger) Reopen() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Reopen()
+}
+
+// ReopenOnSignal reopens the log file whenever the process receives SIGHUP,
+// until the context is cancelled
+func (l *Logger) ReopenOnSignal(ctx context.Context) {
+	if l.file == nil {
+		return
+	}
+
+	go func() {
+		signalCh := make(chan os.Signal, 1)
+		signal.Notify(signalCh, syscall.SIGHUP)
+		defer signal.Stop(signalCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signalCh:
+				if err := l.Reopen(); err != nil {
+					l.Errorf("Could not reopen the log file: %v", err)
+				}
+			}
+		}
+	}()
+}
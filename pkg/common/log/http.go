@@ -0,0 +1,69 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LevelsPath is the path the log levels are served on by the admin APIs.
+// A POST sets the level given in the level query parameter, of the
+// subsystem given in the subsystem query parameter if any, or else the
+// default one. An empty level resets the subsystem to the default level.
+const LevelsPath = "/log/levels"
+
+// LevelsResponse describes the log levels
+type LevelsResponse struct {
+	Level           string            `json:"level"`
+	SubsystemLevels map[string]string `json:"subsystem_levels"`
+}
+
+// LevelsHandler serves and changes the log levels
+func LevelsHandler(levels *Levels) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			if err := setLevel(levels, r.URL.Query().Get("subsystem"), r.URL.Query().Get("level")); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := &LevelsResponse{
+			Level:           levels.Level().String(),
+			SubsystemLevels: make(map[string]string),
+		}
+		for subsystem, level := range levels.SubsystemLevels() {
+			resp.SubsystemLevels[subsystem] = level.String()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func setLevel(levels *Levels, subsystem, value string) error {
+	if value == "" {
+		if subsystem == "" {
+			return fmt.Errorf("a level is required")
+		}
+		levels.ResetSubsystemLevel(subsystem)
+		return nil
+	}
+
+	level, err := logrus.ParseLevel(value)
+	if err != nil {
+		return err
+	}
+	if subsystem == "" {
+		levels.SetLevel(level)
+	} else {
+		levels.SetSubsystemLevel(subsystem, level)
+	}
+	return nil
+}
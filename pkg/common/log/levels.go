@@ -0,0 +1,89 @@
+package log
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Levels keeps the log level of every subsystem. The level of the logger
+// is kept at the most verbose of them, so that logrus does not drop the
+// entries of subsystems more verbose than the default level.
+type Levels struct {
+	mtx        sync.RWMutex
+	logger     *logrus.Logger
+	level      logrus.Level
+	subsystems map[string]logrus.Level
+}
+
+func newLevels(logger *logrus.Logger, level logrus.Level) *Levels {
+	l := &Levels{
+		logger:     logger,
+		level:      level,
+		subsystems: make(map[string]logrus.Level),
+	}
+	l.updateLoggerLevel()
+	return l
+}
+
+// Level returns the level of the subsystems which have none of their own
+func (l *Levels) Level() logrus.Level {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.level
+}
+
+// SetLevel sets the level of the subsystems which have none of their own
+func (l *Levels) SetLevel(level logrus.Level) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.level = level
+	l.updateLoggerLevel()
+}
+
+// SubsystemLevel returns the level of the subsystem
+func (l *Levels) SubsystemLevel(subsystem string) logrus.Level {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	if level, ok := l.subsystems[subsystem]; ok {
+		return level
+	}
+	return l.level
+}
+
+// SetSubsystemLevel sets the level of the subsystem
+func (l *Levels) SetSubsystemLevel(subsystem string, level logrus.Level) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.subsystems[subsystem] = level
+	l.updateLoggerLevel()
+}
+
+// ResetSubsystemLevel makes the subsystem log at the default level again
+func (l *Levels) ResetSubsystemLevel(subsystem string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	delete(l.subsystems, subsystem)
+	l.updateLoggerLevel()
+}
+
+// SubsystemLevels returns the levels of the subsystems which have their own
+func (l *Levels) SubsystemLevels() map[string]logrus.Level {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	levels := make(map[string]logrus.Level)
+	for subsystem, level := range l.subsystems {
+		levels[subsystem] = level
+	}
+	return levels
+}
+
+func (l *Levels) updateLoggerLevel() {
+	max := l.level
+	for _, level := range l.subsystems {
+		if level > max {
+			max = level
+		}
+	}
+	l.logger.SetLevel(max)
+}
@@ -1,33 +1,125 @@
 package log
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
 
-func NewLogger(logLevel string, fileName string) (logrus.FieldLogger, error) {
-	var fd io.Writer
-	var err error
+// Names of the fields shared by the log entries of every component, so that
+// entries can be correlated when shipped to a log aggregator.
+const (
+	// Name of the subsystem logging the entry
+	SubsystemName = "subsystem_name"
 
-	if fileName != "" {
-		fd, err = os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		fd = os.Stdout
-	}
+	// SPIFFE ID the entry is about, e.g. the one of the SVID being signed
+	SPIFFEID = "spiffe_id"
+
+	// SPIFFE ID or address of the caller of the API the entry is about
+	Caller = "caller"
+
+	// Name of the RPC being served
+	RPC = "rpc"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+type Config struct {
+	// Level of the subsystems which have none of their own
+	Level string
+
+	// Level of individual subsystems, keyed by subsystem name
+	SubsystemLevels map[string]string
+
+	// Format of the entries, text or json. Defaults to text.
+	Format string
+
+	// File to append the entries to. Entries are written to stdout if empty.
+	// The file is reopened on SIGHUP, see ReopenOnSignal.
+	File string
+}
+
+// Logger is a logrus logger whose level can be set per subsystem, including
+// at runtime
+type Logger struct {
+	*logrus.Logger
+
+	Levels *Levels
+
+	// file the entries are written to, nil when writing to stdout
+	file *reopenableFile
+}
 
-	logrusLevel, err := logrus.ParseLevel(logLevel)
+func New(c Config) (*Logger, error) {
+	level, err := logrus.ParseLevel(c.Level)
 	if err != nil {
 		return nil, err
 	}
 
+	var formatter logrus.Formatter
+	switch strings.ToLower(c.Format) {
+	case "", TextFormat:
+		formatter = new(logrus.TextFormatter)
+	case JSONFormat:
+		formatter = new(logrus.JSONFormatter)
+	default:
+		return nil, fmt.Errorf("unknown log format %q, must be %q or %q", c.Format, TextFormat, JSONFormat)
+	}
+
+	var fd io.Writer = os.Stdout
+	var file *reopenableFile
+	if c.File != "" {
+		file, err = openFile(c.File)
+		if err != nil {
+			return nil, err
+		}
+		fd = file
+	}
+
 	logger := logrus.New()
 	logger.Out = fd
-	logger.Level = logrusLevel
 
-	return logger, nil
+	levels := newLevels(logger, level)
+	for subsystem, l := range c.SubsystemLevels {
+		subsystemLevel, err := logrus.ParseLevel(l)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level of subsystem %q: %v", subsystem, err)
+		}
+		levels.SetSubsystemLevel(subsystem, subsystemLevel)
+	}
+	logger.Formatter = levelFormatter{Formatter: formatter, levels: levels}
+
+	return &Logger{
+		Logger: logger,
+		Levels: levels,
+		file:   file,
+	}, nil
+}
+
+func NewLogger(logLevel string, fileName string) (*Logger, error) {
+	return New(Config{
+		Level: logLevel,
+		File:  fileName,
+	})
+}
+
+// levelFormatter drops the entries below the level of the subsystem they
+// were logged by, as logrus only knows of a single level.
+type levelFormatter struct {
+	logrus.Formatter
+	levels *Levels
+}
+
+func (f levelFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	subsystem, _ := entry.Data[SubsystemName].(string)
+	if entry.Level > f.levels.SubsystemLevel(subsystem) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
 }
@@ -0,0 +1,141 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSON(t *testing.T) {
+	logger, err := New(Config{
+		Level:  "INFO",
+		Format: "json",
+	})
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	logger.Out = buf
+
+	logger.WithField(SPIFFEID, "spiffe://example.org/workload").Info("Signed SVID")
+
+	entry := make(map[string]interface{})
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "Signed SVID", entry["msg"])
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "spiffe://example.org/workload", entry["spiffe_id"])
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := New(Config{Level: "LOUD"})
+	require.Error(t, err)
+
+	_, err = New(Config{Level: "INFO", Format: "xml"})
+	require.EqualError(t, err, `unknown log format "xml", must be "text" or "json"`)
+
+	_, err = New(Config{Level: "INFO", SubsystemLevels: map[string]string{"catalog": "LOUD"}})
+	require.Error(t, err)
+}
+
+func TestSubsystemLevels(t *testing.T) {
+	logger, err := New(Config{
+		Level:           "WARN",
+		SubsystemLevels: map[string]string{"catalog": "DEBUG"},
+	})
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	logger.Out = buf
+	require.Equal(t, logrus.DebugLevel, logger.Level)
+
+	catalog := logger.WithField(SubsystemName, "catalog")
+	endpoints := logger.WithField(SubsystemName, "endpoints")
+
+	catalog.Debug("catalog debug")
+	endpoints.Info("endpoints info")
+	endpoints.Warn("endpoints warn")
+	require.Contains(t, buf.String(), "catalog debug")
+	require.NotContains(t, buf.String(), "endpoints info")
+	require.Contains(t, buf.String(), "endpoints warn")
+
+	buf.Reset()
+	logger.Levels.ResetSubsystemLevel("catalog")
+	logger.Levels.SetSubsystemLevel("endpoints", logrus.InfoLevel)
+	require.Equal(t, logrus.InfoLevel, logger.Level)
+
+	catalog.Debug("catalog debug")
+	endpoints.Info("endpoints info")
+	require.NotContains(t, buf.String(), "catalog debug")
+	require.Contains(t, buf.String(), "endpoints info")
+}
+
+func TestLevelsHandler(t *testing.T) {
+	logger, err := New(Config{Level: "INFO"})
+	require.NoError(t, err)
+	handler := LevelsHandler(logger.Levels)
+
+	do := func(method, query string) (int, *LevelsResponse) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, LevelsPath+query, nil))
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		resp := new(LevelsResponse)
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		return w.Code, resp
+	}
+
+	code, resp := do("GET", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, &LevelsResponse{Level: "info", SubsystemLevels: map[string]string{}}, resp)
+
+	_, resp = do("POST", "?subsystem=catalog&level=debug")
+	require.Equal(t, &LevelsResponse{Level: "info", SubsystemLevels: map[string]string{"catalog": "debug"}}, resp)
+
+	_, resp = do("POST", "?level=warn")
+	require.Equal(t, &LevelsResponse{Level: "warning", SubsystemLevels: map[string]string{"catalog": "debug"}}, resp)
+
+	_, resp = do("POST", "?subsystem=catalog")
+	require.Equal(t, &LevelsResponse{Level: "warning", SubsystemLevels: map[string]string{}}, resp)
+
+	code, _ = do("POST", "?level=loud")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = do("POST", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = do("DELETE", "")
+	require.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-log")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "spire.log")
+	logger, err := New(Config{
+		Level: "INFO",
+		File:  path,
+	})
+	require.NoError(t, err)
+
+	logger.Info("before rotation")
+	require.NoError(t, os.Rename(path, path+".1"))
+	logger.Info("while rotating")
+	require.NoError(t, logger.Reopen())
+	logger.Info("after rotation")
+
+	rotated, err := ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	require.Contains(t, string(rotated), "before rotation")
+	require.Contains(t, string(rotated), "while rotating")
+
+	current, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(current), "rotating")
+	require.Contains(t, string(current), "after rotation")
+}
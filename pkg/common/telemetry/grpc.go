@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor counts and times every unary call, and counts the
+// calls which failed by status code. The metrics are labeled by gRPC service
+// and method so that every API is reported the same way.
+func UnaryServerInterceptor(t Sink) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer observeRPC(t, info.FullMethod, time.Now(), &err)
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor counts and times every streaming call, and counts
+// the calls which failed by status code. The latency of a stream is how long
+// it stayed open.
+func StreamServerInterceptor(t Sink) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer observeRPC(t, info.FullMethod, time.Now(), &err)
+		return handler(srv, ss)
+	}
+}
+
+func observeRPC(t Sink, fullMethod string, start time.Time, err *error) {
+	labels := rpcLabels(fullMethod)
+	t.IncrCounterWithLabels([]string{"rpc", "requests"}, 1, labels)
+	t.MeasureSinceWithLabels([]string{"rpc", "latency"}, start, labels)
+	if *err != nil {
+		t.IncrCounterWithLabels([]string{"rpc", "errors"}, 1, append(labels, Label{
+			Name:  "code",
+			Value: status.Code(*err).String(),
+		}))
+	}
+}
+
+// rpcLabels splits a full gRPC method name, e.g. /spire.api.node.Node/Attest,
+// into service and method labels
+func rpcLabels(fullMethod string) []Label {
+	service, method := "", strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service, method = method[:i], method[i+1:]
+	}
+	return []Label{
+		{Name: "service", Value: service},
+		{Name: "method", Value: method},
+	}
+}
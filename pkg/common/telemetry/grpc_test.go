@@ -0,0 +1,53 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRPCInterceptors(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	p := NewPrometheusSink()
+	s := NewSink(&SinkConfig{
+		Logger:      ioutil.Discard,
+		ServiceName: "spire_server",
+		Prometheus:  p,
+		StopChan:    stop,
+	})
+
+	unary := UnaryServerInterceptor(s)
+	unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/spire.api.node.Node/FetchJWTSVID"}
+	_, err := unary(context.Background(), nil, unaryInfo, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	_, err = unary(context.Background(), nil, unaryInfo, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	})
+	require.Error(t, err)
+
+	stream := StreamServerInterceptor(s)
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/spire.api.node.Node/Attest"}
+	err = stream(nil, nil, streamInfo, func(interface{}, grpc.ServerStream) error {
+		return errors.New("oh no")
+	})
+	require.Error(t, err)
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	require.Contains(t, body, `spire_server_rpc_requests{method="FetchJWTSVID",service="spire.api.node.Node"} 2`)
+	require.Contains(t, body, `spire_server_rpc_requests{method="Attest",service="spire.api.node.Node"} 1`)
+	require.Contains(t, body, `spire_server_rpc_latency_count{method="FetchJWTSVID",service="spire.api.node.Node"} 2`)
+	require.Contains(t, body, `spire_server_rpc_errors{code="PermissionDenied",method="FetchJWTSVID",service="spire.api.node.Node"} 1`)
+	require.Contains(t, body, `spire_server_rpc_errors{code="Unknown",method="Attest",service="spire.api.node.Node"} 1`)
+}
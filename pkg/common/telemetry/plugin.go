@@ -0,0 +1,124 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/proto/common/metricsink"
+)
+
+const (
+	// pluginFlushInterval is how often the metrics are sent to the plugins
+	pluginFlushInterval = time.Second
+
+	// maxPluginBatch is the number of metrics kept between two flushes.
+	// Metrics are dropped beyond it, e.g. while the plugins are not loaded.
+	maxPluginBatch = 10000
+)
+
+// PluginSink sends the metrics to MetricSink plugins, so that they can be
+// exported to pipelines which are not supported out of the box. The metrics
+// are batched and sent every second to each of the plugins.
+type PluginSink struct {
+	log logrus.FieldLogger
+
+	mtx     sync.Mutex
+	batch   []*metricsink.Metric
+	dropped int
+}
+
+func NewPluginSink(log logrus.FieldLogger) *PluginSink {
+	return &PluginSink{
+		log: log,
+	}
+}
+
+func (s *PluginSink) SetGauge(key []string, val float32) {
+	s.add(metricsink.Metric_GAUGE, key, val, nil)
+}
+
+func (s *PluginSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.add(metricsink.Metric_GAUGE, key, val, labels)
+}
+
+func (s *PluginSink) EmitKey(key []string, val float32) {
+	s.add(metricsink.Metric_KEY, key, val, nil)
+}
+
+func (s *PluginSink) IncrCounter(key []string, val float32) {
+	s.add(metricsink.Metric_COUNTER, key, val, nil)
+}
+
+func (s *PluginSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.add(metricsink.Metric_COUNTER, key, val, labels)
+}
+
+func (s *PluginSink) AddSample(key []string, val float32) {
+	s.add(metricsink.Metric_SAMPLE, key, val, nil)
+}
+
+func (s *PluginSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.add(metricsink.Metric_SAMPLE, key, val, labels)
+}
+
+func (s *PluginSink) add(t metricsink.Metric_Type, key []string, val float32, labels []metrics.Label) {
+	m := &metricsink.Metric{
+		Type:  t,
+		Key:   append([]string(nil), key...),
+		Value: val,
+	}
+	for _, l := range labels {
+		m.Labels = append(m.Labels, &metricsink.Label{Name: l.Name, Value: l.Value})
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if len(s.batch) >= maxPluginBatch {
+		s.dropped++
+		return
+	}
+	s.batch = append(s.batch, m)
+}
+
+// Run sends the metrics to the plugins every second, until the context is
+// cancelled. The metrics are discarded if there are no plugins.
+func (s *PluginSink) Run(ctx context.Context, sinks []metricsink.MetricSink) error {
+	ticker := time.NewTicker(pluginFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(ctx, sinks)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *PluginSink) flush(ctx context.Context, sinks []metricsink.MetricSink) {
+	s.mtx.Lock()
+	batch, dropped := s.batch, s.dropped
+	s.batch, s.dropped = nil, 0
+	s.mtx.Unlock()
+
+	if dropped > 0 {
+		s.log.Warnf("Dropped %d metrics which could not be sent to the plugins in time", dropped)
+	}
+	if len(batch) == 0 || len(sinks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pluginFlushInterval)
+	defer cancel()
+
+	req := &metricsink.EmitMetricsRequest{Metrics: batch}
+	for _, sink := range sinks {
+		if _, err := sink.EmitMetrics(ctx, req); err != nil {
+			s.log.Warnf("Could not send metrics to plugin: %v", err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/armon/go-metrics"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/common/metricsink"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginSink(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	s := NewPluginSink(log)
+
+	s.SetGauge([]string{"spire_agent", "cache_manager", "cached_entries"}, 5)
+	s.IncrCounterWithLabels([]string{"spire_agent", "rpc", "requests"}, 1, []metrics.Label{{Name: "method", Value: "FetchX509SVID"}})
+
+	a := &fakeMetricSink{}
+	b := &fakeMetricSink{err: errors.New("oh no")}
+	s.flush(context.Background(), []metricsink.MetricSink{a, b})
+
+	expected := []*metricsink.Metric{
+		{
+			Type:  metricsink.Metric_GAUGE,
+			Key:   []string{"spire_agent", "cache_manager", "cached_entries"},
+			Value: 5,
+		},
+		{
+			Type:   metricsink.Metric_COUNTER,
+			Key:    []string{"spire_agent", "rpc", "requests"},
+			Value:  1,
+			Labels: []*metricsink.Label{{Name: "method", Value: "FetchX509SVID"}},
+		},
+	}
+	require.Equal(t, expected, a.metrics)
+	require.Equal(t, expected, b.metrics)
+	require.Len(t, hook.AllEntries(), 1)
+
+	// the batch is sent only once
+	s.flush(context.Background(), []metricsink.MetricSink{a})
+	require.Len(t, a.metrics, 2)
+}
+
+func TestPluginSinkDropsMetrics(t *testing.T) {
+	log, hook := test.NewNullLogger()
+	s := NewPluginSink(log)
+
+	for i := 0; i < maxPluginBatch+1; i++ {
+		s.EmitKey([]string{"key"}, 1)
+	}
+
+	a := &fakeMetricSink{}
+	s.flush(context.Background(), []metricsink.MetricSink{a})
+	require.Len(t, a.metrics, maxPluginBatch)
+	require.Equal(t, "Dropped 1 metrics which could not be sent to the plugins in time", hook.LastEntry().Message)
+}
+
+type fakeMetricSink struct {
+	metrics []*metricsink.Metric
+	err     error
+}
+
+func (s *fakeMetricSink) EmitMetrics(ctx context.Context, req *metricsink.EmitMetricsRequest) (*metricsink.EmitMetricsResponse, error) {
+	s.metrics = append(s.metrics, req.Metrics...)
+	return &metricsink.EmitMetricsResponse{}, s.err
+}
@@ -0,0 +1,210 @@
+package telemetry
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/armon/go-metrics"
+)
+
+const (
+	promGauge   = "gauge"
+	promCounter = "counter"
+	promSummary = "summary"
+
+	// Number of the latest samples of each summary series the quantiles are
+	// computed over
+	promSummaryWindow = 1024
+)
+
+var (
+	promQuantiles = []float64{0.5, 0.9, 0.99}
+
+	promInvalidChars  = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+	promLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// PrometheusSink keeps the last value of the gauges, the totals of the
+// counters and the count and sum of the samples it receives, along with the
+// median, 90th and 99th percentiles of the latest samples, and serves them in
+// the Prometheus text exposition format. Keys are joined with underscores.
+type PrometheusSink struct {
+	mtx      sync.Mutex
+	families map[string]*promFamily
+}
+
+type promFamily struct {
+	typ    string
+	series map[string]*promSeries
+}
+
+type promSeries struct {
+	labels string
+	value  float64
+	count  uint64
+
+	// Latest samples of a summary, as a ring of up to promSummaryWindow
+	samples []float64
+}
+
+func NewPrometheusSink() *PrometheusSink {
+	return &PrometheusSink{
+		families: make(map[string]*promFamily),
+	}
+}
+
+func (p *PrometheusSink) SetGauge(key []string, val float32) {
+	p.SetGaugeWithLabels(key, val, nil)
+}
+
+func (p *PrometheusSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	p.update(promGauge, key, labels, func(s *promSeries) {
+		s.value = float64(val)
+	})
+}
+
+// EmitKey has no Prometheus counterpart, the values are dropped
+func (p *PrometheusSink) EmitKey(key []string, val float32) {}
+
+func (p *PrometheusSink) IncrCounter(key []string, val float32) {
+	p.IncrCounterWithLabels(key, val, nil)
+}
+
+func (p *PrometheusSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	p.update(promCounter, key, labels, func(s *promSeries) {
+		s.value += float64(val)
+	})
+}
+
+func (p *PrometheusSink) AddSample(key []string, val float32) {
+	p.AddSampleWithLabels(key, val, nil)
+}
+
+func (p *PrometheusSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	p.update(promSummary, key, labels, func(s *promSeries) {
+		if len(s.samples) < promSummaryWindow {
+			s.samples = append(s.samples, float64(val))
+		} else {
+			s.samples[s.count%promSummaryWindow] = float64(val)
+		}
+		s.value += float64(val)
+		s.count++
+	})
+}
+
+// ServeHTTP writes the metrics out for Prometheus to scrape
+func (p *PrometheusSink) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(p.expose())
+}
+
+// update applies fn to the series of the metric, which is created if
+// needed. Metrics are only of the type they were first used with.
+func (p *PrometheusSink) update(typ string, key []string, labels []metrics.Label, fn func(*promSeries)) {
+	name := promInvalidChars.ReplaceAllString(strings.Join(key, "_"), "_")
+	labelStr := promLabels(labels)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	family, ok := p.families[name]
+	if !ok {
+		family = &promFamily{
+			typ:    typ,
+			series: make(map[string]*promSeries),
+		}
+		p.families[name] = family
+	}
+	if family.typ != typ {
+		return
+	}
+
+	series, ok := family.series[labelStr]
+	if !ok {
+		series = &promSeries{labels: labelStr}
+		family.series[labelStr] = series
+	}
+	fn(series)
+}
+
+func (p *PrometheusSink) expose() []byte {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var names []string
+	for name := range p.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	for _, name := range names {
+		family := p.families[name]
+		fmt.Fprintf(buf, "# TYPE %s %s\n", name, family.typ)
+
+		var labels []string
+		for l := range family.series {
+			labels = append(labels, l)
+		}
+		sort.Strings(labels)
+
+		for _, l := range labels {
+			s := family.series[l]
+			if family.typ == promSummary {
+				sorted := append([]float64(nil), s.samples...)
+				sort.Float64s(sorted)
+				for _, q := range promQuantiles {
+					fmt.Fprintf(buf, "%s%s %v\n", name, withQuantile(s.labels, q), quantile(sorted, q))
+				}
+				fmt.Fprintf(buf, "%s_sum%s %v\n", name, s.labels, s.value)
+				fmt.Fprintf(buf, "%s_count%s %d\n", name, s.labels, s.count)
+			} else {
+				fmt.Fprintf(buf, "%s%s %v\n", name, s.labels, s.value)
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+// promLabels formats the labels as they follow the metric name, sorted by
+// name so the same labels always refer to the same series
+func promLabels(labels []metrics.Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	var pairs []string
+	for _, l := range labels {
+		name := promInvalidChars.ReplaceAllString(l.Name, "_")
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, promLabelReplacer.Replace(l.Value)))
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// withQuantile adds the quantile label to the formatted labels of a series
+func withQuantile(labels string, q float64) string {
+	l := fmt.Sprintf(`quantile="%v"`, q)
+	if labels == "" {
+		return "{" + l + "}"
+	}
+	return "{" + l + "," + labels[1:]
+}
+
+// quantile returns the q-quantile of the sorted samples, by the nearest rank
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/armon/go-metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusSink(t *testing.T) {
+	p := NewPrometheusSink()
+
+	p.SetGauge([]string{"spire_agent", "cache_manager", "cached_entries"}, 3)
+	p.SetGauge([]string{"spire_agent", "cache_manager", "cached_entries"}, 5)
+	p.IncrCounterWithLabels([]string{"spire_agent", "workload_api", "connection"}, 1, []metrics.Label{{Name: "workload_pid", Value: "10"}})
+	p.IncrCounterWithLabels([]string{"spire_agent", "workload_api", "connection"}, 1, []metrics.Label{{Name: "workload_pid", Value: "10"}})
+	p.AddSampleWithLabels([]string{"spire_agent", "workload_api", "workload_attestor_latency"}, 2.5, []metrics.Label{
+		{Name: "attestor_name", Value: "unix"},
+		{Name: "path", Value: `a"b`},
+	})
+	p.AddSampleWithLabels([]string{"spire_agent", "workload_api", "workload_attestor_latency"}, 1.5, []metrics.Label{
+		{Name: "path", Value: `a"b`},
+		{Name: "attestor_name", Value: "unix"},
+	})
+	p.EmitKey([]string{"spire_agent", "ignored"}, 1)
+
+	// Metrics keep the type they were first used with
+	p.IncrCounter([]string{"spire_agent", "cache_manager", "cached_entries"}, 1)
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := ioutil.ReadAll(w.Body)
+	require.NoError(t, err)
+	require.Equal(t, `# TYPE spire_agent_cache_manager_cached_entries gauge
+spire_agent_cache_manager_cached_entries 5
+# TYPE spire_agent_workload_api_connection counter
+spire_agent_workload_api_connection{workload_pid="10"} 2
+# TYPE spire_agent_workload_api_workload_attestor_latency summary
+spire_agent_workload_api_workload_attestor_latency{quantile="0.5",attestor_name="unix",path="a\"b"} 1.5
+spire_agent_workload_api_workload_attestor_latency{quantile="0.9",attestor_name="unix",path="a\"b"} 2.5
+spire_agent_workload_api_workload_attestor_latency{quantile="0.99",attestor_name="unix",path="a\"b"} 2.5
+spire_agent_workload_api_workload_attestor_latency_sum{attestor_name="unix",path="a\"b"} 4
+spire_agent_workload_api_workload_attestor_latency_count{attestor_name="unix",path="a\"b"} 2
+`, string(body))
+}
+
+func TestPrometheusSinkQuantiles(t *testing.T) {
+	p := NewPrometheusSink()
+
+	// Quantiles are computed over the latest samples only
+	for i := 0; i < promSummaryWindow; i++ {
+		p.AddSample([]string{"latency"}, 1000)
+	}
+	for i := 1; i <= promSummaryWindow; i++ {
+		p.AddSample([]string{"latency"}, float32(i))
+	}
+
+	body := string(p.expose())
+	require.Contains(t, body, `latency{quantile="0.5"} 512`+"\n")
+	require.Contains(t, body, `latency{quantile="0.9"} 922`+"\n")
+	require.Contains(t, body, `latency{quantile="0.99"} 1014`+"\n")
+	require.Contains(t, body, "latency_count 2048\n")
+}
@@ -0,0 +1,127 @@
+package telemetry
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/armon/go-metrics"
+)
+
+var statsdReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+
+type StatsdConfig struct {
+	// Address of the StatsD server, e.g. `localhost:8125`
+	Address string
+
+	// Prefix prepended to the name of every metric, if set
+	Prefix string
+
+	// If true the labels are sent as DogStatsD tags. Otherwise, as plain
+	// StatsD has no labels, their values are appended to the metric name.
+	DogStatsD bool
+
+	// Names of the labels which are sent. All labels are sent if empty.
+	AllowedLabels []string
+}
+
+// StatsdSink sends the metrics it receives over UDP to a StatsD or DogStatsD
+// server, one metric per packet. Keys are joined with dots. Metrics which
+// cannot be delivered are dropped.
+type StatsdSink struct {
+	c       StatsdConfig
+	conn    net.Conn
+	allowed map[string]bool
+}
+
+func NewStatsdSink(c StatsdConfig) (*StatsdSink, error) {
+	conn, err := net.Dial("udp", c.Address)
+	if err != nil {
+		return nil, fmt.Errorf("create statsd connection: %v", err)
+	}
+
+	var allowed map[string]bool
+	if len(c.AllowedLabels) > 0 {
+		allowed = make(map[string]bool)
+		for _, l := range c.AllowedLabels {
+			allowed[l] = true
+		}
+	}
+
+	return &StatsdSink{
+		c:       c,
+		conn:    conn,
+		allowed: allowed,
+	}, nil
+}
+
+func (s *StatsdSink) SetGauge(key []string, val float32) {
+	s.SetGaugeWithLabels(key, val, nil)
+}
+
+func (s *StatsdSink) SetGaugeWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.send(key, val, "g", labels)
+}
+
+func (s *StatsdSink) EmitKey(key []string, val float32) {
+	s.send(key, val, "kv", nil)
+}
+
+func (s *StatsdSink) IncrCounter(key []string, val float32) {
+	s.IncrCounterWithLabels(key, val, nil)
+}
+
+func (s *StatsdSink) IncrCounterWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.send(key, val, "c", labels)
+}
+
+func (s *StatsdSink) AddSample(key []string, val float32) {
+	s.AddSampleWithLabels(key, val, nil)
+}
+
+func (s *StatsdSink) AddSampleWithLabels(key []string, val float32, labels []metrics.Label) {
+	s.send(key, val, "ms", labels)
+}
+
+// Close closes the connection to the StatsD server
+func (s *StatsdSink) Close() error {
+	return s.conn.Close()
+}
+
+func (s *StatsdSink) send(key []string, val float32, typ string, labels []metrics.Label) {
+	s.conn.Write(s.format(key, val, typ, labels))
+}
+
+// format returns the StatsD line of the metric, leaving out the labels
+// which are not allowed.
+func (s *StatsdSink) format(key []string, val float32, typ string, labels []metrics.Label) []byte {
+	var parts []string
+	if s.c.Prefix != "" {
+		parts = append(parts, s.c.Prefix)
+	}
+	parts = append(parts, key...)
+
+	var tags []string
+	for _, l := range labels {
+		if s.allowed != nil && !s.allowed[l.Name] {
+			continue
+		}
+		if s.c.DogStatsD {
+			tags = append(tags, statsdReplacer.Replace(l.Name)+":"+statsdReplacer.Replace(l.Value))
+		} else {
+			parts = append(parts, l.Value)
+		}
+	}
+
+	for i, p := range parts {
+		parts[i] = statsdReplacer.Replace(p)
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%s:%v|%s", strings.Join(parts, "."), val, typ)
+	if len(tags) > 0 {
+		fmt.Fprintf(buf, "|#%s", strings.Join(tags, ","))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsdSink(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	s, err := NewStatsdSink(StatsdConfig{
+		Address: conn.LocalAddr().String(),
+		Prefix:  "prod",
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	s.SetGauge([]string{"spire_agent", "cache_manager", "cached_entries"}, 5)
+	s.IncrCounterWithLabels([]string{"spire_agent", "workload_api", "connection"}, 1, []metrics.Label{{Name: "workload_pid", Value: "10"}})
+	s.AddSample([]string{"spire_agent", "cache_manager", "sync_duration"}, 2.5)
+
+	requireReceived(t, conn, "prod.spire_agent.cache_manager.cached_entries:5|g")
+	requireReceived(t, conn, "prod.spire_agent.workload_api.connection.10:1|c")
+	requireReceived(t, conn, "prod.spire_agent.cache_manager.sync_duration:2.5|ms")
+}
+
+func TestStatsdSinkDogStatsD(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	s, err := NewStatsdSink(StatsdConfig{
+		Address:       conn.LocalAddr().String(),
+		DogStatsD:     true,
+		AllowedLabels: []string{"attestor_name", "path"},
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	s.AddSampleWithLabels([]string{"spire_agent", "workload_api", "workload_attestor_latency"}, 1.5, []metrics.Label{
+		{Name: "attestor_name", Value: "unix"},
+		{Name: "workload_pid", Value: "10"},
+		{Name: "path", Value: "a,b"},
+	})
+	s.IncrCounterWithLabels([]string{"spire_agent", "workload_api", "connection"}, 1, []metrics.Label{{Name: "workload_pid", Value: "10"}})
+
+	requireReceived(t, conn, "spire_agent.workload_api.workload_attestor_latency:1.5|ms|#attestor_name:unix,path:a_b")
+	requireReceived(t, conn, "spire_agent.workload_api.connection:1|c")
+}
+
+func requireReceived(t *testing.T, conn net.PacketConn, expected string) {
+	buf := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(buf[:n]))
+}
@@ -7,10 +7,29 @@ import (
 	"github.com/armon/go-metrics"
 )
 
+var (
+	// The in-memory sink aggregates the metrics over intervals, all of which
+	// are dumped but for the current one. A minute of ten second intervals
+	// keeps the dump short enough to be read during an incident.
+	inmemInterval  = 10 * time.Second
+	inmemRetention = time.Minute
+)
+
 type SinkConfig struct {
 	Logger      io.Writer
 	ServiceName string
 
+	// Prometheus, if set, also receives the metrics so they can be scraped
+	Prometheus *PrometheusSink
+
+	// Statsd, if set, also receives the metrics, which it sends to a StatsD
+	// or DogStatsD server
+	Statsd *StatsdSink
+
+	// Plugins, if set, also receives the metrics, which it sends to the
+	// MetricSink plugins
+	Plugins *PluginSink
+
 	StopChan <-chan struct{}
 }
 
@@ -27,17 +46,32 @@ type sink struct {
 func NewSink(c *SinkConfig) Sink {
 	sinks := metrics.FanoutSink{}
 
-	// Always create an in-memory sink
-	interval := 1 * time.Second
-	retention := 1 * time.Hour
-	inmemSink := metrics.NewInmemSink(interval, retention)
+	// Always create an in-memory sink, dumped to the logger on SIGUSR1
+	inmemSink := metrics.NewInmemSink(inmemInterval, inmemRetention)
 	sinks = append(sinks, inmemSink)
 
+	if c.Prometheus != nil {
+		sinks = append(sinks, c.Prometheus)
+	}
+
+	if c.Statsd != nil {
+		sinks = append(sinks, c.Statsd)
+	}
+
+	if c.Plugins != nil {
+		sinks = append(sinks, c.Plugins)
+	}
+
 	// Allow the in-memory sink to be signaled, printing stats to the log
 	inmemSignal := metrics.NewInmemSignal(inmemSink, metrics.DefaultSignal, c.Logger)
 
 	// Although New returns an error type, there is no codepath for non-nil error.
 	config := metrics.DefaultConfig(c.ServiceName)
+	if c.Prometheus != nil || (c.Statsd != nil && c.Statsd.c.DogStatsD) {
+		// Prometheus and Datadog tell the hosts apart, the hostname would
+		// otherwise end up in the name of the gauges
+		config.EnableHostname = false
+	}
 	m, _ := metrics.New(config, sinks)
 
 	t := &sink{
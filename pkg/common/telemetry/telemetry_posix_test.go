@@ -0,0 +1,66 @@
+// +build !windows
+
+package telemetry
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkDumpsOnSignal(t *testing.T) {
+	defer func(interval time.Duration) { inmemInterval = interval }(inmemInterval)
+	inmemInterval = 100 * time.Millisecond
+
+	stop := make(chan struct{})
+	defer close(stop)
+	out := new(syncBuffer)
+	s := NewSink(&SinkConfig{
+		Logger:      out,
+		ServiceName: "spire_agent",
+		StopChan:    stop,
+	})
+
+	s.IncrCounter([]string{"cache_manager", "sync_errors"}, 1)
+	s.AddSample([]string{"cache_manager", "sync_duration"}, 2.5)
+
+	// only the intervals which are over are dumped
+	time.Sleep(2 * inmemInterval)
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+
+	timer := time.NewTimer(5 * time.Second)
+	defer timer.Stop()
+	for {
+		dump := out.String()
+		if strings.Contains(dump, "cache_manager.sync_errors") && strings.Contains(dump, "cache_manager.sync_duration") {
+			return
+		}
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-timer.C:
+			t.Fatalf("metrics not dumped: %q", dump)
+		}
+	}
+}
+
+type syncBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
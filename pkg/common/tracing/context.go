@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+	"regexp"
+)
+
+var traceparentRE = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+type spanContextKey struct{}
+
+// spanContext identifies a span within its trace. IDs are hex encoded.
+type spanContext struct {
+	traceID string
+	spanID  string
+}
+
+func withSpanContext(ctx context.Context, sc spanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+func spanContextFromContext(ctx context.Context) (spanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(spanContext)
+	return sc, ok
+}
+
+// parseTraceparent parses a W3C Trace Context traceparent header, as sent
+// by callers which are themselves traced.
+func parseTraceparent(value string) (spanContext, bool) {
+	m := traceparentRE.FindStringSubmatch(value)
+	if m == nil || m[1] == "00000000000000000000000000000000" || m[2] == "0000000000000000" {
+		return spanContext{}, false
+	}
+	return spanContext{traceID: m[1], spanID: m[2]}, true
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor traces every unary call with a span named after the
+// gRPC method, continuing the trace of the caller if it sent a traceparent.
+func UnaryServerInterceptor(t Tracer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx, span := startRPCSpan(ctx, t, info.FullMethod)
+		defer span.End()
+
+		resp, err = handler(ctx, req)
+		span.SetError(err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor traces every streaming call with a span named
+// after the gRPC method, which lasts as long as the stream.
+func StreamServerInterceptor(t Tracer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startRPCSpan(ss.Context(), t, info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, tracedServerStream{ServerStream: ss, ctx: ctx})
+		span.SetError(err)
+		return err
+	}
+}
+
+func startRPCSpan(ctx context.Context, t Tracer, fullMethod string) (context.Context, Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md["traceparent"]; len(values) > 0 {
+			if sc, ok := parseTraceparent(values[0]); ok {
+				ctx = withSpanContext(ctx, sc)
+			}
+		}
+	}
+
+	ctx, span := t.Start(ctx, strings.TrimPrefix(fullMethod, "/"))
+	span.SetAttribute("rpc.system", "grpc")
+	if i := strings.LastIndex(fullMethod, "/"); i > 0 {
+		span.SetAttribute("rpc.service", fullMethod[1:i])
+		span.SetAttribute("rpc.method", fullMethod[i+1:])
+	}
+	return ctx, span
+}
+
+// tracedServerStream carries the context of the stream span to the handler
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tracedServerStream) Context() context.Context {
+	return s.ctx
+}
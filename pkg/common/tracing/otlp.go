@@ -0,0 +1,314 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultExportInterval = 5 * time.Second
+	defaultMaxQueuedSpans = 2048
+
+	// OTLP span kind and status codes
+	otlpKindInternal = 1
+	otlpStatusOK     = 1
+	otlpStatusError  = 2
+)
+
+type OTLPConfig struct {
+	// URL of the OTLP/HTTP traces endpoint of the collector, e.g.
+	// `http://localhost:4318/v1/traces`
+	Endpoint string
+
+	// Name the spans are reported under, as the service.name resource
+	// attribute
+	ServiceName string
+
+	// How often the spans are exported. Defaults to 5 seconds.
+	ExportInterval time.Duration
+
+	// Maximum number of ended spans waiting to be exported. Spans ended
+	// while the queue is full are dropped. Defaults to 2048.
+	MaxQueuedSpans int
+
+	Log logrus.FieldLogger
+}
+
+// OTLPTracer records spans and exports them to an OpenTelemetry collector
+// over OTLP/HTTP, JSON encoded.
+type OTLPTracer struct {
+	c OTLPConfig
+
+	mtx    sync.Mutex
+	queue  []*otlpSpan
+	client *http.Client
+
+	// test hooks
+	hooks struct {
+		now func() time.Time
+	}
+}
+
+func NewOTLPTracer(c OTLPConfig) *OTLPTracer {
+	if c.ExportInterval == 0 {
+		c.ExportInterval = defaultExportInterval
+	}
+	if c.MaxQueuedSpans == 0 {
+		c.MaxQueuedSpans = defaultMaxQueuedSpans
+	}
+
+	t := &OTLPTracer{
+		c:      c,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	t.hooks.now = time.Now
+	return t
+}
+
+func (t *OTLPTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	sc := spanContext{
+		spanID: newID(8),
+	}
+	parent, ok := spanContextFromContext(ctx)
+	if ok {
+		sc.traceID = parent.traceID
+	} else {
+		sc.traceID = newID(16)
+	}
+
+	s := &otlpSpan{
+		t:     t,
+		sc:    sc,
+		name:  name,
+		start: t.hooks.now(),
+	}
+	if ok {
+		s.parentID = parent.spanID
+	}
+	return withSpanContext(ctx, sc), s
+}
+
+// Run exports the ended spans periodically until the context is cancelled,
+// when the spans still queued are exported one last time.
+func (t *OTLPTracer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(t.c.ExportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.export(ctx)
+		case <-ctx.Done():
+			t.export(context.Background())
+			return nil
+		}
+	}
+}
+
+func (t *OTLPTracer) enqueue(s *otlpSpan) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if len(t.queue) >= t.c.MaxQueuedSpans {
+		return
+	}
+	t.queue = append(t.queue, s)
+}
+
+func (t *OTLPTracer) export(ctx context.Context) {
+	t.mtx.Lock()
+	spans := t.queue
+	t.queue = nil
+	t.mtx.Unlock()
+
+	if len(spans) == 0 {
+		return
+	}
+
+	if err := t.send(ctx, spans); err != nil {
+		t.c.Log.Warnf("Could not export %d spans: %v", len(spans), err)
+	}
+}
+
+func (t *OTLPTracer) send(ctx context.Context, spans []*otlpSpan) error {
+	body, err := json.Marshal(t.request(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", t.c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// request builds the OTLP ExportTraceServiceRequest carrying the spans
+func (t *OTLPTracer) request(spans []*otlpSpan) *otlpRequest {
+	var jsonSpans []otlpJSONSpan
+	for _, s := range spans {
+		jsonSpans = append(jsonSpans, s.toJSON())
+	}
+
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpAttribute{stringAttribute("service.name", t.c.ServiceName)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/spiffe/spire"},
+				Spans: jsonSpans,
+			}},
+		}},
+	}
+}
+
+type otlpSpan struct {
+	t        *OTLPTracer
+	sc       spanContext
+	parentID string
+	name     string
+	start    time.Time
+
+	mtx        sync.Mutex
+	attributes []otlpAttribute
+	err        error
+	ended      bool
+	end        time.Time
+}
+
+func (s *otlpSpan) SetAttribute(key, value string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.attributes = append(s.attributes, stringAttribute(key, value))
+}
+
+func (s *otlpSpan) SetError(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.err = err
+}
+
+func (s *otlpSpan) End() {
+	s.mtx.Lock()
+	if s.ended {
+		s.mtx.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = s.t.hooks.now()
+	s.mtx.Unlock()
+
+	s.t.enqueue(s)
+}
+
+func (s *otlpSpan) toJSON() otlpJSONSpan {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	span := otlpJSONSpan{
+		TraceID:           s.sc.traceID,
+		SpanID:            s.sc.spanID,
+		ParentSpanID:      s.parentID,
+		Name:              s.name,
+		Kind:              otlpKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Attributes:        s.attributes,
+		Status:            otlpStatus{Code: otlpStatusOK},
+	}
+	if s.err != nil {
+		span.Status = otlpStatus{Code: otlpStatusError, Message: s.err.Error()}
+	}
+	return span
+}
+
+// newID returns a random hex encoded ID of n bytes
+func newID(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// JSON encoding of the OTLP protobuf messages, as described in
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope      `json:"scope"`
+	Spans []otlpJSONSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpJSONSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: value}}
+}
@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLPTracer(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer server.Close()
+
+	log, _ := test.NewNullLogger()
+	tracer := NewOTLPTracer(OTLPConfig{
+		Endpoint:    server.URL,
+		ServiceName: "spire-server",
+		Log:         log,
+	})
+	now := time.Unix(1000, 0)
+	tracer.hooks.now = func() time.Time { return now }
+
+	ctx, parent := tracer.Start(context.Background(), "spire.api.node.Node/Attest")
+	_, child := tracer.Start(ctx, "ca.SignCsr")
+	child.SetAttribute("spiffe_id", "spiffe://example.org/agent")
+	child.SetError(errors.New("oh no"))
+	now = now.Add(time.Second)
+	child.End()
+	parent.End()
+	parent.End()
+
+	tracer.export(context.Background())
+
+	req := new(otlpRequest)
+	require.NoError(t, json.Unmarshal(<-bodies, req))
+	require.Len(t, req.ResourceSpans, 1)
+	require.Equal(t, []otlpAttribute{stringAttribute("service.name", "spire-server")}, req.ResourceSpans[0].Resource.Attributes)
+	require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	childSpan, parentSpan := spans[0], spans[1]
+	require.Len(t, parentSpan.TraceID, 32)
+	require.Len(t, parentSpan.SpanID, 16)
+	require.Empty(t, parentSpan.ParentSpanID)
+	require.Equal(t, "spire.api.node.Node/Attest", parentSpan.Name)
+	require.Equal(t, otlpStatus{Code: otlpStatusOK}, parentSpan.Status)
+
+	require.Equal(t, parentSpan.TraceID, childSpan.TraceID)
+	require.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+	require.Equal(t, "ca.SignCsr", childSpan.Name)
+	require.Equal(t, "1000000000000", childSpan.StartTimeUnixNano)
+	require.Equal(t, "1001000000000", childSpan.EndTimeUnixNano)
+	require.Equal(t, []otlpAttribute{stringAttribute("spiffe_id", "spiffe://example.org/agent")}, childSpan.Attributes)
+	require.Equal(t, otlpStatus{Code: otlpStatusError, Message: "oh no"}, childSpan.Status)
+
+	// Nothing left to export
+	tracer.export(context.Background())
+	select {
+	case <-bodies:
+		t.Fatal("unexpected export")
+	default:
+	}
+}
+
+func TestOTLPTracerDropsSpansWhenQueueIsFull(t *testing.T) {
+	tracer := NewOTLPTracer(OTLPConfig{MaxQueuedSpans: 1})
+
+	_, span := tracer.Start(context.Background(), "first")
+	span.End()
+	_, span = tracer.Start(context.Background(), "second")
+	span.End()
+
+	require.Len(t, tracer.queue, 1)
+	require.Equal(t, "first", tracer.queue[0].name)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	sc, ok := parseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	require.True(t, ok)
+	require.Equal(t, spanContext{traceID: "0af7651916cd43dd8448eb211c80319c", spanID: "b7ad6b7169203331"}, sc)
+
+	for _, value := range []string{
+		"",
+		"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+	} {
+		_, ok := parseTraceparent(value)
+		require.False(t, ok, value)
+	}
+}
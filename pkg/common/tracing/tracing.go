@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+)
+
+// Tracer starts spans. A span started from a context carrying another span
+// is a child of it, so spans started down the call path of a request end up
+// in the same trace.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type Span interface {
+	// SetAttribute attaches a key/value pair to the span
+	SetAttribute(key, value string)
+
+	// SetError marks the span as failed with err, if not nil
+	SetError(err error)
+
+	// End marks the span as completed. The span must not be used afterwards.
+	End()
+}
+
+// Noop implements the Tracer interface, but does not record anything.
+// Useful when tracing is disabled, or for testing code which depends on it.
+type Noop struct{}
+
+func (Noop) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key, value string) {}
+func (noopSpan) SetError(err error)             {}
+func (noopSpan) End()                           {}
@@ -31,11 +31,28 @@ func LoadCertPool(path string) (*x509.CertPool, error) {
 // LoadCertificates loads one or more certificates into an []*x509.Certificate from
 // a PEM file on disk.
 func LoadCertificates(path string) ([]*x509.Certificate, error) {
-	rest, err := ioutil.ReadFile(path)
+	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
+	certs, err := ParseCertificates(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found in file")
+	}
+
+	return certs, nil
+}
+
+// ParseCertificates parses the certificates in the PEM data. Blocks of
+// other types are skipped.
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	rest := data
+
 	var certs []*x509.Certificate
 	for blockno := 0; ; blockno++ {
 		var block *pem.Block
@@ -54,9 +71,5 @@ func LoadCertificates(path string) ([]*x509.Certificate, error) {
 		certs = append(certs, cert)
 	}
 
-	if len(certs) == 0 {
-		return nil, errors.New("no certificates found in file")
-	}
-
 	return certs, nil
 }
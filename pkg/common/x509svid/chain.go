@@ -0,0 +1,19 @@
+package x509svid
+
+import (
+	"crypto/x509"
+	"errors"
+)
+
+// ParseChain parses an X509-SVID made of its certificate followed by the
+// intermediate CA certificates, if any, chaining it up to the trust bundle
+func ParseChain(chainDER []byte) (svid *x509.Certificate, intermediates []*x509.Certificate, err error) {
+	certs, err := x509.ParseCertificates(chainDER)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil, errors.New("no certificates found")
+	}
+	return certs[0], certs[1:], nil
+}
@@ -0,0 +1,37 @@
+package x509svid
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChain(t *testing.T) {
+	caCert, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+	svidCert, _, err := util.LoadSVIDFixture()
+	require.NoError(t, err)
+
+	// SVID only
+	svid, intermediates, err := ParseChain(svidCert.Raw)
+	require.NoError(t, err)
+	require.Equal(t, svidCert, svid)
+	require.Empty(t, intermediates)
+
+	// SVID followed by an intermediate
+	chain := append(append([]byte{}, svidCert.Raw...), caCert.Raw...)
+	svid, intermediates, err = ParseChain(chain)
+	require.NoError(t, err)
+	require.Equal(t, svidCert, svid)
+	require.Equal(t, []*x509.Certificate{caCert}, intermediates)
+
+	// empty chain
+	_, _, err = ParseChain(nil)
+	require.EqualError(t, err, "no certificates found")
+
+	// malformed chain
+	_, _, err = ParseChain([]byte("foo"))
+	require.Error(t, err)
+}
@@ -2,10 +2,12 @@ package x509svid
 
 import (
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"fmt"
 	"net/url"
 	"time"
@@ -18,12 +20,51 @@ import (
 const (
 	DefaultServerCABackdate = time.Second * 10
 	DefaultServerCATTL      = time.Hour
+
+	// DefaultSVIDKeyUsage is the key usage of the SVIDs signed, unless
+	// configured otherwise
+	DefaultSVIDKeyUsage = x509.KeyUsageKeyEncipherment |
+		x509.KeyUsageKeyAgreement |
+		x509.KeyUsageDigitalSignature
+)
+
+// DefaultSVIDExtKeyUsage is the extended key usage of the SVIDs signed,
+// unless configured otherwise
+var DefaultSVIDExtKeyUsage = []x509.ExtKeyUsage{
+	x509.ExtKeyUsageServerAuth,
+	x509.ExtKeyUsageClientAuth,
+}
+
+var (
+	// id-pkix-ocsp-nocheck (RFC 6960, section 4.2.2.2.1). The revocation
+	// status of a delegated OCSP responder is not checked, so its
+	// certificate is short-lived instead.
+	oidOCSPNoCheck = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}
+	asn1Null       = []byte{0x05, 0x00}
 )
 
 type ServerCAOptions struct {
 	TTL          time.Duration
 	Backdate     time.Duration
 	SerialNumber x509util.SerialNumber
+
+	// KeyUsage and ExtKeyUsage of the SVIDs signed. They default to
+	// DefaultSVIDKeyUsage and DefaultSVIDExtKeyUsage.
+	KeyUsage    x509.KeyUsage
+	ExtKeyUsage []x509.ExtKeyUsage
+
+	// OmitSubjectKeyID leaves the subject key identifier out of the SVIDs.
+	// The authority key identifier is set whenever the CA certificate has
+	// a subject key identifier.
+	OmitSubjectKeyID bool
+
+	// OmitBasicConstraints leaves the basic constraints extension, marking
+	// the SVIDs as not being CAs, out of the SVIDs.
+	OmitBasicConstraints bool
+
+	// SignatureAlgorithm the certificates are signed with. Defaults to the
+	// default of the x509 package for the CA key.
+	SignatureAlgorithm x509.SignatureAlgorithm
 }
 
 type ServerCA struct {
@@ -42,6 +83,12 @@ func NewServerCA(keypair x509util.Keypair, trustDomain string, options ServerCAO
 	if options.SerialNumber == nil {
 		options.SerialNumber = x509util.NewSerialNumber()
 	}
+	if options.KeyUsage == 0 {
+		options.KeyUsage = DefaultSVIDKeyUsage
+	}
+	if len(options.ExtKeyUsage) == 0 {
+		options.ExtKeyUsage = DefaultSVIDExtKeyUsage
+	}
 
 	return &ServerCA{
 		keypair:     keypair,
@@ -56,7 +103,66 @@ func (ca *ServerCA) SignCSR(ctx context.Context, csrDER []byte, ttl time.Duratio
 		return nil, err
 	}
 
-	keyID, err := x509util.GetSubjectKeyId(csr.PublicKey)
+	var keyID []byte
+	if !ca.options.OmitSubjectKeyID {
+		keyID, err = x509util.GetSubjectKeyId(csr.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+
+	if ttl <= 0 {
+		ttl = ca.options.TTL
+	}
+
+	notBefore := now.Add(-ca.options.Backdate)
+	notAfter := now.Add(ttl)
+
+	caCert, err := ca.keypair.GetCertificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	serialNumber, err := ca.options.SerialNumber.NextNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               csr.Subject,
+		URIs:                  csr.URIs,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		SubjectKeyId:          keyID,
+		KeyUsage:              ca.options.KeyUsage,
+		ExtKeyUsage:           ca.options.ExtKeyUsage,
+		BasicConstraintsValid: !ca.options.OmitBasicConstraints,
+		SignatureAlgorithm:    ca.options.SignatureAlgorithm,
+	}
+
+	certDER, err := ca.keypair.CreateCertificate(ctx, template, csr.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// SignOCSPResponder signs the certificate of a delegated OCSP responder,
+// authorized to sign OCSP responses on behalf of the CA, for the given key.
+func (ca *ServerCA) SignOCSPResponder(ctx context.Context, publicKey crypto.PublicKey, ttl time.Duration) (*x509.Certificate, error) {
+	keyID, err := x509util.GetSubjectKeyId(publicKey)
 	if err != nil {
 		return nil, err
 	}
@@ -85,22 +191,25 @@ func (ca *ServerCA) SignCSR(ctx context.Context, csrDER []byte, ttl time.Duratio
 
 	template := &x509.Certificate{
 		SerialNumber: serialNumber,
-		Subject:      csr.Subject,
-		URIs:         csr.URIs,
+		Subject: pkix.Name{
+			Organization: caCert.Subject.Organization,
+			CommonName:   "SPIRE OCSP Responder",
+		},
 		NotBefore:    notBefore,
 		NotAfter:     notAfter,
 		SubjectKeyId: keyID,
-		KeyUsage: x509.KeyUsageKeyEncipherment |
-			x509.KeyUsageKeyAgreement |
-			x509.KeyUsageDigitalSignature,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
 		ExtKeyUsage: []x509.ExtKeyUsage{
-			x509.ExtKeyUsageServerAuth,
-			x509.ExtKeyUsageClientAuth,
+			x509.ExtKeyUsageOCSPSigning,
+		},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidOCSPNoCheck, Value: asn1Null},
 		},
 		BasicConstraintsValid: true,
+		SignatureAlgorithm:    ca.options.SignatureAlgorithm,
 	}
 
-	certDER, err := ca.keypair.CreateCertificate(ctx, template, csr.PublicKey)
+	certDER, err := ca.keypair.CreateCertificate(ctx, template, publicKey)
 	if err != nil {
 		return nil, err
 	}
@@ -115,9 +224,13 @@ func (ca *ServerCA) SignCSR(ctx context.Context, csrDER []byte, ttl time.Duratio
 
 type ServerCACSROptions struct {
 	Subject pkix.Name
+
+	// SignatureAlgorithm the CSR is signed with. Defaults to ECDSAWithSHA256
+	// for ECDSA keys, and to the default of the x509 package otherwise.
+	SignatureAlgorithm x509.SignatureAlgorithm
 }
 
-func GenerateServerCACSR(key *ecdsa.PrivateKey, trustDomain string, options ServerCACSROptions) ([]byte, error) {
+func GenerateServerCACSR(key crypto.Signer, trustDomain string, options ServerCACSROptions) ([]byte, error) {
 	spiffeID := &url.URL{
 		Scheme: "spiffe",
 		Host:   trustDomain,
@@ -125,9 +238,12 @@ func GenerateServerCACSR(key *ecdsa.PrivateKey, trustDomain string, options Serv
 
 	template := x509.CertificateRequest{
 		Subject:            options.Subject,
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
+		SignatureAlgorithm: options.SignatureAlgorithm,
 		URIs:               []*url.URL{spiffeID},
 	}
+	if _, ok := key.Public().(*ecdsa.PublicKey); ok && template.SignatureAlgorithm == x509.UnknownSignatureAlgorithm {
+		template.SignatureAlgorithm = x509.ECDSAWithSHA256
+	}
 
 	csr, err := x509.CreateCertificateRequest(rand.Reader, &template, key)
 	if err != nil {
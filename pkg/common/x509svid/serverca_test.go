@@ -69,6 +69,25 @@ func (s *ServerCASuite) TestSignCSRSuccess() {
 	s.Require().Equal(
 		x509.KeyUsageKeyEncipherment|x509.KeyUsageKeyAgreement|x509.KeyUsageDigitalSignature,
 		cert.KeyUsage)
+	s.Require().Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	s.Require().True(cert.BasicConstraintsValid)
+}
+
+func (s *ServerCASuite) TestSignCSRWithSigningPolicy() {
+	serverCA := NewServerCA(s.keypair, "example.org", ServerCAOptions{
+		KeyUsage:             x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:          []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		OmitSubjectKeyID:     true,
+		OmitBasicConstraints: true,
+	})
+
+	cert, err := serverCA.SignCSR(context.Background(), s.makeCSR("spiffe://example.org"), 0)
+	s.Require().NoError(err)
+	s.Require().Equal(x509.KeyUsageDigitalSignature, cert.KeyUsage)
+	s.Require().Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	s.Require().Empty(cert.SubjectKeyId)
+	s.Require().False(cert.BasicConstraintsValid)
+	s.Require().Equal(s.caCert.SubjectKeyId, cert.AuthorityKeyId)
 }
 
 func (s *ServerCASuite) TestSignCSRCapsNotAfter() {
@@ -78,3 +97,30 @@ func (s *ServerCASuite) TestSignCSRCapsNotAfter() {
 
 	s.Require().Equal(s.caCert.NotAfter, cert.NotAfter)
 }
+
+func (s *ServerCASuite) TestSignOCSPResponder() {
+	cert, err := s.serverCA.SignOCSPResponder(context.Background(), s.csrKey.Public(), time.Minute)
+	s.Require().NoError(err)
+
+	s.Require().NoError(cert.CheckSignatureFrom(s.caCert))
+	s.Require().False(cert.IsCA)
+	s.Require().Empty(cert.URIs)
+	s.Require().Equal(x509.KeyUsageDigitalSignature, cert.KeyUsage)
+	s.Require().Equal([]x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning}, cert.ExtKeyUsage)
+	s.Require().WithinDuration(time.Now().Add(time.Minute), cert.NotAfter, 5*time.Second)
+
+	var noCheck bool
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidOCSPNoCheck) {
+			noCheck = true
+		}
+	}
+	s.Require().True(noCheck, "id-pkix-ocsp-nocheck extension is missing")
+}
+
+func (s *ServerCASuite) TestSignOCSPResponderCapsNotAfter() {
+	cert, err := s.serverCA.SignOCSPResponder(context.Background(), s.csrKey.Public(), time.Hour*3)
+	s.Require().NoError(err)
+
+	s.Require().Equal(s.caCert.NotAfter, cert.NotAfter)
+}
@@ -2,6 +2,7 @@ package x509util
 
 import (
 	"context"
+	"crypto/rand"
 	"math/big"
 	"sync/atomic"
 )
@@ -21,3 +22,15 @@ func NewSerialNumber() SerialNumber {
 func (m *serialNumber) NextNumber(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(atomic.AddInt64(&m.next, 1)), nil
 }
+
+type randomSerialNumber struct{}
+
+// NewRandomSerialNumber returns serial numbers made of 128 random bits, which
+// are unique in practice, even across restarts.
+func NewRandomSerialNumber() SerialNumber {
+	return randomSerialNumber{}
+}
+
+func (randomSerialNumber) NextNumber(ctx context.Context) (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
@@ -0,0 +1,185 @@
+package k8sregistrar
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/spiffe/spire/pkg/common/config"
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+const (
+	defaultServerAddress  = "127.0.0.1:8081"
+	defaultResyncInterval = time.Minute
+	defaultLogLevel       = "INFO"
+
+	// Paths of the credentials of the service account of the pod, used to
+	// reach the Kubernetes API from within the cluster
+	inClusterTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAPath    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Config is the configuration file of the registrar
+type Config struct {
+	LogLevel string `hcl:"log_level"`
+	LogPath  string `hcl:"log_path"`
+
+	// Address of the SPIRE server. Defaults to 127.0.0.1:8081, e.g. when
+	// running in the pod of the server.
+	ServerAddress string `hcl:"server_address"`
+
+	// Paths of the X509-SVID and key the registrar authenticates to the
+	// server with. The SVID must belong to an admin registration entry.
+	// Not needed to reach the server on the local host.
+	CertPath string `hcl:"cert_path"`
+	KeyPath  string `hcl:"key_path"`
+
+	// Path of the trust bundle the server is verified with. Only optional
+	// when the server address is a loopback address, in which case the
+	// server is not verified if empty.
+	TrustBundlePath string `hcl:"trust_bundle_path"`
+
+	// URL of the Kubernetes API, along with the paths of the bearer token
+	// and CA certificates to reach it with. Default to the ones of the
+	// service account of the pod.
+	KubeAPIURL    string `hcl:"kube_api_url"`
+	KubeTokenPath string `hcl:"kube_token_path"`
+	KubeCAPath    string `hcl:"kube_ca_path"`
+
+	// Parent ID of the registration entries. The registrar owns every
+	// entry with this parent ID, and deletes those matching no pod.
+	ParentID string `hcl:"parent_id"`
+
+	// Template of the SPIFFE IDs of the pods, in Go template syntax. It is
+	// given the .Namespace, .ServiceAccount and .Labels of the pod.
+	SPIFFEIDTemplate string `hcl:"spiffe_id_template"`
+
+	// Labels the pods must have to be registered. They are added to the
+	// selectors of the entries, and are the only ones given to the
+	// template, so that pods with other SPIFFE IDs cannot be told apart.
+	PodLabels []string `hcl:"pod_labels"`
+
+	// Namespaces the pods are registered in. All of them if empty, except
+	// the ignored ones.
+	Namespaces       []string `hcl:"namespaces"`
+	IgnoreNamespaces []string `hcl:"ignore_namespaces"`
+
+	// TTL, in seconds, of the SVIDs issued for the entries. Zero means the
+	// server default.
+	TTL int32 `hcl:"ttl"`
+
+	// How often all the pods are registered again, on top of the changes
+	// watched
+	ResyncInterval string `hcl:"resync_interval"`
+}
+
+// ParseConfig parses the configuration file, expanding environment
+// variables, and applies the defaults
+func ParseConfig(data string) (*Config, error) {
+	hclTree, err := hcl.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.Expand(hclTree); err != nil {
+		return nil, fmt.Errorf("unable to expand config: %v", err)
+	}
+
+	c := &Config{}
+	if err := hcl.DecodeObject(c, hclTree); err != nil {
+		return nil, err
+	}
+
+	// Decoding an empty list leaves the field as is, so the default is only
+	// applied when the option is missing, for it to be cleared with []
+	if root, ok := hclTree.Node.(*ast.ObjectList); ok && len(root.Filter("ignore_namespaces").Items) == 0 {
+		c.IgnoreNamespaces = []string{"kube-system"}
+	}
+
+	if c.LogLevel == "" {
+		c.LogLevel = defaultLogLevel
+	}
+	if c.ServerAddress == "" {
+		c.ServerAddress = defaultServerAddress
+	}
+	if c.KubeAPIURL == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host != "" && port != "" {
+			c.KubeAPIURL = fmt.Sprintf("https://%s:%s", host, port)
+		}
+	}
+	if c.KubeTokenPath == "" {
+		c.KubeTokenPath = inClusterTokenPath
+	}
+	if c.KubeCAPath == "" {
+		c.KubeCAPath = inClusterCAPath
+	}
+	if c.ResyncInterval == "" {
+		c.ResyncInterval = defaultResyncInterval.String()
+	}
+
+	return c, nil
+}
+
+// Validate ensures that the values in Config are valid
+func (c *Config) Validate() error {
+	if c.KubeAPIURL == "" {
+		return errors.New("kube_api_url is required outside of a Kubernetes cluster")
+	}
+	if (c.CertPath == "") != (c.KeyPath == "") {
+		return errors.New("cert_path and key_path must be set together")
+	}
+	if c.TrustBundlePath == "" && !isLoopback(c.ServerAddress) {
+		return errors.New("trust_bundle_path is required unless server_address is a loopback address")
+	}
+	if err := idutil.ValidateSpiffeID(c.ParentID, idutil.AllowAny()); err != nil {
+		return fmt.Errorf("invalid parent_id: %v", err)
+	}
+	if c.SPIFFEIDTemplate == "" {
+		return errors.New("spiffe_id_template is required")
+	}
+	if _, err := parseTemplate(c.SPIFFEIDTemplate); err != nil {
+		return fmt.Errorf("invalid spiffe_id_template: %v", err)
+	}
+	if c.TTL < 0 {
+		return errors.New("ttl must not be negative")
+	}
+	if _, err := c.resyncInterval(); err != nil {
+		return fmt.Errorf("invalid resync_interval: %v", err)
+	}
+	return nil
+}
+
+func (c *Config) resyncInterval() (time.Duration, error) {
+	interval, err := time.ParseDuration(c.ResyncInterval)
+	if err != nil {
+		return 0, err
+	}
+	if interval <= 0 {
+		return 0, errors.New("must be positive")
+	}
+	return interval, nil
+}
+
+// isLoopback tells whether the host of the address is a loopback address
+// or localhost
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("spiffe_id").Option("missingkey=error").Parse(text)
+}
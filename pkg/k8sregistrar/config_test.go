@@ -0,0 +1,126 @@
+package k8sregistrar
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigDefaults(t *testing.T) {
+	os.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	os.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	defer os.Unsetenv("KUBERNETES_SERVICE_HOST")
+	defer os.Unsetenv("KUBERNETES_SERVICE_PORT")
+
+	c, err := ParseConfig(`
+		parent_id = "spiffe://example.org/k8s-node"
+		spiffe_id_template = "spiffe://example.org/ns/{{.Namespace}}/sa/{{.ServiceAccount}}"
+	`)
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+
+	require.Equal(t, "INFO", c.LogLevel)
+	require.Equal(t, "127.0.0.1:8081", c.ServerAddress)
+	require.Equal(t, "https://10.0.0.1:443", c.KubeAPIURL)
+	require.Equal(t, inClusterTokenPath, c.KubeTokenPath)
+	require.Equal(t, inClusterCAPath, c.KubeCAPath)
+	require.Equal(t, []string{"kube-system"}, c.IgnoreNamespaces)
+
+	interval, err := c.resyncInterval()
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, interval)
+}
+
+func TestParseConfigExpandsEnv(t *testing.T) {
+	os.Setenv("REGISTRAR_TEST_PARENT_ID", "spiffe://example.org/k8s-node")
+	defer os.Unsetenv("REGISTRAR_TEST_PARENT_ID")
+
+	c, err := ParseConfig(`
+		kube_api_url = "http://127.0.0.1:8080"
+		parent_id = "${REGISTRAR_TEST_PARENT_ID}"
+		ignore_namespaces = []
+	`)
+	require.NoError(t, err)
+	require.Equal(t, "spiffe://example.org/k8s-node", c.ParentID)
+	require.Empty(t, c.IgnoreNamespaces)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			KubeAPIURL:       "http://127.0.0.1:8080",
+			ParentID:         "spiffe://example.org/k8s-node",
+			SPIFFEIDTemplate: "spiffe://example.org/{{.ServiceAccount}}",
+			ServerAddress:    "127.0.0.1:8081",
+			ResyncInterval:   "1m",
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	// The trust bundle is only optional on the local host
+	for _, addr := range []string{"localhost:8081", "[::1]:8081"} {
+		c := valid()
+		c.ServerAddress = addr
+		require.NoError(t, c.Validate())
+	}
+	c := valid()
+	c.ServerAddress = "spire-server:8081"
+	c.TrustBundlePath = "bundle.pem"
+	require.NoError(t, c.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no kube api url",
+			modify: func(c *Config) { c.KubeAPIURL = "" },
+			err:    "kube_api_url is required outside of a Kubernetes cluster",
+		},
+		{
+			name:   "cert without key",
+			modify: func(c *Config) { c.CertPath = "svid.pem" },
+			err:    "cert_path and key_path must be set together",
+		},
+		{
+			name:   "remote server without trust bundle",
+			modify: func(c *Config) { c.ServerAddress = "spire-server:8081" },
+			err:    "trust_bundle_path is required unless server_address is a loopback address",
+		},
+		{
+			name:   "invalid parent id",
+			modify: func(c *Config) { c.ParentID = "example.org" },
+			err:    `invalid parent_id: "example.org" is not a valid SPIFFE ID: invalid scheme`,
+		},
+		{
+			name:   "no template",
+			modify: func(c *Config) { c.SPIFFEIDTemplate = "" },
+			err:    "spiffe_id_template is required",
+		},
+		{
+			name:   "invalid template",
+			modify: func(c *Config) { c.SPIFFEIDTemplate = "spiffe://example.org/{{.ServiceAccount" },
+			err:    `invalid spiffe_id_template: template: spiffe_id:1: unclosed action`,
+		},
+		{
+			name:   "negative ttl",
+			modify: func(c *Config) { c.TTL = -1 },
+			err:    "ttl must not be negative",
+		},
+		{
+			name:   "invalid resync interval",
+			modify: func(c *Config) { c.ResyncInterval = "0s" },
+			err:    "invalid resync_interval: must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			require.EqualError(t, c.Validate(), tt.err)
+		})
+	}
+}
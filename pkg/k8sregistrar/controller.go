@@ -0,0 +1,488 @@
+package k8sregistrar
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+)
+
+const (
+	selectorType = "k8s"
+
+	// How long to wait before watching the pods again once a watch ends
+	defaultWatchRetryInterval = 5 * time.Second
+)
+
+// Controller registers the pods of a Kubernetes cluster with the SPIRE
+// server. It creates a registration entry for every running pod selected by
+// the configuration, and deletes the entries with the configured parent ID
+// which match no pod.
+//
+// The pods are listed once, then kept up to date by watching their changes
+// from the resource version of the list. Only the entries of the namespaces
+// whose pods changed are reconciled on a change; all of them are at every
+// resync interval.
+type Controller struct {
+	c       *Config
+	log     logrus.FieldLogger
+	kube    *kubeClient
+	entries entry_pb.EntryClient
+
+	tmpl        *template.Template
+	trustDomain string
+	resync      time.Duration
+
+	mu sync.Mutex
+
+	// Pods by namespace and name, whether they have been listed yet, and the
+	// resource version they are up to date with. The version is empty until
+	// the pods are listed, and once it is too old to be watched from.
+	pods            map[string]*pod
+	listed          bool
+	resourceVersion string
+
+	// Namespaces whose pods changed since they were last reconciled, and
+	// whether all of them did, e.g. because the pods were listed again
+	changedNamespaces map[string]bool
+	changedAll        bool
+
+	// Signaled when the pods change. The channel is buffered, so that the
+	// changes made while reconciling are reconciled once afterwards.
+	changed chan struct{}
+
+	watchRetryInterval time.Duration
+}
+
+// New returns a Controller for the validated configuration, dialing the
+// SPIRE server
+func New(ctx context.Context, c *Config, log logrus.FieldLogger) (*Controller, error) {
+	kube, err := newKubeClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := dialServer(ctx, c, log)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial the server: %v", err)
+	}
+
+	return newController(c, log, kube, entry_pb.NewEntryClient(conn))
+}
+
+func newController(c *Config, log logrus.FieldLogger, kube *kubeClient, entries entry_pb.EntryClient) (*Controller, error) {
+	tmpl, err := parseTemplate(c.SPIFFEIDTemplate)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := url.Parse(c.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	resync, err := c.resyncInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Controller{
+		c:                  c,
+		log:                log,
+		kube:               kube,
+		entries:            entries,
+		tmpl:               tmpl,
+		trustDomain:        parentID.Host,
+		resync:             resync,
+		pods:               make(map[string]*pod),
+		changedNamespaces:  make(map[string]bool),
+		changed:            make(chan struct{}, 1),
+		watchRetryInterval: defaultWatchRetryInterval,
+	}, nil
+}
+
+// Run reconciles the registration entries with the pods whenever they
+// change, and at every resync interval, until the context is cancelled
+func (c *Controller) Run(ctx context.Context) error {
+	go c.watch(ctx)
+
+	ticker := time.NewTicker(c.resync)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-ticker.C:
+			err = c.Reconcile(ctx)
+		case <-c.changed:
+			namespaces, all := c.takeChanges()
+			err = c.reconcile(ctx, namespaces, all)
+		case <-ctx.Done():
+			return nil
+		}
+		// Entries left out of date are reconciled at the next resync
+		if err != nil {
+			c.log.Errorf("Could not reconcile registration entries: %v", err)
+		}
+	}
+}
+
+// watch keeps the pods up to date, listing them again when they cannot be
+// watched from the resource version they are up to date with anymore
+func (c *Controller) watch(ctx context.Context) {
+	for {
+		err := c.watchOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.log.Warnf("Pod watch ended: %v", err)
+
+		select {
+		case <-time.After(c.watchRetryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Controller) watchOnce(ctx context.Context) error {
+	c.mu.Lock()
+	resourceVersion := c.resourceVersion
+	c.mu.Unlock()
+
+	if resourceVersion == "" {
+		var err error
+		resourceVersion, err = c.listPods(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.kube.WatchPods(ctx, resourceVersion, c.applyEvent)
+	if isGone(err) {
+		c.mu.Lock()
+		c.resourceVersion = ""
+		c.mu.Unlock()
+	}
+	return err
+}
+
+// listPods lists the pods again, replacing those known, and returns the
+// resource version of the list
+func (c *Controller) listPods(ctx context.Context) (string, error) {
+	list, resourceVersion, err := c.kube.ListPods(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to list pods: %v", err)
+	}
+
+	pods := make(map[string]*pod)
+	for _, p := range list {
+		pods[podKey(p)] = p
+	}
+
+	c.mu.Lock()
+	c.pods = pods
+	c.listed = true
+	c.resourceVersion = resourceVersion
+	c.changedAll = true
+	c.mu.Unlock()
+
+	c.notifyChanged()
+	return resourceVersion, nil
+}
+
+// applyEvent applies a watched change to the known pods. The namespace of
+// the pod is only reconciled if the entry of the pod changes, and not e.g.
+// on every status update.
+func (c *Controller) applyEvent(eventType string, p *pod) {
+	key := podKey(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resourceVersion = p.Metadata.ResourceVersion
+
+	old := c.pods[key]
+	switch eventType {
+	case "ADDED", "MODIFIED":
+		c.pods[key] = p
+	case "DELETED":
+		delete(c.pods, key)
+		p = nil
+	default:
+		return
+	}
+
+	if c.podEntryKey(old) == c.podEntryKey(p) {
+		return
+	}
+	if old != nil {
+		c.changedNamespaces[old.Metadata.Namespace] = true
+	}
+	if p != nil {
+		c.changedNamespaces[p.Metadata.Namespace] = true
+	}
+	c.notifyChanged()
+}
+
+// takeChanges returns the namespaces changed since the last call, or true
+// if all of them have to be reconciled
+func (c *Controller) takeChanges() ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	all := c.changedAll
+	var namespaces []string
+	for namespace := range c.changedNamespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	c.changedAll = false
+	c.changedNamespaces = make(map[string]bool)
+	return namespaces, all
+}
+
+func (c *Controller) notifyChanged() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Reconcile reconciles the registration entries of every namespace with
+// the pods, listing the pods first if they have not been yet
+func (c *Controller) Reconcile(ctx context.Context) error {
+	c.mu.Lock()
+	listed := c.listed
+	c.mu.Unlock()
+
+	if !listed {
+		if _, err := c.listPods(ctx); err != nil {
+			return err
+		}
+	}
+
+	c.takeChanges()
+	return c.reconcile(ctx, nil, true)
+}
+
+// reconcile creates the registration entries of the pods of the namespaces,
+// or of every namespace if all is true, which have none, then deletes the
+// entries of those namespaces with the configured parent ID which match no
+// pod
+func (c *Controller) reconcile(ctx context.Context, namespaces []string, all bool) error {
+	if !all && len(namespaces) == 0 {
+		return nil
+	}
+
+	desired := make(map[string]*common.RegistrationEntry)
+	c.mu.Lock()
+	for _, pod := range c.pods {
+		if !all && !contains(namespaces, pod.Metadata.Namespace) {
+			continue
+		}
+		entry, err := c.entryFor(pod)
+		if err != nil {
+			c.log.Warnf("Could not register pod %s/%s: %v", pod.Metadata.Namespace, pod.Metadata.Name, err)
+			continue
+		}
+		if entry != nil {
+			desired[entryKey(entry)] = entry
+		}
+	}
+	c.mu.Unlock()
+
+	var registered []*common.RegistrationEntry
+	if all {
+		resp, err := c.entries.ListEntries(ctx, &entry_pb.ListEntriesRequest{
+			ByParentId: c.c.ParentID,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to list entries: %v", err)
+		}
+		registered = resp.Entries
+	} else {
+		for _, namespace := range namespaces {
+			resp, err := c.entries.ListEntries(ctx, &entry_pb.ListEntriesRequest{
+				ByParentId:    c.c.ParentID,
+				WithSelectors: []*common.Selector{namespaceSelector(namespace)},
+			})
+			if err != nil {
+				return fmt.Errorf("unable to list entries of namespace %s: %v", namespace, err)
+			}
+			registered = append(registered, resp.Entries...)
+		}
+	}
+
+	var stale []*common.RegistrationEntry
+	existing := make(map[string]bool)
+	for _, entry := range registered {
+		key := entryKey(entry)
+		want, ok := desired[key]
+		if !ok || existing[key] {
+			stale = append(stale, entry)
+			continue
+		}
+		existing[key] = true
+
+		if entry.Ttl != want.Ttl {
+			entry.Ttl = want.Ttl
+			if _, err := c.entries.UpdateEntry(ctx, &entry_pb.UpdateEntryRequest{Entry: entry}); err != nil {
+				return fmt.Errorf("unable to update entry %s: %v", entry.EntryId, err)
+			}
+			c.log.Infof("Updated entry %s for %s", entry.EntryId, entry.SpiffeId)
+		}
+	}
+
+	// Entries are created before the stale ones are deleted, so that pods
+	// relabeled into another SPIFFE ID are never left without one
+	for _, key := range sortedEntryKeys(desired) {
+		if existing[key] {
+			continue
+		}
+		entry := desired[key]
+		resp, err := c.entries.CreateEntry(ctx, &entry_pb.CreateEntryRequest{Entry: entry})
+		if err != nil {
+			return fmt.Errorf("unable to create entry for %s: %v", entry.SpiffeId, err)
+		}
+		c.log.Infof("Created entry %s for %s", resp.Entry.EntryId, entry.SpiffeId)
+	}
+
+	for _, entry := range stale {
+		if _, err := c.entries.DeleteEntry(ctx, &entry_pb.DeleteEntryRequest{Id: entry.EntryId}); err != nil {
+			return fmt.Errorf("unable to delete entry %s: %v", entry.EntryId, err)
+		}
+		c.log.Infof("Deleted entry %s for %s", entry.EntryId, entry.SpiffeId)
+	}
+
+	return nil
+}
+
+// podEntryKey returns the key of the entry of the pod, or an empty string
+// if the pod is nil or not to be registered
+func (c *Controller) podEntryKey(p *pod) string {
+	if p == nil {
+		return ""
+	}
+	entry, err := c.entryFor(p)
+	if err != nil || entry == nil {
+		return ""
+	}
+	return entryKey(entry)
+}
+
+// entryFor returns the registration entry of the pod, or nil if the pod is
+// not to be registered
+func (c *Controller) entryFor(pod *pod) (*common.RegistrationEntry, error) {
+	switch pod.Status.Phase {
+	case "Succeeded", "Failed":
+		return nil, nil
+	}
+
+	namespace := pod.Metadata.Namespace
+	if len(c.c.Namespaces) > 0 && !contains(c.c.Namespaces, namespace) {
+		return nil, nil
+	}
+	if contains(c.c.IgnoreNamespaces, namespace) {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, label := range c.c.PodLabels {
+		value, ok := pod.Metadata.Labels[label]
+		if !ok {
+			return nil, nil
+		}
+		labels[label] = value
+	}
+
+	data := struct {
+		Namespace      string
+		ServiceAccount string
+		Labels         map[string]string
+	}{
+		Namespace:      namespace,
+		ServiceAccount: pod.Spec.ServiceAccountName,
+		Labels:         labels,
+	}
+	buf := new(bytes.Buffer)
+	if err := c.tmpl.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	spiffeID := buf.String()
+	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowTrustDomainWorkload(c.trustDomain)); err != nil {
+		return nil, err
+	}
+
+	selectors := []*common.Selector{
+		namespaceSelector(namespace),
+		{Type: selectorType, Value: "sa:" + pod.Spec.ServiceAccountName},
+	}
+	for _, label := range sortedKeys(labels) {
+		selectors = append(selectors, &common.Selector{
+			Type:  selectorType,
+			Value: fmt.Sprintf("pod-label:%s:%s", label, labels[label]),
+		})
+	}
+
+	return &common.RegistrationEntry{
+		ParentId:  c.c.ParentID,
+		SpiffeId:  spiffeID,
+		Selectors: selectors,
+		Ttl:       c.c.TTL,
+	}, nil
+}
+
+// entryKey identifies the entry by its SPIFFE ID and selectors, regardless
+// of the order of the selectors
+func entryKey(entry *common.RegistrationEntry) string {
+	var selectors []string
+	for _, s := range entry.Selectors {
+		selectors = append(selectors, s.Type+":"+s.Value)
+	}
+	sort.Strings(selectors)
+	return entry.SpiffeId + " " + strings.Join(selectors, ",")
+}
+
+func namespaceSelector(namespace string) *common.Selector {
+	return &common.Selector{Type: selectorType, Value: "ns:" + namespace}
+}
+
+func podKey(p *pod) string {
+	return p.Metadata.Namespace + "/" + p.Metadata.Name
+}
+
+func sortedEntryKeys(m map[string]*common.RegistrationEntry) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,298 @@
+package k8sregistrar
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/mock/proto/api/v1/entry"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	ctx = context.Background()
+)
+
+const (
+	parentID = "spiffe://example.org/k8s-node"
+
+	podsJSON = `{"metadata": {"resourceVersion": "10"}, "items": [
+		{
+			"metadata": {"name": "web-1", "namespace": "default", "labels": {"app": "web", "tier": "front"}},
+			"spec": {"serviceAccountName": "web"},
+			"status": {"phase": "Running"}
+		},
+		{
+			"metadata": {"name": "db-1", "namespace": "default", "labels": {"app": "db"}},
+			"spec": {"serviceAccountName": "db"},
+			"status": {"phase": "Pending"}
+		},
+		{
+			"metadata": {"name": "job-1", "namespace": "default", "labels": {"app": "job"}},
+			"spec": {"serviceAccountName": "job"},
+			"status": {"phase": "Succeeded"}
+		},
+		{
+			"metadata": {"name": "unlabeled", "namespace": "default"},
+			"spec": {"serviceAccountName": "default"},
+			"status": {"phase": "Running"}
+		},
+		{
+			"metadata": {"name": "dns", "namespace": "kube-system", "labels": {"app": "dns"}},
+			"spec": {"serviceAccountName": "dns"},
+			"status": {"phase": "Running"}
+		}
+	]}`
+
+	// The watch ends with the resource version watched from being too old
+	watchJSON = `
+		{"type": "ADDED", "object": {"metadata": {"name": "api-1", "namespace": "staging", "labels": {"app": "api"}, "resourceVersion": "11"}, "status": {"phase": "Pending"}}}
+		{"type": "MODIFIED", "object": {"metadata": {"name": "web-1", "namespace": "default", "labels": {"app": "web", "tier": "front"}, "resourceVersion": "12"}, "spec": {"serviceAccountName": "web"}, "status": {"phase": "Running"}}}
+		{"type": "DELETED", "object": {"metadata": {"name": "dns", "namespace": "kube-system", "labels": {"app": "dns"}, "resourceVersion": "13"}, "status": {"phase": "Running"}}}
+		{"type": "ERROR", "object": {"kind": "Status", "message": "too old resource version: 10 (13)", "reason": "Expired", "code": 410}}
+	`
+)
+
+type ControllerTestSuite struct {
+	suite.Suite
+
+	dir        string
+	kubeServer *httptest.Server
+	kubeAuth   string
+	watchFrom  string
+	mockCtrl   *gomock.Controller
+	entries    *mock_entry.MockEntryClient
+	config     *Config
+	controller *Controller
+}
+
+func TestController(t *testing.T) {
+	suite.Run(t, new(ControllerTestSuite))
+}
+
+func (s *ControllerTestSuite) SetupTest() {
+	var err error
+	s.dir, err = ioutil.TempDir("", "k8sregistrar-test")
+	s.Require().NoError(err)
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.dir, "token"), []byte("t0k3n\n"), 0600))
+
+	s.kubeServer = httptest.NewServer(http.HandlerFunc(s.serveKube))
+
+	s.mockCtrl = gomock.NewController(s.T())
+	s.entries = mock_entry.NewMockEntryClient(s.mockCtrl)
+
+	s.config = &Config{
+		KubeAPIURL:       s.kubeServer.URL,
+		KubeTokenPath:    filepath.Join(s.dir, "token"),
+		ParentID:         parentID,
+		SPIFFEIDTemplate: "spiffe://example.org/ns/{{.Namespace}}/{{.Labels.app}}",
+		PodLabels:        []string{"app"},
+		IgnoreNamespaces: []string{"kube-system"},
+		TTL:              600,
+		ResyncInterval:   "1m",
+	}
+	s.newController()
+}
+
+func (s *ControllerTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.kubeServer.Close()
+	os.RemoveAll(s.dir)
+}
+
+func (s *ControllerTestSuite) newController() {
+	kube, err := newKubeClient(s.config)
+	s.Require().NoError(err)
+	log, _ := test.NewNullLogger()
+	s.controller, err = newController(s.config, log, kube, s.entries)
+	s.Require().NoError(err)
+}
+
+func (s *ControllerTestSuite) serveKube(w http.ResponseWriter, req *http.Request) {
+	s.kubeAuth = req.Header.Get("Authorization")
+	switch {
+	case req.URL.Path != "/api/v1/pods":
+		http.NotFound(w, req)
+	case req.URL.Query().Get("watch") == "true":
+		s.watchFrom = req.URL.Query().Get("resourceVersion")
+		fmt.Fprint(w, watchJSON)
+	default:
+		fmt.Fprint(w, podsJSON)
+	}
+}
+
+func (s *ControllerTestSuite) TestReconcile() {
+	web := &common.RegistrationEntry{
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/default/web",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:default"},
+			{Type: "k8s", Value: "sa:web"},
+			{Type: "k8s", Value: "pod-label:app:web"},
+		},
+		Ttl: 600,
+	}
+	db := &common.RegistrationEntry{
+		EntryId:  "db-entry",
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/default/db",
+		// Selectors are matched regardless of their order
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "pod-label:app:db"},
+			{Type: "k8s", Value: "sa:db"},
+			{Type: "k8s", Value: "ns:default"},
+		},
+		Ttl: 3600,
+	}
+	job := &common.RegistrationEntry{
+		EntryId:  "job-entry",
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/default/job",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:default"},
+			{Type: "k8s", Value: "sa:job"},
+			{Type: "k8s", Value: "pod-label:app:job"},
+		},
+		Ttl: 600,
+	}
+
+	updatedDB := *db
+	updatedDB.Ttl = 600
+
+	gomock.InOrder(
+		s.entries.EXPECT().ListEntries(gomock.Any(), &entry_pb.ListEntriesRequest{ByParentId: parentID}).
+			Return(&entry_pb.ListEntriesResponse{Entries: []*common.RegistrationEntry{db, job}}, nil),
+		s.entries.EXPECT().UpdateEntry(gomock.Any(), &entry_pb.UpdateEntryRequest{Entry: &updatedDB}).
+			Return(&entry_pb.UpdateEntryResponse{Entry: &updatedDB}, nil),
+		s.entries.EXPECT().CreateEntry(gomock.Any(), &entry_pb.CreateEntryRequest{Entry: web}).
+			Return(&entry_pb.CreateEntryResponse{Entry: web}, nil),
+		s.entries.EXPECT().DeleteEntry(gomock.Any(), &entry_pb.DeleteEntryRequest{Id: "job-entry"}).
+			Return(&entry_pb.DeleteEntryResponse{Entry: job}, nil),
+	)
+
+	s.Require().NoError(s.controller.Reconcile(ctx))
+	s.Require().Equal("Bearer t0k3n", s.kubeAuth)
+}
+
+func (s *ControllerTestSuite) TestReconcileSkipsInvalidSPIFFEIDs() {
+	s.config.SPIFFEIDTemplate = "spiffe://other.org/{{.Labels.app}}"
+	s.newController()
+
+	s.entries.EXPECT().ListEntries(gomock.Any(), gomock.Any()).
+		Return(&entry_pb.ListEntriesResponse{}, nil)
+
+	s.Require().NoError(s.controller.Reconcile(ctx))
+}
+
+func (s *ControllerTestSuite) TestReconcileNamespaces() {
+	s.config.Namespaces = []string{"kube-system"}
+	s.config.IgnoreNamespaces = nil
+	s.newController()
+
+	s.entries.EXPECT().ListEntries(gomock.Any(), gomock.Any()).
+		Return(&entry_pb.ListEntriesResponse{}, nil)
+	dns := &common.RegistrationEntry{
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/kube-system/dns",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:kube-system"},
+			{Type: "k8s", Value: "sa:dns"},
+			{Type: "k8s", Value: "pod-label:app:dns"},
+		},
+		Ttl: 600,
+	}
+	s.entries.EXPECT().CreateEntry(gomock.Any(), &entry_pb.CreateEntryRequest{Entry: dns}).
+		Return(&entry_pb.CreateEntryResponse{Entry: dns}, nil)
+
+	s.Require().NoError(s.controller.Reconcile(ctx))
+}
+
+func (s *ControllerTestSuite) TestReconcileKubeFailure() {
+	s.config.KubeAPIURL = s.kubeServer.URL + "/missing"
+	s.newController()
+
+	err := s.controller.Reconcile(ctx)
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "unable to list pods: unexpected status 404 Not Found")
+}
+
+func (s *ControllerTestSuite) TestWatch() {
+	err := s.controller.watchOnce(ctx)
+	s.Require().EqualError(err, "too old resource version: 10 (13) (Expired, code 410)")
+
+	// The pods are watched from the version of the list, and listed again
+	// once the version is too old
+	s.Require().Equal("10", s.watchFrom)
+	s.Require().Equal("", s.controller.resourceVersion)
+	s.Require().Contains(s.controller.pods, "staging/api-1")
+	s.Require().NotContains(s.controller.pods, "kube-system/dns")
+	s.Require().Equal("12", s.controller.pods["default/web-1"].Metadata.ResourceVersion)
+
+	// Only the namespaces where the entries of the pods changed are
+	// reconciled, besides all of them once the pods are listed
+	namespaces, all := s.controller.takeChanges()
+	s.Require().True(all)
+	s.Require().Equal([]string{"staging"}, namespaces)
+}
+
+func (s *ControllerTestSuite) TestReconcileChangedNamespaces() {
+	_, err := s.controller.listPods(ctx)
+	s.Require().NoError(err)
+
+	web := &common.RegistrationEntry{
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/default/web",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:default"},
+			{Type: "k8s", Value: "sa:web"},
+			{Type: "k8s", Value: "pod-label:app:web"},
+		},
+		Ttl: 600,
+	}
+	db := &common.RegistrationEntry{
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/default/db",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:default"},
+			{Type: "k8s", Value: "sa:db"},
+			{Type: "k8s", Value: "pod-label:app:db"},
+		},
+		Ttl: 600,
+	}
+	job := &common.RegistrationEntry{
+		EntryId:  "job-entry",
+		ParentId: parentID,
+		SpiffeId: "spiffe://example.org/ns/default/job",
+		Selectors: []*common.Selector{
+			{Type: "k8s", Value: "ns:default"},
+			{Type: "k8s", Value: "sa:job"},
+			{Type: "k8s", Value: "pod-label:app:job"},
+		},
+		Ttl: 600,
+	}
+
+	gomock.InOrder(
+		s.entries.EXPECT().ListEntries(gomock.Any(), &entry_pb.ListEntriesRequest{
+			ByParentId:    parentID,
+			WithSelectors: []*common.Selector{{Type: "k8s", Value: "ns:default"}},
+		}).Return(&entry_pb.ListEntriesResponse{Entries: []*common.RegistrationEntry{db, job}}, nil),
+		s.entries.EXPECT().CreateEntry(gomock.Any(), &entry_pb.CreateEntryRequest{Entry: web}).
+			Return(&entry_pb.CreateEntryResponse{Entry: web}, nil),
+		s.entries.EXPECT().DeleteEntry(gomock.Any(), &entry_pb.DeleteEntryRequest{Id: "job-entry"}).
+			Return(&entry_pb.DeleteEntryResponse{Entry: job}, nil),
+	)
+
+	s.Require().NoError(s.controller.reconcile(ctx, []string{"default"}, false))
+
+	// Nothing is reconciled without changes
+	s.Require().NoError(s.controller.reconcile(ctx, nil, false))
+}
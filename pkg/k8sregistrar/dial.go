@@ -0,0 +1,86 @@
+package k8sregistrar
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/util"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// dialServer dials the SPIRE server. The X509-SVID and trust bundle are
+// loaded again for each connection, so that they can be rotated on disk.
+func dialServer(ctx context.Context, c *Config, log logrus.StdLogger) (*grpc.ClientConn, error) {
+	credFunc := func() (credentials.TransportCredentials, error) {
+		tlsConfig := &tls.Config{
+			// The server is verified against the trust bundle below, as its
+			// SVID holds no DNS names to verify it with
+			InsecureSkipVerify: true,
+		}
+
+		if c.TrustBundlePath != "" {
+			roots, err := util.LoadCertPool(c.TrustBundlePath)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				return verifyServer(rawCerts, roots)
+			}
+		}
+
+		if c.CertPath != "" {
+			cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		return credentials.NewTLS(tlsConfig), nil
+	}
+
+	dialer := grpcutil.NewGRPCDialer(grpcutil.GRPCDialerConfig{
+		Log:      log,
+		CredFunc: credFunc,
+	})
+
+	addr, err := net.ResolveTCPAddr("tcp", c.ServerAddress)
+	if err != nil {
+		return nil, err
+	}
+	return dialer.Dial(ctx, addr)
+}
+
+func verifyServer(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no server certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	var leaf *x509.Certificate
+	for i, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			leaf = cert
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
@@ -0,0 +1,185 @@
+package k8sregistrar
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// How long listing the pods may take. Watches are not bound, as they
+	// are only ended by the Kubernetes API.
+	listTimeout = 30 * time.Second
+)
+
+// pod holds the fields of a Kubernetes pod the registrar needs
+type pod struct {
+	Metadata struct {
+		Name            string            `json:"name"`
+		Namespace       string            `json:"namespace"`
+		Labels          map[string]string `json:"labels"`
+		ResourceVersion string            `json:"resourceVersion"`
+	} `json:"metadata"`
+	Spec struct {
+		ServiceAccountName string `json:"serviceAccountName"`
+	} `json:"spec"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+type podList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Items []*pod `json:"items"`
+}
+
+// watchEvent is a change of a pod. The object of ERROR events is a status
+// instead of a pod.
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// statusError is a failure reported by the Kubernetes API as a Status object
+type statusError struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	Code    int    `json:"code"`
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s (%s, code %d)", e.Message, e.Reason, e.Code)
+}
+
+// isGone tells whether the error reports that the resource version watched
+// from is too old, in which case the pods must be listed again
+func isGone(err error) bool {
+	statusErr, ok := err.(*statusError)
+	return ok && statusErr.Code == http.StatusGone
+}
+
+// kubeClient lists and watches the pods of the cluster through the
+// Kubernetes API, authenticating with a bearer token
+type kubeClient struct {
+	url       string
+	tokenPath string
+	client    *http.Client
+}
+
+func newKubeClient(c *Config) (*kubeClient, error) {
+	tlsConfig := &tls.Config{}
+	if strings.HasPrefix(c.KubeAPIURL, "https:") {
+		caPEM, err := ioutil.ReadFile(c.KubeCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", c.KubeCAPath, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no CA certificates found in %s", c.KubeCAPath)
+		}
+	}
+
+	return &kubeClient{
+		url:       strings.TrimSuffix(c.KubeAPIURL, "/"),
+		tokenPath: c.KubeTokenPath,
+		client: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// ListPods lists the pods of every namespace, along with the resource
+// version of the list to watch the changes from
+func (k *kubeClient) ListPods(ctx context.Context) ([]*pod, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, listTimeout)
+	defer cancel()
+
+	resp, err := k.get(ctx, "/api/v1/pods")
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	list := new(podList)
+	if err := json.NewDecoder(resp.Body).Decode(list); err != nil {
+		return nil, "", fmt.Errorf("unable to decode pod list: %v", err)
+	}
+	return list.Items, list.Metadata.ResourceVersion, nil
+}
+
+// WatchPods watches the changes made to the pods of every namespace since
+// the given resource version, calling onEvent for each of them, until the
+// watch is ended by the Kubernetes API or the context is cancelled. A
+// *statusError is returned if the watch fails with an ERROR event.
+func (k *kubeClient) WatchPods(ctx context.Context, resourceVersion string, onEvent func(eventType string, p *pod)) error {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("resourceVersion", resourceVersion)
+	resp, err := k.get(ctx, "/api/v1/pods?"+query.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		event := new(watchEvent)
+		if err := decoder.Decode(event); err != nil {
+			return err
+		}
+
+		if event.Type == "ERROR" {
+			statusErr := new(statusError)
+			if err := json.Unmarshal(event.Object, statusErr); err != nil {
+				return fmt.Errorf("unable to decode watch error: %v", err)
+			}
+			return statusErr
+		}
+
+		p := new(pod)
+		if err := json.Unmarshal(event.Object, p); err != nil {
+			return fmt.Errorf("unable to decode %s pod: %v", event.Type, err)
+		}
+		onEvent(event.Type, p)
+	}
+}
+
+func (k *kubeClient) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", k.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	// The token is read on every request, as it may be rotated
+	if k.tokenPath != "" {
+		token, err := ioutil.ReadFile(k.tokenPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if len(token) > 0 {
+			req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+		}
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %s from the Kubernetes API: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
@@ -0,0 +1,134 @@
+package oidcdiscovery
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/config"
+	"github.com/spiffe/spire/pkg/common/idutil"
+)
+
+const (
+	defaultListenAddress = ":443"
+	defaultSocketPath    = "/tmp/agent.sock"
+	defaultLogLevel      = "INFO"
+)
+
+// Config is the configuration file of the provider
+type Config struct {
+	LogLevel string `hcl:"log_level"`
+	LogPath  string `hcl:"log_path"`
+
+	// DNS name the provider is reached at. The issuer is https://<domain>,
+	// which must be the jwt_issuer of the server.
+	Domain string `hcl:"domain"`
+
+	// Trust domain whose JWT signing keys are served, e.g. example.org
+	TrustDomain string `hcl:"trust_domain"`
+
+	// Path of the Workload API socket of the agent the keys are fetched from
+	WorkloadAPISocketPath string `hcl:"workload_api_socket_path"`
+
+	// Address to serve the discovery document and keys on
+	ListenAddress string `hcl:"listen_address"`
+
+	// Paths of the PEM encoded Web PKI certificate chain and private key to
+	// serve with. Not used if ACME is set.
+	CertPath string `hcl:"cert_path"`
+	KeyPath  string `hcl:"key_path"`
+
+	// If set, the Web PKI certificate of the domain is obtained and renewed
+	// from an ACME CA such as Let's Encrypt
+	ACME *ACMEConfig `hcl:"acme"`
+
+	// Serve plain HTTP, e.g. behind a proxy terminating TLS for the domain
+	InsecureHTTP bool `hcl:"insecure_http"`
+
+	// Serve requests whatever their Host header, e.g. behind a proxy which
+	// does not preserve it. Otherwise only requests for the domain are.
+	AllowAnyHost bool `hcl:"allow_any_host"`
+}
+
+// ACMEConfig configures the ACME certificate acquisition, as for the bundle
+// endpoint of the server
+type ACMEConfig struct {
+	DirectoryURL string `hcl:"directory_url"`
+	Email        string `hcl:"email"`
+	CacheDir     string `hcl:"cache_dir"`
+	ToSAccepted  bool   `hcl:"tos_accepted"`
+}
+
+// ParseConfig parses the configuration file, expanding environment
+// variables, and applies the defaults
+func ParseConfig(data string) (*Config, error) {
+	hclTree, err := hcl.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.Expand(hclTree); err != nil {
+		return nil, fmt.Errorf("unable to expand config: %v", err)
+	}
+
+	c := &Config{}
+	if err := hcl.DecodeObject(c, hclTree); err != nil {
+		return nil, err
+	}
+
+	if c.LogLevel == "" {
+		c.LogLevel = defaultLogLevel
+	}
+	if c.WorkloadAPISocketPath == "" {
+		c.WorkloadAPISocketPath = defaultSocketPath
+	}
+	if c.ListenAddress == "" {
+		c.ListenAddress = defaultListenAddress
+	}
+
+	return c, nil
+}
+
+// Validate ensures that the values in Config are valid
+func (c *Config) Validate() error {
+	if c.Domain == "" {
+		return errors.New("domain is required")
+	}
+	if c.TrustDomain == "" {
+		return errors.New("trust_domain is required")
+	}
+	if err := idutil.ValidateSpiffeID(c.trustDomainID(), idutil.AllowAnyTrustDomain()); err != nil {
+		return fmt.Errorf("invalid trust_domain: %v", err)
+	}
+
+	servings := 0
+	if c.CertPath != "" || c.KeyPath != "" {
+		if c.CertPath == "" || c.KeyPath == "" {
+			return errors.New("cert_path and key_path must be set together")
+		}
+		servings++
+	}
+	if c.ACME != nil {
+		if !c.ACME.ToSAccepted {
+			return errors.New("the terms of service of the ACME CA must be accepted with tos_accepted")
+		}
+		servings++
+	}
+	if c.InsecureHTTP {
+		servings++
+	}
+	if servings != 1 {
+		return errors.New("exactly one of cert_path and key_path, acme or insecure_http is required")
+	}
+
+	return nil
+}
+
+// Issuer returns the issuer of the JWT-SVIDs, as served in the discovery
+// document
+func (c *Config) Issuer() string {
+	return "https://" + c.Domain
+}
+
+func (c *Config) trustDomainID() string {
+	return "spiffe://" + c.TrustDomain
+}
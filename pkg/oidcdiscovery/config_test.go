@@ -0,0 +1,104 @@
+package oidcdiscovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigDefaults(t *testing.T) {
+	c, err := ParseConfig(`
+		domain = "oidc.example.org"
+		trust_domain = "example.org"
+		cert_path = "cert.pem"
+		key_path = "key.pem"
+	`)
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+
+	require.Equal(t, "INFO", c.LogLevel)
+	require.Equal(t, "/tmp/agent.sock", c.WorkloadAPISocketPath)
+	require.Equal(t, ":443", c.ListenAddress)
+	require.Equal(t, "https://oidc.example.org", c.Issuer())
+	require.Equal(t, "spiffe://example.org", c.trustDomainID())
+	require.False(t, c.AllowAnyHost)
+}
+
+func TestParseConfigACME(t *testing.T) {
+	c, err := ParseConfig(`
+		domain = "oidc.example.org"
+		trust_domain = "example.org"
+		acme {
+			email = "admin@example.org"
+			cache_dir = "/var/lib/oidc"
+			tos_accepted = true
+		}
+	`)
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+	require.Equal(t, &ACMEConfig{
+		Email:       "admin@example.org",
+		CacheDir:    "/var/lib/oidc",
+		ToSAccepted: true,
+	}, c.ACME)
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		return &Config{
+			Domain:       "oidc.example.org",
+			TrustDomain:  "example.org",
+			InsecureHTTP: true,
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    string
+	}{
+		{
+			name:   "no domain",
+			modify: func(c *Config) { c.Domain = "" },
+			err:    "domain is required",
+		},
+		{
+			name:   "no trust domain",
+			modify: func(c *Config) { c.TrustDomain = "" },
+			err:    "trust_domain is required",
+		},
+		{
+			name:   "invalid trust domain",
+			modify: func(c *Config) { c.TrustDomain = "example.org/path" },
+			err:    `invalid trust_domain: "spiffe://example.org/path" is not a valid trust domain SPIFFE ID: path is not empty`,
+		},
+		{
+			name:   "cert without key",
+			modify: func(c *Config) { c.InsecureHTTP, c.CertPath = false, "cert.pem" },
+			err:    "cert_path and key_path must be set together",
+		},
+		{
+			name:   "terms of service not accepted",
+			modify: func(c *Config) { c.InsecureHTTP, c.ACME = false, &ACMEConfig{} },
+			err:    "the terms of service of the ACME CA must be accepted with tos_accepted",
+		},
+		{
+			name:   "no way to serve",
+			modify: func(c *Config) { c.InsecureHTTP = false },
+			err:    "exactly one of cert_path and key_path, acme or insecure_http is required",
+		},
+		{
+			name:   "several ways to serve",
+			modify: func(c *Config) { c.ACME = &ACMEConfig{ToSAccepted: true} },
+			err:    "exactly one of cert_path and key_path, acme or insecure_http is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			require.EqualError(t, c.Validate(), tt.err)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package oidcdiscovery
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/acmeutil"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	keysPath      = "/keys"
+)
+
+// discoveryDocument is the OpenID Connect discovery document. Only the keys
+// of ID tokens are served, so there are no authorization or token
+// endpoints to advertise.
+type discoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Provider serves the OpenID Connect discovery document and JWK set of a
+// trust domain, for relying parties such as cloud providers to validate the
+// JWT-SVIDs of its workloads as ID tokens
+type Provider struct {
+	c      *Config
+	log    logrus.FieldLogger
+	source *keySource
+}
+
+// New returns a Provider for the validated configuration, fetching the keys
+// from the Workload API of the agent
+func New(ctx context.Context, c *Config, log logrus.FieldLogger) (*Provider, error) {
+	client, err := dialWorkloadAPI(ctx, c.WorkloadAPISocketPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial the Workload API: %v", err)
+	}
+	return &Provider{
+		c:      c,
+		log:    log,
+		source: newKeySource(client, c.trustDomainID(), log),
+	}, nil
+}
+
+// ListenAndServe serves the discovery document and keys until the context
+// is cancelled
+func (p *Provider) ListenAndServe(ctx context.Context) error {
+	go p.source.Run(ctx)
+
+	l, err := net.Listen("tcp", p.c.ListenAddress)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler: p,
+	}
+
+	errChan := make(chan error)
+	if p.c.InsecureHTTP {
+		p.log.Infof("Serving %s over HTTP on %s", p.c.Issuer(), l.Addr())
+		go func() { errChan <- server.Serve(l) }()
+	} else {
+		server.TLSConfig, err = p.tlsConfig()
+		if err != nil {
+			l.Close()
+			return err
+		}
+		p.log.Infof("Serving %s on %s", p.c.Issuer(), l.Addr())
+		go func() { errChan <- server.ServeTLS(l, "", "") }()
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		<-errChan
+		return nil
+	}
+}
+
+// ServeHTTP serves the discovery document and the keys of the domain
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !p.c.AllowAnyHost {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if host != p.c.Domain {
+			http.Error(w, "domain not allowed", http.StatusNotFound)
+			return
+		}
+	}
+
+	switch r.URL.Path {
+	case discoveryPath:
+		p.serveDiscoveryDocument(w)
+	case keysPath:
+		p.serveKeys(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (p *Provider) serveDiscoveryDocument(w http.ResponseWriter) {
+	doc, err := json.Marshal(&discoveryDocument{
+		Issuer:                           p.c.Issuer(),
+		JWKSURI:                          p.c.Issuer() + keysPath,
+		AuthorizationEndpoint:            "",
+		ResponseTypesSupported:           []string{"id_token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256", "ES256", "ES384", "ES512"},
+	})
+	if err != nil {
+		p.log.Errorf("Could not marshal the discovery document: %v", err)
+		http.Error(w, "discovery document not available", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(doc)
+}
+
+func (p *Provider) serveKeys(w http.ResponseWriter) {
+	jwks := p.source.JWKS()
+	if jwks == nil {
+		http.Error(w, "keys not available yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jwks)
+}
+
+// tlsConfig returns the TLS configuration of the provider, serving either
+// the configured certificate or the one obtained through ACME for the domain
+func (p *Provider) tlsConfig() (*tls.Config, error) {
+	if p.c.ACME == nil {
+		cert, err := tls.LoadX509KeyPair(p.c.CertPath, p.c.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load certificate: %v", err)
+		}
+		return &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}, nil
+	}
+
+	return acmeutil.TLSConfig(&acmeutil.Config{
+		DomainName:   p.c.Domain,
+		DirectoryURL: p.c.ACME.DirectoryURL,
+		Email:        p.c.ACME.Email,
+		CacheDir:     p.c.ACME.CacheDir,
+		ToSAccepted:  p.c.ACME.ToSAccepted,
+	})
+}
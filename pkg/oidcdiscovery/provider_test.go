@@ -0,0 +1,183 @@
+package oidcdiscovery
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/api/workload"
+	"github.com/spiffe/spire/test/fakes/fakeacme"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProviderTestSuite struct {
+	suite.Suite
+
+	key crypto.PublicKey
+	p   *Provider
+}
+
+func TestProvider(t *testing.T) {
+	suite.Run(t, new(ProviderTestSuite))
+}
+
+func (s *ProviderTestSuite) SetupTest() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	s.key = key.Public()
+
+	log, _ := test.NewNullLogger()
+	c := &Config{
+		Domain:       "oidc.example.org",
+		TrustDomain:  "example.org",
+		InsecureHTTP: true,
+	}
+	s.p = &Provider{
+		c:      c,
+		log:    log,
+		source: newKeySource(nil, c.trustDomainID(), log),
+	}
+}
+
+func (s *ProviderTestSuite) get(host, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "http://"+host+path, nil)
+	w := httptest.NewRecorder()
+	s.p.ServeHTTP(w, req)
+	return w
+}
+
+func (s *ProviderTestSuite) bundle(keys map[string]crypto.PublicKey) []byte {
+	data, err := bundleutil.Marshal(&bundleutil.Bundle{JWTSigningKeys: keys})
+	s.Require().NoError(err)
+	return data
+}
+
+func (s *ProviderTestSuite) TestDiscoveryDocument() {
+	w := s.get("oidc.example.org", "/.well-known/openid-configuration")
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Require().Equal("application/json", w.Header().Get("Content-Type"))
+
+	doc := new(discoveryDocument)
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), doc))
+	s.Require().Equal("https://oidc.example.org", doc.Issuer)
+	s.Require().Equal("https://oidc.example.org/keys", doc.JWKSURI)
+	s.Require().Equal([]string{"id_token"}, doc.ResponseTypesSupported)
+	s.Require().Equal([]string{"public"}, doc.SubjectTypesSupported)
+	s.Require().Contains(doc.IDTokenSigningAlgValuesSupported, "ES256")
+
+	// The port is ignored
+	w = s.get("oidc.example.org:8443", "/.well-known/openid-configuration")
+	s.Require().Equal(http.StatusOK, w.Code)
+}
+
+func (s *ProviderTestSuite) TestKeys() {
+	// Nothing is served until the keys are fetched
+	w := s.get("oidc.example.org", "/keys")
+	s.Require().Equal(http.StatusServiceUnavailable, w.Code)
+
+	s.Require().NoError(s.p.source.update(&workload.JWTBundlesResponse{
+		Bundles: map[string][]byte{
+			"spiffe://example.org": s.bundle(map[string]crypto.PublicKey{"kid": s.key}),
+			"spiffe://partner.org": s.bundle(map[string]crypto.PublicKey{"other": s.key}),
+		},
+	}))
+
+	w = s.get("oidc.example.org", "/keys")
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Require().Equal("application/json", w.Header().Get("Content-Type"))
+
+	var jwks struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &jwks))
+	s.Require().Len(jwks.Keys, 1)
+	s.Require().Equal("kid", jwks.Keys[0]["kid"])
+	s.Require().Equal("sig", jwks.Keys[0]["use"])
+	s.Require().Equal("ES256", jwks.Keys[0]["alg"])
+}
+
+func (s *ProviderTestSuite) TestKeysUpdateErrors() {
+	err := s.p.source.update(&workload.JWTBundlesResponse{
+		Bundles: map[string][]byte{
+			"spiffe://partner.org": s.bundle(map[string]crypto.PublicKey{"kid": s.key}),
+		},
+	})
+	s.Require().EqualError(err, "no bundle for spiffe://example.org")
+
+	err = s.p.source.update(&workload.JWTBundlesResponse{
+		Bundles: map[string][]byte{
+			"spiffe://example.org": s.bundle(nil),
+		},
+	})
+	s.Require().EqualError(err, "the bundle holds no JWT signing keys")
+	s.Require().Nil(s.p.source.JWKS())
+}
+
+func (s *ProviderTestSuite) TestRejectsOtherDomains() {
+	w := s.get("other.example.org", "/.well-known/openid-configuration")
+	s.Require().Equal(http.StatusNotFound, w.Code)
+
+	w = s.get("oidc.example.org", "/other")
+	s.Require().Equal(http.StatusNotFound, w.Code)
+
+	req := httptest.NewRequest("POST", "http://oidc.example.org/keys", nil)
+	rec := httptest.NewRecorder()
+	s.p.ServeHTTP(rec, req)
+	s.Require().Equal(http.StatusMethodNotAllowed, rec.Code)
+}
+
+func (s *ProviderTestSuite) TestAllowAnyHost() {
+	s.p.c.AllowAnyHost = true
+
+	// e.g. behind a proxy forwarding to the address of the provider
+	w := s.get("10.0.0.1:8080", "/.well-known/openid-configuration")
+	s.Require().Equal(http.StatusOK, w.Code)
+}
+
+func (s *ProviderTestSuite) TestTLSConfigWithACME() {
+	ca, err := fakeacme.New()
+	s.Require().NoError(err)
+	defer ca.Close()
+
+	s.p.c.InsecureHTTP = false
+	s.p.c.ACME = &ACMEConfig{
+		DirectoryURL: ca.URL,
+		ToSAccepted:  true,
+	}
+	tlsConfig, err := s.p.tlsConfig()
+	s.Require().NoError(err)
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	s.Require().NoError(err)
+	defer l.Close()
+	ca.Resolve("oidc.example.org", l.Addr().String())
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}()
+		}
+	}()
+
+	// The certificate of the domain is obtained on the first handshake
+	conn, err := tls.Dial("tcp", l.Addr().String(), &tls.Config{
+		ServerName: "oidc.example.org",
+		RootCAs:    ca.Roots,
+	})
+	s.Require().NoError(err)
+	conn.Close()
+	s.Require().Equal(1, ca.Issued())
+}
@@ -0,0 +1,125 @@
+package oidcdiscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/api/workload"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// How long to wait before fetching the keys again once the stream ends
+	defaultRetryInterval = 5 * time.Second
+)
+
+// keySource keeps the JWT signing keys of the trust domain up to date, as
+// streamed by the Workload API of the agent
+type keySource struct {
+	client        workload.SpiffeWorkloadAPIClient
+	trustDomainID string
+	log           logrus.FieldLogger
+	retryInterval time.Duration
+
+	mtx  sync.RWMutex
+	jwks []byte
+}
+
+func newKeySource(client workload.SpiffeWorkloadAPIClient, trustDomainID string, log logrus.FieldLogger) *keySource {
+	return &keySource{
+		client:        client,
+		trustDomainID: trustDomainID,
+		log:           log,
+		retryInterval: defaultRetryInterval,
+	}
+}
+
+// dialWorkloadAPI dials the Workload API socket of the agent, which is
+// unauthenticated
+func dialWorkloadAPI(ctx context.Context, socketPath string) (workload.SpiffeWorkloadAPIClient, error) {
+	conn, err := grpc.DialContext(ctx, socketPath, grpc.WithInsecure(), grpc.WithDialer(
+		func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	if err != nil {
+		return nil, err
+	}
+	return workload.NewSpiffeWorkloadAPIClient(conn), nil
+}
+
+// JWKS returns the JWK set of the trust domain, or nil if it has not been
+// fetched yet
+func (s *keySource) JWKS() []byte {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.jwks
+}
+
+// Run fetches the keys as they change until the context is cancelled
+func (s *keySource) Run(ctx context.Context) {
+	for {
+		err := s.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warnf("Could not fetch the JWT signing keys: %v", err)
+
+		select {
+		case <-time.After(s.retryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *keySource) watch(ctx context.Context) error {
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+	stream, err := s.client.FetchJWTBundles(ctx, &workload.JWTBundlesRequest{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if err := s.update(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *keySource) update(resp *workload.JWTBundlesResponse) error {
+	data, ok := resp.Bundles[s.trustDomainID]
+	if !ok {
+		return fmt.Errorf("no bundle for %s", s.trustDomainID)
+	}
+	bundle, err := bundleutil.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	if len(bundle.JWTSigningKeys) == 0 {
+		return errors.New("the bundle holds no JWT signing keys")
+	}
+	jwks, err := bundleutil.MarshalJWKS(bundle.JWTSigningKeys)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if string(jwks) != string(s.jwks) {
+		s.log.Infof("Serving %d JWT signing keys", len(bundle.JWTSigningKeys))
+	}
+	s.jwks = jwks
+	return nil
+}
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	common_health "github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/server/health"
+)
+
+// DefaultSocketPath is the default location of the admin API socket
+const DefaultSocketPath = "./spire_admin_api"
+
+// Server serves the admin API over a unix domain socket only accessible
+// to the user running the server
+type Server struct {
+	SocketPath string
+	Log        logrus.FieldLogger
+
+	// If set, the log levels are served and can be changed on
+	// log.LevelsPath
+	LogLevels *log.Levels
+
+	// If set, the health report of the server is served on
+	// common_health.ReportPath
+	Health *health.Checker
+}
+
+// ListenAndServe serves the admin API until the context is cancelled
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	os.Remove(s.SocketPath)
+	l, err := net.Listen("unix", s.SocketPath)
+	if err != nil {
+		return fmt.Errorf("create admin API listener: %v", err)
+	}
+	if err := os.Chmod(s.SocketPath, 0600); err != nil {
+		l.Close()
+		return fmt.Errorf("restrict admin API socket: %v", err)
+	}
+
+	server := &http.Server{Handler: s.Handler()}
+
+	s.Log.Infof("Serving admin API on %s", s.SocketPath)
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		<-errChan
+		return nil
+	}
+}
+
+// Handler returns the HTTP handler serving the admin API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	if s.LogLevels != nil {
+		mux.Handle(log.LevelsPath, log.LevelsHandler(s.LogLevels))
+	}
+	if s.Health != nil {
+		mux.Handle(common_health.ReportPath, s.Health.ReportHandler())
+	}
+	return mux
+}
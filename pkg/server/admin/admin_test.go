@@ -0,0 +1,99 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	common_health "github.com/spiffe/spire/pkg/common/health"
+	common_log "github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/server/health"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenAndServe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "admin-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	logger, err := common_log.New(common_log.Config{Level: "INFO"})
+	require.NoError(t, err)
+	log, _ := test.NewNullLogger()
+	s := &Server{
+		SocketPath: path.Join(dir, "admin.sock"),
+		Log:        log,
+		LogLevels:  logger.Levels,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() { errChan <- s.ListenAndServe(ctx) }()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", s.SocketPath)
+			},
+		},
+	}
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = client.Post("http://server"+common_log.LevelsPath+"?subsystem=catalog&level=debug", "", nil)
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	levelsResp := new(common_log.LevelsResponse)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(levelsResp))
+	require.Equal(t, &common_log.LevelsResponse{
+		Level:           "info",
+		SubsystemLevels: map[string]string{"catalog": "debug"},
+	}, levelsResp)
+
+	// Only the user running the server may use the socket
+	info, err := os.Stat(s.SocketPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cancel()
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("admin API did not stop")
+	}
+}
+
+func TestHandlerServesHealthReport(t *testing.T) {
+	log, _ := test.NewNullLogger()
+	s := &Server{
+		Log:    log,
+		Health: &health.Checker{Catalog: fakeservercatalog.New(), Log: log},
+	}
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + common_health.ReportPath + "?check=" + common_health.CheckShallow)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	report := new(common_health.Report)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(report))
+	require.Equal(t, &common_health.Report{Healthy: true}, report)
+}
@@ -0,0 +1,374 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+const (
+	// defaultRefreshInterval is how often a bundle is fetched when its
+	// endpoint gives no refresh hint
+	defaultRefreshInterval = 5 * time.Minute
+
+	// minRefreshInterval bounds how often a bundle endpoint is fetched,
+	// whatever its refresh hint
+	minRefreshInterval = 30 * time.Second
+
+	// retryInterval is how long to wait before fetching a bundle again
+	// after a failure
+	retryInterval = time.Minute
+
+	// maxBundleSize bounds the size of the bundle documents read
+	maxBundleSize = 1024 * 1024
+
+	// metricsInterval is how often the gauges relative to the current time
+	// are reported between refreshes
+	metricsInterval = 10 * time.Second
+)
+
+// FederatedTrustDomain configures how the bundle of a federated trust domain
+// is fetched
+type FederatedTrustDomain struct {
+	// URL of the bundle endpoint of the trust domain, authenticated with
+	// Web PKI
+	BundleEndpointURL string
+}
+
+// FederationStatus reports the health of the relationship with a federated
+// trust domain
+type FederationStatus struct {
+	TrustDomain       string
+	BundleEndpointURL string
+
+	// Time of the last successful fetch of the bundle. Zero if none yet.
+	LastSuccess time.Time
+
+	// Error and time of the last failed fetch of the bundle, if it failed
+	// since the last successful one
+	LastError   string
+	LastErrorAt time.Time
+
+	// Expiry of the last CA certificate of the stored bundle to expire. Zero
+	// if no bundle is stored.
+	BundleExpiresAt time.Time
+}
+
+// Client keeps the bundles of the federated trust domains up to date in the
+// datastore, fetching them from their bundle endpoints as often as the
+// refresh hints of the bundles advise. Agents receive the updated bundles the
+// next time they sync with the server.
+type Client struct {
+	// Federated trust domains to fetch the bundles of, keyed by SPIFFE ID
+	TrustDomains map[string]FederatedTrustDomain
+	Catalog      catalog.Catalog
+	Log          logrus.FieldLogger
+	Tel          telemetry.Sink
+
+	// HTTP client to fetch the bundles with. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu       sync.Mutex
+	statuses map[string]FederationStatus
+}
+
+// Run fetches the bundles until the context is cancelled
+func (c *Client) Run(ctx context.Context) error {
+	tasks := []func(context.Context) error{
+		func(ctx context.Context) error {
+			return c.startMetricsReporter(ctx, metricsInterval)
+		},
+	}
+	for trustDomain, config := range c.TrustDomains {
+		tasks = append(tasks, c.pollBundle(trustDomain, config))
+	}
+
+	err := util.RunTasks(ctx, tasks...)
+	if err == context.Canceled {
+		err = nil
+	}
+	return err
+}
+
+// pollBundle returns a task refreshing the bundle of the trust domain until
+// the context is cancelled
+func (c *Client) pollBundle(trustDomain string, config FederatedTrustDomain) func(context.Context) error {
+	return func(ctx context.Context) error {
+		var sequence uint64
+		for {
+			var interval time.Duration
+			var err error
+			sequence, interval, err = c.refreshBundle(ctx, trustDomain, config, sequence)
+			if err != nil {
+				c.Log.Warnf("Could not refresh the bundle of %s: %v", trustDomain, err)
+				interval = retryInterval
+			}
+			c.recordStatus(ctx, trustDomain, config, err)
+
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// refreshBundle fetches the bundle of the trust domain and stores it, unless
+// its sequence number shows it is not newer than the last one fetched. It
+// returns the sequence number of the bundle stored, and how long to wait
+// before refreshing it again.
+func (c *Client) refreshBundle(ctx context.Context, trustDomain string, config FederatedTrustDomain, lastSequence uint64) (uint64, time.Duration, error) {
+	data, err := c.fetchBundle(ctx, config.BundleEndpointURL)
+	if err != nil {
+		return lastSequence, 0, err
+	}
+
+	bundle, b, err := unmarshalBundle(trustDomain, data)
+	if err != nil {
+		return lastSequence, 0, err
+	}
+	interval := refreshInterval(b.RefreshHint)
+
+	if b.Sequence != 0 && b.Sequence <= lastSequence {
+		c.Log.Debugf("Bundle of %s is not newer than sequence number %d", trustDomain, lastSequence)
+		return lastSequence, interval, nil
+	}
+
+	existing, err := c.findBundle(ctx, trustDomain)
+	if err != nil {
+		return lastSequence, 0, err
+	}
+	if existing != nil && bundlesEqual(existing, bundle) {
+		return b.Sequence, interval, nil
+	}
+
+	ds := c.Catalog.DataStores()[0]
+	if existing == nil {
+		bundle, err = ds.CreateBundle(ctx, bundle)
+	} else {
+		bundle, err = ds.UpdateBundle(ctx, bundle)
+	}
+	if err != nil {
+		return lastSequence, 0, fmt.Errorf("unable to store bundle: %v", err)
+	}
+	catalog.NotifyBundleUpdated(ctx, c.Catalog, c.Log, bundle)
+
+	c.Log.Infof("Updated the bundle of %s", trustDomain)
+	return b.Sequence, interval, nil
+}
+
+// FederationStatuses returns the status of the relationships with the
+// federated trust domains, sorted by SPIFFE ID
+func (c *Client) FederationStatuses() []FederationStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var statuses []FederationStatus
+	for trustDomain, config := range c.TrustDomains {
+		status, ok := c.statuses[trustDomain]
+		if !ok {
+			status = FederationStatus{
+				TrustDomain:       trustDomain,
+				BundleEndpointURL: config.BundleEndpointURL,
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].TrustDomain < statuses[j].TrustDomain
+	})
+	return statuses
+}
+
+// recordStatus records the outcome of a refresh of the bundle of the trust
+// domain, along with the expiry of the stored bundle, and reports them as
+// metrics
+func (c *Client) recordStatus(ctx context.Context, trustDomain string, config FederatedTrustDomain, fetchErr error) {
+	now := time.Now()
+	expiresAt, err := c.bundleExpiry(ctx, trustDomain)
+	if err != nil {
+		c.Log.Warnf("Could not get the expiry of the bundle of %s: %v", trustDomain, err)
+	}
+
+	c.mu.Lock()
+	if c.statuses == nil {
+		c.statuses = make(map[string]FederationStatus)
+	}
+	status := c.statuses[trustDomain]
+	status.TrustDomain = trustDomain
+	status.BundleEndpointURL = config.BundleEndpointURL
+	if fetchErr != nil {
+		status.LastError = fetchErr.Error()
+		status.LastErrorAt = now
+	} else {
+		status.LastSuccess = now
+		status.LastError = ""
+		status.LastErrorAt = time.Time{}
+	}
+	if err == nil {
+		status.BundleExpiresAt = expiresAt
+	}
+	c.statuses[trustDomain] = status
+	c.mu.Unlock()
+
+	if fetchErr != nil {
+		c.tel().IncrCounterWithLabels([]string{"bundle_client", "fetch_errors"}, 1, statusLabels(status))
+	}
+	c.reportStatus(status, now)
+}
+
+// startMetricsReporter reports the gauges of the recorded statuses until the
+// context is cancelled, for those relative to the current time not to go
+// stale between refreshes
+func (c *Client) startMetricsReporter(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.reportStatuses(time.Now())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reportStatuses reports the gauges of the recorded statuses
+func (c *Client) reportStatuses(now time.Time) {
+	c.mu.Lock()
+	var statuses []FederationStatus
+	for _, status := range c.statuses {
+		statuses = append(statuses, status)
+	}
+	c.mu.Unlock()
+
+	for _, status := range statuses {
+		c.reportStatus(status, now)
+	}
+}
+
+// reportStatus reports the gauges of the status of a federated trust domain
+func (c *Client) reportStatus(status FederationStatus, now time.Time) {
+	tel := c.tel()
+	labels := statusLabels(status)
+	if !status.LastSuccess.IsZero() {
+		tel.SetGaugeWithLabels([]string{"bundle_client", "last_success"}, float32(status.LastSuccess.Unix()), labels)
+		tel.SetGaugeWithLabels([]string{"bundle_client", "seconds_since_last_success"}, float32(now.Sub(status.LastSuccess)/time.Second), labels)
+	}
+	if !status.BundleExpiresAt.IsZero() {
+		tel.SetGaugeWithLabels([]string{"bundle_client", "bundle_expiry"}, float32(status.BundleExpiresAt.Unix()), labels)
+		tel.SetGaugeWithLabels([]string{"bundle_client", "bundle_ttl"}, float32(status.BundleExpiresAt.Sub(now)/time.Second), labels)
+	}
+}
+
+func (c *Client) tel() telemetry.Sink {
+	if c.Tel == nil {
+		return telemetry.Blackhole{}
+	}
+	return c.Tel
+}
+
+func statusLabels(status FederationStatus) []telemetry.Label {
+	return []telemetry.Label{{Name: "trust_domain", Value: status.TrustDomain}}
+}
+
+// bundleExpiry returns the expiry of the last CA certificate of the stored
+// bundle of the trust domain to expire, or zero if there is no bundle
+func (c *Client) bundleExpiry(ctx context.Context, trustDomain string) (time.Time, error) {
+	bundle, err := c.findBundle(ctx, trustDomain)
+	if err != nil || bundle == nil {
+		return time.Time{}, err
+	}
+	certs, err := x509.ParseCertificates(bundle.CaCerts)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse CA certificates: %v", err)
+	}
+
+	var expiresAt time.Time
+	for _, cert := range certs {
+		if cert.NotAfter.After(expiresAt) {
+			expiresAt = cert.NotAfter
+		}
+	}
+	return expiresAt, nil
+}
+
+// fetchBundle fetches the bundle document from the bundle endpoint
+func (c *Client) fetchBundle(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bundle endpoint returned %s", resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxBundleSize))
+}
+
+// findBundle returns the stored bundle of the trust domain, or nil if there
+// is none
+func (c *Client) findBundle(ctx context.Context, trustDomain string) (*datastore.Bundle, error) {
+	resp, err := c.Catalog.DataStores()[0].ListBundles(ctx, &common.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list bundles: %v", err)
+	}
+	for _, b := range resp.Bundles {
+		if b.TrustDomain == trustDomain {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
+
+// refreshInterval returns how long to wait before fetching a bundle again,
+// given its refresh hint
+func refreshInterval(hint time.Duration) time.Duration {
+	switch {
+	case hint <= 0:
+		return defaultRefreshInterval
+	case hint < minRefreshInterval:
+		return minRefreshInterval
+	default:
+		return hint
+	}
+}
+
+func bundlesEqual(a, b *datastore.Bundle) bool {
+	if !bytes.Equal(a.CaCerts, b.CaCerts) || len(a.JwtSigningKeys) != len(b.JwtSigningKeys) || a.RefreshHint != b.RefreshHint {
+		return false
+	}
+	for i := range a.JwtSigningKeys {
+		if !proto.Equal(a.JwtSigningKeys[i], b.JwtSigningKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,247 @@
+package bundle
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/notifier"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/notifier"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+)
+
+const federatedTrustDomain = "spiffe://partner.org"
+
+type ClientTestSuite struct {
+	suite.Suite
+
+	doc    []byte
+	server *httptest.Server
+	ds     *fakedatastore.FakeDataStore
+	tel    *fakeSink
+	c      *Client
+}
+
+// fakeSink records the gauges set and counters increased, keyed by the
+// metric name followed by the label values
+type fakeSink struct {
+	telemetry.Blackhole
+	values map[string]float32
+}
+
+func (s *fakeSink) SetGaugeWithLabels(key []string, val float32, labels []telemetry.Label) {
+	s.values[metricKey(key, labels)] = val
+}
+
+func (s *fakeSink) IncrCounterWithLabels(key []string, val float32, labels []telemetry.Label) {
+	s.values[metricKey(key, labels)] += val
+}
+
+func metricKey(key []string, labels []telemetry.Label) string {
+	for _, label := range labels {
+		key = append(key, label.Value)
+	}
+	return strings.Join(key, ".")
+}
+
+func TestClient(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.doc == nil {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Write(s.doc)
+	}))
+
+	s.ds = fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(s.ds)
+
+	log, _ := test.NewNullLogger()
+	s.tel = &fakeSink{values: make(map[string]float32)}
+	s.c = &Client{
+		TrustDomains: map[string]FederatedTrustDomain{
+			federatedTrustDomain: {BundleEndpointURL: s.server.URL},
+		},
+		Catalog: catalog,
+		Log:     log,
+		Tel:     s.tel,
+	}
+}
+
+func (s *ClientTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ClientTestSuite) TestRefreshBundle() {
+	config := s.c.TrustDomains[federatedTrustDomain]
+
+	// The bundle is created, and refreshed as often as its hint advises
+	bundle1 := s.setBundle(1, 10*time.Minute)
+	sequence, interval, err := s.c.refreshBundle(ctx, federatedTrustDomain, config, 0)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(1), sequence)
+	s.Require().Equal(10*time.Minute, interval)
+	s.requireBundle(bundle1)
+	s.Require().Equal(int64(600), s.fetchBundle().RefreshHint)
+
+	// A bundle which is not newer is ignored
+	s.setBundle(1, 10*time.Minute)
+	sequence, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, sequence)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(1), sequence)
+	s.requireBundle(bundle1)
+
+	// A newer bundle replaces the stored one
+	bundle2 := s.setBundle(2, 0)
+	sequence, interval, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, sequence)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(2), sequence)
+	s.Require().Equal(defaultRefreshInterval, interval)
+	s.requireBundle(bundle2)
+
+	// Failures leave the stored bundle alone
+	s.doc = nil
+	sequence, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, sequence)
+	s.Require().EqualError(err, "bundle endpoint returned 404 Not Found")
+	s.Require().Equal(uint64(2), sequence)
+	s.doc = []byte(`{"keys": []}`)
+	_, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, sequence)
+	s.Require().EqualError(err, "bundle has no X509-SVID authorities")
+	s.requireBundle(bundle2)
+}
+
+func (s *ClientTestSuite) TestRefreshBundleNotifies() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+	n := mock_notifier.NewMockNotifier(ctrl)
+	s.c.Catalog.(*fakeservercatalog.Catalog).SetNotifiers(n)
+
+	// The notifiers are told about the stored bundle, but not about bundles
+	// which are ignored
+	var notified *notifier.NotifyRequest
+	n.EXPECT().Notify(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, req *notifier.NotifyRequest) { notified = req }).
+		Return(&notifier.NotifyResponse{}, nil)
+
+	config := s.c.TrustDomains[federatedTrustDomain]
+	s.setBundle(1, 0)
+	sequence, _, err := s.c.refreshBundle(ctx, federatedTrustDomain, config, 0)
+	s.Require().NoError(err)
+	s.Require().Equal(&notifier.NotifyRequest{
+		BundleUpdated: &notifier.BundleUpdated{Bundle: catalog.NotifierBundle(s.fetchBundle())},
+	}, notified)
+
+	s.setBundle(1, 0)
+	_, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, sequence)
+	s.Require().NoError(err)
+}
+
+func (s *ClientTestSuite) TestFederationStatuses() {
+	config := s.c.TrustDomains[federatedTrustDomain]
+
+	// Trust domains not fetched yet are listed with no status
+	s.Require().Equal([]FederationStatus{{
+		TrustDomain:       federatedTrustDomain,
+		BundleEndpointURL: s.server.URL,
+	}}, s.c.FederationStatuses())
+
+	// A successful fetch records the time and the expiry of the bundle
+	bundle := s.setBundle(1, 0)
+	caCert, err := x509.ParseCertificate(bundle.CaCerts)
+	s.Require().NoError(err)
+	_, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, 0)
+	s.Require().NoError(err)
+	s.c.recordStatus(ctx, federatedTrustDomain, config, err)
+
+	statuses := s.c.FederationStatuses()
+	s.Require().Len(statuses, 1)
+	s.Require().False(statuses[0].LastSuccess.IsZero())
+	s.Require().Empty(statuses[0].LastError)
+	s.Require().True(statuses[0].BundleExpiresAt.Equal(caCert.NotAfter))
+	s.Require().Equal(float32(caCert.NotAfter.Unix()), s.tel.values["bundle_client.bundle_expiry."+federatedTrustDomain])
+	s.Require().Contains(s.tel.values, "bundle_client.seconds_since_last_success."+federatedTrustDomain)
+	s.Require().NotContains(s.tel.values, "bundle_client.fetch_errors."+federatedTrustDomain)
+
+	// A failed fetch records the error, and keeps the last success
+	s.doc = nil
+	_, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, 1)
+	s.Require().Error(err)
+	s.c.recordStatus(ctx, federatedTrustDomain, config, err)
+
+	failed := s.c.FederationStatuses()[0]
+	s.Require().Equal("bundle endpoint returned 404 Not Found", failed.LastError)
+	s.Require().False(failed.LastErrorAt.IsZero())
+	s.Require().Equal(statuses[0].LastSuccess, failed.LastSuccess)
+	s.Require().True(failed.BundleExpiresAt.Equal(caCert.NotAfter))
+	s.Require().Equal(float32(1), s.tel.values["bundle_client.fetch_errors."+federatedTrustDomain])
+
+	// The gauges relative to the current time are reported between
+	// refreshes too
+	s.c.reportStatuses(time.Now().Add(time.Hour))
+	s.Require().InDelta(3600, s.tel.values["bundle_client.seconds_since_last_success."+federatedTrustDomain], 5)
+
+	// A successful fetch clears the error
+	s.setBundle(2, 0)
+	_, _, err = s.c.refreshBundle(ctx, federatedTrustDomain, config, 1)
+	s.Require().NoError(err)
+	s.c.recordStatus(ctx, federatedTrustDomain, config, err)
+
+	recovered := s.c.FederationStatuses()[0]
+	s.Require().Empty(recovered.LastError)
+	s.Require().True(recovered.LastErrorAt.IsZero())
+	s.Require().True(recovered.LastSuccess.After(failed.LastErrorAt) || recovered.LastSuccess.Equal(failed.LastErrorAt))
+}
+
+func (s *ClientTestSuite) TestRefreshInterval() {
+	s.Require().Equal(defaultRefreshInterval, refreshInterval(0))
+	s.Require().Equal(minRefreshInterval, refreshInterval(time.Second))
+	s.Require().Equal(time.Hour, refreshInterval(time.Hour))
+}
+
+// setBundle has the bundle endpoint serve a new bundle, with the given
+// sequence number and refresh hint
+func (s *ClientTestSuite) setBundle(sequence uint64, refreshHint time.Duration) *datastore.Bundle {
+	template, err := util.NewCATemplate("partner.org")
+	s.Require().NoError(err)
+	caCert, _, err := util.SelfSign(template)
+	s.Require().NoError(err)
+
+	s.doc, err = bundleutil.Marshal(&bundleutil.Bundle{
+		RootCAs:     []*x509.Certificate{caCert},
+		RefreshHint: refreshHint,
+		Sequence:    sequence,
+	})
+	s.Require().NoError(err)
+	return &datastore.Bundle{
+		TrustDomain: federatedTrustDomain,
+		CaCerts:     caCert.Raw,
+	}
+}
+
+func (s *ClientTestSuite) requireBundle(expected *datastore.Bundle) {
+	s.Require().Equal(expected.CaCerts, s.fetchBundle().CaCerts)
+}
+
+func (s *ClientTestSuite) fetchBundle() *datastore.Bundle {
+	bundle, err := s.ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: federatedTrustDomain})
+	s.Require().NoError(err)
+	return bundle
+}
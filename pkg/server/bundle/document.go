@@ -0,0 +1,49 @@
+package bundle
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+// defaultRefreshHint is how often federated trust domains are advised to
+// fetch the bundle again, unless the stored bundle has a refresh hint of its
+// own. It is well within the time the next CA spends in the bundle before it
+// is activated.
+const defaultRefreshHint = 5 * time.Minute
+
+// marshalBundle encodes the bundle in the SPIFFE bundle format: the CA
+// certificates as X509-SVID authorities, and the JWT signing keys as
+// JWT-SVID authorities, along with the sequence number and refresh hint of
+// the stored bundle
+func marshalBundle(bundle *datastore.Bundle) ([]byte, error) {
+	b, err := bundleutil.FromDatastore(bundle)
+	if err != nil {
+		return nil, err
+	}
+	if b.RefreshHint <= 0 {
+		b.RefreshHint = defaultRefreshHint
+	}
+	return bundleutil.Marshal(b)
+}
+
+// unmarshalBundle decodes a bundle in the SPIFFE bundle format fetched from
+// the bundle endpoint of the given trust domain. The refresh hint of the
+// document is kept with the bundle.
+func unmarshalBundle(trustDomain string, data []byte) (*datastore.Bundle, *bundleutil.Bundle, error) {
+	b, err := bundleutil.Unmarshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(b.RootCAs) == 0 {
+		return nil, nil, errors.New("bundle has no X509-SVID authorities")
+	}
+
+	bundle, err := bundleutil.ToDatastore(trustDomain, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bundle, b, nil
+}
@@ -0,0 +1,116 @@
+package bundle
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/acmeutil"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+// Config configures the bundle endpoint
+type Config struct {
+	// Address to serve the bundle endpoint on. It is not served if empty.
+	BindAddress string
+
+	// Paths of the PEM encoded Web PKI certificate chain and private key
+	// to serve the endpoint with. Not used if ACME is set.
+	CertPath string
+	KeyPath  string
+
+	// If set, the Web PKI certificate is obtained and renewed from an ACME
+	// CA such as Let's Encrypt. The bundle endpoint has to be reachable on
+	// port 443 of the domain for the CA to validate it.
+	ACME *acmeutil.Config
+}
+
+// Endpoint serves the trust bundle of the server over HTTPS, as a SPIFFE
+// bundle document, for other trust domains to federate with. It is
+// authenticated with a Web PKI certificate, so that it can be fetched
+// without trusting the SPIFFE identity of the server beforehand.
+type Endpoint struct {
+	Config      Config
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+	Log         logrus.FieldLogger
+}
+
+// ListenAndServe serves the bundle endpoint until the context is cancelled
+func (e *Endpoint) ListenAndServe(ctx context.Context) error {
+	tlsConfig, err := e.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", e.Config.BindAddress)
+	if err != nil {
+		return fmt.Errorf("create bundle endpoint listener: %v", err)
+	}
+
+	server := &http.Server{
+		Handler:   e,
+		TLSConfig: tlsConfig,
+	}
+
+	e.Log.Infof("Serving bundle endpoint on %s", l.Addr())
+	errChan := make(chan error)
+	go func() { errChan <- server.ServeTLS(l, "", "") }()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		<-errChan
+		return nil
+	}
+}
+
+// ServeHTTP writes the trust bundle as a SPIFFE bundle document
+func (e *Endpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	bundle, err := e.Catalog.DataStores()[0].FetchBundle(r.Context(), &datastore.Bundle{
+		TrustDomain: e.TrustDomain.String(),
+	})
+	if err != nil {
+		e.Log.Errorf("Could not fetch the bundle: %v", err)
+		http.Error(w, "bundle not available", http.StatusInternalServerError)
+		return
+	}
+
+	doc, err := marshalBundle(bundle)
+	if err != nil {
+		e.Log.Errorf("Could not marshal the bundle: %v", err)
+		http.Error(w, "bundle not available", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(doc)
+}
+
+// tlsConfig returns the TLS configuration of the endpoint, serving either
+// the configured certificate or the one obtained through ACME
+func (e *Endpoint) tlsConfig() (*tls.Config, error) {
+	if e.Config.ACME != nil {
+		return acmeutil.TLSConfig(e.Config.ACME)
+	}
+
+	cert, err := tls.LoadX509KeyPair(e.Config.CertPath, e.Config.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("load bundle endpoint certificate: %v", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}, nil
+}
@@ -0,0 +1,231 @@
+package bundle
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/acmeutil"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakeacme"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/acme"
+)
+
+var ctx = context.Background()
+
+type EndpointTestSuite struct {
+	suite.Suite
+
+	caCert *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	ds     *fakedatastore.FakeDataStore
+	e      *Endpoint
+}
+
+func TestEndpoint(t *testing.T) {
+	suite.Run(t, new(EndpointTestSuite))
+}
+
+func (s *EndpointTestSuite) SetupTest() {
+	template, err := util.NewCATemplate("example.org")
+	s.Require().NoError(err)
+	s.caCert, s.caKey, err = util.SelfSign(template)
+	s.Require().NoError(err)
+
+	s.ds = fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(s.ds)
+
+	log, _ := test.NewNullLogger()
+	s.e = &Endpoint{
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+		Log:         log,
+	}
+}
+
+func (s *EndpointTestSuite) TestServeHTTP() {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(s.caKey.Public())
+	s.Require().NoError(err)
+	_, err = s.ds.CreateBundle(ctx, &datastore.Bundle{
+		TrustDomain: "spiffe://example.org",
+		CaCerts:     s.caCert.Raw,
+		JwtSigningKeys: []*common.PublicKey{{
+			PkixBytes: pkixBytes,
+			Kid:       "key1",
+		}},
+	})
+	s.Require().NoError(err)
+
+	w := httptest.NewRecorder()
+	s.e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Require().Equal("application/json", w.Header().Get("Content-Type"))
+
+	bundle, err := bundleutil.Unmarshal(w.Body.Bytes())
+	s.Require().NoError(err)
+	s.Require().Equal(defaultRefreshHint, bundle.RefreshHint)
+	s.Require().Equal(uint64(1), bundle.Sequence)
+	s.Require().Equal([]*x509.Certificate{s.caCert}, bundle.RootCAs)
+	s.Require().Equal(map[string]crypto.PublicKey{"key1": s.caKey.Public()}, bundle.JWTSigningKeys)
+
+	// Other methods are not allowed
+	w = httptest.NewRecorder()
+	s.e.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	s.Require().Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
+func (s *EndpointTestSuite) TestTLSConfigWithCertificate() {
+	dir, err := ioutil.TempDir("", "bundle-endpoint-")
+	s.Require().NoError(err)
+	defer os.RemoveAll(dir)
+
+	keyBytes, err := x509.MarshalECPrivateKey(s.caKey)
+	s.Require().NoError(err)
+	s.e.Config.CertPath = filepath.Join(dir, "cert.pem")
+	s.e.Config.KeyPath = filepath.Join(dir, "key.pem")
+	s.Require().NoError(ioutil.WriteFile(s.e.Config.CertPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw}), 0600))
+	s.Require().NoError(ioutil.WriteFile(s.e.Config.KeyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+
+	tlsConfig, err := s.e.tlsConfig()
+	s.Require().NoError(err)
+	s.Require().Len(tlsConfig.Certificates, 1)
+	s.Require().Equal(s.caCert.Raw, tlsConfig.Certificates[0].Certificate[0])
+
+	s.e.Config.KeyPath = filepath.Join(dir, "missing.pem")
+	_, err = s.e.tlsConfig()
+	s.Require().Error(err)
+}
+
+func (s *EndpointTestSuite) TestTLSConfigWithACME() {
+	s.e.Config.ACME = &acmeutil.Config{
+		DomainName: "bundle.example.org",
+	}
+	_, err := s.e.tlsConfig()
+	s.Require().EqualError(err, "the terms of service of the ACME CA must be accepted")
+
+	s.e.Config.ACME.ToSAccepted = true
+	tlsConfig, err := s.e.tlsConfig()
+	s.Require().NoError(err)
+	s.Require().NotNil(tlsConfig.GetCertificate)
+	s.Require().Contains(tlsConfig.NextProtos, acme.ALPNProto)
+}
+
+func (s *EndpointTestSuite) TestListenAndServeWithACME() {
+	_, err := s.ds.CreateBundle(ctx, &datastore.Bundle{
+		TrustDomain: "spiffe://example.org",
+		CaCerts:     s.caCert.Raw,
+	})
+	s.Require().NoError(err)
+
+	ca, err := fakeacme.New()
+	s.Require().NoError(err)
+	defer ca.Close()
+
+	// Pick a free port for the endpoint
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	addr := l.Addr().String()
+	l.Close()
+	ca.Resolve("bundle.example.org", addr)
+
+	s.e.Config.BindAddress = addr
+	s.e.Config.ACME = &acmeutil.Config{
+		DomainName:   "bundle.example.org",
+		DirectoryURL: ca.URL,
+		ToSAccepted:  true,
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.e.ListenAndServe(ctx) }()
+	defer func() {
+		cancel()
+		s.Require().NoError(<-errCh)
+	}()
+
+	// The certificate is obtained from the ACME CA on the first request, the
+	// domain being validated through the endpoint itself
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, addr)
+			},
+			TLSClientConfig: &tls.Config{RootCAs: ca.Roots},
+		},
+	}
+	var resp *http.Response
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		resp, err = client.Get("https://bundle.example.org/")
+		if err == nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().Equal(1, ca.Issued())
+
+	data, err := ioutil.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	bundle, err := bundleutil.Unmarshal(data)
+	s.Require().NoError(err)
+	s.Require().Equal([]*x509.Certificate{s.caCert}, bundle.RootCAs)
+}
+
+func (s *EndpointTestSuite) TestUnmarshalBundle() {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(s.caKey.Public())
+	s.Require().NoError(err)
+	bundle := &datastore.Bundle{
+		TrustDomain: "spiffe://example.org",
+		CaCerts:     s.caCert.Raw,
+		JwtSigningKeys: []*common.PublicKey{{
+			PkixBytes: pkixBytes,
+			Kid:       "key1",
+		}},
+	}
+
+	// Bundles served by the endpoint are read back as they were
+	data, err := marshalBundle(bundle)
+	s.Require().NoError(err)
+	actual, b, err := unmarshalBundle("spiffe://example.org", data)
+	s.Require().NoError(err)
+	s.Require().Equal(bundle.CaCerts, actual.CaCerts)
+	s.Require().Equal(bundle.JwtSigningKeys, actual.JwtSigningKeys)
+	s.Require().Equal(int64(300), actual.RefreshHint)
+	s.Require().Equal(defaultRefreshHint, b.RefreshHint)
+	s.Require().Zero(b.Sequence)
+
+	// The stored sequence number and refresh hint are served, if any
+	bundle.SequenceNumber = 3
+	bundle.RefreshHint = 60
+	data, err = marshalBundle(bundle)
+	s.Require().NoError(err)
+	_, b, err = unmarshalBundle("spiffe://example.org", data)
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(3), b.Sequence)
+	s.Require().Equal(time.Minute, b.RefreshHint)
+
+	// Federated bundles must have X509-SVID authorities
+	_, _, err = unmarshalBundle("spiffe://example.org", []byte(`{"keys": []}`))
+	s.Require().EqualError(err, "bundle has no X509-SVID authorities")
+}
@@ -3,23 +3,62 @@ package ca
 import (
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/catalog"
 )
 
+const (
+	// DefaultJWTKeyTTL is how long JWT signing keys are valid for
+	DefaultJWTKeyTTL = 24 * time.Hour
+)
+
 type Config struct {
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
 
+	// UpstreamBundle makes the CA strictly an intermediate of the upstream
+	// CA: the trust bundle holds the upstream CA certificates rather than
+	// the CA certificate, which is then sent along with the SVIDs.
 	UpstreamBundle bool
 
+	// How long JWT signing keys are valid for. Defaults to DefaultJWTKeyTTL.
+	JWTKeyTTL time.Duration
+
+	// Issuer claim of the JWT-SVIDs signed. Not set if empty.
+	JWTIssuer string
+
+	// CRLEnabled makes the CA keep a CRL listing the revoked X509-SVIDs
+	// that have not expired yet.
+	CRLEnabled bool
+
+	// OCSPEnabled makes the CA keep a delegated OCSP responder, signed by
+	// the current CA, to sign OCSP responses with.
+	OCSPEnabled bool
+
+	// Path of the journal recording the CA slots on disk, so that the
+	// prepared and old CAs are known after a restart. No journal is kept
+	// if empty.
+	JournalPath string
+
 	Log logrus.FieldLogger
+
+	// Sink for the CA metrics. Metrics are discarded if not set.
+	Tel telemetry.Sink
 }
 
 func New(c *Config) *manager {
+	if c.Tel == nil {
+		c.Tel = telemetry.Blackhole{}
+	}
+	if c.JWTKeyTTL == 0 {
+		c.JWTKeyTTL = DefaultJWTKeyTTL
+	}
 	return &manager{
-		c:   c,
-		mtx: new(sync.RWMutex),
+		c:              c,
+		mtx:            new(sync.RWMutex),
+		expiryWarnings: make(map[string]logrus.Level),
 	}
 }
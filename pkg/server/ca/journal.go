@@ -0,0 +1,174 @@
+package ca
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// journal records the state of the CA slots on disk, so that after a crash
+// or a restart the CA manager knows which of the CA certificates published in
+// the trust bundle is prepared, active or old, rather than guessing. Only the
+// certificates are recorded: the keys stay with the ServerCA plugin, which
+// has to keep them across restarts for the journal to be of any use.
+type journal struct {
+	path  string
+	state journalState
+}
+
+type journalState struct {
+	Active   *journalEntry `json:"active,omitempty"`
+	Prepared *journalEntry `json:"prepared,omitempty"`
+	Old      *journalEntry `json:"old,omitempty"`
+}
+
+type journalEntry struct {
+	// ASN.1 DER encoded CA certificate
+	Certificate []byte `json:"certificate"`
+
+	// When the CA was prepared and activated, in UNIX time. They are zero
+	// if not known, e.g. for a CA activated before the journal was kept.
+	PreparedAt  int64 `json:"prepared_at,omitempty"`
+	ActivatedAt int64 `json:"activated_at,omitempty"`
+
+	cert *x509.Certificate
+}
+
+// loadJournal loads the journal at the given path. The journal is empty if
+// the file does not exist yet.
+func loadJournal(path string) (*journal, error) {
+	j := &journal{path: path}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &j.state); err != nil {
+		return nil, fmt.Errorf("unable to parse journal: %v", err)
+	}
+
+	for _, entry := range []*journalEntry{j.state.Active, j.state.Prepared, j.state.Old} {
+		if entry == nil {
+			continue
+		}
+		entry.cert, err = x509.ParseCertificate(entry.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse journal certificate: %v", err)
+		}
+	}
+	return j, nil
+}
+
+// save writes the journal to disk, through a temporary file so that a crash
+// never leaves a truncated journal behind. Both the file and the directory
+// are synced before returning, so that the journal survives a power loss.
+func (j *journal) save() error {
+	data, err := json.Marshal(j.state)
+	if err != nil {
+		return err
+	}
+
+	tmpPath := j.path + ".tmp"
+	if err := writeFileSync(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("unable to write journal: %v", err)
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return fmt.Errorf("unable to overwrite journal: %v", err)
+	}
+	if err := syncDir(filepath.Dir(j.path)); err != nil {
+		return fmt.Errorf("unable to sync journal directory: %v", err)
+	}
+	return nil
+}
+
+// writeFileSync is like ioutil.WriteFile, but syncs the file before closing
+// it.
+func writeFileSync(path string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// syncDir syncs the directory at the given path, for a rename in it to be
+// durable.
+func syncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// setPrepared records a CA prepared to replace the active one, replacing the
+// one prepared already if any.
+func (j *journal) setPrepared(cert *x509.Certificate) {
+	j.state.Prepared = newJournalEntry(cert)
+	j.state.Prepared.PreparedAt = time.Now().Unix()
+}
+
+// setActivated records the activation of the prepared CA, the active one
+// becoming the old one.
+func (j *journal) setActivated(cert *x509.Certificate) {
+	active := j.state.Prepared
+	if active == nil || !active.cert.Equal(cert) {
+		active = newJournalEntry(cert)
+	}
+	active.ActivatedAt = time.Now().Unix()
+
+	j.state.Old = j.state.Active
+	j.state.Active = active
+	j.state.Prepared = nil
+}
+
+// reset records the given CAs as the active and prepared ones, forgetting
+// about the old one. The prepared CA may be nil.
+func (j *journal) reset(active, prepared *x509.Certificate) {
+	j.state = journalState{Active: newJournalEntry(active)}
+	if prepared != nil {
+		j.state.Prepared = newJournalEntry(prepared)
+	}
+}
+
+func (j *journal) activeCert() *x509.Certificate {
+	return j.state.Active.certificate()
+}
+
+func (j *journal) preparedCert() *x509.Certificate {
+	return j.state.Prepared.certificate()
+}
+
+func (j *journal) oldCert() *x509.Certificate {
+	return j.state.Old.certificate()
+}
+
+func newJournalEntry(cert *x509.Certificate) *journalEntry {
+	return &journalEntry{
+		Certificate: cert.Raw,
+		cert:        cert,
+	}
+}
+
+func (e *journalEntry) certificate() *x509.Certificate {
+	if e == nil {
+		return nil
+	}
+	return e.cert
+}
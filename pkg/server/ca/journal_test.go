@@ -0,0 +1,82 @@
+package ca
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ca-journal-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal.json")
+
+	// A missing journal is empty
+	j, err := loadJournal(path)
+	require.NoError(t, err)
+	require.Nil(t, j.activeCert())
+	require.Nil(t, j.preparedCert())
+	require.Nil(t, j.oldCert())
+
+	cert1 := createJournalTestCert(t, 1)
+	cert2 := createJournalTestCert(t, 2)
+	cert3 := createJournalTestCert(t, 3)
+
+	j.reset(cert1, nil)
+	j.setPrepared(cert2)
+	require.NoError(t, j.save())
+
+	j, err = loadJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, cert1, j.activeCert())
+	require.Equal(t, cert2, j.preparedCert())
+	require.Nil(t, j.oldCert())
+	require.NotZero(t, j.state.Prepared.PreparedAt)
+
+	// Activating the prepared CA keeps its preparation time
+	preparedAt := j.state.Prepared.PreparedAt
+	j.setActivated(cert2)
+	require.Equal(t, cert2, j.activeCert())
+	require.Equal(t, cert1, j.oldCert())
+	require.Nil(t, j.preparedCert())
+	require.Equal(t, preparedAt, j.state.Active.PreparedAt)
+	require.NotZero(t, j.state.Active.ActivatedAt)
+
+	// A CA activated without being prepared first is recorded all the same
+	j.setActivated(cert3)
+	require.NoError(t, j.save())
+	j, err = loadJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, cert3, j.activeCert())
+	require.Equal(t, cert2, j.oldCert())
+	require.Zero(t, j.state.Active.PreparedAt)
+
+	// No temporary file is left behind
+	_, err = os.Stat(path + ".tmp")
+	require.True(t, os.IsNotExist(err))
+
+	// Failing to write the journal is an error
+	j.path = filepath.Join(dir, "missing", "journal.json")
+	require.Error(t, j.save())
+
+	// A corrupted journal is an error
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0600))
+	_, err = loadJournal(path)
+	require.Error(t, err)
+}
+
+func createJournalTestCert(t *testing.T, serialNumber int64) *x509.Certificate {
+	template, err := util.NewSVIDTemplate("spiffe://example.org")
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(serialNumber)
+	cert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+	return cert
+}
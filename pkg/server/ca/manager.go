@@ -2,18 +2,36 @@ package ca
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/ca"
 	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/notifier"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 )
 
+const (
+	// How long a CRL is valid for. It is signed again once it is halfway
+	// there, or as soon as the SVIDs it has to list change.
+	crlTTL = time.Hour
+)
+
 type Manager interface {
 	// Initializes the CA manager. Must be called before a call to Run().
 	Initialize(ctx context.Context) error
@@ -21,17 +39,108 @@ type Manager interface {
 	// Run runs the CA manager. It blocks until a failure or the context is
 	// canceled.
 	Run(ctx context.Context) error
+
+	// CACertificate returns the certificate of the active CA, or nil if the
+	// CA manager has not been initialized.
+	CACertificate() *x509.Certificate
+
+	// NextCACertificate returns the certificate of the prepared CA, or nil
+	// if none is prepared.
+	NextCACertificate() *x509.Certificate
+
+	// PrevCACertificate returns the certificate of the CA active before the
+	// current one, or nil if it is not known, e.g. after a restart.
+	PrevCACertificate() *x509.Certificate
+
+	// SignJWTSVID signs a JWT-SVID with the current JWT signing key. The
+	// token expires after ttl, or with the key if that is sooner.
+	SignJWTSVID(ctx context.Context, spiffeID string, audience []string, ttl time.Duration) (string, error)
+
+	// CRL returns the latest CRL signed by the CA, ASN.1 DER encoded. It
+	// returns nil unless CRLs are enabled.
+	CRL() []byte
+
+	// OCSPResponders returns the delegated OCSP responders of the current
+	// CA and, until it expires, of the previous one. It returns nil unless
+	// OCSP is enabled.
+	OCSPResponders() []*OCSPResponder
+
+	// PrepareCA prepares a new CA ahead of schedule, replacing the one
+	// prepared already if any, and returns its certificate.
+	PrepareCA(ctx context.Context) (*x509.Certificate, error)
+
+	// ActivateCA activates the prepared CA ahead of schedule, provided its
+	// certificate has the given serial number, in decimal, and returns it.
+	ActivateCA(ctx context.Context, serialNumber string) (*x509.Certificate, error)
+
+	// TaintCA returns the CA certificate with the given serial number, in
+	// decimal, once checked that it is neither active nor prepared, and so
+	// can be tainted. The SVIDs it signed are left to the caller to rotate.
+	TaintCA(ctx context.Context, serialNumber string) (*x509.Certificate, error)
+}
+
+// JWTKey is a key used to sign JWT-SVIDs. The key is held by the CA plugin,
+// and its public key is published in the trust bundle under its key ID until
+// it expires.
+type JWTKey struct {
+	Kid      string
+	NotAfter time.Time
+}
+
+// OCSPResponder is a delegated OCSP responder of a CA. Its certificate,
+// signed by the CA, authorizes it to sign OCSP responses about the
+// certificates the CA issued.
+type OCSPResponder struct {
+	Issuer *x509.Certificate
+	Cert   *x509.Certificate
+	Signer crypto.Signer
 }
 
 type manager struct {
 	c   *Config
 	mtx *sync.RWMutex
 
+	// Serializes the CA rotation, scheduled or forced
+	rotateMtx sync.Mutex
+
 	caCert     *x509.Certificate
 	nextCACert *x509.Certificate
+	prevCACert *x509.Certificate
+
+	jwtKey     *JWTKey
+	nextJWTKey *JWTKey
+
+	// The serial numbers the CRL lists are kept, in order, to tell when it
+	// has to be signed again
+	crl           []byte
+	crlSerials    string
+	crlNextUpdate time.Time
+
+	ocspResponder     *OCSPResponder
+	prevOCSPResponder *OCSPResponder
+
+	// Certificates of the upstream CA known to have signed CA certificates
+	upstreamCerts []*x509.Certificate
+
+	// Level of the last expiry warning logged about each certificate, by
+	// serial number, so that each warning is only logged once
+	expiryWarnings map[string]logrus.Level
+
+	// Records the CA slots on disk if a journal path is configured
+	journal *journal
 }
 
 func (m *manager) Initialize(ctx context.Context) error {
+	if m.c.JournalPath != "" {
+		journal, err := loadJournal(m.c.JournalPath)
+		if err != nil {
+			return fmt.Errorf("load ca journal: %v", err)
+		}
+		m.journal = journal
+	} else if m.c.UpstreamBundle {
+		m.c.Log.Warn("The old CA is not known after a restart with upstream_bundle enabled, and so cannot be tainted, unless ca_journal_path is set")
+	}
+
 	caCert, err := m.loadCertificate(ctx)
 	if err != nil {
 		return fmt.Errorf("load ca certificate: %v", err)
@@ -47,22 +156,70 @@ func (m *manager) Initialize(ctx context.Context) error {
 		}
 	} else {
 		m.caCert = caCert
+		if err := m.restoreSlots(ctx); err != nil {
+			return err
+		}
+		if err := m.loadUpstreamCertificates(ctx); err != nil {
+			return fmt.Errorf("load upstream ca certificates: %v", err)
+		}
 		if err := m.caRotate(ctx); err != nil {
 			return err
 		}
 	}
 
+	// The JWT signing keys are held by the CA plugin, so the keys in use
+	// before a restart are kept, and rotated on schedule
+	if err := m.loadJWTKeys(ctx); err != nil {
+		return fmt.Errorf("load jwt signing keys: %v", err)
+	}
+	if m.jwtKey == nil {
+		if m.nextJWTKey == nil {
+			if err := m.prepareNextJWTKey(ctx); err != nil {
+				return fmt.Errorf("create jwt signing key: %v", err)
+			}
+		}
+		if err := m.activateNextJWTKey(ctx); err != nil {
+			return err
+		}
+	} else if err := m.jwtKeyRotate(ctx); err != nil {
+		return err
+	}
+
+	if m.c.CRLEnabled {
+		if err := m.updateCRL(ctx); err != nil {
+			return err
+		}
+	}
+
+	if m.c.OCSPEnabled && m.ocspResponderIssuer() != m.caCert {
+		if err := m.prepareOCSPResponder(ctx); err != nil {
+			return err
+		}
+	}
+
+	m.emitExpiryMetrics()
 	return nil
 }
 
 func (m *manager) Run(ctx context.Context) error {
-	err := util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
 			return m.startCARotator(ctx, 1*time.Minute)
 		},
+		func(ctx context.Context) error {
+			return m.startJWTKeyRotator(ctx, 1*time.Minute)
+		},
 		func(ctx context.Context) error {
 			return m.startPruner(ctx, 6*time.Hour)
+		},
+	}
+	if m.c.CRLEnabled {
+		tasks = append(tasks, func(ctx context.Context) error {
+			return m.startCRLUpdater(ctx, 1*time.Minute)
 		})
+	}
+
+	err := util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
@@ -92,6 +249,9 @@ func (m *manager) startCARotator(ctx context.Context, interval time.Duration) er
 //
 // TODO: This could probably be simplified with something like a FSM
 func (m *manager) caRotate(ctx context.Context) error {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
 	if m.caCert == nil {
 		return errors.New("ca manager not initialized; no ca cert present")
 	}
@@ -99,22 +259,146 @@ func (m *manager) caRotate(ctx context.Context) error {
 	// Prepare a new CA once the current one is 1/2 of the way to expiration
 	ttl := time.Until(m.caCert.NotAfter)
 	lifetime := m.caCert.NotAfter.Sub(m.caCert.NotBefore)
+	prepared := false
 	if (ttl < lifetime/2) && m.nextCACert == nil {
 		if err := m.prepareNextCA(ctx); err != nil {
 			return err
 		}
+		prepared = true
 	}
 
-	// Activate the new CA once the current one is 5/6ths of the way to expiration
-	if ttl < lifetime/6 {
+	// Activate the new CA once the current one is 5/6ths of the way to expiration.
+	// A CA prepared late is left in the bundle until the next call, so agents
+	// learn about it first, unless the current one has expired already.
+	if ttl < lifetime/6 && (!prepared || ttl <= 0) {
 		if err := m.activateNextCA(ctx); err != nil {
+			// The CA plugin may have lost the key of the next CA, so a new
+			// one is prepared on the next call
+			m.mtx.Lock()
+			m.nextCACert = nil
+			m.mtx.Unlock()
+			return err
+		}
+	}
+
+	// The responder of a CA just activated is prepared straight away, and
+	// again on the next call if that fails
+	if m.c.OCSPEnabled && m.ocspResponderIssuer() != m.caCert {
+		if err := m.prepareOCSPResponder(ctx); err != nil {
 			return err
 		}
 	}
 
+	m.emitExpiryMetrics()
+	m.warnExpiry()
 	return nil
 }
 
+// emitExpiryMetrics reports when the current, the next and the upstream CA
+// certificates expire, as unix timestamps, and how long until they do, in
+// seconds. The next one is reported as zero until it is prepared, and the
+// upstream one until it is known.
+func (m *manager) emitExpiryMetrics() {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	emit := func(name string, cert *x509.Certificate) {
+		var expiry, ttl float32
+		if cert != nil {
+			expiry = float32(cert.NotAfter.Unix())
+			ttl = float32(time.Until(cert.NotAfter).Seconds())
+		}
+		m.c.Tel.SetGauge([]string{"ca_manager", name + "_expiry"}, expiry)
+		m.c.Tel.SetGauge([]string{"ca_manager", name + "_ttl"}, ttl)
+	}
+	emit("ca", m.caCert)
+	emit("next_ca", m.nextCACert)
+	emit("upstream_ca", m.upstreamCert())
+}
+
+// warnExpiry logs a warning once the current or the upstream CA certificate
+// is within a sixth of its lifetime of expiring, and an error once within a
+// twelfth. The current CA is rotated before then, so this means rotation is
+// failing. The upstream CA certificate has to be renewed out of band.
+func (m *manager) warnExpiry() {
+	m.mtx.RLock()
+	caCert, upstreamCert := m.caCert, m.upstreamCert()
+	m.mtx.RUnlock()
+
+	if caCert != nil {
+		m.warnCertExpiry(caCert, "CA certificate %v expires in %v and has not been rotated yet")
+	}
+	if upstreamCert != nil {
+		m.warnCertExpiry(upstreamCert, "Upstream CA certificate %v expires in %v; it must be renewed before the CA can no longer be rotated")
+	}
+}
+
+func (m *manager) warnCertExpiry(cert *x509.Certificate, format string) {
+	ttl := time.Until(cert.NotAfter)
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+
+	var level logrus.Level
+	switch {
+	case ttl < lifetime/12:
+		level = logrus.ErrorLevel
+	case ttl < lifetime/6:
+		level = logrus.WarnLevel
+	default:
+		return
+	}
+
+	// Lower levels are more severe
+	serialNumber := cert.SerialNumber.String()
+	if last, ok := m.expiryWarnings[serialNumber]; ok && last <= level {
+		return
+	}
+	m.expiryWarnings[serialNumber] = level
+
+	ttl = ttl.Round(time.Second)
+	if level == logrus.ErrorLevel {
+		m.c.Log.Errorf(format, cert.SerialNumber, ttl)
+	} else {
+		m.c.Log.Warnf(format, cert.SerialNumber, ttl)
+	}
+}
+
+// upstreamCert returns the upstream CA certificate which signed the current
+// CA certificate, or nil if it is not known.
+func (m *manager) upstreamCert() *x509.Certificate {
+	if m.caCert == nil {
+		return nil
+	}
+	for _, cert := range m.upstreamCerts {
+		if m.caCert.CheckSignatureFrom(cert) == nil {
+			return cert
+		}
+	}
+	return nil
+}
+
+// addUpstreamCerts adds the certificates of an upstream bundle to those
+// known, dropping those which have expired.
+func (m *manager) addUpstreamCerts(certs []*x509.Certificate) {
+	now := time.Now()
+	var upstreamCerts []*x509.Certificate
+	for _, cert := range append(m.upstreamCerts, certs...) {
+		if now.After(cert.NotAfter) || containsCert(upstreamCerts, cert) {
+			continue
+		}
+		upstreamCerts = append(upstreamCerts, cert)
+	}
+	m.upstreamCerts = upstreamCerts
+}
+
+func containsCert(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *manager) loadCertificate(ctx context.Context) (*x509.Certificate, error) {
 	serverCA := m.c.Catalog.CAs()[0]
 
@@ -135,6 +419,129 @@ func (m *manager) loadCertificate(ctx context.Context) (*x509.Certificate, error
 	return caCert, nil
 }
 
+// loadUpstreamCertificates restores the upstream CA certificates from the
+// trust bundle. They are only there with the upstream bundle enabled, and
+// are otherwise known once the next CA is prepared.
+func (m *manager) loadUpstreamCertificates(ctx context.Context) error {
+	if !m.c.UpstreamBundle {
+		return nil
+	}
+
+	ds := m.c.Catalog.DataStores()[0]
+	bundle, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: m.c.TrustDomain.String()})
+	if err != nil {
+		return fmt.Errorf("fetch bundle: %v", err)
+	}
+	certs, err := x509.ParseCertificates(bundle.CaCerts)
+	if err != nil {
+		return fmt.Errorf("parse bundle from datastore: %v", err)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.addUpstreamCerts(certs)
+	return nil
+}
+
+// restoreSlots restores the next and previous CA certificates from the
+// journal, if kept. Otherwise, or if the journal is new, the next CA
+// certificate is looked up in the trust bundle.
+func (m *manager) restoreSlots(ctx context.Context) error {
+	if m.journal == nil || m.journal.activeCert() == nil {
+		if err := m.loadNextCertificate(ctx); err != nil {
+			return fmt.Errorf("load next ca certificate: %v", err)
+		}
+		if m.journal != nil {
+			m.journal.reset(m.caCert, m.nextCACert)
+			if err := m.journal.save(); err != nil {
+				return fmt.Errorf("save ca journal: %v", err)
+			}
+		}
+		return nil
+	}
+
+	journal := m.journal
+	switch {
+	case journal.activeCert().Equal(m.caCert):
+	case journal.preparedCert() != nil && journal.preparedCert().Equal(m.caCert):
+		// The server stopped after the ServerCA plugin loaded the prepared
+		// CA, but before its activation was recorded
+		m.c.Log.Infof("Recovered the activation of CA certificate %v", m.caCert.SerialNumber)
+		journal.setActivated(m.caCert)
+		if err := journal.save(); err != nil {
+			return fmt.Errorf("save ca journal: %v", err)
+		}
+	default:
+		m.c.Log.Warnf("CA certificate %v is not the one recorded as active in the journal; forgetting the prepared and old CAs", m.caCert.SerialNumber)
+		journal.reset(m.caCert, nil)
+		if err := journal.save(); err != nil {
+			return fmt.Errorf("save ca journal: %v", err)
+		}
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.nextCACert = journal.preparedCert()
+	m.prevCACert = journal.oldCert()
+	if m.nextCACert != nil {
+		m.c.Log.Debugf("Found next CA certificate %v in the journal", m.nextCACert.SerialNumber)
+	}
+	return nil
+}
+
+// saveJournal writes the journal to disk, if kept. By the time it is called
+// the slot change has already happened in the ServerCA plugin and the trust
+// bundle, so callers complete it in memory before returning the error. The
+// journal is then out of date until the next successful save.
+func (m *manager) saveJournal() error {
+	if m.journal == nil {
+		return nil
+	}
+	if err := m.journal.save(); err != nil {
+		return fmt.Errorf("save ca journal: %v", err)
+	}
+	return nil
+}
+
+// loadNextCertificate restores the next CA certificate, prepared before the
+// server restarted, from the trust bundle. It is the certificate expiring the
+// latest after the current one.
+func (m *manager) loadNextCertificate(ctx context.Context) error {
+	if m.c.UpstreamBundle {
+		// The CA certificates are not in the trust bundle, and there is no
+		// need for them to be published ahead of time either
+		return nil
+	}
+
+	ds := m.c.Catalog.DataStores()[0]
+	bundle, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: m.c.TrustDomain.String()})
+	if err != nil {
+		return fmt.Errorf("fetch bundle: %v", err)
+	}
+	certs, err := x509.ParseCertificates(bundle.CaCerts)
+	if err != nil {
+		return fmt.Errorf("parse bundle from datastore: %v", err)
+	}
+
+	var nextCACert *x509.Certificate
+	for _, c := range certs {
+		if !c.NotAfter.After(m.caCert.NotAfter) || !c.NotBefore.After(m.caCert.NotBefore) {
+			continue
+		}
+		if nextCACert == nil || c.NotAfter.After(nextCACert.NotAfter) {
+			nextCACert = c
+		}
+	}
+	if nextCACert != nil {
+		m.c.Log.Debugf("Found next CA certificate %v in the bundle", nextCACert.SerialNumber)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.nextCACert = nextCACert
+	return nil
+}
+
 func (m *manager) prepareNextCA(ctx context.Context) error {
 	m.c.Log.Debug("Creating a new CA certificate")
 
@@ -162,10 +569,22 @@ func (m *manager) prepareNextCA(ctx context.Context) error {
 		return fmt.Errorf("store new ca cert: %v", err)
 	}
 
+	// The upstream bundle is only needed to report when it expires
+	upstreamCerts, err := x509.ParseCertificates(signRes.UpstreamTrustBundle)
+	if err != nil {
+		m.c.Log.Warnf("Could not parse the upstream bundle: %v", err)
+	}
+
+	if m.journal != nil {
+		m.journal.setPrepared(cert)
+	}
+	journalErr := m.saveJournal()
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 	m.nextCACert = cert
-	return nil
+	m.addUpstreamCerts(upstreamCerts)
+	return journalErr
 }
 
 func (m *manager) activateNextCA(ctx context.Context) error {
@@ -174,8 +593,27 @@ func (m *manager) activateNextCA(ctx context.Context) error {
 	}
 
 	m.c.Log.Debug("Activating new CA certificate")
-	serverCA := m.c.Catalog.CAs()[0]
 
+	// The notifiers can hold the activation back, e.g. until the new CA is
+	// known to the systems they keep in sync
+	event := &notifier.CARotated{CaCert: m.nextCACert.Raw}
+	if m.caCert != nil {
+		event.PrevCaCert = m.caCert.Raw
+	}
+	if len(m.c.Catalog.Notifiers()) > 0 {
+		// The bundle is only needed by the notifiers
+		ds := m.c.Catalog.DataStores()[0]
+		bundle, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: m.c.TrustDomain.String()})
+		if err != nil {
+			return fmt.Errorf("fetch bundle: %v", err)
+		}
+		event.Bundle = catalog.NotifierBundle(bundle)
+	}
+	if err := catalog.NotifyAndAdvise(ctx, m.c.Catalog, &notifier.NotifyAndAdviseRequest{CaRotated: event}); err != nil {
+		return err
+	}
+
+	serverCA := m.c.Catalog.CAs()[0]
 	loadReq := &ca.LoadCertificateRequest{
 		SignedIntermediateCert: m.nextCACert.Raw,
 	}
@@ -184,10 +622,493 @@ func (m *manager) activateNextCA(ctx context.Context) error {
 		return fmt.Errorf("load new ca cert: %v", err)
 	}
 
+	if m.journal != nil {
+		m.journal.setActivated(m.nextCACert)
+	}
+	journalErr := m.saveJournal()
+
 	m.mtx.Lock()
-	defer m.mtx.Unlock()
+	m.prevCACert = m.caCert
 	m.caCert = m.nextCACert
 	m.nextCACert = nil
+	// The CRL has to be signed again by the new CA certificate
+	m.crlNextUpdate = time.Time{}
+	// The SVIDs signed by the previous CA are valid until it expires, so
+	// its responder is kept until then
+	if m.ocspResponder != nil {
+		m.prevOCSPResponder = m.ocspResponder
+		m.ocspResponder = nil
+	}
+	m.mtx.Unlock()
+
+	catalog.Notify(ctx, m.c.Catalog, m.c.Log, &notifier.NotifyRequest{CaRotated: event})
+	return journalErr
+}
+
+// PrepareCA prepares a new CA, regardless of how long the current one has
+// left. It is published in the trust bundle straight away, but should only
+// be activated once the bundle has reached the agents and federated trust
+// domains.
+func (m *manager) PrepareCA(ctx context.Context) (*x509.Certificate, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	if err := m.prepareNextCA(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mtx.RLock()
+	nextCACert := m.nextCACert
+	m.mtx.RUnlock()
+
+	m.c.Log.Infof("Prepared CA certificate %v on demand", nextCACert.SerialNumber)
+	m.emitExpiryMetrics()
+	return nextCACert, nil
+}
+
+// ActivateCA activates the prepared CA, regardless of how long the current
+// one has left. The serial number guards against activating a CA prepared
+// since the caller last looked. The current CA becomes the previous one,
+// which can then be tainted.
+func (m *manager) ActivateCA(ctx context.Context, serialNumber string) (*x509.Certificate, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	m.mtx.RLock()
+	nextCACert := m.nextCACert
+	m.mtx.RUnlock()
+	if nextCACert != nil && nextCACert.SerialNumber.String() != serialNumber {
+		return nil, fmt.Errorf("CA certificate %s is not the prepared one", serialNumber)
+	}
+
+	if err := m.activateNextCA(ctx); err != nil {
+		return nil, err
+	}
+
+	m.mtx.RLock()
+	caCert := m.caCert
+	m.mtx.RUnlock()
+
+	m.c.Log.Infof("Activated CA certificate %v on demand", caCert.SerialNumber)
+	if m.c.OCSPEnabled {
+		if err := m.prepareOCSPResponder(ctx); err != nil {
+			// It is prepared again by the next scheduled rotation
+			m.c.Log.Errorf("Could not prepare the OCSP responder of the new CA: %v", err)
+		}
+	}
+	m.emitExpiryMetrics()
+	return caCert, nil
+}
+
+// TaintCA looks up the CA certificate with the given serial number among the
+// previous CA and those in the trust bundle, and publishes it as tainted in
+// the trust bundle. Agents rotate their SVID if it was signed by a tainted
+// CA, and are only issued SVIDs for their workloads once they have. The
+// tainted CA stays in the trust bundle until it expires.
+func (m *manager) TaintCA(ctx context.Context, serialNumber string) (*x509.Certificate, error) {
+	m.rotateMtx.Lock()
+	defer m.rotateMtx.Unlock()
+
+	m.mtx.RLock()
+	caCert, nextCACert, prevCACert := m.caCert, m.nextCACert, m.prevCACert
+	m.mtx.RUnlock()
+
+	if caCert != nil && caCert.SerialNumber.String() == serialNumber {
+		return nil, errors.New("the active CA cannot be tainted; a new CA has to be activated first")
+	}
+	if nextCACert != nil && nextCACert.SerialNumber.String() == serialNumber {
+		return nil, errors.New("the prepared CA cannot be tainted; a new CA has to be prepared instead")
+	}
+
+	var certs []*x509.Certificate
+	if prevCACert != nil {
+		certs = append(certs, prevCACert)
+	}
+	if !m.c.UpstreamBundle {
+		// Otherwise the trust bundle holds the upstream CA certificates
+		ds := m.c.Catalog.DataStores()[0]
+		bundle, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: m.c.TrustDomain.String()})
+		if err != nil {
+			return nil, fmt.Errorf("fetch bundle: %v", err)
+		}
+		bundleCerts, err := x509.ParseCertificates(bundle.CaCerts)
+		if err != nil {
+			return nil, fmt.Errorf("parse bundle from datastore: %v", err)
+		}
+		certs = append(certs, bundleCerts...)
+	}
+
+	var taintedCert *x509.Certificate
+	for _, cert := range certs {
+		if cert.SerialNumber.String() == serialNumber {
+			taintedCert = cert
+			break
+		}
+	}
+	if taintedCert == nil {
+		if m.c.UpstreamBundle && m.journal == nil {
+			return nil, fmt.Errorf("no CA certificate found with serial number %s; with upstream_bundle enabled, only the old CA can be tainted, and it is only known after a restart if ca_journal_path is set", serialNumber)
+		}
+		return nil, fmt.Errorf("no CA certificate found with serial number %s", serialNumber)
+	}
+
+	_, err := m.appendBundle(ctx, &datastore.Bundle{
+		TrustDomain:    m.c.TrustDomain.String(),
+		TaintedCaCerts: taintedCert.Raw,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("taint ca certificate in bundle: %v", err)
+	}
+
+	m.c.Log.Warnf("CA certificate %v has been tainted", taintedCert.SerialNumber)
+	return taintedCert, nil
+}
+
+// SignJWTSVID signs a JWT-SVID for the given SPIFFE ID and audience. If ttl is
+// not positive, the default JWT-SVID TTL is used.
+func (m *manager) SignJWTSVID(ctx context.Context, spiffeID string, audience []string, ttl time.Duration) (string, error) {
+	m.mtx.RLock()
+	jwtKey := m.jwtKey
+	m.mtx.RUnlock()
+
+	if jwtKey == nil {
+		return "", errors.New("ca manager not initialized; no jwt signing key present")
+	}
+
+	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAnyInTrustDomain(m.c.TrustDomain.Host)); err != nil {
+		return "", err
+	}
+
+	if ttl <= 0 {
+		ttl = jwtsvid.DefaultTTL
+	}
+
+	// The CA plugin signs with the active key, and does not let the
+	// JWT-SVID outlive it
+	resp, err := m.c.Catalog.CAs()[0].SignJwtSvid(ctx, &ca.SignJwtSvidRequest{
+		SpiffeId: spiffeID,
+		Audience: audience,
+		Ttl:      int32(ttl / time.Second),
+		Issuer:   m.c.JWTIssuer,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.SignedJwtSvid, nil
+}
+
+func (m *manager) startJWTKeyRotator(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := m.jwtKeyRotate(ctx)
+			if err != nil {
+				m.c.Log.Errorf("Problem encountered while tending to JWT signing key rotation: %v", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// jwtKeyRotate rotates the JWT signing key on the same schedule as the CA:
+// the next key is prepared and published once the current one is 1/2 of the
+// way to expiration, and activated once it is 5/6ths of the way. A key
+// prepared late is left in the bundle until the next call, so validators
+// learn about it first, unless the current one has expired already.
+func (m *manager) jwtKeyRotate(ctx context.Context) error {
+	m.mtx.RLock()
+	jwtKey, nextJWTKey := m.jwtKey, m.nextJWTKey
+	m.mtx.RUnlock()
+
+	if jwtKey == nil {
+		return errors.New("ca manager not initialized; no jwt signing key present")
+	}
+
+	ttl := time.Until(jwtKey.NotAfter)
+	lifetime := m.c.JWTKeyTTL
+	prepared := false
+	if ttl < lifetime/2 && nextJWTKey == nil {
+		if err := m.prepareNextJWTKey(ctx); err != nil {
+			return err
+		}
+		prepared = true
+	}
+
+	if ttl < lifetime/6 && (!prepared || ttl <= 0) {
+		if err := m.activateNextJWTKey(ctx); err != nil {
+			// The CA plugin may have lost the prepared key, so a new one is
+			// prepared on the next call
+			m.mtx.Lock()
+			m.nextJWTKey = nil
+			m.mtx.Unlock()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadJWTKeys restores the active and the prepared JWT signing keys the CA
+// plugin holds, if any.
+func (m *manager) loadJWTKeys(ctx context.Context) error {
+	resp, err := m.c.Catalog.CAs()[0].FetchJwtKeys(ctx, &ca.FetchJwtKeysRequest{})
+	if err != nil {
+		return err
+	}
+
+	var jwtKey, nextJWTKey *JWTKey
+	if resp.Active != nil {
+		jwtKey = jwtKeyFromProto(resp.Active)
+	}
+	if resp.Prepared != nil {
+		// The server may have stopped before the prepared key was published
+		if err := m.publishJWTKey(ctx, resp.Prepared); err != nil {
+			return err
+		}
+		nextJWTKey = jwtKeyFromProto(resp.Prepared)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.jwtKey = jwtKey
+	m.nextJWTKey = nextJWTKey
+	return nil
+}
+
+// prepareNextJWTKey has the CA plugin generate a new JWT signing key, and
+// publishes its public key in the trust bundle.
+func (m *manager) prepareNextJWTKey(ctx context.Context) error {
+	m.c.Log.Debug("Creating a new JWT signing key")
+
+	resp, err := m.c.Catalog.CAs()[0].GenerateJwtKey(ctx, &ca.GenerateJwtKeyRequest{
+		NotAfter: time.Now().Add(m.c.JWTKeyTTL).Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("generate jwt signing key: %v", err)
+	}
+	if err := m.publishJWTKey(ctx, resp.JwtKey); err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.nextJWTKey = jwtKeyFromProto(resp.JwtKey)
+	return nil
+}
+
+// publishJWTKey adds the public key of a JWT signing key to the trust bundle
+func (m *manager) publishJWTKey(ctx context.Context, jwtKey *ca.JwtKey) error {
+	_, err := m.appendBundle(ctx, &datastore.Bundle{
+		TrustDomain: m.c.TrustDomain.String(),
+		JwtSigningKeys: []*common.PublicKey{{
+			PkixBytes: jwtKey.PublicKey,
+			Kid:       jwtKey.Kid,
+			NotAfter:  jwtKey.NotAfter,
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("store new jwt signing key: %v", err)
+	}
+	return nil
+}
+
+func (m *manager) activateNextJWTKey(ctx context.Context) error {
+	m.mtx.RLock()
+	nextJWTKey := m.nextJWTKey
+	m.mtx.RUnlock()
+
+	if nextJWTKey == nil {
+		return errors.New("next jwt signing key not prepared")
+	}
+
+	m.c.Log.Debugf("Activating new JWT signing key %q", nextJWTKey.Kid)
+	if _, err := m.c.Catalog.CAs()[0].ActivateJwtKey(ctx, &ca.ActivateJwtKeyRequest{
+		Kid: nextJWTKey.Kid,
+	}); err != nil {
+		return fmt.Errorf("activate jwt signing key: %v", err)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.jwtKey = nextJWTKey
+	m.nextJWTKey = nil
+	return nil
+}
+
+func jwtKeyFromProto(jwtKey *ca.JwtKey) *JWTKey {
+	return &JWTKey{
+		Kid:      jwtKey.Kid,
+		NotAfter: time.Unix(jwtKey.NotAfter, 0),
+	}
+}
+
+// CACertificate returns the certificate of the active CA.
+func (m *manager) CACertificate() *x509.Certificate {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.caCert
+}
+
+// NextCACertificate returns the certificate of the prepared CA.
+func (m *manager) NextCACertificate() *x509.Certificate {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.nextCACert
+}
+
+// PrevCACertificate returns the certificate of the previous CA.
+func (m *manager) PrevCACertificate() *x509.Certificate {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.prevCACert
+}
+
+// CRL returns the latest CRL signed by the CA, or nil if CRLs are not enabled.
+func (m *manager) CRL() []byte {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.crl
+}
+
+func (m *manager) startCRLUpdater(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.updateCRL(ctx); err != nil {
+				m.c.Log.Errorf("Could not update the CRL: %v", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// updateCRL signs a new CRL listing the revoked SVIDs that have not expired
+// yet, if they changed since the last one was signed or if the last one is
+// halfway to its next update.
+func (m *manager) updateCRL(ctx context.Context) error {
+	ds := m.c.Catalog.DataStores()[0]
+	resp, err := ds.ListRevokedSVIDs(ctx, &common.Empty{})
+	if err != nil {
+		return fmt.Errorf("list revoked svids: %v", err)
+	}
+
+	now := time.Now()
+	var revoked []*ca.RevokedCertificate
+	var serials []string
+	for _, svid := range resp.Svids {
+		// Expired SVIDs are rejected anyway, so there is no need to list them
+		if svid.Expiry <= now.Unix() {
+			continue
+		}
+		revoked = append(revoked, &ca.RevokedCertificate{
+			SerialNumber: svid.SerialNumber,
+			RevokedAt:    svid.RevokedAt,
+		})
+		serials = append(serials, svid.SerialNumber)
+	}
+	sort.Strings(serials)
+	crlSerials := strings.Join(serials, ",")
+
+	m.mtx.RLock()
+	upToDate := m.crl != nil && m.crlSerials == crlSerials && now.Before(m.crlNextUpdate.Add(-crlTTL/2))
+	m.mtx.RUnlock()
+	if upToDate {
+		return nil
+	}
+
+	nextUpdate := now.Add(crlTTL)
+	serverCA := m.c.Catalog.CAs()[0]
+	signResp, err := serverCA.SignCrl(ctx, &ca.SignCrlRequest{
+		RevokedCertificates: revoked,
+		NextUpdate:          nextUpdate.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("sign crl: %v", err)
+	}
+
+	m.c.Log.Debugf("Signed a new CRL listing %d revoked SVIDs", len(revoked))
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.crl = signResp.SignedCrl
+	m.crlSerials = crlSerials
+	m.crlNextUpdate = nextUpdate
+	return nil
+}
+
+// OCSPResponders returns the delegated OCSP responders of the current CA
+// and of the previous one, leaving out those whose CA has expired.
+func (m *manager) OCSPResponders() []*OCSPResponder {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	now := time.Now()
+	var responders []*OCSPResponder
+	for _, r := range []*OCSPResponder{m.ocspResponder, m.prevOCSPResponder} {
+		if r != nil && now.Before(r.Issuer.NotAfter) {
+			responders = append(responders, r)
+		}
+	}
+	return responders
+}
+
+func (m *manager) ocspResponderIssuer() *x509.Certificate {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if m.ocspResponder == nil {
+		return nil
+	}
+	return m.ocspResponder.Issuer
+}
+
+// prepareOCSPResponder creates a key for the delegated OCSP responder of the
+// current CA, and gets its certificate signed by the CA. The certificate can
+// not be renewed once the CA is rotated, so it is valid as long as the CA.
+func (m *manager) prepareOCSPResponder(ctx context.Context) error {
+	m.mtx.RLock()
+	caCert := m.caCert
+	m.mtx.RUnlock()
+
+	m.c.Log.Debug("Creating a new OCSP responder certificate")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generate key: %v", err)
+	}
+	pkixBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return fmt.Errorf("marshal public key: %v", err)
+	}
+
+	serverCA := m.c.Catalog.CAs()[0]
+	resp, err := serverCA.SignOcspResponderCert(ctx, &ca.SignOcspResponderCertRequest{
+		PublicKey: pkixBytes,
+		Ttl:       int32(time.Until(caCert.NotAfter) / time.Second),
+	})
+	if err != nil {
+		return fmt.Errorf("sign ocsp responder certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(resp.SignedCertificate)
+	if err != nil {
+		return fmt.Errorf("invalid ocsp responder certificate: %v", err)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.ocspResponder = &OCSPResponder{
+		Issuer: caCert,
+		Cert:   cert,
+		Signer: key,
+	}
 	return nil
 }
 
@@ -210,6 +1131,15 @@ func (m *manager) startPruner(ctx context.Context, interval time.Duration) error
 func (m *manager) prune(ctx context.Context) error {
 	ds := m.c.Catalog.DataStores()[0]
 
+	if m.c.CRLEnabled {
+		// Issued SVIDs are only needed until they expire, since expired ones
+		// are not listed in the CRL
+		_, err := ds.PruneIssuedSVIDs(ctx, &datastore.IssuedSVID{Expiry: time.Now().Unix()})
+		if err != nil {
+			return fmt.Errorf("prune issued svids: %v", err)
+		}
+	}
+
 	oldBundle := &datastore.Bundle{TrustDomain: m.c.TrustDomain.String()}
 	oldBundle, err := ds.FetchBundle(ctx, oldBundle)
 	if err != nil {
@@ -221,12 +1151,23 @@ func (m *manager) prune(ctx context.Context) error {
 		CaCerts:     []byte{},
 	}
 
+	var reload bool
+	for _, k := range oldBundle.JwtSigningKeys {
+		// JWT-SVIDs cannot outlive their key, so expired keys are not
+		// needed to validate any of them
+		if time.Unix(k.NotAfter, 0).After(time.Now()) {
+			newBundle.JwtSigningKeys = append(newBundle.JwtSigningKeys, k)
+		} else {
+			reload = true
+			m.c.Log.Infof("Pruning JWT signing key %q with expiry date %v", k.Kid, time.Unix(k.NotAfter, 0))
+		}
+	}
+
 	certs, err := x509.ParseCertificates(oldBundle.CaCerts)
 	if err != nil {
 		return fmt.Errorf("parse bundle from datastore: %v", err)
 	}
 
-	var reload bool
 	for _, c := range certs {
 		// Be gentle while removing CA certificates
 		// If expired < 24hrs ago, keep it.
@@ -239,38 +1180,151 @@ func (m *manager) prune(ctx context.Context) error {
 		}
 	}
 
+	taintedCerts, err := x509.ParseCertificates(oldBundle.TaintedCaCerts)
+	if err != nil {
+		return fmt.Errorf("parse tainted ca certificates from datastore: %v", err)
+	}
+
+	for _, c := range taintedCerts {
+		// The SVIDs signed by a tainted CA cannot outlive it
+		if c.NotAfter.After(time.Now()) {
+			newBundle.TaintedCaCerts = append(newBundle.TaintedCaCerts, c.Raw...)
+		} else {
+			reload = true
+			m.c.Log.Infof("Pruning tainted CA certificate number %v with expiry date %v", c.SerialNumber, c.NotAfter)
+		}
+	}
+
 	if len(newBundle.CaCerts) == 0 {
 		m.c.Log.Warn("All known CA certificates have expired! Pruning has been halted.")
 		return errors.New("would prune all certificates")
 	}
 
 	if reload {
-		_, err = ds.UpdateBundle(ctx, newBundle)
+		// The notifiers are advised first, like for any other change to the
+		// bundle, although there is little point in vetoing pruning
+		next := *newBundle
+		next.SequenceNumber = oldBundle.SequenceNumber + 1
+		event := &notifier.BundleUpdated{Bundle: catalog.NotifierBundle(&next)}
+		if err := catalog.NotifyAndAdvise(ctx, m.c.Catalog, &notifier.NotifyAndAdviseRequest{BundleUpdated: event}); err != nil {
+			return err
+		}
+		newBundle, err = ds.UpdateBundle(ctx, newBundle)
 		if err != nil {
 			return fmt.Errorf("write new bundle: %v", err)
 		}
+		catalog.NotifyBundleUpdated(ctx, m.c.Catalog, m.c.Log, newBundle)
 	}
 
 	return nil
 }
 
+// storeCACert adds the CA certificate to the trust bundle or, if the CA is
+// strictly an intermediate, the upstream CA certificates it chains up to.
 func (m *manager) storeCACert(ctx context.Context, caCert *x509.Certificate, upstreamBundle []byte) error {
-	m.mtx.RLock()
 	storeReq := &datastore.Bundle{
 		TrustDomain: m.c.TrustDomain.String(),
 		CaCerts:     caCert.Raw,
 	}
-	m.mtx.RUnlock()
 
 	if m.c.UpstreamBundle {
-		storeReq.CaCerts = append(storeReq.CaCerts, upstreamBundle...)
+		if len(upstreamBundle) == 0 {
+			return errors.New("upstream ca returned no bundle")
+		}
+		storeReq.CaCerts = upstreamBundle
 	}
 
+	_, err := m.appendBundle(ctx, storeReq)
+	return err
+}
+
+// appendBundle appends the CA certificates and JWT signing keys to the trust
+// bundle. The notifier plugins are advised of the bundle as it is about to
+// be stored, and nothing is stored if one of them fails, so that they are
+// never left out of a CA or JWT signing key being published. They are
+// notified of the stored bundle afterwards.
+func (m *manager) appendBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
 	ds := m.c.Catalog.DataStores()[0]
-	_, err := ds.AppendBundle(ctx, storeReq)
+	if len(m.c.Catalog.Notifiers()) > 0 {
+		resp, err := ds.ListBundles(ctx, &common.Empty{})
+		if err != nil {
+			return nil, fmt.Errorf("list bundles: %v", err)
+		}
+		var current *datastore.Bundle
+		for _, b := range resp.Bundles {
+			if b.TrustDomain == req.TrustDomain {
+				current = b
+			}
+		}
+		next, err := appendedBundle(current, req)
+		if err != nil {
+			return nil, err
+		}
+		event := &notifier.BundleUpdated{Bundle: catalog.NotifierBundle(next)}
+		if err := catalog.NotifyAndAdvise(ctx, m.c.Catalog, &notifier.NotifyAndAdviseRequest{BundleUpdated: event}); err != nil {
+			return nil, err
+		}
+	}
+
+	bundle, err := ds.AppendBundle(ctx, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	catalog.NotifyBundleUpdated(ctx, m.c.Catalog, m.c.Log, bundle)
+	return bundle, nil
+}
 
-	return nil
+// appendedBundle returns the bundle as the datastore stores it once the CA
+// certificates and JWT signing keys are appended to it, for the notifier
+// plugins to be advised of. The current bundle is nil if not stored yet.
+func appendedBundle(current, req *datastore.Bundle) (*datastore.Bundle, error) {
+	if current == nil {
+		bundle := *req
+		bundle.SequenceNumber = 1
+		return &bundle, nil
+	}
+
+	bundle := *current
+	changed := false
+
+	certs, err := x509.ParseCertificates(current.CaCerts)
+	if err != nil {
+		return nil, fmt.Errorf("parse bundle from datastore: %v", err)
+	}
+	newCerts, err := x509.ParseCertificates(req.CaCerts)
+	if err != nil {
+		return nil, fmt.Errorf("parse ca certificates: %v", err)
+	}
+	for _, newCert := range newCerts {
+		if !containsCert(certs, newCert) {
+			bundle.CaCerts = append(append([]byte{}, bundle.CaCerts...), newCert.Raw...)
+			certs = append(certs, newCert)
+			changed = true
+		}
+	}
+
+	for _, newKey := range req.JwtSigningKeys {
+		found := false
+		for _, k := range bundle.JwtSigningKeys {
+			if k.Kid == newKey.Kid {
+				found = true
+				break
+			}
+		}
+		if !found {
+			bundle.JwtSigningKeys = append(append([]*common.PublicKey{}, bundle.JwtSigningKeys...), newKey)
+			changed = true
+		}
+	}
+
+	// Tainted CAs are not told apart in the notified bundle, but still
+	// change it
+	if len(req.TaintedCaCerts) > 0 {
+		changed = true
+	}
+
+	if changed {
+		bundle.SequenceNumber++
+	}
+	return &bundle, nil
 }
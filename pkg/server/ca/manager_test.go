@@ -2,19 +2,32 @@ package ca
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
+	"io/ioutil"
+	"math/big"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/ca"
 	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/notifier"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
 	"github.com/spiffe/spire/test/mock/proto/server/ca"
 	"github.com/spiffe/spire/test/mock/proto/server/datastore"
+	"github.com/spiffe/spire/test/mock/proto/server/notifier"
 	"github.com/spiffe/spire/test/mock/proto/server/upstreamca"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/require"
@@ -64,12 +77,24 @@ func (m *ManagerTestSuite) SetupTest() {
 
 func (m *ManagerTestSuite) TearDownTest() {
 	m.mockCtrl.Finish()
+	if m.m.c.JournalPath != "" {
+		os.RemoveAll(filepath.Dir(m.m.c.JournalPath))
+	}
 }
 
 func TestManager(t *testing.T) {
 	suite.Run(t, new(ManagerTestSuite))
 }
 
+type fakeGaugeSink struct {
+	telemetry.Blackhole
+	gauges map[string]float32
+}
+
+func (s *fakeGaugeSink) SetGauge(key []string, val float32) {
+	s.gauges[strings.Join(key, ".")] = val
+}
+
 func (m *ManagerTestSuite) TestInitializeWithPristineCA() {
 	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
 	m.Require().NoError(err)
@@ -81,9 +106,11 @@ func (m *ManagerTestSuite) TestInitializeWithPristineCA() {
 	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{}, nil)
 	m.ca.EXPECT().GenerateCsr(gomock.Any(), gomock.Any()).Return(new(ca.GenerateCsrResponse), nil)
 	m.upsCa.EXPECT().SubmitCSR(gomock.Any(), gomock.Any()).Return(&upstreamca.SubmitCSRResponse{Cert: cert.Raw}, nil)
-	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any()).Times(2)
 	m.ca.EXPECT().LoadCertificate(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
 	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().NotNil(m.m.jwtKey)
 }
 
 func (m *ManagerTestSuite) TestInitializeWithLoadedCA() {
@@ -97,7 +124,130 @@ func (m *ManagerTestSuite) TestInitializeWithLoadedCA() {
 	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
 		StoredIntermediateCert: cert.Raw,
 	}, nil)
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		CaCerts: cert.Raw,
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
 	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().Nil(m.m.nextCACert)
+}
+
+func (m *ManagerTestSuite) TestInitializeWithNextCA() {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	template.NotBefore = time.Now().Add(-2 * time.Hour)
+	template.NotAfter = time.Now().Add(1 * time.Hour)
+	cert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(2 * time.Hour)
+	nextCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+
+	// since the next CA certificate prepared before the restart is in the
+	// bundle, the manager should not prepare another one.
+	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: cert.Raw,
+	}, nil)
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		CaCerts: append(append([]byte{}, nextCert.Raw...), cert.Raw...),
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().Equal(cert, m.m.caCert)
+	m.Require().Equal(nextCert, m.m.nextCACert)
+}
+
+func (m *ManagerTestSuite) TestInitializeWithJournal() {
+	oldCert, caCert, nextCert := m.createJournalCerts()
+	m.m.c.JournalPath = m.writeJournal(journalState{
+		Active:   newJournalEntry(caCert),
+		Prepared: newJournalEntry(nextCert),
+		Old:      newJournalEntry(oldCert),
+	})
+
+	// the slots are restored from the journal rather than the bundle
+	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: caCert.Raw,
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().Equal(caCert, m.m.caCert)
+	m.Require().Equal(nextCert, m.m.nextCACert)
+	m.Require().Equal(oldCert, m.m.prevCACert)
+}
+
+func (m *ManagerTestSuite) TestInitializeWithInterruptedActivation() {
+	oldCert, caCert, _ := m.createJournalCerts()
+	m.m.c.JournalPath = m.writeJournal(journalState{
+		Active:   newJournalEntry(oldCert),
+		Prepared: newJournalEntry(caCert),
+	})
+
+	// the server stopped after the prepared CA was loaded, but before its
+	// activation was recorded
+	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: caCert.Raw,
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().Equal(caCert, m.m.caCert)
+	m.Require().Equal(oldCert, m.m.prevCACert)
+
+	journal, err := loadJournal(m.m.c.JournalPath)
+	m.Require().NoError(err)
+	m.Require().Equal(caCert, journal.activeCert())
+	m.Require().Equal(oldCert, journal.oldCert())
+}
+
+func (m *ManagerTestSuite) TestInitializeWithUnknownCA() {
+	oldCert, caCert, nextCert := m.createJournalCerts()
+	m.m.c.JournalPath = m.writeJournal(journalState{
+		Active:   newJournalEntry(oldCert),
+		Prepared: newJournalEntry(nextCert),
+	})
+
+	// the journal starts over from the CA the plugin holds
+	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: caCert.Raw,
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().Nil(m.m.nextCACert)
+	m.Require().Nil(m.m.prevCACert)
+
+	journal, err := loadJournal(m.m.c.JournalPath)
+	m.Require().NoError(err)
+	m.Require().Equal(caCert, journal.activeCert())
+	m.Require().Nil(journal.preparedCert())
+}
+
+func (m *ManagerTestSuite) TestInitializeWithNewJournal() {
+	_, caCert, nextCert := m.createJournalCerts()
+	dir, err := ioutil.TempDir("", "ca-manager-journal-")
+	m.Require().NoError(err)
+	m.m.c.JournalPath = filepath.Join(dir, "journal.json")
+
+	// the next CA is looked up in the bundle, then recorded
+	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: caCert.Raw,
+	}, nil)
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		CaCerts: append(append([]byte{}, nextCert.Raw...), caCert.Raw...),
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.expectNewJWTKey()
+	m.Require().NoError(m.m.Initialize(ctx))
+
+	journal, err := loadJournal(m.m.c.JournalPath)
+	m.Require().NoError(err)
+	m.Require().Equal(caCert, journal.activeCert())
+	m.Require().Equal(nextCert, journal.preparedCert())
 }
 
 func (m *ManagerTestSuite) TestCARotate() {
@@ -138,7 +288,27 @@ func (m *ManagerTestSuite) TestCARotate() {
 	m.Assert().Equal(cert1, m.m.caCert)
 	m.Assert().Nil(m.m.nextCACert)
 
-	// If the ttl has expired, or has almost expired, but the new CA hasn't
+	// If the ttl has almost expired but the new CA hasn't been prepared yet,
+	// it is only activated on the next call, once published in the bundle
+	template.NotBefore = time.Now().Add(-2 * time.Hour)
+	template.NotAfter = time.Now().Add(1 * time.Minute)
+	cert5, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	m.m.caCert = cert5
+	m.ca.EXPECT().GenerateCsr(gomock.Any(), gomock.Any()).Return(new(ca.GenerateCsrResponse), nil)
+	m.upsCa.EXPECT().SubmitCSR(gomock.Any(), gomock.Any()).Return(resp, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.Assert().NoError(m.m.caRotate(ctx))
+	m.Assert().Equal(cert5, m.m.caCert)
+	m.Assert().Equal(cert1, m.m.nextCACert)
+
+	// If the CA plugin fails to activate the next CA, a new one is prepared
+	m.ca.EXPECT().LoadCertificate(gomock.Any(), gomock.Any()).Return(nil, errors.New("no private key"))
+	m.Assert().EqualError(m.m.caRotate(ctx), "load new ca cert: no private key")
+	m.Assert().Equal(cert5, m.m.caCert)
+	m.Assert().Nil(m.m.nextCACert)
+
+	// If the ttl has expired, but the new CA hasn't
 	// been prepared yet due to the rotation interval, make sure the CA is both
 	// prepared and activated on the same rotation call (issue #501)
 	template.NotBefore = time.Now().Add(-3 * time.Hour)
@@ -189,6 +359,325 @@ func (m *ManagerTestSuite) TestActivateNextCA() {
 	m.Assert().Nil(m.m.nextCACert)
 }
 
+func (m *ManagerTestSuite) TestActivateNextCAJournalError() {
+	cert, _, err := util.LoadSVIDFixture()
+	m.Require().NoError(err)
+	m.m.nextCACert = cert
+	m.m.journal = &journal{path: filepath.Join("/nonexistent", "journal.json")}
+
+	req := &ca.LoadCertificateRequest{SignedIntermediateCert: cert.Raw}
+	m.ca.EXPECT().LoadCertificate(gomock.Any(), req)
+
+	// The CA is activated all the same, since the plugin already uses it
+	m.Assert().EqualError(m.m.activateNextCA(ctx), "save ca journal: unable to write journal: open /nonexistent/journal.json.tmp: no such file or directory")
+	m.Assert().Equal(cert, m.m.caCert)
+	m.Assert().Nil(m.m.nextCACert)
+}
+
+func (m *ManagerTestSuite) TestPrepareAndActivateCA() {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(1)
+	cert1, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(2)
+	cert2, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	m.m.caCert = cert1
+
+	// Nothing to activate until a CA is prepared
+	_, err = m.m.ActivateCA(ctx, cert2.SerialNumber.String())
+	m.Require().EqualError(err, "next ca cert not prepared")
+
+	// The CA is prepared even though the current one is new
+	m.ca.EXPECT().GenerateCsr(gomock.Any(), gomock.Any()).Return(new(ca.GenerateCsrResponse), nil)
+	m.upsCa.EXPECT().SubmitCSR(gomock.Any(), gomock.Any()).Return(&upstreamca.SubmitCSRResponse{Cert: cert2.Raw}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	prepared, err := m.m.PrepareCA(ctx)
+	m.Require().NoError(err)
+	m.Require().Equal(cert2, prepared)
+	m.Require().Equal(cert2, m.m.nextCACert)
+
+	// Only the prepared CA can be activated
+	_, err = m.m.ActivateCA(ctx, cert1.SerialNumber.String())
+	m.Require().EqualError(err, "CA certificate 1 is not the prepared one")
+
+	m.ca.EXPECT().LoadCertificate(gomock.Any(), &ca.LoadCertificateRequest{SignedIntermediateCert: cert2.Raw})
+	activated, err := m.m.ActivateCA(ctx, cert2.SerialNumber.String())
+	m.Require().NoError(err)
+	m.Require().Equal(cert2, activated)
+	m.Require().Equal(cert2, m.m.caCert)
+	m.Require().Equal(cert1, m.m.prevCACert)
+	m.Require().Nil(m.m.nextCACert)
+}
+
+func (m *ManagerTestSuite) TestNotifiers() {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(1)
+	cert1, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(2)
+	cert2, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	m.m.caCert = cert1
+
+	n := mock_notifier.NewMockNotifier(m.mockCtrl)
+	m.m.c.Catalog.(*fakeservercatalog.Catalog).SetNotifiers(n)
+
+	// The notifiers are told about the bundle holding the prepared CA before
+	// it is stored. It is neither stored nor prepared if they fail to be.
+	m.ds.EXPECT().ListBundles(gomock.Any(), gomock.Any()).Return(&datastore.Bundles{
+		Bundles: []*datastore.Bundle{{
+			TrustDomain:    m.m.c.TrustDomain.String(),
+			CaCerts:        cert1.Raw,
+			SequenceNumber: 1,
+		}},
+	}, nil).Times(2)
+	bundle := &datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		CaCerts:        append(cert1.Raw, cert2.Raw...),
+		SequenceNumber: 2,
+	}
+	notifierBundle := &notifier.Bundle{
+		TrustDomain:    bundle.TrustDomain,
+		CaCerts:        bundle.CaCerts,
+		SequenceNumber: 2,
+	}
+	bundleUpdated := &notifier.BundleUpdated{Bundle: notifierBundle}
+	m.ca.EXPECT().GenerateCsr(gomock.Any(), gomock.Any()).Return(new(ca.GenerateCsrResponse), nil).Times(2)
+	m.upsCa.EXPECT().SubmitCSR(gomock.Any(), gomock.Any()).Return(&upstreamca.SubmitCSRResponse{Cert: cert2.Raw}, nil).Times(2)
+	n.EXPECT().NotifyAndAdvise(gomock.Any(), &notifier.NotifyAndAdviseRequest{BundleUpdated: bundleUpdated}).
+		Return(nil, errors.New("unreachable"))
+	_, err = m.m.PrepareCA(ctx)
+	m.Require().EqualError(err, "store new ca cert: notifier plugin fake_notifier_1: unreachable")
+	m.Require().Nil(m.m.nextCACert)
+
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any()).Return(bundle, nil)
+	n.EXPECT().NotifyAndAdvise(gomock.Any(), &notifier.NotifyAndAdviseRequest{BundleUpdated: bundleUpdated}).
+		Return(&notifier.NotifyAndAdviseResponse{}, nil)
+	n.EXPECT().Notify(gomock.Any(), &notifier.NotifyRequest{BundleUpdated: bundleUpdated}).
+		Return(&notifier.NotifyResponse{}, nil)
+	_, err = m.m.PrepareCA(ctx)
+	m.Require().NoError(err)
+	m.Require().Equal(cert2, m.m.nextCACert)
+
+	// The CA is not activated unless the notifiers are told about it first
+	caRotated := &notifier.CARotated{CaCert: cert2.Raw, PrevCaCert: cert1.Raw, Bundle: notifierBundle}
+	m.ds.EXPECT().FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: bundle.TrustDomain}).Return(bundle, nil).Times(2)
+	n.EXPECT().NotifyAndAdvise(gomock.Any(), &notifier.NotifyAndAdviseRequest{CaRotated: caRotated}).
+		Return(nil, errors.New("unreachable"))
+	_, err = m.m.ActivateCA(ctx, cert2.SerialNumber.String())
+	m.Require().EqualError(err, "notifier plugin fake_notifier_1: unreachable")
+	m.Require().Equal(cert1, m.m.caCert)
+
+	// Failing to be told once the CA is activated does not matter anymore
+	n.EXPECT().NotifyAndAdvise(gomock.Any(), &notifier.NotifyAndAdviseRequest{CaRotated: caRotated}).
+		Return(&notifier.NotifyAndAdviseResponse{}, nil)
+	m.ca.EXPECT().LoadCertificate(gomock.Any(), &ca.LoadCertificateRequest{SignedIntermediateCert: cert2.Raw})
+	n.EXPECT().Notify(gomock.Any(), &notifier.NotifyRequest{CaRotated: caRotated}).
+		Return(nil, errors.New("unreachable"))
+	_, err = m.m.ActivateCA(ctx, cert2.SerialNumber.String())
+	m.Require().NoError(err)
+	m.Require().Equal(cert2, m.m.caCert)
+}
+
+func (m *ManagerTestSuite) TestTaintCA() {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(1)
+	prevCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(2)
+	oldCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(3)
+	caCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.SerialNumber = big.NewInt(4)
+	nextCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	m.m.prevCACert = prevCert
+	m.m.caCert = caCert
+	m.m.nextCACert = nextCert
+
+	_, err = m.m.TaintCA(ctx, "3")
+	m.Require().EqualError(err, "the active CA cannot be tainted; a new CA has to be activated first")
+	_, err = m.m.TaintCA(ctx, "4")
+	m.Require().EqualError(err, "the prepared CA cannot be tainted; a new CA has to be prepared instead")
+
+	// The previous CA is known without looking it up in the bundle. The
+	// tainted CA is published as such in the bundle.
+	bundle := &datastore.Bundle{CaCerts: append(append([]byte{}, oldCert.Raw...), caCert.Raw...)}
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(bundle, nil).Times(4)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), &datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		TaintedCaCerts: prevCert.Raw,
+	}).Return(bundle, nil)
+	tainted, err := m.m.TaintCA(ctx, "1")
+	m.Require().NoError(err)
+	m.Require().Equal(prevCert, tainted)
+
+	// Older CAs are looked up in the bundle
+	m.ds.EXPECT().AppendBundle(gomock.Any(), &datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		TaintedCaCerts: oldCert.Raw,
+	}).Return(bundle, nil)
+	tainted, err = m.m.TaintCA(ctx, "2")
+	m.Require().NoError(err)
+	m.Require().Equal(oldCert, tainted)
+
+	// The CA is not reported as tainted unless it is published as such
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	_, err = m.m.TaintCA(ctx, "2")
+	m.Require().EqualError(err, "taint ca certificate in bundle: oh no")
+
+	_, err = m.m.TaintCA(ctx, "5")
+	m.Require().EqualError(err, "no CA certificate found with serial number 5")
+
+	// With upstream_bundle enabled, the bundle holds the upstream CAs, so
+	// only the previous CA can be tainted
+	m.m.c.UpstreamBundle = true
+	_, err = m.m.TaintCA(ctx, "2")
+	m.Require().EqualError(err, "no CA certificate found with serial number 2; with upstream_bundle enabled, only the old CA can be tainted, and it is only known after a restart if ca_journal_path is set")
+}
+
+func (m *ManagerTestSuite) TestInitializeWithJWTKeys() {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	cert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	m.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: cert.Raw,
+	}, nil)
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		CaCerts: cert.Raw,
+	}, nil)
+
+	// the keys held by the CA plugin are kept, and the prepared one is
+	// published again in case the server stopped before it was
+	key1 := newJwtKey("key1", m.m.c.JWTKeyTTL/3)
+	key2 := newJwtKey("key2", m.m.c.JWTKeyTTL)
+	m.ca.EXPECT().FetchJwtKeys(gomock.Any(), gomock.Any()).Return(&ca.FetchJwtKeysResponse{
+		Active:   key1,
+		Prepared: key2,
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *datastore.Bundle) {
+		m.Require().Len(req.JwtSigningKeys, 1)
+		m.Assert().Equal("key2", req.JwtSigningKeys[0].Kid)
+	})
+	m.Require().NoError(m.m.Initialize(ctx))
+	m.Require().Equal(jwtKeyFromProto(key1), m.m.jwtKey)
+	m.Require().Equal(jwtKeyFromProto(key2), m.m.nextJWTKey)
+}
+
+func (m *ManagerTestSuite) TestJWTKeyRotate() {
+	// Should return error when uninitialized
+	m.Assert().Error(m.m.jwtKeyRotate(ctx))
+
+	// Should do nothing when called with a new-ish key
+	key1 := &JWTKey{Kid: "key1", NotAfter: time.Now().Add(m.m.c.JWTKeyTTL)}
+	m.m.jwtKey = key1
+	m.Assert().NoError(m.m.jwtKeyRotate(ctx))
+	m.Assert().Equal(key1, m.m.jwtKey)
+	m.Assert().Nil(m.m.nextJWTKey)
+
+	// Should prepare and publish the next key when past 50% of its lifetime
+	key1.NotAfter = time.Now().Add(m.m.c.JWTKeyTTL / 3)
+	m.ca.EXPECT().GenerateJwtKey(gomock.Any(), gomock.Any()).Return(&ca.GenerateJwtKeyResponse{
+		JwtKey: newJwtKey("key2", m.m.c.JWTKeyTTL),
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *datastore.Bundle) {
+		m.Require().Len(req.JwtSigningKeys, 1)
+		m.Assert().Equal("key2", req.JwtSigningKeys[0].Kid)
+		m.Assert().NotEmpty(req.JwtSigningKeys[0].PkixBytes)
+	})
+	m.Assert().NoError(m.m.jwtKeyRotate(ctx))
+	m.Assert().Equal(key1, m.m.jwtKey)
+	key2 := m.m.nextJWTKey
+	m.Require().NotNil(key2)
+
+	// Should activate the next key when almost expired
+	key1.NotAfter = time.Now().Add(time.Minute)
+	m.ca.EXPECT().ActivateJwtKey(gomock.Any(), &ca.ActivateJwtKeyRequest{Kid: "key2"}).Return(&ca.ActivateJwtKeyResponse{}, nil)
+	m.Assert().NoError(m.m.jwtKeyRotate(ctx))
+	m.Assert().Equal(key2, m.m.jwtKey)
+	m.Assert().Nil(m.m.nextJWTKey)
+
+	// If the key has expired before the next one was prepared, the next one
+	// is both prepared and activated
+	key2.NotAfter = time.Now().Add(-time.Minute)
+	m.ca.EXPECT().GenerateJwtKey(gomock.Any(), gomock.Any()).Return(&ca.GenerateJwtKeyResponse{
+		JwtKey: newJwtKey("key3", m.m.c.JWTKeyTTL),
+	}, nil)
+	m.ds.EXPECT().AppendBundle(gomock.Any(), gomock.Any())
+	m.ca.EXPECT().ActivateJwtKey(gomock.Any(), &ca.ActivateJwtKeyRequest{Kid: "key3"}).Return(&ca.ActivateJwtKeyResponse{}, nil)
+	m.Assert().NoError(m.m.jwtKeyRotate(ctx))
+	m.Assert().Equal("key3", m.m.jwtKey.Kid)
+	m.Assert().Nil(m.m.nextJWTKey)
+
+	// If the CA plugin fails to activate the next key, another one is
+	// prepared on the next call
+	m.m.jwtKey.NotAfter = time.Now().Add(time.Minute)
+	m.m.nextJWTKey = &JWTKey{Kid: "key4", NotAfter: time.Now().Add(m.m.c.JWTKeyTTL)}
+	m.ca.EXPECT().ActivateJwtKey(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	m.Assert().EqualError(m.m.jwtKeyRotate(ctx), "activate jwt signing key: oh no")
+	m.Assert().Equal("key3", m.m.jwtKey.Kid)
+	m.Assert().Nil(m.m.nextJWTKey)
+}
+
+func (m *ManagerTestSuite) TestSignJWTSVID() {
+	// Should return error when uninitialized
+	_, err := m.m.SignJWTSVID(ctx, "spiffe://example.org/foo", []string{"audience"}, 0)
+	m.Assert().Error(err)
+
+	m.m.jwtKey = &JWTKey{Kid: "key1", NotAfter: time.Now().Add(m.m.c.JWTKeyTTL)}
+
+	// The CA plugin signs the token with its active key
+	m.ca.EXPECT().SignJwtSvid(gomock.Any(), &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://example.org/foo",
+		Audience: []string{"audience"},
+		Ttl:      3600,
+	}).Return(&ca.SignJwtSvidResponse{SignedJwtSvid: "token"}, nil)
+	token, err := m.m.SignJWTSVID(ctx, "spiffe://example.org/foo", []string{"audience"}, time.Hour)
+	m.Require().NoError(err)
+	m.Assert().Equal("token", token)
+
+	// The default TTL is used unless given, and the issuer claim is set
+	// when configured
+	m.m.c.JWTIssuer = "https://oidc.example.org"
+	m.ca.EXPECT().SignJwtSvid(gomock.Any(), &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://example.org/foo",
+		Audience: []string{"audience"},
+		Ttl:      int32(jwtsvid.DefaultTTL / time.Second),
+		Issuer:   "https://oidc.example.org",
+	}).Return(&ca.SignJwtSvidResponse{SignedJwtSvid: "token"}, nil)
+	_, err = m.m.SignJWTSVID(ctx, "spiffe://example.org/foo", []string{"audience"}, 0)
+	m.Require().NoError(err)
+
+	// The SPIFFE ID must be in the trust domain
+	_, err = m.m.SignJWTSVID(ctx, "spiffe://otherdomain.test/foo", []string{"audience"}, 0)
+	m.Assert().Error(err)
+}
+
+// expectNewJWTKey expects the first JWT signing key to be created and
+// activated, the CA plugin holding none
+func (m *ManagerTestSuite) expectNewJWTKey() {
+	m.ca.EXPECT().FetchJwtKeys(gomock.Any(), gomock.Any()).Return(&ca.FetchJwtKeysResponse{}, nil)
+	m.ca.EXPECT().GenerateJwtKey(gomock.Any(), gomock.Any()).Return(&ca.GenerateJwtKeyResponse{
+		JwtKey: newJwtKey("key1", m.m.c.JWTKeyTTL),
+	}, nil)
+	m.ca.EXPECT().ActivateJwtKey(gomock.Any(), &ca.ActivateJwtKeyRequest{Kid: "key1"}).Return(&ca.ActivateJwtKeyResponse{}, nil)
+}
+
+func newJwtKey(kid string, ttl time.Duration) *ca.JwtKey {
+	return &ca.JwtKey{
+		Kid:       kid,
+		PublicKey: []byte(kid),
+		NotAfter:  time.Now().Add(ttl).Unix(),
+	}
+}
+
 func (m *ManagerTestSuite) TestPrune() {
 	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
 	template.NotAfter = time.Now()
@@ -232,6 +721,161 @@ func (m *ManagerTestSuite) TestPrune() {
 	m.ds.EXPECT().UpdateBundle(gomock.Any(), gomock.Any()).Times(0)
 	err = m.m.prune(ctx)
 	m.Assert().Error(err)
+
+	// Expired JWT signing keys are pruned as well
+	key1 := &common.PublicKey{Kid: "key1", NotAfter: time.Now().Add(time.Hour).Unix()}
+	key2 := &common.PublicKey{Kid: "key2", NotAfter: time.Now().Add(-time.Hour).Unix()}
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		CaCerts:        ca1.Raw,
+		JwtSigningKeys: []*common.PublicKey{key1, key2},
+	}, nil)
+	m.ds.EXPECT().UpdateBundle(gomock.Any(), &datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		CaCerts:        ca1.Raw,
+		JwtSigningKeys: []*common.PublicKey{key1},
+	})
+	err = m.m.prune(ctx)
+	m.Assert().NoError(err)
+
+	// Tainted CA certificates are kept until they expire
+	template.NotAfter = time.Now().Add(time.Hour)
+	ca3, _, err := util.SelfSign(template)
+	require.NoError(m.T(), err)
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		CaCerts:        ca1.Raw,
+		TaintedCaCerts: append(append([]byte{}, ca2.Raw...), ca3.Raw...),
+	}, nil)
+	m.ds.EXPECT().UpdateBundle(gomock.Any(), &datastore.Bundle{
+		TrustDomain:    m.m.c.TrustDomain.String(),
+		CaCerts:        ca1.Raw,
+		TaintedCaCerts: ca3.Raw,
+	})
+	err = m.m.prune(ctx)
+	m.Assert().NoError(err)
+}
+
+func (m *ManagerTestSuite) TestUpdateCRL() {
+	m.m.c.CRLEnabled = true
+	now := time.Now()
+
+	revokedSVIDs := &datastore.IssuedSVIDs{
+		Svids: []*datastore.IssuedSVID{
+			{SerialNumber: "1", Expiry: now.Add(time.Hour).Unix(), RevokedAt: now.Unix()},
+			{SerialNumber: "2", Expiry: now.Add(-time.Hour).Unix(), RevokedAt: now.Unix()},
+		},
+	}
+	m.ds.EXPECT().ListRevokedSVIDs(gomock.Any(), gomock.Any()).Return(revokedSVIDs, nil)
+	m.ca.EXPECT().SignCrl(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *ca.SignCrlRequest) {
+		// the expired SVID is not listed
+		m.Require().Equal([]*ca.RevokedCertificate{
+			{SerialNumber: "1", RevokedAt: now.Unix()},
+		}, req.RevokedCertificates)
+		m.Require().InDelta(now.Add(crlTTL).Unix(), req.NextUpdate, 5)
+	}).Return(&ca.SignCrlResponse{SignedCrl: []byte("crl1")}, nil)
+	m.Require().NoError(m.m.updateCRL(ctx))
+	m.Require().Equal([]byte("crl1"), m.m.CRL())
+
+	// The CRL is not signed again if the revoked SVIDs did not change
+	m.ds.EXPECT().ListRevokedSVIDs(gomock.Any(), gomock.Any()).Return(revokedSVIDs, nil)
+	m.Require().NoError(m.m.updateCRL(ctx))
+	m.Require().Equal([]byte("crl1"), m.m.CRL())
+
+	// ...unless it is halfway to its next update
+	m.m.crlNextUpdate = now.Add(crlTTL / 3)
+	m.ds.EXPECT().ListRevokedSVIDs(gomock.Any(), gomock.Any()).Return(revokedSVIDs, nil)
+	m.ca.EXPECT().SignCrl(gomock.Any(), gomock.Any()).Return(&ca.SignCrlResponse{SignedCrl: []byte("crl2")}, nil)
+	m.Require().NoError(m.m.updateCRL(ctx))
+	m.Require().Equal([]byte("crl2"), m.m.CRL())
+
+	// A newly revoked SVID gets listed right away
+	revokedSVIDs.Svids = append(revokedSVIDs.Svids, &datastore.IssuedSVID{
+		SerialNumber: "3", Expiry: now.Add(time.Hour).Unix(), RevokedAt: now.Unix(),
+	})
+	m.ds.EXPECT().ListRevokedSVIDs(gomock.Any(), gomock.Any()).Return(revokedSVIDs, nil)
+	m.ca.EXPECT().SignCrl(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *ca.SignCrlRequest) {
+		m.Require().Len(req.RevokedCertificates, 2)
+	}).Return(&ca.SignCrlResponse{SignedCrl: []byte("crl3")}, nil)
+	m.Require().NoError(m.m.updateCRL(ctx))
+	m.Require().Equal([]byte("crl3"), m.m.CRL())
+
+	// The CRL is kept if it cannot be signed again
+	m.m.crlNextUpdate = time.Time{}
+	m.ds.EXPECT().ListRevokedSVIDs(gomock.Any(), gomock.Any()).Return(revokedSVIDs, nil)
+	m.ca.EXPECT().SignCrl(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	m.Require().EqualError(m.m.updateCRL(ctx), "sign crl: oh no")
+	m.Require().Equal([]byte("crl3"), m.m.CRL())
+}
+
+func (m *ManagerTestSuite) TestPruneIssuedSVIDs() {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	template.NotAfter = time.Now().Add(time.Hour)
+	caCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+
+	m.m.c.CRLEnabled = true
+	m.ds.EXPECT().PruneIssuedSVIDs(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *datastore.IssuedSVID) {
+		m.Require().InDelta(time.Now().Unix(), req.Expiry, 5)
+	}).Return(&common.Empty{}, nil)
+	m.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		TrustDomain: m.m.c.TrustDomain.String(),
+		CaCerts:     caCert.Raw,
+	}, nil)
+	m.Require().NoError(m.m.prune(ctx))
+}
+
+func (m *ManagerTestSuite) TestOCSPResponders() {
+	m.m.c.OCSPEnabled = true
+	responderCert, _, err := util.LoadSVIDFixture()
+	m.Require().NoError(err)
+	signResp := &ca.SignOcspResponderCertResponse{SignedCertificate: responderCert.Raw}
+
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+	template.NotBefore = time.Now().Add(-2 * time.Hour)
+	template.NotAfter = time.Now().Add(1 * time.Minute)
+	cert1, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	template.NotBefore = time.Now().Add(-time.Minute)
+	template.NotAfter = time.Now().Add(2 * time.Hour)
+	cert2, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+
+	// The responder certificate is valid as long as the CA
+	m.m.caCert = cert1
+	m.ca.EXPECT().SignOcspResponderCert(gomock.Any(), gomock.Any()).Do(func(_ context.Context, req *ca.SignOcspResponderCertRequest) {
+		m.Require().InDelta(60, req.Ttl, 5)
+	}).Return(signResp, nil)
+	m.Require().NoError(m.m.prepareOCSPResponder(ctx))
+	responders := m.m.OCSPResponders()
+	m.Require().Len(responders, 1)
+	m.Require().Equal(cert1, responders[0].Issuer)
+	m.Require().Equal(responderCert, responders[0].Cert)
+
+	// A responder is prepared for the next CA once activated, and the one
+	// of the previous CA is kept
+	m.m.nextCACert = cert2
+	m.ca.EXPECT().LoadCertificate(gomock.Any(), gomock.Any())
+	m.ca.EXPECT().SignOcspResponderCert(gomock.Any(), gomock.Any()).Return(signResp, nil)
+	m.Require().NoError(m.m.caRotate(ctx))
+	responders = m.m.OCSPResponders()
+	m.Require().Len(responders, 2)
+	m.Require().Equal(cert2, responders[0].Issuer)
+	m.Require().Equal(cert1, responders[1].Issuer)
+
+	// The responder of an expired CA is left out
+	template.NotAfter = time.Now().Add(-time.Minute)
+	expiredCert, _, err := util.SelfSign(template)
+	m.Require().NoError(err)
+	m.m.prevOCSPResponder.Issuer = expiredCert
+	responders = m.m.OCSPResponders()
+	m.Require().Len(responders, 1)
+	m.Require().Equal(cert2, responders[0].Issuer)
+
+	// Nothing is prepared again while the current CA has a responder
+	m.Require().NoError(m.m.caRotate(ctx))
 }
 
 func (m *ManagerTestSuite) TestPruner() {
@@ -254,6 +898,69 @@ func (m *ManagerTestSuite) TestPruner() {
 	}
 }
 
+func (m *ManagerTestSuite) TestExpiryMetrics() {
+	sink := &fakeGaugeSink{gauges: make(map[string]float32)}
+	m.m.c.Tel = sink
+
+	upstreamTemplate, err := util.NewCATemplate("example.org")
+	m.Require().NoError(err)
+	upstreamTemplate.NotAfter = time.Now().Add(30 * 24 * time.Hour)
+	upstream, upstreamKey, err := util.SelfSign(upstreamTemplate)
+	m.Require().NoError(err)
+	caTemplate, err := util.NewCATemplate("example.org")
+	m.Require().NoError(err)
+	caTemplate.NotAfter = time.Now().Add(time.Hour)
+	caCert, _, err := util.Sign(caTemplate, upstream, upstreamKey)
+	m.Require().NoError(err)
+
+	// The upstream CA certificate is unknown until the CA is prepared
+	m.m.caCert = caCert
+	m.m.emitExpiryMetrics()
+	m.Require().Equal(float32(caCert.NotAfter.Unix()), sink.gauges["ca_manager.ca_expiry"])
+	m.Require().InDelta(time.Hour.Seconds(), sink.gauges["ca_manager.ca_ttl"], 5)
+	m.Require().Zero(sink.gauges["ca_manager.next_ca_ttl"])
+	m.Require().Zero(sink.gauges["ca_manager.upstream_ca_ttl"])
+
+	m.m.addUpstreamCerts([]*x509.Certificate{upstream})
+	m.m.emitExpiryMetrics()
+	m.Require().Equal(float32(upstream.NotAfter.Unix()), sink.gauges["ca_manager.upstream_ca_expiry"])
+	m.Require().InDelta((30 * 24 * time.Hour).Seconds(), sink.gauges["ca_manager.upstream_ca_ttl"], 5)
+}
+
+func (m *ManagerTestSuite) TestExpiryWarnings() {
+	logger, hook := test.NewNullLogger()
+	m.m.c.Log = logger
+
+	cert := func(ttl time.Duration) *x509.Certificate {
+		now := time.Now()
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			NotBefore:    now.Add(ttl - time.Hour),
+			NotAfter:     now.Add(ttl),
+		}
+	}
+
+	// Nothing is logged until the CA is within a sixth of its lifetime of
+	// expiring
+	m.m.caCert = cert(20 * time.Minute)
+	m.m.warnExpiry()
+	m.Require().Empty(hook.AllEntries())
+
+	// Each warning is logged once, escalating to an error
+	m.m.caCert = cert(8 * time.Minute)
+	m.m.warnExpiry()
+	m.m.warnExpiry()
+	m.Require().Len(hook.AllEntries(), 1)
+	m.Require().Equal(logrus.WarnLevel, hook.LastEntry().Level)
+	m.Require().Equal("CA certificate 1 expires in 8m0s and has not been rotated yet", hook.LastEntry().Message)
+
+	m.m.caCert = cert(4 * time.Minute)
+	m.m.warnExpiry()
+	m.m.warnExpiry()
+	m.Require().Len(hook.AllEntries(), 2)
+	m.Require().Equal(logrus.ErrorLevel, hook.LastEntry().Level)
+}
+
 func (m *ManagerTestSuite) TestStoreCACert() {
 	cert, _, err := util.LoadSVIDFixture()
 	m.Require().NoError(err)
@@ -270,10 +977,90 @@ func (m *ManagerTestSuite) TestStoreCACert() {
 
 	m.Assert().NoError(m.m.storeCACert(ctx, cert, upstream.Raw))
 
-	// With upstream bundle enabled
+	// With upstream bundle enabled, the CA certificate is left out
 	m.m.c.UpstreamBundle = true
-	req.CaCerts = append(req.CaCerts, upstream.Raw...)
+	req = &datastore.Bundle{
+		TrustDomain: m.m.c.TrustDomain.String(),
+		CaCerts:     upstream.Raw,
+	}
 	m.ds.EXPECT().AppendBundle(gomock.Any(), req)
 
 	m.Assert().NoError(m.m.storeCACert(ctx, cert, upstream.Raw))
+
+	// The upstream CA must return its bundle
+	m.Assert().EqualError(m.m.storeCACert(ctx, cert, nil), "upstream ca returned no bundle")
+}
+
+func (m *ManagerTestSuite) TestAppendedBundle() {
+	cert1, cert2, _ := m.createJournalCerts()
+	key1 := &common.PublicKey{Kid: "KID1"}
+	key2 := &common.PublicKey{Kid: "KID2"}
+
+	// Without a stored bundle, the appended one is the first
+	bundle, err := appendedBundle(nil, &datastore.Bundle{TrustDomain: "spiffe://example.org", CaCerts: cert1.Raw})
+	m.Require().NoError(err)
+	m.Require().Equal(&datastore.Bundle{
+		TrustDomain:    "spiffe://example.org",
+		CaCerts:        cert1.Raw,
+		SequenceNumber: 1,
+	}, bundle)
+
+	// Certificates and keys already in the bundle leave it unchanged
+	current := &datastore.Bundle{
+		TrustDomain:    "spiffe://example.org",
+		CaCerts:        cert1.Raw,
+		JwtSigningKeys: []*common.PublicKey{key1},
+		SequenceNumber: 3,
+	}
+	bundle, err = appendedBundle(current, &datastore.Bundle{CaCerts: cert1.Raw, JwtSigningKeys: []*common.PublicKey{key1}})
+	m.Require().NoError(err)
+	m.Require().Equal(current, bundle)
+
+	// New ones are appended, and bump the sequence number once
+	bundle, err = appendedBundle(current, &datastore.Bundle{
+		CaCerts:        append(append([]byte{}, cert1.Raw...), cert2.Raw...),
+		JwtSigningKeys: []*common.PublicKey{key1, key2},
+	})
+	m.Require().NoError(err)
+	m.Require().Equal(&datastore.Bundle{
+		TrustDomain:    "spiffe://example.org",
+		CaCerts:        append(append([]byte{}, cert1.Raw...), cert2.Raw...),
+		JwtSigningKeys: []*common.PublicKey{key1, key2},
+		SequenceNumber: 4,
+	}, bundle)
+	m.Require().Equal(cert1.Raw, current.CaCerts)
+	m.Require().Len(current.JwtSigningKeys, 1)
+
+	// Tainting a CA changes the bundle too
+	bundle, err = appendedBundle(current, &datastore.Bundle{TaintedCaCerts: cert1.Raw})
+	m.Require().NoError(err)
+	m.Require().Equal(uint64(4), bundle.SequenceNumber)
+}
+
+// createJournalCerts returns an old, a current and a next CA certificate,
+// the current one being a third of the way through its lifetime
+func (m *ManagerTestSuite) createJournalCerts() (*x509.Certificate, *x509.Certificate, *x509.Certificate) {
+	template, err := util.NewSVIDTemplate(m.m.c.TrustDomain.String())
+	m.Require().NoError(err)
+
+	var certs []*x509.Certificate
+	for i := 0; i < 3; i++ {
+		template.SerialNumber = big.NewInt(int64(i + 1))
+		template.NotBefore = time.Now().Add(time.Duration(i-2) * time.Hour)
+		template.NotAfter = time.Now().Add(time.Duration(i+1) * time.Hour)
+		cert, _, err := util.SelfSign(template)
+		m.Require().NoError(err)
+		certs = append(certs, cert)
+	}
+	return certs[0], certs[1], certs[2]
+}
+
+// writeJournal writes a journal in a new temporary directory, returning its
+// path
+func (m *ManagerTestSuite) writeJournal(state journalState) string {
+	dir, err := ioutil.TempDir("", "ca-manager-journal-")
+	m.Require().NoError(err)
+	journal := &journal{path: filepath.Join(dir, "journal.json"), state: state}
+	m.Require().NoError(journal.save())
+	return journal.path
 }
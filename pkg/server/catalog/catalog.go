@@ -6,46 +6,61 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tracing"
 	"github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/aws"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/gcp"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/jointoken"
 	"github.com/spiffe/spire/pkg/server/plugin/nodeattestor/x509pop"
 	"github.com/spiffe/spire/pkg/server/plugin/noderesolver/noop"
+	"github.com/spiffe/spire/proto/common/metricsink"
 	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/proto/server/csrpolicy"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"github.com/spiffe/spire/proto/server/nodeattestor"
 	"github.com/spiffe/spire/proto/server/noderesolver"
+	"github.com/spiffe/spire/proto/server/notifier"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 
 	goplugin "github.com/hashicorp/go-plugin"
 	common "github.com/spiffe/spire/pkg/common/catalog"
 	ca_memory "github.com/spiffe/spire/pkg/server/plugin/ca/memory"
+	notifier_webhook "github.com/spiffe/spire/pkg/server/plugin/notifier/webhook"
 	upca_disk "github.com/spiffe/spire/pkg/server/plugin/upstreamca/disk"
 )
 
 const (
 	CAType           = "ServerCA"
+	CSRPolicyType    = "CSRPolicy"
 	DataStoreType    = "DataStore"
+	MetricSinkType   = "MetricSink"
 	NodeAttestorType = "NodeAttestor"
 	NodeResolverType = "NodeResolver"
+	NotifierType     = "Notifier"
 	UpstreamCAType   = "UpstreamCA"
 )
 
 type Catalog interface {
 	CAs() []*ManagedServerCA
+	CSRPolicies() []*ManagedCSRPolicy
 	DataStores() []*ManagedDataStore
+	MetricSinks() []*ManagedMetricSink
 	NodeAttestors() []*ManagedNodeAttestor
 	NodeResolvers() []*ManagedNodeResolver
+	Notifiers() []*ManagedNotifier
 	UpstreamCAs() []*ManagedUpstreamCA
 }
 
 var (
 	supportedPlugins = map[string]goplugin.Plugin{
 		CAType:           &ca.GRPCPlugin{},
+		CSRPolicyType:    &csrpolicy.GRPCPlugin{},
 		DataStoreType:    &datastore.GRPCPlugin{},
+		MetricSinkType:   &metricsink.GRPCPlugin{},
 		NodeAttestorType: &nodeattestor.GRPCPlugin{},
 		NodeResolverType: &noderesolver.GRPCPlugin{},
+		NotifierType:     &notifier.GRPCPlugin{},
 		UpstreamCAType:   &upstreamca.GRPCPlugin{},
 	}
 
@@ -65,6 +80,9 @@ var (
 		NodeResolverType: {
 			"noop": noderesolver.NewBuiltIn(noop.New()),
 		},
+		NotifierType: {
+			"webhook": notifier.NewBuiltIn(notifier_webhook.New()),
+		},
 		UpstreamCAType: {
 			"disk": upstreamca.NewBuiltIn(upca_disk.New()),
 		},
@@ -74,17 +92,35 @@ var (
 type Config struct {
 	PluginConfigs common.PluginConfigMap
 	Log           logrus.FieldLogger
+
+	// If set, the latency of datastore calls is reported to it
+	Tel telemetry.Sink
+
+	// If set, datastore calls are traced with it
+	Tracer tracing.Tracer
+
+	// If set, the built-in plugins only validate their configuration, and
+	// external ones are started but not configured
+	DryRun bool
+
+	// If set, external plugins are only run if their checksum is configured
+	RequirePluginChecksum bool
 }
 
 type ServerCatalog struct {
-	com common.Catalog
-	m   sync.RWMutex
-	log logrus.FieldLogger
+	com    common.Catalog
+	m      sync.RWMutex
+	log    logrus.FieldLogger
+	tel    telemetry.Sink
+	tracer tracing.Tracer
 
 	caPlugins           []*ManagedServerCA
+	csrPolicyPlugins    []*ManagedCSRPolicy
 	dataStorePlugins    []*ManagedDataStore
+	metricSinkPlugins   []*ManagedMetricSink
 	nodeAttestorPlugins []*ManagedNodeAttestor
 	nodeResolverPlugins []*ManagedNodeResolver
+	notifierPlugins     []*ManagedNotifier
 	upstreamCAPlugins   []*ManagedUpstreamCA
 }
 
@@ -94,11 +130,16 @@ func New(c *Config) *ServerCatalog {
 		SupportedPlugins: supportedPlugins,
 		BuiltinPlugins:   builtinPlugins,
 		Log:              c.Log,
+		DryRun:           c.DryRun,
+
+		RequirePluginChecksum: c.RequirePluginChecksum,
 	}
 
 	return &ServerCatalog{
-		log: c.Log,
-		com: common.New(commonConfig),
+		log:    c.Log,
+		tel:    c.Tel,
+		tracer: c.Tracer,
+		com:    common.New(commonConfig),
 	}
 }
 
@@ -143,6 +184,13 @@ func (c *ServerCatalog) CAs() []*ManagedServerCA {
 	return append([]*ManagedServerCA(nil), c.caPlugins...)
 }
 
+func (c *ServerCatalog) CSRPolicies() []*ManagedCSRPolicy {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	return append([]*ManagedCSRPolicy(nil), c.csrPolicyPlugins...)
+}
+
 func (c *ServerCatalog) DataStores() []*ManagedDataStore {
 	c.m.RLock()
 	defer c.m.RUnlock()
@@ -150,6 +198,13 @@ func (c *ServerCatalog) DataStores() []*ManagedDataStore {
 	return append([]*ManagedDataStore(nil), c.dataStorePlugins...)
 }
 
+func (c *ServerCatalog) MetricSinks() []*ManagedMetricSink {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	return append([]*ManagedMetricSink(nil), c.metricSinkPlugins...)
+}
+
 func (c *ServerCatalog) NodeAttestors() []*ManagedNodeAttestor {
 	c.m.RLock()
 	defer c.m.RUnlock()
@@ -164,6 +219,13 @@ func (c *ServerCatalog) NodeResolvers() []*ManagedNodeResolver {
 	return append([]*ManagedNodeResolver(nil), c.nodeResolverPlugins...)
 }
 
+func (c *ServerCatalog) Notifiers() []*ManagedNotifier {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	return append([]*ManagedNotifier(nil), c.notifierPlugins...)
+}
+
 func (c *ServerCatalog) UpstreamCAs() []*ManagedUpstreamCA {
 	c.m.RLock()
 	defer c.m.RUnlock()
@@ -191,12 +253,27 @@ func (c *ServerCatalog) categorize() error {
 				return fmt.Errorf("Plugin %s does not adhere to CA interface", p.Config.PluginName)
 			}
 			c.caPlugins = append(c.caPlugins, NewManagedServerCA(pl, p.Config))
+		case CSRPolicyType:
+			pl, ok := p.Plugin.(csrpolicy.CSRPolicy)
+			if !ok {
+				return fmt.Errorf("Plugin %s does not adhere to CSRPolicy interface", p.Config.PluginName)
+			}
+			c.csrPolicyPlugins = append(c.csrPolicyPlugins, NewManagedCSRPolicy(pl, p.Config))
 		case DataStoreType:
 			pl, ok := p.Plugin.(datastore.DataStore)
 			if !ok {
 				return fmt.Errorf("Plugin %s does not adhere to DataStore interface", p.Config.PluginName)
 			}
+			if c.tel != nil || c.tracer != nil {
+				pl = newInstrumentedDataStore(pl, c.tel, c.tracer)
+			}
 			c.dataStorePlugins = append(c.dataStorePlugins, NewManagedDataStore(pl, p.Config))
+		case MetricSinkType:
+			pl, ok := p.Plugin.(metricsink.MetricSink)
+			if !ok {
+				return fmt.Errorf("Plugin %s does not adhere to MetricSink interface", p.Config.PluginName)
+			}
+			c.metricSinkPlugins = append(c.metricSinkPlugins, NewManagedMetricSink(pl, p.Config))
 		case NodeAttestorType:
 			pl, ok := p.Plugin.(nodeattestor.NodeAttestor)
 			if !ok {
@@ -209,6 +286,12 @@ func (c *ServerCatalog) categorize() error {
 				return fmt.Errorf("Plugin %s does not adhere to NodeResolver interface", p.Config.PluginName)
 			}
 			c.nodeResolverPlugins = append(c.nodeResolverPlugins, NewManagedNodeResolver(pl, p.Config))
+		case NotifierType:
+			pl, ok := p.Plugin.(notifier.Notifier)
+			if !ok {
+				return fmt.Errorf("Plugin %s does not adhere to Notifier interface", p.Config.PluginName)
+			}
+			c.notifierPlugins = append(c.notifierPlugins, NewManagedNotifier(pl, p.Config))
 		case UpstreamCAType:
 			pl, ok := p.Plugin.(upstreamca.UpstreamCA)
 			if !ok {
@@ -220,7 +303,8 @@ func (c *ServerCatalog) categorize() error {
 		}
 	}
 
-	// Guarantee we have at least one of each type
+	// Guarantee we have at least one of each type. CSR policies, metric
+	// sinks and notifiers are optional and not counted.
 	pluginCount := map[string]int{}
 	pluginCount[CAType] = len(c.caPlugins)
 	pluginCount[DataStoreType] = len(c.dataStorePlugins)
@@ -238,8 +322,11 @@ func (c *ServerCatalog) categorize() error {
 
 func (c *ServerCatalog) reset() {
 	c.caPlugins = nil
+	c.csrPolicyPlugins = nil
 	c.dataStorePlugins = nil
+	c.metricSinkPlugins = nil
 	c.nodeAttestorPlugins = nil
 	c.nodeResolverPlugins = nil
+	c.notifierPlugins = nil
 	c.upstreamCAPlugins = nil
 }
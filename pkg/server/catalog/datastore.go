@@ -0,0 +1,274 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tracing"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+// instrumentedDataStore wraps a datastore plugin to measure the latency of every
+// call made to it, labeled by method, and trace it.
+type instrumentedDataStore struct {
+	datastore.DataStore
+	tel    telemetry.Sink
+	tracer tracing.Tracer
+}
+
+func newInstrumentedDataStore(ds datastore.DataStore, tel telemetry.Sink, tracer tracing.Tracer) datastore.DataStore {
+	if tel == nil {
+		tel = telemetry.Blackhole{}
+	}
+	if tracer == nil {
+		tracer = tracing.Noop{}
+	}
+	return instrumentedDataStore{
+		DataStore: ds,
+		tel:       tel,
+		tracer:    tracer,
+	}
+}
+
+// observe starts the span of a call to the datastore. The returned function
+// ends it and reports the latency of the call.
+func (ds instrumentedDataStore) observe(ctx context.Context, method string) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := ds.tracer.Start(ctx, "datastore."+method)
+	return ctx, func() {
+		span.End()
+		ds.tel.MeasureSinceWithLabels([]string{"datastore", "latency"}, start, []telemetry.Label{
+			{Name: "method", Value: method},
+		})
+	}
+}
+
+func (ds instrumentedDataStore) CreateBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
+	ctx, done := ds.observe(ctx, "CreateBundle")
+	defer done()
+	return ds.DataStore.CreateBundle(ctx, req)
+}
+
+func (ds instrumentedDataStore) UpdateBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
+	ctx, done := ds.observe(ctx, "UpdateBundle")
+	defer done()
+	return ds.DataStore.UpdateBundle(ctx, req)
+}
+
+func (ds instrumentedDataStore) AppendBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
+	ctx, done := ds.observe(ctx, "AppendBundle")
+	defer done()
+	return ds.DataStore.AppendBundle(ctx, req)
+}
+
+func (ds instrumentedDataStore) DeleteBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
+	ctx, done := ds.observe(ctx, "DeleteBundle")
+	defer done()
+	return ds.DataStore.DeleteBundle(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
+	ctx, done := ds.observe(ctx, "FetchBundle")
+	defer done()
+	return ds.DataStore.FetchBundle(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListBundles(ctx context.Context, req *common.Empty) (*datastore.Bundles, error) {
+	ctx, done := ds.observe(ctx, "ListBundles")
+	defer done()
+	return ds.DataStore.ListBundles(ctx, req)
+}
+
+func (ds instrumentedDataStore) CreateAttestedNodeEntry(ctx context.Context, req *datastore.CreateAttestedNodeEntryRequest) (*datastore.CreateAttestedNodeEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "CreateAttestedNodeEntry")
+	defer done()
+	return ds.DataStore.CreateAttestedNodeEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchAttestedNodeEntry(ctx context.Context, req *datastore.FetchAttestedNodeEntryRequest) (*datastore.FetchAttestedNodeEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "FetchAttestedNodeEntry")
+	defer done()
+	return ds.DataStore.FetchAttestedNodeEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchStaleNodeEntries(ctx context.Context, req *datastore.FetchStaleNodeEntriesRequest) (*datastore.FetchStaleNodeEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "FetchStaleNodeEntries")
+	defer done()
+	return ds.DataStore.FetchStaleNodeEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListAttestedNodeEntries(ctx context.Context, req *datastore.ListAttestedNodeEntriesRequest) (*datastore.ListAttestedNodeEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "ListAttestedNodeEntries")
+	defer done()
+	return ds.DataStore.ListAttestedNodeEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) CountAttestedNodeEntries(ctx context.Context, req *datastore.CountAttestedNodeEntriesRequest) (*datastore.CountAttestedNodeEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "CountAttestedNodeEntries")
+	defer done()
+	return ds.DataStore.CountAttestedNodeEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) UpdateAttestedNodeEntry(ctx context.Context, req *datastore.UpdateAttestedNodeEntryRequest) (*datastore.UpdateAttestedNodeEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "UpdateAttestedNodeEntry")
+	defer done()
+	return ds.DataStore.UpdateAttestedNodeEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) DeleteAttestedNodeEntry(ctx context.Context, req *datastore.DeleteAttestedNodeEntryRequest) (*datastore.DeleteAttestedNodeEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "DeleteAttestedNodeEntry")
+	defer done()
+	return ds.DataStore.DeleteAttestedNodeEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) CreateNodeResolverMapEntry(ctx context.Context, req *datastore.CreateNodeResolverMapEntryRequest) (*datastore.CreateNodeResolverMapEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "CreateNodeResolverMapEntry")
+	defer done()
+	return ds.DataStore.CreateNodeResolverMapEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchNodeResolverMapEntry(ctx context.Context, req *datastore.FetchNodeResolverMapEntryRequest) (*datastore.FetchNodeResolverMapEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "FetchNodeResolverMapEntry")
+	defer done()
+	return ds.DataStore.FetchNodeResolverMapEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) DeleteNodeResolverMapEntry(ctx context.Context, req *datastore.DeleteNodeResolverMapEntryRequest) (*datastore.DeleteNodeResolverMapEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "DeleteNodeResolverMapEntry")
+	defer done()
+	return ds.DataStore.DeleteNodeResolverMapEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) RectifyNodeResolverMapEntries(ctx context.Context, req *datastore.RectifyNodeResolverMapEntriesRequest) (*datastore.RectifyNodeResolverMapEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "RectifyNodeResolverMapEntries")
+	defer done()
+	return ds.DataStore.RectifyNodeResolverMapEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) CreateRegistrationEntry(ctx context.Context, req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "CreateRegistrationEntry")
+	defer done()
+	return ds.DataStore.CreateRegistrationEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchRegistrationEntry(ctx context.Context, req *datastore.FetchRegistrationEntryRequest) (*datastore.FetchRegistrationEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "FetchRegistrationEntry")
+	defer done()
+	return ds.DataStore.FetchRegistrationEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchRegistrationEntries(ctx context.Context, req *common.Empty) (*datastore.FetchRegistrationEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "FetchRegistrationEntries")
+	defer done()
+	return ds.DataStore.FetchRegistrationEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) CountRegistrationEntries(ctx context.Context, req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "CountRegistrationEntries")
+	defer done()
+	return ds.DataStore.CountRegistrationEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) UpdateRegistrationEntry(ctx context.Context, req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "UpdateRegistrationEntry")
+	defer done()
+	return ds.DataStore.UpdateRegistrationEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) DeleteRegistrationEntry(ctx context.Context, req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
+	ctx, done := ds.observe(ctx, "DeleteRegistrationEntry")
+	defer done()
+	return ds.DataStore.DeleteRegistrationEntry(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListParentIDEntries(ctx context.Context, req *datastore.ListParentIDEntriesRequest) (*datastore.ListParentIDEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "ListParentIDEntries")
+	defer done()
+	return ds.DataStore.ListParentIDEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListSelectorEntries(ctx context.Context, req *datastore.ListSelectorEntriesRequest) (*datastore.ListSelectorEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "ListSelectorEntries")
+	defer done()
+	return ds.DataStore.ListSelectorEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListMatchingEntries(ctx context.Context, req *datastore.ListSelectorEntriesRequest) (*datastore.ListSelectorEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "ListMatchingEntries")
+	defer done()
+	return ds.DataStore.ListMatchingEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListSpiffeEntries(ctx context.Context, req *datastore.ListSpiffeEntriesRequest) (*datastore.ListSpiffeEntriesResponse, error) {
+	ctx, done := ds.observe(ctx, "ListSpiffeEntries")
+	defer done()
+	return ds.DataStore.ListSpiffeEntries(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListEntryEvents(ctx context.Context, req *datastore.ListEntryEventsRequest) (*datastore.ListEntryEventsResponse, error) {
+	ctx, done := ds.observe(ctx, "ListEntryEvents")
+	defer done()
+	return ds.DataStore.ListEntryEvents(ctx, req)
+}
+
+func (ds instrumentedDataStore) PruneEntryEvents(ctx context.Context, req *datastore.EntryEvent) (*common.Empty, error) {
+	ctx, done := ds.observe(ctx, "PruneEntryEvents")
+	defer done()
+	return ds.DataStore.PruneEntryEvents(ctx, req)
+}
+
+func (ds instrumentedDataStore) RegisterToken(ctx context.Context, req *datastore.JoinToken) (*common.Empty, error) {
+	ctx, done := ds.observe(ctx, "RegisterToken")
+	defer done()
+	return ds.DataStore.RegisterToken(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchToken(ctx context.Context, req *datastore.JoinToken) (*datastore.JoinToken, error) {
+	ctx, done := ds.observe(ctx, "FetchToken")
+	defer done()
+	return ds.DataStore.FetchToken(ctx, req)
+}
+
+func (ds instrumentedDataStore) DeleteToken(ctx context.Context, req *datastore.JoinToken) (*common.Empty, error) {
+	ctx, done := ds.observe(ctx, "DeleteToken")
+	defer done()
+	return ds.DataStore.DeleteToken(ctx, req)
+}
+
+func (ds instrumentedDataStore) PruneTokens(ctx context.Context, req *datastore.JoinToken) (*common.Empty, error) {
+	ctx, done := ds.observe(ctx, "PruneTokens")
+	defer done()
+	return ds.DataStore.PruneTokens(ctx, req)
+}
+
+func (ds instrumentedDataStore) CreateIssuedSVID(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	ctx, done := ds.observe(ctx, "CreateIssuedSVID")
+	defer done()
+	return ds.DataStore.CreateIssuedSVID(ctx, req)
+}
+
+func (ds instrumentedDataStore) FetchIssuedSVID(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	ctx, done := ds.observe(ctx, "FetchIssuedSVID")
+	defer done()
+	return ds.DataStore.FetchIssuedSVID(ctx, req)
+}
+
+func (ds instrumentedDataStore) RevokeIssuedSVIDs(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVIDs, error) {
+	ctx, done := ds.observe(ctx, "RevokeIssuedSVIDs")
+	defer done()
+	return ds.DataStore.RevokeIssuedSVIDs(ctx, req)
+}
+
+func (ds instrumentedDataStore) ListRevokedSVIDs(ctx context.Context, req *common.Empty) (*datastore.IssuedSVIDs, error) {
+	ctx, done := ds.observe(ctx, "ListRevokedSVIDs")
+	defer done()
+	return ds.DataStore.ListRevokedSVIDs(ctx, req)
+}
+
+func (ds instrumentedDataStore) PruneIssuedSVIDs(ctx context.Context, req *datastore.IssuedSVID) (*common.Empty, error) {
+	ctx, done := ds.observe(ctx, "PruneIssuedSVIDs")
+	defer done()
+	return ds.DataStore.PruneIssuedSVIDs(ctx, req)
+}
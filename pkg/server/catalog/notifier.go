@@ -0,0 +1,49 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/notifier"
+)
+
+// NotifyAndAdvise notifies the notifier plugins of an event about to take
+// effect, failing as soon as one of them does
+func NotifyAndAdvise(ctx context.Context, c Catalog, req *notifier.NotifyAndAdviseRequest) error {
+	for _, n := range c.Notifiers() {
+		if _, err := n.NotifyAndAdvise(ctx, req); err != nil {
+			return fmt.Errorf("notifier plugin %s: %v", n.Config().PluginName, err)
+		}
+	}
+	return nil
+}
+
+// Notify notifies the notifier plugins of an event that has taken effect.
+// Failures are logged, as the event cannot be called off anymore.
+func Notify(ctx context.Context, c Catalog, log logrus.FieldLogger, req *notifier.NotifyRequest) {
+	for _, n := range c.Notifiers() {
+		if _, err := n.Notify(ctx, req); err != nil {
+			log.Errorf("Could not notify notifier plugin %s: %v", n.Config().PluginName, err)
+		}
+	}
+}
+
+// NotifyBundleUpdated notifies the notifier plugins that the stored bundle
+// has been updated
+func NotifyBundleUpdated(ctx context.Context, c Catalog, log logrus.FieldLogger, bundle *datastore.Bundle) {
+	Notify(ctx, c, log, &notifier.NotifyRequest{
+		BundleUpdated: &notifier.BundleUpdated{Bundle: NotifierBundle(bundle)},
+	})
+}
+
+// NotifierBundle converts the stored bundle for the notifier plugins
+func NotifierBundle(bundle *datastore.Bundle) *notifier.Bundle {
+	return &notifier.Bundle{
+		TrustDomain:    bundle.GetTrustDomain(),
+		CaCerts:        bundle.GetCaCerts(),
+		JwtSigningKeys: bundle.GetJwtSigningKeys(),
+		SequenceNumber: bundle.GetSequenceNumber(),
+	}
+}
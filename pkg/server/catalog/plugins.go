@@ -2,10 +2,13 @@ package catalog
 
 import (
 	common "github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/common/metricsink"
 	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/proto/server/csrpolicy"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"github.com/spiffe/spire/proto/server/nodeattestor"
 	"github.com/spiffe/spire/proto/server/noderesolver"
+	"github.com/spiffe/spire/proto/server/notifier"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 )
 
@@ -25,6 +28,22 @@ func (p *ManagedServerCA) Config() common.PluginConfig {
 	return p.config
 }
 
+type ManagedCSRPolicy struct {
+	config common.PluginConfig
+	csrpolicy.CSRPolicy
+}
+
+func NewManagedCSRPolicy(p csrpolicy.CSRPolicy, config common.PluginConfig) *ManagedCSRPolicy {
+	return &ManagedCSRPolicy{
+		config:    config,
+		CSRPolicy: p,
+	}
+}
+
+func (p *ManagedCSRPolicy) Config() common.PluginConfig {
+	return p.config
+}
+
 type ManagedDataStore struct {
 	config common.PluginConfig
 	datastore.DataStore
@@ -41,6 +60,22 @@ func (p *ManagedDataStore) Config() common.PluginConfig {
 	return p.config
 }
 
+type ManagedMetricSink struct {
+	config common.PluginConfig
+	metricsink.MetricSink
+}
+
+func NewManagedMetricSink(p metricsink.MetricSink, config common.PluginConfig) *ManagedMetricSink {
+	return &ManagedMetricSink{
+		config:     config,
+		MetricSink: p,
+	}
+}
+
+func (p *ManagedMetricSink) Config() common.PluginConfig {
+	return p.config
+}
+
 type ManagedNodeAttestor struct {
 	config common.PluginConfig
 	nodeattestor.NodeAttestor
@@ -73,6 +108,22 @@ func (p *ManagedNodeResolver) Config() common.PluginConfig {
 	return p.config
 }
 
+type ManagedNotifier struct {
+	config common.PluginConfig
+	notifier.Notifier
+}
+
+func NewManagedNotifier(p notifier.Notifier, config common.PluginConfig) *ManagedNotifier {
+	return &ManagedNotifier{
+		config:   config,
+		Notifier: p,
+	}
+}
+
+func (p *ManagedNotifier) Config() common.PluginConfig {
+	return p.config
+}
+
 type ManagedUpstreamCA struct {
 	config common.PluginConfig
 	upstreamca.UpstreamCA
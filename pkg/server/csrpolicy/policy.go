@@ -0,0 +1,250 @@
+package csrpolicy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/common"
+
+	csrpolicy_pb "github.com/spiffe/spire/proto/server/csrpolicy"
+)
+
+const (
+	// TTLPolicyClamp lowers the SVID TTLs exceeding the CA lifetime to it,
+	// logging a warning. This is the default.
+	TTLPolicyClamp = "clamp"
+
+	// TTLPolicyReject refuses to sign SVIDs with TTLs exceeding the CA
+	// lifetime.
+	TTLPolicyReject = "reject"
+)
+
+// CASource provides the certificate of the active CA.
+type CASource interface {
+	CACertificate() *x509.Certificate
+}
+
+// Config holds the configuration of the built-in CSR checks.
+type Config struct {
+	// AllowedKeyTypes lists the key types a CSR may use (e.g. "ec-p256" or
+	// "rsa-2048"). Any key type is allowed if empty.
+	AllowedKeyTypes []string
+
+	// MaxTTL caps the TTL of signed SVIDs. No cap is applied if zero.
+	MaxTTL time.Duration
+
+	// TTLPolicy decides what happens to SVID TTLs exceeding the lifetime of
+	// the CA: TTLPolicyClamp or TTLPolicyReject. Defaults to TTLPolicyClamp.
+	TTLPolicy string
+
+	// CA provides the CA certificate the TTLs are checked against. TTLs are
+	// not checked against the CA lifetime if not set.
+	CA CASource
+
+	// Log receives the warnings about clamped TTLs. Discarded if not set.
+	Log logrus.FieldLogger
+}
+
+// Request describes a CSR the server is about to sign.
+type Request struct {
+	// CSR is the DER encoded certificate signing request.
+	CSR []byte
+
+	// SpiffeID is the SPIFFE ID the SVID is issued for.
+	SpiffeID string
+
+	// TTL is the requested SVID TTL in seconds. Zero means the CA default.
+	TTL int32
+
+	// Entry is the registration entry the SVID is issued for. It is nil
+	// for agent SVIDs.
+	Entry *common.RegistrationEntry
+}
+
+// Policy validates CSRs before they are signed. The built-in checks run
+// first, followed by every CSRPolicy plugin in the catalog.
+type Policy struct {
+	c       Config
+	catalog catalog.Catalog
+}
+
+// New creates a policy with the given built-in configuration. Plugins are
+// looked up in the catalog on every check so catalog reloads are honored.
+func New(c Config, cat catalog.Catalog) *Policy {
+	if c.TTLPolicy == "" {
+		c.TTLPolicy = TTLPolicyClamp
+	}
+	if c.Log == nil {
+		log := logrus.New()
+		log.Out = ioutil.Discard
+		c.Log = log
+	}
+	return &Policy{
+		c:       c,
+		catalog: cat,
+	}
+}
+
+// Check validates the request against the policy and returns the TTL the
+// SVID should be signed with.
+func (p *Policy) Check(ctx context.Context, req *Request) (int32, error) {
+	csr, err := x509.ParseCertificateRequest(req.CSR)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse CSR: %v", err)
+	}
+
+	if err := p.checkKeyType(csr); err != nil {
+		return 0, err
+	}
+	if err := checkSANs(csr, req.SpiffeID); err != nil {
+		return 0, err
+	}
+
+	ttl, err := p.CheckTTL(p.capTTL(req.TTL), fmt.Sprintf("TTL of %s", req.SpiffeID))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, plugin := range p.catalog.CSRPolicies() {
+		_, err := plugin.ValidateCSR(ctx, &csrpolicy_pb.ValidateCSRRequest{
+			Csr:      req.CSR,
+			SpiffeId: req.SpiffeID,
+			Ttl:      ttl,
+			Entry:    req.Entry,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("rejected by CSR policy plugin %s: %v", plugin.Config().PluginName, err)
+		}
+	}
+
+	return ttl, nil
+}
+
+func (p *Policy) checkKeyType(csr *x509.CertificateRequest) error {
+	if len(p.c.AllowedKeyTypes) == 0 {
+		return nil
+	}
+
+	keyType, err := KeyType(csr.PublicKey)
+	if err != nil {
+		return err
+	}
+	for _, allowed := range p.c.AllowedKeyTypes {
+		if keyType == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("key type %q is not allowed", keyType)
+}
+
+// capTTL lowers the TTL to the configured maximum, if any. A zero TTL
+// defers to the CA default, which may exceed the maximum, so it is capped
+// as well.
+func (p *Policy) capTTL(ttl int32) int32 {
+	maxTTL := int32(p.c.MaxTTL / time.Second)
+	if maxTTL > 0 && (ttl <= 0 || ttl > maxTTL) {
+		return maxTTL
+	}
+	return ttl
+}
+
+// CheckTTL checks a TTL, in seconds, against the lifetime of the active CA
+// and returns the TTL to sign with, according to the TTL policy. A zero TTL
+// defers to the CA default, which the CA caps itself. desc describes the TTL
+// in errors and warnings.
+func (p *Policy) CheckTTL(ttl int32, desc string) (int32, error) {
+	lifetime := p.caLifetime()
+	if lifetime <= 0 || time.Duration(ttl)*time.Second <= lifetime {
+		return ttl, nil
+	}
+
+	if p.c.TTLPolicy == TTLPolicyReject {
+		return 0, fmt.Errorf("%s of %v exceeds the CA lifetime of %v", desc, time.Duration(ttl)*time.Second, lifetime)
+	}
+	p.c.Log.Warnf("%s of %v exceeds the CA lifetime of %v; clamping", desc, time.Duration(ttl)*time.Second, lifetime)
+	return int32(lifetime / time.Second), nil
+}
+
+// CheckTTLs checks the maximum TTL and the TTLs of the given registration
+// entries against the lifetime of the active CA, so that misconfigurations
+// are reported on startup rather than once SVIDs are signed. With
+// TTLPolicyReject, the first TTL exceeding the CA lifetime is returned as an
+// error.
+func (p *Policy) CheckTTLs(entries []*common.RegistrationEntry) error {
+	maxTTL := int32(p.c.MaxTTL / time.Second)
+	if maxTTL > 0 {
+		if _, err := p.CheckTTL(maxTTL, "svid_max_ttl"); err != nil {
+			return err
+		}
+	}
+	for _, entry := range entries {
+		// TTLs capped to the maximum TTL were checked along with it
+		if maxTTL > 0 && entry.Ttl >= maxTTL {
+			continue
+		}
+		if _, err := p.CheckTTL(entry.Ttl, fmt.Sprintf("TTL of entry %s", entry.EntryId)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// caLifetime returns the lifetime of the active CA, or zero if unknown
+func (p *Policy) caLifetime() time.Duration {
+	if p.c.CA == nil {
+		return 0
+	}
+	caCert := p.c.CA.CACertificate()
+	if caCert == nil {
+		return 0
+	}
+	return caCert.NotAfter.Sub(caCert.NotBefore)
+}
+
+// checkSANs makes sure the CSR only asks for the SPIFFE ID being issued.
+func checkSANs(csr *x509.CertificateRequest, spiffeID string) error {
+	if len(csr.URIs) != 1 || csr.URIs[0].String() != spiffeID {
+		return fmt.Errorf("CSR must have exactly one URI SAN matching %q", spiffeID)
+	}
+	if len(csr.DNSNames) > 0 || len(csr.EmailAddresses) > 0 || len(csr.IPAddresses) > 0 {
+		return errors.New("CSR must not have DNS, email or IP address SANs")
+	}
+	return nil
+}
+
+// KeyType returns the name of the key type used by the given public key,
+// as accepted by Config.AllowedKeyTypes.
+func KeyType(publicKey interface{}) (string, error) {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ec-p%d", key.Curve.Params().BitSize), nil
+	case *rsa.PublicKey:
+		return fmt.Sprintf("rsa-%d", key.N.BitLen()), nil
+	default:
+		return "", fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+// IsValidKeyType returns true if the given key type name is well formed and
+// could be returned by KeyType.
+func IsValidKeyType(keyType string) bool {
+	var size int
+	switch {
+	case strings.HasPrefix(keyType, "ec-p"):
+		_, err := fmt.Sscanf(keyType, "ec-p%d", &size)
+		return err == nil && fmt.Sprintf("ec-p%d", size) == keyType
+	case strings.HasPrefix(keyType, "rsa-"):
+		_, err := fmt.Sscanf(keyType, "rsa-%d", &size)
+		return err == nil && fmt.Sprintf("rsa-%d", size) == keyType
+	}
+	return false
+}
@@ -0,0 +1,264 @@
+package csrpolicy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/csrpolicy"
+	"github.com/stretchr/testify/suite"
+
+	csrpolicy_pb "github.com/spiffe/spire/proto/server/csrpolicy"
+)
+
+const spiffeID = "spiffe://example.org/workload"
+
+func TestPolicy(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
+
+type PolicyTestSuite struct {
+	suite.Suite
+
+	catalog *fakeservercatalog.Catalog
+}
+
+func (s *PolicyTestSuite) SetupTest() {
+	s.catalog = fakeservercatalog.New()
+}
+
+func (s *PolicyTestSuite) TestNoRestrictions() {
+	p := New(Config{}, s.catalog)
+
+	ttl, err := p.Check(context.Background(), &Request{
+		CSR:      s.makeCSR(s.ecKey(elliptic.P256()), spiffeID),
+		SpiffeID: spiffeID,
+		TTL:      3600,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(3600), ttl)
+}
+
+func (s *PolicyTestSuite) TestAllowedKeyTypes() {
+	p := New(Config{AllowedKeyTypes: []string{"ec-p384", "rsa-2048"}}, s.catalog)
+
+	_, err := p.Check(context.Background(), &Request{
+		CSR:      s.makeCSR(s.ecKey(elliptic.P384()), spiffeID),
+		SpiffeID: spiffeID,
+	})
+	s.Require().NoError(err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+	_, err = p.Check(context.Background(), &Request{
+		CSR:      s.makeCSR(rsaKey, spiffeID),
+		SpiffeID: spiffeID,
+	})
+	s.Require().NoError(err)
+
+	_, err = p.Check(context.Background(), &Request{
+		CSR:      s.makeCSR(s.ecKey(elliptic.P256()), spiffeID),
+		SpiffeID: spiffeID,
+	})
+	s.Require().EqualError(err, `key type "ec-p256" is not allowed`)
+}
+
+func (s *PolicyTestSuite) TestSANsMustMatch() {
+	p := New(Config{}, s.catalog)
+
+	_, err := p.Check(context.Background(), &Request{
+		CSR:      s.makeCSR(s.ecKey(elliptic.P256()), "spiffe://example.org/other"),
+		SpiffeID: spiffeID,
+	})
+	s.Require().Error(err)
+
+	template := &x509.CertificateRequest{
+		URIs:     []*url.URL{s.parseURL(spiffeID)},
+		DNSNames: []string{"example.org"},
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, s.ecKey(elliptic.P256()))
+	s.Require().NoError(err)
+	_, err = p.Check(context.Background(), &Request{
+		CSR:      csr,
+		SpiffeID: spiffeID,
+	})
+	s.Require().EqualError(err, "CSR must not have DNS, email or IP address SANs")
+}
+
+func (s *PolicyTestSuite) TestMaxTTL() {
+	p := New(Config{MaxTTL: time.Minute}, s.catalog)
+	csr := s.makeCSR(s.ecKey(elliptic.P256()), spiffeID)
+
+	for requested, expected := range map[int32]int32{0: 60, 30: 30, 3600: 60} {
+		ttl, err := p.Check(context.Background(), &Request{
+			CSR:      csr,
+			SpiffeID: spiffeID,
+			TTL:      requested,
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(expected, ttl)
+	}
+}
+
+func (s *PolicyTestSuite) TestTTLPolicyClamp() {
+	log, hook := test.NewNullLogger()
+	p := New(Config{CA: s.caSource(time.Hour), Log: log}, s.catalog)
+	csr := s.makeCSR(s.ecKey(elliptic.P256()), spiffeID)
+
+	for requested, expected := range map[int32]int32{0: 0, 60: 60, 7200: 3600} {
+		ttl, err := p.Check(context.Background(), &Request{
+			CSR:      csr,
+			SpiffeID: spiffeID,
+			TTL:      requested,
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(expected, ttl)
+	}
+	s.Require().Len(hook.AllEntries(), 1)
+	s.Require().Equal(logrus.WarnLevel, hook.LastEntry().Level)
+}
+
+func (s *PolicyTestSuite) TestTTLPolicyReject() {
+	p := New(Config{CA: s.caSource(time.Hour), TTLPolicy: TTLPolicyReject}, s.catalog)
+	csr := s.makeCSR(s.ecKey(elliptic.P256()), spiffeID)
+
+	ttl, err := p.Check(context.Background(), &Request{
+		CSR:      csr,
+		SpiffeID: spiffeID,
+		TTL:      3600,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(3600), ttl)
+
+	_, err = p.Check(context.Background(), &Request{
+		CSR:      csr,
+		SpiffeID: spiffeID,
+		TTL:      7200,
+	})
+	s.Require().EqualError(err, "TTL of spiffe://example.org/workload of 2h0m0s exceeds the CA lifetime of 1h0m0s")
+}
+
+func (s *PolicyTestSuite) TestTTLPolicyNoCACertificate() {
+	p := New(Config{CA: s.caSource(0), TTLPolicy: TTLPolicyReject}, s.catalog)
+
+	ttl, err := p.CheckTTL(7200, "ttl")
+	s.Require().NoError(err)
+	s.Require().Equal(int32(7200), ttl)
+}
+
+func (s *PolicyTestSuite) TestCheckTTLs() {
+	entries := []*common.RegistrationEntry{
+		{EntryId: "short", Ttl: 60},
+		{EntryId: "long", Ttl: 7200},
+	}
+
+	log, hook := test.NewNullLogger()
+	p := New(Config{CA: s.caSource(time.Hour), Log: log}, s.catalog)
+	s.Require().NoError(p.CheckTTLs(entries))
+	s.Require().Len(hook.AllEntries(), 1)
+
+	p = New(Config{CA: s.caSource(time.Hour), TTLPolicy: TTLPolicyReject}, s.catalog)
+	s.Require().EqualError(p.CheckTTLs(entries), "TTL of entry long of 2h0m0s exceeds the CA lifetime of 1h0m0s")
+
+	// Entry TTLs are capped to the maximum TTL, which is checked on its own
+	p = New(Config{CA: s.caSource(time.Hour), TTLPolicy: TTLPolicyReject, MaxTTL: 30 * time.Minute}, s.catalog)
+	s.Require().NoError(p.CheckTTLs(entries))
+
+	p = New(Config{CA: s.caSource(time.Hour), TTLPolicy: TTLPolicyReject, MaxTTL: 2 * time.Hour}, s.catalog)
+	s.Require().EqualError(p.CheckTTLs(entries), "svid_max_ttl of 2h0m0s exceeds the CA lifetime of 1h0m0s")
+}
+
+func (s *PolicyTestSuite) TestPlugins() {
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	plugin := mock_csrpolicy.NewMockCSRPolicy(ctrl)
+	s.catalog.SetCSRPolicies(plugin)
+	p := New(Config{}, s.catalog)
+
+	csr := s.makeCSR(s.ecKey(elliptic.P256()), spiffeID)
+	entry := &common.RegistrationEntry{SpiffeId: spiffeID, Ttl: 60}
+	expected := &csrpolicy_pb.ValidateCSRRequest{
+		Csr:      csr,
+		SpiffeId: spiffeID,
+		Ttl:      60,
+		Entry:    entry,
+	}
+	req := &Request{
+		CSR:      csr,
+		SpiffeID: spiffeID,
+		TTL:      60,
+		Entry:    entry,
+	}
+
+	plugin.EXPECT().ValidateCSR(gomock.Any(), expected).Return(&csrpolicy_pb.ValidateCSRResponse{}, nil)
+	_, err := p.Check(context.Background(), req)
+	s.Require().NoError(err)
+
+	plugin.EXPECT().ValidateCSR(gomock.Any(), expected).Return(nil, errors.New("nope"))
+	_, err = p.Check(context.Background(), req)
+	s.Require().EqualError(err, "rejected by CSR policy plugin fake_csrpolicy_1: nope")
+}
+
+func (s *PolicyTestSuite) TestIsValidKeyType() {
+	for _, keyType := range []string{"ec-p256", "ec-p384", "rsa-2048", "rsa-4096"} {
+		s.Require().True(IsValidKeyType(keyType), keyType)
+	}
+	for _, keyType := range []string{"", "ec", "ec-p", "ec-pfoo", "rsa-2048x", "dsa-1024"} {
+		s.Require().False(IsValidKeyType(keyType), keyType)
+	}
+}
+
+type fakeCASource struct {
+	caCert *x509.Certificate
+}
+
+func (f fakeCASource) CACertificate() *x509.Certificate {
+	return f.caCert
+}
+
+// caSource returns a CA source with a CA certificate of the given lifetime,
+// or with no CA certificate if zero
+func (s *PolicyTestSuite) caSource(lifetime time.Duration) CASource {
+	if lifetime == 0 {
+		return fakeCASource{}
+	}
+	notBefore := time.Now()
+	return fakeCASource{caCert: &x509.Certificate{
+		NotBefore: notBefore,
+		NotAfter:  notBefore.Add(lifetime),
+	}}
+}
+
+func (s *PolicyTestSuite) ecKey(curve elliptic.Curve) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	s.Require().NoError(err)
+	return key
+}
+
+func (s *PolicyTestSuite) parseURL(rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	s.Require().NoError(err)
+	return u
+}
+
+func (s *PolicyTestSuite) makeCSR(key interface{}, spiffeID string) []byte {
+	template := &x509.CertificateRequest{
+		URIs: []*url.URL{s.parseURL(spiffeID)},
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	s.Require().NoError(err)
+	return csr
+}
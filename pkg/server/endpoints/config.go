@@ -7,7 +7,14 @@ import (
 
 	"github.com/imkira/go-observer"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tracing"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/localauthority"
+	"github.com/spiffe/spire/pkg/server/signpool"
 
 	"google.golang.org/grpc"
 )
@@ -17,24 +24,81 @@ type Config struct {
 	GRPCAddr *net.TCPAddr
 	HTTPAddr *net.TCPAddr
 
+	// If true, the gRPC health checking service is served alongside the
+	// Node and Registration APIs
+	HealthCheckEnabled bool
+
+	// If true, the gRPC server reflection service is served alongside the
+	// Node and Registration APIs
+	ReflectionEnabled bool
+
+	// Built-in checks applied to CSRs before they are signed
+	CSRPolicy csrpolicy.Config
+
+	// Bounds how many CSRs are signed at once by the Node and SVID APIs
+	SignPool signpool.Config
+
+	// If true, the metrics of the SVIDs issued are labeled by agent and
+	// entry ID
+	SVIDMetricLabels bool
+
+	// If true, the CA is an intermediate of the upstream CA and is not in
+	// the trust bundle, so its certificate is sent along with the SVIDs
+	UpstreamBundle bool
+
 	// A hook allowing the consumer to customize the gRPC server before it starts.
 	GRPCHook func(*grpc.Server) error
 
 	// A subscription to the SVID stream
 	SVIDStream observer.Stream
 
+	// Notifies of the changes pushed to the agents watching for updates
+	UpdateNotifier node.UpdateNotifier
+
 	// The server's configured trust domain. Used for validation, server SVID, etc.
 	TrustDomain url.URL
 
 	// Plugin catalog for retreiving signing certs and generating server SVIDs
 	Catalog catalog.Catalog
 
+	// Signs JWT-SVIDs for the Node API
+	JWTSigner node.JWTSigner
+
+	// Provides the CRL served over HTTP and sent to agents. If set, CRLs
+	// are enabled: the X509-SVIDs signed are recorded and can be revoked
+	// through the Registration API.
+	CRLSource node.CRLSource
+
+	// Fetches the registration entries agents are authorized for, e.g. from
+	// the entry cache. They are fetched from the datastore if unset.
+	EntryFetcher node.EntryFetcher
+
+	// Rotates the CA on demand for the LocalAuthority API, which is only
+	// served if set
+	CARotator localauthority.CARotator
+
+	// Reports the federation statuses for the Bundle API. None are listed
+	// if unset.
+	FederationMonitor bundle.FederationMonitor
+
 	Log logrus.FieldLogger
+	Tel telemetry.Sink
+
+	// Tracer for the gRPC calls. Calls are not traced if not set.
+	Tracer tracing.Tracer
 }
 
 func New(c *Config) *endpoints {
+	if c.Tel == nil {
+		c.Tel = telemetry.Blackhole{}
+	}
+	if c.Tracer == nil {
+		c.Tracer = tracing.Noop{}
+	}
 	return &endpoints{
-		c:   c,
-		mtx: new(sync.RWMutex),
+		c:         c,
+		mtx:       new(sync.RWMutex),
+		csrPolicy: csrpolicy.New(c.CSRPolicy, c.Catalog),
+		signPool:  signpool.New(c.SignPool, c.Tel),
 	}
 }
@@ -9,24 +9,54 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tracing"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
 	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/endpoints/registration"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/agent"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/entry"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/localauthority"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/stats"
+	svidv1 "github.com/spiffe/spire/pkg/server/endpoints/v1/svid"
+	"github.com/spiffe/spire/pkg/server/signpool"
 	"github.com/spiffe/spire/pkg/server/svid"
 
 	node_pb "github.com/spiffe/spire/proto/api/node"
 	registration_pb "github.com/spiffe/spire/proto/api/registration"
+	agent_pb "github.com/spiffe/spire/proto/api/v1/agent"
+	bundle_pb "github.com/spiffe/spire/proto/api/v1/bundle"
+	entry_pb "github.com/spiffe/spire/proto/api/v1/entry"
+	localauthority_pb "github.com/spiffe/spire/proto/api/v1/localauthority"
+	stats_pb "github.com/spiffe/spire/proto/api/v1/stats"
+	svid_pb "github.com/spiffe/spire/proto/api/v1/svid"
 	datastore_pb "github.com/spiffe/spire/proto/server/datastore"
 
 	"golang.org/x/net/context"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/reflection"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// registrationMethodPrefix is the gRPC method prefix shared by all
+// Registration API calls
+const registrationMethodPrefix = "/spire.api.registration.Registration/"
+
+// v1MethodPrefix is the gRPC method prefix shared by all calls to the
+// versioned v1 server APIs
+const v1MethodPrefix = "/spire.api.v1."
+
 // Server manages gRPC and HTTP endpoint lifecycle
 type Server interface {
 	// ListenAndServe starts all endpoints, and blocks for as long as the
@@ -41,6 +71,10 @@ type endpoints struct {
 
 	svid    *x509.Certificate
 	svidKey *ecdsa.PrivateKey
+	svidCA  *x509.Certificate
+
+	csrPolicy *csrpolicy.Policy
+	signPool  *signpool.Pool
 }
 
 // ListenAndServe starts all maintenance routines and endpoints, then blocks
@@ -58,6 +92,16 @@ func (e *endpoints) ListenAndServe(ctx context.Context) error {
 	if err := e.registerRegistrationAPI(ctx, gs, hs); err != nil {
 		return err
 	}
+	if e.c.CRLSource != nil {
+		e.registerCRLHandler(hs)
+	}
+	e.registerV1APIs(gs)
+	if e.c.HealthCheckEnabled {
+		e.registerHealthAPI(gs)
+	}
+	if e.c.ReflectionEnabled {
+		reflection.Register(gs)
+	}
 
 	err := util.RunTasks(ctx,
 		func(ctx context.Context) error {
@@ -79,8 +123,18 @@ func (e *endpoints) createGRPCServer(ctx context.Context) *grpc.Server {
 		GetConfigForClient: e.getGRPCServerConfig(ctx),
 	}
 
-	opts := grpc.Creds(credentials.NewTLS(tlsConfig))
-	return grpc.NewServer(opts)
+	return grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.UnaryInterceptor(grpcutil.ChainUnaryInterceptors(
+			tracing.UnaryServerInterceptor(e.c.Tracer),
+			telemetry.UnaryServerInterceptor(e.c.Tel),
+			e.authorizeUnary(e.newRegistrationHandler()),
+		)),
+		grpc.StreamInterceptor(grpcutil.ChainStreamInterceptors(
+			tracing.StreamServerInterceptor(e.c.Tracer),
+			telemetry.StreamServerInterceptor(e.c.Tel),
+		)),
+	)
 }
 
 func (e *endpoints) createHTTPServer(ctx context.Context) *http.Server {
@@ -103,10 +157,76 @@ func (e *endpoints) registerNodeAPI(gs *grpc.Server) {
 		Log:         e.c.Log.WithField("subsystem_name", "node_api"),
 		Catalog:     e.c.Catalog,
 		TrustDomain: e.c.TrustDomain,
+		CSRPolicy:   e.csrPolicy,
+		SignPool:    e.signPool,
+		Tel:         e.c.Tel,
+		Tracer:      e.c.Tracer,
+
+		UpstreamBundle:   e.c.UpstreamBundle,
+		SVIDMetricLabels: e.c.SVIDMetricLabels,
+		JWTSigner:        e.c.JWTSigner,
+		CRLSource:        e.c.CRLSource,
+		UpdateNotifier:   e.c.UpdateNotifier,
+		EntryFetcher:     e.c.EntryFetcher,
 	})
 	node_pb.RegisterNodeServer(gs, n)
 }
 
+// registerV1APIs creates the versioned v1 API handlers and registers them
+// against the provided gRPC server. They are served alongside the legacy
+// Registration API, which remains available while clients migrate.
+func (e *endpoints) registerV1APIs(gs *grpc.Server) {
+	entry_pb.RegisterEntryServer(gs, &entry.Handler{
+		Log:         e.c.Log.WithField("subsystem_name", "entry_api"),
+		Catalog:     e.c.Catalog,
+		TrustDomain: e.c.TrustDomain,
+	})
+	agent_pb.RegisterAgentServer(gs, &agent.Handler{
+		Log:         e.c.Log.WithField("subsystem_name", "agent_api"),
+		Catalog:     e.c.Catalog,
+		TrustDomain: e.c.TrustDomain,
+	})
+	bundle_pb.RegisterBundleServer(gs, &bundle.Handler{
+		Log:               e.c.Log.WithField("subsystem_name", "bundle_api"),
+		Catalog:           e.c.Catalog,
+		TrustDomain:       e.c.TrustDomain,
+		FederationMonitor: e.c.FederationMonitor,
+	})
+	svid_pb.RegisterSVIDServer(gs, &svidv1.Handler{
+		Log:         e.c.Log.WithField("subsystem_name", "svid_api"),
+		Catalog:     e.c.Catalog,
+		TrustDomain: e.c.TrustDomain,
+		CSRPolicy:   e.csrPolicy,
+		SignPool:    e.signPool,
+
+		UpstreamBundle: e.c.UpstreamBundle,
+	})
+	if e.c.CARotator != nil {
+		localauthority_pb.RegisterLocalAuthorityServer(gs, &localauthority.Handler{
+			Log:       e.c.Log.WithField("subsystem_name", "localauthority_api"),
+			Catalog:   e.c.Catalog,
+			CARotator: e.c.CARotator,
+		})
+	}
+	stats_pb.RegisterStatsServer(gs, &stats.Handler{
+		Log:     e.c.Log.WithField("subsystem_name", "stats_api"),
+		Catalog: e.c.Catalog,
+		CA:      e.c.CARotator,
+	})
+}
+
+// registerHealthAPI creates a gRPC health checking handler and registers it
+// against the provided gRPC server. Every service already registered on the
+// server is reported as serving, so it must be called after the other APIs
+// have been registered.
+func (e *endpoints) registerHealthAPI(gs *grpc.Server) {
+	h := health.NewServer()
+	for service := range gs.GetServiceInfo() {
+		h.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(gs, h)
+}
+
 // registerRegistrationAPI creates a Registration API handler and registers
 // it against the provided gRPC and HTTP servers.
 func (e *endpoints) registerRegistrationAPI(ctx context.Context, gs *grpc.Server, hs *http.Server) error {
@@ -122,11 +242,7 @@ func (e *endpoints) registerRegistrationAPI(ctx context.Context, gs *grpc.Server
 		return fmt.Errorf("error creating http gateway")
 	}
 
-	r := &registration.Handler{
-		Log:         e.c.Log.WithField("subsystem_name", "registration_api"),
-		Catalog:     e.c.Catalog,
-		TrustDomain: e.c.TrustDomain,
-	}
+	r := e.newRegistrationHandler()
 
 	// Register the handler with gRPC first
 	registration_pb.RegisterRegistrationServer(gs, r)
@@ -138,6 +254,57 @@ func (e *endpoints) registerRegistrationAPI(ctx context.Context, gs *grpc.Server
 	return nil
 }
 
+func (e *endpoints) newRegistrationHandler() *registration.Handler {
+	return &registration.Handler{
+		Log:         e.c.Log.WithField("subsystem_name", "registration_api"),
+		Catalog:     e.c.Catalog,
+		TrustDomain: e.c.TrustDomain,
+		CRLEnabled:  e.c.CRLSource != nil,
+	}
+}
+
+// registerCRLHandler serves the CRL of the server CA at /crl on the provided
+// HTTP server, in front of the Registration API gateway. It must be called
+// after registerRegistrationAPI.
+func (e *endpoints) registerCRLHandler(hs *http.Server) {
+	mux := http.NewServeMux()
+	mux.Handle("/", hs.Handler)
+	mux.HandleFunc("/crl", e.serveCRL)
+	hs.Handler = mux
+}
+
+// serveCRL writes the latest CRL signed by the server CA, ASN.1 DER encoded.
+func (e *endpoints) serveCRL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	crl := e.c.CRLSource.CRL()
+	if crl == nil {
+		http.Error(w, "CRL not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	w.Write(crl)
+}
+
+// authorizeUnary returns a gRPC interceptor which authorizes calls to the
+// Registration API and the v1 APIs before they reach the handler. Calls to
+// other services are passed through untouched.
+func (e *endpoints) authorizeUnary(r *registration.Handler) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, registrationMethodPrefix) ||
+			strings.HasPrefix(info.FullMethod, v1MethodPrefix) {
+			if err := r.AuthorizeCall(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
 // runGRPCServer will start the server and block until it exits or we are dying.
 func (e *endpoints) runGRPCServer(ctx context.Context, server *grpc.Server) error {
 	l, err := net.Listen(e.c.GRPCAddr.Network(), e.c.GRPCAddr.String())
@@ -265,14 +432,29 @@ func (e *endpoints) getCerts(ctx context.Context) ([]tls.Certificate, *x509.Cert
 		return nil, nil, fmt.Errorf("parse bundle: %v", err)
 	}
 
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+
+	// The CA is not in the bundle when it is an intermediate of the upstream
+	// CA. It is listed as a client CA too, along with the previous and next
+	// CAs, so agents with an SVID issued by any of them still present it
+	// when they have no intermediates to chain it to the bundle.
+	if e.svidCA != nil {
+		caCerts = append(caCerts, e.svidCA)
+	}
+	if e.c.CARotator != nil {
+		for _, ca := range []*x509.Certificate{e.c.CARotator.PrevCACertificate(), e.c.CARotator.NextCACertificate()} {
+			if ca != nil {
+				caCerts = append(caCerts, ca)
+			}
+		}
+	}
+
 	caPool := x509.NewCertPool()
 	for _, c := range caCerts {
 		caPool.AddCert(c)
 	}
 
-	e.mtx.RLock()
-	defer e.mtx.RUnlock()
-
 	servingCA, err := e.findServingCA(e.svid, caCerts)
 	if err != nil {
 		return nil, nil, fmt.Errorf("find serving CA: %v", err)
@@ -313,6 +495,7 @@ func (e *endpoints) updateSVID() {
 	state := e.c.SVIDStream.Value().(svid.State)
 	e.svid = state.SVID
 	e.svidKey = state.Key
+	e.svidCA = state.CA
 }
 
 func (e *endpoints) getSVIDState() svid.State {
@@ -321,5 +504,6 @@ func (e *endpoints) getSVIDState() svid.State {
 	return svid.State{
 		SVID: e.svid,
 		Key:  e.svidKey,
+		CA:   e.svidCA,
 	}
 }
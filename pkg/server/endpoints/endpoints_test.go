@@ -2,11 +2,16 @@ package endpoints
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"errors"
+	"math/big"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
@@ -14,6 +19,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/imkira/go-observer"
 	"github.com/sirupsen/logrus/hooks/test"
+	spiffe_tls "github.com/spiffe/go-spiffe/tls"
+	pkgutil "github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/endpoints/v1/localauthority"
 	"github.com/spiffe/spire/pkg/server/svid"
 	"github.com/spiffe/spire/proto/server/ca"
 	"github.com/spiffe/spire/proto/server/datastore"
@@ -92,6 +100,56 @@ func (s *EndpointsTestSuite) TestRegisterRegistrationAPI() {
 	s.Assert().Nil(err)
 }
 
+func (s *EndpointsTestSuite) TestRegisterV1APIs() {
+	gs := s.e.createGRPCServer(ctx)
+	s.e.registerV1APIs(gs)
+
+	services := gs.GetServiceInfo()
+	s.Assert().Contains(services, "spire.api.v1.entry.Entry")
+	s.Assert().Contains(services, "spire.api.v1.agent.Agent")
+	s.Assert().Contains(services, "spire.api.v1.bundle.Bundle")
+	s.Assert().Contains(services, "spire.api.v1.svid.SVID")
+	s.Assert().Contains(services, "spire.api.v1.stats.Stats")
+	s.Assert().NotContains(services, "spire.api.v1.localauthority.LocalAuthority")
+
+	// The LocalAuthority API is only served with a CA to rotate
+	s.e.c.CARotator = struct{ localauthority.CARotator }{}
+	gs = s.e.createGRPCServer(ctx)
+	s.e.registerV1APIs(gs)
+	s.Assert().Contains(gs.GetServiceInfo(), "spire.api.v1.localauthority.LocalAuthority")
+}
+
+func (s *EndpointsTestSuite) TestRegisterHealthAPI() {
+	gs := s.e.createGRPCServer(ctx)
+	s.e.registerNodeAPI(gs)
+	s.e.registerHealthAPI(gs)
+
+	s.Assert().Contains(gs.GetServiceInfo(), "grpc.health.v1.Health")
+}
+
+func (s *EndpointsTestSuite) TestServeCRL() {
+	crlSource := &fakeCRLSource{}
+	s.e.c.CRLSource = crlSource
+	hs := s.e.createHTTPServer(ctx)
+	s.e.registerCRLHandler(hs)
+
+	// No CRL signed yet
+	w := httptest.NewRecorder()
+	hs.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/crl", nil))
+	s.Assert().Equal(http.StatusServiceUnavailable, w.Code)
+
+	crlSource.crl = []byte("CRL")
+	w = httptest.NewRecorder()
+	hs.Handler.ServeHTTP(w, httptest.NewRequest("GET", "/crl", nil))
+	s.Assert().Equal(http.StatusOK, w.Code)
+	s.Assert().Equal("application/pkix-crl", w.Header().Get("Content-Type"))
+	s.Assert().Equal("CRL", w.Body.String())
+
+	w = httptest.NewRecorder()
+	hs.Handler.ServeHTTP(w, httptest.NewRequest("POST", "/crl", nil))
+	s.Assert().Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
 func (s *EndpointsTestSuite) TestListenAndServe() {
 	// Expectations
 	cert, _, err := util.LoadSVIDFixture()
@@ -189,6 +247,153 @@ func (s *EndpointsTestSuite) TestGetGRPCServerConfig() {
 	s.Assert().Equal(pool, tlsConfig.ClientCAs)
 }
 
+func (s *EndpointsTestSuite) TestGetGRPCServerConfigWithIntermediateCA() {
+	s.expectBundleLookup()
+	intermediate, _, err := util.LoadSVIDFixture()
+	require.NoError(s.T(), err)
+	s.e.svidCA = intermediate
+
+	tlsConfig, err := s.e.getGRPCServerConfig(ctx)(nil)
+	require.NoError(s.T(), err)
+
+	// Agents only present their SVID if its issuer is among the client CAs
+	s.Assert().Contains(tlsConfig.ClientCAs.Subjects(), intermediate.RawSubject)
+}
+
+func (s *EndpointsTestSuite) TestGRPCHandshakeWithIntermediateCA() {
+	// The upstream CA is in the bundle, the CA of the server is its
+	// intermediate and signs both the server and the agent SVIDs
+	upstreamCA, upstreamKey := s.newCA("upstream", nil, nil)
+	intermediateCA, caKey := s.newCA("intermediate", upstreamCA, upstreamKey)
+	s.setServerSVID(upstreamCA, intermediateCA, caKey)
+
+	agentChain := s.newAgentChain(intermediateCA, caKey)
+	peerCerts := s.handshake(upstreamCA, agentChain)
+	s.Assert().Equal(agentChain.Certificate, rawCerts(peerCerts))
+}
+
+func (s *EndpointsTestSuite) TestGRPCHandshakeAfterCARotation() {
+	// The agent SVID was signed by the CA active before the rotation, and
+	// the agent has not synced since
+	upstreamCA, upstreamKey := s.newCA("upstream", nil, nil)
+	oldCA, oldKey := s.newCA("old", upstreamCA, upstreamKey)
+	newCA, newKey := s.newCA("new", upstreamCA, upstreamKey)
+	s.setServerSVID(upstreamCA, newCA, newKey)
+	s.e.c.CARotator = fakeCARotator{prev: oldCA}
+
+	agentChain := s.newAgentChain(oldCA, oldKey)
+	peerCerts := s.handshake(upstreamCA, agentChain)
+	s.Assert().Equal(agentChain.Certificate, rawCerts(peerCerts))
+
+	// Agents without the intermediates still present their SVID, since the
+	// previous CA is a client CA
+	agentSVID := tls.Certificate{
+		Certificate: agentChain.Certificate[:1],
+		PrivateKey:  agentChain.PrivateKey,
+	}
+	peerCerts = s.handshake(upstreamCA, agentSVID)
+	s.Assert().Equal(agentSVID.Certificate, rawCerts(peerCerts))
+}
+
+// newCA returns a CA certificate and key, signed by the parent if any or
+// self-signed otherwise.
+func (s *EndpointsTestSuite) newCA(name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	template, err := util.NewCATemplate("example.org")
+	s.Require().NoError(err)
+	template.Subject.CommonName = name
+	if parent == nil {
+		ca, key, err := util.SelfSign(template)
+		s.Require().NoError(err)
+		return ca, key
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	s.Require().NoError(err)
+	template.SerialNumber = serial
+	ca, key, err := util.Sign(template, parent, parentKey)
+	s.Require().NoError(err)
+	return ca, key
+}
+
+// setServerSVID has the server serve an SVID signed by the CA, with the
+// upstream CA as the bundle.
+func (s *EndpointsTestSuite) setServerSVID(upstreamCA, ca *x509.Certificate, caKey *ecdsa.PrivateKey) {
+	template, err := util.NewSVIDTemplate("spiffe://example.org/spire/server")
+	s.Require().NoError(err)
+	serverSVID, serverKey, err := util.Sign(template, ca, caKey)
+	s.Require().NoError(err)
+
+	s.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{
+		TrustDomain: "spiffe://example.org",
+		CaCerts:     upstreamCA.Raw,
+	}, nil).AnyTimes()
+	s.e.svid = serverSVID
+	s.e.svidKey = serverKey
+	s.e.svidCA = ca
+}
+
+// newAgentChain returns an agent SVID signed by the CA, followed by the CA
+// certificate, as agents present it when dialing the server.
+func (s *EndpointsTestSuite) newAgentChain(ca *x509.Certificate, caKey *ecdsa.PrivateKey) tls.Certificate {
+	template, err := util.NewSVIDTemplate("spiffe://example.org/spire/agent/test/node")
+	s.Require().NoError(err)
+	agentSVID, agentKey, err := util.Sign(template, ca, caKey)
+	s.Require().NoError(err)
+	return tls.Certificate{
+		Certificate: [][]byte{agentSVID.Raw, ca.Raw},
+		PrivateKey:  agentKey,
+	}
+}
+
+// handshake performs a TLS handshake between an agent trusting the upstream
+// CA and presenting the chain, and the server. It returns the certificates
+// the agent presented.
+func (s *EndpointsTestSuite) handshake(upstreamCA *x509.Certificate, agentChain tls.Certificate) []*x509.Certificate {
+	agentPeer := &spiffe_tls.TLSPeer{
+		SpiffeIDs:  []string{"spiffe://example.org/spire/server"},
+		TrustRoots: pkgutil.NewCertPool(upstreamCA),
+	}
+	clientConfig := agentPeer.NewTLSConfig([]tls.Certificate{agentChain})
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+	server := tls.Server(serverConn, &tls.Config{
+		GetConfigForClient: s.e.getGRPCServerConfig(ctx),
+	})
+	client := tls.Client(clientConn, clientConfig)
+
+	clientErr := make(chan error, 1)
+	go func() {
+		clientErr <- client.Handshake()
+	}()
+	s.Require().NoError(server.Handshake())
+	s.Require().NoError(<-clientErr)
+
+	return server.ConnectionState().PeerCertificates
+}
+
+func rawCerts(certs []*x509.Certificate) [][]byte {
+	var raw [][]byte
+	for _, cert := range certs {
+		raw = append(raw, cert.Raw)
+	}
+	return raw
+}
+
+type fakeCARotator struct {
+	localauthority.CARotator
+	prev *x509.Certificate
+	next *x509.Certificate
+}
+
+func (r fakeCARotator) PrevCACertificate() *x509.Certificate {
+	return r.prev
+}
+
+func (r fakeCARotator) NextCACertificate() *x509.Certificate {
+	return r.next
+}
+
 func (s *EndpointsTestSuite) TestHTTPServerConfig() {
 	cert, _ := s.expectBundleLookup()
 
@@ -265,3 +470,12 @@ func (s *EndpointsTestSuite) expectBundleLookup() ([]tls.Certificate, *x509.Cert
 
 	return []tls.Certificate{tlsCert}, caPool
 }
+
+// fakeCRLSource returns a canned CRL
+type fakeCRLSource struct {
+	crl []byte
+}
+
+func (s *fakeCRLSource) CRL() []byte {
+	return s.crl
+}
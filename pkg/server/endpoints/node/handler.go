@@ -8,11 +8,20 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/go-spiffe/uri"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tracing"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
+	"github.com/spiffe/spire/pkg/server/signpool"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
@@ -21,19 +30,92 @@ import (
 	"github.com/spiffe/spire/proto/server/nodeattestor"
 	"github.com/spiffe/spire/proto/server/noderesolver"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
+// Prefix of the Node API metrics
+const nodeAPI = "node_api"
+
 type HandlerConfig struct {
 	Log         logrus.FieldLogger
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
+
+	// CSRPolicy validates CSRs before they are signed. If not set, only
+	// the built-in checks are run and CSR policy plugins from the catalog.
+	CSRPolicy *csrpolicy.Policy
+
+	// SignPool bounds how many CSRs are signed at once, across handlers. If
+	// not set, the CSRs are signed without bound.
+	SignPool *signpool.Pool
+
+	// Sink for the Node API metrics. Metrics are discarded if not set.
+	Tel telemetry.Sink
+
+	// Tracer for the calls made to the CA. Calls are not traced if not set.
+	Tracer tracing.Tracer
+
+	// If true, the metrics of the SVIDs issued are labeled by agent and
+	// entry ID. They are not labeled otherwise, since there is a label
+	// value per agent and entry.
+	SVIDMetricLabels bool
+
+	// If true, the CA is an intermediate of the upstream CA and is not in
+	// the trust bundle, so its certificate is sent along with the SVIDs.
+	UpstreamBundle bool
+
+	// Signs JWT-SVIDs with the JWT signing keys published in the bundle
+	JWTSigner JWTSigner
+
+	// If set, the X509-SVIDs signed are recorded so that they can be
+	// revoked, and the CRL is sent along with the bundle.
+	CRLSource CRLSource
+
+	// Notifies of the changes pushed to the agents watching for updates.
+	// WatchUpdates is not served if not set.
+	UpdateNotifier UpdateNotifier
+
+	// Fetches the registration entries agents are authorized for. They are
+	// fetched from the datastore if not set.
+	EntryFetcher EntryFetcher
+}
+
+// JWTSigner signs JWT-SVIDs. It is implemented by the CA manager.
+type JWTSigner interface {
+	SignJWTSVID(ctx context.Context, spiffeID string, audience []string, ttl time.Duration) (string, error)
+}
+
+// CRLSource provides the latest CRL signed by the CA. It is implemented by
+// the CA manager.
+type CRLSource interface {
+	CRL() []byte
+}
+
+// UpdateNotifier notifies of the changes of the registration entries, of the
+// selectors of nodes and of the bundle. It is implemented by the update
+// notifier of the server.
+type UpdateNotifier interface {
+	SubscribeToUpdates() (<-chan struct{}, func())
+}
+
+// EntryFetcher fetches the registration entries the given ID is authorized
+// for. It is implemented by the entry cache.
+type EntryFetcher interface {
+	FetchAuthorizedEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, error)
 }
 
 type Handler struct {
 	c HandlerConfig
 
+	// Number of CSRs received and not yet signed
+	pendingCSRs int64
+
+	// Number of agents watching for updates
+	updateWatchers int64
+
 	// test hooks
 	hooks struct {
 		now func() time.Time
@@ -41,6 +123,16 @@ type Handler struct {
 }
 
 func NewHandler(config HandlerConfig) *Handler {
+	if config.CSRPolicy == nil {
+		config.CSRPolicy = csrpolicy.New(csrpolicy.Config{}, config.Catalog)
+	}
+	if config.Tel == nil {
+		config.Tel = telemetry.Blackhole{}
+	}
+	if config.Tracer == nil {
+		config.Tracer = tracing.Noop{}
+	}
+
 	h := &Handler{
 		c: config,
 	}
@@ -50,8 +142,6 @@ func NewHandler(config HandlerConfig) *Handler {
 
 //Attest attests the node and gets the base node SVID.
 func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
-	serverCA := h.c.Catalog.CAs()[0]
-
 	// make sure node attestor stream will be cancelled if things go awry
 	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
@@ -68,11 +158,19 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return errors.New("Error trying to get SpiffeId from CSR")
 	}
 
-	attestedBefore, err := h.isAttested(ctx, baseSpiffeIDFromCSR)
+	attestedNode, err := h.fetchAttestedNode(ctx, baseSpiffeIDFromCSR)
 	if err != nil {
 		h.c.Log.Error(err)
 		return errors.New("Error trying to check if attested")
 	}
+	if attestedNode != nil && isBanned(attestedNode) {
+		h.c.Log.WithFields(logrus.Fields{
+			log.RPC:      "Attest",
+			log.SPIFFEID: baseSpiffeIDFromCSR,
+		}).Warn("Banned agent tried to attest")
+		return errors.New("Agent is banned")
+	}
+	attestedBefore := attestedNode != nil
 
 	// Pick the right node attestor
 	var attestStream nodeattestor.Attest_Stream
@@ -114,12 +212,35 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return errors.New("Error trying to validate attestation")
 	}
 
-	h.c.Log.Debugf("Signing CSR for Agent SVID %v", baseSpiffeIDFromCSR)
-	signResponse, err := serverCA.SignCsr(ctx, &ca.SignCsrRequest{Csr: request.Csr})
+	ttl, err := h.c.CSRPolicy.Check(ctx, &csrpolicy.Request{
+		CSR:      request.Csr,
+		SpiffeID: baseSpiffeIDFromCSR,
+	})
+	if err != nil {
+		h.c.Log.Error(err)
+		return errors.New("CSR rejected by policy")
+	}
+
+	h.c.Log.WithFields(logrus.Fields{
+		log.RPC:      "Attest",
+		log.SPIFFEID: baseSpiffeIDFromCSR,
+	}).Debug("Signing CSR for agent SVID")
+	var signResponse *ca.SignCsrResponse
+	err = h.inSignPool(ctx, func(ctx context.Context) (err error) {
+		signResponse, err = h.signCSR(ctx, &ca.SignCsrRequest{Csr: request.Csr, Ttl: ttl})
+		return err
+	})
 	if err != nil {
 		h.c.Log.Error(err)
 		return errors.New("Error trying to sign CSR")
 	}
+	h.c.Tel.IncrCounterWithLabels([]string{nodeAPI, "agent_svids_issued"}, 1, h.svidMetricLabels(
+		telemetry.Label{Name: "agent_id", Value: baseSpiffeIDFromCSR},
+	))
+	if err := h.recordIssuedSVID(ctx, signResponse.SignedCertificate, ""); err != nil {
+		h.c.Log.Error(err)
+		return errors.New("Error trying to record issued SVID")
+	}
 
 	if attestedBefore {
 		err = h.updateAttestationEntry(ctx, signResponse.SignedCertificate, baseSpiffeIDFromCSR)
@@ -151,7 +272,12 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 
 	p, ok := peer.FromContext(ctx)
 	if ok {
-		h.c.Log.Infof("Node attestation request from %v completed using strategy %v", p.Addr, request.AttestationData.Type)
+		h.c.Log.WithFields(logrus.Fields{
+			log.RPC:      "Attest",
+			log.Caller:   p.Addr.String(),
+			log.SPIFFEID: baseSpiffeIDFromCSR,
+			"attestor":   request.AttestationData.Type,
+		}).Info("Node attestation request completed")
 	}
 
 	if err := stream.Send(response); err != nil {
@@ -189,16 +315,28 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 		}
 		ctxSpiffeID := uriNames[0]
 
-		regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.DataStores()[0], ctxSpiffeID)
+		// An agent whose attested node entry is gone has been evicted. Let
+		// it know so it stops serving workloads instead of waiting for its
+		// SVIDs to expire.
+		attested, err := h.isAttested(ctx, ctxSpiffeID)
 		if err != nil {
 			h.c.Log.Error(err)
-			return errors.New("Error trying to get registration entries")
+			return errors.New("Error trying to verify agent attestation")
+		}
+		if !attested {
+			h.c.Log.WithFields(logrus.Fields{
+				log.RPC:    "FetchX509SVID",
+				log.Caller: ctxSpiffeID,
+			}).Warn("Agent has been evicted")
+			return server.Send(&node.FetchX509SVIDResponse{
+				AgentStatus: node.AgentStatus_EVICTED,
+			})
 		}
 
-		svids, err := h.signCSRs(ctx, peerCert, request.Csrs, regEntries)
+		regEntries, err := h.fetchRegistrationEntries(ctx, ctxSpiffeID)
 		if err != nil {
 			h.c.Log.Error(err)
-			return errors.New("Error trying sign CSRs")
+			return errors.New("Error trying to get registration entries")
 		}
 
 		bundle, err := h.getBundle(ctx)
@@ -207,11 +345,46 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 			return fmt.Errorf("Error retreiving bundle")
 		}
 
+		// An agent whose SVID was signed by a tainted CA is only allowed to
+		// rotate it. It learns about the tainted CA from the response.
+		csrs := request.Csrs
+		taintedCA, err := taintedIssuer(peerCert, bundle.TaintedCaCerts)
+		if err != nil {
+			h.c.Log.Error(err)
+			return errors.New("Error trying to check the SVID against the tainted CAs")
+		}
+		if taintedCA != nil {
+			h.c.Log.WithFields(logrus.Fields{
+				log.RPC:    "FetchX509SVID",
+				log.Caller: ctxSpiffeID,
+			}).Warnf("Agent SVID was signed by tainted CA certificate %v; only signing its own SVID", taintedCA.SerialNumber)
+			csrs = agentCSRs(csrs, ctxSpiffeID)
+		}
+
+		pending := atomic.AddInt64(&h.pendingCSRs, int64(len(csrs)))
+		h.c.Tel.SetGauge([]string{nodeAPI, "pending_csrs"}, float32(pending))
+		svids, err := h.signCSRs(ctx, peerCert, csrs, regEntries)
+		pending = atomic.AddInt64(&h.pendingCSRs, -int64(len(csrs)))
+		h.c.Tel.SetGauge([]string{nodeAPI, "pending_csrs"}, float32(pending))
+		if err == signpool.ErrQueueFull {
+			h.c.Log.Warn(err)
+			return status.Error(codes.ResourceExhausted, "Too many CSRs waiting to be signed, retry later")
+		}
+		if err != nil {
+			h.c.Log.Error(err)
+			return errors.New("Error trying sign CSRs")
+		}
+
 		err = server.Send(&node.FetchX509SVIDResponse{
 			SvidUpdate: &node.SvidUpdate{
-				Svids:               svids,
-				Bundle:              bundle,
-				RegistrationEntries: regEntries,
+				Svids:                svids,
+				Bundle:               bundle.CaCerts,
+				RegistrationEntries:  regEntries,
+				FederatedBundles:     h.getFederatedBundles(ctx, regEntries),
+				JwtSigningKeys:       bundle.JwtSigningKeys,
+				Crl:                  h.getCRL(),
+				BundleSequenceNumber: bundle.SequenceNumber,
+				TaintedCaCerts:       bundle.TaintedCaCerts,
 			},
 		})
 		if err != nil {
@@ -220,6 +393,110 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 	}
 }
 
+//FetchJWTSVID signs a JWT-SVID for a workload the calling agent is entitled to.
+func (h *Handler) FetchJWTSVID(ctx context.Context, request *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+	if request.Jsr == nil {
+		return nil, errors.New("A JSR is required for this request")
+	}
+	if len(request.Jsr.Audience) == 0 {
+		return nil, errors.New("An audience is required for this request")
+	}
+
+	peerCert, err := h.getCertFromCtx(ctx)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("An SVID is required for this request")
+	}
+
+	uriNames, err := uri.GetURINamesFromCertificate(peerCert)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("An SPIFFE ID is required for this request")
+	}
+	callerID := uriNames[0]
+
+	attested, err := h.isAttested(ctx, callerID)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("Error trying to verify agent attestation")
+	}
+	if !attested {
+		h.c.Log.WithFields(logrus.Fields{
+			log.RPC:    "FetchJWTSVID",
+			log.Caller: callerID,
+		}).Warn("Agent has been evicted")
+		return nil, errors.New("Agent has been evicted")
+	}
+
+	bundle, err := h.getBundle(ctx)
+	if err != nil {
+		h.c.Log.Errorf("Error retreiving bundle from datastore: %v", err)
+		return nil, errors.New("Error retreiving bundle")
+	}
+	taintedCA, err := taintedIssuer(peerCert, bundle.TaintedCaCerts)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("Error trying to check the SVID against the tainted CAs")
+	}
+	if taintedCA != nil {
+		h.c.Log.WithFields(logrus.Fields{
+			log.RPC:    "FetchJWTSVID",
+			log.Caller: callerID,
+		}).Warnf("Agent SVID was signed by tainted CA certificate %v", taintedCA.SerialNumber)
+		return nil, errors.New("Agent SVID was signed by a tainted CA and has to be rotated first")
+	}
+
+	regEntries, err := h.fetchRegistrationEntries(ctx, callerID)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("Error trying to get registration entries")
+	}
+
+	var entry *common.RegistrationEntry
+	for _, regEntry := range regEntries {
+		if regEntry.SpiffeId == request.Jsr.SpiffeId {
+			entry = regEntry
+			break
+		}
+	}
+	if entry == nil {
+		h.c.Log.Errorf("Agent %q is not entitled to a JWT-SVID for %q", callerID, request.Jsr.SpiffeId)
+		return nil, errors.New("Not entitled to sign JWT-SVID")
+	}
+
+	h.c.Log.WithFields(logrus.Fields{
+		log.RPC:      "FetchJWTSVID",
+		log.Caller:   callerID,
+		log.SPIFFEID: entry.SpiffeId,
+	}).Debug("Signing JWT-SVID")
+	signCtx, span := h.c.Tracer.Start(ctx, "ca.SignJWTSVID")
+	span.SetAttribute("spiffe_id", entry.SpiffeId)
+	token, err := h.c.JWTSigner.SignJWTSVID(signCtx, entry.SpiffeId, request.Jsr.Audience,
+		time.Duration(entry.JwtSvidTtl)*time.Second)
+	span.SetError(err)
+	span.End()
+	if err != nil {
+		h.c.Log.Error(err)
+		h.c.Tel.IncrCounter([]string{nodeAPI, "jwt_svid_sign_errors"}, 1)
+		return nil, errors.New("Error trying to sign JWT-SVID")
+	}
+	h.c.Tel.IncrCounter([]string{nodeAPI, "jwt_svids_signed"}, 1)
+
+	issuedAt, expiresAt, err := jwtsvid.GetTokenExpiry(token)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, errors.New("Error trying to sign JWT-SVID")
+	}
+
+	return &node.FetchJWTSVIDResponse{
+		Svid: &node.JWTSVID{
+			Token:     token,
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}, nil
+}
+
 //TODO
 func (h *Handler) FetchFederatedBundle(
 	ctx context.Context, request *node.FetchFederatedBundleRequest) (
@@ -227,7 +504,98 @@ func (h *Handler) FetchFederatedBundle(
 	return response, nil
 }
 
+//WatchUpdates notifies the calling agent every time the registration entries
+//it is authorized for or the bundle change, and once when called.
+func (h *Handler) WatchUpdates(request *node.WatchUpdatesRequest, stream node.Node_WatchUpdatesServer) error {
+	if h.c.UpdateNotifier == nil {
+		return status.Error(codes.Unimplemented, "Updates are not pushed by this server")
+	}
+
+	ctx := stream.Context()
+
+	peerCert, err := h.getCertFromCtx(ctx)
+	if err != nil {
+		h.c.Log.Error(err)
+		return errors.New("An SVID is required for this request")
+	}
+
+	uriNames, err := uri.GetURINamesFromCertificate(peerCert)
+	if err != nil {
+		h.c.Log.Error(err)
+		return errors.New("An SPIFFE ID is required for this request")
+	}
+	callerID := uriNames[0]
+
+	attested, err := h.isAttested(ctx, callerID)
+	if err != nil {
+		h.c.Log.Error(err)
+		return errors.New("Error trying to verify agent attestation")
+	}
+	if !attested {
+		h.c.Log.WithFields(logrus.Fields{
+			log.RPC:    "WatchUpdates",
+			log.Caller: callerID,
+		}).Warn("Agent has been evicted")
+		return status.Error(codes.PermissionDenied, "Agent has been evicted")
+	}
+
+	updates, unsubscribe := h.c.UpdateNotifier.SubscribeToUpdates()
+	defer unsubscribe()
+
+	watchers := atomic.AddInt64(&h.updateWatchers, 1)
+	h.c.Tel.SetGauge([]string{nodeAPI, "update_watchers"}, float32(watchers))
+	defer func() {
+		watchers := atomic.AddInt64(&h.updateWatchers, -1)
+		h.c.Tel.SetGauge([]string{nodeAPI, "update_watchers"}, float32(watchers))
+	}()
+
+	// Most changes concern other agents, so the entries of the caller and
+	// the bundle are compared with those it was last notified of before
+	// notifying it
+	var lastEntries []*common.RegistrationEntry
+	var lastBundle *datastore.Bundle
+	for notified := false; ; notified = true {
+		regEntries, err := h.fetchRegistrationEntries(ctx, callerID)
+		if err != nil {
+			h.c.Log.Error(err)
+			return errors.New("Error trying to get registration entries")
+		}
+		bundle, err := h.getBundle(ctx)
+		if err != nil {
+			h.c.Log.Errorf("Error retrieving bundle from datastore: %v", err)
+			return errors.New("Error retrieving bundle")
+		}
+
+		if !notified || !proto.Equal(bundle, lastBundle) || !entriesEqual(regEntries, lastEntries) {
+			if err := stream.Send(&node.WatchUpdatesResponse{}); err != nil {
+				return err
+			}
+			h.c.Tel.IncrCounter([]string{nodeAPI, "updates_pushed"}, 1)
+			lastEntries = regEntries
+			lastBundle = bundle
+		}
+
+		select {
+		case <-updates:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// isAttested returns true if the node has attested and has not been banned
 func (h *Handler) isAttested(ctx context.Context, baseSpiffeID string) (bool, error) {
+	attestedEntry, err := h.fetchAttestedNode(ctx, baseSpiffeID)
+	if err != nil {
+		return false, err
+	}
+
+	return attestedEntry != nil && !isBanned(attestedEntry), nil
+}
+
+// fetchAttestedNode fetches the attested node entry for the given SPIFFE ID,
+// returning nil if the node has not attested
+func (h *Handler) fetchAttestedNode(ctx context.Context, baseSpiffeID string) (*datastore.AttestedNodeEntry, error) {
 
 	dataStore := h.c.Catalog.DataStores()[0]
 
@@ -236,15 +604,21 @@ func (h *Handler) isAttested(ctx context.Context, baseSpiffeID string) (bool, er
 	}
 	fetchResponse, err := dataStore.FetchAttestedNodeEntry(ctx, fetchRequest)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	attestedEntry := fetchResponse.AttestedNodeEntry
 	if attestedEntry != nil && attestedEntry.BaseSpiffeId == baseSpiffeID {
-		return true, nil
+		return attestedEntry, nil
 	}
 
-	return false, nil
+	return nil, nil
+}
+
+// isBanned returns true if the node has been banned. Banned nodes keep their
+// attested node entry, marked as banned, so they can't attest again.
+func isBanned(attestedEntry *datastore.AttestedNodeEntry) bool {
+	return attestedEntry.Banned
 }
 
 func (h *Handler) doAttestChallengeResponse(ctx context.Context,
@@ -367,6 +741,10 @@ func (h *Handler) updateAttestationEntry(ctx context.Context,
 		BaseSpiffeId:       baseSPIFFEID,
 		CertExpirationDate: cert.NotAfter.Format(time.RFC1123Z),
 		CertSerialNumber:   cert.SerialNumber.String(),
+		InputMask: &datastore.AttestedNodeEntryMask{
+			CertSerialNumber:   true,
+			CertExpirationDate: true,
+		},
 	}
 
 	_, err = dataStore.UpdateAttestedNodeEntry(ctx, updateRequest)
@@ -462,8 +840,11 @@ func (h *Handler) getAttestResponse(ctx context.Context,
 		SvidCert: cert.Raw,
 		Ttl:      int32(h.timeUntil(cert.NotAfter).Seconds()),
 	}
+	if err := h.appendCACert(ctx, svids); err != nil {
+		return nil, err
+	}
 
-	regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.DataStores()[0], baseSpiffeID)
+	regEntries, err := h.fetchRegistrationEntries(ctx, baseSpiffeID)
 	if err != nil {
 		return nil, err
 	}
@@ -474,9 +855,14 @@ func (h *Handler) getAttestResponse(ctx context.Context,
 	}
 
 	svidUpdate := &node.SvidUpdate{
-		Svids:               svids,
-		Bundle:              bundle,
-		RegistrationEntries: regEntries,
+		Svids:                svids,
+		Bundle:               bundle.CaCerts,
+		RegistrationEntries:  regEntries,
+		FederatedBundles:     h.getFederatedBundles(ctx, regEntries),
+		JwtSigningKeys:       bundle.JwtSigningKeys,
+		Crl:                  h.getCRL(),
+		BundleSequenceNumber: bundle.SequenceNumber,
+		TaintedCaCerts:       bundle.TaintedCaCerts,
 	}
 	return &node.AttestResponse{SvidUpdate: svidUpdate}, nil
 }
@@ -516,59 +902,173 @@ func (h *Handler) signCSRs(ctx context.Context,
 		regEntriesMap[entry.SpiffeId] = entry
 	}
 
-	dataStore := h.c.Catalog.DataStores()[0]
+	// The CSRs are signed concurrently, by as many workers as the signing
+	// pool lets sign at once, and the remaining ones are abandoned on the
+	// first failure
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		spiffeID string
+		svid     *node.Svid
+		err      error
+	}
+	results := make([]result, len(csrs))
+	next := make(chan int, len(csrs))
+	for i := range csrs {
+		next <- i
+	}
+	close(next)
+
+	workers := h.signWorkers()
+	if workers > len(csrs) {
+		workers = len(csrs)
+	}
+	wg := new(sync.WaitGroup)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				var r result
+				r.err = h.inSignPool(ctx, func(ctx context.Context) (err error) {
+					r.spiffeID, r.svid, err = h.signAgentCSR(ctx, peerCert, callerID, regEntriesMap, csrs[i])
+					return err
+				})
+				if r.err != nil {
+					cancel()
+				}
+				results[i] = r
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the error that caused the others, rather than a cancellation
+	for _, r := range results {
+		if r.err != nil && r.err != context.Canceled {
+			return nil, r.err
+		}
+	}
+
 	svids = make(map[string]*node.Svid)
-	//iterate the CSRs and sign them
-	for _, csr := range csrs {
-		spiffeID, err := getSpiffeIDFromCSR(csr)
-		if err != nil {
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		svids[r.spiffeID] = r.svid
+	}
+
+	if err := h.appendCACert(ctx, svids); err != nil {
+		return nil, err
+	}
+
+	// The renewed agent SVID is only recorded once the whole batch is
+	// signed, or the agent would be left holding an SVID whose serial number
+	// no longer matches
+	if svid, ok := svids[callerID]; ok && h.isAgentID(callerID) {
+		if err := h.updateAttestationEntry(ctx, svid.SvidCert, callerID); err != nil {
 			return nil, err
 		}
+	}
+	return svids, nil
+}
 
-		baseSpiffeIDPrefix := fmt.Sprintf("%s/spire/agent", h.c.TrustDomain.String())
+// signWorkers returns how many CSRs of a batch are worth signing at once
+func (h *Handler) signWorkers() int {
+	if h.c.SignPool == nil {
+		return 1
+	}
+	return h.c.SignPool.Concurrency()
+}
 
-		if spiffeID == callerID && strings.HasPrefix(callerID, baseSpiffeIDPrefix) {
-			res, err := dataStore.FetchAttestedNodeEntry(ctx,
-				&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: spiffeID},
-			)
-			if err != nil {
-				return nil, err
-			}
-			if res.AttestedNodeEntry.CertSerialNumber != peerCert.SerialNumber.String() {
-				err := errors.New("SVID serial number does not match")
-				return nil, err
-			}
+// inSignPool calls fn once the signing pool has a slot for it, if there is a
+// pool, so that the datastore calls around signing a CSR are bounded along
+// with the CA
+func (h *Handler) inSignPool(ctx context.Context, fn func(context.Context) error) error {
+	if h.c.SignPool == nil {
+		return fn(ctx)
+	}
+	return h.c.SignPool.Sign(ctx, fn)
+}
 
-			h.c.Log.Debugf("Signing SVID for %v on request by %v", spiffeID, callerID)
-			svid, err := h.buildBaseSVID(ctx, csr)
-			if err != nil {
-				return nil, err
-			}
-			svids[spiffeID] = svid
+// isAgentID returns true if the SPIFFE ID is the one of an agent
+func (h *Handler) isAgentID(spiffeID string) bool {
+	return strings.HasPrefix(spiffeID, fmt.Sprintf("%s/spire/agent", h.c.TrustDomain.String()))
+}
 
-			h.updateAttestationEntry(ctx, svid.SvidCert, spiffeID)
-			if err != nil {
-				return nil, err
-			}
+// signAgentCSR signs a CSR sent by an agent, either for its own SVID or for
+// one of the registration entries it is authorized for, returning the SPIFFE
+// ID it is for along with the SVID. A renewed agent SVID is left for the
+// caller to record.
+func (h *Handler) signAgentCSR(ctx context.Context, peerCert *x509.Certificate,
+	callerID string, regEntriesMap map[string]*common.RegistrationEntry, csr []byte) (
+	string, *node.Svid, error) {
 
-		} else {
-			h.c.Log.Debugf("Signing SVID for %v on request by %v", spiffeID, callerID)
-			svid, err := h.buildSVID(ctx, spiffeID, regEntriesMap, csr)
-			if err != nil {
-				return nil, err
-			}
-			svids[spiffeID] = svid
+	spiffeID, err := getSpiffeIDFromCSR(csr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if spiffeID == callerID && h.isAgentID(callerID) {
+		res, err := h.c.Catalog.DataStores()[0].FetchAttestedNodeEntry(ctx,
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: spiffeID},
+		)
+		if err != nil {
+			return "", nil, err
+		}
+		if isBanned(res.AttestedNodeEntry) {
+			return "", nil, errors.New("agent is banned")
 		}
+		if res.AttestedNodeEntry.CertSerialNumber != peerCert.SerialNumber.String() {
+			err := errors.New("SVID serial number does not match")
+			return "", nil, err
+		}
+
+		h.c.Log.WithFields(logrus.Fields{
+			log.RPC:      "FetchX509SVID",
+			log.Caller:   callerID,
+			log.SPIFFEID: spiffeID,
+		}).Debug("Signing SVID")
+		svid, err := h.buildBaseSVID(ctx, spiffeID, csr)
+		if err != nil {
+			return "", nil, err
+		}
+		h.c.Tel.IncrCounterWithLabels([]string{nodeAPI, "agent_svids_renewed"}, 1, h.svidMetricLabels(
+			telemetry.Label{Name: "agent_id", Value: callerID},
+		))
+		return spiffeID, svid, nil
 	}
 
-	return svids, nil
+	h.c.Log.WithFields(logrus.Fields{
+		log.RPC:      "FetchX509SVID",
+		log.Caller:   callerID,
+		log.SPIFFEID: spiffeID,
+	}).Debug("Signing SVID")
+	svid, err := h.buildSVID(ctx, spiffeID, regEntriesMap, csr)
+	if err != nil {
+		return "", nil, err
+	}
+	h.c.Tel.IncrCounterWithLabels([]string{nodeAPI, "workload_svids_issued"}, 1, h.svidMetricLabels(
+		telemetry.Label{Name: "entry_id", Value: regEntriesMap[spiffeID].EntryId},
+		telemetry.Label{Name: "agent_id", Value: callerID},
+	))
+	return spiffeID, svid, nil
+}
+
+// svidMetricLabels returns the labels of the metrics of the SVIDs issued,
+// or none if they are not enabled.
+func (h *Handler) svidMetricLabels(labels ...telemetry.Label) []telemetry.Label {
+	if !h.c.SVIDMetricLabels {
+		return nil
+	}
+	return labels
 }
 
 func (h *Handler) buildSVID(ctx context.Context,
 	spiffeID string, regEntries map[string]*common.RegistrationEntry, csr []byte) (
 	*node.Svid, error) {
 
-	serverCA := h.c.Catalog.CAs()[0]
 	//TODO: Validate that other fields are not populated https://github.com/spiffe/spire/issues/161
 	//validate that is present in the registration entries, otherwise we shouldn't sign
 	entry, ok := regEntries[spiffeID]
@@ -577,21 +1077,44 @@ func (h *Handler) buildSVID(ctx context.Context,
 		return nil, err
 	}
 
-	signReq := &ca.SignCsrRequest{Csr: csr, Ttl: entry.Ttl}
-	signResponse, err := serverCA.SignCsr(ctx, signReq)
+	ttl, err := h.c.CSRPolicy.Check(ctx, &csrpolicy.Request{
+		CSR:      csr,
+		SpiffeID: spiffeID,
+		TTL:      entry.Ttl,
+		Entry:    entry,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &node.Svid{SvidCert: signResponse.SignedCertificate, Ttl: entry.Ttl}, nil
+
+	signReq := &ca.SignCsrRequest{Csr: csr, Ttl: ttl}
+	signResponse, err := h.signCSR(ctx, signReq)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.recordIssuedSVID(ctx, signResponse.SignedCertificate, entry.EntryId); err != nil {
+		return nil, err
+	}
+	return &node.Svid{SvidCert: signResponse.SignedCertificate, Ttl: ttl}, nil
 }
 
-func (h *Handler) buildBaseSVID(ctx context.Context, csr []byte) (*node.Svid, error) {
-	serverCA := h.c.Catalog.CAs()[0]
-	signReq := &ca.SignCsrRequest{Csr: csr}
-	signResponse, err := serverCA.SignCsr(ctx, signReq)
+func (h *Handler) buildBaseSVID(ctx context.Context, spiffeID string, csr []byte) (*node.Svid, error) {
+	ttl, err := h.c.CSRPolicy.Check(ctx, &csrpolicy.Request{
+		CSR:      csr,
+		SpiffeID: spiffeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signReq := &ca.SignCsrRequest{Csr: csr, Ttl: ttl}
+	signResponse, err := h.signCSR(ctx, signReq)
 	if err != nil {
 		return nil, err
 	}
+	if err := h.recordIssuedSVID(ctx, signResponse.SignedCertificate, ""); err != nil {
+		return nil, err
+	}
 
 	// Parse base SVID to approximate TTL
 	cert, err := x509.ParseCertificate(signResponse.SignedCertificate)
@@ -605,8 +1128,118 @@ func (h *Handler) buildBaseSVID(ctx context.Context, csr []byte) (*node.Svid, er
 	}, nil
 }
 
-// getBundle fetches the current CA bundle from the datastore.
-func (h *Handler) getBundle(ctx context.Context) ([]byte, error) {
+// entriesEqual returns true if both sorted lists of registration entries
+// hold the same entries
+func entriesEqual(a, b []*common.RegistrationEntry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// signCSR has the server CA sign an X509-SVID, reporting how long it took
+// and whether it succeeded, and tracing the call.
+func (h *Handler) signCSR(ctx context.Context, req *ca.SignCsrRequest) (*ca.SignCsrResponse, error) {
+	defer h.c.Tel.MeasureSince([]string{nodeAPI, "x509_svid_sign_latency"}, time.Now())
+
+	ctx, span := h.c.Tracer.Start(ctx, "ca.SignCsr")
+	defer span.End()
+
+	resp, err := h.c.Catalog.CAs()[0].SignCsr(ctx, req)
+	span.SetError(err)
+	if err != nil {
+		h.c.Tel.IncrCounter([]string{nodeAPI, "x509_svid_sign_errors"}, 1)
+		return nil, err
+	}
+	h.c.Tel.IncrCounter([]string{nodeAPI, "x509_svids_signed"}, 1)
+	return resp, nil
+}
+
+// recordIssuedSVID records an X509-SVID signed for the given entry, or for an
+// agent if there is none, so that it can be revoked. X509-SVIDs are only
+// recorded when CRLs are enabled.
+func (h *Handler) recordIssuedSVID(ctx context.Context, svidCert []byte, entryID string) error {
+	if h.c.CRLSource == nil {
+		return nil
+	}
+
+	cert, err := x509.ParseCertificate(svidCert)
+	if err != nil {
+		return err
+	}
+	uriNames, err := uri.GetURINamesFromCertificate(cert)
+	if err != nil {
+		return err
+	}
+
+	_, err = h.c.Catalog.DataStores()[0].CreateIssuedSVID(ctx, &datastore.IssuedSVID{
+		SerialNumber: cert.SerialNumber.String(),
+		SpiffeId:     uriNames[0],
+		EntryId:      entryID,
+		Expiry:       cert.NotAfter.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("record issued svid: %v", err)
+	}
+	return nil
+}
+
+// fetchRegistrationEntries returns the registration entries the given ID is
+// authorized for, from the entry fetcher if set
+func (h *Handler) fetchRegistrationEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, error) {
+	if h.c.EntryFetcher != nil {
+		return h.c.EntryFetcher.FetchAuthorizedEntries(ctx, id)
+	}
+	return regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.DataStores()[0], id)
+}
+
+// getCRL returns the latest CRL signed by the CA, or nil if CRLs are not
+// enabled.
+func (h *Handler) getCRL() []byte {
+	if h.c.CRLSource == nil {
+		return nil
+	}
+	return h.c.CRLSource.CRL()
+}
+
+// appendCACert appends the certificate of the CA to the SVIDs when the CA is
+// an intermediate of the upstream CA, so that they chain up to the upstream
+// CA certificates in the trust bundle.
+func (h *Handler) appendCACert(ctx context.Context, svids map[string]*node.Svid) error {
+	if !h.c.UpstreamBundle || len(svids) == 0 {
+		return nil
+	}
+
+	resp, err := h.c.Catalog.CAs()[0].FetchCertificate(ctx, &ca.FetchCertificateRequest{})
+	if err != nil {
+		return fmt.Errorf("fetch ca certificate: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(resp.StoredIntermediateCert)
+	if err != nil {
+		return fmt.Errorf("parse ca certificate: %v", err)
+	}
+
+	for spiffeID, svid := range svids {
+		cert, err := x509.ParseCertificate(svid.SvidCert)
+		if err != nil {
+			return err
+		}
+		// The CA may have been rotated since the SVID was signed
+		if err := cert.CheckSignatureFrom(caCert); err != nil {
+			return fmt.Errorf("SVID of %q was not signed by the current ca: %v", spiffeID, err)
+		}
+		svid.SvidCert = append(svid.SvidCert, caCert.Raw...)
+	}
+	return nil
+}
+
+// getBundle fetches the current bundle from the datastore.
+func (h *Handler) getBundle(ctx context.Context) (*datastore.Bundle, error) {
 	ds := h.c.Catalog.DataStores()[0]
 	req := &datastore.Bundle{
 		TrustDomain: h.c.TrustDomain.String(),
@@ -616,7 +1249,37 @@ func (h *Handler) getBundle(ctx context.Context) ([]byte, error) {
 		return nil, fmt.Errorf("get bundle from datastore: %v", err)
 	}
 
-	return b.CaCerts, nil
+	return b, nil
+}
+
+// getFederatedBundles fetches the CA bundles of the trust domains the given
+// registration entries federate with, keyed by trust domain SPIFFE ID.
+// Bundles that cannot be fetched are logged and left out so that a missing
+// federated bundle does not prevent the agent from receiving its SVIDs.
+func (h *Handler) getFederatedBundles(ctx context.Context, regEntries []*common.RegistrationEntry) map[string][]byte {
+	ds := h.c.Catalog.DataStores()[0]
+
+	var federatedBundles map[string][]byte
+	for _, entry := range regEntries {
+		for _, trustDomain := range entry.FederatesWith {
+			if _, ok := federatedBundles[trustDomain]; ok {
+				continue
+			}
+
+			b, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: trustDomain})
+			if err != nil {
+				h.c.Log.Warnf("Error retrieving federated bundle %q from datastore: %v", trustDomain, err)
+				continue
+			}
+
+			if federatedBundles == nil {
+				federatedBundles = make(map[string][]byte)
+			}
+			federatedBundles[trustDomain] = b.CaCerts
+		}
+	}
+
+	return federatedBundles
 }
 
 // timeUntil determines how much time until a date. It utilizes the test hook
@@ -626,6 +1289,35 @@ func (h *Handler) timeUntil(t time.Time) time.Duration {
 }
 
 //TODO: put this into go-spiffe uri?
+// taintedIssuer returns the tainted CA which signed the given SVID, if any,
+// among the tainted CA certificates, ASN.1 DER encoded
+func taintedIssuer(svid *x509.Certificate, taintedCACerts []byte) (*x509.Certificate, error) {
+	if len(taintedCACerts) == 0 {
+		return nil, nil
+	}
+	caCerts, err := x509.ParseCertificates(taintedCACerts)
+	if err != nil {
+		return nil, fmt.Errorf("parse tainted CA certificates: %v", err)
+	}
+	for _, caCert := range caCerts {
+		if svid.CheckSignatureFrom(caCert) == nil {
+			return caCert, nil
+		}
+	}
+	return nil, nil
+}
+
+// agentCSRs returns the CSRs for the SVID of the calling agent itself
+func agentCSRs(csrs [][]byte, callerID string) [][]byte {
+	var agentCSRs [][]byte
+	for _, csr := range csrs {
+		if spiffeID, err := getSpiffeIDFromCSR(csr); err == nil && spiffeID == callerID {
+			agentCSRs = append(agentCSRs, csr)
+		}
+	}
+	return agentCSRs
+}
+
 func getSpiffeIDFromCSR(csr []byte) (spiffeID string, err error) {
 	var parsedCSR *x509.CertificateRequest
 	if parsedCSR, err = x509.ParseCertificateRequest(csr); err != nil {
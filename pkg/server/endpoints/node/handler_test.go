@@ -5,15 +5,20 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"path"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/api/node"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/ca"
@@ -31,8 +36,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -40,6 +47,11 @@ var (
 		Scheme: "spiffe",
 		Host:   "example.org",
 	}
+
+	testJWTSigningKey = &common.PublicKey{
+		PkixBytes: []byte("PKIX"),
+		Kid:       "KID",
+	}
 )
 
 type HandlerTestSuite struct {
@@ -53,9 +65,44 @@ type HandlerTestSuite struct {
 	mockNodeResolver *mock_noderesolver.MockNodeResolver
 	mockContext      *mock_context.MockContext
 	server           *mock_node.MockNode_FetchX509SVIDServer
+	jwtSigner        *fakeJWTSigner
 	now              time.Time
 }
 
+// fakeJWTSigner records the JWT-SVID signing requests and returns a canned
+// token
+type fakeJWTSigner struct {
+	spiffeID string
+	audience []string
+	ttl      time.Duration
+	token    string
+}
+
+func (s *fakeJWTSigner) SignJWTSVID(ctx context.Context, spiffeID string, audience []string, ttl time.Duration) (string, error) {
+	s.spiffeID = spiffeID
+	s.audience = audience
+	s.ttl = ttl
+	return s.token, nil
+}
+
+// fakeCRLSource returns a canned CRL
+type fakeCRLSource struct {
+	crl []byte
+}
+
+func (s *fakeCRLSource) CRL() []byte {
+	return s.crl
+}
+
+// fakeUpdateNotifier notifies of the updates sent on its channel
+type fakeUpdateNotifier struct {
+	updates chan struct{}
+}
+
+func (n *fakeUpdateNotifier) SubscribeToUpdates() (<-chan struct{}, func()) {
+	return n.updates, func() {}
+}
+
 func SetupHandlerTest(t *testing.T) *HandlerTestSuite {
 	suite := &HandlerTestSuite{}
 	suite.SetT(t)
@@ -68,6 +115,7 @@ func SetupHandlerTest(t *testing.T) *HandlerTestSuite {
 	suite.mockNodeResolver = mock_noderesolver.NewMockNodeResolver(mockCtrl)
 	suite.mockContext = mock_context.NewMockContext(mockCtrl)
 	suite.server = mock_node.NewMockNode_FetchX509SVIDServer(suite.ctrl)
+	suite.jwtSigner = &fakeJWTSigner{}
 	suite.now = time.Now()
 
 	catalog := fakeservercatalog.New()
@@ -80,6 +128,7 @@ func SetupHandlerTest(t *testing.T) *HandlerTestSuite {
 		Log:         log,
 		Catalog:     catalog,
 		TrustDomain: testTrustDomain,
+		JWTSigner:   suite.jwtSigner,
 	})
 	suite.handler.hooks.now = func() time.Time {
 		return suite.now
@@ -140,6 +189,31 @@ func TestAttestChallengeResponse(t *testing.T) {
 	suite.NoError(suite.handler.Attest(stream))
 }
 
+func TestAttestBannedAgent(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getAttestTestData()
+	suite.mockDataStore.EXPECT().FetchAttestedNodeEntry(gomock.Any(),
+		&datastore.FetchAttestedNodeEntryRequest{
+			BaseSpiffeId: data.baseSpiffeID,
+		}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:        data.baseSpiffeID,
+				AttestationDataType: "fake_nodeattestor_1",
+				CertSerialNumber:    "18392437442709699290",
+				Banned:              true,
+			},
+		}, nil)
+
+	stream := mock_node.NewMockNode_AttestServer(suite.ctrl)
+	stream.EXPECT().Context().Return(context.Background())
+	stream.EXPECT().Recv().Return(data.request, nil)
+
+	suite.EqualError(suite.handler.Attest(stream), "Agent is banned")
+}
+
 func TestFetchX509SVID(t *testing.T) {
 	suite := SetupHandlerTest(t)
 	defer suite.ctrl.Finish()
@@ -201,6 +275,464 @@ func TestFetchX509SVIDWithRotation(t *testing.T) {
 
 }
 
+func TestFetchX509SVIDRotationNotRecordedOnFailure(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+	data.request.Csrs = [][]byte{
+		getBytesFromPem("base_rotated_csr.pem"),
+		getBytesFromPem("database_csr.pem"),
+	}
+
+	caCert, _, err := util.LoadCAFixture()
+	require.NoError(t, err)
+
+	suite.server.EXPECT().Context().Return(suite.mockContext)
+	suite.server.EXPECT().Recv().Return(data.request, nil)
+	suite.mockContext.EXPECT().Value(gomock.Any()).Return(getFakePeer())
+	suite.mockContext.EXPECT().Done().AnyTimes()
+
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: "18392437442709699290",
+			},
+		}, nil).
+		Times(2)
+	setFetchRegistrationEntriesExpectations(suite, data)
+	suite.mockDataStore.EXPECT().
+		FetchBundle(gomock.Any(), &datastore.Bundle{
+			TrustDomain: testTrustDomain.String()}).
+		Return(&datastore.Bundle{
+			TrustDomain: testTrustDomain.String(),
+			CaCerts:     caCert.Raw}, nil)
+
+	// The agent SVID is signed, but the workload SVID of the same sync is
+	// not, so the agent keeps its current SVID and its serial number is not
+	// updated
+	suite.mockServerCA.EXPECT().
+		SignCsr(gomock.Any(), &ca.SignCsrRequest{Csr: data.request.Csrs[0]}).
+		Return(&ca.SignCsrResponse{SignedCertificate: getBytesFromPem("base_rotated_cert.pem")}, nil)
+	suite.mockServerCA.EXPECT().
+		SignCsr(gomock.Any(), &ca.SignCsrRequest{
+			Csr: data.request.Csrs[1], Ttl: data.byParentIDEntries[0].Ttl,
+		}).
+		Return(nil, errors.New("oh no"))
+
+	require.EqualError(t, suite.handler.FetchX509SVID(suite.server), "Error trying sign CSRs")
+}
+
+func TestFetchX509SVIDWithCRL(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+	suite.handler.c.CRLSource = &fakeCRLSource{crl: []byte("CRL")}
+
+	data := getFetchX509SVIDTestData()
+	for i, entry := range data.byParentIDEntries {
+		entry.EntryId = fmt.Sprintf("entry%d", i)
+	}
+	data.expectation = getExpectedFetchX509SVID(data)
+	data.expectation.Crl = []byte("CRL")
+	setFetchX509SVIDExpectations(suite, data)
+
+	// The SVIDs signed are recorded along with their entries. The CSRs are
+	// signed concurrently, so they are recorded in any order.
+	mtx := new(sync.Mutex)
+	issued := make(map[string]*datastore.IssuedSVID)
+	suite.mockDataStore.EXPECT().CreateIssuedSVID(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, svid *datastore.IssuedSVID) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			issued[svid.SpiffeId] = svid
+		}).
+		Return(&datastore.IssuedSVID{}, nil).
+		Times(3)
+
+	require.NoError(t, suite.handler.FetchX509SVID(suite.server))
+
+	require.Len(t, issued, 3)
+	for i, spiffeID := range []string{data.nodeSpiffeID, data.databaseSpiffeID, data.blogSpiffeID} {
+		cert, err := x509.ParseCertificate(data.generatedCerts[i])
+		require.NoError(t, err)
+		svid := issued[spiffeID]
+		require.NotNil(t, svid, spiffeID)
+		require.Equal(t, cert.SerialNumber.String(), svid.SerialNumber)
+		require.Equal(t, cert.NotAfter.Unix(), svid.Expiry)
+	}
+	require.Equal(t, "entry2", issued[data.nodeSpiffeID].EntryId)
+	require.Equal(t, "entry0", issued[data.databaseSpiffeID].EntryId)
+	require.Equal(t, "entry1", issued[data.blogSpiffeID].EntryId)
+}
+
+func TestFetchX509SVIDEvictedAgent(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+
+	suite.server.EXPECT().Context().Return(suite.mockContext)
+	suite.server.EXPECT().Recv().Return(data.request, nil)
+	suite.mockContext.EXPECT().Value(gomock.Any()).Return(getFakePeer())
+
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{}, nil)
+
+	suite.server.EXPECT().Send(&node.FetchX509SVIDResponse{
+		AgentStatus: node.AgentStatus_EVICTED,
+	}).
+		Return(nil)
+
+	err := suite.handler.FetchX509SVID(suite.server)
+	require.NoError(t, err)
+}
+
+func TestFetchX509SVIDBannedAgent(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+
+	suite.server.EXPECT().Context().Return(suite.mockContext)
+	suite.server.EXPECT().Recv().Return(data.request, nil)
+	suite.mockContext.EXPECT().Value(gomock.Any()).Return(getFakePeer())
+
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: "18392437442709699290",
+				Banned:           true,
+			},
+		}, nil)
+
+	suite.server.EXPECT().Send(&node.FetchX509SVIDResponse{
+		AgentStatus: node.AgentStatus_EVICTED,
+	}).
+		Return(nil)
+
+	err := suite.handler.FetchX509SVID(suite.server)
+	require.NoError(t, err)
+}
+
+func TestFetchX509SVIDTaintedAgent(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+	caCert, agentSVID := newTaintedAgentSVID(t, data.baseSpiffeID)
+	data.request.Csrs = append(data.request.Csrs, getBytesFromPem("base_rotated_csr.pem"))
+	rotatedCert := getBytesFromPem("base_rotated_cert.pem")
+
+	suite.server.EXPECT().Context().Return(suite.mockContext)
+	suite.server.EXPECT().Recv().Return(data.request, nil)
+	suite.mockContext.EXPECT().Value(gomock.Any()).Return(newPeer(agentSVID))
+	suite.mockContext.EXPECT().Done().AnyTimes()
+
+	// The node entry is fetched to check the agent is attested, then to
+	// check the serial number of the SVID it rotates
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: agentSVID.SerialNumber.String(),
+			},
+		}, nil).Times(2)
+	setFetchRegistrationEntriesExpectations(suite, data)
+	suite.mockDataStore.EXPECT().
+		FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: testTrustDomain.String()}).
+		Return(&datastore.Bundle{
+			TrustDomain:    testTrustDomain.String(),
+			CaCerts:        caCert.Raw,
+			TaintedCaCerts: caCert.Raw,
+		}, nil)
+
+	// Only the SVID of the agent itself is signed
+	suite.mockServerCA.EXPECT().
+		SignCsr(gomock.Any(), &ca.SignCsrRequest{Csr: data.request.Csrs[3]}).
+		Return(&ca.SignCsrResponse{SignedCertificate: rotatedCert}, nil)
+	suite.mockDataStore.EXPECT().
+		UpdateAttestedNodeEntry(gomock.Any(), gomock.Any()).
+		Return(&datastore.UpdateAttestedNodeEntryResponse{}, nil)
+
+	cert, err := x509.ParseCertificate(rotatedCert)
+	require.NoError(t, err)
+	suite.server.EXPECT().Send(gomock.Any()).Do(func(resp *node.FetchX509SVIDResponse) {
+		require.Equal(t, map[string]*node.Svid{
+			data.baseSpiffeID: {SvidCert: rotatedCert, Ttl: int32(cert.NotAfter.Sub(suite.now).Seconds())},
+		}, resp.SvidUpdate.Svids)
+		require.Equal(t, caCert.Raw, resp.SvidUpdate.TaintedCaCerts)
+	}).Return(nil)
+	suite.server.EXPECT().Recv().Return(nil, io.EOF)
+
+	require.NoError(t, suite.handler.FetchX509SVID(suite.server))
+}
+
+func TestFetchJWTSVID(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+	data.byParentIDEntries[0].JwtSvidTtl = 60
+	setFetchJWTSVIDExpectations(suite, data)
+
+	caCert, caKey, err := util.LoadCAFixture()
+	require.NoError(t, err)
+	keyID, err := jwtsvid.KeyID(caCert.PublicKey)
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	token, err := jwtsvid.SignToken(data.databaseSpiffeID, []string{"audience"}, expiresAt, caKey, keyID)
+	require.NoError(t, err)
+
+	suite.jwtSigner.token = token
+
+	ctx := peer.NewContext(context.Background(), getFakePeer())
+	resp, err := suite.handler.FetchJWTSVID(ctx, &node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{
+			SpiffeId: data.databaseSpiffeID,
+			Audience: []string{"audience"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, token, resp.Svid.Token)
+	require.Equal(t, expiresAt.Unix(), resp.Svid.ExpiresAt)
+	require.NotZero(t, resp.Svid.IssuedAt)
+	require.Equal(t, data.databaseSpiffeID, suite.jwtSigner.spiffeID)
+	require.Equal(t, []string{"audience"}, suite.jwtSigner.audience)
+	require.Equal(t, time.Minute, suite.jwtSigner.ttl)
+}
+
+func TestFetchJWTSVIDNotEntitled(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+	setFetchJWTSVIDExpectations(suite, data)
+
+	ctx := peer.NewContext(context.Background(), getFakePeer())
+	_, err := suite.handler.FetchJWTSVID(ctx, &node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{
+			SpiffeId: "spiffe://example.org/other",
+			Audience: []string{"audience"},
+		},
+	})
+	require.EqualError(t, err, "Not entitled to sign JWT-SVID")
+}
+
+func TestFetchJWTSVIDTaintedAgent(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	data := getFetchX509SVIDTestData()
+	caCert, agentSVID := newTaintedAgentSVID(t, data.baseSpiffeID)
+
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: agentSVID.SerialNumber.String(),
+			},
+		}, nil)
+	suite.mockDataStore.EXPECT().
+		FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: testTrustDomain.String()}).
+		Return(&datastore.Bundle{
+			TrustDomain:    testTrustDomain.String(),
+			CaCerts:        caCert.Raw,
+			TaintedCaCerts: caCert.Raw,
+		}, nil)
+
+	ctx := peer.NewContext(context.Background(), newPeer(agentSVID))
+	_, err := suite.handler.FetchJWTSVID(ctx, &node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{
+			SpiffeId: data.databaseSpiffeID,
+			Audience: []string{"audience"},
+		},
+	})
+	require.EqualError(t, err, "Agent SVID was signed by a tainted CA and has to be rotated first")
+}
+
+func TestFetchJWTSVIDWithoutAudience(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	_, err := suite.handler.FetchJWTSVID(context.Background(), &node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{SpiffeId: "spiffe://example.org/database"},
+	})
+	require.EqualError(t, err, "An audience is required for this request")
+}
+
+func TestWatchUpdatesWithoutNotifier(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	stream := mock_node.NewMockNode_WatchUpdatesServer(suite.ctrl)
+	err := suite.handler.WatchUpdates(&node.WatchUpdatesRequest{}, stream)
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestWatchUpdatesEvictedAgent(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+	suite.handler.c.UpdateNotifier = &fakeUpdateNotifier{}
+
+	data := getFetchX509SVIDTestData()
+
+	stream := mock_node.NewMockNode_WatchUpdatesServer(suite.ctrl)
+	stream.EXPECT().Context().Return(peer.NewContext(context.Background(), getFakePeer()))
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{}, nil)
+
+	err := suite.handler.WatchUpdates(&node.WatchUpdatesRequest{}, stream)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestWatchUpdates(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+
+	notifier := &fakeUpdateNotifier{
+		updates: make(chan struct{}),
+	}
+	suite.handler.c.UpdateNotifier = notifier
+
+	data := getFetchX509SVIDTestData()
+
+	ctx, cancel := context.WithCancel(peer.NewContext(context.Background(), getFakePeer()))
+	defer cancel()
+
+	stream := mock_node.NewMockNode_WatchUpdatesServer(suite.ctrl)
+	stream.EXPECT().Context().Return(ctx)
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: "18392437442709699290",
+			},
+		}, nil)
+
+	// The entries of the agent don't change, the bundle does on the third
+	// fetch
+	suite.mockDataStore.EXPECT().
+		ListParentIDEntries(gomock.Any(), &datastore.ListParentIDEntriesRequest{ParentId: data.baseSpiffeID}).
+		Return(&datastore.ListParentIDEntriesResponse{
+			RegisteredEntryList: data.byParentIDEntries[:1],
+		}, nil).
+		Times(3)
+	suite.mockDataStore.EXPECT().
+		ListParentIDEntries(gomock.Any(), &datastore.ListParentIDEntriesRequest{ParentId: data.byParentIDEntries[0].SpiffeId}).
+		Return(&datastore.ListParentIDEntriesResponse{}, nil).
+		Times(3)
+	suite.mockDataStore.EXPECT().
+		FetchNodeResolverMapEntry(gomock.Any(), gomock.Any()).
+		Return(&datastore.FetchNodeResolverMapEntryResponse{}, nil).
+		Times(6)
+	gomock.InOrder(
+		suite.mockDataStore.EXPECT().
+			FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: testTrustDomain.String()}).
+			Return(&datastore.Bundle{TrustDomain: testTrustDomain.String(), CaCerts: []byte{1}}, nil).
+			Times(2),
+		suite.mockDataStore.EXPECT().
+			FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: testTrustDomain.String()}).
+			Return(&datastore.Bundle{TrustDomain: testTrustDomain.String(), CaCerts: []byte{1, 2}}, nil),
+	)
+
+	sent := make(chan struct{})
+	stream.EXPECT().Send(&node.WatchUpdatesResponse{}).
+		Do(func(*node.WatchUpdatesResponse) {
+			sent <- struct{}{}
+		}).
+		Return(nil).
+		Times(2)
+
+	done := make(chan error)
+	go func() {
+		done <- suite.handler.WatchUpdates(&node.WatchUpdatesRequest{}, stream)
+	}()
+
+	util.RunWithTimeout(t, 5*time.Second, func() {
+		// Notified once when called
+		<-sent
+
+		// Updates that don't concern the agent are not notified. The
+		// second one is only received once the first one is handled.
+		notifier.updates <- struct{}{}
+		notifier.updates <- struct{}{}
+		<-sent
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func TestEntriesEqual(t *testing.T) {
+	a := []*common.RegistrationEntry{{SpiffeId: "spiffe://example.org/a"}}
+	b := []*common.RegistrationEntry{{SpiffeId: "spiffe://example.org/b"}}
+
+	require.True(t, entriesEqual(nil, nil))
+	require.True(t, entriesEqual(a, []*common.RegistrationEntry{{SpiffeId: "spiffe://example.org/a"}}))
+	require.False(t, entriesEqual(a, b))
+	require.False(t, entriesEqual(a, append(a, b...)))
+}
+
+func TestSVIDMetricLabels(t *testing.T) {
+	label := telemetry.Label{Name: "agent_id", Value: "spiffe://example.org/spire/agent/join_token/abcd"}
+
+	h := &Handler{}
+	require.Empty(t, h.svidMetricLabels(label))
+
+	h.c.SVIDMetricLabels = true
+	require.Equal(t, []telemetry.Label{label}, h.svidMetricLabels(label))
+}
+
+func TestAppendCACert(t *testing.T) {
+	suite := SetupHandlerTest(t)
+	defer suite.ctrl.Finish()
+	suite.handler.c.UpstreamBundle = true
+
+	caTemplate, err := util.NewCATemplate(testTrustDomain.Host)
+	require.NoError(t, err)
+	caCert, caKey, err := util.SelfSign(caTemplate)
+	require.NoError(t, err)
+	svidTemplate, err := util.NewSVIDTemplate("spiffe://example.org/database")
+	require.NoError(t, err)
+	svidCert, _, err := util.Sign(svidTemplate, caCert, caKey)
+	require.NoError(t, err)
+
+	suite.mockServerCA.EXPECT().
+		FetchCertificate(gomock.Any(), &ca.FetchCertificateRequest{}).
+		Return(&ca.FetchCertificateResponse{StoredIntermediateCert: caCert.Raw}, nil).
+		Times(2)
+
+	svids := map[string]*node.Svid{
+		"spiffe://example.org/database": {SvidCert: svidCert.Raw},
+	}
+	require.NoError(t, suite.handler.appendCACert(context.Background(), svids))
+	require.Equal(t, append(svidCert.Raw, caCert.Raw...), svids["spiffe://example.org/database"].SvidCert)
+
+	// SVIDs signed by a previous CA are rejected
+	svids = map[string]*node.Svid{
+		"spiffe://example.org/blog": {SvidCert: getBytesFromPem("blog_cert.pem")},
+	}
+	err = suite.handler.appendCACert(context.Background(), svids)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `SVID of "spiffe://example.org/blog" was not signed by the current ca`)
+}
+
 func getBytesFromPem(fileName string) []byte {
 	pemFile, _ := ioutil.ReadFile(path.Join("../../../../test/fixture/certs", fileName))
 	decodedFile, _ := pem.Decode(pemFile)
@@ -547,9 +1079,57 @@ func setFetchX509SVIDExpectations(
 	suite.server.EXPECT().Recv().Return(data.request, nil)
 
 	suite.mockContext.EXPECT().Value(gomock.Any()).Return(getFakePeer())
+	// The CSRs are signed under a context cancelled on the first failure
+	suite.mockContext.EXPECT().Done().AnyTimes()
 
-	// begin FetchRegistrationEntries()
+	suite.mockDataStore.EXPECT().
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: "18392437442709699290",
+			},
+		}, nil)
+
+	setFetchRegistrationEntriesExpectations(suite, data)
 
+	suite.mockDataStore.EXPECT().
+		FetchBundle(gomock.Any(), &datastore.Bundle{
+			TrustDomain: testTrustDomain.String()}).
+		Return(&datastore.Bundle{
+			TrustDomain:    testTrustDomain.String(),
+			CaCerts:        caCert.Raw,
+			JwtSigningKeys: []*common.PublicKey{testJWTSigningKey}}, nil)
+
+	suite.mockServerCA.EXPECT().
+		SignCsr(gomock.Any(), &ca.SignCsrRequest{
+			Csr: data.request.Csrs[0], Ttl: data.byParentIDEntries[2].Ttl,
+		}).
+		Return(&ca.SignCsrResponse{SignedCertificate: data.generatedCerts[0]}, nil)
+
+	suite.mockServerCA.EXPECT().
+		SignCsr(gomock.Any(), &ca.SignCsrRequest{
+			Csr: data.request.Csrs[1], Ttl: data.byParentIDEntries[0].Ttl,
+		}).
+		Return(&ca.SignCsrResponse{SignedCertificate: data.generatedCerts[1]}, nil)
+
+	suite.mockServerCA.EXPECT().
+		SignCsr(gomock.Any(), &ca.SignCsrRequest{
+			Csr: data.request.Csrs[2], Ttl: data.byParentIDEntries[1].Ttl,
+		}).
+		Return(&ca.SignCsrResponse{SignedCertificate: data.generatedCerts[2]}, nil)
+
+	suite.server.EXPECT().Send(&node.FetchX509SVIDResponse{
+		SvidUpdate: data.expectation,
+	}).
+		Return(nil)
+
+	suite.server.EXPECT().Recv().Return(nil, io.EOF)
+
+}
+
+func setFetchRegistrationEntriesExpectations(suite *HandlerTestSuite, data *fetchSVIDData) {
 	suite.mockDataStore.EXPECT().
 		ListParentIDEntries(gomock.Any(),
 			&datastore.ListParentIDEntriesRequest{ParentId: data.baseSpiffeID}).
@@ -583,41 +1163,45 @@ func setFetchX509SVIDExpectations(
 			}).
 			Return(&datastore.FetchNodeResolverMapEntryResponse{}, nil)
 	}
+}
 
-	// end FetchRegistrationEntries(baseSpiffeID)
-
+func setFetchJWTSVIDExpectations(suite *HandlerTestSuite, data *fetchSVIDData) {
 	suite.mockDataStore.EXPECT().
-		FetchBundle(gomock.Any(), &datastore.Bundle{
-			TrustDomain: testTrustDomain.String()}).
-		Return(&datastore.Bundle{
-			TrustDomain: testTrustDomain.String(),
-			CaCerts:     caCert.Raw}, nil)
-
-	suite.mockServerCA.EXPECT().
-		SignCsr(gomock.Any(), &ca.SignCsrRequest{
-			Csr: data.request.Csrs[0], Ttl: data.byParentIDEntries[2].Ttl,
-		}).
-		Return(&ca.SignCsrResponse{SignedCertificate: data.generatedCerts[0]}, nil)
-
-	suite.mockServerCA.EXPECT().
-		SignCsr(gomock.Any(), &ca.SignCsrRequest{
-			Csr: data.request.Csrs[1], Ttl: data.byParentIDEntries[0].Ttl,
-		}).
-		Return(&ca.SignCsrResponse{SignedCertificate: data.generatedCerts[1]}, nil)
+		FetchAttestedNodeEntry(gomock.Any(),
+			&datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: data.baseSpiffeID}).
+		Return(&datastore.FetchAttestedNodeEntryResponse{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:     data.baseSpiffeID,
+				CertSerialNumber: "18392437442709699290",
+			},
+		}, nil)
 
-	suite.mockServerCA.EXPECT().
-		SignCsr(gomock.Any(), &ca.SignCsrRequest{
-			Csr: data.request.Csrs[2], Ttl: data.byParentIDEntries[1].Ttl,
+	suite.mockDataStore.EXPECT().
+		ListParentIDEntries(gomock.Any(),
+			&datastore.ListParentIDEntriesRequest{ParentId: data.baseSpiffeID}).
+		Return(&datastore.ListParentIDEntriesResponse{
+			RegisteredEntryList: data.byParentIDEntries}, nil)
+	suite.mockDataStore.EXPECT().
+		FetchNodeResolverMapEntry(gomock.Any(), &datastore.FetchNodeResolverMapEntryRequest{
+			BaseSpiffeId: data.baseSpiffeID,
 		}).
-		Return(&ca.SignCsrResponse{SignedCertificate: data.generatedCerts[2]}, nil)
+		Return(&datastore.FetchNodeResolverMapEntryResponse{}, nil)
 
-	suite.server.EXPECT().Send(&node.FetchX509SVIDResponse{
-		SvidUpdate: data.expectation,
-	}).
-		Return(nil)
-
-	suite.server.EXPECT().Recv().Return(nil, io.EOF)
+	for _, entry := range data.byParentIDEntries {
+		suite.mockDataStore.EXPECT().
+			ListParentIDEntries(gomock.Any(), &datastore.ListParentIDEntriesRequest{
+				ParentId: entry.SpiffeId}).
+			Return(&datastore.ListParentIDEntriesResponse{}, nil)
+		suite.mockDataStore.EXPECT().
+			FetchNodeResolverMapEntry(gomock.Any(), &datastore.FetchNodeResolverMapEntryRequest{
+				BaseSpiffeId: entry.SpiffeId,
+			}).
+			Return(&datastore.FetchNodeResolverMapEntryResponse{}, nil)
+	}
 
+	suite.mockDataStore.EXPECT().
+		FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: testTrustDomain.String()}).
+		Return(&datastore.Bundle{TrustDomain: testTrustDomain.String()}, nil)
 }
 
 func getExpectedFetchX509SVID(data *fetchSVIDData) *node.SvidUpdate {
@@ -641,17 +1225,35 @@ func getExpectedFetchX509SVID(data *fetchSVIDData) *node.SvidUpdate {
 		Svids:               svids,
 		Bundle:              caCert.Raw,
 		RegistrationEntries: registrationEntries,
+		JwtSigningKeys:      []*common.PublicKey{testJWTSigningKey},
 	}
 
 	return svidUpdate
 }
 
+// newTaintedAgentSVID returns a CA, to be tainted, and an agent SVID it
+// signed
+func newTaintedAgentSVID(t *testing.T, spiffeID string) (*x509.Certificate, *x509.Certificate) {
+	caTemplate, err := util.NewCATemplate(testTrustDomain.Host)
+	require.NoError(t, err)
+	caCert, caKey, err := util.SelfSign(caTemplate)
+	require.NoError(t, err)
+	svidTemplate, err := util.NewSVIDTemplate(spiffeID)
+	require.NoError(t, err)
+	svid, _, err := util.Sign(svidTemplate, caCert, caKey)
+	require.NoError(t, err)
+	return caCert, svid
+}
+
 func getFakePeer() *peer.Peer {
 	baseCert := getBytesFromPem("base_cert.pem")
 	parsedCert, _ := x509.ParseCertificate(baseCert)
+	return newPeer(parsedCert)
+}
 
+func newPeer(cert *x509.Certificate) *peer.Peer {
 	state := tls.ConnectionState{
-		PeerCertificates: []*x509.Certificate{parsedCert},
+		PeerCertificates: []*x509.Certificate{cert},
 	}
 
 	fakePeer := &peer.Peer{
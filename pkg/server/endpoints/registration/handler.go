@@ -1,20 +1,29 @@
 package registration
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"time"
 
 	"github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/go-spiffe/uri"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
 	"github.com/spiffe/spire/proto/api/registration"
 	"github.com/spiffe/spire/proto/common"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 //Service is used to register SPIFFE IDs, and the attestation logic that should
@@ -23,6 +32,9 @@ type Handler struct {
 	Log         logrus.FieldLogger
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
+
+	// If true, the X509-SVIDs signed are recorded and can be revoked
+	CRLEnabled bool
 }
 
 //Creates an entry in the Registration table,
@@ -31,11 +43,8 @@ func (h *Handler) CreateEntry(
 	ctx context.Context, request *common.RegistrationEntry) (
 	response *registration.RegistrationEntryID, err error) {
 
-	// Validate Spiffe ID
-	err = idutil.ValidateSpiffeID(request.SpiffeId, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
-	if err != nil {
-		h.Log.Error(err)
-		return response, errors.New("Error while validating provided Spiffe ID")
+	if err = h.validateEntry(ctx, request); err != nil {
+		return response, err
 	}
 
 	dataStore := h.Catalog.DataStores()[0]
@@ -109,11 +118,132 @@ func (h *Handler) FetchEntries(
 	return fetchResponse.RegisteredEntries, nil
 }
 
-//TODO
+//Replaces the values of the registration entry with the given ID
 func (h *Handler) UpdateEntry(
 	ctx context.Context, request *registration.UpdateEntryRequest) (
 	response *common.RegistrationEntry, err error) {
-	return response, err
+
+	if request.GetEntry() == nil {
+		return response, errors.New("An entry is required")
+	}
+
+	if err = h.validateEntry(ctx, request.Entry); err != nil {
+		return response, err
+	}
+
+	dataStore := h.Catalog.DataStores()[0]
+	fetchResponse, err := dataStore.FetchRegistrationEntry(ctx,
+		&datastore.FetchRegistrationEntryRequest{RegisteredEntryId: request.Id},
+	)
+	if err != nil {
+		h.Log.Error(err)
+		return response, errors.New("Error trying to fetch entry")
+	}
+	if fetchResponse.RegisteredEntry == nil {
+		return response, fmt.Errorf("No registration entry found with id %q", request.Id)
+	}
+
+	updateResponse, err := dataStore.UpdateRegistrationEntry(ctx,
+		&datastore.UpdateRegistrationEntryRequest{
+			RegisteredEntryId: request.Id,
+			RegisteredEntry:   request.Entry,
+		},
+	)
+	if err != nil {
+		h.Log.Error(err)
+		return response, errors.New("Error trying to update entry")
+	}
+
+	return updateResponse.RegisteredEntry, nil
+}
+
+//Forces the X509-SVIDs issued for an entry to be rotated, along with their
+//keys. Agents rotate them the next time they sync.
+func (h *Handler) RotateEntrySVIDs(
+	ctx context.Context, request *registration.RegistrationEntryID) (
+	response *common.RegistrationEntry, err error) {
+
+	dataStore := h.Catalog.DataStores()[0]
+	fetchResponse, err := dataStore.FetchRegistrationEntry(ctx,
+		&datastore.FetchRegistrationEntryRequest{RegisteredEntryId: request.Id},
+	)
+	if err != nil {
+		h.Log.Error(err)
+		return response, errors.New("Error trying to fetch entry")
+	}
+	if fetchResponse.RegisteredEntry == nil {
+		return response, fmt.Errorf("No registration entry found with id %q", request.Id)
+	}
+
+	entry := fetchResponse.RegisteredEntry
+	entry.RotatedAt = time.Now().Unix()
+	updateResponse, err := dataStore.UpdateRegistrationEntry(ctx,
+		&datastore.UpdateRegistrationEntryRequest{
+			RegisteredEntryId: request.Id,
+			RegisteredEntry:   entry,
+		},
+	)
+	if err != nil {
+		h.Log.Error(err)
+		return response, errors.New("Error trying to rotate entry SVIDs")
+	}
+
+	h.Log.WithFields(logrus.Fields{
+		log.RPC:    "RotateEntrySVIDs",
+		"entry_id": request.Id,
+	}).Info("Forced the rotation of the SVIDs of the entry")
+	return updateResponse.RegisteredEntry, nil
+}
+
+// RevokeSVIDs revokes an X509-SVID, or all the X509-SVIDs issued for an
+// entry, which are then listed in the CRL of the server CA. The SVIDs of the
+// entries involved are rotated so that workloads get new ones.
+func (h *Handler) RevokeSVIDs(
+	ctx context.Context, request *registration.RevokeSVIDsRequest) (
+	response *registration.RevokeSVIDsReply, err error) {
+
+	if !h.CRLEnabled {
+		return response, errors.New("CRLs are not enabled")
+	}
+	if request.SerialNumber == "" && request.EntryId == "" {
+		return response, errors.New("A serial number or an entry ID is required")
+	}
+
+	dataStore := h.Catalog.DataStores()[0]
+	revokeResponse, err := dataStore.RevokeIssuedSVIDs(ctx, &datastore.IssuedSVID{
+		SerialNumber: request.SerialNumber,
+		EntryId:      request.EntryId,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return response, errors.New("Error trying to revoke SVIDs")
+	}
+
+	response = &registration.RevokeSVIDsReply{}
+	rotated := make(map[string]bool)
+	for _, svid := range revokeResponse.Svids {
+		response.SerialNumbers = append(response.SerialNumbers, svid.SerialNumber)
+
+		h.Log.WithFields(logrus.Fields{
+			log.RPC:         "RevokeSVIDs",
+			log.SPIFFEID:    svid.SpiffeId,
+			"serial_number": svid.SerialNumber,
+			"entry_id":      svid.EntryId,
+		}).Info("Revoked SVID")
+
+		// Agent SVIDs are not issued for an entry
+		if svid.EntryId == "" || rotated[svid.EntryId] {
+			continue
+		}
+		rotated[svid.EntryId] = true
+		_, err := h.RotateEntrySVIDs(ctx, &registration.RegistrationEntryID{Id: svid.EntryId})
+		if err != nil {
+			// The entry may have been deleted since the SVID was issued
+			h.Log.Warnf("Could not rotate the SVIDs of entry %q: %v", svid.EntryId, err)
+		}
+	}
+
+	return response, nil
 }
 
 //Returns all the Entries associated with the ParentID value
@@ -273,3 +403,148 @@ func (h *Handler) isEntryUnique(ctx context.Context, ds datastore.DataStore, ent
 
 	return true, nil
 }
+
+// validateEntry validates the SPIFFE ID, the federated trust domains and the
+// TTLs of a registration entry about to be created or updated. Errors are
+// logged, and returned without details.
+func (h *Handler) validateEntry(ctx context.Context, entry *common.RegistrationEntry) error {
+	err := idutil.ValidateSpiffeID(entry.SpiffeId, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
+	if err != nil {
+		h.Log.Error(err)
+		return errors.New("Error while validating provided Spiffe ID")
+	}
+
+	for _, trustDomain := range entry.FederatesWith {
+		if err := h.validateFederatedTrustDomain(trustDomain); err != nil {
+			h.Log.Error(err)
+			return errors.New("Error while validating provided federated trust domains")
+		}
+	}
+
+	// TTLs are validated against the CA lifetime
+	if err := regentryutil.ValidateTTLs(ctx, h.Catalog.CAs()[0], entry); err != nil {
+		h.Log.Error(err)
+		return errors.New("Error while validating provided TTLs")
+	}
+
+	return nil
+}
+
+// validateFederatedTrustDomain makes sure the given SPIFFE ID names a trust
+// domain other than the one this server is authoritative for.
+func (h *Handler) validateFederatedTrustDomain(trustDomain string) error {
+	if err := idutil.ValidateSpiffeID(trustDomain, idutil.AllowAnyTrustDomain()); err != nil {
+		return err
+	}
+	if trustDomain == h.TrustDomain.String() {
+		return fmt.Errorf("%q is the local trust domain and cannot be federated with", trustDomain)
+	}
+	return nil
+}
+
+// AuthorizeCall authorizes a call to the Registration API. Callers presenting
+// a client certificate must hold a valid SVID, issued by this server, for a
+// SPIFFE ID in the server's trust domain that is associated with an admin
+// registration entry. Callers that do not present a certificate are only
+// authorized when connecting over the loopback interface.
+func (h *Handler) AuthorizeCall(ctx context.Context) error {
+	ctxPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.PermissionDenied, "unable to determine caller")
+	}
+
+	var certs []*x509.Certificate
+	if tlsInfo, ok := ctxPeer.AuthInfo.(credentials.TLSInfo); ok {
+		certs = tlsInfo.State.PeerCertificates
+	}
+
+	if len(certs) == 0 {
+		if !isLoopback(ctxPeer.Addr) {
+			h.Log.WithField(log.Caller, ctxPeer.Addr.String()).Warn("Rejected unauthenticated registration API call")
+			return status.Error(codes.PermissionDenied, "an admin SVID is required for non-local callers")
+		}
+		return nil
+	}
+
+	spiffeID, err := h.verifyAdminSVID(ctx, certs)
+	if err != nil {
+		h.Log.WithField(log.Caller, ctxPeer.Addr.String()).Warnf("Rejected registration API call: %v", err)
+		return status.Error(codes.PermissionDenied, "caller is not authorized to use the registration API")
+	}
+
+	h.Log.WithField(log.Caller, spiffeID).Debug("Authorized registration API call from admin")
+	return nil
+}
+
+// verifyAdminSVID verifies the certificate chain presented by the caller
+// against the trust bundle and ensures that the SPIFFE ID it represents
+// belongs to an admin registration entry. It returns the caller's SPIFFE ID.
+func (h *Handler) verifyAdminSVID(ctx context.Context, certs []*x509.Certificate) (string, error) {
+	ds := h.Catalog.DataStores()[0]
+	b, err := ds.FetchBundle(ctx, &datastore.Bundle{
+		TrustDomain: h.TrustDomain.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("get bundle from datastore: %v", err)
+	}
+
+	caCerts, err := x509.ParseCertificates(b.CaCerts)
+	if err != nil {
+		return "", fmt.Errorf("parse bundle: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	for _, c := range caCerts {
+		roots.AddCert(c)
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return "", fmt.Errorf("verify SVID: %v", err)
+	}
+
+	uriNames, err := uri.GetURINamesFromCertificate(certs[0])
+	if err != nil {
+		return "", err
+	}
+	if len(uriNames) != 1 {
+		return "", errors.New("SVID must have exactly one URI SAN")
+	}
+	spiffeID := uriNames[0]
+
+	err = idutil.ValidateSpiffeID(spiffeID, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := ds.ListSpiffeEntries(ctx, &datastore.ListSpiffeEntriesRequest{
+		SpiffeId: spiffeID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range resp.RegisteredEntryList {
+		if entry.Admin {
+			return spiffeID, nil
+		}
+	}
+
+	return "", fmt.Errorf("%v is not an admin workload", spiffeID)
+}
+
+func isLoopback(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	return tcpAddr.IP.IsLoopback()
+}
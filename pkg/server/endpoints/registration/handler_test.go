@@ -1,7 +1,10 @@
 package registration
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net"
 	"net/url"
 	"reflect"
 	"testing"
@@ -10,11 +13,19 @@ import (
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/proto/api/registration"
 	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/ca"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/ca"
 	"github.com/spiffe/spire/test/mock/proto/server/datastore"
 	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 type handlerTestSuite struct {
@@ -23,17 +34,20 @@ type handlerTestSuite struct {
 	ctrl          *gomock.Controller
 	handler       *Handler
 	mockDataStore *mock_datastore.MockDataStore
+	mockServerCA  *mock_ca.MockServerCA
 }
 
 func setupRegistrationTest(t *testing.T) *handlerTestSuite {
-	suite := &handlerTestSuite{}
+	suite := &handlerTestSuite{t: t}
 	mockCtrl := gomock.NewController(t)
 	suite.ctrl = mockCtrl
 	log, _ := test.NewNullLogger()
 	suite.mockDataStore = mock_datastore.NewMockDataStore(mockCtrl)
+	suite.mockServerCA = mock_ca.NewMockServerCA(mockCtrl)
 
 	catalog := fakeservercatalog.New()
 	catalog.SetDataStores(suite.mockDataStore)
+	catalog.SetCAs(suite.mockServerCA)
 
 	suite.handler = &Handler{
 		Log:         log,
@@ -50,6 +64,8 @@ func TestCreateEntry(t *testing.T) {
 		Id: "abcdefgh",
 	}
 	invalidRequest := testutil.GetRegistrationEntries("invalid.json")[0]
+	ttlTooLongRequest := testutil.GetRegistrationEntries("good.json")[0]
+	ttlTooLongRequest.Ttl = 7200
 
 	var testCases = []struct {
 		request          *common.RegistrationEntry
@@ -61,6 +77,7 @@ func TestCreateEntry(t *testing.T) {
 		{goodRequest, nil, errors.New("Error trying to create entry"), createEntryErrorExpectations},
 		{goodRequest, nil, errors.New("Entry already exists"), createEntryNonUniqueExpectations},
 		{invalidRequest, nil, errors.New("Error while validating provided Spiffe ID"), func(suite *handlerTestSuite) {}},
+		{ttlTooLongRequest, nil, errors.New("Error while validating provided TTLs"), fetchCACertificateExpectations},
 	}
 
 	for _, tt := range testCases {
@@ -178,13 +195,23 @@ func TestFetchEntries(t *testing.T) {
 }
 
 func TestUpdateEntry(t *testing.T) {
+	goodEntry := testutil.GetRegistrationEntries("good.json")[0]
+	goodRequest := &registration.UpdateEntryRequest{Id: "abcdefgh", Entry: goodEntry}
+	invalidRequest := &registration.UpdateEntryRequest{
+		Id:    "abcdefgh",
+		Entry: testutil.GetRegistrationEntries("invalid.json")[0],
+	}
+
 	var testCases = []struct {
 		request          *registration.UpdateEntryRequest
 		expectedResponse *common.RegistrationEntry
 		expectedError    error
 		setExpectations  func(*handlerTestSuite)
 	}{
-		{nil, nil, nil, func(*handlerTestSuite) {}},
+		{goodRequest, goodEntry, nil, updateEntryExpectations},
+		{goodRequest, nil, errors.New(`No registration entry found with id "abcdefgh"`), updateEntryNotFoundExpectations},
+		{invalidRequest, nil, errors.New("Error while validating provided Spiffe ID"), func(*handlerTestSuite) {}},
+		{&registration.UpdateEntryRequest{Id: "abcdefgh"}, nil, errors.New("An entry is required"), func(*handlerTestSuite) {}},
 	}
 
 	for _, tt := range testCases {
@@ -204,6 +231,86 @@ func TestUpdateEntry(t *testing.T) {
 	}
 }
 
+func TestRotateEntrySVIDs(t *testing.T) {
+	suite := setupRegistrationTest(t)
+	defer suite.ctrl.Finish()
+
+	entry := testutil.GetRegistrationEntries("good.json")[0]
+	suite.mockDataStore.EXPECT().
+		FetchRegistrationEntry(gomock.Any(), &datastore.FetchRegistrationEntryRequest{RegisteredEntryId: "abcdefgh"}).
+		Return(&datastore.FetchRegistrationEntryResponse{RegisteredEntry: entry}, nil)
+	suite.mockDataStore.EXPECT().
+		UpdateRegistrationEntry(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, req *datastore.UpdateRegistrationEntryRequest) {
+			require.Equal(t, "abcdefgh", req.RegisteredEntryId)
+			require.NotZero(t, req.RegisteredEntry.RotatedAt)
+		}).
+		Return(&datastore.UpdateRegistrationEntryResponse{RegisteredEntry: entry}, nil)
+
+	response, err := suite.handler.RotateEntrySVIDs(nil, &registration.RegistrationEntryID{Id: "abcdefgh"})
+	require.NoError(t, err)
+	require.Equal(t, entry, response)
+	require.NotZero(t, response.RotatedAt)
+
+	// Unknown entry
+	suite.mockDataStore.EXPECT().
+		FetchRegistrationEntry(gomock.Any(), gomock.Any()).
+		Return(&datastore.FetchRegistrationEntryResponse{}, nil)
+	_, err = suite.handler.RotateEntrySVIDs(nil, &registration.RegistrationEntryID{Id: "unknown"})
+	require.EqualError(t, err, `No registration entry found with id "unknown"`)
+}
+
+func TestRevokeSVIDs(t *testing.T) {
+	suite := setupRegistrationTest(t)
+	defer suite.ctrl.Finish()
+
+	// CRLs must be enabled
+	_, err := suite.handler.RevokeSVIDs(nil, &registration.RevokeSVIDsRequest{SerialNumber: "1"})
+	require.EqualError(t, err, "CRLs are not enabled")
+
+	suite.handler.CRLEnabled = true
+	_, err = suite.handler.RevokeSVIDs(nil, &registration.RevokeSVIDsRequest{})
+	require.EqualError(t, err, "A serial number or an entry ID is required")
+
+	// The SVIDs of the entry are revoked and rotated
+	entry := testutil.GetRegistrationEntries("good.json")[0]
+	suite.mockDataStore.EXPECT().
+		RevokeIssuedSVIDs(gomock.Any(), &datastore.IssuedSVID{EntryId: "abcdefgh"}).
+		Return(&datastore.IssuedSVIDs{
+			Svids: []*datastore.IssuedSVID{
+				{SerialNumber: "1", EntryId: "abcdefgh"},
+				{SerialNumber: "2", EntryId: "abcdefgh"},
+			},
+		}, nil)
+	suite.mockDataStore.EXPECT().
+		FetchRegistrationEntry(gomock.Any(), &datastore.FetchRegistrationEntryRequest{RegisteredEntryId: "abcdefgh"}).
+		Return(&datastore.FetchRegistrationEntryResponse{RegisteredEntry: entry}, nil)
+	suite.mockDataStore.EXPECT().
+		UpdateRegistrationEntry(gomock.Any(), gomock.Any()).
+		Return(&datastore.UpdateRegistrationEntryResponse{RegisteredEntry: entry}, nil)
+
+	response, err := suite.handler.RevokeSVIDs(nil, &registration.RevokeSVIDsRequest{EntryId: "abcdefgh"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, response.SerialNumbers)
+
+	// Agent SVIDs are not issued for an entry, so there is nothing to rotate
+	suite.mockDataStore.EXPECT().
+		RevokeIssuedSVIDs(gomock.Any(), &datastore.IssuedSVID{SerialNumber: "3"}).
+		Return(&datastore.IssuedSVIDs{
+			Svids: []*datastore.IssuedSVID{{SerialNumber: "3"}},
+		}, nil)
+	response, err = suite.handler.RevokeSVIDs(nil, &registration.RevokeSVIDsRequest{SerialNumber: "3"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"3"}, response.SerialNumbers)
+
+	// Unknown serial number
+	suite.mockDataStore.EXPECT().
+		RevokeIssuedSVIDs(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("record not found"))
+	_, err = suite.handler.RevokeSVIDs(nil, &registration.RevokeSVIDsRequest{SerialNumber: "4"})
+	require.EqualError(t, err, "Error trying to revoke SVIDs")
+}
+
 func TestListByParentID(t *testing.T) {
 
 	goodRequest := &registration.ParentID{
@@ -449,6 +556,90 @@ func TestCreateJoinToken(t *testing.T) {
 	}
 }
 
+func TestAuthorizeCall(t *testing.T) {
+	caTmpl, err := testutil.NewCATemplate("example.org")
+	require.NoError(t, err)
+	ca, caKey, err := testutil.SelfSign(caTmpl)
+	require.NoError(t, err)
+
+	otherCATmpl, err := testutil.NewCATemplate("example.org")
+	require.NoError(t, err)
+	otherCA, otherCAKey, err := testutil.SelfSign(otherCATmpl)
+	require.NoError(t, err)
+
+	newSVID := func(spiffeID string, parent *x509.Certificate, key interface{}) *x509.Certificate {
+		tmpl, err := testutil.NewSVIDTemplate(spiffeID)
+		require.NoError(t, err)
+		svid, _, err := testutil.Sign(tmpl, parent, key)
+		require.NoError(t, err)
+		return svid
+	}
+	adminSVID := newSVID("spiffe://example.org/admin", ca, caKey)
+	untrustedSVID := newSVID("spiffe://example.org/admin", otherCA, otherCAKey)
+
+	loopback := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 9000}
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9000}
+
+	peerCtx := func(addr net.Addr, certs ...*x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: addr,
+			AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{PeerCertificates: certs},
+			},
+		})
+	}
+
+	expectBundle := func(suite *handlerTestSuite) {
+		suite.mockDataStore.EXPECT().
+			FetchBundle(gomock.Any(), &datastore.Bundle{
+				TrustDomain: "spiffe://example.org",
+			}).
+			Return(&datastore.Bundle{CaCerts: ca.Raw}, nil)
+	}
+	expectEntries := func(suite *handlerTestSuite, admin bool) {
+		suite.mockDataStore.EXPECT().
+			ListSpiffeEntries(gomock.Any(), &datastore.ListSpiffeEntriesRequest{
+				SpiffeId: "spiffe://example.org/admin",
+			}).
+			Return(&datastore.ListSpiffeEntriesResponse{
+				RegisteredEntryList: []*common.RegistrationEntry{
+					{SpiffeId: "spiffe://example.org/admin", Admin: admin},
+				},
+			}, nil)
+	}
+
+	var testCases = []struct {
+		name            string
+		ctx             context.Context
+		expectedCode    codes.Code
+		setExpectations func(*handlerTestSuite)
+	}{
+		{"no peer", context.Background(), codes.PermissionDenied, noExpectations},
+		{"local without SVID", peerCtx(loopback), codes.OK, noExpectations},
+		{"remote without SVID", peerCtx(remote), codes.PermissionDenied, noExpectations},
+		{"admin SVID", peerCtx(remote, adminSVID), codes.OK, func(suite *handlerTestSuite) {
+			expectBundle(suite)
+			expectEntries(suite, true)
+		}},
+		{"non-admin SVID", peerCtx(remote, adminSVID), codes.PermissionDenied, func(suite *handlerTestSuite) {
+			expectBundle(suite)
+			expectEntries(suite, false)
+		}},
+		{"untrusted SVID", peerCtx(loopback, untrustedSVID), codes.PermissionDenied, expectBundle},
+	}
+
+	for _, tt := range testCases {
+		suite := setupRegistrationTest(t)
+		tt.setExpectations(suite)
+
+		err := suite.handler.AuthorizeCall(tt.ctx)
+		if status.Code(err) != tt.expectedCode {
+			t.Errorf("%s: unexpected status code\n Got: %v\n Want: %v\n", tt.name, status.Code(err), tt.expectedCode)
+		}
+		suite.ctrl.Finish()
+	}
+}
+
 //TODO: put this in the test table
 func TestCreateJoinTokenWithoutToken(t *testing.T) {
 	suite := setupRegistrationTest(t)
@@ -515,6 +706,7 @@ func TestFetchBundle(t *testing.T) {
 func noExpectations(*handlerTestSuite) {}
 
 func createEntryExpectations(suite *handlerTestSuite) {
+	fetchCACertificateExpectations(suite)
 	newRegEntry := testutil.GetRegistrationEntries("good.json")[0]
 
 	suite.mockDataStore.EXPECT().
@@ -537,6 +729,7 @@ func createEntryExpectations(suite *handlerTestSuite) {
 }
 
 func createEntryErrorExpectations(suite *handlerTestSuite) {
+	fetchCACertificateExpectations(suite)
 	suite.mockDataStore.EXPECT().
 		ListSpiffeEntries(gomock.Any(), gomock.Any()).
 		Return(&datastore.ListSpiffeEntriesResponse{
@@ -549,6 +742,7 @@ func createEntryErrorExpectations(suite *handlerTestSuite) {
 }
 
 func createEntryNonUniqueExpectations(suite *handlerTestSuite) {
+	fetchCACertificateExpectations(suite)
 	newRegEntry := testutil.GetRegistrationEntries("good.json")[0]
 
 	suite.mockDataStore.EXPECT().
@@ -558,6 +752,18 @@ func createEntryNonUniqueExpectations(suite *handlerTestSuite) {
 		}, nil)
 }
 
+// fetchCACertificateExpectations serves a CA certificate valid for one hour
+func fetchCACertificateExpectations(suite *handlerTestSuite) {
+	template, err := testutil.NewCATemplate("example.org")
+	require.NoError(suite.t, err)
+	caCert, _, err := testutil.SelfSign(template)
+	require.NoError(suite.t, err)
+
+	suite.mockServerCA.EXPECT().
+		FetchCertificate(gomock.Any(), &ca.FetchCertificateRequest{}).
+		Return(&ca.FetchCertificateResponse{StoredIntermediateCert: caCert.Raw}, nil)
+}
+
 func fetchEntryExpectations(suite *handlerTestSuite) {
 	fetchRequest := &datastore.FetchRegistrationEntryRequest{
 		RegisteredEntryId: "abcdefgh",
@@ -570,6 +776,26 @@ func fetchEntryExpectations(suite *handlerTestSuite) {
 		Return(fetchResponse, nil)
 }
 
+func updateEntryExpectations(suite *handlerTestSuite) {
+	fetchCACertificateExpectations(suite)
+	fetchEntryExpectations(suite)
+
+	entry := testutil.GetRegistrationEntries("good.json")[0]
+	suite.mockDataStore.EXPECT().
+		UpdateRegistrationEntry(gomock.Any(), &datastore.UpdateRegistrationEntryRequest{
+			RegisteredEntryId: "abcdefgh",
+			RegisteredEntry:   entry,
+		}).
+		Return(&datastore.UpdateRegistrationEntryResponse{RegisteredEntry: entry}, nil)
+}
+
+func updateEntryNotFoundExpectations(suite *handlerTestSuite) {
+	fetchCACertificateExpectations(suite)
+	suite.mockDataStore.EXPECT().
+		FetchRegistrationEntry(gomock.Any(), gomock.Any()).
+		Return(&datastore.FetchRegistrationEntryResponse{}, nil)
+}
+
 func fetchEntriesExpectations(suite *handlerTestSuite) {
 	fetchResponse := &datastore.FetchRegistrationEntriesResponse{
 		RegisteredEntries: &common.RegistrationEntries{
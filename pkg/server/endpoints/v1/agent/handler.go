@@ -0,0 +1,228 @@
+package agent
+
+import (
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/satori/go.uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler implements the v1 Agent API
+type Handler struct {
+	Log         logrus.FieldLogger
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+}
+
+// ListAgents lists all the attested agents
+func (h *Handler) ListAgents(ctx context.Context, req *agent.ListAgentsRequest) (*agent.ListAgentsResponse, error) {
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.ListAttestedNodeEntries(ctx, &datastore.ListAttestedNodeEntriesRequest{})
+	if err != nil {
+		h.Log.Errorf("Error listing attested nodes: %v", err)
+		return nil, status.Error(codes.Internal, "unable to list agents")
+	}
+
+	agents := make([]*agent.AttestedAgent, 0, len(resp.AttestedNodeEntryList))
+	for _, node := range resp.AttestedNodeEntryList {
+		a, err := h.toAgent(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, a)
+	}
+
+	return &agent.ListAgentsResponse{Agents: agents}, nil
+}
+
+// GetAgent retrieves an attested agent by its SPIFFE ID
+func (h *Handler) GetAgent(ctx context.Context, req *agent.GetAgentRequest) (*agent.GetAgentResponse, error) {
+	node, err := h.fetchNode(ctx, req.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := h.toAgent(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return &agent.GetAgentResponse{Agent: a}, nil
+}
+
+// DeleteAgent deletes an attested agent, forcing it to attest again before
+// it can renew its SVID
+func (h *Handler) DeleteAgent(ctx context.Context, req *agent.DeleteAgentRequest) (*agent.DeleteAgentResponse, error) {
+	node, err := h.fetchNode(ctx, req.SpiffeId)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := h.toAgent(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	_, err = ds.DeleteAttestedNodeEntry(ctx, &datastore.DeleteAttestedNodeEntryRequest{
+		BaseSpiffeId: req.SpiffeId,
+	})
+	if err != nil {
+		h.Log.Errorf("Error deleting attested node %q: %v", req.SpiffeId, err)
+		return nil, status.Error(codes.Internal, "unable to delete agent")
+	}
+
+	return &agent.DeleteAgentResponse{Agent: a}, nil
+}
+
+// BanAgent bans an attested agent. Its attested node entry is kept, marked
+// as banned, so it can neither renew its SVID nor attest again until it is
+// deleted.
+func (h *Handler) BanAgent(ctx context.Context, req *agent.BanAgentRequest) (*agent.BanAgentResponse, error) {
+	if _, err := h.fetchNode(ctx, req.SpiffeId); err != nil {
+		return nil, err
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.UpdateAttestedNodeEntry(ctx, &datastore.UpdateAttestedNodeEntryRequest{
+		BaseSpiffeId: req.SpiffeId,
+		Banned:       true,
+		InputMask:    &datastore.AttestedNodeEntryMask{Banned: true},
+	})
+	if err != nil {
+		h.Log.Errorf("Error banning attested node %q: %v", req.SpiffeId, err)
+		return nil, status.Error(codes.Internal, "unable to ban agent")
+	}
+
+	a, err := h.toAgent(ctx, resp.AttestedNodeEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &agent.BanAgentResponse{Agent: a}, nil
+}
+
+// CreateJoinToken creates a join token that can be used to attest an agent
+func (h *Handler) CreateJoinToken(ctx context.Context, req *agent.CreateJoinTokenRequest) (*agent.CreateJoinTokenResponse, error) {
+	if req.Ttl < 1 {
+		return nil, status.Error(codes.InvalidArgument, "ttl is required")
+	}
+
+	token := req.Token
+	if token == "" {
+		u, err := uuid.NewV4()
+		if err != nil {
+			h.Log.Errorf("Error generating join token: %v", err)
+			return nil, status.Error(codes.Internal, "unable to generate join token")
+		}
+		token = u.String()
+	}
+
+	expiry := time.Now().Unix() + int64(req.Ttl)
+
+	ds := h.Catalog.DataStores()[0]
+	_, err := ds.RegisterToken(ctx, &datastore.JoinToken{
+		Token:  token,
+		Expiry: expiry,
+	})
+	if err != nil {
+		h.Log.Errorf("Error registering join token: %v", err)
+		return nil, status.Error(codes.Internal, "unable to register join token")
+	}
+
+	agentID := &url.URL{
+		Scheme: h.TrustDomain.Scheme,
+		Host:   h.TrustDomain.Host,
+		Path:   path.Join("spire", "agent", "join_token", token),
+	}
+
+	return &agent.CreateJoinTokenResponse{
+		Token:         token,
+		ExpiresAt:     expiry,
+		AgentSpiffeId: agentID.String(),
+	}, nil
+}
+
+// DeleteJoinToken deletes a join token that has not been used yet, so that
+// no agent can attest with it
+func (h *Handler) DeleteJoinToken(ctx context.Context, req *agent.DeleteJoinTokenRequest) (*agent.DeleteJoinTokenResponse, error) {
+	if req.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	token, err := ds.FetchToken(ctx, &datastore.JoinToken{Token: req.Token})
+	if err != nil {
+		h.Log.Errorf("Error fetching join token: %v", err)
+		return nil, status.Error(codes.Internal, "unable to fetch join token")
+	}
+	if token.Token == "" {
+		return nil, status.Error(codes.NotFound, "no such join token")
+	}
+
+	if _, err := ds.DeleteToken(ctx, token); err != nil {
+		h.Log.Errorf("Error deleting join token: %v", err)
+		return nil, status.Error(codes.Internal, "unable to delete join token")
+	}
+
+	return &agent.DeleteJoinTokenResponse{}, nil
+}
+
+// fetchNode fetches the attested node with the given SPIFFE ID, returning a
+// NotFound status if it does not exist
+func (h *Handler) fetchNode(ctx context.Context, spiffeID string) (*datastore.AttestedNodeEntry, error) {
+	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowTrustDomainAgent(h.TrustDomain.Host)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid agent spiffe id: %v", err)
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.FetchAttestedNodeEntry(ctx, &datastore.FetchAttestedNodeEntryRequest{
+		BaseSpiffeId: spiffeID,
+	})
+	if err != nil {
+		h.Log.Errorf("Error fetching attested node %q: %v", spiffeID, err)
+		return nil, status.Error(codes.Internal, "unable to fetch agent")
+	}
+	if resp.AttestedNodeEntry == nil {
+		return nil, status.Errorf(codes.NotFound, "no such agent %q", spiffeID)
+	}
+
+	return resp.AttestedNodeEntry, nil
+}
+
+// toAgent converts an attested node entry into an agent, including the
+// selectors resolved for it
+func (h *Handler) toAgent(ctx context.Context, node *datastore.AttestedNodeEntry) (*agent.AttestedAgent, error) {
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.FetchNodeResolverMapEntry(ctx, &datastore.FetchNodeResolverMapEntryRequest{
+		BaseSpiffeId: node.BaseSpiffeId,
+	})
+	if err != nil {
+		h.Log.Errorf("Error fetching selectors for %q: %v", node.BaseSpiffeId, err)
+		return nil, status.Error(codes.Internal, "unable to fetch agent selectors")
+	}
+
+	var selectors []*common.Selector
+	for _, entry := range resp.NodeResolverMapEntryList {
+		selectors = append(selectors, entry.Selector)
+	}
+
+	return &agent.AttestedAgent{
+		SpiffeId:           node.BaseSpiffeId,
+		AttestationType:    node.AttestationDataType,
+		CertSerialNumber:   node.CertSerialNumber,
+		CertExpirationDate: node.CertExpirationDate,
+		Selectors:          selectors,
+		Banned:             node.Banned,
+	}, nil
+}
@@ -0,0 +1,163 @@
+package agent
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const agentID = "spiffe://example.org/spire/agent/join_token/abcd"
+
+func newTestHandler(t *testing.T) (*Handler, *fakedatastore.FakeDataStore) {
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(ds)
+
+	ctx := context.Background()
+	_, err := ds.CreateAttestedNodeEntry(ctx, &datastore.CreateAttestedNodeEntryRequest{
+		AttestedNodeEntry: &datastore.AttestedNodeEntry{
+			BaseSpiffeId:        agentID,
+			AttestationDataType: "join_token",
+			CertSerialNumber:    "1234",
+			CertExpirationDate:  "Mon, 01 Jan 2018 00:00:00 +0000",
+		},
+	})
+	require.NoError(t, err)
+	_, err = ds.CreateNodeResolverMapEntry(ctx, &datastore.CreateNodeResolverMapEntryRequest{
+		NodeResolverMapEntry: &datastore.NodeResolverMapEntry{
+			BaseSpiffeId: agentID,
+			Selector:     &common.Selector{Type: "type", Value: "value"},
+		},
+	})
+	require.NoError(t, err)
+
+	return &Handler{
+		Log:         log,
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+	}, ds
+}
+
+func TestListAndGetAgent(t *testing.T) {
+	h, _ := newTestHandler(t)
+	ctx := context.Background()
+
+	expected := &agent.AttestedAgent{
+		SpiffeId:           agentID,
+		AttestationType:    "join_token",
+		CertSerialNumber:   "1234",
+		CertExpirationDate: "Mon, 01 Jan 2018 00:00:00 +0000",
+		Selectors:          []*common.Selector{{Type: "type", Value: "value"}},
+	}
+
+	listResp, err := h.ListAgents(ctx, &agent.ListAgentsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []*agent.AttestedAgent{expected}, listResp.Agents)
+
+	getResp, err := h.GetAgent(ctx, &agent.GetAgentRequest{SpiffeId: agentID})
+	require.NoError(t, err)
+	require.Equal(t, expected, getResp.Agent)
+
+	_, err = h.GetAgent(ctx, &agent.GetAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = h.GetAgent(ctx, &agent.GetAgentRequest{SpiffeId: "spiffe://example.org/workload"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDeleteAgent(t *testing.T) {
+	h, ds := newTestHandler(t)
+	ctx := context.Background()
+
+	resp, err := h.DeleteAgent(ctx, &agent.DeleteAgentRequest{SpiffeId: agentID})
+	require.NoError(t, err)
+	require.Equal(t, agentID, resp.Agent.SpiffeId)
+
+	fetchResp, err := ds.FetchAttestedNodeEntry(ctx, &datastore.FetchAttestedNodeEntryRequest{
+		BaseSpiffeId: agentID,
+	})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.AttestedNodeEntry)
+
+	_, err = h.DeleteAgent(ctx, &agent.DeleteAgentRequest{SpiffeId: agentID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestBanAgent(t *testing.T) {
+	h, ds := newTestHandler(t)
+	ctx := context.Background()
+
+	resp, err := h.BanAgent(ctx, &agent.BanAgentRequest{SpiffeId: agentID})
+	require.NoError(t, err)
+	require.Equal(t, agentID, resp.Agent.SpiffeId)
+	require.True(t, resp.Agent.Banned)
+
+	fetchResp, err := ds.FetchAttestedNodeEntry(ctx, &datastore.FetchAttestedNodeEntryRequest{
+		BaseSpiffeId: agentID,
+	})
+	require.NoError(t, err)
+	require.True(t, fetchResp.AttestedNodeEntry.Banned)
+	// Only the banned flag is updated
+	require.Equal(t, "1234", fetchResp.AttestedNodeEntry.CertSerialNumber)
+	require.Equal(t, "Mon, 01 Jan 2018 00:00:00 +0000", fetchResp.AttestedNodeEntry.CertExpirationDate)
+
+	_, err = h.BanAgent(ctx, &agent.BanAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCreateJoinToken(t *testing.T) {
+	h, ds := newTestHandler(t)
+	ctx := context.Background()
+
+	_, err := h.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := h.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{Ttl: 60})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Token)
+	require.True(t, resp.ExpiresAt > time.Now().Unix())
+	require.True(t, strings.HasSuffix(resp.AgentSpiffeId, "/spire/agent/join_token/"+resp.Token))
+
+	token, err := ds.FetchToken(ctx, &datastore.JoinToken{Token: resp.Token})
+	require.NoError(t, err)
+	require.Equal(t, resp.ExpiresAt, token.Expiry)
+
+	resp, err = h.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{Token: "foobar", Ttl: 60})
+	require.NoError(t, err)
+	require.Equal(t, "foobar", resp.Token)
+	require.Equal(t, "spiffe://example.org/spire/agent/join_token/foobar", resp.AgentSpiffeId)
+}
+
+func TestDeleteJoinToken(t *testing.T) {
+	h, ds := newTestHandler(t)
+	ctx := context.Background()
+
+	_, err := h.DeleteJoinToken(ctx, &agent.DeleteJoinTokenRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{Token: "foobar", Ttl: 60})
+	require.NoError(t, err)
+
+	_, err = h.DeleteJoinToken(ctx, &agent.DeleteJoinTokenRequest{Token: "foobar"})
+	require.NoError(t, err)
+
+	token, err := ds.FetchToken(ctx, &datastore.JoinToken{Token: "foobar"})
+	require.NoError(t, err)
+	require.Empty(t, token.Token)
+
+	_, err = h.DeleteJoinToken(ctx, &agent.DeleteJoinTokenRequest{Token: "foobar"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,237 @@
+package bundle
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	serverbundle "github.com/spiffe/spire/pkg/server/bundle"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/api/v1/bundle"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FederationMonitor reports the health of the relationships with the
+// federated trust domains whose bundles are fetched from their bundle
+// endpoints
+type FederationMonitor interface {
+	FederationStatuses() []serverbundle.FederationStatus
+}
+
+// Handler implements the v1 Bundle API
+type Handler struct {
+	Log         logrus.FieldLogger
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+
+	// Reports the federation statuses. None are listed if unset.
+	FederationMonitor FederationMonitor
+}
+
+// GetBundle retrieves the trust bundle of the server's trust domain
+func (h *Handler) GetBundle(ctx context.Context, req *bundle.GetBundleRequest) (*bundle.GetBundleResponse, error) {
+	b, err := h.findBundle(ctx, h.TrustDomain.String())
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, status.Error(codes.NotFound, "bundle not found")
+	}
+
+	return &bundle.GetBundleResponse{Bundle: toTrustBundle(b)}, nil
+}
+
+// ListFederatedBundles lists the bundles of all the federated trust domains
+func (h *Handler) ListFederatedBundles(ctx context.Context, req *bundle.ListFederatedBundlesRequest) (*bundle.ListFederatedBundlesResponse, error) {
+	bundles, err := h.listBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bundle.ListFederatedBundlesResponse{}
+	for _, b := range bundles {
+		if b.TrustDomain == h.TrustDomain.String() {
+			continue
+		}
+		resp.Bundles = append(resp.Bundles, toTrustBundle(b))
+	}
+
+	return resp, nil
+}
+
+// GetFederatedBundle retrieves the bundle of a federated trust domain
+func (h *Handler) GetFederatedBundle(ctx context.Context, req *bundle.GetFederatedBundleRequest) (*bundle.GetFederatedBundleResponse, error) {
+	if err := h.validateFederatedTrustDomain(req.TrustDomain); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	b, err := h.findBundle(ctx, req.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, status.Errorf(codes.NotFound, "no such federated bundle %q", req.TrustDomain)
+	}
+
+	return &bundle.GetFederatedBundleResponse{Bundle: toTrustBundle(b)}, nil
+}
+
+// SetFederatedBundle creates or replaces the bundle of a federated trust
+// domain
+func (h *Handler) SetFederatedBundle(ctx context.Context, req *bundle.SetFederatedBundleRequest) (*bundle.SetFederatedBundleResponse, error) {
+	if req.Bundle == nil {
+		return nil, status.Error(codes.InvalidArgument, "bundle is required")
+	}
+	if err := h.validateFederatedTrustDomain(req.Bundle.TrustDomain); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	certs, err := x509.ParseCertificates(req.Bundle.CaCerts)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid CA certificates: %v", err)
+	}
+	if len(certs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one CA certificate is required")
+	}
+
+	existing, err := h.findBundle(ctx, req.Bundle.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	b := &datastore.Bundle{
+		TrustDomain:    req.Bundle.TrustDomain,
+		CaCerts:        req.Bundle.CaCerts,
+		JwtSigningKeys: req.Bundle.JwtSigningKeys,
+		RefreshHint:    req.Bundle.RefreshHint,
+	}
+	if existing == nil {
+		b, err = ds.CreateBundle(ctx, b)
+	} else {
+		b, err = ds.UpdateBundle(ctx, b)
+	}
+	if err != nil {
+		h.Log.Errorf("Error storing federated bundle %q: %v", req.Bundle.TrustDomain, err)
+		return nil, status.Error(codes.Internal, "unable to store federated bundle")
+	}
+	catalog.NotifyBundleUpdated(ctx, h.Catalog, h.Log, b)
+
+	return &bundle.SetFederatedBundleResponse{Bundle: toTrustBundle(b)}, nil
+}
+
+// DeleteFederatedBundle deletes the bundle of a federated trust domain
+func (h *Handler) DeleteFederatedBundle(ctx context.Context, req *bundle.DeleteFederatedBundleRequest) (*bundle.DeleteFederatedBundleResponse, error) {
+	if err := h.validateFederatedTrustDomain(req.TrustDomain); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	existing, err := h.findBundle(ctx, req.TrustDomain)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, status.Errorf(codes.NotFound, "no such federated bundle %q", req.TrustDomain)
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	if _, err := ds.DeleteBundle(ctx, &datastore.Bundle{TrustDomain: req.TrustDomain}); err != nil {
+		h.Log.Errorf("Error deleting federated bundle %q: %v", req.TrustDomain, err)
+		return nil, status.Error(codes.Internal, "unable to delete federated bundle")
+	}
+
+	return &bundle.DeleteFederatedBundleResponse{Bundle: toTrustBundle(existing)}, nil
+}
+
+// ListFederationStatuses lists the status of the relationships with the
+// federated trust domains whose bundles are fetched from their bundle
+// endpoints
+func (h *Handler) ListFederationStatuses(ctx context.Context, req *bundle.ListFederationStatusesRequest) (*bundle.ListFederationStatusesResponse, error) {
+	resp := &bundle.ListFederationStatusesResponse{}
+	if h.FederationMonitor == nil {
+		return resp, nil
+	}
+
+	now := time.Now()
+	for _, status := range h.FederationMonitor.FederationStatuses() {
+		resp.Statuses = append(resp.Statuses, toFederationStatus(status, now))
+	}
+	return resp, nil
+}
+
+// listBundles lists all the bundles known to the datastore
+func (h *Handler) listBundles(ctx context.Context) ([]*datastore.Bundle, error) {
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.ListBundles(ctx, &common.Empty{})
+	if err != nil {
+		h.Log.Errorf("Error listing bundles: %v", err)
+		return nil, status.Error(codes.Internal, "unable to list bundles")
+	}
+	return resp.Bundles, nil
+}
+
+// findBundle returns the bundle for the given trust domain, or nil if there
+// is none
+func (h *Handler) findBundle(ctx context.Context, trustDomain string) (*datastore.Bundle, error) {
+	bundles, err := h.listBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bundles {
+		if b.TrustDomain == trustDomain {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
+
+// validateFederatedTrustDomain makes sure the given SPIFFE ID names a trust
+// domain other than the one this server is authoritative for
+func (h *Handler) validateFederatedTrustDomain(trustDomain string) error {
+	if err := idutil.ValidateSpiffeID(trustDomain, idutil.AllowAnyTrustDomain()); err != nil {
+		return err
+	}
+	if trustDomain == h.TrustDomain.String() {
+		return fmt.Errorf("%q is the local trust domain", trustDomain)
+	}
+	return nil
+}
+
+func toTrustBundle(b *datastore.Bundle) *bundle.TrustBundle {
+	return &bundle.TrustBundle{
+		TrustDomain:    b.TrustDomain,
+		CaCerts:        b.CaCerts,
+		JwtSigningKeys: b.JwtSigningKeys,
+		SequenceNumber: b.SequenceNumber,
+		RefreshHint:    b.RefreshHint,
+	}
+}
+
+func toFederationStatus(s serverbundle.FederationStatus, now time.Time) *bundle.FederationStatus {
+	status := &bundle.FederationStatus{
+		TrustDomain:       s.TrustDomain,
+		BundleEndpointUrl: s.BundleEndpointURL,
+		LastError:         s.LastError,
+		LastErrorAt:       unixTime(s.LastErrorAt),
+		LastSuccess:       unixTime(s.LastSuccess),
+		BundleExpiresAt:   unixTime(s.BundleExpiresAt),
+	}
+	if !s.LastSuccess.IsZero() {
+		status.SecondsSinceLastSuccess = int64(now.Sub(s.LastSuccess) / time.Second)
+	}
+	return status
+}
+
+// unixTime returns the time in UNIX time, or zero for the zero time
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
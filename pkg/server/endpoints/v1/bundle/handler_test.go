@@ -0,0 +1,200 @@
+package bundle
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	serverbundle "github.com/spiffe/spire/pkg/server/bundle"
+	"github.com/spiffe/spire/proto/api/v1/bundle"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/notifier"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/notifier"
+	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testJWTSigningKey = &common.PublicKey{
+	PkixBytes: []byte("PKIX"),
+	Kid:       "KID",
+}
+
+func newTestHandler(t *testing.T) (*Handler, []byte) {
+	log, _ := test.NewNullLogger()
+	ds := fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(ds)
+
+	caCert := newCACert(t, "example.org")
+	_, err := ds.CreateBundle(context.Background(), &datastore.Bundle{
+		TrustDomain:    "spiffe://example.org",
+		CaCerts:        caCert,
+		JwtSigningKeys: []*common.PublicKey{testJWTSigningKey},
+	})
+	require.NoError(t, err)
+
+	return &Handler{
+		Log:         log,
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+	}, caCert
+}
+
+func newCACert(t *testing.T, trustDomain string) []byte {
+	template, err := testutil.NewCATemplate(trustDomain)
+	require.NoError(t, err)
+	cert, _, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+	return cert.Raw
+}
+
+func TestGetBundle(t *testing.T) {
+	h, caCert := newTestHandler(t)
+
+	resp, err := h.GetBundle(context.Background(), &bundle.GetBundleRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &bundle.TrustBundle{
+		TrustDomain:    "spiffe://example.org",
+		CaCerts:        caCert,
+		JwtSigningKeys: []*common.PublicKey{testJWTSigningKey},
+		SequenceNumber: 1,
+	}, resp.Bundle)
+}
+
+func TestFederatedBundles(t *testing.T) {
+	h, _ := newTestHandler(t)
+	ctx := context.Background()
+
+	federated := &bundle.TrustBundle{
+		TrustDomain:    "spiffe://otherdomain.test",
+		CaCerts:        newCACert(t, "otherdomain.test"),
+		JwtSigningKeys: []*common.PublicKey{testJWTSigningKey},
+		RefreshHint:    60,
+	}
+
+	setResp, err := h.SetFederatedBundle(ctx, &bundle.SetFederatedBundleRequest{Bundle: federated})
+	require.NoError(t, err)
+	federated.SequenceNumber = 1
+	require.Equal(t, federated, setResp.Bundle)
+
+	// setting it again replaces the existing bundle, and bumps its sequence
+	// number
+	federated.CaCerts = newCACert(t, "otherdomain.test")
+	setResp, err = h.SetFederatedBundle(ctx, &bundle.SetFederatedBundleRequest{Bundle: federated})
+	require.NoError(t, err)
+	federated.SequenceNumber = 2
+	require.Equal(t, federated, setResp.Bundle)
+
+	getResp, err := h.GetFederatedBundle(ctx, &bundle.GetFederatedBundleRequest{TrustDomain: federated.TrustDomain})
+	require.NoError(t, err)
+	require.Equal(t, federated, getResp.Bundle)
+
+	listResp, err := h.ListFederatedBundles(ctx, &bundle.ListFederatedBundlesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []*bundle.TrustBundle{federated}, listResp.Bundles)
+
+	deleteResp, err := h.DeleteFederatedBundle(ctx, &bundle.DeleteFederatedBundleRequest{TrustDomain: federated.TrustDomain})
+	require.NoError(t, err)
+	require.Equal(t, federated, deleteResp.Bundle)
+
+	_, err = h.GetFederatedBundle(ctx, &bundle.GetFederatedBundleRequest{TrustDomain: federated.TrustDomain})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSetFederatedBundleNotifies(t *testing.T) {
+	h, _ := newTestHandler(t)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	n := mock_notifier.NewMockNotifier(ctrl)
+	h.Catalog.(*fakeservercatalog.Catalog).SetNotifiers(n)
+
+	caCert := newCACert(t, "otherdomain.test")
+	n.EXPECT().Notify(gomock.Any(), &notifier.NotifyRequest{
+		BundleUpdated: &notifier.BundleUpdated{
+			Bundle: &notifier.Bundle{
+				TrustDomain:    "spiffe://otherdomain.test",
+				CaCerts:        caCert,
+				SequenceNumber: 1,
+			},
+		},
+	}).Return(&notifier.NotifyResponse{}, nil)
+
+	_, err := h.SetFederatedBundle(ctx, &bundle.SetFederatedBundleRequest{
+		Bundle: &bundle.TrustBundle{TrustDomain: "spiffe://otherdomain.test", CaCerts: caCert},
+	})
+	require.NoError(t, err)
+}
+
+func TestFederatedBundlesRejectLocalTrustDomain(t *testing.T) {
+	h, caCert := newTestHandler(t)
+	ctx := context.Background()
+
+	_, err := h.SetFederatedBundle(ctx, &bundle.SetFederatedBundleRequest{
+		Bundle: &bundle.TrustBundle{TrustDomain: "spiffe://example.org", CaCerts: caCert},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.DeleteFederatedBundle(ctx, &bundle.DeleteFederatedBundleRequest{TrustDomain: "spiffe://example.org"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+type fakeFederationMonitor []serverbundle.FederationStatus
+
+func (m fakeFederationMonitor) FederationStatuses() []serverbundle.FederationStatus {
+	return m
+}
+
+func TestListFederationStatuses(t *testing.T) {
+	h, _ := newTestHandler(t)
+	ctx := context.Background()
+
+	// Nothing is listed without federation
+	resp, err := h.ListFederationStatuses(ctx, &bundle.ListFederationStatusesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Statuses)
+
+	lastSuccess := time.Now().Add(-time.Hour)
+	h.FederationMonitor = fakeFederationMonitor{
+		{
+			TrustDomain:       "spiffe://otherdomain.test",
+			BundleEndpointURL: "https://otherdomain.test",
+			LastSuccess:       lastSuccess,
+			LastError:         "oops",
+			LastErrorAt:       time.Unix(2000, 0),
+			BundleExpiresAt:   time.Unix(3000, 0),
+		},
+		{
+			TrustDomain:       "spiffe://thirddomain.test",
+			BundleEndpointURL: "https://thirddomain.test",
+		},
+	}
+	resp, err = h.ListFederationStatuses(ctx, &bundle.ListFederationStatusesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Statuses, 2)
+
+	first := resp.Statuses[0]
+	require.InDelta(t, 3600, first.SecondsSinceLastSuccess, 5)
+	first.SecondsSinceLastSuccess = 0
+	require.Equal(t, &bundle.FederationStatus{
+		TrustDomain:       "spiffe://otherdomain.test",
+		BundleEndpointUrl: "https://otherdomain.test",
+		LastSuccess:       lastSuccess.Unix(),
+		LastError:         "oops",
+		LastErrorAt:       2000,
+		BundleExpiresAt:   3000,
+	}, first)
+	require.Equal(t, &bundle.FederationStatus{
+		TrustDomain:       "spiffe://thirddomain.test",
+		BundleEndpointUrl: "https://thirddomain.test",
+	}, resp.Statuses[1])
+}
@@ -0,0 +1,439 @@
+package entry
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/selector"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler implements the v1 Entry API
+type Handler struct {
+	Log         logrus.FieldLogger
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+}
+
+// CreateEntry creates a registration entry
+func (h *Handler) CreateEntry(ctx context.Context, req *entry.CreateEntryRequest) (*entry.CreateEntryResponse, error) {
+	if req.Entry == nil {
+		return nil, status.Error(codes.InvalidArgument, "entry is required")
+	}
+	if err := h.validateEntry(ctx, req.Entry); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entry: %v", err)
+	}
+
+	ds := h.Catalog.DataStores()[0]
+
+	unique, err := isEntryUnique(ctx, ds, req.Entry)
+	if err != nil {
+		h.Log.Errorf("Error checking entry uniqueness: %v", err)
+		return nil, status.Error(codes.Internal, "unable to create entry")
+	}
+	if !unique {
+		return nil, status.Error(codes.AlreadyExists, "entry already exists")
+	}
+
+	resp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		RegisteredEntry: req.Entry,
+	})
+	if err != nil {
+		h.Log.Errorf("Error creating entry: %v", err)
+		return nil, status.Error(codes.Internal, "unable to create entry")
+	}
+
+	created := *req.Entry
+	created.EntryId = resp.RegisteredEntryId
+	return &entry.CreateEntryResponse{Entry: &created}, nil
+}
+
+// GetEntry retrieves a registration entry by its ID
+func (h *Handler) GetEntry(ctx context.Context, req *entry.GetEntryRequest) (*entry.GetEntryResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	e, err := h.fetchEntry(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry.GetEntryResponse{Entry: e}, nil
+}
+
+// ListEntries lists the registration entries matching all the filters set
+// in the request, sorted by SPIFFE ID, parent ID and selectors. The entries
+// are looked up by the first filter set, then the other filters are applied
+// to them.
+func (h *Handler) ListEntries(ctx context.Context, req *entry.ListEntriesRequest) (*entry.ListEntriesResponse, error) {
+	matching, err := h.listEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	util.SortRegistrationEntries(matching)
+
+	return &entry.ListEntriesResponse{Entries: matching}, nil
+}
+
+// CountEntries counts the registration entries matching all the filters set
+// in the request, which are applied as by ListEntries. Entries filtered by
+// parent ID or SPIFFE ID only are counted by the datastore, without being
+// loaded; the selector and federation filters require listing them.
+func (h *Handler) CountEntries(ctx context.Context, req *entry.CountEntriesRequest) (*entry.CountEntriesResponse, error) {
+	if len(req.BySelectors) == 0 && len(req.WithSelectors) == 0 && len(req.ByFederatesWith) == 0 {
+		ds := h.Catalog.DataStores()[0]
+		resp, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{
+			ByParentId: req.ByParentId,
+			BySpiffeId: req.BySpiffeId,
+		})
+		if err != nil {
+			h.Log.Errorf("Error counting entries: %v", err)
+			return nil, status.Error(codes.Internal, "unable to count entries")
+		}
+		return &entry.CountEntriesResponse{Count: resp.Count}, nil
+	}
+
+	matching, err := h.listEntries(ctx, &entry.ListEntriesRequest{
+		ByParentId:      req.ByParentId,
+		BySpiffeId:      req.BySpiffeId,
+		BySelectors:     req.BySelectors,
+		WithSelectors:   req.WithSelectors,
+		ByFederatesWith: req.ByFederatesWith,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry.CountEntriesResponse{Count: int32(len(matching))}, nil
+}
+
+// listEntries returns the registration entries matching all the filters set
+// in the request, unsorted
+func (h *Handler) listEntries(ctx context.Context, req *entry.ListEntriesRequest) ([]*common.RegistrationEntry, error) {
+	ds := h.Catalog.DataStores()[0]
+
+	var entries []*common.RegistrationEntry
+	var err error
+	switch {
+	case req.ByParentId != "":
+		var resp *datastore.ListParentIDEntriesResponse
+		resp, err = ds.ListParentIDEntries(ctx, &datastore.ListParentIDEntriesRequest{
+			ParentId: req.ByParentId,
+		})
+		if resp != nil {
+			entries = resp.RegisteredEntryList
+		}
+	case req.BySpiffeId != "":
+		var resp *datastore.ListSpiffeEntriesResponse
+		resp, err = ds.ListSpiffeEntries(ctx, &datastore.ListSpiffeEntriesRequest{
+			SpiffeId: req.BySpiffeId,
+		})
+		if resp != nil {
+			entries = resp.RegisteredEntryList
+		}
+	case len(req.BySelectors) > 0:
+		var resp *datastore.ListSelectorEntriesResponse
+		resp, err = ds.ListSelectorEntries(ctx, &datastore.ListSelectorEntriesRequest{
+			Selectors: req.BySelectors,
+		})
+		if resp != nil {
+			entries = resp.RegisteredEntryList
+		}
+	default:
+		var resp *datastore.FetchRegistrationEntriesResponse
+		resp, err = ds.FetchRegistrationEntries(ctx, &common.Empty{})
+		if resp != nil && resp.RegisteredEntries != nil {
+			entries = resp.RegisteredEntries.Entries
+		}
+	}
+	if err != nil {
+		h.Log.Errorf("Error listing entries: %v", err)
+		return nil, status.Error(codes.Internal, "unable to list entries")
+	}
+
+	var matching []*common.RegistrationEntry
+	for _, e := range entries {
+		if matchesFilters(e, req) {
+			matching = append(matching, e)
+		}
+	}
+	return matching, nil
+}
+
+// UpdateEntry overwrites the registration entry identified by the entry ID
+func (h *Handler) UpdateEntry(ctx context.Context, req *entry.UpdateEntryRequest) (*entry.UpdateEntryResponse, error) {
+	if req.Entry == nil {
+		return nil, status.Error(codes.InvalidArgument, "entry is required")
+	}
+	if req.Entry.EntryId == "" {
+		return nil, status.Error(codes.InvalidArgument, "entry id is required")
+	}
+	if err := h.validateEntry(ctx, req.Entry); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entry: %v", err)
+	}
+
+	// Make sure the entry exists so we can report it properly
+	if _, err := h.fetchEntry(ctx, req.Entry.EntryId); err != nil {
+		return nil, err
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		RegisteredEntryId: req.Entry.EntryId,
+		RegisteredEntry:   req.Entry,
+	})
+	if err != nil {
+		h.Log.Errorf("Error updating entry %q: %v", req.Entry.EntryId, err)
+		return nil, status.Error(codes.Internal, "unable to update entry")
+	}
+
+	return &entry.UpdateEntryResponse{Entry: resp.RegisteredEntry}, nil
+}
+
+// DeleteEntry deletes a registration entry by its ID
+func (h *Handler) DeleteEntry(ctx context.Context, req *entry.DeleteEntryRequest) (*entry.DeleteEntryResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if _, err := h.fetchEntry(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		RegisteredEntryId: req.Id,
+	})
+	if err != nil {
+		h.Log.Errorf("Error deleting entry %q: %v", req.Id, err)
+		return nil, status.Error(codes.Internal, "unable to delete entry")
+	}
+
+	return &entry.DeleteEntryResponse{Entry: resp.RegisteredEntry}, nil
+}
+
+// ValidateEntry validates a prospective registration entry without
+// persisting it, and reports the attested agents it would currently match
+func (h *Handler) ValidateEntry(ctx context.Context, req *entry.ValidateEntryRequest) (*entry.ValidateEntryResponse, error) {
+	if req.Entry == nil {
+		return nil, status.Error(codes.InvalidArgument, "entry is required")
+	}
+
+	resp := &entry.ValidateEntryResponse{}
+	if err := h.validateEntry(ctx, req.Entry); err != nil {
+		resp.Problems = append(resp.Problems, err.Error())
+	}
+
+	ds := h.Catalog.DataStores()[0]
+
+	unique, err := isEntryUnique(ctx, ds, req.Entry)
+	if err != nil {
+		h.Log.Errorf("Error checking entry uniqueness: %v", err)
+		return nil, status.Error(codes.Internal, "unable to validate entry")
+	}
+	if !unique {
+		resp.Problems = append(resp.Problems, "entry already exists")
+	}
+
+	parentExists, err := h.parentExists(ctx, ds, req.Entry.ParentId)
+	if err != nil {
+		h.Log.Errorf("Error looking up parent %q: %v", req.Entry.ParentId, err)
+		return nil, status.Error(codes.Internal, "unable to validate entry")
+	}
+	if !parentExists {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("parent id %q is not the server, an attested agent or the SPIFFE ID of another entry", req.Entry.ParentId))
+	}
+
+	resp.MatchingAgents, err = matchingAgents(ctx, ds, req.Entry)
+	if err != nil {
+		h.Log.Errorf("Error finding agents matching the entry: %v", err)
+		return nil, status.Error(codes.Internal, "unable to validate entry")
+	}
+
+	return resp, nil
+}
+
+// fetchEntry fetches the entry with the given ID, returning a NotFound
+// status if it does not exist
+func (h *Handler) fetchEntry(ctx context.Context, id string) (*common.RegistrationEntry, error) {
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		RegisteredEntryId: id,
+	})
+	if err != nil {
+		h.Log.Errorf("Error fetching entry %q: %v", id, err)
+		return nil, status.Error(codes.Internal, "unable to fetch entry")
+	}
+	if resp.RegisteredEntry == nil {
+		return nil, status.Errorf(codes.NotFound, "no such entry %q", id)
+	}
+	return resp.RegisteredEntry, nil
+}
+
+// validateEntry checks the SPIFFE IDs and TTLs carried by the entry
+func (h *Handler) validateEntry(ctx context.Context, e *common.RegistrationEntry) error {
+	if err := idutil.ValidateSpiffeID(e.SpiffeId, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host)); err != nil {
+		return fmt.Errorf("spiffe id: %v", err)
+	}
+	if err := idutil.ValidateSpiffeID(e.ParentId, idutil.AllowAnyInTrustDomain(h.TrustDomain.Host)); err != nil {
+		return fmt.Errorf("parent id: %v", err)
+	}
+	if len(e.Selectors) == 0 {
+		return fmt.Errorf("at least one selector is required")
+	}
+	for _, s := range e.Selectors {
+		if s.Type == "" || s.Value == "" {
+			return fmt.Errorf("selector %q must have a type and a value", s.Type+":"+s.Value)
+		}
+		if strings.Contains(s.Type, ":") {
+			return fmt.Errorf("selector type %q cannot contain a colon", s.Type)
+		}
+	}
+	for _, trustDomain := range e.FederatesWith {
+		if err := idutil.ValidateSpiffeID(trustDomain, idutil.AllowAnyTrustDomain()); err != nil {
+			return fmt.Errorf("federated trust domain: %v", err)
+		}
+		if trustDomain == h.TrustDomain.String() {
+			return fmt.Errorf("%q is the local trust domain and cannot be federated with", trustDomain)
+		}
+	}
+	return regentryutil.ValidateTTLs(ctx, h.Catalog.CAs()[0], e)
+}
+
+// matchesFilters returns true if the entry matches all the filters set in
+// the request
+func matchesFilters(e *common.RegistrationEntry, req *entry.ListEntriesRequest) bool {
+	if req.ByParentId != "" && e.ParentId != req.ByParentId {
+		return false
+	}
+	if req.BySpiffeId != "" && e.SpiffeId != req.BySpiffeId {
+		return false
+	}
+
+	selectors := selector.NewSetFromRaw(e.Selectors)
+	if len(req.BySelectors) > 0 && !selectors.Equal(selector.NewSetFromRaw(req.BySelectors)) {
+		return false
+	}
+	if len(req.WithSelectors) > 0 && !selectors.IncludesSet(selector.NewSetFromRaw(req.WithSelectors)) {
+		return false
+	}
+
+	for _, trustDomain := range req.ByFederatesWith {
+		found := false
+		for _, federatesWith := range e.FederatesWith {
+			if federatesWith == trustDomain {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// isEntryUnique returns false if an entry with the same SPIFFE ID, parent
+// ID and selectors already exists
+func isEntryUnique(ctx context.Context, ds datastore.DataStore, e *common.RegistrationEntry) (bool, error) {
+	resp, err := ds.ListSpiffeEntries(ctx, &datastore.ListSpiffeEntriesRequest{SpiffeId: e.SpiffeId})
+	if err != nil {
+		return false, err
+	}
+
+	selectors := selector.NewSetFromRaw(e.Selectors)
+	for _, existing := range resp.RegisteredEntryList {
+		if existing.ParentId == e.ParentId && selector.NewSetFromRaw(existing.Selectors).Equal(selectors) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// parentExists returns true if the parent ID is the server, an attested agent
+// or the SPIFFE ID of an existing entry
+func (h *Handler) parentExists(ctx context.Context, ds datastore.DataStore, parentID string) (bool, error) {
+	if parentID == h.TrustDomain.String()+"/spire/server" {
+		return true, nil
+	}
+
+	nodeResp, err := ds.FetchAttestedNodeEntry(ctx, &datastore.FetchAttestedNodeEntryRequest{
+		BaseSpiffeId: parentID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if nodeResp.AttestedNodeEntry != nil {
+		return true, nil
+	}
+
+	entriesResp, err := ds.ListSpiffeEntries(ctx, &datastore.ListSpiffeEntriesRequest{SpiffeId: parentID})
+	if err != nil {
+		return false, err
+	}
+	return len(entriesResp.RegisteredEntryList) > 0, nil
+}
+
+// matchingAgents returns the SPIFFE IDs of the attested agents that would be
+// authorized to obtain SVIDs for the entry. It mirrors how entries are
+// fetched for an agent: the entry matches if its parent is the agent or one
+// of the SPIFFE IDs the agent is already authorized for, or if its selectors
+// are a subset of the selectors the agent was resolved to.
+func matchingAgents(ctx context.Context, ds datastore.DataStore, e *common.RegistrationEntry) ([]string, error) {
+	nodesResp, err := ds.ListAttestedNodeEntries(ctx, &datastore.ListAttestedNodeEntriesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	entrySelectors := selector.NewSetFromRaw(e.Selectors)
+
+	var agents []string
+	for _, node := range nodesResp.AttestedNodeEntryList {
+		agentID := node.BaseSpiffeId
+		if e.ParentId == agentID {
+			agents = append(agents, agentID)
+			continue
+		}
+
+		resolveResp, err := ds.FetchNodeResolverMapEntry(ctx, &datastore.FetchNodeResolverMapEntryRequest{
+			BaseSpiffeId: agentID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		var agentSelectors []*common.Selector
+		for _, mapEntry := range resolveResp.NodeResolverMapEntryList {
+			agentSelectors = append(agentSelectors, mapEntry.Selector)
+		}
+		if len(agentSelectors) > 0 && selector.NewSetFromRaw(agentSelectors).IncludesSet(entrySelectors) {
+			agents = append(agents, agentID)
+			continue
+		}
+
+		authorized, err := regentryutil.FetchRegistrationEntries(ctx, ds, agentID)
+		if err != nil {
+			return nil, err
+		}
+		for _, authorizedEntry := range authorized {
+			if authorizedEntry.SpiffeId == e.ParentId {
+				agents = append(agents, agentID)
+				break
+			}
+		}
+	}
+
+	return agents, nil
+}
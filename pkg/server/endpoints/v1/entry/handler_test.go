@@ -0,0 +1,306 @@
+package entry
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/ca"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestHandler(t *testing.T) *Handler {
+	log, _ := test.NewNullLogger()
+
+	// the CA template is valid for one hour
+	template, err := util.NewCATemplate("example.org")
+	require.NoError(t, err)
+	caCert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+
+	serverCA := mock_ca.NewMockServerCA(gomock.NewController(t))
+	serverCA.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: caCert.Raw,
+	}, nil).AnyTimes()
+
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(fakedatastore.New())
+	catalog.SetCAs(serverCA)
+
+	return &Handler{
+		Log:         log,
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+	}
+}
+
+func newTestEntry() *common.RegistrationEntry {
+	return &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/spire/agent/join_token/abcd",
+		SpiffeId: "spiffe://example.org/workload",
+		Selectors: []*common.Selector{
+			{Type: "unix", Value: "uid:1000"},
+		},
+		Ttl: 3600,
+	}
+}
+
+func TestCreateAndGetEntry(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+
+	createResp, err := h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: newTestEntry()})
+	require.NoError(t, err)
+	require.NotEmpty(t, createResp.Entry.EntryId)
+
+	getResp, err := h.GetEntry(ctx, &entry.GetEntryRequest{Id: createResp.Entry.EntryId})
+	require.NoError(t, err)
+	require.Equal(t, createResp.Entry, getResp.Entry)
+
+	// creating the same entry again is rejected
+	_, err = h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: newTestEntry()})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestCreateEntryValidation(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+
+	foreign := newTestEntry()
+	foreign.SpiffeId = "spiffe://otherdomain.test/workload"
+
+	noSelectors := newTestEntry()
+	noSelectors.Selectors = nil
+
+	localFederation := newTestEntry()
+	localFederation.FederatesWith = []string{"spiffe://example.org"}
+
+	negativeJWTSVIDTTL := newTestEntry()
+	negativeJWTSVIDTTL.JwtSvidTtl = -1
+
+	ttlTooLong := newTestEntry()
+	ttlTooLong.Ttl = 7200
+
+	for _, e := range []*common.RegistrationEntry{nil, foreign, noSelectors, localFederation, negativeJWTSVIDTTL, ttlTooLong} {
+		_, err := h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: e})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestGetEntryNotFound(t *testing.T) {
+	h := newTestHandler(t)
+
+	_, err := h.GetEntry(context.Background(), &entry.GetEntryRequest{Id: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListEntries(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+
+	e1 := newTestEntry()
+	e2 := newTestEntry()
+	e2.SpiffeId = "spiffe://example.org/other"
+	e2.Selectors = []*common.Selector{{Type: "unix", Value: "uid:1001"}, {Type: "unix", Value: "gid:1000"}}
+	e2.FederatesWith = []string{"spiffe://partner.org"}
+	for _, e := range []*common.RegistrationEntry{e1, e2} {
+		_, err := h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: e})
+		require.NoError(t, err)
+	}
+
+	// Entries are sorted by SPIFFE ID
+	resp, err := h.ListEntries(ctx, &entry.ListEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+	require.Equal(t, e2.SpiffeId, resp.Entries[0].SpiffeId)
+	require.Equal(t, e1.SpiffeId, resp.Entries[1].SpiffeId)
+
+	resp, err = h.ListEntries(ctx, &entry.ListEntriesRequest{BySpiffeId: e2.SpiffeId})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	require.Equal(t, e2.SpiffeId, resp.Entries[0].SpiffeId)
+
+	resp, err = h.ListEntries(ctx, &entry.ListEntriesRequest{ByParentId: e1.ParentId})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 2)
+
+	resp, err = h.ListEntries(ctx, &entry.ListEntriesRequest{
+		WithSelectors: []*common.Selector{{Type: "unix", Value: "gid:1000"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	require.Equal(t, e2.SpiffeId, resp.Entries[0].SpiffeId)
+
+	resp, err = h.ListEntries(ctx, &entry.ListEntriesRequest{ByFederatesWith: []string{"spiffe://partner.org"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	require.Equal(t, e2.SpiffeId, resp.Entries[0].SpiffeId)
+
+	// Filters combine
+	resp, err = h.ListEntries(ctx, &entry.ListEntriesRequest{
+		ByParentId: e1.ParentId,
+		BySpiffeId: e1.SpiffeId,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	require.Equal(t, e1.SpiffeId, resp.Entries[0].SpiffeId)
+
+	resp, err = h.ListEntries(ctx, &entry.ListEntriesRequest{
+		BySpiffeId:      e1.SpiffeId,
+		ByFederatesWith: []string{"spiffe://partner.org"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Entries)
+}
+
+func TestCountEntries(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+
+	resp, err := h.CountEntries(ctx, &entry.CountEntriesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), resp.Count)
+
+	e1 := newTestEntry()
+	e2 := newTestEntry()
+	e2.SpiffeId = "spiffe://example.org/other"
+	e2.FederatesWith = []string{"spiffe://partner.org"}
+	for _, e := range []*common.RegistrationEntry{e1, e2} {
+		_, err := h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: e})
+		require.NoError(t, err)
+	}
+
+	resp, err = h.CountEntries(ctx, &entry.CountEntriesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.Count)
+
+	// The filters are the same as those of ListEntries
+	resp, err = h.CountEntries(ctx, &entry.CountEntriesRequest{
+		ByParentId:      e1.ParentId,
+		ByFederatesWith: []string{"spiffe://partner.org"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), resp.Count)
+}
+
+func TestUpdateAndDeleteEntry(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+
+	createResp, err := h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: newTestEntry()})
+	require.NoError(t, err)
+
+	updated := *createResp.Entry
+	updated.Ttl = 60
+	updated.JwtSvidTtl = 30
+	updateResp, err := h.UpdateEntry(ctx, &entry.UpdateEntryRequest{Entry: &updated})
+	require.NoError(t, err)
+	require.Equal(t, int32(60), updateResp.Entry.Ttl)
+	require.Equal(t, int32(30), updateResp.Entry.JwtSvidTtl)
+
+	tooLong := updated
+	tooLong.JwtSvidTtl = 7200
+	_, err = h.UpdateEntry(ctx, &entry.UpdateEntryRequest{Entry: &tooLong})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	missing := updated
+	missing.EntryId = "missing"
+	_, err = h.UpdateEntry(ctx, &entry.UpdateEntryRequest{Entry: &missing})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	deleteResp, err := h.DeleteEntry(ctx, &entry.DeleteEntryRequest{Id: updated.EntryId})
+	require.NoError(t, err)
+	require.Equal(t, updated.EntryId, deleteResp.Entry.EntryId)
+
+	_, err = h.DeleteEntry(ctx, &entry.DeleteEntryRequest{Id: updated.EntryId})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestValidateEntry(t *testing.T) {
+	h := newTestHandler(t)
+	ctx := context.Background()
+	ds := h.Catalog.DataStores()[0]
+
+	agentID := "spiffe://example.org/spire/agent/join_token/abcd"
+	otherAgentID := "spiffe://example.org/spire/agent/join_token/efgh"
+	for _, id := range []string{agentID, otherAgentID} {
+		_, err := ds.CreateAttestedNodeEntry(ctx, &datastore.CreateAttestedNodeEntryRequest{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{BaseSpiffeId: id},
+		})
+		require.NoError(t, err)
+	}
+	_, err := ds.CreateNodeResolverMapEntry(ctx, &datastore.CreateNodeResolverMapEntryRequest{
+		NodeResolverMapEntry: &datastore.NodeResolverMapEntry{
+			BaseSpiffeId: otherAgentID,
+			Selector:     &common.Selector{Type: "region", Value: "us-east-1"},
+		},
+	})
+	require.NoError(t, err)
+
+	// workload entry parented by an attested agent
+	resp, err := h.ValidateEntry(ctx, &entry.ValidateEntryRequest{Entry: newTestEntry()})
+	require.NoError(t, err)
+	require.Empty(t, resp.Problems)
+	require.Empty(t, resp.Warnings)
+	require.Equal(t, []string{agentID}, resp.MatchingAgents)
+
+	// node entry matched through the node resolver selectors
+	nodeEntry := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/spire/server",
+		SpiffeId:  "spiffe://example.org/east",
+		Selectors: []*common.Selector{{Type: "region", Value: "us-east-1"}},
+	}
+	resp, err = h.ValidateEntry(ctx, &entry.ValidateEntryRequest{Entry: nodeEntry})
+	require.NoError(t, err)
+	require.Empty(t, resp.Problems)
+	require.Empty(t, resp.Warnings)
+	require.Equal(t, []string{otherAgentID}, resp.MatchingAgents)
+
+	// workload entry parented by the node entry, once it exists
+	aliasedEntry := newTestEntry()
+	aliasedEntry.ParentId = nodeEntry.SpiffeId
+	resp, err = h.ValidateEntry(ctx, &entry.ValidateEntryRequest{Entry: aliasedEntry})
+	require.NoError(t, err)
+	require.Empty(t, resp.Problems)
+	require.Len(t, resp.Warnings, 1)
+	require.Empty(t, resp.MatchingAgents)
+
+	_, err = h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: nodeEntry})
+	require.NoError(t, err)
+	resp, err = h.ValidateEntry(ctx, &entry.ValidateEntryRequest{Entry: aliasedEntry})
+	require.NoError(t, err)
+	require.Empty(t, resp.Problems)
+	require.Empty(t, resp.Warnings)
+	require.Equal(t, []string{otherAgentID}, resp.MatchingAgents)
+
+	// validation problems are reported, and the entry is not persisted
+	invalid := newTestEntry()
+	invalid.Selectors = []*common.Selector{{Type: "unix"}}
+	resp, err = h.ValidateEntry(ctx, &entry.ValidateEntryRequest{Entry: invalid})
+	require.NoError(t, err)
+	require.Equal(t, []string{`selector "unix:" must have a type and a value`}, resp.Problems)
+
+	_, err = h.CreateEntry(ctx, &entry.CreateEntryRequest{Entry: newTestEntry()})
+	require.NoError(t, err)
+	resp, err = h.ValidateEntry(ctx, &entry.ValidateEntryRequest{Entry: newTestEntry()})
+	require.NoError(t, err)
+	require.Equal(t, []string{"entry already exists"}, resp.Problems)
+
+	listResp, err := h.ListEntries(ctx, &entry.ListEntriesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Entries, 2)
+
+	_, err = h.ValidateEntry(ctx, &entry.ValidateEntryRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
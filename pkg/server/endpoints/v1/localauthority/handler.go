@@ -0,0 +1,151 @@
+package localauthority
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CARotator rotates the X509 CA of the server on demand
+type CARotator interface {
+	CACertificate() *x509.Certificate
+	NextCACertificate() *x509.Certificate
+	PrevCACertificate() *x509.Certificate
+	PrepareCA(ctx context.Context) (*x509.Certificate, error)
+	ActivateCA(ctx context.Context, serialNumber string) (*x509.Certificate, error)
+	TaintCA(ctx context.Context, serialNumber string) (*x509.Certificate, error)
+}
+
+// Handler implements the v1 LocalAuthority API
+type Handler struct {
+	Log       logrus.FieldLogger
+	Catalog   catalog.Catalog
+	CARotator CARotator
+}
+
+// GetX509AuthorityState returns the active, prepared and old X509 CAs
+func (h *Handler) GetX509AuthorityState(ctx context.Context, req *localauthority.GetX509AuthorityStateRequest) (*localauthority.GetX509AuthorityStateResponse, error) {
+	return &localauthority.GetX509AuthorityStateResponse{
+		Active:   toAuthorityState(h.CARotator.CACertificate()),
+		Prepared: toAuthorityState(h.CARotator.NextCACertificate()),
+		Old:      toAuthorityState(h.CARotator.PrevCACertificate()),
+	}, nil
+}
+
+// PrepareX509Authority prepares a new X509 CA, replacing any CA prepared
+// already
+func (h *Handler) PrepareX509Authority(ctx context.Context, req *localauthority.PrepareX509AuthorityRequest) (*localauthority.PrepareX509AuthorityResponse, error) {
+	cert, err := h.CARotator.PrepareCA(ctx)
+	if err != nil {
+		h.Log.Errorf("Error preparing CA: %v", err)
+		return nil, status.Error(codes.Internal, "unable to prepare CA")
+	}
+
+	return &localauthority.PrepareX509AuthorityResponse{
+		PreparedAuthority: toAuthorityState(cert),
+	}, nil
+}
+
+// ActivateX509Authority activates the prepared X509 CA, which must be the one
+// with the requested serial number
+func (h *Handler) ActivateX509Authority(ctx context.Context, req *localauthority.ActivateX509AuthorityRequest) (*localauthority.ActivateX509AuthorityResponse, error) {
+	if req.SerialNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "serial number is required")
+	}
+
+	nextCACert := h.CARotator.NextCACertificate()
+	if nextCACert == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no CA is prepared")
+	}
+	if nextCACert.SerialNumber.String() != req.SerialNumber {
+		return nil, status.Errorf(codes.FailedPrecondition, "CA %s is not the prepared one", req.SerialNumber)
+	}
+
+	cert, err := h.CARotator.ActivateCA(ctx, req.SerialNumber)
+	if err != nil {
+		h.Log.Errorf("Error activating CA %s: %v", req.SerialNumber, err)
+		return nil, status.Error(codes.Internal, "unable to activate CA")
+	}
+
+	return &localauthority.ActivateX509AuthorityResponse{
+		ActivatedAuthority: toAuthorityState(cert),
+	}, nil
+}
+
+// TaintX509Authority taints an X509 CA which is no longer active. The CA is
+// published as tainted in the trust bundle, and agents rotate their own SVID
+// if it signed it. The X509-SVIDs issued to workloads do not record which CA
+// signed them, so those of every registration entry are rotated, and are then
+// signed by the active CA.
+func (h *Handler) TaintX509Authority(ctx context.Context, req *localauthority.TaintX509AuthorityRequest) (*localauthority.TaintX509AuthorityResponse, error) {
+	if req.SerialNumber == "" {
+		return nil, status.Error(codes.InvalidArgument, "serial number is required")
+	}
+
+	cert, err := h.CARotator.TaintCA(ctx, req.SerialNumber)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "unable to taint CA: %v", err)
+	}
+
+	rotated, err := h.rotateEntries(ctx)
+	if err != nil {
+		h.Log.Errorf("Error rotating the SVIDs of the entries: %v", err)
+		return nil, status.Error(codes.Internal, "unable to rotate the SVIDs of the entries")
+	}
+
+	h.Log.WithFields(logrus.Fields{
+		"serial_number":   req.SerialNumber,
+		"rotated_entries": rotated,
+	}).Warn("Tainted CA and forced the rotation of the SVIDs of all entries")
+	return &localauthority.TaintX509AuthorityResponse{
+		TaintedAuthority: toAuthorityState(cert),
+		RotatedEntries:   int32(rotated),
+	}, nil
+}
+
+// rotateEntries records the time of the rotation on every registration entry.
+// Agents replace any SVID of an entry issued before then the next time they
+// sync with the server.
+func (h *Handler) rotateEntries(ctx context.Context) (int, error) {
+	ds := h.Catalog.DataStores()[0]
+	resp, err := ds.FetchRegistrationEntries(ctx, &common.Empty{})
+	if err != nil {
+		return 0, err
+	}
+
+	rotatedAt := time.Now().Unix()
+	rotated := 0
+	for _, entry := range resp.GetRegisteredEntries().GetEntries() {
+		entry.RotatedAt = rotatedAt
+		_, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+			RegisteredEntryId: entry.EntryId,
+			RegisteredEntry:   entry,
+		})
+		if err != nil {
+			// The entry may have been deleted since it was fetched
+			h.Log.Warnf("Could not rotate the SVIDs of entry %q: %v", entry.EntryId, err)
+			continue
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+func toAuthorityState(cert *x509.Certificate) *localauthority.AuthorityState {
+	if cert == nil {
+		return nil
+	}
+	return &localauthority.AuthorityState{
+		SerialNumber: cert.SerialNumber.String(),
+		CaCert:       cert.Raw,
+		ExpiresAt:    cert.NotAfter.Unix(),
+	}
+}
@@ -0,0 +1,160 @@
+package localauthority
+
+import (
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/api/v1/localauthority"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/datastore"
+	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ctx = context.Background()
+
+type fakeCARotator struct {
+	caCert     *x509.Certificate
+	nextCACert *x509.Certificate
+	prevCACert *x509.Certificate
+	prepared   *x509.Certificate
+}
+
+func (r *fakeCARotator) CACertificate() *x509.Certificate     { return r.caCert }
+func (r *fakeCARotator) NextCACertificate() *x509.Certificate { return r.nextCACert }
+func (r *fakeCARotator) PrevCACertificate() *x509.Certificate { return r.prevCACert }
+
+func (r *fakeCARotator) PrepareCA(ctx context.Context) (*x509.Certificate, error) {
+	if r.prepared == nil {
+		return nil, errors.New("upstream ca unavailable")
+	}
+	r.nextCACert = r.prepared
+	return r.nextCACert, nil
+}
+
+func (r *fakeCARotator) ActivateCA(ctx context.Context, serialNumber string) (*x509.Certificate, error) {
+	r.prevCACert, r.caCert, r.nextCACert = r.caCert, r.nextCACert, nil
+	return r.caCert, nil
+}
+
+func (r *fakeCARotator) TaintCA(ctx context.Context, serialNumber string) (*x509.Certificate, error) {
+	if r.prevCACert == nil || r.prevCACert.SerialNumber.String() != serialNumber {
+		return nil, errors.New("no CA certificate found with serial number " + serialNumber)
+	}
+	return r.prevCACert, nil
+}
+
+func newCACert(t *testing.T, serialNumber int64) *x509.Certificate {
+	template, err := testutil.NewCATemplate("example.org")
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(serialNumber)
+	cert, _, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+	return cert
+}
+
+func newTestHandler(ds datastore.DataStore, r CARotator) *Handler {
+	log, _ := test.NewNullLogger()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(ds)
+
+	return &Handler{
+		Log:       log,
+		Catalog:   catalog,
+		CARotator: r,
+	}
+}
+
+func TestPrepareAndActivate(t *testing.T) {
+	cert1 := newCACert(t, 1)
+	cert2 := newCACert(t, 2)
+	r := &fakeCARotator{caCert: cert1}
+	h := newTestHandler(nil, r)
+
+	// Nothing to activate until a CA is prepared
+	_, err := h.ActivateX509Authority(ctx, &localauthority.ActivateX509AuthorityRequest{SerialNumber: "2"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = h.PrepareX509Authority(ctx, &localauthority.PrepareX509AuthorityRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	r.prepared = cert2
+	prepareResp, err := h.PrepareX509Authority(ctx, &localauthority.PrepareX509AuthorityRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &localauthority.AuthorityState{
+		SerialNumber: "2",
+		CaCert:       cert2.Raw,
+		ExpiresAt:    cert2.NotAfter.Unix(),
+	}, prepareResp.PreparedAuthority)
+
+	stateResp, err := h.GetX509AuthorityState(ctx, &localauthority.GetX509AuthorityStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "1", stateResp.Active.SerialNumber)
+	require.Equal(t, "2", stateResp.Prepared.SerialNumber)
+	require.Nil(t, stateResp.Old)
+
+	// Only the prepared CA can be activated
+	_, err = h.ActivateX509Authority(ctx, &localauthority.ActivateX509AuthorityRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.ActivateX509Authority(ctx, &localauthority.ActivateX509AuthorityRequest{SerialNumber: "1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	activateResp, err := h.ActivateX509Authority(ctx, &localauthority.ActivateX509AuthorityRequest{SerialNumber: "2"})
+	require.NoError(t, err)
+	require.Equal(t, "2", activateResp.ActivatedAuthority.SerialNumber)
+
+	stateResp, err = h.GetX509AuthorityState(ctx, &localauthority.GetX509AuthorityStateRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "2", stateResp.Active.SerialNumber)
+	require.Nil(t, stateResp.Prepared)
+	require.Equal(t, "1", stateResp.Old.SerialNumber)
+}
+
+func TestTaint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ds := mock_datastore.NewMockDataStore(ctrl)
+	cert1 := newCACert(t, 1)
+	r := &fakeCARotator{caCert: newCACert(t, 2), prevCACert: cert1}
+	h := newTestHandler(ds, r)
+
+	_, err := h.TaintX509Authority(ctx, &localauthority.TaintX509AuthorityRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.TaintX509Authority(ctx, &localauthority.TaintX509AuthorityRequest{SerialNumber: "3"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The SVIDs of every entry are rotated. An entry deleted in the meantime
+	// is skipped.
+	entries := []*common.RegistrationEntry{
+		{EntryId: "entry1", SpiffeId: "spiffe://example.org/foo"},
+		{EntryId: "entry2", SpiffeId: "spiffe://example.org/bar"},
+	}
+	ds.EXPECT().FetchRegistrationEntries(gomock.Any(), &common.Empty{}).
+		Return(&datastore.FetchRegistrationEntriesResponse{
+			RegisteredEntries: &common.RegistrationEntries{Entries: entries},
+		}, nil)
+	ds.EXPECT().UpdateRegistrationEntry(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, req *datastore.UpdateRegistrationEntryRequest) {
+			require.Equal(t, "entry1", req.RegisteredEntryId)
+			require.NotZero(t, req.RegisteredEntry.RotatedAt)
+		}).
+		Return(&datastore.UpdateRegistrationEntryResponse{}, nil)
+	ds.EXPECT().UpdateRegistrationEntry(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("record not found"))
+
+	resp, err := h.TaintX509Authority(ctx, &localauthority.TaintX509AuthorityRequest{SerialNumber: "1"})
+	require.NoError(t, err)
+	require.Equal(t, "1", resp.TaintedAuthority.SerialNumber)
+	require.Equal(t, int32(1), resp.RotatedEntries)
+}
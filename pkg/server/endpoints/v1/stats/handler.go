@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"crypto/x509"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/api/v1/stats"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CA provides the active X509 CA certificate of the server
+type CA interface {
+	CACertificate() *x509.Certificate
+}
+
+// Handler implements the v1 Stats API
+type Handler struct {
+	Log     logrus.FieldLogger
+	Catalog catalog.Catalog
+
+	// Provides the expiry of the active CA, which is not reported if unset
+	CA CA
+}
+
+// GetStats returns the number of registration entries and attested agents,
+// the expiry of the active CA and the datastore plugin of the server
+func (h *Handler) GetStats(ctx context.Context, req *stats.GetStatsRequest) (*stats.GetStatsResponse, error) {
+	ds := h.Catalog.DataStores()[0]
+
+	entries, err := ds.CountRegistrationEntries(ctx, &datastore.CountRegistrationEntriesRequest{})
+	if err != nil {
+		h.Log.Errorf("Error counting entries: %v", err)
+		return nil, status.Error(codes.Internal, "unable to count entries")
+	}
+
+	nodes, err := ds.CountAttestedNodeEntries(ctx, &datastore.CountAttestedNodeEntriesRequest{})
+	if err != nil {
+		h.Log.Errorf("Error counting attested nodes: %v", err)
+		return nil, status.Error(codes.Internal, "unable to count agents")
+	}
+
+	resp := &stats.GetStatsResponse{
+		EntryCount:    entries.Count,
+		AgentCount:    nodes.Count,
+		DatastoreType: ds.Config().PluginName,
+	}
+	if h.CA != nil {
+		if cert := h.CA.CACertificate(); cert != nil {
+			resp.ActiveCaExpiresAt = cert.NotAfter.Unix()
+		}
+	}
+	return resp, nil
+}
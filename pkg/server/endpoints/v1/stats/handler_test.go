@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/api/v1/stats"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+type fakeCA struct {
+	cert *x509.Certificate
+}
+
+func (c fakeCA) CACertificate() *x509.Certificate { return c.cert }
+
+func newTestHandler(ds datastore.DataStore, ca CA) *Handler {
+	log, _ := test.NewNullLogger()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(ds)
+
+	return &Handler{
+		Log:     log,
+		Catalog: catalog,
+		CA:      ca,
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+
+	// A server without an active CA yet
+	h := newTestHandler(ds, fakeCA{})
+	resp, err := h.GetStats(ctx, &stats.GetStatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &stats.GetStatsResponse{DatastoreType: "fake_datastore_1"}, resp)
+
+	h = newTestHandler(ds, nil)
+	resp, err = h.GetStats(ctx, &stats.GetStatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &stats.GetStatsResponse{DatastoreType: "fake_datastore_1"}, resp)
+
+	for _, id := range []string{"spiffe://example.org/a", "spiffe://example.org/b"} {
+		_, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+			RegisteredEntry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/spire/agent/join_token/abcd",
+				SpiffeId:  id,
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		})
+		require.NoError(t, err)
+	}
+	_, err = ds.CreateAttestedNodeEntry(ctx, &datastore.CreateAttestedNodeEntryRequest{
+		AttestedNodeEntry: &datastore.AttestedNodeEntry{
+			BaseSpiffeId:        "spiffe://example.org/spire/agent/join_token/abcd",
+			AttestationDataType: "join_token",
+			CertSerialNumber:    "1234",
+			CertExpirationDate:  "Mon, 01 Jan 2018 00:00:00 +0000",
+		},
+	})
+	require.NoError(t, err)
+
+	template, err := testutil.NewCATemplate("example.org")
+	require.NoError(t, err)
+	caCert, _, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+
+	h = newTestHandler(ds, fakeCA{cert: caCert})
+	resp, err = h.GetStats(ctx, &stats.GetStatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &stats.GetStatsResponse{
+		EntryCount:        2,
+		AgentCount:        1,
+		ActiveCaExpiresAt: caCert.NotAfter.Unix(),
+		DatastoreType:     "fake_datastore_1",
+	}, resp)
+}
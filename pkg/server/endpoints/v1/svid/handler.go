@@ -0,0 +1,116 @@
+package svid
+
+import (
+	"crypto/x509"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/x509svid"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
+	"github.com/spiffe/spire/pkg/server/signpool"
+	"github.com/spiffe/spire/proto/api/v1/svid"
+	"github.com/spiffe/spire/proto/server/ca"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler implements the v1 SVID API
+type Handler struct {
+	Log         logrus.FieldLogger
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+	CSRPolicy   *csrpolicy.Policy
+
+	// Bounds how many CSRs are signed at once. Unbounded if not set.
+	SignPool *signpool.Pool
+
+	// If true, the CA is an intermediate of the upstream CA and is not in
+	// the trust bundle, so its certificate is added to the chain of the SVIDs
+	UpstreamBundle bool
+}
+
+// MintX509SVID mints an X509-SVID for the SPIFFE ID in the given CSR
+func (h *Handler) MintX509SVID(ctx context.Context, req *svid.MintX509SVIDRequest) (*svid.MintX509SVIDResponse, error) {
+	if req.Ttl < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl cannot be negative")
+	}
+
+	csr, err := x509svid.ParseAndValidateCSR(req.Csr, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid CSR: %v", err)
+	}
+	spiffeID := csr.URIs[0].String()
+
+	ttl, err := h.CSRPolicy.Check(ctx, &csrpolicy.Request{
+		CSR:      req.Csr,
+		SpiffeID: spiffeID,
+		TTL:      req.Ttl,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "CSR rejected by policy: %v", err)
+	}
+
+	serverCA := h.Catalog.CAs()[0]
+	resp, err := h.signCSR(ctx, serverCA, &ca.SignCsrRequest{
+		Csr: req.Csr,
+		Ttl: ttl,
+	})
+	if err == signpool.ErrQueueFull {
+		return nil, status.Error(codes.ResourceExhausted, "too many CSRs waiting to be signed, retry later")
+	}
+	if err != nil {
+		h.Log.Errorf("Error signing CSR for %q: %v", spiffeID, err)
+		return nil, status.Error(codes.Internal, "unable to sign CSR")
+	}
+
+	cert, err := x509.ParseCertificate(resp.SignedCertificate)
+	if err != nil {
+		h.Log.Errorf("Error parsing SVID for %q: %v", spiffeID, err)
+		return nil, status.Error(codes.Internal, "unable to parse signed SVID")
+	}
+
+	certChain := [][]byte{cert.Raw}
+	if h.UpstreamBundle {
+		caResp, err := serverCA.FetchCertificate(ctx, &ca.FetchCertificateRequest{})
+		if err != nil {
+			h.Log.Errorf("Error fetching CA certificate: %v", err)
+			return nil, status.Error(codes.Internal, "unable to fetch CA certificate")
+		}
+		caCert, err := x509.ParseCertificate(caResp.StoredIntermediateCert)
+		if err == nil {
+			err = cert.CheckSignatureFrom(caCert)
+		}
+		if err != nil {
+			// The CA may have been rotated since the SVID was signed
+			h.Log.Errorf("CA certificate does not match the SVID for %q: %v", spiffeID, err)
+			return nil, status.Error(codes.Unavailable, "CA certificate changed while signing")
+		}
+		certChain = append(certChain, caCert.Raw)
+	}
+
+	h.Log.Debugf("Minted X509-SVID for %v", spiffeID)
+	return &svid.MintX509SVIDResponse{
+		Svid: &svid.X509SVID{
+			SpiffeId:  spiffeID,
+			CertChain: certChain,
+			ExpiresAt: cert.NotAfter.Unix(),
+		},
+	}, nil
+}
+
+// signCSR has the server CA sign the CSR, through the signing pool if set
+func (h *Handler) signCSR(ctx context.Context, serverCA ca.ServerCA, req *ca.SignCsrRequest) (*ca.SignCsrResponse, error) {
+	if h.SignPool == nil {
+		return serverCA.SignCsr(ctx, req)
+	}
+
+	var resp *ca.SignCsrResponse
+	err := h.SignPool.Sign(ctx, func(ctx context.Context) (err error) {
+		resp, err = serverCA.SignCsr(ctx, req)
+		return err
+	})
+	return resp, err
+}
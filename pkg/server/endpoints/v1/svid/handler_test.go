@@ -0,0 +1,98 @@
+package svid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
+	"github.com/spiffe/spire/proto/api/v1/svid"
+	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/ca"
+	testutil "github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestHandler(serverCA ca.ServerCA) *Handler {
+	log, _ := test.NewNullLogger()
+	catalog := fakeservercatalog.New()
+	catalog.SetCAs(serverCA)
+
+	return &Handler{
+		Log:         log,
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+		CSRPolicy:   csrpolicy.New(csrpolicy.Config{}, catalog),
+	}
+}
+
+func makeCSR(t *testing.T, spiffeID string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	u, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		URIs: []*url.URL{u},
+	}, key)
+	require.NoError(t, err)
+	return csr
+}
+
+func TestMintX509SVID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spiffeID := "spiffe://example.org/workload"
+	csr := makeCSR(t, spiffeID)
+
+	template, err := testutil.NewSVIDTemplate(spiffeID)
+	require.NoError(t, err)
+	cert, _, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+
+	serverCA := mock_ca.NewMockServerCA(ctrl)
+	serverCA.EXPECT().SignCsr(gomock.Any(), &ca.SignCsrRequest{Csr: csr, Ttl: 60}).
+		Return(&ca.SignCsrResponse{SignedCertificate: cert.Raw}, nil)
+
+	h := newTestHandler(serverCA)
+	resp, err := h.MintX509SVID(context.Background(), &svid.MintX509SVIDRequest{Csr: csr, Ttl: 60})
+	require.NoError(t, err)
+	require.Equal(t, &svid.X509SVID{
+		SpiffeId:  spiffeID,
+		CertChain: [][]byte{cert.Raw},
+		ExpiresAt: cert.NotAfter.Unix(),
+	}, resp.Svid)
+}
+
+func TestMintX509SVIDInvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := newTestHandler(mock_ca.NewMockServerCA(ctrl))
+	ctx := context.Background()
+
+	_, err := h.MintX509SVID(ctx, &svid.MintX509SVIDRequest{Csr: []byte("garbage")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.MintX509SVID(ctx, &svid.MintX509SVIDRequest{
+		Csr: makeCSR(t, "spiffe://otherdomain.test/workload"),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.MintX509SVID(ctx, &svid.MintX509SVIDRequest{
+		Csr: makeCSR(t, "spiffe://example.org/workload"),
+		Ttl: -1,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,447 @@
+package entrycache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+const (
+	defaultPollInterval     = time.Second
+	defaultResyncInterval   = 10 * time.Minute
+	defaultMaxNodeSelectors = 100000
+
+	// How long an event ID skipped in the event log is looked for. Event
+	// IDs are allocated when the change is written, so a change committed
+	// after a later one shows up behind it in the log, while the ID of a
+	// change rolled back never shows up.
+	missedEventTimeout = time.Minute
+)
+
+// Cache keeps the registration entries, and the selectors the node resolvers
+// mapped the nodes to, in memory, so that the entries an agent is authorized
+// for are computed without querying the datastore on every sync. It is
+// populated when initialized and kept up to date from the entry event log
+// of the datastore, which also lets servers sharing a datastore see the
+// changes made through each other. The old entry events are pruned by the
+// update notifier; if the cache falls behind and the latest event it applied
+// is pruned, the events following it may be lost, so everything is loaded
+// again.
+type Cache struct {
+	Catalog catalog.Catalog
+	Log     logrus.FieldLogger
+
+	// How often the entry event log is polled. Changes take up to that long
+	// to be seen. Defaults to one second.
+	PollInterval time.Duration
+
+	// How often everything is loaded again from the datastore, as a safety
+	// net for changes the entry events missed. Defaults to ten minutes.
+	ResyncInterval time.Duration
+
+	// Maximum number of IDs whose selectors are kept. Beyond it, an ID is
+	// evicted at random whenever another one is loaded. Defaults to 100,000.
+	MaxNodeSelectors int
+
+	// Serializes the refreshes, so that an entry refreshed concurrently is
+	// not left as fetched by the earlier one
+	refreshMtx sync.Mutex
+
+	mtx sync.RWMutex
+
+	// Registration entries by ID, and indexed by parent ID and by selector
+	entries    map[string]*common.RegistrationEntry
+	byParentID map[string]map[string]*common.RegistrationEntry
+	bySelector map[selectorKey]map[string]*common.RegistrationEntry
+
+	// Selectors of the IDs looked up so far, loaded on first use. Cleared
+	// for an ID when its selectors change, and for all of them when
+	// everything is loaded again. Changes are counted by nodeChanges so that
+	// selectors loaded concurrently with a change are not kept.
+	nodeSelectors map[string][]*common.Selector
+	nodeChanges   uint64
+
+	// ID of the latest event applied, and the IDs skipped before it that
+	// may still show up, with when they were skipped
+	lastEventID  uint64
+	missedEvents map[uint64]time.Time
+}
+
+type selectorKey struct {
+	Type  string
+	Value string
+}
+
+// Initialize loads the registration entries, replacing those cached. Run
+// calls it again at every resync interval. The entry events recorded while
+// loading are applied again by Run, which is harmless since events are
+// applied by fetching the current state of what changed.
+func (c *Cache) Initialize(ctx context.Context) error {
+	c.refreshMtx.Lock()
+	defer c.refreshMtx.Unlock()
+
+	return c.load(ctx)
+}
+
+// load loads the registration entries, and the entry events already
+// applied. The caller must hold the refresh lock.
+func (c *Cache) load(ctx context.Context) error {
+	ds := c.Catalog.DataStores()[0]
+
+	eventsResp, err := ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{})
+	if err != nil {
+		return fmt.Errorf("list entry events: %v", err)
+	}
+	entriesResp, err := ds.FetchRegistrationEntries(ctx, &common.Empty{})
+	if err != nil {
+		return fmt.Errorf("fetch registration entries: %v", err)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.entries = make(map[string]*common.RegistrationEntry)
+	c.byParentID = make(map[string]map[string]*common.RegistrationEntry)
+	c.bySelector = make(map[selectorKey]map[string]*common.RegistrationEntry)
+	c.nodeSelectors = make(map[string][]*common.Selector)
+	c.nodeChanges++
+	c.lastEventID = 0
+	c.missedEvents = make(map[uint64]time.Time)
+
+	// Events recorded before the entries were fetched are applied already,
+	// except those skipped, which may still show up
+	if events := eventsResp.Events; len(events) > 0 {
+		c.lastEventID = events[0].EventId
+		for _, event := range events[1:] {
+			c.trackEvent(event.EventId)
+		}
+	}
+
+	for _, entry := range entriesResp.GetRegisteredEntries().GetEntries() {
+		c.putEntry(entry)
+	}
+
+	c.Log.Infof("Cached %d registration entries", len(c.entries))
+	return nil
+}
+
+// Run applies the entry events as they are recorded, until the context is
+// cancelled
+func (c *Cache) Run(ctx context.Context) error {
+	pollInterval := c.PollInterval
+	if pollInterval == 0 {
+		pollInterval = defaultPollInterval
+	}
+	resyncInterval := c.ResyncInterval
+	if resyncInterval == 0 {
+		resyncInterval = defaultResyncInterval
+	}
+
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+	resyncTicker := time.NewTicker(resyncInterval)
+	defer resyncTicker.Stop()
+
+	for {
+		select {
+		case <-pollTicker.C:
+			if err := c.Refresh(ctx); err != nil {
+				c.Log.Warnf("Could not apply the entry events: %v", err)
+			}
+		case <-resyncTicker.C:
+			if err := c.Initialize(ctx); err != nil {
+				c.Log.Warnf("Could not load the registration entries again: %v", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// FetchAuthorizedEntries returns the registration entries the given ID is
+// authorized for: those it is the parent of or was mapped to by a node
+// resolver, and recursively those of their SPIFFE IDs.
+func (c *Cache) FetchAuthorizedEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, error) {
+	var entries []*common.RegistrationEntry
+	visited := make(map[string]bool)
+	pending := []string{id}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		selectors, err := c.getNodeSelectors(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range c.directEntries(id, selectors) {
+			entries = append(entries, entry)
+			pending = append(pending, entry.SpiffeId)
+		}
+	}
+
+	// The entries are cloned when deduplicated, so they can be changed by
+	// the caller
+	return util.DedupRegistrationEntries(entries), nil
+}
+
+// directEntries returns the registration entries the given ID is the parent
+// of, and those whose selectors are all among the selectors given
+func (c *Cache) directEntries(id string, selectors []*common.Selector) []*common.RegistrationEntry {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	var entries []*common.RegistrationEntry
+	for _, entry := range c.byParentID[id] {
+		entries = append(entries, entry)
+	}
+
+	// Count how many of the selectors of each entry are matched
+	matched := make(map[string]int)
+	seen := make(map[selectorKey]bool)
+	for _, s := range selectors {
+		key := selectorKey{Type: s.Type, Value: s.Value}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		for entryID := range c.bySelector[key] {
+			matched[entryID]++
+		}
+	}
+	for entryID, count := range matched {
+		entry := c.entries[entryID]
+		if count == len(entry.Selectors) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// getNodeSelectors returns the selectors the given ID was mapped to by the
+// node resolvers, loading them from the datastore on first use
+func (c *Cache) getNodeSelectors(ctx context.Context, id string) ([]*common.Selector, error) {
+	c.mtx.RLock()
+	selectors, ok := c.nodeSelectors[id]
+	nodeChanges := c.nodeChanges
+	c.mtx.RUnlock()
+	if ok {
+		return selectors, nil
+	}
+
+	resp, err := c.Catalog.DataStores()[0].FetchNodeResolverMapEntry(ctx,
+		&datastore.FetchNodeResolverMapEntryRequest{
+			BaseSpiffeId: id,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	selectors = []*common.Selector{}
+	for _, entry := range resp.NodeResolverMapEntryList {
+		selectors = append(selectors, entry.Selector)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.nodeChanges == nodeChanges {
+		c.putNodeSelectors(id, selectors)
+	}
+	return selectors, nil
+}
+
+// putNodeSelectors keeps the selectors of the given ID, evicting another ID
+// at random if as many as allowed are kept already. The caller must hold the
+// lock.
+func (c *Cache) putNodeSelectors(id string, selectors []*common.Selector) {
+	maxNodeSelectors := c.MaxNodeSelectors
+	if maxNodeSelectors == 0 {
+		maxNodeSelectors = defaultMaxNodeSelectors
+	}
+
+	if _, ok := c.nodeSelectors[id]; !ok && len(c.nodeSelectors) >= maxNodeSelectors {
+		for evicted := range c.nodeSelectors {
+			delete(c.nodeSelectors, evicted)
+			break
+		}
+	}
+	c.nodeSelectors[id] = selectors
+}
+
+// Refresh applies the entry events recorded since the latest one applied,
+// and those skipped before it that showed up since. Everything is loaded
+// again if the latest event applied was pruned, as the events following it
+// may have been too.
+func (c *Cache) Refresh(ctx context.Context) error {
+	c.refreshMtx.Lock()
+	defer c.refreshMtx.Unlock()
+
+	// The latest event applied is listed again, to tell whether it was
+	// pruned
+	c.mtx.RLock()
+	lastEventID := c.lastEventID
+	afterEventID := lastEventID
+	if afterEventID > 0 {
+		afterEventID--
+	}
+	for eventID := range c.missedEvents {
+		if eventID <= afterEventID {
+			afterEventID = eventID - 1
+		}
+	}
+	c.mtx.RUnlock()
+
+	ds := c.Catalog.DataStores()[0]
+	resp, err := ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{
+		AfterEventId: afterEventID,
+	})
+	if err != nil {
+		return fmt.Errorf("list entry events: %v", err)
+	}
+
+	if lastEventID > 0 && !hasEvent(resp.Events, lastEventID) {
+		c.Log.Infof("Latest entry event applied (%d) was pruned; loading the registration entries again", lastEventID)
+		return c.load(ctx)
+	}
+
+	for _, event := range resp.Events {
+		if c.isApplied(event.EventId) {
+			continue
+		}
+
+		switch {
+		case event.EntryId != "":
+			if err := c.refreshEntry(ctx, ds, event.EntryId); err != nil {
+				return err
+			}
+		case event.NodeSpiffeId != "":
+			c.clearNodeSelectors(event.NodeSpiffeId)
+		}
+
+		c.mtx.Lock()
+		c.trackEvent(event.EventId)
+		c.mtx.Unlock()
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	now := time.Now()
+	for eventID, skippedAt := range c.missedEvents {
+		if now.Sub(skippedAt) > missedEventTimeout {
+			delete(c.missedEvents, eventID)
+		}
+	}
+	return nil
+}
+
+func hasEvent(events []*datastore.EntryEvent, eventID uint64) bool {
+	for _, event := range events {
+		if event.EventId == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+// isApplied returns true if the given event was applied already
+func (c *Cache) isApplied(eventID uint64) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	_, missed := c.missedEvents[eventID]
+	return eventID <= c.lastEventID && !missed
+}
+
+// trackEvent records that the given event is applied, tracking the event
+// IDs it skips. The caller must hold the lock.
+func (c *Cache) trackEvent(eventID uint64) {
+	if eventID <= c.lastEventID {
+		delete(c.missedEvents, eventID)
+		return
+	}
+
+	now := time.Now()
+	for skipped := c.lastEventID + 1; skipped < eventID; skipped++ {
+		c.missedEvents[skipped] = now
+	}
+	c.lastEventID = eventID
+}
+
+// refreshEntry updates the cached registration entry from the datastore,
+// removing it if it was deleted
+func (c *Cache) refreshEntry(ctx context.Context, ds datastore.DataStore, entryID string) error {
+	resp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		RegisteredEntryId: entryID,
+	})
+	if err != nil {
+		return fmt.Errorf("fetch registration entry %q: %v", entryID, err)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.removeEntry(entryID)
+	if resp.RegisteredEntry != nil {
+		c.putEntry(resp.RegisteredEntry)
+	}
+	return nil
+}
+
+func (c *Cache) clearNodeSelectors(id string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.nodeSelectors, id)
+	c.nodeChanges++
+}
+
+// putEntry adds the registration entry to the indexes. The caller must hold
+// the lock.
+func (c *Cache) putEntry(entry *common.RegistrationEntry) {
+	c.entries[entry.EntryId] = entry
+
+	if c.byParentID[entry.ParentId] == nil {
+		c.byParentID[entry.ParentId] = make(map[string]*common.RegistrationEntry)
+	}
+	c.byParentID[entry.ParentId][entry.EntryId] = entry
+
+	for _, s := range entry.Selectors {
+		key := selectorKey{Type: s.Type, Value: s.Value}
+		if c.bySelector[key] == nil {
+			c.bySelector[key] = make(map[string]*common.RegistrationEntry)
+		}
+		c.bySelector[key][entry.EntryId] = entry
+	}
+}
+
+// removeEntry removes the registration entry from the indexes, if cached.
+// The caller must hold the lock.
+func (c *Cache) removeEntry(entryID string) {
+	entry, ok := c.entries[entryID]
+	if !ok {
+		return
+	}
+	delete(c.entries, entryID)
+
+	delete(c.byParentID[entry.ParentId], entryID)
+	if len(c.byParentID[entry.ParentId]) == 0 {
+		delete(c.byParentID, entry.ParentId)
+	}
+
+	for _, s := range entry.Selectors {
+		key := selectorKey{Type: s.Type, Value: s.Value}
+		delete(c.bySelector[key], entryID)
+		if len(c.bySelector[key]) == 0 {
+			delete(c.bySelector, key)
+		}
+	}
+}
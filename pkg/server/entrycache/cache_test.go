@@ -0,0 +1,259 @@
+package entrycache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	ctx = context.Background()
+
+	a1 = &common.Selector{Type: "a", Value: "1"}
+	b2 = &common.Selector{Type: "b", Value: "2"}
+	c3 = &common.Selector{Type: "c", Value: "3"}
+)
+
+const (
+	rootID  = "spiffe://example.org/root"
+	agentID = "spiffe://example.org/agent"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+
+	ds *fakedatastore.FakeDataStore
+	c  *Cache
+}
+
+func TestCache(t *testing.T) {
+	suite.Run(t, new(CacheTestSuite))
+}
+
+func (s *CacheTestSuite) SetupTest() {
+	s.ds = fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(s.ds)
+
+	log, _ := test.NewNullLogger()
+	s.c = &Cache{
+		Catalog: catalog,
+		Log:     log,
+	}
+}
+
+func (s *CacheTestSuite) TestFetchAuthorizedEntriesMatchesDataStore() {
+	//
+	//        root             4(a1,b2)    6(a1,c3)
+	//        /   \           /
+	//       1     2         5
+	//            /
+	//           3
+	//
+	// node resolvers map from 2 to a1 and b2
+	s.createEntry(&common.RegistrationEntry{ParentId: rootID, SpiffeId: "spiffe://example.org/1"})
+	s.createEntry(&common.RegistrationEntry{ParentId: rootID, SpiffeId: "spiffe://example.org/2"})
+	s.createEntry(&common.RegistrationEntry{ParentId: "spiffe://example.org/2", SpiffeId: "spiffe://example.org/3"})
+	s.createEntry(&common.RegistrationEntry{SpiffeId: "spiffe://example.org/4", Selectors: []*common.Selector{a1, b2}})
+	s.createEntry(&common.RegistrationEntry{ParentId: "spiffe://example.org/4", SpiffeId: "spiffe://example.org/5"})
+	s.createEntry(&common.RegistrationEntry{SpiffeId: "spiffe://example.org/6", Selectors: []*common.Selector{a1, c3}})
+	s.createNodeSelector("spiffe://example.org/2", a1)
+	s.createNodeSelector("spiffe://example.org/2", b2)
+
+	s.Require().NoError(s.c.Initialize(ctx))
+
+	s.assertAuthorizedEntries(rootID, 5)
+	s.assertAuthorizedEntries("spiffe://example.org/2", 3)
+	s.assertAuthorizedEntries("spiffe://example.org/6", 0)
+}
+
+func (s *CacheTestSuite) TestPollAppliesEntryChanges() {
+	entryID := s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: "spiffe://example.org/workload"})
+	s.Require().NoError(s.c.Initialize(ctx))
+	s.assertAuthorizedEntries(agentID, 1)
+
+	// Created
+	otherID := s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: "spiffe://example.org/other"})
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.assertAuthorizedEntries(agentID, 2)
+
+	// Updated to another parent
+	_, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		RegisteredEntryId: otherID,
+		RegisteredEntry:   &common.RegistrationEntry{ParentId: rootID, SpiffeId: "spiffe://example.org/other"},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.assertAuthorizedEntries(agentID, 1)
+	s.assertAuthorizedEntries(rootID, 1)
+
+	// Deleted
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		RegisteredEntryId: entryID,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.assertAuthorizedEntries(agentID, 0)
+	s.Len(s.c.entries, 1)
+}
+
+func (s *CacheTestSuite) TestPollAppliesNodeSelectorChanges() {
+	s.createEntry(&common.RegistrationEntry{SpiffeId: "spiffe://example.org/workload", Selectors: []*common.Selector{a1}})
+	s.Require().NoError(s.c.Initialize(ctx))
+
+	// The selectors are loaded on first use, and kept until they change
+	s.assertAuthorizedEntries(agentID, 0)
+	s.createNodeSelector(agentID, a1)
+	s.assertAuthorizedEntries(agentID, 0)
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.assertAuthorizedEntries(agentID, 1)
+
+	_, err := s.ds.DeleteNodeResolverMapEntry(ctx, &datastore.DeleteNodeResolverMapEntryRequest{
+		NodeResolverMapEntry: &datastore.NodeResolverMapEntry{BaseSpiffeId: agentID},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.assertAuthorizedEntries(agentID, 0)
+}
+
+func (s *CacheTestSuite) TestTrackEventRemembersSkippedEvents() {
+	s.Require().NoError(s.c.Initialize(ctx))
+
+	s.c.trackEvent(1)
+	s.c.trackEvent(4)
+	s.Equal(uint64(4), s.c.lastEventID)
+	s.Len(s.c.missedEvents, 2)
+	s.False(s.c.isApplied(2))
+	s.False(s.c.isApplied(3))
+	s.True(s.c.isApplied(4))
+
+	// A skipped event showing up late is applied once
+	s.c.trackEvent(3)
+	s.True(s.c.isApplied(3))
+	s.Len(s.c.missedEvents, 1)
+	s.Equal(uint64(4), s.c.lastEventID)
+}
+
+func (s *CacheTestSuite) TestPollForgetsSkippedEventsAfterTimeout() {
+	for i := 1; i <= 4; i++ {
+		s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: fmt.Sprintf("spiffe://example.org/%d", i)})
+		if i == 3 {
+			s.pruneEntryEvents()
+		}
+	}
+	s.Require().NoError(s.c.Initialize(ctx))
+	s.Equal(uint64(4), s.c.lastEventID)
+
+	// Events 1 and 2 were skipped before event 4
+	s.c.missedEvents[1] = time.Now().Add(-2 * missedEventTimeout)
+	s.c.missedEvents[2] = time.Now()
+
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.Len(s.c.missedEvents, 1)
+	s.Contains(s.c.missedEvents, uint64(2))
+}
+
+func (s *CacheTestSuite) TestPollReloadsWhenLatestEventIsPruned() {
+	s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: "spiffe://example.org/1"})
+	s.Require().NoError(s.c.Initialize(ctx))
+	s.Equal(uint64(1), s.c.lastEventID)
+
+	// The cache falls behind while the events are pruned: the creation of
+	// entry 2 is lost, while the one of entry 3 is still in the log
+	s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: "spiffe://example.org/2"})
+	s.pruneEntryEvents()
+	s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: "spiffe://example.org/3"})
+
+	s.Require().NoError(s.c.Refresh(ctx))
+	s.assertAuthorizedEntries(agentID, 3)
+	s.Equal(uint64(3), s.c.lastEventID)
+}
+
+func (s *CacheTestSuite) TestRunResyncs() {
+	s.c.PollInterval = time.Hour
+	s.c.ResyncInterval = 10 * time.Millisecond
+	s.Require().NoError(s.c.Initialize(ctx))
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan error)
+	go func() { done <- s.c.Run(ctx) }()
+	defer func() {
+		cancel()
+		s.Require().NoError(<-done)
+	}()
+
+	// The entry is loaded by the next resync, the events not being polled
+	s.createEntry(&common.RegistrationEntry{ParentId: agentID, SpiffeId: "spiffe://example.org/workload"})
+	for i := 0; ; i++ {
+		entries, err := s.c.FetchAuthorizedEntries(ctx, agentID)
+		s.Require().NoError(err)
+		if len(entries) == 1 {
+			break
+		}
+		s.Require().True(i < 100, "entry not loaded by a resync")
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (s *CacheTestSuite) TestNodeSelectorsAreBounded() {
+	s.c.MaxNodeSelectors = 2
+	s.Require().NoError(s.c.Initialize(ctx))
+
+	for _, id := range []string{"spiffe://example.org/1", "spiffe://example.org/2", "spiffe://example.org/3"} {
+		s.assertAuthorizedEntries(id, 0)
+		s.True(len(s.c.nodeSelectors) <= 2)
+	}
+	s.Contains(s.c.nodeSelectors, "spiffe://example.org/3")
+
+	// Everything loaded again starts afresh
+	s.Require().NoError(s.c.Initialize(ctx))
+	s.Empty(s.c.nodeSelectors)
+}
+
+func (s *CacheTestSuite) createEntry(entry *common.RegistrationEntry) string {
+	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		RegisteredEntry: entry,
+	})
+	s.Require().NoError(err)
+	return resp.RegisteredEntryId
+}
+
+func (s *CacheTestSuite) createNodeSelector(id string, selector *common.Selector) {
+	_, err := s.ds.CreateNodeResolverMapEntry(ctx, &datastore.CreateNodeResolverMapEntryRequest{
+		NodeResolverMapEntry: &datastore.NodeResolverMapEntry{
+			BaseSpiffeId: id,
+			Selector:     selector,
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *CacheTestSuite) pruneEntryEvents() {
+	_, err := s.ds.PruneEntryEvents(ctx, &datastore.EntryEvent{
+		CreatedAt: time.Now().Add(time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+}
+
+// assertAuthorizedEntries asserts that the cache returns as many entries for
+// the given ID as expected, and the same ones as the datastore
+func (s *CacheTestSuite) assertAuthorizedEntries(id string, expected int) {
+	actual, err := s.c.FetchAuthorizedEntries(ctx, id)
+	s.Require().NoError(err)
+	s.Len(actual, expected)
+
+	if expected > 0 {
+		fromDataStore, err := regentryutil.FetchRegistrationEntries(ctx, s.ds, id)
+		s.Require().NoError(err)
+		s.Equal(fromDataStore, actual)
+	}
+}
@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	common "github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/upstreamca"
+)
+
+// DefaultBindAddress is the default address the health checks are served on
+const DefaultBindAddress = "localhost:8080"
+
+// Config configures the health check endpoint
+type Config struct {
+	// If true, the health checks are served over HTTP
+	Enabled bool
+
+	// Address to serve the health checks on. Defaults to
+	// DefaultBindAddress.
+	BindAddress string
+}
+
+// Checker serves the liveness and readiness of the server. The server is
+// live while its CA has a valid signing certificate, and ready while it is
+// live and both its datastore and upstream CA, if any, can be reached.
+type Checker struct {
+	Config      Config
+	Catalog     catalog.Catalog
+	TrustDomain url.URL
+	Log         logrus.FieldLogger
+}
+
+// ListenAndServe serves the health checks until the context is cancelled
+func (c *Checker) ListenAndServe(ctx context.Context) error {
+	return common.ListenAndServe(ctx, c.bindAddress(), c.Handler(), c.Log)
+}
+
+// Handler returns the HTTP handler serving the health checks
+func (c *Checker) Handler() http.Handler {
+	return common.Handler(c, c.Log)
+}
+
+// ReportHandler returns the HTTP handler serving the health report as JSON
+func (c *Checker) ReportHandler() http.Handler {
+	return common.ReportHandler(c, c.Log)
+}
+
+// Report checks the health of the components of the server the given check
+// depends on
+func (c *Checker) Report(ctx context.Context, check string) (*common.Report, error) {
+	return common.MakeReport(ctx, c, check)
+}
+
+// Components returns the components of the server the given check depends on
+func (c *Checker) Components(check string) ([]common.Component, error) {
+	switch check {
+	case common.CheckShallow:
+		return nil, nil
+	case common.CheckLive:
+		return []common.Component{{Name: "ca", Check: c.checkCA}}, nil
+	case common.CheckReady:
+		return []common.Component{
+			{Name: "ca", Check: c.checkCA},
+			{Name: "datastore", Check: c.checkDataStore},
+			{Name: "upstream_ca", Check: c.checkUpstreamCAs},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown check %q", check)
+	}
+}
+
+func (c *Checker) checkCA(ctx context.Context) error {
+	resp, err := c.Catalog.CAs()[0].FetchCertificate(ctx, &ca.FetchCertificateRequest{})
+	if err != nil {
+		return fmt.Errorf("CA is unavailable: %v", err)
+	}
+	if len(resp.StoredIntermediateCert) == 0 {
+		return errors.New("CA has no signing certificate")
+	}
+	cert, err := x509.ParseCertificate(resp.StoredIntermediateCert)
+	if err != nil {
+		return fmt.Errorf("CA signing certificate is invalid: %v", err)
+	}
+	return checkCACert(cert, time.Now())
+}
+
+func (c *Checker) checkDataStore(ctx context.Context) error {
+	_, err := c.Catalog.DataStores()[0].FetchBundle(ctx, &datastore.Bundle{
+		TrustDomain: c.TrustDomain.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("datastore is unavailable: %v", err)
+	}
+	return nil
+}
+
+// checkUpstreamCAs checks that the external upstream CA plugins answer. The
+// upstream CA client does not expose the plugin calls, but plugins all
+// implement them, so fetching the plugin info tells whether an external
+// upstream CA plugin still answers.
+func (c *Checker) checkUpstreamCAs(ctx context.Context) error {
+	for _, upstreamCA := range c.Catalog.UpstreamCAs() {
+		p, ok := upstreamCA.UpstreamCA.(upstreamca.Plugin)
+		if !ok {
+			continue
+		}
+		if _, err := p.GetPluginInfo(ctx, &plugin.GetPluginInfoRequest{}); err != nil {
+			return fmt.Errorf("upstream CA is unavailable: %v", err)
+		}
+	}
+	return nil
+}
+
+func (c *Checker) bindAddress() string {
+	if c.Config.BindAddress != "" {
+		return c.Config.BindAddress
+	}
+	return DefaultBindAddress
+}
+
+func checkCACert(cert *x509.Certificate, now time.Time) error {
+	if now.After(cert.NotAfter) {
+		return fmt.Errorf("CA signing certificate expired at %v", cert.NotAfter)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	common "github.com/spiffe/spire/pkg/common/health"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/mock/proto/server/ca"
+	"github.com/spiffe/spire/test/mock/proto/server/datastore"
+	"github.com/spiffe/spire/test/mock/proto/server/upstreamca"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+)
+
+var ctx = context.Background()
+
+type CheckerTestSuite struct {
+	suite.Suite
+
+	ctrl       *gomock.Controller
+	ca         *mock_ca.MockServerCA
+	ds         *mock_datastore.MockDataStore
+	upstreamCA *mock_upstreamca.MockPlugin
+	c          *Checker
+}
+
+func TestChecker(t *testing.T) {
+	suite.Run(t, new(CheckerTestSuite))
+}
+
+func (s *CheckerTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.ca = mock_ca.NewMockServerCA(s.ctrl)
+	s.ds = mock_datastore.NewMockDataStore(s.ctrl)
+	s.upstreamCA = mock_upstreamca.NewMockPlugin(s.ctrl)
+
+	catalog := fakeservercatalog.New()
+	catalog.SetCAs(s.ca)
+	catalog.SetDataStores(s.ds)
+	catalog.SetUpstreamCAs(s.upstreamCA)
+
+	log, _ := test.NewNullLogger()
+	s.c = &Checker{
+		Catalog:     catalog,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+		Log:         log,
+	}
+}
+
+func (s *CheckerTestSuite) TearDownTest() {
+	s.ctrl.Finish()
+}
+
+func (s *CheckerTestSuite) TestLive() {
+	s.expectCACert(time.Now().Add(time.Hour))
+	s.Require().NoError(s.check(common.CheckLive))
+
+	s.expectCACert(time.Now().Add(-time.Minute))
+	s.Require().Error(s.check(common.CheckLive))
+
+	s.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{}, nil)
+	s.Require().EqualError(s.check(common.CheckLive), "CA has no signing certificate")
+}
+
+func (s *CheckerTestSuite) TestReady() {
+	s.expectCACert(time.Now().Add(time.Hour))
+	s.ds.EXPECT().FetchBundle(gomock.Any(), &datastore.Bundle{TrustDomain: "spiffe://example.org"}).Return(&datastore.Bundle{}, nil)
+	s.upstreamCA.EXPECT().GetPluginInfo(gomock.Any(), gomock.Any()).Return(&plugin.GetPluginInfoResponse{}, nil)
+	s.Require().NoError(s.check(common.CheckReady))
+
+	s.expectCACert(time.Now().Add(time.Hour))
+	s.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	s.Require().EqualError(s.check(common.CheckReady), "datastore is unavailable: connection refused")
+
+	s.expectCACert(time.Now().Add(time.Hour))
+	s.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(&datastore.Bundle{}, nil)
+	s.upstreamCA.EXPECT().GetPluginInfo(gomock.Any(), gomock.Any()).Return(nil, errors.New("plugin exited"))
+	s.Require().EqualError(s.check(common.CheckReady), "upstream CA is unavailable: plugin exited")
+}
+
+func (s *CheckerTestSuite) TestHandler() {
+	s.expectCACert(time.Now().Add(time.Hour))
+
+	server := httptest.NewServer(s.c.Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + common.LivePath)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	s.expectCACert(time.Now().Add(time.Hour))
+	s.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	resp, err = http.Get(server.URL + common.ReadyPath)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func (s *CheckerTestSuite) TestReport() {
+	report, err := s.c.Report(ctx, common.CheckShallow)
+	s.Require().NoError(err)
+	s.Require().Equal(&common.Report{Healthy: true}, report)
+
+	s.expectCACert(time.Now().Add(time.Hour))
+	report, err = s.c.Report(ctx, common.CheckLive)
+	s.Require().NoError(err)
+	s.Require().Equal(&common.Report{
+		Healthy:    true,
+		Components: []common.ComponentHealth{{Name: "ca", Healthy: true}},
+	}, report)
+
+	// Every component is checked, even after one is found unhealthy
+	s.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{}, nil)
+	s.ds.EXPECT().FetchBundle(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+	s.upstreamCA.EXPECT().GetPluginInfo(gomock.Any(), gomock.Any()).Return(&plugin.GetPluginInfoResponse{}, nil)
+	report, err = s.c.Report(ctx, common.CheckReady)
+	s.Require().NoError(err)
+	s.Require().Equal(&common.Report{
+		Healthy: false,
+		Components: []common.ComponentHealth{
+			{Name: "ca", Error: "CA has no signing certificate"},
+			{Name: "datastore", Error: "datastore is unavailable: connection refused"},
+			{Name: "upstream_ca", Healthy: true},
+		},
+	}, report)
+
+	_, err = s.c.Report(ctx, "deep")
+	s.Require().EqualError(err, `unknown check "deep"`)
+}
+
+func (s *CheckerTestSuite) TestReportHandler() {
+	server := httptest.NewServer(s.c.Handler())
+	defer server.Close()
+
+	s.expectCACert(time.Now().Add(-time.Minute))
+	resp, err := http.Get(server.URL + common.ReportPath)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	report := new(common.Report)
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(report))
+	s.Require().False(report.Healthy)
+	s.Require().Len(report.Components, 1)
+	s.Require().Contains(report.Components[0].Error, "CA signing certificate expired")
+
+	resp, err = http.Get(server.URL + common.ReportPath + "?check=shallow")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + common.ReportPath + "?check=deep")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Require().Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func (s *CheckerTestSuite) expectCACert(notAfter time.Time) {
+	template, err := util.NewCATemplate("example.org")
+	s.Require().NoError(err)
+	template.NotBefore = notAfter.Add(-2 * time.Hour)
+	template.NotAfter = notAfter
+	cert, _, err := util.SelfSign(template)
+	s.Require().NoError(err)
+
+	s.ca.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: cert.Raw,
+	}, nil)
+}
+
+// check checks the components the given check depends on, returning the
+// error of the first unhealthy one as the liveness and readiness endpoints do
+func (s *CheckerTestSuite) check(check string) error {
+	components, err := s.c.Components(check)
+	s.Require().NoError(err)
+	for _, comp := range components {
+		if err := comp.Check(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
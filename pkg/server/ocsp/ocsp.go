@@ -0,0 +1,199 @@
+package ocsp
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/server/datastore"
+	xocsp "golang.org/x/crypto/ocsp"
+)
+
+const (
+	// How long an OCSP response may be cached by clients for. Revocations
+	// take up to that long to be seen by clients caching the responses.
+	responseTTL = 5 * time.Minute
+
+	// maxRequestSize bounds the size of the OCSP requests read, which are
+	// a few hundred bytes at most
+	maxRequestSize = 10 * 1024
+)
+
+// ResponderSource provides the delegated OCSP responders of the CA
+type ResponderSource interface {
+	OCSPResponders() []*ca.OCSPResponder
+}
+
+// Responder is an OCSP responder (RFC 6960) for the X509-SVIDs signed by the
+// server CA. Their revocation status is looked up in the datastore, and the
+// responses are signed by the delegated OCSP responder of the CA that signed
+// them.
+type Responder struct {
+	BindAddress string
+	Catalog     catalog.Catalog
+	Responders  ResponderSource
+	Log         logrus.FieldLogger
+}
+
+// ListenAndServe serves the OCSP responder until the context is cancelled
+func (r *Responder) ListenAndServe(ctx context.Context) error {
+	l, err := net.Listen("tcp", r.BindAddress)
+	if err != nil {
+		return fmt.Errorf("create ocsp listener: %v", err)
+	}
+
+	server := &http.Server{Handler: r}
+
+	r.Log.Infof("Serving OCSP responder on %s", l.Addr())
+	errChan := make(chan error)
+	go func() { errChan <- server.Serve(l) }()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		<-errChan
+		return nil
+	}
+}
+
+// ServeHTTP answers an OCSP request, sent either in the body of a POST
+// request or base64 encoded in the path of a GET request
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var der []byte
+	var err error
+	switch req.Method {
+	case http.MethodGet:
+		der, err = decodeGetRequest(req.URL.Path)
+	case http.MethodPost:
+		der, err = ioutil.ReadAll(io.LimitReader(req.Body, maxRequestSize))
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var resp []byte
+	if err != nil {
+		r.Log.Debugf("Invalid OCSP request: %v", err)
+		resp = xocsp.MalformedRequestErrorResponse
+	} else {
+		resp = r.Respond(req.Context(), der)
+	}
+
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	w.Write(resp)
+}
+
+// Respond returns the signed OCSP response to an ASN.1 DER encoded OCSP
+// request. Errors are reported in the response itself.
+func (r *Responder) Respond(ctx context.Context, der []byte) []byte {
+	req, err := xocsp.ParseRequest(der)
+	if err != nil {
+		r.Log.Debugf("Invalid OCSP request: %v", err)
+		return xocsp.MalformedRequestErrorResponse
+	}
+
+	// Only the certificates signed by the CA can be answered for
+	responder := r.findResponder(req)
+	if responder == nil {
+		return xocsp.UnauthorizedErrorResponse
+	}
+
+	ds := r.Catalog.DataStores()[0]
+	svid, err := ds.FetchIssuedSVID(ctx, &datastore.IssuedSVID{
+		SerialNumber: req.SerialNumber.String(),
+	})
+	if err != nil {
+		r.Log.Errorf("Could not fetch the issued SVID %v: %v", req.SerialNumber, err)
+		return xocsp.InternalErrorErrorResponse
+	}
+
+	now := time.Now()
+	template := xocsp.Response{
+		SerialNumber: req.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(responseTTL),
+		Certificate:  responder.Cert,
+		IssuerHash:   req.HashAlgorithm,
+	}
+	switch {
+	case svid.SerialNumber == "":
+		// SVIDs are forgotten once expired, if they were recorded at all
+		template.Status = xocsp.Unknown
+	case svid.RevokedAt != 0:
+		template.Status = xocsp.Revoked
+		template.RevokedAt = time.Unix(svid.RevokedAt, 0)
+		template.RevocationReason = xocsp.Unspecified
+	default:
+		template.Status = xocsp.Good
+	}
+
+	resp, err := xocsp.CreateResponse(responder.Issuer, responder.Cert, template, responder.Signer)
+	if err != nil {
+		r.Log.Errorf("Could not sign the OCSP response: %v", err)
+		return xocsp.InternalErrorErrorResponse
+	}
+	return resp
+}
+
+// findResponder returns the responder of the CA the request is about, or
+// nil if the CA is not known
+func (r *Responder) findResponder(req *xocsp.Request) *ca.OCSPResponder {
+	if !req.HashAlgorithm.Available() {
+		return nil
+	}
+
+	for _, responder := range r.Responders.OCSPResponders() {
+		nameHash, keyHash, err := issuerHashes(responder.Issuer, req)
+		if err != nil {
+			r.Log.Errorf("Could not hash the CA certificate: %v", err)
+			continue
+		}
+		if bytes.Equal(nameHash, req.IssuerNameHash) && bytes.Equal(keyHash, req.IssuerKeyHash) {
+			return responder
+		}
+	}
+	return nil
+}
+
+// issuerHashes returns the hashes identifying the issuer in an OCSP request,
+// computed with the hash algorithm of the request
+func issuerHashes(issuer *x509.Certificate, req *xocsp.Request) ([]byte, []byte, error) {
+	var publicKeyInfo struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &publicKeyInfo); err != nil {
+		return nil, nil, err
+	}
+
+	h := req.HashAlgorithm.New()
+	h.Write(issuer.RawSubject)
+	nameHash := h.Sum(nil)
+
+	h.Reset()
+	h.Write(publicKeyInfo.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+
+	return nameHash, keyHash, nil
+}
+
+// decodeGetRequest decodes an OCSP request sent, base64 encoded, in the path
+// of a GET request (RFC 6960, appendix A.1). The path is URL decoded already.
+func decodeGetRequest(path string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.TrimPrefix(path, "/"))
+}
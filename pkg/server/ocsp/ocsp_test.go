@@ -0,0 +1,177 @@
+package ocsp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/x509svid"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+	xocsp "golang.org/x/crypto/ocsp"
+)
+
+var ctx = context.Background()
+
+type fakeResponderSource struct {
+	responders []*ca.OCSPResponder
+}
+
+func (s *fakeResponderSource) OCSPResponders() []*ca.OCSPResponder {
+	return s.responders
+}
+
+type ResponderTestSuite struct {
+	suite.Suite
+
+	caCert    *x509.Certificate
+	responder *ca.OCSPResponder
+	ds        *fakedatastore.FakeDataStore
+	r         *Responder
+}
+
+func TestResponder(t *testing.T) {
+	suite.Run(t, new(ResponderTestSuite))
+}
+
+func (s *ResponderTestSuite) SetupTest() {
+	template, err := util.NewCATemplate("example.org")
+	s.Require().NoError(err)
+	caCert, caKey, err := util.SelfSign(template)
+	s.Require().NoError(err)
+	s.caCert = caCert
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	serverCA := x509svid.NewServerCA(x509util.NewMemoryKeypair(caCert, caKey), "example.org", x509svid.ServerCAOptions{})
+	cert, err := serverCA.SignOCSPResponder(ctx, key.Public(), time.Hour)
+	s.Require().NoError(err)
+	s.responder = &ca.OCSPResponder{
+		Issuer: caCert,
+		Cert:   cert,
+		Signer: key,
+	}
+
+	s.ds = fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(s.ds)
+
+	log, _ := test.NewNullLogger()
+	s.r = &Responder{
+		Catalog:    catalog,
+		Responders: &fakeResponderSource{responders: []*ca.OCSPResponder{s.responder}},
+		Log:        log,
+	}
+}
+
+func (s *ResponderTestSuite) TestRespondGood() {
+	s.createIssuedSVID("1", 0)
+
+	resp := s.parseResponse(s.r.Respond(ctx, s.createRequest(1, s.caCert)))
+	s.Require().Equal(xocsp.Good, resp.Status)
+	s.Require().Equal(big.NewInt(1), resp.SerialNumber)
+	s.Require().Equal(s.responder.Cert, resp.Certificate)
+	s.Require().WithinDuration(time.Now().Add(responseTTL), resp.NextUpdate, 5*time.Second)
+}
+
+func (s *ResponderTestSuite) TestRespondRevoked() {
+	revokedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	s.createIssuedSVID("2", revokedAt.Unix())
+
+	resp := s.parseResponse(s.r.Respond(ctx, s.createRequest(2, s.caCert)))
+	s.Require().Equal(xocsp.Revoked, resp.Status)
+	s.Require().True(revokedAt.Equal(resp.RevokedAt))
+}
+
+func (s *ResponderTestSuite) TestRespondUnknown() {
+	resp := s.parseResponse(s.r.Respond(ctx, s.createRequest(3, s.caCert)))
+	s.Require().Equal(xocsp.Unknown, resp.Status)
+}
+
+func (s *ResponderTestSuite) TestRespondOtherIssuer() {
+	template, err := util.NewCATemplate("example.org")
+	s.Require().NoError(err)
+	otherCA, _, err := util.SelfSign(template)
+	s.Require().NoError(err)
+
+	resp := s.r.Respond(ctx, s.createRequest(1, otherCA))
+	s.Require().Equal(xocsp.UnauthorizedErrorResponse, resp)
+}
+
+func (s *ResponderTestSuite) TestRespondMalformed() {
+	resp := s.r.Respond(ctx, []byte("foo"))
+	s.Require().Equal(xocsp.MalformedRequestErrorResponse, resp)
+}
+
+func (s *ResponderTestSuite) TestServeHTTP() {
+	s.createIssuedSVID("1", 0)
+	req := s.createRequest(1, s.caCert)
+
+	// POST
+	w := httptest.NewRecorder()
+	s.r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(req)))
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Require().Equal("application/ocsp-response", w.Header().Get("Content-Type"))
+	s.Require().Equal(xocsp.Good, s.parseResponse(w.Body.Bytes()).Status)
+
+	// GET, with the request base64 encoded in the path
+	w = httptest.NewRecorder()
+	httpReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	httpReq.URL.Path = "/" + base64.StdEncoding.EncodeToString(req)
+	s.r.ServeHTTP(w, httpReq)
+	s.Require().Equal(http.StatusOK, w.Code)
+	s.Require().Equal(xocsp.Good, s.parseResponse(w.Body.Bytes()).Status)
+
+	// GET with a path that is not base64 encoded
+	w = httptest.NewRecorder()
+	s.r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/foo!", nil))
+	body, err := ioutil.ReadAll(w.Body)
+	s.Require().NoError(err)
+	s.Require().Equal(xocsp.MalformedRequestErrorResponse, body)
+
+	// Other methods are not allowed
+	w = httptest.NewRecorder()
+	s.r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", nil))
+	s.Require().Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
+func (s *ResponderTestSuite) createIssuedSVID(serialNumber string, revokedAt int64) {
+	_, err := s.ds.CreateIssuedSVID(ctx, &datastore.IssuedSVID{
+		SerialNumber: serialNumber,
+		SpiffeId:     "spiffe://example.org/foo",
+		Expiry:       time.Now().Add(time.Hour).Unix(),
+		RevokedAt:    revokedAt,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *ResponderTestSuite) createRequest(serialNumber int64, issuer *x509.Certificate) []byte {
+	cert := &x509.Certificate{SerialNumber: big.NewInt(serialNumber)}
+	req, err := xocsp.CreateRequest(cert, issuer, &xocsp.RequestOptions{Hash: crypto.SHA256})
+	s.Require().NoError(err)
+	return req
+}
+
+func (s *ResponderTestSuite) parseResponse(der []byte) *xocsp.Response {
+	// The responder certificate is checked against the CA certificate
+	resp, err := xocsp.ParseResponse(der, s.caCert)
+	s.Require().NoError(err)
+	return resp
+}
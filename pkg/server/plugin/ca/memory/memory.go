@@ -3,21 +3,28 @@ package memory
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/hcl"
 
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/x509svid"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	spi "github.com/spiffe/spire/proto/common/plugin"
@@ -46,22 +53,75 @@ type configuration struct {
 	CertSubject  certSubjectConfig `hcl:"cert_subject" json:"cert_subject"`
 	DefaultTTL   int               `hcl:"default_ttl" json:"default_ttl"`
 	KeypairPath  string            `hcl:"keypair_path" json:"keypair_path"`
+
+	// Type of the CA keys generated, and whether RSA keys sign with
+	// RSASSA-PSS rather than PKCS #1 v1.5
+	KeyType string `hcl:"key_type" json:"key_type"`
+	RSAPSS  bool   `hcl:"rsa_pss" json:"rsa_pss"`
+
+	// Signing policy of the SVIDs
+	SVIDKeyUsage             []string `hcl:"svid_key_usage" json:"svid_key_usage"`
+	SVIDExtKeyUsage          []string `hcl:"svid_ext_key_usage" json:"svid_ext_key_usage"`
+	OmitSVIDSubjectKeyID     bool     `hcl:"omit_svid_subject_key_id" json:"omit_svid_subject_key_id"`
+	OmitSVIDBasicConstraints bool     `hcl:"omit_svid_basic_constraints" json:"omit_svid_basic_constraints"`
 }
 
+// defaultKeyType is the type of the CA keys generated unless configured
+// otherwise
+const defaultKeyType = "ec-p384"
+
+var (
+	// Supported CA key types, along with their size for RSA keys
+	keyTypes = map[string]int{
+		"ec-p256":  0,
+		"ec-p384":  0,
+		"rsa-2048": 2048,
+		"rsa-3072": 3072,
+		"rsa-4096": 4096,
+	}
+
+	// Key usages which may be set on SVIDs, by configuration name
+	svidKeyUsages = map[string]x509.KeyUsage{
+		"digital_signature": x509.KeyUsageDigitalSignature,
+		"key_encipherment":  x509.KeyUsageKeyEncipherment,
+		"key_agreement":     x509.KeyUsageKeyAgreement,
+	}
+
+	// Extended key usages which may be set on SVIDs, by configuration name
+	svidExtKeyUsages = map[string]x509.ExtKeyUsage{
+		"server_auth": x509.ExtKeyUsageServerAuth,
+		"client_auth": x509.ExtKeyUsageClientAuth,
+	}
+)
+
 type MemoryPlugin struct {
 	serialNumber x509util.SerialNumber
 
 	mtx sync.RWMutex
 	// everything below is protected by the mutex
 	config   *configuration
-	newKey   *ecdsa.PrivateKey
+	newKey   crypto.Signer
 	keypair  *x509util.MemoryKeypair
+	key      crypto.Signer
 	serverCA *x509svid.ServerCA
+
+	// JWT signing keys, the active one and the one prepared to replace it
+	jwtKey     *jwtKey
+	nextJWTKey *jwtKey
+}
+
+// jwtKey is a key used to sign JWT-SVIDs, valid until notAfter
+type jwtKey struct {
+	signer   crypto.Signer
+	kid      string
+	notAfter time.Time
 }
 
 func New() *MemoryPlugin {
 	return &MemoryPlugin{
-		serialNumber: x509util.NewSerialNumber(),
+		// SVIDs are revoked by serial number, so serial numbers must not
+		// be reused when the server restarts
+		serialNumber: x509util.NewRandomSerialNumber(),
 	}
 }
 
@@ -74,7 +134,7 @@ func NewWithDefault() *MemoryPlugin {
 			Organization: []string{"SPIFFE"},
 			CommonName:   "",
 		},
-	}, nil)
+	}, nil, nil, nil, nil, nil)
 	return m
 }
 
@@ -87,27 +147,82 @@ func (m *MemoryPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest)
 	if config.TrustDomain == "" {
 		return nil, errors.New("trust domain is required")
 	}
+	if _, ok := keyTypes[config.KeyType]; !ok && config.KeyType != "" {
+		return nil, fmt.Errorf("unsupported key type %q", config.KeyType)
+	}
+	if config.RSAPSS && !strings.HasPrefix(config.KeyType, "rsa-") {
+		return nil, errors.New("rsa_pss requires an RSA key type")
+	}
+	if _, err := parseKeyUsage(config.SVIDKeyUsage); err != nil {
+		return nil, err
+	}
+	if _, err := parseExtKeyUsage(config.SVIDExtKeyUsage); err != nil {
+		return nil, err
+	}
 
-	var keypair *x509util.MemoryKeypair
+	var cert *x509.Certificate
+	var key, newKey crypto.Signer
+	var jwtKey, nextJWTKey *jwtKey
 	if config.KeypairPath != "" {
-		cert, key, err := loadKeypair(config.KeypairPath)
+		var err error
+		cert, key, err = loadKeypair(config.KeypairPath)
+		switch {
+		case err == nil:
+		case os.IsNotExist(err):
+		default:
+			return nil, err
+		}
+
+		// The key of a CA prepared before a restart is activated later on
+		newKey, err = loadKey(nextKeyPath(config.KeypairPath))
+		switch {
+		case err == nil:
+		case os.IsNotExist(err):
+		default:
+			return nil, err
+		}
+
+		// So are the JWT signing keys, so that the JWT-SVIDs signed before
+		// the restart are not left behind by a new key
+		jwtKey, err = loadJWTKey(jwtKeyPath(config.KeypairPath))
 		switch {
 		case err == nil:
-			keypair = x509util.NewMemoryKeypair(cert, key)
 		case os.IsNotExist(err):
 		default:
 			return nil, err
 		}
+		nextJWTKey, err = loadJWTKey(nextKeyPath(jwtKeyPath(config.KeypairPath)))
+		switch {
+		case err == nil:
+		case os.IsNotExist(err):
+		default:
+			return nil, err
+		}
+	}
+
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
 	}
 
-	m.configure(config, keypair)
+	m.configure(config, cert, key, newKey, jwtKey, nextJWTKey)
 	return &spi.ConfigureResponse{}, nil
 }
 
-func (m *MemoryPlugin) configure(config *configuration, keypair *x509util.MemoryKeypair) {
+func (m *MemoryPlugin) configure(config *configuration, cert *x509.Certificate, key, newKey crypto.Signer, jwtKey, nextJWTKey *jwtKey) {
+	if config.KeyType == "" {
+		config.KeyType = defaultKeyType
+	}
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
-	m.keypair = keypair
+	m.keypair = nil
+	if cert != nil {
+		m.keypair = x509util.NewMemoryKeypair(cert, key)
+	}
+	m.key = key
+	m.newKey = newKey
+	m.jwtKey = jwtKey
+	m.nextJWTKey = nextJWTKey
 	m.config = config
 	m.initializeCA()
 }
@@ -118,14 +233,94 @@ func (m *MemoryPlugin) initializeCA() {
 		return
 	}
 
+	// The signing policy is validated on configuration
+	keyUsage, _ := parseKeyUsage(m.config.SVIDKeyUsage)
+	extKeyUsage, _ := parseExtKeyUsage(m.config.SVIDExtKeyUsage)
+
 	m.serverCA = x509svid.NewServerCA(m.keypair, m.config.TrustDomain,
 		x509svid.ServerCAOptions{
-			TTL:          time.Duration(m.config.DefaultTTL) * time.Second,
-			Backdate:     time.Duration(m.config.BackdateSecs) * time.Second,
-			SerialNumber: m.serialNumber,
+			TTL:                  time.Duration(m.config.DefaultTTL) * time.Second,
+			Backdate:             time.Duration(m.config.BackdateSecs) * time.Second,
+			SerialNumber:         m.serialNumber,
+			KeyUsage:             keyUsage,
+			ExtKeyUsage:          extKeyUsage,
+			OmitSubjectKeyID:     m.config.OmitSVIDSubjectKeyID,
+			OmitBasicConstraints: m.config.OmitSVIDBasicConstraints,
+			SignatureAlgorithm:   signatureAlgorithm(m.key, m.config.RSAPSS),
 		})
 }
 
+// generateKey generates a CA key of the given type
+func generateKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case "ec-p256":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ec-p384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "rsa-2048", "rsa-3072", "rsa-4096":
+		return rsa.GenerateKey(rand.Reader, keyTypes[keyType])
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+// signatureAlgorithm returns the algorithm certificates are signed with by
+// the given key. RSA keys of 3072 bits and more sign with SHA-384. ECDSA
+// keys are left to the x509 package, which picks the hash matching the
+// curve.
+func signatureAlgorithm(key crypto.Signer, pss bool) x509.SignatureAlgorithm {
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return x509.UnknownSignatureAlgorithm
+	}
+	sha384 := rsaKey.N.BitLen() >= 3072
+	switch {
+	case pss && sha384:
+		return x509.SHA384WithRSAPSS
+	case pss:
+		return x509.SHA256WithRSAPSS
+	case sha384:
+		return x509.SHA384WithRSA
+	default:
+		return x509.SHA256WithRSA
+	}
+}
+
+// parseKeyUsage returns the key usage named, or zero for the default one.
+// X509-SVIDs must have the digital signature key usage.
+func parseKeyUsage(names []string) (x509.KeyUsage, error) {
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	var keyUsage x509.KeyUsage
+	for _, name := range names {
+		usage, ok := svidKeyUsages[name]
+		if !ok {
+			return 0, fmt.Errorf("unsupported SVID key usage %q", name)
+		}
+		keyUsage |= usage
+	}
+	if keyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return 0, errors.New("SVID key usage must include digital_signature")
+	}
+	return keyUsage, nil
+}
+
+// parseExtKeyUsage returns the extended key usages named, or nil for the
+// default ones
+func parseExtKeyUsage(names []string) ([]x509.ExtKeyUsage, error) {
+	var extKeyUsage []x509.ExtKeyUsage
+	for _, name := range names {
+		usage, ok := svidExtKeyUsages[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported SVID extended key usage %q", name)
+		}
+		extKeyUsage = append(extKeyUsage, usage)
+	}
+	return extKeyUsage, nil
+}
+
 func (*MemoryPlugin) GetPluginInfo(ctx context.Context, req *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
 	return &spi.GetPluginInfoResponse{}, nil
 }
@@ -146,14 +341,217 @@ func (m *MemoryPlugin) SignCsr(ctx context.Context, request *ca.SignCsrRequest)
 	return &ca.SignCsrResponse{SignedCertificate: cert.Raw}, nil
 }
 
+func (m *MemoryPlugin) SignJwtSvid(ctx context.Context, request *ca.SignJwtSvidRequest) (*ca.SignJwtSvidResponse, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	signingKey := m.jwtKey
+	if signingKey == nil {
+		if m.keypair == nil {
+			return nil, errors.New("invalid state: no certificate loaded")
+		}
+
+		// Without a JWT signing key, the JWT-SVID is signed with the key of
+		// the certificate, and cannot outlive it
+		cert, err := m.keypair.GetCertificate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keyID, err := jwtsvid.KeyID(cert.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		signingKey = &jwtKey{
+			signer:   m.key,
+			kid:      keyID,
+			notAfter: cert.NotAfter,
+		}
+	}
+
+	if err := idutil.ValidateSpiffeID(request.SpiffeId, idutil.AllowAnyInTrustDomain(m.config.TrustDomain)); err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(request.Ttl) * time.Second
+	if ttl <= 0 {
+		ttl = jwtsvid.DefaultTTL
+	}
+
+	// The JWT-SVID cannot outlive the key it is validated with
+	expiresAt := time.Now().Add(ttl)
+	if expiresAt.After(signingKey.notAfter) {
+		expiresAt = signingKey.notAfter
+	}
+
+	token, err := jwtsvid.SignTokenWithIssuer(request.Issuer, request.SpiffeId, request.Audience, expiresAt, signingKey.signer, signingKey.kid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ca.SignJwtSvidResponse{SignedJwtSvid: token}, nil
+}
+
+func (m *MemoryPlugin) GenerateJwtKey(ctx context.Context, request *ca.GenerateJwtKeyRequest) (*ca.GenerateJwtKeyResponse, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if request.NotAfter <= 0 {
+		return nil, errors.New("expiration time of the key is required")
+	}
+
+	signer, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.New("generate private key: " + err.Error())
+	}
+	kid, err := jwtsvid.KeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	nextJWTKey := &jwtKey{
+		signer:   signer,
+		kid:      kid,
+		notAfter: time.Unix(request.NotAfter, 0),
+	}
+	if m.config.KeypairPath != "" {
+		if err := writeJWTKey(nextKeyPath(jwtKeyPath(m.config.KeypairPath)), nextJWTKey); err != nil {
+			return nil, err
+		}
+	}
+	m.nextJWTKey = nextJWTKey
+
+	pbKey, err := nextJWTKey.toProto()
+	if err != nil {
+		return nil, err
+	}
+	return &ca.GenerateJwtKeyResponse{JwtKey: pbKey}, nil
+}
+
+func (m *MemoryPlugin) ActivateJwtKey(ctx context.Context, request *ca.ActivateJwtKeyRequest) (*ca.ActivateJwtKeyResponse, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.nextJWTKey == nil {
+		return nil, errors.New("invalid state: no jwt signing key prepared")
+	}
+	if m.nextJWTKey.kid != request.Kid {
+		return nil, fmt.Errorf("prepared jwt signing key has key ID %q, not %q", m.nextJWTKey.kid, request.Kid)
+	}
+
+	if m.config.KeypairPath != "" {
+		if err := writeJWTKey(jwtKeyPath(m.config.KeypairPath), m.nextJWTKey); err != nil {
+			return nil, err
+		}
+		if err := os.Remove(nextKeyPath(jwtKeyPath(m.config.KeypairPath))); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("unable to remove next jwt signing key: %v", err)
+		}
+	}
+
+	m.jwtKey = m.nextJWTKey
+	m.nextJWTKey = nil
+	return &ca.ActivateJwtKeyResponse{}, nil
+}
+
+func (m *MemoryPlugin) FetchJwtKeys(ctx context.Context, request *ca.FetchJwtKeysRequest) (*ca.FetchJwtKeysResponse, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	resp := &ca.FetchJwtKeysResponse{}
+	if m.jwtKey != nil {
+		active, err := m.jwtKey.toProto()
+		if err != nil {
+			return nil, err
+		}
+		resp.Active = active
+	}
+	if m.nextJWTKey != nil {
+		prepared, err := m.nextJWTKey.toProto()
+		if err != nil {
+			return nil, err
+		}
+		resp.Prepared = prepared
+	}
+	return resp, nil
+}
+
+func (k *jwtKey) toProto() (*ca.JwtKey, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(k.signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal public key: %v", err)
+	}
+	return &ca.JwtKey{
+		Kid:       k.kid,
+		PublicKey: publicKey,
+		NotAfter:  k.notAfter.Unix(),
+	}, nil
+}
+
+func (m *MemoryPlugin) SignCrl(ctx context.Context, request *ca.SignCrlRequest) (*ca.SignCrlResponse, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if m.keypair == nil {
+		return nil, errors.New("invalid state: no certificate loaded")
+	}
+
+	cert, err := m.keypair.GetCertificate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked []pkix.RevokedCertificate
+	for _, revokedCert := range request.RevokedCertificates {
+		serialNumber, ok := new(big.Int).SetString(revokedCert.SerialNumber, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid serial number %q", revokedCert.SerialNumber)
+		}
+		revoked = append(revoked, pkix.RevokedCertificate{
+			SerialNumber:   serialNumber,
+			RevocationTime: time.Unix(revokedCert.RevokedAt, 0).UTC(),
+		})
+	}
+
+	crl, err := cert.CreateCRL(rand.Reader, m.key, revoked, time.Now(), time.Unix(request.NextUpdate, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ca.SignCrlResponse{SignedCrl: crl}, nil
+}
+
+func (m *MemoryPlugin) SignOcspResponderCert(ctx context.Context, request *ca.SignOcspResponderCertRequest) (*ca.SignOcspResponderCertResponse, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if m.serverCA == nil {
+		return nil, errors.New("invalid state: no certificate loaded")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(request.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+
+	cert, err := m.serverCA.SignOCSPResponder(ctx, publicKey, time.Duration(request.Ttl)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ca.SignOcspResponderCertResponse{SignedCertificate: cert.Raw}, nil
+}
+
 func (m *MemoryPlugin) GenerateCsr(ctx context.Context, req *ca.GenerateCsrRequest) (*ca.GenerateCsrResponse, error) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
-	newKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	newKey, err := generateKey(m.config.KeyType)
 	if err != nil {
 		return nil, errors.New("generate private key: " + err.Error())
 	}
+	if m.config.KeypairPath != "" {
+		if err := writeKey(nextKeyPath(m.config.KeypairPath), newKey); err != nil {
+			return nil, err
+		}
+	}
 	m.newKey = newKey
 
 	csr, err := x509svid.GenerateServerCACSR(newKey, m.config.TrustDomain,
@@ -163,6 +561,7 @@ func (m *MemoryPlugin) GenerateCsr(ctx context.Context, req *ca.GenerateCsrReque
 				Organization: m.config.CertSubject.Organization,
 				CommonName:   m.config.CertSubject.CommonName,
 			},
+			SignatureAlgorithm: signatureAlgorithm(newKey, m.config.RSAPSS),
 		})
 
 	return &ca.GenerateCsrResponse{Csr: csr}, nil
@@ -198,20 +597,29 @@ func (m *MemoryPlugin) LoadCertificate(ctx context.Context, request *ca.LoadCert
 		return nil, err
 	}
 
+	if !keyMatches(cert, m.newKey) {
+		return nil, errors.New("certificate does not match the private key of the last generated CSR")
+	}
+
 	keypair := x509util.NewMemoryKeypair(cert, m.newKey)
 	if m.config.KeypairPath != "" {
 		if err := writeKeypair(m.config.KeypairPath, cert, m.newKey); err != nil {
 			return nil, err
 		}
+		if err := os.Remove(nextKeyPath(m.config.KeypairPath)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("unable to remove next key: %v", err)
+		}
 	}
 
 	m.keypair = keypair
+	m.key = m.newKey
+	m.newKey = nil
 	m.initializeCA()
 
 	return &ca.LoadCertificateResponse{}, nil
 }
 
-func loadKeypair(path string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+func loadKeypair(path string) (*x509.Certificate, crypto.Signer, error) {
 	pemBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, nil, err
@@ -242,25 +650,145 @@ func loadKeypair(path string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	key, ok := rawKey.(*ecdsa.PrivateKey)
-	if !ok {
-		return nil, nil, fmt.Errorf("expecting ECDSA private key; got %T", rawKey)
-	}
-
-	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, nil, fmt.Errorf("expected certificate to ECDSA public key; got %T", cert.PublicKey)
+	key, err := signerFromKey(rawKey)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// make sure keys match
-	if !(key.X.Cmp(publicKey.X) == 0 && key.Y.Cmp(publicKey.Y) == 0) {
+	if !keyMatches(cert, key) {
 		return nil, nil, errors.New("certificate and key do not match")
 	}
 
 	return cert, key, nil
 }
 
-func writeKeypair(path string, cert *x509.Certificate, key *ecdsa.PrivateKey) error {
+func keyMatches(cert *x509.Certificate, key crypto.Signer) bool {
+	certKey, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return false
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	return err == nil && bytes.Equal(certKey, publicKey)
+}
+
+// signerFromKey returns the parsed private key as a signer, if it is of a
+// supported type
+func signerFromKey(rawKey interface{}) (crypto.Signer, error) {
+	switch key := rawKey.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case *rsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("expecting ECDSA or RSA private key; got %T", rawKey)
+	}
+}
+
+// nextKeyPath returns where the private key of the last generated CSR is
+// persisted until its certificate is loaded.
+func nextKeyPath(keypairPath string) string {
+	return keypairPath + ".next"
+}
+
+// jwtKeyPath returns where the active JWT signing key is persisted. The
+// prepared one is persisted at its nextKeyPath.
+func jwtKeyPath(keypairPath string) string {
+	return keypairPath + ".jwt"
+}
+
+// loadJWTKey loads a JWT signing key, persisted as a PRIVATE KEY block
+// with its expiration time, in seconds since the Unix epoch, as header.
+func loadJWTKey(path string) (*jwtKey, error) {
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(pemBytes)
+	if keyBlock == nil || keyBlock.Type != "PRIVATE KEY" {
+		return nil, errors.New("missing PRIVATE KEY block")
+	}
+	notAfter, err := strconv.ParseInt(keyBlock.Headers["Not-After"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt signing key expiration time: %v", err)
+	}
+	rawKey, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := signerFromKey(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := jwtsvid.KeyID(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &jwtKey{
+		signer:   signer,
+		kid:      kid,
+		notAfter: time.Unix(notAfter, 0),
+	}, nil
+}
+
+func writeJWTKey(path string, key *jwtKey) error {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key.signer)
+	if err != nil {
+		return fmt.Errorf("unable to marshal private key: %v", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type: "PRIVATE KEY",
+		Headers: map[string]string{
+			"Not-After": strconv.FormatInt(key.notAfter.Unix(), 10),
+		},
+		Bytes: keyBytes,
+	})
+	if err := ioutil.WriteFile(path+".tmp", keyPEM, 0600); err != nil {
+		return fmt.Errorf("unable to write temporary jwt signing key: %v", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("unable to overwrite jwt signing key: %v", err)
+	}
+	return nil
+}
+
+func loadKey(path string) (crypto.Signer, error) {
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(pemBytes)
+	if keyBlock == nil || keyBlock.Type != "PRIVATE KEY" {
+		return nil, errors.New("missing PRIVATE KEY block")
+	}
+	rawKey, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return signerFromKey(rawKey)
+}
+
+func writeKey(path string, key crypto.Signer) error {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("unable to marshal private key: %v", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: keyBytes,
+	})
+	if err := ioutil.WriteFile(path, keyPEM, 0600); err != nil {
+		return fmt.Errorf("unable to write next key: %v", err)
+	}
+	return nil
+}
+
+func writeKeypair(path string, cert *x509.Certificate, key crypto.Signer) error {
 	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
 	if err != nil {
 		return fmt.Errorf("unable to marshal private key: %v", err)
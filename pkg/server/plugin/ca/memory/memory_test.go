@@ -2,6 +2,9 @@ package memory
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -16,6 +19,7 @@ import (
 	"time"
 
 	"github.com/spiffe/go-spiffe/uri"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	upca "github.com/spiffe/spire/pkg/server/plugin/upstreamca/disk"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/ca"
@@ -54,6 +58,27 @@ func TestMemory_ConfigureDecodeError(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+func TestMemory_ConfigureSigningPolicy(t *testing.T) {
+	configure := func(config string) error {
+		_, err := New().Configure(ctx, &spi.ConfigureRequest{Configuration: config})
+		return err
+	}
+
+	require.NoError(t, configure(`
+		trust_domain = "example.com"
+		svid_key_usage = ["digital_signature"]
+		svid_ext_key_usage = ["client_auth"]`))
+	require.EqualError(t, configure(`
+		trust_domain = "example.com"
+		svid_key_usage = ["cert_sign"]`), `unsupported SVID key usage "cert_sign"`)
+	require.EqualError(t, configure(`
+		trust_domain = "example.com"
+		svid_key_usage = ["key_agreement"]`), "SVID key usage must include digital_signature")
+	require.EqualError(t, configure(`
+		trust_domain = "example.com"
+		svid_ext_key_usage = ["code_signing"]`), `unsupported SVID extended key usage "code_signing"`)
+}
+
 func TestMemory_ConfigureWithKeypairPath(t *testing.T) {
 	m := New()
 
@@ -115,19 +140,27 @@ func TestMemory_LoadValidCertificate(t *testing.T) {
 	validCertFiles, err := ioutil.ReadDir(testDataDir)
 	assert.NoError(t, err)
 
-	m.GenerateCsr(ctx, &ca.GenerateCsrRequest{})
-
 	for _, file := range validCertFiles {
 		certPEM, err := ioutil.ReadFile(filepath.Join(testDataDir, file.Name()))
 		if assert.NoError(t, err, file.Name()) {
 			block, rest := pem.Decode(certPEM)
 			assert.Len(t, rest, 0, file.Name())
-			_, err := m.LoadCertificate(ctx, &ca.LoadCertificateRequest{SignedIntermediateCert: block.Bytes})
+
+			// reissue the certificate for the key of the CSR
+			m.GenerateCsr(ctx, &ca.GenerateCsrRequest{})
+			template, err := x509.ParseCertificate(block.Bytes)
+			require.NoError(t, err, file.Name())
+			template.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+			template.PublicKey = m.newKey.Public()
+			certDER, err := x509.CreateCertificate(rand.Reader, template, template, m.newKey.Public(), m.newKey)
+			require.NoError(t, err, file.Name())
+
+			_, err = m.LoadCertificate(ctx, &ca.LoadCertificateRequest{SignedIntermediateCert: certDER})
 			assert.NoError(t, err, file.Name())
 
 			resp, err := m.FetchCertificate(ctx, &ca.FetchCertificateRequest{})
 			require.NoError(t, err, file.Name())
-			require.Equal(t, resp.StoredIntermediateCert, block.Bytes, file.Name())
+			require.Equal(t, resp.StoredIntermediateCert, certDER, file.Name())
 		}
 	}
 }
@@ -155,6 +188,92 @@ func TestMemory_LoadCertificateWithKeypairPath(t *testing.T) {
 	require.NotNil(t, key)
 }
 
+func TestMemory_ConfigureKeyType(t *testing.T) {
+	configure := func(config string) error {
+		_, err := New().Configure(ctx, &spi.ConfigureRequest{Configuration: config})
+		return err
+	}
+
+	require.NoError(t, configure(`{ "trust_domain":"example.com", "key_type":"rsa-3072", "rsa_pss":true }`))
+	require.EqualError(t, configure(`{ "trust_domain":"example.com", "key_type":"dsa-1024" }`),
+		`unsupported key type "dsa-1024"`)
+	require.EqualError(t, configure(`{ "trust_domain":"example.com", "rsa_pss":true }`),
+		"rsa_pss requires an RSA key type")
+}
+
+func TestMemory_KeyType(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "ca-memory-key-type-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	keypairPath := filepath.Join(tmpDir, "keypair.pem")
+
+	m := New()
+	_, err = m.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`{ "trust_domain":"example.com", "keypair_path":%q, "key_type":"rsa-3072", "rsa_pss":true }`, keypairPath),
+	})
+	require.NoError(t, err)
+
+	upstreamCA, err := fakeupstreamca.New("example.com")
+	require.NoError(t, err)
+	rotateServerCA(t, upstreamCA, m)
+
+	caResp, err := m.FetchCertificate(ctx, &ca.FetchCertificateRequest{})
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caResp.StoredIntermediateCert)
+	require.NoError(t, err)
+	require.Equal(t, x509.RSA, caCert.PublicKeyAlgorithm)
+
+	resp, err := m.SignCsr(ctx, &ca.SignCsrRequest{Csr: createWorkloadCSR(t, "spiffe://example.com/foo")})
+	require.NoError(t, err)
+	svid, err := x509.ParseCertificate(resp.SignedCertificate)
+	require.NoError(t, err)
+	require.Equal(t, x509.SHA384WithRSAPSS, svid.SignatureAlgorithm)
+	require.NoError(t, svid.CheckSignatureFrom(caCert))
+
+	// the RSA key survives a restart
+	_, key, err := loadKeypair(keypairPath)
+	require.NoError(t, err)
+	require.IsType(t, &rsa.PrivateKey{}, key)
+}
+
+func TestMemory_LoadCertificateAfterRestart(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "ca-memory-load-certificate-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	keypairPath := filepath.Join(tmpDir, "keypair.pem")
+	configure := func() *MemoryPlugin {
+		m := New()
+		_, err := m.Configure(ctx, &spi.ConfigureRequest{
+			Configuration: fmt.Sprintf(`{ "trust_domain":"example.com", "keypair_path":%q }`, keypairPath),
+		})
+		require.NoError(t, err)
+		return m
+	}
+
+	upstreamCA, err := fakeupstreamca.New("example.com")
+	require.NoError(t, err)
+
+	// prepare the CA, then restart before loading its certificate
+	genResp, err := configure().GenerateCsr(ctx, &ca.GenerateCsrRequest{})
+	require.NoError(t, err)
+	subResp, err := upstreamCA.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{Csr: genResp.Csr})
+	require.NoError(t, err)
+
+	m := configure()
+	_, err = m.LoadCertificate(ctx, &ca.LoadCertificateRequest{SignedIntermediateCert: subResp.Cert})
+	require.NoError(t, err)
+	_, err = os.Stat(keypairPath + ".next")
+	require.True(t, os.IsNotExist(err))
+
+	// a certificate for another key is rejected
+	genResp, err = m.GenerateCsr(ctx, &ca.GenerateCsrRequest{})
+	require.NoError(t, err)
+	_, err = m.LoadCertificate(ctx, &ca.LoadCertificateRequest{SignedIntermediateCert: subResp.Cert})
+	require.EqualError(t, err, "certificate does not match the private key of the last generated CSR")
+}
+
 func TestMemory_LoadInvalidCertificate(t *testing.T) {
 	m := NewWithDefault()
 
@@ -246,6 +365,30 @@ func TestMemory_SignCsr(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMemory_SignCsrWithSigningPolicy(t *testing.T) {
+	m := New()
+	template, err := testutil.NewCATemplate("localhost")
+	require.NoError(t, err)
+	cert, key, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+	m.configure(&configuration{
+		TrustDomain:              "localhost",
+		SVIDKeyUsage:             []string{"digital_signature", "key_agreement"},
+		SVIDExtKeyUsage:          []string{"server_auth"},
+		OmitSVIDSubjectKeyID:     true,
+		OmitSVIDBasicConstraints: true,
+	}, cert, key, nil, nil, nil)
+
+	resp, err := m.SignCsr(ctx, &ca.SignCsrRequest{Csr: createWorkloadCSR(t, "spiffe://localhost")})
+	require.NoError(t, err)
+	svid, err := x509.ParseCertificate(resp.SignedCertificate)
+	require.NoError(t, err)
+	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyAgreement, svid.KeyUsage)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, svid.ExtKeyUsage)
+	assert.Empty(t, svid.SubjectKeyId)
+	assert.False(t, svid.BasicConstraintsValid)
+}
+
 func TestMemory_SignCsrWithProblematicTTL(t *testing.T) {
 	m := populateCert(t)
 	caResp, err := m.FetchCertificate(ctx, &ca.FetchCertificateRequest{})
@@ -325,6 +468,220 @@ func TestMemory_SignCsrErrorParsingSpiffeId(t *testing.T) {
 ///
 //func TestMemory_GenerateCsrCreateCertificateRequestError(t *testing.T) {}
 
+func TestMemory_SignJwtSvid(t *testing.T) {
+	m := New()
+	template, err := testutil.NewCATemplate("localhost")
+	require.NoError(t, err)
+	cert, key, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+	m.configure(&configuration{TrustDomain: "localhost"}, cert, key, nil, nil, nil)
+
+	resp, err := m.SignJwtSvid(ctx, &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://localhost/foo",
+		Audience: []string{"audience"},
+		Ttl:      60,
+	})
+	require.NoError(t, err)
+
+	keys, err := jwtsvid.KeysFromCertificates([]*x509.Certificate{cert})
+	require.NoError(t, err)
+	spiffeID, claims, err := jwtsvid.ValidateToken(resp.SignedJwtSvid, keyStore(keys), []string{"audience"})
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://localhost/foo", spiffeID)
+	assert.InDelta(t, time.Now().Add(time.Minute).Unix(), claims["exp"], 5)
+
+	// the JWT-SVID does not outlive the certificate
+	resp, err = m.SignJwtSvid(ctx, &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://localhost/foo",
+		Audience: []string{"audience"},
+		Ttl:      int32(2 * time.Hour / time.Second),
+	})
+	require.NoError(t, err)
+	_, claims, err = jwtsvid.ValidateToken(resp.SignedJwtSvid, keyStore(keys), []string{"audience"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(cert.NotAfter.Unix()), claims["exp"])
+
+	_, err = m.SignJwtSvid(ctx, &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://otherdomain.org/foo",
+		Audience: []string{"audience"},
+	})
+	assert.Error(t, err)
+}
+
+func TestMemory_JwtKeys(t *testing.T) {
+	m := NewWithDefault()
+	notAfter := time.Now().Add(time.Hour).Unix()
+
+	_, err := m.GenerateJwtKey(ctx, &ca.GenerateJwtKeyRequest{})
+	require.EqualError(t, err, "expiration time of the key is required")
+	_, err = m.ActivateJwtKey(ctx, &ca.ActivateJwtKeyRequest{Kid: "foo"})
+	require.EqualError(t, err, "invalid state: no jwt signing key prepared")
+
+	genResp, err := m.GenerateJwtKey(ctx, &ca.GenerateJwtKeyRequest{NotAfter: notAfter})
+	require.NoError(t, err)
+	jwtKey := genResp.JwtKey
+	require.NotEmpty(t, jwtKey.Kid)
+	require.Equal(t, notAfter, jwtKey.NotAfter)
+
+	fetchResp, err := m.FetchJwtKeys(ctx, &ca.FetchJwtKeysRequest{})
+	require.NoError(t, err)
+	require.Nil(t, fetchResp.Active)
+	require.Equal(t, jwtKey, fetchResp.Prepared)
+
+	// the prepared key is only activated under its key ID
+	_, err = m.ActivateJwtKey(ctx, &ca.ActivateJwtKeyRequest{Kid: "foo"})
+	require.EqualError(t, err, fmt.Sprintf("prepared jwt signing key has key ID %q, not \"foo\"", jwtKey.Kid))
+	_, err = m.ActivateJwtKey(ctx, &ca.ActivateJwtKeyRequest{Kid: jwtKey.Kid})
+	require.NoError(t, err)
+
+	fetchResp, err = m.FetchJwtKeys(ctx, &ca.FetchJwtKeysRequest{})
+	require.NoError(t, err)
+	require.Equal(t, jwtKey, fetchResp.Active)
+	require.Nil(t, fetchResp.Prepared)
+
+	// JWT-SVIDs are signed with the active key, and do not outlive it
+	resp, err := m.SignJwtSvid(ctx, &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://localhost/foo",
+		Audience: []string{"audience"},
+		Ttl:      int32(2 * time.Hour / time.Second),
+		Issuer:   "https://oidc.localhost",
+	})
+	require.NoError(t, err)
+	publicKey, err := x509.ParsePKIXPublicKey(jwtKey.PublicKey)
+	require.NoError(t, err)
+	_, claims, err := jwtsvid.ValidateToken(resp.SignedJwtSvid, keyStore{jwtKey.Kid: publicKey}, []string{"audience"})
+	require.NoError(t, err)
+	assert.Equal(t, float64(notAfter), claims["exp"])
+	assert.Equal(t, "https://oidc.localhost", claims["iss"])
+}
+
+func TestMemory_JwtKeysAfterRestart(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "ca-memory-jwt-keys-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	keypairPath := filepath.Join(tmpDir, "keypair.pem")
+	configure := func() *MemoryPlugin {
+		m := New()
+		_, err := m.Configure(ctx, &spi.ConfigureRequest{
+			Configuration: fmt.Sprintf(`{ "trust_domain":"example.com", "keypair_path":%q }`, keypairPath),
+		})
+		require.NoError(t, err)
+		return m
+	}
+	fetchJwtKeys := func(m *MemoryPlugin) *ca.FetchJwtKeysResponse {
+		resp, err := m.FetchJwtKeys(ctx, &ca.FetchJwtKeysRequest{})
+		require.NoError(t, err)
+		return resp
+	}
+
+	notAfter := time.Now().Add(time.Hour).Unix()
+	genResp, err := configure().GenerateJwtKey(ctx, &ca.GenerateJwtKeyRequest{NotAfter: notAfter})
+	require.NoError(t, err)
+	active := genResp.JwtKey
+
+	// the prepared key survives a restart, and so does the key once active
+	m := configure()
+	require.Equal(t, &ca.FetchJwtKeysResponse{Prepared: active}, fetchJwtKeys(m))
+	_, err = m.ActivateJwtKey(ctx, &ca.ActivateJwtKeyRequest{Kid: active.Kid})
+	require.NoError(t, err)
+	_, err = os.Stat(keypairPath + ".jwt.next")
+	require.True(t, os.IsNotExist(err))
+
+	genResp, err = m.GenerateJwtKey(ctx, &ca.GenerateJwtKeyRequest{NotAfter: notAfter + 60})
+	require.NoError(t, err)
+	prepared := genResp.JwtKey
+	require.Equal(t, &ca.FetchJwtKeysResponse{Active: active, Prepared: prepared}, fetchJwtKeys(configure()))
+}
+
+func TestMemory_SignJwtSvidNoCert(t *testing.T) {
+	m := NewWithDefault()
+
+	_, err := m.SignJwtSvid(ctx, &ca.SignJwtSvidRequest{
+		SpiffeId: "spiffe://localhost/foo",
+		Audience: []string{"audience"},
+	})
+	assert.EqualError(t, err, "invalid state: no certificate loaded")
+}
+
+func TestMemory_SignCrl(t *testing.T) {
+	m := New()
+	template, err := testutil.NewCATemplate("localhost")
+	require.NoError(t, err)
+	cert, key, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+	m.configure(&configuration{TrustDomain: "localhost"}, cert, key, nil, nil, nil)
+
+	revokedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	nextUpdate := time.Now().Add(time.Hour).Truncate(time.Second)
+	resp, err := m.SignCrl(ctx, &ca.SignCrlRequest{
+		RevokedCertificates: []*ca.RevokedCertificate{
+			{SerialNumber: "12345", RevokedAt: revokedAt.Unix()},
+		},
+		NextUpdate: nextUpdate.Unix(),
+	})
+	require.NoError(t, err)
+
+	crl, err := x509.ParseDERCRL(resp.SignedCrl)
+	require.NoError(t, err)
+	require.NoError(t, cert.CheckCRLSignature(crl))
+	require.Len(t, crl.TBSCertList.RevokedCertificates, 1)
+	revoked := crl.TBSCertList.RevokedCertificates[0]
+	assert.Equal(t, "12345", revoked.SerialNumber.String())
+	assert.True(t, revokedAt.Equal(revoked.RevocationTime))
+	assert.True(t, nextUpdate.Equal(crl.TBSCertList.NextUpdate))
+
+	_, err = m.SignCrl(ctx, &ca.SignCrlRequest{
+		RevokedCertificates: []*ca.RevokedCertificate{
+			{SerialNumber: "foo"},
+		},
+	})
+	assert.EqualError(t, err, `invalid serial number "foo"`)
+}
+
+func TestMemory_SignCrlNoCert(t *testing.T) {
+	m := NewWithDefault()
+
+	_, err := m.SignCrl(ctx, &ca.SignCrlRequest{})
+	assert.EqualError(t, err, "invalid state: no certificate loaded")
+}
+
+func TestMemory_SignOcspResponderCert(t *testing.T) {
+	m := New()
+	template, err := testutil.NewCATemplate("localhost")
+	require.NoError(t, err)
+	cert, key, err := testutil.SelfSign(template)
+	require.NoError(t, err)
+	m.configure(&configuration{TrustDomain: "localhost"}, cert, key, nil, nil, nil)
+
+	responderKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(responderKey.Public())
+	require.NoError(t, err)
+
+	resp, err := m.SignOcspResponderCert(ctx, &ca.SignOcspResponderCertRequest{
+		PublicKey: publicKey,
+		Ttl:       60,
+	})
+	require.NoError(t, err)
+
+	responderCert, err := x509.ParseCertificate(resp.SignedCertificate)
+	require.NoError(t, err)
+	require.NoError(t, responderCert.CheckSignatureFrom(cert))
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning}, responderCert.ExtKeyUsage)
+	assert.Equal(t, &responderKey.PublicKey, responderCert.PublicKey)
+
+	_, err = m.SignOcspResponderCert(ctx, &ca.SignOcspResponderCertRequest{PublicKey: []byte("foo")})
+	assert.Error(t, err)
+}
+
+func TestMemory_SignOcspResponderCertNoCert(t *testing.T) {
+	m := NewWithDefault()
+
+	_, err := m.SignOcspResponderCert(ctx, &ca.SignOcspResponderCertRequest{})
+	assert.EqualError(t, err, "invalid state: no certificate loaded")
+}
+
 func TestMemory_LoadCertificateInvalidCertFormat(t *testing.T) {
 	m := NewWithDefault()
 
@@ -397,6 +754,16 @@ func createWorkloadCSR(t *testing.T, spiffeID string) []byte {
 	return csr
 }
 
+type keyStore map[string]crypto.PublicKey
+
+func (k keyStore) FindPublicKey(trustDomainID, keyID string) (crypto.PublicKey, error) {
+	key, ok := k[keyID]
+	if !ok {
+		return nil, fmt.Errorf("no key %q", keyID)
+	}
+	return key, nil
+}
+
 func populateCert(t *testing.T) (m ca.ServerCA) {
 	m = NewWithDefault()
 
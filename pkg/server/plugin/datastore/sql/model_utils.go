@@ -2,6 +2,7 @@ package sql
 
 import (
 	"bytes"
+	"crypto/x509"
 )
 
 func (b *Bundle) Append(cert CACert) {
@@ -17,3 +18,34 @@ func (b *Bundle) Contains(cert CACert) bool {
 
 	return false
 }
+
+// TaintCACert adds the certificate to the tainted CA certificates, and returns
+// true unless it was tainted already
+func (b *Bundle) TaintCACert(cert *x509.Certificate) (bool, error) {
+	tainted, err := x509.ParseCertificates(b.TaintedCACerts)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range tainted {
+		if c.Equal(cert) {
+			return false, nil
+		}
+	}
+
+	b.TaintedCACerts = append(b.TaintedCACerts, cert.Raw...)
+	return true, nil
+}
+
+func (b *Bundle) AppendJWTSigningKey(key JWTSigningKey) {
+	b.JWTSigningKeys = append(b.JWTSigningKeys, key)
+}
+
+func (b *Bundle) ContainsJWTSigningKey(key JWTSigningKey) bool {
+	for _, k := range b.JWTSigningKeys {
+		if k.KeyID == key.KeyID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -15,11 +15,27 @@ type CACert struct {
 	BundleID uint `gorm:"not null;index" sql:"type:integer REFERENCES bundles(id)"`
 }
 
+type JWTSigningKey struct {
+	gorm.Model
+
+	KeyID     string    `gorm:"not null"`
+	PublicKey []byte    `gorm:"not null"`
+	Expiry    time.Time `gorm:"not null;index"`
+
+	BundleID uint `gorm:"not null;index" sql:"type:integer REFERENCES bundles(id)"`
+}
+
 type Bundle struct {
 	gorm.Model
 
-	TrustDomain string `gorm:"not null;unique_index"`
-	CACerts     []CACert
+	TrustDomain    string `gorm:"not null;unique_index"`
+	CACerts        []CACert
+	JWTSigningKeys []JWTSigningKey
+	SequenceNumber uint64 `gorm:"not null;default:0"`
+	RefreshHint    int64  `gorm:"not null;default:0"`
+
+	// ASN.1 DER encoded, concatenated
+	TaintedCACerts []byte
 }
 
 type AttestedNodeEntry struct {
@@ -29,6 +45,7 @@ type AttestedNodeEntry struct {
 	DataType     string
 	SerialNumber string
 	ExpiresAt    time.Time
+	Banned       bool
 }
 
 type NodeResolverMapEntry struct {
@@ -42,12 +59,16 @@ type NodeResolverMapEntry struct {
 type RegisteredEntry struct {
 	gorm.Model
 
-	EntryID   string `gorm:"unique_index"`
-	SpiffeID  string
-	ParentID  string
-	TTL       int32
-	Selectors []Selector
-	// TODO: Add support to Federated Bundles [https://github.com/spiffe/spire/issues/42]
+	EntryID    string `gorm:"unique_index"`
+	SpiffeID   string
+	ParentID   string
+	TTL        int32
+	JWTSvidTTL int32
+	Selectors  []Selector
+	Admin      bool
+	RotatedAt  int64
+
+	FederatesWith []FederatedTrustDomain
 }
 
 // Keep time simple and easily comparable with UNIX time
@@ -58,6 +79,28 @@ type JoinToken struct {
 	Expiry int64
 }
 
+// IssuedSVID records an X509-SVID signed by the server. Times are UNIX time,
+// like JoinToken, and RevokedAt is zero unless the SVID is revoked.
+type IssuedSVID struct {
+	gorm.Model
+
+	SerialNumber string `gorm:"unique_index"`
+	SpiffeID     string
+	EntryID      string `gorm:"index"`
+	Expiry       int64
+	RevokedAt    int64
+}
+
+// EntryEvent records a change of a registration entry, or of the selectors
+// of a node, so that changes can be pushed to the agents and the servers can
+// keep their entry caches up to date. The ID is the event ID.
+type EntryEvent struct {
+	gorm.Model
+
+	EntryID      string
+	NodeSpiffeID string
+}
+
 type Selector struct {
 	gorm.Model
 
@@ -66,10 +109,29 @@ type Selector struct {
 	Value             string `gorm:"unique_index:idx_selector_entry"`
 }
 
+// FederatedTrustDomain holds a trust domain that a registration entry
+// federates with
+type FederatedTrustDomain struct {
+	gorm.Model
+
+	RegisteredEntryID uint   `gorm:"unique_index:idx_federated_trust_domain"`
+	TrustDomain       string `gorm:"unique_index:idx_federated_trust_domain"`
+}
+
 func migrateDB(db *gorm.DB) {
-	db.AutoMigrate(&Bundle{}, &CACert{}, &AttestedNodeEntry{},
+	// Nodes used to be banned by clearing their serial number, before the
+	// banned column was added
+	scope := db.NewScope(&AttestedNodeEntry{})
+	bannedBySerialNumber := scope.Dialect().HasTable(scope.TableName()) &&
+		!scope.Dialect().HasColumn(scope.TableName(), "banned")
+
+	db.AutoMigrate(&Bundle{}, &CACert{}, &JWTSigningKey{}, &AttestedNodeEntry{},
 		&NodeResolverMapEntry{}, &RegisteredEntry{}, &JoinToken{},
-		&Selector{})
+		&Selector{}, &FederatedTrustDomain{}, &IssuedSVID{}, &EntryEvent{})
+
+	if bannedBySerialNumber {
+		db.Model(&AttestedNodeEntry{}).Where("serial_number = ?", "").Update("banned", true)
+	}
 
 	return
 }
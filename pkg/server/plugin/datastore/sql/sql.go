@@ -48,23 +48,27 @@ type sqlPlugin struct {
 	mutex *sync.Mutex
 }
 
-// CreateBundle stores the given bundle
+// CreateBundle stores the given bundle, with a sequence number of 1. Returns
+// the stored bundle.
 func (ds *sqlPlugin) CreateBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
 	model, err := ds.bundleToModel(req)
 	if err != nil {
 		return nil, err
 	}
+	model.SequenceNumber = 1
 
 	result := ds.db.Create(model)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return req, nil
+	return ds.modelToBundle(model)
 }
 
-// UpdateBundle updates an existing bundle with the given CAs. Overwrites any
-// existing certificates.
+// UpdateBundle updates an existing bundle with the given CAs, tainted CAs and
+// refresh hint, and bumps its sequence number. Overwrites any existing
+// certificates.
+// Returns the stored bundle.
 func (ds *sqlPlugin) UpdateBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
 	newModel, err := ds.bundleToModel(req)
 	if err != nil {
@@ -81,26 +85,43 @@ func (ds *sqlPlugin) UpdateBundle(ctx context.Context, req *datastore.Bundle) (*
 		return nil, result.Error
 	}
 
-	// Delete existing CA certs - the provided list takes precedence
+	// Delete existing CA certs and JWT signing keys - the provided lists take precedence
 	result = tx.Where("bundle_id = ?", model.ID).Delete(CACert{})
 	if result.Error != nil {
 		tx.Rollback()
 		return nil, result.Error
 	}
+	result = tx.Where("bundle_id = ?", model.ID).Delete(JWTSigningKey{})
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, result.Error
+	}
 
 	// Set the new values
 	model.CACerts = newModel.CACerts
+	model.JWTSigningKeys = newModel.JWTSigningKeys
+	model.RefreshHint = newModel.RefreshHint
+	model.TaintedCACerts = newModel.TaintedCACerts
+	model.SequenceNumber++
 	result = tx.Save(model)
 	if result.Error != nil {
 		tx.Rollback()
 		return nil, result.Error
 	}
 
-	return req, tx.Commit().Error
+	resp, err := ds.modelToBundle(model)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return resp, tx.Commit().Error
 }
 
-// AppendBundle adds the specified CA certificates to an existing bundle. If no bundle exists for the
-// specified trust domain, create one. Returns the entirety.
+// AppendBundle adds the specified CA certificates and tainted CA certificates to an existing bundle.
+// If no bundle exists for the specified trust domain, create one. The refresh hint, if given,
+// replaces the existing one. The sequence number is bumped if the bundle changes. Returns the
+// entirety.
 func (ds *sqlPlugin) AppendBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
 	newModel, err := ds.bundleToModel(req)
 	if err != nil {
@@ -130,11 +151,47 @@ func (ds *sqlPlugin) AppendBundle(ctx context.Context, req *datastore.Bundle) (*
 	}
 	model.CACerts = caCerts
 
+	var jwtSigningKeys []JWTSigningKey
+	result = tx.Model(model).Related(&jwtSigningKeys)
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, result.Error
+	}
+	model.JWTSigningKeys = jwtSigningKeys
+
+	changed := false
 	for _, newCA := range newModel.CACerts {
 		if !model.Contains(newCA) {
 			model.Append(newCA)
+			changed = true
 		}
 	}
+	for _, newKey := range newModel.JWTSigningKeys {
+		if !model.ContainsJWTSigningKey(newKey) {
+			model.AppendJWTSigningKey(newKey)
+			changed = true
+		}
+	}
+	if newModel.RefreshHint != 0 && newModel.RefreshHint != model.RefreshHint {
+		model.RefreshHint = newModel.RefreshHint
+		changed = true
+	}
+	taintedCerts, err := x509.ParseCertificates(newModel.TaintedCACerts)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, cert := range taintedCerts {
+		tainted, err := model.TaintCACert(cert)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		changed = changed || tainted
+	}
+	if changed {
+		model.SequenceNumber++
+	}
 
 	result = tx.Save(model)
 	if result.Error != nil {
@@ -153,9 +210,11 @@ func (ds *sqlPlugin) AppendBundle(ctx context.Context, req *datastore.Bundle) (*
 
 // DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
 func (ds *sqlPlugin) DeleteBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
-	// We don't care if cert data was sent - remove it now to prevent
+	// We don't care if cert or key data was sent - remove it now to prevent
 	// further processing.
 	req.CaCerts = []byte{}
+	req.JwtSigningKeys = nil
+	req.TaintedCaCerts = nil
 
 	model, err := ds.bundleToModel(req)
 	if err != nil {
@@ -179,11 +238,24 @@ func (ds *sqlPlugin) DeleteBundle(ctx context.Context, req *datastore.Bundle) (*
 	}
 	model.CACerts = caCerts
 
+	var jwtSigningKeys []JWTSigningKey
+	result = tx.Model(model).Related(&jwtSigningKeys)
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, result.Error
+	}
+	model.JWTSigningKeys = jwtSigningKeys
+
 	result = tx.Where("bundle_id = ?", model.ID).Delete(CACert{})
 	if result.Error != nil {
 		tx.Rollback()
 		return nil, result.Error
 	}
+	result = tx.Where("bundle_id = ?", model.ID).Delete(JWTSigningKey{})
+	if result.Error != nil {
+		tx.Rollback()
+		return nil, result.Error
+	}
 
 	result = tx.Delete(model)
 	if result.Error != nil {
@@ -219,6 +291,13 @@ func (ds *sqlPlugin) FetchBundle(ctx context.Context, req *datastore.Bundle) (*d
 	}
 	model.CACerts = caCerts
 
+	var jwtSigningKeys []JWTSigningKey
+	result = ds.db.Model(model).Related(&jwtSigningKeys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	model.JWTSigningKeys = jwtSigningKeys
+
 	return ds.modelToBundle(model)
 }
 
@@ -252,6 +331,17 @@ func (ds *sqlPlugin) ListBundles(ctx context.Context, req *common.Empty) (*datas
 		}
 	}
 
+	var jwtSigningKeys []JWTSigningKey
+	result = tx.Find(&jwtSigningKeys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	keyMap := make(map[uint][]JWTSigningKey)
+	for _, key := range jwtSigningKeys {
+		keyMap[key.BundleID] = append(keyMap[key.BundleID], key)
+	}
+
 	resp := &datastore.Bundles{}
 	for _, model := range bundles {
 		certs, ok := caMap[model.ID]
@@ -260,6 +350,7 @@ func (ds *sqlPlugin) ListBundles(ctx context.Context, req *common.Empty) (*datas
 		} else {
 			model.CACerts = []CACert{}
 		}
+		model.JWTSigningKeys = keyMap[model.ID]
 
 		bundle, err := ds.modelToBundle(&model)
 		if err != nil {
@@ -293,6 +384,7 @@ func (ds *sqlPlugin) CreateAttestedNodeEntry(ctx context.Context,
 		DataType:     entry.AttestationDataType,
 		SerialNumber: entry.CertSerialNumber,
 		ExpiresAt:    expiresAt,
+		Banned:       entry.Banned,
 	}
 
 	if err := ds.db.Create(&model).Error; err != nil {
@@ -300,12 +392,7 @@ func (ds *sqlPlugin) CreateAttestedNodeEntry(ctx context.Context,
 	}
 
 	return &datastore.CreateAttestedNodeEntryResponse{
-		AttestedNodeEntry: &datastore.AttestedNodeEntry{
-			BaseSpiffeId:       model.SpiffeID,
-			AttestationDataType:   model.DataType,
-			CertSerialNumber:   model.SerialNumber,
-			CertExpirationDate: expiresAt.Format(datastore.TimeFormat),
-		},
+		AttestedNodeEntry: modelToAttestedNodeEntry(model),
 	}, nil
 }
 
@@ -324,12 +411,7 @@ func (ds *sqlPlugin) FetchAttestedNodeEntry(ctx context.Context,
 		return nil, err
 	}
 	return &datastore.FetchAttestedNodeEntryResponse{
-		AttestedNodeEntry: &datastore.AttestedNodeEntry{
-			BaseSpiffeId:       model.SpiffeID,
-			AttestationDataType:   model.DataType,
-			CertSerialNumber:   model.SerialNumber,
-			CertExpirationDate: model.ExpiresAt.Format(datastore.TimeFormat),
-		},
+		AttestedNodeEntry: modelToAttestedNodeEntry(model),
 	}, nil
 }
 
@@ -349,29 +431,81 @@ func (ds *sqlPlugin) FetchStaleNodeEntries(ctx context.Context,
 	}
 
 	for _, model := range models {
-		resp.AttestedNodeEntryList = append(resp.AttestedNodeEntryList, &datastore.AttestedNodeEntry{
-			BaseSpiffeId:       model.SpiffeID,
-			AttestationDataType:   model.DataType,
-			CertSerialNumber:   model.SerialNumber,
-			CertExpirationDate: model.ExpiresAt.Format(datastore.TimeFormat),
-		})
+		resp.AttestedNodeEntryList = append(resp.AttestedNodeEntryList, modelToAttestedNodeEntry(model))
 	}
 	return resp, nil
 }
 
+func (ds *sqlPlugin) ListAttestedNodeEntries(ctx context.Context,
+	req *datastore.ListAttestedNodeEntriesRequest) (*datastore.ListAttestedNodeEntriesResponse, error) {
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	var models []AttestedNodeEntry
+	if err := ds.db.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListAttestedNodeEntriesResponse{
+		AttestedNodeEntryList: make([]*datastore.AttestedNodeEntry, 0, len(models)),
+	}
+
+	for _, model := range models {
+		resp.AttestedNodeEntryList = append(resp.AttestedNodeEntryList, modelToAttestedNodeEntry(model))
+	}
+	return resp, nil
+}
+
+// CountAttestedNodeEntries counts the attested nodes, without loading them
+func (ds *sqlPlugin) CountAttestedNodeEntries(ctx context.Context,
+	req *datastore.CountAttestedNodeEntriesRequest) (*datastore.CountAttestedNodeEntriesResponse, error) {
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	var count int
+	if err := ds.db.Model(&AttestedNodeEntry{}).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	return &datastore.CountAttestedNodeEntriesResponse{Count: int32(count)}, nil
+}
+
 func (ds *sqlPlugin) UpdateAttestedNodeEntry(ctx context.Context,
 	req *datastore.UpdateAttestedNodeEntryRequest) (*datastore.UpdateAttestedNodeEntryResponse, error) {
 
 	ds.mutex.Lock()
 	defer ds.mutex.Unlock()
 
-	var model AttestedNodeEntry
+	mask := req.InputMask
+	if mask == nil {
+		mask = &datastore.AttestedNodeEntryMask{
+			CertSerialNumber:   true,
+			CertExpirationDate: true,
+			Banned:             true,
+		}
+	}
 
-	expiresAt, err := time.Parse(datastore.TimeFormat, req.CertExpirationDate)
-	if err != nil {
-		return nil, err
+	// Updating with a map so that zero values, such as an unbanned node, are
+	// not skipped, and only the selected fields are updated
+	updates := make(map[string]interface{})
+	if mask.CertSerialNumber {
+		updates["serial_number"] = req.CertSerialNumber
+	}
+	if mask.CertExpirationDate {
+		expiresAt, err := time.Parse(datastore.TimeFormat, req.CertExpirationDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["expires_at"] = expiresAt
+	}
+	if mask.Banned {
+		updates["banned"] = req.Banned
 	}
 
+	var model AttestedNodeEntry
+
 	db := ds.db.Begin()
 
 	if err := db.Find(&model, "spiffe_id = ?", req.BaseSpiffeId).Error; err != nil {
@@ -379,23 +513,15 @@ func (ds *sqlPlugin) UpdateAttestedNodeEntry(ctx context.Context,
 		return nil, err
 	}
 
-	updates := AttestedNodeEntry{
-		SerialNumber: req.CertSerialNumber,
-		ExpiresAt:    expiresAt,
-	}
-
-	if err := db.Model(&model).Updates(updates).Error; err != nil {
-		db.Rollback()
-		return nil, err
+	if len(updates) > 0 {
+		if err := db.Model(&model).Updates(updates).Error; err != nil {
+			db.Rollback()
+			return nil, err
+		}
 	}
 
 	return &datastore.UpdateAttestedNodeEntryResponse{
-		AttestedNodeEntry: &datastore.AttestedNodeEntry{
-			BaseSpiffeId:       model.SpiffeID,
-			AttestationDataType:   model.DataType,
-			CertSerialNumber:   model.SerialNumber,
-			CertExpirationDate: model.ExpiresAt.Format(datastore.TimeFormat),
-		},
+		AttestedNodeEntry: modelToAttestedNodeEntry(model),
 	}, db.Commit().Error
 }
 
@@ -420,12 +546,7 @@ func (ds *sqlPlugin) DeleteAttestedNodeEntry(ctx context.Context,
 	}
 
 	return &datastore.DeleteAttestedNodeEntryResponse{
-		AttestedNodeEntry: &datastore.AttestedNodeEntry{
-			BaseSpiffeId:       model.SpiffeID,
-			AttestationDataType:   model.DataType,
-			CertSerialNumber:   model.SerialNumber,
-			CertExpirationDate: model.ExpiresAt.Format(datastore.TimeFormat),
-		},
+		AttestedNodeEntry: modelToAttestedNodeEntry(model),
 	}, db.Commit().Error
 }
 
@@ -451,7 +572,14 @@ func (ds *sqlPlugin) CreateNodeResolverMapEntry(ctx context.Context,
 		Value:    selector.Value,
 	}
 
-	if err := ds.db.Create(&model).Error; err != nil {
+	tx := ds.db.Begin()
+	if err := tx.Create(&model).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := createEntryEvent(tx, "", model.SpiffeID); err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
@@ -463,7 +591,7 @@ func (ds *sqlPlugin) CreateNodeResolverMapEntry(ctx context.Context,
 				Value: model.Value,
 			},
 		},
-	}, nil
+	}, tx.Commit().Error
 }
 
 func (ds *sqlPlugin) FetchNodeResolverMapEntry(ctx context.Context,
@@ -528,6 +656,11 @@ func (ds *sqlPlugin) DeleteNodeResolverMapEntry(ctx context.Context,
 		return nil, err
 	}
 
+	if err := createEntryEvent(tx, "", entry.BaseSpiffeId); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	resp := &datastore.DeleteNodeResolverMapEntryResponse{
 		NodeResolverMapEntryList: make([]*datastore.NodeResolverMapEntry, 0, len(models)),
 	}
@@ -572,11 +705,13 @@ func (ds *sqlPlugin) CreateRegistrationEntry(ctx context.Context,
 	}
 
 	newRegisteredEntry := RegisteredEntry{
-		EntryID:  entryID.String(),
-		SpiffeID: request.RegisteredEntry.SpiffeId,
-		ParentID: request.RegisteredEntry.ParentId,
-		TTL:      request.RegisteredEntry.Ttl,
-		// TODO: Add support to Federated Bundles [https://github.com/spiffe/spire/issues/42]
+		EntryID:    entryID.String(),
+		SpiffeID:   request.RegisteredEntry.SpiffeId,
+		ParentID:   request.RegisteredEntry.ParentId,
+		TTL:        request.RegisteredEntry.Ttl,
+		JWTSvidTTL: request.RegisteredEntry.JwtSvidTtl,
+		Admin:      request.RegisteredEntry.Admin,
+		RotatedAt:  request.RegisteredEntry.RotatedAt,
 	}
 
 	tx := ds.db.Begin()
@@ -597,6 +732,23 @@ func (ds *sqlPlugin) CreateRegistrationEntry(ctx context.Context,
 		}
 	}
 
+	for _, trustDomain := range request.RegisteredEntry.FederatesWith {
+		newFederatedTrustDomain := FederatedTrustDomain{
+			RegisteredEntryID: newRegisteredEntry.ID,
+			TrustDomain:       trustDomain,
+		}
+
+		if err := tx.Create(&newFederatedTrustDomain).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := createEntryEvent(tx, newRegisteredEntry.EntryID, ""); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	return &datastore.CreateRegistrationEntryResponse{
 		RegisteredEntryId: newRegisteredEntry.EntryID,
 	}, tx.Commit().Error
@@ -629,13 +781,22 @@ func (ds *sqlPlugin) FetchRegistrationEntry(ctx context.Context,
 			Value: selector.Value})
 	}
 
+	federatesWith, err := ds.fetchFederatesWith(ds.db, &fetchedRegisteredEntry)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.FetchRegistrationEntryResponse{
 		RegisteredEntry: &common.RegistrationEntry{
-			EntryId:   fetchedRegisteredEntry.EntryID,
-			Selectors: selectors,
-			SpiffeId:  fetchedRegisteredEntry.SpiffeID,
-			ParentId:  fetchedRegisteredEntry.ParentID,
-			Ttl:       fetchedRegisteredEntry.TTL,
+			EntryId:       fetchedRegisteredEntry.EntryID,
+			Selectors:     selectors,
+			SpiffeId:      fetchedRegisteredEntry.SpiffeID,
+			ParentId:      fetchedRegisteredEntry.ParentID,
+			Ttl:           fetchedRegisteredEntry.TTL,
+			JwtSvidTtl:    fetchedRegisteredEntry.JWTSvidTTL,
+			Admin:         fetchedRegisteredEntry.Admin,
+			RotatedAt:     fetchedRegisteredEntry.RotatedAt,
+			FederatesWith: federatesWith,
 		},
 	}, nil
 }
@@ -666,7 +827,7 @@ func (ds *sqlPlugin) FetchRegistrationEntries(ctx context.Context,
 		}
 	}
 
-	resEntries, err := ds.convertEntries(entries)
+	resEntries, err := ds.convertEntries(ds.db, entries)
 	if err != nil {
 		return nil, err
 	}
@@ -680,6 +841,30 @@ func (ds *sqlPlugin) FetchRegistrationEntries(ctx context.Context,
 	return res, nil
 }
 
+// CountRegistrationEntries counts the registration entries with the parent ID
+// and SPIFFE ID set in the request, if any, without loading them
+func (ds *sqlPlugin) CountRegistrationEntries(ctx context.Context,
+	req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+
+	db := ds.db.Model(&RegisteredEntry{})
+	if req.ByParentId != "" {
+		db = db.Where("parent_id = ?", req.ByParentId)
+	}
+	if req.BySpiffeId != "" {
+		db = db.Where("spiffe_id = ?", req.BySpiffeId)
+	}
+
+	var count int
+	if err := db.Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	return &datastore.CountRegistrationEntriesResponse{Count: int32(count)}, nil
+}
+
 func (ds sqlPlugin) UpdateRegistrationEntry(ctx context.Context,
 	request *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
 
@@ -718,15 +903,37 @@ func (ds sqlPlugin) UpdateRegistrationEntry(ctx context.Context,
 		selectors = append(selectors, selector)
 	}
 
+	// Delete existing federated trust domains - we will write new ones
+	if err = tx.Exec("DELETE FROM federated_trust_domains WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	federatesWith := []FederatedTrustDomain{}
+	for _, trustDomain := range request.RegisteredEntry.FederatesWith {
+		federatesWith = append(federatesWith, FederatedTrustDomain{
+			TrustDomain: trustDomain,
+		})
+	}
+
 	entry.SpiffeID = request.RegisteredEntry.SpiffeId
 	entry.ParentID = request.RegisteredEntry.ParentId
 	entry.TTL = request.RegisteredEntry.Ttl
+	entry.JWTSvidTTL = request.RegisteredEntry.JwtSvidTtl
+	entry.Admin = request.RegisteredEntry.Admin
+	entry.RotatedAt = request.RegisteredEntry.RotatedAt
 	entry.Selectors = selectors
+	entry.FederatesWith = federatesWith
 	if err = tx.Save(&entry).Error; err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
+	if err = createEntryEvent(tx, entry.EntryID, ""); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	if err = tx.Commit().Error; err != nil {
 		tx.Rollback()
 		return nil, err
@@ -739,17 +946,33 @@ func (ds sqlPlugin) UpdateRegistrationEntry(ctx context.Context,
 func (ds *sqlPlugin) DeleteRegistrationEntry(ctx context.Context,
 	request *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
 
+	tx := ds.db.Begin()
+
 	entry := RegisteredEntry{}
-	if err := ds.db.Find(&entry, "entry_id = ?", request.RegisteredEntryId).Error; err != nil {
+	if err := tx.Find(&entry, "entry_id = ?", request.RegisteredEntryId).Error; err != nil {
+		tx.Rollback()
 		return &datastore.DeleteRegistrationEntryResponse{}, err
 	}
 
-	if err := ds.db.Delete(&entry).Error; err != nil {
+	// Convert the entry before deleting it, so that its selectors and
+	// federated trust domains are read in the same transaction
+	respEntry, err := ds.convertEntries(tx, []RegisteredEntry{entry})
+	if err != nil {
+		tx.Rollback()
 		return &datastore.DeleteRegistrationEntryResponse{}, err
 	}
 
-	respEntry, err := ds.convertEntries([]RegisteredEntry{entry})
-	if err != nil {
+	if err := tx.Delete(&entry).Error; err != nil {
+		tx.Rollback()
+		return &datastore.DeleteRegistrationEntryResponse{}, err
+	}
+
+	if err := createEntryEvent(tx, entry.EntryID, ""); err != nil {
+		tx.Rollback()
+		return &datastore.DeleteRegistrationEntryResponse{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return &datastore.DeleteRegistrationEntryResponse{}, err
 	}
 
@@ -775,7 +998,7 @@ func (ds *sqlPlugin) ListParentIDEntries(ctx context.Context,
 		return nil, err
 	}
 
-	regEntryList, err := ds.convertEntries(fetchedRegisteredEntries)
+	regEntryList, err := ds.convertEntries(ds.db, fetchedRegisteredEntries)
 	if err != nil {
 		return nil, err
 	}
@@ -825,7 +1048,7 @@ func (ds *sqlPlugin) ListSpiffeEntries(ctx context.Context,
 		return &datastore.ListSpiffeEntriesResponse{}, err
 	}
 
-	respEntries, err := ds.convertEntries(entries)
+	respEntries, err := ds.convertEntries(ds.db, entries)
 	if err != nil {
 		return &datastore.ListSpiffeEntriesResponse{}, err
 	}
@@ -836,6 +1059,37 @@ func (ds *sqlPlugin) ListSpiffeEntries(ctx context.Context,
 	return resp, nil
 }
 
+// ListEntryEvents lists the entry events with an ID greater than the one
+// in the request, in increasing ID order
+func (ds *sqlPlugin) ListEntryEvents(ctx context.Context,
+	req *datastore.ListEntryEventsRequest) (*datastore.ListEntryEventsResponse, error) {
+
+	var models []EntryEvent
+	if err := ds.db.Where("id > ?", req.AfterEventId).Order("id").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListEntryEventsResponse{
+		Events: make([]*datastore.EntryEvent, 0, len(models)),
+	}
+	for _, model := range models {
+		resp.Events = append(resp.Events, &datastore.EntryEvent{
+			EventId:      uint64(model.ID),
+			EntryId:      model.EntryID,
+			NodeSpiffeId: model.NodeSpiffeID,
+			CreatedAt:    model.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// PruneEntryEvents takes an EntryEvent message, and deletes all entry events
+// created before the date in the message
+func (ds *sqlPlugin) PruneEntryEvents(ctx context.Context, req *datastore.EntryEvent) (*common.Empty, error) {
+	resp := new(common.Empty)
+	return resp, ds.db.Unscoped().Where("created_at < ?", time.Unix(req.CreatedAt, 0)).Delete(EntryEvent{}).Error
+}
+
 // RegisterToken takes a Token message and stores it
 func (ds *sqlPlugin) RegisterToken(ctx context.Context, req *datastore.JoinToken) (*common.Empty, error) {
 	resp := new(common.Empty)
@@ -900,6 +1154,107 @@ func (ds *sqlPlugin) PruneTokens(ctx context.Context, req *datastore.JoinToken)
 	return resp, nil
 }
 
+// CreateIssuedSVID records an SVID issued by the server
+func (ds *sqlPlugin) CreateIssuedSVID(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	if req.SerialNumber == "" || req.SpiffeId == "" || req.Expiry == 0 {
+		return nil, errors.New("serial number, SPIFFE ID and expiry are required")
+	}
+
+	model := IssuedSVID{
+		SerialNumber: req.SerialNumber,
+		SpiffeID:     req.SpiffeId,
+		EntryID:      req.EntryId,
+		Expiry:       req.Expiry,
+		RevokedAt:    req.RevokedAt,
+	}
+
+	if err := ds.db.Create(&model).Error; err != nil {
+		return nil, err
+	}
+
+	return modelToIssuedSVID(model), nil
+}
+
+// FetchIssuedSVID takes an IssuedSVID message and returns the issued SVID with
+// its serial number, or an empty message if there is none
+func (ds *sqlPlugin) FetchIssuedSVID(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	var model IssuedSVID
+	err := ds.db.Find(&model, "serial_number = ?", req.SerialNumber).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.IssuedSVID{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	return modelToIssuedSVID(model), nil
+}
+
+// RevokeIssuedSVIDs revokes the issued SVID with the serial number in the
+// message or, if not set, all the SVIDs issued for the entry in the message.
+// SVIDs already revoked are left as they are.
+func (ds *sqlPlugin) RevokeIssuedSVIDs(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVIDs, error) {
+	revokedAt := req.RevokedAt
+	if revokedAt == 0 {
+		revokedAt = time.Now().Unix()
+	}
+
+	tx := ds.db.Begin()
+
+	var models []IssuedSVID
+	switch {
+	case req.SerialNumber != "":
+		var model IssuedSVID
+		if err := tx.Find(&model, "serial_number = ?", req.SerialNumber).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		models = append(models, model)
+	case req.EntryId != "":
+		if err := tx.Find(&models, "entry_id = ?", req.EntryId).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	default:
+		tx.Rollback()
+		return nil, errors.New("serial number or entry ID is required")
+	}
+
+	resp := new(datastore.IssuedSVIDs)
+	for _, model := range models {
+		if model.RevokedAt == 0 {
+			if err := tx.Model(&model).Update("revoked_at", revokedAt).Error; err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+		resp.Svids = append(resp.Svids, modelToIssuedSVID(model))
+	}
+
+	return resp, tx.Commit().Error
+}
+
+// ListRevokedSVIDs lists all the issued SVIDs that have been revoked
+func (ds *sqlPlugin) ListRevokedSVIDs(ctx context.Context, req *common.Empty) (*datastore.IssuedSVIDs, error) {
+	var models []IssuedSVID
+	if err := ds.db.Find(&models, "revoked_at <> 0").Error; err != nil {
+		return nil, err
+	}
+
+	resp := new(datastore.IssuedSVIDs)
+	for _, model := range models {
+		resp.Svids = append(resp.Svids, modelToIssuedSVID(model))
+	}
+	return resp, nil
+}
+
+// PruneIssuedSVIDs takes an IssuedSVID message, and deletes all issued SVIDs
+// which have expired before the date in the message
+func (ds *sqlPlugin) PruneIssuedSVIDs(ctx context.Context, req *datastore.IssuedSVID) (*common.Empty, error) {
+	resp := new(common.Empty)
+	return resp, ds.db.Where("expiry <= ?", req.Expiry).Delete(IssuedSVID{}).Error
+}
+
 func (ds *sqlPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	resp := &spi.ConfigureResponse{}
 
@@ -924,6 +1279,16 @@ func (ds *sqlPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (
 		return resp, errors.New("connection_string must be set")
 	}
 
+	// Validating the configuration must not touch the database
+	if req.DryRun {
+		switch config.DatabaseType {
+		case "sqlite3", "postgres":
+			return resp, nil
+		default:
+			return resp, fmt.Errorf("unsupported database_type: %v", config.DatabaseType)
+		}
+	}
+
 	if config.ConnectionString != ds.ConnectionString {
 		ds.DatabaseType = config.DatabaseType
 		ds.ConnectionString = config.ConnectionString
@@ -983,7 +1348,7 @@ func (ds *sqlPlugin) listMatchingEntries(selectors []*common.Selector) ([]*commo
 
 	// Weed out entries that have more selectors than requested, since only
 	// EXACT matches should be returned.
-	convertedEntries, err := ds.convertEntriesNoSort(resp)
+	convertedEntries, err := ds.convertEntriesNoSort(ds.db, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -997,8 +1362,18 @@ func (ds *sqlPlugin) listMatchingEntries(selectors []*common.Selector) ([]*commo
 	return entries, nil
 }
 
+// createEntryEvent records a change of the registration entry or of the
+// selectors of the node given
+func createEntryEvent(tx *gorm.DB, entryID, nodeSpiffeID string) error {
+	return tx.Create(&EntryEvent{
+		EntryID:      entryID,
+		NodeSpiffeID: nodeSpiffeID,
+	}).Error
+}
+
 // bundleToModel converts the given Protobuf bundle message to a database model. It
-// performs validation, and fully parses certificates to form CACert embedded models.
+// performs validation, and fully parses certificates and keys to form CACert and
+// JWTSigningKey embedded models.
 func (ds *sqlPlugin) bundleToModel(pb *datastore.Bundle) (*Bundle, error) {
 	id, err := ds.validateTrustDomain(pb.TrustDomain)
 	if err != nil {
@@ -1021,16 +1396,39 @@ func (ds *sqlPlugin) bundleToModel(pb *datastore.Bundle) (*Bundle, error) {
 		caCerts = append(caCerts, cert)
 	}
 
+	if _, err := x509.ParseCertificates(pb.TaintedCaCerts); err != nil {
+		return nil, errors.New("could not parse tainted CA certificates")
+	}
+
+	// Translate JWT signing keys, if any
+	jwtSigningKeys := []JWTSigningKey{}
+	for _, k := range pb.JwtSigningKeys {
+		if k.Kid == "" {
+			return nil, errors.New("missing JWT signing key ID")
+		}
+		if _, err := x509.ParsePKIXPublicKey(k.PkixBytes); err != nil {
+			return nil, errors.New("could not parse JWT signing key")
+		}
+		jwtSigningKeys = append(jwtSigningKeys, JWTSigningKey{
+			KeyID:     k.Kid,
+			PublicKey: k.PkixBytes,
+			Expiry:    time.Unix(k.NotAfter, 0),
+		})
+	}
+
 	bundle := &Bundle{
-		TrustDomain: id.String(),
-		CACerts:     caCerts,
+		TrustDomain:    id.String(),
+		CACerts:        caCerts,
+		JWTSigningKeys: jwtSigningKeys,
+		RefreshHint:    pb.RefreshHint,
+		TaintedCACerts: pb.TaintedCaCerts,
 	}
 
 	return bundle, nil
 }
 
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
-// include any embedded CACert models.
+// include any embedded CACert and JWTSigningKey models.
 func (ds *sqlPlugin) modelToBundle(model *Bundle) (*datastore.Bundle, error) {
 	id, err := ds.validateTrustDomain(model.TrustDomain)
 	if err != nil {
@@ -1043,13 +1441,43 @@ func (ds *sqlPlugin) modelToBundle(model *Bundle) (*datastore.Bundle, error) {
 	}
 
 	pb := &datastore.Bundle{
-		TrustDomain: id.String(),
-		CaCerts:     caCerts,
+		TrustDomain:    id.String(),
+		CaCerts:        caCerts,
+		SequenceNumber: model.SequenceNumber,
+		RefreshHint:    model.RefreshHint,
+		TaintedCaCerts: model.TaintedCACerts,
+	}
+	for _, k := range model.JWTSigningKeys {
+		pb.JwtSigningKeys = append(pb.JwtSigningKeys, &common.PublicKey{
+			PkixBytes: k.PublicKey,
+			Kid:       k.KeyID,
+			NotAfter:  k.Expiry.Unix(),
+		})
 	}
 
 	return pb, nil
 }
 
+func modelToAttestedNodeEntry(model AttestedNodeEntry) *datastore.AttestedNodeEntry {
+	return &datastore.AttestedNodeEntry{
+		BaseSpiffeId:        model.SpiffeID,
+		AttestationDataType: model.DataType,
+		CertSerialNumber:    model.SerialNumber,
+		CertExpirationDate:  model.ExpiresAt.Format(datastore.TimeFormat),
+		Banned:              model.Banned,
+	}
+}
+
+func modelToIssuedSVID(model IssuedSVID) *datastore.IssuedSVID {
+	return &datastore.IssuedSVID{
+		SerialNumber: model.SerialNumber,
+		SpiffeId:     model.SpiffeID,
+		EntryId:      model.EntryID,
+		Expiry:       model.Expiry,
+		RevokedAt:    model.RevokedAt,
+	}
+}
+
 func (ds *sqlPlugin) validateRegistrationEntry(entry *common.RegistrationEntry) error {
 	if entry.Selectors == nil || len(entry.Selectors) == 0 {
 		return errors.New("missing selector list")
@@ -1063,6 +1491,10 @@ func (ds *sqlPlugin) validateRegistrationEntry(entry *common.RegistrationEntry)
 		return errors.New("TTL is not set")
 	}
 
+	if entry.JwtSvidTtl < 0 {
+		return errors.New("JWT-SVID TTL cannot be negative")
+	}
+
 	return nil
 }
 
@@ -1088,8 +1520,8 @@ func (ds *sqlPlugin) validateTrustDomain(in string) (*url.URL, error) {
 	return id, nil
 }
 
-func (ds *sqlPlugin) convertEntries(fetchedRegisteredEntries []RegisteredEntry) (responseEntries []*common.RegistrationEntry, err error) {
-	entries, err := ds.convertEntriesNoSort(fetchedRegisteredEntries)
+func (ds *sqlPlugin) convertEntries(tx *gorm.DB, fetchedRegisteredEntries []RegisteredEntry) (responseEntries []*common.RegistrationEntry, err error) {
+	entries, err := ds.convertEntriesNoSort(tx, fetchedRegisteredEntries)
 	if err != nil {
 		return nil, err
 	}
@@ -1097,11 +1529,11 @@ func (ds *sqlPlugin) convertEntries(fetchedRegisteredEntries []RegisteredEntry)
 	return entries, nil
 }
 
-func (ds *sqlPlugin) convertEntriesNoSort(fetchedRegisteredEntries []RegisteredEntry) (responseEntries []*common.RegistrationEntry, err error) {
+func (ds *sqlPlugin) convertEntriesNoSort(tx *gorm.DB, fetchedRegisteredEntries []RegisteredEntry) (responseEntries []*common.RegistrationEntry, err error) {
 	for _, regEntry := range fetchedRegisteredEntries {
 		var selectors []*common.Selector
 		var fetchedSelectors []*Selector
-		if err = ds.db.Model(&regEntry).Related(&fetchedSelectors).Error; err != nil {
+		if err = tx.Model(&regEntry).Related(&fetchedSelectors).Error; err != nil {
 			return nil, err
 		}
 
@@ -1110,17 +1542,41 @@ func (ds *sqlPlugin) convertEntriesNoSort(fetchedRegisteredEntries []RegisteredE
 				Type:  selector.Type,
 				Value: selector.Value})
 		}
+
+		federatesWith, err := ds.fetchFederatesWith(tx, &regEntry)
+		if err != nil {
+			return nil, err
+		}
+
 		responseEntries = append(responseEntries, &common.RegistrationEntry{
-			EntryId:   regEntry.EntryID,
-			Selectors: selectors,
-			SpiffeId:  regEntry.SpiffeID,
-			ParentId:  regEntry.ParentID,
-			Ttl:       regEntry.TTL,
+			EntryId:       regEntry.EntryID,
+			Selectors:     selectors,
+			SpiffeId:      regEntry.SpiffeID,
+			ParentId:      regEntry.ParentID,
+			Ttl:           regEntry.TTL,
+			JwtSvidTtl:    regEntry.JWTSvidTTL,
+			Admin:         regEntry.Admin,
+			RotatedAt:     regEntry.RotatedAt,
+			FederatesWith: federatesWith,
 		})
 	}
 	return responseEntries, nil
 }
 
+// fetchFederatesWith returns the trust domains the given entry federates with
+func (ds *sqlPlugin) fetchFederatesWith(tx *gorm.DB, entry *RegisteredEntry) ([]string, error) {
+	var fetchedTrustDomains []*FederatedTrustDomain
+	if err := tx.Model(entry).Related(&fetchedTrustDomains).Error; err != nil {
+		return nil, err
+	}
+
+	var federatesWith []string
+	for _, trustDomain := range fetchedTrustDomains {
+		federatesWith = append(federatesWith, trustDomain.TrustDomain)
+	}
+	return federatesWith, nil
+}
+
 // restart will close and re-open the gorm database.
 func (ds *sqlPlugin) restart() error {
 	ds.mutex.Lock()
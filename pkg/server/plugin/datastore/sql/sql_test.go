@@ -2,12 +2,17 @@ package sql
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/jinzhu/gorm"
 	"github.com/spiffe/spire/proto/common"
 	spi "github.com/spiffe/spire/proto/common/plugin"
 	"github.com/spiffe/spire/proto/server/datastore"
@@ -47,8 +52,10 @@ func TestBundle_CRUD(t *testing.T) {
 	}
 
 	// create
-	_, err = ds.CreateBundle(ctx, bundle)
+	cresp, err := ds.CreateBundle(ctx, bundle)
 	require.NoError(t, err)
+	bundle.SequenceNumber = 1
+	assert.Equal(t, bundle, cresp)
 
 	// fetch
 	fresp, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: "spiffe://foo/"})
@@ -74,11 +81,13 @@ func TestBundle_CRUD(t *testing.T) {
 	require.NoError(t, err)
 	certs := append(bundle.CaCerts, cert.Raw...)
 	assert.Equal(t, certs, aresp.CaCerts)
+	assert.Equal(t, uint64(2), aresp.SequenceNumber)
 
 	// append identical
 	aresp, err = ds.AppendBundle(ctx, bundle2)
 	require.NoError(t, err)
 	assert.Equal(t, certs, aresp.CaCerts)
+	assert.Equal(t, uint64(2), aresp.SequenceNumber)
 
 	// append on a new bundle
 	bundle3 := &datastore.Bundle{
@@ -87,11 +96,13 @@ func TestBundle_CRUD(t *testing.T) {
 	}
 	anresp, err := ds.AppendBundle(ctx, bundle3)
 	require.NoError(t, err)
+	bundle3.SequenceNumber = 1
 	assert.Equal(t, bundle3, anresp)
 
 	// update
 	uresp, err := ds.UpdateBundle(ctx, bundle2)
 	require.NoError(t, err)
+	bundle2.SequenceNumber = 3
 	assert.Equal(t, bundle2, uresp)
 
 	lresp, err = ds.ListBundles(ctx, &common.Empty{})
@@ -111,6 +122,155 @@ func TestBundle_CRUD(t *testing.T) {
 	assert.Equal(t, 1, len(lresp.Bundles))
 }
 
+func TestBundle_JWTSigningKeys(t *testing.T) {
+	ds := createDefault(t)
+
+	cert, _, err := testutil.LoadSVIDFixture()
+	require.NoError(t, err)
+	pkixBytes, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	require.NoError(t, err)
+
+	key1 := &common.PublicKey{PkixBytes: pkixBytes, Kid: "key1", NotAfter: 1000}
+	key2 := &common.PublicKey{PkixBytes: pkixBytes, Kid: "key2", NotAfter: 2000}
+
+	bundle := &datastore.Bundle{
+		TrustDomain:    "spiffe://foo/",
+		CaCerts:        cert.Raw,
+		JwtSigningKeys: []*common.PublicKey{key1},
+	}
+
+	// create
+	_, err = ds.CreateBundle(ctx, bundle)
+	require.NoError(t, err)
+
+	fresp, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: "spiffe://foo/"})
+	require.NoError(t, err)
+	bundle.SequenceNumber = 1
+	assert.Equal(t, bundle, fresp)
+
+	// append a new key and an identical one
+	aresp, err := ds.AppendBundle(ctx, &datastore.Bundle{
+		TrustDomain:    bundle.TrustDomain,
+		JwtSigningKeys: []*common.PublicKey{key1, key2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*common.PublicKey{key1, key2}, aresp.JwtSigningKeys)
+
+	lresp, err := ds.ListBundles(ctx, &common.Empty{})
+	require.NoError(t, err)
+	require.Len(t, lresp.Bundles, 1)
+	assert.Equal(t, []*common.PublicKey{key1, key2}, lresp.Bundles[0].JwtSigningKeys)
+
+	// update
+	bundle.JwtSigningKeys = []*common.PublicKey{key2}
+	_, err = ds.UpdateBundle(ctx, bundle)
+	require.NoError(t, err)
+
+	fresp, err = ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: "spiffe://foo/"})
+	require.NoError(t, err)
+	bundle.SequenceNumber = 3
+	assert.Equal(t, bundle, fresp)
+
+	// delete
+	dresp, err := ds.DeleteBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain})
+	require.NoError(t, err)
+	assert.Equal(t, bundle, dresp)
+
+	// invalid keys are rejected
+	bundle.JwtSigningKeys = []*common.PublicKey{{PkixBytes: []byte("foo"), Kid: "key3"}}
+	_, err = ds.CreateBundle(ctx, bundle)
+	require.EqualError(t, err, "could not parse JWT signing key")
+}
+
+func TestBundle_RefreshHint(t *testing.T) {
+	ds := createDefault(t)
+
+	cert, _, err := testutil.LoadSVIDFixture()
+	require.NoError(t, err)
+
+	bundle := &datastore.Bundle{
+		TrustDomain: "spiffe://foo/",
+		CaCerts:     cert.Raw,
+		RefreshHint: 60,
+	}
+
+	// create
+	cresp, err := ds.CreateBundle(ctx, bundle)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), cresp.RefreshHint)
+	assert.Equal(t, uint64(1), cresp.SequenceNumber)
+
+	// appending without a refresh hint keeps the existing one
+	aresp, err := ds.AppendBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain})
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), aresp.RefreshHint)
+	assert.Equal(t, uint64(1), aresp.SequenceNumber)
+
+	// appending a new refresh hint replaces it, and is a change
+	aresp, err = ds.AppendBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain, RefreshHint: 120})
+	require.NoError(t, err)
+	assert.Equal(t, int64(120), aresp.RefreshHint)
+	assert.Equal(t, uint64(2), aresp.SequenceNumber)
+
+	// updating replaces it, and the sequence number given is ignored
+	bundle.RefreshHint = 0
+	bundle.SequenceNumber = 10
+	uresp, err := ds.UpdateBundle(ctx, bundle)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), uresp.RefreshHint)
+	assert.Equal(t, uint64(3), uresp.SequenceNumber)
+
+	fresp, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain})
+	require.NoError(t, err)
+	assert.Equal(t, uresp, fresp)
+}
+
+func TestBundle_TaintedCACerts(t *testing.T) {
+	ds := createDefault(t)
+
+	cert, _, err := testutil.LoadSVIDFixture()
+	require.NoError(t, err)
+	ca, _, err := testutil.LoadCAFixture()
+	require.NoError(t, err)
+
+	bundle := &datastore.Bundle{
+		TrustDomain: "spiffe://foo/",
+		CaCerts:     cert.Raw,
+	}
+	_, err = ds.CreateBundle(ctx, bundle)
+	require.NoError(t, err)
+
+	// appending tainted CA certificates is a change, unless they are
+	// tainted already
+	aresp, err := ds.AppendBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain, TaintedCaCerts: cert.Raw})
+	require.NoError(t, err)
+	assert.Equal(t, cert.Raw, aresp.TaintedCaCerts)
+	assert.Equal(t, uint64(2), aresp.SequenceNumber)
+	aresp, err = ds.AppendBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain, TaintedCaCerts: cert.Raw})
+	require.NoError(t, err)
+	assert.Equal(t, cert.Raw, aresp.TaintedCaCerts)
+	assert.Equal(t, uint64(2), aresp.SequenceNumber)
+
+	// the tainted CA certificates need not be in the bundle
+	aresp, err = ds.AppendBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain, TaintedCaCerts: ca.Raw})
+	require.NoError(t, err)
+	assert.Equal(t, append(cert.Raw, ca.Raw...), aresp.TaintedCaCerts)
+	assert.Equal(t, cert.Raw, aresp.CaCerts)
+
+	fresp, err := ds.FetchBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain})
+	require.NoError(t, err)
+	assert.Equal(t, aresp, fresp)
+
+	// updating replaces them
+	bundle.TaintedCaCerts = ca.Raw
+	uresp, err := ds.UpdateBundle(ctx, bundle)
+	require.NoError(t, err)
+	assert.Equal(t, ca.Raw, uresp.TaintedCaCerts)
+
+	_, err = ds.AppendBundle(ctx, &datastore.Bundle{TrustDomain: bundle.TrustDomain, TaintedCaCerts: []byte("garbage")})
+	require.EqualError(t, err, "could not parse tainted CA certificates")
+}
+
 func Test_CreateAttestedNodeEntry(t *testing.T) {
 	ds := createDefault(t)
 
@@ -169,6 +329,58 @@ func Test_FetchStaleNodeEntries(t *testing.T) {
 	assert.Equal(t, []*datastore.AttestedNodeEntry{epast}, sresp.AttestedNodeEntryList)
 }
 
+func Test_ListAttestedNodeEntries(t *testing.T) {
+	ds := createDefault(t)
+
+	efuture := &datastore.AttestedNodeEntry{
+		BaseSpiffeId:        "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertExpirationDate:  time.Now().Add(time.Hour).Format(datastore.TimeFormat),
+	}
+
+	epast := &datastore.AttestedNodeEntry{
+		BaseSpiffeId:        "bar",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "deadbeef",
+		CertExpirationDate:  time.Now().Add(-time.Hour).Format(datastore.TimeFormat),
+	}
+
+	_, err := ds.CreateAttestedNodeEntry(ctx, &datastore.CreateAttestedNodeEntryRequest{AttestedNodeEntry: efuture})
+	require.NoError(t, err)
+
+	_, err = ds.CreateAttestedNodeEntry(ctx, &datastore.CreateAttestedNodeEntryRequest{AttestedNodeEntry: epast})
+	require.NoError(t, err)
+
+	lresp, err := ds.ListAttestedNodeEntries(ctx, &datastore.ListAttestedNodeEntriesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []*datastore.AttestedNodeEntry{efuture, epast}, lresp.AttestedNodeEntryList)
+}
+
+func Test_CountAttestedNodeEntries(t *testing.T) {
+	ds := createDefault(t)
+
+	cresp, err := ds.CountAttestedNodeEntries(ctx, &datastore.CountAttestedNodeEntriesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), cresp.Count)
+
+	for _, id := range []string{"foo", "bar"} {
+		_, err := ds.CreateAttestedNodeEntry(ctx, &datastore.CreateAttestedNodeEntryRequest{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:        id,
+				AttestationDataType: "aws-tag",
+				CertSerialNumber:    "badcafe",
+				CertExpirationDate:  time.Now().Add(time.Hour).Format(datastore.TimeFormat),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	cresp, err = ds.CountAttestedNodeEntries(ctx, &datastore.CountAttestedNodeEntriesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), cresp.Count)
+}
+
 func Test_UpdateAttestedNodeEntry(t *testing.T) {
 	ds := createDefault(t)
 
@@ -210,6 +422,77 @@ func Test_UpdateAttestedNodeEntry(t *testing.T) {
 	assert.Equal(t, entry.AttestationDataType, fentry.AttestationDataType)
 	assert.Equal(t, userial, fentry.CertSerialNumber)
 	assert.Equal(t, uexpires, fentry.CertExpirationDate)
+
+	// Only the fields selected by the input mask are updated, including zero
+	// values
+	uresp, err = ds.UpdateAttestedNodeEntry(ctx, &datastore.UpdateAttestedNodeEntryRequest{
+		BaseSpiffeId: entry.BaseSpiffeId,
+		Banned:       true,
+		InputMask:    &datastore.AttestedNodeEntryMask{Banned: true},
+	})
+	require.NoError(t, err)
+	assert.True(t, uresp.AttestedNodeEntry.Banned)
+	assert.Equal(t, userial, uresp.AttestedNodeEntry.CertSerialNumber)
+	assert.Equal(t, uexpires, uresp.AttestedNodeEntry.CertExpirationDate)
+
+	uresp, err = ds.UpdateAttestedNodeEntry(ctx, &datastore.UpdateAttestedNodeEntryRequest{
+		BaseSpiffeId:     entry.BaseSpiffeId,
+		CertSerialNumber: "",
+		InputMask:        &datastore.AttestedNodeEntryMask{CertSerialNumber: true},
+	})
+	require.NoError(t, err)
+	assert.True(t, uresp.AttestedNodeEntry.Banned)
+	assert.Equal(t, "", uresp.AttestedNodeEntry.CertSerialNumber)
+
+	fresp, err = ds.FetchAttestedNodeEntry(ctx, &datastore.FetchAttestedNodeEntryRequest{BaseSpiffeId: entry.BaseSpiffeId})
+	require.NoError(t, err)
+	assert.True(t, fresp.AttestedNodeEntry.Banned)
+	assert.Equal(t, "", fresp.AttestedNodeEntry.CertSerialNumber)
+	assert.Equal(t, uexpires, fresp.AttestedNodeEntry.CertExpirationDate)
+
+	// Without an input mask, every field is updated
+	uresp, err = ds.UpdateAttestedNodeEntry(ctx, &datastore.UpdateAttestedNodeEntryRequest{
+		BaseSpiffeId:       entry.BaseSpiffeId,
+		CertSerialNumber:   userial,
+		CertExpirationDate: uexpires,
+	})
+	require.NoError(t, err)
+	assert.False(t, uresp.AttestedNodeEntry.Banned)
+	assert.Equal(t, userial, uresp.AttestedNodeEntry.CertSerialNumber)
+}
+
+func Test_MigrateBannedNodes(t *testing.T) {
+	db, err := sqlite{}.connect(":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	// Nodes used to be banned by clearing their serial number
+	type legacyNode struct {
+		gorm.Model
+
+		SpiffeID     string `gorm:"unique_index"`
+		DataType     string
+		SerialNumber string
+		ExpiresAt    time.Time
+	}
+	legacy := db.Table("attested_node_entries")
+	require.NoError(t, legacy.CreateTable(&legacyNode{}).Error)
+	require.NoError(t, legacy.Create(&legacyNode{SpiffeID: "banned"}).Error)
+	require.NoError(t, legacy.Create(&legacyNode{SpiffeID: "attested", SerialNumber: "badcafe"}).Error)
+
+	migrateDB(db)
+
+	var banned, attested AttestedNodeEntry
+	require.NoError(t, db.Find(&banned, "spiffe_id = ?", "banned").Error)
+	require.NoError(t, db.Find(&attested, "spiffe_id = ?", "attested").Error)
+	assert.True(t, banned.Banned)
+	assert.False(t, attested.Banned)
+
+	// Once the column exists, an empty serial number no longer bans nodes
+	require.NoError(t, db.Model(&banned).Update("banned", false).Error)
+	migrateDB(db)
+	require.NoError(t, db.Find(&banned, "spiffe_id = ?", "banned").Error)
+	assert.False(t, banned.Banned)
 }
 
 func Test_DeleteAttestedNodeEntry(t *testing.T) {
@@ -407,9 +690,11 @@ func Test_FetchRegistrationEntry(t *testing.T) {
 			{Type: "Type2", Value: "Value2"},
 			{Type: "Type3", Value: "Value3"},
 		},
-		SpiffeId: "SpiffeId",
-		ParentId: "ParentId",
-		Ttl:      1,
+		SpiffeId:      "SpiffeId",
+		ParentId:      "ParentId",
+		Ttl:           1,
+		JwtSvidTtl:    2,
+		FederatesWith: []string{"spiffe://otherdomain.org"},
 	}
 
 	createRegistrationEntryResponse, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{RegisteredEntry: registeredEntry})
@@ -478,6 +763,39 @@ func Test_FetchRegistrationEntries(t *testing.T) {
 	assert.Equal(t, expectedResponse, fetchRegistrationEntriesResponse)
 }
 
+func Test_CountRegistrationEntries(t *testing.T) {
+	ds := createDefault(t)
+
+	for _, e := range []*common.RegistrationEntry{
+		{SpiffeId: "spiffe://example.org/foo", ParentId: "spiffe://example.org/bar"},
+		{SpiffeId: "spiffe://example.org/baz", ParentId: "spiffe://example.org/bar"},
+		{SpiffeId: "spiffe://example.org/foo", ParentId: "spiffe://example.org/bat"},
+	} {
+		e.Selectors = []*common.Selector{{Type: "Type1", Value: "Value1"}}
+		_, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{RegisteredEntry: e})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		req   *datastore.CountRegistrationEntriesRequest
+		count int32
+	}{
+		{req: &datastore.CountRegistrationEntriesRequest{}, count: 3},
+		{req: &datastore.CountRegistrationEntriesRequest{ByParentId: "spiffe://example.org/bar"}, count: 2},
+		{req: &datastore.CountRegistrationEntriesRequest{BySpiffeId: "spiffe://example.org/foo"}, count: 2},
+		{req: &datastore.CountRegistrationEntriesRequest{
+			ByParentId: "spiffe://example.org/bar",
+			BySpiffeId: "spiffe://example.org/foo",
+		}, count: 1},
+		{req: &datastore.CountRegistrationEntriesRequest{ByParentId: "spiffe://example.org/none"}, count: 0},
+	}
+	for _, tt := range tests {
+		cresp, err := ds.CountRegistrationEntries(ctx, tt.req)
+		require.NoError(t, err)
+		assert.Equal(t, tt.count, cresp.Count, "%v", tt.req)
+	}
+}
+
 func Test_UpdateRegistrationEntry(t *testing.T) {
 	ds := createDefault(t)
 
@@ -498,6 +816,10 @@ func Test_UpdateRegistrationEntry(t *testing.T) {
 
 	// TODO: Refactor message type to take EntryID directly from the entry - see #449
 	entry1.Ttl = 2
+	entry1.JwtSvidTtl = 3
+	entry1.Admin = true
+	entry1.RotatedAt = 4
+	entry1.FederatesWith = []string{"spiffe://otherdomain.org"}
 	updReq := &datastore.UpdateRegistrationEntryRequest{
 		RegisteredEntryId: createRegistrationEntryResponse.RegisteredEntryId,
 		RegisteredEntry:   entry1,
@@ -523,9 +845,10 @@ func Test_DeleteRegistrationEntry(t *testing.T) {
 			{Type: "Type2", Value: "Value2"},
 			{Type: "Type3", Value: "Value3"},
 		},
-		SpiffeId: "spiffe://example.org/foo",
-		ParentId: "spiffe://example.org/bar",
-		Ttl:      1,
+		SpiffeId:      "spiffe://example.org/foo",
+		ParentId:      "spiffe://example.org/bar",
+		Ttl:           1,
+		FederatesWith: []string{"spiffe://otherdomain.org"},
 	}
 
 	entry2 := &common.RegistrationEntry{
@@ -549,7 +872,8 @@ func Test_DeleteRegistrationEntry(t *testing.T) {
 	require.NotNil(t, res2)
 	entry2.EntryId = res2.RegisteredEntryId
 
-	// Make sure we deleted the right one
+	// Make sure we deleted the right one, and that it is returned with its
+	// selectors and federated trust domains
 	delRes, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{RegisteredEntryId: res1.RegisteredEntryId})
 	require.NoError(t, err)
 	require.Equal(t, entry1, delRes.RegisteredEntry)
@@ -777,10 +1101,198 @@ func Test_PruneTokens(t *testing.T) {
 	assert.Equal(t, "", resp.Token)
 }
 
+func Test_IssuedSVIDs(t *testing.T) {
+	ds := createDefault(t)
+	now := time.Now().Unix()
+
+	svid1 := &datastore.IssuedSVID{
+		SerialNumber: "1",
+		SpiffeId:     "spiffe://example.org/foo",
+		EntryId:      "entry1",
+		Expiry:       now + 60,
+	}
+	svid2 := &datastore.IssuedSVID{
+		SerialNumber: "2",
+		SpiffeId:     "spiffe://example.org/foo",
+		EntryId:      "entry1",
+		Expiry:       now + 120,
+	}
+	svid3 := &datastore.IssuedSVID{
+		SerialNumber: "3",
+		SpiffeId:     "spiffe://example.org/bar",
+		EntryId:      "entry2",
+		Expiry:       now + 60,
+	}
+	for _, svid := range []*datastore.IssuedSVID{svid1, svid2, svid3} {
+		resp, err := ds.CreateIssuedSVID(ctx, svid)
+		require.NoError(t, err)
+		require.Equal(t, svid, resp)
+	}
+
+	// serial numbers are unique
+	_, err := ds.CreateIssuedSVID(ctx, svid1)
+	require.Error(t, err)
+
+	// missing fields
+	_, err = ds.CreateIssuedSVID(ctx, &datastore.IssuedSVID{SerialNumber: "4"})
+	require.Error(t, err)
+
+	// fetch by serial number
+	fetched, err := ds.FetchIssuedSVID(ctx, &datastore.IssuedSVID{SerialNumber: "2"})
+	require.NoError(t, err)
+	require.Equal(t, svid2, fetched)
+
+	fetched, err = ds.FetchIssuedSVID(ctx, &datastore.IssuedSVID{SerialNumber: "4"})
+	require.NoError(t, err)
+	require.Equal(t, &datastore.IssuedSVID{}, fetched)
+
+	// nothing revoked yet
+	revoked, err := ds.ListRevokedSVIDs(ctx, &common.Empty{})
+	require.NoError(t, err)
+	require.Empty(t, revoked.Svids)
+
+	// revoke by serial number
+	resp, err := ds.RevokeIssuedSVIDs(ctx, &datastore.IssuedSVID{
+		SerialNumber: "3",
+		RevokedAt:    now,
+	})
+	require.NoError(t, err)
+	svid3.RevokedAt = now
+	require.Equal(t, []*datastore.IssuedSVID{svid3}, resp.Svids)
+
+	// revoke by entry
+	resp, err = ds.RevokeIssuedSVIDs(ctx, &datastore.IssuedSVID{
+		EntryId:   "entry1",
+		RevokedAt: now + 1,
+	})
+	require.NoError(t, err)
+	svid1.RevokedAt = now + 1
+	svid2.RevokedAt = now + 1
+	require.Equal(t, []*datastore.IssuedSVID{svid1, svid2}, resp.Svids)
+
+	// revoking again leaves the revocation time alone
+	resp, err = ds.RevokeIssuedSVIDs(ctx, &datastore.IssuedSVID{
+		SerialNumber: "3",
+		RevokedAt:    now + 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*datastore.IssuedSVID{svid3}, resp.Svids)
+
+	// unknown serial number
+	_, err = ds.RevokeIssuedSVIDs(ctx, &datastore.IssuedSVID{SerialNumber: "5"})
+	require.Error(t, err)
+
+	// neither serial number nor entry
+	_, err = ds.RevokeIssuedSVIDs(ctx, &datastore.IssuedSVID{})
+	require.Error(t, err)
+
+	revoked, err = ds.ListRevokedSVIDs(ctx, &common.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, []*datastore.IssuedSVID{svid1, svid2, svid3}, revoked.Svids)
+
+	// prune the SVIDs that expired
+	_, err = ds.PruneIssuedSVIDs(ctx, &datastore.IssuedSVID{Expiry: now + 90})
+	require.NoError(t, err)
+	revoked, err = ds.ListRevokedSVIDs(ctx, &common.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, []*datastore.IssuedSVID{svid2}, revoked.Svids)
+}
+
+func Test_EntryEvents(t *testing.T) {
+	ds := createDefault(t)
+
+	entry := &common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	}
+	createResp, err := ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{RegisteredEntry: entry})
+	require.NoError(t, err)
+	entryID := createResp.RegisteredEntryId
+
+	entry.Ttl = 10
+	_, err = ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		RegisteredEntryId: entryID,
+		RegisteredEntry:   entry,
+	})
+	require.NoError(t, err)
+
+	_, err = ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{RegisteredEntryId: entryID})
+	require.NoError(t, err)
+
+	nodeEntry := &datastore.NodeResolverMapEntry{
+		BaseSpiffeId: "spiffe://example.org/agent",
+		Selector:     &common.Selector{Type: "Type1", Value: "Value1"},
+	}
+	_, err = ds.CreateNodeResolverMapEntry(ctx, &datastore.CreateNodeResolverMapEntryRequest{NodeResolverMapEntry: nodeEntry})
+	require.NoError(t, err)
+	_, err = ds.DeleteNodeResolverMapEntry(ctx, &datastore.DeleteNodeResolverMapEntryRequest{NodeResolverMapEntry: nodeEntry})
+	require.NoError(t, err)
+
+	resp, err := ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 5)
+	for i, event := range resp.Events {
+		assert.Equal(t, uint64(i+1), event.EventId)
+		assert.NotZero(t, event.CreatedAt)
+		if i < 3 {
+			assert.Equal(t, entryID, event.EntryId)
+			assert.Empty(t, event.NodeSpiffeId)
+		} else {
+			assert.Empty(t, event.EntryId)
+			assert.Equal(t, nodeEntry.BaseSpiffeId, event.NodeSpiffeId)
+		}
+	}
+
+	// Only the events after the one given are listed
+	resp, err = ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{AfterEventId: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 2)
+	assert.Equal(t, uint64(4), resp.Events[0].EventId)
+
+	// Ensure we don't prune recent events
+	_, err = ds.PruneEntryEvents(ctx, &datastore.EntryEvent{CreatedAt: time.Now().Unix() - 10})
+	require.NoError(t, err)
+	resp, err = ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Events, 5)
+
+	// Ensure we prune old events
+	_, err = ds.PruneEntryEvents(ctx, &datastore.EntryEvent{CreatedAt: time.Now().Unix() + 10})
+	require.NoError(t, err)
+	resp, err = ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Events)
+}
+
 func Test_Configure(t *testing.T) {
 	t.Skipf("TODO")
 }
 
+func Test_ConfigureDryRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-datastore-sql-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, "datastore.sqlite3")
+
+	ds := New()
+	_, err = ds.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`database_type = "sqlite3"
+connection_string = %q`, dbPath),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	_, err = os.Stat(dbPath)
+	require.True(t, os.IsNotExist(err), "the database should not be created")
+
+	_, err = ds.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: `database_type = "mysql"
+connection_string = "foo"`,
+		DryRun: true,
+	})
+	require.EqualError(t, err, "unsupported database_type: mysql")
+}
+
 func Test_GetPluginInfo(t *testing.T) {
 	ds := createDefault(t)
 	resp, err := ds.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
@@ -224,6 +224,10 @@ func (p *IIDAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		config.Secret = os.Getenv(secretKeyVarName)
 	}
 
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
+	}
+
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
@@ -106,6 +106,10 @@ func (p *IITAttestorPlugin) Configure(ctx context.Context, req *spi.ConfigureReq
 		return nil, newError("projectid_whitelist is required")
 	}
 
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
+	}
+
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
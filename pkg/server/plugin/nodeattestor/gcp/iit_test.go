@@ -107,6 +107,31 @@ func (s *IITAttestorSuite) TestErrorWhenNotConfigured() {
 	s.Require().Nil(resp)
 }
 
+func (s *IITAttestorSuite) TestConfigureDryRun() {
+	p := NewIITAttestorPlugin()
+	_, err := p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `
+trust_domain = "example.org"
+projectid_whitelist = ["bar"]
+`,
+		DryRun: true,
+	})
+	s.Require().NoError(err)
+
+	// The configuration is validated, but not applied
+	stream, err := nodeattestor.NewBuiltIn(p).Attest(context.Background())
+	s.Require().NoError(err)
+	defer stream.CloseSend()
+	_, err = stream.Recv()
+	s.requireErrorContains(err, "gcp-iit: not configured")
+
+	_, err = p.Configure(context.Background(), &plugin.ConfigureRequest{
+		Configuration: `trust_domain = "example.org"`,
+		DryRun:        true,
+	})
+	s.requireErrorContains(err, "gcp-iit: projectid_whitelist is required")
+}
+
 func (s *IITAttestorSuite) TestErrorOnInvalidToken() {
 	_, err := s.attest(&nodeattestor.AttestRequest{})
 	s.requireErrorContains(err, "gcp-iit: request missing attestation data")
@@ -95,6 +95,10 @@ func (p *JoinTokenPlugin) Configure(ctx context.Context, req *spi.ConfigureReque
 		return resp, err
 	}
 
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
+	}
+
 	// Set local vars from config struct
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
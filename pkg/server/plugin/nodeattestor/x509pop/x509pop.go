@@ -149,6 +149,10 @@ func (p *X509PoPPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest
 		return nil, newError("unable to load trust bundle: %v", err)
 	}
 
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
+	}
+
 	p.setConfiguration(&configuration{
 		trustDomain: config.TrustDomain,
 		trustBundle: trustBundle,
@@ -0,0 +1,401 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/proto/server/notifier"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 of the request body, keyed with
+	// the configured secret, as "sha256=<hex digest>"
+	SignatureHeader = "X-Spire-Signature"
+
+	// EventHeader holds the type of the event, as in the request body
+	EventHeader = "X-Spire-Event"
+
+	bundleUpdatedEvent = "bundle_updated"
+	caRotatedEvent     = "ca_rotated"
+
+	defaultTimeout       = 10 * time.Second
+	defaultMaxAttempts   = 3
+	defaultRetryInterval = time.Second
+)
+
+type Configuration struct {
+	// HTTPS URLs the events are posted to
+	URLs []string `hcl:"urls" json:"urls"`
+
+	// Key the request bodies are signed with
+	Secret string `hcl:"secret" json:"secret"`
+
+	// Path of the PEM encoded CA certificates the endpoints are verified
+	// with. The system roots are used if empty.
+	CACertPath string `hcl:"ca_cert_path" json:"ca_cert_path"`
+
+	// How long each attempt to post an event may take
+	Timeout string `hcl:"timeout" json:"timeout"`
+
+	// How many times an event is posted to an endpoint before giving up
+	MaxAttempts int `hcl:"max_attempts" json:"max_attempts"`
+
+	// If set, the events are posted before they take effect, which is held
+	// back until they are delivered, rather than once they have
+	Advise bool `hcl:"advise" json:"advise"`
+}
+
+// Event is the body of the requests posted to the endpoints
+type Event struct {
+	// Either "bundle_updated" or "ca_rotated"
+	Type string `json:"type"`
+
+	// Time, in seconds since the Unix epoch, the event was posted at
+	Timestamp int64 `json:"timestamp"`
+
+	// SPIFFE ID of the trust domain
+	TrustDomain string `json:"trust_domain"`
+
+	// Trust bundle of the server, in the SPIFFE bundle format
+	Bundle json.RawMessage `json:"bundle"`
+
+	// Certificates of the new CA and of the one it replaces, base64 encoded
+	// ASN.1 DER. Only set for "ca_rotated" events.
+	CACert     []byte `json:"ca_cert,omitempty"`
+	PrevCACert []byte `json:"prev_ca_cert,omitempty"`
+}
+
+type config struct {
+	urls        []string
+	secret      []byte
+	client      *http.Client
+	maxAttempts int
+	advise      bool
+}
+
+type webhookPlugin struct {
+	mtx    sync.RWMutex
+	config *config
+
+	// How long to wait before the second attempt to post an event. It is
+	// doubled for each further attempt.
+	retryInterval time.Duration
+
+	now func() time.Time
+
+	// Events posted once they have taken effect are queued, and delivered in
+	// order by a goroutine running while the queue is not empty
+	queueMtx   sync.Mutex
+	queue      []*queuedEvent
+	delivering sync.WaitGroup
+}
+
+type queuedEvent struct {
+	config *config
+	event  *Event
+	body   []byte
+}
+
+func New() notifier.Plugin {
+	return newPlugin()
+}
+
+func newPlugin() *webhookPlugin {
+	return &webhookPlugin{
+		retryInterval: defaultRetryInterval,
+		now:           time.Now,
+	}
+}
+
+func (p *webhookPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
+	c := &Configuration{}
+	if err := hcl.Decode(c, req.Configuration); err != nil {
+		return nil, err
+	}
+
+	if len(c.URLs) == 0 {
+		return nil, errors.New("at least one URL is required")
+	}
+	for _, u := range c.URLs {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %v", u, err)
+		}
+		if parsed.Scheme != "https" || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid URL %q: an https URL is required", u)
+		}
+	}
+
+	if c.Secret == "" {
+		return nil, errors.New("secret is required")
+	}
+
+	timeout := defaultTimeout
+	if c.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(c.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout value: %v", err)
+		}
+	}
+
+	maxAttempts := defaultMaxAttempts
+	if c.MaxAttempts < 0 {
+		return nil, errors.New("max_attempts must not be negative")
+	}
+	if c.MaxAttempts > 0 {
+		maxAttempts = c.MaxAttempts
+	}
+
+	tlsConfig := &tls.Config{}
+	if c.CACertPath != "" {
+		pemBytes, err := ioutil.ReadFile(c.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", c.CACertPath, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no CA certificates found in %s", c.CACertPath)
+		}
+	}
+
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.config = &config{
+		urls:   c.URLs,
+		secret: []byte(c.Secret),
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+		maxAttempts: maxAttempts,
+		advise:      c.Advise,
+	}
+	return &spi.ConfigureResponse{}, nil
+}
+
+func (*webhookPlugin) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error) {
+	return &spi.GetPluginInfoResponse{}, nil
+}
+
+func (p *webhookPlugin) Notify(ctx context.Context, req *notifier.NotifyRequest) (*notifier.NotifyResponse, error) {
+	c, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.advise {
+		return &notifier.NotifyResponse{}, nil
+	}
+
+	// The event has taken effect already, so the server is not held up
+	// while it is delivered
+	event, body, err := p.newEvent(req.BundleUpdated, req.CaRotated)
+	if err != nil {
+		return nil, err
+	}
+	p.enqueue(&queuedEvent{config: c, event: event, body: body})
+	return &notifier.NotifyResponse{}, nil
+}
+
+func (p *webhookPlugin) NotifyAndAdvise(ctx context.Context, req *notifier.NotifyAndAdviseRequest) (*notifier.NotifyAndAdviseResponse, error) {
+	c, err := p.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	if !c.advise {
+		return &notifier.NotifyAndAdviseResponse{}, nil
+	}
+
+	event, body, err := p.newEvent(req.BundleUpdated, req.CaRotated)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.post(ctx, c, event, body); err != nil {
+		return nil, err
+	}
+	return &notifier.NotifyAndAdviseResponse{}, nil
+}
+
+func (p *webhookPlugin) getConfig() (*config, error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.config == nil {
+		return nil, errors.New("not configured")
+	}
+	return p.config, nil
+}
+
+// newEvent returns the event to post, along with the request body
+func (p *webhookPlugin) newEvent(bundleUpdated *notifier.BundleUpdated, caRotated *notifier.CARotated) (*Event, []byte, error) {
+	event := &Event{
+		Timestamp: p.now().Unix(),
+	}
+	var bundle *notifier.Bundle
+	switch {
+	case bundleUpdated != nil:
+		event.Type = bundleUpdatedEvent
+		bundle = bundleUpdated.Bundle
+	case caRotated != nil:
+		event.Type = caRotatedEvent
+		event.CACert = caRotated.CaCert
+		event.PrevCACert = caRotated.PrevCaCert
+		bundle = caRotated.Bundle
+	default:
+		return nil, nil, errors.New("unknown event")
+	}
+	if bundle == nil {
+		return nil, nil, fmt.Errorf("no bundle in %s event", event.Type)
+	}
+
+	event.TrustDomain = bundle.TrustDomain
+	b, err := bundleutil.FromDatastore(&datastore.Bundle{
+		TrustDomain:    bundle.TrustDomain,
+		CaCerts:        bundle.CaCerts,
+		JwtSigningKeys: bundle.JwtSigningKeys,
+		SequenceNumber: bundle.SequenceNumber,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	event.Bundle, err = bundleutil.Marshal(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	return event, body, nil
+}
+
+// enqueue queues the event for delivery, starting the goroutine delivering
+// the queued events unless it is running already
+func (p *webhookPlugin) enqueue(e *queuedEvent) {
+	p.queueMtx.Lock()
+	defer p.queueMtx.Unlock()
+	p.queue = append(p.queue, e)
+	if len(p.queue) == 1 {
+		p.delivering.Add(1)
+		go p.deliverQueue()
+	}
+}
+
+// deliverQueue posts the queued events in order, until the queue is empty.
+// Failures are logged, as the events cannot be called off anymore.
+func (p *webhookPlugin) deliverQueue() {
+	defer p.delivering.Done()
+	for {
+		// The event is only dequeued once delivered, for enqueue not to
+		// start another goroutine meanwhile
+		p.queueMtx.Lock()
+		e := p.queue[0]
+		p.queueMtx.Unlock()
+
+		if err := p.post(context.Background(), e.config, e.event, e.body); err != nil {
+			log.Printf("webhook: %v", err)
+		}
+
+		p.queueMtx.Lock()
+		p.queue = p.queue[1:]
+		empty := len(p.queue) == 0
+		p.queueMtx.Unlock()
+		if empty {
+			return
+		}
+	}
+}
+
+// post posts the event to every endpoint, even if posting it to one of them
+// fails
+func (p *webhookPlugin) post(ctx context.Context, c *config, event *Event, body []byte) error {
+	var failures []string
+	for _, u := range c.urls {
+		if err := p.postWithRetries(ctx, c, u, event.Type, body); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", u, err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to post %s event to %s", event.Type, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// postWithRetries posts the body to the endpoint until it is accepted, the
+// attempts run out, or the endpoint rejects it with a client error other
+// than 429, which a retry would not fix
+func (p *webhookPlugin) postWithRetries(ctx context.Context, c *config, u, eventType string, body []byte) error {
+	interval := p.retryInterval
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		retry, err = p.postOnce(ctx, c, u, eventType, body)
+		if err == nil || !retry || attempt >= c.maxAttempts {
+			return err
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		interval *= 2
+	}
+}
+
+func (p *webhookPlugin) postOnce(ctx context.Context, c *config, u, eventType string, body []byte) (retry bool, err error) {
+	req, err := http.NewRequest("POST", u, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(SignatureHeader, Sign(c.secret, body))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+}
+
+// Sign returns the value of the signature header for the request body, for
+// endpoints to check the request against
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,276 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	spi "github.com/spiffe/spire/proto/common/plugin"
+	"github.com/spiffe/spire/proto/server/notifier"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	ctx = context.Background()
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+
+	dir    string
+	server *httptest.Server
+	p      *webhookPlugin
+	bundle *notifier.Bundle
+
+	// If set, requests are not answered until it is closed
+	blocked chan struct{}
+
+	mtx      sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func TestWebhook(t *testing.T) {
+	suite.Run(t, new(WebhookTestSuite))
+}
+
+func (s *WebhookTestSuite) SetupTest() {
+	s.blocked = nil
+	s.statuses = nil
+	s.requests = nil
+	s.bodies = nil
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "webhook-test")
+	s.Require().NoError(err)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw})
+	s.Require().NoError(ioutil.WriteFile(filepath.Join(s.dir, "ca.pem"), caPEM, 0600))
+
+	s.p = newPlugin()
+	s.p.retryInterval = time.Millisecond
+	s.p.now = func() time.Time { return time.Unix(1000, 0) }
+
+	cert, _, err := util.LoadSVIDFixture()
+	s.Require().NoError(err)
+	s.bundle = &notifier.Bundle{
+		TrustDomain:    "spiffe://example.org",
+		CaCerts:        cert.Raw,
+		SequenceNumber: 3,
+	}
+}
+
+func (s *WebhookTestSuite) TearDownTest() {
+	s.server.Close()
+	os.RemoveAll(s.dir)
+}
+
+func (s *WebhookTestSuite) handle(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	if s.blocked != nil {
+		<-s.blocked
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.requests = append(s.requests, req)
+	s.bodies = append(s.bodies, body)
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (s *WebhookTestSuite) configure(extra string) error {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			urls = [%q]
+			secret = "s3cr3t"
+			ca_cert_path = %q
+			%s`, s.server.URL, filepath.Join(s.dir, "ca.pem"), extra),
+	})
+	return err
+}
+
+func (s *WebhookTestSuite) TestConfigure() {
+	_, err := s.p.Notify(ctx, &notifier.NotifyRequest{})
+	s.Require().EqualError(err, "not configured")
+
+	s.Require().NoError(s.configure(`timeout = "1s"`))
+
+	_, err = s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: `secret = "s3cr3t"`})
+	s.Require().EqualError(err, "at least one URL is required")
+
+	_, err = s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: `urls = ["http://example.org"]
+		secret = "s3cr3t"`})
+	s.Require().EqualError(err, `invalid URL "http://example.org": an https URL is required`)
+
+	_, err = s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: `urls = ["https://example.org"]`})
+	s.Require().EqualError(err, "secret is required")
+
+	s.Require().Error(s.configure(`timeout = "soon"`))
+	s.Require().Error(s.configure(`max_attempts = -1`))
+}
+
+func (s *WebhookTestSuite) TestConfigureDryRun() {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			urls = [%q]
+			secret = "s3cr3t"`, s.server.URL),
+		DryRun: true,
+	})
+	s.Require().NoError(err)
+
+	// The configuration is validated, but not applied
+	_, err = s.p.Notify(ctx, &notifier.NotifyRequest{})
+	s.Require().EqualError(err, "not configured")
+
+	_, err = s.p.Configure(ctx, &spi.ConfigureRequest{Configuration: `secret = "s3cr3t"`, DryRun: true})
+	s.Require().EqualError(err, "at least one URL is required")
+}
+
+func (s *WebhookTestSuite) TestNotifyBundleUpdated() {
+	s.Require().NoError(s.configure(""))
+
+	_, err := s.p.Notify(ctx, &notifier.NotifyRequest{
+		BundleUpdated: &notifier.BundleUpdated{Bundle: s.bundle},
+	})
+	s.Require().NoError(err)
+	s.p.delivering.Wait()
+	s.Require().Len(s.requests, 1)
+
+	req := s.requests[0]
+	s.Require().Equal("POST", req.Method)
+	s.Require().Equal("application/json", req.Header.Get("Content-Type"))
+	s.Require().Equal("bundle_updated", req.Header.Get(EventHeader))
+	s.Require().Equal(Sign([]byte("s3cr3t"), s.bodies[0]), req.Header.Get(SignatureHeader))
+
+	event := new(Event)
+	s.Require().NoError(json.Unmarshal(s.bodies[0], event))
+	s.Require().Equal("bundle_updated", event.Type)
+	s.Require().Equal(int64(1000), event.Timestamp)
+	s.Require().Equal("spiffe://example.org", event.TrustDomain)
+	s.Require().Nil(event.CACert)
+
+	bundle, err := bundleutil.Unmarshal(event.Bundle)
+	s.Require().NoError(err)
+	s.Require().Len(bundle.RootCAs, 1)
+	s.Require().Equal(s.bundle.CaCerts, bundle.RootCAs[0].Raw)
+	s.Require().Equal(uint64(3), bundle.Sequence)
+
+	// The event is left to Notify unless advise is set
+	_, err = s.p.NotifyAndAdvise(ctx, &notifier.NotifyAndAdviseRequest{
+		BundleUpdated: &notifier.BundleUpdated{Bundle: s.bundle},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(s.requests, 1)
+}
+
+func (s *WebhookTestSuite) TestNotifyDeliversAsynchronously() {
+	s.Require().NoError(s.configure(""))
+	s.blocked = make(chan struct{})
+
+	// Notify returns before the events are delivered, and failures are not
+	// reported back
+	s.statuses = []int{http.StatusUnauthorized}
+	for _, sequence := range []uint64{4, 5} {
+		s.bundle.SequenceNumber = sequence
+		_, err := s.p.Notify(ctx, &notifier.NotifyRequest{
+			BundleUpdated: &notifier.BundleUpdated{Bundle: s.bundle},
+		})
+		s.Require().NoError(err)
+	}
+	close(s.blocked)
+	s.p.delivering.Wait()
+
+	// The events are delivered in order
+	s.Require().Len(s.bodies, 2)
+	for i, sequence := range []uint64{4, 5} {
+		event := new(Event)
+		s.Require().NoError(json.Unmarshal(s.bodies[i], event))
+		bundle, err := bundleutil.Unmarshal(event.Bundle)
+		s.Require().NoError(err)
+		s.Require().Equal(sequence, bundle.Sequence)
+	}
+}
+
+func (s *WebhookTestSuite) TestNotifyAndAdviseCARotated() {
+	s.Require().NoError(s.configure("advise = true"))
+
+	caRotated := &notifier.CARotated{
+		CaCert:     []byte("new"),
+		PrevCaCert: []byte("old"),
+		Bundle:     s.bundle,
+	}
+	_, err := s.p.NotifyAndAdvise(ctx, &notifier.NotifyAndAdviseRequest{CaRotated: caRotated})
+	s.Require().NoError(err)
+	s.Require().Len(s.requests, 1)
+	s.Require().Equal("ca_rotated", s.requests[0].Header.Get(EventHeader))
+
+	event := new(Event)
+	s.Require().NoError(json.Unmarshal(s.bodies[0], event))
+	s.Require().Equal("ca_rotated", event.Type)
+	s.Require().Equal([]byte("new"), event.CACert)
+	s.Require().Equal([]byte("old"), event.PrevCACert)
+
+	// The event was posted already
+	_, err = s.p.Notify(ctx, &notifier.NotifyRequest{CaRotated: caRotated})
+	s.Require().NoError(err)
+	s.Require().Len(s.requests, 1)
+}
+
+func (s *WebhookTestSuite) TestRetries() {
+	s.Require().NoError(s.configure("advise = true"))
+	req := &notifier.NotifyAndAdviseRequest{BundleUpdated: &notifier.BundleUpdated{Bundle: s.bundle}}
+
+	// Server errors are retried
+	s.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+	_, err := s.p.NotifyAndAdvise(ctx, req)
+	s.Require().NoError(err)
+	s.Require().Len(s.requests, 3)
+
+	// Until the attempts run out
+	s.requests = nil
+	s.statuses = []int{500, 500, 500}
+	_, err = s.p.NotifyAndAdvise(ctx, req)
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "unexpected status 500 Internal Server Error")
+	s.Require().Len(s.requests, 3)
+
+	// Client errors are not retried
+	s.requests = nil
+	s.statuses = []int{http.StatusUnauthorized}
+	_, err = s.p.NotifyAndAdvise(ctx, req)
+	s.Require().Error(err)
+	s.Require().Len(s.requests, 1)
+}
+
+func (s *WebhookTestSuite) TestUntrustedEndpoint() {
+	_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			urls = [%q]
+			secret = "s3cr3t"
+			max_attempts = 1
+			advise = true`, s.server.URL),
+	})
+	s.Require().NoError(err)
+
+	_, err = s.p.NotifyAndAdvise(ctx, &notifier.NotifyAndAdviseRequest{
+		BundleUpdated: &notifier.BundleUpdated{Bundle: s.bundle},
+	})
+	s.Require().Error(err)
+	s.Require().Empty(s.requests)
+}
@@ -99,6 +99,10 @@ func (m *diskPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (
 		return nil, fmt.Errorf("invalid TTL value: %v", err)
 	}
 
+	if req.DryRun {
+		return &spi.ConfigureResponse{}, nil
+	}
+
 	// Set local vars from config struct
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
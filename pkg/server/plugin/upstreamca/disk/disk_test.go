@@ -36,6 +36,19 @@ func TestDisk_Configure(t *testing.T) {
 	require.Equal(t, &spi.ConfigureResponse{}, resp)
 }
 
+func TestDisk_ConfigureDryRun(t *testing.T) {
+	m := New()
+	_, err := m.Configure(ctx, &spi.ConfigureRequest{Configuration: config, DryRun: true})
+	require.NoError(t, err)
+
+	// The configuration is validated, but not applied
+	_, err = m.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{})
+	require.EqualError(t, err, "invalid state: not configured")
+
+	_, err = m.Configure(ctx, &spi.ConfigureRequest{Configuration: `{"trust_domain":"example.com"}`, DryRun: true})
+	require.Error(t, err)
+}
+
 func TestDisk_GetPluginInfo(t *testing.T) {
 	m, err := newWithDefault("_test_data/keys/private_key.pem", "_test_data/keys/cert.pem")
 	require.NoError(t, err)
@@ -10,26 +10,56 @@ import (
 	"runtime"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	common "github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/common/profiling"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/tracing"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/admin"
+	"github.com/spiffe/spire/pkg/server/bundle"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/csrpolicy"
 	"github.com/spiffe/spire/pkg/server/endpoints"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
+	bundlev1 "github.com/spiffe/spire/pkg/server/endpoints/v1/bundle"
+	"github.com/spiffe/spire/pkg/server/entrycache"
+	"github.com/spiffe/spire/pkg/server/health"
+	"github.com/spiffe/spire/pkg/server/ocsp"
+	"github.com/spiffe/spire/pkg/server/signpool"
 	"github.com/spiffe/spire/pkg/server/svid"
+	"github.com/spiffe/spire/pkg/server/updates"
+	common_pb "github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/common/metricsink"
+	"github.com/spiffe/spire/proto/server/datastore"
 	"google.golang.org/grpc"
 
 	_ "golang.org/x/net/trace"
 )
 
+const defaultExpiringSVIDThreshold = 10 * time.Minute
+
 type Config struct {
 	// Configurations for server plugins
 	PluginConfigs common.PluginConfigMap
 
+	// If set, external plugins are only run if their checksum is configured
+	RequirePluginChecksum bool
+
 	Log logrus.FieldLogger
 
+	// Levels of the logger, which can be changed at runtime through the
+	// admin API if set
+	LogLevels *log.Levels
+
+	// Location of the socket to serve the admin API on. The admin API is not
+	// served if empty.
+	AdminSocketPath string
+
 	// Address of SPIRE server
 	BindAddress *net.TCPAddr
 
@@ -42,9 +72,78 @@ type Config struct {
 	// Umask value to use
 	Umask int
 
-	// Include upstream CA certificates in the bundle
+	// If true, the CA is strictly an intermediate of the upstream CA: the
+	// trust bundle holds the upstream CA certificates instead of the CA
+	// certificate, which is sent along with the SVIDs so that they chain up
+	// to the bundle
 	UpstreamBundle bool
 
+	// Configuration of the health checks. If enabled, the liveness and
+	// readiness of the server are served over HTTP, and the gRPC health
+	// checking service is served on the server endpoints.
+	HealthCheck health.Config
+
+	// If true, serves the gRPC server reflection service on the server endpoints
+	ReflectionEnabled bool
+
+	// If true, the X509-SVIDs signed are recorded so that they can be
+	// revoked, and the CA keeps a CRL listing the revoked ones, which is
+	// served over HTTP and sent to agents
+	CRLEnabled bool
+
+	// Address to serve the OCSP responder on. It is not served if empty.
+	// Requires CRLEnabled, since the revocation status of the SVIDs is
+	// looked up in the same records.
+	OCSPBindAddress string
+
+	// Configuration of the bundle endpoint, serving the trust bundle to
+	// federated trust domains over HTTPS
+	BundleEndpoint bundle.Config
+
+	// Federated trust domains whose bundles are fetched from their bundle
+	// endpoints, keyed by SPIFFE ID
+	FederatedTrustDomains map[string]bundle.FederatedTrustDomain
+
+	// Path of the journal the CA manager records the prepared, active and
+	// old CAs in, so that they are known after a restart. No journal is
+	// kept if empty.
+	CAJournalPath string
+
+	// Issuer claim of the JWT-SVIDs signed, e.g. the URL of an OIDC
+	// discovery provider serving the JWT signing keys. Not set if empty.
+	JWTIssuer string
+
+	// Configuration of the registration entry cache, computing the entries
+	// agents are authorized for from memory instead of the datastore
+	EntryCache EntryCacheConfig
+
+	// Built-in checks applied to CSRs before they are signed
+	CSRPolicy csrpolicy.Config
+
+	// Bounds how many CSRs are signed at once, and how many can wait
+	SignPool signpool.Config
+
+	// Address to serve the metrics on for Prometheus to scrape, on the
+	// /metrics path. Metrics are not served if empty.
+	PrometheusBindAddress string
+
+	// Configuration of the StatsD server to send the metrics to. Metrics are
+	// not sent if it has no address.
+	Statsd telemetry.StatsdConfig
+
+	// Agent SVIDs expiring within this duration are reported as expiring
+	// soon. Defaults to 10 minutes.
+	ExpiringSVIDThreshold time.Duration
+
+	// If true, the metrics of the SVIDs issued are labeled by agent and
+	// entry ID. There is a label value per agent and entry, so they are
+	// off by default.
+	SVIDMetricLabels bool
+
+	// URL of the OTLP/HTTP traces endpoint of an OpenTelemetry collector to
+	// export the traces of the RPCs to. Calls are not traced if empty.
+	OTLPTracesEndpoint string
+
 	// If true enables profiling.
 	ProfilingEnabled bool
 
@@ -58,6 +157,19 @@ type Config struct {
 	ProfilingNames []string
 }
 
+// EntryCacheConfig configures the registration entry cache
+type EntryCacheConfig struct {
+	Enabled bool
+
+	// How often the entry event log of the datastore is polled for changes.
+	// Defaults to one second.
+	PollInterval time.Duration
+
+	// How often all the registration entries are loaded again. Defaults to
+	// ten minutes.
+	ResyncInterval time.Duration
+}
+
 type Server struct {
 	config Config
 }
@@ -87,7 +199,44 @@ func (s *Server) run(ctx context.Context) (err error) {
 		defer stopProfiling()
 	}
 
-	cat := s.newCatalog()
+	var prometheus *telemetry.PrometheusSink
+	if s.config.PrometheusBindAddress != "" {
+		prometheus = telemetry.NewPrometheusSink()
+	}
+
+	var statsd *telemetry.StatsdSink
+	if s.config.Statsd.Address != "" {
+		statsd, err = telemetry.NewStatsdSink(s.config.Statsd)
+		if err != nil {
+			return err
+		}
+		defer statsd.Close()
+	}
+
+	// The plugins are only known once the catalog has been started
+	pluginSink := telemetry.NewPluginSink(s.config.Log.WithField("subsystem_name", "telemetry"))
+
+	tel := telemetry.NewSink(&telemetry.SinkConfig{
+		Logger:      s.config.Log.WithField("subsystem_name", "telemetry").Writer(),
+		ServiceName: "spire_server",
+		StopChan:    ctx.Done(),
+		Prometheus:  prometheus,
+		Statsd:      statsd,
+		Plugins:     pluginSink,
+	})
+
+	var tracer tracing.Tracer = tracing.Noop{}
+	var otlpTracer *tracing.OTLPTracer
+	if s.config.OTLPTracesEndpoint != "" {
+		otlpTracer = tracing.NewOTLPTracer(tracing.OTLPConfig{
+			Endpoint:    s.config.OTLPTracesEndpoint,
+			ServiceName: "spire-server",
+			Log:         s.config.Log.WithField("subsystem_name", "tracing"),
+		})
+		tracer = otlpTracer
+	}
+
+	cat := s.newCatalog(tel, tracer)
 	defer cat.Stop()
 
 	if err := cat.Run(ctx); err != nil {
@@ -97,29 +246,182 @@ func (s *Server) run(ctx context.Context) (err error) {
 
 	// CA manager needs to be initialized before the rotator, otherwise the
 	// server CA plugin won't be able to sign CSRs
-	caManager, err := s.newCAManager(ctx, cat)
+	caManager, err := s.newCAManager(ctx, cat, tel)
 	if err != nil {
 		return err
 	}
 
+	if err := s.checkTTLs(ctx, cat, caManager); err != nil {
+		return err
+	}
+
 	svidRotator, err := s.newSVIDRotator(ctx, cat)
 	if err != nil {
 		return err
 	}
 
-	endpointsServer := s.newEndpointsServer(cat, svidRotator)
+	var entryCache *entrycache.Cache
+	if s.config.EntryCache.Enabled {
+		entryCache, err = s.newEntryCache(ctx, cat)
+		if err != nil {
+			return err
+		}
+	}
 
-	err = util.RunTasks(ctx,
+	// The update notifier needs the bundle, which the CA manager creates
+	updateNotifier, err := s.newUpdateNotifier(ctx, cat, entryCache)
+	if err != nil {
+		return err
+	}
+
+	var bundleClient *bundle.Client
+	if len(s.config.FederatedTrustDomains) > 0 {
+		bundleClient = s.newBundleClient(cat, tel)
+	}
+
+	endpointsServer := s.newEndpointsServer(cat, tel, tracer, svidRotator, caManager, bundleClient, entryCache, updateNotifier)
+
+	tasks := []func(context.Context) error{
 		caManager.Run,
 		svidRotator.Run,
+		updateNotifier.Run,
 		endpointsServer.ListenAndServe,
-	)
+		s.reportEntryCounts(cat, tel, time.Minute),
+		s.sendMetricsToPlugins(cat, pluginSink),
+	}
+	if s.config.HealthCheck.Enabled {
+		tasks = append(tasks, s.newHealthChecker(cat).ListenAndServe)
+	}
+	if s.config.OCSPBindAddress != "" {
+		tasks = append(tasks, s.newOCSPResponder(cat, caManager).ListenAndServe)
+	}
+	if s.config.BundleEndpoint.BindAddress != "" {
+		tasks = append(tasks, s.newBundleEndpoint(cat).ListenAndServe)
+	}
+	if bundleClient != nil {
+		tasks = append(tasks, bundleClient.Run)
+	}
+	if entryCache != nil {
+		tasks = append(tasks, entryCache.Run)
+	}
+	if prometheus != nil {
+		tasks = append(tasks, s.servePrometheus(prometheus))
+	}
+	if otlpTracer != nil {
+		tasks = append(tasks, otlpTracer.Run)
+	}
+	if s.config.AdminSocketPath != "" {
+		tasks = append(tasks, s.newAdminServer(cat).ListenAndServe)
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
 	return err
 }
 
+// sendMetricsToPlugins returns a task sending the metrics to the MetricSink
+// plugins until the context is cancelled
+func (s *Server) sendMetricsToPlugins(cat catalog.Catalog, sink *telemetry.PluginSink) func(context.Context) error {
+	var sinks []metricsink.MetricSink
+	for _, p := range cat.MetricSinks() {
+		sinks = append(sinks, p)
+	}
+	return func(ctx context.Context) error {
+		return sink.Run(ctx, sinks)
+	}
+}
+
+// servePrometheus returns a task serving the metrics for Prometheus to
+// scrape until the context is cancelled
+func (s *Server) servePrometheus(sink *telemetry.PrometheusSink) func(context.Context) error {
+	return func(ctx context.Context) error {
+		l, err := net.Listen("tcp", s.config.PrometheusBindAddress)
+		if err != nil {
+			return fmt.Errorf("create prometheus listener: %v", err)
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", sink)
+		server := &http.Server{Handler: mux}
+
+		s.config.Log.Infof("Serving Prometheus metrics on %s", l.Addr())
+		errChan := make(chan error)
+		go func() { errChan <- server.Serve(l) }()
+
+		select {
+		case err := <-errChan:
+			return err
+		case <-ctx.Done():
+			server.Close()
+			<-errChan
+			return nil
+		}
+	}
+}
+
+// reportEntryCounts returns a task reporting how many registration entries
+// and attested nodes are in the datastore, and how many of the agent SVIDs
+// are expiring soon or have expired, every interval, until the context is cancelled
+func (s *Server) reportEntryCounts(cat catalog.Catalog, tel telemetry.Sink, interval time.Duration) func(context.Context) error {
+	threshold := s.config.ExpiringSVIDThreshold
+	if threshold == 0 {
+		threshold = defaultExpiringSVIDThreshold
+	}
+
+	return func(ctx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := countEntries(ctx, cat.DataStores()[0], tel, threshold); err != nil {
+				s.config.Log.Warnf("Could not count the datastore entries: %v", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+func countEntries(ctx context.Context, ds datastore.DataStore, tel telemetry.Sink, expiringThreshold time.Duration) error {
+	entries, err := ds.FetchRegistrationEntries(ctx, &common_pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("fetch registration entries: %v", err)
+	}
+	tel.SetGauge([]string{"datastore", "registration_entries"}, float32(len(entries.GetRegisteredEntries().GetEntries())))
+
+	nodes, err := ds.ListAttestedNodeEntries(ctx, &datastore.ListAttestedNodeEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("list attested nodes: %v", err)
+	}
+	tel.SetGauge([]string{"datastore", "attested_nodes"}, float32(len(nodes.AttestedNodeEntryList)))
+
+	expiring := 0
+	expired := 0
+	now := time.Now()
+	expiringBefore := now.Add(expiringThreshold)
+	for _, node := range nodes.AttestedNodeEntryList {
+		notAfter, err := time.Parse(time.RFC1123Z, node.CertExpirationDate)
+		if err != nil {
+			continue
+		}
+		switch {
+		case notAfter.Before(now):
+			expired++
+		case notAfter.Before(expiringBefore):
+			expiring++
+		}
+	}
+	tel.SetGauge([]string{"datastore", "expiring_agent_svids"}, float32(expiring))
+	tel.SetGauge([]string{"datastore", "expired_agent_svids"}, float32(expired))
+	return nil
+}
+
 func (s *Server) setupProfiling(ctx context.Context) (stop func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	var wg sync.WaitGroup
@@ -179,19 +481,27 @@ func (s *Server) prepareUmask() {
 	syscall.Umask(s.config.Umask)
 }
 
-func (s *Server) newCatalog() *catalog.ServerCatalog {
+func (s *Server) newCatalog(tel telemetry.Sink, tracer tracing.Tracer) *catalog.ServerCatalog {
 	return catalog.New(&catalog.Config{
-		PluginConfigs: s.config.PluginConfigs,
-		Log:           s.config.Log.WithField("subsystem_name", "catalog"),
+		PluginConfigs:         s.config.PluginConfigs,
+		Log:                   s.config.Log.WithField("subsystem_name", "catalog"),
+		Tel:                   tel,
+		Tracer:                tracer,
+		RequirePluginChecksum: s.config.RequirePluginChecksum,
 	})
 }
 
-func (s *Server) newCAManager(ctx context.Context, catalog catalog.Catalog) (ca.Manager, error) {
+func (s *Server) newCAManager(ctx context.Context, catalog catalog.Catalog, tel telemetry.Sink) (ca.Manager, error) {
 	caManager := ca.New(&ca.Config{
 		Catalog:        catalog,
 		TrustDomain:    s.config.TrustDomain,
 		Log:            s.config.Log.WithField("subsystem_name", "ca_manager"),
 		UpstreamBundle: s.config.UpstreamBundle,
+		CRLEnabled:     s.config.CRLEnabled,
+		OCSPEnabled:    s.config.OCSPBindAddress != "",
+		JournalPath:    s.config.CAJournalPath,
+		JWTIssuer:      s.config.JWTIssuer,
+		Tel:            tel,
 	})
 	if err := caManager.Initialize(ctx); err != nil {
 		return nil, err
@@ -199,11 +509,33 @@ func (s *Server) newCAManager(ctx context.Context, catalog catalog.Catalog) (ca.
 	return caManager, nil
 }
 
+// checkTTLs checks the SVID TTLs configured against the lifetime of the CA,
+// according to the TTL policy
+func (s *Server) checkTTLs(ctx context.Context, cat catalog.Catalog, caManager ca.Manager) error {
+	entries, err := cat.DataStores()[0].FetchRegistrationEntries(ctx, &common_pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("fetch registration entries: %v", err)
+	}
+	policy := csrpolicy.New(s.csrPolicyConfig(caManager), cat)
+	return policy.CheckTTLs(entries.GetRegisteredEntries().GetEntries())
+}
+
+// csrPolicyConfig returns the CSR policy configuration, checking the TTLs
+// against the lifetime of the CA managed
+func (s *Server) csrPolicyConfig(caManager ca.Manager) csrpolicy.Config {
+	config := s.config.CSRPolicy
+	config.CA = caManager
+	config.Log = s.config.Log.WithField("subsystem_name", "csr_policy")
+	return config
+}
+
 func (s *Server) newSVIDRotator(ctx context.Context, catalog catalog.Catalog) (svid.Rotator, error) {
 	svidRotator := svid.NewRotator(&svid.RotatorConfig{
 		Catalog:     catalog,
 		Log:         s.config.Log.WithField("subsystem_name", "svid_rotator"),
 		TrustDomain: s.config.TrustDomain,
+
+		UpstreamBundle: s.config.UpstreamBundle,
 	})
 	if err := svidRotator.Initialize(ctx); err != nil {
 		return nil, err
@@ -211,13 +543,115 @@ func (s *Server) newSVIDRotator(ctx context.Context, catalog catalog.Catalog) (s
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidRotator svid.Rotator) endpoints.Server {
-	return endpoints.New(&endpoints.Config{
-		GRPCAddr:    s.config.BindAddress,
-		HTTPAddr:    s.config.BindHTTPAddress,
-		SVIDStream:  svidRotator.Subscribe(),
-		TrustDomain: s.config.TrustDomain,
+func (s *Server) newEntryCache(ctx context.Context, cat catalog.Catalog) (*entrycache.Cache, error) {
+	entryCache := &entrycache.Cache{
+		Catalog:        cat,
+		Log:            s.config.Log.WithField("subsystem_name", "entry_cache"),
+		PollInterval:   s.config.EntryCache.PollInterval,
+		ResyncInterval: s.config.EntryCache.ResyncInterval,
+	}
+	if err := entryCache.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	return entryCache, nil
+}
+
+func (s *Server) newUpdateNotifier(ctx context.Context, catalog catalog.Catalog, entryCache *entrycache.Cache) (*updates.Notifier, error) {
+	var cache updates.EntryCache
+	if entryCache != nil {
+		cache = entryCache
+	}
+	updateNotifier := updates.New(&updates.Config{
 		Catalog:     catalog,
-		Log:         s.config.Log.WithField("subsystem_name", "endpoints"),
+		Log:         s.config.Log.WithField("subsystem_name", "update_notifier"),
+		TrustDomain: s.config.TrustDomain,
+		EntryCache:  cache,
+	})
+	if err := updateNotifier.Initialize(ctx); err != nil {
+		return nil, err
+	}
+	return updateNotifier, nil
+}
+
+func (s *Server) newHealthChecker(cat catalog.Catalog) *health.Checker {
+	return &health.Checker{
+		Config:      s.config.HealthCheck,
+		Catalog:     cat,
+		TrustDomain: s.config.TrustDomain,
+		Log:         s.config.Log.WithField("subsystem_name", "health"),
+	}
+}
+
+func (s *Server) newBundleEndpoint(cat catalog.Catalog) *bundle.Endpoint {
+	return &bundle.Endpoint{
+		Config:      s.config.BundleEndpoint,
+		Catalog:     cat,
+		TrustDomain: s.config.TrustDomain,
+		Log:         s.config.Log.WithField("subsystem_name", "bundle_endpoint"),
+	}
+}
+
+func (s *Server) newBundleClient(cat catalog.Catalog, tel telemetry.Sink) *bundle.Client {
+	return &bundle.Client{
+		TrustDomains: s.config.FederatedTrustDomains,
+		Catalog:      cat,
+		Log:          s.config.Log.WithField("subsystem_name", "bundle_client"),
+		Tel:          tel,
+	}
+}
+
+func (s *Server) newOCSPResponder(cat catalog.Catalog, caManager ca.Manager) *ocsp.Responder {
+	return &ocsp.Responder{
+		BindAddress: s.config.OCSPBindAddress,
+		Catalog:     cat,
+		Responders:  caManager,
+		Log:         s.config.Log.WithField("subsystem_name", "ocsp"),
+	}
+}
+
+func (s *Server) newAdminServer(cat catalog.Catalog) *admin.Server {
+	return &admin.Server{
+		SocketPath: s.config.AdminSocketPath,
+		LogLevels:  s.config.LogLevels,
+		Health:     s.newHealthChecker(cat),
+		Log:        s.config.Log.WithField("subsystem_name", "admin"),
+	}
+}
+
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, tel telemetry.Sink, tracer tracing.Tracer, svidRotator svid.Rotator, caManager ca.Manager, bundleClient *bundle.Client, entryCache *entrycache.Cache, updateNotifier *updates.Notifier) endpoints.Server {
+	var crlSource node.CRLSource
+	if s.config.CRLEnabled {
+		crlSource = caManager
+	}
+	var entryFetcher node.EntryFetcher
+	if entryCache != nil {
+		entryFetcher = entryCache
+	}
+	var federationMonitor bundlev1.FederationMonitor
+	if bundleClient != nil {
+		federationMonitor = bundleClient
+	}
+
+	return endpoints.New(&endpoints.Config{
+		GRPCAddr:           s.config.BindAddress,
+		HTTPAddr:           s.config.BindHTTPAddress,
+		HealthCheckEnabled: s.config.HealthCheck.Enabled,
+		ReflectionEnabled:  s.config.ReflectionEnabled,
+		CSRPolicy:          s.csrPolicyConfig(caManager),
+		SignPool:           s.config.SignPool,
+		SVIDMetricLabels:   s.config.SVIDMetricLabels,
+		UpstreamBundle:     s.config.UpstreamBundle,
+		SVIDStream:         svidRotator.Subscribe(),
+		UpdateNotifier:     updateNotifier,
+		TrustDomain:        s.config.TrustDomain,
+		Catalog:            catalog,
+		JWTSigner:          caManager,
+		CRLSource:          crlSource,
+		EntryFetcher:       entryFetcher,
+		CARotator:          caManager,
+		FederationMonitor:  federationMonitor,
+		Log:                s.config.Log.WithField("subsystem_name", "endpoints"),
+		Tel:                tel,
+		Tracer:             tracer,
 	})
 }
@@ -1,16 +1,24 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/spiffe/spire/pkg/common/log"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/mock/proto/server/ca"
 	"github.com/spiffe/spire/test/mock/proto/server/upstreamca"
 	"github.com/spiffe/spire/test/mock/server/catalog"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -65,3 +73,39 @@ func (suite *ServerTestSuite) TestUmask() {
 	suite.Nil(err)
 	suite.Equal(os.FileMode(0000), fi.Mode().Perm())
 }
+
+func TestCountEntries(t *testing.T) {
+	ds := fakedatastore.New()
+	for i, notAfter := range []time.Time{
+		time.Now().Add(-time.Minute),
+		time.Now().Add(time.Minute),
+		time.Now().Add(time.Hour),
+	} {
+		_, err := ds.CreateAttestedNodeEntry(context.Background(), &datastore.CreateAttestedNodeEntryRequest{
+			AttestedNodeEntry: &datastore.AttestedNodeEntry{
+				BaseSpiffeId:       fmt.Sprintf("spiffe://example.org/spire/agent/%d", i),
+				CertExpirationDate: notAfter.Format(time.RFC1123Z),
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	p := telemetry.NewPrometheusSink()
+	tel := telemetry.NewSink(&telemetry.SinkConfig{
+		Logger:      ioutil.Discard,
+		ServiceName: "spire_server",
+		Prometheus:  p,
+		StopChan:    stop,
+	})
+
+	require.NoError(t, countEntries(context.Background(), ds, tel, 10*time.Minute))
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+	require.Contains(t, body, "spire_server_datastore_attested_nodes 3")
+	require.Contains(t, body, "spire_server_datastore_expiring_agent_svids 1")
+	require.Contains(t, body, "spire_server_datastore_expired_agent_svids 1")
+}
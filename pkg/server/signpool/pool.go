@@ -0,0 +1,109 @@
+package signpool
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// queuedPerSlot is how many CSRs may wait for each slot of the pool when
+// MaxQueued is not set
+const queuedPerSlot = 64
+
+// ErrQueueFull is returned when a CSR is rejected because too many CSRs are
+// already waiting to be signed
+var ErrQueueFull = errors.New("too many CSRs waiting to be signed")
+
+// Config holds the configuration of the signing pool.
+type Config struct {
+	// Concurrency is the maximum number of CSRs the server CA is asked to
+	// sign at once, e.g. the number of concurrent operations supported by
+	// the HSM holding the CA key. Defaults to the number of CPUs.
+	Concurrency int
+
+	// MaxQueued is the maximum number of CSRs waiting to be signed. Further
+	// CSRs are rejected with ErrQueueFull, for agents to retry on their next
+	// sync rather than pile up. Defaults to 64 times the concurrency.
+	MaxQueued int
+}
+
+// Pool bounds how many CSRs the server CA signs at once. It is shared by all
+// the API handlers signing CSRs, which submit the CSRs of a batch through at
+// most Concurrency workers and wait for their turn, so that a mass rotation
+// is signed as fast as the CA allows without overloading it or the
+// datastore.
+type Pool struct {
+	c     Config
+	tel   telemetry.Sink
+	slots chan struct{}
+
+	queued   int64
+	inFlight int64
+}
+
+// New returns a signing pool with the given configuration, reporting its
+// queue depth to the given sink
+func New(c Config, tel telemetry.Sink) *Pool {
+	if c.Concurrency <= 0 {
+		c.Concurrency = runtime.NumCPU()
+	}
+	if c.MaxQueued <= 0 {
+		c.MaxQueued = queuedPerSlot * c.Concurrency
+	}
+	if tel == nil {
+		tel = telemetry.Blackhole{}
+	}
+	return &Pool{
+		c:     c,
+		tel:   tel,
+		slots: make(chan struct{}, c.Concurrency),
+	}
+}
+
+// Concurrency returns the maximum number of CSRs signed at once, which is
+// also the most workers a batch of CSRs is worth submitting them through
+func (p *Pool) Concurrency() int {
+	return p.c.Concurrency
+}
+
+// Sign waits for the CA to be available then calls sign. It returns
+// ErrQueueFull if too many CSRs are waiting already, or the error of the
+// context if it is done while waiting.
+func (p *Pool) Sign(ctx context.Context, sign func(context.Context) error) error {
+	queued := atomic.AddInt64(&p.queued, 1)
+	if queued > int64(p.c.MaxQueued) {
+		p.dequeue()
+		p.tel.IncrCounter([]string{"sign_pool", "rejected"}, 1)
+		return ErrQueueFull
+	}
+	p.tel.SetGauge([]string{"sign_pool", "queue_depth"}, float32(queued))
+
+	start := time.Now()
+	select {
+	case p.slots <- struct{}{}:
+		p.dequeue()
+	case <-ctx.Done():
+		p.dequeue()
+		return ctx.Err()
+	}
+	p.tel.MeasureSince([]string{"sign_pool", "wait_latency"}, start)
+
+	inFlight := atomic.AddInt64(&p.inFlight, 1)
+	p.tel.SetGauge([]string{"sign_pool", "in_flight"}, float32(inFlight))
+	defer func() {
+		<-p.slots
+		inFlight := atomic.AddInt64(&p.inFlight, -1)
+		p.tel.SetGauge([]string{"sign_pool", "in_flight"}, float32(inFlight))
+	}()
+
+	return sign(ctx)
+}
+
+func (p *Pool) dequeue() {
+	queued := atomic.AddInt64(&p.queued, -1)
+	p.tel.SetGauge([]string{"sign_pool", "queue_depth"}, float32(queued))
+}
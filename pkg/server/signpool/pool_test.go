@@ -0,0 +1,115 @@
+package signpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignBoundsConcurrency(t *testing.T) {
+	p := New(Config{Concurrency: 2}, nil)
+
+	var inFlight, maxInFlight int64
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.Sign(context.Background(), func(context.Context) error {
+				n := atomic.AddInt64(&inFlight, 1)
+				for {
+					max := atomic.LoadInt64(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt64(&inFlight, -1)
+				return nil
+			})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int64(2), maxInFlight)
+}
+
+func TestSignReturnsSignError(t *testing.T) {
+	p := New(Config{}, nil)
+
+	err := p.Sign(context.Background(), func(context.Context) error {
+		return errors.New("oh no")
+	})
+	require.EqualError(t, err, "oh no")
+
+	// The slot is released
+	require.NoError(t, p.Sign(context.Background(), func(context.Context) error { return nil }))
+}
+
+func TestSignRejectsWhenQueueFull(t *testing.T) {
+	p := New(Config{Concurrency: 1, MaxQueued: 1}, nil)
+
+	signing := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 2)
+	go func() {
+		done <- p.Sign(context.Background(), func(context.Context) error {
+			close(signing)
+			<-release
+			return nil
+		})
+	}()
+	<-signing
+
+	// One CSR may wait for the slot, the next one is rejected
+	go func() {
+		done <- p.Sign(context.Background(), func(context.Context) error { return nil })
+	}()
+	for atomic.LoadInt64(&p.queued) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	require.Equal(t, ErrQueueFull, p.Sign(context.Background(), func(context.Context) error { return nil }))
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+	require.Equal(t, int64(0), atomic.LoadInt64(&p.queued))
+}
+
+func TestSignStopsWaitingWhenContextDone(t *testing.T) {
+	p := New(Config{Concurrency: 1}, nil)
+
+	signing := make(chan struct{})
+	release := make(chan struct{})
+	go p.Sign(context.Background(), func(context.Context) error {
+		close(signing)
+		<-release
+		return nil
+	})
+	<-signing
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := p.Sign(ctx, func(context.Context) error {
+		t.Fatal("should not be signed")
+		return nil
+	})
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Equal(t, int64(0), atomic.LoadInt64(&p.queued))
+}
+
+func TestNewDefaults(t *testing.T) {
+	p := New(Config{Concurrency: 3}, nil)
+	require.Equal(t, 3, p.Concurrency())
+	require.Equal(t, 3*queuedPerSlot, p.c.MaxQueued)
+
+	p = New(Config{Concurrency: 3, MaxQueued: 5}, nil)
+	require.Equal(t, 5, p.c.MaxQueued)
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
+	"fmt"
 	"net/url"
 	"path"
 	"time"
@@ -33,6 +34,10 @@ type rotator struct {
 type State struct {
 	SVID *x509.Certificate
 	Key  *ecdsa.PrivateKey
+
+	// CA certificate which signed the SVID, when it is not in the trust
+	// bundle. See RotatorConfig.UpstreamBundle.
+	CA *x509.Certificate
 }
 
 // Start generates a new SVID and then starts the rotator.
@@ -120,6 +125,21 @@ func (r *rotator) rotateSVID(ctx context.Context) error {
 		Key:  key,
 	}
 
+	if r.c.UpstreamBundle {
+		caRes, err := ca.FetchCertificate(ctx, &ca_pb.FetchCertificateRequest{})
+		if err != nil {
+			return err
+		}
+		s.CA, err = x509.ParseCertificate(caRes.StoredIntermediateCert)
+		if err != nil {
+			return err
+		}
+		// The CA may have been rotated since the SVID was signed
+		if err := cert.CheckSignatureFrom(s.CA); err != nil {
+			return fmt.Errorf("ca certificate changed while signing the server SVID: %v", err)
+		}
+	}
+
 	r.state.Update(s)
 	return nil
 }
@@ -16,6 +16,10 @@ type RotatorConfig struct {
 
 	// How long to wait between expiry checks
 	Interval time.Duration
+
+	// If true, the CA is an intermediate of the upstream CA and is not in
+	// the trust bundle, so its certificate is kept along with the SVID
+	UpstreamBundle bool
 }
 
 func NewRotator(c *RotatorConfig) *rotator {
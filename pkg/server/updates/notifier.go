@@ -0,0 +1,271 @@
+package updates
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/server/datastore"
+)
+
+const (
+	defaultPollInterval   = time.Second
+	defaultEventRetention = time.Hour
+
+	// How long an event ID skipped in the event log is looked for. Event
+	// IDs are allocated when the change is written, so a change committed
+	// after a later one shows up behind it in the log, while the ID of a
+	// change rolled back never shows up.
+	missedEventTimeout = time.Minute
+)
+
+type Config struct {
+	Catalog     catalog.Catalog
+	Log         logrus.FieldLogger
+	TrustDomain url.URL
+
+	// How often the entry event log and the bundle are polled. Changes take
+	// up to that long to be notified. Defaults to one second.
+	PollInterval time.Duration
+
+	// How long the entry events are kept in the datastore for. Defaults to
+	// one hour.
+	EventRetention time.Duration
+
+	// If set, the entry cache is refreshed before the subscribers are
+	// notified, so that the changes are seen when they fetch the entries.
+	EntryCache EntryCache
+}
+
+// EntryCache is the registration entry cache the agents are served from. It
+// is implemented by the entry cache of the server.
+type EntryCache interface {
+	Refresh(ctx context.Context) error
+}
+
+// Notifier notifies its subscribers of the changes of the registration
+// entries, of the selectors of the nodes and of the bundle, so that they can
+// be pushed to the agents. Changes are seen by polling the entry event log
+// and the bundle, which also lets servers sharing a datastore see the
+// changes made through each other.
+type Notifier struct {
+	c *Config
+
+	mtx sync.Mutex
+
+	// ID of the latest event seen, and the IDs skipped before it that may
+	// still show up, with when they were skipped
+	lastEventID  uint64
+	missedEvents map[uint64]time.Time
+
+	// Bundle as of the latest poll
+	bundle *datastore.Bundle
+
+	subscribers map[chan struct{}]struct{}
+}
+
+func New(c *Config) *Notifier {
+	if c.PollInterval == 0 {
+		c.PollInterval = defaultPollInterval
+	}
+	if c.EventRetention == 0 {
+		c.EventRetention = defaultEventRetention
+	}
+
+	return &Notifier{
+		c:            c,
+		missedEvents: make(map[uint64]time.Time),
+		subscribers:  make(map[chan struct{}]struct{}),
+	}
+}
+
+// Initialize records the latest entry event and the bundle, so that only
+// the changes made afterwards are notified
+func (n *Notifier) Initialize(ctx context.Context) error {
+	ds := n.c.Catalog.DataStores()[0]
+
+	resp, err := ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{})
+	if err != nil {
+		return fmt.Errorf("list entry events: %v", err)
+	}
+	bundle, err := n.fetchBundle(ctx, ds)
+	if err != nil {
+		return err
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	if events := resp.Events; len(events) > 0 {
+		n.lastEventID = events[0].EventId
+		for _, event := range events[1:] {
+			n.trackEvent(event.EventId)
+		}
+	}
+	n.bundle = bundle
+	return nil
+}
+
+// Run polls the entry event log and the bundle, notifying the subscribers
+// of the changes, and prunes the old entry events, until the context is
+// cancelled
+func (n *Notifier) Run(ctx context.Context) error {
+	pollTicker := time.NewTicker(n.c.PollInterval)
+	defer pollTicker.Stop()
+	pruneTicker := time.NewTicker(n.c.EventRetention / 4)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-pollTicker.C:
+			changed, err := n.poll(ctx)
+			if err != nil {
+				n.c.Log.Warnf("Could not poll for updates: %v", err)
+			}
+			if changed {
+				n.refreshEntryCache(ctx)
+				n.notifySubscribers()
+			}
+		case <-pruneTicker.C:
+			if err := n.prune(ctx); err != nil {
+				n.c.Log.Warnf("Could not prune the entry events: %v", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// SubscribeToUpdates returns a channel receiving a value every time the
+// registration entries, the selectors of a node or the bundle change, and a
+// function to call once done with it. Changes made before the value is
+// received are notified once.
+func (n *Notifier) SubscribeToUpdates() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		n.mtx.Lock()
+		defer n.mtx.Unlock()
+		delete(n.subscribers, ch)
+	}
+}
+
+// poll returns true if entry events were recorded since the latest poll, or
+// if the bundle changed
+func (n *Notifier) poll(ctx context.Context) (bool, error) {
+	ds := n.c.Catalog.DataStores()[0]
+
+	n.mtx.Lock()
+	afterEventID := n.lastEventID
+	for eventID := range n.missedEvents {
+		if eventID <= afterEventID {
+			afterEventID = eventID - 1
+		}
+	}
+	n.mtx.Unlock()
+
+	resp, err := ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{
+		AfterEventId: afterEventID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("list entry events: %v", err)
+	}
+	bundle, err := n.fetchBundle(ctx, ds)
+	if err != nil {
+		return false, err
+	}
+
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	changed := false
+	for _, event := range resp.Events {
+		if n.trackEvent(event.EventId) {
+			changed = true
+		}
+	}
+	if !proto.Equal(bundle, n.bundle) {
+		n.bundle = bundle
+		changed = true
+	}
+
+	now := time.Now()
+	for eventID, skippedAt := range n.missedEvents {
+		if now.Sub(skippedAt) > missedEventTimeout {
+			delete(n.missedEvents, eventID)
+		}
+	}
+	return changed, nil
+}
+
+// trackEvent records that the given event was seen, tracking the event IDs
+// it skips, and returns true if it was not seen before. The caller must hold
+// the lock.
+func (n *Notifier) trackEvent(eventID uint64) bool {
+	if eventID <= n.lastEventID {
+		if _, missed := n.missedEvents[eventID]; !missed {
+			return false
+		}
+		delete(n.missedEvents, eventID)
+		return true
+	}
+
+	now := time.Now()
+	for skipped := n.lastEventID + 1; skipped < eventID; skipped++ {
+		n.missedEvents[skipped] = now
+	}
+	n.lastEventID = eventID
+	return true
+}
+
+// fetchBundle returns the bundle of the trust domain, which the CA manager
+// creates before the notifier is initialized
+func (n *Notifier) fetchBundle(ctx context.Context, ds datastore.DataStore) (*datastore.Bundle, error) {
+	bundle, err := ds.FetchBundle(ctx, &datastore.Bundle{
+		TrustDomain: n.c.TrustDomain.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch bundle: %v", err)
+	}
+	return bundle, nil
+}
+
+// refreshEntryCache applies the changes to the entry cache, if any. Errors
+// are only logged, the cache catching up on its next refresh.
+func (n *Notifier) refreshEntryCache(ctx context.Context) {
+	if n.c.EntryCache == nil {
+		return
+	}
+	if err := n.c.EntryCache.Refresh(ctx); err != nil {
+		n.c.Log.Warnf("Could not refresh the entry cache: %v", err)
+	}
+}
+
+// notifySubscribers lets the subscribers know that something changed,
+// without waiting for those that were not done with the previous change
+func (n *Notifier) notifySubscribers() {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// prune deletes the entry events older than the retention period
+func (n *Notifier) prune(ctx context.Context) error {
+	_, err := n.c.Catalog.DataStores()[0].PruneEntryEvents(ctx, &datastore.EntryEvent{
+		CreatedAt: time.Now().Add(-n.c.EventRetention).Unix(),
+	})
+	return err
+}
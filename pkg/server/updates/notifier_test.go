@@ -0,0 +1,181 @@
+package updates
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/datastore"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	ctx = context.Background()
+)
+
+type NotifierTestSuite struct {
+	suite.Suite
+
+	ds *fakedatastore.FakeDataStore
+	n  *Notifier
+}
+
+func TestNotifier(t *testing.T) {
+	suite.Run(t, new(NotifierTestSuite))
+}
+
+func (s *NotifierTestSuite) SetupTest() {
+	s.ds = fakedatastore.New()
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStores(s.ds)
+
+	_, err := s.ds.CreateBundle(ctx, &datastore.Bundle{
+		TrustDomain: "spiffe://example.org",
+		CaCerts:     []byte{1},
+	})
+	s.Require().NoError(err)
+
+	log, _ := test.NewNullLogger()
+	s.n = New(&Config{
+		Catalog:     catalog,
+		Log:         log,
+		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
+	})
+}
+
+func (s *NotifierTestSuite) TestPollSeesEntryChanges() {
+	entryID := s.createEntry()
+	s.Require().NoError(s.n.Initialize(ctx))
+
+	// Changes made before initializing are not notified
+	s.assertPoll(false)
+
+	_, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		RegisteredEntryId: entryID,
+	})
+	s.Require().NoError(err)
+	s.assertPoll(true)
+	s.assertPoll(false)
+
+	_, err = s.ds.CreateNodeResolverMapEntry(ctx, &datastore.CreateNodeResolverMapEntryRequest{
+		NodeResolverMapEntry: &datastore.NodeResolverMapEntry{
+			BaseSpiffeId: "spiffe://example.org/agent",
+			Selector:     &common.Selector{Type: "a", Value: "1"},
+		},
+	})
+	s.Require().NoError(err)
+	s.assertPoll(true)
+}
+
+func (s *NotifierTestSuite) TestPollSeesBundleChanges() {
+	s.Require().NoError(s.n.Initialize(ctx))
+	s.assertPoll(false)
+
+	_, err := s.ds.AppendBundle(ctx, &datastore.Bundle{
+		TrustDomain: "spiffe://example.org",
+		CaCerts:     []byte{2},
+	})
+	s.Require().NoError(err)
+	s.assertPoll(true)
+	s.assertPoll(false)
+}
+
+func (s *NotifierTestSuite) TestTrackEventSeesSkippedEvents() {
+	s.n.lastEventID = 1
+	s.True(s.n.trackEvent(4))
+	s.Len(s.n.missedEvents, 2)
+
+	// A skipped event showing up later is a change, unlike those seen
+	s.False(s.n.trackEvent(4))
+	s.False(s.n.trackEvent(1))
+	s.True(s.n.trackEvent(3))
+	s.False(s.n.trackEvent(3))
+	s.Len(s.n.missedEvents, 1)
+}
+
+func (s *NotifierTestSuite) TestSubscribersAreNotifiedOnce() {
+	updates, unsubscribe := s.n.SubscribeToUpdates()
+
+	// Pending notifications are not queued up
+	s.n.notifySubscribers()
+	s.n.notifySubscribers()
+	s.assertNotified(updates, true)
+	s.assertNotified(updates, false)
+
+	unsubscribe()
+	s.n.notifySubscribers()
+	s.assertNotified(updates, false)
+}
+
+func (s *NotifierTestSuite) TestEntryCacheIsRefreshedBeforeNotifying() {
+	cache := new(fakeEntryCache)
+	s.n.c.EntryCache = cache
+	s.n.c.PollInterval = time.Millisecond
+	s.Require().NoError(s.n.Initialize(ctx))
+
+	updates, unsubscribe := s.n.SubscribeToUpdates()
+	defer unsubscribe()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.n.Run(runCtx)
+
+	s.createEntry()
+	select {
+	case <-updates:
+		s.Equal(int32(1), atomic.LoadInt32(&cache.refreshes))
+	case <-time.After(time.Minute):
+		s.Fail("no notification")
+	}
+}
+
+func (s *NotifierTestSuite) TestPruneDeletesOldEvents() {
+	s.createEntry()
+	s.n.c.EventRetention = -time.Minute
+	s.Require().NoError(s.n.prune(ctx))
+
+	resp, err := s.ds.ListEntryEvents(ctx, &datastore.ListEntryEventsRequest{})
+	s.Require().NoError(err)
+	s.Empty(resp.Events)
+}
+
+func (s *NotifierTestSuite) createEntry() string {
+	resp, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		RegisteredEntry: &common.RegistrationEntry{
+			ParentId: "spiffe://example.org/agent",
+			SpiffeId: "spiffe://example.org/workload",
+		},
+	})
+	s.Require().NoError(err)
+	return resp.RegisteredEntryId
+}
+
+func (s *NotifierTestSuite) assertPoll(changed bool) {
+	actual, err := s.n.poll(ctx)
+	s.Require().NoError(err)
+	s.Equal(changed, actual)
+}
+
+func (s *NotifierTestSuite) assertNotified(updates <-chan struct{}, notified bool) {
+	select {
+	case <-updates:
+		s.True(notified, "unexpected notification")
+	default:
+		s.False(notified, "expected a notification")
+	}
+}
+
+type fakeEntryCache struct {
+	refreshes int32
+}
+
+func (c *fakeEntryCache) Refresh(ctx context.Context) error {
+	atomic.AddInt32(&c.refreshes, 1)
+	return nil
+}
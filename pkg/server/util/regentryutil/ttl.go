@@ -0,0 +1,45 @@
+package regentryutil
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/ca"
+)
+
+// ValidateTTLs makes sure the X509-SVID and JWT-SVID TTLs of the entry do not
+// exceed the lifetime of the server CA, since the CA cannot sign SVIDs that
+// outlive its own certificate. The check is skipped if the CA has not loaded
+// a certificate yet.
+func ValidateTTLs(ctx context.Context, serverCA ca.ServerCA, entry *common.RegistrationEntry) error {
+	if entry.Ttl < 0 {
+		return fmt.Errorf("ttl cannot be negative")
+	}
+	if entry.JwtSvidTtl < 0 {
+		return fmt.Errorf("jwt_svid_ttl cannot be negative")
+	}
+
+	resp, err := serverCA.FetchCertificate(ctx, &ca.FetchCertificateRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to fetch CA certificate: %v", err)
+	}
+	if len(resp.StoredIntermediateCert) == 0 {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(resp.StoredIntermediateCert)
+	if err != nil {
+		return fmt.Errorf("unable to parse CA certificate: %v", err)
+	}
+
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	if ttl := time.Duration(entry.Ttl) * time.Second; ttl > lifetime {
+		return fmt.Errorf("ttl of %v exceeds the CA lifetime of %v", ttl, lifetime)
+	}
+	if ttl := time.Duration(entry.JwtSvidTtl) * time.Second; ttl > lifetime {
+		return fmt.Errorf("jwt_svid_ttl of %v exceeds the CA lifetime of %v", ttl, lifetime)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package regentryutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/common"
+	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/test/mock/proto/server/ca"
+	"github.com/spiffe/spire/test/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTTLs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the CA template is valid for one hour
+	template, err := util.NewCATemplate("example.org")
+	require.NoError(t, err)
+	caCert, _, err := util.SelfSign(template)
+	require.NoError(t, err)
+
+	serverCA := mock_ca.NewMockServerCA(ctrl)
+	serverCA.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{
+		StoredIntermediateCert: caCert.Raw,
+	}, nil).AnyTimes()
+
+	testCases := []struct {
+		entry *common.RegistrationEntry
+		err   string
+	}{
+		{entry: &common.RegistrationEntry{}},
+		{entry: &common.RegistrationEntry{Ttl: 3600, JwtSvidTtl: 300}},
+		{entry: &common.RegistrationEntry{Ttl: -1}, err: "ttl cannot be negative"},
+		{entry: &common.RegistrationEntry{JwtSvidTtl: -1}, err: "jwt_svid_ttl cannot be negative"},
+		{entry: &common.RegistrationEntry{Ttl: 3601}, err: "ttl of 1h0m1s exceeds the CA lifetime of 1h0m0s"},
+		{entry: &common.RegistrationEntry{JwtSvidTtl: 7200}, err: "jwt_svid_ttl of 2h0m0s exceeds the CA lifetime of 1h0m0s"},
+	}
+
+	for _, tt := range testCases {
+		err := ValidateTTLs(ctx, serverCA, tt.entry)
+		if tt.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestValidateTTLsWithoutCACertificate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	serverCA := mock_ca.NewMockServerCA(ctrl)
+	serverCA.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(&ca.FetchCertificateResponse{}, nil)
+	require.NoError(t, ValidateTTLs(ctx, serverCA, &common.RegistrationEntry{Ttl: 1 << 30}))
+
+	serverCA.EXPECT().FetchCertificate(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	require.EqualError(t, ValidateTTLs(ctx, serverCA, &common.RegistrationEntry{}), "unable to fetch CA certificate: oh no")
+}
@@ -0,0 +1,576 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: delegated.proto
+
+package delegated
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/spiffe/spire/proto/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Empty from public import github.com/spiffe/spire/proto/common/common.proto
+type Empty = common.Empty
+
+// AttestationData from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestationData = common.AttestationData
+
+// Selector from public import github.com/spiffe/spire/proto/common/common.proto
+type Selector = common.Selector
+
+// Selectors from public import github.com/spiffe/spire/proto/common/common.proto
+type Selectors = common.Selectors
+
+// RegistrationEntry from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntry = common.RegistrationEntry
+
+// RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntries = common.RegistrationEntries
+
+// Identifies the workload SVIDs are fetched for, either by its selectors or
+// by the PID of a local process, which the agent attests.
+type Workload struct {
+	// Selectors of the workload.
+	Selectors []*common.Selector `protobuf:"bytes,1,rep,name=selectors" json:"selectors,omitempty"`
+	// PID of the workload.
+	Pid                  int32    `protobuf:"varint,2,opt,name=pid" json:"pid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Workload) Reset()         { *m = Workload{} }
+func (m *Workload) String() string { return proto.CompactTextString(m) }
+func (*Workload) ProtoMessage()    {}
+func (*Workload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{0}
+}
+func (m *Workload) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Workload.Unmarshal(m, b)
+}
+func (m *Workload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Workload.Marshal(b, m, deterministic)
+}
+func (dst *Workload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Workload.Merge(dst, src)
+}
+func (m *Workload) XXX_Size() int {
+	return xxx_messageInfo_Workload.Size(m)
+}
+func (m *Workload) XXX_DiscardUnknown() {
+	xxx_messageInfo_Workload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Workload proto.InternalMessageInfo
+
+func (m *Workload) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *Workload) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+// Represents a request for the X509-SVIDs of a workload.
+type FetchX509SVIDsRequest struct {
+	// The workload to fetch the X509-SVIDs for.
+	Workload             *Workload `protobuf:"bytes,1,opt,name=workload" json:"workload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *FetchX509SVIDsRequest) Reset()         { *m = FetchX509SVIDsRequest{} }
+func (m *FetchX509SVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchX509SVIDsRequest) ProtoMessage()    {}
+func (*FetchX509SVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{1}
+}
+func (m *FetchX509SVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchX509SVIDsRequest.Unmarshal(m, b)
+}
+func (m *FetchX509SVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchX509SVIDsRequest.Marshal(b, m, deterministic)
+}
+func (dst *FetchX509SVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchX509SVIDsRequest.Merge(dst, src)
+}
+func (m *FetchX509SVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchX509SVIDsRequest.Size(m)
+}
+func (m *FetchX509SVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchX509SVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchX509SVIDsRequest proto.InternalMessageInfo
+
+func (m *FetchX509SVIDsRequest) GetWorkload() *Workload {
+	if m != nil {
+		return m.Workload
+	}
+	return nil
+}
+
+// An X509-SVID and its private key.
+type X509SVID struct {
+	// The SPIFFE ID of the SVID.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// ASN.1 DER encoded certificate chain. MAY include intermediates,
+	// the leaf certificate (or SVID itself) MUST come first.
+	X509Svid []byte `protobuf:"bytes,2,opt,name=x509_svid,json=x509Svid,proto3" json:"x509_svid,omitempty"`
+	// ASN.1 DER encoded PKCS#8 private key.
+	X509SvidKey          []byte   `protobuf:"bytes,3,opt,name=x509_svid_key,json=x509SvidKey,proto3" json:"x509_svid_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVID) Reset()         { *m = X509SVID{} }
+func (m *X509SVID) String() string { return proto.CompactTextString(m) }
+func (*X509SVID) ProtoMessage()    {}
+func (*X509SVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{2}
+}
+func (m *X509SVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVID.Unmarshal(m, b)
+}
+func (m *X509SVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVID.Marshal(b, m, deterministic)
+}
+func (dst *X509SVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVID.Merge(dst, src)
+}
+func (m *X509SVID) XXX_Size() int {
+	return xxx_messageInfo_X509SVID.Size(m)
+}
+func (m *X509SVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVID proto.InternalMessageInfo
+
+func (m *X509SVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *X509SVID) GetX509Svid() []byte {
+	if m != nil {
+		return m.X509Svid
+	}
+	return nil
+}
+
+func (m *X509SVID) GetX509SvidKey() []byte {
+	if m != nil {
+		return m.X509SvidKey
+	}
+	return nil
+}
+
+// Represents the X509-SVIDs of a workload and the bundles it trusts.
+type FetchX509SVIDsResponse struct {
+	// The X509-SVIDs the workload is entitled to.
+	Svids []*X509SVID `protobuf:"bytes,1,rep,name=svids" json:"svids,omitempty"`
+	// ASN.1 DER encoded bundle of the agent's trust domain.
+	Bundle []byte `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// ASN.1 DER encoded bundles of the trust domains the workload
+	// federates with, keyed by trust domain SPIFFE ID.
+	FederatedBundles     map[string][]byte `protobuf:"bytes,3,rep,name=federated_bundles,json=federatedBundles" json:"federated_bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *FetchX509SVIDsResponse) Reset()         { *m = FetchX509SVIDsResponse{} }
+func (m *FetchX509SVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchX509SVIDsResponse) ProtoMessage()    {}
+func (*FetchX509SVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{3}
+}
+func (m *FetchX509SVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchX509SVIDsResponse.Unmarshal(m, b)
+}
+func (m *FetchX509SVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchX509SVIDsResponse.Marshal(b, m, deterministic)
+}
+func (dst *FetchX509SVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchX509SVIDsResponse.Merge(dst, src)
+}
+func (m *FetchX509SVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchX509SVIDsResponse.Size(m)
+}
+func (m *FetchX509SVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchX509SVIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchX509SVIDsResponse proto.InternalMessageInfo
+
+func (m *FetchX509SVIDsResponse) GetSvids() []*X509SVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+func (m *FetchX509SVIDsResponse) GetBundle() []byte {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+func (m *FetchX509SVIDsResponse) GetFederatedBundles() map[string][]byte {
+	if m != nil {
+		return m.FederatedBundles
+	}
+	return nil
+}
+
+// Represents a request for the JWT-SVIDs of a workload.
+type FetchJWTSVIDsRequest struct {
+	// The workload to fetch the JWT-SVIDs for.
+	Workload *Workload `protobuf:"bytes,1,opt,name=workload" json:"workload,omitempty"`
+	// The audience of the JWT-SVIDs. Required.
+	Audience             []string `protobuf:"bytes,2,rep,name=audience" json:"audience,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchJWTSVIDsRequest) Reset()         { *m = FetchJWTSVIDsRequest{} }
+func (m *FetchJWTSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchJWTSVIDsRequest) ProtoMessage()    {}
+func (*FetchJWTSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{4}
+}
+func (m *FetchJWTSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJWTSVIDsRequest.Unmarshal(m, b)
+}
+func (m *FetchJWTSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJWTSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (dst *FetchJWTSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJWTSVIDsRequest.Merge(dst, src)
+}
+func (m *FetchJWTSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchJWTSVIDsRequest.Size(m)
+}
+func (m *FetchJWTSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJWTSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJWTSVIDsRequest proto.InternalMessageInfo
+
+func (m *FetchJWTSVIDsRequest) GetWorkload() *Workload {
+	if m != nil {
+		return m.Workload
+	}
+	return nil
+}
+
+func (m *FetchJWTSVIDsRequest) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
+}
+
+// A JWT-SVID.
+type JWTSVID struct {
+	// The SPIFFE ID of the SVID.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// Encoded using JWS Compact Serialization.
+	Svid                 string   `protobuf:"bytes,2,opt,name=svid" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JWTSVID) Reset()         { *m = JWTSVID{} }
+func (m *JWTSVID) String() string { return proto.CompactTextString(m) }
+func (*JWTSVID) ProtoMessage()    {}
+func (*JWTSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{5}
+}
+func (m *JWTSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTSVID.Unmarshal(m, b)
+}
+func (m *JWTSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTSVID.Marshal(b, m, deterministic)
+}
+func (dst *JWTSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTSVID.Merge(dst, src)
+}
+func (m *JWTSVID) XXX_Size() int {
+	return xxx_messageInfo_JWTSVID.Size(m)
+}
+func (m *JWTSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTSVID proto.InternalMessageInfo
+
+func (m *JWTSVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *JWTSVID) GetSvid() string {
+	if m != nil {
+		return m.Svid
+	}
+	return ""
+}
+
+// Represents the JWT-SVIDs of a workload.
+type FetchJWTSVIDsResponse struct {
+	// The JWT-SVIDs the workload is entitled to.
+	Svids                []*JWTSVID `protobuf:"bytes,1,rep,name=svids" json:"svids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FetchJWTSVIDsResponse) Reset()         { *m = FetchJWTSVIDsResponse{} }
+func (m *FetchJWTSVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchJWTSVIDsResponse) ProtoMessage()    {}
+func (*FetchJWTSVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_delegated_5bf0cc21c562028e, []int{6}
+}
+func (m *FetchJWTSVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJWTSVIDsResponse.Unmarshal(m, b)
+}
+func (m *FetchJWTSVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJWTSVIDsResponse.Marshal(b, m, deterministic)
+}
+func (dst *FetchJWTSVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJWTSVIDsResponse.Merge(dst, src)
+}
+func (m *FetchJWTSVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchJWTSVIDsResponse.Size(m)
+}
+func (m *FetchJWTSVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJWTSVIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJWTSVIDsResponse proto.InternalMessageInfo
+
+func (m *FetchJWTSVIDsResponse) GetSvids() []*JWTSVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Workload)(nil), "spire.api.delegated.Workload")
+	proto.RegisterType((*FetchX509SVIDsRequest)(nil), "spire.api.delegated.FetchX509SVIDsRequest")
+	proto.RegisterType((*X509SVID)(nil), "spire.api.delegated.X509SVID")
+	proto.RegisterType((*FetchX509SVIDsResponse)(nil), "spire.api.delegated.FetchX509SVIDsResponse")
+	proto.RegisterMapType((map[string][]byte)(nil), "spire.api.delegated.FetchX509SVIDsResponse.FederatedBundlesEntry")
+	proto.RegisterType((*FetchJWTSVIDsRequest)(nil), "spire.api.delegated.FetchJWTSVIDsRequest")
+	proto.RegisterType((*JWTSVID)(nil), "spire.api.delegated.JWTSVID")
+	proto.RegisterType((*FetchJWTSVIDsResponse)(nil), "spire.api.delegated.FetchJWTSVIDsResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for DelegatedIdentity service
+
+type DelegatedIdentityClient interface {
+	// Streams the X509-SVIDs of a workload, sending a new response every
+	// time they or the bundles change.
+	FetchX509SVIDs(ctx context.Context, in *FetchX509SVIDsRequest, opts ...grpc.CallOption) (DelegatedIdentity_FetchX509SVIDsClient, error)
+	// Fetches the JWT-SVIDs of a workload for the given audience.
+	FetchJWTSVIDs(ctx context.Context, in *FetchJWTSVIDsRequest, opts ...grpc.CallOption) (*FetchJWTSVIDsResponse, error)
+}
+
+type delegatedIdentityClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDelegatedIdentityClient(cc *grpc.ClientConn) DelegatedIdentityClient {
+	return &delegatedIdentityClient{cc}
+}
+
+func (c *delegatedIdentityClient) FetchX509SVIDs(ctx context.Context, in *FetchX509SVIDsRequest, opts ...grpc.CallOption) (DelegatedIdentity_FetchX509SVIDsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_DelegatedIdentity_serviceDesc.Streams[0], c.cc, "/spire.api.delegated.DelegatedIdentity/FetchX509SVIDs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &delegatedIdentityFetchX509SVIDsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DelegatedIdentity_FetchX509SVIDsClient interface {
+	Recv() (*FetchX509SVIDsResponse, error)
+	grpc.ClientStream
+}
+
+type delegatedIdentityFetchX509SVIDsClient struct {
+	grpc.ClientStream
+}
+
+func (x *delegatedIdentityFetchX509SVIDsClient) Recv() (*FetchX509SVIDsResponse, error) {
+	m := new(FetchX509SVIDsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *delegatedIdentityClient) FetchJWTSVIDs(ctx context.Context, in *FetchJWTSVIDsRequest, opts ...grpc.CallOption) (*FetchJWTSVIDsResponse, error) {
+	out := new(FetchJWTSVIDsResponse)
+	err := grpc.Invoke(ctx, "/spire.api.delegated.DelegatedIdentity/FetchJWTSVIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for DelegatedIdentity service
+
+type DelegatedIdentityServer interface {
+	// Streams the X509-SVIDs of a workload, sending a new response every
+	// time they or the bundles change.
+	FetchX509SVIDs(*FetchX509SVIDsRequest, DelegatedIdentity_FetchX509SVIDsServer) error
+	// Fetches the JWT-SVIDs of a workload for the given audience.
+	FetchJWTSVIDs(context.Context, *FetchJWTSVIDsRequest) (*FetchJWTSVIDsResponse, error)
+}
+
+func RegisterDelegatedIdentityServer(s *grpc.Server, srv DelegatedIdentityServer) {
+	s.RegisterService(&_DelegatedIdentity_serviceDesc, srv)
+}
+
+func _DelegatedIdentity_FetchX509SVIDs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchX509SVIDsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DelegatedIdentityServer).FetchX509SVIDs(m, &delegatedIdentityFetchX509SVIDsServer{stream})
+}
+
+type DelegatedIdentity_FetchX509SVIDsServer interface {
+	Send(*FetchX509SVIDsResponse) error
+	grpc.ServerStream
+}
+
+type delegatedIdentityFetchX509SVIDsServer struct {
+	grpc.ServerStream
+}
+
+func (x *delegatedIdentityFetchX509SVIDsServer) Send(m *FetchX509SVIDsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DelegatedIdentity_FetchJWTSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchJWTSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DelegatedIdentityServer).FetchJWTSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.delegated.DelegatedIdentity/FetchJWTSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DelegatedIdentityServer).FetchJWTSVIDs(ctx, req.(*FetchJWTSVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DelegatedIdentity_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.delegated.DelegatedIdentity",
+	HandlerType: (*DelegatedIdentityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FetchJWTSVIDs",
+			Handler:    _DelegatedIdentity_FetchJWTSVIDs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchX509SVIDs",
+			Handler:       _DelegatedIdentity_FetchX509SVIDs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "delegated.proto",
+}
+
+func init() { proto.RegisterFile("delegated.proto", fileDescriptor_delegated_5bf0cc21c562028e) }
+
+var fileDescriptor_delegated_5bf0cc21c562028e = []byte{
+	// 491 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x41, 0x6f, 0xd3, 0x4c,
+	0x10, 0xfd, 0x1c, 0x7f, 0x29, 0xf6, 0x84, 0x42, 0xbb, 0x94, 0xc8, 0x32, 0x20, 0x45, 0x3e, 0x85,
+	0x22, 0x39, 0x21, 0xa5, 0x12, 0xe9, 0x8d, 0x52, 0x2a, 0x85, 0x5e, 0xd0, 0x06, 0x51, 0xc4, 0x25,
+	0x72, 0xbc, 0xe3, 0xc6, 0x8a, 0x63, 0x1b, 0xaf, 0x1d, 0xc8, 0x5f, 0xe1, 0x17, 0xf2, 0x33, 0xd0,
+	0x7a, 0xd7, 0xae, 0x12, 0x19, 0x54, 0x24, 0x4e, 0xd9, 0x9d, 0x79, 0xef, 0xcd, 0xdb, 0x79, 0x91,
+	0xe1, 0x21, 0xc3, 0x08, 0x6f, 0xbc, 0x1c, 0x99, 0x9b, 0x66, 0x49, 0x9e, 0x90, 0x47, 0x3c, 0x0d,
+	0x33, 0x74, 0xbd, 0x34, 0x74, 0xeb, 0x96, 0xfd, 0xf2, 0x26, 0xcc, 0x17, 0xc5, 0xdc, 0xf5, 0x93,
+	0xd5, 0x80, 0xa7, 0x61, 0x10, 0xe0, 0xa0, 0x84, 0x0d, 0x4a, 0xce, 0xc0, 0x4f, 0x56, 0xab, 0x24,
+	0x56, 0x3f, 0x52, 0xc7, 0xa1, 0x60, 0x5c, 0x27, 0xd9, 0x32, 0x4a, 0x3c, 0x46, 0x5e, 0x81, 0xc9,
+	0x31, 0x42, 0x3f, 0x4f, 0x32, 0x6e, 0x69, 0x3d, 0xbd, 0xdf, 0x19, 0x75, 0x5d, 0x39, 0x47, 0x71,
+	0xa6, 0xaa, 0x4d, 0x6f, 0x81, 0xe4, 0x00, 0xf4, 0x34, 0x64, 0x56, 0xab, 0xa7, 0xf5, 0xdb, 0x54,
+	0x1c, 0x1d, 0x0a, 0x8f, 0x2f, 0x31, 0xf7, 0x17, 0x9f, 0x4f, 0x87, 0xe3, 0xe9, 0xa7, 0xc9, 0x05,
+	0xa7, 0xf8, 0xb5, 0x40, 0x9e, 0x93, 0x31, 0x18, 0xdf, 0xd4, 0x30, 0x4b, 0xeb, 0x69, 0xfd, 0xce,
+	0xe8, 0x99, 0xdb, 0xf0, 0x0e, 0xb7, 0x72, 0x44, 0x6b, 0xb8, 0xb3, 0x00, 0xa3, 0x92, 0x23, 0x4f,
+	0xc0, 0x94, 0xaf, 0x9b, 0x85, 0x52, 0xc7, 0xa4, 0x86, 0x2c, 0x4c, 0x98, 0x68, 0x7e, 0x3f, 0x1d,
+	0x8e, 0x67, 0x7c, 0xad, 0x4c, 0xdd, 0xa7, 0x86, 0x28, 0x4c, 0xd7, 0x21, 0x23, 0x0e, 0xec, 0xd7,
+	0xcd, 0xd9, 0x12, 0x37, 0x96, 0x5e, 0x02, 0x3a, 0x15, 0xe0, 0x0a, 0x37, 0xce, 0x8f, 0x16, 0x74,
+	0x77, 0xed, 0xf3, 0x34, 0x89, 0x39, 0x92, 0x13, 0x68, 0x0b, 0x66, 0xb5, 0x9c, 0x66, 0xf3, 0x15,
+	0x8d, 0x4a, 0x2c, 0xe9, 0xc2, 0xde, 0xbc, 0x88, 0x59, 0x84, 0xca, 0x8d, 0xba, 0x91, 0x18, 0x0e,
+	0x03, 0x64, 0x98, 0x09, 0xd2, 0x4c, 0xd6, 0xb8, 0xa5, 0x97, 0xc2, 0x6f, 0x1a, 0x85, 0x9b, 0x4d,
+	0xb9, 0x97, 0x95, 0xc8, 0xb9, 0xd4, 0x78, 0x17, 0xe7, 0xd9, 0x86, 0x1e, 0x04, 0x3b, 0x65, 0xfb,
+	0xad, 0x48, 0xa5, 0x01, 0x2a, 0x02, 0x14, 0xab, 0x90, 0x8b, 0x14, 0x47, 0x72, 0x04, 0xed, 0xb5,
+	0x17, 0x15, 0x95, 0x63, 0x79, 0x39, 0x6b, 0xbd, 0xd6, 0x9c, 0x15, 0x1c, 0x95, 0x36, 0xde, 0x5f,
+	0x7f, 0xfc, 0x47, 0xc9, 0x12, 0x1b, 0x0c, 0xaf, 0x60, 0x21, 0xc6, 0xbe, 0x98, 0xa7, 0x8b, 0x30,
+	0xab, 0xbb, 0x73, 0x06, 0xf7, 0xd4, 0xa4, 0x3f, 0x87, 0x4e, 0xe0, 0xff, 0x3a, 0x6f, 0x93, 0x96,
+	0x67, 0xe7, 0x4a, 0xfd, 0x0b, 0x6f, 0xad, 0xaa, 0x14, 0x47, 0xdb, 0x29, 0x3e, 0x6d, 0x34, 0xaa,
+	0x58, 0x2a, 0xc4, 0xd1, 0x4f, 0x0d, 0x0e, 0x2f, 0xaa, 0xe6, 0x84, 0x61, 0x9c, 0x87, 0xf9, 0x86,
+	0x2c, 0xe1, 0xc1, 0x76, 0x28, 0xe4, 0xf8, 0x4e, 0xc9, 0x95, 0x3b, 0xb3, 0x5f, 0xfc, 0x45, 0xca,
+	0x43, 0x8d, 0x04, 0xb0, 0xbf, 0xf5, 0x1e, 0xf2, 0xfc, 0xf7, 0xfc, 0x9d, 0x78, 0xec, 0xe3, 0xbb,
+	0x40, 0xe5, 0xa4, 0xf3, 0xce, 0x17, 0xb3, 0x86, 0x7c, 0xf8, 0x6f, 0xbe, 0x57, 0x7e, 0x27, 0x4e,
+	0x7e, 0x0d, 0x00, 0x02, 0x35, 0x84, 0xc5, 0x82, 0x04, 0x00, 0x00,
+}
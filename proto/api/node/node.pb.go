@@ -42,6 +42,33 @@ type RegistrationEntry = common.RegistrationEntry
 // RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
 type RegistrationEntries = common.RegistrationEntries
 
+// The status of the calling agent as seen by the server.
+type AgentStatus int32
+
+const (
+	// The agent is attested and may keep serving workloads.
+	AgentStatus_ACTIVE AgentStatus = 0
+	// The agent has been evicted. It must discard its cached SVIDs and
+	// keys and stop serving workloads.
+	AgentStatus_EVICTED AgentStatus = 1
+)
+
+var AgentStatus_name = map[int32]string{
+	0: "ACTIVE",
+	1: "EVICTED",
+}
+var AgentStatus_value = map[string]int32{
+	"ACTIVE":  0,
+	"EVICTED": 1,
+}
+
+func (x AgentStatus) String() string {
+	return proto.EnumName(AgentStatus_name, int32(x))
+}
+func (AgentStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{0}
+}
+
 // A type which contains the "Spiffe Verifiable Identity Document" and
 // a TTL indicating when the SVID expires.
 type Svid struct {
@@ -58,7 +85,7 @@ func (m *Svid) Reset()         { *m = Svid{} }
 func (m *Svid) String() string { return proto.CompactTextString(m) }
 func (*Svid) ProtoMessage()    {}
 func (*Svid) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{0}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{0}
 }
 func (m *Svid) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Svid.Unmarshal(m, b)
@@ -102,17 +129,32 @@ type SvidUpdate struct {
 	Bundle []byte `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
 	// A type representing a curated record that the Spire Server uses to set up
 	// and manage the various registered nodes and workloads that are controlled by it.
-	RegistrationEntries  []*common.RegistrationEntry `protobuf:"bytes,3,rep,name=registration_entries,json=registrationEntries" json:"registration_entries,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	RegistrationEntries []*common.RegistrationEntry `protobuf:"bytes,3,rep,name=registration_entries,json=registrationEntries" json:"registration_entries,omitempty"`
+	// CA bundles belonging to foreign trust domains that the registration
+	// entries federate with, keyed by the SPIFFE ID of the trust domain.
+	// Bundles are ASN.1 DER encoded.
+	FederatedBundles map[string][]byte `protobuf:"bytes,4,rep,name=federated_bundles,json=federatedBundles" json:"federated_bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// JWT signing keys of the SPIRE Server bundle
+	JwtSigningKeys []*common.PublicKey `protobuf:"bytes,5,rep,name=jwt_signing_keys,json=jwtSigningKeys" json:"jwt_signing_keys,omitempty"`
+	// CRL of the SPIRE Server CA, ASN.1 DER encoded. Not set unless CRLs
+	// are enabled
+	Crl []byte `protobuf:"bytes,6,opt,name=crl,proto3" json:"crl,omitempty"`
+	// Sequence number of the SPIRE Server bundle, increased every time the
+	// bundle changes
+	BundleSequenceNumber uint64 `protobuf:"varint,7,opt,name=bundle_sequence_number,json=bundleSequenceNumber" json:"bundle_sequence_number,omitempty"`
+	// Tainted CA certificates of the SPIRE Server. Agents rotate their SVID
+	// if it was signed by one of them. ASN.1 DER encoded
+	TaintedCaCerts       []byte   `protobuf:"bytes,8,opt,name=tainted_ca_certs,json=taintedCaCerts,proto3" json:"tainted_ca_certs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SvidUpdate) Reset()         { *m = SvidUpdate{} }
 func (m *SvidUpdate) String() string { return proto.CompactTextString(m) }
 func (*SvidUpdate) ProtoMessage()    {}
 func (*SvidUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{1}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{1}
 }
 func (m *SvidUpdate) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SvidUpdate.Unmarshal(m, b)
@@ -153,6 +195,41 @@ func (m *SvidUpdate) GetRegistrationEntries() []*common.RegistrationEntry {
 	return nil
 }
 
+func (m *SvidUpdate) GetFederatedBundles() map[string][]byte {
+	if m != nil {
+		return m.FederatedBundles
+	}
+	return nil
+}
+
+func (m *SvidUpdate) GetJwtSigningKeys() []*common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKeys
+	}
+	return nil
+}
+
+func (m *SvidUpdate) GetCrl() []byte {
+	if m != nil {
+		return m.Crl
+	}
+	return nil
+}
+
+func (m *SvidUpdate) GetBundleSequenceNumber() uint64 {
+	if m != nil {
+		return m.BundleSequenceNumber
+	}
+	return 0
+}
+
+func (m *SvidUpdate) GetTaintedCaCerts() []byte {
+	if m != nil {
+		return m.TaintedCaCerts
+	}
+	return nil
+}
+
 // Represents a request to attest the node.
 type AttestRequest struct {
 	// A type which contains attestation data for specific platform.
@@ -170,7 +247,7 @@ func (m *AttestRequest) Reset()         { *m = AttestRequest{} }
 func (m *AttestRequest) String() string { return proto.CompactTextString(m) }
 func (*AttestRequest) ProtoMessage()    {}
 func (*AttestRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{2}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{2}
 }
 func (m *AttestRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestRequest.Unmarshal(m, b)
@@ -230,7 +307,7 @@ func (m *AttestResponse) Reset()         { *m = AttestResponse{} }
 func (m *AttestResponse) String() string { return proto.CompactTextString(m) }
 func (*AttestResponse) ProtoMessage()    {}
 func (*AttestResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{3}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{3}
 }
 func (m *AttestResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestResponse.Unmarshal(m, b)
@@ -277,7 +354,7 @@ func (m *FetchX509SVIDRequest) Reset()         { *m = FetchX509SVIDRequest{} }
 func (m *FetchX509SVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchX509SVIDRequest) ProtoMessage()    {}
 func (*FetchX509SVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{4}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{4}
 }
 func (m *FetchX509SVIDRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchX509SVIDRequest.Unmarshal(m, b)
@@ -309,7 +386,9 @@ func (m *FetchX509SVIDRequest) GetCsrs() [][]byte {
 type FetchX509SVIDResponse struct {
 	// It includes a map of signed SVIDs and an array of all current Registration
 	// Entries which are relevant to the caller SPIFFE ID.
-	SvidUpdate           *SvidUpdate `protobuf:"bytes,1,opt,name=svid_update,json=svidUpdate" json:"svid_update,omitempty"`
+	SvidUpdate *SvidUpdate `protobuf:"bytes,1,opt,name=svid_update,json=svidUpdate" json:"svid_update,omitempty"`
+	// The status of the calling agent. When EVICTED, no SVID update is sent.
+	AgentStatus          AgentStatus `protobuf:"varint,2,opt,name=agent_status,json=agentStatus,enum=spire.api.node.AgentStatus" json:"agent_status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
 	XXX_sizecache        int32       `json:"-"`
@@ -319,7 +398,7 @@ func (m *FetchX509SVIDResponse) Reset()         { *m = FetchX509SVIDResponse{} }
 func (m *FetchX509SVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchX509SVIDResponse) ProtoMessage()    {}
 func (*FetchX509SVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{5}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{5}
 }
 func (m *FetchX509SVIDResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchX509SVIDResponse.Unmarshal(m, b)
@@ -346,6 +425,200 @@ func (m *FetchX509SVIDResponse) GetSvidUpdate() *SvidUpdate {
 	return nil
 }
 
+func (m *FetchX509SVIDResponse) GetAgentStatus() AgentStatus {
+	if m != nil {
+		return m.AgentStatus
+	}
+	return AgentStatus_ACTIVE
+}
+
+// JSR is a JWT-SVID signing request.
+type JSR struct {
+	// SPIFFE ID of the workload the JWT-SVID is requested for.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// Audience of the JWT-SVID.
+	Audience             []string `protobuf:"bytes,2,rep,name=audience" json:"audience,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JSR) Reset()         { *m = JSR{} }
+func (m *JSR) String() string { return proto.CompactTextString(m) }
+func (*JSR) ProtoMessage()    {}
+func (*JSR) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{6}
+}
+func (m *JSR) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JSR.Unmarshal(m, b)
+}
+func (m *JSR) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JSR.Marshal(b, m, deterministic)
+}
+func (dst *JSR) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JSR.Merge(dst, src)
+}
+func (m *JSR) XXX_Size() int {
+	return xxx_messageInfo_JSR.Size(m)
+}
+func (m *JSR) XXX_DiscardUnknown() {
+	xxx_messageInfo_JSR.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JSR proto.InternalMessageInfo
+
+func (m *JSR) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *JSR) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
+}
+
+// A signed JWT-SVID along with its issue and expiry times.
+type JWTSVID struct {
+	// The signed JWT-SVID in compact serialization.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// Issue time, in seconds since the Unix epoch.
+	IssuedAt int64 `protobuf:"varint,2,opt,name=issued_at,json=issuedAt" json:"issued_at,omitempty"`
+	// Expiry time, in seconds since the Unix epoch.
+	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JWTSVID) Reset()         { *m = JWTSVID{} }
+func (m *JWTSVID) String() string { return proto.CompactTextString(m) }
+func (*JWTSVID) ProtoMessage()    {}
+func (*JWTSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{7}
+}
+func (m *JWTSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTSVID.Unmarshal(m, b)
+}
+func (m *JWTSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTSVID.Marshal(b, m, deterministic)
+}
+func (dst *JWTSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTSVID.Merge(dst, src)
+}
+func (m *JWTSVID) XXX_Size() int {
+	return xxx_messageInfo_JWTSVID.Size(m)
+}
+func (m *JWTSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTSVID proto.InternalMessageInfo
+
+func (m *JWTSVID) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *JWTSVID) GetIssuedAt() int64 {
+	if m != nil {
+		return m.IssuedAt
+	}
+	return 0
+}
+
+func (m *JWTSVID) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// Represents a request for a JWT-SVID.
+type FetchJWTSVIDRequest struct {
+	// The JWT-SVID signing request.
+	Jsr                  *JSR     `protobuf:"bytes,1,opt,name=jsr" json:"jsr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchJWTSVIDRequest) Reset()         { *m = FetchJWTSVIDRequest{} }
+func (m *FetchJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchJWTSVIDRequest) ProtoMessage()    {}
+func (*FetchJWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{8}
+}
+func (m *FetchJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *FetchJWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (dst *FetchJWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJWTSVIDRequest.Merge(dst, src)
+}
+func (m *FetchJWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchJWTSVIDRequest.Size(m)
+}
+func (m *FetchJWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJWTSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJWTSVIDRequest proto.InternalMessageInfo
+
+func (m *FetchJWTSVIDRequest) GetJsr() *JSR {
+	if m != nil {
+		return m.Jsr
+	}
+	return nil
+}
+
+// Represents a response with a signed JWT-SVID.
+type FetchJWTSVIDResponse struct {
+	// The signed JWT-SVID.
+	Svid                 *JWTSVID `protobuf:"bytes,1,opt,name=svid" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchJWTSVIDResponse) Reset()         { *m = FetchJWTSVIDResponse{} }
+func (m *FetchJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchJWTSVIDResponse) ProtoMessage()    {}
+func (*FetchJWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{9}
+}
+func (m *FetchJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJWTSVIDResponse.Unmarshal(m, b)
+}
+func (m *FetchJWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJWTSVIDResponse.Marshal(b, m, deterministic)
+}
+func (dst *FetchJWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJWTSVIDResponse.Merge(dst, src)
+}
+func (m *FetchJWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchJWTSVIDResponse.Size(m)
+}
+func (m *FetchJWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJWTSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJWTSVIDResponse proto.InternalMessageInfo
+
+func (m *FetchJWTSVIDResponse) GetSvid() *JWTSVID {
+	if m != nil {
+		return m.Svid
+	}
+	return nil
+}
+
 // Represents a request with an array of SPIFFE Ids.
 type FetchFederatedBundleRequest struct {
 	// An array of SPIFFE Ids.
@@ -359,7 +632,7 @@ func (m *FetchFederatedBundleRequest) Reset()         { *m = FetchFederatedBundl
 func (m *FetchFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchFederatedBundleRequest) ProtoMessage()    {}
 func (*FetchFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{6}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{10}
 }
 func (m *FetchFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchFederatedBundleRequest.Unmarshal(m, b)
@@ -399,7 +672,7 @@ func (m *FetchFederatedBundleResponse) Reset()         { *m = FetchFederatedBund
 func (m *FetchFederatedBundleResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchFederatedBundleResponse) ProtoMessage()    {}
 func (*FetchFederatedBundleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_4be23de3db683a67, []int{7}
+	return fileDescriptor_node_ae5256cf570cdda9, []int{11}
 }
 func (m *FetchFederatedBundleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchFederatedBundleResponse.Unmarshal(m, b)
@@ -426,17 +699,88 @@ func (m *FetchFederatedBundleResponse) GetFederatedBundles() map[string][]byte {
 	return nil
 }
 
+// Represents a request to watch for updates.
+type WatchUpdatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchUpdatesRequest) Reset()         { *m = WatchUpdatesRequest{} }
+func (m *WatchUpdatesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchUpdatesRequest) ProtoMessage()    {}
+func (*WatchUpdatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{12}
+}
+func (m *WatchUpdatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchUpdatesRequest.Unmarshal(m, b)
+}
+func (m *WatchUpdatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchUpdatesRequest.Marshal(b, m, deterministic)
+}
+func (dst *WatchUpdatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchUpdatesRequest.Merge(dst, src)
+}
+func (m *WatchUpdatesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchUpdatesRequest.Size(m)
+}
+func (m *WatchUpdatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchUpdatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchUpdatesRequest proto.InternalMessageInfo
+
+// Represents a notification that the registration entries the caller is
+// authorized for, or the bundle, changed.
+type WatchUpdatesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchUpdatesResponse) Reset()         { *m = WatchUpdatesResponse{} }
+func (m *WatchUpdatesResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchUpdatesResponse) ProtoMessage()    {}
+func (*WatchUpdatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_node_ae5256cf570cdda9, []int{13}
+}
+func (m *WatchUpdatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchUpdatesResponse.Unmarshal(m, b)
+}
+func (m *WatchUpdatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchUpdatesResponse.Marshal(b, m, deterministic)
+}
+func (dst *WatchUpdatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchUpdatesResponse.Merge(dst, src)
+}
+func (m *WatchUpdatesResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchUpdatesResponse.Size(m)
+}
+func (m *WatchUpdatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchUpdatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchUpdatesResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*Svid)(nil), "spire.api.node.Svid")
 	proto.RegisterType((*SvidUpdate)(nil), "spire.api.node.SvidUpdate")
+	proto.RegisterMapType((map[string][]byte)(nil), "spire.api.node.SvidUpdate.FederatedBundlesEntry")
 	proto.RegisterMapType((map[string]*Svid)(nil), "spire.api.node.SvidUpdate.SvidsEntry")
 	proto.RegisterType((*AttestRequest)(nil), "spire.api.node.AttestRequest")
 	proto.RegisterType((*AttestResponse)(nil), "spire.api.node.AttestResponse")
 	proto.RegisterType((*FetchX509SVIDRequest)(nil), "spire.api.node.FetchX509SVIDRequest")
 	proto.RegisterType((*FetchX509SVIDResponse)(nil), "spire.api.node.FetchX509SVIDResponse")
+	proto.RegisterType((*JSR)(nil), "spire.api.node.JSR")
+	proto.RegisterType((*JWTSVID)(nil), "spire.api.node.JWTSVID")
+	proto.RegisterType((*FetchJWTSVIDRequest)(nil), "spire.api.node.FetchJWTSVIDRequest")
+	proto.RegisterType((*FetchJWTSVIDResponse)(nil), "spire.api.node.FetchJWTSVIDResponse")
 	proto.RegisterType((*FetchFederatedBundleRequest)(nil), "spire.api.node.FetchFederatedBundleRequest")
 	proto.RegisterType((*FetchFederatedBundleResponse)(nil), "spire.api.node.FetchFederatedBundleResponse")
 	proto.RegisterMapType((map[string][]byte)(nil), "spire.api.node.FetchFederatedBundleResponse.FederatedBundlesEntry")
+	proto.RegisterType((*WatchUpdatesRequest)(nil), "spire.api.node.WatchUpdatesRequest")
+	proto.RegisterType((*WatchUpdatesResponse)(nil), "spire.api.node.WatchUpdatesResponse")
+	proto.RegisterEnum("spire.api.node.AgentStatus", AgentStatus_name, AgentStatus_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -456,9 +800,16 @@ type NodeClient interface {
 	// Base Node SVID or the Registered Node SVID used for this call)
 	// List can be empty to allow Node Agent cache refresh).
 	FetchX509SVID(ctx context.Context, opts ...grpc.CallOption) (Node_FetchX509SVIDClient, error)
+	// Get a JWT-SVID for a workload the Node Agent is entitled to. The
+	// TTL is taken from the registration entry.
+	FetchJWTSVID(ctx context.Context, in *FetchJWTSVIDRequest, opts ...grpc.CallOption) (*FetchJWTSVIDResponse, error)
 	// Called by the Node Agent to fetch the named Federated CA Bundle.
 	// Used in the event that authorized workloads reference a Federated Bundle.
 	FetchFederatedBundle(ctx context.Context, in *FetchFederatedBundleRequest, opts ...grpc.CallOption) (*FetchFederatedBundleResponse, error)
+	// Notifies the Node Agent every time the registration entries it is
+	// authorized for or the bundle change, and once when called, so that it
+	// calls FetchX509SVID right away instead of on its next sync.
+	WatchUpdates(ctx context.Context, in *WatchUpdatesRequest, opts ...grpc.CallOption) (Node_WatchUpdatesClient, error)
 }
 
 type nodeClient struct {
@@ -531,6 +882,15 @@ func (x *nodeFetchX509SVIDClient) Recv() (*FetchX509SVIDResponse, error) {
 	return m, nil
 }
 
+func (c *nodeClient) FetchJWTSVID(ctx context.Context, in *FetchJWTSVIDRequest, opts ...grpc.CallOption) (*FetchJWTSVIDResponse, error) {
+	out := new(FetchJWTSVIDResponse)
+	err := grpc.Invoke(ctx, "/spire.api.node.Node/FetchJWTSVID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeClient) FetchFederatedBundle(ctx context.Context, in *FetchFederatedBundleRequest, opts ...grpc.CallOption) (*FetchFederatedBundleResponse, error) {
 	out := new(FetchFederatedBundleResponse)
 	err := grpc.Invoke(ctx, "/spire.api.node.Node/FetchFederatedBundle", in, out, c.cc, opts...)
@@ -540,6 +900,38 @@ func (c *nodeClient) FetchFederatedBundle(ctx context.Context, in *FetchFederate
 	return out, nil
 }
 
+func (c *nodeClient) WatchUpdates(ctx context.Context, in *WatchUpdatesRequest, opts ...grpc.CallOption) (Node_WatchUpdatesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Node_serviceDesc.Streams[2], c.cc, "/spire.api.node.Node/WatchUpdates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeWatchUpdatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_WatchUpdatesClient interface {
+	Recv() (*WatchUpdatesResponse, error)
+	grpc.ClientStream
+}
+
+type nodeWatchUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeWatchUpdatesClient) Recv() (*WatchUpdatesResponse, error) {
+	m := new(WatchUpdatesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Node service
 
 type NodeServer interface {
@@ -549,9 +941,16 @@ type NodeServer interface {
 	// Base Node SVID or the Registered Node SVID used for this call)
 	// List can be empty to allow Node Agent cache refresh).
 	FetchX509SVID(Node_FetchX509SVIDServer) error
+	// Get a JWT-SVID for a workload the Node Agent is entitled to. The
+	// TTL is taken from the registration entry.
+	FetchJWTSVID(context.Context, *FetchJWTSVIDRequest) (*FetchJWTSVIDResponse, error)
 	// Called by the Node Agent to fetch the named Federated CA Bundle.
 	// Used in the event that authorized workloads reference a Federated Bundle.
 	FetchFederatedBundle(context.Context, *FetchFederatedBundleRequest) (*FetchFederatedBundleResponse, error)
+	// Notifies the Node Agent every time the registration entries it is
+	// authorized for or the bundle change, and once when called, so that it
+	// calls FetchX509SVID right away instead of on its next sync.
+	WatchUpdates(*WatchUpdatesRequest, Node_WatchUpdatesServer) error
 }
 
 func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
@@ -610,6 +1009,24 @@ func (x *nodeFetchX509SVIDServer) Recv() (*FetchX509SVIDRequest, error) {
 	return m, nil
 }
 
+func _Node_FetchJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchJWTSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).FetchJWTSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.node.Node/FetchJWTSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).FetchJWTSVID(ctx, req.(*FetchJWTSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Node_FetchFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FetchFederatedBundleRequest)
 	if err := dec(in); err != nil {
@@ -628,10 +1045,35 @@ func _Node_FetchFederatedBundle_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_WatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUpdatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).WatchUpdates(m, &nodeWatchUpdatesServer{stream})
+}
+
+type Node_WatchUpdatesServer interface {
+	Send(*WatchUpdatesResponse) error
+	grpc.ServerStream
+}
+
+type nodeWatchUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeWatchUpdatesServer) Send(m *WatchUpdatesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Node_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.node.Node",
 	HandlerType: (*NodeServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FetchJWTSVID",
+			Handler:    _Node_FetchJWTSVID_Handler,
+		},
 		{
 			MethodName: "FetchFederatedBundle",
 			Handler:    _Node_FetchFederatedBundle_Handler,
@@ -650,49 +1092,76 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchUpdates",
+			Handler:       _Node_WatchUpdates_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "node.proto",
 }
 
-func init() { proto.RegisterFile("node.proto", fileDescriptor_node_4be23de3db683a67) }
-
-var fileDescriptor_node_4be23de3db683a67 = []byte{
-	// 583 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x6f, 0x6f, 0x12, 0x4f,
-	0x10, 0xfe, 0x1d, 0x50, 0x02, 0x03, 0xed, 0x0f, 0x57, 0x34, 0x97, 0x6b, 0xab, 0xe4, 0x62, 0x13,
-	0x52, 0xcd, 0x51, 0x31, 0x4d, 0xb4, 0x7d, 0x55, 0x5a, 0x1b, 0x1b, 0x93, 0xc6, 0x6c, 0xd5, 0x18,
-	0xdf, 0xe0, 0x72, 0x37, 0xc0, 0xa5, 0x70, 0x77, 0xdd, 0x5d, 0x48, 0xfa, 0x01, 0x8c, 0x5f, 0xc5,
-	0x0f, 0xe4, 0x07, 0x32, 0xbb, 0x7b, 0x17, 0xe0, 0x42, 0xfd, 0x93, 0xf8, 0x8a, 0xd9, 0x67, 0x9f,
-	0x9d, 0x79, 0x66, 0xe6, 0xe1, 0x00, 0xa2, 0x38, 0x40, 0x2f, 0xe1, 0xb1, 0x8c, 0xc9, 0x96, 0x48,
-	0x42, 0x8e, 0x1e, 0x4b, 0x42, 0x4f, 0xa1, 0xce, 0xf3, 0x51, 0x28, 0xc7, 0xb3, 0x81, 0xe7, 0xc7,
-	0xd3, 0x8e, 0x48, 0xc2, 0xe1, 0x10, 0x3b, 0x9a, 0xd1, 0xd1, 0xf4, 0x8e, 0x1f, 0x4f, 0xa7, 0x71,
-	0x94, 0xfe, 0x98, 0x14, 0xee, 0x21, 0x94, 0xae, 0xe6, 0x61, 0x40, 0xb6, 0xa1, 0x2a, 0xe6, 0x61,
-	0xd0, 0xf7, 0x91, 0x4b, 0xdb, 0x6a, 0x59, 0xed, 0x3a, 0xad, 0x28, 0xe0, 0x14, 0xb9, 0x24, 0x0d,
-	0x28, 0x4a, 0x39, 0xb1, 0x0b, 0x2d, 0xab, 0xbd, 0x41, 0x55, 0xe8, 0x7e, 0x2d, 0x00, 0xa8, 0x77,
-	0x1f, 0x92, 0x80, 0x49, 0x24, 0xc7, 0xb0, 0xa1, 0xc8, 0xc2, 0xb6, 0x5a, 0xc5, 0x76, 0xad, 0xbb,
-	0xe7, 0xad, 0x0a, 0xf3, 0x16, 0x54, 0x1d, 0x8a, 0xd7, 0x91, 0xe4, 0xb7, 0xd4, 0xbc, 0x21, 0x0f,
-	0xa1, 0x3c, 0x98, 0x45, 0xc1, 0x04, 0x75, 0x81, 0x3a, 0x4d, 0x4f, 0x84, 0x42, 0x93, 0xe3, 0x28,
-	0x14, 0x92, 0x33, 0x19, 0xc6, 0x51, 0x1f, 0x23, 0xc9, 0x43, 0x14, 0x76, 0x51, 0xd7, 0x78, 0x9c,
-	0xd6, 0x48, 0xbb, 0xa1, 0x4b, 0x4c, 0x93, 0xfd, 0x3e, 0xcf, 0x41, 0x21, 0x0a, 0xe7, 0xd2, 0xc8,
-	0x36, 0x02, 0x54, 0x5f, 0xd7, 0x78, 0xab, 0xdb, 0xad, 0x52, 0x15, 0x92, 0x7d, 0xd8, 0x98, 0xb3,
-	0xc9, 0xcc, 0x48, 0xa9, 0x75, 0x9b, 0xeb, 0x1a, 0xa1, 0x86, 0x72, 0x54, 0x78, 0x69, 0xb9, 0xdf,
-	0x2c, 0xd8, 0x3c, 0x91, 0x12, 0x85, 0xa4, 0x78, 0x33, 0x43, 0x21, 0xc9, 0x1b, 0x68, 0x30, 0x0d,
-	0x18, 0xd1, 0x01, 0x93, 0x4c, 0x17, 0xa8, 0x75, 0x77, 0x57, 0x15, 0x9f, 0x2c, 0x58, 0x67, 0x4c,
-	0x32, 0xfa, 0x3f, 0x5b, 0x05, 0x94, 0x3a, 0x5f, 0xf0, 0x74, 0x28, 0x2a, 0x24, 0x0e, 0x54, 0x38,
-	0x8a, 0x24, 0x8e, 0x04, 0xda, 0x45, 0xb3, 0xa3, 0xec, 0xec, 0x5e, 0xc3, 0x56, 0x26, 0xc4, 0x20,
-	0xe4, 0x18, 0x6a, 0x7a, 0xa5, 0x33, 0x3d, 0xf8, 0x54, 0x84, 0x73, 0xf7, 0x6a, 0x28, 0x88, 0xc5,
-	0x46, 0x77, 0xa0, 0xea, 0x8f, 0xd9, 0x64, 0x82, 0xd1, 0x28, 0xdb, 0xcb, 0x02, 0x70, 0xf7, 0xa1,
-	0x79, 0x8e, 0xd2, 0x1f, 0x7f, 0x3a, 0x3c, 0x78, 0x75, 0xf5, 0xf1, 0xe2, 0x2c, 0x6b, 0x9e, 0x40,
-	0xc9, 0x17, 0x5c, 0xd8, 0x85, 0x56, 0xb1, 0x5d, 0xa7, 0x3a, 0x76, 0xdf, 0xc3, 0x83, 0x1c, 0xf7,
-	0x1f, 0xe8, 0x73, 0x8f, 0x60, 0x5b, 0x67, 0x3d, 0xc7, 0x00, 0x39, 0x93, 0x18, 0xf4, 0xb4, 0x69,
-	0x32, 0x21, 0xca, 0xce, 0xfa, 0x0f, 0xd0, 0x0f, 0x03, 0x6d, 0xca, 0x2a, 0xad, 0x18, 0xe0, 0x22,
-	0x70, 0x7f, 0x58, 0xb0, 0xb3, 0xfe, 0x71, 0xaa, 0x2c, 0x86, 0x7b, 0xc3, 0xec, 0xaa, 0x6f, 0xdc,
-	0x98, 0x59, 0xbb, 0x97, 0xd7, 0xf7, 0xab, 0x44, 0x5e, 0x0e, 0x4f, 0x7d, 0xdf, 0x18, 0xe6, 0x60,
-	0xe7, 0x54, 0xcd, 0x68, 0x0d, 0x75, 0x8d, 0x43, 0x9b, 0xcb, 0x0e, 0xad, 0x2f, 0x79, 0xb1, 0xfb,
-	0xbd, 0x00, 0xa5, 0xcb, 0x38, 0x40, 0xf2, 0x16, 0xca, 0xc6, 0x0a, 0x64, 0x37, 0xaf, 0x76, 0xc5,
-	0xab, 0xce, 0xa3, 0xbb, 0xae, 0x8d, 0xfc, 0xb6, 0x75, 0x60, 0x91, 0x2f, 0xb0, 0xb9, 0xb2, 0x3e,
-	0xf2, 0x64, 0xed, 0x04, 0x72, 0x4e, 0x70, 0xf6, 0x7e, 0xc3, 0x5a, 0xaa, 0x70, 0x93, 0x9a, 0x29,
-	0x37, 0x01, 0xf2, 0xf4, 0xcf, 0x46, 0x6d, 0xea, 0x3d, 0xfb, 0x9b, 0xbd, 0xf4, 0xca, 0x9f, 0x4b,
-	0x8a, 0xf4, 0xee, 0xbf, 0x41, 0x59, 0x7f, 0x06, 0x5f, 0xfc, 0x0c, 0x00, 0x00, 0xff, 0xff, 0xfc,
-	0x7e, 0x82, 0x00, 0x57, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("node.proto", fileDescriptor_node_ae5256cf570cdda9) }
+
+var fileDescriptor_node_ae5256cf570cdda9 = []byte{
+	// 930 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xed, 0x4e, 0x1b, 0x47,
+	0x14, 0xcd, 0xb2, 0xc6, 0xd8, 0xd7, 0x8e, 0xeb, 0x0e, 0x86, 0xac, 0x96, 0xd0, 0x5a, 0x5b, 0xa8,
+	0x2c, 0x52, 0x19, 0xea, 0x36, 0x52, 0x9b, 0x54, 0x91, 0x8c, 0x21, 0x2a, 0x20, 0xa1, 0x68, 0x4c,
+	0x49, 0xdb, 0xa8, 0xda, 0x8e, 0x77, 0x07, 0x33, 0x60, 0x76, 0x9d, 0x9d, 0x59, 0x52, 0x3f, 0x41,
+	0x5f, 0xa0, 0x8f, 0xd6, 0xdf, 0x55, 0x1f, 0xa5, 0x9a, 0x0f, 0x87, 0xf5, 0xe2, 0xd0, 0x44, 0xca,
+	0x2f, 0xcf, 0xde, 0x7b, 0xee, 0xd7, 0xb9, 0x67, 0x46, 0x06, 0x88, 0xe2, 0x90, 0xb6, 0xc7, 0x49,
+	0x2c, 0x62, 0x54, 0xe3, 0x63, 0x96, 0xd0, 0x36, 0x19, 0xb3, 0xb6, 0xb4, 0xba, 0x5f, 0x0f, 0x99,
+	0x38, 0x4f, 0x07, 0xed, 0x20, 0xbe, 0xda, 0xe6, 0x63, 0x76, 0x76, 0x46, 0xb7, 0x15, 0x62, 0x5b,
+	0xc1, 0xb7, 0x83, 0xf8, 0xea, 0x2a, 0x8e, 0xcc, 0x8f, 0x4e, 0xe1, 0x3d, 0x86, 0x42, 0xff, 0x9a,
+	0x85, 0x68, 0x0d, 0xca, 0xfc, 0x9a, 0x85, 0x7e, 0x40, 0x13, 0xe1, 0x58, 0x4d, 0xab, 0x55, 0xc5,
+	0x25, 0x69, 0xe8, 0xd1, 0x44, 0xa0, 0x3a, 0xd8, 0x42, 0x8c, 0x9c, 0x85, 0xa6, 0xd5, 0x5a, 0xc4,
+	0xf2, 0xe8, 0xfd, 0x53, 0x00, 0x90, 0x71, 0x3f, 0x8d, 0x43, 0x22, 0x28, 0x7a, 0x0a, 0x8b, 0x12,
+	0xcc, 0x1d, 0xab, 0x69, 0xb7, 0x2a, 0x9d, 0xcd, 0xf6, 0x6c, 0x63, 0xed, 0x1b, 0xa8, 0x3a, 0xf2,
+	0xfd, 0x48, 0x24, 0x13, 0xac, 0x63, 0xd0, 0x2a, 0x14, 0x07, 0x69, 0x14, 0x8e, 0xa8, 0x2a, 0x50,
+	0xc5, 0xe6, 0x0b, 0x61, 0x68, 0x24, 0x74, 0xc8, 0xb8, 0x48, 0x88, 0x60, 0x71, 0xe4, 0xd3, 0x48,
+	0x24, 0x8c, 0x72, 0xc7, 0x56, 0x35, 0x3e, 0x37, 0x35, 0xcc, 0x34, 0x38, 0x83, 0xd4, 0xd9, 0x97,
+	0x93, 0x9c, 0x89, 0x51, 0x8e, 0x7e, 0x83, 0x4f, 0xcf, 0x68, 0x48, 0x13, 0x22, 0x68, 0xe8, 0xeb,
+	0x3a, 0xdc, 0x29, 0xa8, 0x84, 0x3b, 0x77, 0x34, 0xfd, 0x7c, 0x1a, 0xb3, 0xab, 0x43, 0x74, 0x85,
+	0xfa, 0x59, 0xce, 0x8c, 0xba, 0x50, 0xbf, 0x78, 0x23, 0x7c, 0xce, 0x86, 0x11, 0x8b, 0x86, 0xfe,
+	0x25, 0x9d, 0x70, 0x67, 0x51, 0x65, 0x7f, 0x30, 0xdb, 0xee, 0x8b, 0x74, 0x30, 0x62, 0xc1, 0x11,
+	0x9d, 0xe0, 0xda, 0xc5, 0x1b, 0xd1, 0xd7, 0xf8, 0x23, 0x3a, 0xe1, 0x92, 0xeb, 0x20, 0x19, 0x39,
+	0x45, 0x45, 0x85, 0x3c, 0xa2, 0x6f, 0x61, 0x55, 0x77, 0xea, 0x73, 0xfa, 0x3a, 0xa5, 0x51, 0x40,
+	0xfd, 0x28, 0xbd, 0x1a, 0xd0, 0xc4, 0x59, 0x6a, 0x5a, 0xad, 0x02, 0x6e, 0x68, 0x6f, 0xdf, 0x38,
+	0x8f, 0x95, 0x0f, 0xb5, 0xa0, 0x2e, 0x08, 0x8b, 0xe4, 0x9c, 0x01, 0x51, 0x6b, 0xe5, 0x4e, 0x49,
+	0x25, 0xad, 0x19, 0x7b, 0x8f, 0xc8, 0xe5, 0x72, 0xf7, 0x58, 0xaf, 0x52, 0x0f, 0x25, 0xeb, 0x5f,
+	0xd2, 0x89, 0x92, 0x40, 0x19, 0xcb, 0x23, 0xda, 0x82, 0xc5, 0x6b, 0x32, 0x4a, 0xf5, 0x7a, 0x2a,
+	0x9d, 0xc6, 0x3c, 0x9e, 0xb0, 0x86, 0x3c, 0x59, 0xf8, 0xce, 0x72, 0x7b, 0xb0, 0x32, 0x97, 0xaf,
+	0x39, 0xa9, 0x1b, 0xd9, 0xd4, 0xd5, 0x4c, 0x12, 0xef, 0x4f, 0x0b, 0xee, 0x77, 0x85, 0xa0, 0x5c,
+	0x60, 0x39, 0x17, 0x17, 0xe8, 0x47, 0xa8, 0x13, 0x65, 0xd0, 0x6a, 0x08, 0x89, 0x20, 0x2a, 0x55,
+	0xa5, 0xb3, 0x3e, 0xcb, 0x6d, 0xf7, 0x06, 0xb5, 0x47, 0x04, 0xc1, 0x9f, 0x90, 0x59, 0x83, 0xa2,
+	0x98, 0x27, 0xa6, 0xa6, 0x3c, 0x22, 0x17, 0x4a, 0x09, 0xe5, 0xe3, 0x38, 0xe2, 0xd4, 0xb1, 0xb5,
+	0xf8, 0xa7, 0xdf, 0xde, 0x25, 0xd4, 0xa6, 0x8d, 0x68, 0x0b, 0x7a, 0x0a, 0x15, 0x75, 0x57, 0x52,
+	0x25, 0x0e, 0xd3, 0x84, 0xfb, 0x6e, 0xf9, 0x60, 0xe0, 0x6f, 0xcf, 0xe8, 0x21, 0x94, 0x83, 0x73,
+	0x32, 0x1a, 0xd1, 0x68, 0x38, 0x1d, 0xfb, 0xc6, 0xe0, 0x6d, 0x41, 0xe3, 0x39, 0x15, 0xc1, 0xf9,
+	0xcf, 0x8f, 0x77, 0xbe, 0xef, 0x9f, 0x1e, 0xec, 0x4d, 0x87, 0x47, 0x50, 0x08, 0x78, 0xc2, 0x9d,
+	0x85, 0xa6, 0xdd, 0xaa, 0x62, 0x75, 0xf6, 0xfe, 0xb2, 0x60, 0x25, 0x07, 0xfe, 0x18, 0x0d, 0x3e,
+	0x83, 0x2a, 0x19, 0xd2, 0x48, 0xf8, 0x92, 0xb2, 0x94, 0xab, 0x1e, 0x6b, 0x9d, 0xb5, 0x7c, 0x74,
+	0x57, 0x62, 0xfa, 0x0a, 0x82, 0x2b, 0xe4, 0xe6, 0xc3, 0x7b, 0x06, 0xf6, 0x61, 0x1f, 0xab, 0x07,
+	0x45, 0x3d, 0x41, 0x3e, 0x0b, 0xcd, 0xca, 0x4b, 0xda, 0x70, 0x10, 0x4a, 0xbe, 0x49, 0x1a, 0x32,
+	0x29, 0x57, 0x35, 0x52, 0x19, 0xbf, 0xfd, 0xf6, 0x5e, 0xc1, 0xd2, 0xe1, 0xcb, 0x13, 0x39, 0x8f,
+	0x94, 0x87, 0x88, 0x2f, 0x69, 0x64, 0xe2, 0xf5, 0x87, 0xcc, 0xcc, 0x38, 0x4f, 0x69, 0xe8, 0x13,
+	0xa1, 0xba, 0xb3, 0x71, 0x49, 0x1b, 0xba, 0x02, 0xad, 0x03, 0xd0, 0x3f, 0x64, 0xa7, 0x5c, 0x7a,
+	0x6d, 0xe5, 0x2d, 0x1b, 0x4b, 0x57, 0x78, 0x3f, 0xc0, 0xb2, 0xa2, 0xcc, 0x54, 0x98, 0xd2, 0xbb,
+	0x09, 0xf6, 0x05, 0x4f, 0x0c, 0x51, 0xcb, 0xf9, 0x51, 0x0f, 0xfb, 0x18, 0x4b, 0xbf, 0xd7, 0x83,
+	0xc6, 0x6c, 0xb4, 0xe1, 0xfb, 0x11, 0x14, 0x24, 0x81, 0x26, 0xfe, 0xc1, 0xad, 0x78, 0x03, 0x57,
+	0x20, 0xef, 0x09, 0xac, 0xa9, 0x24, 0xb9, 0x3b, 0x32, 0x6d, 0x25, 0xc7, 0x9b, 0x9d, 0xe5, 0xcd,
+	0xfb, 0xdb, 0x82, 0x87, 0xf3, 0x83, 0x4d, 0x27, 0xf1, 0xbc, 0xf7, 0x4d, 0x3f, 0xca, 0xbb, 0xf9,
+	0xb6, 0xee, 0x4a, 0xf4, 0xbe, 0x2f, 0xde, 0xc7, 0xb9, 0xec, 0x2b, 0xb0, 0xfc, 0x92, 0x88, 0xe0,
+	0x5c, 0x2b, 0x90, 0x1b, 0x2a, 0xbc, 0x55, 0x68, 0xcc, 0x9a, 0x75, 0x6f, 0x5b, 0x5f, 0x42, 0x25,
+	0xa3, 0x3e, 0x04, 0x50, 0xec, 0xf6, 0x4e, 0x0e, 0x4e, 0xf7, 0xeb, 0xf7, 0x50, 0x05, 0x96, 0xf6,
+	0x4f, 0x0f, 0x7a, 0x27, 0xfb, 0x7b, 0x75, 0xab, 0xf3, 0xaf, 0x0d, 0x85, 0xe3, 0x38, 0xa4, 0xe8,
+	0x08, 0x8a, 0xfa, 0x0a, 0xa3, 0xf5, 0x5b, 0x32, 0xce, 0xbe, 0x31, 0xee, 0x67, 0xef, 0x72, 0xeb,
+	0xca, 0x2d, 0x6b, 0xc7, 0x42, 0xbf, 0xc3, 0xfd, 0x99, 0x5b, 0x87, 0x36, 0xe6, 0x12, 0x9b, 0xbb,
+	0xc1, 0xee, 0xe6, 0xff, 0xa0, 0x32, 0x15, 0x7e, 0x81, 0x6a, 0x56, 0x66, 0xe8, 0x8b, 0xb9, 0xa1,
+	0xb3, 0x12, 0x76, 0x37, 0xee, 0x06, 0x19, 0x7d, 0xbc, 0x36, 0x0a, 0xce, 0xed, 0x0c, 0x3d, 0x7a,
+	0x3f, 0x71, 0xe8, 0x52, 0x5f, 0x7d, 0x88, 0x92, 0xd0, 0x2b, 0xa8, 0x66, 0xb7, 0x78, 0x7b, 0x9a,
+	0x39, 0xab, 0x77, 0x37, 0xee, 0x06, 0xe9, 0xd4, 0x3b, 0xd6, 0x6e, 0xf1, 0xd7, 0x82, 0x74, 0xbf,
+	0xb8, 0x37, 0x28, 0xaa, 0xff, 0x33, 0xdf, 0xfc, 0x37, 0x00, 0x40, 0xbd, 0xff, 0xf4, 0x20, 0x09,
+	0x00, 0x00,
 }
@@ -59,7 +59,7 @@ func (m *RegistrationEntryID) Reset()         { *m = RegistrationEntryID{} }
 func (m *RegistrationEntryID) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntryID) ProtoMessage()    {}
 func (*RegistrationEntryID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{0}
+	return fileDescriptor_registration_646abbc430e07df2, []int{0}
 }
 func (m *RegistrationEntryID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegistrationEntryID.Unmarshal(m, b)
@@ -99,7 +99,7 @@ func (m *ParentID) Reset()         { *m = ParentID{} }
 func (m *ParentID) String() string { return proto.CompactTextString(m) }
 func (*ParentID) ProtoMessage()    {}
 func (*ParentID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{1}
+	return fileDescriptor_registration_646abbc430e07df2, []int{1}
 }
 func (m *ParentID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ParentID.Unmarshal(m, b)
@@ -139,7 +139,7 @@ func (m *SpiffeID) Reset()         { *m = SpiffeID{} }
 func (m *SpiffeID) String() string { return proto.CompactTextString(m) }
 func (*SpiffeID) ProtoMessage()    {}
 func (*SpiffeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{2}
+	return fileDescriptor_registration_646abbc430e07df2, []int{2}
 }
 func (m *SpiffeID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SpiffeID.Unmarshal(m, b)
@@ -181,7 +181,7 @@ func (m *UpdateEntryRequest) Reset()         { *m = UpdateEntryRequest{} }
 func (m *UpdateEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateEntryRequest) ProtoMessage()    {}
 func (*UpdateEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{3}
+	return fileDescriptor_registration_646abbc430e07df2, []int{3}
 }
 func (m *UpdateEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateEntryRequest.Unmarshal(m, b)
@@ -232,7 +232,7 @@ func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
 func (m *FederatedBundle) String() string { return proto.CompactTextString(m) }
 func (*FederatedBundle) ProtoMessage()    {}
 func (*FederatedBundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{4}
+	return fileDescriptor_registration_646abbc430e07df2, []int{4}
 }
 func (m *FederatedBundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FederatedBundle.Unmarshal(m, b)
@@ -286,7 +286,7 @@ func (m *CreateFederatedBundleRequest) Reset()         { *m = CreateFederatedBun
 func (m *CreateFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateFederatedBundleRequest) ProtoMessage()    {}
 func (*CreateFederatedBundleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{5}
+	return fileDescriptor_registration_646abbc430e07df2, []int{5}
 }
 func (m *CreateFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateFederatedBundleRequest.Unmarshal(m, b)
@@ -326,7 +326,7 @@ func (m *ListFederatedBundlesReply) Reset()         { *m = ListFederatedBundlesR
 func (m *ListFederatedBundlesReply) String() string { return proto.CompactTextString(m) }
 func (*ListFederatedBundlesReply) ProtoMessage()    {}
 func (*ListFederatedBundlesReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{6}
+	return fileDescriptor_registration_646abbc430e07df2, []int{6}
 }
 func (m *ListFederatedBundlesReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListFederatedBundlesReply.Unmarshal(m, b)
@@ -366,7 +366,7 @@ func (m *FederatedSpiffeID) Reset()         { *m = FederatedSpiffeID{} }
 func (m *FederatedSpiffeID) String() string { return proto.CompactTextString(m) }
 func (*FederatedSpiffeID) ProtoMessage()    {}
 func (*FederatedSpiffeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{7}
+	return fileDescriptor_registration_646abbc430e07df2, []int{7}
 }
 func (m *FederatedSpiffeID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FederatedSpiffeID.Unmarshal(m, b)
@@ -408,7 +408,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{8}
+	return fileDescriptor_registration_646abbc430e07df2, []int{8}
 }
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
@@ -455,7 +455,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_registration_24dc75133328fa1a, []int{9}
+	return fileDescriptor_registration_646abbc430e07df2, []int{9}
 }
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bundle.Unmarshal(m, b)
@@ -482,6 +482,96 @@ func (m *Bundle) GetCaCerts() []byte {
 	return nil
 }
 
+// A request to revoke X509-SVIDs
+type RevokeSVIDsRequest struct {
+	// Serial number, in decimal, of the SVID to revoke
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	// ID of the entry all the SVIDs of which are revoked, if no serial
+	// number is set
+	EntryId              string   `protobuf:"bytes,2,opt,name=entry_id,json=entryId" json:"entry_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeSVIDsRequest) Reset()         { *m = RevokeSVIDsRequest{} }
+func (m *RevokeSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeSVIDsRequest) ProtoMessage()    {}
+func (*RevokeSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_646abbc430e07df2, []int{10}
+}
+func (m *RevokeSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeSVIDsRequest.Unmarshal(m, b)
+}
+func (m *RevokeSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (dst *RevokeSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeSVIDsRequest.Merge(dst, src)
+}
+func (m *RevokeSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeSVIDsRequest.Size(m)
+}
+func (m *RevokeSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeSVIDsRequest proto.InternalMessageInfo
+
+func (m *RevokeSVIDsRequest) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *RevokeSVIDsRequest) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+// A reply with the revoked X509-SVIDs
+type RevokeSVIDsReply struct {
+	// Serial numbers, in decimal, of the revoked SVIDs
+	SerialNumbers        []string `protobuf:"bytes,1,rep,name=serial_numbers,json=serialNumbers" json:"serial_numbers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeSVIDsReply) Reset()         { *m = RevokeSVIDsReply{} }
+func (m *RevokeSVIDsReply) String() string { return proto.CompactTextString(m) }
+func (*RevokeSVIDsReply) ProtoMessage()    {}
+func (*RevokeSVIDsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_registration_646abbc430e07df2, []int{11}
+}
+func (m *RevokeSVIDsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeSVIDsReply.Unmarshal(m, b)
+}
+func (m *RevokeSVIDsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeSVIDsReply.Marshal(b, m, deterministic)
+}
+func (dst *RevokeSVIDsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeSVIDsReply.Merge(dst, src)
+}
+func (m *RevokeSVIDsReply) XXX_Size() int {
+	return xxx_messageInfo_RevokeSVIDsReply.Size(m)
+}
+func (m *RevokeSVIDsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeSVIDsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeSVIDsReply proto.InternalMessageInfo
+
+func (m *RevokeSVIDsReply) GetSerialNumbers() []string {
+	if m != nil {
+		return m.SerialNumbers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RegistrationEntryID)(nil), "spire.api.registration.RegistrationEntryID")
 	proto.RegisterType((*ParentID)(nil), "spire.api.registration.ParentID")
@@ -493,6 +583,8 @@ func init() {
 	proto.RegisterType((*FederatedSpiffeID)(nil), "spire.api.registration.FederatedSpiffeID")
 	proto.RegisterType((*JoinToken)(nil), "spire.api.registration.JoinToken")
 	proto.RegisterType((*Bundle)(nil), "spire.api.registration.Bundle")
+	proto.RegisterType((*RevokeSVIDsRequest)(nil), "spire.api.registration.RevokeSVIDsRequest")
+	proto.RegisterType((*RevokeSVIDsReply)(nil), "spire.api.registration.RevokeSVIDsReply")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -516,6 +608,12 @@ type RegistrationClient interface {
 	FetchEntries(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*common.RegistrationEntries, error)
 	// Updates a specific registered entry.
 	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*common.RegistrationEntry, error)
+	// Forces the X509-SVIDs issued for an entry to be rotated, along with
+	// their keys, and returns the updated entry.
+	RotateEntrySVIDs(ctx context.Context, in *RegistrationEntryID, opts ...grpc.CallOption) (*common.RegistrationEntry, error)
+	// Revokes an X509-SVID, or all the X509-SVIDs issued for an entry, which
+	// are then listed in the CRL of the server CA.
+	RevokeSVIDs(ctx context.Context, in *RevokeSVIDsRequest, opts ...grpc.CallOption) (*RevokeSVIDsReply, error)
 	// Returns all the Entries associated with the ParentID value.
 	ListByParentID(ctx context.Context, in *ParentID, opts ...grpc.CallOption) (*common.RegistrationEntries, error)
 	// Returns all the entries associated with a selector value.
@@ -589,6 +687,24 @@ func (c *registrationClient) UpdateEntry(ctx context.Context, in *UpdateEntryReq
 	return out, nil
 }
 
+func (c *registrationClient) RotateEntrySVIDs(ctx context.Context, in *RegistrationEntryID, opts ...grpc.CallOption) (*common.RegistrationEntry, error) {
+	out := new(common.RegistrationEntry)
+	err := grpc.Invoke(ctx, "/spire.api.registration.Registration/RotateEntrySVIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) RevokeSVIDs(ctx context.Context, in *RevokeSVIDsRequest, opts ...grpc.CallOption) (*RevokeSVIDsReply, error) {
+	out := new(RevokeSVIDsReply)
+	err := grpc.Invoke(ctx, "/spire.api.registration.Registration/RevokeSVIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) ListByParentID(ctx context.Context, in *ParentID, opts ...grpc.CallOption) (*common.RegistrationEntries, error) {
 	out := new(common.RegistrationEntries)
 	err := grpc.Invoke(ctx, "/spire.api.registration.Registration/ListByParentID", in, out, c.cc, opts...)
@@ -683,6 +799,12 @@ type RegistrationServer interface {
 	FetchEntries(context.Context, *common.Empty) (*common.RegistrationEntries, error)
 	// Updates a specific registered entry.
 	UpdateEntry(context.Context, *UpdateEntryRequest) (*common.RegistrationEntry, error)
+	// Forces the X509-SVIDs issued for an entry to be rotated, along with
+	// their keys, and returns the updated entry.
+	RotateEntrySVIDs(context.Context, *RegistrationEntryID) (*common.RegistrationEntry, error)
+	// Revokes an X509-SVID, or all the X509-SVIDs issued for an entry, which
+	// are then listed in the CRL of the server CA.
+	RevokeSVIDs(context.Context, *RevokeSVIDsRequest) (*RevokeSVIDsReply, error)
 	// Returns all the Entries associated with the ParentID value.
 	ListByParentID(context.Context, *ParentID) (*common.RegistrationEntries, error)
 	// Returns all the entries associated with a selector value.
@@ -797,6 +919,42 @@ func _Registration_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_RotateEntrySVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegistrationEntryID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).RotateEntrySVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/RotateEntrySVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).RotateEntrySVIDs(ctx, req.(*RegistrationEntryID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_RevokeSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).RevokeSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/RevokeSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).RevokeSVIDs(ctx, req.(*RevokeSVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_ListByParentID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ParentID)
 	if err := dec(in); err != nil {
@@ -983,6 +1141,14 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateEntry",
 			Handler:    _Registration_UpdateEntry_Handler,
 		},
+		{
+			MethodName: "RotateEntrySVIDs",
+			Handler:    _Registration_RotateEntrySVIDs_Handler,
+		},
+		{
+			MethodName: "RevokeSVIDs",
+			Handler:    _Registration_RevokeSVIDs_Handler,
+		},
 		{
 			MethodName: "ListByParentID",
 			Handler:    _Registration_ListByParentID_Handler,
@@ -1024,50 +1190,56 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 	Metadata: "registration.proto",
 }
 
-func init() { proto.RegisterFile("registration.proto", fileDescriptor_registration_24dc75133328fa1a) }
-
-var fileDescriptor_registration_24dc75133328fa1a = []byte{
-	// 663 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcf, 0x4e, 0xdb, 0x4e,
-	0x10, 0xfe, 0x39, 0x08, 0x08, 0xe3, 0xfc, 0x02, 0x2c, 0x7f, 0x14, 0x5c, 0xd4, 0x06, 0xa3, 0xaa,
-	0x40, 0x25, 0x5b, 0x40, 0x7b, 0xe9, 0xad, 0xe1, 0x8f, 0x44, 0xdb, 0x03, 0x32, 0xa5, 0x95, 0x5a,
-	0x09, 0xe4, 0xd8, 0x93, 0xb0, 0xc5, 0xf1, 0xba, 0xf6, 0xe6, 0x10, 0x55, 0xbd, 0xf4, 0x15, 0xfa,
-	0x1a, 0x7d, 0x9b, 0xbe, 0x42, 0x1f, 0xa4, 0xca, 0xae, 0xd7, 0x04, 0x63, 0x93, 0x70, 0xe8, 0x29,
-	0xde, 0x9d, 0x99, 0xef, 0x9b, 0x6f, 0x66, 0x67, 0x02, 0x24, 0xc6, 0x2e, 0x4d, 0x78, 0xec, 0x72,
-	0xca, 0x42, 0x2b, 0x8a, 0x19, 0x67, 0x64, 0x35, 0x89, 0x68, 0x8c, 0x96, 0x1b, 0x51, 0x6b, 0xd4,
-	0x6a, 0xac, 0x77, 0x19, 0xeb, 0x06, 0x68, 0xbb, 0x11, 0xb5, 0xdd, 0x30, 0x64, 0x5c, 0x5c, 0x27,
-	0x32, 0xca, 0xd8, 0xed, 0x52, 0x7e, 0xd5, 0x6f, 0x5b, 0x1e, 0xeb, 0xd9, 0x49, 0x44, 0x3b, 0x1d,
-	0xb4, 0x05, 0x8e, 0x2d, 0xcc, 0xb6, 0xc7, 0x7a, 0x3d, 0x16, 0xa6, 0x3f, 0x32, 0xc4, 0x7c, 0x0a,
-	0x4b, 0xce, 0x08, 0xc1, 0x51, 0xc8, 0xe3, 0xc1, 0xc9, 0x21, 0xa9, 0x43, 0x85, 0xfa, 0x0d, 0xad,
-	0xa9, 0x6d, 0xcd, 0x39, 0x15, 0xea, 0x9b, 0x06, 0x54, 0x4f, 0xdd, 0x18, 0x43, 0x5e, 0x6c, 0x3b,
-	0x13, 0x64, 0x05, 0xb6, 0xcf, 0x40, 0xce, 0x23, 0xdf, 0xe5, 0x28, 0x80, 0x1d, 0xfc, 0xda, 0xc7,
-	0x84, 0xe7, 0xbd, 0xc8, 0x4b, 0x98, 0xc6, 0xa1, 0xbd, 0x51, 0x69, 0x6a, 0x5b, 0xfa, 0xde, 0x13,
-	0x4b, 0xaa, 0x4f, 0x13, 0xbd, 0x93, 0x9f, 0x23, 0xbd, 0xcd, 0x6b, 0x98, 0x3f, 0x46, 0x1f, 0x63,
-	0x97, 0xa3, 0xdf, 0xea, 0x87, 0x7e, 0x80, 0xe4, 0x11, 0xcc, 0x49, 0xe1, 0x97, 0x19, 0x41, 0x55,
-	0x5e, 0x9c, 0xf8, 0x64, 0x1b, 0x16, 0x3a, 0xca, 0xff, 0xb2, 0x2d, 0x02, 0x04, 0x63, 0xcd, 0x99,
-	0xef, 0xe4, 0x70, 0x16, 0x60, 0x8a, 0xf3, 0xa0, 0x31, 0xd5, 0xd4, 0xb6, 0xa6, 0x9d, 0xe1, 0xa7,
-	0x19, 0xc3, 0xfa, 0x41, 0x8c, 0x2e, 0xc7, 0x1c, 0xa5, 0xd2, 0xe4, 0x14, 0x80, 0x6b, 0x42, 0xce,
-	0x33, 0xab, 0xb8, 0x99, 0x56, 0x1e, 0x29, 0x9f, 0x85, 0x79, 0x01, 0x6b, 0xef, 0x68, 0xc2, 0x73,
-	0x7e, 0x89, 0x83, 0x51, 0x30, 0x20, 0xaf, 0x61, 0x56, 0xd2, 0x24, 0x0d, 0xad, 0x39, 0xf5, 0x10,
-	0x1e, 0x15, 0x67, 0x6e, 0xc2, 0x62, 0x66, 0x2b, 0x6d, 0xe1, 0x3e, 0xcc, 0xbd, 0x61, 0x34, 0x7c,
-	0xcf, 0xae, 0x31, 0x24, 0xcb, 0x30, 0xcd, 0x87, 0x1f, 0xa9, 0x5d, 0x1e, 0x54, 0xb5, 0x2a, 0x37,
-	0xd5, 0xda, 0x84, 0x99, 0xb4, 0x92, 0x6b, 0x50, 0xf5, 0xdc, 0x4b, 0x0f, 0x63, 0x9e, 0x88, 0xa0,
-	0x9a, 0x33, 0xeb, 0xb9, 0x07, 0xc3, 0xe3, 0xde, 0x2f, 0x80, 0xda, 0x68, 0x73, 0x49, 0x08, 0xba,
-	0xac, 0xb1, 0x68, 0x33, 0x19, 0xf7, 0x0e, 0x8c, 0xe7, 0x65, 0x8a, 0x0b, 0x9e, 0xb4, 0xb9, 0xf8,
-	0xe3, 0xf7, 0x9f, 0x9f, 0x15, 0xdd, 0x9c, 0xb1, 0xc5, 0xeb, 0x79, 0xa5, 0xed, 0x90, 0x6b, 0xd0,
-	0x0f, 0x31, 0x40, 0xc5, 0xf7, 0x10, 0x38, 0x63, 0x5c, 0x72, 0x66, 0x5d, 0xf0, 0x55, 0x77, 0x52,
-	0x3e, 0xc2, 0x00, 0x8e, 0x91, 0x7b, 0x57, 0xff, 0x82, 0x6b, 0x49, 0x70, 0xfd, 0x4f, 0x74, 0xc9,
-	0x65, 0x7f, 0xa3, 0xfe, 0x77, 0xf2, 0x01, 0x6a, 0x19, 0x21, 0xc5, 0x84, 0x2c, 0xdd, 0x46, 0x39,
-	0xea, 0x45, 0x7c, 0x60, 0x6c, 0xdc, 0x0f, 0x4d, 0x31, 0x51, 0x42, 0x88, 0x12, 0xf2, 0x05, 0xf4,
-	0x91, 0x99, 0x26, 0x3b, 0x65, 0x4a, 0xee, 0x0e, 0xfe, 0xc4, 0x45, 0x33, 0x14, 0xd7, 0x39, 0xd4,
-	0x87, 0x13, 0xd0, 0x1a, 0x64, 0xdb, 0xa7, 0x59, 0x46, 0xa7, 0x3c, 0x26, 0x90, 0x44, 0xde, 0x2a,
-	0xd8, 0x33, 0x0c, 0xd0, 0xe3, 0x2c, 0x26, 0xab, 0xb7, 0x83, 0xd4, 0xfd, 0x24, 0x60, 0x59, 0x8e,
-	0xd9, 0x08, 0x95, 0xe6, 0xa8, 0x3c, 0x26, 0x81, 0x6d, 0xc3, 0x4a, 0xe1, 0xc2, 0x21, 0x2f, 0xca,
-	0xd0, 0xef, 0xdb, 0x4f, 0x46, 0x51, 0xf7, 0xc9, 0x05, 0x2c, 0x17, 0x2d, 0x98, 0xe2, 0xa7, 0xb2,
-	0x5b, 0xc6, 0x5b, 0xbe, 0xa3, 0xce, 0x61, 0x45, 0xbe, 0x82, 0xbc, 0x86, 0x49, 0x77, 0x55, 0x71,
-	0xda, 0x1f, 0x61, 0x45, 0xce, 0x6d, 0x1e, 0x76, 0x7b, 0x2c, 0x6c, 0xd6, 0x81, 0x12, 0xe0, 0x79,
-	0x59, 0xc4, 0x9b, 0x8d, 0xb7, 0x51, 0x06, 0x99, 0xb9, 0x18, 0xe3, 0x5d, 0x48, 0x0b, 0x74, 0x31,
-	0x8b, 0x69, 0x9e, 0x85, 0xf5, 0x7d, 0x5c, 0x06, 0x23, 0x83, 0x5a, 0xf5, 0x4f, 0xb5, 0xd1, 0xeb,
-	0xd3, 0xff, 0x4e, 0xb5, 0xf6, 0x8c, 0xf8, 0x17, 0xdf, 0xff, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x00,
-	0xe9, 0x25, 0x8f, 0x44, 0x08, 0x00, 0x00,
+func init() { proto.RegisterFile("registration.proto", fileDescriptor_registration_646abbc430e07df2) }
+
+var fileDescriptor_registration_646abbc430e07df2 = []byte{
+	// 755 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0xc5, 0xa9, 0xda, 0x26, 0xe3, 0x34, 0x4d, 0xb7, 0x17, 0xa5, 0xa6, 0x82, 0xd4, 0x55, 0x45,
+	0x5a, 0xa4, 0x58, 0x6d, 0xe1, 0x01, 0xde, 0x48, 0x2f, 0x52, 0x00, 0xa1, 0xca, 0xbd, 0x20, 0x81,
+	0xd4, 0xe0, 0xd8, 0x93, 0xd4, 0x24, 0xf1, 0x1a, 0x7b, 0x83, 0x14, 0x21, 0x5e, 0xf8, 0x05, 0x3e,
+	0x8d, 0x5f, 0xe0, 0x0f, 0xf8, 0x01, 0xe4, 0xdd, 0xd8, 0x4d, 0x5c, 0xbb, 0x49, 0x25, 0x78, 0x8a,
+	0xbd, 0x33, 0x73, 0xce, 0xdc, 0xf6, 0x38, 0x40, 0x3c, 0x6c, 0xdb, 0x3e, 0xf3, 0x0c, 0x66, 0x53,
+	0xa7, 0xea, 0x7a, 0x94, 0x51, 0xb2, 0xe6, 0xbb, 0xb6, 0x87, 0x55, 0xc3, 0xb5, 0xab, 0xa3, 0x56,
+	0x65, 0xa3, 0x4d, 0x69, 0xbb, 0x8b, 0x9a, 0xe1, 0xda, 0x9a, 0xe1, 0x38, 0x94, 0xf1, 0x63, 0x5f,
+	0x44, 0x29, 0x7b, 0x6d, 0x9b, 0x5d, 0xf7, 0x9b, 0x55, 0x93, 0xf6, 0x34, 0xdf, 0xb5, 0x5b, 0x2d,
+	0xd4, 0x38, 0x8e, 0xc6, 0xcd, 0x9a, 0x49, 0x7b, 0x3d, 0xea, 0x0c, 0x7f, 0x44, 0x88, 0xba, 0x0d,
+	0xcb, 0xfa, 0x08, 0xc1, 0xb1, 0xc3, 0xbc, 0x41, 0xfd, 0x88, 0x14, 0x20, 0x63, 0x5b, 0x25, 0xa9,
+	0x2c, 0x55, 0x72, 0x7a, 0xc6, 0xb6, 0x54, 0x05, 0xb2, 0xa7, 0x86, 0x87, 0x0e, 0x4b, 0xb6, 0x9d,
+	0x71, 0xb2, 0x04, 0xdb, 0x47, 0x20, 0x17, 0xae, 0x65, 0x30, 0xe4, 0xc0, 0x3a, 0x7e, 0xe9, 0xa3,
+	0xcf, 0xe2, 0x5e, 0xe4, 0x39, 0xcc, 0x62, 0x60, 0x2f, 0x65, 0xca, 0x52, 0x45, 0xde, 0x7f, 0x5c,
+	0x15, 0xd5, 0x0f, 0x13, 0xbd, 0x95, 0x9f, 0x2e, 0xbc, 0xd5, 0x0e, 0x2c, 0x9e, 0xa0, 0x85, 0x9e,
+	0xc1, 0xd0, 0xaa, 0xf5, 0x1d, 0xab, 0x8b, 0xe4, 0x21, 0xe4, 0x44, 0xe1, 0x8d, 0x88, 0x20, 0x2b,
+	0x0e, 0xea, 0x16, 0xd9, 0x81, 0x62, 0x2b, 0xf4, 0x6f, 0x34, 0x79, 0x00, 0x67, 0xcc, 0xeb, 0x8b,
+	0xad, 0x18, 0x4e, 0x11, 0x66, 0x18, 0xeb, 0x96, 0x66, 0xca, 0x52, 0x65, 0x56, 0x0f, 0x1e, 0x55,
+	0x0f, 0x36, 0x0e, 0x3d, 0x34, 0x18, 0xc6, 0x28, 0xc3, 0x9a, 0xf4, 0x04, 0x70, 0x89, 0x97, 0xf3,
+	0xa4, 0x9a, 0x3c, 0xcc, 0x6a, 0x1c, 0x29, 0x9e, 0x85, 0x7a, 0x05, 0xeb, 0x6f, 0x6d, 0x9f, 0xc5,
+	0xfc, 0x7c, 0x1d, 0xdd, 0xee, 0x80, 0xbc, 0x82, 0x79, 0x41, 0xe3, 0x97, 0xa4, 0xf2, 0xcc, 0x7d,
+	0x78, 0xc2, 0x38, 0x75, 0x0b, 0x96, 0x22, 0x5b, 0xea, 0x08, 0x0f, 0x20, 0xf7, 0x9a, 0xda, 0xce,
+	0x39, 0xed, 0xa0, 0x43, 0x56, 0x60, 0x96, 0x05, 0x0f, 0x43, 0xbb, 0x78, 0x09, 0xbb, 0x95, 0xb9,
+	0xe9, 0xd6, 0x16, 0xcc, 0x0d, 0x3b, 0xb9, 0x0e, 0x59, 0xd3, 0x68, 0x98, 0xe8, 0x31, 0x9f, 0x07,
+	0xe5, 0xf5, 0x79, 0xd3, 0x38, 0x0c, 0x5e, 0xd5, 0x73, 0x20, 0x3a, 0x7e, 0xa5, 0x1d, 0x3c, 0xbb,
+	0xac, 0x1f, 0xf9, 0x61, 0x23, 0xb7, 0x60, 0xc1, 0x47, 0xcf, 0x36, 0xba, 0x0d, 0xa7, 0xdf, 0x6b,
+	0xa2, 0x37, 0xa4, 0xca, 0x8b, 0xc3, 0x77, 0xfc, 0x2c, 0x40, 0xe5, 0x3b, 0x10, 0x8c, 0x39, 0xc3,
+	0xed, 0xf3, 0xfc, 0xbd, 0x6e, 0xa9, 0x2f, 0xa0, 0x38, 0x86, 0x1a, 0xf4, 0x6a, 0x1b, 0x0a, 0x63,
+	0x98, 0xa2, 0x65, 0x39, 0x7d, 0x61, 0x14, 0xd4, 0xdf, 0xff, 0x23, 0x43, 0x7e, 0x74, 0xdb, 0x88,
+	0x03, 0xb2, 0x18, 0x3a, 0xdf, 0x3b, 0x32, 0x69, 0x31, 0x95, 0xa7, 0x69, 0x23, 0x48, 0xb8, 0x63,
+	0xea, 0xd2, 0x8f, 0x5f, 0xbf, 0x7f, 0x66, 0x64, 0x75, 0x4e, 0xe3, 0xa9, 0xbf, 0x94, 0x76, 0x49,
+	0x07, 0xe4, 0x23, 0xec, 0x62, 0xc8, 0x77, 0x1f, 0x38, 0x65, 0x52, 0x72, 0x6a, 0x81, 0xf3, 0x65,
+	0x77, 0x87, 0x7c, 0x84, 0x02, 0x9c, 0x20, 0x33, 0xaf, 0xff, 0x07, 0xd7, 0x32, 0xe7, 0x5a, 0x20,
+	0xb2, 0xe0, 0xd2, 0xbe, 0xd9, 0xd6, 0x77, 0x72, 0x09, 0xf9, 0x88, 0xd0, 0x46, 0x9f, 0x2c, 0x8f,
+	0xa3, 0x1c, 0xf7, 0x5c, 0x36, 0x50, 0x36, 0xef, 0x86, 0xb6, 0xd1, 0x0f, 0x0b, 0x21, 0x61, 0x21,
+	0x9f, 0x41, 0x1e, 0x11, 0x19, 0xb2, 0x9b, 0x56, 0xc9, 0x6d, 0x25, 0x9a, 0xba, 0x69, 0x4a, 0xc8,
+	0xf5, 0x09, 0x8a, 0x3a, 0x65, 0x21, 0x0c, 0x5f, 0xb1, 0x7f, 0xdb, 0x3a, 0x62, 0x82, 0x3c, 0xb2,
+	0xbf, 0xe9, 0xd5, 0xdc, 0xbe, 0x3a, 0x4a, 0x65, 0x2a, 0xdf, 0xe0, 0x42, 0x5c, 0x40, 0x21, 0x50,
+	0x96, 0xda, 0x20, 0x52, 0xf5, 0x72, 0x5a, 0x6c, 0xe8, 0x31, 0xc5, 0x64, 0xc8, 0x9b, 0x10, 0xf6,
+	0x0c, 0xbb, 0x68, 0x32, 0xea, 0x91, 0xb5, 0xf1, 0xa0, 0xf0, 0x7c, 0x1a, 0xb0, 0x28, 0xc7, 0x48,
+	0x9a, 0x52, 0x73, 0x0c, 0x3d, 0xa6, 0x81, 0x6d, 0xc2, 0x6a, 0xa2, 0x90, 0x93, 0x67, 0x69, 0xe8,
+	0x77, 0xe9, 0xbe, 0x92, 0xb4, 0xc4, 0xe4, 0x0a, 0x56, 0x92, 0x84, 0x3b, 0x79, 0xe3, 0xf7, 0xd2,
+	0x78, 0xd3, 0xb5, 0xff, 0x02, 0x56, 0xc5, 0x32, 0xc7, 0x6b, 0x98, 0xf6, 0x1b, 0x90, 0x9c, 0xf6,
+	0x7b, 0x58, 0x15, 0xf2, 0x13, 0x87, 0xdd, 0x99, 0x08, 0x1b, 0x4d, 0x20, 0x05, 0x78, 0x51, 0x34,
+	0xf1, 0xe6, 0x4b, 0xb2, 0x99, 0x06, 0x19, 0xb9, 0x28, 0x93, 0x5d, 0x48, 0x0d, 0x64, 0x2e, 0x29,
+	0xc3, 0x3c, 0x13, 0xfb, 0xfb, 0x28, 0x0d, 0x46, 0x04, 0xd5, 0x0a, 0x1f, 0xf2, 0xa3, 0xc7, 0xa7,
+	0x0f, 0x4e, 0xa5, 0xe6, 0x1c, 0xff, 0x77, 0x74, 0xf0, 0x77, 0x00, 0xf9, 0x7c, 0x2b, 0xf0, 0x9c,
+	0x09, 0x00, 0x00,
 }
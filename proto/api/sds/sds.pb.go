@@ -0,0 +1,400 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: sds.proto
+
+package sds
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import any "github.com/golang/protobuf/ptypes/any"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Identifies the Envoy instance making a request
+// (envoy.config.core.v3.Node).
+type Node struct {
+	// An opaque node identifier.
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// The cluster the node belongs to.
+	Cluster              string   `protobuf:"bytes,2,opt,name=cluster" json:"cluster,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sds_ea0f052ee1d4fdc4, []int{0}
+}
+func (m *Node) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Node.Unmarshal(m, b)
+}
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+}
+func (dst *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(dst, src)
+}
+func (m *Node) XXX_Size() int {
+	return xxx_messageInfo_Node.Size(m)
+}
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Node proto.InternalMessageInfo
+
+func (m *Node) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Node) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
+// A request for secrets
+// (envoy.service.discovery.v3.DiscoveryRequest).
+type DiscoveryRequest struct {
+	// The version of the last response successfully applied, empty on the
+	// first request.
+	VersionInfo string `protobuf:"bytes,1,opt,name=version_info,json=versionInfo" json:"version_info,omitempty"`
+	// The node making the request.
+	Node *Node `protobuf:"bytes,2,opt,name=node" json:"node,omitempty"`
+	// The names of the secrets being requested. All secrets are requested
+	// if empty.
+	ResourceNames []string `protobuf:"bytes,3,rep,name=resource_names,json=resourceNames" json:"resource_names,omitempty"`
+	// The type of the resources being requested.
+	TypeUrl string `protobuf:"bytes,4,opt,name=type_url,json=typeUrl" json:"type_url,omitempty"`
+	// The nonce of the response this request acknowledges or rejects.
+	ResponseNonce        string   `protobuf:"bytes,5,opt,name=response_nonce,json=responseNonce" json:"response_nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscoveryRequest) Reset()         { *m = DiscoveryRequest{} }
+func (m *DiscoveryRequest) String() string { return proto.CompactTextString(m) }
+func (*DiscoveryRequest) ProtoMessage()    {}
+func (*DiscoveryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sds_ea0f052ee1d4fdc4, []int{1}
+}
+func (m *DiscoveryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiscoveryRequest.Unmarshal(m, b)
+}
+func (m *DiscoveryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiscoveryRequest.Marshal(b, m, deterministic)
+}
+func (dst *DiscoveryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscoveryRequest.Merge(dst, src)
+}
+func (m *DiscoveryRequest) XXX_Size() int {
+	return xxx_messageInfo_DiscoveryRequest.Size(m)
+}
+func (m *DiscoveryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscoveryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscoveryRequest proto.InternalMessageInfo
+
+func (m *DiscoveryRequest) GetVersionInfo() string {
+	if m != nil {
+		return m.VersionInfo
+	}
+	return ""
+}
+
+func (m *DiscoveryRequest) GetNode() *Node {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func (m *DiscoveryRequest) GetResourceNames() []string {
+	if m != nil {
+		return m.ResourceNames
+	}
+	return nil
+}
+
+func (m *DiscoveryRequest) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *DiscoveryRequest) GetResponseNonce() string {
+	if m != nil {
+		return m.ResponseNonce
+	}
+	return ""
+}
+
+// A response carrying secrets
+// (envoy.service.discovery.v3.DiscoveryResponse).
+type DiscoveryResponse struct {
+	// The version of the response.
+	VersionInfo string `protobuf:"bytes,1,opt,name=version_info,json=versionInfo" json:"version_info,omitempty"`
+	// The secrets, packed as
+	// envoy.extensions.transport_sockets.tls.v3.Secret messages.
+	Resources []*any.Any `protobuf:"bytes,2,rep,name=resources" json:"resources,omitempty"`
+	// The type of the resources in the response.
+	TypeUrl string `protobuf:"bytes,4,opt,name=type_url,json=typeUrl" json:"type_url,omitempty"`
+	// The nonce Envoy echoes back when acknowledging the response.
+	Nonce                string   `protobuf:"bytes,5,opt,name=nonce" json:"nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiscoveryResponse) Reset()         { *m = DiscoveryResponse{} }
+func (m *DiscoveryResponse) String() string { return proto.CompactTextString(m) }
+func (*DiscoveryResponse) ProtoMessage()    {}
+func (*DiscoveryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_sds_ea0f052ee1d4fdc4, []int{2}
+}
+func (m *DiscoveryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiscoveryResponse.Unmarshal(m, b)
+}
+func (m *DiscoveryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiscoveryResponse.Marshal(b, m, deterministic)
+}
+func (dst *DiscoveryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscoveryResponse.Merge(dst, src)
+}
+func (m *DiscoveryResponse) XXX_Size() int {
+	return xxx_messageInfo_DiscoveryResponse.Size(m)
+}
+func (m *DiscoveryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscoveryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscoveryResponse proto.InternalMessageInfo
+
+func (m *DiscoveryResponse) GetVersionInfo() string {
+	if m != nil {
+		return m.VersionInfo
+	}
+	return ""
+}
+
+func (m *DiscoveryResponse) GetResources() []*any.Any {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *DiscoveryResponse) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *DiscoveryResponse) GetNonce() string {
+	if m != nil {
+		return m.Nonce
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Node)(nil), "envoy.service.secret.v3.Node")
+	proto.RegisterType((*DiscoveryRequest)(nil), "envoy.service.secret.v3.DiscoveryRequest")
+	proto.RegisterType((*DiscoveryResponse)(nil), "envoy.service.secret.v3.DiscoveryResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for SecretDiscoveryService service
+
+type SecretDiscoveryServiceClient interface {
+	// Streams secrets to Envoy, sending updates as they change.
+	StreamSecrets(ctx context.Context, opts ...grpc.CallOption) (SecretDiscoveryService_StreamSecretsClient, error)
+	// Fetches the current secrets.
+	FetchSecrets(ctx context.Context, in *DiscoveryRequest, opts ...grpc.CallOption) (*DiscoveryResponse, error)
+}
+
+type secretDiscoveryServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSecretDiscoveryServiceClient(cc *grpc.ClientConn) SecretDiscoveryServiceClient {
+	return &secretDiscoveryServiceClient{cc}
+}
+
+func (c *secretDiscoveryServiceClient) StreamSecrets(ctx context.Context, opts ...grpc.CallOption) (SecretDiscoveryService_StreamSecretsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SecretDiscoveryService_serviceDesc.Streams[0], c.cc, "/envoy.service.secret.v3.SecretDiscoveryService/StreamSecrets", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &secretDiscoveryServiceStreamSecretsClient{stream}
+	return x, nil
+}
+
+type SecretDiscoveryService_StreamSecretsClient interface {
+	Send(*DiscoveryRequest) error
+	Recv() (*DiscoveryResponse, error)
+	grpc.ClientStream
+}
+
+type secretDiscoveryServiceStreamSecretsClient struct {
+	grpc.ClientStream
+}
+
+func (x *secretDiscoveryServiceStreamSecretsClient) Send(m *DiscoveryRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *secretDiscoveryServiceStreamSecretsClient) Recv() (*DiscoveryResponse, error) {
+	m := new(DiscoveryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *secretDiscoveryServiceClient) FetchSecrets(ctx context.Context, in *DiscoveryRequest, opts ...grpc.CallOption) (*DiscoveryResponse, error) {
+	out := new(DiscoveryResponse)
+	err := grpc.Invoke(ctx, "/envoy.service.secret.v3.SecretDiscoveryService/FetchSecrets", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SecretDiscoveryService service
+
+type SecretDiscoveryServiceServer interface {
+	// Streams secrets to Envoy, sending updates as they change.
+	StreamSecrets(SecretDiscoveryService_StreamSecretsServer) error
+	// Fetches the current secrets.
+	FetchSecrets(context.Context, *DiscoveryRequest) (*DiscoveryResponse, error)
+}
+
+func RegisterSecretDiscoveryServiceServer(s *grpc.Server, srv SecretDiscoveryServiceServer) {
+	s.RegisterService(&_SecretDiscoveryService_serviceDesc, srv)
+}
+
+func _SecretDiscoveryService_StreamSecrets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SecretDiscoveryServiceServer).StreamSecrets(&secretDiscoveryServiceStreamSecretsServer{stream})
+}
+
+type SecretDiscoveryService_StreamSecretsServer interface {
+	Send(*DiscoveryResponse) error
+	Recv() (*DiscoveryRequest, error)
+	grpc.ServerStream
+}
+
+type secretDiscoveryServiceStreamSecretsServer struct {
+	grpc.ServerStream
+}
+
+func (x *secretDiscoveryServiceStreamSecretsServer) Send(m *DiscoveryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *secretDiscoveryServiceStreamSecretsServer) Recv() (*DiscoveryRequest, error) {
+	m := new(DiscoveryRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SecretDiscoveryService_FetchSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscoveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretDiscoveryServiceServer).FetchSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envoy.service.secret.v3.SecretDiscoveryService/FetchSecrets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretDiscoveryServiceServer).FetchSecrets(ctx, req.(*DiscoveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SecretDiscoveryService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "envoy.service.secret.v3.SecretDiscoveryService",
+	HandlerType: (*SecretDiscoveryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FetchSecrets",
+			Handler:    _SecretDiscoveryService_FetchSecrets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSecrets",
+			Handler:       _SecretDiscoveryService_StreamSecrets_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "sds.proto",
+}
+
+func init() { proto.RegisterFile("sds.proto", fileDescriptor_sds_ea0f052ee1d4fdc4) }
+
+var fileDescriptor_sds_ea0f052ee1d4fdc4 = []byte{
+	// 362 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x91, 0xdf, 0x4a, 0xe3, 0x40,
+	0x14, 0xc6, 0x99, 0xa4, 0xdd, 0xdd, 0x4c, 0xff, 0xb0, 0x3b, 0x94, 0xdd, 0xb4, 0xb0, 0x10, 0x0b,
+	0x42, 0xf4, 0x62, 0x5a, 0xd3, 0x27, 0x50, 0x44, 0xf0, 0xa6, 0x17, 0x29, 0xde, 0x78, 0x13, 0xda,
+	0xe4, 0xb4, 0x46, 0xd2, 0x99, 0x3a, 0x27, 0x09, 0xe4, 0x59, 0x7c, 0x2a, 0x9f, 0xc3, 0x97, 0x90,
+	0x64, 0x12, 0x2d, 0x42, 0xa5, 0x37, 0x5e, 0x9e, 0xef, 0x7c, 0xf9, 0xf2, 0x9d, 0xdf, 0x50, 0x0b,
+	0x23, 0xe4, 0x3b, 0x25, 0x53, 0xc9, 0xfe, 0x81, 0xc8, 0x65, 0xc1, 0x11, 0x54, 0x1e, 0x87, 0xc0,
+	0x11, 0x42, 0x05, 0x29, 0xcf, 0x67, 0xa3, 0xe1, 0x46, 0xca, 0x4d, 0x02, 0x93, 0xca, 0xb6, 0xca,
+	0xd6, 0x93, 0xa5, 0x28, 0xf4, 0x37, 0xe3, 0x29, 0x6d, 0xcd, 0x65, 0x04, 0xac, 0x4f, 0x8d, 0x38,
+	0xb2, 0x89, 0x43, 0x5c, 0xcb, 0x37, 0xe2, 0x88, 0xd9, 0xf4, 0x67, 0x98, 0x64, 0x98, 0x82, 0xb2,
+	0x8d, 0x4a, 0x6c, 0xc6, 0xf1, 0x0b, 0xa1, 0xbf, 0xaf, 0x63, 0x0c, 0x65, 0x0e, 0xaa, 0xf0, 0xe1,
+	0x29, 0x03, 0x4c, 0xd9, 0x09, 0xed, 0xe6, 0xa0, 0x30, 0x96, 0x22, 0x88, 0xc5, 0x5a, 0xd6, 0x41,
+	0x9d, 0x5a, 0xbb, 0x15, 0x6b, 0xc9, 0x2e, 0x68, 0x4b, 0xc8, 0x08, 0xaa, 0xb8, 0x8e, 0xf7, 0x9f,
+	0x1f, 0x28, 0xcb, 0xcb, 0x3a, 0x7e, 0x65, 0x65, 0xa7, 0xb4, 0xaf, 0x00, 0x65, 0xa6, 0x42, 0x08,
+	0xc4, 0x72, 0x0b, 0x68, 0x9b, 0x8e, 0xe9, 0x5a, 0x7e, 0xaf, 0x51, 0xe7, 0xa5, 0xc8, 0x86, 0xf4,
+	0x57, 0x5a, 0xec, 0x20, 0xc8, 0x54, 0x62, 0xb7, 0x74, 0xd9, 0x72, 0xbe, 0x53, 0x49, 0x9d, 0xb0,
+	0x93, 0x02, 0x21, 0x10, 0x52, 0x84, 0x60, 0xb7, 0x1d, 0x52, 0x27, 0x54, 0xea, 0xbc, 0x14, 0xc7,
+	0xcf, 0x84, 0xfe, 0xd9, 0xbb, 0x49, 0xaf, 0x8e, 0x39, 0xca, 0xa3, 0x56, 0xd3, 0x05, 0x6d, 0xc3,
+	0x31, 0xdd, 0x8e, 0x37, 0xe0, 0x9a, 0x36, 0x6f, 0x68, 0xf3, 0x4b, 0x51, 0xf8, 0x1f, 0xb6, 0xaf,
+	0xea, 0x0e, 0x68, 0x7b, 0xbf, 0xa5, 0x1e, 0xbc, 0x57, 0x42, 0xff, 0x2e, 0x2a, 0x3e, 0xef, 0x1d,
+	0x17, 0x1a, 0x1b, 0x7b, 0xa4, 0xbd, 0x45, 0xaa, 0x60, 0xb9, 0xd5, 0x7b, 0x64, 0x67, 0x07, 0xb9,
+	0x7e, 0x7e, 0xb3, 0xd1, 0xf9, 0x31, 0x56, 0x8d, 0xc2, 0x25, 0x53, 0xc2, 0x80, 0x76, 0x6f, 0x20,
+	0x0d, 0x1f, 0xbe, 0xf7, 0x57, 0x57, 0xed, 0x7b, 0x13, 0x23, 0x5c, 0xfd, 0xa8, 0xf0, 0xcd, 0xde,
+	0x06, 0x00, 0x76, 0x2b, 0x38, 0xbf, 0xe0, 0x02, 0x00, 0x00,
+}
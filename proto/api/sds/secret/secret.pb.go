@@ -0,0 +1,499 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: secret.proto
+
+package secret
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import any "github.com/golang/protobuf/ptypes/any"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Data source consisting of inline bytes
+// (envoy.config.core.v3.DataSource).
+type DataSource struct {
+	// Bytes inlined in the configuration.
+	InlineBytes          []byte   `protobuf:"bytes,2,opt,name=inline_bytes,json=inlineBytes,proto3" json:"inline_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataSource) Reset()         { *m = DataSource{} }
+func (m *DataSource) String() string { return proto.CompactTextString(m) }
+func (*DataSource) ProtoMessage()    {}
+func (*DataSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{0}
+}
+func (m *DataSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataSource.Unmarshal(m, b)
+}
+func (m *DataSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataSource.Marshal(b, m, deterministic)
+}
+func (dst *DataSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataSource.Merge(dst, src)
+}
+func (m *DataSource) XXX_Size() int {
+	return xxx_messageInfo_DataSource.Size(m)
+}
+func (m *DataSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataSource proto.InternalMessageInfo
+
+func (m *DataSource) GetInlineBytes() []byte {
+	if m != nil {
+		return m.InlineBytes
+	}
+	return nil
+}
+
+// Typed extension configuration
+// (envoy.config.core.v3.TypedExtensionConfig).
+type TypedExtensionConfig struct {
+	// The name of the extension.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The typed configuration of the extension.
+	TypedConfig          *any.Any `protobuf:"bytes,2,opt,name=typed_config,json=typedConfig" json:"typed_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TypedExtensionConfig) Reset()         { *m = TypedExtensionConfig{} }
+func (m *TypedExtensionConfig) String() string { return proto.CompactTextString(m) }
+func (*TypedExtensionConfig) ProtoMessage()    {}
+func (*TypedExtensionConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{1}
+}
+func (m *TypedExtensionConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TypedExtensionConfig.Unmarshal(m, b)
+}
+func (m *TypedExtensionConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TypedExtensionConfig.Marshal(b, m, deterministic)
+}
+func (dst *TypedExtensionConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TypedExtensionConfig.Merge(dst, src)
+}
+func (m *TypedExtensionConfig) XXX_Size() int {
+	return xxx_messageInfo_TypedExtensionConfig.Size(m)
+}
+func (m *TypedExtensionConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_TypedExtensionConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TypedExtensionConfig proto.InternalMessageInfo
+
+func (m *TypedExtensionConfig) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TypedExtensionConfig) GetTypedConfig() *any.Any {
+	if m != nil {
+		return m.TypedConfig
+	}
+	return nil
+}
+
+// A TLS certificate and its private key.
+type TlsCertificate struct {
+	// The PEM encoded certificate chain.
+	CertificateChain *DataSource `protobuf:"bytes,1,opt,name=certificate_chain,json=certificateChain" json:"certificate_chain,omitempty"`
+	// The PEM encoded private key.
+	PrivateKey           *DataSource `protobuf:"bytes,2,opt,name=private_key,json=privateKey" json:"private_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TlsCertificate) Reset()         { *m = TlsCertificate{} }
+func (m *TlsCertificate) String() string { return proto.CompactTextString(m) }
+func (*TlsCertificate) ProtoMessage()    {}
+func (*TlsCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{2}
+}
+func (m *TlsCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TlsCertificate.Unmarshal(m, b)
+}
+func (m *TlsCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TlsCertificate.Marshal(b, m, deterministic)
+}
+func (dst *TlsCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TlsCertificate.Merge(dst, src)
+}
+func (m *TlsCertificate) XXX_Size() int {
+	return xxx_messageInfo_TlsCertificate.Size(m)
+}
+func (m *TlsCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_TlsCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TlsCertificate proto.InternalMessageInfo
+
+func (m *TlsCertificate) GetCertificateChain() *DataSource {
+	if m != nil {
+		return m.CertificateChain
+	}
+	return nil
+}
+
+func (m *TlsCertificate) GetPrivateKey() *DataSource {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+// The context used to validate peer certificates.
+type CertificateValidationContext struct {
+	// The PEM encoded CA certificates to trust.
+	TrustedCa *DataSource `protobuf:"bytes,1,opt,name=trusted_ca,json=trustedCa" json:"trusted_ca,omitempty"`
+	// A custom certificate validator, used instead of trusted_ca.
+	CustomValidatorConfig *TypedExtensionConfig `protobuf:"bytes,12,opt,name=custom_validator_config,json=customValidatorConfig" json:"custom_validator_config,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}              `json:"-"`
+	XXX_unrecognized      []byte                `json:"-"`
+	XXX_sizecache         int32                 `json:"-"`
+}
+
+func (m *CertificateValidationContext) Reset()         { *m = CertificateValidationContext{} }
+func (m *CertificateValidationContext) String() string { return proto.CompactTextString(m) }
+func (*CertificateValidationContext) ProtoMessage()    {}
+func (*CertificateValidationContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{3}
+}
+func (m *CertificateValidationContext) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CertificateValidationContext.Unmarshal(m, b)
+}
+func (m *CertificateValidationContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CertificateValidationContext.Marshal(b, m, deterministic)
+}
+func (dst *CertificateValidationContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CertificateValidationContext.Merge(dst, src)
+}
+func (m *CertificateValidationContext) XXX_Size() int {
+	return xxx_messageInfo_CertificateValidationContext.Size(m)
+}
+func (m *CertificateValidationContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_CertificateValidationContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CertificateValidationContext proto.InternalMessageInfo
+
+func (m *CertificateValidationContext) GetTrustedCa() *DataSource {
+	if m != nil {
+		return m.TrustedCa
+	}
+	return nil
+}
+
+func (m *CertificateValidationContext) GetCustomValidatorConfig() *TypedExtensionConfig {
+	if m != nil {
+		return m.CustomValidatorConfig
+	}
+	return nil
+}
+
+// Configuration of the SPIFFE certificate validator
+// (envoy.tls.cert_validator.spiffe).
+type SPIFFECertValidatorConfig struct {
+	// The trust domains peers may belong to.
+	TrustDomains         []*SPIFFECertValidatorConfig_TrustDomain `protobuf:"bytes,1,rep,name=trust_domains,json=trustDomains" json:"trust_domains,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                 `json:"-"`
+	XXX_unrecognized     []byte                                   `json:"-"`
+	XXX_sizecache        int32                                    `json:"-"`
+}
+
+func (m *SPIFFECertValidatorConfig) Reset()         { *m = SPIFFECertValidatorConfig{} }
+func (m *SPIFFECertValidatorConfig) String() string { return proto.CompactTextString(m) }
+func (*SPIFFECertValidatorConfig) ProtoMessage()    {}
+func (*SPIFFECertValidatorConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{4}
+}
+func (m *SPIFFECertValidatorConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SPIFFECertValidatorConfig.Unmarshal(m, b)
+}
+func (m *SPIFFECertValidatorConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SPIFFECertValidatorConfig.Marshal(b, m, deterministic)
+}
+func (dst *SPIFFECertValidatorConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SPIFFECertValidatorConfig.Merge(dst, src)
+}
+func (m *SPIFFECertValidatorConfig) XXX_Size() int {
+	return xxx_messageInfo_SPIFFECertValidatorConfig.Size(m)
+}
+func (m *SPIFFECertValidatorConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_SPIFFECertValidatorConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SPIFFECertValidatorConfig proto.InternalMessageInfo
+
+func (m *SPIFFECertValidatorConfig) GetTrustDomains() []*SPIFFECertValidatorConfig_TrustDomain {
+	if m != nil {
+		return m.TrustDomains
+	}
+	return nil
+}
+
+// A trust domain and its bundle.
+type SPIFFECertValidatorConfig_TrustDomain struct {
+	// The name of the trust domain, e.g. "example.org".
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// The PEM encoded CA certificates of the trust domain.
+	TrustBundle          *DataSource `protobuf:"bytes,2,opt,name=trust_bundle,json=trustBundle" json:"trust_bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *SPIFFECertValidatorConfig_TrustDomain) Reset()         { *m = SPIFFECertValidatorConfig_TrustDomain{} }
+func (m *SPIFFECertValidatorConfig_TrustDomain) String() string { return proto.CompactTextString(m) }
+func (*SPIFFECertValidatorConfig_TrustDomain) ProtoMessage()    {}
+func (*SPIFFECertValidatorConfig_TrustDomain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{4, 0}
+}
+func (m *SPIFFECertValidatorConfig_TrustDomain) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SPIFFECertValidatorConfig_TrustDomain.Unmarshal(m, b)
+}
+func (m *SPIFFECertValidatorConfig_TrustDomain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SPIFFECertValidatorConfig_TrustDomain.Marshal(b, m, deterministic)
+}
+func (dst *SPIFFECertValidatorConfig_TrustDomain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SPIFFECertValidatorConfig_TrustDomain.Merge(dst, src)
+}
+func (m *SPIFFECertValidatorConfig_TrustDomain) XXX_Size() int {
+	return xxx_messageInfo_SPIFFECertValidatorConfig_TrustDomain.Size(m)
+}
+func (m *SPIFFECertValidatorConfig_TrustDomain) XXX_DiscardUnknown() {
+	xxx_messageInfo_SPIFFECertValidatorConfig_TrustDomain.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SPIFFECertValidatorConfig_TrustDomain proto.InternalMessageInfo
+
+func (m *SPIFFECertValidatorConfig_TrustDomain) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SPIFFECertValidatorConfig_TrustDomain) GetTrustBundle() *DataSource {
+	if m != nil {
+		return m.TrustBundle
+	}
+	return nil
+}
+
+// A named secret.
+type Secret struct {
+	// The name Envoy uses to refer to the secret.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Types that are valid to be assigned to Type:
+	//	*Secret_TlsCertificate
+	//	*Secret_ValidationContext
+	Type                 isSecret_Type `protobuf_oneof:"type"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Secret) Reset()         { *m = Secret{} }
+func (m *Secret) String() string { return proto.CompactTextString(m) }
+func (*Secret) ProtoMessage()    {}
+func (*Secret) Descriptor() ([]byte, []int) {
+	return fileDescriptor_secret_e4d6b479882ef737, []int{5}
+}
+func (m *Secret) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Secret.Unmarshal(m, b)
+}
+func (m *Secret) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Secret.Marshal(b, m, deterministic)
+}
+func (dst *Secret) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Secret.Merge(dst, src)
+}
+func (m *Secret) XXX_Size() int {
+	return xxx_messageInfo_Secret.Size(m)
+}
+func (m *Secret) XXX_DiscardUnknown() {
+	xxx_messageInfo_Secret.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Secret proto.InternalMessageInfo
+
+type isSecret_Type interface {
+	isSecret_Type()
+}
+
+type Secret_TlsCertificate struct {
+	TlsCertificate *TlsCertificate `protobuf:"bytes,2,opt,name=tls_certificate,json=tlsCertificate,oneof"`
+}
+type Secret_ValidationContext struct {
+	ValidationContext *CertificateValidationContext `protobuf:"bytes,4,opt,name=validation_context,json=validationContext,oneof"`
+}
+
+func (*Secret_TlsCertificate) isSecret_Type()    {}
+func (*Secret_ValidationContext) isSecret_Type() {}
+
+func (m *Secret) GetType() isSecret_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *Secret) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Secret) GetTlsCertificate() *TlsCertificate {
+	if x, ok := m.GetType().(*Secret_TlsCertificate); ok {
+		return x.TlsCertificate
+	}
+	return nil
+}
+
+func (m *Secret) GetValidationContext() *CertificateValidationContext {
+	if x, ok := m.GetType().(*Secret_ValidationContext); ok {
+		return x.ValidationContext
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*Secret) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _Secret_OneofMarshaler, _Secret_OneofUnmarshaler, _Secret_OneofSizer, []interface{}{
+		(*Secret_TlsCertificate)(nil),
+		(*Secret_ValidationContext)(nil),
+	}
+}
+
+func _Secret_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*Secret)
+	// type
+	switch x := m.Type.(type) {
+	case *Secret_TlsCertificate:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.TlsCertificate); err != nil {
+			return err
+		}
+	case *Secret_ValidationContext:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ValidationContext); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("Secret.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _Secret_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*Secret)
+	switch tag {
+	case 2: // type.tls_certificate
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(TlsCertificate)
+		err := b.DecodeMessage(msg)
+		m.Type = &Secret_TlsCertificate{msg}
+		return true, err
+	case 4: // type.validation_context
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(CertificateValidationContext)
+		err := b.DecodeMessage(msg)
+		m.Type = &Secret_ValidationContext{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _Secret_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*Secret)
+	// type
+	switch x := m.Type.(type) {
+	case *Secret_TlsCertificate:
+		s := proto.Size(x.TlsCertificate)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *Secret_ValidationContext:
+		s := proto.Size(x.ValidationContext)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+func init() {
+	proto.RegisterType((*DataSource)(nil), "envoy.extensions.transport_sockets.tls.v3.DataSource")
+	proto.RegisterType((*TypedExtensionConfig)(nil), "envoy.extensions.transport_sockets.tls.v3.TypedExtensionConfig")
+	proto.RegisterType((*TlsCertificate)(nil), "envoy.extensions.transport_sockets.tls.v3.TlsCertificate")
+	proto.RegisterType((*CertificateValidationContext)(nil), "envoy.extensions.transport_sockets.tls.v3.CertificateValidationContext")
+	proto.RegisterType((*SPIFFECertValidatorConfig)(nil), "envoy.extensions.transport_sockets.tls.v3.SPIFFECertValidatorConfig")
+	proto.RegisterType((*SPIFFECertValidatorConfig_TrustDomain)(nil), "envoy.extensions.transport_sockets.tls.v3.SPIFFECertValidatorConfig.TrustDomain")
+	proto.RegisterType((*Secret)(nil), "envoy.extensions.transport_sockets.tls.v3.Secret")
+}
+
+func init() { proto.RegisterFile("secret.proto", fileDescriptor_secret_e4d6b479882ef737) }
+
+var fileDescriptor_secret_e4d6b479882ef737 = []byte{
+	// 493 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x4d, 0x6f, 0xd3, 0x30,
+	0x18, 0xc7, 0x97, 0x52, 0x55, 0xec, 0x49, 0x18, 0xcc, 0x1a, 0xa2, 0x9b, 0x38, 0x94, 0x9e, 0xca,
+	0xc5, 0x95, 0x3a, 0x21, 0xc4, 0x09, 0xd1, 0x6e, 0x63, 0x88, 0xcb, 0x94, 0x56, 0x15, 0xe2, 0x12,
+	0x39, 0x8e, 0x5b, 0xac, 0xa5, 0x76, 0x15, 0x3f, 0x09, 0x8d, 0xb8, 0x72, 0xe7, 0x8b, 0xf1, 0x55,
+	0xf8, 0x0e, 0x28, 0x76, 0x4b, 0xbb, 0xa9, 0x20, 0xaa, 0xde, 0xe2, 0xb7, 0xdf, 0xff, 0x79, 0xf9,
+	0x3f, 0x81, 0xc0, 0x08, 0x9e, 0x09, 0xa4, 0xf3, 0x4c, 0xa3, 0x26, 0x2f, 0x85, 0x2a, 0x74, 0x49,
+	0xc5, 0x02, 0x85, 0x32, 0x52, 0x2b, 0x43, 0x31, 0x63, 0xca, 0xcc, 0x75, 0x86, 0x91, 0xd1, 0xfc,
+	0x56, 0xa0, 0xa1, 0x98, 0x1a, 0x5a, 0x9c, 0x9f, 0x9d, 0x4e, 0xb5, 0x9e, 0xa6, 0xa2, 0x6b, 0x1f,
+	0xc6, 0xf9, 0xa4, 0xcb, 0x54, 0xe9, 0x28, 0xed, 0x2e, 0xc0, 0x05, 0x43, 0x36, 0xd4, 0x79, 0xc6,
+	0x05, 0x79, 0x01, 0x81, 0x54, 0xa9, 0x54, 0x22, 0x8a, 0x4b, 0x14, 0xa6, 0x59, 0x6b, 0x79, 0x9d,
+	0x20, 0xf4, 0xdd, 0x5e, 0xbf, 0xda, 0x6a, 0x73, 0x38, 0x19, 0x95, 0x73, 0x91, 0x5c, 0xae, 0x74,
+	0x07, 0x5a, 0x4d, 0xe4, 0x94, 0x10, 0xa8, 0x2b, 0x36, 0x13, 0x4d, 0xaf, 0xe5, 0x75, 0x0e, 0x43,
+	0xfb, 0x4d, 0x5e, 0x43, 0x80, 0xd5, 0xdd, 0x88, 0xdb, 0x3b, 0x16, 0xe7, 0xf7, 0x4e, 0xa8, 0x0b,
+	0x87, 0xae, 0xc2, 0xa1, 0xef, 0x54, 0x19, 0xfa, 0xf6, 0xa6, 0x83, 0xb5, 0x7f, 0x7a, 0x70, 0x34,
+	0x4a, 0xcd, 0x40, 0x64, 0x28, 0x27, 0x92, 0x33, 0x14, 0x24, 0x86, 0x63, 0xbe, 0x5e, 0x46, 0xfc,
+	0x0b, 0x93, 0xca, 0x8a, 0xf9, 0xbd, 0x57, 0xf4, 0xbf, 0x4b, 0x41, 0xd7, 0xc9, 0x86, 0x4f, 0x36,
+	0x78, 0x83, 0x0a, 0x47, 0xc6, 0xe0, 0xcf, 0x33, 0x59, 0x54, 0xfc, 0x5b, 0x51, 0x36, 0x6b, 0xfb,
+	0xd0, 0x61, 0x49, 0xfa, 0x28, 0xca, 0xf6, 0x2f, 0x0f, 0x9e, 0x6f, 0xe4, 0x32, 0x66, 0xa9, 0x4c,
+	0x18, 0xba, 0xda, 0xa1, 0x58, 0x20, 0x19, 0x01, 0x60, 0x96, 0x1b, 0xac, 0x4a, 0xc5, 0xf6, 0xcb,
+	0xea, 0x70, 0x09, 0x1a, 0x30, 0xf2, 0x15, 0x9e, 0xf1, 0xdc, 0xa0, 0x9e, 0x45, 0x85, 0x53, 0xd4,
+	0xd9, 0xaa, 0x13, 0x81, 0x95, 0x78, 0xbb, 0x83, 0xc4, 0xb6, 0xa6, 0x87, 0x4f, 0x1d, 0x7f, 0xbc,
+	0xc2, 0x2f, 0xdb, 0xf7, 0xa3, 0x06, 0xa7, 0xc3, 0x9b, 0x0f, 0x57, 0x57, 0x97, 0x55, 0xd6, 0xf7,
+	0x4e, 0x49, 0x0e, 0x8f, 0x6c, 0x8c, 0x51, 0xa2, 0x67, 0x4c, 0x2a, 0xd3, 0xf4, 0x5a, 0x0f, 0x3a,
+	0x7e, 0xef, 0x66, 0x87, 0x60, 0xfe, 0x0a, 0xa7, 0xa3, 0x8a, 0x7c, 0x61, 0xc1, 0x61, 0x80, 0xeb,
+	0x85, 0x39, 0xfb, 0x06, 0xfe, 0xc6, 0xe1, 0x56, 0xbf, 0x7e, 0x02, 0xf7, 0x24, 0x8a, 0x73, 0x95,
+	0xa4, 0x62, 0x3f, 0x03, 0xf8, 0x16, 0xd5, 0xb7, 0xa4, 0xf6, 0xf7, 0x1a, 0x34, 0x86, 0x76, 0x7a,
+	0xb7, 0x0a, 0x27, 0xf0, 0x18, 0x53, 0x13, 0x6d, 0x18, 0x72, 0xa9, 0xfd, 0x66, 0x97, 0x0e, 0xdd,
+	0x19, 0x98, 0xeb, 0x83, 0xf0, 0x08, 0xef, 0x8e, 0xd0, 0x02, 0x48, 0xf1, 0xc7, 0x7a, 0x11, 0x77,
+	0xde, 0x6b, 0xd6, 0xad, 0xd0, 0xfb, 0x1d, 0x84, 0xfe, 0x65, 0xe5, 0xeb, 0x83, 0xf0, 0xb8, 0xb8,
+	0xbf, 0xd9, 0x6f, 0x40, 0xbd, 0x1a, 0xef, 0xfe, 0xc3, 0xcf, 0x0d, 0xf7, 0x0f, 0x8b, 0x1b, 0x76,
+	0xf8, 0xcf, 0x7f, 0x0f, 0x00, 0x8e, 0xef, 0xc8, 0x9c, 0xd4, 0x04, 0x00, 0x00,
+}
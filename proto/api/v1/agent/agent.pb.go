@@ -0,0 +1,930 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: agent.proto
+
+package agent
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/spiffe/spire/proto/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Empty from public import github.com/spiffe/spire/proto/common/common.proto
+type Empty = common.Empty
+
+// AttestationData from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestationData = common.AttestationData
+
+// Selector from public import github.com/spiffe/spire/proto/common/common.proto
+type Selector = common.Selector
+
+// Selectors from public import github.com/spiffe/spire/proto/common/common.proto
+type Selectors = common.Selectors
+
+// RegistrationEntry from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntry = common.RegistrationEntry
+
+// RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntries = common.RegistrationEntries
+
+// An agent that has attested to the server.
+type AttestedAgent struct {
+	// SPIFFE ID of the agent.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// Type of attestation the agent performed.
+	AttestationType string `protobuf:"bytes,2,opt,name=attestation_type,json=attestationType" json:"attestation_type,omitempty"`
+	// Serial number of the agent SVID.
+	CertSerialNumber string `protobuf:"bytes,3,opt,name=cert_serial_number,json=certSerialNumber" json:"cert_serial_number,omitempty"`
+	// Expiration date of the agent SVID.
+	CertExpirationDate string `protobuf:"bytes,4,opt,name=cert_expiration_date,json=certExpirationDate" json:"cert_expiration_date,omitempty"`
+	// Selectors resolved for the agent.
+	Selectors []*common.Selector `protobuf:"bytes,5,rep,name=selectors" json:"selectors,omitempty"`
+	// Whether the agent has been banned.
+	Banned               bool     `protobuf:"varint,6,opt,name=banned" json:"banned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttestedAgent) Reset()         { *m = AttestedAgent{} }
+func (m *AttestedAgent) String() string { return proto.CompactTextString(m) }
+func (*AttestedAgent) ProtoMessage()    {}
+func (*AttestedAgent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{0}
+}
+func (m *AttestedAgent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttestedAgent.Unmarshal(m, b)
+}
+func (m *AttestedAgent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttestedAgent.Marshal(b, m, deterministic)
+}
+func (dst *AttestedAgent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttestedAgent.Merge(dst, src)
+}
+func (m *AttestedAgent) XXX_Size() int {
+	return xxx_messageInfo_AttestedAgent.Size(m)
+}
+func (m *AttestedAgent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttestedAgent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttestedAgent proto.InternalMessageInfo
+
+func (m *AttestedAgent) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *AttestedAgent) GetAttestationType() string {
+	if m != nil {
+		return m.AttestationType
+	}
+	return ""
+}
+
+func (m *AttestedAgent) GetCertSerialNumber() string {
+	if m != nil {
+		return m.CertSerialNumber
+	}
+	return ""
+}
+
+func (m *AttestedAgent) GetCertExpirationDate() string {
+	if m != nil {
+		return m.CertExpirationDate
+	}
+	return ""
+}
+
+func (m *AttestedAgent) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *AttestedAgent) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+// Represents a request to list the attested agents.
+type ListAgentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
+func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAgentsRequest) ProtoMessage()    {}
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{1}
+}
+func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentsRequest.Unmarshal(m, b)
+}
+func (m *ListAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentsRequest.Merge(dst, src)
+}
+func (m *ListAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAgentsRequest.Size(m)
+}
+func (m *ListAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
+
+// Represents a list of attested agents.
+type ListAgentsResponse struct {
+	// The attested agents.
+	Agents               []*AttestedAgent `protobuf:"bytes,1,rep,name=agents" json:"agents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
+func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAgentsResponse) ProtoMessage()    {}
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{2}
+}
+func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentsResponse.Unmarshal(m, b)
+}
+func (m *ListAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentsResponse.Merge(dst, src)
+}
+func (m *ListAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAgentsResponse.Size(m)
+}
+func (m *ListAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentsResponse proto.InternalMessageInfo
+
+func (m *ListAgentsResponse) GetAgents() []*AttestedAgent {
+	if m != nil {
+		return m.Agents
+	}
+	return nil
+}
+
+// Represents a request to retrieve an attested agent.
+type GetAgentRequest struct {
+	// SPIFFE ID of the agent.
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAgentRequest) Reset()         { *m = GetAgentRequest{} }
+func (m *GetAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAgentRequest) ProtoMessage()    {}
+func (*GetAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{3}
+}
+func (m *GetAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAgentRequest.Unmarshal(m, b)
+}
+func (m *GetAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAgentRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAgentRequest.Merge(dst, src)
+}
+func (m *GetAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAgentRequest.Size(m)
+}
+func (m *GetAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAgentRequest proto.InternalMessageInfo
+
+func (m *GetAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents the retrieved attested agent.
+type GetAgentResponse struct {
+	// The attested agent.
+	Agent                *AttestedAgent `protobuf:"bytes,1,opt,name=agent" json:"agent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetAgentResponse) Reset()         { *m = GetAgentResponse{} }
+func (m *GetAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAgentResponse) ProtoMessage()    {}
+func (*GetAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{4}
+}
+func (m *GetAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAgentResponse.Unmarshal(m, b)
+}
+func (m *GetAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAgentResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAgentResponse.Merge(dst, src)
+}
+func (m *GetAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_GetAgentResponse.Size(m)
+}
+func (m *GetAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAgentResponse proto.InternalMessageInfo
+
+func (m *GetAgentResponse) GetAgent() *AttestedAgent {
+	if m != nil {
+		return m.Agent
+	}
+	return nil
+}
+
+// Represents a request to delete an attested agent.
+type DeleteAgentRequest struct {
+	// SPIFFE ID of the agent.
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteAgentRequest) Reset()         { *m = DeleteAgentRequest{} }
+func (m *DeleteAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteAgentRequest) ProtoMessage()    {}
+func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{5}
+}
+func (m *DeleteAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAgentRequest.Unmarshal(m, b)
+}
+func (m *DeleteAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAgentRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAgentRequest.Merge(dst, src)
+}
+func (m *DeleteAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteAgentRequest.Size(m)
+}
+func (m *DeleteAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAgentRequest proto.InternalMessageInfo
+
+func (m *DeleteAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents the deleted attested agent.
+type DeleteAgentResponse struct {
+	// The deleted agent.
+	Agent                *AttestedAgent `protobuf:"bytes,1,opt,name=agent" json:"agent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *DeleteAgentResponse) Reset()         { *m = DeleteAgentResponse{} }
+func (m *DeleteAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteAgentResponse) ProtoMessage()    {}
+func (*DeleteAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{6}
+}
+func (m *DeleteAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteAgentResponse.Unmarshal(m, b)
+}
+func (m *DeleteAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteAgentResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteAgentResponse.Merge(dst, src)
+}
+func (m *DeleteAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteAgentResponse.Size(m)
+}
+func (m *DeleteAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteAgentResponse proto.InternalMessageInfo
+
+func (m *DeleteAgentResponse) GetAgent() *AttestedAgent {
+	if m != nil {
+		return m.Agent
+	}
+	return nil
+}
+
+// Represents a request to ban an attested agent.
+type BanAgentRequest struct {
+	// SPIFFE ID of the agent.
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanAgentRequest) Reset()         { *m = BanAgentRequest{} }
+func (m *BanAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*BanAgentRequest) ProtoMessage()    {}
+func (*BanAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{7}
+}
+func (m *BanAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentRequest.Unmarshal(m, b)
+}
+func (m *BanAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentRequest.Marshal(b, m, deterministic)
+}
+func (dst *BanAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentRequest.Merge(dst, src)
+}
+func (m *BanAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_BanAgentRequest.Size(m)
+}
+func (m *BanAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentRequest proto.InternalMessageInfo
+
+func (m *BanAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents the banned agent.
+type BanAgentResponse struct {
+	// The banned agent.
+	Agent                *AttestedAgent `protobuf:"bytes,1,opt,name=agent" json:"agent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BanAgentResponse) Reset()         { *m = BanAgentResponse{} }
+func (m *BanAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*BanAgentResponse) ProtoMessage()    {}
+func (*BanAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{8}
+}
+func (m *BanAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentResponse.Unmarshal(m, b)
+}
+func (m *BanAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentResponse.Marshal(b, m, deterministic)
+}
+func (dst *BanAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentResponse.Merge(dst, src)
+}
+func (m *BanAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_BanAgentResponse.Size(m)
+}
+func (m *BanAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentResponse proto.InternalMessageInfo
+
+func (m *BanAgentResponse) GetAgent() *AttestedAgent {
+	if m != nil {
+		return m.Agent
+	}
+	return nil
+}
+
+// Represents a request to create a join token.
+type CreateJoinTokenRequest struct {
+	// The join token. If not set, one will be generated.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// TTL in seconds.
+	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{} }
+func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateJoinTokenRequest) ProtoMessage()    {}
+func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{9}
+}
+func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *CreateJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJoinTokenRequest.Merge(dst, src)
+}
+func (m *CreateJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateJoinTokenRequest.Size(m)
+}
+func (m *CreateJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJoinTokenRequest proto.InternalMessageInfo
+
+func (m *CreateJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CreateJoinTokenRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+// Represents the created join token.
+type CreateJoinTokenResponse struct {
+	// The join token.
+	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	// Expiration date, represented in UNIX time.
+	ExpiresAt int64 `protobuf:"varint,2,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	// SPIFFE ID the agent attesting with this token will be issued.
+	AgentSpiffeId        string   `protobuf:"bytes,3,opt,name=agent_spiffe_id,json=agentSpiffeId" json:"agent_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse{} }
+func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateJoinTokenResponse) ProtoMessage()    {}
+func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{10}
+}
+func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *CreateJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJoinTokenResponse.Merge(dst, src)
+}
+func (m *CreateJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateJoinTokenResponse.Size(m)
+}
+func (m *CreateJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJoinTokenResponse proto.InternalMessageInfo
+
+func (m *CreateJoinTokenResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CreateJoinTokenResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *CreateJoinTokenResponse) GetAgentSpiffeId() string {
+	if m != nil {
+		return m.AgentSpiffeId
+	}
+	return ""
+}
+
+// Represents a request to delete a join token.
+type DeleteJoinTokenRequest struct {
+	// The join token.
+	Token                string   `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{} }
+func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteJoinTokenRequest) ProtoMessage()    {}
+func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{11}
+}
+func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *DeleteJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJoinTokenRequest.Merge(dst, src)
+}
+func (m *DeleteJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Size(m)
+}
+func (m *DeleteJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJoinTokenRequest proto.InternalMessageInfo
+
+func (m *DeleteJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// Represents the deletion of a join token.
+type DeleteJoinTokenResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse{} }
+func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteJoinTokenResponse) ProtoMessage()    {}
+func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_agent_92663a5e438943c8, []int{12}
+}
+func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *DeleteJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJoinTokenResponse.Merge(dst, src)
+}
+func (m *DeleteJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Size(m)
+}
+func (m *DeleteJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJoinTokenResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*AttestedAgent)(nil), "spire.api.v1.agent.AttestedAgent")
+	proto.RegisterType((*ListAgentsRequest)(nil), "spire.api.v1.agent.ListAgentsRequest")
+	proto.RegisterType((*ListAgentsResponse)(nil), "spire.api.v1.agent.ListAgentsResponse")
+	proto.RegisterType((*GetAgentRequest)(nil), "spire.api.v1.agent.GetAgentRequest")
+	proto.RegisterType((*GetAgentResponse)(nil), "spire.api.v1.agent.GetAgentResponse")
+	proto.RegisterType((*DeleteAgentRequest)(nil), "spire.api.v1.agent.DeleteAgentRequest")
+	proto.RegisterType((*DeleteAgentResponse)(nil), "spire.api.v1.agent.DeleteAgentResponse")
+	proto.RegisterType((*BanAgentRequest)(nil), "spire.api.v1.agent.BanAgentRequest")
+	proto.RegisterType((*BanAgentResponse)(nil), "spire.api.v1.agent.BanAgentResponse")
+	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.api.v1.agent.CreateJoinTokenRequest")
+	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.api.v1.agent.CreateJoinTokenResponse")
+	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.api.v1.agent.DeleteJoinTokenRequest")
+	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.api.v1.agent.DeleteJoinTokenResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Agent service
+
+type AgentClient interface {
+	// Lists all the attested agents.
+	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// Retrieves an attested agent by its SPIFFE ID.
+	GetAgent(ctx context.Context, in *GetAgentRequest, opts ...grpc.CallOption) (*GetAgentResponse, error)
+	// Deletes an attested agent. The agent must attest again before it
+	// can renew its SVID.
+	DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*DeleteAgentResponse, error)
+	// Bans an attested agent. The agent can't attest again until it is
+	// deleted.
+	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error)
+	// Creates a join token that can be used to attest an agent.
+	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
+	// Deletes a join token that has not been used yet, so that no agent can
+	// attest with it.
+	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+}
+
+type agentClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAgentClient(cc *grpc.ClientConn) AgentClient {
+	return &agentClient{cc}
+}
+
+func (c *agentClient) ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error) {
+	out := new(ListAgentsResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.agent.Agent/ListAgents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetAgent(ctx context.Context, in *GetAgentRequest, opts ...grpc.CallOption) (*GetAgentResponse, error) {
+	out := new(GetAgentResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.agent.Agent/GetAgent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteAgent(ctx context.Context, in *DeleteAgentRequest, opts ...grpc.CallOption) (*DeleteAgentResponse, error) {
+	out := new(DeleteAgentResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.agent.Agent/DeleteAgent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error) {
+	out := new(BanAgentResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.agent.Agent/BanAgent", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
+	out := new(CreateJoinTokenResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.agent.Agent/CreateJoinToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
+	out := new(DeleteJoinTokenResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.agent.Agent/DeleteJoinToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Agent service
+
+type AgentServer interface {
+	// Lists all the attested agents.
+	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// Retrieves an attested agent by its SPIFFE ID.
+	GetAgent(context.Context, *GetAgentRequest) (*GetAgentResponse, error)
+	// Deletes an attested agent. The agent must attest again before it
+	// can renew its SVID.
+	DeleteAgent(context.Context, *DeleteAgentRequest) (*DeleteAgentResponse, error)
+	// Bans an attested agent. The agent can't attest again until it is
+	// deleted.
+	BanAgent(context.Context, *BanAgentRequest) (*BanAgentResponse, error)
+	// Creates a join token that can be used to attest an agent.
+	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
+	// Deletes a join token that has not been used yet, so that no agent can
+	// attest with it.
+	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
+}
+
+func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
+	s.RegisterService(&_Agent_serviceDesc, srv)
+}
+
+func _Agent_ListAgents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAgentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListAgents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.agent.Agent/ListAgents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListAgents(ctx, req.(*ListAgentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.agent.Agent/GetAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetAgent(ctx, req.(*GetAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.agent.Agent/DeleteAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteAgent(ctx, req.(*DeleteAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_BanAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).BanAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.agent.Agent/BanAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).BanAgent(ctx, req.(*BanAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).CreateJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.agent.Agent/CreateJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).CreateJoinToken(ctx, req.(*CreateJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).DeleteJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.agent.Agent/DeleteJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).DeleteJoinToken(ctx, req.(*DeleteJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Agent_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.v1.agent.Agent",
+	HandlerType: (*AgentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAgents",
+			Handler:    _Agent_ListAgents_Handler,
+		},
+		{
+			MethodName: "GetAgent",
+			Handler:    _Agent_GetAgent_Handler,
+		},
+		{
+			MethodName: "DeleteAgent",
+			Handler:    _Agent_DeleteAgent_Handler,
+		},
+		{
+			MethodName: "BanAgent",
+			Handler:    _Agent_BanAgent_Handler,
+		},
+		{
+			MethodName: "CreateJoinToken",
+			Handler:    _Agent_CreateJoinToken_Handler,
+		},
+		{
+			MethodName: "DeleteJoinToken",
+			Handler:    _Agent_DeleteJoinToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "agent.proto",
+}
+
+func init() { proto.RegisterFile("agent.proto", fileDescriptor_agent_92663a5e438943c8) }
+
+var fileDescriptor_agent_92663a5e438943c8 = []byte{
+	// 571 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x7f, 0x6f, 0x12, 0x41,
+	0x10, 0x95, 0x52, 0x10, 0x86, 0x34, 0xe0, 0xb6, 0xa1, 0xe7, 0x19, 0x13, 0x3c, 0x6b, 0x45, 0x6b,
+	0x0e, 0xa9, 0x26, 0xc6, 0xff, 0xa4, 0xd6, 0x18, 0x7f, 0xa4, 0x9a, 0xa3, 0xfe, 0xa3, 0x89, 0x97,
+	0x05, 0xa6, 0xf5, 0x14, 0xee, 0xce, 0xdb, 0xa1, 0xb1, 0x1f, 0xc0, 0x2f, 0xe7, 0xa7, 0x32, 0xcc,
+	0x2e, 0x1e, 0x85, 0x0b, 0xc5, 0xf4, 0x2f, 0xd8, 0x99, 0x37, 0xef, 0xcd, 0xce, 0xbe, 0xc9, 0x41,
+	0x45, 0x9e, 0x62, 0x48, 0x6e, 0x9c, 0x44, 0x14, 0x09, 0xa1, 0xe2, 0x20, 0x41, 0x57, 0xc6, 0x81,
+	0x7b, 0xd6, 0x76, 0x39, 0x63, 0xb7, 0x4f, 0x03, 0xfa, 0x36, 0xee, 0xb9, 0xfd, 0x68, 0xd4, 0x52,
+	0x71, 0x70, 0x72, 0x82, 0x2d, 0x46, 0xb5, 0xb8, 0xa4, 0xd5, 0x8f, 0x46, 0xa3, 0x28, 0x34, 0x3f,
+	0x9a, 0xc6, 0xf9, 0xbd, 0x06, 0x1b, 0x1d, 0x22, 0x54, 0x84, 0x83, 0xce, 0x84, 0x44, 0xdc, 0x82,
+	0xb2, 0xae, 0xf5, 0x83, 0x81, 0x95, 0x6b, 0xe4, 0x9a, 0x65, 0xaf, 0xa4, 0x03, 0x6f, 0x06, 0xe2,
+	0x01, 0xd4, 0x24, 0xa3, 0x25, 0x05, 0x51, 0xe8, 0xd3, 0x79, 0x8c, 0xd6, 0x1a, 0x63, 0xaa, 0x33,
+	0xf1, 0xe3, 0xf3, 0x18, 0xc5, 0x23, 0x10, 0x7d, 0x4c, 0xc8, 0x57, 0x98, 0x04, 0x72, 0xe8, 0x87,
+	0xe3, 0x51, 0x0f, 0x13, 0x2b, 0xcf, 0xe0, 0xda, 0x24, 0xd3, 0xe5, 0xc4, 0x11, 0xc7, 0xc5, 0x63,
+	0xd8, 0x62, 0x34, 0xfe, 0x8a, 0x83, 0x44, 0x93, 0x0f, 0x24, 0xa1, 0xb5, 0xce, 0x78, 0x66, 0x7a,
+	0xf5, 0x2f, 0x75, 0x28, 0x09, 0xc5, 0x53, 0x28, 0x2b, 0x1c, 0x62, 0x9f, 0xa2, 0x44, 0x59, 0x85,
+	0x46, 0xbe, 0x59, 0xd9, 0xaf, 0xbb, 0x7a, 0x28, 0xe6, 0x86, 0x5d, 0x93, 0xf6, 0x52, 0xa0, 0xa8,
+	0x43, 0xb1, 0x27, 0xc3, 0x10, 0x07, 0x56, 0xb1, 0x91, 0x6b, 0x96, 0x3c, 0x73, 0x72, 0x36, 0xe1,
+	0xc6, 0xfb, 0x40, 0x11, 0x8f, 0x40, 0x79, 0xf8, 0x73, 0x8c, 0x8a, 0x9c, 0x0f, 0x20, 0x66, 0x83,
+	0x2a, 0x8e, 0x42, 0x85, 0xe2, 0x39, 0x14, 0x79, 0xdc, 0xca, 0xca, 0xb1, 0xea, 0x1d, 0x77, 0xf1,
+	0x29, 0xdc, 0x0b, 0x33, 0xf5, 0x4c, 0x81, 0xe3, 0x42, 0xf5, 0x35, 0x6a, 0x3e, 0xa3, 0xb1, 0x74,
+	0xdc, 0xce, 0x3b, 0xa8, 0xa5, 0x78, 0x23, 0xff, 0x0c, 0x0a, 0xcc, 0xc6, 0xe0, 0x95, 0xd4, 0x35,
+	0xde, 0x69, 0x83, 0x38, 0xc4, 0x21, 0x12, 0xae, 0xae, 0x7f, 0x04, 0x9b, 0x17, 0x4a, 0xae, 0xda,
+	0x82, 0x0b, 0xd5, 0x03, 0x19, 0xfe, 0xd7, 0xfd, 0x53, 0xfc, 0x55, 0xc5, 0x5f, 0x40, 0xfd, 0x65,
+	0x82, 0x92, 0xf0, 0x6d, 0x14, 0x84, 0xc7, 0xd1, 0x0f, 0x0c, 0xa7, 0x3d, 0x6c, 0x41, 0x81, 0x26,
+	0x67, 0xa3, 0xaf, 0x0f, 0xa2, 0x06, 0x79, 0xa2, 0x21, 0xdb, 0xbb, 0xe0, 0x4d, 0xfe, 0x3a, 0x67,
+	0xb0, 0xbd, 0xc0, 0x60, 0xba, 0xca, 0xa6, 0xb8, 0x0d, 0xc0, 0x86, 0x46, 0xe5, 0x4b, 0x62, 0xa6,
+	0xbc, 0x57, 0x36, 0x91, 0x0e, 0x89, 0x5d, 0xa8, 0x72, 0x6b, 0x7e, 0x3a, 0x01, 0xbd, 0x1f, 0x1b,
+	0x1c, 0xee, 0x4e, 0xc7, 0xe0, 0x42, 0x5d, 0x3f, 0xc3, 0x6a, 0x9d, 0x3b, 0x37, 0x61, 0x7b, 0x01,
+	0xaf, 0xfb, 0xdc, 0xff, 0xb3, 0x0e, 0x05, 0xbd, 0xe7, 0x5f, 0x00, 0x52, 0x73, 0x8b, 0x7b, 0x59,
+	0x63, 0x5c, 0xd8, 0x08, 0x7b, 0xf7, 0x32, 0x98, 0x19, 0xc7, 0x27, 0x28, 0x4d, 0x8d, 0x2b, 0xee,
+	0x66, 0xd5, 0xcc, 0xad, 0x81, 0xbd, 0xb3, 0x1c, 0x64, 0x68, 0xbf, 0x42, 0x65, 0xc6, 0x8f, 0x22,
+	0xb3, 0x9b, 0x45, 0x8f, 0xdb, 0xf7, 0x2f, 0xc5, 0xa5, 0x6d, 0x4f, 0xfd, 0x96, 0xdd, 0xf6, 0x9c,
+	0x7b, 0xed, 0x9d, 0xe5, 0x20, 0x43, 0xfb, 0x1d, 0xaa, 0x73, 0xbe, 0x11, 0x0f, 0xb3, 0x0a, 0xb3,
+	0xed, 0x69, 0xef, 0xad, 0x84, 0x4d, 0xb5, 0xe6, 0xde, 0x3e, 0x5b, 0x2b, 0xdb, 0x50, 0xf6, 0xde,
+	0x4a, 0x58, 0xad, 0x75, 0x70, 0xfd, 0xb3, 0x5e, 0xad, 0x8f, 0xd7, 0x7a, 0x45, 0xfe, 0x9c, 0x3c,
+	0xf9, 0x3b, 0x00, 0x73, 0x6b, 0x50, 0x68, 0xa4, 0x06, 0x00, 0x00,
+}
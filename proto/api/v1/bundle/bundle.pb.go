@@ -0,0 +1,975 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: bundle.proto
+
+package bundle
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/spiffe/spire/proto/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// The CA bundle of a trust domain.
+type TrustBundle struct {
+	// SPIFFE ID of the trust domain.
+	TrustDomain string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain" json:"trust_domain,omitempty"`
+	// CA certificates.
+	// ASN.1 DER encoded
+	CaCerts []byte `protobuf:"bytes,2,opt,name=ca_certs,json=caCerts,proto3" json:"ca_certs,omitempty"`
+	// JWT signing keys.
+	JwtSigningKeys []*common.PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys" json:"jwt_signing_keys,omitempty"`
+	// Sequence number of the bundle, increased every time the bundle
+	// changes.
+	SequenceNumber uint64 `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+	// How often, in seconds, the bundle should be refreshed. Zero if not
+	// advised.
+	RefreshHint          int64    `protobuf:"varint,5,opt,name=refresh_hint,json=refreshHint" json:"refresh_hint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrustBundle) Reset()         { *m = TrustBundle{} }
+func (m *TrustBundle) String() string { return proto.CompactTextString(m) }
+func (*TrustBundle) ProtoMessage()    {}
+func (*TrustBundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{0}
+}
+func (m *TrustBundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrustBundle.Unmarshal(m, b)
+}
+func (m *TrustBundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrustBundle.Marshal(b, m, deterministic)
+}
+func (dst *TrustBundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrustBundle.Merge(dst, src)
+}
+func (m *TrustBundle) XXX_Size() int {
+	return xxx_messageInfo_TrustBundle.Size(m)
+}
+func (m *TrustBundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrustBundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrustBundle proto.InternalMessageInfo
+
+func (m *TrustBundle) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+func (m *TrustBundle) GetCaCerts() []byte {
+	if m != nil {
+		return m.CaCerts
+	}
+	return nil
+}
+
+func (m *TrustBundle) GetJwtSigningKeys() []*common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKeys
+	}
+	return nil
+}
+
+func (m *TrustBundle) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *TrustBundle) GetRefreshHint() int64 {
+	if m != nil {
+		return m.RefreshHint
+	}
+	return 0
+}
+
+// Represents a request to retrieve the server's trust bundle.
+type GetBundleRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBundleRequest) Reset()         { *m = GetBundleRequest{} }
+func (m *GetBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBundleRequest) ProtoMessage()    {}
+func (*GetBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{1}
+}
+func (m *GetBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBundleRequest.Unmarshal(m, b)
+}
+func (m *GetBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBundleRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBundleRequest.Merge(dst, src)
+}
+func (m *GetBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBundleRequest.Size(m)
+}
+func (m *GetBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBundleRequest proto.InternalMessageInfo
+
+// Represents the server's trust bundle.
+type GetBundleResponse struct {
+	// The trust bundle.
+	Bundle               *TrustBundle `protobuf:"bytes,1,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetBundleResponse) Reset()         { *m = GetBundleResponse{} }
+func (m *GetBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBundleResponse) ProtoMessage()    {}
+func (*GetBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{2}
+}
+func (m *GetBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBundleResponse.Unmarshal(m, b)
+}
+func (m *GetBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBundleResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBundleResponse.Merge(dst, src)
+}
+func (m *GetBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBundleResponse.Size(m)
+}
+func (m *GetBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBundleResponse proto.InternalMessageInfo
+
+func (m *GetBundleResponse) GetBundle() *TrustBundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// Represents a request to list the federated bundles.
+type ListFederatedBundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederatedBundlesRequest) Reset()         { *m = ListFederatedBundlesRequest{} }
+func (m *ListFederatedBundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederatedBundlesRequest) ProtoMessage()    {}
+func (*ListFederatedBundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{3}
+}
+func (m *ListFederatedBundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederatedBundlesRequest.Unmarshal(m, b)
+}
+func (m *ListFederatedBundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederatedBundlesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListFederatedBundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederatedBundlesRequest.Merge(dst, src)
+}
+func (m *ListFederatedBundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederatedBundlesRequest.Size(m)
+}
+func (m *ListFederatedBundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederatedBundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederatedBundlesRequest proto.InternalMessageInfo
+
+// Represents a list of federated bundles.
+type ListFederatedBundlesResponse struct {
+	// The federated bundles.
+	Bundles              []*TrustBundle `protobuf:"bytes,1,rep,name=bundles" json:"bundles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ListFederatedBundlesResponse) Reset()         { *m = ListFederatedBundlesResponse{} }
+func (m *ListFederatedBundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederatedBundlesResponse) ProtoMessage()    {}
+func (*ListFederatedBundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{4}
+}
+func (m *ListFederatedBundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederatedBundlesResponse.Unmarshal(m, b)
+}
+func (m *ListFederatedBundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederatedBundlesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListFederatedBundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederatedBundlesResponse.Merge(dst, src)
+}
+func (m *ListFederatedBundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederatedBundlesResponse.Size(m)
+}
+func (m *ListFederatedBundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederatedBundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederatedBundlesResponse proto.InternalMessageInfo
+
+func (m *ListFederatedBundlesResponse) GetBundles() []*TrustBundle {
+	if m != nil {
+		return m.Bundles
+	}
+	return nil
+}
+
+// Represents a request to retrieve a federated bundle.
+type GetFederatedBundleRequest struct {
+	// SPIFFE ID of the federated trust domain.
+	TrustDomain          string   `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain" json:"trust_domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFederatedBundleRequest) Reset()         { *m = GetFederatedBundleRequest{} }
+func (m *GetFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFederatedBundleRequest) ProtoMessage()    {}
+func (*GetFederatedBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{5}
+}
+func (m *GetFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFederatedBundleRequest.Unmarshal(m, b)
+}
+func (m *GetFederatedBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFederatedBundleRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetFederatedBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFederatedBundleRequest.Merge(dst, src)
+}
+func (m *GetFederatedBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFederatedBundleRequest.Size(m)
+}
+func (m *GetFederatedBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFederatedBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFederatedBundleRequest proto.InternalMessageInfo
+
+func (m *GetFederatedBundleRequest) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+// Represents the retrieved federated bundle.
+type GetFederatedBundleResponse struct {
+	// The federated bundle.
+	Bundle               *TrustBundle `protobuf:"bytes,1,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetFederatedBundleResponse) Reset()         { *m = GetFederatedBundleResponse{} }
+func (m *GetFederatedBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFederatedBundleResponse) ProtoMessage()    {}
+func (*GetFederatedBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{6}
+}
+func (m *GetFederatedBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFederatedBundleResponse.Unmarshal(m, b)
+}
+func (m *GetFederatedBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFederatedBundleResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetFederatedBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFederatedBundleResponse.Merge(dst, src)
+}
+func (m *GetFederatedBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFederatedBundleResponse.Size(m)
+}
+func (m *GetFederatedBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFederatedBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFederatedBundleResponse proto.InternalMessageInfo
+
+func (m *GetFederatedBundleResponse) GetBundle() *TrustBundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// Represents a request to create or replace a federated bundle.
+type SetFederatedBundleRequest struct {
+	// The federated bundle.
+	Bundle               *TrustBundle `protobuf:"bytes,1,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetFederatedBundleRequest) Reset()         { *m = SetFederatedBundleRequest{} }
+func (m *SetFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetFederatedBundleRequest) ProtoMessage()    {}
+func (*SetFederatedBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{7}
+}
+func (m *SetFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetFederatedBundleRequest.Unmarshal(m, b)
+}
+func (m *SetFederatedBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetFederatedBundleRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetFederatedBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFederatedBundleRequest.Merge(dst, src)
+}
+func (m *SetFederatedBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_SetFederatedBundleRequest.Size(m)
+}
+func (m *SetFederatedBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFederatedBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFederatedBundleRequest proto.InternalMessageInfo
+
+func (m *SetFederatedBundleRequest) GetBundle() *TrustBundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// Represents the stored federated bundle.
+type SetFederatedBundleResponse struct {
+	// The federated bundle.
+	Bundle               *TrustBundle `protobuf:"bytes,1,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *SetFederatedBundleResponse) Reset()         { *m = SetFederatedBundleResponse{} }
+func (m *SetFederatedBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*SetFederatedBundleResponse) ProtoMessage()    {}
+func (*SetFederatedBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{8}
+}
+func (m *SetFederatedBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetFederatedBundleResponse.Unmarshal(m, b)
+}
+func (m *SetFederatedBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetFederatedBundleResponse.Marshal(b, m, deterministic)
+}
+func (dst *SetFederatedBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetFederatedBundleResponse.Merge(dst, src)
+}
+func (m *SetFederatedBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_SetFederatedBundleResponse.Size(m)
+}
+func (m *SetFederatedBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetFederatedBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetFederatedBundleResponse proto.InternalMessageInfo
+
+func (m *SetFederatedBundleResponse) GetBundle() *TrustBundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// Represents a request to delete a federated bundle.
+type DeleteFederatedBundleRequest struct {
+	// SPIFFE ID of the federated trust domain.
+	TrustDomain          string   `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain" json:"trust_domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBundleRequest{} }
+func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
+func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{9}
+}
+func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederatedBundleRequest.Unmarshal(m, b)
+}
+func (m *DeleteFederatedBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederatedBundleRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteFederatedBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederatedBundleRequest.Merge(dst, src)
+}
+func (m *DeleteFederatedBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederatedBundleRequest.Size(m)
+}
+func (m *DeleteFederatedBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederatedBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederatedBundleRequest proto.InternalMessageInfo
+
+func (m *DeleteFederatedBundleRequest) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+// Represents the deleted federated bundle.
+type DeleteFederatedBundleResponse struct {
+	// The deleted federated bundle.
+	Bundle               *TrustBundle `protobuf:"bytes,1,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *DeleteFederatedBundleResponse) Reset()         { *m = DeleteFederatedBundleResponse{} }
+func (m *DeleteFederatedBundleResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederatedBundleResponse) ProtoMessage()    {}
+func (*DeleteFederatedBundleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{10}
+}
+func (m *DeleteFederatedBundleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederatedBundleResponse.Unmarshal(m, b)
+}
+func (m *DeleteFederatedBundleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederatedBundleResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteFederatedBundleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederatedBundleResponse.Merge(dst, src)
+}
+func (m *DeleteFederatedBundleResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederatedBundleResponse.Size(m)
+}
+func (m *DeleteFederatedBundleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederatedBundleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederatedBundleResponse proto.InternalMessageInfo
+
+func (m *DeleteFederatedBundleResponse) GetBundle() *TrustBundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// The health of the relationship with a federated trust domain, whose bundle
+// is fetched from its bundle endpoint.
+type FederationStatus struct {
+	// SPIFFE ID of the federated trust domain.
+	TrustDomain string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain" json:"trust_domain,omitempty"`
+	// URL of the bundle endpoint of the trust domain.
+	BundleEndpointUrl string `protobuf:"bytes,2,opt,name=bundle_endpoint_url,json=bundleEndpointUrl" json:"bundle_endpoint_url,omitempty"`
+	// Time of the last successful fetch of the bundle, represented in UNIX
+	// time. Zero if it was not fetched yet.
+	LastSuccess int64 `protobuf:"varint,3,opt,name=last_success,json=lastSuccess" json:"last_success,omitempty"`
+	// Error of the last failed fetch of the bundle, if it failed since the
+	// last successful fetch.
+	LastError string `protobuf:"bytes,4,opt,name=last_error,json=lastError" json:"last_error,omitempty"`
+	// Time of the last failed fetch of the bundle, represented in UNIX
+	// time. Zero if none failed since the last successful fetch.
+	LastErrorAt int64 `protobuf:"varint,5,opt,name=last_error_at,json=lastErrorAt" json:"last_error_at,omitempty"`
+	// Time, in seconds, since the last successful fetch of the bundle. Zero
+	// if it was not fetched yet.
+	SecondsSinceLastSuccess int64 `protobuf:"varint,6,opt,name=seconds_since_last_success,json=secondsSinceLastSuccess" json:"seconds_since_last_success,omitempty"`
+	// Expiration date of the last CA certificate of the stored bundle to
+	// expire, represented in UNIX time. Zero if no bundle is stored.
+	BundleExpiresAt      int64    `protobuf:"varint,7,opt,name=bundle_expires_at,json=bundleExpiresAt" json:"bundle_expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederationStatus) Reset()         { *m = FederationStatus{} }
+func (m *FederationStatus) String() string { return proto.CompactTextString(m) }
+func (*FederationStatus) ProtoMessage()    {}
+func (*FederationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{11}
+}
+func (m *FederationStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederationStatus.Unmarshal(m, b)
+}
+func (m *FederationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederationStatus.Marshal(b, m, deterministic)
+}
+func (dst *FederationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederationStatus.Merge(dst, src)
+}
+func (m *FederationStatus) XXX_Size() int {
+	return xxx_messageInfo_FederationStatus.Size(m)
+}
+func (m *FederationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederationStatus proto.InternalMessageInfo
+
+func (m *FederationStatus) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+func (m *FederationStatus) GetBundleEndpointUrl() string {
+	if m != nil {
+		return m.BundleEndpointUrl
+	}
+	return ""
+}
+
+func (m *FederationStatus) GetLastSuccess() int64 {
+	if m != nil {
+		return m.LastSuccess
+	}
+	return 0
+}
+
+func (m *FederationStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *FederationStatus) GetLastErrorAt() int64 {
+	if m != nil {
+		return m.LastErrorAt
+	}
+	return 0
+}
+
+func (m *FederationStatus) GetSecondsSinceLastSuccess() int64 {
+	if m != nil {
+		return m.SecondsSinceLastSuccess
+	}
+	return 0
+}
+
+func (m *FederationStatus) GetBundleExpiresAt() int64 {
+	if m != nil {
+		return m.BundleExpiresAt
+	}
+	return 0
+}
+
+// Represents a request to list the status of the federation relationships.
+type ListFederationStatusesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFederationStatusesRequest) Reset()         { *m = ListFederationStatusesRequest{} }
+func (m *ListFederationStatusesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFederationStatusesRequest) ProtoMessage()    {}
+func (*ListFederationStatusesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{12}
+}
+func (m *ListFederationStatusesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationStatusesRequest.Unmarshal(m, b)
+}
+func (m *ListFederationStatusesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationStatusesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListFederationStatusesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationStatusesRequest.Merge(dst, src)
+}
+func (m *ListFederationStatusesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFederationStatusesRequest.Size(m)
+}
+func (m *ListFederationStatusesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationStatusesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationStatusesRequest proto.InternalMessageInfo
+
+// Represents the status of the federation relationships.
+type ListFederationStatusesResponse struct {
+	// The status of each federated trust domain whose bundle is fetched
+	// from its bundle endpoint.
+	Statuses             []*FederationStatus `protobuf:"bytes,1,rep,name=statuses" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListFederationStatusesResponse) Reset()         { *m = ListFederationStatusesResponse{} }
+func (m *ListFederationStatusesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFederationStatusesResponse) ProtoMessage()    {}
+func (*ListFederationStatusesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bundle_9453cd675bbdfcc1, []int{13}
+}
+func (m *ListFederationStatusesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFederationStatusesResponse.Unmarshal(m, b)
+}
+func (m *ListFederationStatusesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFederationStatusesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListFederationStatusesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFederationStatusesResponse.Merge(dst, src)
+}
+func (m *ListFederationStatusesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFederationStatusesResponse.Size(m)
+}
+func (m *ListFederationStatusesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFederationStatusesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFederationStatusesResponse proto.InternalMessageInfo
+
+func (m *ListFederationStatusesResponse) GetStatuses() []*FederationStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*TrustBundle)(nil), "spire.api.v1.bundle.TrustBundle")
+	proto.RegisterType((*GetBundleRequest)(nil), "spire.api.v1.bundle.GetBundleRequest")
+	proto.RegisterType((*GetBundleResponse)(nil), "spire.api.v1.bundle.GetBundleResponse")
+	proto.RegisterType((*ListFederatedBundlesRequest)(nil), "spire.api.v1.bundle.ListFederatedBundlesRequest")
+	proto.RegisterType((*ListFederatedBundlesResponse)(nil), "spire.api.v1.bundle.ListFederatedBundlesResponse")
+	proto.RegisterType((*GetFederatedBundleRequest)(nil), "spire.api.v1.bundle.GetFederatedBundleRequest")
+	proto.RegisterType((*GetFederatedBundleResponse)(nil), "spire.api.v1.bundle.GetFederatedBundleResponse")
+	proto.RegisterType((*SetFederatedBundleRequest)(nil), "spire.api.v1.bundle.SetFederatedBundleRequest")
+	proto.RegisterType((*SetFederatedBundleResponse)(nil), "spire.api.v1.bundle.SetFederatedBundleResponse")
+	proto.RegisterType((*DeleteFederatedBundleRequest)(nil), "spire.api.v1.bundle.DeleteFederatedBundleRequest")
+	proto.RegisterType((*DeleteFederatedBundleResponse)(nil), "spire.api.v1.bundle.DeleteFederatedBundleResponse")
+	proto.RegisterType((*FederationStatus)(nil), "spire.api.v1.bundle.FederationStatus")
+	proto.RegisterType((*ListFederationStatusesRequest)(nil), "spire.api.v1.bundle.ListFederationStatusesRequest")
+	proto.RegisterType((*ListFederationStatusesResponse)(nil), "spire.api.v1.bundle.ListFederationStatusesResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Bundle service
+
+type BundleClient interface {
+	// Retrieves the trust bundle of the server's trust domain.
+	GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*GetBundleResponse, error)
+	// Lists the bundles of all the federated trust domains.
+	ListFederatedBundles(ctx context.Context, in *ListFederatedBundlesRequest, opts ...grpc.CallOption) (*ListFederatedBundlesResponse, error)
+	// Retrieves the bundle of a federated trust domain.
+	GetFederatedBundle(ctx context.Context, in *GetFederatedBundleRequest, opts ...grpc.CallOption) (*GetFederatedBundleResponse, error)
+	// Creates or replaces the bundle of a federated trust domain.
+	SetFederatedBundle(ctx context.Context, in *SetFederatedBundleRequest, opts ...grpc.CallOption) (*SetFederatedBundleResponse, error)
+	// Deletes the bundle of a federated trust domain.
+	DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*DeleteFederatedBundleResponse, error)
+	// Lists the status of the relationships with the federated trust
+	// domains whose bundles are fetched from their bundle endpoints.
+	ListFederationStatuses(ctx context.Context, in *ListFederationStatusesRequest, opts ...grpc.CallOption) (*ListFederationStatusesResponse, error)
+}
+
+type bundleClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBundleClient(cc *grpc.ClientConn) BundleClient {
+	return &bundleClient{cc}
+}
+
+func (c *bundleClient) GetBundle(ctx context.Context, in *GetBundleRequest, opts ...grpc.CallOption) (*GetBundleResponse, error) {
+	out := new(GetBundleResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.bundle.Bundle/GetBundle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleClient) ListFederatedBundles(ctx context.Context, in *ListFederatedBundlesRequest, opts ...grpc.CallOption) (*ListFederatedBundlesResponse, error) {
+	out := new(ListFederatedBundlesResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.bundle.Bundle/ListFederatedBundles", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleClient) GetFederatedBundle(ctx context.Context, in *GetFederatedBundleRequest, opts ...grpc.CallOption) (*GetFederatedBundleResponse, error) {
+	out := new(GetFederatedBundleResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.bundle.Bundle/GetFederatedBundle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleClient) SetFederatedBundle(ctx context.Context, in *SetFederatedBundleRequest, opts ...grpc.CallOption) (*SetFederatedBundleResponse, error) {
+	out := new(SetFederatedBundleResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.bundle.Bundle/SetFederatedBundle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleClient) DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*DeleteFederatedBundleResponse, error) {
+	out := new(DeleteFederatedBundleResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.bundle.Bundle/DeleteFederatedBundle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bundleClient) ListFederationStatuses(ctx context.Context, in *ListFederationStatusesRequest, opts ...grpc.CallOption) (*ListFederationStatusesResponse, error) {
+	out := new(ListFederationStatusesResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.bundle.Bundle/ListFederationStatuses", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Bundle service
+
+type BundleServer interface {
+	// Retrieves the trust bundle of the server's trust domain.
+	GetBundle(context.Context, *GetBundleRequest) (*GetBundleResponse, error)
+	// Lists the bundles of all the federated trust domains.
+	ListFederatedBundles(context.Context, *ListFederatedBundlesRequest) (*ListFederatedBundlesResponse, error)
+	// Retrieves the bundle of a federated trust domain.
+	GetFederatedBundle(context.Context, *GetFederatedBundleRequest) (*GetFederatedBundleResponse, error)
+	// Creates or replaces the bundle of a federated trust domain.
+	SetFederatedBundle(context.Context, *SetFederatedBundleRequest) (*SetFederatedBundleResponse, error)
+	// Deletes the bundle of a federated trust domain.
+	DeleteFederatedBundle(context.Context, *DeleteFederatedBundleRequest) (*DeleteFederatedBundleResponse, error)
+	// Lists the status of the relationships with the federated trust
+	// domains whose bundles are fetched from their bundle endpoints.
+	ListFederationStatuses(context.Context, *ListFederationStatusesRequest) (*ListFederationStatusesResponse, error)
+}
+
+func RegisterBundleServer(s *grpc.Server, srv BundleServer) {
+	s.RegisterService(&_Bundle_serviceDesc, srv)
+}
+
+func _Bundle_GetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).GetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.bundle.Bundle/GetBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).GetBundle(ctx, req.(*GetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bundle_ListFederatedBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederatedBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).ListFederatedBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.bundle.Bundle/ListFederatedBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).ListFederatedBundles(ctx, req.(*ListFederatedBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bundle_GetFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFederatedBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).GetFederatedBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.bundle.Bundle/GetFederatedBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).GetFederatedBundle(ctx, req.(*GetFederatedBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bundle_SetFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFederatedBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).SetFederatedBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.bundle.Bundle/SetFederatedBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).SetFederatedBundle(ctx, req.(*SetFederatedBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bundle_DeleteFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFederatedBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).DeleteFederatedBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.bundle.Bundle/DeleteFederatedBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).DeleteFederatedBundle(ctx, req.(*DeleteFederatedBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bundle_ListFederationStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFederationStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BundleServer).ListFederationStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.bundle.Bundle/ListFederationStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BundleServer).ListFederationStatuses(ctx, req.(*ListFederationStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Bundle_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.v1.bundle.Bundle",
+	HandlerType: (*BundleServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBundle",
+			Handler:    _Bundle_GetBundle_Handler,
+		},
+		{
+			MethodName: "ListFederatedBundles",
+			Handler:    _Bundle_ListFederatedBundles_Handler,
+		},
+		{
+			MethodName: "GetFederatedBundle",
+			Handler:    _Bundle_GetFederatedBundle_Handler,
+		},
+		{
+			MethodName: "SetFederatedBundle",
+			Handler:    _Bundle_SetFederatedBundle_Handler,
+		},
+		{
+			MethodName: "DeleteFederatedBundle",
+			Handler:    _Bundle_DeleteFederatedBundle_Handler,
+		},
+		{
+			MethodName: "ListFederationStatuses",
+			Handler:    _Bundle_ListFederationStatuses_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bundle.proto",
+}
+
+func init() { proto.RegisterFile("bundle.proto", fileDescriptor_bundle_9453cd675bbdfcc1) }
+
+var fileDescriptor_bundle_9453cd675bbdfcc1 = []byte{
+	// 683 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5d, 0x4f, 0x13, 0x41,
+	0x14, 0xcd, 0x52, 0x28, 0xf4, 0x16, 0xf9, 0x18, 0x54, 0xca, 0x4a, 0x75, 0xdd, 0x44, 0x6d, 0x7c,
+	0xd8, 0x4a, 0x79, 0x31, 0x9a, 0x98, 0x14, 0x41, 0x4c, 0x40, 0x63, 0x76, 0x85, 0x44, 0x5e, 0x36,
+	0xdb, 0xed, 0x05, 0x06, 0xdb, 0xd9, 0xba, 0x33, 0x2b, 0x12, 0x13, 0x13, 0xff, 0x93, 0xff, 0xc4,
+	0x37, 0x7f, 0x8d, 0xd9, 0x99, 0x69, 0xa9, 0xb0, 0x2d, 0x2d, 0xc4, 0x27, 0xb2, 0x67, 0xee, 0x39,
+	0xe7, 0xce, 0x1d, 0xce, 0x4d, 0x61, 0xb6, 0x91, 0xb0, 0x66, 0x0b, 0x9d, 0x4e, 0x1c, 0x89, 0x88,
+	0x2c, 0xf1, 0x0e, 0x8d, 0xd1, 0x09, 0x3a, 0xd4, 0xf9, 0xba, 0xe6, 0xa8, 0x23, 0x73, 0xed, 0x88,
+	0x8a, 0xe3, 0xa4, 0xe1, 0x84, 0x51, 0xbb, 0xca, 0x3b, 0xf4, 0xf0, 0x10, 0xab, 0xb2, 0xac, 0x2a,
+	0x39, 0xd5, 0x30, 0x6a, 0xb7, 0x23, 0xa6, 0xff, 0x28, 0x1d, 0xfb, 0x8f, 0x01, 0xc5, 0x8f, 0x71,
+	0xc2, 0xc5, 0x86, 0x94, 0x20, 0x0f, 0x61, 0x56, 0xa4, 0x9f, 0x7e, 0x33, 0x6a, 0x07, 0x94, 0x95,
+	0x0c, 0xcb, 0xa8, 0x14, 0xdc, 0xa2, 0xc4, 0x36, 0x25, 0x44, 0x56, 0x60, 0x26, 0x0c, 0xfc, 0x10,
+	0x63, 0xc1, 0x4b, 0x13, 0x96, 0x51, 0x99, 0x75, 0xa7, 0xc3, 0xe0, 0x75, 0xfa, 0x49, 0xea, 0xb0,
+	0x70, 0x72, 0x2a, 0x7c, 0x4e, 0x8f, 0x18, 0x65, 0x47, 0xfe, 0x67, 0x3c, 0xe3, 0xa5, 0x9c, 0x95,
+	0xab, 0x14, 0x6b, 0xcb, 0x8e, 0x6a, 0x58, 0x9b, 0x7f, 0x48, 0x1a, 0x2d, 0x1a, 0xee, 0xe0, 0x99,
+	0x3b, 0x77, 0x72, 0x2a, 0x3c, 0x55, 0xbf, 0x83, 0x67, 0x9c, 0x3c, 0x81, 0x79, 0x8e, 0x5f, 0x12,
+	0x64, 0x21, 0xfa, 0x2c, 0x69, 0x37, 0x30, 0x2e, 0x4d, 0x5a, 0x46, 0x65, 0xd2, 0x9d, 0xeb, 0xc2,
+	0xef, 0x25, 0x9a, 0x76, 0x1a, 0xe3, 0x61, 0x8c, 0xfc, 0xd8, 0x3f, 0xa6, 0x4c, 0x94, 0xa6, 0x2c,
+	0xa3, 0x92, 0x73, 0x8b, 0x1a, 0x7b, 0x4b, 0x99, 0xb0, 0x09, 0x2c, 0x6c, 0xa3, 0xbe, 0x99, 0x9b,
+	0xb2, 0xb9, 0xb0, 0xdf, 0xc1, 0x62, 0x1f, 0xc6, 0x3b, 0x11, 0xe3, 0x48, 0x9e, 0x43, 0x5e, 0x8d,
+	0x50, 0xde, 0xb7, 0x58, 0xb3, 0x9c, 0x8c, 0xf1, 0x3a, 0x7d, 0x73, 0x72, 0x75, 0xbd, 0x5d, 0x86,
+	0x7b, 0xbb, 0x94, 0x8b, 0x37, 0xd8, 0xc4, 0x38, 0x10, 0xd8, 0x54, 0xc7, 0xbc, 0xeb, 0x76, 0x00,
+	0xab, 0xd9, 0xc7, 0xda, 0xf8, 0x05, 0x4c, 0x2b, 0x21, 0x5e, 0x32, 0xac, 0xdc, 0x48, 0xce, 0x5d,
+	0x82, 0xfd, 0x0a, 0x56, 0xb6, 0xf1, 0xa2, 0xb4, 0x36, 0x1e, 0xe1, 0x1d, 0xed, 0x7d, 0x30, 0xb3,
+	0xf8, 0x37, 0x1e, 0xc9, 0x1e, 0xac, 0x78, 0x03, 0xfb, 0xba, 0xbe, 0xec, 0x3e, 0x98, 0xde, 0xff,
+	0x68, 0xb7, 0x0e, 0xab, 0x9b, 0xd8, 0x42, 0x81, 0xd7, 0x9f, 0xe4, 0x27, 0x28, 0x0f, 0x90, 0xb8,
+	0x71, 0x77, 0xbf, 0x26, 0x60, 0x41, 0xab, 0xd2, 0x88, 0x79, 0x22, 0x10, 0x09, 0x1f, 0x25, 0xa4,
+	0x0e, 0x2c, 0x29, 0x05, 0x1f, 0x59, 0xb3, 0x13, 0x51, 0x26, 0xfc, 0x24, 0x6e, 0xc9, 0xbc, 0x16,
+	0xdc, 0x45, 0x75, 0xb4, 0xa5, 0x4f, 0xf6, 0xe2, 0x56, 0x2a, 0xd9, 0x0a, 0xb8, 0xf0, 0x79, 0x12,
+	0x86, 0xc8, 0xd3, 0xd4, 0xca, 0x34, 0xa5, 0x98, 0xa7, 0x20, 0x52, 0x06, 0x90, 0x25, 0x18, 0xc7,
+	0x91, 0x0a, 0x65, 0xc1, 0x2d, 0xa4, 0xc8, 0x56, 0x0a, 0x10, 0x1b, 0x6e, 0x9d, 0x1f, 0xfb, 0x41,
+	0x2f, 0x90, 0xbd, 0x8a, 0xba, 0x20, 0x2f, 0xc1, 0xe4, 0x18, 0x46, 0xac, 0xc9, 0x7d, 0x4e, 0xd3,
+	0x84, 0xff, 0xe3, 0x99, 0x97, 0x84, 0x65, 0x5d, 0xe1, 0xa5, 0x05, 0xbb, 0x7d, 0xfe, 0x4f, 0x61,
+	0xb1, 0x7b, 0xa5, 0x6f, 0xe9, 0xf4, 0x78, 0x6a, 0x32, 0x2d, 0x39, 0xf3, 0xfa, 0x42, 0x0a, 0xaf,
+	0x0b, 0xfb, 0x01, 0x94, 0xfb, 0x72, 0xd7, 0x9b, 0xdc, 0x79, 0x30, 0x43, 0xb8, 0x3f, 0xa8, 0x40,
+	0xbf, 0x59, 0x1d, 0x66, 0xb8, 0xc6, 0x74, 0x36, 0x1f, 0x65, 0xbe, 0xda, 0x45, 0x09, 0xb7, 0x47,
+	0xab, 0xfd, 0x9e, 0x82, 0xbc, 0xde, 0xab, 0x07, 0x50, 0xe8, 0xad, 0x1d, 0x92, 0x2d, 0x74, 0x71,
+	0x55, 0x99, 0x8f, 0xaf, 0x2a, 0xd3, 0x9d, 0x7e, 0x87, 0xdb, 0x59, 0x4b, 0x86, 0x3c, 0xcb, 0xe4,
+	0x0f, 0x59, 0x57, 0xe6, 0xda, 0x18, 0x0c, 0x6d, 0x9e, 0x00, 0xb9, 0xbc, 0x45, 0x88, 0x33, 0xa8,
+	0xf5, 0xec, 0x90, 0x99, 0xd5, 0x91, 0xeb, 0xcf, 0x6d, 0xbd, 0x51, 0x6d, 0xbd, 0x31, 0x6d, 0x87,
+	0xac, 0x99, 0x1f, 0x70, 0x27, 0x33, 0xe9, 0x24, 0x7b, 0x72, 0xc3, 0x16, 0x8b, 0x59, 0x1b, 0x87,
+	0xa2, 0xfd, 0x7f, 0x1a, 0x70, 0x37, 0xfb, 0xff, 0x96, 0xd4, 0xae, 0x7a, 0xbb, 0xcb, 0x29, 0x30,
+	0xd7, 0xc7, 0xe2, 0xa8, 0x1e, 0x36, 0x66, 0x0e, 0xf4, 0x72, 0x6a, 0xe4, 0xe5, 0x6f, 0x88, 0xf5,
+	0xbf, 0x03, 0x00, 0x38, 0x9a, 0x7a, 0xbd, 0x9b, 0x08, 0x00, 0x00,
+}
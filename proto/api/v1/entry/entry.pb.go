@@ -0,0 +1,1049 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: entry.proto
+
+package entry
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/spiffe/spire/proto/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// Empty from public import github.com/spiffe/spire/proto/common/common.proto
+type Empty = common.Empty
+
+// AttestationData from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestationData = common.AttestationData
+
+// Selector from public import github.com/spiffe/spire/proto/common/common.proto
+type Selector = common.Selector
+
+// Selectors from public import github.com/spiffe/spire/proto/common/common.proto
+type Selectors = common.Selectors
+
+// RegistrationEntry from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntry = common.RegistrationEntry
+
+// RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntries = common.RegistrationEntries
+
+// Represents a request to create a registration entry.
+type CreateEntryRequest struct {
+	// The entry to create. The entry ID is assigned by the server.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *CreateEntryRequest) Reset()         { *m = CreateEntryRequest{} }
+func (m *CreateEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateEntryRequest) ProtoMessage()    {}
+func (*CreateEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{0}
+}
+func (m *CreateEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEntryRequest.Unmarshal(m, b)
+}
+func (m *CreateEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEntryRequest.Marshal(b, m, deterministic)
+}
+func (dst *CreateEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEntryRequest.Merge(dst, src)
+}
+func (m *CreateEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateEntryRequest.Size(m)
+}
+func (m *CreateEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEntryRequest proto.InternalMessageInfo
+
+func (m *CreateEntryRequest) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents the created registration entry.
+type CreateEntryResponse struct {
+	// The created entry, including its entry ID.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *CreateEntryResponse) Reset()         { *m = CreateEntryResponse{} }
+func (m *CreateEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateEntryResponse) ProtoMessage()    {}
+func (*CreateEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{1}
+}
+func (m *CreateEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateEntryResponse.Unmarshal(m, b)
+}
+func (m *CreateEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateEntryResponse.Marshal(b, m, deterministic)
+}
+func (dst *CreateEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateEntryResponse.Merge(dst, src)
+}
+func (m *CreateEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateEntryResponse.Size(m)
+}
+func (m *CreateEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateEntryResponse proto.InternalMessageInfo
+
+func (m *CreateEntryResponse) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents a request to retrieve a registration entry.
+type GetEntryRequest struct {
+	// ID of the entry to retrieve.
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEntryRequest) Reset()         { *m = GetEntryRequest{} }
+func (m *GetEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEntryRequest) ProtoMessage()    {}
+func (*GetEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{2}
+}
+func (m *GetEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEntryRequest.Unmarshal(m, b)
+}
+func (m *GetEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEntryRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEntryRequest.Merge(dst, src)
+}
+func (m *GetEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEntryRequest.Size(m)
+}
+func (m *GetEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEntryRequest proto.InternalMessageInfo
+
+func (m *GetEntryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// Represents the retrieved registration entry.
+type GetEntryResponse struct {
+	// The retrieved entry.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *GetEntryResponse) Reset()         { *m = GetEntryResponse{} }
+func (m *GetEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEntryResponse) ProtoMessage()    {}
+func (*GetEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{3}
+}
+func (m *GetEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEntryResponse.Unmarshal(m, b)
+}
+func (m *GetEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEntryResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEntryResponse.Merge(dst, src)
+}
+func (m *GetEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEntryResponse.Size(m)
+}
+func (m *GetEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEntryResponse proto.InternalMessageInfo
+
+func (m *GetEntryResponse) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents a request to list registration entries. Only the entries
+// matching all the filters set are returned, or all entries if none is set.
+// Entries are sorted by SPIFFE ID, then parent ID, then selectors.
+type ListEntriesRequest struct {
+	// Only return entries with this parent ID.
+	ByParentId string `protobuf:"bytes,1,opt,name=by_parent_id,json=byParentId" json:"by_parent_id,omitempty"`
+	// Only return entries with this SPIFFE ID.
+	BySpiffeId string `protobuf:"bytes,2,opt,name=by_spiffe_id,json=bySpiffeId" json:"by_spiffe_id,omitempty"`
+	// Only return entries with exactly this set of selectors.
+	BySelectors []*common.Selector `protobuf:"bytes,3,rep,name=by_selectors,json=bySelectors" json:"by_selectors,omitempty"`
+	// Only return entries holding all these selectors, and possibly others.
+	WithSelectors []*common.Selector `protobuf:"bytes,4,rep,name=with_selectors,json=withSelectors" json:"with_selectors,omitempty"`
+	// Only return entries federating with all these trust domains.
+	ByFederatesWith      []string `protobuf:"bytes,5,rep,name=by_federates_with,json=byFederatesWith" json:"by_federates_with,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEntriesRequest) Reset()         { *m = ListEntriesRequest{} }
+func (m *ListEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEntriesRequest) ProtoMessage()    {}
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{4}
+}
+func (m *ListEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntriesRequest.Unmarshal(m, b)
+}
+func (m *ListEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntriesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntriesRequest.Merge(dst, src)
+}
+func (m *ListEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEntriesRequest.Size(m)
+}
+func (m *ListEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntriesRequest proto.InternalMessageInfo
+
+func (m *ListEntriesRequest) GetByParentId() string {
+	if m != nil {
+		return m.ByParentId
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetBySpiffeId() string {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *ListEntriesRequest) GetWithSelectors() []*common.Selector {
+	if m != nil {
+		return m.WithSelectors
+	}
+	return nil
+}
+
+func (m *ListEntriesRequest) GetByFederatesWith() []string {
+	if m != nil {
+		return m.ByFederatesWith
+	}
+	return nil
+}
+
+// Represents a list of registration entries.
+type ListEntriesResponse struct {
+	// The matching entries.
+	Entries              []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ListEntriesResponse) Reset()         { *m = ListEntriesResponse{} }
+func (m *ListEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEntriesResponse) ProtoMessage()    {}
+func (*ListEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{5}
+}
+func (m *ListEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntriesResponse.Unmarshal(m, b)
+}
+func (m *ListEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntriesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntriesResponse.Merge(dst, src)
+}
+func (m *ListEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEntriesResponse.Size(m)
+}
+func (m *ListEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntriesResponse proto.InternalMessageInfo
+
+func (m *ListEntriesResponse) GetEntries() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// Represents a request to count registration entries. The filters are the
+// same as those of ListEntriesRequest.
+type CountEntriesRequest struct {
+	// Only count entries with this parent ID.
+	ByParentId string `protobuf:"bytes,1,opt,name=by_parent_id,json=byParentId" json:"by_parent_id,omitempty"`
+	// Only count entries with this SPIFFE ID.
+	BySpiffeId string `protobuf:"bytes,2,opt,name=by_spiffe_id,json=bySpiffeId" json:"by_spiffe_id,omitempty"`
+	// Only count entries with exactly this set of selectors.
+	BySelectors []*common.Selector `protobuf:"bytes,3,rep,name=by_selectors,json=bySelectors" json:"by_selectors,omitempty"`
+	// Only count entries holding all these selectors, and possibly others.
+	WithSelectors []*common.Selector `protobuf:"bytes,4,rep,name=with_selectors,json=withSelectors" json:"with_selectors,omitempty"`
+	// Only count entries federating with all these trust domains.
+	ByFederatesWith      []string `protobuf:"bytes,5,rep,name=by_federates_with,json=byFederatesWith" json:"by_federates_with,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountEntriesRequest) Reset()         { *m = CountEntriesRequest{} }
+func (m *CountEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*CountEntriesRequest) ProtoMessage()    {}
+func (*CountEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{6}
+}
+func (m *CountEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountEntriesRequest.Unmarshal(m, b)
+}
+func (m *CountEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountEntriesRequest.Marshal(b, m, deterministic)
+}
+func (dst *CountEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountEntriesRequest.Merge(dst, src)
+}
+func (m *CountEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_CountEntriesRequest.Size(m)
+}
+func (m *CountEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountEntriesRequest proto.InternalMessageInfo
+
+func (m *CountEntriesRequest) GetByParentId() string {
+	if m != nil {
+		return m.ByParentId
+	}
+	return ""
+}
+
+func (m *CountEntriesRequest) GetBySpiffeId() string {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return ""
+}
+
+func (m *CountEntriesRequest) GetBySelectors() []*common.Selector {
+	if m != nil {
+		return m.BySelectors
+	}
+	return nil
+}
+
+func (m *CountEntriesRequest) GetWithSelectors() []*common.Selector {
+	if m != nil {
+		return m.WithSelectors
+	}
+	return nil
+}
+
+func (m *CountEntriesRequest) GetByFederatesWith() []string {
+	if m != nil {
+		return m.ByFederatesWith
+	}
+	return nil
+}
+
+// Represents the number of matching registration entries.
+type CountEntriesResponse struct {
+	// The number of matching entries.
+	Count                int32    `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountEntriesResponse) Reset()         { *m = CountEntriesResponse{} }
+func (m *CountEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountEntriesResponse) ProtoMessage()    {}
+func (*CountEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{7}
+}
+func (m *CountEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountEntriesResponse.Unmarshal(m, b)
+}
+func (m *CountEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountEntriesResponse.Marshal(b, m, deterministic)
+}
+func (dst *CountEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountEntriesResponse.Merge(dst, src)
+}
+func (m *CountEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountEntriesResponse.Size(m)
+}
+func (m *CountEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountEntriesResponse proto.InternalMessageInfo
+
+func (m *CountEntriesResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// Represents a request to update a registration entry.
+type UpdateEntryRequest struct {
+	// The entry to update. The entry ID identifies the entry, all the
+	// other fields are overwritten.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *UpdateEntryRequest) Reset()         { *m = UpdateEntryRequest{} }
+func (m *UpdateEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateEntryRequest) ProtoMessage()    {}
+func (*UpdateEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{8}
+}
+func (m *UpdateEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateEntryRequest.Unmarshal(m, b)
+}
+func (m *UpdateEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateEntryRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEntryRequest.Merge(dst, src)
+}
+func (m *UpdateEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateEntryRequest.Size(m)
+}
+func (m *UpdateEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateEntryRequest proto.InternalMessageInfo
+
+func (m *UpdateEntryRequest) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents the updated registration entry.
+type UpdateEntryResponse struct {
+	// The updated entry.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *UpdateEntryResponse) Reset()         { *m = UpdateEntryResponse{} }
+func (m *UpdateEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateEntryResponse) ProtoMessage()    {}
+func (*UpdateEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{9}
+}
+func (m *UpdateEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateEntryResponse.Unmarshal(m, b)
+}
+func (m *UpdateEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateEntryResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateEntryResponse.Merge(dst, src)
+}
+func (m *UpdateEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateEntryResponse.Size(m)
+}
+func (m *UpdateEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateEntryResponse proto.InternalMessageInfo
+
+func (m *UpdateEntryResponse) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents a request to delete a registration entry.
+type DeleteEntryRequest struct {
+	// ID of the entry to delete.
+	Id                   string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteEntryRequest) Reset()         { *m = DeleteEntryRequest{} }
+func (m *DeleteEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteEntryRequest) ProtoMessage()    {}
+func (*DeleteEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{10}
+}
+func (m *DeleteEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEntryRequest.Unmarshal(m, b)
+}
+func (m *DeleteEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEntryRequest.Marshal(b, m, deterministic)
+}
+func (dst *DeleteEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEntryRequest.Merge(dst, src)
+}
+func (m *DeleteEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteEntryRequest.Size(m)
+}
+func (m *DeleteEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEntryRequest proto.InternalMessageInfo
+
+func (m *DeleteEntryRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// Represents the deleted registration entry.
+type DeleteEntryResponse struct {
+	// The deleted entry.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *DeleteEntryResponse) Reset()         { *m = DeleteEntryResponse{} }
+func (m *DeleteEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteEntryResponse) ProtoMessage()    {}
+func (*DeleteEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{11}
+}
+func (m *DeleteEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteEntryResponse.Unmarshal(m, b)
+}
+func (m *DeleteEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteEntryResponse.Marshal(b, m, deterministic)
+}
+func (dst *DeleteEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteEntryResponse.Merge(dst, src)
+}
+func (m *DeleteEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteEntryResponse.Size(m)
+}
+func (m *DeleteEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteEntryResponse proto.InternalMessageInfo
+
+func (m *DeleteEntryResponse) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents a request to validate a prospective registration entry.
+type ValidateEntryRequest struct {
+	// The entry to validate. It is not persisted.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ValidateEntryRequest) Reset()         { *m = ValidateEntryRequest{} }
+func (m *ValidateEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateEntryRequest) ProtoMessage()    {}
+func (*ValidateEntryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{12}
+}
+func (m *ValidateEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateEntryRequest.Unmarshal(m, b)
+}
+func (m *ValidateEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateEntryRequest.Marshal(b, m, deterministic)
+}
+func (dst *ValidateEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateEntryRequest.Merge(dst, src)
+}
+func (m *ValidateEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateEntryRequest.Size(m)
+}
+func (m *ValidateEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateEntryRequest proto.InternalMessageInfo
+
+func (m *ValidateEntryRequest) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// Represents the result of validating a prospective registration entry.
+type ValidateEntryResponse struct {
+	// Problems that would cause the entry to be rejected on creation. The
+	// entry is valid if there are none.
+	Problems []string `protobuf:"bytes,1,rep,name=problems" json:"problems,omitempty"`
+	// Issues that do not prevent the entry from being created, such as a
+	// parent ID that does not exist yet.
+	Warnings []string `protobuf:"bytes,2,rep,name=warnings" json:"warnings,omitempty"`
+	// SPIFFE IDs of the attested agents that would currently be authorized
+	// to obtain SVIDs for the entry.
+	MatchingAgents       []string `protobuf:"bytes,3,rep,name=matching_agents,json=matchingAgents" json:"matching_agents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateEntryResponse) Reset()         { *m = ValidateEntryResponse{} }
+func (m *ValidateEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateEntryResponse) ProtoMessage()    {}
+func (*ValidateEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_entry_f7860e1822c0318a, []int{13}
+}
+func (m *ValidateEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateEntryResponse.Unmarshal(m, b)
+}
+func (m *ValidateEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateEntryResponse.Marshal(b, m, deterministic)
+}
+func (dst *ValidateEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateEntryResponse.Merge(dst, src)
+}
+func (m *ValidateEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateEntryResponse.Size(m)
+}
+func (m *ValidateEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateEntryResponse proto.InternalMessageInfo
+
+func (m *ValidateEntryResponse) GetProblems() []string {
+	if m != nil {
+		return m.Problems
+	}
+	return nil
+}
+
+func (m *ValidateEntryResponse) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *ValidateEntryResponse) GetMatchingAgents() []string {
+	if m != nil {
+		return m.MatchingAgents
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CreateEntryRequest)(nil), "spire.api.v1.entry.CreateEntryRequest")
+	proto.RegisterType((*CreateEntryResponse)(nil), "spire.api.v1.entry.CreateEntryResponse")
+	proto.RegisterType((*GetEntryRequest)(nil), "spire.api.v1.entry.GetEntryRequest")
+	proto.RegisterType((*GetEntryResponse)(nil), "spire.api.v1.entry.GetEntryResponse")
+	proto.RegisterType((*ListEntriesRequest)(nil), "spire.api.v1.entry.ListEntriesRequest")
+	proto.RegisterType((*ListEntriesResponse)(nil), "spire.api.v1.entry.ListEntriesResponse")
+	proto.RegisterType((*CountEntriesRequest)(nil), "spire.api.v1.entry.CountEntriesRequest")
+	proto.RegisterType((*CountEntriesResponse)(nil), "spire.api.v1.entry.CountEntriesResponse")
+	proto.RegisterType((*UpdateEntryRequest)(nil), "spire.api.v1.entry.UpdateEntryRequest")
+	proto.RegisterType((*UpdateEntryResponse)(nil), "spire.api.v1.entry.UpdateEntryResponse")
+	proto.RegisterType((*DeleteEntryRequest)(nil), "spire.api.v1.entry.DeleteEntryRequest")
+	proto.RegisterType((*DeleteEntryResponse)(nil), "spire.api.v1.entry.DeleteEntryResponse")
+	proto.RegisterType((*ValidateEntryRequest)(nil), "spire.api.v1.entry.ValidateEntryRequest")
+	proto.RegisterType((*ValidateEntryResponse)(nil), "spire.api.v1.entry.ValidateEntryResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Entry service
+
+type EntryClient interface {
+	// Creates a registration entry.
+	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error)
+	// Retrieves a registration entry by its ID.
+	GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*GetEntryResponse, error)
+	// Lists registration entries, optionally filtered.
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	// Counts registration entries, optionally filtered, without returning
+	// them.
+	CountEntries(ctx context.Context, in *CountEntriesRequest, opts ...grpc.CallOption) (*CountEntriesResponse, error)
+	// Updates a registration entry.
+	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error)
+	// Deletes a registration entry.
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error)
+	// Validates a prospective registration entry without creating it, and
+	// reports which agents it would currently match.
+	ValidateEntry(ctx context.Context, in *ValidateEntryRequest, opts ...grpc.CallOption) (*ValidateEntryResponse, error)
+}
+
+type entryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEntryClient(cc *grpc.ClientConn) EntryClient {
+	return &entryClient{cc}
+}
+
+func (c *entryClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
+	out := new(CreateEntryResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/CreateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) GetEntry(ctx context.Context, in *GetEntryRequest, opts ...grpc.CallOption) (*GetEntryResponse, error) {
+	out := new(GetEntryResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/GetEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
+	out := new(ListEntriesResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/ListEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) CountEntries(ctx context.Context, in *CountEntriesRequest, opts ...grpc.CallOption) (*CountEntriesResponse, error) {
+	out := new(CountEntriesResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/CountEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error) {
+	out := new(UpdateEntryResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/UpdateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error) {
+	out := new(DeleteEntryResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/DeleteEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *entryClient) ValidateEntry(ctx context.Context, in *ValidateEntryRequest, opts ...grpc.CallOption) (*ValidateEntryResponse, error) {
+	out := new(ValidateEntryResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.entry.Entry/ValidateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Entry service
+
+type EntryServer interface {
+	// Creates a registration entry.
+	CreateEntry(context.Context, *CreateEntryRequest) (*CreateEntryResponse, error)
+	// Retrieves a registration entry by its ID.
+	GetEntry(context.Context, *GetEntryRequest) (*GetEntryResponse, error)
+	// Lists registration entries, optionally filtered.
+	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
+	// Counts registration entries, optionally filtered, without returning
+	// them.
+	CountEntries(context.Context, *CountEntriesRequest) (*CountEntriesResponse, error)
+	// Updates a registration entry.
+	UpdateEntry(context.Context, *UpdateEntryRequest) (*UpdateEntryResponse, error)
+	// Deletes a registration entry.
+	DeleteEntry(context.Context, *DeleteEntryRequest) (*DeleteEntryResponse, error)
+	// Validates a prospective registration entry without creating it, and
+	// reports which agents it would currently match.
+	ValidateEntry(context.Context, *ValidateEntryRequest) (*ValidateEntryResponse, error)
+}
+
+func RegisterEntryServer(s *grpc.Server, srv EntryServer) {
+	s.RegisterService(&_Entry_serviceDesc, srv)
+}
+
+func _Entry_CreateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).CreateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/CreateEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).CreateEntry(ctx, req.(*CreateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_GetEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).GetEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/GetEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).GetEntry(ctx, req.(*GetEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).ListEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/ListEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).ListEntries(ctx, req.(*ListEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_CountEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).CountEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/CountEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).CountEntries(ctx, req.(*CountEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).UpdateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/UpdateEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).UpdateEntry(ctx, req.(*UpdateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).DeleteEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/DeleteEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).DeleteEntry(ctx, req.(*DeleteEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Entry_ValidateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EntryServer).ValidateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.entry.Entry/ValidateEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EntryServer).ValidateEntry(ctx, req.(*ValidateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Entry_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.v1.entry.Entry",
+	HandlerType: (*EntryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEntry",
+			Handler:    _Entry_CreateEntry_Handler,
+		},
+		{
+			MethodName: "GetEntry",
+			Handler:    _Entry_GetEntry_Handler,
+		},
+		{
+			MethodName: "ListEntries",
+			Handler:    _Entry_ListEntries_Handler,
+		},
+		{
+			MethodName: "CountEntries",
+			Handler:    _Entry_CountEntries_Handler,
+		},
+		{
+			MethodName: "UpdateEntry",
+			Handler:    _Entry_UpdateEntry_Handler,
+		},
+		{
+			MethodName: "DeleteEntry",
+			Handler:    _Entry_DeleteEntry_Handler,
+		},
+		{
+			MethodName: "ValidateEntry",
+			Handler:    _Entry_ValidateEntry_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "entry.proto",
+}
+
+func init() { proto.RegisterFile("entry.proto", fileDescriptor_entry_f7860e1822c0318a) }
+
+var fileDescriptor_entry_f7860e1822c0318a = []byte{
+	// 594 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0x5f, 0x8f, 0xd2, 0x4e,
+	0x14, 0xfd, 0x01, 0xbf, 0xba, 0xcb, 0xed, 0xfe, 0xd1, 0x01, 0x0d, 0xe9, 0x8b, 0x88, 0x1b, 0x41,
+	0x63, 0x4a, 0x16, 0xe3, 0xc3, 0x3e, 0xf8, 0xa0, 0xeb, 0x9f, 0x10, 0x31, 0x21, 0xdd, 0xac, 0x26,
+	0x3e, 0x2c, 0x69, 0xe9, 0x05, 0x26, 0x81, 0xb6, 0x76, 0x06, 0xd7, 0x7e, 0x22, 0xbf, 0xa5, 0x9a,
+	0xce, 0x4c, 0x97, 0x76, 0xdb, 0xc0, 0x46, 0xf6, 0xcd, 0x27, 0x98, 0x7b, 0xcf, 0x3d, 0x77, 0xee,
+	0xcd, 0x9c, 0x93, 0x82, 0x8e, 0x1e, 0x0f, 0x23, 0x33, 0x08, 0x7d, 0xee, 0x13, 0xc2, 0x02, 0x1a,
+	0xa2, 0x69, 0x07, 0xd4, 0xfc, 0x7e, 0x6c, 0x8a, 0x8c, 0x71, 0x3c, 0xa5, 0x7c, 0xb6, 0x74, 0xcc,
+	0xb1, 0xbf, 0xe8, 0xb2, 0x80, 0x4e, 0x26, 0xd8, 0x15, 0xa8, 0xae, 0x28, 0xe9, 0x8e, 0xfd, 0xc5,
+	0xc2, 0xf7, 0xd4, 0x8f, 0xa4, 0x69, 0x7d, 0x04, 0x72, 0x1a, 0xa2, 0xcd, 0xf1, 0x5d, 0xcc, 0x60,
+	0xe1, 0xb7, 0x25, 0x32, 0x4e, 0x5e, 0x82, 0x26, 0x18, 0x1b, 0xa5, 0x66, 0xa9, 0xa3, 0xf7, 0x1e,
+	0x9a, 0xb2, 0x99, 0xaa, 0xb4, 0x70, 0x4a, 0x19, 0x0f, 0x6d, 0x4e, 0x7d, 0x4f, 0x96, 0x49, 0x74,
+	0x6b, 0x00, 0xb5, 0x0c, 0x19, 0x0b, 0x7c, 0x8f, 0xe1, 0xdf, 0xb2, 0x3d, 0x82, 0xc3, 0x0f, 0xc8,
+	0x33, 0xf7, 0x3a, 0x80, 0x32, 0x75, 0x05, 0x4d, 0xd5, 0x2a, 0x53, 0xb7, 0xd5, 0x87, 0xbb, 0x2b,
+	0xc8, 0x76, 0xdd, 0x7e, 0x95, 0x80, 0x0c, 0x28, 0x13, 0x64, 0x14, 0x59, 0xd2, 0xb1, 0x09, 0x7b,
+	0x4e, 0x34, 0x0a, 0xec, 0x10, 0x3d, 0x3e, 0xba, 0xea, 0x0d, 0x4e, 0x34, 0x14, 0xa1, 0xbe, 0xab,
+	0x10, 0x72, 0xdd, 0x31, 0xa2, 0x9c, 0x20, 0xce, 0x44, 0xa8, 0xef, 0x92, 0x13, 0x89, 0xc0, 0x39,
+	0x8e, 0xb9, 0x1f, 0xb2, 0x46, 0xa5, 0x59, 0xe9, 0xe8, 0xbd, 0x07, 0xd9, 0x8b, 0x9d, 0xa9, 0xb4,
+	0xa5, 0x3b, 0x51, 0xf2, 0x9f, 0x91, 0x57, 0x70, 0x70, 0x49, 0xf9, 0x2c, 0x55, 0xfc, 0xff, 0xda,
+	0xe2, 0xfd, 0x18, 0xbd, 0x2a, 0x7f, 0x06, 0xf7, 0x9c, 0x68, 0x34, 0x41, 0x17, 0x43, 0x9b, 0x23,
+	0x1b, 0xc5, 0xd9, 0x86, 0xd6, 0xac, 0x74, 0xaa, 0xd6, 0xa1, 0x13, 0xbd, 0x4f, 0xe2, 0x5f, 0x28,
+	0x9f, 0xb5, 0x86, 0x50, 0xcb, 0xcc, 0xaf, 0xd6, 0x79, 0x02, 0x3b, 0x28, 0x43, 0x8d, 0x52, 0xb3,
+	0x72, 0x93, 0x85, 0x26, 0xf8, 0xd6, 0xef, 0x12, 0xd4, 0x4e, 0xfd, 0xa5, 0xf7, 0xef, 0xee, 0xf4,
+	0x39, 0xd4, 0xb3, 0x0b, 0x50, 0x4b, 0xad, 0x83, 0x36, 0x8e, 0xe3, 0x62, 0x74, 0xcd, 0x92, 0x87,
+	0x58, 0x8b, 0xe7, 0x81, 0x7b, 0x7b, 0x5a, 0xcc, 0x90, 0x6d, 0xa7, 0x8e, 0x23, 0x20, 0x6f, 0x71,
+	0x8e, 0x1c, 0xd7, 0xca, 0x71, 0x00, 0xb5, 0x0c, 0x6a, 0xbb, 0x9e, 0x9f, 0xa0, 0xfe, 0xd9, 0x9e,
+	0xd3, 0xdb, 0x5a, 0xc8, 0x0f, 0xb8, 0x7f, 0x8d, 0x4e, 0x5d, 0xcf, 0x80, 0xdd, 0x20, 0xf4, 0x9d,
+	0x39, 0x2e, 0xe4, 0x13, 0xaf, 0x5a, 0x57, 0xe7, 0x38, 0x77, 0x69, 0x87, 0x1e, 0xf5, 0xa6, 0xac,
+	0x51, 0x96, 0xb9, 0xe4, 0x4c, 0xda, 0x70, 0xb8, 0xb0, 0xf9, 0x78, 0x46, 0xbd, 0xe9, 0xc8, 0x9e,
+	0xa2, 0xc7, 0xe5, 0x2b, 0xac, 0x5a, 0x07, 0x49, 0xf8, 0xb5, 0x88, 0xf6, 0x7e, 0x6a, 0xa0, 0x89,
+	0x96, 0xe4, 0x02, 0xf4, 0x94, 0x41, 0x92, 0x27, 0x66, 0xde, 0xc4, 0xcd, 0xbc, 0x1d, 0x1b, 0xed,
+	0x8d, 0x38, 0x35, 0xca, 0x39, 0xec, 0x26, 0x7e, 0x48, 0x1e, 0x17, 0x15, 0x5d, 0x33, 0x54, 0xe3,
+	0x68, 0x3d, 0x48, 0xd1, 0x5e, 0x80, 0x9e, 0xb2, 0x86, 0xe2, 0x6b, 0xe7, 0xbd, 0xd3, 0x68, 0x6f,
+	0xc4, 0x29, 0x7e, 0x1b, 0xf6, 0xd2, 0x32, 0x21, 0xc5, 0xf3, 0xe6, 0x9d, 0xc4, 0xe8, 0x6c, 0x06,
+	0xae, 0x46, 0x48, 0xc9, 0xa1, 0x78, 0x84, 0xbc, 0xf8, 0x8c, 0xf6, 0x46, 0xdc, 0x8a, 0x3f, 0xf5,
+	0xf4, 0x8b, 0xf9, 0xf3, 0x0a, 0x32, 0xda, 0x1b, 0x71, 0x8a, 0xdf, 0x85, 0xfd, 0xcc, 0xeb, 0x25,
+	0x85, 0xa3, 0x17, 0xe9, 0xc5, 0x78, 0x7a, 0x03, 0xa4, 0xec, 0xf2, 0x66, 0xe7, 0xab, 0x14, 0xcb,
+	0xf0, 0x3f, 0xe7, 0x8e, 0xf8, 0x3e, 0x78, 0xf1, 0x67, 0x00, 0xce, 0xd9, 0x0e, 0xcc, 0x75, 0x08,
+	0x00, 0x00,
+}
@@ -0,0 +1,648 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: localauthority.proto
+
+package localauthority
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// A CA of the server.
+type AuthorityState struct {
+	// Serial number of the CA certificate, in decimal.
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	// CA certificate.
+	// ASN.1 DER encoded
+	CaCert []byte `protobuf:"bytes,2,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`
+	// Expiration date, represented in UNIX time.
+	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorityState) Reset()         { *m = AuthorityState{} }
+func (m *AuthorityState) String() string { return proto.CompactTextString(m) }
+func (*AuthorityState) ProtoMessage()    {}
+func (*AuthorityState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{0}
+}
+func (m *AuthorityState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorityState.Unmarshal(m, b)
+}
+func (m *AuthorityState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorityState.Marshal(b, m, deterministic)
+}
+func (dst *AuthorityState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorityState.Merge(dst, src)
+}
+func (m *AuthorityState) XXX_Size() int {
+	return xxx_messageInfo_AuthorityState.Size(m)
+}
+func (m *AuthorityState) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorityState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorityState proto.InternalMessageInfo
+
+func (m *AuthorityState) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *AuthorityState) GetCaCert() []byte {
+	if m != nil {
+		return m.CaCert
+	}
+	return nil
+}
+
+func (m *AuthorityState) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// Represents a request to get the state of the X509 CAs of the server.
+type GetX509AuthorityStateRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetX509AuthorityStateRequest) Reset()         { *m = GetX509AuthorityStateRequest{} }
+func (m *GetX509AuthorityStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetX509AuthorityStateRequest) ProtoMessage()    {}
+func (*GetX509AuthorityStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{1}
+}
+func (m *GetX509AuthorityStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetX509AuthorityStateRequest.Unmarshal(m, b)
+}
+func (m *GetX509AuthorityStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetX509AuthorityStateRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetX509AuthorityStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetX509AuthorityStateRequest.Merge(dst, src)
+}
+func (m *GetX509AuthorityStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetX509AuthorityStateRequest.Size(m)
+}
+func (m *GetX509AuthorityStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetX509AuthorityStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetX509AuthorityStateRequest proto.InternalMessageInfo
+
+// Represents the state of the X509 CAs of the server.
+type GetX509AuthorityStateResponse struct {
+	// The CA which signs X509-SVIDs.
+	Active *AuthorityState `protobuf:"bytes,1,opt,name=active" json:"active,omitempty"`
+	// The CA prepared to replace the active one, if any.
+	Prepared *AuthorityState `protobuf:"bytes,2,opt,name=prepared" json:"prepared,omitempty"`
+	// The CA active before the current one, if known.
+	Old                  *AuthorityState `protobuf:"bytes,3,opt,name=old" json:"old,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetX509AuthorityStateResponse) Reset()         { *m = GetX509AuthorityStateResponse{} }
+func (m *GetX509AuthorityStateResponse) String() string { return proto.CompactTextString(m) }
+func (*GetX509AuthorityStateResponse) ProtoMessage()    {}
+func (*GetX509AuthorityStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{2}
+}
+func (m *GetX509AuthorityStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetX509AuthorityStateResponse.Unmarshal(m, b)
+}
+func (m *GetX509AuthorityStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetX509AuthorityStateResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetX509AuthorityStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetX509AuthorityStateResponse.Merge(dst, src)
+}
+func (m *GetX509AuthorityStateResponse) XXX_Size() int {
+	return xxx_messageInfo_GetX509AuthorityStateResponse.Size(m)
+}
+func (m *GetX509AuthorityStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetX509AuthorityStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetX509AuthorityStateResponse proto.InternalMessageInfo
+
+func (m *GetX509AuthorityStateResponse) GetActive() *AuthorityState {
+	if m != nil {
+		return m.Active
+	}
+	return nil
+}
+
+func (m *GetX509AuthorityStateResponse) GetPrepared() *AuthorityState {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
+func (m *GetX509AuthorityStateResponse) GetOld() *AuthorityState {
+	if m != nil {
+		return m.Old
+	}
+	return nil
+}
+
+// Represents a request to prepare a new X509 CA.
+type PrepareX509AuthorityRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrepareX509AuthorityRequest) Reset()         { *m = PrepareX509AuthorityRequest{} }
+func (m *PrepareX509AuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*PrepareX509AuthorityRequest) ProtoMessage()    {}
+func (*PrepareX509AuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{3}
+}
+func (m *PrepareX509AuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrepareX509AuthorityRequest.Unmarshal(m, b)
+}
+func (m *PrepareX509AuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrepareX509AuthorityRequest.Marshal(b, m, deterministic)
+}
+func (dst *PrepareX509AuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareX509AuthorityRequest.Merge(dst, src)
+}
+func (m *PrepareX509AuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_PrepareX509AuthorityRequest.Size(m)
+}
+func (m *PrepareX509AuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareX509AuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareX509AuthorityRequest proto.InternalMessageInfo
+
+// Represents the prepared X509 CA.
+type PrepareX509AuthorityResponse struct {
+	// The prepared CA, published in the trust bundle.
+	PreparedAuthority    *AuthorityState `protobuf:"bytes,1,opt,name=prepared_authority,json=preparedAuthority" json:"prepared_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PrepareX509AuthorityResponse) Reset()         { *m = PrepareX509AuthorityResponse{} }
+func (m *PrepareX509AuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*PrepareX509AuthorityResponse) ProtoMessage()    {}
+func (*PrepareX509AuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{4}
+}
+func (m *PrepareX509AuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrepareX509AuthorityResponse.Unmarshal(m, b)
+}
+func (m *PrepareX509AuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrepareX509AuthorityResponse.Marshal(b, m, deterministic)
+}
+func (dst *PrepareX509AuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareX509AuthorityResponse.Merge(dst, src)
+}
+func (m *PrepareX509AuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_PrepareX509AuthorityResponse.Size(m)
+}
+func (m *PrepareX509AuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareX509AuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareX509AuthorityResponse proto.InternalMessageInfo
+
+func (m *PrepareX509AuthorityResponse) GetPreparedAuthority() *AuthorityState {
+	if m != nil {
+		return m.PreparedAuthority
+	}
+	return nil
+}
+
+// Represents a request to activate the prepared X509 CA.
+type ActivateX509AuthorityRequest struct {
+	// Serial number, in decimal, of the prepared CA certificate.
+	SerialNumber         string   `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivateX509AuthorityRequest) Reset()         { *m = ActivateX509AuthorityRequest{} }
+func (m *ActivateX509AuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivateX509AuthorityRequest) ProtoMessage()    {}
+func (*ActivateX509AuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{5}
+}
+func (m *ActivateX509AuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateX509AuthorityRequest.Unmarshal(m, b)
+}
+func (m *ActivateX509AuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateX509AuthorityRequest.Marshal(b, m, deterministic)
+}
+func (dst *ActivateX509AuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateX509AuthorityRequest.Merge(dst, src)
+}
+func (m *ActivateX509AuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_ActivateX509AuthorityRequest.Size(m)
+}
+func (m *ActivateX509AuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateX509AuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateX509AuthorityRequest proto.InternalMessageInfo
+
+func (m *ActivateX509AuthorityRequest) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+// Represents the activated X509 CA.
+type ActivateX509AuthorityResponse struct {
+	// The CA which signs X509-SVIDs from then on.
+	ActivatedAuthority   *AuthorityState `protobuf:"bytes,1,opt,name=activated_authority,json=activatedAuthority" json:"activated_authority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ActivateX509AuthorityResponse) Reset()         { *m = ActivateX509AuthorityResponse{} }
+func (m *ActivateX509AuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*ActivateX509AuthorityResponse) ProtoMessage()    {}
+func (*ActivateX509AuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{6}
+}
+func (m *ActivateX509AuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateX509AuthorityResponse.Unmarshal(m, b)
+}
+func (m *ActivateX509AuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateX509AuthorityResponse.Marshal(b, m, deterministic)
+}
+func (dst *ActivateX509AuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateX509AuthorityResponse.Merge(dst, src)
+}
+func (m *ActivateX509AuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_ActivateX509AuthorityResponse.Size(m)
+}
+func (m *ActivateX509AuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateX509AuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateX509AuthorityResponse proto.InternalMessageInfo
+
+func (m *ActivateX509AuthorityResponse) GetActivatedAuthority() *AuthorityState {
+	if m != nil {
+		return m.ActivatedAuthority
+	}
+	return nil
+}
+
+// Represents a request to taint an X509 CA which is no longer active.
+type TaintX509AuthorityRequest struct {
+	// Serial number, in decimal, of the CA certificate to taint.
+	SerialNumber         string   `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintX509AuthorityRequest) Reset()         { *m = TaintX509AuthorityRequest{} }
+func (m *TaintX509AuthorityRequest) String() string { return proto.CompactTextString(m) }
+func (*TaintX509AuthorityRequest) ProtoMessage()    {}
+func (*TaintX509AuthorityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{7}
+}
+func (m *TaintX509AuthorityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintX509AuthorityRequest.Unmarshal(m, b)
+}
+func (m *TaintX509AuthorityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintX509AuthorityRequest.Marshal(b, m, deterministic)
+}
+func (dst *TaintX509AuthorityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintX509AuthorityRequest.Merge(dst, src)
+}
+func (m *TaintX509AuthorityRequest) XXX_Size() int {
+	return xxx_messageInfo_TaintX509AuthorityRequest.Size(m)
+}
+func (m *TaintX509AuthorityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintX509AuthorityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintX509AuthorityRequest proto.InternalMessageInfo
+
+func (m *TaintX509AuthorityRequest) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+// Represents the tainted X509 CA.
+type TaintX509AuthorityResponse struct {
+	// The tainted CA.
+	TaintedAuthority *AuthorityState `protobuf:"bytes,1,opt,name=tainted_authority,json=taintedAuthority" json:"tainted_authority,omitempty"`
+	// Number of registration entries whose X509-SVIDs are rotated.
+	RotatedEntries       int32    `protobuf:"varint,2,opt,name=rotated_entries,json=rotatedEntries" json:"rotated_entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintX509AuthorityResponse) Reset()         { *m = TaintX509AuthorityResponse{} }
+func (m *TaintX509AuthorityResponse) String() string { return proto.CompactTextString(m) }
+func (*TaintX509AuthorityResponse) ProtoMessage()    {}
+func (*TaintX509AuthorityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_localauthority_8526122b6b1991c2, []int{8}
+}
+func (m *TaintX509AuthorityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintX509AuthorityResponse.Unmarshal(m, b)
+}
+func (m *TaintX509AuthorityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintX509AuthorityResponse.Marshal(b, m, deterministic)
+}
+func (dst *TaintX509AuthorityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintX509AuthorityResponse.Merge(dst, src)
+}
+func (m *TaintX509AuthorityResponse) XXX_Size() int {
+	return xxx_messageInfo_TaintX509AuthorityResponse.Size(m)
+}
+func (m *TaintX509AuthorityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintX509AuthorityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintX509AuthorityResponse proto.InternalMessageInfo
+
+func (m *TaintX509AuthorityResponse) GetTaintedAuthority() *AuthorityState {
+	if m != nil {
+		return m.TaintedAuthority
+	}
+	return nil
+}
+
+func (m *TaintX509AuthorityResponse) GetRotatedEntries() int32 {
+	if m != nil {
+		return m.RotatedEntries
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*AuthorityState)(nil), "spire.api.v1.localauthority.AuthorityState")
+	proto.RegisterType((*GetX509AuthorityStateRequest)(nil), "spire.api.v1.localauthority.GetX509AuthorityStateRequest")
+	proto.RegisterType((*GetX509AuthorityStateResponse)(nil), "spire.api.v1.localauthority.GetX509AuthorityStateResponse")
+	proto.RegisterType((*PrepareX509AuthorityRequest)(nil), "spire.api.v1.localauthority.PrepareX509AuthorityRequest")
+	proto.RegisterType((*PrepareX509AuthorityResponse)(nil), "spire.api.v1.localauthority.PrepareX509AuthorityResponse")
+	proto.RegisterType((*ActivateX509AuthorityRequest)(nil), "spire.api.v1.localauthority.ActivateX509AuthorityRequest")
+	proto.RegisterType((*ActivateX509AuthorityResponse)(nil), "spire.api.v1.localauthority.ActivateX509AuthorityResponse")
+	proto.RegisterType((*TaintX509AuthorityRequest)(nil), "spire.api.v1.localauthority.TaintX509AuthorityRequest")
+	proto.RegisterType((*TaintX509AuthorityResponse)(nil), "spire.api.v1.localauthority.TaintX509AuthorityResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for LocalAuthority service
+
+type LocalAuthorityClient interface {
+	// Gets the active, prepared and old X509 CAs.
+	GetX509AuthorityState(ctx context.Context, in *GetX509AuthorityStateRequest, opts ...grpc.CallOption) (*GetX509AuthorityStateResponse, error)
+	// Prepares a new X509 CA ahead of schedule, replacing any CA prepared
+	// already. It is published in the trust bundle straight away.
+	PrepareX509Authority(ctx context.Context, in *PrepareX509AuthorityRequest, opts ...grpc.CallOption) (*PrepareX509AuthorityResponse, error)
+	// Activates the prepared X509 CA ahead of schedule.
+	ActivateX509Authority(ctx context.Context, in *ActivateX509AuthorityRequest, opts ...grpc.CallOption) (*ActivateX509AuthorityResponse, error)
+	// Taints an X509 CA which is no longer active. It is published as
+	// tainted in the trust bundle, forcing the agents whose X509-SVID it
+	// signed to rotate it, and the X509-SVIDs of all the registration
+	// entries are rotated.
+	TaintX509Authority(ctx context.Context, in *TaintX509AuthorityRequest, opts ...grpc.CallOption) (*TaintX509AuthorityResponse, error)
+}
+
+type localAuthorityClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLocalAuthorityClient(cc *grpc.ClientConn) LocalAuthorityClient {
+	return &localAuthorityClient{cc}
+}
+
+func (c *localAuthorityClient) GetX509AuthorityState(ctx context.Context, in *GetX509AuthorityStateRequest, opts ...grpc.CallOption) (*GetX509AuthorityStateResponse, error) {
+	out := new(GetX509AuthorityStateResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.localauthority.LocalAuthority/GetX509AuthorityState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) PrepareX509Authority(ctx context.Context, in *PrepareX509AuthorityRequest, opts ...grpc.CallOption) (*PrepareX509AuthorityResponse, error) {
+	out := new(PrepareX509AuthorityResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.localauthority.LocalAuthority/PrepareX509Authority", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) ActivateX509Authority(ctx context.Context, in *ActivateX509AuthorityRequest, opts ...grpc.CallOption) (*ActivateX509AuthorityResponse, error) {
+	out := new(ActivateX509AuthorityResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.localauthority.LocalAuthority/ActivateX509Authority", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *localAuthorityClient) TaintX509Authority(ctx context.Context, in *TaintX509AuthorityRequest, opts ...grpc.CallOption) (*TaintX509AuthorityResponse, error) {
+	out := new(TaintX509AuthorityResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.localauthority.LocalAuthority/TaintX509Authority", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for LocalAuthority service
+
+type LocalAuthorityServer interface {
+	// Gets the active, prepared and old X509 CAs.
+	GetX509AuthorityState(context.Context, *GetX509AuthorityStateRequest) (*GetX509AuthorityStateResponse, error)
+	// Prepares a new X509 CA ahead of schedule, replacing any CA prepared
+	// already. It is published in the trust bundle straight away.
+	PrepareX509Authority(context.Context, *PrepareX509AuthorityRequest) (*PrepareX509AuthorityResponse, error)
+	// Activates the prepared X509 CA ahead of schedule.
+	ActivateX509Authority(context.Context, *ActivateX509AuthorityRequest) (*ActivateX509AuthorityResponse, error)
+	// Taints an X509 CA which is no longer active. It is published as
+	// tainted in the trust bundle, forcing the agents whose X509-SVID it
+	// signed to rotate it, and the X509-SVIDs of all the registration
+	// entries are rotated.
+	TaintX509Authority(context.Context, *TaintX509AuthorityRequest) (*TaintX509AuthorityResponse, error)
+}
+
+func RegisterLocalAuthorityServer(s *grpc.Server, srv LocalAuthorityServer) {
+	s.RegisterService(&_LocalAuthority_serviceDesc, srv)
+}
+
+func _LocalAuthority_GetX509AuthorityState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetX509AuthorityStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).GetX509AuthorityState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.localauthority.LocalAuthority/GetX509AuthorityState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).GetX509AuthorityState(ctx, req.(*GetX509AuthorityStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_PrepareX509Authority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareX509AuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).PrepareX509Authority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.localauthority.LocalAuthority/PrepareX509Authority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).PrepareX509Authority(ctx, req.(*PrepareX509AuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_ActivateX509Authority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateX509AuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).ActivateX509Authority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.localauthority.LocalAuthority/ActivateX509Authority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).ActivateX509Authority(ctx, req.(*ActivateX509AuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LocalAuthority_TaintX509Authority_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintX509AuthorityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocalAuthorityServer).TaintX509Authority(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.localauthority.LocalAuthority/TaintX509Authority",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocalAuthorityServer).TaintX509Authority(ctx, req.(*TaintX509AuthorityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _LocalAuthority_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.v1.localauthority.LocalAuthority",
+	HandlerType: (*LocalAuthorityServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetX509AuthorityState",
+			Handler:    _LocalAuthority_GetX509AuthorityState_Handler,
+		},
+		{
+			MethodName: "PrepareX509Authority",
+			Handler:    _LocalAuthority_PrepareX509Authority_Handler,
+		},
+		{
+			MethodName: "ActivateX509Authority",
+			Handler:    _LocalAuthority_ActivateX509Authority_Handler,
+		},
+		{
+			MethodName: "TaintX509Authority",
+			Handler:    _LocalAuthority_TaintX509Authority_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "localauthority.proto",
+}
+
+func init() {
+	proto.RegisterFile("localauthority.proto", fileDescriptor_localauthority_8526122b6b1991c2)
+}
+
+var fileDescriptor_localauthority_8526122b6b1991c2 = []byte{
+	// 461 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x5d, 0x8b, 0xd3, 0x50,
+	0x10, 0xe5, 0x6e, 0xb4, 0xba, 0xe3, 0x1a, 0x77, 0xaf, 0x2b, 0xc6, 0x6c, 0x23, 0x25, 0x3e, 0x58,
+	0x10, 0x82, 0xae, 0xf8, 0xd1, 0x05, 0xc1, 0x5a, 0x64, 0x5f, 0x44, 0x24, 0xfa, 0xb0, 0x2c, 0x42,
+	0xb8, 0x9b, 0x0e, 0x18, 0x48, 0x93, 0x78, 0x33, 0x2d, 0x2a, 0xf8, 0xe8, 0x93, 0xe0, 0x5f, 0xf0,
+	0xef, 0xf8, 0x63, 0xfc, 0x11, 0x92, 0xe4, 0x26, 0x25, 0x9a, 0xc6, 0x6d, 0xfa, 0x98, 0x73, 0xe7,
+	0x9c, 0x39, 0x73, 0x98, 0x21, 0xb0, 0x1f, 0xc6, 0xbe, 0x08, 0xc5, 0x9c, 0x3e, 0xc4, 0x32, 0xa0,
+	0xcf, 0x4e, 0x22, 0x63, 0x8a, 0xf9, 0x41, 0x9a, 0x04, 0x12, 0x1d, 0x91, 0x04, 0xce, 0xe2, 0x81,
+	0x53, 0x2f, 0xb1, 0x67, 0xa0, 0x8f, 0xcb, 0x8f, 0xb7, 0x24, 0x08, 0xf9, 0x1d, 0xb8, 0x9a, 0xa2,
+	0x0c, 0x44, 0xe8, 0x45, 0xf3, 0xd9, 0x19, 0x4a, 0x83, 0x0d, 0xd8, 0x70, 0xdb, 0xdd, 0x29, 0xc0,
+	0xd7, 0x39, 0xc6, 0x6f, 0xc2, 0x25, 0x5f, 0x78, 0x3e, 0x4a, 0x32, 0xb6, 0x06, 0x6c, 0xb8, 0xe3,
+	0xf6, 0x7c, 0x31, 0x41, 0x49, 0xdc, 0x02, 0xc0, 0x4f, 0x59, 0xbf, 0xd4, 0x13, 0x64, 0x68, 0x03,
+	0x36, 0xd4, 0xdc, 0x6d, 0x85, 0x8c, 0xc9, 0xbe, 0x0d, 0xfd, 0x63, 0xa4, 0x93, 0x47, 0xf7, 0x47,
+	0xf5, 0xae, 0x2e, 0x7e, 0x9c, 0x63, 0x4a, 0xf6, 0x6f, 0x06, 0xd6, 0x8a, 0x82, 0x34, 0x89, 0xa3,
+	0x14, 0xf9, 0x04, 0x7a, 0xc2, 0xa7, 0x60, 0x81, 0xb9, 0xaf, 0x2b, 0x87, 0xf7, 0x9c, 0x96, 0xf1,
+	0x9c, 0xbf, 0x44, 0x14, 0x95, 0x1f, 0xc3, 0xe5, 0x44, 0x62, 0x22, 0x24, 0x4e, 0x8d, 0xad, 0xf5,
+	0x65, 0x2a, 0x32, 0x7f, 0x06, 0x5a, 0x1c, 0x4e, 0x0d, 0x6d, 0x7d, 0x8d, 0x8c, 0x67, 0x5b, 0x70,
+	0xf0, 0xa6, 0x90, 0xaa, 0x4d, 0x5c, 0xa6, 0xf1, 0x05, 0xfa, 0xcd, 0xcf, 0x2a, 0x8b, 0x53, 0xe0,
+	0xa5, 0x13, 0xaf, 0x6a, 0xd4, 0x25, 0x97, 0xbd, 0x52, 0xa6, 0xc2, 0xed, 0x09, 0xf4, 0xc7, 0x59,
+	0x58, 0x82, 0x1a, 0xbd, 0x9d, 0x6b, 0x4d, 0xec, 0xaf, 0x60, 0xad, 0x10, 0x51, 0x13, 0xbc, 0x87,
+	0xeb, 0x42, 0x15, 0x6c, 0x38, 0x02, 0xaf, 0x74, 0x96, 0x33, 0x3c, 0x87, 0x5b, 0xef, 0x44, 0x10,
+	0x51, 0xf7, 0x01, 0x7e, 0x32, 0x30, 0x9b, 0x24, 0x94, 0xfd, 0x13, 0xd8, 0xa3, 0xec, 0x75, 0x53,
+	0xf3, 0xbb, 0x4a, 0xa5, 0x82, 0xf9, 0x5d, 0xb8, 0x26, 0x63, 0xca, 0x63, 0xc1, 0x88, 0x64, 0x80,
+	0x69, 0xbe, 0xa8, 0x17, 0x5d, 0x5d, 0xc1, 0x2f, 0x0b, 0xf4, 0xf0, 0xd7, 0x05, 0xd0, 0x5f, 0x65,
+	0xe2, 0x4b, 0xee, 0x0f, 0x06, 0x37, 0x1a, 0x8f, 0x88, 0x8f, 0x5a, 0x4d, 0xb5, 0x5d, 0xa6, 0x79,
+	0xd4, 0x85, 0xaa, 0x62, 0xfa, 0xce, 0x60, 0xbf, 0x69, 0x91, 0xf9, 0xd3, 0x56, 0xd1, 0x96, 0xd3,
+	0x30, 0x47, 0x1d, 0x98, 0xca, 0x4d, 0x16, 0x4f, 0xe3, 0x56, 0xfe, 0x27, 0x9e, 0xb6, 0x73, 0x30,
+	0x8f, 0xba, 0x50, 0x95, 0xa1, 0x6f, 0x0c, 0xf8, 0xbf, 0x4b, 0xc6, 0x1f, 0xb7, 0x4a, 0xae, 0x5c,
+	0x6c, 0xf3, 0xc9, 0xda, 0xbc, 0xc2, 0xc7, 0x8b, 0xdd, 0x53, 0xbd, 0x5e, 0x7c, 0xd6, 0xcb, 0xff,
+	0x20, 0x0f, 0xff, 0x0c, 0x00, 0xd3, 0xa1, 0x73, 0x61, 0x59, 0x06, 0x00, 0x00,
+}
@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: svid.proto
+
+package svid
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// An X509-SVID.
+type X509SVID struct {
+	// SPIFFE ID of the SVID.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// Certificate chain, leaf first.
+	// ASN.1 DER encoded
+	CertChain [][]byte `protobuf:"bytes,2,rep,name=cert_chain,json=certChain,proto3" json:"cert_chain,omitempty"`
+	// Expiration date, represented in UNIX time.
+	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVID) Reset()         { *m = X509SVID{} }
+func (m *X509SVID) String() string { return proto.CompactTextString(m) }
+func (*X509SVID) ProtoMessage()    {}
+func (*X509SVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_svid_a6be5a7067968721, []int{0}
+}
+func (m *X509SVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVID.Unmarshal(m, b)
+}
+func (m *X509SVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVID.Marshal(b, m, deterministic)
+}
+func (dst *X509SVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVID.Merge(dst, src)
+}
+func (m *X509SVID) XXX_Size() int {
+	return xxx_messageInfo_X509SVID.Size(m)
+}
+func (m *X509SVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVID proto.InternalMessageInfo
+
+func (m *X509SVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *X509SVID) GetCertChain() [][]byte {
+	if m != nil {
+		return m.CertChain
+	}
+	return nil
+}
+
+func (m *X509SVID) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// Represents a request to mint an X509-SVID.
+type MintX509SVIDRequest struct {
+	// Certificate signing request carrying the SPIFFE ID as its only URI SAN.
+	// ASN.1 DER encoded
+	Csr []byte `protobuf:"bytes,1,opt,name=csr,proto3" json:"csr,omitempty"`
+	// TTL in seconds. If not set, the server default is used.
+	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MintX509SVIDRequest) Reset()         { *m = MintX509SVIDRequest{} }
+func (m *MintX509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*MintX509SVIDRequest) ProtoMessage()    {}
+func (*MintX509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_svid_a6be5a7067968721, []int{1}
+}
+func (m *MintX509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MintX509SVIDRequest.Unmarshal(m, b)
+}
+func (m *MintX509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MintX509SVIDRequest.Marshal(b, m, deterministic)
+}
+func (dst *MintX509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MintX509SVIDRequest.Merge(dst, src)
+}
+func (m *MintX509SVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_MintX509SVIDRequest.Size(m)
+}
+func (m *MintX509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MintX509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MintX509SVIDRequest proto.InternalMessageInfo
+
+func (m *MintX509SVIDRequest) GetCsr() []byte {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
+func (m *MintX509SVIDRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+// Represents the minted X509-SVID.
+type MintX509SVIDResponse struct {
+	// The minted SVID.
+	Svid                 *X509SVID `protobuf:"bytes,1,opt,name=svid" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *MintX509SVIDResponse) Reset()         { *m = MintX509SVIDResponse{} }
+func (m *MintX509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*MintX509SVIDResponse) ProtoMessage()    {}
+func (*MintX509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_svid_a6be5a7067968721, []int{2}
+}
+func (m *MintX509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MintX509SVIDResponse.Unmarshal(m, b)
+}
+func (m *MintX509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MintX509SVIDResponse.Marshal(b, m, deterministic)
+}
+func (dst *MintX509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MintX509SVIDResponse.Merge(dst, src)
+}
+func (m *MintX509SVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_MintX509SVIDResponse.Size(m)
+}
+func (m *MintX509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MintX509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MintX509SVIDResponse proto.InternalMessageInfo
+
+func (m *MintX509SVIDResponse) GetSvid() *X509SVID {
+	if m != nil {
+		return m.Svid
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*X509SVID)(nil), "spire.api.v1.svid.X509SVID")
+	proto.RegisterType((*MintX509SVIDRequest)(nil), "spire.api.v1.svid.MintX509SVIDRequest")
+	proto.RegisterType((*MintX509SVIDResponse)(nil), "spire.api.v1.svid.MintX509SVIDResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for SVID service
+
+type SVIDClient interface {
+	// Mints an X509-SVID for the SPIFFE ID in the given CSR.
+	MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error)
+}
+
+type sVIDClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSVIDClient(cc *grpc.ClientConn) SVIDClient {
+	return &sVIDClient{cc}
+}
+
+func (c *sVIDClient) MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error) {
+	out := new(MintX509SVIDResponse)
+	err := grpc.Invoke(ctx, "/spire.api.v1.svid.SVID/MintX509SVID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SVID service
+
+type SVIDServer interface {
+	// Mints an X509-SVID for the SPIFFE ID in the given CSR.
+	MintX509SVID(context.Context, *MintX509SVIDRequest) (*MintX509SVIDResponse, error)
+}
+
+func RegisterSVIDServer(s *grpc.Server, srv SVIDServer) {
+	s.RegisterService(&_SVID_serviceDesc, srv)
+}
+
+func _SVID_MintX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintX509SVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SVIDServer).MintX509SVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.v1.svid.SVID/MintX509SVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SVIDServer).MintX509SVID(ctx, req.(*MintX509SVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SVID_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.api.v1.svid.SVID",
+	HandlerType: (*SVIDServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MintX509SVID",
+			Handler:    _SVID_MintX509SVID_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "svid.proto",
+}
+
+func init() { proto.RegisterFile("svid.proto", fileDescriptor_svid_a6be5a7067968721) }
+
+var fileDescriptor_svid_a6be5a7067968721 = []byte{
+	// 246 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0x2e, 0xcb, 0x4c,
+	0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x2c, 0x2e, 0xc8, 0x2c, 0x4a, 0xd5, 0x4b, 0x2c,
+	0xc8, 0xd4, 0x2b, 0x33, 0xd4, 0x03, 0x49, 0x28, 0xa5, 0x72, 0x71, 0x44, 0x98, 0x1a, 0x58, 0x06,
+	0x87, 0x79, 0xba, 0x08, 0x49, 0x73, 0x71, 0x16, 0x17, 0x64, 0xa6, 0xa5, 0xa5, 0xc6, 0x67, 0xa6,
+	0x48, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x71, 0x40, 0x04, 0x3c, 0x53, 0x84, 0x64, 0xb9, 0xb8,
+	0x92, 0x53, 0x8b, 0x4a, 0xe2, 0x93, 0x33, 0x12, 0x33, 0xf3, 0x24, 0x98, 0x14, 0x98, 0x35, 0x78,
+	0x82, 0x38, 0x41, 0x22, 0xce, 0x20, 0x01, 0x90, 0x74, 0x6a, 0x05, 0xc8, 0xf4, 0xe2, 0xf8, 0xc4,
+	0x12, 0x09, 0x66, 0x05, 0x46, 0x0d, 0xe6, 0x20, 0x4e, 0xa8, 0x88, 0x63, 0x89, 0x92, 0x25, 0x97,
+	0xb0, 0x6f, 0x66, 0x5e, 0x09, 0xcc, 0xaa, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x21, 0x01,
+	0x2e, 0xe6, 0xe4, 0xe2, 0x22, 0xb0, 0x5d, 0x3c, 0x41, 0x20, 0x26, 0x48, 0xa4, 0xa4, 0x24, 0x47,
+	0x82, 0x49, 0x81, 0x51, 0x83, 0x35, 0x08, 0xc4, 0x54, 0x72, 0xe7, 0x12, 0x41, 0xd5, 0x5a, 0x5c,
+	0x90, 0x9f, 0x57, 0x9c, 0x2a, 0xa4, 0xcf, 0xc5, 0x02, 0xf2, 0x01, 0x58, 0x33, 0xb7, 0x91, 0xb4,
+	0x1e, 0x86, 0xdf, 0xf4, 0xe0, 0x5a, 0xc0, 0x0a, 0x8d, 0xd2, 0xb9, 0x58, 0xc0, 0xde, 0x8c, 0xe7,
+	0xe2, 0x41, 0x36, 0x50, 0x48, 0x0d, 0x8b, 0x56, 0x2c, 0x8e, 0x95, 0x52, 0x27, 0xa8, 0x0e, 0xe2,
+	0x32, 0x27, 0xb6, 0x28, 0xb0, 0x85, 0x49, 0x6c, 0xe0, 0x50, 0x37, 0x06, 0x0c, 0x00, 0xbb, 0x96,
+	0x50, 0x22, 0x83, 0x01, 0x00, 0x00,
+}
@@ -6,6 +6,7 @@ package workload
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _struct "github.com/golang/protobuf/ptypes/struct"
 
 import (
 	context "golang.org/x/net/context"
@@ -33,7 +34,7 @@ func (m *X509SVIDRequest) Reset()         { *m = X509SVIDRequest{} }
 func (m *X509SVIDRequest) String() string { return proto.CompactTextString(m) }
 func (*X509SVIDRequest) ProtoMessage()    {}
 func (*X509SVIDRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_workload_9e6c20ae36e034a5, []int{0}
+	return fileDescriptor_workload_0c72276bb2b54269, []int{0}
 }
 func (m *X509SVIDRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_X509SVIDRequest.Unmarshal(m, b)
@@ -76,7 +77,7 @@ func (m *X509SVIDResponse) Reset()         { *m = X509SVIDResponse{} }
 func (m *X509SVIDResponse) String() string { return proto.CompactTextString(m) }
 func (*X509SVIDResponse) ProtoMessage()    {}
 func (*X509SVIDResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_workload_9e6c20ae36e034a5, []int{1}
+	return fileDescriptor_workload_0c72276bb2b54269, []int{1}
 }
 func (m *X509SVIDResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_X509SVIDResponse.Unmarshal(m, b)
@@ -139,7 +140,7 @@ func (m *X509SVID) Reset()         { *m = X509SVID{} }
 func (m *X509SVID) String() string { return proto.CompactTextString(m) }
 func (*X509SVID) ProtoMessage()    {}
 func (*X509SVID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_workload_9e6c20ae36e034a5, []int{2}
+	return fileDescriptor_workload_0c72276bb2b54269, []int{2}
 }
 func (m *X509SVID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_X509SVID.Unmarshal(m, b)
@@ -187,11 +188,413 @@ func (m *X509SVID) GetBundle() []byte {
 	return nil
 }
 
+type X509BundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509BundlesRequest) Reset()         { *m = X509BundlesRequest{} }
+func (m *X509BundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*X509BundlesRequest) ProtoMessage()    {}
+func (*X509BundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{3}
+}
+func (m *X509BundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509BundlesRequest.Unmarshal(m, b)
+}
+func (m *X509BundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509BundlesRequest.Marshal(b, m, deterministic)
+}
+func (dst *X509BundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509BundlesRequest.Merge(dst, src)
+}
+func (m *X509BundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_X509BundlesRequest.Size(m)
+}
+func (m *X509BundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509BundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509BundlesRequest proto.InternalMessageInfo
+
+// The X509BundlesResponse message carries the CA certificate bundles
+// the workload should trust, without any SVID or private key.
+type X509BundlesResponse struct {
+	// CA certificate bundles keyed by the SPIFFE ID of the Trust Domain,
+	// including the workload's own Trust Domain. Bundles are ASN.1 DER
+	// encoded.
+	Bundles map[string][]byte `protobuf:"bytes,1,rep,name=bundles" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ASN.1 DER encoded
+	Crl                  [][]byte `protobuf:"bytes,2,rep,name=crl,proto3" json:"crl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509BundlesResponse) Reset()         { *m = X509BundlesResponse{} }
+func (m *X509BundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*X509BundlesResponse) ProtoMessage()    {}
+func (*X509BundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{4}
+}
+func (m *X509BundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509BundlesResponse.Unmarshal(m, b)
+}
+func (m *X509BundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509BundlesResponse.Marshal(b, m, deterministic)
+}
+func (dst *X509BundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509BundlesResponse.Merge(dst, src)
+}
+func (m *X509BundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_X509BundlesResponse.Size(m)
+}
+func (m *X509BundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509BundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509BundlesResponse proto.InternalMessageInfo
+
+func (m *X509BundlesResponse) GetBundles() map[string][]byte {
+	if m != nil {
+		return m.Bundles
+	}
+	return nil
+}
+
+func (m *X509BundlesResponse) GetCrl() [][]byte {
+	if m != nil {
+		return m.Crl
+	}
+	return nil
+}
+
+// The JWTSVID message carries a single JWT-SVID for one of the
+// identities the workload is entitled to.
+type JWTSVID struct {
+	// The SPIFFE ID of the JWT-SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// Encoded using JWS Compact Serialization
+	Svid                 string   `protobuf:"bytes,2,opt,name=svid" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JWTSVID) Reset()         { *m = JWTSVID{} }
+func (m *JWTSVID) String() string { return proto.CompactTextString(m) }
+func (*JWTSVID) ProtoMessage()    {}
+func (*JWTSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{5}
+}
+func (m *JWTSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTSVID.Unmarshal(m, b)
+}
+func (m *JWTSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTSVID.Marshal(b, m, deterministic)
+}
+func (dst *JWTSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTSVID.Merge(dst, src)
+}
+func (m *JWTSVID) XXX_Size() int {
+	return xxx_messageInfo_JWTSVID.Size(m)
+}
+func (m *JWTSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTSVID proto.InternalMessageInfo
+
+func (m *JWTSVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *JWTSVID) GetSvid() string {
+	if m != nil {
+		return m.Svid
+	}
+	return ""
+}
+
+type JWTSVIDRequest struct {
+	// The audience the JWT-SVIDs are requested for. MUST have at least
+	// one value.
+	Audience []string `protobuf:"bytes,1,rep,name=audience" json:"audience,omitempty"`
+	// SPIFFE ID of the JWT-SVID requested. If unset, JWT-SVIDs are
+	// returned for every identity the workload is entitled to.
+	SpiffeId             string   `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JWTSVIDRequest) Reset()         { *m = JWTSVIDRequest{} }
+func (m *JWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*JWTSVIDRequest) ProtoMessage()    {}
+func (*JWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{6}
+}
+func (m *JWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *JWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (dst *JWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTSVIDRequest.Merge(dst, src)
+}
+func (m *JWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_JWTSVIDRequest.Size(m)
+}
+func (m *JWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTSVIDRequest proto.InternalMessageInfo
+
+func (m *JWTSVIDRequest) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
+}
+
+func (m *JWTSVIDRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// The JWTSVIDResponse message carries a set of JWT-SVIDs.
+type JWTSVIDResponse struct {
+	Svids                []*JWTSVID `protobuf:"bytes,1,rep,name=svids" json:"svids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *JWTSVIDResponse) Reset()         { *m = JWTSVIDResponse{} }
+func (m *JWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*JWTSVIDResponse) ProtoMessage()    {}
+func (*JWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{7}
+}
+func (m *JWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTSVIDResponse.Unmarshal(m, b)
+}
+func (m *JWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTSVIDResponse.Marshal(b, m, deterministic)
+}
+func (dst *JWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTSVIDResponse.Merge(dst, src)
+}
+func (m *JWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_JWTSVIDResponse.Size(m)
+}
+func (m *JWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTSVIDResponse proto.InternalMessageInfo
+
+func (m *JWTSVIDResponse) GetSvids() []*JWTSVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+type JWTBundlesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JWTBundlesRequest) Reset()         { *m = JWTBundlesRequest{} }
+func (m *JWTBundlesRequest) String() string { return proto.CompactTextString(m) }
+func (*JWTBundlesRequest) ProtoMessage()    {}
+func (*JWTBundlesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{8}
+}
+func (m *JWTBundlesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTBundlesRequest.Unmarshal(m, b)
+}
+func (m *JWTBundlesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTBundlesRequest.Marshal(b, m, deterministic)
+}
+func (dst *JWTBundlesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTBundlesRequest.Merge(dst, src)
+}
+func (m *JWTBundlesRequest) XXX_Size() int {
+	return xxx_messageInfo_JWTBundlesRequest.Size(m)
+}
+func (m *JWTBundlesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTBundlesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTBundlesRequest proto.InternalMessageInfo
+
+// The JWTBundlesResponse message carries the public keys used to
+// validate JWT-SVIDs.
+type JWTBundlesResponse struct {
+	// JWK sets keyed by the SPIFFE ID of the Trust Domain, including
+	// the workload's own Trust Domain.
+	Bundles              map[string][]byte `protobuf:"bytes,1,rep,name=bundles" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *JWTBundlesResponse) Reset()         { *m = JWTBundlesResponse{} }
+func (m *JWTBundlesResponse) String() string { return proto.CompactTextString(m) }
+func (*JWTBundlesResponse) ProtoMessage()    {}
+func (*JWTBundlesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{9}
+}
+func (m *JWTBundlesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JWTBundlesResponse.Unmarshal(m, b)
+}
+func (m *JWTBundlesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JWTBundlesResponse.Marshal(b, m, deterministic)
+}
+func (dst *JWTBundlesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JWTBundlesResponse.Merge(dst, src)
+}
+func (m *JWTBundlesResponse) XXX_Size() int {
+	return xxx_messageInfo_JWTBundlesResponse.Size(m)
+}
+func (m *JWTBundlesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JWTBundlesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JWTBundlesResponse proto.InternalMessageInfo
+
+func (m *JWTBundlesResponse) GetBundles() map[string][]byte {
+	if m != nil {
+		return m.Bundles
+	}
+	return nil
+}
+
+type ValidateJWTSVIDRequest struct {
+	// The audience the JWT-SVID is expected to carry.
+	Audience string `protobuf:"bytes,1,opt,name=audience" json:"audience,omitempty"`
+	// Encoded using JWS Compact Serialization
+	Svid                 string   `protobuf:"bytes,2,opt,name=svid" json:"svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateJWTSVIDRequest) Reset()         { *m = ValidateJWTSVIDRequest{} }
+func (m *ValidateJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateJWTSVIDRequest) ProtoMessage()    {}
+func (*ValidateJWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{10}
+}
+func (m *ValidateJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateJWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *ValidateJWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateJWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (dst *ValidateJWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateJWTSVIDRequest.Merge(dst, src)
+}
+func (m *ValidateJWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateJWTSVIDRequest.Size(m)
+}
+func (m *ValidateJWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateJWTSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateJWTSVIDRequest proto.InternalMessageInfo
+
+func (m *ValidateJWTSVIDRequest) GetAudience() string {
+	if m != nil {
+		return m.Audience
+	}
+	return ""
+}
+
+func (m *ValidateJWTSVIDRequest) GetSvid() string {
+	if m != nil {
+		return m.Svid
+	}
+	return ""
+}
+
+// The ValidateJWTSVIDResponse message carries the SPIFFE ID and claims
+// of a valid JWT-SVID.
+type ValidateJWTSVIDResponse struct {
+	// The SPIFFE ID of the validated JWT-SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// All of the claims of the validated JWT-SVID
+	Claims               *_struct.Struct `protobuf:"bytes,2,opt,name=claims" json:"claims,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ValidateJWTSVIDResponse) Reset()         { *m = ValidateJWTSVIDResponse{} }
+func (m *ValidateJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateJWTSVIDResponse) ProtoMessage()    {}
+func (*ValidateJWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_workload_0c72276bb2b54269, []int{11}
+}
+func (m *ValidateJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateJWTSVIDResponse.Unmarshal(m, b)
+}
+func (m *ValidateJWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateJWTSVIDResponse.Marshal(b, m, deterministic)
+}
+func (dst *ValidateJWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateJWTSVIDResponse.Merge(dst, src)
+}
+func (m *ValidateJWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateJWTSVIDResponse.Size(m)
+}
+func (m *ValidateJWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateJWTSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateJWTSVIDResponse proto.InternalMessageInfo
+
+func (m *ValidateJWTSVIDResponse) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *ValidateJWTSVIDResponse) GetClaims() *_struct.Struct {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*X509SVIDRequest)(nil), "X509SVIDRequest")
 	proto.RegisterType((*X509SVIDResponse)(nil), "X509SVIDResponse")
 	proto.RegisterMapType((map[string][]byte)(nil), "X509SVIDResponse.FederatedBundlesEntry")
 	proto.RegisterType((*X509SVID)(nil), "X509SVID")
+	proto.RegisterType((*X509BundlesRequest)(nil), "X509BundlesRequest")
+	proto.RegisterType((*X509BundlesResponse)(nil), "X509BundlesResponse")
+	proto.RegisterMapType((map[string][]byte)(nil), "X509BundlesResponse.BundlesEntry")
+	proto.RegisterType((*JWTSVID)(nil), "JWTSVID")
+	proto.RegisterType((*JWTSVIDRequest)(nil), "JWTSVIDRequest")
+	proto.RegisterType((*JWTSVIDResponse)(nil), "JWTSVIDResponse")
+	proto.RegisterType((*JWTBundlesRequest)(nil), "JWTBundlesRequest")
+	proto.RegisterType((*JWTBundlesResponse)(nil), "JWTBundlesResponse")
+	proto.RegisterMapType((map[string][]byte)(nil), "JWTBundlesResponse.BundlesEntry")
+	proto.RegisterType((*ValidateJWTSVIDRequest)(nil), "ValidateJWTSVIDRequest")
+	proto.RegisterType((*ValidateJWTSVIDResponse)(nil), "ValidateJWTSVIDResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -210,6 +613,20 @@ type SpiffeWorkloadAPIClient interface {
 	// well as related information like trust bundles and CRLs. As
 	// this information changes, subsequent messages will be sent.
 	FetchX509SVID(ctx context.Context, in *X509SVIDRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchX509SVIDClient, error)
+	// Fetch the X.509 bundles the workload should trust, without any SVID
+	// or private key, e.g. for workloads that only validate peers. As the
+	// bundles change, subsequent messages will be sent.
+	FetchX509Bundles(ctx context.Context, in *X509BundlesRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchX509BundlesClient, error)
+	// JWT-SVID Profile
+	// Fetch JWT-SVIDs for the requested audience, for all or one of the
+	// SPIFFE identities the workload is entitled to.
+	FetchJWTSVID(ctx context.Context, in *JWTSVIDRequest, opts ...grpc.CallOption) (*JWTSVIDResponse, error)
+	// Fetch the JWT bundles used to validate JWT-SVIDs. As the bundles
+	// change, subsequent messages will be sent.
+	FetchJWTBundles(ctx context.Context, in *JWTBundlesRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchJWTBundlesClient, error)
+	// Validate a JWT-SVID against the JWT bundles, returning its SPIFFE
+	// ID and claims.
+	ValidateJWTSVID(ctx context.Context, in *ValidateJWTSVIDRequest, opts ...grpc.CallOption) (*ValidateJWTSVIDResponse, error)
 }
 
 type spiffeWorkloadAPIClient struct {
@@ -252,6 +669,88 @@ func (x *spiffeWorkloadAPIFetchX509SVIDClient) Recv() (*X509SVIDResponse, error)
 	return m, nil
 }
 
+func (c *spiffeWorkloadAPIClient) FetchX509Bundles(ctx context.Context, in *X509BundlesRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchX509BundlesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SpiffeWorkloadAPI_serviceDesc.Streams[1], c.cc, "/SpiffeWorkloadAPI/FetchX509Bundles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &spiffeWorkloadAPIFetchX509BundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SpiffeWorkloadAPI_FetchX509BundlesClient interface {
+	Recv() (*X509BundlesResponse, error)
+	grpc.ClientStream
+}
+
+type spiffeWorkloadAPIFetchX509BundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *spiffeWorkloadAPIFetchX509BundlesClient) Recv() (*X509BundlesResponse, error) {
+	m := new(X509BundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *spiffeWorkloadAPIClient) FetchJWTSVID(ctx context.Context, in *JWTSVIDRequest, opts ...grpc.CallOption) (*JWTSVIDResponse, error) {
+	out := new(JWTSVIDResponse)
+	err := grpc.Invoke(ctx, "/SpiffeWorkloadAPI/FetchJWTSVID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spiffeWorkloadAPIClient) FetchJWTBundles(ctx context.Context, in *JWTBundlesRequest, opts ...grpc.CallOption) (SpiffeWorkloadAPI_FetchJWTBundlesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SpiffeWorkloadAPI_serviceDesc.Streams[2], c.cc, "/SpiffeWorkloadAPI/FetchJWTBundles", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &spiffeWorkloadAPIFetchJWTBundlesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SpiffeWorkloadAPI_FetchJWTBundlesClient interface {
+	Recv() (*JWTBundlesResponse, error)
+	grpc.ClientStream
+}
+
+type spiffeWorkloadAPIFetchJWTBundlesClient struct {
+	grpc.ClientStream
+}
+
+func (x *spiffeWorkloadAPIFetchJWTBundlesClient) Recv() (*JWTBundlesResponse, error) {
+	m := new(JWTBundlesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *spiffeWorkloadAPIClient) ValidateJWTSVID(ctx context.Context, in *ValidateJWTSVIDRequest, opts ...grpc.CallOption) (*ValidateJWTSVIDResponse, error) {
+	out := new(ValidateJWTSVIDResponse)
+	err := grpc.Invoke(ctx, "/SpiffeWorkloadAPI/ValidateJWTSVID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for SpiffeWorkloadAPI service
 
 type SpiffeWorkloadAPIServer interface {
@@ -260,6 +759,20 @@ type SpiffeWorkloadAPIServer interface {
 	// well as related information like trust bundles and CRLs. As
 	// this information changes, subsequent messages will be sent.
 	FetchX509SVID(*X509SVIDRequest, SpiffeWorkloadAPI_FetchX509SVIDServer) error
+	// Fetch the X.509 bundles the workload should trust, without any SVID
+	// or private key, e.g. for workloads that only validate peers. As the
+	// bundles change, subsequent messages will be sent.
+	FetchX509Bundles(*X509BundlesRequest, SpiffeWorkloadAPI_FetchX509BundlesServer) error
+	// JWT-SVID Profile
+	// Fetch JWT-SVIDs for the requested audience, for all or one of the
+	// SPIFFE identities the workload is entitled to.
+	FetchJWTSVID(context.Context, *JWTSVIDRequest) (*JWTSVIDResponse, error)
+	// Fetch the JWT bundles used to validate JWT-SVIDs. As the bundles
+	// change, subsequent messages will be sent.
+	FetchJWTBundles(*JWTBundlesRequest, SpiffeWorkloadAPI_FetchJWTBundlesServer) error
+	// Validate a JWT-SVID against the JWT bundles, returning its SPIFFE
+	// ID and claims.
+	ValidateJWTSVID(context.Context, *ValidateJWTSVIDRequest) (*ValidateJWTSVIDResponse, error)
 }
 
 func RegisterSpiffeWorkloadAPIServer(s *grpc.Server, srv SpiffeWorkloadAPIServer) {
@@ -287,42 +800,156 @@ func (x *spiffeWorkloadAPIFetchX509SVIDServer) Send(m *X509SVIDResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _SpiffeWorkloadAPI_FetchX509Bundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(X509BundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpiffeWorkloadAPIServer).FetchX509Bundles(m, &spiffeWorkloadAPIFetchX509BundlesServer{stream})
+}
+
+type SpiffeWorkloadAPI_FetchX509BundlesServer interface {
+	Send(*X509BundlesResponse) error
+	grpc.ServerStream
+}
+
+type spiffeWorkloadAPIFetchX509BundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *spiffeWorkloadAPIFetchX509BundlesServer) Send(m *X509BundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SpiffeWorkloadAPI_FetchJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JWTSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpiffeWorkloadAPIServer).FetchJWTSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SpiffeWorkloadAPI/FetchJWTSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpiffeWorkloadAPIServer).FetchJWTSVID(ctx, req.(*JWTSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpiffeWorkloadAPI_FetchJWTBundles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JWTBundlesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpiffeWorkloadAPIServer).FetchJWTBundles(m, &spiffeWorkloadAPIFetchJWTBundlesServer{stream})
+}
+
+type SpiffeWorkloadAPI_FetchJWTBundlesServer interface {
+	Send(*JWTBundlesResponse) error
+	grpc.ServerStream
+}
+
+type spiffeWorkloadAPIFetchJWTBundlesServer struct {
+	grpc.ServerStream
+}
+
+func (x *spiffeWorkloadAPIFetchJWTBundlesServer) Send(m *JWTBundlesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SpiffeWorkloadAPI_ValidateJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateJWTSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpiffeWorkloadAPIServer).ValidateJWTSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SpiffeWorkloadAPI/ValidateJWTSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpiffeWorkloadAPIServer).ValidateJWTSVID(ctx, req.(*ValidateJWTSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SpiffeWorkloadAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "SpiffeWorkloadAPI",
 	HandlerType: (*SpiffeWorkloadAPIServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FetchJWTSVID",
+			Handler:    _SpiffeWorkloadAPI_FetchJWTSVID_Handler,
+		},
+		{
+			MethodName: "ValidateJWTSVID",
+			Handler:    _SpiffeWorkloadAPI_ValidateJWTSVID_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "FetchX509SVID",
 			Handler:       _SpiffeWorkloadAPI_FetchX509SVID_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "FetchX509Bundles",
+			Handler:       _SpiffeWorkloadAPI_FetchX509Bundles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FetchJWTBundles",
+			Handler:       _SpiffeWorkloadAPI_FetchJWTBundles_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "workload.proto",
 }
 
-func init() { proto.RegisterFile("workload.proto", fileDescriptor_workload_9e6c20ae36e034a5) }
-
-var fileDescriptor_workload_9e6c20ae36e034a5 = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x4d, 0x4f, 0xf2, 0x40,
-	0x10, 0xc7, 0xb3, 0xf4, 0x81, 0xc0, 0x00, 0x8f, 0xed, 0x46, 0xcd, 0x06, 0x0f, 0x36, 0xbd, 0xd8,
-	0x53, 0x43, 0x30, 0x18, 0xf1, 0xe6, 0x1b, 0x09, 0xe1, 0x62, 0x8a, 0x51, 0x6f, 0x0d, 0xb0, 0xd3,
-	0xd8, 0xd0, 0xd0, 0xda, 0x6d, 0xab, 0xbd, 0xf9, 0x51, 0xfd, 0x28, 0x66, 0xfb, 0x66, 0x52, 0xbd,
-	0xcd, 0xfc, 0xe7, 0x37, 0x3b, 0x33, 0xfb, 0x87, 0xff, 0xef, 0x41, 0xb4, 0xf3, 0x83, 0x35, 0xb7,
-	0xc2, 0x28, 0x88, 0x03, 0x43, 0x83, 0x83, 0x97, 0xe9, 0x78, 0xb6, 0x7a, 0x5a, 0xdc, 0xd9, 0xf8,
-	0x96, 0xa0, 0x88, 0x8d, 0x2f, 0x02, 0xea, 0x8f, 0x26, 0xc2, 0x60, 0x2f, 0x90, 0x9e, 0x42, 0x5b,
-	0xa4, 0x1e, 0x17, 0x8c, 0xe8, 0x8a, 0xd9, 0x9f, 0xf4, 0xac, 0x9a, 0x28, 0x74, 0xaa, 0x82, 0xb2,
-	0x8d, 0x7c, 0xd6, 0xd2, 0x15, 0x73, 0x60, 0xcb, 0x90, 0x3e, 0x82, 0xe6, 0x22, 0xc7, 0x68, 0x1d,
-	0x23, 0x77, 0x36, 0xc9, 0x9e, 0xfb, 0x28, 0x98, 0x92, 0xb7, 0x9f, 0x59, 0xcd, 0x01, 0xd6, 0xbc,
-	0x42, 0x6f, 0x0a, 0xf2, 0x7e, 0x1f, 0x47, 0x99, 0xad, 0xba, 0x0d, 0x79, 0x74, 0x0b, 0x47, 0x7f,
-	0xa2, 0x72, 0x81, 0x1d, 0x66, 0x8c, 0xe8, 0xc4, 0xec, 0xd9, 0x32, 0xa4, 0x87, 0xd0, 0x4e, 0xd7,
-	0x7e, 0x82, 0xac, 0xa5, 0x13, 0x73, 0x60, 0x17, 0xc9, 0x55, 0xeb, 0x92, 0x18, 0x9f, 0x04, 0xba,
-	0xd5, 0x06, 0xf4, 0x04, 0x7a, 0x22, 0xf4, 0x5c, 0x17, 0x1d, 0x8f, 0x97, 0xed, 0xdd, 0x42, 0x58,
-	0x70, 0x59, 0xfc, 0x98, 0x8e, 0x67, 0x8e, 0x3c, 0xb2, 0x7c, 0xa7, 0x2b, 0x85, 0x55, 0xea, 0x71,
-	0x6a, 0xc0, 0xb0, 0x2e, 0x3a, 0x72, 0xb8, 0x92, 0x03, 0xfd, 0x0a, 0x58, 0x62, 0x46, 0x8f, 0xa1,
-	0x53, 0xdc, 0xce, 0xfe, 0xe5, 0xc5, 0x32, 0x9b, 0x2c, 0x41, 0x5b, 0xe5, 0x43, 0x9e, 0x4b, 0x43,
-	0xae, 0x1f, 0x16, 0xf4, 0x02, 0x86, 0x73, 0x8c, 0xb7, 0xaf, 0xf5, 0x6e, 0xaa, 0xd5, 0x70, 0x67,
-	0xa4, 0xfd, 0xfa, 0xba, 0x31, 0xd9, 0x74, 0x72, 0x33, 0xcf, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff,
-	0x54, 0xd1, 0x4a, 0xc1, 0xde, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("workload.proto", fileDescriptor_workload_0c72276bb2b54269) }
+
+var fileDescriptor_workload_0c72276bb2b54269 = []byte{
+	// 579 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xdd, 0x6e, 0x12, 0x41,
+	0x14, 0xc7, 0x33, 0xd0, 0x52, 0x38, 0xd0, 0xb2, 0x0c, 0x58, 0x36, 0xab, 0x51, 0xdc, 0x1b, 0xb9,
+	0x1a, 0x28, 0xa6, 0xc6, 0xa2, 0x89, 0x51, 0x6b, 0x23, 0xf5, 0xc6, 0x2c, 0xa4, 0x78, 0x47, 0x16,
+	0x66, 0xc0, 0x4d, 0x57, 0x16, 0xf7, 0x03, 0xe5, 0xce, 0x07, 0xf0, 0x21, 0x7c, 0x17, 0x5f, 0xc4,
+	0x47, 0x31, 0x3b, 0x3b, 0xb3, 0xba, 0x1f, 0xd6, 0x44, 0xef, 0x66, 0xce, 0xfc, 0xcf, 0x99, 0x39,
+	0xbf, 0x73, 0xe6, 0xc0, 0xd1, 0x27, 0xc7, 0xbd, 0xb6, 0x1d, 0x93, 0x92, 0x8d, 0xeb, 0xf8, 0x8e,
+	0x76, 0x67, 0xe5, 0x38, 0x2b, 0x9b, 0xf5, 0xf8, 0x6e, 0x1e, 0x2c, 0x7b, 0x9e, 0xef, 0x06, 0x0b,
+	0x3f, 0x3a, 0xd5, 0x1b, 0x50, 0x7f, 0x77, 0xda, 0x3f, 0x1b, 0x5f, 0x8d, 0xce, 0x0d, 0xf6, 0x31,
+	0x60, 0x9e, 0xaf, 0xff, 0x40, 0xa0, 0xfc, 0xb2, 0x79, 0x1b, 0x67, 0xed, 0x31, 0x7c, 0x0f, 0xf6,
+	0xbd, 0xad, 0x45, 0x3d, 0x15, 0x75, 0x8a, 0xdd, 0xea, 0xa0, 0x42, 0x62, 0x45, 0x64, 0xc7, 0x0a,
+	0x14, 0x17, 0xae, 0xad, 0x16, 0x3a, 0xc5, 0x6e, 0xcd, 0x08, 0x97, 0x78, 0x02, 0x8d, 0x25, 0xa3,
+	0xcc, 0x35, 0x7d, 0x46, 0x67, 0xf3, 0x60, 0x4d, 0x6d, 0xe6, 0xa9, 0x45, 0xee, 0xfe, 0x80, 0xa4,
+	0x2f, 0x20, 0x17, 0x52, 0xfa, 0x22, 0x52, 0xbe, 0x5a, 0xfb, 0xee, 0xce, 0x50, 0x96, 0x29, 0xb3,
+	0xf6, 0x12, 0x6e, 0xe5, 0x4a, 0xc3, 0x07, 0x5c, 0xb3, 0x9d, 0x8a, 0x3a, 0xa8, 0x5b, 0x31, 0xc2,
+	0x25, 0x6e, 0xc1, 0xfe, 0xd6, 0xb4, 0x03, 0xa6, 0x16, 0x3a, 0xa8, 0x5b, 0x33, 0xa2, 0xcd, 0xb0,
+	0xf0, 0x18, 0xe9, 0x5f, 0x10, 0x94, 0xe5, 0x0b, 0xf0, 0x6d, 0xa8, 0x78, 0x1b, 0x6b, 0xb9, 0x64,
+	0x33, 0x8b, 0x0a, 0xf7, 0x72, 0x64, 0x18, 0xd1, 0xf0, 0xf0, 0xf3, 0x69, 0xff, 0x6c, 0x16, 0x26,
+	0x29, 0xe2, 0x94, 0x43, 0xc3, 0x78, 0x6b, 0x51, 0xac, 0xc3, 0x61, 0x7c, 0x38, 0x0b, 0x2f, 0x2f,
+	0x72, 0x41, 0x55, 0x0a, 0xde, 0xb0, 0x1d, 0x3e, 0x86, 0x52, 0x94, 0xbb, 0xba, 0xc7, 0x0f, 0xc5,
+	0x4e, 0x6f, 0x01, 0x0e, 0x5f, 0x20, 0x52, 0x90, 0xec, 0xbf, 0x21, 0x68, 0x26, 0xcc, 0x02, 0xff,
+	0x13, 0x38, 0x90, 0x04, 0xa3, 0x02, 0xdc, 0x27, 0x39, 0x32, 0x92, 0x60, 0x27, 0x3d, 0xb2, 0xa5,
+	0xd1, 0x86, 0x50, 0xfb, 0x67, 0x76, 0x43, 0x38, 0xb8, 0x9c, 0x4e, 0xfe, 0x4e, 0x0e, 0xc3, 0x5e,
+	0x0c, 0xad, 0x62, 0xf0, 0xb5, 0x3e, 0x82, 0x23, 0xe1, 0x2b, 0x12, 0xc6, 0x1a, 0x94, 0xcd, 0x80,
+	0x5a, 0x6c, 0xbd, 0x60, 0x3c, 0xb3, 0x8a, 0x11, 0xef, 0x93, 0xe1, 0x0b, 0xc9, 0xf0, 0xfa, 0x09,
+	0xd4, 0xe3, 0x50, 0x02, 0xd2, 0xdd, 0x64, 0x8f, 0x96, 0x89, 0x14, 0x44, 0x66, 0xbd, 0x09, 0x8d,
+	0xcb, 0xe9, 0x24, 0x45, 0xfc, 0x2b, 0x02, 0xfc, 0xbb, 0x55, 0xc4, 0x1a, 0xa6, 0x81, 0x77, 0x48,
+	0x56, 0x95, 0xcf, 0xfb, 0xbf, 0xe8, 0xbe, 0x86, 0xe3, 0x2b, 0xd3, 0xb6, 0xa8, 0xe9, 0xb3, 0x1b,
+	0x49, 0xa1, 0x04, 0xa9, 0x3c, 0xd6, 0x2b, 0x68, 0x67, 0x22, 0x89, 0xe4, 0x6e, 0xac, 0x5b, 0x0f,
+	0x4a, 0x0b, 0xdb, 0xb4, 0x3e, 0x78, 0x3c, 0x5a, 0x75, 0xd0, 0x26, 0xd1, 0x00, 0x21, 0x72, 0x80,
+	0x90, 0x31, 0x1f, 0x20, 0x86, 0x90, 0x0d, 0xbe, 0x17, 0xa0, 0x31, 0xe6, 0xde, 0x53, 0x31, 0x79,
+	0x9e, 0xbf, 0x1d, 0xe1, 0x47, 0x70, 0x78, 0xc1, 0xfc, 0xc5, 0xfb, 0xf8, 0x9b, 0x29, 0x24, 0x35,
+	0x68, 0xb4, 0x46, 0x66, 0x0a, 0xf4, 0x11, 0x7e, 0x06, 0x4a, 0xec, 0x27, 0x28, 0xe2, 0x26, 0xc9,
+	0x7e, 0x15, 0xad, 0x95, 0xf7, 0x03, 0xfa, 0x08, 0x9f, 0x40, 0x8d, 0x07, 0x90, 0x4d, 0x5a, 0x27,
+	0x49, 0x90, 0x9a, 0x42, 0xd2, 0x3c, 0x9e, 0x42, 0x5d, 0xba, 0xc8, 0x2b, 0x31, 0xc9, 0xb4, 0x8a,
+	0xd6, 0xcc, 0x69, 0x81, 0x3e, 0xc2, 0xe7, 0x50, 0x4f, 0x81, 0xc6, 0x6d, 0x92, 0x5f, 0x44, 0x4d,
+	0x25, 0x7f, 0xa8, 0xc9, 0xbc, 0xc4, 0xf1, 0x3e, 0xfc, 0x39, 0x00, 0x3e, 0xa3, 0x67, 0x57, 0xbf,
+	0x05, 0x00, 0x00,
 }
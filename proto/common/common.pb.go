@@ -29,7 +29,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_450217a1e26bcceb, []int{0}
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -64,7 +64,7 @@ func (m *AttestationData) Reset()         { *m = AttestationData{} }
 func (m *AttestationData) String() string { return proto.CompactTextString(m) }
 func (*AttestationData) ProtoMessage()    {}
 func (*AttestationData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_450217a1e26bcceb, []int{1}
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{1}
 }
 func (m *AttestationData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestationData.Unmarshal(m, b)
@@ -115,7 +115,7 @@ func (m *Selector) Reset()         { *m = Selector{} }
 func (m *Selector) String() string { return proto.CompactTextString(m) }
 func (*Selector) ProtoMessage()    {}
 func (*Selector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_450217a1e26bcceb, []int{2}
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{2}
 }
 func (m *Selector) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Selector.Unmarshal(m, b)
@@ -162,7 +162,7 @@ func (m *Selectors) Reset()         { *m = Selectors{} }
 func (m *Selectors) String() string { return proto.CompactTextString(m) }
 func (*Selectors) ProtoMessage()    {}
 func (*Selectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_450217a1e26bcceb, []int{3}
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{3}
 }
 func (m *Selectors) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Selectors.Unmarshal(m, b)
@@ -201,12 +201,24 @@ type RegistrationEntry struct {
 	// caller. It is defined as a URI comprising a “trust domain” and an
 	// associated path.
 	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
-	// * Time to live.
+	// * Time to live, in seconds, of the X509-SVIDs issued for this entry.
+	// Zero means the server default is used.
 	Ttl int32 `protobuf:"varint,4,opt,name=ttl" json:"ttl,omitempty"`
-	// * A list of federated bundle spiffe ids.
-	FbSpiffeIds []string `protobuf:"bytes,5,rep,name=fb_spiffe_ids,json=fbSpiffeIds" json:"fb_spiffe_ids,omitempty"`
+	// * A list of federated trust domain SPIFFE IDs. Bundles for these
+	// trust domains are delivered to workloads alongside their SVIDs.
+	FederatesWith []string `protobuf:"bytes,5,rep,name=federates_with,json=federatesWith" json:"federates_with,omitempty"`
 	// * Entry ID
-	EntryId              string   `protobuf:"bytes,6,opt,name=entry_id,json=entryId" json:"entry_id,omitempty"`
+	EntryId string `protobuf:"bytes,6,opt,name=entry_id,json=entryId" json:"entry_id,omitempty"`
+	// * Whether or not the workload is an admin workload. Admin workloads
+	// can use their SVID to authenticate with the Registration API.
+	Admin bool `protobuf:"varint,7,opt,name=admin" json:"admin,omitempty"`
+	// * Time to live, in seconds, of the JWT-SVIDs issued for this entry.
+	// Zero means the server default is used.
+	JwtSvidTtl int32 `protobuf:"varint,8,opt,name=jwt_svid_ttl,json=jwtSvidTtl" json:"jwt_svid_ttl,omitempty"`
+	// * Time, in seconds since the Unix epoch, the X509-SVIDs of this
+	// entry were last forced to rotate. Agents holding SVIDs issued before
+	// then rotate them, along with their keys, as soon as they sync.
+	RotatedAt            int64    `protobuf:"varint,9,opt,name=rotated_at,json=rotatedAt" json:"rotated_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -216,7 +228,7 @@ func (m *RegistrationEntry) Reset()         { *m = RegistrationEntry{} }
 func (m *RegistrationEntry) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntry) ProtoMessage()    {}
 func (*RegistrationEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_450217a1e26bcceb, []int{4}
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{4}
 }
 func (m *RegistrationEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegistrationEntry.Unmarshal(m, b)
@@ -264,9 +276,9 @@ func (m *RegistrationEntry) GetTtl() int32 {
 	return 0
 }
 
-func (m *RegistrationEntry) GetFbSpiffeIds() []string {
+func (m *RegistrationEntry) GetFederatesWith() []string {
 	if m != nil {
-		return m.FbSpiffeIds
+		return m.FederatesWith
 	}
 	return nil
 }
@@ -278,6 +290,27 @@ func (m *RegistrationEntry) GetEntryId() string {
 	return ""
 }
 
+func (m *RegistrationEntry) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
+	}
+	return false
+}
+
+func (m *RegistrationEntry) GetJwtSvidTtl() int32 {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return 0
+}
+
+func (m *RegistrationEntry) GetRotatedAt() int64 {
+	if m != nil {
+		return m.RotatedAt
+	}
+	return 0
+}
+
 // * A list of registration entries.
 type RegistrationEntries struct {
 	// * A list of RegistrationEntry.
@@ -291,7 +324,7 @@ func (m *RegistrationEntries) Reset()         { *m = RegistrationEntries{} }
 func (m *RegistrationEntries) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntries) ProtoMessage()    {}
 func (*RegistrationEntries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_450217a1e26bcceb, []int{5}
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{5}
 }
 func (m *RegistrationEntries) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegistrationEntries.Unmarshal(m, b)
@@ -318,6 +351,64 @@ func (m *RegistrationEntries) GetEntries() []*RegistrationEntry {
 	return nil
 }
 
+// * A public key, e.g. a JWT signing key of a trust domain.
+type PublicKey struct {
+	// * PKIX encoded public key.
+	PkixBytes []byte `protobuf:"bytes,1,opt,name=pkix_bytes,json=pkixBytes,proto3" json:"pkix_bytes,omitempty"`
+	// * Key ID, set in the header of the JWT-SVIDs signed with the key.
+	Kid string `protobuf:"bytes,2,opt,name=kid" json:"kid,omitempty"`
+	// * Time, in seconds since the Unix epoch, the key expires at.
+	NotAfter             int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublicKey) Reset()         { *m = PublicKey{} }
+func (m *PublicKey) String() string { return proto.CompactTextString(m) }
+func (*PublicKey) ProtoMessage()    {}
+func (*PublicKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_common_b0e7bdddb52a9928, []int{6}
+}
+func (m *PublicKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublicKey.Unmarshal(m, b)
+}
+func (m *PublicKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublicKey.Marshal(b, m, deterministic)
+}
+func (dst *PublicKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublicKey.Merge(dst, src)
+}
+func (m *PublicKey) XXX_Size() int {
+	return xxx_messageInfo_PublicKey.Size(m)
+}
+func (m *PublicKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublicKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublicKey proto.InternalMessageInfo
+
+func (m *PublicKey) GetPkixBytes() []byte {
+	if m != nil {
+		return m.PkixBytes
+	}
+	return nil
+}
+
+func (m *PublicKey) GetKid() string {
+	if m != nil {
+		return m.Kid
+	}
+	return ""
+}
+
+func (m *PublicKey) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "spire.common.Empty")
 	proto.RegisterType((*AttestationData)(nil), "spire.common.AttestationData")
@@ -325,30 +416,38 @@ func init() {
 	proto.RegisterType((*Selectors)(nil), "spire.common.Selectors")
 	proto.RegisterType((*RegistrationEntry)(nil), "spire.common.RegistrationEntry")
 	proto.RegisterType((*RegistrationEntries)(nil), "spire.common.RegistrationEntries")
-}
-
-func init() { proto.RegisterFile("common.proto", fileDescriptor_common_450217a1e26bcceb) }
-
-var fileDescriptor_common_450217a1e26bcceb = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0x4f, 0x4b, 0xfb, 0x40,
-	0x10, 0x25, 0xbf, 0x34, 0x6d, 0x32, 0xed, 0x0f, 0x75, 0x15, 0x89, 0x78, 0x30, 0xec, 0x29, 0xa7,
-	0x20, 0xda, 0x4b, 0x0f, 0x1e, 0x14, 0x7b, 0xe8, 0x4d, 0xb6, 0x37, 0x2f, 0x65, 0xdb, 0x4c, 0x64,
-	0xa1, 0xf9, 0xc3, 0xee, 0x28, 0xe4, 0x7b, 0xfa, 0x81, 0x24, 0xbb, 0x4d, 0xd5, 0x2a, 0x78, 0x9b,
-	0x7d, 0xf3, 0xde, 0x63, 0xde, 0x63, 0x61, 0xb2, 0xa9, 0xcb, 0xb2, 0xae, 0xb2, 0x46, 0xd7, 0x54,
-	0xb3, 0x89, 0x69, 0x94, 0xc6, 0xcc, 0x61, 0x7c, 0x04, 0xc1, 0xbc, 0x6c, 0xa8, 0xe5, 0x33, 0x38,
-	0xba, 0x27, 0x42, 0x43, 0x92, 0x54, 0x5d, 0x3d, 0x4a, 0x92, 0x8c, 0xc1, 0x80, 0xda, 0x06, 0x63,
-	0x2f, 0xf1, 0xd2, 0x48, 0xd8, 0xb9, 0xc3, 0x72, 0x49, 0x32, 0xfe, 0x97, 0x78, 0xe9, 0x44, 0xd8,
-	0x99, 0x4f, 0x21, 0x5c, 0xe2, 0x16, 0x37, 0x54, 0xeb, 0x5f, 0x35, 0x67, 0x10, 0xbc, 0xc9, 0xed,
-	0x2b, 0x5a, 0x51, 0x24, 0xdc, 0x83, 0xdf, 0x41, 0xd4, 0xab, 0x0c, 0xbb, 0x86, 0x11, 0x56, 0xa4,
-	0x15, 0x9a, 0xd8, 0x4b, 0xfc, 0x74, 0x7c, 0x73, 0x9e, 0x7d, 0x3d, 0x33, 0xeb, 0x99, 0xa2, 0xa7,
-	0xf1, 0x77, 0x0f, 0x4e, 0x04, 0xbe, 0x28, 0x43, 0xda, 0x5e, 0x3c, 0xaf, 0x48, 0xb7, 0x6c, 0x0a,
-	0x91, 0xe9, 0x4d, 0xff, 0x70, 0xfa, 0x24, 0xb2, 0x4b, 0x88, 0x1a, 0xa9, 0xb1, 0xa2, 0x95, 0xca,
-	0x77, 0x47, 0x86, 0x0e, 0x58, 0xe4, 0xdd, 0xd2, 0x34, 0xaa, 0x28, 0xb0, 0x5b, 0xfa, 0x6e, 0xe9,
-	0x80, 0x45, 0xce, 0x8e, 0xc1, 0x27, 0xda, 0xc6, 0x83, 0xc4, 0x4b, 0x03, 0xd1, 0x8d, 0x8c, 0xc3,
-	0xff, 0x62, 0xbd, 0xda, 0x2b, 0x4c, 0x1c, 0x24, 0x7e, 0x1a, 0x89, 0x71, 0xb1, 0x5e, 0xee, 0x44,
-	0x86, 0x5d, 0x40, 0xd8, 0xc5, 0x68, 0x3b, 0xc7, 0xa1, 0x75, 0xb4, 0xb1, 0xda, 0x45, 0xce, 0x9f,
-	0xe0, 0xf4, 0x30, 0x95, 0x42, 0xc3, 0x66, 0x87, 0xfd, 0x5c, 0x7d, 0x4f, 0xf5, 0xa3, 0x89, 0x7d,
-	0x51, 0x0f, 0xe1, 0xf3, 0xd0, 0x91, 0xd6, 0x43, 0xfb, 0x01, 0x6e, 0x3f, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0xb9, 0xa4, 0x13, 0xc7, 0x10, 0x02, 0x00, 0x00,
+	proto.RegisterType((*PublicKey)(nil), "spire.common.PublicKey")
+}
+
+func init() { proto.RegisterFile("common.proto", fileDescriptor_common_b0e7bdddb52a9928) }
+
+var fileDescriptor_common_b0e7bdddb52a9928 = []byte{
+	// 425 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0xe5, 0xba, 0x49, 0xbc, 0x43, 0xf8, 0xb7, 0x20, 0x64, 0x84, 0x10, 0x96, 0x25, 0x24,
+	0x9f, 0x22, 0x04, 0xbd, 0xf4, 0xc0, 0x21, 0x15, 0x3d, 0x44, 0x5c, 0xaa, 0x2d, 0x12, 0x82, 0x8b,
+	0xb5, 0xc9, 0x4e, 0xe8, 0xb6, 0xce, 0xae, 0xb5, 0x3b, 0x4d, 0xf0, 0x4b, 0xf1, 0x8c, 0x68, 0xd7,
+	0x75, 0x0b, 0x01, 0xa9, 0xb7, 0x99, 0x6f, 0x66, 0xbe, 0x19, 0xff, 0xbc, 0x30, 0x5d, 0xd9, 0xcd,
+	0xc6, 0x9a, 0x59, 0xeb, 0x2c, 0x59, 0x3e, 0xf5, 0xad, 0x76, 0x38, 0xeb, 0xb5, 0x72, 0x02, 0xa3,
+	0xd3, 0x4d, 0x4b, 0x5d, 0x79, 0x0c, 0x8f, 0xe7, 0x44, 0xe8, 0x49, 0x92, 0xb6, 0xe6, 0x93, 0x24,
+	0xc9, 0x39, 0x1c, 0x52, 0xd7, 0x62, 0x9e, 0x14, 0x49, 0xc5, 0x44, 0x8c, 0x83, 0xa6, 0x24, 0xc9,
+	0xfc, 0xa0, 0x48, 0xaa, 0xa9, 0x88, 0x71, 0x79, 0x04, 0xd9, 0x39, 0x36, 0xb8, 0x22, 0xeb, 0xfe,
+	0x3b, 0xf3, 0x1c, 0x46, 0x5b, 0xd9, 0x5c, 0x63, 0x1c, 0x62, 0xa2, 0x4f, 0xca, 0x8f, 0xc0, 0x86,
+	0x29, 0xcf, 0xdf, 0xc1, 0x04, 0x0d, 0x39, 0x8d, 0x3e, 0x4f, 0x8a, 0xb4, 0x7a, 0xf0, 0xfe, 0xc5,
+	0xec, 0xcf, 0x33, 0x67, 0x43, 0xa7, 0x18, 0xda, 0xca, 0x5f, 0x07, 0xf0, 0x54, 0xe0, 0x0f, 0xed,
+	0xc9, 0xc5, 0x8b, 0x4f, 0x0d, 0xb9, 0x8e, 0x1f, 0x01, 0xf3, 0x83, 0xe9, 0x3d, 0x4e, 0x77, 0x8d,
+	0xfc, 0x15, 0xb0, 0x56, 0x3a, 0x34, 0x54, 0x6b, 0x75, 0x73, 0x64, 0xd6, 0x0b, 0x0b, 0x15, 0x8a,
+	0xbe, 0xd5, 0xeb, 0x35, 0x86, 0x62, 0xda, 0x17, 0x7b, 0x61, 0xa1, 0xf8, 0x13, 0x48, 0x89, 0x9a,
+	0xfc, 0xb0, 0x48, 0xaa, 0x91, 0x08, 0x21, 0x7f, 0x0b, 0x8f, 0xd6, 0xa8, 0xd0, 0x49, 0x42, 0x5f,
+	0xef, 0x34, 0x5d, 0xe4, 0xa3, 0x22, 0xad, 0x98, 0x78, 0x78, 0xab, 0x7e, 0xd5, 0x74, 0xc1, 0x5f,
+	0x42, 0x16, 0xbe, 0xa4, 0x0b, 0xa6, 0xe3, 0x68, 0x1a, 0xbf, 0xac, 0x5b, 0xa8, 0x80, 0x4b, 0xaa,
+	0x8d, 0x36, 0xf9, 0xa4, 0x48, 0xaa, 0x4c, 0xf4, 0x09, 0x2f, 0x60, 0x7a, 0xb9, 0xa3, 0xda, 0x6f,
+	0xb5, 0xaa, 0xc3, 0xca, 0x2c, 0xae, 0x84, 0xcb, 0x1d, 0x9d, 0x6f, 0xb5, 0xfa, 0x42, 0x0d, 0x7f,
+	0x0d, 0xe0, 0x2c, 0x49, 0x42, 0x55, 0x4b, 0xca, 0x59, 0x91, 0x54, 0xa9, 0x60, 0x37, 0xca, 0x9c,
+	0xca, 0x33, 0x78, 0xb6, 0xcf, 0x4b, 0xa3, 0xe7, 0xc7, 0xfb, 0xe4, 0xdf, 0xfc, 0xcd, 0xeb, 0x1f,
+	0xc6, 0x77, 0xbf, 0xe0, 0x1b, 0xb0, 0xb3, 0xeb, 0x65, 0xa3, 0x57, 0x9f, 0xb1, 0x0b, 0xdb, 0xdb,
+	0x2b, 0xfd, 0xb3, 0x5e, 0x76, 0x14, 0xad, 0xc2, 0xf3, 0x60, 0x41, 0x39, 0x09, 0x42, 0x00, 0x75,
+	0x75, 0x0b, 0x37, 0x84, 0x81, 0xab, 0xb1, 0x54, 0xcb, 0x35, 0xa1, 0x8b, 0x5c, 0x53, 0x91, 0x19,
+	0x4b, 0xf3, 0x90, 0x9f, 0x64, 0xdf, 0xc7, 0xfd, 0xfe, 0xe5, 0x38, 0xbe, 0xda, 0x0f, 0xbf, 0x07,
+	0x00, 0xe4, 0xe9, 0xa1, 0xbd, 0xc5, 0x02, 0x00, 0x00,
 }
@@ -0,0 +1,114 @@
+package metricsink
+
+import (
+	"context"
+	"net/rpc"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	go_plugin "github.com/hashicorp/go-plugin"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"google.golang.org/grpc"
+)
+
+// MetricSink is the interface used by all non-catalog components.
+type MetricSink interface {
+	EmitMetrics(context.Context, *EmitMetricsRequest) (*EmitMetricsResponse, error)
+}
+
+// Plugin is the interface implemented by plugin implementations
+type Plugin interface {
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+	EmitMetrics(context.Context, *EmitMetricsRequest) (*EmitMetricsResponse, error)
+}
+
+type BuiltIn struct {
+	plugin Plugin
+}
+
+var _ MetricSink = (*BuiltIn)(nil)
+
+func NewBuiltIn(plugin Plugin) *BuiltIn {
+	return &BuiltIn{
+		plugin: plugin,
+	}
+}
+
+func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	resp, err := b.plugin.Configure(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	resp, err := b.plugin.GetPluginInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) EmitMetrics(ctx context.Context, req *EmitMetricsRequest) (*EmitMetricsResponse, error) {
+	resp, err := b.plugin.EmitMetrics(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+var Handshake = go_plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "MetricSink",
+	MagicCookieValue: "MetricSink",
+}
+
+type GRPCPlugin struct {
+	ServerImpl MetricSinkServer
+}
+
+func (p GRPCPlugin) Server(*go_plugin.MuxBroker) (interface{}, error) {
+	return empty.Empty{}, nil
+}
+
+func (p GRPCPlugin) Client(b *go_plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return empty.Empty{}, nil
+}
+
+func (p GRPCPlugin) GRPCServer(s *grpc.Server) error {
+	RegisterMetricSinkServer(s, p.ServerImpl)
+	return nil
+}
+
+func (p GRPCPlugin) GRPCClient(c *grpc.ClientConn) (interface{}, error) {
+	return &GRPCClient{client: NewMetricSinkClient(c)}, nil
+}
+
+type GRPCServer struct {
+	Plugin Plugin
+}
+
+func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return s.Plugin.Configure(ctx, req)
+}
+func (s *GRPCServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return s.Plugin.GetPluginInfo(ctx, req)
+}
+func (s *GRPCServer) EmitMetrics(ctx context.Context, req *EmitMetricsRequest) (*EmitMetricsResponse, error) {
+	return s.Plugin.EmitMetrics(ctx, req)
+}
+
+type GRPCClient struct {
+	client MetricSinkClient
+}
+
+func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return c.client.Configure(ctx, req)
+}
+func (c *GRPCClient) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return c.client.GetPluginInfo(ctx, req)
+}
+func (c *GRPCClient) EmitMetrics(ctx context.Context, req *EmitMetricsRequest) (*EmitMetricsResponse, error) {
+	return c.client.EmitMetrics(ctx, req)
+}
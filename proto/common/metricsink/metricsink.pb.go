@@ -0,0 +1,441 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: metricsink.proto
+
+package metricsink
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import plugin "github.com/spiffe/spire/proto/common/plugin"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ConfigureRequest from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type ConfigureRequest = plugin.ConfigureRequest
+
+// ConfigureResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type ConfigureResponse = plugin.ConfigureResponse
+
+// GetPluginInfoRequest from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type GetPluginInfoRequest = plugin.GetPluginInfoRequest
+
+// GetPluginInfoResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type GetPluginInfoResponse = plugin.GetPluginInfoResponse
+
+type Metric_Type int32
+
+const (
+	// * The last value a gauge is set to is retained
+	Metric_GAUGE Metric_Type = 0
+	// * A key/value pair emitted once
+	Metric_KEY Metric_Type = 1
+	// * The values of a counter are accumulated
+	Metric_COUNTER Metric_Type = 2
+	// * A sample, e.g. the time taken by a call in milliseconds
+	Metric_SAMPLE Metric_Type = 3
+)
+
+var Metric_Type_name = map[int32]string{
+	0: "GAUGE",
+	1: "KEY",
+	2: "COUNTER",
+	3: "SAMPLE",
+}
+var Metric_Type_value = map[string]int32{
+	"GAUGE":   0,
+	"KEY":     1,
+	"COUNTER": 2,
+	"SAMPLE":  3,
+}
+
+func (x Metric_Type) String() string {
+	return proto.EnumName(Metric_Type_name, int32(x))
+}
+func (Metric_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_metricsink_fed0b38a4a658641, []int{1, 0}
+}
+
+// * Label of a metric
+type Label struct {
+	// * Name of the label
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// * Value of the label
+	Value                string   `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Label) Reset()         { *m = Label{} }
+func (m *Label) String() string { return proto.CompactTextString(m) }
+func (*Label) ProtoMessage()    {}
+func (*Label) Descriptor() ([]byte, []int) {
+	return fileDescriptor_metricsink_fed0b38a4a658641, []int{0}
+}
+func (m *Label) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Label.Unmarshal(m, b)
+}
+func (m *Label) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Label.Marshal(b, m, deterministic)
+}
+func (dst *Label) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Label.Merge(dst, src)
+}
+func (m *Label) XXX_Size() int {
+	return xxx_messageInfo_Label.Size(m)
+}
+func (m *Label) XXX_DiscardUnknown() {
+	xxx_messageInfo_Label.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Label proto.InternalMessageInfo
+
+func (m *Label) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Label) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// * A metric emitted by the agent or the server
+type Metric struct {
+	// * Type of the metric
+	Type Metric_Type `protobuf:"varint,1,opt,name=type,enum=spire.common.metricsink.Metric_Type" json:"type,omitempty"`
+	// * Key of the metric, starting with the service name, e.g.
+	// ["spire_server", "rpc", "latency"]
+	Key []string `protobuf:"bytes,2,rep,name=key" json:"key,omitempty"`
+	// * Value of the metric
+	Value float32 `protobuf:"fixed32,3,opt,name=value" json:"value,omitempty"`
+	// * Labels of the metric
+	Labels               []*Label `protobuf:"bytes,4,rep,name=labels" json:"labels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Metric) Reset()         { *m = Metric{} }
+func (m *Metric) String() string { return proto.CompactTextString(m) }
+func (*Metric) ProtoMessage()    {}
+func (*Metric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_metricsink_fed0b38a4a658641, []int{1}
+}
+func (m *Metric) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Metric.Unmarshal(m, b)
+}
+func (m *Metric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Metric.Marshal(b, m, deterministic)
+}
+func (dst *Metric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Metric.Merge(dst, src)
+}
+func (m *Metric) XXX_Size() int {
+	return xxx_messageInfo_Metric.Size(m)
+}
+func (m *Metric) XXX_DiscardUnknown() {
+	xxx_messageInfo_Metric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Metric proto.InternalMessageInfo
+
+func (m *Metric) GetType() Metric_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Metric_GAUGE
+}
+
+func (m *Metric) GetKey() []string {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *Metric) GetValue() float32 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *Metric) GetLabels() []*Label {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type EmitMetricsRequest struct {
+	// * Metrics emitted since the previous request, oldest first
+	Metrics              []*Metric `protobuf:"bytes,1,rep,name=metrics" json:"metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *EmitMetricsRequest) Reset()         { *m = EmitMetricsRequest{} }
+func (m *EmitMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*EmitMetricsRequest) ProtoMessage()    {}
+func (*EmitMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_metricsink_fed0b38a4a658641, []int{2}
+}
+func (m *EmitMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmitMetricsRequest.Unmarshal(m, b)
+}
+func (m *EmitMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmitMetricsRequest.Marshal(b, m, deterministic)
+}
+func (dst *EmitMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmitMetricsRequest.Merge(dst, src)
+}
+func (m *EmitMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_EmitMetricsRequest.Size(m)
+}
+func (m *EmitMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmitMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmitMetricsRequest proto.InternalMessageInfo
+
+func (m *EmitMetricsRequest) GetMetrics() []*Metric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+type EmitMetricsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmitMetricsResponse) Reset()         { *m = EmitMetricsResponse{} }
+func (m *EmitMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*EmitMetricsResponse) ProtoMessage()    {}
+func (*EmitMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_metricsink_fed0b38a4a658641, []int{3}
+}
+func (m *EmitMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmitMetricsResponse.Unmarshal(m, b)
+}
+func (m *EmitMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmitMetricsResponse.Marshal(b, m, deterministic)
+}
+func (dst *EmitMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmitMetricsResponse.Merge(dst, src)
+}
+func (m *EmitMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_EmitMetricsResponse.Size(m)
+}
+func (m *EmitMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmitMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmitMetricsResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Label)(nil), "spire.common.metricsink.Label")
+	proto.RegisterType((*Metric)(nil), "spire.common.metricsink.Metric")
+	proto.RegisterType((*EmitMetricsRequest)(nil), "spire.common.metricsink.EmitMetricsRequest")
+	proto.RegisterType((*EmitMetricsResponse)(nil), "spire.common.metricsink.EmitMetricsResponse")
+	proto.RegisterEnum("spire.common.metricsink.Metric_Type", Metric_Type_name, Metric_Type_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for MetricSink service
+
+type MetricSinkClient interface {
+	// * Responsible for configuration of the plugin.
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	// * Returns the  version and related metadata of the installed plugin.
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+	// * Receives the metrics emitted since the previous call, in batches
+	// sent every second.
+	EmitMetrics(ctx context.Context, in *EmitMetricsRequest, opts ...grpc.CallOption) (*EmitMetricsResponse, error)
+}
+
+type metricSinkClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMetricSinkClient(cc *grpc.ClientConn) MetricSinkClient {
+	return &metricSinkClient{cc}
+}
+
+func (c *metricSinkClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := grpc.Invoke(ctx, "/spire.common.metricsink.MetricSink/Configure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricSinkClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := grpc.Invoke(ctx, "/spire.common.metricsink.MetricSink/GetPluginInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metricSinkClient) EmitMetrics(ctx context.Context, in *EmitMetricsRequest, opts ...grpc.CallOption) (*EmitMetricsResponse, error) {
+	out := new(EmitMetricsResponse)
+	err := grpc.Invoke(ctx, "/spire.common.metricsink.MetricSink/EmitMetrics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MetricSink service
+
+type MetricSinkServer interface {
+	// * Responsible for configuration of the plugin.
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	// * Returns the  version and related metadata of the installed plugin.
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+	// * Receives the metrics emitted since the previous call, in batches
+	// sent every second.
+	EmitMetrics(context.Context, *EmitMetricsRequest) (*EmitMetricsResponse, error)
+}
+
+func RegisterMetricSinkServer(s *grpc.Server, srv MetricSinkServer) {
+	s.RegisterService(&_MetricSink_serviceDesc, srv)
+}
+
+func _MetricSink_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricSinkServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.common.metricsink.MetricSink/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricSinkServer).Configure(ctx, req.(*plugin.ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricSink_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.GetPluginInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricSinkServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.common.metricsink.MetricSink/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricSinkServer).GetPluginInfo(ctx, req.(*plugin.GetPluginInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetricSink_EmitMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmitMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricSinkServer).EmitMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.common.metricsink.MetricSink/EmitMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricSinkServer).EmitMetrics(ctx, req.(*EmitMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MetricSink_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.common.metricsink.MetricSink",
+	HandlerType: (*MetricSinkServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Configure",
+			Handler:    _MetricSink_Configure_Handler,
+		},
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _MetricSink_GetPluginInfo_Handler,
+		},
+		{
+			MethodName: "EmitMetrics",
+			Handler:    _MetricSink_EmitMetrics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "metricsink.proto",
+}
+
+func init() { proto.RegisterFile("metricsink.proto", fileDescriptor_metricsink_fed0b38a4a658641) }
+
+var fileDescriptor_metricsink_fed0b38a4a658641 = []byte{
+	// 397 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xdb, 0xee, 0xd2, 0x40,
+	0x10, 0xc6, 0xff, 0x3d, 0x50, 0xd2, 0x41, 0x4d, 0x33, 0x6a, 0x6c, 0xb8, 0xd0, 0xa6, 0x51, 0x53,
+	0x0f, 0x69, 0x23, 0x24, 0x06, 0x2f, 0x91, 0x34, 0xc4, 0x08, 0x42, 0x0a, 0x5c, 0xc8, 0x1d, 0x90,
+	0x2d, 0x6c, 0xe8, 0xc9, 0x6e, 0x6b, 0xc2, 0xab, 0xf9, 0x22, 0xbe, 0x8e, 0xe9, 0x6e, 0x11, 0x89,
+	0x7f, 0x02, 0x57, 0xdd, 0xee, 0x7c, 0xdf, 0xfc, 0xbe, 0x99, 0x16, 0x8c, 0x98, 0x14, 0x39, 0xdd,
+	0x30, 0x9a, 0xec, 0xdd, 0x2c, 0x4f, 0x8b, 0x14, 0x9f, 0xb1, 0x8c, 0xe6, 0xc4, 0xdd, 0xa4, 0x71,
+	0x9c, 0x26, 0xee, 0xa9, 0xdc, 0xee, 0x6d, 0x69, 0xb1, 0x2b, 0xd7, 0x55, 0xc5, 0x63, 0x19, 0x0d,
+	0x43, 0xe2, 0x71, 0xa9, 0xc7, 0x7d, 0x9e, 0x30, 0x78, 0x59, 0x54, 0x6e, 0xe9, 0xf1, 0x21, 0x5a,
+	0xda, 0x1f, 0xa0, 0x31, 0x5a, 0xad, 0x49, 0x84, 0x08, 0x6a, 0xb2, 0x8a, 0x89, 0x29, 0x59, 0x92,
+	0xa3, 0x07, 0xfc, 0x8c, 0x4f, 0xa0, 0xf1, 0x73, 0x15, 0x95, 0xc4, 0x94, 0xf9, 0xa5, 0x78, 0xb1,
+	0x7f, 0x4b, 0xa0, 0x8d, 0x39, 0x1b, 0x7b, 0xa0, 0x16, 0x87, 0x4c, 0x98, 0x1e, 0x75, 0x5e, 0xba,
+	0x17, 0xf2, 0xb9, 0x42, 0xee, 0xce, 0x0f, 0x19, 0x09, 0xb8, 0x03, 0x0d, 0x50, 0xf6, 0xe4, 0x60,
+	0xca, 0x96, 0xe2, 0xe8, 0x41, 0x75, 0x3c, 0xc1, 0x14, 0x4b, 0x72, 0xe4, 0x1a, 0x86, 0x1f, 0x41,
+	0x8b, 0xaa, 0x7c, 0xcc, 0x54, 0x2d, 0xc5, 0x69, 0x75, 0x9e, 0x5f, 0x64, 0xf0, 0x31, 0x82, 0x5a,
+	0x6d, 0x77, 0x41, 0xad, 0x68, 0xa8, 0x43, 0x63, 0xd8, 0x5f, 0x0c, 0x7d, 0xe3, 0x0e, 0x9b, 0xa0,
+	0x7c, 0xf5, 0xbf, 0x1b, 0x12, 0xb6, 0xa0, 0x39, 0x98, 0x2c, 0xbe, 0xcd, 0xfd, 0xc0, 0x90, 0x11,
+	0x40, 0x9b, 0xf5, 0xc7, 0xd3, 0x91, 0x6f, 0x28, 0xf6, 0x04, 0xd0, 0x8f, 0x69, 0x21, 0xd2, 0xb2,
+	0x80, 0xfc, 0x28, 0x09, 0x2b, 0xf0, 0x13, 0x34, 0x6b, 0x8c, 0x29, 0xf1, 0x0c, 0x2f, 0xae, 0xcc,
+	0x19, 0x1c, 0xf5, 0xf6, 0x53, 0x78, 0x7c, 0xd6, 0x90, 0x65, 0x69, 0xc2, 0x48, 0xe7, 0x97, 0x0c,
+	0x20, 0xee, 0x66, 0x34, 0xd9, 0xe3, 0x12, 0xf4, 0x41, 0x9a, 0x84, 0x74, 0x5b, 0xe6, 0x04, 0x5f,
+	0x9d, 0x37, 0xaf, 0x3f, 0xd6, 0xdf, 0x7a, 0x1d, 0xaa, 0xfd, 0xfa, 0x9a, 0x4c, 0xa0, 0x30, 0x84,
+	0x87, 0x43, 0x52, 0x4c, 0x79, 0xf9, 0x4b, 0x12, 0xa6, 0xf8, 0xe6, 0x5e, 0xe3, 0x99, 0xe6, 0xc8,
+	0x78, 0x7b, 0x8b, 0xb4, 0xe6, 0xec, 0xa0, 0xf5, 0xcf, 0xa4, 0xf8, 0xee, 0xe2, 0x8a, 0xfe, 0x5f,
+	0x70, 0xfb, 0xfd, 0x6d, 0x62, 0x41, 0xfa, 0xfc, 0x60, 0x09, 0x27, 0xc5, 0xf4, 0x6e, 0xad, 0xf1,
+	0x1f, 0xb9, 0xfb, 0x67, 0x00, 0x04, 0x6f, 0xdc, 0xfd, 0x2f, 0x03, 0x00, 0x00,
+}
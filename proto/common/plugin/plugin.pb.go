@@ -21,7 +21,10 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 // * Represents the plugin-specific configuration string.
 type ConfigureRequest struct {
 	// * The configuration for the plugin.
-	Configuration        string   `protobuf:"bytes,1,opt,name=configuration" json:"configuration,omitempty"`
+	Configuration string `protobuf:"bytes,1,opt,name=configuration" json:"configuration,omitempty"`
+	// * If true, the plugin only validates the configuration, without acting
+	// on it, e.g. by connecting to a database or creating files.
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -31,7 +34,7 @@ func (m *ConfigureRequest) Reset()         { *m = ConfigureRequest{} }
 func (m *ConfigureRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureRequest) ProtoMessage()    {}
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_e90ff65dec367489, []int{0}
+	return fileDescriptor_plugin_b3417f3887c427bb, []int{0}
 }
 func (m *ConfigureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureRequest.Unmarshal(m, b)
@@ -58,6 +61,13 @@ func (m *ConfigureRequest) GetConfiguration() string {
 	return ""
 }
 
+func (m *ConfigureRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 // * Represents a list of configuration problems
 // found in the configuration string.
 type ConfigureResponse struct {
@@ -72,7 +82,7 @@ func (m *ConfigureResponse) Reset()         { *m = ConfigureResponse{} }
 func (m *ConfigureResponse) String() string { return proto.CompactTextString(m) }
 func (*ConfigureResponse) ProtoMessage()    {}
 func (*ConfigureResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_e90ff65dec367489, []int{1}
+	return fileDescriptor_plugin_b3417f3887c427bb, []int{1}
 }
 func (m *ConfigureResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureResponse.Unmarshal(m, b)
@@ -110,7 +120,7 @@ func (m *GetPluginInfoRequest) Reset()         { *m = GetPluginInfoRequest{} }
 func (m *GetPluginInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*GetPluginInfoRequest) ProtoMessage()    {}
 func (*GetPluginInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_e90ff65dec367489, []int{2}
+	return fileDescriptor_plugin_b3417f3887c427bb, []int{2}
 }
 func (m *GetPluginInfoRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPluginInfoRequest.Unmarshal(m, b)
@@ -150,7 +160,7 @@ func (m *GetPluginInfoResponse) Reset()         { *m = GetPluginInfoResponse{} }
 func (m *GetPluginInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPluginInfoResponse) ProtoMessage()    {}
 func (*GetPluginInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_e90ff65dec367489, []int{3}
+	return fileDescriptor_plugin_b3417f3887c427bb, []int{3}
 }
 func (m *GetPluginInfoResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetPluginInfoResponse.Unmarshal(m, b)
@@ -240,26 +250,27 @@ func init() {
 	proto.RegisterType((*GetPluginInfoResponse)(nil), "spire.common.plugin.GetPluginInfoResponse")
 }
 
-func init() { proto.RegisterFile("plugin.proto", fileDescriptor_plugin_e90ff65dec367489) }
+func init() { proto.RegisterFile("plugin.proto", fileDescriptor_plugin_b3417f3887c427bb) }
 
-var fileDescriptor_plugin_e90ff65dec367489 = []byte{
-	// 280 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x51, 0xbd, 0x4e, 0xc3, 0x30,
-	0x10, 0x56, 0x7f, 0x48, 0x9b, 0x03, 0x24, 0x30, 0x50, 0x59, 0x88, 0x21, 0x8a, 0x18, 0x3a, 0x45,
-	0x42, 0x2c, 0xcc, 0x74, 0x40, 0x48, 0x0c, 0x28, 0x23, 0x9b, 0x71, 0xaf, 0xc1, 0x52, 0xe3, 0x33,
-	0xb6, 0x83, 0x94, 0x27, 0xe0, 0xb5, 0x51, 0x6c, 0x97, 0x16, 0xb6, 0xfb, 0xfe, 0xfc, 0xd9, 0x3e,
-	0x38, 0x31, 0xdb, 0xae, 0x51, 0xba, 0x32, 0x96, 0x3c, 0xb1, 0x0b, 0x67, 0x94, 0xc5, 0x4a, 0x52,
-	0xdb, 0x92, 0xae, 0xa2, 0x54, 0x3e, 0xc0, 0xd9, 0x8a, 0xf4, 0x46, 0x35, 0x9d, 0xc5, 0x1a, 0x3f,
-	0x3b, 0x74, 0x9e, 0xdd, 0xc2, 0xa9, 0x4c, 0x9c, 0xf0, 0x8a, 0x34, 0x1f, 0x15, 0xa3, 0x65, 0x5e,
-	0xff, 0x25, 0xcb, 0x3b, 0x38, 0x3f, 0x48, 0x3a, 0x43, 0xda, 0x21, 0xbb, 0x81, 0x1c, 0xad, 0x25,
-	0xfb, 0xa2, 0x9c, 0xe7, 0xa3, 0x62, 0xb2, 0xcc, 0xeb, 0x3d, 0x51, 0x2e, 0xe0, 0xf2, 0x09, 0xfd,
-	0x6b, 0x68, 0x7e, 0xd6, 0x1b, 0x4a, 0x85, 0xe5, 0xf7, 0x18, 0xae, 0xfe, 0x09, 0xe9, 0x3c, 0x06,
-	0x53, 0x2d, 0x5a, 0x4c, 0x37, 0x08, 0x33, 0xbb, 0x86, 0xb9, 0x14, 0x1e, 0x1b, 0xb2, 0x3d, 0x1f,
-	0x07, 0xfe, 0x17, 0x0f, 0x7e, 0xdf, 0x1b, 0xe4, 0x93, 0xe8, 0x1f, 0x66, 0x56, 0xc0, 0xf1, 0x1a,
-	0x9d, 0xb4, 0xca, 0x84, 0xc7, 0x4c, 0x83, 0x74, 0x48, 0x05, 0x87, 0xf0, 0xb8, 0xb2, 0x28, 0x3c,
-	0xae, 0xf9, 0x51, 0x72, 0xec, 0xa9, 0xa1, 0x73, 0x4b, 0x32, 0xfe, 0x46, 0x16, 0x3b, 0x77, 0x98,
-	0x71, 0x98, 0x7d, 0xa1, 0x75, 0x83, 0x34, 0x0b, 0xd2, 0x0e, 0xb2, 0x05, 0x64, 0xa2, 0xf3, 0x1f,
-	0x64, 0xf9, 0x3c, 0x08, 0x09, 0x0d, 0x09, 0x49, 0xad, 0x11, 0xba, 0xe7, 0x79, 0x4c, 0x24, 0xf8,
-	0x38, 0x7f, 0xcb, 0xe2, 0x62, 0xde, 0xb3, 0xb0, 0xb4, 0xfb, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x42, 0x04, 0xa6, 0x8c, 0xc4, 0x01, 0x00, 0x00,
+var fileDescriptor_plugin_b3417f3887c427bb = []byte{
+	// 297 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x51, 0xcd, 0x4e, 0xc3, 0x30,
+	0x0c, 0x56, 0xb7, 0xd1, 0xb5, 0x06, 0x24, 0x08, 0x30, 0x22, 0xc4, 0xa1, 0xaa, 0x38, 0xec, 0x34,
+	0x09, 0xf1, 0x06, 0xec, 0x80, 0x90, 0x38, 0x40, 0x8f, 0x5c, 0x50, 0x68, 0xbd, 0x51, 0x69, 0x8d,
+	0x83, 0x93, 0x22, 0xf5, 0x09, 0x78, 0x6d, 0xd4, 0x34, 0x63, 0x83, 0x9b, 0xbf, 0x1f, 0xdb, 0x5f,
+	0x62, 0x38, 0x32, 0x9b, 0x76, 0x5d, 0xeb, 0x85, 0x61, 0x72, 0x24, 0xce, 0xac, 0xa9, 0x19, 0x17,
+	0x25, 0x35, 0x0d, 0xe9, 0xc5, 0x20, 0xe5, 0x2f, 0x70, 0xb2, 0x24, 0xbd, 0xaa, 0xd7, 0x2d, 0x63,
+	0x81, 0x9f, 0x2d, 0x5a, 0x27, 0x6e, 0xe0, 0xb8, 0x0c, 0x9c, 0x72, 0x35, 0x69, 0x19, 0x65, 0xd1,
+	0x3c, 0x2d, 0xfe, 0x92, 0xe2, 0x12, 0xa6, 0x15, 0x77, 0x6f, 0xdc, 0x6a, 0x39, 0xca, 0xa2, 0x79,
+	0x52, 0xc4, 0x15, 0x77, 0x45, 0xab, 0xf3, 0x5b, 0x38, 0xdd, 0x1b, 0x69, 0x0d, 0x69, 0x8b, 0xe2,
+	0x1a, 0x52, 0x64, 0x26, 0x7e, 0xaa, 0xad, 0x93, 0x51, 0x36, 0x9e, 0xa7, 0xc5, 0x8e, 0xc8, 0x67,
+	0x70, 0xfe, 0x80, 0xee, 0xd9, 0x47, 0x7a, 0xd4, 0x2b, 0x0a, 0x49, 0xf2, 0xef, 0x11, 0x5c, 0xfc,
+	0x13, 0xc2, 0x3c, 0x01, 0x13, 0xad, 0x1a, 0x0c, 0xd1, 0x7c, 0x2d, 0xae, 0x20, 0x29, 0x95, 0xc3,
+	0x35, 0x71, 0xe7, 0x23, 0xa5, 0xc5, 0x2f, 0xee, 0xfd, 0xae, 0x33, 0x28, 0xc7, 0x83, 0xbf, 0xaf,
+	0x45, 0x06, 0x87, 0x15, 0xda, 0x92, 0x6b, 0xe3, 0x5f, 0x39, 0xf1, 0xd2, 0x3e, 0xe5, 0x1d, 0xca,
+	0xe1, 0x92, 0x51, 0x39, 0xac, 0xe4, 0x41, 0x70, 0xec, 0xa8, 0x7e, 0xe7, 0x86, 0xca, 0xe1, 0x9b,
+	0xe2, 0x61, 0xe7, 0x16, 0x0b, 0x09, 0xd3, 0x2f, 0x64, 0xdb, 0x4b, 0x53, 0x2f, 0x6d, 0xa1, 0x98,
+	0x41, 0xac, 0x5a, 0xf7, 0x41, 0x2c, 0x13, 0x2f, 0x04, 0xd4, 0x77, 0x94, 0xd4, 0x18, 0xa5, 0x3b,
+	0x99, 0x0e, 0x1d, 0x01, 0xde, 0x27, 0xaf, 0xf1, 0x70, 0xb1, 0xf7, 0xd8, 0x5f, 0xf3, 0xee, 0x67,
+	0x00, 0x50, 0x29, 0x14, 0xec, 0xdd, 0x01, 0x00, 0x00,
 }
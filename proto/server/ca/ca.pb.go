@@ -51,7 +51,7 @@ func (m *SignCsrRequest) Reset()         { *m = SignCsrRequest{} }
 func (m *SignCsrRequest) String() string { return proto.CompactTextString(m) }
 func (*SignCsrRequest) ProtoMessage()    {}
 func (*SignCsrRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{0}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{0}
 }
 func (m *SignCsrRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignCsrRequest.Unmarshal(m, b)
@@ -98,7 +98,7 @@ func (m *SignCsrResponse) Reset()         { *m = SignCsrResponse{} }
 func (m *SignCsrResponse) String() string { return proto.CompactTextString(m) }
 func (*SignCsrResponse) ProtoMessage()    {}
 func (*SignCsrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{1}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{1}
 }
 func (m *SignCsrResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignCsrResponse.Unmarshal(m, b)
@@ -125,6 +125,402 @@ func (m *SignCsrResponse) GetSignedCertificate() []byte {
 	return nil
 }
 
+// * Represents a request to sign a JWT-SVID.
+type SignJwtSvidRequest struct {
+	// * SPIFFE ID of the JWT-SVID subject.
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// * Audience of the JWT-SVID.
+	Audience []string `protobuf:"bytes,2,rep,name=audience" json:"audience,omitempty"`
+	// * TTL
+	Ttl int32 `protobuf:"varint,3,opt,name=ttl" json:"ttl,omitempty"`
+	// * Issuer claim of the JWT-SVID, left out if empty.
+	Issuer               string   `protobuf:"bytes,4,opt,name=issuer" json:"issuer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignJwtSvidRequest) Reset()         { *m = SignJwtSvidRequest{} }
+func (m *SignJwtSvidRequest) String() string { return proto.CompactTextString(m) }
+func (*SignJwtSvidRequest) ProtoMessage()    {}
+func (*SignJwtSvidRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{2}
+}
+func (m *SignJwtSvidRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignJwtSvidRequest.Unmarshal(m, b)
+}
+func (m *SignJwtSvidRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignJwtSvidRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignJwtSvidRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignJwtSvidRequest.Merge(dst, src)
+}
+func (m *SignJwtSvidRequest) XXX_Size() int {
+	return xxx_messageInfo_SignJwtSvidRequest.Size(m)
+}
+func (m *SignJwtSvidRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignJwtSvidRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignJwtSvidRequest proto.InternalMessageInfo
+
+func (m *SignJwtSvidRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *SignJwtSvidRequest) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
+}
+
+func (m *SignJwtSvidRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *SignJwtSvidRequest) GetIssuer() string {
+	if m != nil {
+		return m.Issuer
+	}
+	return ""
+}
+
+// * Represents a response with a signed JWT-SVID.
+type SignJwtSvidResponse struct {
+	// * Signed JWT-SVID.
+	SignedJwtSvid        string   `protobuf:"bytes,1,opt,name=signed_jwt_svid,json=signedJwtSvid" json:"signed_jwt_svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignJwtSvidResponse) Reset()         { *m = SignJwtSvidResponse{} }
+func (m *SignJwtSvidResponse) String() string { return proto.CompactTextString(m) }
+func (*SignJwtSvidResponse) ProtoMessage()    {}
+func (*SignJwtSvidResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{3}
+}
+func (m *SignJwtSvidResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignJwtSvidResponse.Unmarshal(m, b)
+}
+func (m *SignJwtSvidResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignJwtSvidResponse.Marshal(b, m, deterministic)
+}
+func (dst *SignJwtSvidResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignJwtSvidResponse.Merge(dst, src)
+}
+func (m *SignJwtSvidResponse) XXX_Size() int {
+	return xxx_messageInfo_SignJwtSvidResponse.Size(m)
+}
+func (m *SignJwtSvidResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignJwtSvidResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignJwtSvidResponse proto.InternalMessageInfo
+
+func (m *SignJwtSvidResponse) GetSignedJwtSvid() string {
+	if m != nil {
+		return m.SignedJwtSvid
+	}
+	return ""
+}
+
+// * Represents a key used to sign JWT-SVIDs.
+type JwtKey struct {
+	// * Key ID of the key.
+	Kid string `protobuf:"bytes,1,opt,name=kid" json:"kid,omitempty"`
+	// * Public key, PKIX ASN.1 DER encoded.
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// * Expiration time of the key, in seconds since the Unix epoch.
+	NotAfter             int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JwtKey) Reset()         { *m = JwtKey{} }
+func (m *JwtKey) String() string { return proto.CompactTextString(m) }
+func (*JwtKey) ProtoMessage()    {}
+func (*JwtKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{4}
+}
+func (m *JwtKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JwtKey.Unmarshal(m, b)
+}
+func (m *JwtKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JwtKey.Marshal(b, m, deterministic)
+}
+func (dst *JwtKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JwtKey.Merge(dst, src)
+}
+func (m *JwtKey) XXX_Size() int {
+	return xxx_messageInfo_JwtKey.Size(m)
+}
+func (m *JwtKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_JwtKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JwtKey proto.InternalMessageInfo
+
+func (m *JwtKey) GetKid() string {
+	if m != nil {
+		return m.Kid
+	}
+	return ""
+}
+
+func (m *JwtKey) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *JwtKey) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+// * Represents a request to generate a JWT signing key.
+type GenerateJwtKeyRequest struct {
+	// * Expiration time of the key, in seconds since the Unix epoch.
+	NotAfter             int64    `protobuf:"varint,1,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateJwtKeyRequest) Reset()         { *m = GenerateJwtKeyRequest{} }
+func (m *GenerateJwtKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*GenerateJwtKeyRequest) ProtoMessage()    {}
+func (*GenerateJwtKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{5}
+}
+func (m *GenerateJwtKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateJwtKeyRequest.Unmarshal(m, b)
+}
+func (m *GenerateJwtKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateJwtKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *GenerateJwtKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateJwtKeyRequest.Merge(dst, src)
+}
+func (m *GenerateJwtKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_GenerateJwtKeyRequest.Size(m)
+}
+func (m *GenerateJwtKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateJwtKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateJwtKeyRequest proto.InternalMessageInfo
+
+func (m *GenerateJwtKeyRequest) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+// * Represents a response with the generated JWT signing key.
+type GenerateJwtKeyResponse struct {
+	// * Generated JWT signing key, prepared until it is activated.
+	JwtKey               *JwtKey  `protobuf:"bytes,1,opt,name=jwt_key,json=jwtKey" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateJwtKeyResponse) Reset()         { *m = GenerateJwtKeyResponse{} }
+func (m *GenerateJwtKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*GenerateJwtKeyResponse) ProtoMessage()    {}
+func (*GenerateJwtKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{6}
+}
+func (m *GenerateJwtKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GenerateJwtKeyResponse.Unmarshal(m, b)
+}
+func (m *GenerateJwtKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GenerateJwtKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *GenerateJwtKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateJwtKeyResponse.Merge(dst, src)
+}
+func (m *GenerateJwtKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_GenerateJwtKeyResponse.Size(m)
+}
+func (m *GenerateJwtKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateJwtKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateJwtKeyResponse proto.InternalMessageInfo
+
+func (m *GenerateJwtKeyResponse) GetJwtKey() *JwtKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+// * Represents a request to activate the prepared JWT signing key.
+type ActivateJwtKeyRequest struct {
+	// * Key ID of the prepared key.
+	Kid                  string   `protobuf:"bytes,1,opt,name=kid" json:"kid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivateJwtKeyRequest) Reset()         { *m = ActivateJwtKeyRequest{} }
+func (m *ActivateJwtKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivateJwtKeyRequest) ProtoMessage()    {}
+func (*ActivateJwtKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{7}
+}
+func (m *ActivateJwtKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateJwtKeyRequest.Unmarshal(m, b)
+}
+func (m *ActivateJwtKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateJwtKeyRequest.Marshal(b, m, deterministic)
+}
+func (dst *ActivateJwtKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateJwtKeyRequest.Merge(dst, src)
+}
+func (m *ActivateJwtKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_ActivateJwtKeyRequest.Size(m)
+}
+func (m *ActivateJwtKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateJwtKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateJwtKeyRequest proto.InternalMessageInfo
+
+func (m *ActivateJwtKeyRequest) GetKid() string {
+	if m != nil {
+		return m.Kid
+	}
+	return ""
+}
+
+// * Represents an empty response.
+type ActivateJwtKeyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivateJwtKeyResponse) Reset()         { *m = ActivateJwtKeyResponse{} }
+func (m *ActivateJwtKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*ActivateJwtKeyResponse) ProtoMessage()    {}
+func (*ActivateJwtKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{8}
+}
+func (m *ActivateJwtKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateJwtKeyResponse.Unmarshal(m, b)
+}
+func (m *ActivateJwtKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateJwtKeyResponse.Marshal(b, m, deterministic)
+}
+func (dst *ActivateJwtKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateJwtKeyResponse.Merge(dst, src)
+}
+func (m *ActivateJwtKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_ActivateJwtKeyResponse.Size(m)
+}
+func (m *ActivateJwtKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateJwtKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateJwtKeyResponse proto.InternalMessageInfo
+
+// * Represents an empty request.
+type FetchJwtKeysRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchJwtKeysRequest) Reset()         { *m = FetchJwtKeysRequest{} }
+func (m *FetchJwtKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchJwtKeysRequest) ProtoMessage()    {}
+func (*FetchJwtKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{9}
+}
+func (m *FetchJwtKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJwtKeysRequest.Unmarshal(m, b)
+}
+func (m *FetchJwtKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJwtKeysRequest.Marshal(b, m, deterministic)
+}
+func (dst *FetchJwtKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJwtKeysRequest.Merge(dst, src)
+}
+func (m *FetchJwtKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchJwtKeysRequest.Size(m)
+}
+func (m *FetchJwtKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJwtKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJwtKeysRequest proto.InternalMessageInfo
+
+// * Represents a response with the stored JWT signing keys.
+type FetchJwtKeysResponse struct {
+	// * Key JWT-SVIDs are signed with, if any.
+	Active *JwtKey `protobuf:"bytes,1,opt,name=active" json:"active,omitempty"`
+	// * Key generated and not activated yet, if any.
+	Prepared             *JwtKey  `protobuf:"bytes,2,opt,name=prepared" json:"prepared,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchJwtKeysResponse) Reset()         { *m = FetchJwtKeysResponse{} }
+func (m *FetchJwtKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchJwtKeysResponse) ProtoMessage()    {}
+func (*FetchJwtKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{10}
+}
+func (m *FetchJwtKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJwtKeysResponse.Unmarshal(m, b)
+}
+func (m *FetchJwtKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJwtKeysResponse.Marshal(b, m, deterministic)
+}
+func (dst *FetchJwtKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJwtKeysResponse.Merge(dst, src)
+}
+func (m *FetchJwtKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchJwtKeysResponse.Size(m)
+}
+func (m *FetchJwtKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJwtKeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJwtKeysResponse proto.InternalMessageInfo
+
+func (m *FetchJwtKeysResponse) GetActive() *JwtKey {
+	if m != nil {
+		return m.Active
+	}
+	return nil
+}
+
+func (m *FetchJwtKeysResponse) GetPrepared() *JwtKey {
+	if m != nil {
+		return m.Prepared
+	}
+	return nil
+}
+
 // * Represents an empty request.
 type GenerateCsrRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -136,7 +532,7 @@ func (m *GenerateCsrRequest) Reset()         { *m = GenerateCsrRequest{} }
 func (m *GenerateCsrRequest) String() string { return proto.CompactTextString(m) }
 func (*GenerateCsrRequest) ProtoMessage()    {}
 func (*GenerateCsrRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{2}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{11}
 }
 func (m *GenerateCsrRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenerateCsrRequest.Unmarshal(m, b)
@@ -169,7 +565,7 @@ func (m *GenerateCsrResponse) Reset()         { *m = GenerateCsrResponse{} }
 func (m *GenerateCsrResponse) String() string { return proto.CompactTextString(m) }
 func (*GenerateCsrResponse) ProtoMessage()    {}
 func (*GenerateCsrResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{3}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{12}
 }
 func (m *GenerateCsrResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GenerateCsrResponse.Unmarshal(m, b)
@@ -207,7 +603,7 @@ func (m *FetchCertificateRequest) Reset()         { *m = FetchCertificateRequest
 func (m *FetchCertificateRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchCertificateRequest) ProtoMessage()    {}
 func (*FetchCertificateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{4}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{13}
 }
 func (m *FetchCertificateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchCertificateRequest.Unmarshal(m, b)
@@ -240,7 +636,7 @@ func (m *FetchCertificateResponse) Reset()         { *m = FetchCertificateRespon
 func (m *FetchCertificateResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchCertificateResponse) ProtoMessage()    {}
 func (*FetchCertificateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{5}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{14}
 }
 func (m *FetchCertificateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchCertificateResponse.Unmarshal(m, b)
@@ -280,7 +676,7 @@ func (m *LoadCertificateRequest) Reset()         { *m = LoadCertificateRequest{}
 func (m *LoadCertificateRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadCertificateRequest) ProtoMessage()    {}
 func (*LoadCertificateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{6}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{15}
 }
 func (m *LoadCertificateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadCertificateRequest.Unmarshal(m, b)
@@ -318,7 +714,7 @@ func (m *LoadCertificateResponse) Reset()         { *m = LoadCertificateResponse
 func (m *LoadCertificateResponse) String() string { return proto.CompactTextString(m) }
 func (*LoadCertificateResponse) ProtoMessage()    {}
 func (*LoadCertificateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ca_57dbdb4225bd218f, []int{7}
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{16}
 }
 func (m *LoadCertificateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadCertificateResponse.Unmarshal(m, b)
@@ -338,15 +734,256 @@ func (m *LoadCertificateResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_LoadCertificateResponse proto.InternalMessageInfo
 
+// * Represents a revoked certificate to list in a CRL.
+type RevokedCertificate struct {
+	// * Serial number of the certificate, in decimal.
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	// * Revocation time, in seconds since the Unix epoch.
+	RevokedAt            int64    `protobuf:"varint,2,opt,name=revoked_at,json=revokedAt" json:"revoked_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokedCertificate) Reset()         { *m = RevokedCertificate{} }
+func (m *RevokedCertificate) String() string { return proto.CompactTextString(m) }
+func (*RevokedCertificate) ProtoMessage()    {}
+func (*RevokedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{17}
+}
+func (m *RevokedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokedCertificate.Unmarshal(m, b)
+}
+func (m *RevokedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokedCertificate.Marshal(b, m, deterministic)
+}
+func (dst *RevokedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokedCertificate.Merge(dst, src)
+}
+func (m *RevokedCertificate) XXX_Size() int {
+	return xxx_messageInfo_RevokedCertificate.Size(m)
+}
+func (m *RevokedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokedCertificate proto.InternalMessageInfo
+
+func (m *RevokedCertificate) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *RevokedCertificate) GetRevokedAt() int64 {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return 0
+}
+
+// * Represents a request to sign a CRL.
+type SignCrlRequest struct {
+	// * Certificates to list as revoked.
+	RevokedCertificates []*RevokedCertificate `protobuf:"bytes,1,rep,name=revoked_certificates,json=revokedCertificates" json:"revoked_certificates,omitempty"`
+	// * Time, in seconds since the Unix epoch, by which the next CRL is issued.
+	NextUpdate           int64    `protobuf:"varint,2,opt,name=next_update,json=nextUpdate" json:"next_update,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignCrlRequest) Reset()         { *m = SignCrlRequest{} }
+func (m *SignCrlRequest) String() string { return proto.CompactTextString(m) }
+func (*SignCrlRequest) ProtoMessage()    {}
+func (*SignCrlRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{18}
+}
+func (m *SignCrlRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignCrlRequest.Unmarshal(m, b)
+}
+func (m *SignCrlRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignCrlRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignCrlRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignCrlRequest.Merge(dst, src)
+}
+func (m *SignCrlRequest) XXX_Size() int {
+	return xxx_messageInfo_SignCrlRequest.Size(m)
+}
+func (m *SignCrlRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignCrlRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignCrlRequest proto.InternalMessageInfo
+
+func (m *SignCrlRequest) GetRevokedCertificates() []*RevokedCertificate {
+	if m != nil {
+		return m.RevokedCertificates
+	}
+	return nil
+}
+
+func (m *SignCrlRequest) GetNextUpdate() int64 {
+	if m != nil {
+		return m.NextUpdate
+	}
+	return 0
+}
+
+// * Represents a response with a signed CRL.
+type SignCrlResponse struct {
+	// * Signed CRL, ASN.1 DER encoded.
+	SignedCrl            []byte   `protobuf:"bytes,1,opt,name=signed_crl,json=signedCrl,proto3" json:"signed_crl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignCrlResponse) Reset()         { *m = SignCrlResponse{} }
+func (m *SignCrlResponse) String() string { return proto.CompactTextString(m) }
+func (*SignCrlResponse) ProtoMessage()    {}
+func (*SignCrlResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{19}
+}
+func (m *SignCrlResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignCrlResponse.Unmarshal(m, b)
+}
+func (m *SignCrlResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignCrlResponse.Marshal(b, m, deterministic)
+}
+func (dst *SignCrlResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignCrlResponse.Merge(dst, src)
+}
+func (m *SignCrlResponse) XXX_Size() int {
+	return xxx_messageInfo_SignCrlResponse.Size(m)
+}
+func (m *SignCrlResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignCrlResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignCrlResponse proto.InternalMessageInfo
+
+func (m *SignCrlResponse) GetSignedCrl() []byte {
+	if m != nil {
+		return m.SignedCrl
+	}
+	return nil
+}
+
+// * Represents a request to sign the certificate of a delegated OCSP responder.
+type SignOcspResponderCertRequest struct {
+	// * Public key of the responder, PKIX ASN.1 DER encoded.
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// * TTL
+	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignOcspResponderCertRequest) Reset()         { *m = SignOcspResponderCertRequest{} }
+func (m *SignOcspResponderCertRequest) String() string { return proto.CompactTextString(m) }
+func (*SignOcspResponderCertRequest) ProtoMessage()    {}
+func (*SignOcspResponderCertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{20}
+}
+func (m *SignOcspResponderCertRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignOcspResponderCertRequest.Unmarshal(m, b)
+}
+func (m *SignOcspResponderCertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignOcspResponderCertRequest.Marshal(b, m, deterministic)
+}
+func (dst *SignOcspResponderCertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignOcspResponderCertRequest.Merge(dst, src)
+}
+func (m *SignOcspResponderCertRequest) XXX_Size() int {
+	return xxx_messageInfo_SignOcspResponderCertRequest.Size(m)
+}
+func (m *SignOcspResponderCertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignOcspResponderCertRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignOcspResponderCertRequest proto.InternalMessageInfo
+
+func (m *SignOcspResponderCertRequest) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *SignOcspResponderCertRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+// * Represents a response with a signed OCSP responder certificate.
+type SignOcspResponderCertResponse struct {
+	// * Signed OCSP responder certificate.
+	SignedCertificate    []byte   `protobuf:"bytes,1,opt,name=signed_certificate,json=signedCertificate,proto3" json:"signed_certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignOcspResponderCertResponse) Reset()         { *m = SignOcspResponderCertResponse{} }
+func (m *SignOcspResponderCertResponse) String() string { return proto.CompactTextString(m) }
+func (*SignOcspResponderCertResponse) ProtoMessage()    {}
+func (*SignOcspResponderCertResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca_eabb5ef24dc51fbc, []int{21}
+}
+func (m *SignOcspResponderCertResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignOcspResponderCertResponse.Unmarshal(m, b)
+}
+func (m *SignOcspResponderCertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignOcspResponderCertResponse.Marshal(b, m, deterministic)
+}
+func (dst *SignOcspResponderCertResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignOcspResponderCertResponse.Merge(dst, src)
+}
+func (m *SignOcspResponderCertResponse) XXX_Size() int {
+	return xxx_messageInfo_SignOcspResponderCertResponse.Size(m)
+}
+func (m *SignOcspResponderCertResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignOcspResponderCertResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignOcspResponderCertResponse proto.InternalMessageInfo
+
+func (m *SignOcspResponderCertResponse) GetSignedCertificate() []byte {
+	if m != nil {
+		return m.SignedCertificate
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SignCsrRequest)(nil), "spire.server.ca.SignCsrRequest")
 	proto.RegisterType((*SignCsrResponse)(nil), "spire.server.ca.SignCsrResponse")
+	proto.RegisterType((*SignJwtSvidRequest)(nil), "spire.server.ca.SignJwtSvidRequest")
+	proto.RegisterType((*SignJwtSvidResponse)(nil), "spire.server.ca.SignJwtSvidResponse")
+	proto.RegisterType((*JwtKey)(nil), "spire.server.ca.JwtKey")
+	proto.RegisterType((*GenerateJwtKeyRequest)(nil), "spire.server.ca.GenerateJwtKeyRequest")
+	proto.RegisterType((*GenerateJwtKeyResponse)(nil), "spire.server.ca.GenerateJwtKeyResponse")
+	proto.RegisterType((*ActivateJwtKeyRequest)(nil), "spire.server.ca.ActivateJwtKeyRequest")
+	proto.RegisterType((*ActivateJwtKeyResponse)(nil), "spire.server.ca.ActivateJwtKeyResponse")
+	proto.RegisterType((*FetchJwtKeysRequest)(nil), "spire.server.ca.FetchJwtKeysRequest")
+	proto.RegisterType((*FetchJwtKeysResponse)(nil), "spire.server.ca.FetchJwtKeysResponse")
 	proto.RegisterType((*GenerateCsrRequest)(nil), "spire.server.ca.GenerateCsrRequest")
 	proto.RegisterType((*GenerateCsrResponse)(nil), "spire.server.ca.GenerateCsrResponse")
 	proto.RegisterType((*FetchCertificateRequest)(nil), "spire.server.ca.FetchCertificateRequest")
 	proto.RegisterType((*FetchCertificateResponse)(nil), "spire.server.ca.FetchCertificateResponse")
 	proto.RegisterType((*LoadCertificateRequest)(nil), "spire.server.ca.LoadCertificateRequest")
 	proto.RegisterType((*LoadCertificateResponse)(nil), "spire.server.ca.LoadCertificateResponse")
+	proto.RegisterType((*RevokedCertificate)(nil), "spire.server.ca.RevokedCertificate")
+	proto.RegisterType((*SignCrlRequest)(nil), "spire.server.ca.SignCrlRequest")
+	proto.RegisterType((*SignCrlResponse)(nil), "spire.server.ca.SignCrlResponse")
+	proto.RegisterType((*SignOcspResponderCertRequest)(nil), "spire.server.ca.SignOcspResponderCertRequest")
+	proto.RegisterType((*SignOcspResponderCertResponse)(nil), "spire.server.ca.SignOcspResponderCertResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -362,12 +999,24 @@ const _ = grpc.SupportPackageIsVersion4
 type ServerCAClient interface {
 	// * Interface will take in a CSR and sign it with the stored intermediate certificate.
 	SignCsr(ctx context.Context, in *SignCsrRequest, opts ...grpc.CallOption) (*SignCsrResponse, error)
+	// * Signs a JWT-SVID with the active JWT signing key, or with the key of the stored intermediate certificate if none is active.
+	SignJwtSvid(ctx context.Context, in *SignJwtSvidRequest, opts ...grpc.CallOption) (*SignJwtSvidResponse, error)
+	// * Generates a JWT signing key and stores it as prepared, replacing the one prepared already if any.
+	GenerateJwtKey(ctx context.Context, in *GenerateJwtKeyRequest, opts ...grpc.CallOption) (*GenerateJwtKeyResponse, error)
+	// * Activates the prepared JWT signing key, provided it has the given key ID.
+	ActivateJwtKey(ctx context.Context, in *ActivateJwtKeyRequest, opts ...grpc.CallOption) (*ActivateJwtKeyResponse, error)
+	// * Used to read the stored JWT signing keys.
+	FetchJwtKeys(ctx context.Context, in *FetchJwtKeysRequest, opts ...grpc.CallOption) (*FetchJwtKeysResponse, error)
 	// * Used for generating a CSR for the intermediate signing certificate. The CSR will then be submitted to the CA plugin for signing.
 	GenerateCsr(ctx context.Context, in *GenerateCsrRequest, opts ...grpc.CallOption) (*GenerateCsrResponse, error)
 	// * Used to read the stored Intermediate Server cert.
 	FetchCertificate(ctx context.Context, in *FetchCertificateRequest, opts ...grpc.CallOption) (*FetchCertificateResponse, error)
 	// * Used for setting/storing the signed intermediate certificate.
 	LoadCertificate(ctx context.Context, in *LoadCertificateRequest, opts ...grpc.CallOption) (*LoadCertificateResponse, error)
+	// * Signs a CRL with the stored intermediate certificate.
+	SignCrl(ctx context.Context, in *SignCrlRequest, opts ...grpc.CallOption) (*SignCrlResponse, error)
+	// * Signs the certificate of a delegated OCSP responder with the stored intermediate certificate.
+	SignOcspResponderCert(ctx context.Context, in *SignOcspResponderCertRequest, opts ...grpc.CallOption) (*SignOcspResponderCertResponse, error)
 	// * Responsible for configuration of the plugin.
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// * Returns the  version and related metadata of the installed plugin.
@@ -391,6 +1040,42 @@ func (c *serverCAClient) SignCsr(ctx context.Context, in *SignCsrRequest, opts .
 	return out, nil
 }
 
+func (c *serverCAClient) SignJwtSvid(ctx context.Context, in *SignJwtSvidRequest, opts ...grpc.CallOption) (*SignJwtSvidResponse, error) {
+	out := new(SignJwtSvidResponse)
+	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/SignJwtSvid", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverCAClient) GenerateJwtKey(ctx context.Context, in *GenerateJwtKeyRequest, opts ...grpc.CallOption) (*GenerateJwtKeyResponse, error) {
+	out := new(GenerateJwtKeyResponse)
+	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/GenerateJwtKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverCAClient) ActivateJwtKey(ctx context.Context, in *ActivateJwtKeyRequest, opts ...grpc.CallOption) (*ActivateJwtKeyResponse, error) {
+	out := new(ActivateJwtKeyResponse)
+	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/ActivateJwtKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverCAClient) FetchJwtKeys(ctx context.Context, in *FetchJwtKeysRequest, opts ...grpc.CallOption) (*FetchJwtKeysResponse, error) {
+	out := new(FetchJwtKeysResponse)
+	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/FetchJwtKeys", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serverCAClient) GenerateCsr(ctx context.Context, in *GenerateCsrRequest, opts ...grpc.CallOption) (*GenerateCsrResponse, error) {
 	out := new(GenerateCsrResponse)
 	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/GenerateCsr", in, out, c.cc, opts...)
@@ -418,6 +1103,24 @@ func (c *serverCAClient) LoadCertificate(ctx context.Context, in *LoadCertificat
 	return out, nil
 }
 
+func (c *serverCAClient) SignCrl(ctx context.Context, in *SignCrlRequest, opts ...grpc.CallOption) (*SignCrlResponse, error) {
+	out := new(SignCrlResponse)
+	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/SignCrl", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverCAClient) SignOcspResponderCert(ctx context.Context, in *SignOcspResponderCertRequest, opts ...grpc.CallOption) (*SignOcspResponderCertResponse, error) {
+	out := new(SignOcspResponderCertResponse)
+	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/SignOcspResponderCert", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serverCAClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := grpc.Invoke(ctx, "/spire.server.ca.ServerCA/Configure", in, out, c.cc, opts...)
@@ -441,12 +1144,24 @@ func (c *serverCAClient) GetPluginInfo(ctx context.Context, in *plugin.GetPlugin
 type ServerCAServer interface {
 	// * Interface will take in a CSR and sign it with the stored intermediate certificate.
 	SignCsr(context.Context, *SignCsrRequest) (*SignCsrResponse, error)
+	// * Signs a JWT-SVID with the active JWT signing key, or with the key of the stored intermediate certificate if none is active.
+	SignJwtSvid(context.Context, *SignJwtSvidRequest) (*SignJwtSvidResponse, error)
+	// * Generates a JWT signing key and stores it as prepared, replacing the one prepared already if any.
+	GenerateJwtKey(context.Context, *GenerateJwtKeyRequest) (*GenerateJwtKeyResponse, error)
+	// * Activates the prepared JWT signing key, provided it has the given key ID.
+	ActivateJwtKey(context.Context, *ActivateJwtKeyRequest) (*ActivateJwtKeyResponse, error)
+	// * Used to read the stored JWT signing keys.
+	FetchJwtKeys(context.Context, *FetchJwtKeysRequest) (*FetchJwtKeysResponse, error)
 	// * Used for generating a CSR for the intermediate signing certificate. The CSR will then be submitted to the CA plugin for signing.
 	GenerateCsr(context.Context, *GenerateCsrRequest) (*GenerateCsrResponse, error)
 	// * Used to read the stored Intermediate Server cert.
 	FetchCertificate(context.Context, *FetchCertificateRequest) (*FetchCertificateResponse, error)
 	// * Used for setting/storing the signed intermediate certificate.
 	LoadCertificate(context.Context, *LoadCertificateRequest) (*LoadCertificateResponse, error)
+	// * Signs a CRL with the stored intermediate certificate.
+	SignCrl(context.Context, *SignCrlRequest) (*SignCrlResponse, error)
+	// * Signs the certificate of a delegated OCSP responder with the stored intermediate certificate.
+	SignOcspResponderCert(context.Context, *SignOcspResponderCertRequest) (*SignOcspResponderCertResponse, error)
 	// * Responsible for configuration of the plugin.
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// * Returns the  version and related metadata of the installed plugin.
@@ -475,6 +1190,78 @@ func _ServerCA_SignCsr_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerCA_SignJwtSvid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignJwtSvidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCAServer).SignJwtSvid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.ca.ServerCA/SignJwtSvid",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCAServer).SignJwtSvid(ctx, req.(*SignJwtSvidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerCA_GenerateJwtKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateJwtKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCAServer).GenerateJwtKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.ca.ServerCA/GenerateJwtKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCAServer).GenerateJwtKey(ctx, req.(*GenerateJwtKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerCA_ActivateJwtKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateJwtKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCAServer).ActivateJwtKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.ca.ServerCA/ActivateJwtKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCAServer).ActivateJwtKey(ctx, req.(*ActivateJwtKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerCA_FetchJwtKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchJwtKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCAServer).FetchJwtKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.ca.ServerCA/FetchJwtKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCAServer).FetchJwtKeys(ctx, req.(*FetchJwtKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServerCA_GenerateCsr_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateCsrRequest)
 	if err := dec(in); err != nil {
@@ -529,6 +1316,42 @@ func _ServerCA_LoadCertificate_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerCA_SignCrl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignCrlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCAServer).SignCrl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.ca.ServerCA/SignCrl",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCAServer).SignCrl(ctx, req.(*SignCrlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerCA_SignOcspResponderCert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignOcspResponderCertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerCAServer).SignOcspResponderCert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.ca.ServerCA/SignOcspResponderCert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerCAServer).SignOcspResponderCert(ctx, req.(*SignOcspResponderCertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServerCA_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -573,6 +1396,22 @@ var _ServerCA_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SignCsr",
 			Handler:    _ServerCA_SignCsr_Handler,
 		},
+		{
+			MethodName: "SignJwtSvid",
+			Handler:    _ServerCA_SignJwtSvid_Handler,
+		},
+		{
+			MethodName: "GenerateJwtKey",
+			Handler:    _ServerCA_GenerateJwtKey_Handler,
+		},
+		{
+			MethodName: "ActivateJwtKey",
+			Handler:    _ServerCA_ActivateJwtKey_Handler,
+		},
+		{
+			MethodName: "FetchJwtKeys",
+			Handler:    _ServerCA_FetchJwtKeys_Handler,
+		},
 		{
 			MethodName: "GenerateCsr",
 			Handler:    _ServerCA_GenerateCsr_Handler,
@@ -585,6 +1424,14 @@ var _ServerCA_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LoadCertificate",
 			Handler:    _ServerCA_LoadCertificate_Handler,
 		},
+		{
+			MethodName: "SignCrl",
+			Handler:    _ServerCA_SignCrl_Handler,
+		},
+		{
+			MethodName: "SignOcspResponderCert",
+			Handler:    _ServerCA_SignOcspResponderCert_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _ServerCA_Configure_Handler,
@@ -598,34 +1445,65 @@ var _ServerCA_serviceDesc = grpc.ServiceDesc{
 	Metadata: "ca.proto",
 }
 
-func init() { proto.RegisterFile("ca.proto", fileDescriptor_ca_57dbdb4225bd218f) }
-
-var fileDescriptor_ca_57dbdb4225bd218f = []byte{
-	// 412 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0x4f, 0x8f, 0xd3, 0x30,
-	0x10, 0xc5, 0xe9, 0xb2, 0xc0, 0x32, 0xfc, 0xe9, 0x62, 0xd0, 0x52, 0x72, 0xa1, 0x0a, 0x7f, 0x9a,
-	0x22, 0x94, 0x48, 0x80, 0x10, 0x37, 0x04, 0x91, 0xa8, 0x2a, 0xf5, 0x50, 0xa5, 0x17, 0xd4, 0x5b,
-	0xea, 0x4c, 0x52, 0x4b, 0x8d, 0x1d, 0x6c, 0x87, 0x6f, 0xc8, 0xf7, 0x42, 0x49, 0x9c, 0xd2, 0xd6,
-	0x0d, 0xed, 0x29, 0x91, 0xe7, 0xcd, 0xef, 0xd9, 0xf3, 0x34, 0x70, 0x45, 0x63, 0xbf, 0x90, 0x42,
-	0x0b, 0xd2, 0x57, 0x05, 0x93, 0xe8, 0x2b, 0x94, 0xbf, 0x51, 0xfa, 0x34, 0x76, 0xbe, 0x64, 0x4c,
-	0xaf, 0xcb, 0x95, 0x4f, 0x45, 0x1e, 0xa8, 0x82, 0xa5, 0x29, 0x06, 0xb5, 0x24, 0xa8, 0xf5, 0x01,
-	0x15, 0x79, 0x2e, 0x78, 0x50, 0x6c, 0xca, 0x8c, 0xb5, 0x9f, 0x06, 0xe5, 0x7e, 0x82, 0xc7, 0x0b,
-	0x96, 0xf1, 0x50, 0xc9, 0x08, 0x7f, 0x95, 0xa8, 0x34, 0xb9, 0x86, 0xdb, 0x54, 0xc9, 0x41, 0x6f,
-	0xd8, 0xf3, 0x1e, 0x46, 0xd5, 0x6f, 0x75, 0xa2, 0xf5, 0x66, 0x70, 0x31, 0xec, 0x79, 0x77, 0xa2,
-	0xea, 0xd7, 0xfd, 0x0a, 0xfd, 0x6d, 0x97, 0x2a, 0x04, 0x57, 0x48, 0xde, 0xc3, 0x13, 0xc5, 0x32,
-	0x8e, 0x49, 0x88, 0x52, 0xb3, 0x94, 0xd1, 0x58, 0xa3, 0x81, 0xd8, 0x05, 0xf7, 0x19, 0x90, 0x09,
-	0x72, 0x94, 0xb1, 0xc6, 0x7f, 0xd6, 0xee, 0x08, 0x9e, 0xee, 0x9d, 0x1a, 0xb4, 0x75, 0x23, 0xf7,
-	0x05, 0x3c, 0xff, 0x81, 0x9a, 0xae, 0x77, 0x90, 0x2d, 0x23, 0x82, 0x81, 0x5d, 0x32, 0xa0, 0xcf,
-	0x70, 0xa3, 0xb4, 0x90, 0x98, 0x4c, 0xb9, 0x46, 0x99, 0x63, 0xc2, 0x2a, 0x27, 0x94, 0xda, 0xb0,
-	0x3b, 0xaa, 0xee, 0x1c, 0x6e, 0x66, 0x22, 0x4e, 0x6c, 0xb7, 0x9a, 0x58, 0x3f, 0xae, 0x93, 0x78,
-	0xb4, 0x5a, 0x3d, 0xc0, 0x22, 0x36, 0x97, 0xfc, 0xf0, 0xe7, 0x12, 0xae, 0x16, 0x75, 0xb2, 0xe1,
-	0x37, 0x32, 0x83, 0x7b, 0x66, 0xd0, 0xe4, 0xa5, 0x7f, 0x90, 0xba, 0xbf, 0x1f, 0x9c, 0x33, 0xec,
-	0x16, 0x98, 0xf7, 0xff, 0x84, 0x07, 0x3b, 0xf3, 0x25, 0xaf, 0xac, 0x06, 0x3b, 0x13, 0xe7, 0xf5,
-	0xff, 0x45, 0x86, 0x9c, 0xc1, 0xf5, 0xe1, 0xd4, 0x89, 0x67, 0x75, 0x76, 0x64, 0xe6, 0x8c, 0xcf,
-	0x50, 0x1a, 0xa3, 0x04, 0xfa, 0x07, 0x83, 0x23, 0x23, 0xab, 0xfb, 0x78, 0x58, 0x8e, 0x77, 0x5a,
-	0x68, 0x5c, 0x96, 0x70, 0x3f, 0x14, 0x3c, 0x65, 0x59, 0x29, 0x91, 0xbc, 0x31, 0x6d, 0xcd, 0x16,
-	0xf9, 0x66, 0x7d, 0xb6, 0xf5, 0x96, 0xfe, 0xf6, 0x94, 0xcc, 0xb0, 0x53, 0x78, 0x34, 0x41, 0x3d,
-	0xaf, 0xcb, 0x53, 0x9e, 0x0a, 0x32, 0x3e, 0xda, 0xb8, 0xa7, 0x69, 0x3d, 0xde, 0x9d, 0x23, 0x6d,
-	0x7c, 0xbe, 0x5f, 0x2e, 0x2f, 0x68, 0x3c, 0xbf, 0xb5, 0xba, 0x5b, 0x2f, 0xfa, 0xc7, 0xbf, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xaa, 0xf6, 0x00, 0x38, 0x3f, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("ca.proto", fileDescriptor_ca_eabb5ef24dc51fbc) }
+
+var fileDescriptor_ca_eabb5ef24dc51fbc = []byte{
+	// 901 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0x61, 0x6f, 0xdb, 0x36,
+	0x10, 0x9d, 0xe3, 0xce, 0xb5, 0x2f, 0x49, 0xdd, 0x31, 0x89, 0xeb, 0xa9, 0x0b, 0x6a, 0x30, 0x5d,
+	0xe2, 0x0c, 0xab, 0x5d, 0xa4, 0xc5, 0xb0, 0x2f, 0xc3, 0x90, 0x19, 0x58, 0x91, 0xac, 0x68, 0x03,
+	0x05, 0x2b, 0x8a, 0xee, 0x83, 0xa1, 0x48, 0x67, 0x97, 0x8d, 0x2c, 0x69, 0x24, 0xe5, 0xac, 0xc0,
+	0x7e, 0xc0, 0xf6, 0xaf, 0x0b, 0x8a, 0x94, 0x6d, 0x99, 0x72, 0x9c, 0x4f, 0x92, 0x8e, 0xef, 0xee,
+	0x3d, 0x1e, 0x8f, 0x0f, 0x82, 0xba, 0xef, 0xf5, 0x12, 0x1e, 0xcb, 0x98, 0x34, 0x45, 0xc2, 0x38,
+	0xf6, 0x04, 0xf2, 0x29, 0xf2, 0x9e, 0xef, 0x39, 0x3f, 0x8f, 0x99, 0xfc, 0x98, 0x5e, 0xf5, 0xfc,
+	0x78, 0xd2, 0x17, 0x09, 0x1b, 0x8d, 0xb0, 0x9f, 0x41, 0xfa, 0x19, 0xbe, 0xef, 0xc7, 0x93, 0x49,
+	0x1c, 0xf5, 0x93, 0x30, 0x1d, 0xb3, 0xfc, 0xa1, 0x4b, 0xd1, 0x97, 0xf0, 0xe0, 0x92, 0x8d, 0xa3,
+	0x81, 0xe0, 0x2e, 0xfe, 0x9d, 0xa2, 0x90, 0xe4, 0x21, 0x54, 0x7d, 0xc1, 0xdb, 0x95, 0x4e, 0xa5,
+	0xbb, 0xe5, 0xaa, 0x57, 0x15, 0x91, 0x32, 0x6c, 0x6f, 0x74, 0x2a, 0xdd, 0xaf, 0x5d, 0xf5, 0x4a,
+	0x7f, 0x85, 0xe6, 0x2c, 0x4b, 0x24, 0x71, 0x24, 0x90, 0xfc, 0x08, 0xdf, 0x08, 0x36, 0x8e, 0x30,
+	0x18, 0x20, 0x97, 0x6c, 0xc4, 0x7c, 0x4f, 0xa2, 0x29, 0x62, 0x2f, 0xd0, 0x1b, 0x20, 0xaa, 0xc0,
+	0xf9, 0x8d, 0xbc, 0x9c, 0xb2, 0x20, 0xa7, 0x7e, 0x0c, 0x0d, 0xad, 0x7e, 0xc8, 0x82, 0x2c, 0xb7,
+	0xe1, 0xd6, 0x75, 0xe0, 0x2c, 0x20, 0x0e, 0xd4, 0xbd, 0x34, 0x60, 0x18, 0xf9, 0xd8, 0xde, 0xe8,
+	0x54, 0xd5, 0x5a, 0xfe, 0x9d, 0x2b, 0xac, 0xce, 0x14, 0x92, 0x16, 0xd4, 0x98, 0x10, 0x29, 0xf2,
+	0xf6, 0xbd, 0xac, 0x8e, 0xf9, 0xa2, 0xbf, 0xc0, 0x4e, 0x81, 0xd8, 0xa8, 0x3f, 0x84, 0xa6, 0x16,
+	0x39, 0xfc, 0x74, 0x23, 0x87, 0x62, 0x3a, 0xe3, 0xdf, 0xd6, 0x61, 0x83, 0xa7, 0xef, 0xa0, 0x76,
+	0x7e, 0x23, 0xff, 0xc0, 0xcf, 0x8a, 0xf2, 0x7a, 0x86, 0x52, 0xaf, 0x64, 0x1f, 0x20, 0x49, 0xaf,
+	0x42, 0xe6, 0x0f, 0xaf, 0xf1, 0x73, 0xd6, 0xad, 0x2d, 0xb7, 0xa1, 0x23, 0x2a, 0xe1, 0x31, 0x34,
+	0xa2, 0x58, 0x0e, 0xbd, 0x91, 0x44, 0x9e, 0x29, 0xad, 0xba, 0xf5, 0x28, 0x96, 0xa7, 0xea, 0x9b,
+	0xbe, 0x84, 0xbd, 0x57, 0x18, 0x21, 0xf7, 0x24, 0xea, 0xfa, 0x0b, 0x2d, 0x99, 0x67, 0x55, 0x96,
+	0xb2, 0xce, 0xa1, 0xb5, 0x9c, 0x65, 0xf6, 0xf3, 0x1c, 0xee, 0xab, 0x8d, 0x28, 0x21, 0x2a, 0x69,
+	0xf3, 0xe4, 0x51, 0x6f, 0x69, 0x66, 0x7a, 0x26, 0xa3, 0xf6, 0x29, 0x7b, 0xd2, 0x63, 0xd8, 0x3b,
+	0xf5, 0x25, 0x9b, 0x5a, 0x0a, 0xac, 0x8d, 0xd2, 0x36, 0xb4, 0x96, 0xa1, 0x9a, 0x96, 0xee, 0xc1,
+	0xce, 0xef, 0x28, 0xfd, 0x8f, 0x3a, 0x2c, 0x4c, 0x09, 0xfa, 0x2f, 0xec, 0x16, 0xc3, 0x46, 0x65,
+	0x1f, 0x6a, 0x9e, 0x2a, 0x84, 0x6b, 0x45, 0x6a, 0x18, 0x79, 0x01, 0xf5, 0x84, 0x63, 0xe2, 0x71,
+	0x0c, 0xda, 0x1b, 0xb7, 0xa7, 0xcc, 0x80, 0x74, 0x17, 0x48, 0xde, 0xa5, 0xf9, 0x98, 0xd3, 0x23,
+	0xd8, 0x29, 0x44, 0x8d, 0x24, 0x6b, 0xfa, 0xe9, 0xb7, 0xf0, 0x28, 0x13, 0xbf, 0x30, 0xbe, 0x79,
+	0x0d, 0x17, 0xda, 0xf6, 0x92, 0x29, 0xf4, 0x13, 0xb4, 0x84, 0x8c, 0x39, 0x06, 0x67, 0x91, 0x44,
+	0x3e, 0xc1, 0x80, 0x29, 0x26, 0xe4, 0xd2, 0xd4, 0x5e, 0xb1, 0x4a, 0x2f, 0xa0, 0xf5, 0x3a, 0xf6,
+	0x02, 0x9b, 0x2d, 0xab, 0x98, 0x0d, 0xe3, 0xca, 0x8a, 0xa5, 0xab, 0x6a, 0x03, 0x56, 0x45, 0x73,
+	0x5e, 0xef, 0x81, 0xb8, 0x38, 0x8d, 0xaf, 0x0b, 0x97, 0x93, 0x1c, 0xc0, 0xb6, 0x40, 0xce, 0xbc,
+	0x70, 0x18, 0xa5, 0x93, 0x2b, 0x33, 0x77, 0x0d, 0x77, 0x4b, 0x07, 0xdf, 0x64, 0x31, 0x35, 0xed,
+	0x5c, 0xa7, 0x0e, 0x3d, 0x99, 0x1d, 0x46, 0xd5, 0x6d, 0x98, 0xc8, 0xa9, 0xa4, 0xff, 0x57, 0x8c,
+	0xb1, 0xf0, 0x30, 0xd7, 0xff, 0x0e, 0x76, 0xf3, 0x0c, 0x7f, 0xce, 0x26, 0xda, 0x95, 0x4e, 0xb5,
+	0xbb, 0x79, 0x72, 0x60, 0x1d, 0xa4, 0xad, 0xcc, 0xdd, 0xe1, 0x56, 0x4c, 0x90, 0x27, 0xb0, 0x19,
+	0xe1, 0x3f, 0x72, 0x98, 0x26, 0x81, 0xf2, 0x1c, 0x2d, 0x05, 0x54, 0xe8, 0xcf, 0x2c, 0x42, 0x9f,
+	0x43, 0x73, 0x26, 0xc5, 0x9c, 0xce, 0x3e, 0x80, 0xb9, 0xef, 0x3e, 0x0f, 0x4d, 0xff, 0x1a, 0x3a,
+	0x32, 0xe0, 0x21, 0x7d, 0x0b, 0xdf, 0xa9, 0x8c, 0xb7, 0xbe, 0x48, 0x74, 0x4a, 0x80, 0x5c, 0x71,
+	0xe6, 0x5b, 0x29, 0x5e, 0xf5, 0xca, 0xf2, 0x55, 0xb7, 0x0d, 0xf3, 0x0d, 0xec, 0xaf, 0x28, 0x68,
+	0x04, 0x3d, 0x03, 0x92, 0x0b, 0xba, 0x83, 0x7f, 0x9e, 0xfc, 0xd7, 0x80, 0xfa, 0x65, 0xd6, 0xa9,
+	0xc1, 0x29, 0x79, 0x0d, 0xf7, 0x8d, 0x1b, 0x93, 0x27, 0x56, 0x17, 0x8b, 0xee, 0xee, 0x74, 0x56,
+	0x03, 0x8c, 0x92, 0xf7, 0xb0, 0xb9, 0xe0, 0x90, 0xe4, 0xa0, 0x34, 0xa1, 0x68, 0xdc, 0xce, 0xd3,
+	0xdb, 0x41, 0xa6, 0xb2, 0x07, 0x0f, 0x8a, 0x76, 0x45, 0x0e, 0xad, 0xbc, 0x52, 0x17, 0x74, 0x8e,
+	0xd6, 0xe2, 0xe6, 0x14, 0x45, 0x6b, 0x2a, 0xa1, 0x28, 0xb5, 0x39, 0xe7, 0x68, 0x2d, 0xce, 0x50,
+	0xfc, 0x05, 0x5b, 0x8b, 0x66, 0x46, 0xec, 0xbd, 0x97, 0x58, 0xa0, 0xf3, 0xfd, 0x1a, 0xd4, 0xbc,
+	0xf9, 0x0b, 0xae, 0x54, 0xd2, 0x7c, 0xdb, 0xc9, 0x9c, 0xa7, 0xb7, 0x83, 0x4c, 0xe5, 0x31, 0x3c,
+	0x5c, 0xf6, 0x2a, 0xd2, 0x2d, 0x17, 0x65, 0x7b, 0x8f, 0x73, 0x7c, 0x07, 0xa4, 0x21, 0x0a, 0xa0,
+	0xb9, 0x64, 0x37, 0xc4, 0xee, 0x6d, 0xb9, 0xc5, 0x39, 0xdd, 0xf5, 0x40, 0xc3, 0x92, 0xcf, 0x3c,
+	0x0f, 0x57, 0xcd, 0x3c, 0x0f, 0xd7, 0xcc, 0xfc, 0x82, 0x1d, 0x4c, 0x61, 0xaf, 0xf4, 0x7a, 0x92,
+	0x67, 0xa5, 0xa9, 0xab, 0x7c, 0xc1, 0xe9, 0xdd, 0x15, 0x6e, 0x78, 0x3f, 0x40, 0x63, 0x10, 0x47,
+	0x23, 0x36, 0x4e, 0x39, 0x92, 0x7c, 0x44, 0xf4, 0xdf, 0x5a, 0xcf, 0xfc, 0xa6, 0xcd, 0xd6, 0x73,
+	0x8e, 0xc3, 0x75, 0x30, 0x53, 0x7b, 0x04, 0xdb, 0xaf, 0x50, 0x5e, 0x64, 0xcb, 0x67, 0xd1, 0x28,
+	0x26, 0xc7, 0xa5, 0x89, 0x05, 0x4c, 0xce, 0xf1, 0xc3, 0x5d, 0xa0, 0x9a, 0xe7, 0xb7, 0x7b, 0x1f,
+	0x36, 0x7c, 0xef, 0xe2, 0xab, 0xab, 0x5a, 0xf6, 0x43, 0xf9, 0xe2, 0xcb, 0x00, 0x99, 0x96, 0x6f,
+	0x07, 0xa7, 0x0a, 0x00, 0x00,
 }
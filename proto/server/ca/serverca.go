@@ -13,17 +13,29 @@ import (
 // ServerCA is the interface used by all non-catalog components.
 type ServerCA interface {
 	SignCsr(context.Context, *SignCsrRequest) (*SignCsrResponse, error)
+	SignJwtSvid(context.Context, *SignJwtSvidRequest) (*SignJwtSvidResponse, error)
+	GenerateJwtKey(context.Context, *GenerateJwtKeyRequest) (*GenerateJwtKeyResponse, error)
+	ActivateJwtKey(context.Context, *ActivateJwtKeyRequest) (*ActivateJwtKeyResponse, error)
+	FetchJwtKeys(context.Context, *FetchJwtKeysRequest) (*FetchJwtKeysResponse, error)
 	GenerateCsr(context.Context, *GenerateCsrRequest) (*GenerateCsrResponse, error)
 	FetchCertificate(context.Context, *FetchCertificateRequest) (*FetchCertificateResponse, error)
 	LoadCertificate(context.Context, *LoadCertificateRequest) (*LoadCertificateResponse, error)
+	SignCrl(context.Context, *SignCrlRequest) (*SignCrlResponse, error)
+	SignOcspResponderCert(context.Context, *SignOcspResponderCertRequest) (*SignOcspResponderCertResponse, error)
 }
 
 // Plugin is the interface implemented by plugin implementations
 type Plugin interface {
 	SignCsr(context.Context, *SignCsrRequest) (*SignCsrResponse, error)
+	SignJwtSvid(context.Context, *SignJwtSvidRequest) (*SignJwtSvidResponse, error)
+	GenerateJwtKey(context.Context, *GenerateJwtKeyRequest) (*GenerateJwtKeyResponse, error)
+	ActivateJwtKey(context.Context, *ActivateJwtKeyRequest) (*ActivateJwtKeyResponse, error)
+	FetchJwtKeys(context.Context, *FetchJwtKeysRequest) (*FetchJwtKeysResponse, error)
 	GenerateCsr(context.Context, *GenerateCsrRequest) (*GenerateCsrResponse, error)
 	FetchCertificate(context.Context, *FetchCertificateRequest) (*FetchCertificateResponse, error)
 	LoadCertificate(context.Context, *LoadCertificateRequest) (*LoadCertificateResponse, error)
+	SignCrl(context.Context, *SignCrlRequest) (*SignCrlResponse, error)
+	SignOcspResponderCert(context.Context, *SignOcspResponderCertRequest) (*SignOcspResponderCertResponse, error)
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 }
@@ -48,6 +60,38 @@ func (b BuiltIn) SignCsr(ctx context.Context, req *SignCsrRequest) (*SignCsrResp
 	return resp, nil
 }
 
+func (b BuiltIn) SignJwtSvid(ctx context.Context, req *SignJwtSvidRequest) (*SignJwtSvidResponse, error) {
+	resp, err := b.plugin.SignJwtSvid(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) GenerateJwtKey(ctx context.Context, req *GenerateJwtKeyRequest) (*GenerateJwtKeyResponse, error) {
+	resp, err := b.plugin.GenerateJwtKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) ActivateJwtKey(ctx context.Context, req *ActivateJwtKeyRequest) (*ActivateJwtKeyResponse, error) {
+	resp, err := b.plugin.ActivateJwtKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) FetchJwtKeys(ctx context.Context, req *FetchJwtKeysRequest) (*FetchJwtKeysResponse, error) {
+	resp, err := b.plugin.FetchJwtKeys(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) GenerateCsr(ctx context.Context, req *GenerateCsrRequest) (*GenerateCsrResponse, error) {
 	resp, err := b.plugin.GenerateCsr(ctx, req)
 	if err != nil {
@@ -72,6 +116,22 @@ func (b BuiltIn) LoadCertificate(ctx context.Context, req *LoadCertificateReques
 	return resp, nil
 }
 
+func (b BuiltIn) SignCrl(ctx context.Context, req *SignCrlRequest) (*SignCrlResponse, error) {
+	resp, err := b.plugin.SignCrl(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) SignOcspResponderCert(ctx context.Context, req *SignOcspResponderCertRequest) (*SignOcspResponderCertResponse, error) {
+	resp, err := b.plugin.SignOcspResponderCert(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	resp, err := b.plugin.Configure(ctx, req)
 	if err != nil {
@@ -122,6 +182,18 @@ type GRPCServer struct {
 func (s *GRPCServer) SignCsr(ctx context.Context, req *SignCsrRequest) (*SignCsrResponse, error) {
 	return s.Plugin.SignCsr(ctx, req)
 }
+func (s *GRPCServer) SignJwtSvid(ctx context.Context, req *SignJwtSvidRequest) (*SignJwtSvidResponse, error) {
+	return s.Plugin.SignJwtSvid(ctx, req)
+}
+func (s *GRPCServer) GenerateJwtKey(ctx context.Context, req *GenerateJwtKeyRequest) (*GenerateJwtKeyResponse, error) {
+	return s.Plugin.GenerateJwtKey(ctx, req)
+}
+func (s *GRPCServer) ActivateJwtKey(ctx context.Context, req *ActivateJwtKeyRequest) (*ActivateJwtKeyResponse, error) {
+	return s.Plugin.ActivateJwtKey(ctx, req)
+}
+func (s *GRPCServer) FetchJwtKeys(ctx context.Context, req *FetchJwtKeysRequest) (*FetchJwtKeysResponse, error) {
+	return s.Plugin.FetchJwtKeys(ctx, req)
+}
 func (s *GRPCServer) GenerateCsr(ctx context.Context, req *GenerateCsrRequest) (*GenerateCsrResponse, error) {
 	return s.Plugin.GenerateCsr(ctx, req)
 }
@@ -131,6 +203,12 @@ func (s *GRPCServer) FetchCertificate(ctx context.Context, req *FetchCertificate
 func (s *GRPCServer) LoadCertificate(ctx context.Context, req *LoadCertificateRequest) (*LoadCertificateResponse, error) {
 	return s.Plugin.LoadCertificate(ctx, req)
 }
+func (s *GRPCServer) SignCrl(ctx context.Context, req *SignCrlRequest) (*SignCrlResponse, error) {
+	return s.Plugin.SignCrl(ctx, req)
+}
+func (s *GRPCServer) SignOcspResponderCert(ctx context.Context, req *SignOcspResponderCertRequest) (*SignOcspResponderCertResponse, error) {
+	return s.Plugin.SignOcspResponderCert(ctx, req)
+}
 func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return s.Plugin.Configure(ctx, req)
 }
@@ -145,6 +223,18 @@ type GRPCClient struct {
 func (c *GRPCClient) SignCsr(ctx context.Context, req *SignCsrRequest) (*SignCsrResponse, error) {
 	return c.client.SignCsr(ctx, req)
 }
+func (c *GRPCClient) SignJwtSvid(ctx context.Context, req *SignJwtSvidRequest) (*SignJwtSvidResponse, error) {
+	return c.client.SignJwtSvid(ctx, req)
+}
+func (c *GRPCClient) GenerateJwtKey(ctx context.Context, req *GenerateJwtKeyRequest) (*GenerateJwtKeyResponse, error) {
+	return c.client.GenerateJwtKey(ctx, req)
+}
+func (c *GRPCClient) ActivateJwtKey(ctx context.Context, req *ActivateJwtKeyRequest) (*ActivateJwtKeyResponse, error) {
+	return c.client.ActivateJwtKey(ctx, req)
+}
+func (c *GRPCClient) FetchJwtKeys(ctx context.Context, req *FetchJwtKeysRequest) (*FetchJwtKeysResponse, error) {
+	return c.client.FetchJwtKeys(ctx, req)
+}
 func (c *GRPCClient) GenerateCsr(ctx context.Context, req *GenerateCsrRequest) (*GenerateCsrResponse, error) {
 	return c.client.GenerateCsr(ctx, req)
 }
@@ -154,6 +244,12 @@ func (c *GRPCClient) FetchCertificate(ctx context.Context, req *FetchCertificate
 func (c *GRPCClient) LoadCertificate(ctx context.Context, req *LoadCertificateRequest) (*LoadCertificateResponse, error) {
 	return c.client.LoadCertificate(ctx, req)
 }
+func (c *GRPCClient) SignCrl(ctx context.Context, req *SignCrlRequest) (*SignCrlResponse, error) {
+	return c.client.SignCrl(ctx, req)
+}
+func (c *GRPCClient) SignOcspResponderCert(ctx context.Context, req *SignOcspResponderCertRequest) (*SignOcspResponderCertResponse, error) {
+	return c.client.SignOcspResponderCert(ctx, req)
+}
 func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return c.client.Configure(ctx, req)
 }
@@ -0,0 +1,114 @@
+package csrpolicy
+
+import (
+	"context"
+	"net/rpc"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	go_plugin "github.com/hashicorp/go-plugin"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"google.golang.org/grpc"
+)
+
+// CSRPolicy is the interface used by all non-catalog components.
+type CSRPolicy interface {
+	ValidateCSR(context.Context, *ValidateCSRRequest) (*ValidateCSRResponse, error)
+}
+
+// Plugin is the interface implemented by plugin implementations
+type Plugin interface {
+	ValidateCSR(context.Context, *ValidateCSRRequest) (*ValidateCSRResponse, error)
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+type BuiltIn struct {
+	plugin Plugin
+}
+
+var _ CSRPolicy = (*BuiltIn)(nil)
+
+func NewBuiltIn(plugin Plugin) *BuiltIn {
+	return &BuiltIn{
+		plugin: plugin,
+	}
+}
+
+func (b BuiltIn) ValidateCSR(ctx context.Context, req *ValidateCSRRequest) (*ValidateCSRResponse, error) {
+	resp, err := b.plugin.ValidateCSR(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	resp, err := b.plugin.Configure(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	resp, err := b.plugin.GetPluginInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+var Handshake = go_plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "CSRPolicy",
+	MagicCookieValue: "CSRPolicy",
+}
+
+type GRPCPlugin struct {
+	ServerImpl CSRPolicyServer
+}
+
+func (p GRPCPlugin) Server(*go_plugin.MuxBroker) (interface{}, error) {
+	return empty.Empty{}, nil
+}
+
+func (p GRPCPlugin) Client(b *go_plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return empty.Empty{}, nil
+}
+
+func (p GRPCPlugin) GRPCServer(s *grpc.Server) error {
+	RegisterCSRPolicyServer(s, p.ServerImpl)
+	return nil
+}
+
+func (p GRPCPlugin) GRPCClient(c *grpc.ClientConn) (interface{}, error) {
+	return &GRPCClient{client: NewCSRPolicyClient(c)}, nil
+}
+
+type GRPCServer struct {
+	Plugin Plugin
+}
+
+func (s *GRPCServer) ValidateCSR(ctx context.Context, req *ValidateCSRRequest) (*ValidateCSRResponse, error) {
+	return s.Plugin.ValidateCSR(ctx, req)
+}
+func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return s.Plugin.Configure(ctx, req)
+}
+func (s *GRPCServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return s.Plugin.GetPluginInfo(ctx, req)
+}
+
+type GRPCClient struct {
+	client CSRPolicyClient
+}
+
+func (c *GRPCClient) ValidateCSR(ctx context.Context, req *ValidateCSRRequest) (*ValidateCSRResponse, error) {
+	return c.client.ValidateCSR(ctx, req)
+}
+func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return c.client.Configure(ctx, req)
+}
+func (c *GRPCClient) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return c.client.GetPluginInfo(ctx, req)
+}
@@ -0,0 +1,330 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: csrpolicy.proto
+
+package csrpolicy
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/spiffe/spire/proto/common"
+import plugin "github.com/spiffe/spire/proto/common/plugin"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ConfigureRequest from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type ConfigureRequest = plugin.ConfigureRequest
+
+// ConfigureResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type ConfigureResponse = plugin.ConfigureResponse
+
+// GetPluginInfoRequest from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type GetPluginInfoRequest = plugin.GetPluginInfoRequest
+
+// GetPluginInfoResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type GetPluginInfoResponse = plugin.GetPluginInfoResponse
+
+// Empty from public import github.com/spiffe/spire/proto/common/common.proto
+type Empty = common.Empty
+
+// AttestationData from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestationData = common.AttestationData
+
+// Selector from public import github.com/spiffe/spire/proto/common/common.proto
+type Selector = common.Selector
+
+// Selectors from public import github.com/spiffe/spire/proto/common/common.proto
+type Selectors = common.Selectors
+
+// RegistrationEntry from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntry = common.RegistrationEntry
+
+// RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntries = common.RegistrationEntries
+
+// * Represents a request to validate a CSR.
+type ValidateCSRRequest struct {
+	// * Certificate signing request (DER encoded).
+	Csr []byte `protobuf:"bytes,1,opt,name=csr,proto3" json:"csr,omitempty"`
+	// * SPIFFE ID the SVID will be issued for.
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// * TTL in seconds requested for the SVID. Zero means the CA default.
+	Ttl int32 `protobuf:"varint,3,opt,name=ttl" json:"ttl,omitempty"`
+	// * Registration entry the SVID is issued for. Not set for agent SVIDs.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,4,opt,name=entry" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ValidateCSRRequest) Reset()         { *m = ValidateCSRRequest{} }
+func (m *ValidateCSRRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidateCSRRequest) ProtoMessage()    {}
+func (*ValidateCSRRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_csrpolicy_a520ce5e73706721, []int{0}
+}
+func (m *ValidateCSRRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateCSRRequest.Unmarshal(m, b)
+}
+func (m *ValidateCSRRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateCSRRequest.Marshal(b, m, deterministic)
+}
+func (dst *ValidateCSRRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateCSRRequest.Merge(dst, src)
+}
+func (m *ValidateCSRRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidateCSRRequest.Size(m)
+}
+func (m *ValidateCSRRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateCSRRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateCSRRequest proto.InternalMessageInfo
+
+func (m *ValidateCSRRequest) GetCsr() []byte {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
+func (m *ValidateCSRRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *ValidateCSRRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *ValidateCSRRequest) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+// * Represents an empty response. The CSR is accepted unless an error is returned.
+type ValidateCSRResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidateCSRResponse) Reset()         { *m = ValidateCSRResponse{} }
+func (m *ValidateCSRResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidateCSRResponse) ProtoMessage()    {}
+func (*ValidateCSRResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_csrpolicy_a520ce5e73706721, []int{1}
+}
+func (m *ValidateCSRResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidateCSRResponse.Unmarshal(m, b)
+}
+func (m *ValidateCSRResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidateCSRResponse.Marshal(b, m, deterministic)
+}
+func (dst *ValidateCSRResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidateCSRResponse.Merge(dst, src)
+}
+func (m *ValidateCSRResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidateCSRResponse.Size(m)
+}
+func (m *ValidateCSRResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidateCSRResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidateCSRResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*ValidateCSRRequest)(nil), "spire.server.csrpolicy.ValidateCSRRequest")
+	proto.RegisterType((*ValidateCSRResponse)(nil), "spire.server.csrpolicy.ValidateCSRResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for CSRPolicy service
+
+type CSRPolicyClient interface {
+	// * Validates a CSR, returning an error if it must not be signed.
+	ValidateCSR(ctx context.Context, in *ValidateCSRRequest, opts ...grpc.CallOption) (*ValidateCSRResponse, error)
+	// * Responsible for configuration of the plugin.
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	// * Returns the  version and related metadata of the installed plugin.
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type cSRPolicyClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCSRPolicyClient(cc *grpc.ClientConn) CSRPolicyClient {
+	return &cSRPolicyClient{cc}
+}
+
+func (c *cSRPolicyClient) ValidateCSR(ctx context.Context, in *ValidateCSRRequest, opts ...grpc.CallOption) (*ValidateCSRResponse, error) {
+	out := new(ValidateCSRResponse)
+	err := grpc.Invoke(ctx, "/spire.server.csrpolicy.CSRPolicy/ValidateCSR", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cSRPolicyClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := grpc.Invoke(ctx, "/spire.server.csrpolicy.CSRPolicy/Configure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cSRPolicyClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := grpc.Invoke(ctx, "/spire.server.csrpolicy.CSRPolicy/GetPluginInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for CSRPolicy service
+
+type CSRPolicyServer interface {
+	// * Validates a CSR, returning an error if it must not be signed.
+	ValidateCSR(context.Context, *ValidateCSRRequest) (*ValidateCSRResponse, error)
+	// * Responsible for configuration of the plugin.
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	// * Returns the  version and related metadata of the installed plugin.
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+func RegisterCSRPolicyServer(s *grpc.Server, srv CSRPolicyServer) {
+	s.RegisterService(&_CSRPolicy_serviceDesc, srv)
+}
+
+func _CSRPolicy_ValidateCSR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateCSRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CSRPolicyServer).ValidateCSR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.csrpolicy.CSRPolicy/ValidateCSR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CSRPolicyServer).ValidateCSR(ctx, req.(*ValidateCSRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CSRPolicy_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CSRPolicyServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.csrpolicy.CSRPolicy/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CSRPolicyServer).Configure(ctx, req.(*plugin.ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CSRPolicy_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.GetPluginInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CSRPolicyServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.csrpolicy.CSRPolicy/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CSRPolicyServer).GetPluginInfo(ctx, req.(*plugin.GetPluginInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CSRPolicy_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.csrpolicy.CSRPolicy",
+	HandlerType: (*CSRPolicyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ValidateCSR",
+			Handler:    _CSRPolicy_ValidateCSR_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _CSRPolicy_Configure_Handler,
+		},
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _CSRPolicy_GetPluginInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "csrpolicy.proto",
+}
+
+func init() { proto.RegisterFile("csrpolicy.proto", fileDescriptor_csrpolicy_a520ce5e73706721) }
+
+var fileDescriptor_csrpolicy_a520ce5e73706721 = []byte{
+	// 325 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xd1, 0x4a, 0xfb, 0x30,
+	0x14, 0xc6, 0xff, 0xd9, 0xfe, 0x13, 0x9b, 0x29, 0x4a, 0x44, 0x29, 0xf3, 0xc2, 0x32, 0x50, 0xea,
+	0x84, 0x14, 0x27, 0x82, 0xd7, 0x0e, 0x91, 0xdd, 0x95, 0x0c, 0xbc, 0xd8, 0x8d, 0x6c, 0xdd, 0x49,
+	0x0d, 0x74, 0x49, 0x4c, 0x52, 0x61, 0xef, 0xe0, 0xfb, 0xf8, 0x7a, 0xd2, 0x66, 0x1b, 0x0e, 0x07,
+	0xee, 0xea, 0x1c, 0x7a, 0x7e, 0xdf, 0xf9, 0xfa, 0x9d, 0xe0, 0xa3, 0xcc, 0x1a, 0xad, 0x0a, 0x91,
+	0x2d, 0xa8, 0x36, 0xca, 0x29, 0x72, 0x66, 0xb5, 0x30, 0x40, 0x2d, 0x98, 0x0f, 0x30, 0x74, 0x3d,
+	0xed, 0x3c, 0xe4, 0xc2, 0xbd, 0x95, 0x53, 0x9a, 0xa9, 0x79, 0x62, 0xb5, 0xe0, 0x1c, 0x92, 0x9a,
+	0x4c, 0x6a, 0x59, 0x92, 0xa9, 0xf9, 0x5c, 0xc9, 0x44, 0x17, 0x65, 0x2e, 0x56, 0xc5, 0x6f, 0xec,
+	0xdc, 0xee, 0xa4, 0xf4, 0xc5, 0x4b, 0xba, 0x9f, 0x08, 0x93, 0x97, 0x49, 0x21, 0x66, 0x13, 0x07,
+	0x83, 0x11, 0x63, 0xf0, 0x5e, 0x82, 0x75, 0xe4, 0x18, 0x37, 0x33, 0x6b, 0x42, 0x14, 0xa1, 0xf8,
+	0x80, 0x55, 0x2d, 0x39, 0xc7, 0x81, 0x5f, 0xf9, 0x2a, 0x66, 0x61, 0x23, 0x42, 0x71, 0xc0, 0xf6,
+	0xfd, 0x87, 0xe1, 0xac, 0xc2, 0x9d, 0x2b, 0xc2, 0x66, 0x84, 0xe2, 0x16, 0xab, 0x5a, 0x72, 0x8f,
+	0x5b, 0x20, 0x9d, 0x59, 0x84, 0xff, 0x23, 0x14, 0xb7, 0xfb, 0x17, 0xd4, 0x87, 0x5d, 0x7a, 0x33,
+	0xc8, 0x85, 0x75, 0x66, 0xe2, 0x84, 0x92, 0x4f, 0x15, 0xc6, 0x3c, 0xdd, 0x3d, 0xc5, 0x27, 0x1b,
+	0x7f, 0x63, 0xb5, 0x92, 0x16, 0xfa, 0x5f, 0x0d, 0x1c, 0x0c, 0x46, 0x2c, 0xad, 0x0f, 0x44, 0x38,
+	0x6e, 0xff, 0x80, 0x48, 0x8f, 0x6e, 0x3f, 0x24, 0xfd, 0x9d, 0xab, 0x73, 0xb3, 0x13, 0xeb, 0x5d,
+	0xc9, 0x18, 0x07, 0x03, 0x25, 0xb9, 0xc8, 0x4b, 0x03, 0xe4, 0x72, 0x33, 0xc1, 0xf2, 0xee, 0xeb,
+	0xf9, 0xca, 0xe0, 0xea, 0x2f, 0x6c, 0xb9, 0x9b, 0xe3, 0xc3, 0x67, 0x70, 0x69, 0x3d, 0x1e, 0x4a,
+	0xae, 0xc8, 0xf5, 0x56, 0xe1, 0x06, 0xb3, 0xf2, 0xe8, 0xed, 0x82, 0x7a, 0x9f, 0xc7, 0xf6, 0x38,
+	0x58, 0x87, 0x4c, 0xff, 0xa5, 0x68, 0xba, 0x57, 0xbf, 0xfb, 0xdd, 0xf7, 0x00, 0xeb, 0x30, 0x7e,
+	0xb0, 0x8f, 0x02, 0x00, 0x00,
+}
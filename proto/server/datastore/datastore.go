@@ -22,6 +22,8 @@ type DataStore interface {
 	CreateAttestedNodeEntry(context.Context, *CreateAttestedNodeEntryRequest) (*CreateAttestedNodeEntryResponse, error)
 	FetchAttestedNodeEntry(context.Context, *FetchAttestedNodeEntryRequest) (*FetchAttestedNodeEntryResponse, error)
 	FetchStaleNodeEntries(context.Context, *FetchStaleNodeEntriesRequest) (*FetchStaleNodeEntriesResponse, error)
+	ListAttestedNodeEntries(context.Context, *ListAttestedNodeEntriesRequest) (*ListAttestedNodeEntriesResponse, error)
+	CountAttestedNodeEntries(context.Context, *CountAttestedNodeEntriesRequest) (*CountAttestedNodeEntriesResponse, error)
 	UpdateAttestedNodeEntry(context.Context, *UpdateAttestedNodeEntryRequest) (*UpdateAttestedNodeEntryResponse, error)
 	DeleteAttestedNodeEntry(context.Context, *DeleteAttestedNodeEntryRequest) (*DeleteAttestedNodeEntryResponse, error)
 	CreateNodeResolverMapEntry(context.Context, *CreateNodeResolverMapEntryRequest) (*CreateNodeResolverMapEntryResponse, error)
@@ -31,16 +33,24 @@ type DataStore interface {
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	FetchRegistrationEntries(context.Context, *common.Empty) (*FetchRegistrationEntriesResponse, error)
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	ListParentIDEntries(context.Context, *ListParentIDEntriesRequest) (*ListParentIDEntriesResponse, error)
 	ListSelectorEntries(context.Context, *ListSelectorEntriesRequest) (*ListSelectorEntriesResponse, error)
 	ListMatchingEntries(context.Context, *ListSelectorEntriesRequest) (*ListSelectorEntriesResponse, error)
 	ListSpiffeEntries(context.Context, *ListSpiffeEntriesRequest) (*ListSpiffeEntriesResponse, error)
+	ListEntryEvents(context.Context, *ListEntryEventsRequest) (*ListEntryEventsResponse, error)
+	PruneEntryEvents(context.Context, *EntryEvent) (*common.Empty, error)
 	RegisterToken(context.Context, *JoinToken) (*common.Empty, error)
 	FetchToken(context.Context, *JoinToken) (*JoinToken, error)
 	DeleteToken(context.Context, *JoinToken) (*common.Empty, error)
 	PruneTokens(context.Context, *JoinToken) (*common.Empty, error)
+	CreateIssuedSVID(context.Context, *IssuedSVID) (*IssuedSVID, error)
+	FetchIssuedSVID(context.Context, *IssuedSVID) (*IssuedSVID, error)
+	RevokeIssuedSVIDs(context.Context, *IssuedSVID) (*IssuedSVIDs, error)
+	ListRevokedSVIDs(context.Context, *common.Empty) (*IssuedSVIDs, error)
+	PruneIssuedSVIDs(context.Context, *IssuedSVID) (*common.Empty, error)
 }
 
 // Plugin is the interface implemented by plugin implementations
@@ -54,6 +64,8 @@ type Plugin interface {
 	CreateAttestedNodeEntry(context.Context, *CreateAttestedNodeEntryRequest) (*CreateAttestedNodeEntryResponse, error)
 	FetchAttestedNodeEntry(context.Context, *FetchAttestedNodeEntryRequest) (*FetchAttestedNodeEntryResponse, error)
 	FetchStaleNodeEntries(context.Context, *FetchStaleNodeEntriesRequest) (*FetchStaleNodeEntriesResponse, error)
+	ListAttestedNodeEntries(context.Context, *ListAttestedNodeEntriesRequest) (*ListAttestedNodeEntriesResponse, error)
+	CountAttestedNodeEntries(context.Context, *CountAttestedNodeEntriesRequest) (*CountAttestedNodeEntriesResponse, error)
 	UpdateAttestedNodeEntry(context.Context, *UpdateAttestedNodeEntryRequest) (*UpdateAttestedNodeEntryResponse, error)
 	DeleteAttestedNodeEntry(context.Context, *DeleteAttestedNodeEntryRequest) (*DeleteAttestedNodeEntryResponse, error)
 	CreateNodeResolverMapEntry(context.Context, *CreateNodeResolverMapEntryRequest) (*CreateNodeResolverMapEntryResponse, error)
@@ -63,16 +75,24 @@ type Plugin interface {
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	FetchRegistrationEntries(context.Context, *common.Empty) (*FetchRegistrationEntriesResponse, error)
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	ListParentIDEntries(context.Context, *ListParentIDEntriesRequest) (*ListParentIDEntriesResponse, error)
 	ListSelectorEntries(context.Context, *ListSelectorEntriesRequest) (*ListSelectorEntriesResponse, error)
 	ListMatchingEntries(context.Context, *ListSelectorEntriesRequest) (*ListSelectorEntriesResponse, error)
 	ListSpiffeEntries(context.Context, *ListSpiffeEntriesRequest) (*ListSpiffeEntriesResponse, error)
+	ListEntryEvents(context.Context, *ListEntryEventsRequest) (*ListEntryEventsResponse, error)
+	PruneEntryEvents(context.Context, *EntryEvent) (*common.Empty, error)
 	RegisterToken(context.Context, *JoinToken) (*common.Empty, error)
 	FetchToken(context.Context, *JoinToken) (*JoinToken, error)
 	DeleteToken(context.Context, *JoinToken) (*common.Empty, error)
 	PruneTokens(context.Context, *JoinToken) (*common.Empty, error)
+	CreateIssuedSVID(context.Context, *IssuedSVID) (*IssuedSVID, error)
+	FetchIssuedSVID(context.Context, *IssuedSVID) (*IssuedSVID, error)
+	RevokeIssuedSVIDs(context.Context, *IssuedSVID) (*IssuedSVIDs, error)
+	ListRevokedSVIDs(context.Context, *common.Empty) (*IssuedSVIDs, error)
+	PruneIssuedSVIDs(context.Context, *IssuedSVID) (*common.Empty, error)
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 }
@@ -161,6 +181,22 @@ func (b BuiltIn) FetchStaleNodeEntries(ctx context.Context, req *FetchStaleNodeE
 	return resp, nil
 }
 
+func (b BuiltIn) ListAttestedNodeEntries(ctx context.Context, req *ListAttestedNodeEntriesRequest) (*ListAttestedNodeEntriesResponse, error) {
+	resp, err := b.plugin.ListAttestedNodeEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) CountAttestedNodeEntries(ctx context.Context, req *CountAttestedNodeEntriesRequest) (*CountAttestedNodeEntriesResponse, error) {
+	resp, err := b.plugin.CountAttestedNodeEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) UpdateAttestedNodeEntry(ctx context.Context, req *UpdateAttestedNodeEntryRequest) (*UpdateAttestedNodeEntryResponse, error) {
 	resp, err := b.plugin.UpdateAttestedNodeEntry(ctx, req)
 	if err != nil {
@@ -233,6 +269,14 @@ func (b BuiltIn) FetchRegistrationEntries(ctx context.Context, req *common.Empty
 	return resp, nil
 }
 
+func (b BuiltIn) CountRegistrationEntries(ctx context.Context, req *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error) {
+	resp, err := b.plugin.CountRegistrationEntries(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) UpdateRegistrationEntry(ctx context.Context, req *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	resp, err := b.plugin.UpdateRegistrationEntry(ctx, req)
 	if err != nil {
@@ -281,6 +325,22 @@ func (b BuiltIn) ListSpiffeEntries(ctx context.Context, req *ListSpiffeEntriesRe
 	return resp, nil
 }
 
+func (b BuiltIn) ListEntryEvents(ctx context.Context, req *ListEntryEventsRequest) (*ListEntryEventsResponse, error) {
+	resp, err := b.plugin.ListEntryEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) PruneEntryEvents(ctx context.Context, req *EntryEvent) (*common.Empty, error) {
+	resp, err := b.plugin.PruneEntryEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) RegisterToken(ctx context.Context, req *JoinToken) (*common.Empty, error) {
 	resp, err := b.plugin.RegisterToken(ctx, req)
 	if err != nil {
@@ -313,6 +373,46 @@ func (b BuiltIn) PruneTokens(ctx context.Context, req *JoinToken) (*common.Empty
 	return resp, nil
 }
 
+func (b BuiltIn) CreateIssuedSVID(ctx context.Context, req *IssuedSVID) (*IssuedSVID, error) {
+	resp, err := b.plugin.CreateIssuedSVID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) FetchIssuedSVID(ctx context.Context, req *IssuedSVID) (*IssuedSVID, error) {
+	resp, err := b.plugin.FetchIssuedSVID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) RevokeIssuedSVIDs(ctx context.Context, req *IssuedSVID) (*IssuedSVIDs, error) {
+	resp, err := b.plugin.RevokeIssuedSVIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) ListRevokedSVIDs(ctx context.Context, req *common.Empty) (*IssuedSVIDs, error) {
+	resp, err := b.plugin.ListRevokedSVIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) PruneIssuedSVIDs(ctx context.Context, req *IssuedSVID) (*common.Empty, error) {
+	resp, err := b.plugin.PruneIssuedSVIDs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	resp, err := b.plugin.Configure(ctx, req)
 	if err != nil {
@@ -387,6 +487,12 @@ func (s *GRPCServer) FetchAttestedNodeEntry(ctx context.Context, req *FetchAttes
 func (s *GRPCServer) FetchStaleNodeEntries(ctx context.Context, req *FetchStaleNodeEntriesRequest) (*FetchStaleNodeEntriesResponse, error) {
 	return s.Plugin.FetchStaleNodeEntries(ctx, req)
 }
+func (s *GRPCServer) ListAttestedNodeEntries(ctx context.Context, req *ListAttestedNodeEntriesRequest) (*ListAttestedNodeEntriesResponse, error) {
+	return s.Plugin.ListAttestedNodeEntries(ctx, req)
+}
+func (s *GRPCServer) CountAttestedNodeEntries(ctx context.Context, req *CountAttestedNodeEntriesRequest) (*CountAttestedNodeEntriesResponse, error) {
+	return s.Plugin.CountAttestedNodeEntries(ctx, req)
+}
 func (s *GRPCServer) UpdateAttestedNodeEntry(ctx context.Context, req *UpdateAttestedNodeEntryRequest) (*UpdateAttestedNodeEntryResponse, error) {
 	return s.Plugin.UpdateAttestedNodeEntry(ctx, req)
 }
@@ -414,6 +520,9 @@ func (s *GRPCServer) FetchRegistrationEntry(ctx context.Context, req *FetchRegis
 func (s *GRPCServer) FetchRegistrationEntries(ctx context.Context, req *common.Empty) (*FetchRegistrationEntriesResponse, error) {
 	return s.Plugin.FetchRegistrationEntries(ctx, req)
 }
+func (s *GRPCServer) CountRegistrationEntries(ctx context.Context, req *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error) {
+	return s.Plugin.CountRegistrationEntries(ctx, req)
+}
 func (s *GRPCServer) UpdateRegistrationEntry(ctx context.Context, req *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return s.Plugin.UpdateRegistrationEntry(ctx, req)
 }
@@ -432,6 +541,12 @@ func (s *GRPCServer) ListMatchingEntries(ctx context.Context, req *ListSelectorE
 func (s *GRPCServer) ListSpiffeEntries(ctx context.Context, req *ListSpiffeEntriesRequest) (*ListSpiffeEntriesResponse, error) {
 	return s.Plugin.ListSpiffeEntries(ctx, req)
 }
+func (s *GRPCServer) ListEntryEvents(ctx context.Context, req *ListEntryEventsRequest) (*ListEntryEventsResponse, error) {
+	return s.Plugin.ListEntryEvents(ctx, req)
+}
+func (s *GRPCServer) PruneEntryEvents(ctx context.Context, req *EntryEvent) (*common.Empty, error) {
+	return s.Plugin.PruneEntryEvents(ctx, req)
+}
 func (s *GRPCServer) RegisterToken(ctx context.Context, req *JoinToken) (*common.Empty, error) {
 	return s.Plugin.RegisterToken(ctx, req)
 }
@@ -444,6 +559,21 @@ func (s *GRPCServer) DeleteToken(ctx context.Context, req *JoinToken) (*common.E
 func (s *GRPCServer) PruneTokens(ctx context.Context, req *JoinToken) (*common.Empty, error) {
 	return s.Plugin.PruneTokens(ctx, req)
 }
+func (s *GRPCServer) CreateIssuedSVID(ctx context.Context, req *IssuedSVID) (*IssuedSVID, error) {
+	return s.Plugin.CreateIssuedSVID(ctx, req)
+}
+func (s *GRPCServer) FetchIssuedSVID(ctx context.Context, req *IssuedSVID) (*IssuedSVID, error) {
+	return s.Plugin.FetchIssuedSVID(ctx, req)
+}
+func (s *GRPCServer) RevokeIssuedSVIDs(ctx context.Context, req *IssuedSVID) (*IssuedSVIDs, error) {
+	return s.Plugin.RevokeIssuedSVIDs(ctx, req)
+}
+func (s *GRPCServer) ListRevokedSVIDs(ctx context.Context, req *common.Empty) (*IssuedSVIDs, error) {
+	return s.Plugin.ListRevokedSVIDs(ctx, req)
+}
+func (s *GRPCServer) PruneIssuedSVIDs(ctx context.Context, req *IssuedSVID) (*common.Empty, error) {
+	return s.Plugin.PruneIssuedSVIDs(ctx, req)
+}
 func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return s.Plugin.Configure(ctx, req)
 }
@@ -482,6 +612,12 @@ func (c *GRPCClient) FetchAttestedNodeEntry(ctx context.Context, req *FetchAttes
 func (c *GRPCClient) FetchStaleNodeEntries(ctx context.Context, req *FetchStaleNodeEntriesRequest) (*FetchStaleNodeEntriesResponse, error) {
 	return c.client.FetchStaleNodeEntries(ctx, req)
 }
+func (c *GRPCClient) ListAttestedNodeEntries(ctx context.Context, req *ListAttestedNodeEntriesRequest) (*ListAttestedNodeEntriesResponse, error) {
+	return c.client.ListAttestedNodeEntries(ctx, req)
+}
+func (c *GRPCClient) CountAttestedNodeEntries(ctx context.Context, req *CountAttestedNodeEntriesRequest) (*CountAttestedNodeEntriesResponse, error) {
+	return c.client.CountAttestedNodeEntries(ctx, req)
+}
 func (c *GRPCClient) UpdateAttestedNodeEntry(ctx context.Context, req *UpdateAttestedNodeEntryRequest) (*UpdateAttestedNodeEntryResponse, error) {
 	return c.client.UpdateAttestedNodeEntry(ctx, req)
 }
@@ -509,6 +645,9 @@ func (c *GRPCClient) FetchRegistrationEntry(ctx context.Context, req *FetchRegis
 func (c *GRPCClient) FetchRegistrationEntries(ctx context.Context, req *common.Empty) (*FetchRegistrationEntriesResponse, error) {
 	return c.client.FetchRegistrationEntries(ctx, req)
 }
+func (c *GRPCClient) CountRegistrationEntries(ctx context.Context, req *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error) {
+	return c.client.CountRegistrationEntries(ctx, req)
+}
 func (c *GRPCClient) UpdateRegistrationEntry(ctx context.Context, req *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error) {
 	return c.client.UpdateRegistrationEntry(ctx, req)
 }
@@ -527,6 +666,12 @@ func (c *GRPCClient) ListMatchingEntries(ctx context.Context, req *ListSelectorE
 func (c *GRPCClient) ListSpiffeEntries(ctx context.Context, req *ListSpiffeEntriesRequest) (*ListSpiffeEntriesResponse, error) {
 	return c.client.ListSpiffeEntries(ctx, req)
 }
+func (c *GRPCClient) ListEntryEvents(ctx context.Context, req *ListEntryEventsRequest) (*ListEntryEventsResponse, error) {
+	return c.client.ListEntryEvents(ctx, req)
+}
+func (c *GRPCClient) PruneEntryEvents(ctx context.Context, req *EntryEvent) (*common.Empty, error) {
+	return c.client.PruneEntryEvents(ctx, req)
+}
 func (c *GRPCClient) RegisterToken(ctx context.Context, req *JoinToken) (*common.Empty, error) {
 	return c.client.RegisterToken(ctx, req)
 }
@@ -539,6 +684,21 @@ func (c *GRPCClient) DeleteToken(ctx context.Context, req *JoinToken) (*common.E
 func (c *GRPCClient) PruneTokens(ctx context.Context, req *JoinToken) (*common.Empty, error) {
 	return c.client.PruneTokens(ctx, req)
 }
+func (c *GRPCClient) CreateIssuedSVID(ctx context.Context, req *IssuedSVID) (*IssuedSVID, error) {
+	return c.client.CreateIssuedSVID(ctx, req)
+}
+func (c *GRPCClient) FetchIssuedSVID(ctx context.Context, req *IssuedSVID) (*IssuedSVID, error) {
+	return c.client.FetchIssuedSVID(ctx, req)
+}
+func (c *GRPCClient) RevokeIssuedSVIDs(ctx context.Context, req *IssuedSVID) (*IssuedSVIDs, error) {
+	return c.client.RevokeIssuedSVIDs(ctx, req)
+}
+func (c *GRPCClient) ListRevokedSVIDs(ctx context.Context, req *common.Empty) (*IssuedSVIDs, error) {
+	return c.client.ListRevokedSVIDs(ctx, req)
+}
+func (c *GRPCClient) PruneIssuedSVIDs(ctx context.Context, req *IssuedSVID) (*common.Empty, error) {
+	return c.client.PruneIssuedSVIDs(ctx, req)
+}
 func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	return c.client.Configure(ctx, req)
 }
@@ -61,7 +61,20 @@ type Bundle struct {
 	TrustDomain string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain" json:"trust_domain,omitempty"`
 	// CA Certificates
 	// ASN.1 DER encoded
-	CaCerts              []byte   `protobuf:"bytes,2,opt,name=ca_certs,json=caCerts,proto3" json:"ca_certs,omitempty"`
+	CaCerts []byte `protobuf:"bytes,2,opt,name=ca_certs,json=caCerts,proto3" json:"ca_certs,omitempty"`
+	// JWT signing keys
+	JwtSigningKeys []*common.PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys" json:"jwt_signing_keys,omitempty"`
+	// Sequence number of the bundle, increased by the datastore every time
+	// the bundle changes
+	SequenceNumber uint64 `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+	// How often, in seconds, the bundle should be refreshed. Zero if not
+	// advised
+	RefreshHint int64 `protobuf:"varint,5,opt,name=refresh_hint,json=refreshHint" json:"refresh_hint,omitempty"`
+	// CA certificates which are tainted, e.g. because their key may be
+	// compromised. The SVIDs they signed are rotated. They are not in
+	// ca_certs if the CA is an intermediate of the upstream CA.
+	// ASN.1 DER encoded
+	TaintedCaCerts       []byte   `protobuf:"bytes,6,opt,name=tainted_ca_certs,json=taintedCaCerts,proto3" json:"tainted_ca_certs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -71,7 +84,7 @@ func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{0}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{0}
 }
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bundle.Unmarshal(m, b)
@@ -105,6 +118,34 @@ func (m *Bundle) GetCaCerts() []byte {
 	return nil
 }
 
+func (m *Bundle) GetJwtSigningKeys() []*common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKeys
+	}
+	return nil
+}
+
+func (m *Bundle) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+func (m *Bundle) GetRefreshHint() int64 {
+	if m != nil {
+		return m.RefreshHint
+	}
+	return 0
+}
+
+func (m *Bundle) GetTaintedCaCerts() []byte {
+	if m != nil {
+		return m.TaintedCaCerts
+	}
+	return nil
+}
+
 type Bundles struct {
 	Bundles              []*Bundle `protobuf:"bytes,1,rep,name=bundles" json:"bundles,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -116,7 +157,7 @@ func (m *Bundles) Reset()         { *m = Bundles{} }
 func (m *Bundles) String() string { return proto.CompactTextString(m) }
 func (*Bundles) ProtoMessage()    {}
 func (*Bundles) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{1}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{1}
 }
 func (m *Bundles) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bundles.Unmarshal(m, b)
@@ -157,7 +198,7 @@ func (m *NodeResolverMapEntry) Reset()         { *m = NodeResolverMapEntry{} }
 func (m *NodeResolverMapEntry) String() string { return proto.CompactTextString(m) }
 func (*NodeResolverMapEntry) ProtoMessage()    {}
 func (*NodeResolverMapEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{2}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{2}
 }
 func (m *NodeResolverMapEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeResolverMapEntry.Unmarshal(m, b)
@@ -202,7 +243,10 @@ type AttestedNodeEntry struct {
 	// Serial number
 	CertSerialNumber string `protobuf:"bytes,3,opt,name=certSerialNumber" json:"certSerialNumber,omitempty"`
 	// Expiration date
-	CertExpirationDate   string   `protobuf:"bytes,4,opt,name=certExpirationDate" json:"certExpirationDate,omitempty"`
+	CertExpirationDate string `protobuf:"bytes,4,opt,name=certExpirationDate" json:"certExpirationDate,omitempty"`
+	// If true, the node is banned: it can neither renew its SVID nor attest
+	// again until its entry is deleted
+	Banned               bool     `protobuf:"varint,5,opt,name=banned" json:"banned,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -212,7 +256,7 @@ func (m *AttestedNodeEntry) Reset()         { *m = AttestedNodeEntry{} }
 func (m *AttestedNodeEntry) String() string { return proto.CompactTextString(m) }
 func (*AttestedNodeEntry) ProtoMessage()    {}
 func (*AttestedNodeEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{3}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{3}
 }
 func (m *AttestedNodeEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AttestedNodeEntry.Unmarshal(m, b)
@@ -260,6 +304,71 @@ func (m *AttestedNodeEntry) GetCertExpirationDate() string {
 	return ""
 }
 
+func (m *AttestedNodeEntry) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+// Selects the fields of an Attested Node entry to update
+type AttestedNodeEntryMask struct {
+	// Update the serial number
+	CertSerialNumber bool `protobuf:"varint,1,opt,name=certSerialNumber" json:"certSerialNumber,omitempty"`
+	// Update the expiration date
+	CertExpirationDate bool `protobuf:"varint,2,opt,name=certExpirationDate" json:"certExpirationDate,omitempty"`
+	// Update whether the node is banned
+	Banned               bool     `protobuf:"varint,3,opt,name=banned" json:"banned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttestedNodeEntryMask) Reset()         { *m = AttestedNodeEntryMask{} }
+func (m *AttestedNodeEntryMask) String() string { return proto.CompactTextString(m) }
+func (*AttestedNodeEntryMask) ProtoMessage()    {}
+func (*AttestedNodeEntryMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{4}
+}
+func (m *AttestedNodeEntryMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttestedNodeEntryMask.Unmarshal(m, b)
+}
+func (m *AttestedNodeEntryMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttestedNodeEntryMask.Marshal(b, m, deterministic)
+}
+func (dst *AttestedNodeEntryMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttestedNodeEntryMask.Merge(dst, src)
+}
+func (m *AttestedNodeEntryMask) XXX_Size() int {
+	return xxx_messageInfo_AttestedNodeEntryMask.Size(m)
+}
+func (m *AttestedNodeEntryMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttestedNodeEntryMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttestedNodeEntryMask proto.InternalMessageInfo
+
+func (m *AttestedNodeEntryMask) GetCertSerialNumber() bool {
+	if m != nil {
+		return m.CertSerialNumber
+	}
+	return false
+}
+
+func (m *AttestedNodeEntryMask) GetCertExpirationDate() bool {
+	if m != nil {
+		return m.CertExpirationDate
+	}
+	return false
+}
+
+func (m *AttestedNodeEntryMask) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
 // Represents an Attested Node entry to create
 type CreateAttestedNodeEntryRequest struct {
 	// Attested node entry
@@ -273,7 +382,7 @@ func (m *CreateAttestedNodeEntryRequest) Reset()         { *m = CreateAttestedNo
 func (m *CreateAttestedNodeEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeEntryRequest) ProtoMessage()    {}
 func (*CreateAttestedNodeEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{4}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{5}
 }
 func (m *CreateAttestedNodeEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateAttestedNodeEntryRequest.Unmarshal(m, b)
@@ -313,7 +422,7 @@ func (m *CreateAttestedNodeEntryResponse) Reset()         { *m = CreateAttestedN
 func (m *CreateAttestedNodeEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeEntryResponse) ProtoMessage()    {}
 func (*CreateAttestedNodeEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{5}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{6}
 }
 func (m *CreateAttestedNodeEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateAttestedNodeEntryResponse.Unmarshal(m, b)
@@ -353,7 +462,7 @@ func (m *FetchAttestedNodeEntryRequest) Reset()         { *m = FetchAttestedNode
 func (m *FetchAttestedNodeEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeEntryRequest) ProtoMessage()    {}
 func (*FetchAttestedNodeEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{6}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{7}
 }
 func (m *FetchAttestedNodeEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchAttestedNodeEntryRequest.Unmarshal(m, b)
@@ -393,7 +502,7 @@ func (m *FetchAttestedNodeEntryResponse) Reset()         { *m = FetchAttestedNod
 func (m *FetchAttestedNodeEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeEntryResponse) ProtoMessage()    {}
 func (*FetchAttestedNodeEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{7}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{8}
 }
 func (m *FetchAttestedNodeEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchAttestedNodeEntryResponse.Unmarshal(m, b)
@@ -431,7 +540,7 @@ func (m *FetchStaleNodeEntriesRequest) Reset()         { *m = FetchStaleNodeEntr
 func (m *FetchStaleNodeEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchStaleNodeEntriesRequest) ProtoMessage()    {}
 func (*FetchStaleNodeEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{8}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{9}
 }
 func (m *FetchStaleNodeEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchStaleNodeEntriesRequest.Unmarshal(m, b)
@@ -464,7 +573,7 @@ func (m *FetchStaleNodeEntriesResponse) Reset()         { *m = FetchStaleNodeEnt
 func (m *FetchStaleNodeEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchStaleNodeEntriesResponse) ProtoMessage()    {}
 func (*FetchStaleNodeEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{9}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{10}
 }
 func (m *FetchStaleNodeEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchStaleNodeEntriesResponse.Unmarshal(m, b)
@@ -491,6 +600,146 @@ func (m *FetchStaleNodeEntriesResponse) GetAttestedNodeEntryList() []*AttestedNo
 	return nil
 }
 
+// Empty Request
+type ListAttestedNodeEntriesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAttestedNodeEntriesRequest) Reset()         { *m = ListAttestedNodeEntriesRequest{} }
+func (m *ListAttestedNodeEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAttestedNodeEntriesRequest) ProtoMessage()    {}
+func (*ListAttestedNodeEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{11}
+}
+func (m *ListAttestedNodeEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttestedNodeEntriesRequest.Unmarshal(m, b)
+}
+func (m *ListAttestedNodeEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttestedNodeEntriesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListAttestedNodeEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttestedNodeEntriesRequest.Merge(dst, src)
+}
+func (m *ListAttestedNodeEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAttestedNodeEntriesRequest.Size(m)
+}
+func (m *ListAttestedNodeEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttestedNodeEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttestedNodeEntriesRequest proto.InternalMessageInfo
+
+// Represents all the attested nodes
+type ListAttestedNodeEntriesResponse struct {
+	// List of attested node entries
+	AttestedNodeEntryList []*AttestedNodeEntry `protobuf:"bytes,1,rep,name=attestedNodeEntryList" json:"attestedNodeEntryList,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}             `json:"-"`
+	XXX_unrecognized      []byte               `json:"-"`
+	XXX_sizecache         int32                `json:"-"`
+}
+
+func (m *ListAttestedNodeEntriesResponse) Reset()         { *m = ListAttestedNodeEntriesResponse{} }
+func (m *ListAttestedNodeEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAttestedNodeEntriesResponse) ProtoMessage()    {}
+func (*ListAttestedNodeEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{12}
+}
+func (m *ListAttestedNodeEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAttestedNodeEntriesResponse.Unmarshal(m, b)
+}
+func (m *ListAttestedNodeEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAttestedNodeEntriesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListAttestedNodeEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAttestedNodeEntriesResponse.Merge(dst, src)
+}
+func (m *ListAttestedNodeEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAttestedNodeEntriesResponse.Size(m)
+}
+func (m *ListAttestedNodeEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAttestedNodeEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAttestedNodeEntriesResponse proto.InternalMessageInfo
+
+func (m *ListAttestedNodeEntriesResponse) GetAttestedNodeEntryList() []*AttestedNodeEntry {
+	if m != nil {
+		return m.AttestedNodeEntryList
+	}
+	return nil
+}
+
+type CountAttestedNodeEntriesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountAttestedNodeEntriesRequest) Reset()         { *m = CountAttestedNodeEntriesRequest{} }
+func (m *CountAttestedNodeEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*CountAttestedNodeEntriesRequest) ProtoMessage()    {}
+func (*CountAttestedNodeEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{13}
+}
+func (m *CountAttestedNodeEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAttestedNodeEntriesRequest.Unmarshal(m, b)
+}
+func (m *CountAttestedNodeEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAttestedNodeEntriesRequest.Marshal(b, m, deterministic)
+}
+func (dst *CountAttestedNodeEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAttestedNodeEntriesRequest.Merge(dst, src)
+}
+func (m *CountAttestedNodeEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_CountAttestedNodeEntriesRequest.Size(m)
+}
+func (m *CountAttestedNodeEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAttestedNodeEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAttestedNodeEntriesRequest proto.InternalMessageInfo
+
+type CountAttestedNodeEntriesResponse struct {
+	// Number of attested node entries
+	Count                int32    `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountAttestedNodeEntriesResponse) Reset()         { *m = CountAttestedNodeEntriesResponse{} }
+func (m *CountAttestedNodeEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountAttestedNodeEntriesResponse) ProtoMessage()    {}
+func (*CountAttestedNodeEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{14}
+}
+func (m *CountAttestedNodeEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountAttestedNodeEntriesResponse.Unmarshal(m, b)
+}
+func (m *CountAttestedNodeEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountAttestedNodeEntriesResponse.Marshal(b, m, deterministic)
+}
+func (dst *CountAttestedNodeEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountAttestedNodeEntriesResponse.Merge(dst, src)
+}
+func (m *CountAttestedNodeEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountAttestedNodeEntriesResponse.Size(m)
+}
+func (m *CountAttestedNodeEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountAttestedNodeEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountAttestedNodeEntriesResponse proto.InternalMessageInfo
+
+func (m *CountAttestedNodeEntriesResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 // Represents Attested node entry fields to update
 type UpdateAttestedNodeEntryRequest struct {
 	// SPIFFE ID
@@ -498,17 +747,21 @@ type UpdateAttestedNodeEntryRequest struct {
 	// Serial number
 	CertSerialNumber string `protobuf:"bytes,2,opt,name=certSerialNumber" json:"certSerialNumber,omitempty"`
 	// Expiration date
-	CertExpirationDate   string   `protobuf:"bytes,3,opt,name=certExpirationDate" json:"certExpirationDate,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CertExpirationDate string `protobuf:"bytes,3,opt,name=certExpirationDate" json:"certExpirationDate,omitempty"`
+	// Whether the node is banned
+	Banned bool `protobuf:"varint,4,opt,name=banned" json:"banned,omitempty"`
+	// Fields to update. All of them are updated if not set.
+	InputMask            *AttestedNodeEntryMask `protobuf:"bytes,5,opt,name=inputMask" json:"inputMask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *UpdateAttestedNodeEntryRequest) Reset()         { *m = UpdateAttestedNodeEntryRequest{} }
 func (m *UpdateAttestedNodeEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeEntryRequest) ProtoMessage()    {}
 func (*UpdateAttestedNodeEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{10}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{15}
 }
 func (m *UpdateAttestedNodeEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateAttestedNodeEntryRequest.Unmarshal(m, b)
@@ -549,6 +802,20 @@ func (m *UpdateAttestedNodeEntryRequest) GetCertExpirationDate() string {
 	return ""
 }
 
+func (m *UpdateAttestedNodeEntryRequest) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+func (m *UpdateAttestedNodeEntryRequest) GetInputMask() *AttestedNodeEntryMask {
+	if m != nil {
+		return m.InputMask
+	}
+	return nil
+}
+
 // Represents the updated Attested node entry
 type UpdateAttestedNodeEntryResponse struct {
 	// Attested node entry
@@ -562,7 +829,7 @@ func (m *UpdateAttestedNodeEntryResponse) Reset()         { *m = UpdateAttestedN
 func (m *UpdateAttestedNodeEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeEntryResponse) ProtoMessage()    {}
 func (*UpdateAttestedNodeEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{11}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{16}
 }
 func (m *UpdateAttestedNodeEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateAttestedNodeEntryResponse.Unmarshal(m, b)
@@ -602,7 +869,7 @@ func (m *DeleteAttestedNodeEntryRequest) Reset()         { *m = DeleteAttestedNo
 func (m *DeleteAttestedNodeEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeEntryRequest) ProtoMessage()    {}
 func (*DeleteAttestedNodeEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{12}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{17}
 }
 func (m *DeleteAttestedNodeEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteAttestedNodeEntryRequest.Unmarshal(m, b)
@@ -641,7 +908,7 @@ func (m *DeleteAttestedNodeEntryResponse) Reset()         { *m = DeleteAttestedN
 func (m *DeleteAttestedNodeEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeEntryResponse) ProtoMessage()    {}
 func (*DeleteAttestedNodeEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{13}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{18}
 }
 func (m *DeleteAttestedNodeEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteAttestedNodeEntryResponse.Unmarshal(m, b)
@@ -681,7 +948,7 @@ func (m *CreateNodeResolverMapEntryRequest) Reset()         { *m = CreateNodeRes
 func (m *CreateNodeResolverMapEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateNodeResolverMapEntryRequest) ProtoMessage()    {}
 func (*CreateNodeResolverMapEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{14}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{19}
 }
 func (m *CreateNodeResolverMapEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNodeResolverMapEntryRequest.Unmarshal(m, b)
@@ -721,7 +988,7 @@ func (m *CreateNodeResolverMapEntryResponse) Reset()         { *m = CreateNodeRe
 func (m *CreateNodeResolverMapEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateNodeResolverMapEntryResponse) ProtoMessage()    {}
 func (*CreateNodeResolverMapEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{15}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{20}
 }
 func (m *CreateNodeResolverMapEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateNodeResolverMapEntryResponse.Unmarshal(m, b)
@@ -761,7 +1028,7 @@ func (m *FetchNodeResolverMapEntryRequest) Reset()         { *m = FetchNodeResol
 func (m *FetchNodeResolverMapEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchNodeResolverMapEntryRequest) ProtoMessage()    {}
 func (*FetchNodeResolverMapEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{16}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{21}
 }
 func (m *FetchNodeResolverMapEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchNodeResolverMapEntryRequest.Unmarshal(m, b)
@@ -801,7 +1068,7 @@ func (m *FetchNodeResolverMapEntryResponse) Reset()         { *m = FetchNodeReso
 func (m *FetchNodeResolverMapEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchNodeResolverMapEntryResponse) ProtoMessage()    {}
 func (*FetchNodeResolverMapEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{17}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{22}
 }
 func (m *FetchNodeResolverMapEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchNodeResolverMapEntryResponse.Unmarshal(m, b)
@@ -841,7 +1108,7 @@ func (m *DeleteNodeResolverMapEntryRequest) Reset()         { *m = DeleteNodeRes
 func (m *DeleteNodeResolverMapEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteNodeResolverMapEntryRequest) ProtoMessage()    {}
 func (*DeleteNodeResolverMapEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{18}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{23}
 }
 func (m *DeleteNodeResolverMapEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteNodeResolverMapEntryRequest.Unmarshal(m, b)
@@ -881,7 +1148,7 @@ func (m *DeleteNodeResolverMapEntryResponse) Reset()         { *m = DeleteNodeRe
 func (m *DeleteNodeResolverMapEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteNodeResolverMapEntryResponse) ProtoMessage()    {}
 func (*DeleteNodeResolverMapEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{19}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{24}
 }
 func (m *DeleteNodeResolverMapEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteNodeResolverMapEntryResponse.Unmarshal(m, b)
@@ -921,7 +1188,7 @@ func (m *RectifyNodeResolverMapEntriesRequest) Reset()         { *m = RectifyNod
 func (m *RectifyNodeResolverMapEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*RectifyNodeResolverMapEntriesRequest) ProtoMessage()    {}
 func (*RectifyNodeResolverMapEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{20}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{25}
 }
 func (m *RectifyNodeResolverMapEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RectifyNodeResolverMapEntriesRequest.Unmarshal(m, b)
@@ -961,7 +1228,7 @@ func (m *RectifyNodeResolverMapEntriesResponse) Reset()         { *m = RectifyNo
 func (m *RectifyNodeResolverMapEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*RectifyNodeResolverMapEntriesResponse) ProtoMessage()    {}
 func (*RectifyNodeResolverMapEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{21}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{26}
 }
 func (m *RectifyNodeResolverMapEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RectifyNodeResolverMapEntriesResponse.Unmarshal(m, b)
@@ -1001,7 +1268,7 @@ func (m *CreateRegistrationEntryRequest) Reset()         { *m = CreateRegistrati
 func (m *CreateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryRequest) ProtoMessage()    {}
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{22}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{27}
 }
 func (m *CreateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRegistrationEntryRequest.Unmarshal(m, b)
@@ -1041,7 +1308,7 @@ func (m *CreateRegistrationEntryResponse) Reset()         { *m = CreateRegistrat
 func (m *CreateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryResponse) ProtoMessage()    {}
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{23}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{28}
 }
 func (m *CreateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRegistrationEntryResponse.Unmarshal(m, b)
@@ -1081,7 +1348,7 @@ func (m *FetchRegistrationEntryRequest) Reset()         { *m = FetchRegistration
 func (m *FetchRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryRequest) ProtoMessage()    {}
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{24}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{29}
 }
 func (m *FetchRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchRegistrationEntryRequest.Unmarshal(m, b)
@@ -1121,7 +1388,7 @@ func (m *FetchRegistrationEntryResponse) Reset()         { *m = FetchRegistratio
 func (m *FetchRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{25}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{30}
 }
 func (m *FetchRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchRegistrationEntryResponse.Unmarshal(m, b)
@@ -1161,7 +1428,7 @@ func (m *FetchRegistrationEntriesResponse) Reset()         { *m = FetchRegistrat
 func (m *FetchRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntriesResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{26}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{31}
 }
 func (m *FetchRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FetchRegistrationEntriesResponse.Unmarshal(m, b)
@@ -1188,6 +1455,93 @@ func (m *FetchRegistrationEntriesResponse) GetRegisteredEntries() *common.Regist
 	return nil
 }
 
+type CountRegistrationEntriesRequest struct {
+	// If set, only the entries with this parent ID are counted
+	ByParentId string `protobuf:"bytes,1,opt,name=by_parent_id,json=byParentId" json:"by_parent_id,omitempty"`
+	// If set, only the entries with this SPIFFE ID are counted
+	BySpiffeId           string   `protobuf:"bytes,2,opt,name=by_spiffe_id,json=bySpiffeId" json:"by_spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountRegistrationEntriesRequest) Reset()         { *m = CountRegistrationEntriesRequest{} }
+func (m *CountRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*CountRegistrationEntriesRequest) ProtoMessage()    {}
+func (*CountRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{32}
+}
+func (m *CountRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountRegistrationEntriesRequest.Unmarshal(m, b)
+}
+func (m *CountRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountRegistrationEntriesRequest.Marshal(b, m, deterministic)
+}
+func (dst *CountRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountRegistrationEntriesRequest.Merge(dst, src)
+}
+func (m *CountRegistrationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_CountRegistrationEntriesRequest.Size(m)
+}
+func (m *CountRegistrationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountRegistrationEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountRegistrationEntriesRequest proto.InternalMessageInfo
+
+func (m *CountRegistrationEntriesRequest) GetByParentId() string {
+	if m != nil {
+		return m.ByParentId
+	}
+	return ""
+}
+
+func (m *CountRegistrationEntriesRequest) GetBySpiffeId() string {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return ""
+}
+
+type CountRegistrationEntriesResponse struct {
+	// Number of registration entries
+	Count                int32    `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CountRegistrationEntriesResponse) Reset()         { *m = CountRegistrationEntriesResponse{} }
+func (m *CountRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*CountRegistrationEntriesResponse) ProtoMessage()    {}
+func (*CountRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{33}
+}
+func (m *CountRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CountRegistrationEntriesResponse.Unmarshal(m, b)
+}
+func (m *CountRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CountRegistrationEntriesResponse.Marshal(b, m, deterministic)
+}
+func (dst *CountRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CountRegistrationEntriesResponse.Merge(dst, src)
+}
+func (m *CountRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_CountRegistrationEntriesResponse.Size(m)
+}
+func (m *CountRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CountRegistrationEntriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CountRegistrationEntriesResponse proto.InternalMessageInfo
+
+func (m *CountRegistrationEntriesResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
 // Represents a Registration entry to update
 type UpdateRegistrationEntryRequest struct {
 	// Registration entry ID
@@ -1203,7 +1557,7 @@ func (m *UpdateRegistrationEntryRequest) Reset()         { *m = UpdateRegistrati
 func (m *UpdateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryRequest) ProtoMessage()    {}
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{27}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{34}
 }
 func (m *UpdateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRegistrationEntryRequest.Unmarshal(m, b)
@@ -1250,7 +1604,7 @@ func (m *UpdateRegistrationEntryResponse) Reset()         { *m = UpdateRegistrat
 func (m *UpdateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryResponse) ProtoMessage()    {}
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{28}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{35}
 }
 func (m *UpdateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRegistrationEntryResponse.Unmarshal(m, b)
@@ -1290,7 +1644,7 @@ func (m *DeleteRegistrationEntryRequest) Reset()         { *m = DeleteRegistrati
 func (m *DeleteRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryRequest) ProtoMessage()    {}
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{29}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{36}
 }
 func (m *DeleteRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRegistrationEntryRequest.Unmarshal(m, b)
@@ -1330,7 +1684,7 @@ func (m *DeleteRegistrationEntryResponse) Reset()         { *m = DeleteRegistrat
 func (m *DeleteRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryResponse) ProtoMessage()    {}
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{30}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{37}
 }
 func (m *DeleteRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRegistrationEntryResponse.Unmarshal(m, b)
@@ -1370,7 +1724,7 @@ func (m *ListParentIDEntriesRequest) Reset()         { *m = ListParentIDEntriesR
 func (m *ListParentIDEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListParentIDEntriesRequest) ProtoMessage()    {}
 func (*ListParentIDEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{31}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{38}
 }
 func (m *ListParentIDEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListParentIDEntriesRequest.Unmarshal(m, b)
@@ -1410,7 +1764,7 @@ func (m *ListParentIDEntriesResponse) Reset()         { *m = ListParentIDEntries
 func (m *ListParentIDEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListParentIDEntriesResponse) ProtoMessage()    {}
 func (*ListParentIDEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{32}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{39}
 }
 func (m *ListParentIDEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListParentIDEntriesResponse.Unmarshal(m, b)
@@ -1450,7 +1804,7 @@ func (m *ListSelectorEntriesRequest) Reset()         { *m = ListSelectorEntriesR
 func (m *ListSelectorEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListSelectorEntriesRequest) ProtoMessage()    {}
 func (*ListSelectorEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{33}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{40}
 }
 func (m *ListSelectorEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSelectorEntriesRequest.Unmarshal(m, b)
@@ -1490,7 +1844,7 @@ func (m *ListSelectorEntriesResponse) Reset()         { *m = ListSelectorEntries
 func (m *ListSelectorEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListSelectorEntriesResponse) ProtoMessage()    {}
 func (*ListSelectorEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{34}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{41}
 }
 func (m *ListSelectorEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSelectorEntriesResponse.Unmarshal(m, b)
@@ -1530,7 +1884,7 @@ func (m *ListSpiffeEntriesRequest) Reset()         { *m = ListSpiffeEntriesReque
 func (m *ListSpiffeEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListSpiffeEntriesRequest) ProtoMessage()    {}
 func (*ListSpiffeEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{35}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{42}
 }
 func (m *ListSpiffeEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSpiffeEntriesRequest.Unmarshal(m, b)
@@ -1570,7 +1924,7 @@ func (m *ListSpiffeEntriesResponse) Reset()         { *m = ListSpiffeEntriesResp
 func (m *ListSpiffeEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListSpiffeEntriesResponse) ProtoMessage()    {}
 func (*ListSpiffeEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{36}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{43}
 }
 func (m *ListSpiffeEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSpiffeEntriesResponse.Unmarshal(m, b)
@@ -1611,7 +1965,7 @@ func (m *JoinToken) Reset()         { *m = JoinToken{} }
 func (m *JoinToken) String() string { return proto.CompactTextString(m) }
 func (*JoinToken) ProtoMessage()    {}
 func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_datastore_396395c57dd52724, []int{37}
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{44}
 }
 func (m *JoinToken) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
@@ -1645,17 +1999,287 @@ func (m *JoinToken) GetExpiry() int64 {
 	return 0
 }
 
+// Represents an X509-SVID signed by the server, recorded so that it can be
+// revoked
+type IssuedSVID struct {
+	// Serial number of the certificate, in decimal
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber" json:"serial_number,omitempty"`
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId" json:"spiffe_id,omitempty"`
+	// ID of the registration entry the SVID was signed for. Not set for
+	// node SVIDs
+	EntryId string `protobuf:"bytes,3,opt,name=entry_id,json=entryId" json:"entry_id,omitempty"`
+	// Expiration date, represented in UNIX time
+	Expiry int64 `protobuf:"varint,4,opt,name=expiry" json:"expiry,omitempty"`
+	// Revocation date, represented in UNIX time. Zero unless revoked
+	RevokedAt            int64    `protobuf:"varint,5,opt,name=revoked_at,json=revokedAt" json:"revoked_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedSVID) Reset()         { *m = IssuedSVID{} }
+func (m *IssuedSVID) String() string { return proto.CompactTextString(m) }
+func (*IssuedSVID) ProtoMessage()    {}
+func (*IssuedSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{45}
+}
+func (m *IssuedSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedSVID.Unmarshal(m, b)
+}
+func (m *IssuedSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedSVID.Marshal(b, m, deterministic)
+}
+func (dst *IssuedSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedSVID.Merge(dst, src)
+}
+func (m *IssuedSVID) XXX_Size() int {
+	return xxx_messageInfo_IssuedSVID.Size(m)
+}
+func (m *IssuedSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedSVID proto.InternalMessageInfo
+
+func (m *IssuedSVID) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *IssuedSVID) GetRevokedAt() int64 {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return 0
+}
+
+type IssuedSVIDs struct {
+	Svids                []*IssuedSVID `protobuf:"bytes,1,rep,name=svids" json:"svids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *IssuedSVIDs) Reset()         { *m = IssuedSVIDs{} }
+func (m *IssuedSVIDs) String() string { return proto.CompactTextString(m) }
+func (*IssuedSVIDs) ProtoMessage()    {}
+func (*IssuedSVIDs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{46}
+}
+func (m *IssuedSVIDs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedSVIDs.Unmarshal(m, b)
+}
+func (m *IssuedSVIDs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedSVIDs.Marshal(b, m, deterministic)
+}
+func (dst *IssuedSVIDs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedSVIDs.Merge(dst, src)
+}
+func (m *IssuedSVIDs) XXX_Size() int {
+	return xxx_messageInfo_IssuedSVIDs.Size(m)
+}
+func (m *IssuedSVIDs) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedSVIDs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedSVIDs proto.InternalMessageInfo
+
+func (m *IssuedSVIDs) GetSvids() []*IssuedSVID {
+	if m != nil {
+		return m.Svids
+	}
+	return nil
+}
+
+// Records a change of a registration entry, or of the selectors a node
+// resolver mapped to a node, so that servers can tell which agents to push
+// the change to and keep their entry caches up to date
+type EntryEvent struct {
+	// ID of the event, increased by the datastore for every event
+	EventId uint64 `protobuf:"varint,1,opt,name=event_id,json=eventId" json:"event_id,omitempty"`
+	// ID of the registration entry created, updated or deleted. Not set for
+	// node selector changes
+	EntryId string `protobuf:"bytes,2,opt,name=entry_id,json=entryId" json:"entry_id,omitempty"`
+	// SPIFFE ID of the node whose selectors changed. Not set for
+	// registration entry changes
+	NodeSpiffeId string `protobuf:"bytes,3,opt,name=node_spiffe_id,json=nodeSpiffeId" json:"node_spiffe_id,omitempty"`
+	// Creation date, represented in UNIX time
+	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntryEvent) Reset()         { *m = EntryEvent{} }
+func (m *EntryEvent) String() string { return proto.CompactTextString(m) }
+func (*EntryEvent) ProtoMessage()    {}
+func (*EntryEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{47}
+}
+func (m *EntryEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryEvent.Unmarshal(m, b)
+}
+func (m *EntryEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryEvent.Marshal(b, m, deterministic)
+}
+func (dst *EntryEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryEvent.Merge(dst, src)
+}
+func (m *EntryEvent) XXX_Size() int {
+	return xxx_messageInfo_EntryEvent.Size(m)
+}
+func (m *EntryEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryEvent proto.InternalMessageInfo
+
+func (m *EntryEvent) GetEventId() uint64 {
+	if m != nil {
+		return m.EventId
+	}
+	return 0
+}
+
+func (m *EntryEvent) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *EntryEvent) GetNodeSpiffeId() string {
+	if m != nil {
+		return m.NodeSpiffeId
+	}
+	return ""
+}
+
+func (m *EntryEvent) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type ListEntryEventsRequest struct {
+	// Only the events with a greater ID are listed
+	AfterEventId         uint64   `protobuf:"varint,1,opt,name=after_event_id,json=afterEventId" json:"after_event_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEntryEventsRequest) Reset()         { *m = ListEntryEventsRequest{} }
+func (m *ListEntryEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEntryEventsRequest) ProtoMessage()    {}
+func (*ListEntryEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{48}
+}
+func (m *ListEntryEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntryEventsRequest.Unmarshal(m, b)
+}
+func (m *ListEntryEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntryEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListEntryEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntryEventsRequest.Merge(dst, src)
+}
+func (m *ListEntryEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEntryEventsRequest.Size(m)
+}
+func (m *ListEntryEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntryEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntryEventsRequest proto.InternalMessageInfo
+
+func (m *ListEntryEventsRequest) GetAfterEventId() uint64 {
+	if m != nil {
+		return m.AfterEventId
+	}
+	return 0
+}
+
+type ListEntryEventsResponse struct {
+	// Events, in increasing ID order
+	Events               []*EntryEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListEntryEventsResponse) Reset()         { *m = ListEntryEventsResponse{} }
+func (m *ListEntryEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEntryEventsResponse) ProtoMessage()    {}
+func (*ListEntryEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_datastore_660305bc2c8665a1, []int{49}
+}
+func (m *ListEntryEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntryEventsResponse.Unmarshal(m, b)
+}
+func (m *ListEntryEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntryEventsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListEntryEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntryEventsResponse.Merge(dst, src)
+}
+func (m *ListEntryEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEntryEventsResponse.Size(m)
+}
+func (m *ListEntryEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntryEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListEntryEventsResponse proto.InternalMessageInfo
+
+func (m *ListEntryEventsResponse) GetEvents() []*EntryEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Bundle)(nil), "spire.server.datastore.Bundle")
 	proto.RegisterType((*Bundles)(nil), "spire.server.datastore.Bundles")
 	proto.RegisterType((*NodeResolverMapEntry)(nil), "spire.server.datastore.NodeResolverMapEntry")
 	proto.RegisterType((*AttestedNodeEntry)(nil), "spire.server.datastore.AttestedNodeEntry")
+	proto.RegisterType((*AttestedNodeEntryMask)(nil), "spire.server.datastore.AttestedNodeEntryMask")
 	proto.RegisterType((*CreateAttestedNodeEntryRequest)(nil), "spire.server.datastore.CreateAttestedNodeEntryRequest")
 	proto.RegisterType((*CreateAttestedNodeEntryResponse)(nil), "spire.server.datastore.CreateAttestedNodeEntryResponse")
 	proto.RegisterType((*FetchAttestedNodeEntryRequest)(nil), "spire.server.datastore.FetchAttestedNodeEntryRequest")
 	proto.RegisterType((*FetchAttestedNodeEntryResponse)(nil), "spire.server.datastore.FetchAttestedNodeEntryResponse")
 	proto.RegisterType((*FetchStaleNodeEntriesRequest)(nil), "spire.server.datastore.FetchStaleNodeEntriesRequest")
 	proto.RegisterType((*FetchStaleNodeEntriesResponse)(nil), "spire.server.datastore.FetchStaleNodeEntriesResponse")
+	proto.RegisterType((*ListAttestedNodeEntriesRequest)(nil), "spire.server.datastore.ListAttestedNodeEntriesRequest")
+	proto.RegisterType((*ListAttestedNodeEntriesResponse)(nil), "spire.server.datastore.ListAttestedNodeEntriesResponse")
+	proto.RegisterType((*CountAttestedNodeEntriesRequest)(nil), "spire.server.datastore.CountAttestedNodeEntriesRequest")
+	proto.RegisterType((*CountAttestedNodeEntriesResponse)(nil), "spire.server.datastore.CountAttestedNodeEntriesResponse")
 	proto.RegisterType((*UpdateAttestedNodeEntryRequest)(nil), "spire.server.datastore.UpdateAttestedNodeEntryRequest")
 	proto.RegisterType((*UpdateAttestedNodeEntryResponse)(nil), "spire.server.datastore.UpdateAttestedNodeEntryResponse")
 	proto.RegisterType((*DeleteAttestedNodeEntryRequest)(nil), "spire.server.datastore.DeleteAttestedNodeEntryRequest")
@@ -1673,6 +2297,8 @@ func init() {
 	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
 	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
 	proto.RegisterType((*FetchRegistrationEntriesResponse)(nil), "spire.server.datastore.FetchRegistrationEntriesResponse")
+	proto.RegisterType((*CountRegistrationEntriesRequest)(nil), "spire.server.datastore.CountRegistrationEntriesRequest")
+	proto.RegisterType((*CountRegistrationEntriesResponse)(nil), "spire.server.datastore.CountRegistrationEntriesResponse")
 	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
 	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
 	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
@@ -1684,6 +2310,11 @@ func init() {
 	proto.RegisterType((*ListSpiffeEntriesRequest)(nil), "spire.server.datastore.ListSpiffeEntriesRequest")
 	proto.RegisterType((*ListSpiffeEntriesResponse)(nil), "spire.server.datastore.ListSpiffeEntriesResponse")
 	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
+	proto.RegisterType((*IssuedSVID)(nil), "spire.server.datastore.IssuedSVID")
+	proto.RegisterType((*IssuedSVIDs)(nil), "spire.server.datastore.IssuedSVIDs")
+	proto.RegisterType((*EntryEvent)(nil), "spire.server.datastore.EntryEvent")
+	proto.RegisterType((*ListEntryEventsRequest)(nil), "spire.server.datastore.ListEntryEventsRequest")
+	proto.RegisterType((*ListEntryEventsResponse)(nil), "spire.server.datastore.ListEntryEventsResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1715,6 +2346,10 @@ type DataStoreClient interface {
 	FetchAttestedNodeEntry(ctx context.Context, in *FetchAttestedNodeEntryRequest, opts ...grpc.CallOption) (*FetchAttestedNodeEntryResponse, error)
 	// Retrieves dead nodes for which the base SVID has expired
 	FetchStaleNodeEntries(ctx context.Context, in *FetchStaleNodeEntriesRequest, opts ...grpc.CallOption) (*FetchStaleNodeEntriesResponse, error)
+	// Lists all the Attested Node Entries
+	ListAttestedNodeEntries(ctx context.Context, in *ListAttestedNodeEntriesRequest, opts ...grpc.CallOption) (*ListAttestedNodeEntriesResponse, error)
+	// Counts the Attested Node Entries
+	CountAttestedNodeEntries(ctx context.Context, in *CountAttestedNodeEntriesRequest, opts ...grpc.CallOption) (*CountAttestedNodeEntriesResponse, error)
 	// Updates the Attested Node Entry
 	UpdateAttestedNodeEntry(ctx context.Context, in *UpdateAttestedNodeEntryRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeEntryResponse, error)
 	// Deletes the Attested Node Entry
@@ -1733,6 +2368,8 @@ type DataStoreClient interface {
 	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
 	// Retrieve all registration entries
 	FetchRegistrationEntries(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*FetchRegistrationEntriesResponse, error)
+	// Counts the registration entries
+	CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error)
 	// Updates a specific registered entry
 	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
 	// Deletes a specific registered entry
@@ -1745,6 +2382,10 @@ type DataStoreClient interface {
 	ListMatchingEntries(ctx context.Context, in *ListSelectorEntriesRequest, opts ...grpc.CallOption) (*ListSelectorEntriesResponse, error)
 	// Retrieves all the registered entry with the same SpiffeId
 	ListSpiffeEntries(ctx context.Context, in *ListSpiffeEntriesRequest, opts ...grpc.CallOption) (*ListSpiffeEntriesResponse, error)
+	// Lists the registration entry and node selector change events
+	ListEntryEvents(ctx context.Context, in *ListEntryEventsRequest, opts ...grpc.CallOption) (*ListEntryEventsResponse, error)
+	// Delete all entry events created before the date in the message
+	PruneEntryEvents(ctx context.Context, in *EntryEvent, opts ...grpc.CallOption) (*common.Empty, error)
 	// Register a new join token
 	RegisterToken(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*common.Empty, error)
 	// Fetch a token record
@@ -1753,6 +2394,16 @@ type DataStoreClient interface {
 	DeleteToken(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*common.Empty, error)
 	// Delete all tokens with expiry less than the one specified
 	PruneTokens(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*common.Empty, error)
+	// Records an issued SVID
+	CreateIssuedSVID(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*IssuedSVID, error)
+	// Fetches the issued SVID with the serial number specified
+	FetchIssuedSVID(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*IssuedSVID, error)
+	// Revokes the issued SVID with the serial number specified or, if not set, all the SVIDs issued for the entry specified
+	RevokeIssuedSVIDs(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*IssuedSVIDs, error)
+	// List all revoked SVIDs
+	ListRevokedSVIDs(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*IssuedSVIDs, error)
+	// Delete all issued SVIDs with expiry less than the one specified
+	PruneIssuedSVIDs(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*common.Empty, error)
 	// Applies the plugin configuration
 	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -1848,6 +2499,24 @@ func (c *dataStoreClient) FetchStaleNodeEntries(ctx context.Context, in *FetchSt
 	return out, nil
 }
 
+func (c *dataStoreClient) ListAttestedNodeEntries(ctx context.Context, in *ListAttestedNodeEntriesRequest, opts ...grpc.CallOption) (*ListAttestedNodeEntriesResponse, error) {
+	out := new(ListAttestedNodeEntriesResponse)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/ListAttestedNodeEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CountAttestedNodeEntries(ctx context.Context, in *CountAttestedNodeEntriesRequest, opts ...grpc.CallOption) (*CountAttestedNodeEntriesResponse, error) {
+	out := new(CountAttestedNodeEntriesResponse)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/CountAttestedNodeEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) UpdateAttestedNodeEntry(ctx context.Context, in *UpdateAttestedNodeEntryRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeEntryResponse, error) {
 	out := new(UpdateAttestedNodeEntryResponse)
 	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNodeEntry", in, out, c.cc, opts...)
@@ -1929,6 +2598,15 @@ func (c *dataStoreClient) FetchRegistrationEntries(ctx context.Context, in *comm
 	return out, nil
 }
 
+func (c *dataStoreClient) CountRegistrationEntries(ctx context.Context, in *CountRegistrationEntriesRequest, opts ...grpc.CallOption) (*CountRegistrationEntriesResponse, error) {
+	out := new(CountRegistrationEntriesResponse)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/CountRegistrationEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
 	out := new(UpdateRegistrationEntryResponse)
 	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateRegistrationEntry", in, out, c.cc, opts...)
@@ -1983,6 +2661,24 @@ func (c *dataStoreClient) ListSpiffeEntries(ctx context.Context, in *ListSpiffeE
 	return out, nil
 }
 
+func (c *dataStoreClient) ListEntryEvents(ctx context.Context, in *ListEntryEventsRequest, opts ...grpc.CallOption) (*ListEntryEventsResponse, error) {
+	out := new(ListEntryEventsResponse)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/ListEntryEvents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneEntryEvents(ctx context.Context, in *EntryEvent, opts ...grpc.CallOption) (*common.Empty, error) {
+	out := new(common.Empty)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneEntryEvents", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) RegisterToken(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*common.Empty, error) {
 	out := new(common.Empty)
 	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/RegisterToken", in, out, c.cc, opts...)
@@ -2019,6 +2715,51 @@ func (c *dataStoreClient) PruneTokens(ctx context.Context, in *JoinToken, opts .
 	return out, nil
 }
 
+func (c *dataStoreClient) CreateIssuedSVID(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*IssuedSVID, error) {
+	out := new(IssuedSVID)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateIssuedSVID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchIssuedSVID(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*IssuedSVID, error) {
+	out := new(IssuedSVID)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchIssuedSVID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) RevokeIssuedSVIDs(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*IssuedSVIDs, error) {
+	out := new(IssuedSVIDs)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/RevokeIssuedSVIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRevokedSVIDs(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*IssuedSVIDs, error) {
+	out := new(IssuedSVIDs)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRevokedSVIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneIssuedSVIDs(ctx context.Context, in *IssuedSVID, opts ...grpc.CallOption) (*common.Empty, error) {
+	out := new(common.Empty)
+	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneIssuedSVIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
 	out := new(plugin.ConfigureResponse)
 	err := grpc.Invoke(ctx, "/spire.server.datastore.DataStore/Configure", in, out, c.cc, opts...)
@@ -2058,6 +2799,10 @@ type DataStoreServer interface {
 	FetchAttestedNodeEntry(context.Context, *FetchAttestedNodeEntryRequest) (*FetchAttestedNodeEntryResponse, error)
 	// Retrieves dead nodes for which the base SVID has expired
 	FetchStaleNodeEntries(context.Context, *FetchStaleNodeEntriesRequest) (*FetchStaleNodeEntriesResponse, error)
+	// Lists all the Attested Node Entries
+	ListAttestedNodeEntries(context.Context, *ListAttestedNodeEntriesRequest) (*ListAttestedNodeEntriesResponse, error)
+	// Counts the Attested Node Entries
+	CountAttestedNodeEntries(context.Context, *CountAttestedNodeEntriesRequest) (*CountAttestedNodeEntriesResponse, error)
 	// Updates the Attested Node Entry
 	UpdateAttestedNodeEntry(context.Context, *UpdateAttestedNodeEntryRequest) (*UpdateAttestedNodeEntryResponse, error)
 	// Deletes the Attested Node Entry
@@ -2076,6 +2821,8 @@ type DataStoreServer interface {
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
 	// Retrieve all registration entries
 	FetchRegistrationEntries(context.Context, *common.Empty) (*FetchRegistrationEntriesResponse, error)
+	// Counts the registration entries
+	CountRegistrationEntries(context.Context, *CountRegistrationEntriesRequest) (*CountRegistrationEntriesResponse, error)
 	// Updates a specific registered entry
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 	// Deletes a specific registered entry
@@ -2088,6 +2835,10 @@ type DataStoreServer interface {
 	ListMatchingEntries(context.Context, *ListSelectorEntriesRequest) (*ListSelectorEntriesResponse, error)
 	// Retrieves all the registered entry with the same SpiffeId
 	ListSpiffeEntries(context.Context, *ListSpiffeEntriesRequest) (*ListSpiffeEntriesResponse, error)
+	// Lists the registration entry and node selector change events
+	ListEntryEvents(context.Context, *ListEntryEventsRequest) (*ListEntryEventsResponse, error)
+	// Delete all entry events created before the date in the message
+	PruneEntryEvents(context.Context, *EntryEvent) (*common.Empty, error)
 	// Register a new join token
 	RegisterToken(context.Context, *JoinToken) (*common.Empty, error)
 	// Fetch a token record
@@ -2096,6 +2847,16 @@ type DataStoreServer interface {
 	DeleteToken(context.Context, *JoinToken) (*common.Empty, error)
 	// Delete all tokens with expiry less than the one specified
 	PruneTokens(context.Context, *JoinToken) (*common.Empty, error)
+	// Records an issued SVID
+	CreateIssuedSVID(context.Context, *IssuedSVID) (*IssuedSVID, error)
+	// Fetches the issued SVID with the serial number specified
+	FetchIssuedSVID(context.Context, *IssuedSVID) (*IssuedSVID, error)
+	// Revokes the issued SVID with the serial number specified or, if not set, all the SVIDs issued for the entry specified
+	RevokeIssuedSVIDs(context.Context, *IssuedSVID) (*IssuedSVIDs, error)
+	// List all revoked SVIDs
+	ListRevokedSVIDs(context.Context, *common.Empty) (*IssuedSVIDs, error)
+	// Delete all issued SVIDs with expiry less than the one specified
+	PruneIssuedSVIDs(context.Context, *IssuedSVID) (*common.Empty, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
@@ -2268,6 +3029,42 @@ func _DataStore_FetchStaleNodeEntries_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ListAttestedNodeEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttestedNodeEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListAttestedNodeEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListAttestedNodeEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListAttestedNodeEntries(ctx, req.(*ListAttestedNodeEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CountAttestedNodeEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountAttestedNodeEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountAttestedNodeEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CountAttestedNodeEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountAttestedNodeEntries(ctx, req.(*CountAttestedNodeEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_UpdateAttestedNodeEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateAttestedNodeEntryRequest)
 	if err := dec(in); err != nil {
@@ -2430,6 +3227,24 @@ func _DataStore_FetchRegistrationEntries_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CountRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CountRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CountRegistrationEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CountRegistrationEntries(ctx, req.(*CountRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_UpdateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateRegistrationEntryRequest)
 	if err := dec(in); err != nil {
@@ -2538,6 +3353,42 @@ func _DataStore_ListSpiffeEntries_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_ListEntryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntryEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListEntryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListEntryEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListEntryEvents(ctx, req.(*ListEntryEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneEntryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntryEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneEntryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneEntryEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneEntryEvents(ctx, req.(*EntryEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_RegisterToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JoinToken)
 	if err := dec(in); err != nil {
@@ -2610,6 +3461,96 @@ func _DataStore_PruneTokens_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataStore_CreateIssuedSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuedSVID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateIssuedSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateIssuedSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateIssuedSVID(ctx, req.(*IssuedSVID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchIssuedSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuedSVID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchIssuedSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchIssuedSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchIssuedSVID(ctx, req.(*IssuedSVID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_RevokeIssuedSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuedSVID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).RevokeIssuedSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/RevokeIssuedSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).RevokeIssuedSVIDs(ctx, req.(*IssuedSVID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListRevokedSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListRevokedSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListRevokedSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListRevokedSVIDs(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneIssuedSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuedSVID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneIssuedSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneIssuedSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneIssuedSVIDs(ctx, req.(*IssuedSVID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DataStore_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(plugin.ConfigureRequest)
 	if err := dec(in); err != nil {
@@ -2686,6 +3627,14 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchStaleNodeEntries",
 			Handler:    _DataStore_FetchStaleNodeEntries_Handler,
 		},
+		{
+			MethodName: "ListAttestedNodeEntries",
+			Handler:    _DataStore_ListAttestedNodeEntries_Handler,
+		},
+		{
+			MethodName: "CountAttestedNodeEntries",
+			Handler:    _DataStore_CountAttestedNodeEntries_Handler,
+		},
 		{
 			MethodName: "UpdateAttestedNodeEntry",
 			Handler:    _DataStore_UpdateAttestedNodeEntry_Handler,
@@ -2722,6 +3671,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchRegistrationEntries",
 			Handler:    _DataStore_FetchRegistrationEntries_Handler,
 		},
+		{
+			MethodName: "CountRegistrationEntries",
+			Handler:    _DataStore_CountRegistrationEntries_Handler,
+		},
 		{
 			MethodName: "UpdateRegistrationEntry",
 			Handler:    _DataStore_UpdateRegistrationEntry_Handler,
@@ -2746,6 +3699,14 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListSpiffeEntries",
 			Handler:    _DataStore_ListSpiffeEntries_Handler,
 		},
+		{
+			MethodName: "ListEntryEvents",
+			Handler:    _DataStore_ListEntryEvents_Handler,
+		},
+		{
+			MethodName: "PruneEntryEvents",
+			Handler:    _DataStore_PruneEntryEvents_Handler,
+		},
 		{
 			MethodName: "RegisterToken",
 			Handler:    _DataStore_RegisterToken_Handler,
@@ -2762,6 +3723,26 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PruneTokens",
 			Handler:    _DataStore_PruneTokens_Handler,
 		},
+		{
+			MethodName: "CreateIssuedSVID",
+			Handler:    _DataStore_CreateIssuedSVID_Handler,
+		},
+		{
+			MethodName: "FetchIssuedSVID",
+			Handler:    _DataStore_FetchIssuedSVID_Handler,
+		},
+		{
+			MethodName: "RevokeIssuedSVIDs",
+			Handler:    _DataStore_RevokeIssuedSVIDs_Handler,
+		},
+		{
+			MethodName: "ListRevokedSVIDs",
+			Handler:    _DataStore_ListRevokedSVIDs_Handler,
+		},
+		{
+			MethodName: "PruneIssuedSVIDs",
+			Handler:    _DataStore_PruneIssuedSVIDs_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
@@ -2775,91 +3756,128 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 	Metadata: "datastore.proto",
 }
 
-func init() { proto.RegisterFile("datastore.proto", fileDescriptor_datastore_396395c57dd52724) }
-
-var fileDescriptor_datastore_396395c57dd52724 = []byte{
-	// 1327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0xcf, 0x6f, 0x1b, 0x45,
-	0x14, 0x66, 0x1b, 0x48, 0xe2, 0xe7, 0x54, 0x25, 0x93, 0x34, 0x38, 0x0b, 0xcd, 0x8f, 0x15, 0x45,
-	0x69, 0x55, 0x39, 0xad, 0xdb, 0x86, 0x04, 0xc1, 0xa1, 0x75, 0x92, 0x2a, 0x88, 0xa4, 0x61, 0x53,
-	0x84, 0xd4, 0x4b, 0xd8, 0xd8, 0xe3, 0x64, 0x15, 0x7b, 0x77, 0xd9, 0x19, 0x57, 0x4d, 0x0f, 0x88,
-	0x03, 0x3f, 0x24, 0x24, 0x50, 0x11, 0x27, 0x24, 0x0e, 0xfc, 0x33, 0xfc, 0x53, 0x9c, 0xd0, 0xce,
-	0xcc, 0x6e, 0x6c, 0xef, 0xcc, 0xac, 0xd7, 0xb5, 0xcd, 0x29, 0xd9, 0x9d, 0x79, 0xdf, 0xfb, 0xde,
-	0x9b, 0x99, 0x37, 0xef, 0x5b, 0xc3, 0xb5, 0xba, 0x43, 0x1d, 0x42, 0xfd, 0x10, 0x97, 0x83, 0xd0,
-	0xa7, 0x3e, 0x5a, 0x20, 0x81, 0x1b, 0xe2, 0x32, 0xc1, 0xe1, 0x0b, 0x1c, 0x96, 0x93, 0x51, 0x73,
-	0xf3, 0xd4, 0xa5, 0x67, 0xed, 0x93, 0x72, 0xcd, 0x6f, 0xad, 0x93, 0xc0, 0x6d, 0x34, 0xf0, 0x3a,
-	0x9b, 0xb9, 0xce, 0xcc, 0xd6, 0x6b, 0x7e, 0xab, 0xe5, 0x7b, 0xeb, 0x41, 0xb3, 0x7d, 0xea, 0xc6,
-	0x7f, 0x38, 0xa2, 0x79, 0xaf, 0x2f, 0x4b, 0xfe, 0x87, 0x9b, 0x58, 0xbb, 0x30, 0xf9, 0xb8, 0xed,
-	0xd5, 0x9b, 0x18, 0xad, 0xc2, 0x0c, 0x0d, 0xdb, 0x84, 0x1e, 0xd7, 0xfd, 0x96, 0xe3, 0x7a, 0x25,
-	0x63, 0xc5, 0x58, 0x2b, 0xd8, 0x45, 0xf6, 0x6e, 0x9b, 0xbd, 0x42, 0x8b, 0x30, 0x5d, 0x73, 0x8e,
-	0x6b, 0x38, 0xa4, 0xa4, 0x74, 0x65, 0xc5, 0x58, 0x9b, 0xb1, 0xa7, 0x6a, 0x4e, 0x35, 0x7a, 0xb4,
-	0xaa, 0x30, 0xc5, 0x71, 0x08, 0xda, 0x84, 0xa9, 0x13, 0xfe, 0x6f, 0xc9, 0x58, 0x99, 0x58, 0x2b,
-	0x56, 0x96, 0xca, 0xf2, 0x48, 0xcb, 0xdc, 0xc2, 0x8e, 0xa7, 0x5b, 0x1e, 0xcc, 0x1f, 0xf8, 0x75,
-	0x6c, 0x63, 0xe2, 0x37, 0x5f, 0xe0, 0x70, 0xdf, 0x09, 0x76, 0x3c, 0x1a, 0x5e, 0x20, 0x0b, 0x66,
-	0x4e, 0x1c, 0x82, 0x8f, 0x58, 0x48, 0x7b, 0x75, 0x41, 0xad, 0xeb, 0x1d, 0xaa, 0xc0, 0x34, 0xc1,
-	0x4d, 0x5c, 0xa3, 0x7e, 0xc8, 0xb8, 0x15, 0x2b, 0x0b, 0xc2, 0xad, 0x88, 0xf7, 0x48, 0x8c, 0xda,
-	0xc9, 0x3c, 0xeb, 0x1f, 0x03, 0x66, 0x1f, 0x51, 0x8a, 0x09, 0xc5, 0xf5, 0xc8, 0x71, 0xff, 0xde,
-	0xee, 0xc2, 0x9c, 0xc3, 0x0c, 0x1d, 0xea, 0xfa, 0xde, 0xb6, 0x43, 0x9d, 0x67, 0x17, 0x01, 0x66,
-	0x8e, 0x0b, 0xb6, 0x6c, 0x08, 0xdd, 0x86, 0x77, 0xa3, 0xc4, 0x1d, 0xe1, 0xd0, 0x75, 0x9a, 0x07,
-	0xed, 0xd6, 0x09, 0x0e, 0x4b, 0x13, 0x6c, 0x7a, 0xea, 0x3d, 0x2a, 0x03, 0x8a, 0xde, 0xed, 0xbc,
-	0x0c, 0xdc, 0x30, 0x46, 0xc1, 0xa5, 0xb7, 0xd9, 0x6c, 0xc9, 0x88, 0x75, 0x01, 0x4b, 0xd5, 0x10,
-	0x3b, 0x14, 0xa7, 0x82, 0xb1, 0xf1, 0xb7, 0x6d, 0x4c, 0x28, 0xfa, 0x1a, 0x66, 0x9d, 0xde, 0x31,
-	0x16, 0x58, 0xb1, 0x72, 0x4b, 0xb5, 0x3a, 0x69, 0xb0, 0x34, 0x86, 0xf5, 0x0a, 0x96, 0x95, 0xae,
-	0x49, 0xe0, 0x7b, 0x04, 0x8f, 0xce, 0x77, 0x15, 0x6e, 0xec, 0x62, 0x5a, 0x3b, 0x53, 0x46, 0xdd,
-	0xc7, 0x4a, 0x46, 0xb9, 0x53, 0x81, 0x8c, 0x9a, 0xff, 0x12, 0x7c, 0xc0, 0x5c, 0x1f, 0x51, 0xa7,
-	0x89, 0xe3, 0xd7, 0x2e, 0x26, 0x82, 0xbe, 0xf5, 0xbd, 0x21, 0x02, 0x4c, 0x4f, 0x10, 0xd4, 0x8e,
-	0xe1, 0x7a, 0x0a, 0xf6, 0x0b, 0x97, 0x50, 0x71, 0xf0, 0x72, 0xd0, 0x93, 0xe3, 0x58, 0x7f, 0x1b,
-	0xb0, 0xf4, 0x55, 0x50, 0xd7, 0x6d, 0xad, 0x7e, 0x8e, 0x8b, 0x6c, 0xf3, 0x5f, 0xc9, 0xb5, 0xf9,
-	0x27, 0x94, 0x9b, 0xff, 0x15, 0x2c, 0x2b, 0x19, 0x8e, 0x7a, 0x05, 0xb7, 0x61, 0x69, 0x1b, 0x37,
-	0xf1, 0x9b, 0x65, 0x27, 0x8a, 0x40, 0x89, 0x32, 0xea, 0x08, 0x7e, 0x34, 0x60, 0x95, 0x1f, 0x60,
-	0x59, 0xe5, 0x8d, 0xa3, 0xf8, 0x06, 0xe6, 0x3d, 0xc9, 0xb0, 0x60, 0x70, 0x47, 0xc5, 0x40, 0x0a,
-	0x29, 0x45, 0xb2, 0x7e, 0x32, 0xc0, 0xd2, 0xf1, 0x10, 0x79, 0x18, 0x3d, 0x91, 0x5d, 0x58, 0x61,
-	0x67, 0x4e, 0x97, 0x8e, 0x7e, 0x16, 0xf5, 0x57, 0x03, 0x56, 0x35, 0x40, 0x22, 0x9e, 0x33, 0x28,
-	0xc9, 0x58, 0x74, 0x9c, 0xe1, 0x7c, 0x31, 0x29, 0xd1, 0xd8, 0x42, 0xf3, 0x5d, 0xf6, 0xff, 0x2e,
-	0xf4, 0x6f, 0x06, 0x58, 0x3a, 0x1e, 0x63, 0x4f, 0xcc, 0x6b, 0x03, 0x3e, 0xb4, 0x71, 0x8d, 0xba,
-	0x8d, 0x0b, 0x89, 0xe5, 0x65, 0x39, 0x1e, 0x23, 0xa5, 0xdf, 0x0d, 0xb8, 0x99, 0x41, 0x69, 0xec,
-	0x69, 0x3a, 0x8f, 0x7b, 0x0c, 0x1b, 0x9f, 0xba, 0x84, 0xf2, 0x02, 0xdc, 0xb5, 0x77, 0xf6, 0xe0,
-	0x5a, 0xc8, 0xc6, 0x70, 0x88, 0xeb, 0x9d, 0xdb, 0x66, 0xb9, 0xbb, 0x11, 0x4b, 0x03, 0xf4, 0xda,
-	0x59, 0x4f, 0xe3, 0xae, 0x42, 0xe2, 0x4c, 0x44, 0x7e, 0x07, 0x66, 0x7b, 0xac, 0x92, 0x83, 0x98,
-	0x1e, 0xb0, 0xf6, 0xc5, 0x4d, 0xaa, 0x24, 0x9f, 0x0f, 0xee, 0x5c, 0x34, 0x0d, 0x6a, 0x7a, 0x43,
-	0x4c, 0x06, 0x11, 0x15, 0xa9, 0x77, 0x6a, 0xe7, 0x3e, 0x78, 0xda, 0x4b, 0xdf, 0x65, 0xdd, 0x77,
-	0xe4, 0x70, 0x55, 0xef, 0x30, 0x42, 0x49, 0xdb, 0x5a, 0x7f, 0x26, 0x17, 0xff, 0x70, 0x52, 0x26,
-	0x4b, 0xc8, 0x95, 0x01, 0x13, 0xd2, 0x8c, 0x6f, 0xfc, 0xb1, 0xa4, 0xff, 0x20, 0xbe, 0xe3, 0x87,
-	0xb4, 0x77, 0x9a, 0xf1, 0x6d, 0x3f, 0x16, 0xf6, 0x9b, 0x60, 0x46, 0xc7, 0xf7, 0xd0, 0x09, 0xb1,
-	0x47, 0xf7, 0xb6, 0x7b, 0x4a, 0x9a, 0x09, 0xd3, 0x01, 0x1f, 0x89, 0x09, 0x27, 0xcf, 0x56, 0x00,
-	0xef, 0x4b, 0x2d, 0x05, 0xc7, 0x2f, 0x61, 0xae, 0xc7, 0x57, 0x47, 0xd1, 0xc9, 0xe4, 0x29, 0xb3,
-	0xb5, 0x6c, 0xce, 0x35, 0x16, 0x6a, 0x3d, 0x5c, 0x1f, 0x40, 0x21, 0x16, 0x6e, 0xb1, 0xb0, 0x54,
-	0x29, 0xbc, 0xcb, 0x89, 0x71, 0x14, 0x29, 0xcc, 0xd1, 0x45, 0xb1, 0x01, 0x25, 0xe6, 0x91, 0x35,
-	0x02, 0xe9, 0x7c, 0x93, 0xee, 0xa6, 0x21, 0x79, 0xb6, 0x3c, 0x58, 0x94, 0xd8, 0x8d, 0x8e, 0xe7,
-	0x16, 0x14, 0x3e, 0xf7, 0x5d, 0xef, 0x99, 0x7f, 0x8e, 0x3d, 0x34, 0x0f, 0xef, 0xd0, 0xe8, 0x1f,
-	0xc1, 0x8a, 0x3f, 0xa0, 0x05, 0x98, 0xc4, 0x51, 0xb3, 0xcd, 0x8f, 0xea, 0x84, 0x2d, 0x9e, 0x2a,
-	0xff, 0x9a, 0x50, 0x88, 0x84, 0xed, 0x51, 0x74, 0x91, 0xa0, 0x03, 0x98, 0xe1, 0xc5, 0x5a, 0x7c,
-	0x48, 0xc8, 0x90, 0xfb, 0x66, 0xc6, 0x78, 0x84, 0xc7, 0x8f, 0xf7, 0xf0, 0xf0, 0x1e, 0x05, 0x01,
-	0xf6, 0xea, 0xc3, 0xc3, 0xe3, 0x07, 0x78, 0x48, 0x78, 0xfb, 0x50, 0x64, 0xf5, 0x7d, 0x48, 0x70,
-	0x55, 0x28, 0x46, 0xeb, 0x1b, 0x7f, 0x8d, 0x99, 0xeb, 0xde, 0x1c, 0x3b, 0xad, 0x80, 0x5e, 0x98,
-	0xcb, 0x7a, 0x0c, 0x82, 0x7e, 0x31, 0xe0, 0x3d, 0x85, 0xae, 0x47, 0x1b, 0x2a, 0x63, 0xfd, 0x37,
-	0x08, 0xf3, 0xe3, 0xdc, 0x76, 0x62, 0xf3, 0xff, 0x6c, 0xc0, 0x82, 0x5c, 0xa3, 0xa3, 0x87, 0x2a,
-	0x4c, 0xed, 0x87, 0x01, 0x73, 0x23, 0xaf, 0x99, 0x60, 0xf2, 0x83, 0x01, 0xd7, 0xa5, 0x8a, 0x1c,
-	0x3d, 0xd0, 0x22, 0x2a, 0x14, 0xbe, 0xf9, 0x30, 0xa7, 0x95, 0xa0, 0x11, 0xad, 0x8e, 0x42, 0xf3,
-	0xaa, 0x57, 0x47, 0x2f, 0xe3, 0xd5, 0xab, 0x93, 0x25, 0xae, 0x23, 0x32, 0x0a, 0xf9, 0xaa, 0x26,
-	0xa3, 0x57, 0xcd, 0x6a, 0x32, 0x59, 0x3a, 0xf9, 0x0f, 0x03, 0x4c, 0xb5, 0x8c, 0x44, 0x5b, 0xfa,
-	0x2d, 0xa8, 0x51, 0x46, 0xe6, 0x27, 0x83, 0x98, 0x0a, 0x56, 0xaf, 0x0d, 0x58, 0x54, 0x6a, 0x41,
-	0xb4, 0xa9, 0xdd, 0x04, 0x3a, 0x4e, 0x5b, 0x03, 0x58, 0x76, 0x24, 0x4a, 0x2d, 0xc3, 0xd4, 0x89,
-	0xca, 0x94, 0x90, 0xea, 0x44, 0xf5, 0xa1, 0xfa, 0xfe, 0x32, 0xe0, 0x86, 0x56, 0xf8, 0xa0, 0x4f,
-	0x55, 0xe8, 0xfd, 0x48, 0x38, 0xf3, 0xb3, 0x01, 0xad, 0x3b, 0xb6, 0xba, 0x42, 0x97, 0x64, 0x55,
-	0x45, 0x55, 0xf3, 0x98, 0x55, 0x15, 0xd5, 0x4d, 0x62, 0x52, 0x15, 0xd3, 0x5c, 0xf4, 0x65, 0x45,
-	0x49, 0x65, 0x23, 0xaf, 0x99, 0x60, 0xe2, 0x42, 0x49, 0x25, 0x50, 0xe4, 0xd7, 0xcf, 0x66, 0x2e,
-	0x47, 0xf2, 0xca, 0x97, 0x63, 0x05, 0xf4, 0x3a, 0x26, 0xab, 0xf2, 0xa9, 0xe3, 0xbe, 0xac, 0x7c,
-	0x39, 0xc8, 0xe8, 0xb5, 0x44, 0x56, 0xe5, 0x53, 0x93, 0xf9, 0x0e, 0xe6, 0x24, 0xed, 0x3a, 0xaa,
-	0xa8, 0xf0, 0xd4, 0xaa, 0xc0, 0xbc, 0x9f, 0xcb, 0xa6, 0xdb, 0x7f, 0x4f, 0xa3, 0xad, 0xf7, 0x2f,
-	0xef, 0xf4, 0xf5, 0xfe, 0x55, 0x9d, 0xbc, 0xf0, 0xbf, 0xef, 0xd0, 0xda, 0x99, 0xeb, 0x9d, 0x8e,
-	0xdd, 0xff, 0x4b, 0x98, 0x4d, 0xb5, 0xef, 0xe8, 0xae, 0x16, 0x49, 0xa2, 0x10, 0xcc, 0x7b, 0x39,
-	0x2c, 0x84, 0xe7, 0x27, 0x70, 0xd5, 0x16, 0xfd, 0x3d, 0x6f, 0xe6, 0x57, 0x55, 0x18, 0x49, 0xbf,
-	0x6f, 0xca, 0x8e, 0x25, 0xb2, 0x01, 0xd8, 0x01, 0xec, 0x1b, 0x25, 0x7b, 0x0a, 0xda, 0x81, 0x22,
-	0xdf, 0xb9, 0x6f, 0x46, 0x6d, 0x07, 0x8a, 0x87, 0x61, 0xdb, 0xe3, 0x28, 0x64, 0x60, 0x98, 0xe7,
-	0x50, 0xa8, 0xfa, 0x5e, 0xc3, 0x3d, 0x6d, 0x87, 0x18, 0xdd, 0xec, 0x9e, 0x21, 0x7e, 0x49, 0x4d,
-	0xc6, 0xe3, 0x15, 0xf9, 0x28, 0x6b, 0x9a, 0x58, 0x86, 0x06, 0x5c, 0x7d, 0x82, 0xe9, 0x21, 0x1b,
-	0xde, 0xf3, 0x1a, 0x3e, 0xba, 0x25, 0x35, 0xec, 0x9a, 0x13, 0xfb, 0xb8, 0xdd, 0xcf, 0x54, 0xee,
-	0xe7, 0x71, 0xf1, 0x79, 0x21, 0x89, 0xf7, 0xf0, 0xad, 0x43, 0xe3, 0x64, 0x92, 0xfd, 0x92, 0x7b,
-	0xff, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x19, 0x54, 0x7e, 0x5e, 0x61, 0x1e, 0x00, 0x00,
+func init() { proto.RegisterFile("datastore.proto", fileDescriptor_datastore_660305bc2c8665a1) }
+
+var fileDescriptor_datastore_660305bc2c8665a1 = []byte{
+	// 1916 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5a, 0xdf, 0x6f, 0xe3, 0xc6,
+	0x11, 0x2e, 0x6d, 0x9f, 0x7d, 0x1a, 0xf9, 0xfc, 0x63, 0xef, 0xce, 0xa7, 0x63, 0x6a, 0x5b, 0xe6,
+	0x5d, 0x5a, 0x27, 0x48, 0xe5, 0xc4, 0x97, 0x9c, 0x75, 0x87, 0xb6, 0x80, 0x23, 0x3b, 0x57, 0xf7,
+	0xe2, 0x8b, 0x4b, 0x27, 0x2d, 0x1a, 0x14, 0x50, 0x29, 0x71, 0x65, 0x33, 0x96, 0x49, 0x95, 0xbb,
+	0x72, 0xa2, 0x3e, 0x14, 0x45, 0xd1, 0x34, 0x40, 0x8b, 0x14, 0x29, 0xfa, 0xd2, 0x02, 0x7d, 0xe8,
+	0x3f, 0xd6, 0xbf, 0xa5, 0xc5, 0xfe, 0xa0, 0x44, 0x89, 0xbb, 0x14, 0xe9, 0x48, 0xee, 0x93, 0xcc,
+	0xdd, 0xf9, 0x66, 0xbe, 0x9d, 0x1d, 0x0e, 0x77, 0x3f, 0x18, 0x96, 0x5d, 0x87, 0x3a, 0x84, 0x06,
+	0x21, 0xae, 0x74, 0xc2, 0x80, 0x06, 0x68, 0x8d, 0x74, 0xbc, 0x10, 0x57, 0x08, 0x0e, 0xaf, 0x70,
+	0x58, 0xe9, 0xcf, 0x9a, 0xd5, 0x33, 0x8f, 0x9e, 0x77, 0x1b, 0x95, 0x66, 0x70, 0xb9, 0x43, 0x3a,
+	0x5e, 0xab, 0x85, 0x77, 0xb8, 0xe5, 0x0e, 0x87, 0xed, 0x34, 0x83, 0xcb, 0xcb, 0xc0, 0xdf, 0xe9,
+	0xb4, 0xbb, 0x67, 0x5e, 0xf4, 0x23, 0x3c, 0x9a, 0xef, 0x64, 0x42, 0x8a, 0x1f, 0x01, 0xb1, 0xfe,
+	0x6b, 0xc0, 0xfc, 0xfb, 0x5d, 0xdf, 0x6d, 0x63, 0xb4, 0x05, 0x8b, 0x34, 0xec, 0x12, 0x5a, 0x77,
+	0x83, 0x4b, 0xc7, 0xf3, 0x4b, 0x46, 0xd9, 0xd8, 0x2e, 0xd8, 0x45, 0x3e, 0x76, 0xc0, 0x87, 0xd0,
+	0x43, 0xb8, 0xdd, 0x74, 0xea, 0x4d, 0x1c, 0x52, 0x52, 0x9a, 0x29, 0x1b, 0xdb, 0x8b, 0xf6, 0x42,
+	0xd3, 0xa9, 0xb1, 0x47, 0xb4, 0x0f, 0x2b, 0x9f, 0x7d, 0x4e, 0xeb, 0xc4, 0x3b, 0xf3, 0x3d, 0xff,
+	0xac, 0x7e, 0x81, 0x7b, 0xa4, 0x34, 0x5b, 0x9e, 0xdd, 0x2e, 0xee, 0x3e, 0xa8, 0x88, 0x85, 0xca,
+	0xb8, 0x27, 0xdd, 0x46, 0xdb, 0x6b, 0xbe, 0xc4, 0x3d, 0x7b, 0xe9, 0xb3, 0xcf, 0xe9, 0xa9, 0xb0,
+	0x7f, 0x89, 0x7b, 0x04, 0x7d, 0x1f, 0x96, 0x09, 0xfe, 0x4d, 0x17, 0xfb, 0x4d, 0x5c, 0xf7, 0xbb,
+	0x97, 0x0d, 0x1c, 0x96, 0xe6, 0xca, 0xc6, 0xf6, 0x9c, 0xbd, 0x14, 0x0d, 0xbf, 0xe2, 0xa3, 0x8c,
+	0x69, 0x88, 0x5b, 0x21, 0x26, 0xe7, 0xf5, 0x73, 0xcf, 0xa7, 0xa5, 0x5b, 0x65, 0x63, 0x7b, 0xd6,
+	0x2e, 0xca, 0xb1, 0x9f, 0x78, 0x3e, 0x45, 0xdb, 0xb0, 0x42, 0x1d, 0xcf, 0xa7, 0xd8, 0xad, 0xf7,
+	0x19, 0xcf, 0x73, 0xc6, 0x4b, 0x72, 0xbc, 0x26, 0x88, 0x5b, 0x35, 0x58, 0x10, 0x09, 0x20, 0xa8,
+	0x0a, 0x0b, 0x0d, 0xf1, 0x67, 0xc9, 0xe0, 0xd4, 0x37, 0x2a, 0xea, 0x3d, 0xaa, 0x08, 0x84, 0x1d,
+	0x99, 0x5b, 0x3e, 0xdc, 0x7b, 0x15, 0xb8, 0xd8, 0xc6, 0x24, 0x68, 0x5f, 0xe1, 0xf0, 0xd8, 0xe9,
+	0x1c, 0xfa, 0x34, 0xec, 0x21, 0x0b, 0x16, 0x1b, 0x0e, 0xc1, 0xa7, 0x7c, 0x33, 0x8e, 0x5c, 0x99,
+	0xd3, 0xa1, 0x31, 0xb4, 0x0b, 0xb7, 0x09, 0x6e, 0xe3, 0x26, 0x0d, 0x42, 0x9e, 0xd4, 0xe2, 0xee,
+	0xda, 0x70, 0xc6, 0x4e, 0xe5, 0xac, 0xdd, 0xb7, 0xb3, 0xfe, 0x63, 0xc0, 0xea, 0x3e, 0xa5, 0x98,
+	0x50, 0xec, 0xb2, 0xc0, 0xd9, 0xa3, 0xbd, 0x0d, 0x77, 0x1d, 0x0e, 0x74, 0xa8, 0x17, 0xf8, 0x07,
+	0x0e, 0x75, 0x3e, 0xee, 0x75, 0x30, 0x0f, 0x5c, 0xb0, 0x55, 0x53, 0xe8, 0x4d, 0x58, 0x61, 0xf9,
+	0x3b, 0xc5, 0xa1, 0xe7, 0xb4, 0xc5, 0x0e, 0x94, 0x66, 0xb9, 0x79, 0x62, 0x1c, 0x55, 0x00, 0xb1,
+	0xb1, 0xc3, 0x2f, 0x3a, 0x5e, 0x18, 0x79, 0xc1, 0x7c, 0x17, 0x0b, 0xb6, 0x62, 0x06, 0xad, 0xc1,
+	0x7c, 0xc3, 0xf1, 0x7d, 0xec, 0xf2, 0x3d, 0xbc, 0x6d, 0xcb, 0x27, 0xeb, 0x2f, 0x06, 0xdc, 0x4f,
+	0xac, 0xef, 0xd8, 0x21, 0x17, 0x4a, 0x36, 0x06, 0xc7, 0x66, 0x65, 0x33, 0xc3, 0xad, 0xd3, 0xd9,
+	0xcc, 0x0e, 0xb1, 0xe9, 0xc1, 0x46, 0x2d, 0xc4, 0x0e, 0xc5, 0x09, 0x4a, 0x36, 0x2b, 0x4c, 0x42,
+	0xd1, 0x2f, 0x60, 0xd5, 0x19, 0x9d, 0xe3, 0xb4, 0x8a, 0xbb, 0x6f, 0xe8, 0x6a, 0x28, 0xe9, 0x2c,
+	0xe9, 0xc3, 0xfa, 0x2d, 0x6c, 0x6a, 0x43, 0x93, 0x4e, 0xe0, 0x13, 0x3c, 0xbd, 0xd8, 0x35, 0x58,
+	0xff, 0x00, 0xd3, 0xe6, 0xb9, 0x76, 0xd5, 0x19, 0xea, 0x8d, 0xe5, 0x4e, 0xe7, 0x64, 0xda, 0xfc,
+	0x37, 0xe0, 0xbb, 0x3c, 0xf4, 0x29, 0x75, 0xda, 0x38, 0x1a, 0xf6, 0x30, 0x91, 0xf4, 0xad, 0xdf,
+	0x1b, 0xb0, 0xae, 0x31, 0x90, 0xd4, 0xea, 0x70, 0x3f, 0xe1, 0xf6, 0x43, 0x8f, 0x50, 0xd9, 0x1e,
+	0x72, 0xd0, 0x53, 0xfb, 0xb1, 0xca, 0xb0, 0xc1, 0x7e, 0x47, 0xed, 0x63, 0x24, 0xff, 0x60, 0xc0,
+	0xa6, 0xd6, 0xe4, 0xa6, 0x68, 0x6e, 0xc1, 0x66, 0x2d, 0xe8, 0xfa, 0x69, 0x3c, 0xab, 0x50, 0xd6,
+	0x9b, 0x48, 0x9e, 0xf7, 0xe0, 0x56, 0x93, 0xd9, 0xf0, 0xdd, 0xbd, 0x65, 0x8b, 0x07, 0xeb, 0xcb,
+	0x19, 0xd8, 0xf8, 0xa4, 0xe3, 0xa6, 0xbd, 0x5e, 0x59, 0x1a, 0x9b, 0xaa, 0x31, 0xcc, 0xe4, 0x6a,
+	0x53, 0xb3, 0x19, 0xda, 0xd4, 0x5c, 0xbc, 0x31, 0xa0, 0x97, 0x50, 0xf0, 0xfc, 0x4e, 0x97, 0xb2,
+	0xce, 0xc4, 0x3b, 0x58, 0x71, 0xf7, 0x07, 0x99, 0x93, 0xcd, 0x40, 0xf6, 0x00, 0xcf, 0x5e, 0x75,
+	0x6d, 0x1a, 0xa6, 0xfd, 0xaa, 0x1c, 0xc0, 0xc6, 0x01, 0x6e, 0xe3, 0x6f, 0xb7, 0x05, 0x6c, 0x05,
+	0x5a, 0x2f, 0xd3, 0x5e, 0xc1, 0x97, 0x06, 0x6c, 0x89, 0x4e, 0xa9, 0xfa, 0x10, 0x47, 0xab, 0xf8,
+	0x35, 0xdc, 0xf3, 0x15, 0xd3, 0x92, 0xc1, 0x5b, 0x3a, 0x06, 0x4a, 0x97, 0x4a, 0x4f, 0xd6, 0x9f,
+	0x0c, 0xb0, 0xd2, 0x78, 0xc8, 0x3c, 0x4c, 0x9f, 0xc8, 0x07, 0x50, 0xe6, 0xcd, 0x2d, 0x2d, 0x1d,
+	0x59, 0x36, 0xf5, 0x6b, 0x03, 0xb6, 0x52, 0x1c, 0xc9, 0xf5, 0x9c, 0x43, 0x49, 0xc5, 0x22, 0xd6,
+	0x85, 0xf2, 0xad, 0x49, 0xeb, 0x8d, 0x6f, 0xb4, 0xa8, 0xb2, 0xff, 0xef, 0x46, 0xff, 0xd5, 0x00,
+	0x2b, 0x8d, 0xc7, 0x8d, 0x27, 0xe6, 0x1b, 0x03, 0x1e, 0xdb, 0xb8, 0x49, 0xbd, 0x56, 0x4f, 0x81,
+	0x1c, 0xb4, 0xea, 0x1b, 0xa4, 0xf4, 0x37, 0x03, 0x5e, 0x1f, 0x43, 0xe9, 0xc6, 0xd3, 0x74, 0x11,
+	0x1d, 0xe6, 0x6c, 0x7c, 0xe6, 0x11, 0x2a, 0xba, 0xfc, 0x50, 0xed, 0x1c, 0xc1, 0x72, 0xc8, 0xe7,
+	0x70, 0x88, 0xdd, 0x78, 0xd9, 0x6c, 0x0e, 0x9f, 0xcb, 0x93, 0x0e, 0x46, 0x71, 0xd6, 0x47, 0xd1,
+	0xf1, 0x4d, 0x11, 0x4c, 0xae, 0xfc, 0x2d, 0x58, 0x1d, 0x41, 0xf5, 0x5f, 0xc4, 0xe4, 0x84, 0x75,
+	0x2c, 0x8f, 0x2c, 0x5a, 0xf2, 0xf9, 0xdc, 0x5d, 0xc0, 0x86, 0xce, 0x9d, 0xa4, 0x37, 0xc1, 0x64,
+	0x10, 0xd9, 0x91, 0x46, 0x4d, 0xe3, 0x75, 0xf0, 0xd1, 0x28, 0x7d, 0x0f, 0x13, 0x19, 0x70, 0x2b,
+	0x3d, 0x20, 0xf3, 0x92, 0xc4, 0x5a, 0x58, 0x1e, 0x5d, 0x94, 0x41, 0x45, 0xca, 0xca, 0xb0, 0xd8,
+	0xe8, 0xd5, 0x3b, 0x4e, 0x88, 0x7d, 0x5a, 0xf7, 0xa2, 0x6c, 0x41, 0xa3, 0x77, 0xc2, 0x87, 0x8e,
+	0x5c, 0x69, 0x21, 0xae, 0xd4, 0xcc, 0x62, 0x26, 0xb2, 0xe8, 0x77, 0xc9, 0xe8, 0xf8, 0x93, 0xb6,
+	0x36, 0xf5, 0xf1, 0xe7, 0x9f, 0x46, 0x74, 0xfc, 0x99, 0xcc, 0x9e, 0xaa, 0x76, 0x6c, 0xe6, 0x9a,
+	0x3b, 0xd6, 0x8e, 0x8e, 0x24, 0x37, 0x52, 0x1f, 0xaf, 0xa2, 0x43, 0xc8, 0x84, 0x8a, 0xbb, 0x0d,
+	0x9b, 0x5a, 0x7f, 0x93, 0x67, 0x5f, 0x05, 0x93, 0xf5, 0x17, 0x59, 0x33, 0x07, 0x23, 0x35, 0x66,
+	0xc2, 0x6d, 0x51, 0x60, 0x7d, 0xc2, 0xfd, 0x67, 0xab, 0x03, 0xaf, 0x29, 0x91, 0x92, 0xe3, 0xcf,
+	0xe0, 0xee, 0x48, 0xac, 0x58, 0x57, 0x1c, 0xcb, 0x53, 0x85, 0xb5, 0x6c, 0xc1, 0x35, 0x12, 0x16,
+	0x46, 0xb8, 0xbe, 0x0b, 0x85, 0x48, 0x68, 0x88, 0x84, 0x10, 0x9d, 0x22, 0x31, 0x30, 0x8c, 0x56,
+	0x91, 0xf0, 0x39, 0xbd, 0x55, 0x3c, 0x85, 0x12, 0x8f, 0xc8, 0xdf, 0xc1, 0x64, 0xbe, 0xc9, 0xf0,
+	0xa9, 0xa6, 0xff, 0x6c, 0xf9, 0xf0, 0x50, 0x81, 0x9b, 0x1e, 0xcf, 0x67, 0x50, 0xf8, 0x69, 0xe0,
+	0xf9, 0x1f, 0x07, 0x17, 0xd8, 0x67, 0x4d, 0x80, 0xb2, 0x3f, 0x24, 0x2b, 0xf1, 0xc0, 0x2e, 0x18,
+	0x98, 0x5d, 0x39, 0xc4, 0xab, 0x3a, 0x6b, 0xcb, 0x27, 0xeb, 0xdf, 0x06, 0xc0, 0x11, 0x21, 0x5d,
+	0xec, 0x9e, 0xfe, 0xfc, 0xe8, 0x00, 0x3d, 0x82, 0x3b, 0x84, 0xdf, 0x63, 0x22, 0x7d, 0x4c, 0x1e,
+	0xd8, 0x48, 0xfc, 0x72, 0xf3, 0x1a, 0x14, 0x46, 0x3b, 0x55, 0x7f, 0xed, 0x4c, 0xc1, 0xc3, 0x8c,
+	0x18, 0x9b, 0x13, 0xf7, 0x9d, 0x05, 0x2c, 0xfb, 0xc6, 0x80, 0xc3, 0x5c, 0x9c, 0x03, 0x5a, 0x07,
+	0x08, 0xf1, 0x55, 0x70, 0x81, 0xdd, 0xba, 0x13, 0x69, 0x6d, 0x05, 0x39, 0xb2, 0x4f, 0xad, 0x17,
+	0x50, 0x1c, 0x30, 0x64, 0x1a, 0xda, 0x2d, 0x72, 0xe5, 0xb9, 0x51, 0xe1, 0x58, 0xba, 0xaf, 0xf6,
+	0x00, 0x63, 0x0b, 0x80, 0xf5, 0x95, 0x01, 0xc0, 0x93, 0x76, 0x78, 0x85, 0x7d, 0xca, 0x99, 0x5e,
+	0xc5, 0x3a, 0xf2, 0x9c, 0xbd, 0xc0, 0x9f, 0x47, 0x16, 0x31, 0x33, 0xbc, 0x88, 0xc7, 0xb0, 0xe4,
+	0x07, 0x2e, 0x8e, 0xf5, 0x6a, 0xb1, 0xca, 0x45, 0x36, 0xda, 0xbf, 0x2b, 0xae, 0x03, 0x34, 0xf9,
+	0x67, 0x99, 0x2f, 0x49, 0x2c, 0xb7, 0x20, 0x47, 0xf6, 0xa9, 0xf5, 0x63, 0x58, 0x63, 0x1b, 0x37,
+	0x20, 0xd3, 0x2f, 0xab, 0xc7, 0xb0, 0xe4, 0xb4, 0x28, 0x0e, 0xeb, 0x23, 0xd4, 0x16, 0xf9, 0xe8,
+	0xa1, 0xe0, 0x67, 0x7d, 0x02, 0x0f, 0x12, 0x78, 0x59, 0x5e, 0xcf, 0x61, 0x9e, 0x43, 0xc7, 0xe6,
+	0x67, 0x00, 0xb6, 0x25, 0x62, 0xf7, 0x1f, 0x8f, 0xa0, 0xc0, 0x54, 0xb9, 0x53, 0x66, 0x80, 0x5e,
+	0xc1, 0xa2, 0x38, 0x5a, 0x48, 0xf9, 0x76, 0x8c, 0x56, 0x69, 0x8e, 0x99, 0x67, 0xfe, 0x44, 0xaf,
+	0x9f, 0x9c, 0xbf, 0xfd, 0x4e, 0x07, 0xfb, 0xee, 0xe4, 0xfc, 0x89, 0x6e, 0x3e, 0x21, 0x7f, 0xc7,
+	0x50, 0xe4, 0xa7, 0x91, 0x09, 0xb9, 0xab, 0x41, 0x91, 0xed, 0x79, 0x24, 0x25, 0xdf, 0x1d, 0xee,
+	0x14, 0x87, 0x97, 0x1d, 0xda, 0x33, 0x37, 0xd3, 0x7d, 0x10, 0xf4, 0x67, 0x03, 0x1e, 0x68, 0xe4,
+	0x3e, 0xf4, 0x54, 0x07, 0x4e, 0x97, 0x26, 0xcd, 0xbd, 0xdc, 0x38, 0x59, 0xaa, 0x5f, 0x19, 0xb0,
+	0xa6, 0x96, 0xee, 0xd0, 0x7b, 0x3a, 0x9f, 0xa9, 0x7a, 0xa1, 0xf9, 0x34, 0x2f, 0x4c, 0x32, 0xf9,
+	0xa3, 0x01, 0xf7, 0x95, 0x42, 0x1d, 0x7a, 0x37, 0xd5, 0xa3, 0x46, 0xf8, 0x33, 0xdf, 0xcb, 0x89,
+	0x92, 0x34, 0xd8, 0xee, 0x68, 0xa4, 0x38, 0xfd, 0xee, 0xa4, 0xcb, 0x7b, 0xe6, 0x5e, 0x6e, 0x9c,
+	0x24, 0xf3, 0xb5, 0x01, 0x25, 0x9d, 0xe0, 0x86, 0xf4, 0x7b, 0x9e, 0xae, 0xe2, 0x99, 0xd5, 0xfc,
+	0xc0, 0x58, 0x72, 0x34, 0xf2, 0x95, 0x3e, 0x39, 0xe9, 0xb2, 0x9f, 0xb9, 0x97, 0x1b, 0x17, 0x23,
+	0xa3, 0x51, 0xa2, 0xf4, 0x64, 0xd2, 0x05, 0x30, 0x73, 0x2f, 0x37, 0x4e, 0x92, 0xf9, 0xbb, 0x01,
+	0xa6, 0x5e, 0x11, 0x42, 0xcf, 0xd2, 0xdf, 0xcf, 0x14, 0x91, 0xc3, 0x7c, 0x7e, 0x1d, 0xa8, 0x64,
+	0xf5, 0x8d, 0x01, 0x0f, 0xb5, 0xb2, 0x0e, 0xaa, 0xa6, 0xbe, 0x21, 0x69, 0x9c, 0x9e, 0x5d, 0x03,
+	0x19, 0x4b, 0x94, 0x5e, 0x51, 0xd1, 0x27, 0x6a, 0xac, 0x1a, 0x64, 0x3e, 0xbf, 0x0e, 0x54, 0xb2,
+	0xfa, 0x97, 0x01, 0xeb, 0xa9, 0x1a, 0x06, 0xfa, 0xa1, 0xce, 0x7b, 0x16, 0x35, 0xc6, 0xfc, 0xd1,
+	0x35, 0xd1, 0xb1, 0x52, 0xd7, 0x48, 0x0c, 0xe3, 0x3e, 0x19, 0xba, 0x6b, 0x96, 0xb9, 0x97, 0x1b,
+	0x37, 0xfa, 0xc9, 0x48, 0x72, 0x49, 0xef, 0xb9, 0x5a, 0x2a, 0x4f, 0xf3, 0xc2, 0x24, 0x13, 0x0f,
+	0x4a, 0x3a, 0xad, 0x41, 0xfd, 0x6d, 0xae, 0xe6, 0x0a, 0xa4, 0xec, 0xc4, 0xaa, 0x58, 0xe9, 0x9d,
+	0x58, 0x2f, 0x4a, 0x98, 0xd5, 0xfc, 0xc0, 0x44, 0x27, 0xce, 0x51, 0x11, 0xe9, 0x0a, 0x84, 0xb9,
+	0x97, 0x1b, 0x97, 0xe8, 0xc4, 0x39, 0xc8, 0xa4, 0xab, 0x00, 0xe6, 0x5e, 0x6e, 0x9c, 0x24, 0xf3,
+	0x3b, 0xb8, 0xab, 0xb8, 0x68, 0xa3, 0xdd, 0xb4, 0x6f, 0xb0, 0xfa, 0x3e, 0x6f, 0x3e, 0xc9, 0x85,
+	0x19, 0x8e, 0x3f, 0x72, 0x45, 0x4e, 0x8f, 0xaf, 0xbe, 0xa3, 0x9b, 0x4f, 0x72, 0x61, 0x86, 0xe3,
+	0x1f, 0x3b, 0xb4, 0x79, 0xee, 0xf9, 0x67, 0x37, 0x1e, 0xff, 0x0b, 0x58, 0x4d, 0x5c, 0xbc, 0xd1,
+	0xdb, 0xa9, 0x9e, 0x14, 0x77, 0x7b, 0xf3, 0x9d, 0x1c, 0x08, 0x19, 0x39, 0x84, 0xe5, 0x91, 0x1b,
+	0x19, 0xaa, 0xa4, 0x79, 0x49, 0x5e, 0xfd, 0xcc, 0x9d, 0xcc, 0xf6, 0x32, 0xe6, 0x4b, 0x58, 0x39,
+	0x09, 0xbb, 0x3e, 0x8e, 0x07, 0xcd, 0x70, 0xdd, 0x33, 0x55, 0xed, 0x09, 0xbd, 0x80, 0x3b, 0xb6,
+	0x94, 0x16, 0x84, 0x8e, 0xb0, 0xa5, 0xf3, 0xd4, 0x97, 0x1a, 0xd4, 0x8e, 0x6c, 0x00, 0xde, 0xd1,
+	0x32, 0x7b, 0x19, 0x6f, 0x82, 0x0e, 0xa1, 0x28, 0x5e, 0xbd, 0x6f, 0x47, 0xed, 0x10, 0x8a, 0x3c,
+	0x61, 0xdc, 0x84, 0x5c, 0xdb, 0xcd, 0xa7, 0xb0, 0x22, 0xbe, 0x53, 0x31, 0xe1, 0x24, 0x83, 0x0c,
+	0x61, 0x66, 0xb0, 0x41, 0xbf, 0x84, 0x65, 0x9e, 0xbd, 0x29, 0xb8, 0xfe, 0x15, 0xac, 0xda, 0x5c,
+	0x54, 0x89, 0xab, 0x29, 0x59, 0x9c, 0x3f, 0x1a, 0x6f, 0x43, 0xd0, 0x87, 0xb0, 0xc2, 0xea, 0x54,
+	0x44, 0x90, 0x63, 0xca, 0xef, 0x60, 0x26, 0x6f, 0x51, 0x69, 0xe7, 0xa5, 0xaa, 0xd9, 0xaf, 0x42,
+	0x2d, 0xf0, 0x5b, 0xde, 0x59, 0x37, 0xc4, 0xe8, 0xf5, 0x61, 0x0b, 0xf9, 0xef, 0x6d, 0xfd, 0xf9,
+	0xe8, 0x65, 0xfc, 0xde, 0x38, 0x33, 0xf9, 0x0e, 0xb6, 0xe0, 0xce, 0x0b, 0x4c, 0x4f, 0xf8, 0xf4,
+	0x91, 0xdf, 0x0a, 0xd0, 0x1b, 0x4a, 0xe0, 0x90, 0x4d, 0x14, 0xe3, 0xcd, 0x2c, 0xa6, 0x22, 0xce,
+	0xfb, 0xc5, 0x4f, 0x0b, 0xfd, 0x05, 0x9f, 0x7c, 0xe7, 0xc4, 0x68, 0xcc, 0xf3, 0x7f, 0xaf, 0x7b,
+	0xf2, 0xbf, 0x01, 0x00, 0x66, 0xad, 0x74, 0x25, 0xf6, 0x27, 0x00, 0x00,
 }
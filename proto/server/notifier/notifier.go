@@ -0,0 +1,130 @@
+package notifier
+
+import (
+	"context"
+	"net/rpc"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	go_plugin "github.com/hashicorp/go-plugin"
+	"github.com/spiffe/spire/proto/common/plugin"
+	"google.golang.org/grpc"
+)
+
+// Notifier is the interface used by all non-catalog components.
+type Notifier interface {
+	Notify(context.Context, *NotifyRequest) (*NotifyResponse, error)
+	NotifyAndAdvise(context.Context, *NotifyAndAdviseRequest) (*NotifyAndAdviseResponse, error)
+}
+
+// Plugin is the interface implemented by plugin implementations
+type Plugin interface {
+	Notify(context.Context, *NotifyRequest) (*NotifyResponse, error)
+	NotifyAndAdvise(context.Context, *NotifyAndAdviseRequest) (*NotifyAndAdviseResponse, error)
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+type BuiltIn struct {
+	plugin Plugin
+}
+
+var _ Notifier = (*BuiltIn)(nil)
+
+func NewBuiltIn(plugin Plugin) *BuiltIn {
+	return &BuiltIn{
+		plugin: plugin,
+	}
+}
+
+func (b BuiltIn) Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	resp, err := b.plugin.Notify(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) NotifyAndAdvise(ctx context.Context, req *NotifyAndAdviseRequest) (*NotifyAndAdviseResponse, error) {
+	resp, err := b.plugin.NotifyAndAdvise(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	resp, err := b.plugin.Configure(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b BuiltIn) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	resp, err := b.plugin.GetPluginInfo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+var Handshake = go_plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "Notifier",
+	MagicCookieValue: "Notifier",
+}
+
+type GRPCPlugin struct {
+	ServerImpl NotifierServer
+}
+
+func (p GRPCPlugin) Server(*go_plugin.MuxBroker) (interface{}, error) {
+	return empty.Empty{}, nil
+}
+
+func (p GRPCPlugin) Client(b *go_plugin.MuxBroker, c *rpc.Client) (interface{}, error) {
+	return empty.Empty{}, nil
+}
+
+func (p GRPCPlugin) GRPCServer(s *grpc.Server) error {
+	RegisterNotifierServer(s, p.ServerImpl)
+	return nil
+}
+
+func (p GRPCPlugin) GRPCClient(c *grpc.ClientConn) (interface{}, error) {
+	return &GRPCClient{client: NewNotifierClient(c)}, nil
+}
+
+type GRPCServer struct {
+	Plugin Plugin
+}
+
+func (s *GRPCServer) Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	return s.Plugin.Notify(ctx, req)
+}
+func (s *GRPCServer) NotifyAndAdvise(ctx context.Context, req *NotifyAndAdviseRequest) (*NotifyAndAdviseResponse, error) {
+	return s.Plugin.NotifyAndAdvise(ctx, req)
+}
+func (s *GRPCServer) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return s.Plugin.Configure(ctx, req)
+}
+func (s *GRPCServer) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return s.Plugin.GetPluginInfo(ctx, req)
+}
+
+type GRPCClient struct {
+	client NotifierClient
+}
+
+func (c *GRPCClient) Notify(ctx context.Context, req *NotifyRequest) (*NotifyResponse, error) {
+	return c.client.Notify(ctx, req)
+}
+func (c *GRPCClient) NotifyAndAdvise(ctx context.Context, req *NotifyAndAdviseRequest) (*NotifyAndAdviseResponse, error) {
+	return c.client.NotifyAndAdvise(ctx, req)
+}
+func (c *GRPCClient) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	return c.client.Configure(ctx, req)
+}
+func (c *GRPCClient) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return c.client.GetPluginInfo(ctx, req)
+}
@@ -0,0 +1,612 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: notifier.proto
+
+package notifier
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/spiffe/spire/proto/common"
+import plugin "github.com/spiffe/spire/proto/common/plugin"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ConfigureRequest from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type ConfigureRequest = plugin.ConfigureRequest
+
+// ConfigureResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type ConfigureResponse = plugin.ConfigureResponse
+
+// GetPluginInfoRequest from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type GetPluginInfoRequest = plugin.GetPluginInfoRequest
+
+// GetPluginInfoResponse from public import github.com/spiffe/spire/proto/common/plugin/plugin.proto
+type GetPluginInfoResponse = plugin.GetPluginInfoResponse
+
+// Empty from public import github.com/spiffe/spire/proto/common/common.proto
+type Empty = common.Empty
+
+// AttestationData from public import github.com/spiffe/spire/proto/common/common.proto
+type AttestationData = common.AttestationData
+
+// Selector from public import github.com/spiffe/spire/proto/common/common.proto
+type Selector = common.Selector
+
+// Selectors from public import github.com/spiffe/spire/proto/common/common.proto
+type Selectors = common.Selectors
+
+// RegistrationEntry from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntry = common.RegistrationEntry
+
+// RegistrationEntries from public import github.com/spiffe/spire/proto/common/common.proto
+type RegistrationEntries = common.RegistrationEntries
+
+// PublicKey from public import github.com/spiffe/spire/proto/common/common.proto
+type PublicKey = common.PublicKey
+
+// * Represents a trust bundle, of the server or of a federated trust domain.
+type Bundle struct {
+	// * SPIFFE ID of the trust domain.
+	TrustDomain string `protobuf:"bytes,1,opt,name=trust_domain,json=trustDomain" json:"trust_domain,omitempty"`
+	// * CA certificates (ASN.1 DER encoded).
+	CaCerts []byte `protobuf:"bytes,2,opt,name=ca_certs,json=caCerts,proto3" json:"ca_certs,omitempty"`
+	// * JWT signing keys.
+	JwtSigningKeys []*common.PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys" json:"jwt_signing_keys,omitempty"`
+	// * Sequence number of the bundle.
+	SequenceNumber       uint64   `protobuf:"varint,4,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Bundle) Reset()         { *m = Bundle{} }
+func (m *Bundle) String() string { return proto.CompactTextString(m) }
+func (*Bundle) ProtoMessage()    {}
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{0}
+}
+func (m *Bundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Bundle.Unmarshal(m, b)
+}
+func (m *Bundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Bundle.Marshal(b, m, deterministic)
+}
+func (dst *Bundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Bundle.Merge(dst, src)
+}
+func (m *Bundle) XXX_Size() int {
+	return xxx_messageInfo_Bundle.Size(m)
+}
+func (m *Bundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Bundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Bundle proto.InternalMessageInfo
+
+func (m *Bundle) GetTrustDomain() string {
+	if m != nil {
+		return m.TrustDomain
+	}
+	return ""
+}
+
+func (m *Bundle) GetCaCerts() []byte {
+	if m != nil {
+		return m.CaCerts
+	}
+	return nil
+}
+
+func (m *Bundle) GetJwtSigningKeys() []*common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKeys
+	}
+	return nil
+}
+
+func (m *Bundle) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
+}
+
+// * Represents an update of a trust bundle.
+type BundleUpdated struct {
+	// * Trust bundle, once updated.
+	Bundle               *Bundle  `protobuf:"bytes,1,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BundleUpdated) Reset()         { *m = BundleUpdated{} }
+func (m *BundleUpdated) String() string { return proto.CompactTextString(m) }
+func (*BundleUpdated) ProtoMessage()    {}
+func (*BundleUpdated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{1}
+}
+func (m *BundleUpdated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BundleUpdated.Unmarshal(m, b)
+}
+func (m *BundleUpdated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BundleUpdated.Marshal(b, m, deterministic)
+}
+func (dst *BundleUpdated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BundleUpdated.Merge(dst, src)
+}
+func (m *BundleUpdated) XXX_Size() int {
+	return xxx_messageInfo_BundleUpdated.Size(m)
+}
+func (m *BundleUpdated) XXX_DiscardUnknown() {
+	xxx_messageInfo_BundleUpdated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BundleUpdated proto.InternalMessageInfo
+
+func (m *BundleUpdated) GetBundle() *Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// * Represents the activation of a new CA.
+type CARotated struct {
+	// * Certificate of the new CA (ASN.1 DER encoded).
+	CaCert []byte `protobuf:"bytes,1,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`
+	// * Certificate of the CA it replaces (ASN.1 DER encoded). Not set if the server had no active CA.
+	PrevCaCert []byte `protobuf:"bytes,2,opt,name=prev_ca_cert,json=prevCaCert,proto3" json:"prev_ca_cert,omitempty"`
+	// * Trust bundle the new CA is published in.
+	Bundle               *Bundle  `protobuf:"bytes,3,opt,name=bundle" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CARotated) Reset()         { *m = CARotated{} }
+func (m *CARotated) String() string { return proto.CompactTextString(m) }
+func (*CARotated) ProtoMessage()    {}
+func (*CARotated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{2}
+}
+func (m *CARotated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CARotated.Unmarshal(m, b)
+}
+func (m *CARotated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CARotated.Marshal(b, m, deterministic)
+}
+func (dst *CARotated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CARotated.Merge(dst, src)
+}
+func (m *CARotated) XXX_Size() int {
+	return xxx_messageInfo_CARotated.Size(m)
+}
+func (m *CARotated) XXX_DiscardUnknown() {
+	xxx_messageInfo_CARotated.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CARotated proto.InternalMessageInfo
+
+func (m *CARotated) GetCaCert() []byte {
+	if m != nil {
+		return m.CaCert
+	}
+	return nil
+}
+
+func (m *CARotated) GetPrevCaCert() []byte {
+	if m != nil {
+		return m.PrevCaCert
+	}
+	return nil
+}
+
+func (m *CARotated) GetBundle() *Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// * Represents an event that has taken effect. Exactly one field is set.
+type NotifyRequest struct {
+	// * Set if the trust bundle of the server, or of a federated trust domain, has been updated.
+	BundleUpdated *BundleUpdated `protobuf:"bytes,1,opt,name=bundle_updated,json=bundleUpdated" json:"bundle_updated,omitempty"`
+	// * Set if a new CA has been activated.
+	CaRotated            *CARotated `protobuf:"bytes,2,opt,name=ca_rotated,json=caRotated" json:"ca_rotated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *NotifyRequest) Reset()         { *m = NotifyRequest{} }
+func (m *NotifyRequest) String() string { return proto.CompactTextString(m) }
+func (*NotifyRequest) ProtoMessage()    {}
+func (*NotifyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{3}
+}
+func (m *NotifyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyRequest.Unmarshal(m, b)
+}
+func (m *NotifyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyRequest.Marshal(b, m, deterministic)
+}
+func (dst *NotifyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyRequest.Merge(dst, src)
+}
+func (m *NotifyRequest) XXX_Size() int {
+	return xxx_messageInfo_NotifyRequest.Size(m)
+}
+func (m *NotifyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyRequest proto.InternalMessageInfo
+
+func (m *NotifyRequest) GetBundleUpdated() *BundleUpdated {
+	if m != nil {
+		return m.BundleUpdated
+	}
+	return nil
+}
+
+func (m *NotifyRequest) GetCaRotated() *CARotated {
+	if m != nil {
+		return m.CaRotated
+	}
+	return nil
+}
+
+// * Represents an empty response.
+type NotifyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotifyResponse) Reset()         { *m = NotifyResponse{} }
+func (m *NotifyResponse) String() string { return proto.CompactTextString(m) }
+func (*NotifyResponse) ProtoMessage()    {}
+func (*NotifyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{4}
+}
+func (m *NotifyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyResponse.Unmarshal(m, b)
+}
+func (m *NotifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyResponse.Marshal(b, m, deterministic)
+}
+func (dst *NotifyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyResponse.Merge(dst, src)
+}
+func (m *NotifyResponse) XXX_Size() int {
+	return xxx_messageInfo_NotifyResponse.Size(m)
+}
+func (m *NotifyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyResponse proto.InternalMessageInfo
+
+// * Represents an event about to take effect. Exactly one field is set.
+type NotifyAndAdviseRequest struct {
+	// * Set if the trust bundle of the server is about to be updated, e.g. with a new CA or JWT signing key, which is neither stored nor used unless the plugin returns successfully.
+	BundleUpdated *BundleUpdated `protobuf:"bytes,1,opt,name=bundle_updated,json=bundleUpdated" json:"bundle_updated,omitempty"`
+	// * Set if a new CA is about to be activated.
+	CaRotated            *CARotated `protobuf:"bytes,2,opt,name=ca_rotated,json=caRotated" json:"ca_rotated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *NotifyAndAdviseRequest) Reset()         { *m = NotifyAndAdviseRequest{} }
+func (m *NotifyAndAdviseRequest) String() string { return proto.CompactTextString(m) }
+func (*NotifyAndAdviseRequest) ProtoMessage()    {}
+func (*NotifyAndAdviseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{5}
+}
+func (m *NotifyAndAdviseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyAndAdviseRequest.Unmarshal(m, b)
+}
+func (m *NotifyAndAdviseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyAndAdviseRequest.Marshal(b, m, deterministic)
+}
+func (dst *NotifyAndAdviseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyAndAdviseRequest.Merge(dst, src)
+}
+func (m *NotifyAndAdviseRequest) XXX_Size() int {
+	return xxx_messageInfo_NotifyAndAdviseRequest.Size(m)
+}
+func (m *NotifyAndAdviseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyAndAdviseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyAndAdviseRequest proto.InternalMessageInfo
+
+func (m *NotifyAndAdviseRequest) GetBundleUpdated() *BundleUpdated {
+	if m != nil {
+		return m.BundleUpdated
+	}
+	return nil
+}
+
+func (m *NotifyAndAdviseRequest) GetCaRotated() *CARotated {
+	if m != nil {
+		return m.CaRotated
+	}
+	return nil
+}
+
+// * Represents an empty response. The event takes effect unless an error is returned.
+type NotifyAndAdviseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NotifyAndAdviseResponse) Reset()         { *m = NotifyAndAdviseResponse{} }
+func (m *NotifyAndAdviseResponse) String() string { return proto.CompactTextString(m) }
+func (*NotifyAndAdviseResponse) ProtoMessage()    {}
+func (*NotifyAndAdviseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_notifier_c4c8623300fb45fe, []int{6}
+}
+func (m *NotifyAndAdviseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyAndAdviseResponse.Unmarshal(m, b)
+}
+func (m *NotifyAndAdviseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyAndAdviseResponse.Marshal(b, m, deterministic)
+}
+func (dst *NotifyAndAdviseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyAndAdviseResponse.Merge(dst, src)
+}
+func (m *NotifyAndAdviseResponse) XXX_Size() int {
+	return xxx_messageInfo_NotifyAndAdviseResponse.Size(m)
+}
+func (m *NotifyAndAdviseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyAndAdviseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyAndAdviseResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Bundle)(nil), "spire.server.notifier.Bundle")
+	proto.RegisterType((*BundleUpdated)(nil), "spire.server.notifier.BundleUpdated")
+	proto.RegisterType((*CARotated)(nil), "spire.server.notifier.CARotated")
+	proto.RegisterType((*NotifyRequest)(nil), "spire.server.notifier.NotifyRequest")
+	proto.RegisterType((*NotifyResponse)(nil), "spire.server.notifier.NotifyResponse")
+	proto.RegisterType((*NotifyAndAdviseRequest)(nil), "spire.server.notifier.NotifyAndAdviseRequest")
+	proto.RegisterType((*NotifyAndAdviseResponse)(nil), "spire.server.notifier.NotifyAndAdviseResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Notifier service
+
+type NotifierClient interface {
+	// * Notifies the plugin of an event once it has taken effect. Errors are logged but otherwise ignored.
+	Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error)
+	// * Notifies the plugin of an event before it takes effect. The event is called off if an error is returned.
+	NotifyAndAdvise(ctx context.Context, in *NotifyAndAdviseRequest, opts ...grpc.CallOption) (*NotifyAndAdviseResponse, error)
+	// * Responsible for configuration of the plugin.
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	// * Returns the  version and related metadata of the installed plugin.
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type notifierClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNotifierClient(cc *grpc.ClientConn) NotifierClient {
+	return &notifierClient{cc}
+}
+
+func (c *notifierClient) Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error) {
+	out := new(NotifyResponse)
+	err := grpc.Invoke(ctx, "/spire.server.notifier.Notifier/Notify", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierClient) NotifyAndAdvise(ctx context.Context, in *NotifyAndAdviseRequest, opts ...grpc.CallOption) (*NotifyAndAdviseResponse, error) {
+	out := new(NotifyAndAdviseResponse)
+	err := grpc.Invoke(ctx, "/spire.server.notifier.Notifier/NotifyAndAdvise", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := grpc.Invoke(ctx, "/spire.server.notifier.Notifier/Configure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notifierClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := grpc.Invoke(ctx, "/spire.server.notifier.Notifier/GetPluginInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Notifier service
+
+type NotifierServer interface {
+	// * Notifies the plugin of an event once it has taken effect. Errors are logged but otherwise ignored.
+	Notify(context.Context, *NotifyRequest) (*NotifyResponse, error)
+	// * Notifies the plugin of an event before it takes effect. The event is called off if an error is returned.
+	NotifyAndAdvise(context.Context, *NotifyAndAdviseRequest) (*NotifyAndAdviseResponse, error)
+	// * Responsible for configuration of the plugin.
+	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
+	// * Returns the  version and related metadata of the installed plugin.
+	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
+}
+
+func RegisterNotifierServer(s *grpc.Server, srv NotifierServer) {
+	s.RegisterService(&_Notifier_serviceDesc, srv)
+}
+
+func _Notifier_Notify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServer).Notify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.notifier.Notifier/Notify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServer).Notify(ctx, req.(*NotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifier_NotifyAndAdvise_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyAndAdviseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServer).NotifyAndAdvise(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.notifier.Notifier/NotifyAndAdvise",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServer).NotifyAndAdvise(ctx, req.(*NotifyAndAdviseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifier_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.notifier.Notifier/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServer).Configure(ctx, req.(*plugin.ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notifier_GetPluginInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(plugin.GetPluginInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotifierServer).GetPluginInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.notifier.Notifier/GetPluginInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotifierServer).GetPluginInfo(ctx, req.(*plugin.GetPluginInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Notifier_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "spire.server.notifier.Notifier",
+	HandlerType: (*NotifierServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Notify",
+			Handler:    _Notifier_Notify_Handler,
+		},
+		{
+			MethodName: "NotifyAndAdvise",
+			Handler:    _Notifier_NotifyAndAdvise_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _Notifier_Configure_Handler,
+		},
+		{
+			MethodName: "GetPluginInfo",
+			Handler:    _Notifier_GetPluginInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "notifier.proto",
+}
+
+func init() { proto.RegisterFile("notifier.proto", fileDescriptor_notifier_c4c8623300fb45fe) }
+
+var fileDescriptor_notifier_c4c8623300fb45fe = []byte{
+	// 523 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xcd, 0x8a, 0x13, 0x4d,
+	0x14, 0xfd, 0xfa, 0xcb, 0x90, 0x49, 0x6e, 0x7e, 0x66, 0x28, 0xd0, 0x64, 0x02, 0x42, 0x1b, 0x1c,
+	0x8d, 0x82, 0x1d, 0x8c, 0x08, 0xee, 0x24, 0x13, 0x51, 0x24, 0x10, 0x42, 0xcb, 0x6c, 0x66, 0xd3,
+	0xf4, 0xcf, 0xed, 0x58, 0x63, 0xba, 0xaa, 0xad, 0xaa, 0xce, 0x90, 0x85, 0xcf, 0xe1, 0xca, 0xad,
+	0x0f, 0xe0, 0x13, 0x4a, 0xaa, 0xaa, 0x47, 0x32, 0xce, 0x4f, 0xdc, 0xb9, 0xaa, 0xee, 0x7b, 0xcf,
+	0xb9, 0xe7, 0x9c, 0xdb, 0x74, 0x41, 0x9b, 0x71, 0x45, 0x53, 0x8a, 0xc2, 0xcb, 0x05, 0x57, 0x9c,
+	0xdc, 0x93, 0x39, 0x15, 0xe8, 0x49, 0x14, 0x2b, 0x14, 0x5e, 0xd9, 0xec, 0xbd, 0x5e, 0x50, 0xf5,
+	0xa9, 0x88, 0xbc, 0x98, 0x67, 0x43, 0x99, 0xd3, 0x34, 0xc5, 0xa1, 0x06, 0x0e, 0x35, 0x6b, 0x18,
+	0xf3, 0x2c, 0xe3, 0x6c, 0x98, 0x2f, 0x8b, 0x05, 0x2d, 0x0f, 0x33, 0xb0, 0xf7, 0x62, 0x27, 0xa6,
+	0x39, 0x0c, 0xa5, 0xff, 0xd3, 0x81, 0xea, 0x49, 0xc1, 0x92, 0x25, 0x92, 0x87, 0xd0, 0x54, 0xa2,
+	0x90, 0x2a, 0x48, 0x78, 0x16, 0x52, 0xd6, 0x75, 0x5c, 0x67, 0x50, 0xf7, 0x1b, 0xba, 0xf6, 0x56,
+	0x97, 0xc8, 0x11, 0xd4, 0xe2, 0x30, 0x88, 0x51, 0x28, 0xd9, 0xfd, 0xdf, 0x75, 0x06, 0x4d, 0x7f,
+	0x3f, 0x0e, 0x27, 0x9b, 0x57, 0x32, 0x86, 0xc3, 0xf3, 0x0b, 0x15, 0x48, 0xba, 0x60, 0x94, 0x2d,
+	0x82, 0xcf, 0xb8, 0x96, 0xdd, 0x8a, 0x5b, 0x19, 0x34, 0x46, 0x1d, 0xcf, 0xe4, 0xb4, 0xba, 0xf3,
+	0x22, 0x5a, 0xd2, 0x78, 0x8a, 0x6b, 0xbf, 0x7d, 0x7e, 0xa1, 0x3e, 0x1a, 0xfc, 0x14, 0xd7, 0x92,
+	0x3c, 0x81, 0x03, 0x89, 0x5f, 0x0a, 0x64, 0x31, 0x06, 0xac, 0xc8, 0x22, 0x14, 0xdd, 0x3d, 0xd7,
+	0x19, 0xec, 0xf9, 0xed, 0xb2, 0x3c, 0xd3, 0xd5, 0xfe, 0x3b, 0x68, 0x19, 0xcf, 0xa7, 0x79, 0x12,
+	0x2a, 0x4c, 0xc8, 0x2b, 0xa8, 0x46, 0xba, 0xa0, 0x4d, 0x37, 0x46, 0x0f, 0xbc, 0x6b, 0x57, 0xeb,
+	0x19, 0x96, 0x6f, 0xc1, 0xfd, 0xaf, 0x50, 0x9f, 0x8c, 0x7d, 0xae, 0xf4, 0x8c, 0x0e, 0xec, 0xdb,
+	0x6c, 0x7a, 0x48, 0xd3, 0xaf, 0x9a, 0x68, 0xc4, 0x85, 0x66, 0x2e, 0x70, 0x15, 0x94, 0x5d, 0x13,
+	0x1c, 0x36, 0xb5, 0x89, 0x41, 0xfc, 0x96, 0xaf, 0xfc, 0x8d, 0xfc, 0x77, 0x07, 0x5a, 0xb3, 0x4d,
+	0x6f, 0xed, 0x6f, 0xf2, 0x49, 0x45, 0xa6, 0xd0, 0x36, 0xbd, 0xa0, 0x30, 0xc9, 0x6c, 0x9e, 0x47,
+	0xb7, 0x0e, 0xb4, 0x5b, 0xf0, 0x5b, 0xd1, 0xd6, 0x52, 0xde, 0x00, 0xc4, 0x61, 0x20, 0x4c, 0x3c,
+	0xed, 0xba, 0x31, 0x72, 0x6f, 0x18, 0x74, 0xb9, 0x06, 0xbf, 0x1e, 0x87, 0xf6, 0xb1, 0x7f, 0x08,
+	0xed, 0xd2, 0x9e, 0xcc, 0x39, 0x93, 0xd8, 0xff, 0xe1, 0xc0, 0x7d, 0x53, 0x1a, 0xb3, 0x64, 0x9c,
+	0xac, 0xa8, 0xc4, 0x7f, 0xd3, 0xfa, 0x11, 0x74, 0xfe, 0xf0, 0x69, 0x32, 0x8c, 0xbe, 0x55, 0xa0,
+	0x36, 0xb3, 0x64, 0x72, 0x0a, 0x55, 0x83, 0x23, 0x37, 0xf9, 0xdc, 0xfa, 0x40, 0xbd, 0xe3, 0x3b,
+	0x50, 0x46, 0x83, 0xe4, 0x70, 0x70, 0x45, 0x9e, 0x3c, 0xbf, 0x95, 0x79, 0x75, 0x9d, 0x3d, 0x6f,
+	0x57, 0xb8, 0x55, 0x3c, 0x83, 0xfa, 0x84, 0xb3, 0x94, 0x2e, 0x0a, 0x81, 0xe4, 0x78, 0xfb, 0x8f,
+	0xb3, 0x77, 0xc4, 0x65, 0xbf, 0xd4, 0x78, 0x7c, 0x17, 0xcc, 0xce, 0x4e, 0xa1, 0xf5, 0x1e, 0xd5,
+	0x5c, 0xb7, 0x3f, 0xb0, 0x94, 0x93, 0xa7, 0xd7, 0x12, 0xb7, 0x30, 0xa5, 0xc6, 0xb3, 0x5d, 0xa0,
+	0x46, 0xe7, 0x04, 0xce, 0x6a, 0x65, 0xce, 0xf9, 0x7f, 0x73, 0x27, 0xaa, 0xea, 0x2b, 0xea, 0xe5,
+	0xaf, 0x01, 0x00, 0x0f, 0xdc, 0x8c, 0x04, 0x38, 0x05, 0x00, 0x00,
+}
@@ -0,0 +1,484 @@
+package fakeacme
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+var (
+	// id-pe-acmeIdentifier, carrying the key authorization digest in the
+	// tls-alpn-01 challenge certificate
+	acmeIdentifierOID = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+)
+
+// CA is an ACME CA serving the subset of RFC 8555 the autocert manager
+// relies on. Domains are validated through the tls-alpn-01 challenge, by
+// dialing the address they were resolved to with Resolve, and checking the
+// key authorization of the challenge certificate.
+type CA struct {
+	// URL of the ACME directory
+	URL string
+
+	// Roots the issued certificates chain up to
+	Roots *x509.CertPool
+
+	server   *httptest.Server
+	rootKey  *ecdsa.PrivateKey
+	rootCert *x509.Certificate
+
+	mu         sync.Mutex
+	thumbprint string
+	addrs      map[string]string
+	authzs     map[string]*authorization
+	orders     []*order
+	issued     int
+}
+
+type authorization struct {
+	Status     string      `json:"status"`
+	Identifier identifier  `json:"identifier"`
+	Challenges []challenge `json:"challenges"`
+}
+
+type identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type challenge struct {
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	Token  string `json:"token"`
+	Status string `json:"status"`
+}
+
+type order struct {
+	Status         string       `json:"status"`
+	Identifiers    []identifier `json:"identifiers"`
+	Authorizations []string     `json:"authorizations"`
+	Finalize       string       `json:"finalize"`
+	Certificate    string       `json:"certificate,omitempty"`
+
+	chain [][]byte
+}
+
+// New starts a CA, which is stopped with Close
+func New() (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "FAKEACME"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to self-sign certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &CA{
+		Roots:    x509.NewCertPool(),
+		rootKey:  key,
+		rootCert: cert,
+		addrs:    make(map[string]string),
+		authzs:   make(map[string]*authorization),
+	}
+	ca.Roots.AddCert(cert)
+	ca.server = httptest.NewServer(http.HandlerFunc(ca.handle))
+	ca.URL = ca.server.URL + "/directory"
+	return ca, nil
+}
+
+// Close stops the CA
+func (ca *CA) Close() {
+	ca.server.Close()
+}
+
+// Resolve sets the address the domain is validated at
+func (ca *CA) Resolve(domain, addr string) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	ca.addrs[domain] = addr
+}
+
+// Issued returns the number of certificates issued so far
+func (ca *CA) Issued() int {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return ca.issued
+}
+
+func (ca *CA) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", strconv.FormatInt(time.Now().UnixNano(), 10))
+
+	path := r.URL.Path
+	switch {
+	case path == "/directory":
+		writeJSON(w, http.StatusOK, map[string]string{
+			"newNonce":   ca.url("/new-nonce"),
+			"newAccount": ca.url("/new-account"),
+			"newOrder":   ca.url("/new-order"),
+		})
+	case path == "/new-nonce":
+		w.WriteHeader(http.StatusOK)
+	case path == "/new-account":
+		ca.newAccount(w, r)
+	case path == "/new-order":
+		ca.newOrder(w, r)
+	case strings.HasPrefix(path, "/authz/"):
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		authz, ok := ca.authzs[strings.TrimPrefix(path, "/authz/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, authz)
+	case strings.HasPrefix(path, "/challenge/"):
+		ca.acceptChallenge(w, r, strings.TrimPrefix(path, "/challenge/"))
+	case strings.HasPrefix(path, "/order/"):
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		o, ok := ca.order(strings.TrimPrefix(path, "/order/"))
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, o)
+	case strings.HasPrefix(path, "/finalize/"):
+		ca.finalize(w, r, strings.TrimPrefix(path, "/finalize/"))
+	case strings.HasPrefix(path, "/cert/"):
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+		o, ok := ca.order(strings.TrimPrefix(path, "/cert/"))
+		if !ok || o.chain == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		for _, der := range o.chain {
+			pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (ca *CA) newAccount(w http.ResponseWriter, r *http.Request) {
+	header, _, err := decodeJWS(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if header.JWK == nil {
+		http.Error(w, "account key required", http.StatusBadRequest)
+		return
+	}
+	thumbprint, err := header.JWK.thumbprint()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ca.mu.Lock()
+	ca.thumbprint = thumbprint
+	ca.mu.Unlock()
+
+	w.Header().Set("Location", ca.url("/account/1"))
+	writeJSON(w, http.StatusCreated, map[string]string{"status": acme.StatusValid})
+}
+
+func (ca *CA) newOrder(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Identifiers []identifier `json:"identifiers"`
+	}
+	if _, payload, err := decodeJWS(r); err != nil || json.Unmarshal(payload, &req) != nil {
+		http.Error(w, "malformed order", http.StatusBadRequest)
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	id := strconv.Itoa(len(ca.orders))
+	o := &order{
+		Status:      acme.StatusPending,
+		Identifiers: req.Identifiers,
+		Finalize:    ca.url("/finalize/" + id),
+	}
+	for _, ident := range req.Identifiers {
+		authz, ok := ca.authzs[ident.Value]
+		if !ok {
+			authz = &authorization{
+				Status:     acme.StatusPending,
+				Identifier: ident,
+				Challenges: []challenge{{
+					Type:   "tls-alpn-01",
+					URL:    ca.url("/challenge/" + ident.Value),
+					Token:  "token-" + strings.Replace(ident.Value, ".", "-", -1),
+					Status: acme.StatusPending,
+				}},
+			}
+			ca.authzs[ident.Value] = authz
+		}
+		o.Authorizations = append(o.Authorizations, ca.url("/authz/"+ident.Value))
+	}
+	ca.orders = append(ca.orders, o)
+	ca.updateOrders()
+
+	w.Header().Set("Location", ca.url("/order/"+id))
+	writeJSON(w, http.StatusCreated, o)
+}
+
+func (ca *CA) acceptChallenge(w http.ResponseWriter, r *http.Request, domain string) {
+	ca.mu.Lock()
+	authz, ok := ca.authzs[domain]
+	addr := ca.addrs[domain]
+	thumbprint := ca.thumbprint
+	ca.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	chal := authz.Challenges[0]
+	err := verifyTLSALPN01(addr, domain, chal.Token+"."+thumbprint)
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	status := acme.StatusValid
+	if err != nil {
+		status = acme.StatusInvalid
+	}
+	authz.Status = status
+	authz.Challenges[0].Status = status
+	ca.updateOrders()
+	writeJSON(w, http.StatusOK, authz.Challenges[0])
+}
+
+func (ca *CA) finalize(w http.ResponseWriter, r *http.Request, id string) {
+	var req struct {
+		CSR string `json:"csr"`
+	}
+	if _, payload, err := decodeJWS(r); err != nil || json.Unmarshal(payload, &req) != nil {
+		http.Error(w, "malformed finalization", http.StatusBadRequest)
+		return
+	}
+	csrDER, err := base64.RawURLEncoding.DecodeString(req.CSR)
+	if err != nil {
+		http.Error(w, "malformed csr", http.StatusBadRequest)
+		return
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		http.Error(w, "malformed csr", http.StatusBadRequest)
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	o, ok := ca.order(id)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if o.Status != acme.StatusReady {
+		http.Error(w, "order not ready", http.StatusForbidden)
+		return
+	}
+
+	ca.issued++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(int64(ca.issued) + 1),
+		NotBefore:    time.Now().Add(-time.Minute),
+		// Long enough for the certificate not to be renewed straight away
+		NotAfter:    time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, ident := range o.Identifiers {
+		template.DNSNames = append(template.DNSNames, ident.Value)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.rootCert, csr.PublicKey, ca.rootKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	o.chain = [][]byte{der, ca.rootCert.Raw}
+	o.Status = acme.StatusValid
+	o.Certificate = ca.url("/cert/" + id)
+	w.Header().Set("Location", ca.url("/order/"+id))
+	writeJSON(w, http.StatusOK, o)
+}
+
+// updateOrders moves the pending orders along with their authorizations.
+// It requires ca.mu to be locked.
+func (ca *CA) updateOrders() {
+	for _, o := range ca.orders {
+		if o.Status != acme.StatusPending {
+			continue
+		}
+		status := acme.StatusReady
+		for _, ident := range o.Identifiers {
+			switch ca.authzs[ident.Value].Status {
+			case acme.StatusInvalid:
+				status = acme.StatusInvalid
+			case acme.StatusPending:
+				if status == acme.StatusReady {
+					status = acme.StatusPending
+				}
+			}
+		}
+		o.Status = status
+	}
+}
+
+// order returns the order with the given ID. It requires ca.mu to be locked.
+func (ca *CA) order(id string) (*order, bool) {
+	i, err := strconv.Atoi(id)
+	if err != nil || i < 0 || i >= len(ca.orders) {
+		return nil, false
+	}
+	return ca.orders[i], true
+}
+
+func (ca *CA) url(path string) string {
+	return ca.server.URL + path
+}
+
+// verifyTLSALPN01 validates the domain as described in RFC 8737
+func verifyTLSALPN01(addr, domain, keyAuthorization string) error {
+	if addr == "" {
+		return fmt.Errorf("no address to validate %q at", domain)
+	}
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		ServerName:         domain,
+		NextProtos:         []string{acme.ALPNProto},
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if state.NegotiatedProtocol != acme.ALPNProto {
+		return fmt.Errorf("negotiated protocol %q, not %q", state.NegotiatedProtocol, acme.ALPNProto)
+	}
+	if len(state.PeerCertificates) != 1 {
+		return errors.New("expected a single challenge certificate")
+	}
+	cert := state.PeerCertificates[0]
+	if err := cert.VerifyHostname(domain); err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256([]byte(keyAuthorization))
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(acmeIdentifierOID) {
+			continue
+		}
+		var value []byte
+		if _, err := asn1.Unmarshal(ext.Value, &value); err != nil {
+			return err
+		}
+		if !bytes.Equal(value, digest[:]) {
+			return errors.New("key authorization mismatch")
+		}
+		return nil
+	}
+	return errors.New("challenge certificate has no acmeIdentifier extension")
+}
+
+type jwsHeader struct {
+	JWK *jwk   `json:"jwk"`
+	KID string `json:"kid"`
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k *jwk) thumbprint() (string, error) {
+	if k.Kty != "EC" || k.Crv != "P-256" {
+		return "", fmt.Errorf("unsupported account key %s %s", k.Kty, k.Crv)
+	}
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return "", err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return "", err
+	}
+	return acme.JWKThumbprint(&ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	})
+}
+
+// decodeJWS decodes the protected header and payload of a request, without
+// verifying its signature
+func decodeJWS(r *http.Request) (*jwsHeader, []byte, error) {
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return nil, nil, err
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := new(jwsHeader)
+	if err := json.Unmarshal(protected, header); err != nil {
+		return nil, nil, err
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header, payload, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
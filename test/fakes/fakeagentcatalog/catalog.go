@@ -8,10 +8,12 @@ import (
 	"github.com/spiffe/spire/proto/agent/keymanager"
 	"github.com/spiffe/spire/proto/agent/nodeattestor"
 	"github.com/spiffe/spire/proto/agent/workloadattestor"
+	"github.com/spiffe/spire/proto/common/metricsink"
 )
 
 type Catalog struct {
 	keyManagers       []*catalog.ManagedKeyManager
+	metricSinks       []*catalog.ManagedMetricSink
 	nodeAttestors     []*catalog.ManagedNodeAttestor
 	workloadAttestors []*catalog.ManagedWorkloadAttestor
 }
@@ -34,6 +36,20 @@ func (c *Catalog) KeyManagers() []*catalog.ManagedKeyManager {
 	return c.keyManagers
 }
 
+func (c *Catalog) SetMetricSinks(metricSinks ...metricsink.MetricSink) {
+	c.metricSinks = nil
+	for i, metricSink := range metricSinks {
+		c.metricSinks = append(c.metricSinks, catalog.NewManagedMetricSink(
+			metricSink, common.PluginConfig{
+				PluginName: pluginName("metricsink", i),
+			}))
+	}
+}
+
+func (c *Catalog) MetricSinks() []*catalog.ManagedMetricSink {
+	return c.metricSinks
+}
+
 func (c *Catalog) SetNodeAttestors(nodeAttestors ...nodeattestor.NodeAttestor) {
 	c.nodeAttestors = nil
 	for i, nodeAttestor := range nodeAttestors {
@@ -2,8 +2,10 @@ package fakedatastore
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -31,6 +33,8 @@ var (
 	ErrNoSuchRegistrationEntry           = errors.New("no such registration entry")
 	ErrNoSuchToken                       = errors.New("no such token")
 	ErrTokenAlreadyExists                = errors.New("token already exists")
+	ErrIssuedSVIDAlreadyExists           = errors.New("issued SVID already exists")
+	ErrNoSuchIssuedSVID                  = errors.New("no such issued SVID")
 )
 
 type FakeDataStore struct {
@@ -41,6 +45,9 @@ type FakeDataStore struct {
 	nodeResolverMapEntries *radix.Tree
 	registrationEntries    map[string]*datastore.RegistrationEntry
 	tokens                 map[string]*datastore.JoinToken
+	issuedSVIDs            map[string]*datastore.IssuedSVID
+	entryEvents            []*datastore.EntryEvent
+	nextEventID            uint64
 }
 
 var _ datastore.DataStore = (*FakeDataStore)(nil)
@@ -52,6 +59,7 @@ func New() *FakeDataStore {
 		nodeResolverMapEntries: radix.New(),
 		registrationEntries:    make(map[string]*datastore.RegistrationEntry),
 		tokens:                 make(map[string]*datastore.JoinToken),
+		issuedSVIDs:            make(map[string]*datastore.IssuedSVID),
 	}
 }
 
@@ -64,8 +72,10 @@ func (s *FakeDataStore) CreateBundle(ctx context.Context, req *datastore.Bundle)
 		return nil, ErrBundleAlreadyExists
 	}
 
-	s.bundles[req.TrustDomain] = cloneBundle(req)
-	return cloneBundle(req), nil
+	bundle := cloneBundle(req)
+	bundle.SequenceNumber = 1
+	s.bundles[req.TrustDomain] = bundle
+	return cloneBundle(bundle), nil
 }
 
 // UpdateBundle updates an existing bundle with the given CAs. Overwrites any
@@ -74,8 +84,13 @@ func (s *FakeDataStore) UpdateBundle(ctx context.Context, req *datastore.Bundle)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.bundles[req.TrustDomain] = cloneBundle(req)
-	return cloneBundle(req), nil
+	bundle := cloneBundle(req)
+	bundle.SequenceNumber = 1
+	if existing, ok := s.bundles[req.TrustDomain]; ok {
+		bundle.SequenceNumber = existing.SequenceNumber + 1
+	}
+	s.bundles[req.TrustDomain] = bundle
+	return cloneBundle(bundle), nil
 }
 
 // AppendBundle adds the specified CA certificates to an existing bundle. If no bundle exists for the
@@ -92,10 +107,49 @@ func (s *FakeDataStore) AppendBundle(ctx context.Context, req *datastore.Bundle)
 		s.bundles[req.TrustDomain] = bundle
 	}
 
-	bundle.CaCerts = append(bundle.CaCerts, cloneBundle(req).CaCerts...)
+	req = cloneBundle(req)
+	taintedCACerts, err := appendTaintedCACerts(bundle.TaintedCaCerts, req.TaintedCaCerts)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.CaCerts) > 0 || len(req.JwtSigningKeys) > 0 || (req.RefreshHint != 0 && req.RefreshHint != bundle.RefreshHint) ||
+		len(taintedCACerts) != len(bundle.TaintedCaCerts) {
+		bundle.SequenceNumber++
+	}
+	bundle.CaCerts = append(bundle.CaCerts, req.CaCerts...)
+	bundle.TaintedCaCerts = taintedCACerts
+	bundle.JwtSigningKeys = append(bundle.JwtSigningKeys, req.JwtSigningKeys...)
+	if req.RefreshHint != 0 {
+		bundle.RefreshHint = req.RefreshHint
+	}
 	return cloneBundle(bundle), nil
 }
 
+// appendTaintedCACerts appends the given tainted CA certificates to those
+// tainted already, skipping those which are
+func appendTaintedCACerts(tainted, certs []byte) ([]byte, error) {
+	taintedCerts, err := x509.ParseCertificates(tainted)
+	if err != nil {
+		return nil, err
+	}
+	newCerts, err := x509.ParseCertificates(certs)
+	if err != nil {
+		return nil, err
+	}
+
+nextCert:
+	for _, cert := range newCerts {
+		for _, taintedCert := range taintedCerts {
+			if cert.Equal(taintedCert) {
+				continue nextCert
+			}
+		}
+		tainted = append(tainted, cert.Raw...)
+		taintedCerts = append(taintedCerts, cert)
+	}
+	return tainted, nil
+}
+
 // DeleteBundle deletes the bundle with the matching TrustDomain. Any CACert data passed is ignored.
 func (s *FakeDataStore) DeleteBundle(ctx context.Context, req *datastore.Bundle) (*datastore.Bundle, error) {
 	s.mu.Lock()
@@ -191,6 +245,32 @@ func (s *FakeDataStore) FetchStaleNodeEntries(ctx context.Context,
 	return resp, nil
 }
 
+func (s *FakeDataStore) ListAttestedNodeEntries(ctx context.Context,
+	req *datastore.ListAttestedNodeEntriesRequest) (*datastore.ListAttestedNodeEntriesResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListAttestedNodeEntriesResponse)
+	for _, attestedNodeEntry := range s.attestedNodeEntries {
+		resp.AttestedNodeEntryList = append(resp.AttestedNodeEntryList,
+			cloneAttestedNodeEntry(attestedNodeEntry))
+	}
+
+	return resp, nil
+}
+
+func (s *FakeDataStore) CountAttestedNodeEntries(ctx context.Context,
+	req *datastore.CountAttestedNodeEntriesRequest) (*datastore.CountAttestedNodeEntriesResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return &datastore.CountAttestedNodeEntriesResponse{
+		Count: int32(len(s.attestedNodeEntries)),
+	}, nil
+}
+
 func (s *FakeDataStore) UpdateAttestedNodeEntry(ctx context.Context,
 	req *datastore.UpdateAttestedNodeEntryRequest) (*datastore.UpdateAttestedNodeEntryResponse, error) {
 
@@ -201,8 +281,23 @@ func (s *FakeDataStore) UpdateAttestedNodeEntry(ctx context.Context,
 	if !ok {
 		return nil, ErrNoSuchAttestedNodeEntry
 	}
-	attestedNodeEntry.CertSerialNumber = req.CertSerialNumber
-	attestedNodeEntry.CertExpirationDate = req.CertExpirationDate
+	mask := req.InputMask
+	if mask == nil {
+		mask = &datastore.AttestedNodeEntryMask{
+			CertSerialNumber:   true,
+			CertExpirationDate: true,
+			Banned:             true,
+		}
+	}
+	if mask.CertSerialNumber {
+		attestedNodeEntry.CertSerialNumber = req.CertSerialNumber
+	}
+	if mask.CertExpirationDate {
+		attestedNodeEntry.CertExpirationDate = req.CertExpirationDate
+	}
+	if mask.Banned {
+		attestedNodeEntry.Banned = req.Banned
+	}
 
 	return &datastore.UpdateAttestedNodeEntryResponse{
 		AttestedNodeEntry: cloneAttestedNodeEntry(attestedNodeEntry),
@@ -240,6 +335,7 @@ func (s *FakeDataStore) CreateNodeResolverMapEntry(ctx context.Context,
 	}
 
 	s.nodeResolverMapEntries.Insert(key, cloneNodeResolverMapEntry(entry))
+	s.createEntryEvent("", entry.BaseSpiffeId)
 	return &datastore.CreateNodeResolverMapEntryResponse{
 		NodeResolverMapEntry: cloneNodeResolverMapEntry(entry),
 	}, nil
@@ -294,6 +390,7 @@ func (s *FakeDataStore) DeleteNodeResolverMapEntry(ctx context.Context,
 			})
 		s.nodeResolverMapEntries.DeletePrefix(prefix)
 	}
+	s.createEntryEvent("", req.NodeResolverMapEntry.BaseSpiffeId)
 
 	return resp, nil
 }
@@ -317,6 +414,7 @@ func (s *FakeDataStore) CreateRegistrationEntry(ctx context.Context,
 	entry := cloneRegistrationEntry(request.RegisteredEntry)
 	entry.EntryId = entryID
 	s.registrationEntries[entryID] = entry
+	s.createEntryEvent(entryID, "")
 
 	return &datastore.CreateRegistrationEntryResponse{
 		RegisteredEntryId: entryID,
@@ -357,7 +455,27 @@ func (s *FakeDataStore) FetchRegistrationEntries(ctx context.Context,
 	}, nil
 }
 
-func (s FakeDataStore) UpdateRegistrationEntry(ctx context.Context,
+func (s *FakeDataStore) CountRegistrationEntries(ctx context.Context,
+	req *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.CountRegistrationEntriesResponse)
+	for _, entry := range s.registrationEntries {
+		if req.ByParentId != "" && entry.ParentId != req.ByParentId {
+			continue
+		}
+		if req.BySpiffeId != "" && entry.SpiffeId != req.BySpiffeId {
+			continue
+		}
+		resp.Count++
+	}
+
+	return resp, nil
+}
+
+func (s *FakeDataStore) UpdateRegistrationEntry(ctx context.Context,
 	request *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
 
 	s.mu.Lock()
@@ -371,6 +489,7 @@ func (s FakeDataStore) UpdateRegistrationEntry(ctx context.Context,
 	entry := cloneRegistrationEntry(request.RegisteredEntry)
 	entry.EntryId = request.RegisteredEntryId
 	s.registrationEntries[request.RegisteredEntryId] = entry
+	s.createEntryEvent(entry.EntryId, "")
 
 	return &datastore.UpdateRegistrationEntryResponse{
 		RegisteredEntry: cloneRegistrationEntry(entry),
@@ -388,6 +507,7 @@ func (s *FakeDataStore) DeleteRegistrationEntry(ctx context.Context,
 		return nil, ErrNoSuchRegistrationEntry
 	}
 	delete(s.registrationEntries, request.RegisteredEntryId)
+	s.createEntryEvent(request.RegisteredEntryId, "")
 
 	return &datastore.DeleteRegistrationEntryResponse{
 		RegisteredEntry: cloneRegistrationEntry(registrationEntry),
@@ -476,6 +596,41 @@ func (s *FakeDataStore) ListSpiffeEntries(ctx context.Context,
 	}, nil
 }
 
+// ListEntryEvents lists the entry events with an ID greater than the one
+// in the request, in increasing ID order
+func (s *FakeDataStore) ListEntryEvents(ctx context.Context,
+	req *datastore.ListEntryEventsRequest) (*datastore.ListEntryEventsResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListEntryEventsResponse)
+	for _, event := range s.entryEvents {
+		if event.EventId > req.AfterEventId {
+			resp.Events = append(resp.Events, cloneEntryEvent(event))
+		}
+	}
+
+	return resp, nil
+}
+
+// PruneEntryEvents takes an EntryEvent message, and deletes all entry events
+// created before the date in the message
+func (s *FakeDataStore) PruneEntryEvents(ctx context.Context, req *datastore.EntryEvent) (*common.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []*datastore.EntryEvent
+	for _, event := range s.entryEvents {
+		if event.CreatedAt >= req.CreatedAt {
+			events = append(events, event)
+		}
+	}
+	s.entryEvents = events
+
+	return &common.Empty{}, nil
+}
+
 // RegisterToken takes a Token message and stores it
 func (s *FakeDataStore) RegisterToken(ctx context.Context, req *datastore.JoinToken) (*common.Empty, error) {
 	s.mu.Lock()
@@ -531,6 +686,105 @@ func (s *FakeDataStore) PruneTokens(ctx context.Context, req *datastore.JoinToke
 	return &common.Empty{}, nil
 }
 
+// CreateIssuedSVID records an SVID issued by the server
+func (s *FakeDataStore) CreateIssuedSVID(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.issuedSVIDs[req.SerialNumber]; ok {
+		return nil, ErrIssuedSVIDAlreadyExists
+	}
+	s.issuedSVIDs[req.SerialNumber] = cloneIssuedSVID(req)
+
+	return cloneIssuedSVID(req), nil
+}
+
+// FetchIssuedSVID takes an IssuedSVID message and returns the issued SVID with
+// its serial number, or an empty message if there is none
+func (s *FakeDataStore) FetchIssuedSVID(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	svid, ok := s.issuedSVIDs[req.SerialNumber]
+	if !ok {
+		return &datastore.IssuedSVID{}, nil
+	}
+
+	return cloneIssuedSVID(svid), nil
+}
+
+// RevokeIssuedSVIDs revokes the issued SVID with the serial number in the
+// message or, if not set, all the SVIDs issued for the entry in the message
+func (s *FakeDataStore) RevokeIssuedSVIDs(ctx context.Context, req *datastore.IssuedSVID) (*datastore.IssuedSVIDs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revokedAt := req.RevokedAt
+	if revokedAt == 0 {
+		revokedAt = time.Now().Unix()
+	}
+
+	var svids []*datastore.IssuedSVID
+	switch {
+	case req.SerialNumber != "":
+		svid, ok := s.issuedSVIDs[req.SerialNumber]
+		if !ok {
+			return nil, ErrNoSuchIssuedSVID
+		}
+		svids = append(svids, svid)
+	case req.EntryId != "":
+		for _, svid := range s.issuedSVIDs {
+			if svid.EntryId == req.EntryId {
+				svids = append(svids, svid)
+			}
+		}
+	default:
+		return nil, errors.New("serial number or entry ID is required")
+	}
+
+	resp := new(datastore.IssuedSVIDs)
+	for _, svid := range svids {
+		if svid.RevokedAt == 0 {
+			svid.RevokedAt = revokedAt
+		}
+		resp.Svids = append(resp.Svids, cloneIssuedSVID(svid))
+	}
+	sortIssuedSVIDs(resp.Svids)
+
+	return resp, nil
+}
+
+// ListRevokedSVIDs lists all the issued SVIDs that have been revoked
+func (s *FakeDataStore) ListRevokedSVIDs(ctx context.Context, req *common.Empty) (*datastore.IssuedSVIDs, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.IssuedSVIDs)
+	for _, svid := range s.issuedSVIDs {
+		if svid.RevokedAt != 0 {
+			resp.Svids = append(resp.Svids, cloneIssuedSVID(svid))
+		}
+	}
+	sortIssuedSVIDs(resp.Svids)
+
+	return resp, nil
+}
+
+// PruneIssuedSVIDs takes an IssuedSVID message, and deletes all issued SVIDs
+// which have expired before the date in the message
+func (s *FakeDataStore) PruneIssuedSVIDs(ctx context.Context, req *datastore.IssuedSVID) (*common.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, svid := range s.issuedSVIDs {
+		if svid.Expiry <= req.Expiry {
+			delete(s.issuedSVIDs, key)
+		}
+	}
+
+	return &common.Empty{}, nil
+}
+
 func (s *FakeDataStore) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return &spi.ConfigureResponse{}, nil
 }
@@ -555,10 +809,26 @@ func cloneRegistrationEntry(registrationEntry *datastore.RegistrationEntry) *dat
 	return proto.Clone(registrationEntry).(*datastore.RegistrationEntry)
 }
 
+func cloneEntryEvent(event *datastore.EntryEvent) *datastore.EntryEvent {
+	return proto.Clone(event).(*datastore.EntryEvent)
+}
+
 func cloneJoinToken(token *datastore.JoinToken) *datastore.JoinToken {
 	return proto.Clone(token).(*datastore.JoinToken)
 }
 
+func cloneIssuedSVID(svid *datastore.IssuedSVID) *datastore.IssuedSVID {
+	return proto.Clone(svid).(*datastore.IssuedSVID)
+}
+
+// sortIssuedSVIDs sorts SVIDs by serial number so that results do not depend
+// on map ordering
+func sortIssuedSVIDs(svids []*datastore.IssuedSVID) {
+	sort.Slice(svids, func(i, j int) bool {
+		return svids[i].SerialNumber < svids[j].SerialNumber
+	})
+}
+
 func nodeResolverMapEntryKey(nodeResolverMapEntry *datastore.NodeResolverMapEntry) string {
 	return fmt.Sprintf("%s%c%s%c%s",
 		nodeResolverMapEntry.BaseSpiffeId, selectorKeySeparator,
@@ -570,6 +840,18 @@ func nodeResolverMapEntrySpiffeIDPrefix(spiffeID string) string {
 	return fmt.Sprintf("%s%c", spiffeID, selectorKeySeparator)
 }
 
+// createEntryEvent records a change of the registration entry or of the
+// selectors of the node given. The caller must hold the lock.
+func (s *FakeDataStore) createEntryEvent(entryID, nodeSpiffeID string) {
+	s.nextEventID++
+	s.entryEvents = append(s.entryEvents, &datastore.EntryEvent{
+		EventId:      s.nextEventID,
+		EntryId:      entryID,
+		NodeSpiffeId: nodeSpiffeID,
+		CreatedAt:    time.Now().Unix(),
+	})
+}
+
 func newRegistrationEntryID() (string, error) {
 	entryID, err := uuid.NewV4()
 	if err != nil {
@@ -583,7 +865,7 @@ nextSelector:
 	for _, candidate := range subset {
 		for _, selector := range selectors {
 			if candidate.Type == selector.Type && candidate.Value == selector.Value {
-				break nextSelector
+				continue nextSelector
 			}
 		}
 		return false
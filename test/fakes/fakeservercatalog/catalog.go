@@ -5,18 +5,24 @@ import (
 
 	common "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/proto/common/metricsink"
 	"github.com/spiffe/spire/proto/server/ca"
+	"github.com/spiffe/spire/proto/server/csrpolicy"
 	"github.com/spiffe/spire/proto/server/datastore"
 	"github.com/spiffe/spire/proto/server/nodeattestor"
 	"github.com/spiffe/spire/proto/server/noderesolver"
+	"github.com/spiffe/spire/proto/server/notifier"
 	"github.com/spiffe/spire/proto/server/upstreamca"
 )
 
 type Catalog struct {
 	cas           []*catalog.ManagedServerCA
+	csrPolicies   []*catalog.ManagedCSRPolicy
 	dataStores    []*catalog.ManagedDataStore
+	metricSinks   []*catalog.ManagedMetricSink
 	nodeAttestors []*catalog.ManagedNodeAttestor
 	nodeResolvers []*catalog.ManagedNodeResolver
+	notifiers     []*catalog.ManagedNotifier
 	upstreamCAs   []*catalog.ManagedUpstreamCA
 }
 
@@ -38,6 +44,20 @@ func (c *Catalog) CAs() []*catalog.ManagedServerCA {
 	return c.cas
 }
 
+func (c *Catalog) SetCSRPolicies(csrPolicies ...csrpolicy.CSRPolicy) {
+	c.csrPolicies = nil
+	for i, csrPolicy := range csrPolicies {
+		c.csrPolicies = append(c.csrPolicies, catalog.NewManagedCSRPolicy(
+			csrPolicy, common.PluginConfig{
+				PluginName: pluginName("csrpolicy", i),
+			}))
+	}
+}
+
+func (c *Catalog) CSRPolicies() []*catalog.ManagedCSRPolicy {
+	return c.csrPolicies
+}
+
 func (c *Catalog) SetDataStores(dataStores ...datastore.DataStore) {
 	c.dataStores = nil
 	for i, dataStore := range dataStores {
@@ -52,6 +72,20 @@ func (c *Catalog) DataStores() []*catalog.ManagedDataStore {
 	return c.dataStores
 }
 
+func (c *Catalog) SetMetricSinks(metricSinks ...metricsink.MetricSink) {
+	c.metricSinks = nil
+	for i, metricSink := range metricSinks {
+		c.metricSinks = append(c.metricSinks, catalog.NewManagedMetricSink(
+			metricSink, common.PluginConfig{
+				PluginName: pluginName("metricsink", i),
+			}))
+	}
+}
+
+func (c *Catalog) MetricSinks() []*catalog.ManagedMetricSink {
+	return c.metricSinks
+}
+
 func (c *Catalog) SetNodeAttestors(nodeAttestors ...nodeattestor.NodeAttestor) {
 	c.nodeAttestors = nil
 	for i, nodeAttestor := range nodeAttestors {
@@ -80,6 +114,20 @@ func (c *Catalog) NodeResolvers() []*catalog.ManagedNodeResolver {
 	return c.nodeResolvers
 }
 
+func (c *Catalog) SetNotifiers(notifiers ...notifier.Notifier) {
+	c.notifiers = nil
+	for i, notifier := range notifiers {
+		c.notifiers = append(c.notifiers, catalog.NewManagedNotifier(
+			notifier, common.PluginConfig{
+				PluginName: pluginName("notifier", i),
+			}))
+	}
+}
+
+func (c *Catalog) Notifiers() []*catalog.ManagedNotifier {
+	return c.notifiers
+}
+
 func (c *Catalog) SetUpstreamCAs(upstreamCAs ...upstreamca.UpstreamCA) {
 	c.upstreamCAs = nil
 	for i, upstreamCA := range upstreamCAs {
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/api/node"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAgent attests with the server and syncs with it like an agent would,
+// without running any plugins or serving workloads
+type fakeAgent struct {
+	index  int
+	config AgentsConfig
+	rng    *rand.Rand
+
+	serverAddr string
+	attest     *Recorder
+	sync       *Recorder
+
+	agentID string
+	token   string
+	key     *ecdsa.PrivateKey
+	svid    []byte
+
+	// CSRs of the registration entries of the agent, generated once and
+	// sent again on every rotation
+	csrs [][]byte
+
+	// IDs of the registration entries created for the agent, deleted once
+	// the run is over
+	entryIDs []string
+}
+
+// setUp creates the join token the agent attests with and the registration
+// entries it is the parent of, through the server APIs
+func (a *fakeAgent) setUp(ctx context.Context, agentClient agent.AgentClient, entryClient entry.EntryClient, trustDomain string) error {
+	resp, err := agentClient.CreateJoinToken(ctx, &agent.CreateJoinTokenRequest{Ttl: 3600})
+	if err != nil {
+		return fmt.Errorf("create join token: %v", err)
+	}
+	a.token = resp.Token
+	a.agentID = resp.AgentSpiffeId
+
+	a.key, err = ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	if err != nil {
+		return err
+	}
+	entryKey, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < a.config.Entries; i++ {
+		spiffeID := fmt.Sprintf("spiffe://%s/load/agent%d/workload%d", trustDomain, a.index, i)
+		resp, err := entryClient.CreateEntry(ctx, &entry.CreateEntryRequest{
+			Entry: &common.RegistrationEntry{
+				ParentId: a.agentID,
+				SpiffeId: spiffeID,
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: fmt.Sprintf("uid:%d", 10000+i)},
+				},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("create entry %q: %v", spiffeID, err)
+		}
+		a.entryIDs = append(a.entryIDs, resp.Entry.EntryId)
+
+		csr, err := util.MakeCSR(entryKey, spiffeID)
+		if err != nil {
+			return err
+		}
+		a.csrs = append(a.csrs, csr)
+	}
+	return nil
+}
+
+// tearDown deletes what setUp created on the server, along with the agent
+// if it attested, returning the first failure. Either the join token or the
+// agent is already gone, depending on whether the agent attested.
+func (a *fakeAgent) tearDown(ctx context.Context, agentClient agent.AgentClient, entryClient entry.EntryClient) error {
+	var firstErr error
+	fail := func(what string, err error) {
+		if firstErr == nil && status.Code(err) != codes.NotFound {
+			firstErr = fmt.Errorf("%s: %v", what, err)
+		}
+	}
+
+	for _, id := range a.entryIDs {
+		if _, err := entryClient.DeleteEntry(ctx, &entry.DeleteEntryRequest{Id: id}); err != nil {
+			fail(fmt.Sprintf("delete entry %q", id), err)
+		}
+	}
+	if a.token == "" {
+		return firstErr
+	}
+	if _, err := agentClient.DeleteJoinToken(ctx, &agent.DeleteJoinTokenRequest{Token: a.token}); err != nil {
+		fail("delete join token", err)
+	}
+	if _, err := agentClient.DeleteAgent(ctx, &agent.DeleteAgentRequest{SpiffeId: a.agentID}); err != nil {
+		fail(fmt.Sprintf("delete agent %q", a.agentID), err)
+	}
+	return firstErr
+}
+
+// run attests the agent, then syncs every sync interval until the context
+// is done. The first sync is delayed by a random fraction of the interval so
+// that the agents don't all sync at once.
+func (a *fakeAgent) run(ctx context.Context) error {
+	if err := a.attestAgent(ctx); err != nil {
+		return err
+	}
+
+	conn, err := a.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := node.NewNodeClient(conn)
+
+	interval := time.Duration(a.config.SyncInterval) * time.Millisecond
+	timer := time.NewTimer(time.Duration(a.rng.Int63n(int64(interval))))
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			// Failures are recorded, and the agent tries again on the next
+			// sync like a real one would
+			a.syncAgent(ctx, client)
+			timer.Reset(interval)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// attestAgent attests the agent with its join token, keeping the agent SVID
+// received
+func (a *fakeAgent) attestAgent(ctx context.Context) (err error) {
+	start := time.Now()
+	defer func() { a.attest.Record(time.Since(start), err) }()
+
+	csr, err := util.MakeCSR(a.key, a.agentID)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(a.serverAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		// The server is trusted on first use, like an agent with
+		// insecure_bootstrap
+		InsecureSkipVerify: true,
+	})))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := node.NewNodeClient(conn).Attest(ctx)
+	if err != nil {
+		return err
+	}
+	err = stream.Send(&node.AttestRequest{
+		AttestationData: &common.AttestationData{
+			Type: "join_token",
+			Data: []byte(a.token),
+		},
+		Csr: csr,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("attest %q: %v", a.agentID, err)
+	}
+	stream.CloseSend()
+
+	svid, ok := resp.GetSvidUpdate().GetSvids()[a.agentID]
+	if !ok {
+		return fmt.Errorf("no SVID issued to %q", a.agentID)
+	}
+	a.svid = svid.SvidCert
+	return nil
+}
+
+// syncAgent fetches the registration entries of the agent, sending CSRs for
+// a random selection of them
+func (a *fakeAgent) syncAgent(ctx context.Context, client node.NodeClient) (err error) {
+	var csrs [][]byte
+	for _, csr := range a.csrs {
+		if a.rng.Float64() < a.config.RotateFraction {
+			csrs = append(csrs, csr)
+		}
+	}
+
+	start := time.Now()
+	defer func() {
+		// Syncs cut short by the end of the run are not counted
+		if ctx.Err() == nil {
+			a.sync.Record(time.Since(start), err)
+		}
+	}()
+
+	stream, err := client.FetchX509SVID(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&node.FetchX509SVIDRequest{Csrs: csrs}); err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	stream.CloseSend()
+
+	if n := len(resp.GetSvidUpdate().GetSvids()); n != len(csrs) {
+		return fmt.Errorf("%q got %d SVIDs for %d CSRs", a.agentID, n, len(csrs))
+	}
+	return nil
+}
+
+// dial connects to the server with the agent SVID, along with the CA
+// certificate sent with it if any
+func (a *fakeAgent) dial() (*grpc.ClientConn, error) {
+	certs, err := x509.ParseCertificates(a.svid)
+	if err != nil {
+		return nil, err
+	}
+	chain := tls.Certificate{
+		PrivateKey: a.key,
+		Leaf:       certs[0],
+	}
+	for _, cert := range certs {
+		chain.Certificate = append(chain.Certificate, cert.Raw)
+	}
+	return grpc.Dial(a.serverAddr, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		Certificates:       []tls.Certificate{chain},
+		InsecureSkipVerify: true,
+	})))
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/api/v1/agent"
+	"github.com/spiffe/spire/proto/api/v1/entry"
+	"github.com/spiffe/spire/proto/api/workload"
+	"github.com/spiffe/spire/proto/common"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// registrationTimeout bounds how long the agent is waited for to issue
+	// an SVID to the fake workloads once they are registered
+	registrationTimeout = 30 * time.Second
+
+	// registrationPollInterval is how often the Workload API is polled for
+	// that SVID
+	registrationPollInterval = 500 * time.Millisecond
+)
+
+func main() {
+	scenarioPath := flag.String("scenario", "", "Path of the HCL file describing the scenario to run")
+	serverAddr := flag.String("serverAddr", util.DefaultServerAddr, "Address of the SPIRE server")
+	trustDomain := flag.String("trustDomain", "example.org", "Trust domain of the SPIRE server")
+	socketPath := flag.String("socketPath", "/tmp/agent.sock", "Path of the Workload API socket of the SPIRE agent")
+	agentID := flag.String("agentID", "", "SPIFFE ID of the SPIRE agent, to register the fake workloads under. They must be registered already if unset")
+	flag.Parse()
+
+	if *scenarioPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	scenario, err := LoadScenario(*scenarioPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	h := &harness{
+		scenario:    scenario,
+		serverAddr:  *serverAddr,
+		trustDomain: *trustDomain,
+		socketPath:  *socketPath,
+		agentID:     *agentID,
+		recorders: map[string]*Recorder{
+			opAgentAttest:   new(Recorder),
+			opAgentSync:     new(Recorder),
+			opWorkloadFetch: new(Recorder),
+		},
+	}
+
+	// What was created on the server is deleted even if the set up failed
+	// half way, so that runs don't add up
+	ctx := context.Background()
+	err = h.setUp(ctx)
+	if err == nil {
+		h.run(ctx)
+	}
+	h.tearDown(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !h.report() {
+		os.Exit(1)
+	}
+}
+
+// harness generates the load of a scenario and reports how the server and
+// agent held up
+type harness struct {
+	scenario    *Scenario
+	serverAddr  string
+	trustDomain string
+	socketPath  string
+	agentID     string
+
+	agentClient agent.AgentClient
+	entryClient entry.EntryClient
+
+	agents    []*fakeAgent
+	workloads []*fakeWorkload
+	recorders map[string]*Recorder
+
+	// ID of the registration entry of the fake workloads, if the harness
+	// created it
+	workloadEntryID string
+}
+
+// setUp creates the fake agents and workloads, and what they need on the
+// server. This is not part of the measured run.
+func (h *harness) setUp(ctx context.Context) error {
+	s := h.scenario
+	log.Printf("Setting up scenario %q: %d agents with %d entries each, %d workloads",
+		s.Name, s.Agents.Count, s.Agents.Entries, s.Workloads.Count)
+
+	if s.Agents.Count > 0 || h.agentID != "" {
+		var err error
+		h.agentClient, err = util.NewAgentClient(ctx, h.serverAddr)
+		if err != nil {
+			return err
+		}
+		h.entryClient, err = util.NewEntryClient(ctx, h.serverAddr)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.Agents.Count > 0 {
+
+		for i := 0; i < s.Agents.Count; i++ {
+			a := &fakeAgent{
+				index:      i,
+				config:     s.Agents,
+				rng:        rand.New(rand.NewSource(s.Seed + int64(i))),
+				serverAddr: h.serverAddr,
+				attest:     h.recorders[opAgentAttest],
+				sync:       h.recorders[opAgentSync],
+			}
+			h.agents = append(h.agents, a)
+			if err := a.setUp(ctx, h.agentClient, h.entryClient, h.trustDomain); err != nil {
+				return fmt.Errorf("set up agent %d: %v", i, err)
+			}
+		}
+	}
+
+	if s.Workloads.Count > 0 {
+		conn, err := dialWorkloadAPI(h.socketPath)
+		if err != nil {
+			return err
+		}
+		client := workload.NewSpiffeWorkloadAPIClient(conn)
+
+		if h.agentID != "" {
+			if err := h.registerWorkloads(ctx, client); err != nil {
+				return err
+			}
+		}
+
+		for i := 0; i < s.Workloads.Count; i++ {
+			h.workloads = append(h.workloads, &fakeWorkload{
+				config: s.Workloads,
+				client: client,
+				fetch:  h.recorders[opWorkloadFetch],
+			})
+		}
+	}
+	return nil
+}
+
+// registerWorkloads registers the user running the harness under the agent,
+// then waits for the agent to issue the fake workloads an SVID
+func (h *harness) registerWorkloads(ctx context.Context, client workload.SpiffeWorkloadAPIClient) error {
+	spiffeID := fmt.Sprintf("spiffe://%s/load/workload", h.trustDomain)
+	resp, err := h.entryClient.CreateEntry(ctx, &entry.CreateEntryRequest{
+		Entry: &common.RegistrationEntry{
+			ParentId: h.agentID,
+			SpiffeId: spiffeID,
+			Selectors: []*common.Selector{
+				{Type: "unix", Value: fmt.Sprintf("uid:%d", os.Getuid())},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("register workloads: %v", err)
+	}
+	h.workloadEntryID = resp.Entry.EntryId
+
+	ctx, cancel := context.WithTimeout(ctx, registrationTimeout)
+	defer cancel()
+	for !hasSVID(ctx, client, spiffeID) {
+		select {
+		case <-time.After(registrationPollInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("agent did not issue an SVID for %q within %v", spiffeID, registrationTimeout)
+		}
+	}
+	return nil
+}
+
+// hasSVID returns true if the Workload API serves an X509-SVID with the
+// given SPIFFE ID to the user running the harness
+func hasSVID(ctx context.Context, client workload.SpiffeWorkloadAPIClient, spiffeID string) bool {
+	ctx, cancel := context.WithTimeout(ctx, registrationPollInterval)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+	stream, err := client.FetchX509SVID(ctx, &workload.X509SVIDRequest{})
+	if err != nil {
+		return false
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return false
+	}
+	for _, svid := range resp.Svids {
+		if svid.SpiffeId == spiffeID {
+			return true
+		}
+	}
+	return false
+}
+
+// tearDown deletes what the harness created on the server. Failures are
+// logged, as what is left behind only affects later runs.
+func (h *harness) tearDown(ctx context.Context) {
+	if h.agentClient == nil {
+		return
+	}
+	log.Printf("Tearing down scenario %q", h.scenario.Name)
+
+	for _, a := range h.agents {
+		if err := a.tearDown(ctx, h.agentClient, h.entryClient); err != nil {
+			log.Printf("Failed to tear down agent %d: %v", a.index, err)
+		}
+	}
+	if h.workloadEntryID != "" {
+		_, err := h.entryClient.DeleteEntry(ctx, &entry.DeleteEntryRequest{Id: h.workloadEntryID})
+		if err != nil {
+			log.Printf("Failed to delete the workload entry: %v", err)
+		}
+	}
+}
+
+// run generates the load for the duration of the scenario
+func (h *harness) run(ctx context.Context) {
+	log.Printf("Running scenario %q for %v", h.scenario.Name, h.scenario.duration())
+	ctx, cancel := context.WithTimeout(ctx, h.scenario.duration())
+	defer cancel()
+
+	wg := new(sync.WaitGroup)
+	for _, a := range h.agents {
+		wg.Add(1)
+		go func(a *fakeAgent) {
+			defer wg.Done()
+			if err := a.run(ctx); err != nil {
+				log.Printf("Agent %d stopped: %v", a.index, err)
+			}
+		}(a)
+	}
+	for _, w := range h.workloads {
+		wg.Add(1)
+		go func(w *fakeWorkload) {
+			defer wg.Done()
+			w.run(ctx)
+		}(w)
+	}
+	wg.Wait()
+}
+
+// report prints the stats of each operation and checks them against the
+// thresholds of the scenario, returning false if any is not met
+func (h *harness) report() bool {
+	ops := []string{opAgentAttest, opAgentSync, opWorkloadFetch}
+	stats := make(map[string]Stats)
+	for _, op := range ops {
+		stats[op] = h.recorders[op].Stats(h.scenario.duration())
+	}
+
+	fmt.Printf("\nScenario %q, seed %d, %v\n\n", h.scenario.Name, h.scenario.Seed, h.scenario.duration())
+	printReport(os.Stdout, ops, stats)
+
+	passed := true
+	for _, op := range ops {
+		threshold, ok := h.scenario.Thresholds[op]
+		if !ok {
+			continue
+		}
+		for _, failure := range stats[op].Check(threshold) {
+			fmt.Printf("FAIL %s: %s\n", op, failure)
+			passed = false
+		}
+	}
+	if passed {
+		fmt.Println("\nPASS")
+	}
+	return passed
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/hcl"
+)
+
+// Scenario describes the load generated: how many fake agents attest and
+// sync with the server, how many fake workloads call the Workload API of the
+// agent, for how long, and the latency and throughput expected of each
+// operation.
+type Scenario struct {
+	Name string `hcl:"name"`
+
+	// Seed of the random choices made while generating load, e.g. the CSRs
+	// sent on each sync, so that runs of the same scenario are comparable
+	Seed int64 `hcl:"seed"`
+
+	// How long the load is generated for, in seconds, once set up
+	Duration int `hcl:"duration"`
+
+	Agents     AgentsConfig         `hcl:"agents"`
+	Workloads  WorkloadsConfig      `hcl:"workloads"`
+	Thresholds map[string]Threshold `hcl:"threshold"`
+}
+
+// AgentsConfig describes the fake agents. Each one attests with a join token
+// and syncs with the server periodically, sending CSRs for the registration
+// entries it is the parent of.
+type AgentsConfig struct {
+	Count int `hcl:"count"`
+
+	// Number of registration entries created for each agent
+	Entries int `hcl:"entries"`
+
+	// How often each agent syncs, in milliseconds. The first sync of each
+	// agent is spread over this interval.
+	SyncInterval int `hcl:"sync_interval"`
+
+	// Fraction of the entries of an agent a CSR is sent for on each sync,
+	// from 0 to 1. Set to 1 to have every SVID rotate at every sync, as
+	// during a mass rotation.
+	RotateFraction float64 `hcl:"rotate_fraction"`
+}
+
+// WorkloadsConfig describes the fake workloads. Each one fetches its
+// X509-SVIDs from the Workload API in a loop, as fast as it is served.
+type WorkloadsConfig struct {
+	Count int `hcl:"count"`
+
+	// How long each workload waits between fetches, in milliseconds
+	Interval int `hcl:"interval"`
+}
+
+// Threshold is the performance expected of an operation for the scenario
+// to pass. The latency and rate are not checked if unset.
+type Threshold struct {
+	// Maximum 99th percentile latency, in milliseconds
+	MaxP99 float64 `hcl:"max_p99"`
+
+	// Minimum number of successful operations per second
+	MinRate float64 `hcl:"min_rate"`
+
+	// Maximum fraction of failed operations, from 0 to 1. None may fail if
+	// unset.
+	MaxErrorRate float64 `hcl:"max_error_rate"`
+}
+
+// LoadScenario reads the scenario in the HCL file at the given path
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := new(Scenario)
+	hclTree, err := hcl.Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if err := hcl.DecodeObject(s, hclTree); err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %q: %v", path, err)
+	}
+	return s, nil
+}
+
+func (s *Scenario) validate() error {
+	if s.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if s.Agents.Count < 0 || s.Agents.Entries < 0 || s.Workloads.Count < 0 {
+		return errors.New("counts cannot be negative")
+	}
+	if s.Agents.Count > 0 && s.Agents.SyncInterval <= 0 {
+		return errors.New("agents sync_interval must be positive")
+	}
+	if s.Agents.RotateFraction < 0 || s.Agents.RotateFraction > 1 {
+		return errors.New("agents rotate_fraction must be between 0 and 1")
+	}
+	for name := range s.Thresholds {
+		switch name {
+		case opAgentAttest, opAgentSync, opWorkloadFetch:
+		default:
+			return fmt.Errorf("unknown operation %q in threshold", name)
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) duration() time.Duration {
+	return time.Duration(s.Duration) * time.Second
+}
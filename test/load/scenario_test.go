@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadScenario(t *testing.T) {
+	s, err := LoadScenario("scenarios/mass_rotation.hcl")
+	require.NoError(t, err)
+
+	assert.Equal(t, "mass_rotation", s.Name)
+	assert.Equal(t, int64(1), s.Seed)
+	assert.Equal(t, time.Minute, s.duration())
+	assert.Equal(t, AgentsConfig{
+		Count:          100,
+		Entries:        50,
+		SyncInterval:   5000,
+		RotateFraction: 1,
+	}, s.Agents)
+	assert.Equal(t, map[string]Threshold{
+		opAgentSync: {MaxP99: 5000, MinRate: 15},
+	}, s.Thresholds)
+}
+
+func TestLoadScenarioBundled(t *testing.T) {
+	paths, err := filepath.Glob("scenarios/*.hcl")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		_, err := LoadScenario(path)
+		assert.NoError(t, err, path)
+	}
+}
+
+func TestLoadScenarioInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "load")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, tt := range []struct {
+		config string
+		err    string
+	}{
+		{
+			config: `name = "none"`,
+			err:    "duration must be positive",
+		},
+		{
+			config: `duration = 1
+			agents { count = 1 }`,
+			err: "agents sync_interval must be positive",
+		},
+		{
+			config: `duration = 1
+			agents { rotate_fraction = 2 }`,
+			err: "agents rotate_fraction must be between 0 and 1",
+		},
+		{
+			config: `duration = 1
+			threshold "agent_rotate" { max_p99 = 1 }`,
+			err: `unknown operation "agent_rotate" in threshold`,
+		},
+	} {
+		path := filepath.Join(dir, "scenario.hcl")
+		require.NoError(t, ioutil.WriteFile(path, []byte(tt.config), 0600))
+		_, err := LoadScenario(path)
+		assert.EqualError(t, err, `invalid scenario "`+path+`": `+tt.err)
+	}
+}
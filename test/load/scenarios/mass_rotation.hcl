@@ -0,0 +1,18 @@
+# Every agent rotating every SVID on every sync, as after a CA rotation or a
+# registration change affecting every workload. Exercises the CA and the
+# CSR signing pool.
+name = "mass_rotation"
+seed = 1
+duration = 60
+
+agents {
+    count = 100
+    entries = 50
+    sync_interval = 5000
+    rotate_fraction = 1
+}
+
+threshold "agent_sync" {
+    max_p99 = 5000
+    min_rate = 15
+}
@@ -0,0 +1,18 @@
+# Agents syncing at a steady pace, rotating a few SVIDs on each sync, as in a
+# deployment at rest. Exercises the entry lookups of the datastore or of the
+# entry cache more than the CA.
+name = "steady_sync"
+seed = 1
+duration = 60
+
+agents {
+    count = 200
+    entries = 10
+    sync_interval = 5000
+    rotate_fraction = 0.1
+}
+
+threshold "agent_sync" {
+    max_p99 = 250
+    min_rate = 30
+}
@@ -0,0 +1,14 @@
+# Workloads fetching their X509-SVIDs from the agent as fast as they are
+# served. Exercises the workload attestation and the cache of the agent.
+name = "workload_api"
+seed = 1
+duration = 60
+
+workloads {
+    count = 50
+}
+
+threshold "workload_fetch" {
+    max_p99 = 100
+    min_rate = 200
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Operations measured
+const (
+	opAgentAttest   = "agent_attest"
+	opAgentSync     = "agent_sync"
+	opWorkloadFetch = "workload_fetch"
+)
+
+// Recorder collects the latencies of an operation, and how many times it
+// failed. It is safe for concurrent use.
+type Recorder struct {
+	mtx       sync.Mutex
+	latencies []time.Duration
+	errors    int
+}
+
+// Record records an operation which took the given time, failed if err is
+// not nil
+func (r *Recorder) Record(latency time.Duration, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if err != nil {
+		r.errors++
+		return
+	}
+	r.latencies = append(r.latencies, latency)
+}
+
+// Stats summarizes the operations recorded over the given period
+func (r *Recorder) Stats(period time.Duration) Stats {
+	r.mtx.Lock()
+	latencies := append([]time.Duration(nil), r.latencies...)
+	errors := r.errors
+	r.mtx.Unlock()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	s := Stats{
+		Count:  len(latencies),
+		Errors: errors,
+	}
+	if period > 0 {
+		s.Rate = float64(s.Count) / period.Seconds()
+	}
+	if len(latencies) > 0 {
+		s.Min = latencies[0]
+		s.P50 = percentile(latencies, 50)
+		s.P90 = percentile(latencies, 90)
+		s.P99 = percentile(latencies, 99)
+		s.Max = latencies[len(latencies)-1]
+	}
+	return s
+}
+
+// Stats are the latency and throughput of an operation
+type Stats struct {
+	// Number of successful and failed operations
+	Count  int
+	Errors int
+
+	// Successful operations per second
+	Rate float64
+
+	Min time.Duration
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+// ErrorRate returns the fraction of the operations which failed
+func (s Stats) ErrorRate() float64 {
+	total := s.Count + s.Errors
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(total)
+}
+
+// Check returns the ways the stats fall short of the given threshold
+func (s Stats) Check(t Threshold) []string {
+	var failures []string
+	if t.MaxP99 > 0 && millis(s.P99) > t.MaxP99 {
+		failures = append(failures, fmt.Sprintf("p99 latency %.1fms exceeds %.1fms", millis(s.P99), t.MaxP99))
+	}
+	if t.MinRate > 0 && s.Rate < t.MinRate {
+		failures = append(failures, fmt.Sprintf("rate %.1f/s is below %.1f/s", s.Rate, t.MinRate))
+	}
+	if s.ErrorRate() > t.MaxErrorRate {
+		failures = append(failures, fmt.Sprintf("error rate %.2f%% exceeds %.2f%%", 100*s.ErrorRate(), 100*t.MaxErrorRate))
+	}
+	return failures
+}
+
+// percentile returns the latency below which the given percentage of the
+// sorted latencies fall, using the nearest rank
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// printReport writes a table of the stats of each operation
+func printReport(w io.Writer, ops []string, stats map[string]Stats) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "OPERATION\tCOUNT\tERRORS\tRATE/S\tMIN\tP50\tP90\tP99\tMAX")
+	for _, op := range ops {
+		s := stats[op]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%.1fms\n",
+			op, s.Count, s.Errors, s.Rate,
+			millis(s.Min), millis(s.P50), millis(s.P90), millis(s.P99), millis(s.Max))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderStats(t *testing.T) {
+	r := new(Recorder)
+	for i := 100; i > 0; i-- {
+		r.Record(time.Duration(i)*time.Millisecond, nil)
+	}
+	r.Record(time.Hour, errors.New("failed"))
+
+	s := r.Stats(10 * time.Second)
+	assert.Equal(t, 100, s.Count)
+	assert.Equal(t, 1, s.Errors)
+	assert.Equal(t, 10.0, s.Rate)
+	assert.Equal(t, time.Millisecond, s.Min)
+	assert.Equal(t, 50*time.Millisecond, s.P50)
+	assert.Equal(t, 90*time.Millisecond, s.P90)
+	assert.Equal(t, 99*time.Millisecond, s.P99)
+	assert.Equal(t, 100*time.Millisecond, s.Max)
+	assert.InDelta(t, 1.0/101, s.ErrorRate(), 1e-9)
+}
+
+func TestRecorderStatsEmpty(t *testing.T) {
+	s := new(Recorder).Stats(time.Second)
+	assert.Equal(t, Stats{}, s)
+	assert.Equal(t, 0.0, s.ErrorRate())
+}
+
+func TestStatsCheck(t *testing.T) {
+	s := Stats{Count: 99, Errors: 1, Rate: 9.9, P99: 120 * time.Millisecond}
+
+	assert.Empty(t, s.Check(Threshold{MaxP99: 150, MinRate: 5, MaxErrorRate: 0.05}))
+
+	failures := s.Check(Threshold{MaxP99: 100, MinRate: 10})
+	require.Len(t, failures, 3)
+	assert.Equal(t, "p99 latency 120.0ms exceeds 100.0ms", failures[0])
+	assert.Equal(t, "rate 9.9/s is below 10.0/s", failures[1])
+	assert.Equal(t, "error rate 1.00% exceeds 0.00%", failures[2])
+}
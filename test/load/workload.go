@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/spiffe/spire/proto/api/workload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// errorBackoff is how long a fake workload waits after a failed fetch
+const errorBackoff = time.Second
+
+// fakeWorkload fetches its X509-SVIDs from the Workload API in a loop. All
+// the fake workloads run as the user running the harness, so they are
+// attested the same way and only load the agent.
+type fakeWorkload struct {
+	config WorkloadsConfig
+	client workload.SpiffeWorkloadAPIClient
+	fetch  *Recorder
+}
+
+// dialWorkloadAPI connects to the Workload API served on the given socket
+func dialWorkloadAPI(socketPath string) (*grpc.ClientConn, error) {
+	return grpc.Dial(socketPath,
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+}
+
+// run fetches the X509-SVIDs until the context is done
+func (w *fakeWorkload) run(ctx context.Context) {
+	interval := time.Duration(w.config.Interval) * time.Millisecond
+	for ctx.Err() == nil {
+		start := time.Now()
+		err := w.fetchX509SVID(ctx)
+		// Fetches cut short by the end of the run are not counted
+		if ctx.Err() == nil {
+			w.fetch.Record(time.Since(start), err)
+		}
+
+		wait := interval
+		if err != nil && wait < errorBackoff {
+			// Don't spin on an agent that is down
+			wait = errorBackoff
+		}
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+			}
+		}
+	}
+}
+
+// fetchX509SVID opens an X509-SVID stream and waits for the first response,
+// which is what a workload starting up waits for
+func (w *fakeWorkload) fetchX509SVID(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("workload.spiffe.io", "true"))
+	stream, err := w.client.FetchX509SVID(ctx, &workload.X509SVIDRequest{})
+	if err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if len(resp.Svids) == 0 {
+		return errors.New("no X509-SVIDs received")
+	}
+	return nil
+}
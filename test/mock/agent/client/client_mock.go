@@ -5,11 +5,11 @@
 package mock_client
 
 import (
-	reflect "reflect"
-
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	client "github.com/spiffe/spire/pkg/agent/client"
 	node "github.com/spiffe/spire/proto/api/node"
+	reflect "reflect"
 )
 
 // MockClient is a mock of Client interface
@@ -35,17 +35,30 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// FetchJWTSVID mocks base method
+func (m *MockClient) FetchJWTSVID(arg0 context.Context, arg1 *node.JSR) (*client.JWTSVID, error) {
+	ret := m.ctrl.Call(m, "FetchJWTSVID", arg0, arg1)
+	ret0, _ := ret[0].(*client.JWTSVID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTSVID indicates an expected call of FetchJWTSVID
+func (mr *MockClientMockRecorder) FetchJWTSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTSVID", reflect.TypeOf((*MockClient)(nil).FetchJWTSVID), arg0, arg1)
+}
+
 // FetchUpdates mocks base method
-func (m *MockClient) FetchUpdates(arg0 *node.FetchX509SVIDRequest) (*client.Update, error) {
-	ret := m.ctrl.Call(m, "FetchUpdates", arg0)
+func (m *MockClient) FetchUpdates(arg0 context.Context, arg1 *node.FetchX509SVIDRequest) (*client.Update, error) {
+	ret := m.ctrl.Call(m, "FetchUpdates", arg0, arg1)
 	ret0, _ := ret[0].(*client.Update)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FetchUpdates indicates an expected call of FetchUpdates
-func (mr *MockClientMockRecorder) FetchUpdates(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUpdates", reflect.TypeOf((*MockClient)(nil).FetchUpdates), arg0)
+func (mr *MockClientMockRecorder) FetchUpdates(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchUpdates", reflect.TypeOf((*MockClient)(nil).FetchUpdates), arg0, arg1)
 }
 
 // Release mocks base method
@@ -57,3 +70,15 @@ func (m *MockClient) Release() {
 func (mr *MockClientMockRecorder) Release() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockClient)(nil).Release))
 }
+
+// WatchUpdates mocks base method
+func (m *MockClient) WatchUpdates(arg0 context.Context, arg1 func()) error {
+	ret := m.ctrl.Call(m, "WatchUpdates", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchUpdates indicates an expected call of WatchUpdates
+func (mr *MockClientMockRecorder) WatchUpdates(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUpdates", reflect.TypeOf((*MockClient)(nil).WatchUpdates), arg0, arg1)
+}
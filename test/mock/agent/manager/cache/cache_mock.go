@@ -5,6 +5,7 @@
 package mock_cache
 
 import (
+	crypto "crypto"
 	x509 "crypto/x509"
 	gomock "github.com/golang/mock/gomock"
 	go_observer "github.com/imkira/go-observer"
@@ -48,6 +49,18 @@ func (mr *MockCacheMockRecorder) Bundle() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bundle", reflect.TypeOf((*MockCache)(nil).Bundle))
 }
 
+// CRL mocks base method
+func (m *MockCache) CRL() []byte {
+	ret := m.ctrl.Call(m, "CRL")
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// CRL indicates an expected call of CRL
+func (mr *MockCacheMockRecorder) CRL() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CRL", reflect.TypeOf((*MockCache)(nil).CRL))
+}
+
 // DeleteEntry mocks base method
 func (m *MockCache) DeleteEntry(arg0 *common.RegistrationEntry) bool {
 	ret := m.ctrl.Call(m, "DeleteEntry", arg0)
@@ -84,6 +97,18 @@ func (mr *MockCacheMockRecorder) Entry(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entry", reflect.TypeOf((*MockCache)(nil).Entry), arg0)
 }
 
+// HasSubscribers mocks base method
+func (m *MockCache) HasSubscribers(arg0 cache.Selectors) bool {
+	ret := m.ctrl.Call(m, "HasSubscribers", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasSubscribers indicates an expected call of HasSubscribers
+func (mr *MockCacheMockRecorder) HasSubscribers(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSubscribers", reflect.TypeOf((*MockCache)(nil).HasSubscribers), arg0)
+}
+
 // IsEmpty mocks base method
 func (m *MockCache) IsEmpty() bool {
 	ret := m.ctrl.Call(m, "IsEmpty")
@@ -96,6 +121,18 @@ func (mr *MockCacheMockRecorder) IsEmpty() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmpty", reflect.TypeOf((*MockCache)(nil).IsEmpty))
 }
 
+// JWTSigningKeys mocks base method
+func (m *MockCache) JWTSigningKeys() map[string]crypto.PublicKey {
+	ret := m.ctrl.Call(m, "JWTSigningKeys")
+	ret0, _ := ret[0].(map[string]crypto.PublicKey)
+	return ret0
+}
+
+// JWTSigningKeys indicates an expected call of JWTSigningKeys
+func (mr *MockCacheMockRecorder) JWTSigningKeys() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWTSigningKeys", reflect.TypeOf((*MockCache)(nil).JWTSigningKeys))
+}
+
 // SetBundle mocks base method
 func (m *MockCache) SetBundle(arg0 []*x509.Certificate) {
 	m.ctrl.Call(m, "SetBundle", arg0)
@@ -106,6 +143,16 @@ func (mr *MockCacheMockRecorder) SetBundle(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBundle", reflect.TypeOf((*MockCache)(nil).SetBundle), arg0)
 }
 
+// SetCRL mocks base method
+func (m *MockCache) SetCRL(arg0 []byte) {
+	m.ctrl.Call(m, "SetCRL", arg0)
+}
+
+// SetCRL indicates an expected call of SetCRL
+func (mr *MockCacheMockRecorder) SetCRL(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCRL", reflect.TypeOf((*MockCache)(nil).SetCRL), arg0)
+}
+
 // SetEntry mocks base method
 func (m *MockCache) SetEntry(arg0 *cache.Entry) {
 	m.ctrl.Call(m, "SetEntry", arg0)
@@ -116,6 +163,16 @@ func (mr *MockCacheMockRecorder) SetEntry(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEntry", reflect.TypeOf((*MockCache)(nil).SetEntry), arg0)
 }
 
+// SetJWTSigningKeys mocks base method
+func (m *MockCache) SetJWTSigningKeys(arg0 map[string]crypto.PublicKey) {
+	m.ctrl.Call(m, "SetJWTSigningKeys", arg0)
+}
+
+// SetJWTSigningKeys indicates an expected call of SetJWTSigningKeys
+func (mr *MockCacheMockRecorder) SetJWTSigningKeys(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJWTSigningKeys", reflect.TypeOf((*MockCache)(nil).SetJWTSigningKeys), arg0)
+}
+
 // Subscribe mocks base method
 func (m *MockCache) Subscribe(arg0 cache.Selectors) cache.Subscriber {
 	ret := m.ctrl.Call(m, "Subscribe", arg0)
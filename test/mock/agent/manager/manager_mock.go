@@ -8,9 +8,11 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	go_observer "github.com/imkira/go-observer"
+	client "github.com/spiffe/spire/pkg/agent/client"
 	cache "github.com/spiffe/spire/pkg/agent/manager/cache"
 	common "github.com/spiffe/spire/proto/common"
 	reflect "reflect"
+	time "time"
 )
 
 // MockManager is a mock of Manager interface
@@ -36,6 +38,31 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// CachedEntries mocks base method
+func (m *MockManager) CachedEntries() []*cache.Entry {
+	ret := m.ctrl.Call(m, "CachedEntries")
+	ret0, _ := ret[0].([]*cache.Entry)
+	return ret0
+}
+
+// CachedEntries indicates an expected call of CachedEntries
+func (mr *MockManagerMockRecorder) CachedEntries() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedEntries", reflect.TypeOf((*MockManager)(nil).CachedEntries))
+}
+
+// FetchJWTSVID mocks base method
+func (m *MockManager) FetchJWTSVID(arg0 context.Context, arg1 string, arg2 []string) (*client.JWTSVID, error) {
+	ret := m.ctrl.Call(m, "FetchJWTSVID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*client.JWTSVID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTSVID indicates an expected call of FetchJWTSVID
+func (mr *MockManagerMockRecorder) FetchJWTSVID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTSVID", reflect.TypeOf((*MockManager)(nil).FetchJWTSVID), arg0, arg1, arg2)
+}
+
 // Initialize mocks base method
 func (m *MockManager) Initialize(arg0 context.Context) error {
 	ret := m.ctrl.Call(m, "Initialize", arg0)
@@ -48,6 +75,18 @@ func (mr *MockManagerMockRecorder) Initialize(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initialize", reflect.TypeOf((*MockManager)(nil).Initialize), arg0)
 }
 
+// LastSync mocks base method
+func (m *MockManager) LastSync() time.Time {
+	ret := m.ctrl.Call(m, "LastSync")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastSync indicates an expected call of LastSync
+func (mr *MockManagerMockRecorder) LastSync() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSync", reflect.TypeOf((*MockManager)(nil).LastSync))
+}
+
 // MatchingEntries mocks base method
 func (m *MockManager) MatchingEntries(arg0 []*common.Selector) []*cache.Entry {
 	ret := m.ctrl.Call(m, "MatchingEntries", arg0)
@@ -60,6 +99,19 @@ func (mr *MockManagerMockRecorder) MatchingEntries(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchingEntries", reflect.TypeOf((*MockManager)(nil).MatchingEntries), arg0)
 }
 
+// RotateSVIDs mocks base method
+func (m *MockManager) RotateSVIDs(arg0 string) (int, error) {
+	ret := m.ctrl.Call(m, "RotateSVIDs", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSVIDs indicates an expected call of RotateSVIDs
+func (mr *MockManagerMockRecorder) RotateSVIDs(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSVIDs", reflect.TypeOf((*MockManager)(nil).RotateSVIDs), arg0)
+}
+
 // Run mocks base method
 func (m *MockManager) Run(arg0 context.Context) error {
 	ret := m.ctrl.Call(m, "Run", arg0)
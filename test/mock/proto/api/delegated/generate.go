@@ -0,0 +1,3 @@
+package mock_delegated
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/delegated DelegatedIdentity_FetchX509SVIDsServer > delegated.go"
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/spiffe/spire/proto/api/node (interfaces: NodeClient,Node_AttestClient,Node_AttestServer,Node_FetchX509SVIDClient,NodeServer,Node_FetchX509SVIDServer)
+// Source: github.com/spiffe/spire/proto/api/node (interfaces: NodeClient,Node_AttestClient,Node_AttestServer,Node_FetchX509SVIDClient,NodeServer,Node_FetchX509SVIDServer,Node_WatchUpdatesClient,Node_WatchUpdatesServer)
 
 // Package mock_node is a generated GoMock package.
 package mock_node
@@ -72,6 +72,24 @@ func (mr *MockNodeClientMockRecorder) FetchFederatedBundle(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFederatedBundle", reflect.TypeOf((*MockNodeClient)(nil).FetchFederatedBundle), varargs...)
 }
 
+// FetchJWTSVID mocks base method
+func (m *MockNodeClient) FetchJWTSVID(arg0 context.Context, arg1 *node.FetchJWTSVIDRequest, arg2 ...grpc.CallOption) (*node.FetchJWTSVIDResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchJWTSVID", varargs...)
+	ret0, _ := ret[0].(*node.FetchJWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTSVID indicates an expected call of FetchJWTSVID
+func (mr *MockNodeClientMockRecorder) FetchJWTSVID(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTSVID", reflect.TypeOf((*MockNodeClient)(nil).FetchJWTSVID), varargs...)
+}
+
 // FetchX509SVID mocks base method
 func (m *MockNodeClient) FetchX509SVID(arg0 context.Context, arg1 ...grpc.CallOption) (node.Node_FetchX509SVIDClient, error) {
 	varargs := []interface{}{arg0}
@@ -90,6 +108,24 @@ func (mr *MockNodeClientMockRecorder) FetchX509SVID(arg0 interface{}, arg1 ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509SVID", reflect.TypeOf((*MockNodeClient)(nil).FetchX509SVID), varargs...)
 }
 
+// WatchUpdates mocks base method
+func (m *MockNodeClient) WatchUpdates(arg0 context.Context, arg1 *node.WatchUpdatesRequest, arg2 ...grpc.CallOption) (node.Node_WatchUpdatesClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchUpdates", varargs...)
+	ret0, _ := ret[0].(node.Node_WatchUpdatesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchUpdates indicates an expected call of WatchUpdates
+func (mr *MockNodeClientMockRecorder) WatchUpdates(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUpdates", reflect.TypeOf((*MockNodeClient)(nil).WatchUpdates), varargs...)
+}
+
 // MockNode_AttestClient is a mock of Node_AttestClient interface
 type MockNode_AttestClient struct {
 	ctrl     *gomock.Controller
@@ -498,6 +534,19 @@ func (mr *MockNodeServerMockRecorder) FetchFederatedBundle(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchFederatedBundle", reflect.TypeOf((*MockNodeServer)(nil).FetchFederatedBundle), arg0, arg1)
 }
 
+// FetchJWTSVID mocks base method
+func (m *MockNodeServer) FetchJWTSVID(arg0 context.Context, arg1 *node.FetchJWTSVIDRequest) (*node.FetchJWTSVIDResponse, error) {
+	ret := m.ctrl.Call(m, "FetchJWTSVID", arg0, arg1)
+	ret0, _ := ret[0].(*node.FetchJWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTSVID indicates an expected call of FetchJWTSVID
+func (mr *MockNodeServerMockRecorder) FetchJWTSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTSVID", reflect.TypeOf((*MockNodeServer)(nil).FetchJWTSVID), arg0, arg1)
+}
+
 // FetchX509SVID mocks base method
 func (m *MockNodeServer) FetchX509SVID(arg0 node.Node_FetchX509SVIDServer) error {
 	ret := m.ctrl.Call(m, "FetchX509SVID", arg0)
@@ -510,6 +559,18 @@ func (mr *MockNodeServerMockRecorder) FetchX509SVID(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509SVID", reflect.TypeOf((*MockNodeServer)(nil).FetchX509SVID), arg0)
 }
 
+// WatchUpdates mocks base method
+func (m *MockNodeServer) WatchUpdates(arg0 *node.WatchUpdatesRequest, arg1 node.Node_WatchUpdatesServer) error {
+	ret := m.ctrl.Call(m, "WatchUpdates", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchUpdates indicates an expected call of WatchUpdates
+func (mr *MockNodeServerMockRecorder) WatchUpdates(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUpdates", reflect.TypeOf((*MockNodeServer)(nil).WatchUpdates), arg0, arg1)
+}
+
 // MockNode_FetchX509SVIDServer is a mock of Node_FetchX509SVIDServer interface
 type MockNode_FetchX509SVIDServer struct {
 	ctrl     *gomock.Controller
@@ -627,3 +688,217 @@ func (m *MockNode_FetchX509SVIDServer) SetTrailer(arg0 metadata.MD) {
 func (mr *MockNode_FetchX509SVIDServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockNode_FetchX509SVIDServer)(nil).SetTrailer), arg0)
 }
+
+// MockNode_WatchUpdatesClient is a mock of Node_WatchUpdatesClient interface
+type MockNode_WatchUpdatesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockNode_WatchUpdatesClientMockRecorder
+}
+
+// MockNode_WatchUpdatesClientMockRecorder is the mock recorder for MockNode_WatchUpdatesClient
+type MockNode_WatchUpdatesClientMockRecorder struct {
+	mock *MockNode_WatchUpdatesClient
+}
+
+// NewMockNode_WatchUpdatesClient creates a new mock instance
+func NewMockNode_WatchUpdatesClient(ctrl *gomock.Controller) *MockNode_WatchUpdatesClient {
+	mock := &MockNode_WatchUpdatesClient{ctrl: ctrl}
+	mock.recorder = &MockNode_WatchUpdatesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNode_WatchUpdatesClient) EXPECT() *MockNode_WatchUpdatesClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method
+func (m *MockNode_WatchUpdatesClient) CloseSend() error {
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend
+func (mr *MockNode_WatchUpdatesClientMockRecorder) CloseSend() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).CloseSend))
+}
+
+// Context mocks base method
+func (m *MockNode_WatchUpdatesClient) Context() context.Context {
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockNode_WatchUpdatesClientMockRecorder) Context() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).Context))
+}
+
+// Header mocks base method
+func (m *MockNode_WatchUpdatesClient) Header() (metadata.MD, error) {
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header
+func (mr *MockNode_WatchUpdatesClientMockRecorder) Header() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).Header))
+}
+
+// Recv mocks base method
+func (m *MockNode_WatchUpdatesClient) Recv() (*node.WatchUpdatesResponse, error) {
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*node.WatchUpdatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv
+func (mr *MockNode_WatchUpdatesClientMockRecorder) Recv() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method
+func (m *MockNode_WatchUpdatesClient) RecvMsg(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockNode_WatchUpdatesClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockNode_WatchUpdatesClient) SendMsg(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockNode_WatchUpdatesClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method
+func (m *MockNode_WatchUpdatesClient) Trailer() metadata.MD {
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer
+func (mr *MockNode_WatchUpdatesClientMockRecorder) Trailer() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockNode_WatchUpdatesClient)(nil).Trailer))
+}
+
+// MockNode_WatchUpdatesServer is a mock of Node_WatchUpdatesServer interface
+type MockNode_WatchUpdatesServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockNode_WatchUpdatesServerMockRecorder
+}
+
+// MockNode_WatchUpdatesServerMockRecorder is the mock recorder for MockNode_WatchUpdatesServer
+type MockNode_WatchUpdatesServerMockRecorder struct {
+	mock *MockNode_WatchUpdatesServer
+}
+
+// NewMockNode_WatchUpdatesServer creates a new mock instance
+func NewMockNode_WatchUpdatesServer(ctrl *gomock.Controller) *MockNode_WatchUpdatesServer {
+	mock := &MockNode_WatchUpdatesServer{ctrl: ctrl}
+	mock.recorder = &MockNode_WatchUpdatesServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNode_WatchUpdatesServer) EXPECT() *MockNode_WatchUpdatesServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method
+func (m *MockNode_WatchUpdatesServer) Context() context.Context {
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockNode_WatchUpdatesServerMockRecorder) Context() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).Context))
+}
+
+// RecvMsg mocks base method
+func (m *MockNode_WatchUpdatesServer) RecvMsg(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockNode_WatchUpdatesServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method
+func (m *MockNode_WatchUpdatesServer) Send(arg0 *node.WatchUpdatesResponse) error {
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockNode_WatchUpdatesServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockNode_WatchUpdatesServer) SendHeader(arg0 metadata.MD) error {
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockNode_WatchUpdatesServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockNode_WatchUpdatesServer) SendMsg(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockNode_WatchUpdatesServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method
+func (m *MockNode_WatchUpdatesServer) SetHeader(arg0 metadata.MD) error {
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockNode_WatchUpdatesServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockNode_WatchUpdatesServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockNode_WatchUpdatesServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockNode_WatchUpdatesServer)(nil).SetTrailer), arg0)
+}
@@ -252,6 +252,42 @@ func (mr *MockRegistrationClientMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederatedBundles), varargs...)
 }
 
+// RevokeSVIDs mocks base method
+func (m *MockRegistrationClient) RevokeSVIDs(arg0 context.Context, arg1 *registration.RevokeSVIDsRequest, arg2 ...grpc.CallOption) (*registration.RevokeSVIDsReply, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSVIDs", varargs...)
+	ret0, _ := ret[0].(*registration.RevokeSVIDsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSVIDs indicates an expected call of RevokeSVIDs
+func (mr *MockRegistrationClientMockRecorder) RevokeSVIDs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSVIDs", reflect.TypeOf((*MockRegistrationClient)(nil).RevokeSVIDs), varargs...)
+}
+
+// RotateEntrySVIDs mocks base method
+func (m *MockRegistrationClient) RotateEntrySVIDs(arg0 context.Context, arg1 *registration.RegistrationEntryID, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RotateEntrySVIDs", varargs...)
+	ret0, _ := ret[0].(*common.RegistrationEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEntrySVIDs indicates an expected call of RotateEntrySVIDs
+func (mr *MockRegistrationClientMockRecorder) RotateEntrySVIDs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEntrySVIDs", reflect.TypeOf((*MockRegistrationClient)(nil).RotateEntrySVIDs), varargs...)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationClient) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -467,6 +503,32 @@ func (mr *MockRegistrationServerMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederatedBundles), arg0, arg1)
 }
 
+// RevokeSVIDs mocks base method
+func (m *MockRegistrationServer) RevokeSVIDs(arg0 context.Context, arg1 *registration.RevokeSVIDsRequest) (*registration.RevokeSVIDsReply, error) {
+	ret := m.ctrl.Call(m, "RevokeSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*registration.RevokeSVIDsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSVIDs indicates an expected call of RevokeSVIDs
+func (mr *MockRegistrationServerMockRecorder) RevokeSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSVIDs", reflect.TypeOf((*MockRegistrationServer)(nil).RevokeSVIDs), arg0, arg1)
+}
+
+// RotateEntrySVIDs mocks base method
+func (m *MockRegistrationServer) RotateEntrySVIDs(arg0 context.Context, arg1 *registration.RegistrationEntryID) (*common.RegistrationEntry, error) {
+	ret := m.ctrl.Call(m, "RotateEntrySVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*common.RegistrationEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEntrySVIDs indicates an expected call of RotateEntrySVIDs
+func (mr *MockRegistrationServerMockRecorder) RotateEntrySVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEntrySVIDs", reflect.TypeOf((*MockRegistrationServer)(nil).RotateEntrySVIDs), arg0, arg1)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationServer) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
 	ret := m.ctrl.Call(m, "UpdateEntry", arg0, arg1)
@@ -0,0 +1,3 @@
+package mock_agent
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/v1/agent AgentClient,AgentServer > agent.go"
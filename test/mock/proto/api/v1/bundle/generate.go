@@ -0,0 +1,3 @@
+package mock_bundle
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/v1/bundle BundleClient,BundleServer > bundle.go"
@@ -0,0 +1,3 @@
+package mock_entry
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/v1/entry EntryClient,EntryServer > entry.go"
@@ -0,0 +1,3 @@
+package mock_stats
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/v1/stats StatsClient,StatsServer > stats.go"
@@ -1,3 +1,3 @@
 package mock_workload
 
-//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/workload SpiffeWorkloadAPIClient,SpiffeWorkloadAPIServer,SpiffeWorkloadAPI_FetchX509SVIDClient,SpiffeWorkloadAPI_FetchX509SVIDServer > workload.go"
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/api/workload SpiffeWorkloadAPIClient,SpiffeWorkloadAPIServer,SpiffeWorkloadAPI_FetchX509SVIDClient,SpiffeWorkloadAPI_FetchX509SVIDServer,SpiffeWorkloadAPI_FetchX509BundlesServer > workload.go"
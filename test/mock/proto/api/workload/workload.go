@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/spiffe/spire/proto/api/workload (interfaces: SpiffeWorkloadAPIClient,SpiffeWorkloadAPIServer,SpiffeWorkloadAPI_FetchX509SVIDClient,SpiffeWorkloadAPI_FetchX509SVIDServer)
+// Source: github.com/spiffe/spire/proto/api/workload (interfaces: SpiffeWorkloadAPIClient,SpiffeWorkloadAPIServer,SpiffeWorkloadAPI_FetchX509SVIDClient,SpiffeWorkloadAPI_FetchX509SVIDServer,SpiffeWorkloadAPI_FetchX509BundlesServer)
 
 // Package mock_workload is a generated GoMock package.
 package mock_workload
@@ -36,6 +36,60 @@ func (m *MockSpiffeWorkloadAPIClient) EXPECT() *MockSpiffeWorkloadAPIClientMockR
 	return m.recorder
 }
 
+// FetchJWTBundles mocks base method
+func (m *MockSpiffeWorkloadAPIClient) FetchJWTBundles(arg0 context.Context, arg1 *workload.JWTBundlesRequest, arg2 ...grpc.CallOption) (workload.SpiffeWorkloadAPI_FetchJWTBundlesClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchJWTBundles", varargs...)
+	ret0, _ := ret[0].(workload.SpiffeWorkloadAPI_FetchJWTBundlesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTBundles indicates an expected call of FetchJWTBundles
+func (mr *MockSpiffeWorkloadAPIClientMockRecorder) FetchJWTBundles(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTBundles", reflect.TypeOf((*MockSpiffeWorkloadAPIClient)(nil).FetchJWTBundles), varargs...)
+}
+
+// FetchJWTSVID mocks base method
+func (m *MockSpiffeWorkloadAPIClient) FetchJWTSVID(arg0 context.Context, arg1 *workload.JWTSVIDRequest, arg2 ...grpc.CallOption) (*workload.JWTSVIDResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchJWTSVID", varargs...)
+	ret0, _ := ret[0].(*workload.JWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTSVID indicates an expected call of FetchJWTSVID
+func (mr *MockSpiffeWorkloadAPIClientMockRecorder) FetchJWTSVID(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTSVID", reflect.TypeOf((*MockSpiffeWorkloadAPIClient)(nil).FetchJWTSVID), varargs...)
+}
+
+// FetchX509Bundles mocks base method
+func (m *MockSpiffeWorkloadAPIClient) FetchX509Bundles(arg0 context.Context, arg1 *workload.X509BundlesRequest, arg2 ...grpc.CallOption) (workload.SpiffeWorkloadAPI_FetchX509BundlesClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchX509Bundles", varargs...)
+	ret0, _ := ret[0].(workload.SpiffeWorkloadAPI_FetchX509BundlesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchX509Bundles indicates an expected call of FetchX509Bundles
+func (mr *MockSpiffeWorkloadAPIClientMockRecorder) FetchX509Bundles(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509Bundles", reflect.TypeOf((*MockSpiffeWorkloadAPIClient)(nil).FetchX509Bundles), varargs...)
+}
+
 // FetchX509SVID mocks base method
 func (m *MockSpiffeWorkloadAPIClient) FetchX509SVID(arg0 context.Context, arg1 *workload.X509SVIDRequest, arg2 ...grpc.CallOption) (workload.SpiffeWorkloadAPI_FetchX509SVIDClient, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -54,6 +108,24 @@ func (mr *MockSpiffeWorkloadAPIClientMockRecorder) FetchX509SVID(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509SVID", reflect.TypeOf((*MockSpiffeWorkloadAPIClient)(nil).FetchX509SVID), varargs...)
 }
 
+// ValidateJWTSVID mocks base method
+func (m *MockSpiffeWorkloadAPIClient) ValidateJWTSVID(arg0 context.Context, arg1 *workload.ValidateJWTSVIDRequest, arg2 ...grpc.CallOption) (*workload.ValidateJWTSVIDResponse, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ValidateJWTSVID", varargs...)
+	ret0, _ := ret[0].(*workload.ValidateJWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJWTSVID indicates an expected call of ValidateJWTSVID
+func (mr *MockSpiffeWorkloadAPIClientMockRecorder) ValidateJWTSVID(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJWTSVID", reflect.TypeOf((*MockSpiffeWorkloadAPIClient)(nil).ValidateJWTSVID), varargs...)
+}
+
 // MockSpiffeWorkloadAPIServer is a mock of SpiffeWorkloadAPIServer interface
 type MockSpiffeWorkloadAPIServer struct {
 	ctrl     *gomock.Controller
@@ -77,6 +149,43 @@ func (m *MockSpiffeWorkloadAPIServer) EXPECT() *MockSpiffeWorkloadAPIServerMockR
 	return m.recorder
 }
 
+// FetchJWTBundles mocks base method
+func (m *MockSpiffeWorkloadAPIServer) FetchJWTBundles(arg0 *workload.JWTBundlesRequest, arg1 workload.SpiffeWorkloadAPI_FetchJWTBundlesServer) error {
+	ret := m.ctrl.Call(m, "FetchJWTBundles", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchJWTBundles indicates an expected call of FetchJWTBundles
+func (mr *MockSpiffeWorkloadAPIServerMockRecorder) FetchJWTBundles(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTBundles", reflect.TypeOf((*MockSpiffeWorkloadAPIServer)(nil).FetchJWTBundles), arg0, arg1)
+}
+
+// FetchJWTSVID mocks base method
+func (m *MockSpiffeWorkloadAPIServer) FetchJWTSVID(arg0 context.Context, arg1 *workload.JWTSVIDRequest) (*workload.JWTSVIDResponse, error) {
+	ret := m.ctrl.Call(m, "FetchJWTSVID", arg0, arg1)
+	ret0, _ := ret[0].(*workload.JWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJWTSVID indicates an expected call of FetchJWTSVID
+func (mr *MockSpiffeWorkloadAPIServerMockRecorder) FetchJWTSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJWTSVID", reflect.TypeOf((*MockSpiffeWorkloadAPIServer)(nil).FetchJWTSVID), arg0, arg1)
+}
+
+// FetchX509Bundles mocks base method
+func (m *MockSpiffeWorkloadAPIServer) FetchX509Bundles(arg0 *workload.X509BundlesRequest, arg1 workload.SpiffeWorkloadAPI_FetchX509BundlesServer) error {
+	ret := m.ctrl.Call(m, "FetchX509Bundles", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchX509Bundles indicates an expected call of FetchX509Bundles
+func (mr *MockSpiffeWorkloadAPIServerMockRecorder) FetchX509Bundles(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509Bundles", reflect.TypeOf((*MockSpiffeWorkloadAPIServer)(nil).FetchX509Bundles), arg0, arg1)
+}
+
 // FetchX509SVID mocks base method
 func (m *MockSpiffeWorkloadAPIServer) FetchX509SVID(arg0 *workload.X509SVIDRequest, arg1 workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
 	ret := m.ctrl.Call(m, "FetchX509SVID", arg0, arg1)
@@ -89,6 +198,19 @@ func (mr *MockSpiffeWorkloadAPIServerMockRecorder) FetchX509SVID(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchX509SVID", reflect.TypeOf((*MockSpiffeWorkloadAPIServer)(nil).FetchX509SVID), arg0, arg1)
 }
 
+// ValidateJWTSVID mocks base method
+func (m *MockSpiffeWorkloadAPIServer) ValidateJWTSVID(arg0 context.Context, arg1 *workload.ValidateJWTSVIDRequest) (*workload.ValidateJWTSVIDResponse, error) {
+	ret := m.ctrl.Call(m, "ValidateJWTSVID", arg0, arg1)
+	ret0, _ := ret[0].(*workload.ValidateJWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateJWTSVID indicates an expected call of ValidateJWTSVID
+func (mr *MockSpiffeWorkloadAPIServerMockRecorder) ValidateJWTSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateJWTSVID", reflect.TypeOf((*MockSpiffeWorkloadAPIServer)(nil).ValidateJWTSVID), arg0, arg1)
+}
+
 // MockSpiffeWorkloadAPI_FetchX509SVIDClient is a mock of SpiffeWorkloadAPI_FetchX509SVIDClient interface
 type MockSpiffeWorkloadAPI_FetchX509SVIDClient struct {
 	ctrl     *gomock.Controller
@@ -302,3 +424,108 @@ func (m *MockSpiffeWorkloadAPI_FetchX509SVIDServer) SetTrailer(arg0 metadata.MD)
 func (mr *MockSpiffeWorkloadAPI_FetchX509SVIDServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509SVIDServer)(nil).SetTrailer), arg0)
 }
+
+// MockSpiffeWorkloadAPI_FetchX509BundlesServer is a mock of SpiffeWorkloadAPI_FetchX509BundlesServer interface
+type MockSpiffeWorkloadAPI_FetchX509BundlesServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder
+}
+
+// MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder is the mock recorder for MockSpiffeWorkloadAPI_FetchX509BundlesServer
+type MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder struct {
+	mock *MockSpiffeWorkloadAPI_FetchX509BundlesServer
+}
+
+// NewMockSpiffeWorkloadAPI_FetchX509BundlesServer creates a new mock instance
+func NewMockSpiffeWorkloadAPI_FetchX509BundlesServer(ctrl *gomock.Controller) *MockSpiffeWorkloadAPI_FetchX509BundlesServer {
+	mock := &MockSpiffeWorkloadAPI_FetchX509BundlesServer{ctrl: ctrl}
+	mock.recorder = &MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) EXPECT() *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) Context() context.Context {
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) Context() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).Context))
+}
+
+// RecvMsg mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) RecvMsg(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).RecvMsg), arg0)
+}
+
+// Send mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) Send(arg0 *workload.X509BundlesResponse) error {
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) SendHeader(arg0 metadata.MD) error {
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) SendMsg(arg0 interface{}) error {
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).SendMsg), arg0)
+}
+
+// SetHeader mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) SetHeader(arg0 metadata.MD) error {
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method
+func (m *MockSpiffeWorkloadAPI_FetchX509BundlesServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer
+func (mr *MockSpiffeWorkloadAPI_FetchX509BundlesServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockSpiffeWorkloadAPI_FetchX509BundlesServer)(nil).SetTrailer), arg0)
+}
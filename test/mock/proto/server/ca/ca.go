@@ -35,6 +35,19 @@ func (m *MockServerCA) EXPECT() *MockServerCAMockRecorder {
 	return m.recorder
 }
 
+// ActivateJwtKey mocks base method
+func (m *MockServerCA) ActivateJwtKey(arg0 context.Context, arg1 *ca.ActivateJwtKeyRequest) (*ca.ActivateJwtKeyResponse, error) {
+	ret := m.ctrl.Call(m, "ActivateJwtKey", arg0, arg1)
+	ret0, _ := ret[0].(*ca.ActivateJwtKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateJwtKey indicates an expected call of ActivateJwtKey
+func (mr *MockServerCAMockRecorder) ActivateJwtKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateJwtKey", reflect.TypeOf((*MockServerCA)(nil).ActivateJwtKey), arg0, arg1)
+}
+
 // FetchCertificate mocks base method
 func (m *MockServerCA) FetchCertificate(arg0 context.Context, arg1 *ca.FetchCertificateRequest) (*ca.FetchCertificateResponse, error) {
 	ret := m.ctrl.Call(m, "FetchCertificate", arg0, arg1)
@@ -48,6 +61,19 @@ func (mr *MockServerCAMockRecorder) FetchCertificate(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCertificate", reflect.TypeOf((*MockServerCA)(nil).FetchCertificate), arg0, arg1)
 }
 
+// FetchJwtKeys mocks base method
+func (m *MockServerCA) FetchJwtKeys(arg0 context.Context, arg1 *ca.FetchJwtKeysRequest) (*ca.FetchJwtKeysResponse, error) {
+	ret := m.ctrl.Call(m, "FetchJwtKeys", arg0, arg1)
+	ret0, _ := ret[0].(*ca.FetchJwtKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJwtKeys indicates an expected call of FetchJwtKeys
+func (mr *MockServerCAMockRecorder) FetchJwtKeys(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJwtKeys", reflect.TypeOf((*MockServerCA)(nil).FetchJwtKeys), arg0, arg1)
+}
+
 // GenerateCsr mocks base method
 func (m *MockServerCA) GenerateCsr(arg0 context.Context, arg1 *ca.GenerateCsrRequest) (*ca.GenerateCsrResponse, error) {
 	ret := m.ctrl.Call(m, "GenerateCsr", arg0, arg1)
@@ -61,6 +87,19 @@ func (mr *MockServerCAMockRecorder) GenerateCsr(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCsr", reflect.TypeOf((*MockServerCA)(nil).GenerateCsr), arg0, arg1)
 }
 
+// GenerateJwtKey mocks base method
+func (m *MockServerCA) GenerateJwtKey(arg0 context.Context, arg1 *ca.GenerateJwtKeyRequest) (*ca.GenerateJwtKeyResponse, error) {
+	ret := m.ctrl.Call(m, "GenerateJwtKey", arg0, arg1)
+	ret0, _ := ret[0].(*ca.GenerateJwtKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateJwtKey indicates an expected call of GenerateJwtKey
+func (mr *MockServerCAMockRecorder) GenerateJwtKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJwtKey", reflect.TypeOf((*MockServerCA)(nil).GenerateJwtKey), arg0, arg1)
+}
+
 // LoadCertificate mocks base method
 func (m *MockServerCA) LoadCertificate(arg0 context.Context, arg1 *ca.LoadCertificateRequest) (*ca.LoadCertificateResponse, error) {
 	ret := m.ctrl.Call(m, "LoadCertificate", arg0, arg1)
@@ -74,6 +113,19 @@ func (mr *MockServerCAMockRecorder) LoadCertificate(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCertificate", reflect.TypeOf((*MockServerCA)(nil).LoadCertificate), arg0, arg1)
 }
 
+// SignCrl mocks base method
+func (m *MockServerCA) SignCrl(arg0 context.Context, arg1 *ca.SignCrlRequest) (*ca.SignCrlResponse, error) {
+	ret := m.ctrl.Call(m, "SignCrl", arg0, arg1)
+	ret0, _ := ret[0].(*ca.SignCrlResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignCrl indicates an expected call of SignCrl
+func (mr *MockServerCAMockRecorder) SignCrl(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCrl", reflect.TypeOf((*MockServerCA)(nil).SignCrl), arg0, arg1)
+}
+
 // SignCsr mocks base method
 func (m *MockServerCA) SignCsr(arg0 context.Context, arg1 *ca.SignCsrRequest) (*ca.SignCsrResponse, error) {
 	ret := m.ctrl.Call(m, "SignCsr", arg0, arg1)
@@ -87,6 +139,32 @@ func (mr *MockServerCAMockRecorder) SignCsr(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCsr", reflect.TypeOf((*MockServerCA)(nil).SignCsr), arg0, arg1)
 }
 
+// SignJwtSvid mocks base method
+func (m *MockServerCA) SignJwtSvid(arg0 context.Context, arg1 *ca.SignJwtSvidRequest) (*ca.SignJwtSvidResponse, error) {
+	ret := m.ctrl.Call(m, "SignJwtSvid", arg0, arg1)
+	ret0, _ := ret[0].(*ca.SignJwtSvidResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignJwtSvid indicates an expected call of SignJwtSvid
+func (mr *MockServerCAMockRecorder) SignJwtSvid(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignJwtSvid", reflect.TypeOf((*MockServerCA)(nil).SignJwtSvid), arg0, arg1)
+}
+
+// SignOcspResponderCert mocks base method
+func (m *MockServerCA) SignOcspResponderCert(arg0 context.Context, arg1 *ca.SignOcspResponderCertRequest) (*ca.SignOcspResponderCertResponse, error) {
+	ret := m.ctrl.Call(m, "SignOcspResponderCert", arg0, arg1)
+	ret0, _ := ret[0].(*ca.SignOcspResponderCertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignOcspResponderCert indicates an expected call of SignOcspResponderCert
+func (mr *MockServerCAMockRecorder) SignOcspResponderCert(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOcspResponderCert", reflect.TypeOf((*MockServerCA)(nil).SignOcspResponderCert), arg0, arg1)
+}
+
 // MockPlugin is a mock of Plugin interface
 type MockPlugin struct {
 	ctrl     *gomock.Controller
@@ -110,6 +188,19 @@ func (m *MockPlugin) EXPECT() *MockPluginMockRecorder {
 	return m.recorder
 }
 
+// ActivateJwtKey mocks base method
+func (m *MockPlugin) ActivateJwtKey(arg0 context.Context, arg1 *ca.ActivateJwtKeyRequest) (*ca.ActivateJwtKeyResponse, error) {
+	ret := m.ctrl.Call(m, "ActivateJwtKey", arg0, arg1)
+	ret0, _ := ret[0].(*ca.ActivateJwtKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateJwtKey indicates an expected call of ActivateJwtKey
+func (mr *MockPluginMockRecorder) ActivateJwtKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateJwtKey", reflect.TypeOf((*MockPlugin)(nil).ActivateJwtKey), arg0, arg1)
+}
+
 // Configure mocks base method
 func (m *MockPlugin) Configure(arg0 context.Context, arg1 *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	ret := m.ctrl.Call(m, "Configure", arg0, arg1)
@@ -136,6 +227,19 @@ func (mr *MockPluginMockRecorder) FetchCertificate(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCertificate", reflect.TypeOf((*MockPlugin)(nil).FetchCertificate), arg0, arg1)
 }
 
+// FetchJwtKeys mocks base method
+func (m *MockPlugin) FetchJwtKeys(arg0 context.Context, arg1 *ca.FetchJwtKeysRequest) (*ca.FetchJwtKeysResponse, error) {
+	ret := m.ctrl.Call(m, "FetchJwtKeys", arg0, arg1)
+	ret0, _ := ret[0].(*ca.FetchJwtKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchJwtKeys indicates an expected call of FetchJwtKeys
+func (mr *MockPluginMockRecorder) FetchJwtKeys(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchJwtKeys", reflect.TypeOf((*MockPlugin)(nil).FetchJwtKeys), arg0, arg1)
+}
+
 // GenerateCsr mocks base method
 func (m *MockPlugin) GenerateCsr(arg0 context.Context, arg1 *ca.GenerateCsrRequest) (*ca.GenerateCsrResponse, error) {
 	ret := m.ctrl.Call(m, "GenerateCsr", arg0, arg1)
@@ -149,6 +253,19 @@ func (mr *MockPluginMockRecorder) GenerateCsr(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCsr", reflect.TypeOf((*MockPlugin)(nil).GenerateCsr), arg0, arg1)
 }
 
+// GenerateJwtKey mocks base method
+func (m *MockPlugin) GenerateJwtKey(arg0 context.Context, arg1 *ca.GenerateJwtKeyRequest) (*ca.GenerateJwtKeyResponse, error) {
+	ret := m.ctrl.Call(m, "GenerateJwtKey", arg0, arg1)
+	ret0, _ := ret[0].(*ca.GenerateJwtKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateJwtKey indicates an expected call of GenerateJwtKey
+func (mr *MockPluginMockRecorder) GenerateJwtKey(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJwtKey", reflect.TypeOf((*MockPlugin)(nil).GenerateJwtKey), arg0, arg1)
+}
+
 // GetPluginInfo mocks base method
 func (m *MockPlugin) GetPluginInfo(arg0 context.Context, arg1 *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
 	ret := m.ctrl.Call(m, "GetPluginInfo", arg0, arg1)
@@ -175,6 +292,19 @@ func (mr *MockPluginMockRecorder) LoadCertificate(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCertificate", reflect.TypeOf((*MockPlugin)(nil).LoadCertificate), arg0, arg1)
 }
 
+// SignCrl mocks base method
+func (m *MockPlugin) SignCrl(arg0 context.Context, arg1 *ca.SignCrlRequest) (*ca.SignCrlResponse, error) {
+	ret := m.ctrl.Call(m, "SignCrl", arg0, arg1)
+	ret0, _ := ret[0].(*ca.SignCrlResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignCrl indicates an expected call of SignCrl
+func (mr *MockPluginMockRecorder) SignCrl(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCrl", reflect.TypeOf((*MockPlugin)(nil).SignCrl), arg0, arg1)
+}
+
 // SignCsr mocks base method
 func (m *MockPlugin) SignCsr(arg0 context.Context, arg1 *ca.SignCsrRequest) (*ca.SignCsrResponse, error) {
 	ret := m.ctrl.Call(m, "SignCsr", arg0, arg1)
@@ -187,3 +317,29 @@ func (m *MockPlugin) SignCsr(arg0 context.Context, arg1 *ca.SignCsrRequest) (*ca
 func (mr *MockPluginMockRecorder) SignCsr(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCsr", reflect.TypeOf((*MockPlugin)(nil).SignCsr), arg0, arg1)
 }
+
+// SignJwtSvid mocks base method
+func (m *MockPlugin) SignJwtSvid(arg0 context.Context, arg1 *ca.SignJwtSvidRequest) (*ca.SignJwtSvidResponse, error) {
+	ret := m.ctrl.Call(m, "SignJwtSvid", arg0, arg1)
+	ret0, _ := ret[0].(*ca.SignJwtSvidResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignJwtSvid indicates an expected call of SignJwtSvid
+func (mr *MockPluginMockRecorder) SignJwtSvid(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignJwtSvid", reflect.TypeOf((*MockPlugin)(nil).SignJwtSvid), arg0, arg1)
+}
+
+// SignOcspResponderCert mocks base method
+func (m *MockPlugin) SignOcspResponderCert(arg0 context.Context, arg1 *ca.SignOcspResponderCertRequest) (*ca.SignOcspResponderCertResponse, error) {
+	ret := m.ctrl.Call(m, "SignOcspResponderCert", arg0, arg1)
+	ret0, _ := ret[0].(*ca.SignOcspResponderCertResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignOcspResponderCert indicates an expected call of SignOcspResponderCert
+func (mr *MockPluginMockRecorder) SignOcspResponderCert(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOcspResponderCert", reflect.TypeOf((*MockPlugin)(nil).SignOcspResponderCert), arg0, arg1)
+}
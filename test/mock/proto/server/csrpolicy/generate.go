@@ -0,0 +1,3 @@
+package mock_csrpolicy
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/server/csrpolicy CSRPolicy,CSRPolicyClient,CSRPolicyServer,Plugin > csrpolicy.go"
@@ -49,6 +49,32 @@ func (mr *MockDataStoreMockRecorder) AppendBundle(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBundle", reflect.TypeOf((*MockDataStore)(nil).AppendBundle), arg0, arg1)
 }
 
+// CountAttestedNodeEntries mocks base method
+func (m *MockDataStore) CountAttestedNodeEntries(arg0 context.Context, arg1 *datastore.CountAttestedNodeEntriesRequest) (*datastore.CountAttestedNodeEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "CountAttestedNodeEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CountAttestedNodeEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAttestedNodeEntries indicates an expected call of CountAttestedNodeEntries
+func (mr *MockDataStoreMockRecorder) CountAttestedNodeEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAttestedNodeEntries", reflect.TypeOf((*MockDataStore)(nil).CountAttestedNodeEntries), arg0, arg1)
+}
+
+// CountRegistrationEntries mocks base method
+func (m *MockDataStore) CountRegistrationEntries(arg0 context.Context, arg1 *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "CountRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CountRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRegistrationEntries indicates an expected call of CountRegistrationEntries
+func (mr *MockDataStoreMockRecorder) CountRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRegistrationEntries", reflect.TypeOf((*MockDataStore)(nil).CountRegistrationEntries), arg0, arg1)
+}
+
 // CreateAttestedNodeEntry mocks base method
 func (m *MockDataStore) CreateAttestedNodeEntry(arg0 context.Context, arg1 *datastore.CreateAttestedNodeEntryRequest) (*datastore.CreateAttestedNodeEntryResponse, error) {
 	ret := m.ctrl.Call(m, "CreateAttestedNodeEntry", arg0, arg1)
@@ -75,6 +101,19 @@ func (mr *MockDataStoreMockRecorder) CreateBundle(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockDataStore)(nil).CreateBundle), arg0, arg1)
 }
 
+// CreateIssuedSVID mocks base method
+func (m *MockDataStore) CreateIssuedSVID(arg0 context.Context, arg1 *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	ret := m.ctrl.Call(m, "CreateIssuedSVID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssuedSVID indicates an expected call of CreateIssuedSVID
+func (mr *MockDataStoreMockRecorder) CreateIssuedSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssuedSVID", reflect.TypeOf((*MockDataStore)(nil).CreateIssuedSVID), arg0, arg1)
+}
+
 // CreateNodeResolverMapEntry mocks base method
 func (m *MockDataStore) CreateNodeResolverMapEntry(arg0 context.Context, arg1 *datastore.CreateNodeResolverMapEntryRequest) (*datastore.CreateNodeResolverMapEntryResponse, error) {
 	ret := m.ctrl.Call(m, "CreateNodeResolverMapEntry", arg0, arg1)
@@ -192,6 +231,19 @@ func (mr *MockDataStoreMockRecorder) FetchBundle(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockDataStore)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchIssuedSVID mocks base method
+func (m *MockDataStore) FetchIssuedSVID(arg0 context.Context, arg1 *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	ret := m.ctrl.Call(m, "FetchIssuedSVID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchIssuedSVID indicates an expected call of FetchIssuedSVID
+func (mr *MockDataStoreMockRecorder) FetchIssuedSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIssuedSVID", reflect.TypeOf((*MockDataStore)(nil).FetchIssuedSVID), arg0, arg1)
+}
+
 // FetchNodeResolverMapEntry mocks base method
 func (m *MockDataStore) FetchNodeResolverMapEntry(arg0 context.Context, arg1 *datastore.FetchNodeResolverMapEntryRequest) (*datastore.FetchNodeResolverMapEntryResponse, error) {
 	ret := m.ctrl.Call(m, "FetchNodeResolverMapEntry", arg0, arg1)
@@ -257,6 +309,19 @@ func (mr *MockDataStoreMockRecorder) FetchToken(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchToken", reflect.TypeOf((*MockDataStore)(nil).FetchToken), arg0, arg1)
 }
 
+// ListAttestedNodeEntries mocks base method
+func (m *MockDataStore) ListAttestedNodeEntries(arg0 context.Context, arg1 *datastore.ListAttestedNodeEntriesRequest) (*datastore.ListAttestedNodeEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "ListAttestedNodeEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListAttestedNodeEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttestedNodeEntries indicates an expected call of ListAttestedNodeEntries
+func (mr *MockDataStoreMockRecorder) ListAttestedNodeEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttestedNodeEntries", reflect.TypeOf((*MockDataStore)(nil).ListAttestedNodeEntries), arg0, arg1)
+}
+
 // ListBundles mocks base method
 func (m *MockDataStore) ListBundles(arg0 context.Context, arg1 *common.Empty) (*datastore.Bundles, error) {
 	ret := m.ctrl.Call(m, "ListBundles", arg0, arg1)
@@ -270,6 +335,19 @@ func (mr *MockDataStoreMockRecorder) ListBundles(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*MockDataStore)(nil).ListBundles), arg0, arg1)
 }
 
+// ListEntryEvents mocks base method
+func (m *MockDataStore) ListEntryEvents(arg0 context.Context, arg1 *datastore.ListEntryEventsRequest) (*datastore.ListEntryEventsResponse, error) {
+	ret := m.ctrl.Call(m, "ListEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListEntryEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntryEvents indicates an expected call of ListEntryEvents
+func (mr *MockDataStoreMockRecorder) ListEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntryEvents", reflect.TypeOf((*MockDataStore)(nil).ListEntryEvents), arg0, arg1)
+}
+
 // ListMatchingEntries mocks base method
 func (m *MockDataStore) ListMatchingEntries(arg0 context.Context, arg1 *datastore.ListSelectorEntriesRequest) (*datastore.ListSelectorEntriesResponse, error) {
 	ret := m.ctrl.Call(m, "ListMatchingEntries", arg0, arg1)
@@ -296,6 +374,19 @@ func (mr *MockDataStoreMockRecorder) ListParentIDEntries(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParentIDEntries", reflect.TypeOf((*MockDataStore)(nil).ListParentIDEntries), arg0, arg1)
 }
 
+// ListRevokedSVIDs mocks base method
+func (m *MockDataStore) ListRevokedSVIDs(arg0 context.Context, arg1 *common.Empty) (*datastore.IssuedSVIDs, error) {
+	ret := m.ctrl.Call(m, "ListRevokedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVIDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevokedSVIDs indicates an expected call of ListRevokedSVIDs
+func (mr *MockDataStoreMockRecorder) ListRevokedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevokedSVIDs", reflect.TypeOf((*MockDataStore)(nil).ListRevokedSVIDs), arg0, arg1)
+}
+
 // ListSelectorEntries mocks base method
 func (m *MockDataStore) ListSelectorEntries(arg0 context.Context, arg1 *datastore.ListSelectorEntriesRequest) (*datastore.ListSelectorEntriesResponse, error) {
 	ret := m.ctrl.Call(m, "ListSelectorEntries", arg0, arg1)
@@ -322,6 +413,32 @@ func (mr *MockDataStoreMockRecorder) ListSpiffeEntries(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpiffeEntries", reflect.TypeOf((*MockDataStore)(nil).ListSpiffeEntries), arg0, arg1)
 }
 
+// PruneEntryEvents mocks base method
+func (m *MockDataStore) PruneEntryEvents(arg0 context.Context, arg1 *datastore.EntryEvent) (*common.Empty, error) {
+	ret := m.ctrl.Call(m, "PruneEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*common.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneEntryEvents indicates an expected call of PruneEntryEvents
+func (mr *MockDataStoreMockRecorder) PruneEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneEntryEvents", reflect.TypeOf((*MockDataStore)(nil).PruneEntryEvents), arg0, arg1)
+}
+
+// PruneIssuedSVIDs mocks base method
+func (m *MockDataStore) PruneIssuedSVIDs(arg0 context.Context, arg1 *datastore.IssuedSVID) (*common.Empty, error) {
+	ret := m.ctrl.Call(m, "PruneIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*common.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneIssuedSVIDs indicates an expected call of PruneIssuedSVIDs
+func (mr *MockDataStoreMockRecorder) PruneIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneIssuedSVIDs", reflect.TypeOf((*MockDataStore)(nil).PruneIssuedSVIDs), arg0, arg1)
+}
+
 // PruneTokens mocks base method
 func (m *MockDataStore) PruneTokens(arg0 context.Context, arg1 *datastore.JoinToken) (*common.Empty, error) {
 	ret := m.ctrl.Call(m, "PruneTokens", arg0, arg1)
@@ -361,6 +478,19 @@ func (mr *MockDataStoreMockRecorder) RegisterToken(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterToken", reflect.TypeOf((*MockDataStore)(nil).RegisterToken), arg0, arg1)
 }
 
+// RevokeIssuedSVIDs mocks base method
+func (m *MockDataStore) RevokeIssuedSVIDs(arg0 context.Context, arg1 *datastore.IssuedSVID) (*datastore.IssuedSVIDs, error) {
+	ret := m.ctrl.Call(m, "RevokeIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVIDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeIssuedSVIDs indicates an expected call of RevokeIssuedSVIDs
+func (mr *MockDataStoreMockRecorder) RevokeIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeIssuedSVIDs", reflect.TypeOf((*MockDataStore)(nil).RevokeIssuedSVIDs), arg0, arg1)
+}
+
 // UpdateAttestedNodeEntry mocks base method
 func (m *MockDataStore) UpdateAttestedNodeEntry(arg0 context.Context, arg1 *datastore.UpdateAttestedNodeEntryRequest) (*datastore.UpdateAttestedNodeEntryResponse, error) {
 	ret := m.ctrl.Call(m, "UpdateAttestedNodeEntry", arg0, arg1)
@@ -449,6 +579,32 @@ func (mr *MockPluginMockRecorder) Configure(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockPlugin)(nil).Configure), arg0, arg1)
 }
 
+// CountAttestedNodeEntries mocks base method
+func (m *MockPlugin) CountAttestedNodeEntries(arg0 context.Context, arg1 *datastore.CountAttestedNodeEntriesRequest) (*datastore.CountAttestedNodeEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "CountAttestedNodeEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CountAttestedNodeEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAttestedNodeEntries indicates an expected call of CountAttestedNodeEntries
+func (mr *MockPluginMockRecorder) CountAttestedNodeEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAttestedNodeEntries", reflect.TypeOf((*MockPlugin)(nil).CountAttestedNodeEntries), arg0, arg1)
+}
+
+// CountRegistrationEntries mocks base method
+func (m *MockPlugin) CountRegistrationEntries(arg0 context.Context, arg1 *datastore.CountRegistrationEntriesRequest) (*datastore.CountRegistrationEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "CountRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CountRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRegistrationEntries indicates an expected call of CountRegistrationEntries
+func (mr *MockPluginMockRecorder) CountRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRegistrationEntries", reflect.TypeOf((*MockPlugin)(nil).CountRegistrationEntries), arg0, arg1)
+}
+
 // CreateAttestedNodeEntry mocks base method
 func (m *MockPlugin) CreateAttestedNodeEntry(arg0 context.Context, arg1 *datastore.CreateAttestedNodeEntryRequest) (*datastore.CreateAttestedNodeEntryResponse, error) {
 	ret := m.ctrl.Call(m, "CreateAttestedNodeEntry", arg0, arg1)
@@ -475,6 +631,19 @@ func (mr *MockPluginMockRecorder) CreateBundle(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockPlugin)(nil).CreateBundle), arg0, arg1)
 }
 
+// CreateIssuedSVID mocks base method
+func (m *MockPlugin) CreateIssuedSVID(arg0 context.Context, arg1 *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	ret := m.ctrl.Call(m, "CreateIssuedSVID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssuedSVID indicates an expected call of CreateIssuedSVID
+func (mr *MockPluginMockRecorder) CreateIssuedSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssuedSVID", reflect.TypeOf((*MockPlugin)(nil).CreateIssuedSVID), arg0, arg1)
+}
+
 // CreateNodeResolverMapEntry mocks base method
 func (m *MockPlugin) CreateNodeResolverMapEntry(arg0 context.Context, arg1 *datastore.CreateNodeResolverMapEntryRequest) (*datastore.CreateNodeResolverMapEntryResponse, error) {
 	ret := m.ctrl.Call(m, "CreateNodeResolverMapEntry", arg0, arg1)
@@ -592,6 +761,19 @@ func (mr *MockPluginMockRecorder) FetchBundle(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockPlugin)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchIssuedSVID mocks base method
+func (m *MockPlugin) FetchIssuedSVID(arg0 context.Context, arg1 *datastore.IssuedSVID) (*datastore.IssuedSVID, error) {
+	ret := m.ctrl.Call(m, "FetchIssuedSVID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchIssuedSVID indicates an expected call of FetchIssuedSVID
+func (mr *MockPluginMockRecorder) FetchIssuedSVID(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIssuedSVID", reflect.TypeOf((*MockPlugin)(nil).FetchIssuedSVID), arg0, arg1)
+}
+
 // FetchNodeResolverMapEntry mocks base method
 func (m *MockPlugin) FetchNodeResolverMapEntry(arg0 context.Context, arg1 *datastore.FetchNodeResolverMapEntryRequest) (*datastore.FetchNodeResolverMapEntryResponse, error) {
 	ret := m.ctrl.Call(m, "FetchNodeResolverMapEntry", arg0, arg1)
@@ -670,6 +852,19 @@ func (mr *MockPluginMockRecorder) GetPluginInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginInfo", reflect.TypeOf((*MockPlugin)(nil).GetPluginInfo), arg0, arg1)
 }
 
+// ListAttestedNodeEntries mocks base method
+func (m *MockPlugin) ListAttestedNodeEntries(arg0 context.Context, arg1 *datastore.ListAttestedNodeEntriesRequest) (*datastore.ListAttestedNodeEntriesResponse, error) {
+	ret := m.ctrl.Call(m, "ListAttestedNodeEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListAttestedNodeEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttestedNodeEntries indicates an expected call of ListAttestedNodeEntries
+func (mr *MockPluginMockRecorder) ListAttestedNodeEntries(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttestedNodeEntries", reflect.TypeOf((*MockPlugin)(nil).ListAttestedNodeEntries), arg0, arg1)
+}
+
 // ListBundles mocks base method
 func (m *MockPlugin) ListBundles(arg0 context.Context, arg1 *common.Empty) (*datastore.Bundles, error) {
 	ret := m.ctrl.Call(m, "ListBundles", arg0, arg1)
@@ -683,6 +878,19 @@ func (mr *MockPluginMockRecorder) ListBundles(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*MockPlugin)(nil).ListBundles), arg0, arg1)
 }
 
+// ListEntryEvents mocks base method
+func (m *MockPlugin) ListEntryEvents(arg0 context.Context, arg1 *datastore.ListEntryEventsRequest) (*datastore.ListEntryEventsResponse, error) {
+	ret := m.ctrl.Call(m, "ListEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListEntryEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntryEvents indicates an expected call of ListEntryEvents
+func (mr *MockPluginMockRecorder) ListEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntryEvents", reflect.TypeOf((*MockPlugin)(nil).ListEntryEvents), arg0, arg1)
+}
+
 // ListMatchingEntries mocks base method
 func (m *MockPlugin) ListMatchingEntries(arg0 context.Context, arg1 *datastore.ListSelectorEntriesRequest) (*datastore.ListSelectorEntriesResponse, error) {
 	ret := m.ctrl.Call(m, "ListMatchingEntries", arg0, arg1)
@@ -709,6 +917,19 @@ func (mr *MockPluginMockRecorder) ListParentIDEntries(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParentIDEntries", reflect.TypeOf((*MockPlugin)(nil).ListParentIDEntries), arg0, arg1)
 }
 
+// ListRevokedSVIDs mocks base method
+func (m *MockPlugin) ListRevokedSVIDs(arg0 context.Context, arg1 *common.Empty) (*datastore.IssuedSVIDs, error) {
+	ret := m.ctrl.Call(m, "ListRevokedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVIDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevokedSVIDs indicates an expected call of ListRevokedSVIDs
+func (mr *MockPluginMockRecorder) ListRevokedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevokedSVIDs", reflect.TypeOf((*MockPlugin)(nil).ListRevokedSVIDs), arg0, arg1)
+}
+
 // ListSelectorEntries mocks base method
 func (m *MockPlugin) ListSelectorEntries(arg0 context.Context, arg1 *datastore.ListSelectorEntriesRequest) (*datastore.ListSelectorEntriesResponse, error) {
 	ret := m.ctrl.Call(m, "ListSelectorEntries", arg0, arg1)
@@ -735,6 +956,32 @@ func (mr *MockPluginMockRecorder) ListSpiffeEntries(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSpiffeEntries", reflect.TypeOf((*MockPlugin)(nil).ListSpiffeEntries), arg0, arg1)
 }
 
+// PruneEntryEvents mocks base method
+func (m *MockPlugin) PruneEntryEvents(arg0 context.Context, arg1 *datastore.EntryEvent) (*common.Empty, error) {
+	ret := m.ctrl.Call(m, "PruneEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*common.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneEntryEvents indicates an expected call of PruneEntryEvents
+func (mr *MockPluginMockRecorder) PruneEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneEntryEvents", reflect.TypeOf((*MockPlugin)(nil).PruneEntryEvents), arg0, arg1)
+}
+
+// PruneIssuedSVIDs mocks base method
+func (m *MockPlugin) PruneIssuedSVIDs(arg0 context.Context, arg1 *datastore.IssuedSVID) (*common.Empty, error) {
+	ret := m.ctrl.Call(m, "PruneIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*common.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneIssuedSVIDs indicates an expected call of PruneIssuedSVIDs
+func (mr *MockPluginMockRecorder) PruneIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneIssuedSVIDs", reflect.TypeOf((*MockPlugin)(nil).PruneIssuedSVIDs), arg0, arg1)
+}
+
 // PruneTokens mocks base method
 func (m *MockPlugin) PruneTokens(arg0 context.Context, arg1 *datastore.JoinToken) (*common.Empty, error) {
 	ret := m.ctrl.Call(m, "PruneTokens", arg0, arg1)
@@ -774,6 +1021,19 @@ func (mr *MockPluginMockRecorder) RegisterToken(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterToken", reflect.TypeOf((*MockPlugin)(nil).RegisterToken), arg0, arg1)
 }
 
+// RevokeIssuedSVIDs mocks base method
+func (m *MockPlugin) RevokeIssuedSVIDs(arg0 context.Context, arg1 *datastore.IssuedSVID) (*datastore.IssuedSVIDs, error) {
+	ret := m.ctrl.Call(m, "RevokeIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.IssuedSVIDs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeIssuedSVIDs indicates an expected call of RevokeIssuedSVIDs
+func (mr *MockPluginMockRecorder) RevokeIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeIssuedSVIDs", reflect.TypeOf((*MockPlugin)(nil).RevokeIssuedSVIDs), arg0, arg1)
+}
+
 // UpdateAttestedNodeEntry mocks base method
 func (m *MockPlugin) UpdateAttestedNodeEntry(arg0 context.Context, arg1 *datastore.UpdateAttestedNodeEntryRequest) (*datastore.UpdateAttestedNodeEntryResponse, error) {
 	ret := m.ctrl.Call(m, "UpdateAttestedNodeEntry", arg0, arg1)
@@ -0,0 +1,3 @@
+package mock_notifier
+
+//go:generate sh -c "mockgen github.com/spiffe/spire/proto/server/notifier Notifier,NotifierClient,NotifierServer,Plugin > notifier.go"